@@ -5,11 +5,17 @@
 // injects the real key, proxies to the provider, and meters token usage per
 // tenant.
 //
-//	model-gateway serve  --secret-file /etc/containarium/jwt.secret   (keys from env)
+//	model-gateway serve  --secret-file /etc/containarium/jwt.secret [--budgets policy.json] [--routes routes.json]
+//	                     [--upstream anthropic=http://127.0.0.1:8867,openai=...] [--cache-ttl 10m]   (keys from env)
 //	model-gateway mint   --secret-file ... --tenant T --provider gemini [--skill S] [--allowed-models a,b] [--ttl 1h]
+//	                     [--rpm N] [--daily-tokens N] [--monthly-tokens N] [--daily-cost-usd X] [--monthly-cost-usd X] [--budget-mode hard|soft]
+//	                     [--no-cache]
+//	model-gateway fake-provider --addr :8867 [--name fake] [--fail-status 503]
 //
 // `mint` stands in for the daemon's provisionSkillBox, which mints the same
-// token alongside the platform JWT in production.
+// token alongside the platform JWT in production. `fake-provider` is a local
+// upstream speaking both chat schemas; with `serve --upstream` it exercises
+// routes and failover without real keys.
 package main
 
 import (
@@ -33,13 +39,15 @@ func main() {
 		serve(os.Args[2:])
 	case "mint":
 		mint(os.Args[2:])
+	case "fake-provider":
+		fakeProvider(os.Args[2:])
 	default:
 		usage()
 	}
 }
 
 func usage() {
-	fmt.Fprintln(os.Stderr, "usage: model-gateway <serve|mint> [flags]")
+	fmt.Fprintln(os.Stderr, "usage: model-gateway <serve|mint|fake-provider> [flags]")
 	os.Exit(2)
 }
 
@@ -59,10 +67,45 @@ func serve(args []string) {
 	fs := flag.NewFlagSet("serve", flag.ExitOnError)
 	addr := fs.String("addr", ":8866", "listen address")
 	secretFile := fs.String("secret-file", "/etc/containarium/jwt.secret", "shared HMAC secret (the daemon's jwt.secret)")
+	budgetsFile := fs.String("budgets", "", "budget policy JSON: per-tenant/skill budgets, rate limits and model prices (empty = token budgets only)")
+	routesFile := fs.String("routes", "", "routing policy JSON: model aliases, fallback chains and weighted splits (empty = no routing)")
+	upstreams := fs.String("upstream", "", "comma-separated provider=url overrides of provider base URLs, e.g. for a local fake-provider")
+	cacheTTL := fs.Duration("cache-ttl", 0, "serve replayed non-streaming calls from an exact-match response cache for this long (0 = off)")
+	cacheEntries := fs.Int("cache-max-entries", 0, "response cache entry bound (0 = default)")
+	cacheMB := fs.Int64("cache-max-mb", 0, "response cache size bound in MiB (0 = default)")
 	_ = fs.Parse(args)
 
 	secret := readSecret(*secretFile)
+	var budgets *modelgateway.BudgetPolicy
+	if *budgetsFile != "" {
+		p, err := modelgateway.LoadBudgetPolicy(*budgetsFile)
+		if err != nil {
+			log.Fatalf("load budgets: %v", err)
+		}
+		budgets = p
+	}
 	providers := modelgateway.DefaultProviders()
+	if *upstreams != "" {
+		for _, kv := range strings.Split(*upstreams, ",") {
+			name, u, ok := strings.Cut(kv, "=")
+			if !ok || providers[name] == nil {
+				log.Fatalf("--upstream %q: want provider=url for a known provider", kv)
+			}
+			providers[name].UpstreamURL = strings.TrimSuffix(u, "/")
+		}
+	}
+	var routes *modelgateway.RoutingPolicy
+	if *routesFile != "" {
+		p, err := modelgateway.LoadRoutingPolicy(*routesFile, providers)
+		if err != nil {
+			log.Fatalf("load routes: %v", err)
+		}
+		routes = p
+	}
+	var cache *modelgateway.CacheConfig
+	if *cacheTTL > 0 {
+		cache = &modelgateway.CacheConfig{TTL: *cacheTTL, MaxEntries: *cacheEntries, MaxBytes: *cacheMB << 20}
+	}
 
 	// The gateway holds the REAL provider keys (read from its OWN env, never a
 	// box). A provider with no key in env is simply not served.
@@ -78,7 +121,7 @@ func serve(args []string) {
 		log.Fatal("no provider keys in env — set one of ANTHROPIC_API_KEY / OPENAI_API_KEY / GEMINI_API_KEY")
 	}
 
-	gw := modelgateway.New(modelgateway.Config{Secret: secret, Providers: providers, ProviderKeys: keys})
+	gw := modelgateway.New(modelgateway.Config{Secret: secret, Providers: providers, ProviderKeys: keys, Budgets: budgets, Routes: routes, Cache: cache})
 	log.Printf("model-gateway: listening on %s, providers=%s (provider keys held in the gateway only)", *addr, strings.Join(loaded, ","))
 	srv := &http.Server{
 		Addr:         *addr,
@@ -90,6 +133,22 @@ func serve(args []string) {
 	log.Fatal(srv.ListenAndServe())
 }
 
+func fakeProvider(args []string) {
+	fs := flag.NewFlagSet("fake-provider", flag.ExitOnError)
+	addr := fs.String("addr", ":8867", "listen address")
+	name := fs.String("name", "fake", "name echoed in replies")
+	failStatus := fs.Int("fail-status", 0, "answer every call with this HTTP status (e.g. 429, 503) to exercise failover")
+	_ = fs.Parse(args)
+
+	log.Printf("fake-provider %s: listening on %s (fail-status=%d)", *name, *addr, *failStatus)
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           &modelgateway.FakeProvider{Name: *name, FailStatus: *failStatus},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatal(srv.ListenAndServe())
+}
+
 func mint(args []string) {
 	fs := flag.NewFlagSet("mint", flag.ExitOnError)
 	secretFile := fs.String("secret-file", "/etc/containarium/jwt.secret", "shared HMAC secret")
@@ -99,7 +158,16 @@ func mint(args []string) {
 	provider := fs.String("provider", "", "provider: anthropic|openai|gemini (required)")
 	models := fs.String("allowed-models", "", "comma-separated allowed model ids (empty = any)")
 	ttl := fs.Duration("ttl", time.Hour, "token lifetime")
+	var budget modelgateway.Budget
+	fs.IntVar(&budget.RequestsPerMinute, "rpm", 0, "requests-per-minute limit (0 = none)")
+	fs.Int64Var(&budget.DailyTokens, "daily-tokens", 0, "daily token budget, UTC day (0 = none)")
+	fs.Int64Var(&budget.MonthlyTokens, "monthly-tokens", 0, "monthly token budget, UTC month (0 = none)")
+	fs.Float64Var(&budget.DailyCostUSD, "daily-cost-usd", 0, "daily cost budget in USD, rated by the gateway's price table (0 = none)")
+	fs.Float64Var(&budget.MonthlyCostUSD, "monthly-cost-usd", 0, "monthly cost budget in USD (0 = none)")
+	mode := fs.String("budget-mode", "hard", "hard (refuse with 429) or soft (alert only) once a budget is reached")
+	noCache := fs.Bool("no-cache", false, "opt this token's calls out of the gateway's response cache")
 	_ = fs.Parse(args)
+	budget.Mode = modelgateway.BudgetMode(*mode)
 	if *tenant == "" || *provider == "" {
 		log.Fatal("mint: --tenant and --provider are required")
 	}
@@ -113,9 +181,19 @@ func mint(args []string) {
 		RunID:         *run,
 		Provider:      *provider,
 		AllowedModels: allowed,
+		Budget:        budgetOrNil(budget),
+		NoCache:       *noCache,
 	}, *ttl)
 	if err != nil {
 		log.Fatalf("mint: %v", err)
 	}
 	fmt.Println(tok)
 }
+
+// budgetOrNil leaves the budget out of the token when no limit was set.
+func budgetOrNil(b modelgateway.Budget) *modelgateway.Budget {
+	if b == (modelgateway.Budget{Mode: b.Mode}) {
+		return nil
+	}
+	return &b
+}
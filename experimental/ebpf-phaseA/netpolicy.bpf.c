@@ -17,10 +17,21 @@
 //       -c netpolicy.bpf.c -o netpolicy.bpf.o
 //
 // Requires kernel ≥ 5.4 (LPM_TRIE + perf_event_output). Ubuntu 24.04 is fine.
+//
+// IPv4 and IPv6 are policed alike. The v6 path has its own maps (egress_cidr6,
+// deny_cidr6, ip_tenant6, flows6 — same semantics, 16-byte addresses), walks
+// up to IPV6_EXT_MAX extension headers to find the L4 ports, and passes
+// link-local and multicast destinations unpoliced: they never leave the bridge
+// and carry neighbour discovery, without which no v6 traffic flows at all. A
+// header chain that does not resolve within IPV6_EXT_MAX is denied outright
+// (fail closed): without its L4 header no port-scoped rule or signature scan
+// could see it.
 
 #include <linux/bpf.h>
 #include <linux/if_ether.h>
 #include <linux/ip.h>
+#include <linux/ipv6.h>
+#include <linux/in6.h>
 #include <linux/in.h>
 #include <linux/tcp.h>
 #include <linux/udp.h>
@@ -39,6 +50,7 @@
 #define DENY_REASON_POLICY        0  // failed allow-list / intra-tenant / metadata
 #define DENY_REASON_VIRTUAL_PATCH 1  // matched an explicit virtual-patch deny rule (#660)
 #define DENY_REASON_SIGNATURE     2  // matched a cleartext exploit signature (#661, Tier 2)
+#define DENY_REASON_UNPARSED      3  // IPv6 header chain did not resolve to an L4 header
 
 // Per-veth policy config, keyed by the veth's host ifindex. The loader writes
 // one entry per managed container veth.
@@ -48,12 +60,35 @@ struct policy_cfg {
     __u8  allow_intra;   // 1 = same-tenant container↔container allowed
     __u8  allow_metadata; // 1 = may reach 169.254.169.254 (default 0 = denied)
     __u8  pad;
+    // Which policy's egress_cidr / egress_ports / deny_cidr entries apply: a
+    // label-selected box policy's id, or 0 for the tenant-wide policy (keyed
+    // by tenant_id). Peer and event attribution always use tenant_id. A
+    // loader predating box policies writes the 8-byte struct and never
+    // reaches this field.
+    __u32 policy_id;
 };
 
+// policy_key is the tenant_id field of the egress/deny LPM keys for a veth:
+// its box policy's id when one selects it, else its tenant's.
+static __always_inline __u32 policy_key(const struct policy_cfg *cfg) {
+    return cfg->policy_id ? cfg->policy_id : cfg->tenant_id;
+}
+
 // Cloud metadata service IP (169.254.169.254). Compared against the packet's
-// network-byte-order daddr via bpf_htonl. #315 Phase D.
+// network-byte-order daddr via bpf_htonl. #315 Phase D. Its IPv6 twin is
+// fd00:ec2::254 (EC2's IPv6 IMDS endpoint), checked by is_metadata6.
 #define METADATA_IPV4 0xA9FEA9FE
 
+// Address family carried in deny_event.family.
+#define FAMILY_IPV4 4
+#define FAMILY_IPV6 6
+
+// Extension headers the v6 parser will step over before giving up on finding
+// the L4 header. A packet with more is denied (DENY_REASON_UNPARSED): stacking
+// headers is otherwise a free way past every port-scoped deny rule and the
+// signature scan.
+#define IPV6_EXT_MAX 4
+
 struct {
     __uint(type, BPF_MAP_TYPE_HASH);
     __type(key, __u32);                 // host veth ifindex
@@ -100,6 +135,81 @@ struct {
     __uint(map_flags, BPF_F_NO_PREALLOC);
 } deny_cidr SEC(".maps");
 
+// IPv6 twins of egress_cidr / deny_cidr: prefixlen counts the tenant_id (32
+// bits) + the IPv6 prefix bits, so up to 160.
+struct egress_key6 {
+    __u32 prefixlen;     // 32 + cidr_bits
+    __u32 tenant_id;
+    __u8  addr[16];      // network byte order, masked to cidr_bits
+};
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __type(key, struct egress_key6);
+    __type(value, __u8);
+    __uint(max_entries, 65536);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+} egress_cidr6 SEC(".maps");
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __type(key, struct egress_key6);
+    __type(value, struct deny_val);
+    __uint(max_entries, 65536);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+} deny_cidr6 SEC(".maps");
+
+// Port/protocol-scoped egress allow rules. Consulted only when the bare
+// egress_cidr(6) allow-list misses: same tenant-scoped LPM key, but the value
+// lists the (proto, port range)s allowed to that prefix. The daemon folds
+// every broader prefix's ranges into each narrower entry, so the single LPM
+// hit answers for the union of the rules covering the destination.
+#define EGRESS_PORT_RULES_MAX 8
+
+struct port_rule {
+    __u16 lo;            // host byte order, inclusive
+    __u16 hi;
+    __u8  proto;         // IPPROTO_TCP | IPPROTO_UDP | 0 (either)
+    __u8  pad[3];
+};
+
+struct egress_ports_val {
+    __u32 n;             // rules in use, ≤ EGRESS_PORT_RULES_MAX
+    struct port_rule r[EGRESS_PORT_RULES_MAX];
+};
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __type(key, struct egress_key);
+    __type(value, struct egress_ports_val);
+    __uint(max_entries, 65536);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+} egress_ports SEC(".maps");
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __type(key, struct egress_key6);
+    __type(value, struct egress_ports_val);
+    __uint(max_entries, 65536);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+} egress_ports6 SEC(".maps");
+
+// ports_allow reports whether (proto, dport) falls in one of v's ranges. Only
+// TCP and UDP carry ports, so any other protocol never matches.
+static __always_inline int ports_allow(const struct egress_ports_val *v, __u8 proto, __u16 dport) {
+    if (!v || (proto != IPPROTO_TCP && proto != IPPROTO_UDP))
+        return 0;
+#pragma clang loop unroll(full)
+    for (int i = 0; i < EGRESS_PORT_RULES_MAX; i++) {
+        if ((__u32)i >= v->n)
+            break;
+        const struct port_rule *r = &v->r[i];
+        if ((r->proto == 0 || r->proto == proto) && dport >= r->lo && dport <= r->hi)
+            return 1;
+    }
+    return 0;
+}
+
 // Destination-IP → tenant_id for intra-backend traffic. The loader populates
 // this from every managed container's IP, so the program can tell "dst is
 // another container of tenant T" from "dst is external".
@@ -110,6 +220,14 @@ struct {
     __uint(max_entries, 4096);
 } ip_tenant SEC(".maps");
 
+// ip_tenant for managed containers' IPv6 addresses.
+struct {
+    __uint(type, BPF_MAP_TYPE_HASH);
+    __type(key, struct in6_addr);       // dst IPv6 (network byte order)
+    __type(value, __u32);               // tenant_id
+    __uint(max_entries, 4096);
+} ip_tenant6 SEC(".maps");
+
 // Stats counters the validator reads (like Phase 0). 0=seen, 1=would_deny.
 struct {
     __uint(type, BPF_MAP_TYPE_ARRAY);
@@ -167,6 +285,24 @@ struct {
     __uint(max_entries, 65536);
 } flows SEC(".maps");
 
+// IPv6 flows, keyed by the widened tuple; same flow_stat value.
+struct flow_key6 {
+    __u32 ifindex;
+    __u8  saddr[16];   // network byte order
+    __u8  daddr[16];
+    __u16 sport;       // host byte order
+    __u16 dport;
+    __u8  proto;
+    __u8  pad[3];
+};
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LRU_HASH);
+    __type(key, struct flow_key6);
+    __type(value, struct flow_stat);
+    __uint(max_entries, 65536);
+} flows6 SEC(".maps");
+
 // Perf event ring for denied flows; the daemon's consumer turns each into an
 // audit row (action=net_deny).
 struct {
@@ -186,7 +322,10 @@ struct deny_event {
     __u8  proto;
     __u8  reason;        // DENY_REASON_* (was pad; size unchanged) #660
     __u16 sig_id;        // matched signature id when reason==SIGNATURE, else 0 (#661)
-    __u16 pad2;
+    __u8  family;        // FAMILY_IPV4 | FAMILY_IPV6 (was pad; 0 reads as v4)
+    __u8  pad2;
+    __u8  saddr6[16];    // IPv6 only (saddr/daddr are 0 then); appended tail
+    __u8  daddr6[16];
 };
 
 // --- Tier 2 (#661): cleartext exploit-signature scanning --------------------
@@ -403,15 +542,294 @@ static __always_inline void account_reply(struct __sk_buff *skb, __u32 ifindex,
     bpf_map_update_elem(&flows, &fk, &nfs, BPF_ANY);
 }
 
+// --- IPv6 ------------------------------------------------------------------
+
+struct ipv6_frag {
+    __u8   nexthdr;
+    __u8   reserved;
+    __be16 frag_off;     // offset (13 bits) | res (2) | M flag (1)
+    __be32 identification;
+};
+
+struct l4_info {
+    __u32 off;           // offset of the L4 header from skb->data
+    __u16 sport;         // host byte order; 0 when unknown
+    __u16 dport;
+    __u8  proto;         // final next-header
+};
+
+// ipv6_ext_hdr reports whether proto is an IPv6 extension header rather than
+// an upper-layer one. AH and Mobility count although ipv6_l4 does not step
+// over them: whatever follows them is as unseen as after too many headers.
+static __always_inline int ipv6_ext_hdr(__u8 proto) {
+    return proto == IPPROTO_HOPOPTS || proto == IPPROTO_ROUTING ||
+           proto == IPPROTO_FRAGMENT || proto == IPPROTO_DSTOPTS ||
+           proto == IPPROTO_AH || proto == IPPROTO_MH;
+}
+
+// ipv6_l4 steps over hop-by-hop, routing, destination-options and fragment
+// headers (at most IPV6_EXT_MAX) to the L4 header and reads TCP/UDP ports.
+// Loads go through bpf_skb_load_bytes, so no packet-pointer bounds to prove.
+// A non-first fragment has no L4 header: its ports stay 0.
+//
+// Returns -1 when the chain does not resolve — still an extension header
+// after IPV6_EXT_MAX, or a header past the end of the packet. l4 is filled
+// in either way, for accounting; the caller denies the packet.
+static __always_inline int ipv6_l4(struct __sk_buff *skb, __u8 nexthdr, __u32 off,
+                                   struct l4_info *l4) {
+    __u8 proto = nexthdr;
+    int tail_frag = 0;
+    int truncated = 0;
+#pragma clang loop unroll(full)
+    for (int i = 0; i < IPV6_EXT_MAX; i++) {
+        if (proto == IPPROTO_HOPOPTS || proto == IPPROTO_ROUTING || proto == IPPROTO_DSTOPTS) {
+            struct ipv6_opt_hdr oh;
+            if (bpf_skb_load_bytes(skb, off, &oh, sizeof(oh)) < 0) {
+                truncated = 1;
+                break;
+            }
+            proto = oh.nexthdr;
+            off += ((__u32)oh.hdrlen + 1) * 8;
+        } else if (proto == IPPROTO_FRAGMENT) {
+            struct ipv6_frag fh;
+            if (bpf_skb_load_bytes(skb, off, &fh, sizeof(fh)) < 0) {
+                truncated = 1;
+                break;
+            }
+            proto = fh.nexthdr;
+            off += sizeof(fh);
+            if (fh.frag_off & bpf_htons(0xFFF8))
+                tail_frag = 1;
+        } else {
+            break;
+        }
+    }
+    l4->off = off;
+    l4->proto = proto;
+    l4->sport = 0;
+    l4->dport = 0;
+    if (truncated || ipv6_ext_hdr(proto))
+        return -1;
+    if (tail_frag)
+        return 0;
+    if (proto == IPPROTO_TCP || proto == IPPROTO_UDP) {
+        __be16 ports[2]; // TCP and UDP both open with source, dest
+        if (bpf_skb_load_bytes(skb, off, ports, sizeof(ports)) == 0) {
+            l4->sport = bpf_ntohs(ports[0]);
+            l4->dport = bpf_ntohs(ports[1]);
+        }
+    }
+    return 0;
+}
+
+// ipv6_local_scope: multicast (ff00::/8 — NDP, MLD, DHCPv6) and link-local
+// unicast (fe80::/10) destinations, which stay on the bridge.
+static __always_inline int ipv6_local_scope(const struct in6_addr *a) {
+    return a->s6_addr[0] == 0xff ||
+           (a->s6_addr[0] == 0xfe && (a->s6_addr[1] & 0xc0) == 0x80);
+}
+
+// is_metadata6 matches fd00:ec2::254.
+static __always_inline int is_metadata6(const struct in6_addr *a) {
+    return a->s6_addr32[0] == bpf_htonl(0xfd000ec2) && a->s6_addr32[1] == 0 &&
+           a->s6_addr32[2] == 0 && a->s6_addr32[3] == bpf_htonl(0x00000254);
+}
+
+// account_flow6 / account_reply6 are account_flow / account_reply over the
+// flows6 map.
+static __always_inline void account_flow6(struct __sk_buff *skb, __u32 ifindex,
+                                          const struct in6_addr *saddr,
+                                          const struct in6_addr *daddr,
+                                          __u16 sport, __u16 dport, __u8 proto, int reply) {
+    struct flow_key6 fk = {};
+    fk.ifindex = ifindex;
+    __builtin_memcpy(fk.saddr, saddr, 16);
+    __builtin_memcpy(fk.daddr, daddr, 16);
+    fk.sport = sport;
+    fk.dport = dport;
+    fk.proto = proto;
+
+    __u64 now = bpf_ktime_get_ns();
+    __u64 len = skb->len;
+
+    struct flow_stat *fs = bpf_map_lookup_elem(&flows6, &fk);
+    if (fs) {
+        if (reply) {
+            __sync_fetch_and_add(&fs->rx_packets, 1);
+            __sync_fetch_and_add(&fs->rx_bytes, len);
+        } else {
+            __sync_fetch_and_add(&fs->packets, 1);
+            __sync_fetch_and_add(&fs->bytes, len);
+        }
+        fs->last_ns = now;
+        return;
+    }
+    struct flow_stat nfs = {};
+    if (reply) {
+        nfs.rx_packets = 1;
+        nfs.rx_bytes = len;
+    } else {
+        nfs.packets = 1;
+        nfs.bytes = len;
+    }
+    nfs.first_ns = now;
+    nfs.last_ns = now;
+    bpf_map_update_elem(&flows6, &fk, &nfs, BPF_ANY);
+}
+
+static __always_inline void emit_deny6(struct __sk_buff *skb, __u32 ifindex, __u32 tenant_id,
+                                       const struct in6_addr *saddr,
+                                       const struct in6_addr *daddr,
+                                       __u16 dport, __u8 proto, __u8 reason, __u16 sig_id) {
+    struct deny_event ev = {};
+    ev.ifindex = ifindex;
+    ev.tenant_id = tenant_id;
+    ev.dport = dport;
+    ev.proto = proto;
+    ev.reason = reason;
+    ev.sig_id = sig_id;
+    ev.family = FAMILY_IPV6;
+    __builtin_memcpy(ev.saddr6, saddr, 16);
+    __builtin_memcpy(ev.daddr6, daddr, 16);
+    bpf_perf_event_output(skb, &events, BPF_F_CURRENT_CPU, &ev, sizeof(ev));
+}
+
+// netpolicy_ingress6 is the IPv6 half of netpolicy_ingress: the same decision
+// order (virtual-patch deny, metadata, same-tenant peer, egress allow-list)
+// over the v6 maps.
+static __always_inline int netpolicy_ingress6(struct __sk_buff *skb, struct ethhdr *eth,
+                                              void *data_end, __u32 ifindex,
+                                              struct policy_cfg *cfg) {
+    struct ipv6hdr *ip6 = (void *)(eth + 1);
+    if ((void *)(ip6 + 1) > data_end)
+        return TC_ACT_OK;
+
+    bump(STAT_SEEN);
+
+    // Copy the addresses to the stack: map keys and memcpy sources alike.
+    struct in6_addr saddr = ip6->saddr;
+    struct in6_addr daddr = ip6->daddr;
+
+    struct l4_info l4 = {};
+    int unparsed = ipv6_l4(skb, ip6->nexthdr, sizeof(*eth) + sizeof(*ip6), &l4) < 0;
+
+    account_flow6(skb, ifindex, &saddr, &daddr, l4.sport, l4.dport, l4.proto, 0);
+
+    if (ipv6_local_scope(&daddr))
+        return TC_ACT_OK; // NDP / link-local: never policed, or nothing works.
+
+    if (unparsed) {
+        // Fail closed: the ports a deny rule or an allowed range keys on are
+        // behind headers the program did not reach.
+        bump(STAT_WOULD_DENY);
+        emit_deny6(skb, ifindex, cfg->tenant_id, &saddr, &daddr, 0, l4.proto, DENY_REASON_UNPARSED, 0);
+        if (cfg->mode == MODE_ENFORCE)
+            return TC_ACT_SHOT;
+        return TC_ACT_OK;
+    }
+
+    __u8 deny_reason = DENY_REASON_POLICY;
+    int allowed = 0;
+
+    struct egress_key6 k = {};
+    k.prefixlen = 32 + 128; // full tenant match + /128 dst (LPM shortens)
+    k.tenant_id = policy_key(cfg);
+    __builtin_memcpy(k.addr, &daddr, 16);
+
+    struct deny_val *dv = bpf_map_lookup_elem(&deny_cidr6, &k);
+    if (dv &&
+        (dv->port == 0 || dv->port == l4.dport) &&
+        (dv->proto == 0 || dv->proto == l4.proto)) {
+        deny_reason = DENY_REASON_VIRTUAL_PATCH;
+    } else if (is_metadata6(&daddr)) {
+        allowed = cfg->allow_metadata ? 1 : 0;
+    } else {
+        __u32 *dst_tenant = bpf_map_lookup_elem(&ip_tenant6, &daddr);
+        if (dst_tenant) {
+            if (*dst_tenant == cfg->tenant_id && cfg->allow_intra)
+                allowed = 1;
+        } else if (bpf_map_lookup_elem(&egress_cidr6, &k)) {
+            allowed = 1;
+        } else if (ports_allow(bpf_map_lookup_elem(&egress_ports6, &k), l4.proto, l4.dport)) {
+            allowed = 1;
+        }
+    }
+
+    if (allowed)
+        return TC_ACT_OK;
+
+    bump(STAT_WOULD_DENY);
+    emit_deny6(skb, ifindex, cfg->tenant_id, &saddr, &daddr, l4.dport, l4.proto, deny_reason, 0);
+    if (cfg->mode == MODE_ENFORCE)
+        return TC_ACT_SHOT;
+    return TC_ACT_OK;
+}
+
+// netpolicy_egress6 is the IPv6 half of netpolicy_egress: reply accounting in
+// request orientation, then the Tier 2 inbound signature scan.
+static __always_inline int netpolicy_egress6(struct __sk_buff *skb, struct ethhdr *eth,
+                                             void *data_end, __u32 ifindex,
+                                             struct policy_cfg *cfg) {
+    struct ipv6hdr *ip6 = (void *)(eth + 1);
+    if ((void *)(ip6 + 1) > data_end)
+        return TC_ACT_OK;
+
+    struct in6_addr saddr = ip6->saddr; // the peer
+    struct in6_addr daddr = ip6->daddr; // the container
+
+    struct l4_info l4 = {};
+    int unparsed = ipv6_l4(skb, ip6->nexthdr, sizeof(*eth) + sizeof(*ip6), &l4) < 0;
+
+    account_flow6(skb, ifindex, &daddr, &saddr, l4.dport, l4.sport, l4.proto, 1);
+
+    if (unparsed) {
+        // Fail closed, as on ingress: the signature scan cannot see a payload
+        // it cannot find.
+        bump(STAT_WOULD_DENY);
+        emit_deny6(skb, ifindex, cfg->tenant_id, &saddr, &daddr, 0, l4.proto, DENY_REASON_UNPARSED, 0);
+        if (cfg->mode == MODE_ENFORCE)
+            return TC_ACT_SHOT;
+        return TC_ACT_OK;
+    }
+
+    if (l4.proto == IPPROTO_TCP && l4.dport != 0 && sig_scan_enabled()) {
+        __u8 doff;
+        if (bpf_skb_load_bytes(skb, l4.off + 12, &doff, 1) < 0)
+            return TC_ACT_OK;
+        __u32 tcp_hlen = (__u32)(doff >> 4) * 4;
+        __u32 payload_off = l4.off + tcp_hlen;
+        __u32 skb_len = skb->len;
+        if (tcp_hlen >= sizeof(struct tcphdr) && payload_off < skb_len) {
+            __u32 sig = scan_inbound(skb, payload_off, skb_len - payload_off);
+            if (sig) {
+                bump(STAT_WOULD_DENY);
+                emit_deny6(skb, ifindex, cfg->tenant_id, &saddr, &daddr, l4.dport, l4.proto,
+                           DENY_REASON_SIGNATURE, (__u16)sig);
+                if (cfg->mode == MODE_ENFORCE)
+                    return TC_ACT_SHOT;
+            }
+        }
+    }
+    return TC_ACT_OK;
+}
+
 SEC("classifier/netpolicy")
 int netpolicy_ingress(struct __sk_buff *skb) {
     void *data = (void *)(long)skb->data;
     void *data_end = (void *)(long)skb->data_end;
 
-    // Only manage IPv4 for Phase A. Anything else passes untouched.
+    // IPv4 below, IPv6 in netpolicy_ingress6. Anything else (ARP, …) passes
+    // untouched.
     struct ethhdr *eth = data;
     if ((void *)(eth + 1) > data_end)
         return TC_ACT_OK;
+    if (eth->h_proto == bpf_htons(ETH_P_IPV6)) {
+        __u32 ifindex6 = skb->ingress_ifindex;
+        struct policy_cfg *cfg6 = bpf_map_lookup_elem(&veth_policy, &ifindex6);
+        if (!cfg6)
+            return TC_ACT_OK;
+        return netpolicy_ingress6(skb, eth, data_end, ifindex6, cfg6);
+    }
     if (eth->h_proto != bpf_htons(ETH_P_IP))
         return TC_ACT_OK;
 
@@ -462,7 +880,7 @@ int netpolicy_ingress(struct __sk_buff *skb) {
     {
         struct egress_key dk = {};
         dk.prefixlen = 32 + 32; // full tenant match + /32 dst (LPM shortens to the rule's prefix)
-        dk.tenant_id = cfg->tenant_id;
+        dk.tenant_id = policy_key(cfg);
         dk.addr = daddr;
         struct deny_val *dv = bpf_map_lookup_elem(&deny_cidr, &dk);
         if (dv &&
@@ -497,10 +915,12 @@ int netpolicy_ingress(struct __sk_buff *skb) {
             // allow-list.
             struct egress_key k = {};
             k.prefixlen = 32 + 32; // full tenant match + full /32 dst (LPM shortens)
-            k.tenant_id = cfg->tenant_id;
+            k.tenant_id = policy_key(cfg);
             k.addr = daddr;
             if (bpf_map_lookup_elem(&egress_cidr, &k))
                 allowed = 1;
+            else if (ports_allow(bpf_map_lookup_elem(&egress_ports, &k), ip->protocol, dport))
+                allowed = 1; // port/protocol-scoped allow rule
         }
     }
 
@@ -518,6 +938,7 @@ int netpolicy_ingress(struct __sk_buff *skb) {
     ev.proto = ip->protocol;
     ev.dport = dport; // parsed once above
     ev.reason = deny_reason; // policy miss vs. explicit virtual-patch deny (#660)
+    ev.family = FAMILY_IPV4;
 
     bpf_perf_event_output(skb, &events, BPF_F_CURRENT_CPU, &ev, sizeof(ev));
 
@@ -543,6 +964,13 @@ int netpolicy_egress(struct __sk_buff *skb) {
     struct ethhdr *eth = data;
     if ((void *)(eth + 1) > data_end)
         return TC_ACT_OK;
+    if (eth->h_proto == bpf_htons(ETH_P_IPV6)) {
+        __u32 ifindex6 = skb->ifindex;
+        struct policy_cfg *cfg6 = bpf_map_lookup_elem(&veth_policy, &ifindex6);
+        if (!cfg6)
+            return TC_ACT_OK;
+        return netpolicy_egress6(skb, eth, data_end, ifindex6, cfg6);
+    }
     if (eth->h_proto != bpf_htons(ETH_P_IP))
         return TC_ACT_OK;
 
@@ -602,6 +1030,7 @@ int netpolicy_egress(struct __sk_buff *skb) {
                     ev.dport = dport; // the container's service port (tcp->dest)
                     ev.reason = DENY_REASON_SIGNATURE;
                     ev.sig_id = (__u16)sig;
+                    ev.family = FAMILY_IPV4;
                     bpf_perf_event_output(skb, &events, BPF_F_CURRENT_CPU, &ev, sizeof(ev));
                     if (cfg->mode == MODE_ENFORCE)
                         return TC_ACT_SHOT; // drop the exploit before it reaches the service
@@ -7,7 +7,9 @@
 #   - can be scoped to a port/proto,
 #   - self-removes at its expiry,
 #   - is cleared by `patch rm`,
-# and that none of it regresses the existing allow-list path.
+# and that none of it regresses the existing allow-list path. It also checks
+# that an IPv6 packet whose extension-header chain outruns IPV6_EXT_MAX is
+# denied (DENY_REASON_UNPARSED) instead of slipping past the port rules.
 #
 # The compiled BPF object is never committed (built on the backend / in CI), so
 # this runbook is what exercises the new deny_cidr map + the deny-first branch in
@@ -31,6 +33,8 @@
 #                   the trailing "-container")
 #   PROBE_IP        reachable test destination to block (default 1.1.1.1)
 #   PROBE_PORT      TCP port for the port-scoped test (default 443)
+#   PROBE_IP6       IPv6 destination for the header-chain test
+#                   (default 2606:4700:4700::1111)
 #   TIMEOUT         per-probe timeout seconds (default 5)
 #   RECONCILE_WAIT  seconds to wait for a reconcile to apply a policy change
 #                   (default 15; the loop ticks every 10s)
@@ -51,6 +55,7 @@ CONTAINER="${CONTAINER:?set CONTAINER to a throwaway tenant container name}"
 TENANT="${TENANT:-${CONTAINER%-container}}"
 PROBE_IP="${PROBE_IP:-1.1.1.1}"
 PROBE_PORT="${PROBE_PORT:-443}"
+PROBE_IP6="${PROBE_IP6:-2606:4700:4700::1111}"
 TIMEOUT="${TIMEOUT:-5}"
 RECONCILE_WAIT="${RECONCILE_WAIT:-15}"
 EXPIRY_SECS="${EXPIRY_SECS:-45}"
@@ -95,6 +100,31 @@ audit_saw_virtual_patch() {
     | grep -qiE 'virtual.?patch'
 }
 
+# send_ext_chain: from the container, send one UDP datagram to PROBE_IP6:PROBE_PORT
+# behind $1 destination-options headers. The kernel writes the fixed header with
+# next-header 60; the payload carries the chain, then UDP (checksum 0: it only
+# has to reach the veth). Returns 99 if the container has no python3 or no raw
+# sockets.
+send_ext_chain() {
+  incus exec "$CONTAINER" -- python3 -c '
+import socket, struct, sys
+n = int(sys.argv[1])
+try:
+    s = socket.socket(socket.AF_INET6, socket.SOCK_RAW, 60)
+except (OSError, AttributeError):
+    sys.exit(99)
+chain = b"".join(struct.pack("!BB6x", 60 if i < n - 1 else 17, 0) for i in range(n))
+s.sendto(chain + struct.pack("!HHHH", 40000, int(sys.argv[3]), 8, 0), (sys.argv[2], 0))
+' "$1" "$PROBE_IP6" "$PROBE_PORT" </dev/null >/dev/null 2>&1 || {
+    rc=$?; [ "$rc" = 99 ] || [ "$rc" = 127 ] && return 99; return "$rc"; }
+}
+
+# journal_count: how many daemon log lines since $1 match $2 (-1 if no journalctl).
+journal_count() {
+  command -v journalctl >/dev/null 2>&1 || { echo -1; return; }
+  journalctl -u "$JOURNAL_UNIT" --since "$1" 2>/dev/null | grep -cE "$2" || true
+}
+
 reconcile() { sleep "$RECONCILE_WAIT"; }
 
 SNAPSHOT="" HAD_POLICY=0
@@ -266,6 +296,43 @@ else
 fi
 info "manual: confirm a neighbour container with no policy is unaffected, and the 'seen' stat still climbs (the ingress accounting path is untouched)."
 
+# ---------------------------------------------------------------------------
+phase "Phase 6 — IPv6 header chain past IPV6_EXT_MAX is denied"
+# Open v6 egress too, so only the unresolved chain — not an allow-list miss — can
+# deny the probe. The parser steps over IPV6_EXT_MAX (4) extension headers: four
+# destination-options headers then UDP resolves; five does not and must be
+# denied (daemon log kind "unparsed-ipv6", audit network_policy.unparsed_ipv6),
+# dropped under enforce. This is also what first loads the fail-closed branch of
+# ipv6_l4 through the verifier.
+"$CTR" "${CTR_ARGS[@]}" network-policy set "$TENANT" --mode enforce \
+  --egress-cidr 0.0.0.0/0 --egress-cidr ::/0 >/dev/null
+reconcile
+SINCE="$(date '+%Y-%m-%d %H:%M:%S' 2>/dev/null || echo '2 min ago')"
+rc4=0; send_ext_chain 4 || rc4=$?
+sleep 2
+resolved="$(journal_count "$SINCE" 'netpolicy\] unparsed-ipv6:')"
+SINCE="$(date '+%Y-%m-%d %H:%M:%S' 2>/dev/null || echo '2 min ago')"
+rc5=0; send_ext_chain 5 || rc5=$?
+sleep 2
+unresolved="$(journal_count "$SINCE" 'netpolicy\] unparsed-ipv6:')"
+want="log_only"
+[ "$ARMED" = yes ] && want="DROPPED"
+if [ "$rc4" = 99 ] || [ "$rc5" = 99 ]; then
+  skip "header-chain test: container has no python3 or cannot open a raw socket"
+elif [ "$unresolved" = -1 ]; then
+  skip "header-chain test: journalctl unavailable — verify the daemon logged \"[netpolicy] unparsed-ipv6:\" for the 5-header probe"
+else
+  [ "$resolved" = 0 ] && pass "4 extension headers resolve: no unparsed-ipv6 deny" \
+                      || fail "4 extension headers were denied as unparsed — the parser stops short of IPV6_EXT_MAX"
+  if [ "$unresolved" -gt 0 ] \
+     && journalctl -u "$JOURNAL_UNIT" --since "$SINCE" 2>/dev/null | grep 'unparsed-ipv6:' | grep -q "$want"; then
+    pass "5 extension headers: denied as unparsed-ipv6 ($want)"
+  else
+    fail "5 extension headers were NOT denied as unparsed-ipv6 ($want expected) — ipv6_l4 is failing open"
+  fi
+fi
+"$CTR" "${CTR_ARGS[@]}" network-policy set "$TENANT" --mode enforce --egress-cidr 0.0.0.0/0 >/dev/null
+
 # ---------------------------------------------------------------------------
 phase "Result"
 printf 'PASS=%d  FAIL=%d  SKIP=%d   (enforcement armed: %s)\n' "$PASS" "$FAIL" "$SKIP" "$ARMED"
@@ -98,6 +98,10 @@ func resourceTypeString(rt pb.ResourceType) string {
 		return "metrics"
 	case pb.ResourceType_RESOURCE_TYPE_TRAFFIC:
 		return "traffic"
+	case pb.ResourceType_RESOURCE_TYPE_BACKUP:
+		return "backup"
+	case pb.ResourceType_RESOURCE_TYPE_WAF:
+		return "waf"
 	default:
 		return "unknown"
 	}
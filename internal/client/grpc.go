@@ -463,6 +463,33 @@ func (c *GRPCClient) RefreshSecrets(username string) (string, int32, error) {
 	return resp.Message, resp.Stamped, nil
 }
 
+// ListSecretVersions returns a secret's retained versions, newest first.
+func (c *GRPCClient) ListSecretVersions(username, name string) ([]*pb.SecretVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := c.client.ListSecretVersions(ctx, &pb.ListSecretVersionsRequest{
+		Username: username, Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list secret versions: %w", err)
+	}
+	return resp.Versions, nil
+}
+
+// RollbackSecret makes a retained version of a secret current again via
+// gRPC. The running container sees it after RefreshSecrets.
+func (c *GRPCClient) RollbackSecret(username, name string, version int32) (*pb.SecretMetadata, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := c.client.RollbackSecret(ctx, &pb.RollbackSecretRequest{
+		Username: username, Name: name, Version: version,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("rollback secret: %w", err)
+	}
+	return resp.Secret, resp.Message, nil
+}
+
 // ResizeContainer changes a container's CPU / memory / disk via gRPC.
 // Empty string for any field means "no change". Disk can only grow —
 // the server rejects shrinks.
@@ -702,6 +729,19 @@ func (c *GRPCClient) RunAgentSkill(skillID, backendID, pool, inputJSON string) (
 	return resp, nil
 }
 
+// GetAgentModelUsage returns model-gateway usage rollups for the filters and
+// grouping in req.
+func (c *GRPCClient) GetAgentModelUsage(req *pb.GetAgentModelUsageRequest) ([]*pb.ModelUsageRollup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.agentClient.GetAgentModelUsage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent model usage: %w", err)
+	}
+	return resp.Rollups, nil
+}
+
 // EnqueueAgentTask places a task on the pull queue for a skill (prototype).
 func (c *GRPCClient) EnqueueAgentTask(skillID, inputJSON string) (*pb.EnqueueAgentTaskResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -871,6 +911,54 @@ func (c *GRPCClient) DeleteBackup(id string) (*pb.DeleteBackupResponse, error) {
 	return resp, nil
 }
 
+// SetBackupSchedule creates or replaces a tenant's backup schedule via gRPC.
+func (c *GRPCClient) SetBackupSchedule(sched *pb.BackupSchedule) (*pb.BackupSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.backupClient.SetBackupSchedule(ctx, &pb.SetBackupScheduleRequest{Schedule: sched})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set backup schedule: %w", err)
+	}
+	return resp.Schedule, nil
+}
+
+// GetBackupSchedule fetches a tenant's backup schedule via gRPC.
+func (c *GRPCClient) GetBackupSchedule(username string) (*pb.BackupSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.backupClient.GetBackupSchedule(ctx, &pb.GetBackupScheduleRequest{Username: username})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup schedule: %w", err)
+	}
+	return resp.Schedule, nil
+}
+
+// ListBackupSchedules lists backup schedules via gRPC.
+func (c *GRPCClient) ListBackupSchedules() ([]*pb.BackupSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.backupClient.ListBackupSchedules(ctx, &pb.ListBackupSchedulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup schedules: %w", err)
+	}
+	return resp.Schedules, nil
+}
+
+// DeleteBackupSchedule removes a tenant's backup schedule via gRPC.
+func (c *GRPCClient) DeleteBackupSchedule(username string) (*pb.DeleteBackupScheduleResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.backupClient.DeleteBackupSchedule(ctx, &pb.DeleteBackupScheduleRequest{Username: username})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete backup schedule: %w", err)
+	}
+	return resp, nil
+}
+
 // CreateVolume creates a shared CephFS volume via gRPC.
 func (c *GRPCClient) CreateVolume(req *pb.CreateVolumeRequest) (*pb.CreateVolumeResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -1241,6 +1329,117 @@ func (c *GRPCClient) RollbackContainerSnapshot(req *pb.RollbackContainerSnapshot
 	return resp, nil
 }
 
+// CloneContainer forks a new container from a snapshot via gRPC.
+func (c *GRPCClient) CloneContainer(req *pb.CloneContainerRequest) (*pb.CloneContainerResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	resp, err := c.client.CloneContainer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone container: %w", err)
+	}
+	return resp, nil
+}
+
+// SetContainerSnapshotPolicy sets a container's snapshot schedule via gRPC.
+func (c *GRPCClient) SetContainerSnapshotPolicy(req *pb.SetContainerSnapshotPolicyRequest) (*pb.SetContainerSnapshotPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.client.SetContainerSnapshotPolicy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set snapshot policy: %w", err)
+	}
+	return resp, nil
+}
+
+// GetContainerSnapshotPolicy reads a container's snapshot schedule via gRPC.
+func (c *GRPCClient) GetContainerSnapshotPolicy(req *pb.GetContainerSnapshotPolicyRequest) (*pb.GetContainerSnapshotPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetContainerSnapshotPolicy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot policy: %w", err)
+	}
+	return resp, nil
+}
+
+// DeleteContainerSnapshotPolicy removes a container's snapshot schedule via gRPC.
+func (c *GRPCClient) DeleteContainerSnapshotPolicy(req *pb.DeleteContainerSnapshotPolicyRequest) (*pb.DeleteContainerSnapshotPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.client.DeleteContainerSnapshotPolicy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete snapshot policy: %w", err)
+	}
+	return resp, nil
+}
+
+// ExportContainerSnapshot ships a snapshot off the host via gRPC. The
+// daemon streams the whole snapshot before it answers, hence the timeout.
+func (c *GRPCClient) ExportContainerSnapshot(req *pb.ExportContainerSnapshotRequest) (*pb.ExportContainerSnapshotResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	resp, err := c.client.ExportContainerSnapshot(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export snapshot: %w", err)
+	}
+	return resp, nil
+}
+
+// ImportContainerSnapshot restores a container from exported snapshots via
+// gRPC.
+func (c *GRPCClient) ImportContainerSnapshot(req *pb.ImportContainerSnapshotRequest) (*pb.ImportContainerSnapshotResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	resp, err := c.client.ImportContainerSnapshot(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import snapshot: %w", err)
+	}
+	return resp, nil
+}
+
+// ListContainerSnapshotFiles lists a directory in a snapshot via gRPC.
+func (c *GRPCClient) ListContainerSnapshotFiles(req *pb.ListContainerSnapshotFilesRequest) (*pb.ListContainerSnapshotFilesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ListContainerSnapshotFiles(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshot files: %w", err)
+	}
+	return resp, nil
+}
+
+// ReadContainerSnapshotFile reads a range of a file in a snapshot via gRPC.
+func (c *GRPCClient) ReadContainerSnapshotFile(req *pb.ReadContainerSnapshotFileRequest) (*pb.ReadContainerSnapshotFileResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ReadContainerSnapshotFile(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	return resp, nil
+}
+
+// DiffContainerSnapshots diffs a snapshot against a later one or the live
+// container via gRPC.
+func (c *GRPCClient) DiffContainerSnapshots(req *pb.DiffContainerSnapshotsRequest) (*pb.DiffContainerSnapshotsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	resp, err := c.client.DiffContainerSnapshots(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff snapshot: %w", err)
+	}
+	return resp, nil
+}
+
 // --- managed Kubernetes clusters (#1413) -------------------------------
 
 // CreateCluster records a new managed cluster (provisioning is
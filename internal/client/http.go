@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -989,6 +990,54 @@ func (c *HTTPClient) RefreshSecrets(username string) (string, int32, error) {
 	return result.Message, result.Stamped, nil
 }
 
+// ListSecretVersions returns a secret's retained versions, newest first.
+// Decoded with protojson for the same reason as ListSecrets.
+func (c *HTTPClient) ListSecretVersions(username, name string) ([]*pb.SecretVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	path := fmt.Sprintf("/v1/secrets/%s/%s/versions", url.PathEscape(username), url.PathEscape(name))
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list secret versions: %w", err)
+	}
+	defer drainClose(resp)
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, parseErr(b, resp.StatusCode, "list secret versions")
+	}
+	var result pb.ListSecretVersionsResponse
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("decode secret versions response: %w", err)
+	}
+	return result.GetVersions(), nil
+}
+
+// RollbackSecret makes a retained version of a secret current again via
+// HTTP. The running container sees it after RefreshSecrets.
+func (c *HTTPClient) RollbackSecret(username, name string, version int32) (*pb.SecretMetadata, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	body, err := protojson.Marshal(&pb.RollbackSecretRequest{Version: version})
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/secrets/%s/%s/rollback", url.PathEscape(username), url.PathEscape(name))
+	resp, err := c.doRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, "", fmt.Errorf("rollback secret: %w", err)
+	}
+	defer drainClose(resp)
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, "", parseErr(b, resp.StatusCode, "rollback secret")
+	}
+	var result pb.RollbackSecretResponse
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, &result); err != nil {
+		return nil, "", fmt.Errorf("decode rollback response: %w", err)
+	}
+	return result.GetSecret(), result.GetMessage(), nil
+}
+
 // parseErr is a tiny helper used across the secrets HTTP methods to
 // surface the server's structured error body (`{"error":"..."}`)
 // when present, falling back to the status code otherwise.
@@ -1422,6 +1471,49 @@ func (c *HTTPClient) RunAgentSkill(skillID, backendID, pool, inputJSON string) (
 	return out, nil
 }
 
+// GetAgentModelUsage returns model-gateway usage rollups (GET
+// /v1/agent-usage), with req's filters as query params. Mirrors
+// GRPCClient.GetAgentModelUsage.
+func (c *HTTPClient) GetAgentModelUsage(req *pb.GetAgentModelUsageRequest) ([]*pb.ModelUsageRollup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	q := url.Values{}
+	for k, v := range map[string]string{"tenant": req.Tenant, "skill_id": req.SkillId, "run_id": req.RunId} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	if req.StartTime != nil {
+		q.Set("start_time", req.StartTime.AsTime().Format(time.RFC3339Nano))
+	}
+	if req.EndTime != nil {
+		q.Set("end_time", req.EndTime.AsTime().Format(time.RFC3339Nano))
+	}
+	if req.GroupBy != pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_UNSPECIFIED {
+		q.Set("group_by", req.GroupBy.String())
+	}
+	path := "/v1/agent-usage"
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get agent model usage: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "get agent model usage")
+	}
+	out := &pb.GetAgentModelUsageResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out.Rollups, nil
+}
+
 // EnqueueAgentTask places a task on the pull queue for a skill (prototype).
 func (c *HTTPClient) EnqueueAgentTask(skillID, inputJSON string) (*pb.EnqueueAgentTaskResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -1756,6 +1848,100 @@ func (c *HTTPClient) DeleteBackup(id string) (*pb.DeleteBackupResponse, error) {
 	return out, nil
 }
 
+// SetBackupSchedule creates or replaces a tenant's backup schedule via HTTP.
+func (c *HTTPClient) SetBackupSchedule(sched *pb.BackupSchedule) (*pb.BackupSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	body, err := protojson.Marshal(&pb.SetBackupScheduleRequest{Schedule: sched})
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/backup-schedules", json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("set backup schedule: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "set backup schedule")
+	}
+	out := &pb.SetBackupScheduleResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out.Schedule, nil
+}
+
+// GetBackupSchedule fetches a tenant's backup schedule via HTTP.
+func (c *HTTPClient) GetBackupSchedule(username string) (*pb.BackupSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1/backup-schedules/%s", url.PathEscape(username))
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get backup schedule: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "get backup schedule")
+	}
+	out := &pb.GetBackupScheduleResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out.Schedule, nil
+}
+
+// ListBackupSchedules lists backup schedules via HTTP.
+func (c *HTTPClient) ListBackupSchedules() ([]*pb.BackupSchedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/backup-schedules", nil)
+	if err != nil {
+		return nil, fmt.Errorf("list backup schedules: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "list backup schedules")
+	}
+	out := &pb.ListBackupSchedulesResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out.Schedules, nil
+}
+
+// DeleteBackupSchedule removes a tenant's backup schedule via HTTP.
+func (c *HTTPClient) DeleteBackupSchedule(username string) (*pb.DeleteBackupScheduleResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1/backup-schedules/%s", url.PathEscape(username))
+	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete backup schedule: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "delete backup schedule")
+	}
+	out := &pb.DeleteBackupScheduleResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
 // GetKMSStatus reports the active KMS backend + envelope state via HTTP.
 func (c *HTTPClient) GetKMSStatus() (*pb.GetKMSStatusResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -2139,6 +2325,260 @@ func (c *HTTPClient) RollbackContainerSnapshot(req *pb.RollbackContainerSnapshot
 	return out, nil
 }
 
+// CloneContainer forks a new container from a snapshot via HTTP.
+func (c *HTTPClient) CloneContainer(req *pb.CloneContainerRequest) (*pb.CloneContainerResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/%s/clone",
+		url.PathEscape(req.GetUsername()), url.PathEscape(req.GetSnapshot()))
+	resp, err := c.doRequest(ctx, http.MethodPost, path, json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("clone container: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "clone container")
+	}
+	out := &pb.CloneContainerResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// SetContainerSnapshotPolicy sets a container's snapshot schedule via HTTP.
+func (c *HTTPClient) SetContainerSnapshotPolicy(req *pb.SetContainerSnapshotPolicyRequest) (*pb.SetContainerSnapshotPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshot-policy", url.PathEscape(req.GetUsername()))
+	resp, err := c.doRequest(ctx, http.MethodPut, path, json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("set snapshot policy: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "set snapshot policy")
+	}
+	out := &pb.SetContainerSnapshotPolicyResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// GetContainerSnapshotPolicy reads a container's snapshot schedule via HTTP.
+func (c *HTTPClient) GetContainerSnapshotPolicy(req *pb.GetContainerSnapshotPolicyRequest) (*pb.GetContainerSnapshotPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1/containers/%s/snapshot-policy", url.PathEscape(req.GetUsername()))
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot policy: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "get snapshot policy")
+	}
+	out := &pb.GetContainerSnapshotPolicyResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteContainerSnapshotPolicy removes a container's snapshot schedule via HTTP.
+func (c *HTTPClient) DeleteContainerSnapshotPolicy(req *pb.DeleteContainerSnapshotPolicyRequest) (*pb.DeleteContainerSnapshotPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1/containers/%s/snapshot-policy", url.PathEscape(req.GetUsername()))
+	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("delete snapshot policy: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "delete snapshot policy")
+	}
+	out := &pb.DeleteContainerSnapshotPolicyResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// ExportContainerSnapshot ships a snapshot off the host via HTTP.
+func (c *HTTPClient) ExportContainerSnapshot(req *pb.ExportContainerSnapshotRequest) (*pb.ExportContainerSnapshotResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/%s/export",
+		url.PathEscape(req.GetUsername()), url.PathEscape(req.GetSnapshot()))
+	resp, err := c.doRequest(ctx, http.MethodPost, path, json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("export snapshot: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "export snapshot")
+	}
+	out := &pb.ExportContainerSnapshotResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// ImportContainerSnapshot restores a container from exported snapshots via
+// HTTP.
+func (c *HTTPClient) ImportContainerSnapshot(req *pb.ImportContainerSnapshotRequest) (*pb.ImportContainerSnapshotResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/import", url.PathEscape(req.GetUsername()))
+	resp, err := c.doRequest(ctx, http.MethodPost, path, json.RawMessage(body))
+	if err != nil {
+		return nil, fmt.Errorf("import snapshot: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "import snapshot")
+	}
+	out := &pb.ImportContainerSnapshotResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// ListContainerSnapshotFiles lists a directory in a snapshot via HTTP.
+func (c *HTTPClient) ListContainerSnapshotFiles(req *pb.ListContainerSnapshotFilesRequest) (*pb.ListContainerSnapshotFilesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	q := url.Values{}
+	if req.GetPath() != "" {
+		q.Set("path", req.GetPath())
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/%s/files",
+		url.PathEscape(req.GetUsername()), url.PathEscape(req.GetSnapshot()))
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshot files: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "list snapshot files")
+	}
+	out := &pb.ListContainerSnapshotFilesResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// ReadContainerSnapshotFile reads a range of a file in a snapshot via HTTP.
+func (c *HTTPClient) ReadContainerSnapshotFile(req *pb.ReadContainerSnapshotFileRequest) (*pb.ReadContainerSnapshotFileResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	q := url.Values{}
+	q.Set("path", req.GetPath())
+	if req.GetOffset() > 0 {
+		q.Set("offset", strconv.FormatInt(req.GetOffset(), 10))
+	}
+	if req.GetLength() > 0 {
+		q.Set("length", strconv.FormatInt(req.GetLength(), 10))
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/%s/file",
+		url.PathEscape(req.GetUsername()), url.PathEscape(req.GetSnapshot()))
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot file: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "read snapshot file")
+	}
+	out := &pb.ReadContainerSnapshotFileResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
+// DiffContainerSnapshots diffs a snapshot against a later one or the live container via HTTP.
+func (c *HTTPClient) DiffContainerSnapshots(req *pb.DiffContainerSnapshotsRequest) (*pb.DiffContainerSnapshotsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	q := url.Values{}
+	if req.GetToSnapshot() != "" {
+		q.Set("to_snapshot", req.GetToSnapshot())
+	}
+	path := fmt.Sprintf("/v1/containers/%s/snapshots/%s/diff",
+		url.PathEscape(req.GetUsername()), url.PathEscape(req.GetFromSnapshot()))
+	if enc := q.Encode(); enc != "" {
+		path += "?" + enc
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("diff snapshot: %w", err)
+	}
+	defer drainClose(resp)
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, httpError(bodyBytes, resp.StatusCode, "diff snapshot")
+	}
+	out := &pb.DiffContainerSnapshotsResponse{}
+	if err := protojson.Unmarshal(bodyBytes, out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
+
 // --- managed Kubernetes clusters (#1413) -------------------------------
 
 func clusterQuery(owner string) string {
@@ -56,6 +56,9 @@ func runAgentRun(cmd *cobra.Command, args []string) error {
 	if resp.Container != nil {
 		fmt.Printf("\n✓ box ready: %s (%s)\n", resp.Container.Name, resp.Container.State)
 	}
+	if resp.RunId != "" {
+		fmt.Printf("  run: %s (containarium agent usage --run %s)\n", resp.RunId, resp.RunId)
+	}
 	if resp.ArtifactJson != "" {
 		fmt.Printf("\nArtifact:\n%s\n", resp.ArtifactJson)
 	} else {
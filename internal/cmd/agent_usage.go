@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+var (
+	agentUsageTenant  string
+	agentUsageSkill   string
+	agentUsageRun     string
+	agentUsageSince   time.Duration
+	agentUsageGroupBy string
+)
+
+var agentUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show model-gateway usage by tenant, skill or run",
+	Long: `Roll up the model calls skill boxes made through the daemon's model
+gateway: calls, failures, tokens and latency per provider and model, grouped
+by tenant (default), skill or run. Read from the daemon's durable usage
+ledger, so it survives daemon restarts.
+
+Examples:
+  containarium agent usage --server <host>
+  containarium agent usage --since 24h --group-by skill --server <host>
+  containarium agent usage --run agentrun-1a2b3c --server <host>`,
+	Args: cobra.NoArgs,
+	RunE: runAgentUsage,
+}
+
+func init() {
+	agentCmd.AddCommand(agentUsageCmd)
+	agentUsageCmd.Flags().StringVar(&agentUsageTenant, "tenant", "", "Only calls from this tenant")
+	agentUsageCmd.Flags().StringVar(&agentUsageSkill, "skill", "", "Only calls from this skill")
+	agentUsageCmd.Flags().StringVar(&agentUsageRun, "run", "", "Only calls from this run (agent or crew run id)")
+	agentUsageCmd.Flags().DurationVar(&agentUsageSince, "since", 0, "Only calls in this trailing window, e.g. 24h (default: all)")
+	agentUsageCmd.Flags().StringVar(&agentUsageGroupBy, "group-by", "tenant", "Group rows by tenant, skill or run")
+}
+
+func runAgentUsage(cmd *cobra.Command, args []string) error {
+	groupBy, ok := map[string]pb.ModelUsageGroupBy{
+		"tenant": pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_TENANT,
+		"skill":  pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_SKILL,
+		"run":    pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_RUN,
+	}[agentUsageGroupBy]
+	if !ok {
+		return fmt.Errorf("--group-by must be tenant, skill or run, got %q", agentUsageGroupBy)
+	}
+	req := &pb.GetAgentModelUsageRequest{
+		Tenant:  agentUsageTenant,
+		SkillId: agentUsageSkill,
+		RunId:   agentUsageRun,
+		GroupBy: groupBy,
+	}
+	if agentUsageSince > 0 {
+		req.StartTime = timestamppb.New(time.Now().Add(-agentUsageSince))
+	}
+
+	c, err := newAgentClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	rows, err := c.GetAgentModelUsage(req)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No model usage recorded.")
+		return nil
+	}
+	fmt.Printf("%-28s %-10s %-28s %7s %6s %10s %10s %10s %8s\n",
+		strings.ToUpper(agentUsageGroupBy), "PROVIDER", "MODEL", "CALLS", "FAILED", "IN", "OUT", "CACHED", "AVG MS")
+	fmt.Println(strings.Repeat("-", 128))
+	for _, r := range rows {
+		key := r.Tenant
+		switch groupBy {
+		case pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_SKILL:
+			key = r.Tenant + "/" + r.SkillId
+		case pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_RUN:
+			key = r.RunId
+		}
+		if key == "" || key == "/" {
+			key = "-"
+		}
+		avg := int64(0)
+		if r.Calls > 0 {
+			avg = r.TotalLatencyMs / r.Calls
+		}
+		fmt.Printf("%-28s %-10s %-28s %7d %6d %10d %10d %10d %8d\n",
+			key, r.Provider, r.Model, r.Calls, r.FailedCalls, r.InputTokens, r.OutputTokens, r.CachedTokens, avg)
+	}
+	return nil
+}
@@ -11,18 +11,20 @@ import (
 var backupCmd = &cobra.Command{
 	Use:   "backup",
 	Short: "Back up and restore the databases inside containers (off-host)",
-	Long: `Create, list, restore, and delete logical (pg_dump) backups of the
-databases running inside Containarium containers. Backups are stored off
-the database host — in a host backup directory (local) or a GCS bucket
-(gcs) — so a dump never shares a failure domain with the data it
-protects. See docs/DB-BACKUP-OPERATIONS.md for the operator runbook and
-the ISO 27001 A.8.13 control mapping.
+	Long: `Create, list, restore, and delete backups of the databases running
+inside Containarium containers: PostgreSQL (pg_dump), MySQL/MariaDB
+(mysqldump) and Redis (RDB snapshot). Backups are stored off the database
+host — in a host backup directory (local) or a GCS bucket (gcs) — so a
+dump never shares a failure domain with the data it protects. See
+docs/DB-BACKUP-OPERATIONS.md for the operator runbook and the ISO 27001
+A.8.13 control mapping.
 
   containarium backup create alice --database app --dest gcs --gcs-bucket gs://my-backups/pg --server <host>
   containarium backup list alice --server <host>
   containarium backup restore alice-app-20260605T130405Z --clean --server <host>
   containarium backup verify alice-app-20260605T130405Z --target scratch --server <host>
-  containarium backup delete alice-app-20260605T130405Z --server <host>`,
+  containarium backup delete alice-app-20260605T130405Z --server <host>
+  containarium backup schedule set alice --cron "0 3 * * *" --keep-daily 7 --keep-weekly 4 --server <host>`,
 }
 
 func init() {
@@ -63,13 +65,29 @@ func parseDestination(s string) (pb.BackupDestination, error) {
 		return pb.BackupDestination_BACKUP_DESTINATION_LOCAL, nil
 	case "gcs":
 		return pb.BackupDestination_BACKUP_DESTINATION_GCS, nil
+	case "s3":
+		return pb.BackupDestination_BACKUP_DESTINATION_S3, nil
 	default:
 		return pb.BackupDestination_BACKUP_DESTINATION_UNSPECIFIED,
-			fmt.Errorf("invalid --dest %q (expected 'local' or 'gcs')", s)
+			fmt.Errorf("invalid --dest %q (expected 'local', 'gcs' or 's3')", s)
+	}
+}
+
+// parseEngine maps the --engine flag to the proto enum.
+func parseEngine(s string) (pb.BackupEngine, error) {
+	switch s {
+	case "postgres":
+		return pb.BackupEngine_BACKUP_ENGINE_POSTGRES, nil
+	case "mysql":
+		return pb.BackupEngine_BACKUP_ENGINE_MYSQL, nil
+	case "redis":
+		return pb.BackupEngine_BACKUP_ENGINE_REDIS, nil
+	default:
+		return pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED,
+			fmt.Errorf("invalid --engine %q (expected 'postgres', 'mysql' or 'redis')", s)
 	}
 }
 
-// destLabel renders a destination enum for human output.
 // engineLabel renders the engine the way it read before it became an enum
 // (#1157). The wire value is now BACKUP_ENGINE_POSTGRES; a human running
 // `backup get` should still see "postgres".
@@ -77,6 +95,10 @@ func engineLabel(e pb.BackupEngine) string {
 	switch e {
 	case pb.BackupEngine_BACKUP_ENGINE_POSTGRES:
 		return "postgres"
+	case pb.BackupEngine_BACKUP_ENGINE_MYSQL:
+		return "mysql"
+	case pb.BackupEngine_BACKUP_ENGINE_REDIS:
+		return "redis"
 	default:
 		// Covers a record written before the enum existed, and one whose
 		// engine this build does not know. Both are genuinely unknown to
@@ -85,12 +107,15 @@ func engineLabel(e pb.BackupEngine) string {
 	}
 }
 
+// destLabel renders a destination enum for human output.
 func destLabel(d pb.BackupDestination) string {
 	switch d {
 	case pb.BackupDestination_BACKUP_DESTINATION_LOCAL:
 		return "local"
 	case pb.BackupDestination_BACKUP_DESTINATION_GCS:
 		return "gcs"
+	case pb.BackupDestination_BACKUP_DESTINATION_S3:
+		return "s3"
 	default:
 		return "unspecified"
 	}
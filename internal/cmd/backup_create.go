@@ -8,9 +8,11 @@ import (
 )
 
 var (
+	backupCreateEngine   string
 	backupCreateDatabase string
 	backupCreateDest     string
 	backupCreateBucket   string
+	backupCreateS3Bucket string
 	backupCreateDBUser   string
 	backupCreateDBPass   string
 	backupCreateDBHost   string
@@ -20,22 +22,32 @@ var (
 var backupCreateCmd = &cobra.Command{
 	Use:   "create <username>",
 	Short: "Dump a container's database(s) and store them off-host",
-	Long: `Run pg_dump inside the tenant's container and store the compressed
-dump(s) at the chosen destination.
+	Long: `Run the engine's dump tool inside the tenant's container and store the
+compressed dump(s) at the chosen destination. --engine selects postgres
+(pg_dump, the default), mysql (mysqldump or mariadb-dump) or redis (an
+RDB snapshot via BGSAVE).
 
 Omit --database to back up EVERY non-template database found in the
 container — the default. This is usually what you want: no need to
 already know a database name, and there's no name to get wrong. Pass
---database to dump just that one instead.
+--database to dump just that one instead. A Redis backup always covers
+the whole instance, so --database does not apply.
 
-Connection defaults target a per-container local Postgres on loopback
-(user "postgres", host 127.0.0.1, port 5432). The password, if needed, is
-passed to pg_dump via PGPASSWORD inside the container — never on argv.
+Connection defaults target a per-container local server on loopback
+(host 127.0.0.1; user "postgres" and port 5432 for Postgres, "root" and
+3306 for MySQL, port 6379 for Redis). The password, if needed, is passed
+to the dump tool through the environment inside the container — never
+on argv.
 
 Examples:
   containarium backup create alice --dest local --server <host>
   containarium backup create alice --database app --dest gcs \
-      --gcs-bucket gs://my-backups/pg --db-password "$PGPW" --server <host>`,
+      --gcs-bucket gs://my-backups/pg --db-password "$PGPW" --server <host>
+  containarium backup create alice --dest s3 \
+      --s3-bucket s3://my-backups/pg --server <host>
+  containarium backup create alice --engine mysql --database shop \
+      --db-password "$MYSQL_PW" --dest local --server <host>
+  containarium backup create alice --engine redis --dest local --server <host>`,
 	Args: cobra.ExactArgs(1),
 	RunE: runBackupCreate,
 }
@@ -43,17 +55,23 @@ Examples:
 func init() {
 	backupCmd.AddCommand(backupCreateCmd)
 	f := backupCreateCmd.Flags()
+	f.StringVar(&backupCreateEngine, "engine", "postgres", "database engine: 'postgres', 'mysql' or 'redis'")
 	f.StringVar(&backupCreateDatabase, "database", "", "database name to dump; omit to back up every non-template database found (default)")
-	f.StringVar(&backupCreateDest, "dest", "local", "destination: 'local' or 'gcs'")
+	f.StringVar(&backupCreateDest, "dest", "local", "destination: 'local', 'gcs' or 's3'")
 	f.StringVar(&backupCreateBucket, "gcs-bucket", "", "GCS bucket/prefix for --dest gcs, e.g. gs://my-backups/pg")
-	f.StringVar(&backupCreateDBUser, "db-user", "", "Postgres role (default: postgres)")
-	f.StringVar(&backupCreateDBPass, "db-password", "", "Postgres password (omit for peer/trust auth)")
+	f.StringVar(&backupCreateS3Bucket, "s3-bucket", "", "S3 bucket/prefix for --dest s3, e.g. s3://my-backups/pg (endpoint and credentials are daemon config)")
+	f.StringVar(&backupCreateDBUser, "db-user", "", "DB user (default: postgres for Postgres, root for MySQL; Redis ACL user)")
+	f.StringVar(&backupCreateDBPass, "db-password", "", "DB password (omit for peer/trust auth)")
 	f.StringVar(&backupCreateDBHost, "db-host", "", "DB host as seen inside the container (default: 127.0.0.1)")
-	f.Int32Var(&backupCreateDBPort, "db-port", 0, "DB port (default: 5432)")
+	f.Int32Var(&backupCreateDBPort, "db-port", 0, "DB port (default: the engine's standard port)")
 }
 
 func runBackupCreate(cmd *cobra.Command, args []string) error {
 	username := args[0]
+	engine, err := parseEngine(backupCreateEngine)
+	if err != nil {
+		return err
+	}
 	dest, err := parseDestination(backupCreateDest)
 	if err != nil {
 		return err
@@ -61,6 +79,9 @@ func runBackupCreate(cmd *cobra.Command, args []string) error {
 	if dest == pb.BackupDestination_BACKUP_DESTINATION_GCS && backupCreateBucket == "" {
 		return fmt.Errorf("--gcs-bucket is required when --dest gcs")
 	}
+	if dest == pb.BackupDestination_BACKUP_DESTINATION_S3 && backupCreateS3Bucket == "" {
+		return fmt.Errorf("--s3-bucket is required when --dest s3")
+	}
 
 	c, err := newBackupClient()
 	if err != nil {
@@ -75,8 +96,10 @@ func runBackupCreate(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := c.CreateBackup(&pb.CreateBackupRequest{
 		Username:    username,
+		Engine:      engine,
 		Destination: dest,
 		GcsBucket:   backupCreateBucket,
+		S3Bucket:    backupCreateS3Bucket,
 		Connection: &pb.PgConnection{
 			Database: backupCreateDatabase,
 			User:     backupCreateDBUser,
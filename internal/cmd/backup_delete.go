@@ -9,9 +9,10 @@ import (
 var backupDeleteCmd = &cobra.Command{
 	Use:   "delete <id>",
 	Short: "Delete a stored dump and its index entry",
-	Long: `Delete a stored dump (and its metadata sidecar). Retention policy is
-the caller's responsibility — wire this into a cron job that prunes
-backups older than your retention window. See docs/DB-BACKUP-OPERATIONS.md.`,
+	Long: `Delete a stored dump (and its metadata sidecar). Scheduled backups are
+pruned automatically by their schedule's retention policy ('backup
+schedule set --keep-daily ...'); use this for on-demand backups, which a
+schedule never deletes. See docs/DB-BACKUP-OPERATIONS.md.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runBackupDelete,
 }
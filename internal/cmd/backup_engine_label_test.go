@@ -14,6 +14,18 @@ func TestEngineLabel(t *testing.T) {
 		t.Errorf("engineLabel(POSTGRES) = %q, want %q — the CLI's output should not change "+
 			"because the wire type did", got, "postgres")
 	}
+	for _, name := range []string{"postgres", "mysql", "redis"} {
+		e, err := parseEngine(name)
+		if err != nil {
+			t.Fatalf("parseEngine(%q): %v", name, err)
+		}
+		if got := engineLabel(e); got != name {
+			t.Errorf("engineLabel(parseEngine(%q)) = %q — --engine and `backup get` should agree", name, got)
+		}
+	}
+	if _, err := parseEngine("mariadb"); err == nil {
+		t.Error(`parseEngine("mariadb") should fail: MariaDB is dumped with --engine mysql`)
+	}
 	if got := engineLabel(pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED); got != "unspecified" {
 		t.Errorf("engineLabel(UNSPECIFIED) = %q, want %q", got, "unspecified")
 	}
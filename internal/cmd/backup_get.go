@@ -37,5 +37,13 @@ func runBackupGet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("SHA-256:     %s\n", r.Sha256)
 	fmt.Printf("Destination: %s\n", destLabel(r.Destination))
 	fmt.Printf("Location:    %s\n", r.Location)
+	if e := r.Encryption; e != nil {
+		fmt.Printf("Encryption:  %s (key wrapped by %s)\n", e.Algorithm, e.KekId)
+	} else {
+		fmt.Printf("Encryption:  none (taken without a KMS configured)\n")
+	}
+	if r.Scheduled {
+		fmt.Printf("Scheduled:   yes (pruned by the tenant's retention policy)\n")
+	}
 	return nil
 }
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/footprintai/containarium/internal/client"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupScheduleCron         string
+	backupScheduleEngine       string
+	backupScheduleDatabases    []string
+	backupScheduleDest         string
+	backupScheduleBucket       string
+	backupScheduleS3Bucket     string
+	backupScheduleKeepDaily    int32
+	backupScheduleKeepWeekly   int32
+	backupScheduleKeepMonthly  int32
+	backupScheduleVerifyTarget string
+	backupScheduleDBUser       string
+	backupScheduleDBHost       string
+	backupScheduleDBPort       int32
+)
+
+var backupScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage recurring backups with retention",
+	Long: `Set, show, list and delete per-tenant backup schedules. The daemon
+runs each schedule on its cron expression (UTC), prunes the tenant's
+older scheduled backups to the retention policy after every successful
+dump, and optionally restore-tests each new backup into a scratch
+tenant. On-demand backups are never pruned by a schedule.
+
+A failed run is published as an EVENT_TYPE_BACKUP_SCHEDULE_FAILED event
+and raises the BackupScheduleFailed alert. See docs/DB-BACKUP-OPERATIONS.md.
+
+Examples:
+  containarium backup schedule set alice --cron "0 3 * * *" \
+      --keep-daily 7 --keep-weekly 4 --keep-monthly 12 --server <host>
+  containarium backup schedule get alice --server <host>
+  containarium backup schedule list --server <host>
+  containarium backup schedule delete alice --server <host>`,
+}
+
+var backupScheduleSetCmd = &cobra.Command{
+	Use:   "set <username>",
+	Short: "Create or replace a tenant's backup schedule",
+	Long: `Create or replace a tenant's backup schedule. Replacing a schedule
+keeps its run history; the next run is the first cron time after the
+change.
+
+Omit --database to back up every non-template database found on each
+run, as 'backup create' does. There is no --db-password: a schedule is
+persisted on the host, so scheduled dumps use trust/peer auth or a
+credentials file inside the container (PGPASSFILE, a MySQL option file).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBackupScheduleSet,
+}
+
+var backupScheduleGetCmd = &cobra.Command{
+	Use:   "get <username>",
+	Short: "Show a tenant's backup schedule and its last run",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBackupScheduleGet,
+}
+
+var backupScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List backup schedules",
+	Long: `List backup schedules. Admins see every tenant's schedule; a
+non-admin token sees only its own.`,
+	Args: cobra.NoArgs,
+	RunE: runBackupScheduleList,
+}
+
+var backupScheduleDeleteCmd = &cobra.Command{
+	Use:   "delete <username>",
+	Short: "Delete a tenant's backup schedule (backups already taken are kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBackupScheduleDelete,
+}
+
+func init() {
+	backupCmd.AddCommand(backupScheduleCmd)
+	backupScheduleCmd.AddCommand(backupScheduleSetCmd, backupScheduleGetCmd,
+		backupScheduleListCmd, backupScheduleDeleteCmd)
+
+	f := backupScheduleSetCmd.Flags()
+	f.StringVar(&backupScheduleCron, "cron", "", `five-field cron expression in UTC, e.g. "0 3 * * *" or @daily (required)`)
+	f.StringVar(&backupScheduleEngine, "engine", "postgres", "database engine: 'postgres', 'mysql' or 'redis'")
+	f.StringArrayVar(&backupScheduleDatabases, "database", nil, "database to dump each run (repeatable); omit to back up every non-template database found (default)")
+	f.StringVar(&backupScheduleDest, "dest", "local", "destination: 'local', 'gcs' or 's3'")
+	f.StringVar(&backupScheduleBucket, "gcs-bucket", "", "GCS bucket/prefix for --dest gcs, e.g. gs://my-backups/pg")
+	f.StringVar(&backupScheduleS3Bucket, "s3-bucket", "", "S3 bucket/prefix for --dest s3, e.g. s3://my-backups/pg")
+	f.Int32Var(&backupScheduleKeepDaily, "keep-daily", 0, "keep the newest scheduled backup of each of the last N days")
+	f.Int32Var(&backupScheduleKeepWeekly, "keep-weekly", 0, "keep the newest scheduled backup of each of the last N ISO weeks")
+	f.Int32Var(&backupScheduleKeepMonthly, "keep-monthly", 0, "keep the newest scheduled backup of each of the last N months")
+	f.StringVar(&backupScheduleVerifyTarget, "verify-target", "", "restore-test each new backup into this tenant's container (must differ from <username>)")
+	f.StringVar(&backupScheduleDBUser, "db-user", "", "DB user (default: postgres for Postgres, root for MySQL; Redis ACL user)")
+	f.StringVar(&backupScheduleDBHost, "db-host", "", "DB host as seen inside the container (default: 127.0.0.1)")
+	f.Int32Var(&backupScheduleDBPort, "db-port", 0, "DB port (default: the engine's standard port)")
+	_ = backupScheduleSetCmd.MarkFlagRequired("cron")
+}
+
+// backupScheduleAPI is the subset of the typed client used by the
+// schedule commands. Kept apart from backupAPI so fakes of the one-shot
+// backup commands don't have to grow schedule methods.
+type backupScheduleAPI interface {
+	SetBackupSchedule(sched *pb.BackupSchedule) (*pb.BackupSchedule, error)
+	GetBackupSchedule(username string) (*pb.BackupSchedule, error)
+	ListBackupSchedules() ([]*pb.BackupSchedule, error)
+	DeleteBackupSchedule(username string) (*pb.DeleteBackupScheduleResponse, error)
+	Close() error
+}
+
+func newBackupScheduleClient() (backupScheduleAPI, error) {
+	if serverAddr == "" {
+		return nil, fmt.Errorf("--server is required")
+	}
+	if httpMode {
+		return client.NewHTTPClient(serverAddr, authToken)
+	}
+	return client.NewGRPCClient(serverAddr, certsDir, insecure)
+}
+
+func runBackupScheduleSet(cmd *cobra.Command, args []string) error {
+	engine, err := parseEngine(backupScheduleEngine)
+	if err != nil {
+		return err
+	}
+	dest, err := parseDestination(backupScheduleDest)
+	if err != nil {
+		return err
+	}
+
+	c, err := newBackupScheduleClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	sched, err := c.SetBackupSchedule(&pb.BackupSchedule{
+		Username:  args[0],
+		Cron:      backupScheduleCron,
+		Engine:    engine,
+		Databases: backupScheduleDatabases,
+		Connection: &pb.PgConnection{
+			User: backupScheduleDBUser,
+			Host: backupScheduleDBHost,
+			Port: backupScheduleDBPort,
+		},
+		Destination: dest,
+		GcsBucket:   backupScheduleBucket,
+		S3Bucket:    backupScheduleS3Bucket,
+		Retention: &pb.BackupRetention{
+			Daily:   backupScheduleKeepDaily,
+			Weekly:  backupScheduleKeepWeekly,
+			Monthly: backupScheduleKeepMonthly,
+		},
+		VerifyTargetUsername: backupScheduleVerifyTarget,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ backup schedule set for %s (next run %s)\n", sched.Username, sched.NextRunAt)
+	return nil
+}
+
+func runBackupScheduleGet(cmd *cobra.Command, args []string) error {
+	c, err := newBackupScheduleClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	s, err := c.GetBackupSchedule(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("User:          %s\n", s.Username)
+	fmt.Printf("Cron (UTC):    %s\n", s.Cron)
+	fmt.Printf("Engine:        %s\n", engineLabel(s.Engine))
+	fmt.Printf("Databases:     %s\n", databasesLabel(s.Databases))
+	fmt.Printf("Destination:   %s\n", destLabel(s.Destination))
+	if s.GcsBucket != "" {
+		fmt.Printf("GCS bucket:    %s\n", s.GcsBucket)
+	}
+	if s.S3Bucket != "" {
+		fmt.Printf("S3 bucket:     %s\n", s.S3Bucket)
+	}
+	fmt.Printf("Retention:     %s\n", retentionLabel(s.Retention))
+	if s.VerifyTargetUsername != "" {
+		fmt.Printf("Verify target: %s\n", s.VerifyTargetUsername)
+	}
+	fmt.Printf("Updated:       %s\n", s.UpdatedAt)
+	fmt.Printf("Next run:      %s\n", s.NextRunAt)
+
+	r := s.LastRun
+	if r == nil {
+		fmt.Printf("Last run:      never\n")
+		return nil
+	}
+	fmt.Printf("Last run:      %s %s\n", scheduleRunLabel(r), r.StartedAt)
+	fmt.Printf("  Backups:     %s\n", strings.Join(r.BackupIds, ", "))
+	if len(r.PrunedIds) > 0 {
+		fmt.Printf("  Pruned:      %s\n", strings.Join(r.PrunedIds, ", "))
+	}
+	for _, f := range r.Failures {
+		fmt.Printf("  Failure:     %s\n", f)
+	}
+	for _, f := range r.VerificationFailures {
+		fmt.Printf("  Verify fail: %s\n", f)
+	}
+	return nil
+}
+
+func runBackupScheduleList(cmd *cobra.Command, args []string) error {
+	c, err := newBackupScheduleClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	schedules, err := c.ListBackupSchedules()
+	if err != nil {
+		return err
+	}
+	if len(schedules) == 0 {
+		fmt.Println("No backup schedules found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tCRON (UTC)\tDATABASES\tDEST\tRETENTION\tNEXT RUN\tLAST RUN")
+	for _, s := range schedules {
+		last := "never"
+		if s.LastRun != nil {
+			last = scheduleRunLabel(s.LastRun) + " " + s.LastRun.StartedAt
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Username, s.Cron, databasesLabel(s.Databases), destLabel(s.Destination),
+			retentionLabel(s.Retention), s.NextRunAt, last)
+	}
+	return w.Flush()
+}
+
+func runBackupScheduleDelete(cmd *cobra.Command, args []string) error {
+	c, err := newBackupScheduleClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.DeleteBackupSchedule(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s\n", resp.Message)
+	return nil
+}
+
+func databasesLabel(dbs []string) string {
+	if len(dbs) == 0 {
+		return "all"
+	}
+	return strings.Join(dbs, ",")
+}
+
+// retentionLabel renders a policy as "7d/4w/12m". A policy that keeps
+// everything says so, since an unbounded schedule is worth noticing.
+func retentionLabel(r *pb.BackupRetention) string {
+	if r == nil || (r.Daily == 0 && r.Weekly == 0 && r.Monthly == 0) {
+		return "keep all"
+	}
+	return fmt.Sprintf("%dd/%dw/%dm", r.Daily, r.Weekly, r.Monthly)
+}
+
+func scheduleRunLabel(r *pb.BackupScheduleRun) string {
+	if r.Ok {
+		return "ok"
+	}
+	return "FAIL"
+}
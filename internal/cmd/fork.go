@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+// Forking a box from a snapshot (#1160c). A top-level verb rather than
+// `snapshot fork` because what it makes is a box, and the snapshot is only
+// where it starts.
+
+var (
+	forkSSHKeyFiles []string
+	forkLabels      []string
+)
+
+var forkCmd = &cobra.Command{
+	Use:   "fork <username>@<snapshot> <new-username>",
+	Short: "Create a new container from a snapshot of an existing one",
+	Long: `Create a new container from a snapshot of an existing one, to branch a
+working box into siblings that try alternatives in parallel.
+
+The fork is a copy-on-write ZFS clone of the snapshot: instant, and free
+until it diverges from its source. It gets a fresh identity — the source's
+user renamed to <new-username>, new SSH host keys, its own IP, its own
+secrets — and the source's labels with any --label overlaid. Without
+--ssh-key it accepts the keys the source's user had in the snapshot.
+
+The fork stays on the source's storage pool and in the source's tenant. It
+pins the snapshot: the snapshot cannot be deleted while a fork of it exists.
+
+  containarium snapshot create alice --name base --server <host>
+  containarium fork alice@base alice-try1 --label attempt=1 --server <host>
+  containarium fork alice@base alice-try2 --label attempt=2 --server <host>`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFork,
+}
+
+func init() {
+	rootCmd.AddCommand(forkCmd)
+	f := forkCmd.Flags()
+	f.StringArrayVar(&forkSSHKeyFiles, "ssh-key", nil,
+		"path to an SSH public key file for the fork (repeatable); default keeps the snapshot's keys")
+	f.StringArrayVar(&forkLabels, "label", nil, "label to set on the fork, key=value (repeatable)")
+}
+
+// parseForkSource splits "<username>@<snapshot>".
+func parseForkSource(ref string) (username, snapshot string, err error) {
+	username, snapshot, ok := strings.Cut(ref, "@")
+	if !ok || username == "" || snapshot == "" {
+		return "", "", fmt.Errorf("invalid source %q: expected <username>@<snapshot>", ref)
+	}
+	return username, snapshot, nil
+}
+
+func runFork(cmd *cobra.Command, args []string) error {
+	username, snapshot, err := parseForkSource(args[0])
+	if err != nil {
+		return err
+	}
+	labels, err := parseKeyValues(forkLabels)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for _, path := range forkSSHKeyFiles {
+		// #nosec G304 -- operator-supplied --ssh-key path, as on create.
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read SSH key file %q: %w", path, err)
+		}
+		keys = append(keys, strings.TrimSpace(string(b)))
+	}
+
+	c, err := newSnapshotClientFn()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.CloneContainer(&pb.CloneContainerRequest{
+		Username:    username,
+		Snapshot:    snapshot,
+		NewUsername: args[1],
+		SshKeys:     keys,
+		Labels:      labels,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Forked %s from %s@%s\n", resp.GetContainer().GetName(), username, snapshot)
+	if ip := resp.GetContainer().GetNetwork().GetIpAddress(); ip != "" {
+		fmt.Printf("   IP: %s\n", ip)
+	}
+	if resp.GetSshCommand() != "" {
+		fmt.Printf("   SSH: %s\n", resp.GetSshCommand())
+	}
+	fmt.Printf("   Note: %s@%s cannot be deleted while this fork exists.\n", username, snapshot)
+	return nil
+}
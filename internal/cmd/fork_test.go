@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseForkSource(t *testing.T) {
+	user, snap, err := parseForkSource("alice@base")
+	if err != nil || user != "alice" || snap != "base" {
+		t.Fatalf("parseForkSource = %q, %q, %v", user, snap, err)
+	}
+	for _, bad := range []string{"alice", "@base", "alice@", ""} {
+		if _, _, err := parseForkSource(bad); err == nil {
+			t.Errorf("parseForkSource(%q) accepted", bad)
+		}
+	}
+}
+
+func TestFork_SendsSourceSnapshotAndLabels(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+	forkLabels = []string{"attempt=2"}
+	t.Cleanup(func() { forkLabels = nil })
+
+	out := captureStdout(t, func() {
+		if err := runFork(nil, []string{"alice@base", "alice-try2"}); err != nil {
+			t.Fatalf("runFork: %v", err)
+		}
+	})
+
+	req := api.cloned
+	if req.GetUsername() != "alice" || req.GetSnapshot() != "base" || req.GetNewUsername() != "alice-try2" {
+		t.Fatalf("sent %+v", req)
+	}
+	if req.GetLabels()["attempt"] != "2" {
+		t.Errorf("labels = %v", req.GetLabels())
+	}
+	for _, want := range []string{"alice-try2-container", "ssh alice-try2@host", "cannot be deleted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFork_RejectsABadSourceBeforeCallingTheDaemon(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+
+	if err := runFork(nil, []string{"alice", "alice-try2"}); err == nil {
+		t.Fatal("accepted a source with no snapshot")
+	}
+	if api.cloned != nil {
+		t.Errorf("the daemon was called anyway: %+v", api.cloned)
+	}
+}
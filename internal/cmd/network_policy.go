@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
 	"time"
@@ -40,8 +41,10 @@ var (
 	npAllowIntraTenant bool
 	npEgressCidrs      []string
 	npEgressDomains    []string
+	npEgressRules      []string
 	npMode             string
 	npAllowMetadata    bool
+	npDryRun           bool
 )
 
 var networkPolicySetCmd = &cobra.Command{
@@ -129,11 +132,18 @@ func init() {
 		"Allowed egress destination CIDR (repeatable, e.g. --egress-cidr 10.0.0.0/8)")
 	networkPolicySetCmd.Flags().StringSliceVar(&npEgressDomains, "egress-domain", nil,
 		"Allowed egress domain (repeatable, e.g. --egress-domain api.github.com)")
+	// StringArray, not StringSlice: a rule is one value containing a space, and
+	// splitting on commas would buy nothing here.
+	networkPolicySetCmd.Flags().StringArrayVar(&npEgressRules, "egress-rule", nil,
+		`Port-scoped egress allow rule "<cidr|domain> <tcp|udp|any>[/<port>[-<end>]]" (repeatable, e.g. --egress-rule "api.github.com tcp/443")`)
 	networkPolicySetCmd.Flags().StringVar(&npMode, "mode", "log_only",
 		"Enforcement mode: log_only | enforce")
 	networkPolicySetCmd.Flags().BoolVar(&npAllowMetadata, "allow-metadata", false,
 		"Allow reaching the cloud metadata service (169.254.169.254); default deny even if a CIDR would cover it")
-	networkPolicySetCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output the stored policy as JSON")
+	networkPolicySetCmd.Flags().BoolVar(&npDryRun, "dry-run", false,
+		"Store nothing; replay the tenant's recorded egress through the policy and report what would change")
+	networkPolicySetCmd.Flags().StringVar(&npSince, "since", "7d", "With --dry-run, the window replayed: days (7d) or a Go duration (36h)")
+	networkPolicySetCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output the stored policy (or the --dry-run report) as JSON")
 
 	networkPolicyGetCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output as JSON")
 	networkPolicyListCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output as JSON")
@@ -286,14 +296,24 @@ func runNetworkPolicySignatureList(cmd *cobra.Command, args []string) error {
 // grpc-gateway). Local so a server-side schema change surfaces as a decode
 // failure here, not a silent field-drop.
 type netPolicyJSON struct {
-	Tenant           string         `json:"tenant"`
-	AllowIntraTenant bool           `json:"allowIntraTenant"`
-	EgressCidrs      []string       `json:"egressCidrs"`
-	EgressDomains    []string       `json:"egressDomains"`
-	AllowMetadata    bool           `json:"allowMetadata"`
-	Mode             string         `json:"mode"`
-	Source           string         `json:"source"`
-	DenyRules        []denyRuleJSON `json:"denyRules,omitempty"`
+	Tenant           string           `json:"tenant"`
+	AllowIntraTenant bool             `json:"allowIntraTenant"`
+	EgressCidrs      []string         `json:"egressCidrs"`
+	EgressDomains    []string         `json:"egressDomains"`
+	AllowMetadata    bool             `json:"allowMetadata"`
+	Mode             string           `json:"mode"`
+	Source           string           `json:"source"`
+	DenyRules        []denyRuleJSON   `json:"denyRules,omitempty"`
+	EgressRules      []egressRuleJSON `json:"egressRules,omitempty"`
+}
+
+// egressRuleJSON mirrors NetworkPolicyEgressRule, grpc-gateway camelCase.
+type egressRuleJSON struct {
+	Cidr    string `json:"cidr,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	Proto   string `json:"proto,omitempty"`
+	Port    uint32 `json:"port,omitempty"`
+	PortEnd uint32 `json:"portEnd,omitempty"`
 }
 
 // denyRuleJSON mirrors NetworkPolicyDenyRule (#660), grpc-gateway camelCase.
@@ -332,21 +352,17 @@ func runNetworkPolicySet(cmd *cobra.Command, args []string) error {
 	if serverAddr == "" {
 		return errServerRequired()
 	}
-	mode, err := normalizeMode(npMode)
-	if err != nil {
-		return err
-	}
 	// `set` declares the allow-policy only; virtual-patch deny rules (#660) are
 	// owned by `network-policy patch` and preserved server-side across a set, so
 	// no client round-trip is needed to keep them.
-	body := setNetworkPolicyRequest{Policy: netPolicyJSON{
-		Tenant:           args[0],
-		AllowIntraTenant: npAllowIntraTenant,
-		EgressCidrs:      npEgressCidrs,
-		EgressDomains:    npEgressDomains,
-		AllowMetadata:    npAllowMetadata,
-		Mode:             mode,
-	}}
+	policy, err := policyFromFlags(args[0])
+	if err != nil {
+		return err
+	}
+	body := setNetworkPolicyRequest{Policy: policy}
+	if npDryRun {
+		return simulateNetworkPolicy(cmd, body.Policy)
+	}
 	var out policyEnvelope
 	if err := doJSON("POST", strings.TrimSuffix(serverAddr, "/")+"/v1/network-policies", body, &out); err != nil {
 		return err
@@ -359,6 +375,32 @@ func runNetworkPolicySet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// policyFromFlags builds the allow-policy the set flags describe (shared by
+// `set` and `box set`).
+func policyFromFlags(tenant string) (netPolicyJSON, error) {
+	mode, err := normalizeMode(npMode)
+	if err != nil {
+		return netPolicyJSON{}, err
+	}
+	rules := make([]egressRuleJSON, 0, len(npEgressRules))
+	for _, raw := range npEgressRules {
+		r, err := parseEgressRule(raw)
+		if err != nil {
+			return netPolicyJSON{}, err
+		}
+		rules = append(rules, r)
+	}
+	return netPolicyJSON{
+		Tenant:           tenant,
+		AllowIntraTenant: npAllowIntraTenant,
+		EgressCidrs:      npEgressCidrs,
+		EgressDomains:    npEgressDomains,
+		EgressRules:      rules,
+		AllowMetadata:    npAllowMetadata,
+		Mode:             mode,
+	}, nil
+}
+
 func runNetworkPolicyGet(cmd *cobra.Command, args []string) error {
 	if serverAddr == "" {
 		return errServerRequired()
@@ -527,6 +569,63 @@ func denyRuleFromFlags() (denyRuleJSON, error) {
 	}, nil
 }
 
+// parseEgressRule parses the --egress-rule syntax
+// "<cidr|domain> <tcp|udp|any>[/<port>[-<end>]]". A destination that parses as
+// an address or prefix is a CIDR, anything else a domain; "any" leaves the
+// protocol empty (TCP and UDP). The server re-validates authoritatively.
+func parseEgressRule(s string) (egressRuleJSON, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return egressRuleJSON{}, fmt.Errorf("invalid --egress-rule %q (want \"<cidr|domain> <tcp|udp|any>[/<port>[-<end>]]\")", s)
+	}
+	var r egressRuleJSON
+	dest := fields[0]
+	if _, err := netip.ParsePrefix(dest); err == nil {
+		r.Cidr = dest
+	} else if _, err := netip.ParseAddr(dest); err == nil {
+		r.Cidr = dest
+	} else {
+		r.Domain = dest
+	}
+	proto, ports, hasPorts := strings.Cut(strings.ToLower(fields[1]), "/")
+	switch proto {
+	case "tcp", "udp":
+		r.Proto = proto
+	case "any":
+	default:
+		return egressRuleJSON{}, fmt.Errorf("invalid --egress-rule %q: proto must be tcp, udp or any (got %q)", s, proto)
+	}
+	if !hasPorts {
+		return r, nil
+	}
+	lo, hi, isRange := strings.Cut(ports, "-")
+	port, err := strconv.ParseUint(lo, 10, 16)
+	if err != nil || port == 0 {
+		return egressRuleJSON{}, fmt.Errorf("invalid --egress-rule %q: bad port %q", s, lo)
+	}
+	r.Port = uint32(port)
+	if isRange {
+		end, err := strconv.ParseUint(hi, 10, 16)
+		if err != nil || end < port {
+			return egressRuleJSON{}, fmt.Errorf("invalid --egress-rule %q: bad port range %q", s, ports)
+		}
+		r.PortEnd = uint32(end)
+	}
+	return r, nil
+}
+
+// egressRuleSummary renders a rule back in the --egress-rule syntax.
+func egressRuleSummary(r egressRuleJSON) string {
+	s := r.Cidr + r.Domain + " " + denyProtoStr(r.Proto)
+	if r.Port != 0 {
+		s += "/" + strconv.Itoa(int(r.Port))
+		if r.PortEnd > r.Port {
+			s += "-" + strconv.Itoa(int(r.PortEnd))
+		}
+	}
+	return s
+}
+
 func denyRuleSummary(r denyRuleJSON) string {
 	s := r.Cidr
 	if r.Proto != "" {
@@ -616,9 +715,12 @@ func shortMode(m string) string {
 }
 
 func egressSummary(p netPolicyJSON) string {
-	parts := make([]string, 0, len(p.EgressCidrs)+len(p.EgressDomains))
+	parts := make([]string, 0, len(p.EgressCidrs)+len(p.EgressDomains)+len(p.EgressRules))
 	parts = append(parts, p.EgressCidrs...)
 	parts = append(parts, p.EgressDomains...)
+	for _, r := range p.EgressRules {
+		parts = append(parts, egressRuleSummary(r))
+	}
 	if len(parts) == 0 {
 		return "(none)"
 	}
@@ -639,6 +741,12 @@ func printPolicy(w io.Writer, p netPolicyJSON) {
 	if len(p.EgressDomains) > 0 {
 		fmt.Fprintf(w, "  egress-domains:     %s\n", strings.Join(p.EgressDomains, ", "))
 	}
+	if len(p.EgressRules) > 0 {
+		fmt.Fprintf(w, "  egress-rules:\n")
+		for _, r := range p.EgressRules {
+			fmt.Fprintf(w, "    - %s\n", egressRuleSummary(r))
+		}
+	}
 	printDenyRules(w, p.DenyRules)
 }
 
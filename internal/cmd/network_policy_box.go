@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// network-policy box set flags (the allow-policy flags are shared with set)
+var (
+	npBoxSelect   []string
+	npBoxPriority int32
+)
+
+var networkPolicyBoxCmd = &cobra.Command{
+	Use:   "box",
+	Short: "Manage label-selected policies for a subset of a tenant's boxes",
+	Long: `Manage box policies: network policies that apply to the boxes of a tenant
+whose labels (containarium label set) match a selector.
+
+A selected box is policed by the box policy INSTEAD of the tenant-wide policy:
+its egress allow-list, --allow-intra-tenant, --allow-metadata and --mode all
+come from the box policy. The tenant's virtual patches (network-policy patch)
+still apply to every box. When several box policies select the same box, the
+highest --priority wins, then the one with more --select labels, then the
+lower name. A box no box policy selects keeps the tenant-wide policy.
+
+Examples:
+  # A database that reaches nothing
+  containarium network-policy box set alice db --select role=db --mode enforce
+
+  # CI runners that need the internet
+  containarium network-policy box set alice ci --select role=ci \
+    --egress-cidr 0.0.0.0/0 --egress-cidr ::/0 --mode enforce`,
+}
+
+var networkPolicyBoxSetCmd = &cobra.Command{
+	Use:   "set <tenant> <name> --select key=value [...]",
+	Short: "Create or update a box policy",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runNetworkPolicyBoxSet,
+}
+
+var networkPolicyBoxListCmd = &cobra.Command{
+	Use:   "list [tenant]",
+	Short: "List box policies (of one tenant, or all)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runNetworkPolicyBoxList,
+}
+
+var networkPolicyBoxRmCmd = &cobra.Command{
+	Use:   "rm <tenant> <name>",
+	Short: "Delete a box policy (its boxes fall back to the tenant-wide policy)",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runNetworkPolicyBoxRm,
+}
+
+func init() {
+	networkPolicyCmd.AddCommand(networkPolicyBoxCmd)
+	networkPolicyBoxCmd.AddCommand(networkPolicyBoxSetCmd, networkPolicyBoxListCmd, networkPolicyBoxRmCmd)
+
+	f := networkPolicyBoxSetCmd.Flags()
+	f.StringArrayVar(&npBoxSelect, "select", nil, "Label a box must carry to be selected, key=value (repeatable; all must match)")
+	f.Int32Var(&npBoxPriority, "priority", 0, "Precedence among the tenant's box policies; higher wins")
+	f.BoolVar(&npAllowIntraTenant, "allow-intra-tenant", false, "Allow selected boxes to reach the tenant's other boxes")
+	f.StringSliceVar(&npEgressCidrs, "egress-cidr", nil, "Allowed egress destination CIDR (repeatable)")
+	f.StringSliceVar(&npEgressDomains, "egress-domain", nil, "Allowed egress domain (repeatable)")
+	f.StringArrayVar(&npEgressRules, "egress-rule", nil, `Port-scoped egress allow rule "<cidr|domain> <tcp|udp|any>[/<port>[-<end>]]" (repeatable)`)
+	f.StringVar(&npMode, "mode", "log_only", "Enforcement mode: log_only | enforce")
+	f.BoolVar(&npAllowMetadata, "allow-metadata", false, "Allow reaching the cloud metadata service")
+	f.BoolVar(&npJSONOut, "json", false, "Output the stored box policy as JSON")
+	_ = networkPolicyBoxSetCmd.MarkFlagRequired("select")
+
+	networkPolicyBoxListCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output as JSON")
+}
+
+// boxPolicyJSON mirrors BoxNetworkPolicy, grpc-gateway camelCase.
+type boxPolicyJSON struct {
+	Tenant   string            `json:"tenant"`
+	Name     string            `json:"name"`
+	Selector map[string]string `json:"selector"`
+	Priority int32             `json:"priority,omitempty"`
+	Policy   netPolicyJSON     `json:"policy"`
+}
+
+type boxPolicyEnvelope struct {
+	Policy boxPolicyJSON `json:"policy"`
+}
+type boxPoliciesEnvelope struct {
+	Policies []boxPolicyJSON `json:"policies"`
+}
+
+// parseSelector parses the --select flags the way `label set` parses labels.
+func parseSelector(pairs []string) (map[string]string, error) {
+	sel := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --select %q (expected key=value)", p)
+		}
+		k = strings.TrimSpace(k)
+		if k == "" {
+			return nil, fmt.Errorf("empty label key in --select %q", p)
+		}
+		sel[k] = strings.TrimSpace(v)
+	}
+	if len(sel) == 0 {
+		return nil, fmt.Errorf("at least one --select key=value is required")
+	}
+	return sel, nil
+}
+
+func runNetworkPolicyBoxSet(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	sel, err := parseSelector(npBoxSelect)
+	if err != nil {
+		return err
+	}
+	policy, err := policyFromFlags(args[0])
+	if err != nil {
+		return err
+	}
+	body := boxPolicyEnvelope{Policy: boxPolicyJSON{
+		Tenant:   args[0],
+		Name:     args[1],
+		Selector: sel,
+		Priority: npBoxPriority,
+		Policy:   policy,
+	}}
+	var out boxPolicyEnvelope
+	if err := doJSON("POST", strings.TrimSuffix(serverAddr, "/")+"/v1/box-network-policies", body, &out); err != nil {
+		return err
+	}
+	if npJSONOut {
+		return printJSON(out.Policy)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ box policy %q set for %q (selects %s)\n", out.Policy.Name, out.Policy.Tenant, selectorString(out.Policy.Selector))
+	printPolicy(cmd.OutOrStdout(), out.Policy.Policy)
+	return nil
+}
+
+func runNetworkPolicyBoxList(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	u := strings.TrimSuffix(serverAddr, "/") + "/v1/box-network-policies"
+	if len(args) == 1 {
+		u += "?tenant=" + url.QueryEscape(args[0])
+	}
+	var out boxPoliciesEnvelope
+	if err := getJSON(u, &out); err != nil {
+		return err
+	}
+	if npJSONOut {
+		return printJSON(out.Policies)
+	}
+	printBoxPolicies(cmd.OutOrStdout(), out.Policies)
+	return nil
+}
+
+func printBoxPolicies(w io.Writer, policies []boxPolicyJSON) {
+	if len(policies) == 0 {
+		fmt.Fprintln(w, "No box policies.")
+		return
+	}
+	fmt.Fprintf(w, "%-20s %-16s %-28s %-5s %-12s %-6s %s\n", "TENANT", "NAME", "SELECTOR", "PRIO", "MODE", "INTRA", "EGRESS")
+	for _, b := range policies {
+		fmt.Fprintf(w, "%-20s %-16s %-28s %-5d %-12s %-6v %s\n", b.Tenant, b.Name, selectorString(b.Selector),
+			b.Priority, shortMode(b.Policy.Mode), b.Policy.AllowIntraTenant, egressSummary(b.Policy))
+	}
+}
+
+// selectorString renders a selector as sorted key=value pairs.
+func selectorString(sel map[string]string) string {
+	parts := make([]string, 0, len(sel))
+	for k, v := range sel {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func runNetworkPolicyBoxRm(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	u := strings.TrimSuffix(serverAddr, "/") + "/v1/box-network-policies/" + url.PathEscape(args[0]) + "/" + url.PathEscape(args[1])
+	if err := doJSON("DELETE", u, nil, nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ box policy %q deleted for %q\n", args[1], args[0])
+	return nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	sel, err := parseSelector([]string{"role=db", " env = prod "})
+	if err != nil || len(sel) != 2 || sel["env"] != "prod" {
+		t.Fatalf("parseSelector = %v, %v", sel, err)
+	}
+	if got := selectorString(sel); got != "env=prod,role=db" {
+		t.Errorf("selectorString = %q", got)
+	}
+	for _, bad := range [][]string{nil, {"role"}, {"=db"}} {
+		if _, err := parseSelector(bad); err == nil {
+			t.Errorf("parseSelector(%q) accepted", bad)
+		}
+	}
+}
+
+func TestBoxPoliciesJSON_Decodes(t *testing.T) {
+	raw := `{"policies":[{"tenant":"alice","name":"ci","selector":{"role":"ci"},"priority":2,
+"policy":{"tenant":"alice","egressCidrs":["0.0.0.0/0"],"mode":"NETWORK_POLICY_MODE_ENFORCE"}}]}`
+	var env boxPoliciesEnvelope
+	if err := json.Unmarshal([]byte(raw), &env); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printBoxPolicies(&buf, env.Policies)
+	out := buf.String()
+	for _, want := range []string{"alice", "ci", "role=ci", "ENFORCE", "0.0.0.0/0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// simulateRequest mirrors SimulateNetworkPolicyRequest, grpc-gateway camelCase.
+type simulateRequest struct {
+	Policy netPolicyJSON `json:"policy"`
+	Since  string        `json:"since,omitempty"`
+}
+
+// simulatedFlowJSON mirrors NetworkPolicySimulatedFlow.
+type simulatedFlowJSON struct {
+	DestIP         string   `json:"destIp"`
+	Domain         string   `json:"domain,omitempty"`
+	Proto          string   `json:"proto"`
+	Port           uint32   `json:"port,omitempty"`
+	Flows          int64    `json:"flows,omitempty,string"`
+	Bytes          int64    `json:"bytes,omitempty,string"`
+	LastSeen       string   `json:"lastSeen,omitempty"`
+	Containers     []string `json:"containers,omitempty"`
+	PeerTenant     string   `json:"peerTenant,omitempty"`
+	CurrentReason  string   `json:"currentReason,omitempty"`
+	CurrentEntry   string   `json:"currentEntry,omitempty"`
+	ProposedReason string   `json:"proposedReason,omitempty"`
+	ProposedEntry  string   `json:"proposedEntry,omitempty"`
+}
+
+// simulationJSON mirrors SimulateNetworkPolicyResponse (int64 counters arrive
+// as strings).
+type simulationJSON struct {
+	Policy                   netPolicyJSON       `json:"policy"`
+	Current                  *netPolicyJSON      `json:"current,omitempty"`
+	Since                    string              `json:"since,omitempty"`
+	Destinations             int64               `json:"destinations,omitempty,string"`
+	Flows                    int64               `json:"flows,omitempty,string"`
+	NewlyDeniedDestinations  int64               `json:"newlyDeniedDestinations,omitempty,string"`
+	NewlyDeniedFlows         int64               `json:"newlyDeniedFlows,omitempty,string"`
+	NewlyAllowedDestinations int64               `json:"newlyAllowedDestinations,omitempty,string"`
+	NewlyAllowedFlows        int64               `json:"newlyAllowedFlows,omitempty,string"`
+	StillDeniedDestinations  int64               `json:"stillDeniedDestinations,omitempty,string"`
+	StillDeniedFlows         int64               `json:"stillDeniedFlows,omitempty,string"`
+	NewlyDenied              []simulatedFlowJSON `json:"newlyDenied,omitempty"`
+	NewlyAllowed             []simulatedFlowJSON `json:"newlyAllowed,omitempty"`
+}
+
+// simulateNetworkPolicy is `set --dry-run`: the policy set would send goes to
+// the simulate endpoint instead, which stores nothing.
+func simulateNetworkPolicy(cmd *cobra.Command, p netPolicyJSON) error {
+	var out simulationJSON
+	body := simulateRequest{Policy: p, Since: npSince}
+	if err := doJSON("POST", strings.TrimSuffix(serverAddr, "/")+"/v1/network-policies/simulate", body, &out); err != nil {
+		return err
+	}
+	if npJSONOut {
+		return printJSON(out)
+	}
+	printSimulation(cmd.OutOrStdout(), out)
+	return nil
+}
+
+func printSimulation(w io.Writer, s simulationJSON) {
+	fmt.Fprintf(w, "Dry run for %q — nothing stored. Replayed %d flow(s) to %d destination(s) since %s.\n",
+		s.Policy.Tenant, s.Flows, s.Destinations, s.Since)
+	if s.Current == nil {
+		fmt.Fprintln(w, "The tenant has no policy today, so every flow is currently allowed.")
+	}
+	fmt.Fprintf(w, "  newly denied:  %d flow(s) to %d destination(s)\n", s.NewlyDeniedFlows, s.NewlyDeniedDestinations)
+	fmt.Fprintf(w, "  newly allowed: %d flow(s) to %d destination(s)\n", s.NewlyAllowedFlows, s.NewlyAllowedDestinations)
+	fmt.Fprintf(w, "  still denied:  %d flow(s) to %d destination(s)\n", s.StillDeniedFlows, s.StillDeniedDestinations)
+	if shortMode(s.Policy.Mode) != "ENFORCE" && s.NewlyDeniedFlows > 0 {
+		fmt.Fprintln(w, "  (the proposed policy is log_only: these would be logged, not dropped)")
+	}
+	printSimulatedFlows(w, "Newly denied", s.NewlyDenied, s.NewlyDeniedDestinations, func(f simulatedFlowJSON) string {
+		return "was " + simulatedVerdict(f.CurrentReason, f.CurrentEntry) + ", now " + f.ProposedReason
+	})
+	printSimulatedFlows(w, "Newly allowed", s.NewlyAllowed, s.NewlyAllowedDestinations, func(f simulatedFlowJSON) string {
+		return "was " + f.CurrentReason + ", now " + simulatedVerdict(f.ProposedReason, f.ProposedEntry)
+	})
+}
+
+func printSimulatedFlows(w io.Writer, title string, fs []simulatedFlowJSON, total int64, why func(simulatedFlowJSON) string) {
+	if len(fs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s (%d of %d, busiest first):\n", title, len(fs), total)
+	fmt.Fprintf(w, "  %-40s %-10s %-8s %s\n", "DESTINATION", "PORT", "FLOWS", "WHY")
+	for _, f := range fs {
+		dest := f.DestIP
+		if f.Domain != "" {
+			dest += " (" + f.Domain + ")"
+		}
+		if f.PeerTenant != "" {
+			dest += " [" + f.PeerTenant + "]"
+		}
+		fmt.Fprintf(w, "  %-40s %-10s %-8d %s\n", dest, observedPortStr(observedDestJSON{Proto: f.Proto, Port: f.Port}), f.Flows, why(f))
+	}
+}
+
+// simulatedVerdict renders a reason with the allow-list entry that matched.
+func simulatedVerdict(reason, entry string) string {
+	if entry == "" {
+		return reason
+	}
+	return reason + " (" + entry + ")"
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSimulationJSON_Decodes(t *testing.T) {
+	raw := `{"policy":{"tenant":"alice","mode":"NETWORK_POLICY_MODE_LOG_ONLY"},"current":{"tenant":"alice"},"since":"2026-10-15T00:00:00Z",
+"destinations":"3","flows":"40","newlyDeniedDestinations":"1","newlyDeniedFlows":"7",
+"newlyDenied":[{"destIp":"10.1.2.4","proto":"tcp","port":5432,"flows":"7","currentReason":"allow-list","currentEntry":"10.0.0.0/8","proposedReason":"not-in-allow-list"}]}`
+	var s simulationJSON
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Flows != 40 || s.NewlyDeniedFlows != 7 || len(s.NewlyDenied) != 1 || s.NewlyDenied[0].Flows != 7 {
+		t.Fatalf("decoded %+v", s)
+	}
+
+	var buf bytes.Buffer
+	printSimulation(&buf, s)
+	out := buf.String()
+	for _, want := range []string{
+		"Replayed 40 flow(s) to 3 destination(s)",
+		"newly denied:  7 flow(s) to 1 destination(s)",
+		"logged, not dropped",
+		"Newly denied (1 of 1, busiest first)",
+		"was allow-list (10.0.0.0/8), now not-in-allow-list",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
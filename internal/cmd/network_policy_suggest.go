@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// npSince is the look-back window of suggest and set --dry-run.
+var npSince string
+
+var networkPolicySuggestCmd = &cobra.Command{
+	Use:   "suggest <tenant> [--since 7d]",
+	Short: "Suggest a policy from the egress a tenant actually made (learn mode)",
+	Long: `Build a candidate network policy from the egress flows the tenant's
+containers made over a window (default 7d), and report it against the current
+policy: the entries it would add and remove, and every observed destination
+the current policy would deny if enforced.
+
+Nothing is changed. Destinations are named by the current policy's domains or
+by a forward-confirmed reverse lookup; the rest are allowed as host addresses.
+Cross-tenant peers, the metadata service and virtual-patched destinations are
+never suggested. Review the candidate, then apply it with the printed
+"network-policy set" command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNetworkPolicySuggest,
+}
+
+func init() {
+	networkPolicyCmd.AddCommand(networkPolicySuggestCmd)
+	networkPolicySuggestCmd.Flags().StringVar(&npSince, "since", "7d", "Look-back window: days (7d) or a Go duration (36h)")
+	networkPolicySuggestCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output as JSON")
+}
+
+// observedDestJSON mirrors NetworkPolicyObservedDestination, grpc-gateway
+// camelCase (int64 counters arrive as strings).
+type observedDestJSON struct {
+	DestIP     string   `json:"destIp"`
+	Domain     string   `json:"domain,omitempty"`
+	Proto      string   `json:"proto"`
+	Port       uint32   `json:"port,omitempty"`
+	Flows      int64    `json:"flows,omitempty,string"`
+	Bytes      int64    `json:"bytes,omitempty,string"`
+	LastSeen   string   `json:"lastSeen,omitempty"`
+	Containers []string `json:"containers,omitempty"`
+	PeerTenant string   `json:"peerTenant,omitempty"`
+	Allowed    bool     `json:"allowed,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+	Entry      string   `json:"entry,omitempty"`
+	Rule       string   `json:"rule,omitempty"`
+	Excluded   string   `json:"excluded,omitempty"`
+}
+
+// suggestionJSON mirrors SuggestNetworkPolicyResponse.
+type suggestionJSON struct {
+	Candidate    netPolicyJSON      `json:"candidate"`
+	Current      *netPolicyJSON     `json:"current,omitempty"`
+	Destinations []observedDestJSON `json:"destinations,omitempty"`
+	Added        []string           `json:"added,omitempty"`
+	Removed      []string           `json:"removed,omitempty"`
+	WouldDeny    int32              `json:"wouldDeny,omitempty"`
+	Since        string             `json:"since,omitempty"`
+}
+
+func runNetworkPolicySuggest(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	u := strings.TrimSuffix(serverAddr, "/") + "/v1/network-policies/" + url.PathEscape(args[0]) +
+		"/suggest?since=" + url.QueryEscape(npSince)
+	var out suggestionJSON
+	if err := getJSON(u, &out); err != nil {
+		return err
+	}
+	if npJSONOut {
+		return printJSON(out)
+	}
+	printSuggestion(cmd.OutOrStdout(), args[0], out)
+	return nil
+}
+
+func printSuggestion(w io.Writer, tenant string, s suggestionJSON) {
+	fmt.Fprintf(w, "Observed egress for %q since %s: %d destination(s)\n", tenant, s.Since, len(s.Destinations))
+	if s.Current == nil {
+		fmt.Fprintln(w, "No current policy, so every flow is allowed today; the diff is against an empty one.")
+	}
+
+	fmt.Fprintln(w, "\nCandidate policy:")
+	printPolicy(w, s.Candidate)
+
+	fmt.Fprintln(w, "\nChanges against the current policy:")
+	if len(s.Added)+len(s.Removed) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, e := range s.Added {
+		fmt.Fprintf(w, "  + %s\n", e)
+	}
+	for _, e := range s.Removed {
+		fmt.Fprintf(w, "  - %s (unused in the window)\n", e)
+	}
+
+	fmt.Fprintf(w, "\nDestinations the current policy would deny if enforced: %d\n", s.WouldDeny)
+	if s.WouldDeny > 0 {
+		fmt.Fprintf(w, "  %-40s %-10s %-8s %-22s %s\n", "DESTINATION", "PORT", "FLOWS", "REASON", "SUGGESTED")
+		for _, d := range s.Destinations {
+			if d.Allowed {
+				continue
+			}
+			dest := d.DestIP
+			if d.Domain != "" {
+				dest += " (" + d.Domain + ")"
+			}
+			if d.PeerTenant != "" {
+				dest += " [" + d.PeerTenant + "]"
+			}
+			suggested := d.Rule
+			if d.Excluded != "" {
+				suggested = "not allowed: " + d.Excluded
+			}
+			fmt.Fprintf(w, "  %-40s %-10s %-8d %-22s %s\n", dest, observedPortStr(d), d.Flows, d.Reason, suggested)
+		}
+	}
+
+	fmt.Fprintln(w, "\nApply with:")
+	fmt.Fprintf(w, "  %s\n", suggestSetCommand(s.Candidate))
+}
+
+func observedPortStr(d observedDestJSON) string {
+	if d.Port == 0 {
+		return d.Proto
+	}
+	return d.Proto + "/" + strconv.Itoa(int(d.Port))
+}
+
+// suggestSetCommand renders the `network-policy set` invocation that stores a
+// candidate. Deny rules are not part of it: `set` preserves them server-side.
+func suggestSetCommand(p netPolicyJSON) string {
+	parts := []string{"containarium network-policy set", p.Tenant, "--mode", strings.ToLower(shortMode(p.Mode))}
+	if p.AllowIntraTenant {
+		parts = append(parts, "--allow-intra-tenant")
+	}
+	if p.AllowMetadata {
+		parts = append(parts, "--allow-metadata")
+	}
+	for _, c := range p.EgressCidrs {
+		parts = append(parts, "--egress-cidr", c)
+	}
+	for _, d := range p.EgressDomains {
+		parts = append(parts, "--egress-domain", d)
+	}
+	for _, r := range p.EgressRules {
+		parts = append(parts, "--egress-rule", strconv.Quote(egressRuleSummary(r)))
+	}
+	return strings.Join(parts, " ")
+}
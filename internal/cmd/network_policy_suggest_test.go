@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// The gateway sends int64 counters as strings; the mirror types must decode
+// them.
+func TestSuggestionJSON_Decodes(t *testing.T) {
+	raw := `{"candidate":{"tenant":"alice","mode":"NETWORK_POLICY_MODE_ENFORCE","egressRules":[{"domain":"pypi.org","proto":"tcp","port":443}]},
+"destinations":[{"destIp":"151.101.1.69","domain":"pypi.org","proto":"tcp","port":443,"flows":"12","bytes":"4096","reason":"not-in-allow-list","rule":"pypi.org tcp/443"}],
+"added":["pypi.org tcp/443"],"wouldDeny":1,"since":"2026-10-09T00:00:00Z"}`
+	var s suggestionJSON
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Current != nil || len(s.Destinations) != 1 || s.Destinations[0].Flows != 12 || s.Destinations[0].Bytes != 4096 {
+		t.Fatalf("decoded %+v", s)
+	}
+
+	var buf bytes.Buffer
+	printSuggestion(&buf, "alice", s)
+	out := buf.String()
+	for _, want := range []string{
+		"No current policy",
+		"  + pypi.org tcp/443",
+		"would deny if enforced: 1",
+		"151.101.1.69 (pypi.org)",
+		`containarium network-policy set alice --mode enforce --egress-rule "pypi.org tcp/443"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEgressRule(t *testing.T) {
+	cases := map[string]egressRuleJSON{
+		"api.github.com tcp/443":    {Domain: "api.github.com", Proto: "tcp", Port: 443},
+		"10.0.0.0/8 tcp/5432":       {Cidr: "10.0.0.0/8", Proto: "tcp", Port: 5432},
+		"2001:db8::1 UDP/8000-8100": {Cidr: "2001:db8::1", Proto: "udp", Port: 8000, PortEnd: 8100},
+		"192.0.2.0/24 udp":          {Cidr: "192.0.2.0/24", Proto: "udp"},
+		"  example.com   any/53  ":  {Domain: "example.com", Port: 53},
+		"198.51.100.7 any/1-65535":  {Cidr: "198.51.100.7", Port: 1, PortEnd: 65535},
+	}
+	for in, want := range cases {
+		got, err := parseEgressRule(in)
+		if err != nil {
+			t.Errorf("parseEgressRule(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseEgressRule(%q) = %+v, want %+v", in, got, want)
+		}
+		// The printed form parses back to the same rule.
+		if again, err := parseEgressRule(egressRuleSummary(got)); err != nil || again != got {
+			t.Errorf("summary %q did not round-trip: %+v, %v", egressRuleSummary(got), again, err)
+		}
+	}
+}
+
+func TestParseEgressRule_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"10.0.0.0/8":               "want",
+		"10.0.0.0/8 tcp 443":       "want",
+		"10.0.0.0/8 sctp/9":        "proto must be",
+		"10.0.0.0/8 tcp/0":         "bad port",
+		"10.0.0.0/8 tcp/70000":     "bad port",
+		"10.0.0.0/8 tcp/443-80":    "bad port range",
+		"10.0.0.0/8 tcp/443-https": "bad port range",
+	}
+	for in, want := range cases {
+		if _, err := parseEgressRule(in); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseEgressRule(%q) err = %v, want containing %q", in, err, want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// waf-rule subcommand flags
+var (
+	npWAFTarget   string
+	npWAFHeader   string
+	npWAFMatch    string
+	npWAFPattern  string
+	npWAFAction   string
+	npWAFTenants  []string
+	npWAFNote     string
+	npWAFDisabled bool
+	npWAFRPM      int32
+	npWAFBurst    int32
+)
+
+func init() {
+	// Tier 3 (#662) operator WAF rules.
+	networkPolicyCmd.AddCommand(networkPolicyWAFRuleCmd)
+	networkPolicyWAFRuleCmd.AddCommand(networkPolicyWAFRuleAddCmd, networkPolicyWAFRuleRmCmd, networkPolicyWAFRuleListCmd)
+	f := networkPolicyWAFRuleAddCmd.Flags()
+	f.StringVar(&npWAFTarget, "target", "", "Request part to match: method | path | query | header | body (required unless a rate_limit rule counts every request)")
+	f.StringVar(&npWAFHeader, "header", "", "Header name, with --target header")
+	f.StringVar(&npWAFMatch, "match", "literal", "literal (case-insensitive substring) | regex (RE2)")
+	f.StringVar(&npWAFPattern, "pattern", "", "Literal or regular expression to match (required)")
+	f.StringVar(&npWAFAction, "action", "block", "block (403 when enforcement is armed) | log (audit only) | rate_limit (429 past --rpm per client IP)")
+	f.Int32Var(&npWAFRPM, "rpm", 0, "Requests per minute per client source IP, with --action rate_limit")
+	f.Int32Var(&npWAFBurst, "burst", 0, "Requests a client may send at once, with --action rate_limit (default --rpm)")
+	f.StringSliceVar(&npWAFTenants, "tenant", nil, "Enable only for this tenant's containers (repeatable; default every tenant)")
+	f.StringVar(&npWAFNote, "note", "", "Operator note, typically the CVE id this virtual-patches")
+	f.BoolVar(&npWAFDisabled, "disabled", false, "Store the rule but don't load it into the WAF")
+	f.BoolVar(&npJSONOut, "json", false, "Output the stored rule as JSON")
+	networkPolicyWAFRuleListCmd.Flags().BoolVar(&npJSONOut, "json", false, "Output as JSON")
+}
+
+var networkPolicyWAFRuleCmd = &cobra.Command{
+	Use:     "waf-rule",
+	Aliases: []string{"waf"},
+	Short:   "Manage operator rules for the userspace WAF (#662)",
+	Long: `Manage operator rules for the Tier 3 userspace WAF — regex or literal
+matchers on a steered HTTP request's method, path, query, one header or body,
+applied after URL/percent/unicode normalization. A rule blocks (403, when
+enforcement is armed), only logs, or rate-limits: a rate_limit rule counts the
+requests it matches per client source IP and refuses (429) those past --rpm.
+Each match is audited and published on the event bus. Rules are global unless
+--tenant limits them.
+
+Every request on a keep-alive connection is inspected, including up to
+CONTAINARIUM_WAF_MAX_BODY_BYTES (default 64KiB) of its body.
+
+Rules take effect only where WAF inspection is on
+(CONTAINARIUM_WAF_TPROXY_ADDR + CONTAINARIUM_WAF_INSPECT=1), and are reloaded
+into the running proxy on every edit without dropping connections.`,
+}
+
+var networkPolicyWAFRuleAddCmd = &cobra.Command{
+	Use:   "add <name> [--target <part> --pattern <pattern>] [--action rate_limit --rpm <n>]",
+	Short: "Add or update a WAF rule (upsert by name)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNetworkPolicyWAFRuleAdd,
+}
+
+var networkPolicyWAFRuleRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"delete"},
+	Short:   "Remove a WAF rule",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runNetworkPolicyWAFRuleRm,
+}
+
+var networkPolicyWAFRuleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List WAF rules",
+	Args:  cobra.NoArgs,
+	RunE:  runNetworkPolicyWAFRuleList,
+}
+
+// wafRuleJSON mirrors the WAFRule wire shape (grpc-gateway camelCase).
+type wafRuleJSON struct {
+	Name    string   `json:"name"`
+	Target  string   `json:"target"`
+	Header  string   `json:"header,omitempty"`
+	Match   string   `json:"match"`
+	Pattern string   `json:"pattern"`
+	Action  string   `json:"action"`
+	Tenants []string `json:"tenants,omitempty"`
+	Enabled bool     `json:"enabled"`
+	ID      uint32   `json:"id"`
+	Note    string   `json:"note,omitempty"`
+	RPM     int32    `json:"requestsPerMinute,omitempty"`
+	Burst   int32    `json:"burst,omitempty"`
+}
+
+type wafRuleEnvelope struct {
+	Rule wafRuleJSON `json:"rule"`
+}
+type wafRulesEnvelope struct {
+	Rules []wafRuleJSON `json:"rules"`
+}
+
+func runNetworkPolicyWAFRuleAdd(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	countAll := npWAFAction == "rate_limit" && strings.TrimSpace(npWAFTarget) == "" && npWAFPattern == ""
+	if strings.TrimSpace(npWAFTarget) == "" && !countAll {
+		return fmt.Errorf("--target is required")
+	}
+	if npWAFPattern == "" && !countAll {
+		return fmt.Errorf("--pattern is required")
+	}
+	body := wafRuleEnvelope{Rule: wafRuleJSON{
+		Name:    args[0],
+		Target:  npWAFTarget,
+		Header:  npWAFHeader,
+		Match:   npWAFMatch,
+		Pattern: npWAFPattern,
+		Action:  npWAFAction,
+		Tenants: npWAFTenants,
+		Enabled: !npWAFDisabled,
+		Note:    npWAFNote,
+		RPM:     npWAFRPM,
+		Burst:   npWAFBurst,
+	}}
+	var out wafRuleEnvelope
+	if err := doJSON("POST", strings.TrimSuffix(serverAddr, "/")+"/v1/waf-rules", body, &out); err != nil {
+		return err
+	}
+	if npJSONOut {
+		return printJSON(out.Rule)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ waf rule %q set (id %d)\n", out.Rule.Name, out.Rule.ID)
+	return nil
+}
+
+func runNetworkPolicyWAFRuleRm(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	u := strings.TrimSuffix(serverAddr, "/") + "/v1/waf-rules/" + url.PathEscape(args[0])
+	if err := doJSON("DELETE", u, nil, nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ waf rule %q removed\n", args[0])
+	return nil
+}
+
+func runNetworkPolicyWAFRuleList(cmd *cobra.Command, args []string) error {
+	if serverAddr == "" {
+		return errServerRequired()
+	}
+	var out wafRulesEnvelope
+	if err := getJSON(strings.TrimSuffix(serverAddr, "/")+"/v1/waf-rules", &out); err != nil {
+		return err
+	}
+	if npJSONOut {
+		return printJSON(out.Rules)
+	}
+	w := cmd.OutOrStdout()
+	if len(out.Rules) == 0 {
+		fmt.Fprintln(w, "No WAF rules (built-in signatures are always active when inspection is enabled).")
+		return nil
+	}
+	fmt.Fprintf(w, "%-20s %-6s %-8s %-14s %-18s %-8s %-24s %s\n", "NAME", "ID", "ENABLED", "ACTION", "TARGET", "MATCH", "PATTERN", "TENANTS")
+	for _, r := range out.Rules {
+		target := r.Target
+		if r.Header != "" {
+			target += ":" + r.Header
+		}
+		if target == "" {
+			target = "*"
+		}
+		action := r.Action
+		if r.RPM > 0 {
+			burst := r.Burst
+			if burst == 0 {
+				burst = r.RPM
+			}
+			action = fmt.Sprintf("%s %d/m:%d", action, r.RPM, burst)
+		}
+		tenants := "*"
+		if len(r.Tenants) > 0 {
+			tenants = strings.Join(r.Tenants, ",")
+		}
+		fmt.Fprintf(w, "%-20s %-6d %-8v %-14s %-18s %-8s %-24q %s\n", r.Name, r.ID, r.Enabled, action, target, r.Match, r.Pattern, tenants)
+	}
+	return nil
+}
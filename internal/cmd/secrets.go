@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/footprintai/containarium/internal/client"
@@ -96,6 +97,40 @@ without restarting the box.`,
 	RunE: runSecretsRefresh,
 }
 
+var secretsVersionsCmd = &cobra.Command{
+	Use:   "versions <username> <NAME>",
+	Short: "List a secret's retained versions (metadata only)",
+	Long: `Lists the versions of a secret the daemon still holds, newest first,
+with when each was written and its delivery mode. The current version
+is marked with *. The daemon keeps the newest 10 versions of each
+secret, the current one included; deleting a secret deletes them all.
+
+Values are never shown — 'secrets rollback' is the way back to one.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSecretsVersions,
+}
+
+var secretsRollbackCmd = &cobra.Command{
+	Use:   "rollback <username> <NAME> <version>",
+	Short: "Make an earlier version of a secret current again",
+	Long: `Restores a retained version's value and delivery mode. It is written
+as a new version, so the value it replaces stays in 'secrets versions'
+and the rollback can itself be rolled back.
+
+Like 'secrets set', this changes the store only. Pass --refresh (or run
+'secrets refresh' afterwards) to deliver the restored value to the box
+without restarting it.
+
+Examples:
+  containarium secrets versions alice OPENAI_API_KEY
+  containarium secrets rollback alice OPENAI_API_KEY 3 --refresh`,
+	Args: cobra.ExactArgs(3),
+	RunE: runSecretsRollback,
+}
+
+// secretsRollbackRefresh is bound to `--refresh` on `secrets rollback`.
+var secretsRollbackRefresh bool
+
 // secretsDelivery is the value bound to `--delivery` on
 // `secrets set` (Phase 4.3 Phase A). Allowed values: "",
 // "env" (default; server normalizes ""→"env"), "file"
@@ -116,6 +151,10 @@ func init() {
 	secretsCmd.AddCommand(secretsListCmd)
 	secretsCmd.AddCommand(secretsDeleteCmd)
 	secretsCmd.AddCommand(secretsRefreshCmd)
+	secretsCmd.AddCommand(secretsVersionsCmd)
+	secretsCmd.AddCommand(secretsRollbackCmd)
+	secretsRollbackCmd.Flags().BoolVar(&secretsRollbackRefresh, "refresh", false,
+		"deliver the restored value to the box right away, as 'secrets refresh' does")
 }
 
 func runSecretsSet(cmd *cobra.Command, args []string) error {
@@ -297,6 +336,110 @@ func runSecretsRefresh(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSecretsVersions(cmd *cobra.Command, args []string) error {
+	username, name := args[0], args[1]
+	if serverAddr == "" {
+		return fmt.Errorf("--server is required for secrets commands")
+	}
+
+	var versions []*pb.SecretVersion
+	if httpMode {
+		h, err := client.NewHTTPClient(serverAddr, authToken)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = h.Close() }()
+		if versions, err = h.ListSecretVersions(username, name); err != nil {
+			return err
+		}
+	} else {
+		g, err := client.NewGRPCClient(serverAddr, certsDir, insecure)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = g.Close() }()
+		if versions, err = g.ListSecretVersions(username, name); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("  %-8s %-8s %s\n", "VERSION", "DELIVERY", "WRITTEN")
+	for _, v := range versions {
+		mark := " "
+		if v.GetCurrent() {
+			mark = "*"
+		}
+		fmt.Printf("%s %-8d %-8s %s\n", mark, v.GetVersion(), secretDeliveryLabel(v.GetDeliveryMode()),
+			strings.TrimSuffix(v.GetCreatedAt(), "Z"))
+	}
+	return nil
+}
+
+func runSecretsRollback(cmd *cobra.Command, args []string) error {
+	username, name := args[0], args[1]
+	v, err := strconv.ParseInt(args[2], 10, 32)
+	if err != nil || v < 1 {
+		return fmt.Errorf("version must be a positive number, as 'secrets versions' lists it; got %q", args[2])
+	}
+	version := int32(v) // #nosec G115 -- ParseInt with bitSize 32 bounds it
+	if serverAddr == "" {
+		return fmt.Errorf("--server is required for secrets commands")
+	}
+
+	var meta *pb.SecretMetadata
+	var msg, refreshMsg string
+	var stamped int32
+	var refreshErr error
+
+	if httpMode {
+		h, herr := client.NewHTTPClient(serverAddr, authToken)
+		if herr != nil {
+			return herr
+		}
+		defer func() { _ = h.Close() }()
+		if meta, msg, err = h.RollbackSecret(username, name, version); err != nil {
+			return err
+		}
+		if secretsRollbackRefresh {
+			refreshMsg, stamped, refreshErr = h.RefreshSecrets(username)
+		}
+	} else {
+		g, gerr := client.NewGRPCClient(serverAddr, certsDir, insecure)
+		if gerr != nil {
+			return gerr
+		}
+		defer func() { _ = g.Close() }()
+		if meta, msg, err = g.RollbackSecret(username, name, version); err != nil {
+			return err
+		}
+		if secretsRollbackRefresh {
+			refreshMsg, stamped, refreshErr = g.RefreshSecrets(username)
+		}
+	}
+
+	fmt.Printf("✓ %s%s\n", msg, rollbackDeliverySuffix(meta))
+	if !secretsRollbackRefresh {
+		fmt.Printf("  Run 'containarium secrets refresh %s' to deliver it to the box.\n", username)
+		return nil
+	}
+	if refreshErr != nil {
+		// The rollback is committed; only delivery failed, and a
+		// plain refresh retries it.
+		return fmt.Errorf("rolled back, but delivering it failed (retry with 'secrets refresh'): %w", refreshErr)
+	}
+	fmt.Printf("✓ %s (stamped=%d)\n", refreshMsg, stamped)
+	return nil
+}
+
+// rollbackDeliverySuffix names a non-default delivery mode, as `secrets
+// set` does — a rollback can change it.
+func rollbackDeliverySuffix(meta *pb.SecretMetadata) string {
+	if label := secretDeliveryLabel(meta.GetDeliveryMode()); label != "" && label != "env" {
+		return fmt.Sprintf(" (delivery=%s)", label)
+	}
+	return ""
+}
+
 // secretDeliveryLabel renders the delivery enum as the short operator-facing
 // word ("env" / "file" / "compose") rather than its proto name. Mirrors
 // destLabel in backup.go.
@@ -42,7 +42,8 @@ var secretsMigrateCmd = &cobra.Command{
 	Long: `Rewrite pre-Phase-4.1 secrets rows so they use the envelope
 encryption path. The daemon already writes new secrets through the
 envelope path when KMS is configured; this tool migrates the rows
-that were written before KMS was enabled.
+that were written before KMS was enabled, including the retained
+prior versions that 'secrets rollback' reads.
 
 Properties:
   • Idempotent — already-envelope rows are skipped.
@@ -74,7 +75,8 @@ re-wrap into.`,
 var secretsCoverageCmd = &cobra.Command{
 	Use:   "envelope-coverage",
 	Short: "Report how many secrets rows are envelope vs legacy (Phase 4.1)",
-	Long: `Counts the rows in the secrets table by encryption mode. Use to
+	Long: `Counts the rows in the secrets table, and the retained prior
+versions in secret_versions, by encryption mode. Use to
 confirm migration progress and to decide when it's safe to retire
 the master key (Phase E — operator-driven).
 
@@ -122,7 +124,11 @@ func runSecretsMigrate(cmd *cobra.Command, args []string) error {
 	if len(res.Errors) > 0 {
 		fmt.Fprintf(os.Stderr, "\nFailed rows:\n")
 		for _, e := range res.Errors {
-			fmt.Fprintf(os.Stderr, "  %s/%s — %s\n", e.Username, e.Name, e.Err)
+			name := e.Name
+			if e.Version != 0 {
+				name = fmt.Sprintf("%s@v%d", e.Name, e.Version) // a retained prior version
+			}
+			fmt.Fprintf(os.Stderr, "  %s/%s — %s\n", e.Username, name, e.Err)
 		}
 		// Non-zero exit so wrapper scripts notice.
 		return fmt.Errorf("%d rows failed migration", res.Failed)
@@ -12,8 +12,8 @@ import (
 //
 // A ZFS snapshot of a container's dataset: instant, space-efficient, and
 // takeable while the container runs. It is NOT a backup — it shares blocks
-// with the live dataset and dies with the pool. `containarium backup` is the
-// off-host one; this is the cheap rollback point you take before an upgrade.
+// with the live dataset and dies with the pool — until `snapshot export`
+// copies it off the host.
 
 var snapshotCmd = &cobra.Command{
 	Use:   "snapshot",
@@ -26,17 +26,24 @@ before an upgrade or a risky change; it is the cheapest rollback point
 available.
 
 A snapshot is NOT a backup. It lives in the same pool as the data it
-snapshots, so it survives a bad "rm -rf" and not a lost disk. For off-host
-copies see ` + "`containarium backup`" + `.
+snapshots, so it survives a bad "rm -rf" and not a lost disk — until
+` + "`snapshot export`" + ` copies it off the host.
 
 Snapshots work on encrypted containers with the key unloaded: ZFS allows it,
 and refusing would mean a key-custody outage silently stopped your backup
 window. Reading a snapshot back does need the key.
 
+To recover a single file, browse the snapshot instead of rolling back:
+` + "`snapshot ls`" + `, ` + "`snapshot cat`" + ` and ` + "`snapshot diff`" + ` read it in place.
+
   containarium snapshot create alice --name before-upgrade --server <host>
   containarium snapshot list alice --server <host>
   containarium snapshot delete alice before-upgrade --server <host>
-  containarium snapshot rollback alice before-upgrade --force --server <host>`,
+  containarium snapshot diff alice before-upgrade --server <host>
+  containarium snapshot cat alice before-upgrade /etc/hosts --server <host>
+  containarium snapshot rollback alice before-upgrade --force --server <host>
+  containarium snapshot schedule set alice --every 1h --keep-last 24 --server <host>
+  containarium snapshot export alice before-upgrade --to s3://backups/snapshots --server <host>`,
 }
 
 func init() {
@@ -51,6 +58,15 @@ type snapshotAPI interface {
 	ListContainerSnapshots(req *pb.ListContainerSnapshotsRequest) (*pb.ListContainerSnapshotsResponse, error)
 	DeleteContainerSnapshot(req *pb.DeleteContainerSnapshotRequest) (*pb.DeleteContainerSnapshotResponse, error)
 	RollbackContainerSnapshot(req *pb.RollbackContainerSnapshotRequest) (*pb.RollbackContainerSnapshotResponse, error)
+	CloneContainer(req *pb.CloneContainerRequest) (*pb.CloneContainerResponse, error)
+	SetContainerSnapshotPolicy(req *pb.SetContainerSnapshotPolicyRequest) (*pb.SetContainerSnapshotPolicyResponse, error)
+	GetContainerSnapshotPolicy(req *pb.GetContainerSnapshotPolicyRequest) (*pb.GetContainerSnapshotPolicyResponse, error)
+	DeleteContainerSnapshotPolicy(req *pb.DeleteContainerSnapshotPolicyRequest) (*pb.DeleteContainerSnapshotPolicyResponse, error)
+	ExportContainerSnapshot(req *pb.ExportContainerSnapshotRequest) (*pb.ExportContainerSnapshotResponse, error)
+	ImportContainerSnapshot(req *pb.ImportContainerSnapshotRequest) (*pb.ImportContainerSnapshotResponse, error)
+	ListContainerSnapshotFiles(req *pb.ListContainerSnapshotFilesRequest) (*pb.ListContainerSnapshotFilesResponse, error)
+	ReadContainerSnapshotFile(req *pb.ReadContainerSnapshotFileRequest) (*pb.ReadContainerSnapshotFileResponse, error)
+	DiffContainerSnapshots(req *pb.DiffContainerSnapshotsRequest) (*pb.DiffContainerSnapshotsResponse, error)
 	Close() error
 }
 
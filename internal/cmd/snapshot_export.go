@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+// Off-host snapshot export and restore (#1160d): the step that makes a
+// snapshot a backup.
+
+var (
+	snapshotExportTo       string
+	snapshotExportFull     bool
+	snapshotImportFrom     string
+	snapshotImportSource   string
+	snapshotImportSnapshot string
+	snapshotImportBackend  string
+	snapshotImportSSHKeys  []string
+)
+
+var snapshotExportCmd = &cobra.Command{
+	Use:   "export <username> <snapshot>",
+	Short: "Copy a snapshot off the host as a raw ZFS stream",
+	Long: `Copy a container snapshot to an object store or a mounted directory, so it
+survives losing the host.
+
+The snapshot is sent as a raw ZFS stream: an encrypted container's export
+is ciphertext under its tenant's key, and the target never holds the key.
+Each export to a target after the first sends only what changed since the
+previous one, as long as that snapshot still exists; --full sends
+everything, so a restore needs nothing exported before it.
+
+Targets are s3://bucket/prefix and gs://bucket/prefix (with the same
+credentials database backups use) or file:///path — admins only, under the
+directory the daemon's CONTAINARIUM_SNAPSHOT_EXPORT_FILE_ROOT names.
+
+  containarium snapshot export alice nightly --to s3://backups/snapshots --server <host>
+  containarium snapshot export alice weekly --to file:///mnt/nas/snapshots --full --server <host>`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSnapshotExport,
+}
+
+var snapshotImportCmd = &cobra.Command{
+	Use:   "import <username>",
+	Short: "Create a container from exported snapshots",
+	Long: `Create a container from snapshots exported with ` + "`snapshot export`" + `, on this
+daemon or — with --backend — any backend in the pool, whether or not the
+host it was exported from still exists.
+
+The container gets the exported one's files, limits and devices, and its
+own IP and secrets. Without --source the chain exported for <username> is
+restored under the same name; with it, the exported user is renamed to
+<username>. Without --snapshot the newest export is restored. An encrypted
+export needs its tenant's key from key custody, as starting the original
+container would.
+
+  containarium snapshot import alice --from s3://backups/snapshots --server <host>
+  containarium snapshot import alice-restored --source alice --snapshot nightly \
+    --from s3://backups/snapshots --backend host-b --server <host>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotImport,
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotExportCmd, snapshotImportCmd)
+
+	f := snapshotExportCmd.Flags()
+	f.StringVar(&snapshotExportTo, "to", "", "target URI: s3://bucket/prefix, gs://bucket/prefix or file:///path (required)")
+	f.BoolVar(&snapshotExportFull, "full", false, "send the whole snapshot even when an incremental send is possible")
+	_ = snapshotExportCmd.MarkFlagRequired("to")
+
+	f = snapshotImportCmd.Flags()
+	f.StringVar(&snapshotImportFrom, "from", "", "the target URI the snapshots were exported to (required)")
+	f.StringVar(&snapshotImportSource, "source", "", "the exported container's username (default: <username>)")
+	f.StringVar(&snapshotImportSnapshot, "snapshot", "", "the exported snapshot to restore (default: the newest)")
+	f.StringVar(&snapshotImportBackend, "backend", "", "the backend to restore onto (default: the daemon you are talking to)")
+	f.StringArrayVar(&snapshotImportSSHKeys, "ssh-key", nil,
+		"path to an SSH public key file for the container (repeatable); default keeps the snapshot's keys")
+	_ = snapshotImportCmd.MarkFlagRequired("from")
+}
+
+func runSnapshotExport(cmd *cobra.Command, args []string) error {
+	c, err := newSnapshotClientFn()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.ExportContainerSnapshot(&pb.ExportContainerSnapshotRequest{
+		Username: args[0],
+		Snapshot: args[1],
+		Target:   snapshotExportTo,
+		Full:     snapshotExportFull,
+	})
+	if err != nil {
+		return err
+	}
+
+	e := resp.GetExport()
+	kind := "full"
+	if e.GetBaseSnapshot() != "" {
+		kind = "incremental from " + e.GetBaseSnapshot()
+	}
+	fmt.Printf("✅ Exported %s@%s (%s, %s)\n", args[0], args[1], kind, humanBytes(e.GetSizeBytes()))
+	fmt.Printf("   Object: %s\n", e.GetObject())
+	fmt.Printf("   Chain:  %d export(s) at %s\n", len(resp.GetChain()), snapshotExportTo)
+	return nil
+}
+
+func runSnapshotImport(cmd *cobra.Command, args []string) error {
+	var keys []string
+	for _, path := range snapshotImportSSHKeys {
+		// #nosec G304 -- operator-supplied --ssh-key path, as on create.
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read SSH key file %q: %w", path, err)
+		}
+		keys = append(keys, strings.TrimSpace(string(b)))
+	}
+
+	c, err := newSnapshotClientFn()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.ImportContainerSnapshot(&pb.ImportContainerSnapshotRequest{
+		Username:       args[0],
+		Source:         snapshotImportFrom,
+		SourceUsername: snapshotImportSource,
+		Snapshot:       snapshotImportSnapshot,
+		BackendId:      snapshotImportBackend,
+		SshKeys:        keys,
+	})
+	if err != nil {
+		return err
+	}
+
+	source := snapshotImportSource
+	if source == "" {
+		source = args[0]
+	}
+	fmt.Printf("✅ Restored %s from %s@%s\n", resp.GetContainer().GetName(), source, resp.GetSnapshot())
+	if b := resp.GetContainer().GetBackendId(); b != "" {
+		fmt.Printf("   Backend: %s\n", b)
+	}
+	if ip := resp.GetContainer().GetNetwork().GetIpAddress(); ip != "" {
+		fmt.Printf("   IP: %s\n", ip)
+	}
+	if resp.GetSshCommand() != "" {
+		fmt.Printf("   SSH: %s\n", resp.GetSshCommand())
+	}
+	return nil
+}
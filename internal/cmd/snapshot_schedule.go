@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotScheduleEvery       time.Duration
+	snapshotScheduleCron        string
+	snapshotScheduleKeepLast    int32
+	snapshotScheduleKeepDaily   int32
+	snapshotScheduleKeepWeekly  int32
+	snapshotSchedulePreCommand  string
+	snapshotSchedulePostCommand string
+	snapshotScheduleFreeze      bool
+)
+
+var snapshotScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Take a container's snapshots on a schedule, with retention",
+	Long: `Manage a container's snapshot schedule.
+
+The daemon takes a snapshot named auto-<UTC timestamp> on the schedule and
+prunes the scheduled snapshots the retention no longer keeps. Snapshots you
+take by hand are never counted or pruned.
+
+  containarium snapshot schedule set alice --every 1h --keep-last 24 --keep-daily 7 --server <host>
+  containarium snapshot schedule get alice --server <host>
+  containarium snapshot schedule delete alice --server <host>`,
+}
+
+var snapshotScheduleSetCmd = &cobra.Command{
+	Use:   "set <username>",
+	Short: "Create or replace a container's snapshot schedule",
+	Long: `Create or replace a container's snapshot schedule.
+
+Exactly one of --every and --cron, and at least one --keep-* count. The first
+snapshot is taken one interval (or cron slot) after the schedule is set.
+
+Quiescing a running box before the snapshot:
+
+  --pre-command   shell command run in the box first; the snapshot is
+                  skipped if it fails (e.g. "fsfreeze -f /data" or a
+                  database checkpoint)
+  --post-command  shell command run in the box afterwards — always, even
+                  when --pre-command failed, so it can undo it
+  --freeze        pause the box's processes for the instant of the snapshot
+
+Each command has five minutes to finish; one still running then has failed.
+
+  containarium snapshot schedule set alice --cron "0 */6 * * *" --keep-daily 7 --keep-weekly 4 \
+    --pre-command "fsfreeze -f /data" --post-command "fsfreeze -u /data" --server <host>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotScheduleSet,
+}
+
+var snapshotScheduleGetCmd = &cobra.Command{
+	Use:   "get <username>",
+	Short: "Show a container's snapshot schedule and when it next runs",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotScheduleGet,
+}
+
+var snapshotScheduleDeleteCmd = &cobra.Command{
+	Use:   "delete <username>",
+	Short: "Stop a container's scheduled snapshots (the ones taken are kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotScheduleDelete,
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotScheduleCmd)
+	snapshotScheduleCmd.AddCommand(snapshotScheduleSetCmd, snapshotScheduleGetCmd, snapshotScheduleDeleteCmd)
+	f := snapshotScheduleSetCmd.Flags()
+	f.DurationVar(&snapshotScheduleEvery, "every", 0, "take a snapshot at this interval (e.g. 1h; minimum 5m)")
+	f.StringVar(&snapshotScheduleCron, "cron", "", "take a snapshot on this 5-field cron expression (UTC)")
+	f.Int32Var(&snapshotScheduleKeepLast, "keep-last", 0, "keep the newest N scheduled snapshots")
+	f.Int32Var(&snapshotScheduleKeepDaily, "keep-daily", 0, "keep the newest scheduled snapshot of each of the last N days")
+	f.Int32Var(&snapshotScheduleKeepWeekly, "keep-weekly", 0, "keep the newest scheduled snapshot of each of the last N weeks")
+	f.StringVar(&snapshotSchedulePreCommand, "pre-command", "", "shell command run in the box before each snapshot")
+	f.StringVar(&snapshotSchedulePostCommand, "post-command", "", "shell command run in the box after each snapshot")
+	f.BoolVar(&snapshotScheduleFreeze, "freeze", false, "pause the box's processes while the snapshot is taken")
+}
+
+func runSnapshotScheduleSet(cmd *cobra.Command, args []string) error {
+	if (snapshotScheduleEvery == 0) == (snapshotScheduleCron == "") {
+		return fmt.Errorf("exactly one of --every and --cron is required")
+	}
+	c, err := newSnapshotClientFn()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.SetContainerSnapshotPolicy(&pb.SetContainerSnapshotPolicyRequest{
+		Username: args[0],
+		Policy: &pb.ContainerSnapshotPolicy{
+			IntervalSeconds: int64(snapshotScheduleEvery / time.Second),
+			Cron:            snapshotScheduleCron,
+			KeepLast:        snapshotScheduleKeepLast,
+			KeepDaily:       snapshotScheduleKeepDaily,
+			KeepWeekly:      snapshotScheduleKeepWeekly,
+			PreCommand:      snapshotSchedulePreCommand,
+			PostCommand:     snapshotSchedulePostCommand,
+			Freeze:          snapshotScheduleFreeze,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Snapshot schedule set for %s\n", args[0])
+	printSnapshotPolicy(resp.GetPolicy())
+	return nil
+}
+
+func runSnapshotScheduleGet(cmd *cobra.Command, args []string) error {
+	c, err := newSnapshotClientFn()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.GetContainerSnapshotPolicy(&pb.GetContainerSnapshotPolicyRequest{Username: args[0]})
+	if err != nil {
+		return err
+	}
+	printSnapshotPolicy(resp.GetPolicy())
+	return nil
+}
+
+func runSnapshotScheduleDelete(cmd *cobra.Command, args []string) error {
+	c, err := newSnapshotClientFn()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	resp, err := c.DeleteContainerSnapshotPolicy(&pb.DeleteContainerSnapshotPolicyRequest{Username: args[0]})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s\n", resp.GetMessage())
+	return nil
+}
+
+func printSnapshotPolicy(p *pb.ContainerSnapshotPolicy) {
+	if p.GetCron() != "" {
+		fmt.Printf("   Schedule:  cron %q (UTC)\n", p.GetCron())
+	} else {
+		fmt.Printf("   Schedule:  every %s\n", time.Duration(p.GetIntervalSeconds())*time.Second)
+	}
+	fmt.Printf("   Retention: last %d, daily %d, weekly %d\n",
+		p.GetKeepLast(), p.GetKeepDaily(), p.GetKeepWeekly())
+	if p.GetPreCommand() != "" {
+		fmt.Printf("   Before:    %s\n", p.GetPreCommand())
+	}
+	if p.GetFreeze() {
+		fmt.Println("   Freeze:    yes")
+	}
+	if p.GetPostCommand() != "" {
+		fmt.Printf("   After:     %s\n", p.GetPostCommand())
+	}
+	if p.GetNextRunAt() != "" {
+		fmt.Printf("   Next run:  %s\n", p.GetNextRunAt())
+	}
+}
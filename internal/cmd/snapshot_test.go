@@ -5,8 +5,10 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
@@ -59,6 +61,15 @@ type fakeSnapshotAPI struct {
 	listResp     *pb.ListContainerSnapshotsResponse
 	rolledBack   *pb.RollbackContainerSnapshotRequest
 	rollbackResp *pb.RollbackContainerSnapshotResponse
+	cloned       *pb.CloneContainerRequest
+	policySet    *pb.SetContainerSnapshotPolicyRequest
+	exported     *pb.ExportContainerSnapshotRequest
+	imported     *pb.ImportContainerSnapshotRequest
+	listedFiles  *pb.ListContainerSnapshotFilesRequest
+	fileContent  string
+	reads        []*pb.ReadContainerSnapshotFileRequest
+	diffed       *pb.DiffContainerSnapshotsRequest
+	diffResp     *pb.DiffContainerSnapshotsResponse
 	err          error
 }
 
@@ -95,6 +106,94 @@ func (f *fakeSnapshotAPI) RollbackContainerSnapshot(req *pb.RollbackContainerSna
 	return f.rollbackResp, nil
 }
 
+func (f *fakeSnapshotAPI) CloneContainer(req *pb.CloneContainerRequest) (*pb.CloneContainerResponse, error) {
+	f.cloned = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.CloneContainerResponse{
+		Container:  &pb.Container{Name: req.GetNewUsername() + "-container"},
+		SshCommand: "ssh " + req.GetNewUsername() + "@host",
+	}, nil
+}
+
+func (f *fakeSnapshotAPI) SetContainerSnapshotPolicy(req *pb.SetContainerSnapshotPolicyRequest) (*pb.SetContainerSnapshotPolicyResponse, error) {
+	f.policySet = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.SetContainerSnapshotPolicyResponse{Policy: req.GetPolicy()}, nil
+}
+
+func (f *fakeSnapshotAPI) GetContainerSnapshotPolicy(*pb.GetContainerSnapshotPolicyRequest) (*pb.GetContainerSnapshotPolicyResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.GetContainerSnapshotPolicyResponse{Policy: &pb.ContainerSnapshotPolicy{}}, nil
+}
+
+func (f *fakeSnapshotAPI) DeleteContainerSnapshotPolicy(*pb.DeleteContainerSnapshotPolicyRequest) (*pb.DeleteContainerSnapshotPolicyResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.DeleteContainerSnapshotPolicyResponse{}, nil
+}
+
+func (f *fakeSnapshotAPI) ExportContainerSnapshot(req *pb.ExportContainerSnapshotRequest) (*pb.ExportContainerSnapshotResponse, error) {
+	f.exported = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	e := &pb.SnapshotExport{Snapshot: req.GetSnapshot(), BaseSnapshot: "prev", SizeBytes: 2048, Object: req.GetTarget() + "/x.zfs"}
+	return &pb.ExportContainerSnapshotResponse{Export: e, Chain: []*pb.SnapshotExport{{Snapshot: "prev"}, e}}, nil
+}
+
+func (f *fakeSnapshotAPI) ImportContainerSnapshot(req *pb.ImportContainerSnapshotRequest) (*pb.ImportContainerSnapshotResponse, error) {
+	f.imported = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.ImportContainerSnapshotResponse{
+		Container: &pb.Container{Name: req.GetUsername() + "-container", BackendId: req.GetBackendId()},
+		Snapshot:  "nightly",
+	}, nil
+}
+
+func (f *fakeSnapshotAPI) ListContainerSnapshotFiles(req *pb.ListContainerSnapshotFilesRequest) (*pb.ListContainerSnapshotFilesResponse, error) {
+	f.listedFiles = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.ListContainerSnapshotFilesResponse{Path: "/home/alice", Entries: []*pb.SnapshotFileEntry{
+		{Name: "notes.txt", Type: pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_FILE, SizeBytes: 17, Mode: 0o640},
+		{Name: "projects", Type: pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_DIRECTORY, Mode: 0o755},
+		{Name: "latest", Type: pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_SYMLINK, LinkTarget: "projects/x"},
+	}}, nil
+}
+
+// ReadContainerSnapshotFile serves fileContent four bytes at a time, so a
+// caller that stops after one range is caught.
+func (f *fakeSnapshotAPI) ReadContainerSnapshotFile(req *pb.ReadContainerSnapshotFileRequest) (*pb.ReadContainerSnapshotFileResponse, error) {
+	f.reads = append(f.reads, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	size := int64(len(f.fileContent))
+	start := min(req.GetOffset(), size)
+	end := min(start+4, size)
+	return &pb.ReadContainerSnapshotFileResponse{
+		Content: []byte(f.fileContent[start:end]), Offset: start, SizeBytes: size, Eof: end == size,
+	}, nil
+}
+
+func (f *fakeSnapshotAPI) DiffContainerSnapshots(req *pb.DiffContainerSnapshotsRequest) (*pb.DiffContainerSnapshotsResponse, error) {
+	f.diffed = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.diffResp, nil
+}
+
 func (f *fakeSnapshotAPI) Close() error { return nil }
 
 func withSnapshotAPI(t *testing.T, api *fakeSnapshotAPI) {
@@ -310,3 +409,164 @@ func TestSnapshotRollback_PropagatesTheRefusal(t *testing.T) {
 			"restored")
 	}
 }
+
+// Schedule CLI. The interval travels as whole seconds and the hooks exactly
+// as typed: the daemon runs them as root in the box.
+func TestSnapshotScheduleSet_SendsThePolicy(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+	snapshotScheduleEvery, snapshotScheduleKeepLast = 90*time.Minute, 24
+	snapshotSchedulePreCommand, snapshotScheduleFreeze = "fsfreeze -f /data", true
+	t.Cleanup(func() {
+		snapshotScheduleEvery, snapshotScheduleKeepLast = 0, 0
+		snapshotSchedulePreCommand, snapshotScheduleFreeze = "", false
+	})
+
+	out := captureStdout(t, func() {
+		if err := runSnapshotScheduleSet(nil, []string{"alice"}); err != nil {
+			t.Fatalf("runSnapshotScheduleSet: %v", err)
+		}
+	})
+
+	p := api.policySet.GetPolicy()
+	if api.policySet.GetUsername() != "alice" || p.GetIntervalSeconds() != 5400 || p.GetKeepLast() != 24 ||
+		p.GetPreCommand() != "fsfreeze -f /data" || !p.GetFreeze() {
+		t.Fatalf("sent %+v", api.policySet)
+	}
+	if !strings.Contains(out, "every 1h30m0s") {
+		t.Errorf("output does not echo the schedule:\n%s", out)
+	}
+}
+
+func TestSnapshotScheduleSet_RequiresExactlyOneSchedule(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+	t.Cleanup(func() { snapshotScheduleEvery, snapshotScheduleCron = 0, "" })
+
+	for _, c := range []struct {
+		every time.Duration
+		cron  string
+	}{{0, ""}, {time.Hour, "@daily"}} {
+		snapshotScheduleEvery, snapshotScheduleCron = c.every, c.cron
+		if err := runSnapshotScheduleSet(nil, []string{"alice"}); err == nil {
+			t.Errorf("every=%s cron=%q was accepted", c.every, c.cron)
+		}
+	}
+	if api.policySet != nil {
+		t.Errorf("the daemon was called anyway: %+v", api.policySet)
+	}
+}
+
+func TestSnapshotExport_SendsTheTargetAndSaysWhatWasSent(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+	snapshotExportTo = "s3://backups/snaps"
+	t.Cleanup(func() { snapshotExportTo = "" })
+
+	out := captureStdout(t, func() {
+		if err := runSnapshotExport(nil, []string{"alice", "nightly"}); err != nil {
+			t.Fatalf("runSnapshotExport: %v", err)
+		}
+	})
+
+	if api.exported.GetUsername() != "alice" || api.exported.GetSnapshot() != "nightly" ||
+		api.exported.GetTarget() != "s3://backups/snaps" || api.exported.GetFull() {
+		t.Fatalf("sent %+v", api.exported)
+	}
+	if !strings.Contains(out, "incremental from prev") || !strings.Contains(out, "2 export(s)") {
+		t.Errorf("output does not say how it was sent:\n%s", out)
+	}
+}
+
+func TestSnapshotImport_SendsTheSourceAndBackend(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+	snapshotImportFrom, snapshotImportSource, snapshotImportBackend = "s3://backups/snaps", "alice", "host-b"
+	t.Cleanup(func() { snapshotImportFrom, snapshotImportSource, snapshotImportBackend = "", "", "" })
+
+	out := captureStdout(t, func() {
+		if err := runSnapshotImport(nil, []string{"alice-restored"}); err != nil {
+			t.Fatalf("runSnapshotImport: %v", err)
+		}
+	})
+
+	r := api.imported
+	if r.GetUsername() != "alice-restored" || r.GetSourceUsername() != "alice" ||
+		r.GetSource() != "s3://backups/snaps" || r.GetBackendId() != "host-b" {
+		t.Fatalf("sent %+v", r)
+	}
+	if !strings.Contains(out, "from alice@nightly") || !strings.Contains(out, "host-b") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestSnapshotLs_ShowsWhatEachEntryIs(t *testing.T) {
+	api := &fakeSnapshotAPI{}
+	withSnapshotAPI(t, api)
+
+	out := captureStdout(t, func() {
+		if err := runSnapshotLs(nil, []string{"alice", "nightly", "/home/alice"}); err != nil {
+			t.Fatalf("runSnapshotLs: %v", err)
+		}
+	})
+
+	if r := api.listedFiles; r.GetUsername() != "alice" || r.GetSnapshot() != "nightly" || r.GetPath() != "/home/alice" {
+		t.Fatalf("sent %+v", r)
+	}
+	for _, want := range []string{"0640", "notes.txt", "projects/", "latest -> projects/x"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// A file larger than one response must come back whole.
+func TestSnapshotCat_ReadsTheWholeFile(t *testing.T) {
+	api := &fakeSnapshotAPI{fileContent: "remember the milk"}
+	withSnapshotAPI(t, api)
+	snapshotCatOutput = filepath.Join(t.TempDir(), "notes.txt")
+	t.Cleanup(func() { snapshotCatOutput = "" })
+
+	out := captureStdout(t, func() {
+		if err := runSnapshotCat(nil, []string{"alice", "nightly", "/home/alice/notes.txt"}); err != nil {
+			t.Fatalf("runSnapshotCat: %v", err)
+		}
+	})
+
+	got, err := os.ReadFile(snapshotCatOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "remember the milk" {
+		t.Errorf("saved %q after %d reads", got, len(api.reads))
+	}
+	if !strings.Contains(out, "Saved /home/alice/notes.txt") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestSnapshotDiff_AgainstTheLiveBoxByDefault(t *testing.T) {
+	api := &fakeSnapshotAPI{diffResp: &pb.DiffContainerSnapshotsResponse{
+		Changes: []*pb.SnapshotFileChange{
+			{Path: "/etc/hosts", Kind: pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_MODIFIED, OldSizeBytes: 100, NewSizeBytes: 2048},
+			{Path: "/home/alice/notes.txt", Kind: pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_DELETED, OldSizeBytes: 17},
+		},
+		Modified: 1, Deleted: 1,
+	}}
+	withSnapshotAPI(t, api)
+
+	out := captureStdout(t, func() {
+		if err := runSnapshotDiff(nil, []string{"alice", "nightly"}); err != nil {
+			t.Fatalf("runSnapshotDiff: %v", err)
+		}
+	})
+
+	if api.diffed.GetFromSnapshot() != "nightly" || api.diffed.GetToSnapshot() != "" {
+		t.Fatalf("sent %+v", api.diffed)
+	}
+	for _, want := range []string{"M /etc/hosts", "- /home/alice/notes.txt", "0 added, 1 modified, 1 deleted between nightly and now"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q:\n%s", want, out)
+		}
+	}
+}
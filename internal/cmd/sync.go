@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/footprintai/containarium/internal/transfer"
 	"github.com/spf13/cobra"
@@ -16,6 +20,11 @@ var (
 	syncDelete       bool
 	syncExcludes     []string
 	syncVerbose      bool
+	syncWatch        bool
+	syncDebounce     time.Duration
+	syncFromBox      bool
+	syncPullForce    bool
+	syncGitIgnore    bool
 )
 
 var syncCmd = &cobra.Command{
@@ -31,9 +40,27 @@ This is the "make the remote look like local right now" mode. Use
 Excluded by default (substring match): node_modules/, .terraform/,
 __pycache__/, .pytest_cache/, .venv/, venv/, .DS_Store, .idea/, .vscode/.
 
+With --gitignore, exclusion follows git's rules instead: every .gitignore
+and .containariumignore in the tree is honored (a .containariumignore
+overrides .gitignore in the same directory, e.g. "!dist/" to ship a build
+output git ignores), and --exclude values are read as gitignore patterns.
+The same rules protect the container side: --delete never removes a file
+the rules ignore.
+
 By default, files that exist on the remote but not locally are LEFT in
 place. Pass --delete to remove them.
 
+With --watch, sync runs once and then keeps watching the local directory,
+shipping each burst of edits (debounced) over one persistent SSH session
+until interrupted. Each batch prints one summary line.
+
+With --from-box, the direction reverses: the container's directory is
+mirrored back into the local one. Pull is three-way against the state the
+last sync left behind, so a file edited both locally and in the box since
+then is reported as a conflict and left alone (--force takes the box's
+version). --delete removes local files the box has deleted since the last
+sync; files that never went through a sync are never deleted.
+
 Examples:
   # Mirror cwd to the container's default ~/work
   containarium sync demo-blog
@@ -45,7 +72,16 @@ Examples:
   containarium sync demo-blog /path/to/local --remote-path /srv/app
 
   # Add custom excludes on top of the defaults
-  containarium sync demo-blog --exclude target/ --exclude dist/`,
+  containarium sync demo-blog --exclude target/ --exclude dist/
+
+  # Respect the project's .gitignore
+  containarium sync demo-blog --gitignore --delete
+
+  # Keep the container in step with local edits until Ctrl-C
+  containarium sync demo-blog --watch --delete
+
+  # Bring an agent's edits in the box back to the laptop
+  containarium sync demo-blog --from-box`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runSync,
 }
@@ -56,8 +92,13 @@ func init() {
 	syncCmd.Flags().StringVar(&syncSentinelHost, "sentinel", "", "Sentinel SSH host (default: $CONTAINARIUM_SENTINEL_HOST)")
 	syncCmd.Flags().StringVar(&syncKeyPath, "key", "", "SSH key path (default: ~/.containarium/keys/<username>)")
 	syncCmd.Flags().BoolVar(&syncDelete, "delete", false, "Remove remote files that don't exist locally (rsync --delete)")
-	syncCmd.Flags().StringSliceVar(&syncExcludes, "exclude", nil, "Additional exclude patterns (substring match)")
+	syncCmd.Flags().StringSliceVar(&syncExcludes, "exclude", nil, "Additional exclude patterns (substring match; gitignore pattern with --gitignore)")
+	syncCmd.Flags().BoolVar(&syncGitIgnore, "gitignore", false, "Honor .gitignore/.containariumignore files with gitignore semantics")
 	syncCmd.Flags().BoolVarP(&syncVerbose, "verbose", "v", false, "Verbose progress on stderr")
+	syncCmd.Flags().BoolVarP(&syncWatch, "watch", "w", false, "Keep watching the local directory and ship changes as they happen")
+	syncCmd.Flags().DurationVar(&syncDebounce, "debounce", transfer.DefaultWatchDebounce, "Quiet period after the last change before a --watch batch ships")
+	syncCmd.Flags().BoolVar(&syncFromBox, "from-box", false, "Reverse direction: mirror the container's directory into the local one")
+	syncCmd.Flags().BoolVar(&syncPullForce, "force", false, "With --from-box, overwrite local files that conflict with the box's version")
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
@@ -68,10 +109,14 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply user --exclude on top of the defaults (don't replace).
-	excludes := append([]string{}, transfer.DefaultSyncExcludes...)
+	defaults := transfer.DefaultSyncExcludes
+	if syncGitIgnore {
+		defaults = transfer.DefaultSyncIgnores
+	}
+	excludes := append([]string{}, defaults...)
 	excludes = append(excludes, syncExcludes...)
 
-	res, err := transfer.Sync(transfer.SyncOptions{
+	opts := transfer.SyncOptions{
 		Options: transfer.Options{
 			Username:     syncUser,
 			SentinelHost: syncSentinelHost,
@@ -80,18 +125,54 @@ func runSync(cmd *cobra.Command, args []string) error {
 			RemotePath:   syncRemotePath,
 			Verbose:      syncVerbose,
 		},
-		Delete:   syncDelete,
-		Excludes: excludes,
-	})
+		Delete:    syncDelete,
+		Excludes:  excludes,
+		GitIgnore: syncGitIgnore,
+	}
+
+	if syncFromBox {
+		if syncWatch {
+			return fmt.Errorf("--watch and --from-box can't be combined")
+		}
+		res, err := transfer.Pull(transfer.PullOptions{SyncOptions: opts, Force: syncPullForce})
+		if err != nil {
+			return err
+		}
+		printSyncResult(res)
+		for _, p := range res.Conflicts {
+			fmt.Fprintf(os.Stderr, "conflict: %s (changed locally and in the box; rerun with --force to take the box's version)\n", p)
+		}
+		return nil
+	}
+
+	if syncWatch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return transfer.Watch(ctx, transfer.WatchOptions{
+			SyncOptions: opts,
+			Debounce:    syncDebounce,
+		}, printSyncResult)
+	}
+
+	res, err := transfer.Sync(opts)
 	if err != nil {
 		return err
 	}
+	printSyncResult(res)
+	return nil
+}
 
-	if res.Added == 0 && res.Modified == 0 && res.Deleted == 0 {
+// printSyncResult writes the one-line summary for a sync (or one --watch
+// batch) to stdout.
+func printSyncResult(res *transfer.SyncResult) {
+	if res.Added == 0 && res.Modified == 0 && res.Deleted == 0 && len(res.Conflicts) == 0 {
 		fmt.Fprintln(os.Stdout, "sync: no changes")
-		return nil
+		return
 	}
-	fmt.Fprintf(os.Stdout, "sync: +%d -%d ~%d files, %d bytes shipped\n",
+	fmt.Fprintf(os.Stdout, "sync: +%d -%d ~%d files, %d bytes shipped",
 		res.Added, res.Deleted, res.Modified, res.Bytes)
-	return nil
+	if len(res.Conflicts) > 0 {
+		fmt.Fprintf(os.Stdout, ", %d conflict(s)", len(res.Conflicts))
+	}
+	fmt.Fprintln(os.Stdout)
 }
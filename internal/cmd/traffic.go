@@ -23,6 +23,7 @@ import (
 //	GET /v1/containers/{name}/connections          → connections
 //	GET /v1/containers/{name}/connections/summary  → summary
 //	GET /v1/containers/{name}/traffic/history      → history
+//	GET /v1/traffic/http[?containerName=]          → http
 //
 // Server + token resolution mirrors the ssh/connect commands (pickSSHServer +
 // the bearer token auto-filled by root's PersistentPreRunE), so `traffic` works
@@ -46,6 +47,7 @@ Subcommands:
   connections <box>   active connections (source/dest IP, port, proto, bytes)
   summary <box>       per-box totals + top destinations
   history <box>       closed connections recorded in the traffic history
+  http [box]          HTTP request rate, 5xx ratio and latency (edge access log)
 
 Reads the platform daemon's TrafficService over its HTTP API, using the
 server + token you logged in with (override with --server / --token).`,
@@ -73,11 +75,18 @@ var trafficHistoryCmd = &cobra.Command{
 	RunE:  runTrafficHistory,
 }
 
+var trafficHTTPCmd = &cobra.Command{
+	Use:   "http [box]",
+	Short: "Show HTTP request rate, error ratio and latency quantiles per box",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runTrafficHTTP,
+}
+
 func init() {
 	rootCmd.AddCommand(trafficCmd)
-	trafficCmd.AddCommand(trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd)
+	trafficCmd.AddCommand(trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd, trafficHTTPCmd)
 
-	for _, c := range []*cobra.Command{trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd} {
+	for _, c := range []*cobra.Command{trafficConnectionsCmd, trafficSummaryCmd, trafficHistoryCmd, trafficHTTPCmd} {
 		c.Flags().StringVar(&trafficServerFlag, "server", "", "server to query (default: the logged-in server)")
 		c.Flags().StringVarP(&trafficFormat, "format", "f", "table", "output format: table, json")
 	}
@@ -162,6 +171,26 @@ type queryHistoryResp struct {
 	TotalCount  int32                  `json:"totalCount"`
 }
 
+type httpMetrics struct {
+	ContainerName          string    `json:"containerName"`
+	Requests               flexInt64 `json:"requests"`
+	ServerErrors           flexInt64 `json:"serverErrors"`
+	ClientErrors           flexInt64 `json:"clientErrors"`
+	RequestsPerSecond      float64   `json:"requestsPerSecond"`
+	ErrorRatio             float64   `json:"errorRatio"`
+	ResponseBytesPerSecond float64   `json:"responseBytesPerSecond"`
+	P50Ms                  float64   `json:"p50Ms"`
+	P95Ms                  float64   `json:"p95Ms"`
+	P99Ms                  float64   `json:"p99Ms"`
+}
+
+type getHTTPMetricsResp struct {
+	Containers  []httpMetrics `json:"containers"`
+	WindowStart string        `json:"windowStart"`
+	WindowEnd   string        `json:"windowEnd"`
+	Enabled     bool          `json:"enabled"`
+}
+
 // trafficGet performs an authenticated GET against the resolved traffic server
 // and decodes the JSON body into out.
 func trafficGet(ctx context.Context, path string, query url.Values, out any) error {
@@ -336,6 +365,41 @@ func runTrafficHistory(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTrafficHTTP(cmd *cobra.Command, args []string) error {
+	path := "/v1/traffic/http"
+	if len(args) == 1 {
+		path = "/v1/containers/" + url.PathEscape(args[0]) + "/traffic/http"
+	}
+	var resp getHTTPMetricsResp
+	if err := trafficGet(cmd.Context(), path, nil, &resp); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if trafficFormat == "json" {
+		return writeJSON(out, resp)
+	}
+	if !resp.Enabled {
+		fmt.Fprintln(out, "HTTP metrics are not enabled on this server (set CONTAINARIUM_ACCESS_LOG).")
+		return nil
+	}
+	if len(resp.Containers) == 0 {
+		fmt.Fprintln(out, "No HTTP requests in the last window.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "BOX\tREQ/S\t5XX\tERR%\tP50\tP95\tP99\tOUT/S")
+	for _, m := range resp.Containers {
+		fmt.Fprintf(tw, "%s\t%.2f\t%d\t%.1f%%\t%s\t%s\t%s\t%s\n",
+			m.ContainerName, m.RequestsPerSecond, m.ServerErrors, m.ErrorRatio*100,
+			millis(m.P50Ms), millis(m.P95Ms), millis(m.P99Ms),
+			humanBytes(int64(m.ResponseBytesPerSecond)))
+	}
+	_ = tw.Flush()
+	fmt.Fprintf(out, "\nWindow %s → %s.\n", resp.WindowStart, resp.WindowEnd)
+	return nil
+}
+
 // --- small display helpers (writeJSON + humanBytes are shared, see runner.go /
 // backup_create.go) ---
 
@@ -349,3 +413,8 @@ func hostPort(ip string, port uint32) string {
 	}
 	return ip + ":" + strconv.FormatUint(uint64(port), 10)
 }
+
+// millis renders a latency in milliseconds, e.g. 12.5 → "12.5ms".
+func millis(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', 1, 64) + "ms"
+}
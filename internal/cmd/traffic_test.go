@@ -126,3 +126,43 @@ func TestTrafficConnections_EndToEnd(t *testing.T) {
 		}
 	}
 }
+
+// TestTrafficHTTP_EndToEnd: a named box hits the per-container route and the
+// RED figures render as a table.
+func TestTrafficHTTP_EndToEnd(t *testing.T) {
+	home := withTempHome(t)
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"containers":[{"containerName":"web-container","requests":"300",` +
+			`"serverErrors":"3","requestsPerSecond":10,"errorRatio":0.01,` +
+			`"responseBytesPerSecond":2048,"p50Ms":12.5,"p95Ms":80,"p99Ms":240}],` +
+			`"windowStart":"2026-01-01T00:00:00Z","windowEnd":"2026-01-01T00:00:30Z","enabled":true}`))
+	}))
+	defer srv.Close()
+	_ = seedCreds(t, home, srv.URL, map[string]credentials.ServerCreds{
+		srv.URL: {Token: "tok-traffic"},
+	})
+
+	trafficServerFlag, trafficFormat = "", "table"
+	t.Cleanup(func() { trafficServerFlag, trafficFormat = "", "table" })
+
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.Background())
+	if err := runTrafficHTTP(cmd, []string{"web-container"}); err != nil {
+		t.Fatalf("runTrafficHTTP: %v", err)
+	}
+	if gotPath != "/v1/containers/web-container/traffic/http" {
+		t.Errorf("path = %q", gotPath)
+	}
+	out := buf.String()
+	for _, want := range []string{"web-container", "10.00", "1.0%", "12.5ms", "240.0ms", "2.0 KiB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q; got:\n%s", want, out)
+		}
+	}
+}
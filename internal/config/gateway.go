@@ -4,4 +4,16 @@ package config
 const (
 	// EnvGatewayOutputFilter — output filtering is on unless set to "0".
 	EnvGatewayOutputFilter = "CONTAINARIUM_GATEWAY_OUTPUT_FILTER"
+	// EnvGatewayBudgets — path to the model-gateway budget policy JSON
+	// (per-tenant/skill budgets, rate limits, model prices). Unset = only
+	// budgets carried in gateway tokens apply.
+	EnvGatewayBudgets = "CONTAINARIUM_GATEWAY_BUDGETS"
+	// EnvGatewayRoutes — path to the model-gateway routing policy JSON
+	// (model aliases, provider fallback chains, weighted splits). Unset =
+	// every call goes to the token's provider.
+	EnvGatewayRoutes = "CONTAINARIUM_GATEWAY_ROUTES"
+	// EnvGatewayCacheTTL — a duration (e.g. "10m") turns on the model
+	// gateway's exact-match response cache with that TTL. Unset or "0" =
+	// off.
+	EnvGatewayCacheTTL = "CONTAINARIUM_GATEWAY_CACHE_TTL"
 )
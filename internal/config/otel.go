@@ -5,3 +5,8 @@ const (
 	EnvOTELRequireAuth   = "CONTAINARIUM_OTEL_REQUIRE_AUTH"
 	EnvOTELCollectorBind = "CONTAINARIUM_OTEL_COLLECTOR_BIND"
 )
+
+// CONTAINARIUM_ACCESS_LOG names the edge's JSON access log; when set, the
+// daemon tails it for the per-container HTTP RED plane (request rate, 5xx
+// ratio, latency quantiles — docs/REQUEST-RATE-PLANE-DESIGN.md). Unset → off.
+const EnvAccessLog = "CONTAINARIUM_ACCESS_LOG"
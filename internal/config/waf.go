@@ -2,9 +2,11 @@ package config
 
 // CONTAINARIUM_WAF_* variable names — the in-daemon WAF (tproxy inspection +
 // ingress). WAF_INSPECT / WAF_INGRESS use the shared truthy convention
-// (1/true/yes/on) at their read sites.
+// (1/true/yes/on) at their read sites. WAF_MAX_BODY_BYTES bounds how much of
+// each request body the inspector sees (unset → 64KiB, negative → heads only).
 const (
-	EnvWAFTProxyAddr = "CONTAINARIUM_WAF_TPROXY_ADDR"
-	EnvWAFInspect    = "CONTAINARIUM_WAF_INSPECT"
-	EnvWAFIngress    = "CONTAINARIUM_WAF_INGRESS"
+	EnvWAFTProxyAddr   = "CONTAINARIUM_WAF_TPROXY_ADDR"
+	EnvWAFInspect      = "CONTAINARIUM_WAF_INSPECT"
+	EnvWAFIngress      = "CONTAINARIUM_WAF_INGRESS"
+	EnvWAFMaxBodyBytes = "CONTAINARIUM_WAF_MAX_BODY_BYTES"
 )
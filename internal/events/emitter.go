@@ -103,6 +103,32 @@ func (e *Emitter) EmitContainerStateChanged(container *pb.Container, previousSta
 	e.bus.Publish(event)
 }
 
+// EmitContainerSnapshotCreated emits an event when the snapshot scheduler
+// takes a snapshot
+func (e *Emitter) EmitContainerSnapshotCreated(snap *pb.ContainerSnapshotEvent) {
+	e.emitContainerSnapshot(pb.EventType_EVENT_TYPE_CONTAINER_SNAPSHOT_CREATED, snap)
+}
+
+// EmitContainerSnapshotPruned emits an event when the snapshot scheduler
+// prunes a snapshot its retention no longer keeps
+func (e *Emitter) EmitContainerSnapshotPruned(snap *pb.ContainerSnapshotEvent) {
+	e.emitContainerSnapshot(pb.EventType_EVENT_TYPE_CONTAINER_SNAPSHOT_PRUNED, snap)
+}
+
+// EmitContainerSnapshotFailed emits an event when a scheduled snapshot, its
+// hooks or a prune fails
+func (e *Emitter) EmitContainerSnapshotFailed(snap *pb.ContainerSnapshotEvent) {
+	e.emitContainerSnapshot(pb.EventType_EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED, snap)
+}
+
+func (e *Emitter) emitContainerSnapshot(eventType pb.EventType, snap *pb.ContainerSnapshotEvent) {
+	event := newEvent(eventType, pb.ResourceType_RESOURCE_TYPE_CONTAINER, snap.ContainerName)
+	event.Payload = &pb.Event_ContainerSnapshotEvent{
+		ContainerSnapshotEvent: snap,
+	}
+	e.bus.Publish(event)
+}
+
 // App Events
 
 // EmitAppDeployed emits an event when an app is deployed
@@ -240,3 +266,49 @@ func (e *Emitter) EmitTrafficEvent(trafficEvent *pb.TrafficEvent) {
 	}
 	e.bus.Publish(event)
 }
+
+// EmitBackupProgress emits a progress report for a running backup operation
+func (e *Emitter) EmitBackupProgress(progress *pb.BackupProgressEvent) {
+	event := newEvent(
+		pb.EventType_EVENT_TYPE_BACKUP_PROGRESS,
+		pb.ResourceType_RESOURCE_TYPE_BACKUP,
+		progress.BackupId,
+	)
+	event.Payload = &pb.Event_BackupProgressEvent{
+		BackupProgressEvent: progress,
+	}
+	e.bus.Publish(event)
+}
+
+// EmitBackupScheduleRun emits the outcome of a scheduled backup run, as
+// BACKUP_SCHEDULE_FAILED when anything in it failed
+func (e *Emitter) EmitBackupScheduleRun(username string, run *pb.BackupScheduleRun) {
+	eventType := pb.EventType_EVENT_TYPE_BACKUP_SCHEDULE_SUCCEEDED
+	if !run.Ok {
+		eventType = pb.EventType_EVENT_TYPE_BACKUP_SCHEDULE_FAILED
+	}
+	event := newEvent(eventType, pb.ResourceType_RESOURCE_TYPE_BACKUP, username)
+	event.Payload = &pb.Event_BackupScheduleRunEvent{
+		BackupScheduleRunEvent: &pb.BackupScheduleRunEvent{
+			Username: username,
+			Run:      run,
+		},
+	}
+	e.bus.Publish(event)
+}
+
+// WAF Events
+
+// EmitWAFMatch emits a userspace WAF rule match, keyed by the connection's
+// original destination
+func (e *Emitter) EmitWAFMatch(match *pb.WAFMatchEvent) {
+	event := newEvent(
+		pb.EventType_EVENT_TYPE_WAF_MATCH,
+		pb.ResourceType_RESOURCE_TYPE_WAF,
+		match.Destination,
+	)
+	event.Payload = &pb.Event_WafMatchEvent{
+		WafMatchEvent: match,
+	}
+	e.bus.Publish(event)
+}
@@ -114,6 +114,10 @@ func parseEventFilter(r *http.Request) *pb.SubscribeEventsRequest {
 			filter.ResourceTypes = append(filter.ResourceTypes, pb.ResourceType_RESOURCE_TYPE_ROUTE)
 		case "METRICS", "RESOURCE_TYPE_METRICS":
 			filter.ResourceTypes = append(filter.ResourceTypes, pb.ResourceType_RESOURCE_TYPE_METRICS)
+		case "BACKUP", "RESOURCE_TYPE_BACKUP":
+			filter.ResourceTypes = append(filter.ResourceTypes, pb.ResourceType_RESOURCE_TYPE_BACKUP)
+		case "WAF", "RESOURCE_TYPE_WAF":
+			filter.ResourceTypes = append(filter.ResourceTypes, pb.ResourceType_RESOURCE_TYPE_WAF)
 		}
 	}
 
@@ -17,9 +17,11 @@ func backupTools() []Tool {
 	return []Tool{
 		{
 			Name: "create_backup",
-			Description: "Back up a tenant's database off-host. Runs pg_dump inside " +
+			Description: "Back up a tenant's database off-host. Runs the engine's " +
+				"dump tool (pg_dump, mysqldump/mariadb-dump, or a Redis BGSAVE) inside " +
 				"the tenant's container and stores the compressed dump in a host " +
-				"backup directory (dest 'local') or a GCS bucket (dest 'gcs'). The " +
+				"backup directory (dest 'local'), a GCS bucket (dest 'gcs') or an " +
+				"S3-compatible bucket (dest 's3'). The " +
 				"point is off-host durability — a dump never shares a failure " +
 				"domain with the database it protects. Returns the backup ID, " +
 				"size, and SHA-256. Mirrors `containarium backup create`.",
@@ -30,26 +32,35 @@ func backupTools() []Tool {
 						"type":        "string",
 						"description": "Tenant whose container holds the database.",
 					},
+					"engine": map[string]interface{}{
+						"type":        "string",
+						"description": "Database engine: 'postgres', 'mysql' (MySQL or MariaDB) or 'redis'. Default 'postgres'.",
+						"enum":        []string{"postgres", "mysql", "redis"},
+					},
 					"database": map[string]interface{}{
 						"type":        "string",
-						"description": "Logical database name to dump.",
+						"description": "Logical database name to dump. For engine 'redis' use 'redis': an RDB snapshot covers the whole instance.",
 					},
 					"dest": map[string]interface{}{
 						"type":        "string",
-						"description": "Destination: 'local' (host backup dir) or 'gcs'. Default 'local'.",
-						"enum":        []string{"local", "gcs"},
+						"description": "Destination: 'local' (host backup dir), 'gcs' or 's3'. Default 'local'.",
+						"enum":        []string{"local", "gcs", "s3"},
 					},
 					"gcs_bucket": map[string]interface{}{
 						"type":        "string",
 						"description": "GCS bucket/prefix for dest 'gcs', e.g. 'gs://my-backups/pg'. Required when dest=gcs.",
 					},
+					"s3_bucket": map[string]interface{}{
+						"type":        "string",
+						"description": "S3 bucket/prefix for dest 's3', e.g. 's3://my-backups/pg'. Required when dest=s3; the endpoint and credentials are daemon configuration.",
+					},
 					"db_user": map[string]interface{}{
 						"type":        "string",
-						"description": "Postgres role. Default 'postgres'.",
+						"description": "DB user. Default 'postgres' for Postgres, 'root' for MySQL; a Redis ACL user.",
 					},
 					"db_password": map[string]interface{}{
 						"type":        "string",
-						"description": "Postgres password. Omit for peer/trust auth. Passed via PGPASSWORD inside the container, never on argv.",
+						"description": "DB password. Omit for peer/trust auth. Passed through the environment inside the container (PGPASSWORD, MYSQL_PWD, REDISCLI_AUTH), never on argv.",
 					},
 				},
 				"required": []string{"username", "database"},
@@ -142,14 +153,30 @@ func handleCreateBackup(client API, args map[string]interface{}) (string, error)
 		destEnum = "BACKUP_DESTINATION_LOCAL"
 	case "gcs":
 		destEnum = "BACKUP_DESTINATION_GCS"
+	case "s3":
+		destEnum = "BACKUP_DESTINATION_S3"
+	default:
+		return "", fmt.Errorf("invalid dest %q (expected 'local', 'gcs' or 's3')", dest)
+	}
+	engine := getStringArg(args, "engine", "postgres")
+	var engineEnum string
+	switch engine {
+	case "postgres":
+		engineEnum = "BACKUP_ENGINE_POSTGRES"
+	case "mysql":
+		engineEnum = "BACKUP_ENGINE_MYSQL"
+	case "redis":
+		engineEnum = "BACKUP_ENGINE_REDIS"
 	default:
-		return "", fmt.Errorf("invalid dest %q (expected 'local' or 'gcs')", dest)
+		return "", fmt.Errorf("invalid engine %q (expected 'postgres', 'mysql' or 'redis')", engine)
 	}
 
 	resp, err := client.CreateBackup(CreateBackupRequest{
 		Username:    getStringArg(args, "username", ""),
+		Engine:      engineEnum,
 		Destination: destEnum,
 		GCSBucket:   getStringArg(args, "gcs_bucket", ""),
+		S3Bucket:    getStringArg(args, "s3_bucket", ""),
 		Connection: &PgConnectionBody{
 			Database: getStringArg(args, "database", ""),
 			User:     getStringArg(args, "db_user", ""),
@@ -246,6 +273,8 @@ func backupDestLabel(enumName string) string {
 		return "local"
 	case "BACKUP_DESTINATION_GCS":
 		return "gcs"
+	case "BACKUP_DESTINATION_S3":
+		return "s3"
 	default:
 		return enumName
 	}
@@ -453,13 +453,16 @@ type PgConnectionBody struct {
 	Port     int32  `json:"port,omitempty"`
 }
 
-// CreateBackupRequest is the body for POST /v1/backups. Destination is the
-// proto enum NAME ("BACKUP_DESTINATION_LOCAL" / "BACKUP_DESTINATION_GCS").
+// CreateBackupRequest is the body for POST /v1/backups. Destination and
+// Engine are proto enum NAMEs ("BACKUP_DESTINATION_LOCAL",
+// "BACKUP_ENGINE_MYSQL").
 type CreateBackupRequest struct {
 	Username    string            `json:"username"`
+	Engine      string            `json:"engine,omitempty"`
 	Connection  *PgConnectionBody `json:"connection,omitempty"`
 	Destination string            `json:"destination"`
 	GCSBucket   string            `json:"gcs_bucket,omitempty"`
+	S3Bucket    string            `json:"s3_bucket,omitempty"`
 }
 
 // BackupRecord mirrors the proto BackupRecord on the response side
@@ -3,6 +3,7 @@ package mcp
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/footprintai/containarium/internal/transfer"
 )
@@ -76,7 +77,12 @@ func handleSync(client API, args map[string]interface{}) (string, error) {
 		return "", fmt.Errorf("username is required")
 	}
 
-	excludes := append([]string{}, transfer.DefaultSyncExcludes...)
+	gitIgnore := getBoolArg(args, "gitignore", false)
+	defaults := transfer.DefaultSyncExcludes
+	if gitIgnore {
+		defaults = transfer.DefaultSyncIgnores
+	}
+	excludes := append([]string{}, defaults...)
 	if extra, ok := args["exclude"].([]interface{}); ok {
 		for _, e := range extra {
 			if s, ok := e.(string); ok && s != "" {
@@ -85,7 +91,7 @@ func handleSync(client API, args map[string]interface{}) (string, error) {
 		}
 	}
 
-	res, err := transfer.Sync(transfer.SyncOptions{
+	opts := transfer.SyncOptions{
 		Options: transfer.Options{
 			Username:     username,
 			SentinelHost: pickSentinel(client, args),
@@ -94,9 +100,35 @@ func handleSync(client API, args map[string]interface{}) (string, error) {
 			RemotePath:   getStringArg(args, "remote_path", ""),
 			Verbose:      false,
 		},
-		Delete:   getBoolArg(args, "delete", false),
-		Excludes: excludes,
-	})
+		Delete:    getBoolArg(args, "delete", false),
+		Excludes:  excludes,
+		GitIgnore: gitIgnore,
+	}
+
+	if getBoolArg(args, "from_box", false) {
+		res, err := transfer.Pull(transfer.PullOptions{
+			SyncOptions: opts,
+			Force:       getBoolArg(args, "force", false),
+		})
+		if err != nil {
+			return "", sentinelHint("sync", username, err)
+		}
+		if res.Added == 0 && res.Modified == 0 && res.Deleted == 0 && len(res.Conflicts) == 0 {
+			return "sync (from box): no changes (local already matches remote)", nil
+		}
+		out := fmt.Sprintf(
+			"sync (from box): +%d added, ~%d modified, -%d deleted, %d bytes fetched",
+			res.Added, res.Modified, res.Deleted, res.Bytes,
+		)
+		if len(res.Conflicts) > 0 {
+			out += fmt.Sprintf("\n%d conflict(s) — changed both locally and in the box since the last sync, left untouched "+
+				"(pass force=true to take the box's version):\n  %s",
+				len(res.Conflicts), strings.Join(res.Conflicts, "\n  "))
+		}
+		return out, nil
+	}
+
+	res, err := transfer.Sync(opts)
 	if err != nil {
 		return "", sentinelHint("sync", username, err)
 	}
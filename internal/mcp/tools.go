@@ -750,9 +750,16 @@ func (s *Server) registerTools() {
 				"(atomic per commit, refuses on dirty tree), use `push` instead.\n\n" +
 				"By default, files that exist on the remote but not locally are LEFT in " +
 				"place. Pass `delete=true` for true rsync --delete semantics.\n\n" +
+				"Pass `from_box=true` to reverse direction and bring the container's " +
+				"edits back into `local_path`. That pull is three-way against the state " +
+				"the last sync left behind: files changed on both sides since then are " +
+				"reported as conflicts and left untouched unless `force=true`.\n\n" +
 				"Default excludes (substring match): node_modules/, .terraform/, " +
 				"__pycache__/, .pytest_cache/, .venv/, venv/, .DS_Store, .idea/, .vscode/. " +
-				"Pass an `exclude` array to add to (not replace) the defaults.",
+				"Pass an `exclude` array to add to (not replace) the defaults. Pass " +
+				"`gitignore=true` to honor the project's .gitignore / .containariumignore " +
+				"files with real gitignore semantics (excludes become gitignore patterns, " +
+				"and ignored files on the remote are never deleted).",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -772,10 +779,22 @@ func (s *Server) registerTools() {
 						"type":        "boolean",
 						"description": "If true, remove files on the remote that don't exist locally (rsync --delete semantics). Default false.",
 					},
+					"from_box": map[string]interface{}{
+						"type":        "boolean",
+						"description": "If true, pull: mirror remote_path in the container back into local_path. Default false.",
+					},
+					"force": map[string]interface{}{
+						"type":        "boolean",
+						"description": "With from_box, overwrite local files that were also changed locally since the last sync. Default false.",
+					},
 					"exclude": map[string]interface{}{
 						"type":        "array",
 						"items":       map[string]string{"type": "string"},
-						"description": "Additional exclude patterns (substring match), added to the sensible defaults.",
+						"description": "Additional exclude patterns (substring match, or gitignore patterns with gitignore=true), added to the sensible defaults.",
+					},
+					"gitignore": map[string]interface{}{
+						"type":        "boolean",
+						"description": "If true, honor .gitignore and .containariumignore files with gitignore semantics. Default false.",
 					},
 					"sentinel": map[string]interface{}{
 						"type":        "string",
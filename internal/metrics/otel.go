@@ -71,6 +71,13 @@ type EgressFanoutFetcher interface {
 	EgressFanout() []EgressFanoutStat
 }
 
+// HTTPStatsFetcher reports the latest per-container HTTP RED window from the
+// edge access log. Implemented by reqrate.Tracker; nil when access-log
+// aggregation is off, in which case the request-rate plane stays dark.
+type HTTPStatsFetcher interface {
+	Latest() (samples []reqrate.REDSample, from, to time.Time)
+}
+
 // PeerMetricsFetcher fetches container and system metrics from peer backends.
 type PeerMetricsFetcher interface {
 	// FetchPeerMetrics returns container metrics from all healthy peers.
@@ -129,6 +136,11 @@ type Collector struct {
 	containerProcessCount otelmetric.Int64Gauge
 	containerRequestRate  otelmetric.Float64Gauge
 
+	// HTTP RED instruments (request rate above), from the edge access log.
+	containerHTTPErrorRatio otelmetric.Float64Gauge
+	containerHTTPDuration   otelmetric.Float64Gauge
+	containerHTTPBytesRate  otelmetric.Float64Gauge
+
 	// Egress fan-out instruments (crawler detection, #231 follow-on).
 	containerEgressDistinctDest otelmetric.Int64Gauge
 	containerEgressConnections  otelmetric.Int64Gauge
@@ -144,6 +156,7 @@ type Collector struct {
 	cancel        context.CancelFunc
 	peerFetcher   PeerMetricsFetcher
 	egressFetcher EgressFanoutFetcher
+	httpFetcher   HTTPStatsFetcher
 }
 
 // NewCollector creates a new OTel metrics collector
@@ -306,9 +319,9 @@ func (c *Collector) initInstruments() error {
 	// Request-rate plane (#231): edge-measured HTTP requests/sec per container.
 	// → VM series container_request_rate{container_id=...}, the same join key
 	// the bytes plane uses, consumed by the cloud's RequestRatePanel. Recorded
-	// only when access-log aggregation is enabled (see RecordRequestRates and
+	// only when access-log aggregation is enabled (see RecordHTTPRED and
 	// docs/REQUEST-RATE-PLANE-DESIGN.md); the instrument is created
-	// unconditionally so the wiring slice has nothing to bootstrap.
+	// unconditionally so there is nothing to bootstrap when it's turned on.
 	c.containerRequestRate, err = meter.Float64Gauge("container.request_rate",
 		otelmetric.WithDescription("Container HTTP requests per second (edge-measured)"),
 		otelmetric.WithUnit("1/s"))
@@ -316,6 +329,31 @@ func (c *Collector) initInstruments() error {
 		return err
 	}
 
+	// The rest of the HTTP RED plane, from the same access-log window: the
+	// share of requests answered 5xx, response latency quantiles (one series
+	// per quantile label, summary-style — the daemon ships the window's
+	// quantiles, not raw buckets), and response bytes/sec.
+	c.containerHTTPErrorRatio, err = meter.Float64Gauge("container.http.error_ratio",
+		otelmetric.WithDescription("Share of container HTTP requests answered 5xx (edge-measured)"),
+		otelmetric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+
+	c.containerHTTPDuration, err = meter.Float64Gauge("container.http.request_duration",
+		otelmetric.WithDescription("Container HTTP request duration quantiles (edge-measured)"),
+		otelmetric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	c.containerHTTPBytesRate, err = meter.Float64Gauge("container.http.response_bytes_rate",
+		otelmetric.WithDescription("Container HTTP response body bytes per second (edge-measured)"),
+		otelmetric.WithUnit("By/s"))
+	if err != nil {
+		return err
+	}
+
 	// Egress fan-out (#231 follow-on): the crawler-detection signal. A large,
 	// churning count of distinct outbound destinations is a crawler's hallmark;
 	// a normal app talks to a handful. Per-container aggregates only — the raw
@@ -500,6 +538,12 @@ func (c *Collector) collect() {
 		c.RecordEgressFanout(c.egressFetcher.EgressFanout())
 	}
 
+	// HTTP RED plane: the latest closed access-log window.
+	if c.httpFetcher != nil {
+		samples, _, _ := c.httpFetcher.Latest()
+		c.RecordHTTPRED(samples)
+	}
+
 	// Collect metrics from peer backends
 	if c.peerFetcher != nil {
 		peerMetrics := c.peerFetcher.FetchPeerMetrics("")
@@ -522,10 +566,9 @@ func (c *Collector) collect() {
 }
 
 // RecordRequestRates records edge-measured per-container request rates
-// (container.request_rate) for one collection tick. The collector calls this
-// only when access-log aggregation is enabled (CONTAINARIUM_ACCESS_LOG=1, a
-// later wiring slice — see docs/REQUEST-RATE-PLANE-DESIGN.md); until then it
-// has no callers and the plane stays dark.
+// (container.request_rate) for one collection tick — the rate-only form;
+// when access-log aggregation is enabled the tick records the full RED set
+// through RecordHTTPRED instead (see docs/REQUEST-RATE-PLANE-DESIGN.md).
 //
 // Samples carry their own container.id (the cloud_container_id, empty on
 // standalone boxes) so the VM series joins to a tenant exactly like the bytes
@@ -544,6 +587,39 @@ func (c *Collector) RecordRequestRates(samples []reqrate.Sample) {
 	}
 }
 
+// RecordHTTPRED records one access-log window's per-container RED samples:
+// container.request_rate plus the error ratio, duration quantiles and
+// response byte rate, with the same attribute set RecordRequestRates uses.
+func (c *Collector) RecordHTTPRED(samples []reqrate.REDSample) {
+	for _, s := range samples {
+		attrSet := []attribute.KeyValue{
+			attribute.String("container.name", s.ContainerName),
+			attribute.String("backend.id", c.config.LocalBackendID),
+		}
+		if s.ContainerID != "" {
+			attrSet = append(attrSet, attribute.String("container.id", s.ContainerID))
+		}
+		attrs := otelmetric.WithAttributes(attrSet...)
+		c.containerRequestRate.Record(c.ctx, s.RequestsPerSec, attrs)
+		c.containerHTTPErrorRatio.Record(c.ctx, s.ErrorRatio, attrs)
+		c.containerHTTPBytesRate.Record(c.ctx, s.BytesPerSec, attrs)
+		for _, q := range []struct {
+			label string
+			d     time.Duration
+		}{{"0.5", s.P50}, {"0.95", s.P95}, {"0.99", s.P99}} {
+			qAttrs := append(append([]attribute.KeyValue(nil), attrSet...), attribute.String("quantile", q.label))
+			c.containerHTTPDuration.Record(c.ctx, q.d.Seconds(), otelmetric.WithAttributes(qAttrs...))
+		}
+	}
+}
+
+// SetHTTPStatsFetcher sets the HTTP RED source. When set, each collection
+// tick records the container.request_rate and container.http.* gauges from
+// its latest window.
+func (c *Collector) SetHTTPStatsFetcher(fetcher HTTPStatsFetcher) {
+	c.httpFetcher = fetcher
+}
+
 // SetPeerFetcher sets the peer metrics fetcher for collecting metrics from peer backends.
 func (c *Collector) SetPeerFetcher(fetcher PeerMetricsFetcher) {
 	c.peerFetcher = fetcher
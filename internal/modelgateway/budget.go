@@ -0,0 +1,536 @@
+package modelgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Budgets and rate limits (design note responsibility 7). A Budget caps one
+// scope's spend — token counts and cost over daily/monthly UTC windows, and a
+// requests-per-minute token bucket — so a runaway agent loop hits a wall
+// instead of the shared provider account.
+//
+// A budget comes from either place, and both apply:
+//
+//   - the gateway token (GatewayClaims.Budget) — minted per skill box, it caps
+//     that tenant/skill;
+//   - the daemon-side BudgetPolicy — per tenant, per tenant/skill, plus a
+//     default for unlisted tenants, and the model price table cost budgets are
+//     rated with.
+//
+// Where both set the same limit on a tenant/skill the tighter one wins.
+// Spend is counted in memory, per scope, like the Meter: a restart starts the
+// windows afresh. Admission checks spend *before* the call (its cost is only
+// known from the response), so a scope can overshoot by its last call.
+
+// BudgetMode is what happens once a limit is reached.
+type BudgetMode string
+
+const (
+	// BudgetHard refuses further calls with a provider-shaped 429 until the
+	// window resets. The default.
+	BudgetHard BudgetMode = "hard"
+	// BudgetSoft admits them, but alerts (log + Config.Alerts) and marks the
+	// response with a budget-warning header.
+	BudgetSoft BudgetMode = "soft"
+)
+
+// Budget is the set of limits for one scope. Zero fields are unlimited.
+type Budget struct {
+	RequestsPerMinute int        `json:"requests_per_minute,omitempty"`
+	DailyTokens       int64      `json:"daily_tokens,omitempty"`
+	MonthlyTokens     int64      `json:"monthly_tokens,omitempty"`
+	DailyCostUSD      float64    `json:"daily_cost_usd,omitempty"`
+	MonthlyCostUSD    float64    `json:"monthly_cost_usd,omitempty"`
+	Mode              BudgetMode `json:"mode,omitempty"` // "" = hard
+}
+
+// Limit names, as they appear in alerts, refusals and the budget-warning
+// header.
+const (
+	limitRPM            = "requests_per_minute"
+	limitDailyTokens    = "daily_tokens"
+	limitMonthlyTokens  = "monthly_tokens"
+	limitDailyCostUSD   = "daily_cost_usd"
+	limitMonthlyCostUSD = "monthly_cost_usd"
+)
+
+func (b *Budget) empty() bool {
+	return b == nil || (b.RequestsPerMinute == 0 && b.DailyTokens == 0 && b.MonthlyTokens == 0 &&
+		b.DailyCostUSD == 0 && b.MonthlyCostUSD == 0)
+}
+
+func (b *Budget) soft() bool { return b.Mode == BudgetSoft }
+
+func (b *Budget) validate() error {
+	if b == nil {
+		return nil
+	}
+	if b.RequestsPerMinute < 0 || b.DailyTokens < 0 || b.MonthlyTokens < 0 || b.DailyCostUSD < 0 || b.MonthlyCostUSD < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	switch b.Mode {
+	case "", BudgetHard, BudgetSoft:
+		return nil
+	}
+	return fmt.Errorf("budget mode %q: want %q or %q", b.Mode, BudgetHard, BudgetSoft)
+}
+
+// mergeBudgets combines the budgets that apply to one scope: the tighter of
+// each limit, and soft only if every budget setting limits is soft. Pure.
+func mergeBudgets(bs ...*Budget) *Budget {
+	var out *Budget
+	for _, b := range bs {
+		if b.empty() {
+			continue
+		}
+		if out == nil {
+			c := *b
+			out = &c
+			continue
+		}
+		out.RequestsPerMinute = tighter(out.RequestsPerMinute, b.RequestsPerMinute)
+		out.DailyTokens = tighter(out.DailyTokens, b.DailyTokens)
+		out.MonthlyTokens = tighter(out.MonthlyTokens, b.MonthlyTokens)
+		out.DailyCostUSD = tighter(out.DailyCostUSD, b.DailyCostUSD)
+		out.MonthlyCostUSD = tighter(out.MonthlyCostUSD, b.MonthlyCostUSD)
+		if !b.soft() {
+			out.Mode = BudgetHard
+		}
+	}
+	return out
+}
+
+// tighter returns the smaller of two limits, where zero means unlimited.
+func tighter[T int | int64 | float64](a, b T) T {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// ModelPrice is one model's price in USD per million tokens. Cached tokens
+// are the provider-reported cache reads.
+type ModelPrice struct {
+	InputPerMTok  float64 `json:"input_per_mtok"`
+	OutputPerMTok float64 `json:"output_per_mtok"`
+	CachedPerMTok float64 `json:"cached_per_mtok,omitempty"`
+}
+
+// PriceTable maps a model id to its price. A key also matches every model it
+// is a prefix of, so "claude-sonnet-4" prices "claude-sonnet-4-20250514";
+// the longest matching key wins. OSS ships no prices — rating is the
+// operator's (or Cloud's) call, per the design note's metering/billing split.
+type PriceTable map[string]ModelPrice
+
+// cost rates u. ok is false for a model the table has no price for.
+func (t PriceTable) cost(u Usage) (usd float64, ok bool) {
+	p, ok := t[u.Model]
+	if !ok {
+		match := ""
+		for k, v := range t {
+			if strings.HasPrefix(u.Model, k) && len(k) > len(match) {
+				match, p, ok = k, v, true
+			}
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	return (float64(u.InputTokens)*p.InputPerMTok +
+		float64(u.OutputTokens)*p.OutputPerMTok +
+		float64(u.CachedTokens)*p.CachedPerMTok) / 1e6, true
+}
+
+// TenantBudget is one tenant's entry in a BudgetPolicy: a tenant-wide budget
+// and optional per-skill budgets inside it.
+type TenantBudget struct {
+	Budget
+	Skills map[string]*Budget `json:"skills,omitempty"`
+}
+
+// BudgetPolicy is the daemon-side budget store, loaded from a JSON file
+// (LoadBudgetPolicy):
+//
+//	{
+//	  "prices":  {"claude-sonnet-4": {"input_per_mtok": 3, "output_per_mtok": 15}},
+//	  "default": {"daily_cost_usd": 20},
+//	  "tenants": {"acme": {"monthly_cost_usd": 500, "requests_per_minute": 120,
+//	                       "skills": {"triage": {"daily_tokens": 2000000, "mode": "soft"}}}}
+//	}
+//
+// Default applies, with its own counters, to each tenant not listed.
+type BudgetPolicy struct {
+	Prices  PriceTable               `json:"prices,omitempty"`
+	Default *Budget                  `json:"default,omitempty"`
+	Tenants map[string]*TenantBudget `json:"tenants,omitempty"`
+}
+
+// LoadBudgetPolicy reads and validates a BudgetPolicy file. Unknown fields
+// are an error, so a misspelt limit is caught at startup rather than
+// silently unenforced.
+func LoadBudgetPolicy(path string) (*BudgetPolicy, error) {
+	raw, err := os.ReadFile(path) // #nosec G304 — operator-supplied config path
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var p BudgetPolicy
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("parse budget policy %s: %w", path, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("budget policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+func (p *BudgetPolicy) validate() error {
+	if err := p.Default.validate(); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	for tenant, tb := range p.Tenants {
+		if tb == nil {
+			continue
+		}
+		if err := tb.Budget.validate(); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenant, err)
+		}
+		for skill, b := range tb.Skills {
+			if err := b.validate(); err != nil {
+				return fmt.Errorf("tenant %s skill %s: %w", tenant, skill, err)
+			}
+		}
+	}
+	for model, pr := range p.Prices {
+		if pr.InputPerMTok < 0 || pr.OutputPerMTok < 0 || pr.CachedPerMTok < 0 {
+			return fmt.Errorf("price for %s must not be negative", model)
+		}
+	}
+	return nil
+}
+
+// scopeKey identifies a spend counter: a whole tenant, or one of its skills.
+type scopeKey struct {
+	tenant, skill string
+	whole         bool
+}
+
+// budgetScope is one limit set a call is checked against.
+type budgetScope struct {
+	key    scopeKey
+	budget *Budget
+}
+
+// budgetScopes resolves the budgets that apply to a call made with claims:
+// the tenant-wide policy budget, and the tenant/skill budget merged from the
+// policy and the token. Scopes with no limits are dropped. Pure.
+func budgetScopes(p *BudgetPolicy, c *GatewayClaims) []budgetScope {
+	var tenant, skill *Budget
+	if p != nil {
+		if tb := p.Tenants[c.Tenant]; tb != nil {
+			tenant = &tb.Budget
+			skill = tb.Skills[c.SkillID]
+		} else {
+			tenant = p.Default
+		}
+	}
+	var out []budgetScope
+	if !tenant.empty() {
+		out = append(out, budgetScope{key: scopeKey{tenant: c.Tenant, whole: true}, budget: tenant})
+	}
+	if b := mergeBudgets(skill, c.Budget); b != nil {
+		out = append(out, budgetScope{key: scopeKey{tenant: c.Tenant, skill: c.SkillID}, budget: b})
+	}
+	return out
+}
+
+// BudgetAlert reports a scope reaching one of its limits. Skill is empty for
+// a tenant-wide budget.
+type BudgetAlert struct {
+	Tenant string     `json:"tenant"`
+	Skill  string     `json:"skill,omitempty"`
+	Limit  string     `json:"limit"`
+	Used   float64    `json:"used"`
+	Max    float64    `json:"max"`
+	Mode   BudgetMode `json:"mode"`
+	At     time.Time  `json:"at"`
+}
+
+// BudgetAlertSink receives budget alerts, e.g. to page or notify a tenant.
+// Alerts are always logged; the sink is optional.
+type BudgetAlertSink interface {
+	BudgetAlert(a BudgetAlert)
+}
+
+// budgetRefusal is why a call was not admitted.
+type budgetRefusal struct {
+	limit      string
+	quota      bool // a spend budget, as opposed to the request rate
+	retryAfter time.Duration
+	message    string
+}
+
+// spend is one scope's counters. The day/month fields name the window the
+// counters belong to; a call in a later window resets them.
+type spend struct {
+	day, month             string
+	dayTokens, monthTokens int64
+	dayCost, monthCost     float64
+	bucket                 float64 // RPM tokens available
+	refilled               time.Time
+	rpmAlerted             time.Time
+}
+
+// budgetLedger counts spend per scope and enforces budgets against it.
+type budgetLedger struct {
+	mu       sync.Mutex
+	rows     map[scopeKey]*spend
+	unpriced map[string]bool // models already logged as unpriced
+	now      func() time.Time
+}
+
+func newBudgetLedger() *budgetLedger {
+	return &budgetLedger{rows: map[scopeKey]*spend{}, unpriced: map[string]bool{}, now: time.Now}
+}
+
+// row returns k's counters, rolled into the current windows. Caller holds mu.
+func (l *budgetLedger) row(k scopeKey, now time.Time) *spend {
+	s := l.rows[k]
+	if s == nil {
+		s = &spend{bucket: -1}
+		l.rows[k] = s
+	}
+	if day := now.Format("2006-01-02"); s.day != day {
+		s.day, s.dayTokens, s.dayCost = day, 0, 0
+	}
+	if month := now.Format("2006-01"); s.month != month {
+		s.month, s.monthTokens, s.monthCost = month, 0, 0
+	}
+	return s
+}
+
+// refill tops up an RPM bucket of capacity rpm. A new bucket starts full.
+func (s *spend) refill(rpm int, now time.Time) {
+	capacity := float64(rpm)
+	if s.bucket < 0 {
+		s.bucket = capacity
+	} else {
+		s.bucket = math.Min(capacity, s.bucket+now.Sub(s.refilled).Minutes()*capacity)
+	}
+	s.refilled = now
+}
+
+// admit decides whether a call may proceed. A hard limit already reached
+// refuses it; a soft one admits it and is returned in warnings (its alert
+// fired when it was crossed). The RPM buckets are only drawn from once every
+// scope admits the call, so a refused call costs no rate.
+func (l *budgetLedger) admit(scopes []budgetScope) (refusal *budgetRefusal, warnings []string, alerts []BudgetAlert) {
+	if len(scopes) == 0 {
+		return nil, nil, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now().UTC()
+	for _, sc := range scopes {
+		s, b := l.row(sc.key, now), sc.budget
+		for _, c := range s.uses(b, now) {
+			if c.max == 0 || c.used < c.max {
+				continue
+			}
+			if b.soft() {
+				warnings = append(warnings, c.limit)
+				continue
+			}
+			return &budgetRefusal{
+				limit: c.limit, quota: true, retryAfter: c.reset.Sub(now),
+				message: fmt.Sprintf("%s budget exhausted for %s (%s of %s); resets at %s",
+					c.limit, sc.key, formatAmount(c.limit, c.used), formatAmount(c.limit, c.max), c.reset.Format(time.RFC3339)),
+			}, nil, nil
+		}
+	}
+	for _, sc := range scopes {
+		s, b := l.rows[sc.key], sc.budget
+		if b.RequestsPerMinute == 0 {
+			continue
+		}
+		s.refill(b.RequestsPerMinute, now)
+		if s.bucket >= 1 {
+			continue
+		}
+		if !b.soft() {
+			wait := time.Duration((1 - s.bucket) / float64(b.RequestsPerMinute) * float64(time.Minute))
+			return &budgetRefusal{
+				limit: limitRPM, retryAfter: wait,
+				message: fmt.Sprintf("rate limit of %d requests per minute reached for %s", b.RequestsPerMinute, sc.key),
+			}, nil, nil
+		}
+		warnings = append(warnings, limitRPM)
+		if now.Sub(s.rpmAlerted) >= time.Minute {
+			s.rpmAlerted = now
+			alerts = append(alerts, sc.alert(limitRPM, float64(b.RequestsPerMinute), float64(b.RequestsPerMinute), now))
+		}
+	}
+	for _, sc := range scopes {
+		if s := l.rows[sc.key]; sc.budget.RequestsPerMinute > 0 {
+			s.bucket = math.Max(0, s.bucket-1)
+		}
+	}
+	return nil, warnings, alerts
+}
+
+// record adds a completed call's usage to every scope, returning an alert for
+// each limit the call crossed. unpriced is true the first time a model
+// without a price is charged to a cost budget: its cost counts as zero.
+func (l *budgetLedger) record(scopes []budgetScope, prices PriceTable, u Usage) (alerts []BudgetAlert, unpriced bool) {
+	if len(scopes) == 0 {
+		return nil, false
+	}
+	cost, priced := prices.cost(u)
+	tokens := u.InputTokens + u.OutputTokens
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now().UTC()
+	for _, sc := range scopes {
+		s, b := l.row(sc.key, now), sc.budget
+		if !priced && (b.DailyCostUSD > 0 || b.MonthlyCostUSD > 0) && !l.unpriced[u.Model] {
+			l.unpriced[u.Model] = true
+			unpriced = true
+		}
+		s.dayTokens += tokens
+		s.monthTokens += tokens
+		s.dayCost += cost
+		s.monthCost += cost
+		for _, c := range s.uses(b, now) {
+			delta := cost
+			if !strings.HasSuffix(c.limit, "_usd") {
+				delta = float64(tokens)
+			}
+			if c.max > 0 && c.used-delta < c.max && c.used >= c.max {
+				alerts = append(alerts, sc.alert(c.limit, c.used, c.max, now))
+			}
+		}
+	}
+	return alerts, unpriced
+}
+
+// limitUse is one spend limit of a budget against the counter it caps.
+type limitUse struct {
+	limit     string
+	used, max float64
+	reset     time.Time
+}
+
+func (s *spend) uses(b *Budget, now time.Time) []limitUse {
+	return []limitUse{
+		{limitDailyTokens, float64(s.dayTokens), float64(b.DailyTokens), nextDay(now)},
+		{limitMonthlyTokens, float64(s.monthTokens), float64(b.MonthlyTokens), nextMonth(now)},
+		{limitDailyCostUSD, s.dayCost, b.DailyCostUSD, nextDay(now)},
+		{limitMonthlyCostUSD, s.monthCost, b.MonthlyCostUSD, nextMonth(now)},
+	}
+}
+
+func (sc budgetScope) alert(limit string, used, max float64, now time.Time) BudgetAlert {
+	mode := BudgetHard
+	if sc.budget.soft() {
+		mode = BudgetSoft
+	}
+	return BudgetAlert{Tenant: sc.key.tenant, Skill: sc.key.skill, Limit: limit, Used: used, Max: max, Mode: mode, At: now}
+}
+
+func (k scopeKey) String() string {
+	if k.whole {
+		return "tenant " + k.tenant
+	}
+	return "tenant " + k.tenant + " skill " + k.skill
+}
+
+// BudgetRow is a snapshot of one scope's spend in the current windows.
+type BudgetRow struct {
+	Tenant         string  `json:"tenant"`
+	Skill          string  `json:"skill,omitempty"`
+	TenantWide     bool    `json:"tenant_wide,omitempty"`
+	Day            string  `json:"day"`
+	DailyTokens    int64   `json:"daily_tokens"`
+	DailyCostUSD   float64 `json:"daily_cost_usd"`
+	Month          string  `json:"month"`
+	MonthlyTokens  int64   `json:"monthly_tokens"`
+	MonthlyCostUSD float64 `json:"monthly_cost_usd"`
+}
+
+// snapshot returns every scope's spend, rolled to the current windows, in a
+// stable order.
+func (l *budgetLedger) snapshot() []BudgetRow {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now().UTC()
+	out := make([]BudgetRow, 0, len(l.rows))
+	for k := range l.rows {
+		s := l.row(k, now)
+		out = append(out, BudgetRow{
+			Tenant: k.tenant, Skill: k.skill, TenantWide: k.whole,
+			Day: s.day, DailyTokens: s.dayTokens, DailyCostUSD: s.dayCost,
+			Month: s.month, MonthlyTokens: s.monthTokens, MonthlyCostUSD: s.monthCost,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Tenant != out[j].Tenant {
+			return out[i].Tenant < out[j].Tenant
+		}
+		if out[i].TenantWide != out[j].TenantWide {
+			return out[i].TenantWide
+		}
+		return out[i].Skill < out[j].Skill
+	})
+	return out
+}
+
+func nextDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+func nextMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func formatAmount(limit string, v float64) string {
+	if strings.HasSuffix(limit, "_usd") {
+		return fmt.Sprintf("$%.2f", v)
+	}
+	return fmt.Sprintf("%.0f tokens", v)
+}
+
+// writeLimitError answers a refused call with a 429 in the provider's own
+// error shape, so the SDK in the box backs off (and surfaces the message) as
+// it would for the provider's own limit. Retry-After carries the wait.
+func writeLimitError(w http.ResponseWriter, prov *Provider, r *budgetRefusal) {
+	var body any
+	if prov.limitError != nil {
+		body = prov.limitError(r.message, r.quota)
+	} else {
+		body = map[string]any{"error": map[string]any{"message": r.message}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(r.retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// openAILimitError is the OpenAI 429 shape, also used for Google's
+// OpenAI-compatible surface.
+func openAILimitError(msg string, quota bool) any {
+	typ, code := "requests", "rate_limit_exceeded"
+	if quota {
+		typ, code = "insufficient_quota", "insufficient_quota"
+	}
+	return map[string]any{"error": map[string]any{"message": msg, "type": typ, "param": nil, "code": code}}
+}
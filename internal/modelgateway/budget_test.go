@@ -0,0 +1,292 @@
+package modelgateway
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// budgetRig is a gateway in front of a fake provider answering every call
+// with the given usage body, on a settable clock.
+type budgetRig struct {
+	t      *testing.T
+	secret []byte
+	gw     *Gateway
+	srv    *httptest.Server
+	now    time.Time
+	calls  int // upstream calls actually made
+}
+
+func newBudgetRig(t *testing.T, provider, body string, policy *BudgetPolicy, alerts BudgetAlertSink) *budgetRig {
+	t.Helper()
+	rig := &budgetRig{t: t, secret: []byte("s"), now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	up := fakeUpstream(t, func(*http.Request) { rig.calls++ }, body)
+	t.Cleanup(up.Close)
+	providers := DefaultProviders()
+	providers[provider].UpstreamURL = up.URL
+	rig.gw = New(Config{
+		Secret: rig.secret, Providers: providers, ProviderKeys: map[string]string{provider: "K"},
+		Budgets: policy, Alerts: alerts, Logger: testLogger(),
+	})
+	rig.gw.budgets.now = func() time.Time { return rig.now }
+	rig.srv = httptest.NewServer(rig.gw.Handler())
+	t.Cleanup(rig.srv.Close)
+	return rig
+}
+
+func testLogger() *log.Logger { return log.New(io.Discard, "", 0) }
+
+func (r *budgetRig) call(c GatewayClaims, path string) *http.Response {
+	r.t.Helper()
+	tok, err := MintToken(r.secret, c, time.Hour)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	req, _ := http.NewRequest("POST", r.srv.URL+"/v1/model/"+c.Provider+path, strings.NewReader(`{"model":"m"}`))
+	req.Header.Set("Authorization", "Bearer "+tok)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	r.t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func decodeBody(t *testing.T, resp *http.Response) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+type captureAlerts struct {
+	mu     sync.Mutex
+	alerts []BudgetAlert
+}
+
+func (c *captureAlerts) BudgetAlert(a BudgetAlert) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.alerts = append(c.alerts, a)
+}
+
+const anthropicUsage16 = `{"model":"claude-test","usage":{"input_tokens":12,"output_tokens":4}}`
+
+func TestBudget_HardDailyTokens_AnthropicShaped429UntilTomorrow(t *testing.T) {
+	rig := newBudgetRig(t, "anthropic", anthropicUsage16, nil, nil)
+	claims := GatewayClaims{Tenant: "acme", SkillID: "s1", Provider: "anthropic", Budget: &Budget{DailyTokens: 20}}
+
+	// 16 tokens spent is under 20, so the second call is admitted; it takes
+	// the scope to 32 and the third is refused without reaching upstream.
+	for i := 0; i < 2; i++ {
+		if resp := rig.call(claims, "/v1/messages"); resp.StatusCode != 200 {
+			t.Fatalf("call %d: status %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := rig.call(claims, "/v1/messages")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status %d, want 429", resp.StatusCode)
+	}
+	if rig.calls != 2 {
+		t.Errorf("upstream calls = %d, want 2 (a refused call must not reach the provider)", rig.calls)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "43200" {
+		t.Errorf("Retry-After = %q, want 43200 (seconds to UTC midnight)", got)
+	}
+	body := decodeBody(t, resp)
+	errObj, _ := body["error"].(map[string]any)
+	if body["type"] != "error" || errObj["type"] != "rate_limit_error" || !strings.Contains(errObj["message"].(string), "daily_tokens") {
+		t.Errorf("not an Anthropic-shaped rate-limit error: %v", body)
+	}
+
+	// Another skill of the same tenant has its own counter.
+	other := claims
+	other.SkillID = "s2"
+	if resp := rig.call(other, "/v1/messages"); resp.StatusCode != 200 {
+		t.Errorf("another skill was refused: %d", resp.StatusCode)
+	}
+
+	rig.now = rig.now.Add(12 * time.Hour)
+	if resp := rig.call(claims, "/v1/messages"); resp.StatusCode != 200 {
+		t.Errorf("the next UTC day still refused: %d", resp.StatusCode)
+	}
+}
+
+func TestBudget_RPMTokenBucket_OpenAIShaped(t *testing.T) {
+	rig := newBudgetRig(t, "openai", `{"model":"gpt-test","usage":{"prompt_tokens":1,"completion_tokens":1}}`, nil, nil)
+	claims := GatewayClaims{Tenant: "acme", Provider: "openai", Budget: &Budget{RequestsPerMinute: 2}}
+
+	for i := 0; i < 2; i++ {
+		if resp := rig.call(claims, "/v1/chat/completions"); resp.StatusCode != 200 {
+			t.Fatalf("call %d: status %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := rig.call(claims, "/v1/chat/completions")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status %d, want 429", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30 (one token refills in 60s/2)", got)
+	}
+	errObj, _ := decodeBody(t, resp)["error"].(map[string]any)
+	if errObj["code"] != "rate_limit_exceeded" || errObj["type"] != "requests" {
+		t.Errorf("not an OpenAI-shaped rate-limit error: %v", errObj)
+	}
+
+	rig.now = rig.now.Add(30 * time.Second)
+	if resp := rig.call(claims, "/v1/chat/completions"); resp.StatusCode != 200 {
+		t.Errorf("refilled bucket still refused: %d", resp.StatusCode)
+	}
+}
+
+func TestBudget_PolicyCostBudget_GeminiShaped(t *testing.T) {
+	policy := &BudgetPolicy{
+		Prices:  PriceTable{"gemini-2.5": {InputPerMTok: 1_000_000, OutputPerMTok: 0}}, // $1 per input token
+		Tenants: map[string]*TenantBudget{"acme": {Budget: Budget{MonthlyCostUSD: 5}}},
+	}
+	rig := newBudgetRig(t, "gemini", `{"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":1}}`, policy, nil)
+	claims := GatewayClaims{Tenant: "acme", SkillID: "s1", Provider: "gemini"}
+	path := "/v1beta/models/gemini-2.5-flash:generateContent"
+
+	for i := 0; i < 2; i++ { // $3, then $6 — over from the second call on
+		if resp := rig.call(claims, path); resp.StatusCode != 200 {
+			t.Fatalf("call %d: status %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := rig.call(claims, path)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status %d, want 429", resp.StatusCode)
+	}
+	errObj, _ := decodeBody(t, resp)["error"].(map[string]any)
+	if errObj["status"] != "RESOURCE_EXHAUSTED" || errObj["code"] != float64(429) {
+		t.Errorf("not a Gemini-shaped error: %v", errObj)
+	}
+
+	// The policy's tenant budget covers every skill of the tenant.
+	claims.SkillID = "s2"
+	if resp := rig.call(claims, path); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("tenant-wide budget not applied to another skill: %d", resp.StatusCode)
+	}
+	// Unlisted tenants are unaffected (no default).
+	if resp := rig.call(GatewayClaims{Tenant: "other", Provider: "gemini"}, path); resp.StatusCode != 200 {
+		t.Errorf("an unlisted tenant was refused: %d", resp.StatusCode)
+	}
+
+	rows := rig.gw.budgets.snapshot()
+	if len(rows) != 1 || !rows[0].TenantWide || rows[0].MonthlyCostUSD != 6 || rows[0].Month != "2026-10" {
+		t.Errorf("budget snapshot = %+v", rows)
+	}
+}
+
+func TestBudget_SoftModeAdmitsAndAlertsOnce(t *testing.T) {
+	alerts := &captureAlerts{}
+	rig := newBudgetRig(t, "anthropic", anthropicUsage16, nil, alerts)
+	claims := GatewayClaims{Tenant: "acme", SkillID: "s1", Provider: "anthropic",
+		Budget: &Budget{DailyTokens: 20, Mode: BudgetSoft}}
+
+	var last *http.Response
+	for i := 0; i < 4; i++ {
+		last = rig.call(claims, "/v1/messages")
+		if last.StatusCode != 200 {
+			t.Fatalf("call %d: soft budget refused with %d", i+1, last.StatusCode)
+		}
+	}
+	if got := last.Header.Get(budgetWarningHeader); got != limitDailyTokens {
+		t.Errorf("%s = %q, want %q", budgetWarningHeader, got, limitDailyTokens)
+	}
+	waitFor(t, "alert", func() bool {
+		alerts.mu.Lock()
+		defer alerts.mu.Unlock()
+		return len(alerts.alerts) > 0
+	})
+	alerts.mu.Lock()
+	defer alerts.mu.Unlock()
+	if len(alerts.alerts) != 1 {
+		t.Fatalf("alerts = %+v, want exactly one (on crossing)", alerts.alerts)
+	}
+	if a := alerts.alerts[0]; a.Tenant != "acme" || a.Skill != "s1" || a.Limit != limitDailyTokens || a.Used != 32 || a.Mode != BudgetSoft {
+		t.Errorf("alert = %+v", a)
+	}
+}
+
+func TestBudgetScopes_TighterOfPolicyAndToken(t *testing.T) {
+	policy := &BudgetPolicy{
+		Default: &Budget{DailyCostUSD: 10},
+		Tenants: map[string]*TenantBudget{"acme": {
+			Skills: map[string]*Budget{"s1": {DailyTokens: 1000, RequestsPerMinute: 60, Mode: BudgetSoft}},
+		}},
+	}
+	scopes := budgetScopes(policy, &GatewayClaims{Tenant: "acme", SkillID: "s1",
+		Budget: &Budget{DailyTokens: 500, RequestsPerMinute: 120}})
+	if len(scopes) != 1 {
+		t.Fatalf("scopes = %+v, want only the skill scope (acme has no tenant-wide limits)", scopes)
+	}
+	b := scopes[0].budget
+	if b.DailyTokens != 500 || b.RequestsPerMinute != 60 || b.Mode != BudgetHard {
+		t.Errorf("merged budget = %+v, want the tighter of each limit and hard", b)
+	}
+
+	scopes = budgetScopes(policy, &GatewayClaims{Tenant: "unlisted", SkillID: "x"})
+	if len(scopes) != 1 || !scopes[0].key.whole || scopes[0].budget.DailyCostUSD != 10 {
+		t.Errorf("unlisted tenant scopes = %+v, want the default tenant-wide budget", scopes)
+	}
+	if got := budgetScopes(nil, &GatewayClaims{Tenant: "acme"}); len(got) != 0 {
+		t.Errorf("no policy and no token budget should mean no scopes, got %+v", got)
+	}
+}
+
+func TestPriceTable_LongestPrefix(t *testing.T) {
+	prices := PriceTable{
+		"claude":          {InputPerMTok: 100},
+		"claude-sonnet-4": {InputPerMTok: 3, OutputPerMTok: 15, CachedPerMTok: 0.3},
+	}
+	got, ok := prices.cost(Usage{Model: "claude-sonnet-4-20250514", InputTokens: 1_000_000, OutputTokens: 100_000, CachedTokens: 1_000_000})
+	if !ok || got != 3+1.5+0.3 {
+		t.Errorf("cost = %v, %v; want 4.8 from the claude-sonnet-4 price", got, ok)
+	}
+	if _, ok := prices.cost(Usage{Model: "gpt-test"}); ok {
+		t.Error("an unpriced model reported a price")
+	}
+}
+
+func TestLoadBudgetPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	p, err := LoadBudgetPolicy(write("ok.json", `{
+		"prices": {"claude-sonnet-4": {"input_per_mtok": 3, "output_per_mtok": 15}},
+		"tenants": {"acme": {"monthly_cost_usd": 500, "skills": {"triage": {"daily_tokens": 2000000, "mode": "soft"}}}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Tenants["acme"].MonthlyCostUSD != 500 || p.Tenants["acme"].Skills["triage"].Mode != BudgetSoft {
+		t.Errorf("policy = %+v", p.Tenants["acme"])
+	}
+
+	for name, body := range map[string]string{
+		"typo.json":     `{"tenants": {"acme": {"daily_token": 5}}}`,
+		"mode.json":     `{"default": {"daily_tokens": 5, "mode": "loud"}}`,
+		"negative.json": `{"tenants": {"acme": {"skills": {"s": {"requests_per_minute": -1}}}}}`,
+	} {
+		if _, err := LoadBudgetPolicy(write(name, body)); err == nil {
+			t.Errorf("%s: loaded, want an error", name)
+		}
+	}
+}
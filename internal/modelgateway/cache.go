@@ -0,0 +1,176 @@
+package modelgateway
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Exact-match response cache (opt-in, Config.Cache). Agent crews replay
+// identical non-streaming calls — a retry after a lease redelivery, the same
+// tool-planning prompt across runs — and each one is billed again. With the
+// cache on, a repeat of a call the same tenant made on the same provider
+// within the TTL is answered from the gateway without reaching the provider,
+// metered as a zero-token call with Usage.CacheHit set and written to the
+// ledger as one with CallRecord.CacheHit.
+//
+// Only successful, non-streaming JSON answers are stored. The key is the
+// tenant, the provider, the upstream path and the request body normalised
+// (JSON re-encoded, so key order and whitespace do not matter); the model is
+// part of the body. A token minted with no_cache opts its calls out entirely.
+// Hits are served before budget admission: they spend nothing upstream.
+
+// CacheConfig sizes the response cache. Zero fields take the defaults.
+type CacheConfig struct {
+	TTL        time.Duration // how long an answer is served from the cache
+	MaxEntries int           // entries held before the least recently used is evicted
+	MaxBytes   int64         // response bytes held, likewise
+}
+
+const (
+	defaultCacheTTL        = 10 * time.Minute
+	defaultCacheMaxEntries = 1024
+	defaultCacheMaxBytes   = 64 << 20
+
+	// cacheHeader tells the caller whether a cacheable call was a hit or a
+	// miss. Absent when the call was not cacheable.
+	cacheHeader = "X-Containarium-Cache"
+)
+
+// cacheEntry is one stored answer. provider and model are what served it,
+// for the hit's metering.
+type cacheEntry struct {
+	key         string
+	body        []byte
+	contentType string
+	provider    string
+	model       string
+	expires     time.Time
+}
+
+// responseCache is an LRU bounded by entry count and bytes, with a TTL per
+// entry. Expired entries are dropped when looked up or evicted.
+type responseCache struct {
+	cfg   CacheConfig
+	now   func() time.Time
+	mu    sync.Mutex
+	ll    *list.List // front = most recently used
+	items map[string]*list.Element
+	bytes int64
+	hits  int64
+}
+
+func newResponseCache(cfg CacheConfig) *responseCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultCacheTTL
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultCacheMaxEntries
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultCacheMaxBytes
+	}
+	return &responseCache{cfg: cfg, now: time.Now, ll: list.New(), items: map[string]*list.Element{}}
+}
+
+// responseCacheKey keys a call, or reports it uncacheable: a body that is not
+// a JSON object, or a streaming request.
+func responseCacheKey(tenant, provider, path string, body []byte) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var m map[string]any
+	if dec.Decode(&m) != nil || m == nil {
+		return "", false
+	}
+	if s, _ := m["stream"].(bool); s {
+		return "", false
+	}
+	norm, err := json.Marshal(m) // map keys marshal sorted
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	for _, part := range [][]byte{[]byte(tenant), []byte(provider), []byte(path), norm} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+func (c *responseCache) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el := c.items[key]
+	if el == nil {
+		return nil
+	}
+	e := el.Value.(*cacheEntry)
+	if !c.now().Before(e.expires) {
+		c.remove(el)
+		return nil
+	}
+	c.ll.MoveToFront(el)
+	c.hits++
+	return e
+}
+
+// put stores an answer, evicting from the least recently used end until the
+// bounds hold. An answer larger than the whole byte bound is not stored.
+func (c *responseCache) put(e *cacheEntry) {
+	if int64(len(e.body)) > c.cfg.MaxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el := c.items[e.key]; el != nil {
+		c.remove(el)
+	}
+	e.expires = c.now().Add(c.cfg.TTL)
+	c.items[e.key] = c.ll.PushFront(e)
+	c.bytes += int64(len(e.body))
+	for c.ll.Len() > c.cfg.MaxEntries || c.bytes > c.cfg.MaxBytes {
+		c.remove(c.ll.Back())
+	}
+}
+
+func (c *responseCache) remove(el *list.Element) {
+	e := c.ll.Remove(el).(*cacheEntry)
+	delete(c.items, e.key)
+	c.bytes -= int64(len(e.body))
+}
+
+// stats is the cache's line on /__gateway/status.
+func (c *responseCache) stats() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return map[string]int64{"entries": int64(c.ll.Len()), "bytes": c.bytes, "hits": c.hits}
+}
+
+// serveCached answers a call from the cache and meters it as a zero-token
+// hit against the provider that originally served it.
+func (g *Gateway) serveCached(w http.ResponseWriter, claims *GatewayClaims, e *cacheEntry) {
+	start := time.Now()
+	w.Header().Set("Content-Type", e.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(e.body)))
+	w.Header().Set(cacheHeader, "hit")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(e.body)
+	g.cfg.Logger.Printf("model-gateway: CACHE hit tenant=%s skill=%s provider=%s model=%s",
+		claims.Tenant, claims.SkillID, e.provider, e.model)
+	g.recordUsage(claims, nil, e.provider, Usage{Model: e.model, CacheHit: true})
+	if g.cfg.Ledger != nil {
+		// A hit is still a call the box made: the ledger counts it, at zero
+		// provider tokens, so per-run attribution sees the whole run.
+		g.recordCall(CallRecord{
+			At: start, Tenant: claims.Tenant, Skill: claims.SkillID, RunID: claims.RunID,
+			Provider: e.provider, Model: e.model,
+			Latency: time.Since(start), Status: http.StatusOK, CacheHit: true,
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package modelgateway
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheKey(t *testing.T) {
+	key := func(tenant, provider, body string) string {
+		k, ok := responseCacheKey(tenant, provider, "/v1/messages", []byte(body))
+		if !ok {
+			t.Fatalf("%s: not cacheable", body)
+		}
+		return k
+	}
+	base := key("acme", "anthropic", `{"model":"m","messages":[{"role":"user","content":"hi"}],"max_tokens":10}`)
+	if k := key("acme", "anthropic", `{ "max_tokens": 10, "messages":[{"content":"hi","role":"user"}], "model":"m" }`); k != base {
+		t.Error("key order and whitespace changed the key")
+	}
+	for name, k := range map[string]string{
+		"another tenant":   key("globex", "anthropic", `{"model":"m","messages":[{"role":"user","content":"hi"}],"max_tokens":10}`),
+		"another provider": key("acme", "openai", `{"model":"m","messages":[{"role":"user","content":"hi"}],"max_tokens":10}`),
+		"another model":    key("acme", "anthropic", `{"model":"m2","messages":[{"role":"user","content":"hi"}],"max_tokens":10}`),
+	} {
+		if k == base {
+			t.Errorf("%s shares the key", name)
+		}
+	}
+	for _, body := range []string{`{"model":"m","stream":true}`, `not json`, `[1,2]`} {
+		if _, ok := responseCacheKey("acme", "anthropic", "/v1/messages", []byte(body)); ok {
+			t.Errorf("%s is cacheable", body)
+		}
+	}
+}
+
+func TestResponseCache_TTLAndBounds(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := newResponseCache(CacheConfig{TTL: time.Minute, MaxEntries: 2, MaxBytes: 10})
+	c.now = func() time.Time { return now }
+	put := func(key, body string) { c.put(&cacheEntry{key: key, body: []byte(body)}) }
+
+	put("a", "1234")
+	put("b", "1234")
+	if c.get("a") == nil {
+		t.Fatal("a missing")
+	}
+	put("c", "1234") // over MaxEntries: b is the least recently used
+	if c.get("b") != nil || c.get("a") == nil || c.get("c") == nil {
+		t.Error("entry bound evicted the wrong entry")
+	}
+	put("d", "12345678") // over MaxBytes: evict from the LRU end until it fits
+	if c.get("a") != nil || c.get("c") != nil || c.get("d") == nil {
+		t.Errorf("byte bound: %v", c.stats())
+	}
+	put("huge", "12345678901")
+	if c.get("huge") != nil {
+		t.Error("an answer over the byte bound was stored")
+	}
+
+	now = now.Add(time.Minute)
+	if c.get("d") != nil {
+		t.Error("expired entry served")
+	}
+	if s := c.stats(); s["entries"] != 0 || s["bytes"] != 0 {
+		t.Errorf("expired entry still held: %v", s)
+	}
+}
+
+// A replay of a non-streaming call is answered without the provider and
+// metered as a zero-token hit; a no_cache token always reaches the provider.
+func TestGateway_CacheServesRepeatAsZeroCostHit(t *testing.T) {
+	secret := []byte("s")
+	var upstreamCalls atomic.Int64
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		upstreamCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"model":"claude-test","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":12,"output_tokens":4}}`)
+	}))
+	defer up.Close()
+	providers := DefaultProviders()
+	providers["anthropic"].UpstreamURL = up.URL
+	sink := &captureSink{}
+	ledger := &captureLedger{}
+	gw := New(Config{Secret: secret, Providers: providers, ProviderKeys: map[string]string{"anthropic": "K"},
+		Sink: sink, Ledger: ledger, Cache: &CacheConfig{TTL: time.Minute}})
+	srv := httptest.NewServer(gw.Handler())
+	defer srv.Close()
+
+	call := func(claims GatewayClaims, body string) (string, []byte) {
+		tok, _ := MintToken(secret, claims, time.Minute)
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/model/anthropic/v1/messages", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+tok)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			t.Fatalf("status %d", resp.StatusCode)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		return resp.Header.Get(cacheHeader), b
+	}
+	claims := GatewayClaims{Tenant: "acme", SkillID: "s1", Provider: "anthropic"}
+	h1, b1 := call(claims, `{"model":"claude-test","max_tokens":10}`)
+	h2, b2 := call(claims, `{"max_tokens":10, "model":"claude-test"}`)
+	if h1 != "miss" || h2 != "hit" || upstreamCalls.Load() != 1 || !bytes.Equal(b1, b2) {
+		t.Fatalf("headers %q/%q, upstream calls %d: want miss then hit from one provider call", h1, h2, upstreamCalls.Load())
+	}
+	if !sink.u.CacheHit || sink.u.InputTokens != 0 || sink.u.Model != "claude-test" || sink.calls != 2 {
+		t.Errorf("sink should get the hit as zero-token usage: %+v (calls %d)", sink.u, sink.calls)
+	}
+	snap := gw.Meter().Snapshot()
+	if len(snap) != 1 || snap[0].Calls != 2 || snap[0].CacheHits != 1 || snap[0].InputTokens != 12 {
+		t.Errorf("meter: %+v", snap)
+	}
+	// The ledger sees both calls, the hit at zero provider tokens. The miss
+	// is written once its handler returns, which may be after the hit's.
+	waitFor(t, "both calls recorded", func() bool { return len(ledger.snapshot()) == 2 })
+	calls := ledger.snapshot()
+	hit := slices.IndexFunc(calls, func(c CallRecord) bool { return c.CacheHit })
+	if len(calls) != 2 || hit < 0 || calls[1-hit].CacheHit || calls[1-hit].InputTokens != 12 {
+		t.Fatalf("ledger: %+v", calls)
+	}
+	if c := calls[hit]; c.InputTokens != 0 || c.OutputTokens != 0 || c.Provider != "anthropic" ||
+		c.Model != "claude-test" || c.Tenant != "acme" || c.Skill != "s1" || c.Status != 200 {
+		t.Errorf("cache hit not recorded as a zero-token call: %+v", c)
+	}
+
+	claims.NoCache = true
+	if h, _ := call(claims, `{"model":"claude-test","max_tokens":10}`); h != "" || upstreamCalls.Load() != 2 {
+		t.Errorf("no_cache token: header %q, upstream calls %d", h, upstreamCalls.Load())
+	}
+}
@@ -0,0 +1,119 @@
+package modelgateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// FakeProvider is a local stand-in for a provider API, for exercising routes
+// and failover without real keys or spend. It answers both chat schemas —
+// Anthropic at .../messages, OpenAI-style at .../chat/completions — echoing
+// the requested model, and streams when asked to. Point a provider's
+// UpstreamURL at it (`model-gateway serve --upstream`).
+type FakeProvider struct {
+	// Name goes into every reply, so a caller can tell which upstream
+	// answered.
+	Name string
+	// FailStatus, when non-zero, is returned for every call instead (e.g.
+	// 429 or 503, to make the gateway fail over).
+	FailStatus int
+
+	calls atomic.Int64
+}
+
+// Calls is how many requests the fake has received.
+func (f *FakeProvider) Calls() int64 { return f.calls.Load() }
+
+// Fake usage per reply.
+const (
+	fakeInputTokens  = 10
+	fakeOutputTokens = 5
+)
+
+func (f *FakeProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls.Add(1)
+	var req struct {
+		Model  string `json:"model"`
+		Stream bool   `json:"stream"`
+	}
+	raw, _ := io.ReadAll(r.Body)
+	_ = json.Unmarshal(raw, &req)
+	text := fmt.Sprintf("fake reply from %s (%s)", f.Name, req.Model)
+	anthropic := strings.HasSuffix(r.URL.Path, "/messages")
+	if !anthropic && !strings.HasSuffix(r.URL.Path, "/chat/completions") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if f.FailStatus != 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.FailStatus)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+			"type": "fake_error", "message": fmt.Sprintf("%s is failing with %d", f.Name, f.FailStatus),
+		}})
+		return
+	}
+	if req.Stream {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, ev := range fakeStream(anthropic, req.Model, text) {
+			_, _ = io.WriteString(w, ev)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if anthropic {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id": "msg_fake", "type": "message", "role": "assistant", "model": req.Model,
+			"content":     []any{map[string]any{"type": "text", "text": text}},
+			"stop_reason": "end_turn",
+			"usage":       map[string]any{"input_tokens": fakeInputTokens, "output_tokens": fakeOutputTokens},
+		})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id": "chatcmpl-fake", "object": "chat.completion", "model": req.Model,
+		"choices": []any{map[string]any{"index": 0, "finish_reason": "stop",
+			"message": map[string]any{"role": "assistant", "content": text}}},
+		"usage": map[string]any{"prompt_tokens": fakeInputTokens, "completion_tokens": fakeOutputTokens,
+			"total_tokens": fakeInputTokens + fakeOutputTokens},
+	})
+}
+
+// fakeStream is a minimal SSE reply in either schema, ending with the usage
+// event the gateway meters.
+func fakeStream(anthropic bool, model, text string) []string {
+	data := func(v any) string {
+		b, _ := json.Marshal(v)
+		return "data: " + string(b) + "\n\n"
+	}
+	if anthropic {
+		return []string{
+			"event: message_start\n" + data(map[string]any{"type": "message_start", "message": map[string]any{
+				"id": "msg_fake", "type": "message", "role": "assistant", "model": model, "content": []any{},
+				"usage": map[string]any{"input_tokens": fakeInputTokens, "output_tokens": 0}}}),
+			"event: content_block_start\n" + data(map[string]any{"type": "content_block_start", "index": 0,
+				"content_block": map[string]any{"type": "text", "text": ""}}),
+			"event: content_block_delta\n" + data(map[string]any{"type": "content_block_delta", "index": 0,
+				"delta": map[string]any{"type": "text_delta", "text": text}}),
+			"event: content_block_stop\n" + data(map[string]any{"type": "content_block_stop", "index": 0}),
+			"event: message_delta\n" + data(map[string]any{"type": "message_delta",
+				"delta": map[string]any{"stop_reason": "end_turn"}, "usage": map[string]any{"output_tokens": fakeOutputTokens}}),
+			"event: message_stop\n" + data(map[string]any{"type": "message_stop"}),
+		}
+	}
+	chunk := func(delta map[string]any, finish any) map[string]any {
+		return map[string]any{"id": "chatcmpl-fake", "object": "chat.completion.chunk", "model": model,
+			"choices": []any{map[string]any{"index": 0, "delta": delta, "finish_reason": finish}}}
+	}
+	return []string{
+		data(chunk(map[string]any{"role": "assistant", "content": text}, nil)),
+		data(chunk(map[string]any{}, "stop")),
+		data(map[string]any{"id": "chatcmpl-fake", "object": "chat.completion.chunk", "model": model, "choices": []any{},
+			"usage": map[string]any{"prompt_tokens": fakeInputTokens, "completion_tokens": fakeOutputTokens}}),
+		"data: [DONE]\n\n",
+	}
+}
@@ -2,6 +2,7 @@ package modelgateway
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -31,19 +32,35 @@ type Config struct {
 	Providers    map[string]*Provider // provider registry (see DefaultProviders)
 	ProviderKeys map[string]string    // provider name -> REAL API key, held here only
 	Sink         UsageSink            // optional: durable/billing usage writer (nil = in-memory only)
+	Ledger       CallLedger           // optional: per-call usage ledger (nil = none)
 	Logger       *log.Logger
 	// OutputFilter enables prompt-exfiltration redaction on the streaming chat
 	// path (a hold-back window over the assistant text; #670 layer 2). Streaming
 	// token metering is independent and always on. Fail-open regardless.
 	OutputFilter bool
+	// Budgets is the daemon-side budget policy (see BudgetPolicy); nil means
+	// only budgets carried in gateway tokens apply. Alerts optionally
+	// receives budget alerts on top of the log line each one gets.
+	Budgets *BudgetPolicy
+	Alerts  BudgetAlertSink
+	// Routes is the model routing table (aliases, fallback chains, weighted
+	// splits; see RoutingPolicy). Nil proxies every call to the token's
+	// provider as is.
+	Routes *RoutingPolicy
+	// Cache turns on the exact-match response cache (see CacheConfig); nil
+	// leaves it off.
+	Cache *CacheConfig
 }
 
 // Gateway brokers every agent box's model calls: it authenticates the box's
 // scoped gateway token, injects the real provider key (which never leaves the
 // gateway), proxies to the provider, and meters per-tenant token usage.
 type Gateway struct {
-	cfg   Config
-	meter *Meter
+	cfg     Config
+	meter   *Meter
+	budgets *budgetLedger
+	pick    func(n int) int // weighted-split draw; replaced in tests
+	cache   *responseCache  // nil unless Config.Cache is set
 
 	// Request-lifecycle observability: a monotonic request id, a live
 	// in-flight gauge, and lifetime completed/failed counters. These make
@@ -61,7 +78,11 @@ func New(cfg Config) *Gateway {
 	if cfg.Logger == nil {
 		cfg.Logger = log.Default()
 	}
-	return &Gateway{cfg: cfg, meter: NewMeter()}
+	g := &Gateway{cfg: cfg, meter: NewMeter(), budgets: newBudgetLedger(), pick: pickWeighted}
+	if cfg.Cache != nil {
+		g.cache = newResponseCache(*cfg.Cache)
+	}
+	return g
 }
 
 // Meter exposes the usage rollups (for tests / the usage endpoint).
@@ -78,6 +99,11 @@ func (g *Gateway) Handler() http.Handler {
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(g.meter.Snapshot())
 	})
+	// Spend against budgets in the current day/month windows, per scope.
+	mux.HandleFunc("/__gateway/budgets", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(g.budgets.snapshot())
+	})
 	mux.HandleFunc("/__gateway/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
@@ -88,11 +114,15 @@ func (g *Gateway) Handler() http.Handler {
 	// signal the per-request START/END logs let you drill into.
 	mux.HandleFunc("/__gateway/status", func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(map[string]any{
+		status := map[string]any{
 			"inflight":  g.inflight.Load(),
 			"completed": g.completed.Load(),
 			"failed":    g.failed.Load(),
-		})
+		}
+		if g.cache != nil {
+			status["cache"] = g.cache.stats()
+		}
+		_ = json.NewEncoder(w).Encode(status)
 	})
 	return mux
 }
@@ -145,8 +175,35 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Routing: a chat call for a model with a route goes to the route's
+	// targets through failoverTransport, not straight to provName.
+	var plan []RouteTarget
+	routeModel := ""
+	if g.cfg.Routes != nil && prov.schema != "" && r.Method == http.MethodPost && upstreamPath == prov.chatPath {
+		raw, rerr := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if rerr != nil {
+			http.Error(w, "read request body: "+rerr.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+		if m := requestModel(raw); g.cfg.Routes.Routes[m] != nil {
+			if len(claims.AllowedModels) > 0 && !contains(claims.AllowedModels, m) {
+				http.Error(w, "model not allowed by token: "+m, http.StatusForbidden)
+				return
+			}
+			_, streaming := ensureStreamUsage(raw)
+			plan = routePlan(g.cfg.Routes.Routes[m], g.cfg.Providers, prov.schema, streaming, g.pick)
+			if len(plan) == 0 {
+				http.Error(w, "no route target for model "+m+" speaks this client's schema (streaming calls are not translated)", http.StatusBadGateway)
+				return
+			}
+			routeModel = m
+		}
+	}
+
 	key := g.cfg.ProviderKeys[provName]
-	if key == "" {
+	if key == "" && plan == nil {
 		http.Error(w, "gateway holds no key for provider "+provName, http.StatusBadGateway)
 		return
 	}
@@ -162,6 +219,42 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Response cache: a repeat of a cached call is answered here, before
+	// budgets (it spends nothing upstream) and without a provider call.
+	cacheKey := ""
+	if g.cache != nil && !claims.NoCache && r.Method == http.MethodPost {
+		raw, rerr := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if rerr != nil {
+			http.Error(w, "read request body: "+rerr.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+		if k, ok := responseCacheKey(claims.Tenant, provName, upstreamPath, raw); ok {
+			if e := g.cache.get(k); e != nil {
+				g.serveCached(w, claims, e)
+				return
+			}
+			cacheKey = k
+		}
+	}
+
+	// Budgets + rate limits, before any upstream spend: a hard limit refuses
+	// with a 429 in the provider's own shape; a soft one is flagged on the
+	// response and alerted on.
+	scopes := budgetScopes(g.cfg.Budgets, claims)
+	refusal, warnings, alerts := g.budgets.admit(scopes)
+	g.alert(alerts)
+	if refusal != nil {
+		g.cfg.Logger.Printf("model-gateway: REFUSED tenant=%s skill=%s provider=%s limit=%s retry_after=%s",
+			claims.Tenant, claims.SkillID, provName, refusal.limit, refusal.retryAfter.Round(time.Second))
+		writeLimitError(w, prov, refusal)
+		return
+	}
+	if len(warnings) > 0 {
+		w.Header().Set(budgetWarningHeader, strings.Join(warnings, ","))
+	}
+
 	upstream, err := url.Parse(prov.UpstreamURL)
 	if err != nil {
 		http.Error(w, "bad upstream url", http.StatusInternalServerError)
@@ -192,6 +285,9 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 	if logModel == "" {
 		logModel = pathModel
 	}
+	if logModel == "" {
+		logModel = routeModel
+	}
 	// Request-lifecycle capture, read after ServeHTTP returns. Mutex-guarded
 	// because the streaming usage callback runs in filterSSEStream's goroutine
 	// (the non-streaming path sets them inline in ModifyResponse).
@@ -204,6 +300,11 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 	markStreamed := func() { mu.Lock(); streamed = true; mu.Unlock() }
 	captureUsage := func(u Usage) { mu.Lock(); metered = true; lastU = u; mu.Unlock() }
 
+	// servedName/servedModel are the provider and model that answered: the
+	// token's provider, or the route target failoverTransport settled on.
+	// Set before ModifyResponse runs, in the same goroutine.
+	servedName, servedModel := provName, ""
+
 	proxy := &httputil.ReverseProxy{
 		// Flush streamed (SSE) responses promptly so chat tokens aren't buffered.
 		FlushInterval: -1,
@@ -226,7 +327,9 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 			// Streaming (SSE): intercept to meter usage + redact prompt leakage.
 			if strings.Contains(resp.Header.Get("Content-Type"), "text/event-stream") {
 				meterModel := reqModel
-				if meterModel == "" {
+				if servedModel != "" {
+					meterModel = servedModel
+				} else if meterModel == "" {
 					meterModel = pathModel
 				}
 				markStreamed()
@@ -234,10 +337,7 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 					if u.Model == "" {
 						u.Model = meterModel
 					}
-					g.meter.record(claims.Tenant, claims.SkillID, provName, u)
-					if g.cfg.Sink != nil {
-						g.cfg.Sink.RecordUsage(claims.Tenant, claims.SkillID, provName, u)
-					}
+					g.recordUsage(claims, scopes, servedName, u)
 					captureUsage(u) // folded into the END lifecycle log
 				}
 				pr, pw := io.Pipe()
@@ -248,6 +348,9 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 				resp.ContentLength = -1
 				return nil
 			}
+			if cacheKey != "" {
+				resp.Header.Set(cacheHeader, "miss")
+			}
 			// Metering on non-streaming, uncompressed JSON. Compressed bodies
 			// pass through unmetered.
 			if resp.Header.Get("Content-Encoding") != "" {
@@ -268,10 +371,7 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 			var decoded map[string]any
 			if json.Unmarshal(body, &decoded) == nil {
 				u := prov.parseUsage(decoded, pathModel)
-				g.meter.record(claims.Tenant, claims.SkillID, provName, u)
-				if g.cfg.Sink != nil {
-					g.cfg.Sink.RecordUsage(claims.Tenant, claims.SkillID, provName, u)
-				}
+				g.recordUsage(claims, scopes, servedName, u)
 				captureUsage(u) // folded into the END lifecycle log
 
 				// Normalize Gemini's non-conformant tool-call finish_reason
@@ -280,11 +380,16 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 				// when a tool call is actually present; normal responses untouched.
 				if (provName == "openai" || provName == "gemini-openai") && normalizeNonStreamToolFinish(decoded) {
 					if nb, merr := json.Marshal(decoded); merr == nil {
+						body = nb
 						resp.Body = io.NopCloser(bytes.NewReader(nb))
 						resp.ContentLength = int64(len(nb))
 						resp.Header.Set("Content-Length", strconv.Itoa(len(nb)))
 					}
 				}
+				if cacheKey != "" && resp.StatusCode == http.StatusOK {
+					g.cache.put(&cacheEntry{key: cacheKey, body: body, contentType: resp.Header.Get("Content-Type"),
+						provider: servedName, model: u.Model})
+				}
 			}
 			return nil
 		},
@@ -293,6 +398,13 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	var routed *failoverTransport
+	if plan != nil {
+		routed = &failoverTransport{g: g, base: http.DefaultTransport, entry: prov, plan: plan,
+			served: func(p *Provider, model string) { servedName, servedModel = p.Name, model }}
+		proxy.Transport = routed
+	}
+
 	// --- request lifecycle: START → serve → END ---
 	// Every accepted request gets a START and a matching END line keyed by a
 	// monotonic req id, plus the live inflight gauge. For a streaming response
@@ -301,6 +413,10 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 	reqID := g.reqSeq.Add(1)
 	start := time.Now()
 	inflight := g.inflight.Add(1)
+	defer g.inflight.Add(-1) // a panic in the proxy must not leave the gauge up
+	if routed != nil {
+		routed.reqID = reqID
+	}
 	g.cfg.Logger.Printf("model-gateway: req=%d START tenant=%s skill=%s provider=%s model=%s inflight=%d",
 		reqID, claims.Tenant, claims.SkillID, provName, logModel, inflight)
 
@@ -326,7 +442,70 @@ func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
 	}
 	g.cfg.Logger.Printf("model-gateway: req=%d END status=%s http=%d stream=%t dur=%s in=%d out=%d cached=%d inflight=%d%s",
 		reqID, status, sw.status, st, dur.Round(time.Millisecond),
-		lu.InputTokens, lu.OutputTokens, lu.CachedTokens, g.inflight.Add(-1), warn)
+		lu.InputTokens, lu.OutputTokens, lu.CachedTokens, g.inflight.Load()-1, warn)
+
+	if g.cfg.Ledger != nil {
+		model := lu.Model
+		if !md || model == "" {
+			model = logModel
+			if servedModel != "" {
+				model = servedModel
+			}
+		}
+		g.recordCall(CallRecord{
+			At: start, Tenant: claims.Tenant, Skill: claims.SkillID, RunID: claims.RunID,
+			Provider: servedName, Model: model,
+			InputTokens: lu.InputTokens, OutputTokens: lu.OutputTokens, CachedTokens: lu.CachedTokens,
+			Latency: dur, Status: sw.status, Streamed: st,
+		})
+	}
+}
+
+// ledgerWriteTimeout bounds one ledger write. The response has already gone
+// out, so a slow database only delays this handler goroutine.
+const ledgerWriteTimeout = 5 * time.Second
+
+// recordCall writes one call to the ledger. A failed write is logged, not
+// retried: the Meter and Sink still saw the usage.
+func (g *Gateway) recordCall(c CallRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), ledgerWriteTimeout)
+	defer cancel()
+	if err := g.cfg.Ledger.RecordCall(ctx, c); err != nil {
+		g.cfg.Logger.Printf("model-gateway: WARNING usage ledger write failed tenant=%s run=%s: %v", c.Tenant, c.RunID, err)
+	}
+}
+
+// budgetWarningHeader lists, on an admitted response, the soft limits the
+// caller is already over.
+const budgetWarningHeader = "X-Containarium-Budget-Warning"
+
+// recordUsage meters one call: the in-memory rollup, the optional durable
+// sink, and the budget counters of every scope it was admitted under.
+func (g *Gateway) recordUsage(claims *GatewayClaims, scopes []budgetScope, provider string, u Usage) {
+	g.meter.record(claims.Tenant, claims.SkillID, provider, u)
+	if g.cfg.Sink != nil {
+		g.cfg.Sink.RecordUsage(claims.Tenant, claims.SkillID, provider, u)
+	}
+	var prices PriceTable
+	if g.cfg.Budgets != nil {
+		prices = g.cfg.Budgets.Prices
+	}
+	alerts, unpriced := g.budgets.record(scopes, prices, u)
+	if unpriced {
+		g.cfg.Logger.Printf("model-gateway: WARNING no price for model %q — its calls count $0 against cost budgets", u.Model)
+	}
+	g.alert(alerts)
+}
+
+// alert logs each budget alert and forwards it to the configured sink.
+func (g *Gateway) alert(alerts []BudgetAlert) {
+	for _, a := range alerts {
+		g.cfg.Logger.Printf("model-gateway: BUDGET tenant=%s skill=%s limit=%s used=%g max=%g mode=%s",
+			a.Tenant, a.Skill, a.Limit, a.Used, a.Max, a.Mode)
+		if g.cfg.Alerts != nil {
+			g.cfg.Alerts.BudgetAlert(a)
+		}
+	}
 }
 
 // statusWriter wraps http.ResponseWriter to capture the response status code for
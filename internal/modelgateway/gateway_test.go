@@ -3,6 +3,7 @@ package modelgateway
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -256,6 +258,76 @@ func TestGateway_NilSinkIsSafe(t *testing.T) {
 	}
 }
 
+// captureLedger records the calls the gateway writes to a CallLedger.
+type captureLedger struct {
+	mu    sync.Mutex
+	calls []CallRecord
+}
+
+func (l *captureLedger) RecordCall(_ context.Context, c CallRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, c)
+	return nil
+}
+
+func (l *captureLedger) snapshot() []CallRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]CallRecord(nil), l.calls...)
+}
+
+// Every proxied call — failed ones included — lands in the ledger with the
+// token's run attribution, which the Meter and Sink do not carry.
+func TestGateway_RecordsCallsInLedger(t *testing.T) {
+	secret := []byte("s")
+	var fail atomic.Bool
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"claude-test","usage":{"input_tokens":12,"output_tokens":4,"cache_read_input_tokens":2}}`))
+	}))
+	defer up.Close()
+	providers := DefaultProviders()
+	providers["anthropic"].UpstreamURL = up.URL
+	ledger := &captureLedger{}
+	gw := New(Config{Secret: secret, Providers: providers, ProviderKeys: map[string]string{"anthropic": "K"}, Ledger: ledger})
+	srv := httptest.NewServer(gw.Handler())
+	defer srv.Close()
+
+	tok, _ := MintToken(secret, GatewayClaims{Tenant: "acme", SkillID: "s1", RunID: "run-1", Provider: "anthropic"}, time.Minute)
+	call := func() {
+		req, _ := http.NewRequest("POST", srv.URL+"/v1/model/anthropic/v1/messages", strings.NewReader(`{"model":"claude-test"}`))
+		req.Header.Set("Authorization", "Bearer "+tok)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	call()
+	fail.Store(true)
+	waitFor(t, "first call recorded", func() bool { return len(ledger.snapshot()) == 1 })
+	call()
+	waitFor(t, "second call recorded", func() bool { return len(ledger.snapshot()) == 2 })
+
+	calls := ledger.snapshot()
+	ok := calls[0]
+	if ok.Tenant != "acme" || ok.Skill != "s1" || ok.RunID != "run-1" || ok.Provider != "anthropic" || ok.Model != "claude-test" {
+		t.Errorf("attribution wrong: %+v", ok)
+	}
+	if ok.InputTokens != 12 || ok.OutputTokens != 4 || ok.CachedTokens != 2 || ok.Status != 200 || ok.At.IsZero() {
+		t.Errorf("metered call wrong: %+v", ok)
+	}
+	if bad := calls[1]; bad.Status != http.StatusBadGateway || bad.RunID != "run-1" {
+		t.Errorf("failed call wrong: %+v", bad)
+	}
+}
+
 func TestGateway_Gemini_PathModel_AllowedModelsEnforced(t *testing.T) {
 	secret := []byte("s")
 	var gKey string
@@ -1,8 +1,10 @@
 package modelgateway
 
 import (
+	"context"
 	"sort"
 	"sync"
+	"time"
 )
 
 // meterKey attributes usage to a tenant/skill/provider/model.
@@ -20,6 +22,7 @@ type MeterRow struct {
 	InputTokens  int64  `json:"input_tokens"`
 	OutputTokens int64  `json:"output_tokens"`
 	CachedTokens int64  `json:"cached_tokens"`
+	CacheHits    int64  `json:"cache_hits,omitempty"` // calls answered from the response cache
 }
 
 // Meter is the in-memory per-tenant model-token writer the metering plane lacks
@@ -46,6 +49,9 @@ func (m *Meter) record(tenant, skill, provider string, u Usage) {
 	r.InputTokens += u.InputTokens
 	r.OutputTokens += u.OutputTokens
 	r.CachedTokens += u.CachedTokens
+	if u.CacheHit {
+		r.CacheHits++
+	}
 }
 
 // Snapshot returns the rollups in a stable order.
@@ -67,3 +73,32 @@ func (m *Meter) Snapshot() []MeterRow {
 	})
 	return out
 }
+
+// CallRecord is one proxied model call as the durable usage ledger stores it:
+// the attribution from the gateway token, the metered tokens (zero when the
+// provider reported none, e.g. a failed call or a cache hit), and how the
+// call went.
+type CallRecord struct {
+	At           time.Time
+	Tenant       string
+	Skill        string
+	RunID        string
+	Provider     string
+	Model        string
+	InputTokens  int64
+	OutputTokens int64
+	CachedTokens int64
+	Latency      time.Duration
+	Status       int // HTTP status the gateway returned to the box
+	Streamed     bool
+	CacheHit     bool // answered from the response cache; Provider and Model are the cached call's
+}
+
+// CallLedger durably records every call the gateway proxies, so usage
+// survives a restart and can be billed and attributed per run — the Meter is
+// a live in-memory readout, the Sink a counter stream. The daemon wires a
+// Postgres-backed ledger; nil records nothing. Kept an interface so
+// modelgateway stays free of a database dependency.
+type CallLedger interface {
+	RecordCall(ctx context.Context, c CallRecord) error
+}
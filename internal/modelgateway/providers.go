@@ -21,6 +21,15 @@ type Provider struct {
 	// pathModel is the model id recovered from the request path (Gemini puts
 	// the model in the URL, not the response body).
 	parseUsage func(body map[string]any, pathModel string) Usage
+	// limitError builds the body of a 429 the gateway itself answers (a
+	// budget or rate limit), in the provider's native error shape. quota is
+	// true for a spend budget, false for the request rate.
+	limitError func(msg string, quota bool) any
+	// schema and chatPath make a provider routable (see RoutingPolicy): the
+	// chat wire format it speaks and the upstream path of its chat endpoint.
+	// Empty for native Gemini, whose model lives in the URL.
+	schema   chatSchema
+	chatPath string
 }
 
 // Usage is the metered token counts for one model call.
@@ -29,6 +38,9 @@ type Usage struct {
 	InputTokens  int64
 	OutputTokens int64
 	CachedTokens int64
+	// CacheHit marks a call answered from the gateway's response cache: no
+	// provider call, so the token counts are zero.
+	CacheHit bool
 }
 
 func stripGatewayAuth(h http.Header) {
@@ -72,6 +84,11 @@ func DefaultProviders() map[string]*Provider {
 					CachedTokens: num(u, "cache_read_input_tokens"),
 				}
 			},
+			limitError: func(msg string, _ bool) any {
+				return map[string]any{"type": "error", "error": map[string]any{"type": "rate_limit_error", "message": msg}}
+			},
+			schema:   schemaAnthropic,
+			chatPath: "/v1/messages",
 		},
 		"openai": {
 			Name:        "openai",
@@ -90,6 +107,9 @@ func DefaultProviders() map[string]*Provider {
 					OutputTokens: num(u, "completion_tokens"),
 				}
 			},
+			limitError: openAILimitError,
+			schema:     schemaOpenAI,
+			chatPath:   "/v1/chat/completions",
 		},
 		"gemini": {
 			Name:        "gemini",
@@ -108,6 +128,9 @@ func DefaultProviders() map[string]*Provider {
 					CachedTokens: num(u, "cachedContentTokenCount"),
 				}
 			},
+			limitError: func(msg string, _ bool) any {
+				return map[string]any{"error": map[string]any{"code": 429, "message": msg, "status": "RESOURCE_EXHAUSTED"}}
+			},
 		},
 		// gemini-openai brokers Gemini through Google's *OpenAI-compatible*
 		// surface (/v1beta/openai/...), not the native generateContent API. It
@@ -137,6 +160,9 @@ func DefaultProviders() map[string]*Provider {
 					OutputTokens: num(u, "completion_tokens"),
 				}
 			},
+			limitError: openAILimitError,
+			schema:     schemaOpenAI,
+			chatPath:   "/v1beta/openai/chat/completions",
 		},
 	}
 }
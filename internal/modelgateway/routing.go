@@ -0,0 +1,269 @@
+package modelgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+)
+
+// Model routing and provider failover. A gateway token binds a box to one
+// provider, and without routing a 429 or 5xx from that provider fails the
+// agent. A RoutingPolicy maps the model a box asks for to an ordered list of
+// provider/model targets:
+//
+//   - an alias is a route with one target ("fast" -> claude-haiku-4-5);
+//   - a fallback chain lists several: a retryable failure (transport error,
+//     429, 5xx) on one target moves the call to the next;
+//   - a weighted split gives targets weights: one weighted target is picked
+//     by weight to go first, and the rest follow in listed order.
+//
+// Routing applies to the chat endpoint of the token's provider (Anthropic
+// messages, OpenAI-style chat completions). A target speaking the other chat
+// schema gets a translated request, and its response is translated back, so
+// the box keeps one client. A streaming call is only routed to targets of the
+// box's own schema; failover happens before any byte reaches the box, so it
+// is invisible to the client. The token's AllowedModels ceiling applies to
+// the model the box asked for; the operator's routes decide what serves it.
+
+// RouteTarget is one provider/model a route can send a call to.
+type RouteTarget struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	// Weight puts the target in the route's weighted split. Zero = fallback
+	// only.
+	Weight int `json:"weight,omitempty"`
+}
+
+// Route is the targets a requested model resolves to.
+type Route struct {
+	Targets []RouteTarget `json:"targets"`
+}
+
+// RoutingPolicy is the daemon-side routing table, loaded from a JSON file
+// (LoadRoutingPolicy) and keyed by the model name a box requests:
+//
+//	{
+//	  "routes": {
+//	    "fast":            {"targets": [{"provider": "anthropic", "model": "claude-haiku-4-5"}]},
+//	    "claude-sonnet-4": {"targets": [{"provider": "anthropic", "model": "claude-sonnet-4"},
+//	                                    {"provider": "openai", "model": "gpt-4.1"}]},
+//	    "balanced":        {"targets": [{"provider": "openai", "model": "gpt-4.1-mini", "weight": 80},
+//	                                    {"provider": "gemini-openai", "model": "gemini-2.5-flash", "weight": 20}]}
+//	  }
+//	}
+//
+// A requested model with no route is proxied to the token's provider as is.
+type RoutingPolicy struct {
+	Routes map[string]*Route `json:"routes"`
+}
+
+// LoadRoutingPolicy reads a RoutingPolicy file and validates it against
+// providers. Unknown fields are an error, and so is a target on a provider
+// that is not registered or has no chat endpoint to route to.
+func LoadRoutingPolicy(path string, providers map[string]*Provider) (*RoutingPolicy, error) {
+	raw, err := os.ReadFile(path) // #nosec G304 — operator-supplied config path
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var p RoutingPolicy
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("parse routing policy %s: %w", path, err)
+	}
+	if err := p.validate(providers); err != nil {
+		return nil, fmt.Errorf("routing policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+func (p *RoutingPolicy) validate(providers map[string]*Provider) error {
+	for model, rt := range p.Routes {
+		if rt == nil || len(rt.Targets) == 0 {
+			return fmt.Errorf("route %s: no targets", model)
+		}
+		for i, t := range rt.Targets {
+			prov := providers[t.Provider]
+			switch {
+			case prov == nil:
+				return fmt.Errorf("route %s target %d: unknown provider %q", model, i, t.Provider)
+			case prov.schema == "":
+				return fmt.Errorf("route %s target %d: provider %s has no routable chat endpoint", model, i, t.Provider)
+			case t.Model == "":
+				return fmt.Errorf("route %s target %d: model is required", model, i)
+			case t.Weight < 0:
+				return fmt.Errorf("route %s target %d: weight must not be negative", model, i)
+			}
+		}
+	}
+	return nil
+}
+
+// routePlan orders rt's targets for one call: the weighted pick (pick(n)
+// returns a number in [0, n)) first, then the rest in listed order. Targets
+// the call cannot use are dropped — cross-schema ones when streaming.
+func routePlan(rt *Route, providers map[string]*Provider, entry chatSchema, streaming bool, pick func(int) int) []RouteTarget {
+	targets := rt.Targets
+	total := 0
+	for _, t := range targets {
+		total += t.Weight
+	}
+	if total > 0 {
+		n := pick(total)
+		for i, t := range targets {
+			if n -= t.Weight; n < 0 {
+				targets = append([]RouteTarget{t}, append(append([]RouteTarget{}, targets[:i]...), targets[i+1:]...)...)
+				break
+			}
+		}
+	}
+	plan := make([]RouteTarget, 0, len(targets))
+	for _, t := range targets {
+		if streaming && providers[t.Provider].schema != entry {
+			continue
+		}
+		plan = append(plan, t)
+	}
+	return plan
+}
+
+// retryableStatus is an upstream answer worth trying the next target for:
+// rate limited or overloaded, or a server-side failure.
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// failoverTransport sends a routed call to its plan's targets in turn. Each
+// attempt gets the target's key, path and (translated) body; a retryable
+// failure moves on to the next target, and the last target's answer is
+// returned whatever it is. A cross-schema response is translated back to the
+// entry schema before the proxy's metering sees it.
+type failoverTransport struct {
+	g      *Gateway
+	base   http.RoundTripper
+	entry  *Provider
+	plan   []RouteTarget
+	reqID  uint64
+	served func(p *Provider, model string)
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	raw, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var in map[string]any
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return nil, fmt.Errorf("routed request is not JSON: %w", err)
+	}
+	lastErr := errors.New("no route target has a provider key")
+	for i, target := range t.plan {
+		prov := t.g.cfg.Providers[target.Provider]
+		key := t.g.cfg.ProviderKeys[target.Provider]
+		if key == "" {
+			t.g.cfg.Logger.Printf("model-gateway: req=%d ROUTE skip provider=%s: no key", t.reqID, target.Provider)
+			continue
+		}
+		out, err := t.attemptRequest(req, in, prov, key, target.Model)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(out)
+		if (err != nil || retryableStatus(resp.StatusCode)) && i < len(t.plan)-1 {
+			reason := ""
+			if err != nil {
+				reason = err.Error()
+			} else {
+				reason = "http " + strconv.Itoa(resp.StatusCode)
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+			t.g.cfg.Logger.Printf("model-gateway: req=%d FAILOVER provider=%s model=%s reason=%q", t.reqID, target.Provider, target.Model, reason)
+			lastErr = fmt.Errorf("route target %s/%s failed: %s", target.Provider, target.Model, reason)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.g.cfg.Logger.Printf("model-gateway: req=%d ROUTED provider=%s model=%s attempt=%d", t.reqID, target.Provider, target.Model, i+1)
+		t.served(prov, target.Model)
+		if prov.schema != t.entry.schema {
+			if err := translateResponseBody(resp, prov.schema, t.entry.schema); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// attemptRequest builds the upstream request for one target.
+func (t *failoverTransport) attemptRequest(req *http.Request, in map[string]any, prov *Provider, key, model string) (*http.Request, error) {
+	body, err := json.Marshal(translateRequest(in, t.entry.schema, prov.schema, model))
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := url.Parse(prov.UpstreamURL)
+	if err != nil {
+		return nil, fmt.Errorf("bad upstream url for %s: %w", prov.Name, err)
+	}
+	out := req.Clone(req.Context())
+	out.URL.Scheme, out.URL.Host, out.URL.Path, out.URL.RawPath = upstream.Scheme, upstream.Host, prov.chatPath, ""
+	out.Host = upstream.Host
+	prov.inject(out.Header, key)
+	if prov.schema == schemaAnthropic {
+		if out.Header.Get("anthropic-version") == "" {
+			out.Header.Set("anthropic-version", anthropicVersion)
+		}
+	} else {
+		out.Header.Del("anthropic-version")
+		out.Header.Del("anthropic-beta")
+	}
+	out.Header.Set("Content-Type", "application/json")
+	out.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	out.ContentLength = int64(len(body))
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	return out, nil
+}
+
+// translateResponseBody rewrites a cross-schema response in place: success
+// bodies are translated, error bodies re-shaped, so the box's SDK parses
+// either. Compressed bodies never arrive here (the proxy drops the client's
+// Accept-Encoding, so the transport decompresses).
+func translateResponseBody(resp *http.Response, from, to chatSchema) error {
+	raw, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	var out any
+	if resp.StatusCode >= 400 {
+		out = translateError(raw, resp.StatusCode, to)
+	} else {
+		var in map[string]any
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return fmt.Errorf("translate %s response: %w", from, err)
+		}
+		out = translateResponse(in, from, to)
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	resp.Header.Set("Content-Type", "application/json")
+	return nil
+}
+
+// pickWeighted is the default weighted-split draw.
+func pickWeighted(n int) int { return rand.IntN(n) }
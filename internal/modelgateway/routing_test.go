@@ -0,0 +1,255 @@
+package modelgateway
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// routedGateway serves a gateway whose providers point at fakes, with keys
+// for every provider and the given routes.
+func routedGateway(t *testing.T, routes map[string]*Route, upstreams map[string]http.Handler) (*Gateway, string) {
+	t.Helper()
+	providers := DefaultProviders()
+	keys := map[string]string{}
+	for name, h := range upstreams {
+		up := httptest.NewServer(h)
+		t.Cleanup(up.Close)
+		providers[name].UpstreamURL = up.URL
+		keys[name] = "KEY-" + name
+	}
+	policy := &RoutingPolicy{Routes: routes}
+	if err := policy.validate(providers); err != nil {
+		t.Fatal(err)
+	}
+	gw := New(Config{Secret: []byte("s"), Providers: providers, ProviderKeys: keys, Routes: policy})
+	srv := httptest.NewServer(gw.Handler())
+	t.Cleanup(srv.Close)
+	return gw, srv.URL
+}
+
+func postChat(t *testing.T, url string, claims GatewayClaims, path, body string) (int, map[string]any) {
+	t.Helper()
+	tok, err := MintToken([]byte("s"), claims, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("POST", url+"/v1/model/"+claims.Provider+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+tok)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+	var out map[string]any
+	_ = json.Unmarshal(raw, &out)
+	return resp.StatusCode, out
+}
+
+// An Anthropic-speaking box whose provider is down is answered by OpenAI, in
+// Anthropic's shape, and the call is metered against the provider that
+// served it.
+func TestRouting_FailsOverAcrossSchemas(t *testing.T) {
+	down := &FakeProvider{Name: "anthropic", FailStatus: http.StatusServiceUnavailable}
+	up := &FakeProvider{Name: "openai"}
+	gw, url := routedGateway(t, map[string]*Route{
+		"sonnet": {Targets: []RouteTarget{{Provider: "anthropic", Model: "claude-sonnet-4"}, {Provider: "openai", Model: "gpt-4.1"}}},
+	}, map[string]http.Handler{"anthropic": down, "openai": up})
+
+	code, body := postChat(t, url, GatewayClaims{Tenant: "acme", Provider: "anthropic"}, "/v1/messages",
+		`{"model":"sonnet","max_tokens":64,"system":"be brief","messages":[{"role":"user","content":"hi"}]}`)
+	if code != 200 {
+		t.Fatalf("status %d: %v", code, body)
+	}
+	if down.Calls() != 1 || up.Calls() != 1 {
+		t.Errorf("calls: anthropic=%d openai=%d, want 1 each", down.Calls(), up.Calls())
+	}
+	content := asList(body["content"])
+	if body["type"] != "message" || len(content) != 1 ||
+		content[0].(map[string]any)["text"] != "fake reply from openai (gpt-4.1)" || body["stop_reason"] != "end_turn" {
+		t.Errorf("response not translated to the Anthropic shape: %v", body)
+	}
+	snap := gw.Meter().Snapshot()
+	if len(snap) != 1 || snap[0].Provider != "openai" || snap[0].InputTokens != fakeInputTokens {
+		t.Errorf("metering should follow the serving provider: %+v", snap)
+	}
+}
+
+// Same-schema aliasing rewrites only the model, and the target gets its own
+// key — never the gateway token or the entry provider's key.
+func TestRouting_AliasCarriesTargetKey(t *testing.T) {
+	var gotAuth, gotModel, gotPath string
+	target := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotPath = r.Header.Get("Authorization"), r.URL.Path
+		gotModel = requestModel(mustRead(r.Body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"model":"gemini-2.5-flash","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":1}}`)
+	})
+	_, url := routedGateway(t, map[string]*Route{
+		"fast": {Targets: []RouteTarget{{Provider: "gemini-openai", Model: "gemini-2.5-flash"}}},
+	}, map[string]http.Handler{"openai": &FakeProvider{}, "gemini-openai": target})
+
+	code, _ := postChat(t, url, GatewayClaims{Tenant: "acme", Provider: "openai"}, "/v1/chat/completions",
+		`{"model":"fast","messages":[{"role":"user","content":"hi"}]}`)
+	if code != 200 {
+		t.Fatalf("status %d", code)
+	}
+	if gotAuth != "Bearer KEY-gemini-openai" || gotModel != "gemini-2.5-flash" || gotPath != "/v1beta/openai/chat/completions" {
+		t.Errorf("target got auth=%q model=%q path=%q", gotAuth, gotModel, gotPath)
+	}
+}
+
+func TestRouting_LastTargetErrorReachesClientInItsSchema(t *testing.T) {
+	_, url := routedGateway(t, map[string]*Route{
+		"sonnet": {Targets: []RouteTarget{{Provider: "openai", Model: "gpt-4.1"}}},
+	}, map[string]http.Handler{"anthropic": &FakeProvider{}, "openai": &FakeProvider{Name: "openai", FailStatus: http.StatusTooManyRequests}})
+
+	code, body := postChat(t, url, GatewayClaims{Tenant: "acme", Provider: "anthropic"}, "/v1/messages",
+		`{"model":"sonnet","max_tokens":64,"messages":[{"role":"user","content":"hi"}]}`)
+	if code != http.StatusTooManyRequests {
+		t.Fatalf("status %d, want the last target's 429", code)
+	}
+	if subMap(body, "error")["type"] != "rate_limit_error" {
+		t.Errorf("error not in the Anthropic shape: %v", body)
+	}
+}
+
+// When every backend answers 5xx, each is tried once and the client gets the
+// last answer; when the chain runs out on a target it cannot even try, the
+// error names the failure that ended the chain instead of a bare "failed".
+func TestRouting_EveryTargetFails(t *testing.T) {
+	a := &FakeProvider{Name: "anthropic", FailStatus: http.StatusServiceUnavailable}
+	o := &FakeProvider{Name: "openai", FailStatus: http.StatusBadGateway}
+	_, url := routedGateway(t, map[string]*Route{
+		"sonnet": {Targets: []RouteTarget{{Provider: "anthropic", Model: "claude-sonnet-4"}, {Provider: "openai", Model: "gpt-4.1"}}},
+		// gemini-openai has no upstream here, so no key: it is skipped.
+		"keyless-last": {Targets: []RouteTarget{{Provider: "anthropic", Model: "claude-sonnet-4"}, {Provider: "openai", Model: "gpt-4.1"}, {Provider: "gemini-openai", Model: "gemini-2.5-flash"}}},
+	}, map[string]http.Handler{"anthropic": a, "openai": o})
+
+	code, _ := postChat(t, url, GatewayClaims{Tenant: "acme", Provider: "anthropic"}, "/v1/messages",
+		`{"model":"sonnet","max_tokens":64,"messages":[{"role":"user","content":"hi"}]}`)
+	if code != http.StatusBadGateway || a.Calls() != 1 || o.Calls() != 1 {
+		t.Errorf("status %d, calls anthropic=%d openai=%d; want the last target's 502 after one call each", code, a.Calls(), o.Calls())
+	}
+
+	tok, err := MintToken([]byte("s"), GatewayClaims{Tenant: "acme", Provider: "anthropic"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("POST", url+"/v1/model/anthropic/v1/messages",
+		strings.NewReader(`{"model":"keyless-last","max_tokens":64,"messages":[{"role":"user","content":"hi"}]}`))
+	req.Header.Set("Authorization", "Bearer "+tok)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	msg := string(mustRead(resp.Body))
+	if resp.StatusCode != http.StatusBadGateway || !strings.Contains(msg, "openai/gpt-4.1") || !strings.Contains(msg, "http 502") {
+		t.Errorf("status %d body %q; want a 502 naming the last failed target", resp.StatusCode, msg)
+	}
+}
+
+// A malformed tools list on a cross-schema route is answered, and the
+// inflight gauge comes back down either way.
+func TestRouting_MalformedToolsDoNotLeakInflight(t *testing.T) {
+	up := &FakeProvider{Name: "anthropic"}
+	gw, url := routedGateway(t, map[string]*Route{
+		"sonnet": {Targets: []RouteTarget{{Provider: "anthropic", Model: "claude-sonnet-4"}}},
+	}, map[string]http.Handler{"openai": &FakeProvider{}, "anthropic": up})
+
+	code, _ := postChat(t, url, GatewayClaims{Tenant: "acme", Provider: "openai"}, "/v1/chat/completions",
+		`{"model":"sonnet","messages":[{"role":"user","content":"hi"}],"tools":["x"]}`)
+	if code != http.StatusOK || up.Calls() != 1 {
+		t.Errorf("status %d, upstream calls %d", code, up.Calls())
+	}
+	waitFor(t, "inflight back to zero", func() bool { return gw.inflight.Load() == 0 })
+}
+
+func TestRouting_TokenCeilingAppliesToRequestedModel(t *testing.T) {
+	up := &FakeProvider{}
+	_, url := routedGateway(t, map[string]*Route{
+		"big": {Targets: []RouteTarget{{Provider: "openai", Model: "gpt-4.1"}}},
+	}, map[string]http.Handler{"openai": up})
+
+	code, _ := postChat(t, url, GatewayClaims{Tenant: "acme", Provider: "openai", AllowedModels: []string{"small"}},
+		"/v1/chat/completions", `{"model":"big","messages":[]}`)
+	if code != http.StatusForbidden || up.Calls() != 0 {
+		t.Errorf("status %d, upstream calls %d: a route must not lift the token's model ceiling", code, up.Calls())
+	}
+}
+
+func TestRoutePlan(t *testing.T) {
+	providers := DefaultProviders()
+	rt := &Route{Targets: []RouteTarget{
+		{Provider: "anthropic", Model: "a"},
+		{Provider: "openai", Model: "b", Weight: 80},
+		{Provider: "gemini-openai", Model: "c", Weight: 20},
+	}}
+	models := func(p []RouteTarget) string {
+		var s []string
+		for _, t := range p {
+			s = append(s, t.Model)
+		}
+		return strings.Join(s, ",")
+	}
+	for _, tc := range []struct {
+		name      string
+		draw      int
+		streaming bool
+		want      string
+	}{
+		{"draw in the first weight", 79, false, "b,a,c"},
+		{"draw in the second weight", 80, false, "c,a,b"},
+		{"streaming keeps the box's schema only", 0, true, "b,c"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := routePlan(rt, providers, schemaOpenAI, tc.streaming, func(n int) int {
+				if n != 100 {
+					t.Errorf("drew from %d, want the total weight 100", n)
+				}
+				return tc.draw
+			})
+			if models(got) != tc.want {
+				t.Errorf("plan = %s, want %s", models(got), tc.want)
+			}
+		})
+	}
+	if got := routePlan(&Route{Targets: rt.Targets[:1]}, providers, schemaOpenAI, false, nil); models(got) != "a" {
+		t.Errorf("unweighted plan = %s, want listed order", models(got))
+	}
+}
+
+func TestLoadRoutingPolicy_Rejects(t *testing.T) {
+	for name, body := range map[string]string{
+		"unknown provider":     `{"routes":{"x":{"targets":[{"provider":"nope","model":"m"}]}}}`,
+		"native gemini target": `{"routes":{"x":{"targets":[{"provider":"gemini","model":"m"}]}}}`,
+		"no targets":           `{"routes":{"x":{"targets":[]}}}`,
+		"missing model":        `{"routes":{"x":{"targets":[{"provider":"openai"}]}}}`,
+		"misspelt field":       `{"routes":{"x":{"target":[{"provider":"openai","model":"m"}]}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "routes.json")
+			if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadRoutingPolicy(path, DefaultProviders()); err == nil {
+				t.Error("policy accepted")
+			}
+		})
+	}
+}
+
+func mustRead(r io.Reader) []byte {
+	b, _ := io.ReadAll(r)
+	return b
+}
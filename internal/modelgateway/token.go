@@ -5,10 +5,10 @@
 // of a raw provider key; the gateway validates it, injects the real key (held
 // only here), proxies to the provider, and meters token usage per tenant.
 //
-// This implements Phase 0 (transparent proxy + key custody) and Phase 1
-// (metering) of the design note. Caching, central tiering, rate-limiting, and
-// persistent rollups are later phases. Mechanism-only — no named tenants,
-// skills, or packs.
+// This implements Phase 0 (transparent proxy + key custody), Phase 1
+// (metering) and the budget/rate-limit half of Phase 2 of the design note.
+// Caching, central tiering, and persistent rollups are later phases.
+// Mechanism-only — no named tenants, skills, or packs.
 package modelgateway
 
 import (
@@ -27,8 +27,8 @@ const gatewayIssuer = "containarium-model-gateway"
 // GatewayClaims is the model-gateway token: a scoped, short-lived credential a
 // box presents instead of a raw provider key. It is signed HS256 with the
 // daemon's shared secret (the same trust root as the platform JWT — no new
-// PKI), carries the attribution the gateway meters by, and the model ceiling it
-// enforces.
+// PKI), carries the attribution the gateway meters by, and the model ceiling
+// and optional spend budget it enforces.
 //
 // In production this is minted inside the daemon's provisionSkillBox alongside
 // the platform JWT (the design's "no new mint verb" point); the prototype's
@@ -39,6 +39,11 @@ type GatewayClaims struct {
 	RunID         string   `json:"run_id,omitempty"`
 	Provider      string   `json:"provider"`
 	AllowedModels []string `json:"allowed_models,omitempty"`
+	// Budget caps this tenant/skill's spend, on top of any daemon-side
+	// BudgetPolicy (the tighter limit wins).
+	Budget *Budget `json:"budget,omitempty"`
+	// NoCache opts this token's calls out of the gateway's response cache.
+	NoCache bool `json:"no_cache,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -51,6 +56,9 @@ func MintToken(secret []byte, c GatewayClaims, ttl time.Duration) (string, error
 	if c.Provider == "" {
 		return "", fmt.Errorf("provider required")
 	}
+	if err := c.Budget.validate(); err != nil {
+		return "", err
+	}
 	jti := make([]byte, 16)
 	if _, err := rand.Read(jti); err != nil {
 		return "", err
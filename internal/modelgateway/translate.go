@@ -0,0 +1,401 @@
+package modelgateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Chat-schema translation, so a route can fail a box's call over to a
+// provider that speaks the other wire format while the box keeps one client.
+// Covers what agent loops send: system prompts, text turns, tool definitions,
+// tool calls and tool results, sampling and stop parameters, and usage.
+// Images and other content blocks are dropped rather than guessed at, and
+// streaming responses are not translated — a streaming call only fails over
+// between providers of the same schema (routePlan).
+
+// chatSchema is a chat API's wire format.
+type chatSchema string
+
+const (
+	schemaAnthropic chatSchema = "anthropic" // POST /v1/messages
+	schemaOpenAI    chatSchema = "openai"    // POST .../chat/completions
+)
+
+// defaultAnthropicMaxTokens fills Anthropic's required max_tokens when an
+// OpenAI-shaped request leaves it out.
+const defaultAnthropicMaxTokens = 4096
+
+// anthropicVersion is sent on translated calls to Anthropic; a box speaking
+// OpenAI never sets it.
+const anthropicVersion = "2023-06-01"
+
+// translateRequest rewrites an entry-schema chat request for a target of
+// schema to, calling model. Same schema only swaps the model.
+func translateRequest(in map[string]any, from, to chatSchema, model string) map[string]any {
+	switch {
+	case from == to:
+		out := make(map[string]any, len(in))
+		for k, v := range in {
+			out[k] = v
+		}
+		out["model"] = model
+		return out
+	case from == schemaAnthropic:
+		return anthropicToOpenAIRequest(in, model)
+	default:
+		return openAIToAnthropicRequest(in, model)
+	}
+}
+
+// translateResponse rewrites a target's successful, non-streaming chat
+// response into the entry schema.
+func translateResponse(in map[string]any, from, to chatSchema) map[string]any {
+	switch {
+	case from == to:
+		return in
+	case from == schemaAnthropic:
+		return anthropicToOpenAIResponse(in)
+	default:
+		return openAIToAnthropicResponse(in)
+	}
+}
+
+// translateError builds an error body in schema to from an upstream error
+// response of either schema (both carry error.message).
+func translateError(raw []byte, status int, to chatSchema) map[string]any {
+	msg := http.StatusText(status)
+	var in map[string]any
+	if json.Unmarshal(raw, &in) == nil {
+		if m, _ := subMap(in, "error")["message"].(string); m != "" {
+			msg = m
+		}
+	}
+	if to == schemaAnthropic {
+		typ := "api_error"
+		switch {
+		case status == http.StatusTooManyRequests:
+			typ = "rate_limit_error"
+		case status == http.StatusBadRequest:
+			typ = "invalid_request_error"
+		case status == 529:
+			typ = "overloaded_error"
+		}
+		return map[string]any{"type": "error", "error": map[string]any{"type": typ, "message": msg}}
+	}
+	return map[string]any{"error": map[string]any{"message": msg, "type": "upstream_error", "param": nil, "code": nil}}
+}
+
+// --- Anthropic -> OpenAI --------------------------------------------
+
+func anthropicToOpenAIRequest(in map[string]any, model string) map[string]any {
+	out := map[string]any{"model": model}
+	var msgs []any
+	if sys := blockText(in["system"]); sys != "" {
+		msgs = append(msgs, map[string]any{"role": "system", "content": sys})
+	}
+	for _, m := range asList(in["messages"]) {
+		mm, _ := m.(map[string]any)
+		role, _ := mm["role"].(string)
+		var text strings.Builder
+		var calls, results []any
+		switch c := mm["content"].(type) {
+		case string:
+			text.WriteString(c)
+		case []any:
+			for _, b := range c {
+				bm, _ := b.(map[string]any)
+				switch bm["type"] {
+				case "text":
+					s, _ := bm["text"].(string)
+					text.WriteString(s)
+				case "tool_use":
+					args, _ := json.Marshal(bm["input"])
+					calls = append(calls, map[string]any{
+						"id": bm["id"], "type": "function",
+						"function": map[string]any{"name": bm["name"], "arguments": string(args)},
+					})
+				case "tool_result":
+					results = append(results, map[string]any{
+						"role": "tool", "tool_call_id": bm["tool_use_id"], "content": blockText(bm["content"]),
+					})
+				}
+			}
+		}
+		// Tool results answer the previous assistant turn, so they go ahead
+		// of any text in the same user turn.
+		msgs = append(msgs, results...)
+		if role == "assistant" {
+			msg := map[string]any{"role": "assistant", "content": nil}
+			if text.Len() > 0 {
+				msg["content"] = text.String()
+			}
+			if len(calls) > 0 {
+				msg["tool_calls"] = calls
+			}
+			msgs = append(msgs, msg)
+		} else if text.Len() > 0 {
+			msgs = append(msgs, map[string]any{"role": role, "content": text.String()})
+		}
+	}
+	out["messages"] = msgs
+	copyFields(out, in, "max_tokens", "temperature", "top_p", "stream")
+	if stop, ok := in["stop_sequences"]; ok {
+		out["stop"] = stop
+	}
+	if tools := asList(in["tools"]); len(tools) > 0 {
+		var fns []any
+		for _, t := range tools {
+			tm, _ := t.(map[string]any)
+			fns = append(fns, map[string]any{"type": "function", "function": map[string]any{
+				"name": tm["name"], "description": tm["description"], "parameters": tm["input_schema"],
+			}})
+		}
+		out["tools"] = fns
+	}
+	if tc, _ := in["tool_choice"].(map[string]any); tc != nil {
+		switch tc["type"] {
+		case "auto", "none":
+			out["tool_choice"] = tc["type"]
+		case "any":
+			out["tool_choice"] = "required"
+		case "tool":
+			out["tool_choice"] = map[string]any{"type": "function", "function": map[string]any{"name": tc["name"]}}
+		}
+	}
+	return out
+}
+
+func anthropicToOpenAIResponse(in map[string]any) map[string]any {
+	var text strings.Builder
+	var calls []any
+	for _, b := range asList(in["content"]) {
+		bm, _ := b.(map[string]any)
+		switch bm["type"] {
+		case "text":
+			s, _ := bm["text"].(string)
+			text.WriteString(s)
+		case "tool_use":
+			args, _ := json.Marshal(bm["input"])
+			calls = append(calls, map[string]any{
+				"id": bm["id"], "type": "function",
+				"function": map[string]any{"name": bm["name"], "arguments": string(args)},
+			})
+		}
+	}
+	msg := map[string]any{"role": "assistant", "content": nil}
+	if text.Len() > 0 {
+		msg["content"] = text.String()
+	}
+	if len(calls) > 0 {
+		msg["tool_calls"] = calls
+	}
+	finish := "stop"
+	switch in["stop_reason"] {
+	case "max_tokens":
+		finish = "length"
+	case "tool_use":
+		finish = "tool_calls"
+	case "refusal":
+		finish = "content_filter"
+	}
+	u := subMap(in, "usage")
+	cached := num(u, "cache_read_input_tokens")
+	prompt := num(u, "input_tokens") + cached // OpenAI's prompt count includes cache hits
+	return map[string]any{
+		"id":      in["id"],
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   in["model"],
+		"choices": []any{map[string]any{"index": 0, "message": msg, "finish_reason": finish}},
+		"usage": map[string]any{
+			"prompt_tokens":         prompt,
+			"completion_tokens":     num(u, "output_tokens"),
+			"total_tokens":          prompt + num(u, "output_tokens"),
+			"prompt_tokens_details": map[string]any{"cached_tokens": cached},
+		},
+	}
+}
+
+// --- OpenAI -> Anthropic --------------------------------------------
+
+func openAIToAnthropicRequest(in map[string]any, model string) map[string]any {
+	out := map[string]any{"model": model, "max_tokens": defaultAnthropicMaxTokens}
+	var system []string
+	var msgs []any
+	// appendTurn adds blocks as a turn of role, merging into the previous turn
+	// when it has the same role: Anthropic requires alternating turns, and
+	// OpenAI sends each tool result as a message of its own.
+	appendTurn := func(role string, blocks []any) {
+		if len(blocks) == 0 {
+			return
+		}
+		if n := len(msgs); n > 0 {
+			if last := msgs[n-1].(map[string]any); last["role"] == role {
+				last["content"] = append(last["content"].([]any), blocks...)
+				return
+			}
+		}
+		msgs = append(msgs, map[string]any{"role": role, "content": blocks})
+	}
+	for _, m := range asList(in["messages"]) {
+		mm, _ := m.(map[string]any)
+		text := blockText(mm["content"])
+		switch mm["role"] {
+		case "system", "developer":
+			if text != "" {
+				system = append(system, text)
+			}
+		case "tool":
+			appendTurn("user", []any{map[string]any{
+				"type": "tool_result", "tool_use_id": mm["tool_call_id"], "content": text,
+			}})
+		case "assistant":
+			var blocks []any
+			if text != "" {
+				blocks = append(blocks, map[string]any{"type": "text", "text": text})
+			}
+			for _, tc := range asList(mm["tool_calls"]) {
+				tcm, _ := tc.(map[string]any)
+				fn := subMap(tcm, "function")
+				input := map[string]any{}
+				if args, _ := fn["arguments"].(string); args != "" {
+					_ = json.Unmarshal([]byte(args), &input)
+				}
+				blocks = append(blocks, map[string]any{"type": "tool_use", "id": tcm["id"], "name": fn["name"], "input": input})
+			}
+			appendTurn("assistant", blocks)
+		default:
+			if text != "" {
+				appendTurn("user", []any{map[string]any{"type": "text", "text": text}})
+			}
+		}
+	}
+	if len(system) > 0 {
+		out["system"] = strings.Join(system, "\n\n")
+	}
+	out["messages"] = msgs
+	for _, k := range []string{"max_completion_tokens", "max_tokens"} {
+		if v, ok := in[k]; ok {
+			out["max_tokens"] = v
+		}
+	}
+	copyFields(out, in, "temperature", "top_p", "stream")
+	switch stop := in["stop"].(type) {
+	case string:
+		out["stop_sequences"] = []any{stop}
+	case []any:
+		out["stop_sequences"] = stop
+	}
+	if tools := asList(in["tools"]); len(tools) > 0 {
+		var defs []any
+		for _, t := range tools {
+			tm, ok := t.(map[string]any)
+			if !ok {
+				continue // not a tool definition; the body is the client's
+			}
+			fn := subMap(tm, "function")
+			schema := fn["parameters"]
+			if schema == nil {
+				schema = map[string]any{"type": "object"}
+			}
+			defs = append(defs, map[string]any{"name": fn["name"], "description": fn["description"], "input_schema": schema})
+		}
+		if len(defs) > 0 {
+			out["tools"] = defs
+		}
+	}
+	switch tc := in["tool_choice"].(type) {
+	case string:
+		switch tc {
+		case "auto", "none":
+			out["tool_choice"] = map[string]any{"type": tc}
+		case "required":
+			out["tool_choice"] = map[string]any{"type": "any"}
+		}
+	case map[string]any:
+		out["tool_choice"] = map[string]any{"type": "tool", "name": subMap(tc, "function")["name"]}
+	}
+	return out
+}
+
+func openAIToAnthropicResponse(in map[string]any) map[string]any {
+	var choice map[string]any
+	if cs := asList(in["choices"]); len(cs) > 0 {
+		choice, _ = cs[0].(map[string]any)
+	}
+	msg := subMap(choice, "message")
+	content := []any{}
+	if text := blockText(msg["content"]); text != "" {
+		content = append(content, map[string]any{"type": "text", "text": text})
+	}
+	for _, tc := range asList(msg["tool_calls"]) {
+		tcm, _ := tc.(map[string]any)
+		fn := subMap(tcm, "function")
+		input := map[string]any{}
+		if args, _ := fn["arguments"].(string); args != "" {
+			_ = json.Unmarshal([]byte(args), &input)
+		}
+		content = append(content, map[string]any{"type": "tool_use", "id": tcm["id"], "name": fn["name"], "input": input})
+	}
+	stop := "end_turn"
+	switch choice["finish_reason"] {
+	case "length":
+		stop = "max_tokens"
+	case "tool_calls":
+		stop = "tool_use"
+	case "content_filter":
+		stop = "refusal"
+	}
+	u := subMap(in, "usage")
+	cached := num(subMap(u, "prompt_tokens_details"), "cached_tokens")
+	return map[string]any{
+		"id":            in["id"],
+		"type":          "message",
+		"role":          "assistant",
+		"model":         in["model"],
+		"content":       content,
+		"stop_reason":   stop,
+		"stop_sequence": nil,
+		"usage": map[string]any{
+			"input_tokens":            num(u, "prompt_tokens") - cached,
+			"output_tokens":           num(u, "completion_tokens"),
+			"cache_read_input_tokens": cached,
+		},
+	}
+}
+
+// --- helpers ----------------------------------------------------------
+
+func asList(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+// blockText flattens content that is either a string or a list of blocks
+// (either schema's text parts) into its text.
+func blockText(v any) string {
+	switch c := v.(type) {
+	case string:
+		return c
+	case []any:
+		var b strings.Builder
+		for _, p := range c {
+			if pm, _ := p.(map[string]any); pm["type"] == "text" {
+				s, _ := pm["text"].(string)
+				b.WriteString(s)
+			}
+		}
+		return b.String()
+	}
+	return ""
+}
+
+func copyFields(dst, src map[string]any, keys ...string) {
+	for _, k := range keys {
+		if v, ok := src[k]; ok {
+			dst[k] = v
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package modelgateway
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func roundTripJSON(t *testing.T, m map[string]any) map[string]any {
+	t.Helper()
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decodeJSON(t, string(b))
+}
+
+// An agent turn with a tool call and its result survives the trip into
+// OpenAI's shape: tool definitions, the assistant's tool call, and the result
+// as a tool message ahead of the user's follow-up text.
+func TestAnthropicToOpenAIRequest_ToolTurn(t *testing.T) {
+	in := decodeJSON(t, `{
+		"model": "sonnet", "max_tokens": 100, "system": [{"type":"text","text":"be brief"}],
+		"stop_sequences": ["END"],
+		"tools": [{"name":"ls","description":"list","input_schema":{"type":"object"}}],
+		"tool_choice": {"type":"any"},
+		"messages": [
+			{"role":"user","content":"list files"},
+			{"role":"assistant","content":[{"type":"text","text":"ok"},{"type":"tool_use","id":"tu1","name":"ls","input":{"dir":"/"}}]},
+			{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu1","content":"a b"},{"type":"text","text":"thanks"}]}
+		]}`)
+	out := translateRequest(in, schemaAnthropic, schemaOpenAI, "gpt-4.1")
+	got, _ := json.Marshal(out)
+	want := decodeJSON(t, `{
+		"model": "gpt-4.1", "max_tokens": 100, "stop": ["END"], "tool_choice": "required",
+		"tools": [{"type":"function","function":{"name":"ls","description":"list","parameters":{"type":"object"}}}],
+		"messages": [
+			{"role":"system","content":"be brief"},
+			{"role":"user","content":"list files"},
+			{"role":"assistant","content":"ok","tool_calls":[{"id":"tu1","type":"function","function":{"name":"ls","arguments":"{\"dir\":\"/\"}"}}]},
+			{"role":"tool","tool_call_id":"tu1","content":"a b"},
+			{"role":"user","content":"thanks"}
+		]}`)
+	if !reflect.DeepEqual(decodeJSON(t, string(got)), want) {
+		t.Errorf("translated request:\n%s", got)
+	}
+}
+
+// OpenAI sends each tool result as its own message; Anthropic needs them
+// merged into one user turn, and the system messages lifted out.
+func TestOpenAIToAnthropicRequest_MergesToolResults(t *testing.T) {
+	in := decodeJSON(t, `{
+		"model": "fast", "max_completion_tokens": 50, "stop": "END",
+		"messages": [
+			{"role":"system","content":"be brief"},
+			{"role":"user","content":[{"type":"text","text":"two things"}]},
+			{"role":"assistant","content":null,"tool_calls":[
+				{"id":"c1","type":"function","function":{"name":"a","arguments":"{}"}},
+				{"id":"c2","type":"function","function":{"name":"b","arguments":"{\"x\":1}"}}]},
+			{"role":"tool","tool_call_id":"c1","content":"A"},
+			{"role":"tool","tool_call_id":"c2","content":"B"}
+		]}`)
+	out := translateRequest(in, schemaOpenAI, schemaAnthropic, "claude-haiku-4-5")
+	got, _ := json.Marshal(out)
+	want := decodeJSON(t, `{
+		"model": "claude-haiku-4-5", "max_tokens": 50, "system": "be brief", "stop_sequences": ["END"],
+		"messages": [
+			{"role":"user","content":[{"type":"text","text":"two things"}]},
+			{"role":"assistant","content":[
+				{"type":"tool_use","id":"c1","name":"a","input":{}},
+				{"type":"tool_use","id":"c2","name":"b","input":{"x":1}}]},
+			{"role":"user","content":[
+				{"type":"tool_result","tool_use_id":"c1","content":"A"},
+				{"type":"tool_result","tool_use_id":"c2","content":"B"}]}
+		]}`)
+	if !reflect.DeepEqual(decodeJSON(t, string(got)), want) {
+		t.Errorf("translated request:\n%s", got)
+	}
+}
+
+// tools is the client's to fill: an entry that is not an object is skipped,
+// not asserted on, so a malformed body cannot panic the routed call.
+func TestOpenAIToAnthropicRequest_SkipsMalformedTools(t *testing.T) {
+	in := decodeJSON(t, `{"model":"fast","messages":[],"tools":["x",7,
+		{"type":"function","function":{"name":"f","parameters":{"type":"object"}}}]}`)
+	out := translateRequest(in, schemaOpenAI, schemaAnthropic, "claude-haiku-4-5")
+	tools := asList(out["tools"])
+	if len(tools) != 1 || tools[0].(map[string]any)["name"] != "f" {
+		t.Errorf("tools = %v, want only f", out["tools"])
+	}
+	if out := translateRequest(decodeJSON(t, `{"model":"fast","messages":[],"tools":["x"]}`),
+		schemaOpenAI, schemaAnthropic, "claude-haiku-4-5"); out["tools"] != nil {
+		t.Errorf("tools = %v, want none", out["tools"])
+	}
+}
+
+func TestTranslateResponse_ToolCallAndUsage(t *testing.T) {
+	openai := decodeJSON(t, `{
+		"id": "c", "model": "gpt-4.1",
+		"choices": [{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant","content":"checking",
+			"tool_calls":[{"id":"c1","type":"function","function":{"name":"ls","arguments":"{\"dir\":\"/\"}"}}]}}],
+		"usage": {"prompt_tokens": 100, "completion_tokens": 7, "prompt_tokens_details": {"cached_tokens": 60}}}`)
+	// Through JSON, as on the wire: the gateway meters the re-encoded body.
+	a := roundTripJSON(t, translateResponse(openai, schemaOpenAI, schemaAnthropic))
+	if a["stop_reason"] != "tool_use" || len(asList(a["content"])) != 2 {
+		t.Fatalf("anthropic response: %v", a)
+	}
+	// The Anthropic provider meters the translated body as if Anthropic had
+	// answered: input excludes the cache hits, which count separately.
+	u := DefaultProviders()["anthropic"].parseUsage(a, "")
+	if u.InputTokens != 40 || u.OutputTokens != 7 || u.CachedTokens != 60 {
+		t.Errorf("usage through the translation: %+v", u)
+	}
+
+	back := roundTripJSON(t, translateResponse(a, schemaAnthropic, schemaOpenAI))
+	choice := asList(back["choices"])[0].(map[string]any)
+	msg := choice["message"].(map[string]any)
+	if choice["finish_reason"] != "tool_calls" || msg["content"] != "checking" || len(asList(msg["tool_calls"])) != 1 {
+		t.Errorf("openai response: %v", back)
+	}
+	if u := DefaultProviders()["openai"].parseUsage(back, ""); u.InputTokens != 100 || u.OutputTokens != 7 {
+		t.Errorf("usage after the round trip: %+v", u)
+	}
+}
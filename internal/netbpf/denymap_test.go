@@ -40,13 +40,23 @@ func TestCompileDeny(t *testing.T) {
 	}
 }
 
-func TestCompileDeny_RejectsIPv6(t *testing.T) {
+func TestCompileDeny_IPv6(t *testing.T) {
 	c := netpolicy.CompiledPolicy{
 		Tenant:    "alice",
-		DenyRules: []netpolicy.DenyRule{{CIDR: netip.MustParsePrefix("2001:db8::/32")}},
+		DenyRules: []netpolicy.DenyRule{{CIDR: netip.MustParsePrefix("2001:db8::1/128"), Port: 6379, Proto: 6}},
 	}
-	if _, err := CompileDeny(1, c); err == nil {
-		t.Fatal("expected error for IPv6 deny CIDR (Phase A is IPv4-only)")
+	entries, err := CompileDeny(1, c)
+	if err != nil {
+		t.Fatalf("CompileDeny: %v", err)
+	}
+	a6 := netip.MustParseAddr("2001:db8::1").As16()
+	want := DenyEntry{PrefixLen: 32 + 128, TenantID: 1, IPv6: true, Addr6: a6, Port: 6379, Proto: 6}
+	if len(entries) != 1 || entries[0] != want {
+		t.Fatalf("entries = %+v, want [%+v]", entries, want)
+	}
+	// A v4 and a v6 rule never share a map slot.
+	if entries[0].Key() == (DenyKey{PrefixLen: 32 + 128, TenantID: 1}) {
+		t.Error("v6 key collides with the zero v4 key")
 	}
 }
 
@@ -21,6 +21,12 @@ type DenyEvent struct {
 	Proto    uint8  // IP protocol number (1=ICMP, 6=TCP, 17=UDP)
 	Reason   uint8  // why the flow was denied (DenyReason*); 0 on objects predating #660
 	SigID    uint16 // matched signature id when Reason==SIGNATURE, else 0 (#661); 0 on objects predating it
+
+	// IPv6 marks an IPv6 flow: Saddr/Daddr are then 0 and the addresses are in
+	// Saddr6/Daddr6 (network byte order). Always false on objects predating v6.
+	IPv6   bool
+	Saddr6 [16]byte
+	Daddr6 [16]byte
 }
 
 // Deny reasons carried in DenyEvent.Reason — mirror the DENY_REASON_* #defines
@@ -30,12 +36,19 @@ const (
 	DenyReasonPolicy       uint8 = 0 // failed the egress allow-list / intra-tenant / metadata check
 	DenyReasonVirtualPatch uint8 = 1 // matched an explicit virtual-patch deny rule (#660)
 	DenyReasonSignature    uint8 = 2 // matched a cleartext exploit signature (#661, Tier 2)
+	DenyReasonUnparsed     uint8 = 3 // IPv6 header chain did not resolve to an L4 header (fail closed)
 )
 
 // denyEventSizeV1 is the wire size of struct deny_event through the Reason byte
-// (#660). #661 appends u16 sig_id + u16 pad (→ 24 bytes); decoding stays tolerant
-// of both so an object built before either feature still parses (missing tail → 0).
-const denyEventSizeV1 = 20
+// (#660). #661 appends u16 sig_id + u16 pad (→ 24 bytes); IPv6 turns the first
+// pad byte into a family byte and appends the two 16-byte v6 addresses (→ 56
+// bytes). Decoding stays tolerant of every size so an object built before any
+// of these features still parses (missing tail → 0, i.e. IPv4).
+const (
+	denyEventSizeV1 = 20
+	denyEventSizeV6 = 56
+	familyIPv6      = 6 // deny_event.family / flow family for an IPv6 packet
+)
 
 // ParseDenyEvent decodes one perf-ring sample into a DenyEvent. It tolerates a
 // sample longer than the struct (perf samples are padded, and newer objects
@@ -58,14 +71,31 @@ func ParseDenyEvent(raw []byte) (DenyEvent, error) {
 	if len(raw) >= 22 { // #661 sig_id tail present
 		ev.SigID = b.Uint16(raw[20:22])
 	}
+	if len(raw) >= denyEventSizeV6 && raw[22] == familyIPv6 {
+		ev.IPv6 = true
+		copy(ev.Saddr6[:], raw[24:40])
+		copy(ev.Daddr6[:], raw[40:56])
+	}
 	return ev, nil
 }
 
-// Src and Dst render the network-byte-order addresses as netip.Addr. The wire
-// value is a __u32 holding the 4 IPv4 bytes in network order; NativeEndian.Put
-// writes them back to the same byte sequence regardless of host endianness.
-func (e DenyEvent) Src() netip.Addr { return ipFromBE(e.Saddr) }
-func (e DenyEvent) Dst() netip.Addr { return ipFromBE(e.Daddr) }
+// Src and Dst render the network-byte-order addresses as netip.Addr. The v4
+// wire value is a __u32 holding the 4 IPv4 bytes in network order;
+// NativeEndian.Put writes them back to the same byte sequence regardless of
+// host endianness. The v6 addresses are carried as raw bytes.
+func (e DenyEvent) Src() netip.Addr {
+	if e.IPv6 {
+		return netip.AddrFrom16(e.Saddr6)
+	}
+	return ipFromBE(e.Saddr)
+}
+
+func (e DenyEvent) Dst() netip.Addr {
+	if e.IPv6 {
+		return netip.AddrFrom16(e.Daddr6)
+	}
+	return ipFromBE(e.Daddr)
+}
 
 func ipFromBE(v uint32) netip.Addr {
 	var b [4]byte
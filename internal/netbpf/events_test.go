@@ -4,6 +4,11 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"maps"
+	"net/netip"
+	"os"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/cilium/ebpf/perf"
@@ -118,3 +123,54 @@ func TestConsumeDenyEvents_CtxCancel(t *testing.T) {
 		t.Errorf("expected no events after ctx cancel, got %d", len(sink.events))
 	}
 }
+
+// An IPv6 event carries family 6 in the former pad byte and the addresses in
+// the appended tail; Src/Dst render them.
+func TestParseDenyEvent_IPv6Tail(t *testing.T) {
+	raw := make([]byte, denyEventSizeV6)
+	binary.NativeEndian.PutUint32(raw[4:8], 3)
+	binary.NativeEndian.PutUint16(raw[16:18], 443)
+	raw[18], raw[19], raw[22] = 6, DenyReasonVirtualPatch, familyIPv6
+	src, dst := netip.MustParseAddr("fd42::10"), netip.MustParseAddr("2001:db8::1")
+	s16, d16 := src.As16(), dst.As16()
+	copy(raw[24:40], s16[:])
+	copy(raw[40:56], d16[:])
+
+	ev, err := ParseDenyEvent(raw)
+	if err != nil {
+		t.Fatalf("ParseDenyEvent: %v", err)
+	}
+	if !ev.IPv6 || ev.Src() != src || ev.Dst() != dst || ev.Dport != 443 || ev.TenantID != 3 {
+		t.Errorf("v6 event = %+v (src %s dst %s)", ev, ev.Src(), ev.Dst())
+	}
+	// The same 56 bytes with family 0 are an IPv4 event.
+	raw[22] = 0
+	if ev, _ := ParseDenyEvent(raw); ev.IPv6 || !ev.Src().Is4() {
+		t.Errorf("family 0 should decode as IPv4, got %+v", ev)
+	}
+}
+
+// The DenyReason* constants are the contract with the C program; a reason the
+// object emits but Go does not know would be audited as a plain policy deny.
+// Keep them in lockstep with the DENY_REASON_* #defines.
+func TestDenyReasons_MatchBPFSource(t *testing.T) {
+	src, err := os.ReadFile("../../experimental/ebpf-phaseA/netpolicy.bpf.c")
+	if err != nil {
+		t.Fatalf("read BPF source: %v", err)
+	}
+	want := map[string]uint8{
+		"POLICY":        DenyReasonPolicy,
+		"VIRTUAL_PATCH": DenyReasonVirtualPatch,
+		"SIGNATURE":     DenyReasonSignature,
+		"UNPARSED":      DenyReasonUnparsed,
+	}
+	re := regexp.MustCompile(`(?m)^#define DENY_REASON_(\w+)\s+(\d+)`)
+	got := map[string]uint8{}
+	for _, m := range re.FindAllStringSubmatch(string(src), -1) {
+		n, _ := strconv.ParseUint(m[2], 10, 8)
+		got[m[1]] = uint8(n)
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("DENY_REASON_* in netpolicy.bpf.c = %v, Go constants = %v", got, want)
+	}
+}
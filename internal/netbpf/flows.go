@@ -25,6 +25,12 @@ type FlowRecord struct {
 	LastNs    uint64 // bpf_ktime_get_ns at most recent packet (monotonic)
 	RxPackets uint64 // reply: peer → container (#631); 0 if the object predates it
 	RxBytes   uint64
+
+	// IPv6 marks a record from the flows6 map: Saddr/Daddr are then 0 and the
+	// addresses are in Saddr6/Daddr6 (network byte order).
+	IPv6   bool
+	Saddr6 [16]byte
+	Daddr6 [16]byte
 }
 
 // flowKeySize is the wire size of `struct flow_key`. flowStatSizeV1 is the
@@ -32,19 +38,35 @@ type FlowRecord struct {
 // layout with the appended rx counters (#631). Decode accepts either — a value
 // from an older object (no rx fields) leaves RxPackets/RxBytes at 0.
 //
+// IPv6 flows live in a separate flows6 map whose `struct flow_key6` widens
+// the addresses; the value is the same flow_stat.
+//
 //	flow_key  = u32 ifindex + u32 saddr + u32 daddr + u16 sport + u16 dport + u8 proto + u8[3] pad
+//	flow_key6 = u32 ifindex + u8[16] saddr + u8[16] daddr + u16 sport + u16 dport + u8 proto + u8[3] pad
 //	flow_stat = u64 packets + u64 bytes + u64 first_ns + u64 last_ns [+ u64 rx_packets + u64 rx_bytes]
 const (
 	flowKeySize    = 20
+	flowKey6Size   = 44
 	flowStatSizeV1 = 32
 	flowStatSize   = 48
 )
 
 // Src and Dst render the network-byte-order addresses as netip.Addr, matching
-// DenyEvent.Src/Dst (the wire value is a __u32 holding the 4 IPv4 bytes in
+// DenyEvent.Src/Dst (the v4 wire value is a __u32 holding the 4 IPv4 bytes in
 // network order).
-func (f FlowRecord) Src() netip.Addr { return ipFromBE(f.Saddr) }
-func (f FlowRecord) Dst() netip.Addr { return ipFromBE(f.Daddr) }
+func (f FlowRecord) Src() netip.Addr {
+	if f.IPv6 {
+		return netip.AddrFrom16(f.Saddr6)
+	}
+	return ipFromBE(f.Saddr)
+}
+
+func (f FlowRecord) Dst() netip.Addr {
+	if f.IPv6 {
+		return netip.AddrFrom16(f.Daddr6)
+	}
+	return ipFromBE(f.Daddr)
+}
 
 // decodeFlowKey fills the 5-tuple fields of rec from a raw `struct flow_key`.
 func decodeFlowKey(b []byte, rec *FlowRecord) error {
@@ -60,6 +82,21 @@ func decodeFlowKey(b []byte, rec *FlowRecord) error {
 	return nil
 }
 
+// decodeFlowKey6 fills the 5-tuple fields of rec from a raw `struct flow_key6`.
+func decodeFlowKey6(b []byte, rec *FlowRecord) error {
+	if len(b) < flowKey6Size {
+		return fmt.Errorf("netbpf: flow6 key sample too short: %d < %d bytes", len(b), flowKey6Size)
+	}
+	rec.IPv6 = true
+	rec.Ifindex = binary.NativeEndian.Uint32(b[0:4])
+	copy(rec.Saddr6[:], b[4:20])
+	copy(rec.Daddr6[:], b[20:36])
+	rec.Sport = binary.NativeEndian.Uint16(b[36:38])
+	rec.Dport = binary.NativeEndian.Uint16(b[38:40])
+	rec.Proto = b[40]
+	return nil
+}
+
 // decodeFlowStat fills the counter fields of rec from a raw `struct flow_stat`.
 // Accepts both the v1 (tx-only, 32-byte) and current (48-byte, with rx) layouts
 // (#631): a v1 value leaves RxPackets/RxBytes at 0.
@@ -2,6 +2,7 @@ package netbpf
 
 import (
 	"encoding/binary"
+	"net/netip"
 	"testing"
 )
 
@@ -82,3 +83,28 @@ func TestDecodeFlow_ShortSamples(t *testing.T) {
 		t.Error("expected error for short flow stat")
 	}
 }
+
+func TestDecodeFlowKey6_RoundTrip(t *testing.T) {
+	want := FlowRecord{
+		Ifindex: 59,
+		IPv6:    true,
+		Saddr6:  netip.MustParseAddr("fd42::2a").As16(),
+		Daddr6:  netip.MustParseAddr("2606:4700::1111").As16(),
+		Sport:   51000,
+		Dport:   443,
+		Proto:   6,
+	}
+	var got FlowRecord
+	if err := decodeFlowKey6(encodeFlowKey6(want), &got); err != nil {
+		t.Fatalf("decodeFlowKey6: %v", err)
+	}
+	if got != want {
+		t.Errorf("round-trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+	if got.Src().String() != "fd42::2a" || got.Dst().String() != "2606:4700::1111" {
+		t.Errorf("Src/Dst = %s/%s", got.Src(), got.Dst())
+	}
+	if err := decodeFlowKey6(make([]byte, flowKeySize), &got); err == nil {
+		t.Error("a v4-sized key must not decode as a v6 key")
+	}
+}
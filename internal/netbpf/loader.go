@@ -27,6 +27,12 @@ const (
 	mapDenyCIDR         = "deny_cidr"
 	mapSignatures       = "signatures"
 	mapSigConfig        = "sig_config"
+	mapEgressCIDR6      = "egress_cidr6"
+	mapDenyCIDR6        = "deny_cidr6"
+	mapIPTenant6        = "ip_tenant6"
+	mapFlows6           = "flows6"
+	mapEgressPorts      = "egress_ports"
+	mapEgressPorts6     = "egress_ports6"
 	statSeen            = uint32(0)
 	statWouldDeny       = uint32(1)
 	statsEntryCount     = 2
@@ -190,20 +196,73 @@ func (l *Loader) DetachVeth(ifindex int) error {
 }
 
 // SetVethPolicy writes the per-veth policy config into the veth_policy map,
-// keyed by the veth's host ifindex.
+// keyed by the veth's host ifindex. An object predating box policies has the
+// 8-byte struct; the caller must not hand it a non-zero PolicyID
+// (HasBoxPolicies), which it could not hold.
 func (l *Loader) SetVethPolicy(ifindex int, cfg PolicyConfig) error {
 	key := uint32(ifindex) // #nosec G115 -- ifindex is a small positive int
+	m := l.coll.Maps[mapVethPolicy]
 	val := vethPolicyValue(cfg)
-	if err := l.coll.Maps[mapVethPolicy].Update(&key, val[:], ebpf.UpdateAny); err != nil {
+	if !l.HasBoxPolicies() {
+		if cfg.PolicyID != 0 {
+			return fmt.Errorf("netbpf: veth_policy[%d]: box policy %d needs a rebuilt netpolicy.bpf.o", ifindex, cfg.PolicyID)
+		}
+		if err := m.Update(&key, val[:vethPolicyValueSizeV1], ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("netbpf: update veth_policy[%d]: %w", ifindex, err)
+		}
+		return nil
+	}
+	if err := m.Update(&key, val[:], ebpf.UpdateAny); err != nil {
 		return fmt.Errorf("netbpf: update veth_policy[%d]: %w", ifindex, err)
 	}
 	return nil
 }
 
-// AddEgress installs one egress allow-list LPM entry.
+// HasBoxPolicies reports whether the loaded object's veth_policy carries the
+// policy_id field that points a veth at a label-selected box policy's
+// entries. An object built before it still loads; the daemon then polices a
+// box-policy veth as its own tenant (see the enforcer) until the operator
+// rebuilds netpolicy.bpf.o.
+func (l *Loader) HasBoxPolicies() bool {
+	return l.coll.Maps[mapVethPolicy].ValueSize() >= vethPolicyValueSize
+}
+
+// HasIPv6 reports whether the loaded object polices IPv6: it carries the v6
+// allow-list and peer maps (egress_cidr6, ip_tenant6). Like the flows and deny
+// maps they are NOT required by Load — an object built before v6 support
+// still loads and enforces IPv4, while every IPv6 packet passes unpoliced; the
+// daemon skips v6 entries (and says so) until the operator rebuilds
+// netpolicy.bpf.o.
+func (l *Loader) HasIPv6() bool {
+	return l.coll.Maps[mapEgressCIDR6] != nil && l.coll.Maps[mapIPTenant6] != nil
+}
+
+// v6Map returns the named optional map (the IPv6 maps, egress_ports), or an
+// error naming it when the loaded object predates it.
+func (l *Loader) v6Map(name string) (*ebpf.Map, error) {
+	m := l.coll.Maps[name]
+	if m == nil {
+		return nil, fmt.Errorf("netbpf: %s map not present (rebuild netpolicy.bpf.o?)", name)
+	}
+	return m, nil
+}
+
+// AddEgress installs one egress allow-list LPM entry, into egress_cidr6 for an
+// IPv6 entry.
 func (l *Loader) AddEgress(e EgressEntry) error {
-	key := egressKeyBytes(e)
 	one := uint8(1)
+	if e.IPv6 {
+		m, err := l.v6Map(mapEgressCIDR6)
+		if err != nil {
+			return err
+		}
+		key := egressKey6Bytes(e)
+		if err := m.Update(key[:], &one, ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("netbpf: update egress_cidr6: %w", err)
+		}
+		return nil
+	}
+	key := egressKeyBytes(e)
 	if err := l.coll.Maps[mapEgressCIDR].Update(key[:], &one, ebpf.UpdateAny); err != nil {
 		return fmt.Errorf("netbpf: update egress_cidr: %w", err)
 	}
@@ -215,6 +274,17 @@ func (l *Loader) AddEgress(e EgressEntry) error {
 // entry is a security hole once a tenant is in enforce mode. A missing key is
 // not an error (the desired state is already reached).
 func (l *Loader) DeleteEgress(e EgressEntry) error {
+	if e.IPv6 {
+		m, err := l.v6Map(mapEgressCIDR6)
+		if err != nil {
+			return err
+		}
+		key := egressKey6Bytes(e)
+		if err := m.Delete(key[:]); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return fmt.Errorf("netbpf: delete egress_cidr6: %w", err)
+		}
+		return nil
+	}
 	key := egressKeyBytes(e)
 	if err := l.coll.Maps[mapEgressCIDR].Delete(key[:]); err != nil {
 		if errors.Is(err, ebpf.ErrKeyNotExist) {
@@ -237,12 +307,23 @@ func (l *Loader) HasDenyRules() bool { return l.coll.Maps[mapDenyCIDR] != nil }
 // changing only a rule's port/proto rewrites the same map slot. Errors if the
 // loaded object lacks the deny map (HasDenyRules is false).
 func (l *Loader) AddDeny(e DenyEntry) error {
+	val := denyValueBytes(e)
+	if e.IPv6 {
+		m, err := l.v6Map(mapDenyCIDR6)
+		if err != nil {
+			return err
+		}
+		key := denyKey6Bytes(e.Key())
+		if err := m.Update(key[:], val[:], ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("netbpf: update deny_cidr6: %w", err)
+		}
+		return nil
+	}
 	m := l.coll.Maps[mapDenyCIDR]
 	if m == nil {
 		return fmt.Errorf("netbpf: deny_cidr map not present (rebuild netpolicy.bpf.o?)")
 	}
 	key := denyKeyBytes(e.Key())
-	val := denyValueBytes(e)
 	if err := m.Update(key[:], val[:], ebpf.UpdateAny); err != nil {
 		return fmt.Errorf("netbpf: update deny_cidr: %w", err)
 	}
@@ -254,6 +335,17 @@ func (l *Loader) AddDeny(e DenyEntry) error {
 // a stale deny entry would keep blocking traffic after the operator cleared the
 // rule. A missing key is not an error (desired state already reached).
 func (l *Loader) DeleteDeny(k DenyKey) error {
+	if k.IPv6 {
+		m, err := l.v6Map(mapDenyCIDR6)
+		if err != nil {
+			return err
+		}
+		key := denyKey6Bytes(k)
+		if err := m.Delete(key[:]); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return fmt.Errorf("netbpf: delete deny_cidr6: %w", err)
+		}
+		return nil
+	}
 	m := l.coll.Maps[mapDenyCIDR]
 	if m == nil {
 		return fmt.Errorf("netbpf: deny_cidr map not present (rebuild netpolicy.bpf.o?)")
@@ -268,6 +360,49 @@ func (l *Loader) DeleteDeny(k DenyKey) error {
 	return nil
 }
 
+// HasEgressPorts reports whether the loaded object carries the port-scoped
+// egress_ports map. Optional like the deny map: an older object still loads,
+// and the daemon leaves port-scoped allow rules uninstalled — so their
+// traffic is denied (fail closed) — until netpolicy.bpf.o is rebuilt.
+func (l *Loader) HasEgressPorts() bool { return l.coll.Maps[mapEgressPorts] != nil }
+
+// egressPortsMap picks egress_ports or egress_ports6 for a key, with its name.
+func (l *Loader) egressPortsMap(k EgressEntry) (*ebpf.Map, string, error) {
+	name := mapEgressPorts
+	if k.IPv6 {
+		name = mapEgressPorts6
+	}
+	m, err := l.v6Map(name)
+	return m, name, err
+}
+
+// AddEgressPorts installs (or updates) one port-scoped allow entry. Update is
+// an upsert, so a changed port list rewrites the same slot.
+func (l *Loader) AddEgressPorts(e EgressPortsEntry) error {
+	m, name, err := l.egressPortsMap(e.Key)
+	if err != nil {
+		return err
+	}
+	val := egressPortsValueBytes(e)
+	if err := m.Update(egressLPMKey(e.Key), val[:], ebpf.UpdateAny); err != nil {
+		return fmt.Errorf("netbpf: update %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteEgressPorts removes a port-scoped allow entry by its key. A missing
+// key is not an error.
+func (l *Loader) DeleteEgressPorts(k EgressEntry) error {
+	m, name, err := l.egressPortsMap(k)
+	if err != nil {
+		return err
+	}
+	if err := m.Delete(egressLPMKey(k)); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+		return fmt.Errorf("netbpf: delete %s: %w", name, err)
+	}
+	return nil
+}
+
 // SetIPTenant maps a managed container IP (network byte order, 4 bytes) to its
 // tenant ID, so the program can distinguish same-tenant peers from external
 // destinations.
@@ -295,6 +430,33 @@ func (l *Loader) DeleteIPTenant(ip [4]byte) error {
 	return nil
 }
 
+// SetIPTenant6 is SetIPTenant for a container's IPv6 address (16 bytes,
+// network byte order), keyed into ip_tenant6. Errors if the loaded object
+// lacks the map (HasIPv6 is false).
+func (l *Loader) SetIPTenant6(ip [16]byte, tenantID uint32) error {
+	m, err := l.v6Map(mapIPTenant6)
+	if err != nil {
+		return err
+	}
+	if err := m.Update(ip[:], &tenantID, ebpf.UpdateAny); err != nil {
+		return fmt.Errorf("netbpf: update ip_tenant6: %w", err)
+	}
+	return nil
+}
+
+// DeleteIPTenant6 removes an IPv6 -> tenant mapping; a missing key is not an
+// error.
+func (l *Loader) DeleteIPTenant6(ip [16]byte) error {
+	m, err := l.v6Map(mapIPTenant6)
+	if err != nil {
+		return err
+	}
+	if err := m.Delete(ip[:]); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+		return fmt.Errorf("netbpf: delete ip_tenant6: %w", err)
+	}
+	return nil
+}
+
 // Stats reads the (seen, wouldDeny) counters — the validator's success signal,
 // mirroring the Phase 0 counter read.
 func (l *Loader) Stats() (seen, wouldDeny uint64, err error) {
@@ -321,6 +483,7 @@ func (l *Loader) HasFlowAccounting() bool { return l.coll.Maps[mapFlows] != nil
 
 // Flows snapshots the per-flow accounting map (#627): every observed flow on a
 // managed veth with its cumulative byte/packet tally and first/last timestamps.
+// IPv6 flows are read from flows6 when the object carries it.
 // Read-only — entries are aged out by the map's LRU eviction and the caller's
 // idle pruning, NOT drained here, so the cumulative counters stay monotonic for
 // the active-connection view. Returns an error if the object lacks the map
@@ -330,16 +493,30 @@ func (l *Loader) Flows() ([]FlowRecord, error) {
 	if m == nil {
 		return nil, fmt.Errorf("netbpf: flows map not present (rebuild netpolicy.bpf.o?)")
 	}
+	out, err := readFlows(m, flowKeySize, decodeFlowKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("netbpf: iterate flows: %w", err)
+	}
+	if m6 := l.coll.Maps[mapFlows6]; m6 != nil {
+		if out, err = readFlows(m6, flowKey6Size, decodeFlowKey6, out); err != nil {
+			return nil, fmt.Errorf("netbpf: iterate flows6: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// readFlows appends every entry of a flows map to out, decoding keys with
+// decodeKey.
+func readFlows(m *ebpf.Map, keySize int, decodeKey func([]byte, *FlowRecord) error, out []FlowRecord) ([]FlowRecord, error) {
 	// Size the value buffer to the map's actual ValueSize so Lookup matches the
 	// kernel map whether the object is the v1 (32-byte) or current (48-byte, with
 	// rx) flow_stat (#631). decodeFlowStat tolerates either length.
-	keyBuf := make([]byte, flowKeySize)
+	keyBuf := make([]byte, keySize)
 	valBuf := make([]byte, m.ValueSize())
-	var out []FlowRecord
 	it := m.Iterate()
 	for it.Next(&keyBuf, &valBuf) {
 		var rec FlowRecord
-		if err := decodeFlowKey(keyBuf, &rec); err != nil {
+		if err := decodeKey(keyBuf, &rec); err != nil {
 			return nil, err
 		}
 		if err := decodeFlowStat(valBuf, &rec); err != nil {
@@ -347,10 +524,7 @@ func (l *Loader) Flows() ([]FlowRecord, error) {
 		}
 		out = append(out, rec)
 	}
-	if err := it.Err(); err != nil {
-		return nil, fmt.Errorf("netbpf: iterate flows: %w", err)
-	}
-	return out, nil
+	return out, it.Err()
 }
 
 // DeleteFlow removes one entry from the flows map, identified by rec's 5-tuple
@@ -359,11 +533,15 @@ func (l *Loader) Flows() ([]FlowRecord, error) {
 // doesn't linger in the LRU map until eviction (which, on a far-from-full map,
 // effectively never happens). A key that's already gone is not an error.
 func (l *Loader) DeleteFlow(rec FlowRecord) error {
-	m := l.coll.Maps[mapFlows]
+	name, key := mapFlows, encodeFlowKey(rec)
+	if rec.IPv6 {
+		name, key = mapFlows6, encodeFlowKey6(rec)
+	}
+	m := l.coll.Maps[name]
 	if m == nil {
-		return fmt.Errorf("netbpf: flows map not present (rebuild netpolicy.bpf.o?)")
+		return fmt.Errorf("netbpf: %s map not present (rebuild netpolicy.bpf.o?)", name)
 	}
-	if err := m.Delete(encodeFlowKey(rec)); err != nil {
+	if err := m.Delete(key); err != nil {
 		if errors.Is(err, ebpf.ErrKeyNotExist) {
 			return nil
 		}
@@ -388,6 +566,18 @@ func encodeFlowKey(rec FlowRecord) []byte {
 	return b
 }
 
+// encodeFlowKey6 is encodeFlowKey for the 44-byte `struct flow_key6`.
+func encodeFlowKey6(rec FlowRecord) []byte {
+	b := make([]byte, flowKey6Size)
+	binary.NativeEndian.PutUint32(b[0:4], rec.Ifindex)
+	copy(b[4:20], rec.Saddr6[:])
+	copy(b[20:36], rec.Daddr6[:])
+	binary.NativeEndian.PutUint16(b[36:38], rec.Sport)
+	binary.NativeEndian.PutUint16(b[38:40], rec.Dport)
+	b[40] = rec.Proto
+	return b
+}
+
 // Close detaches every veth link (ingress + egress) and frees the collection.
 func (l *Loader) Close() error {
 	var errs []error
@@ -412,15 +602,24 @@ func (l *Loader) Close() error {
 
 // --- byte-layout helpers (kept next to the C struct definitions they mirror) ---
 
-// vethPolicyValue serializes a PolicyConfig into the 8-byte `struct policy_cfg`
-// layout: u32 tenant_id, u8 mode, u8 allow_intra, u8 allow_metadata, u8 pad.
+// vethPolicyValueSize is sizeof(struct policy_cfg); vethPolicyValueSizeV1 is
+// its size before policy_id was added, which older objects still carry.
+const (
+	vethPolicyValueSize   = 12
+	vethPolicyValueSizeV1 = 8
+)
+
+// vethPolicyValue serializes a PolicyConfig into the 12-byte `struct policy_cfg`
+// layout: u32 tenant_id, u8 mode, u8 allow_intra, u8 allow_metadata, u8 pad,
+// u32 policy_id. The first 8 bytes are the pre-box-policy layout.
 // Native byte order (the kernel reads the map value with native loads).
-func vethPolicyValue(cfg PolicyConfig) [8]byte {
-	var b [8]byte
+func vethPolicyValue(cfg PolicyConfig) [vethPolicyValueSize]byte {
+	var b [vethPolicyValueSize]byte
 	binary.NativeEndian.PutUint32(b[0:4], cfg.TenantID)
 	b[4] = cfg.Mode
 	b[5] = cfg.AllowIntra
 	b[6] = cfg.AllowMetadata
+	binary.NativeEndian.PutUint32(b[8:12], cfg.PolicyID)
 	return b
 }
 
@@ -437,6 +636,26 @@ func egressKeyBytes(e EgressEntry) [12]byte {
 	return b
 }
 
+// egressKey6Bytes serializes an IPv6 EgressEntry into the 24-byte `struct
+// egress_key6` layout: u32 prefixlen, u32 tenant_id, then the 16 address bytes
+// in network order. Same byte-order rules as egressKeyBytes.
+func egressKey6Bytes(e EgressEntry) [24]byte {
+	var b [24]byte
+	binary.NativeEndian.PutUint32(b[0:4], e.PrefixLen)
+	binary.NativeEndian.PutUint32(b[4:8], e.TenantID)
+	copy(b[8:24], e.Addr6[:])
+	return b
+}
+
+// denyKey6Bytes is denyKeyBytes for deny_cidr6 (the egress_key6 layout).
+func denyKey6Bytes(k DenyKey) [24]byte {
+	var b [24]byte
+	binary.NativeEndian.PutUint32(b[0:4], k.PrefixLen)
+	binary.NativeEndian.PutUint32(b[4:8], k.TenantID)
+	copy(b[8:24], k.Addr6[:])
+	return b
+}
+
 // denyKeyBytes serializes a DenyKey into the 12-byte `struct egress_key` layout
 // the deny_cidr LPM trie shares with egress_cidr (u32 prefixlen, u32 tenant_id,
 // 4 addr bytes). Same byte-order rules as egressKeyBytes.
@@ -448,6 +667,35 @@ func denyKeyBytes(k DenyKey) [12]byte {
 	return b
 }
 
+// egressLPMKey is the egress_key or egress_key6 bytes for an entry's family.
+func egressLPMKey(e EgressEntry) []byte {
+	if e.IPv6 {
+		k := egressKey6Bytes(e)
+		return k[:]
+	}
+	k := egressKeyBytes(e)
+	return k[:]
+}
+
+// egressPortsValueSize is sizeof(struct egress_ports_val): u32 n, then
+// MaxPortRules 8-byte `struct port_rule`s.
+const egressPortsValueSize = 4 + MaxPortRules*8
+
+// egressPortsValueBytes serializes an EgressPortsEntry's rules into `struct
+// egress_ports_val`: u32 n, then per rule u16 lo, u16 hi (host byte order, as
+// the program compares ntoh'd ports), u8 proto, 3 pad bytes.
+func egressPortsValueBytes(e EgressPortsEntry) [egressPortsValueSize]byte {
+	var b [egressPortsValueSize]byte
+	binary.NativeEndian.PutUint32(b[0:4], e.N)
+	for i, r := range e.Rules {
+		off := 4 + i*8
+		binary.NativeEndian.PutUint16(b[off:off+2], r.Lo)
+		binary.NativeEndian.PutUint16(b[off+2:off+4], r.Hi)
+		b[off+4] = r.Proto
+	}
+	return b
+}
+
 // denyValueBytes serializes a DenyEntry's scope into the 4-byte `struct deny_val`
 // layout: u16 port (host byte order — the program compares it against the ntoh'd
 // dport), u8 proto, u8 flags (reserved 0).
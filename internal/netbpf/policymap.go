@@ -2,6 +2,8 @@ package netbpf
 
 import (
 	"fmt"
+	"net/netip"
+	"sort"
 
 	"github.com/footprintai/containarium/internal/netpolicy"
 	"github.com/footprintai/containarium/internal/safecast"
@@ -23,36 +25,46 @@ type PolicyConfig struct {
 	Mode          uint8
 	AllowIntra    uint8
 	AllowMetadata uint8
+	// PolicyID keys the veth's egress/deny/ports entries when a label-selected
+	// box policy polices it; 0 means the tenant-wide entries (keyed by
+	// TenantID). Peer checks and deny events always use TenantID.
+	PolicyID uint32
 }
 
 // EgressEntry is one allowed-egress LPM-trie entry the loader writes into the
-// BPF egress_cidr map. It is the tenant-scoped destination prefix the sender's
-// policy permits. Field layout mirrors `struct egress_key` in netpolicy.bpf.c
-// (prefixlen counts the 32-bit exact tenant match plus the IPv4 prefix bits;
-// Addr holds the masked network address in network byte order).
+// BPF egress_cidr map (or egress_cidr6 for an IPv6 prefix). It is the
+// tenant-scoped destination prefix the sender's policy permits. Field layout
+// mirrors `struct egress_key` / `struct egress_key6` in netpolicy.bpf.c
+// (prefixlen counts the 32-bit exact tenant match plus the address prefix bits;
+// Addr / Addr6 hold the masked network address in network byte order). Exactly
+// one of Addr and Addr6 is meaningful, selected by IPv6.
 type EgressEntry struct {
 	PrefixLen uint32
 	TenantID  uint32
 	Addr      [4]byte
+	IPv6      bool
+	Addr6     [16]byte
 }
 
 // tenantPrefixBits is the LPM prefix length contributed by the tenant_id field:
-// a tenant match is always exact (all 32 bits), then the IPv4 prefix bits
+// a tenant match is always exact (all 32 bits), then the address prefix bits
 // follow.
 const tenantPrefixBits = 32
 
 // DenyEntry is one virtual-patch deny rule (#660) the loader writes into the BPF
-// deny_cidr LPM-trie map. The key (PrefixLen, TenantID, Addr) mirrors EgressEntry
-// — a tenant-scoped destination prefix — and the value (Port, Proto) further
-// scopes the block to a single service (0 = any). Deny beats the egress
-// allow-list. The struct is comparable so reconcile can diff desired vs.
-// installed; DenyKey is the kernel map key (CIDR only — the value carries
-// port/proto), so changing only a rule's port updates the same map entry rather
-// than churning two.
+// deny_cidr LPM-trie map (deny_cidr6 for IPv6). The key (PrefixLen, TenantID,
+// Addr/Addr6) mirrors EgressEntry — a tenant-scoped destination prefix — and
+// the value (Port, Proto) further scopes the block to a single service (0 =
+// any). Deny beats the egress allow-list. The struct is comparable so reconcile
+// can diff desired vs. installed; DenyKey is the kernel map key (CIDR only —
+// the value carries port/proto), so changing only a rule's port updates the
+// same map entry rather than churning two.
 type DenyEntry struct {
 	PrefixLen uint32
 	TenantID  uint32
 	Addr      [4]byte
+	IPv6      bool
+	Addr6     [16]byte
 	Port      uint16
 	Proto     uint8
 }
@@ -63,28 +75,42 @@ type DenyKey struct {
 	PrefixLen uint32
 	TenantID  uint32
 	Addr      [4]byte
+	IPv6      bool
+	Addr6     [16]byte
 }
 
 // Key returns the entry's kernel-map key.
 func (e DenyEntry) Key() DenyKey {
-	return DenyKey{PrefixLen: e.PrefixLen, TenantID: e.TenantID, Addr: e.Addr}
+	return DenyKey{PrefixLen: e.PrefixLen, TenantID: e.TenantID, Addr: e.Addr, IPv6: e.IPv6, Addr6: e.Addr6}
 }
 
-// CompileDeny renders a tenant's virtual-patch deny rules into LPM-trie entries.
-// Like CompileEgress it is IPv4-only (the BPF program parses IPv4); a v6 deny
-// rule is rejected rather than silently dropped, so an operator can't believe a
-// v6 destination is blocked when it isn't. Expired rules are NOT filtered here —
-// the daemon drops them (DenyRule.Expired) before calling this.
+// lpmAddr splits a masked prefix into the address half of an LPM key: the four
+// IPv4 bytes, or the sixteen IPv6 bytes with v6 set.
+func lpmAddr(p netip.Prefix) (a4 [4]byte, v6 bool, a6 [16]byte) {
+	if p.Addr().Is4() {
+		return p.Addr().As4(), false, a6
+	}
+	return a4, true, p.Addr().As16()
+}
+
+// CompileDeny renders a tenant's virtual-patch deny rules into LPM-trie
+// entries, IPv4 and IPv6 alike (an IPv6 rule targets deny_cidr6). A prefix that
+// is neither — an invalid netip.Prefix — is rejected rather than silently
+// dropped. Expired rules are NOT filtered here — the daemon drops them
+// (DenyRule.Expired) before calling this.
 func CompileDeny(tenantID uint32, c netpolicy.CompiledPolicy) ([]DenyEntry, error) {
 	out := make([]DenyEntry, 0, len(c.DenyRules))
 	for _, d := range c.DenyRules {
-		if !d.CIDR.Addr().Is4() {
-			return nil, fmt.Errorf("netbpf: deny CIDR %s is not IPv4 (Phase A is IPv4-only)", d.CIDR)
+		if !d.CIDR.IsValid() {
+			return nil, fmt.Errorf("netbpf: invalid deny CIDR %s", d.CIDR)
 		}
+		a4, v6, a6 := lpmAddr(d.CIDR)
 		out = append(out, DenyEntry{
 			PrefixLen: tenantPrefixBits + safecast.U32(d.CIDR.Bits()),
 			TenantID:  tenantID,
-			Addr:      d.CIDR.Addr().As4(),
+			Addr:      a4,
+			IPv6:      v6,
+			Addr6:     a6,
 			Port:      d.Port,
 			Proto:     d.Proto,
 		})
@@ -112,24 +138,138 @@ func CompileConfig(tenantID uint32, c netpolicy.CompiledPolicy) PolicyConfig {
 }
 
 // CompileEgress renders the tenant's egress allow-list (the already-parsed,
-// masked, deduped EgressCIDRs of a CompiledPolicy) into LPM-trie entries.
-//
-// Phase A is IPv4-only (the BPF program parses IPv4 only); any IPv6 CIDR is
-// rejected with an error rather than silently dropped, so a v6 allow-rule can't
-// masquerade as effective. EgressDomains are not handled here — the daemon's
-// resolver (Phase C) folds resolved domain IPs into the same map.
+// masked, deduped EgressCIDRs of a CompiledPolicy) into LPM-trie entries; an
+// IPv6 prefix yields an entry for egress_cidr6. Whether the loaded object can
+// hold v6 entries is the daemon's call (Loader.HasIPv6), not this layer's.
+// EgressDomains are not handled here — the daemon's resolver (Phase C) folds
+// resolved domain IPs (A and AAAA) into the same maps.
 func CompileEgress(tenantID uint32, c netpolicy.CompiledPolicy) ([]EgressEntry, error) {
 	out := make([]EgressEntry, 0, len(c.EgressCIDRs))
 	for _, p := range c.EgressCIDRs {
-		if !p.Addr().Is4() {
-			return nil, fmt.Errorf("netbpf: egress CIDR %s is not IPv4 (Phase A is IPv4-only)", p)
+		if !p.IsValid() {
+			return nil, fmt.Errorf("netbpf: invalid egress CIDR %s", p)
 		}
-		entry := EgressEntry{
+		a4, v6, a6 := lpmAddr(p) // masked network address, network byte order
+		out = append(out, EgressEntry{
 			PrefixLen: tenantPrefixBits + safecast.U32(p.Bits()),
 			TenantID:  tenantID,
-			Addr:      p.Addr().As4(), // masked network address, network byte order
+			Addr:      a4,
+			IPv6:      v6,
+			Addr6:     a6,
+		})
+	}
+	return out, nil
+}
+
+// MaxPortRules is how many proto/port ranges one egress_ports entry holds —
+// mirrors EGRESS_PORT_RULES_MAX in netpolicy.bpf.c, which scans them in an
+// unrolled loop.
+const MaxPortRules = 8
+
+// PortRule is one allowed destination port range, inclusive, for Proto (0 =
+// tcp or udp). Field layout mirrors `struct port_rule` in netpolicy.bpf.c.
+type PortRule struct {
+	Lo, Hi uint16
+	Proto  uint8
+}
+
+// EgressPortsEntry is one port/protocol-scoped allow entry the loader writes
+// into the BPF egress_ports LPM trie (egress_ports6 for IPv6). Key is the same
+// tenant-scoped prefix key an EgressEntry is; the value is the first N Rules.
+// Like DenyEntry, the struct is comparable and the key narrower than the
+// entry, so a changed port list upserts the same slot.
+type EgressPortsEntry struct {
+	Key   EgressEntry
+	N     uint32
+	Rules [MaxPortRules]PortRule
+}
+
+// CompileEgressPorts renders a tenant's port-scoped EgressRules into
+// egress_ports entries, one per distinct CIDR. Domain rules are skipped: the
+// daemon folds their resolved IPs in as host-prefix CIDR rules first.
+//
+// The kernel's LPM lookup returns only the most specific matching prefix, but
+// a destination is allowed by the union of every rule whose prefix covers it.
+// So each entry also carries the ranges of every broader prefix that contains
+// it ("10.0.0.0/8 tcp/5432" plus "10.1.0.0/16 tcp/80" installs tcp/80,5432 at
+// the /16). Overlapping or adjacent ranges for a protocol are merged; an entry
+// still needing more than MaxPortRules ranges is an error for the tenant.
+func CompileEgressPorts(tenantID uint32, c netpolicy.CompiledPolicy) ([]EgressPortsEntry, error) {
+	byPrefix := make(map[netip.Prefix][]PortRule)
+	for _, r := range c.EgressRules {
+		if r.Domain != "" {
+			continue
+		}
+		if !r.CIDR.IsValid() {
+			return nil, fmt.Errorf("netbpf: invalid egress rule CIDR %s", r.CIDR)
 		}
-		out = append(out, entry)
+		pr := PortRule{Lo: r.PortLo, Hi: r.PortHi, Proto: r.Proto}
+		if r.AllPorts() {
+			pr.Hi = 65535
+		}
+		byPrefix[r.CIDR] = append(byPrefix[r.CIDR], pr)
+	}
+	out := make([]EgressPortsEntry, 0, len(byPrefix))
+	for p := range byPrefix {
+		var rules []PortRule
+		for q, qr := range byPrefix {
+			if q.Bits() <= p.Bits() && q.Contains(p.Addr()) {
+				rules = append(rules, qr...)
+			}
+		}
+		rules = mergePortRules(rules)
+		if len(rules) > MaxPortRules {
+			return nil, fmt.Errorf("netbpf: egress rules for %s need %d port ranges (max %d)", p, len(rules), MaxPortRules)
+		}
+		a4, v6, a6 := lpmAddr(p)
+		e := EgressPortsEntry{
+			Key: EgressEntry{
+				PrefixLen: tenantPrefixBits + safecast.U32(p.Bits()),
+				TenantID:  tenantID,
+				Addr:      a4,
+				IPv6:      v6,
+				Addr6:     a6,
+			},
+			N: safecast.U32(len(rules)),
+		}
+		copy(e.Rules[:], rules)
+		out = append(out, e)
 	}
+	sort.Slice(out, func(i, j int) bool { return egressPortsLess(out[i].Key, out[j].Key) })
 	return out, nil
 }
+
+// mergePortRules sorts ranges by protocol then start and coalesces
+// overlapping or adjacent ranges of the same protocol.
+func mergePortRules(in []PortRule) []PortRule {
+	sort.Slice(in, func(i, j int) bool {
+		if in[i].Proto != in[j].Proto {
+			return in[i].Proto < in[j].Proto
+		}
+		return in[i].Lo < in[j].Lo
+	})
+	var out []PortRule
+	for _, r := range in {
+		if n := len(out); n > 0 && out[n-1].Proto == r.Proto && uint32(r.Lo) <= uint32(out[n-1].Hi)+1 {
+			out[n-1].Hi = max(out[n-1].Hi, r.Hi)
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// egressPortsLess orders keys v4 before v6, then by address and prefix
+// length, so compiled entries are stable across reconciles.
+func egressPortsLess(a, b EgressEntry) bool {
+	if a.IPv6 != b.IPv6 {
+		return !a.IPv6
+	}
+	if a.Addr != b.Addr {
+		return string(a.Addr[:]) < string(b.Addr[:])
+	}
+	if a.Addr6 != b.Addr6 {
+		return string(a.Addr6[:]) < string(b.Addr6[:])
+	}
+	return a.PrefixLen < b.PrefixLen
+}
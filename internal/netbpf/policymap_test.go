@@ -79,13 +79,23 @@ func TestCompileEgress(t *testing.T) {
 	}
 }
 
-func TestCompileEgress_RejectsIPv6(t *testing.T) {
+func TestCompileEgress_IPv6(t *testing.T) {
 	c := netpolicy.CompiledPolicy{
 		Tenant:      "alice",
 		EgressCIDRs: []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")},
 	}
-	if _, err := CompileEgress(1, c); err == nil {
-		t.Fatal("expected error for IPv6 egress CIDR (Phase A is IPv4-only)")
+	entries, err := CompileEgress(1, c)
+	if err != nil {
+		t.Fatalf("CompileEgress: %v", err)
+	}
+	want := EgressEntry{PrefixLen: 32 + 32, TenantID: 1, IPv6: true,
+		Addr6: [16]byte{0x20, 0x01, 0x0d, 0xb8}}
+	if len(entries) != 1 || entries[0] != want {
+		t.Fatalf("entries = %+v, want [%+v]", entries, want)
+	}
+	key := egressKey6Bytes(entries[0])
+	if len(key) != 24 || key[8] != 0x20 || key[11] != 0xb8 || key[12] != 0 {
+		t.Errorf("v6 key = %v, want the 16 address bytes at [8:24]", key)
 	}
 }
 
@@ -99,3 +109,71 @@ func TestCompileEgress_Empty(t *testing.T) {
 		t.Errorf("got %d entries, want 0", len(entries))
 	}
 }
+
+// A narrower prefix inherits the ranges of every broader one covering it, so
+// the single most-specific LPM hit still answers for the union; ranges merge.
+func TestCompileEgressPorts_InheritsAndMerges(t *testing.T) {
+	c := mustCompile(t, &pb.NetworkPolicy{
+		Tenant: "a",
+		EgressRules: []*pb.NetworkPolicyEgressRule{
+			{Cidr: "10.0.0.0/8", Proto: "tcp", Port: 5432},
+			{Cidr: "10.1.0.0/16", Proto: "tcp", Port: 80},
+			{Cidr: "10.1.0.0/16", Proto: "tcp", Port: 81, PortEnd: 90},
+			{Cidr: "2001:db8::/32", Proto: "udp"},
+			{Domain: "api.github.com", Proto: "tcp", Port: 443}, // skipped: the daemon folds resolved IPs
+		},
+	})
+	got, err := CompileEgressPorts(7, c)
+	if err != nil {
+		t.Fatalf("CompileEgressPorts: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(got), got)
+	}
+	slash8, slash16, v6 := got[0], got[1], got[2]
+	if slash8.Key.PrefixLen != 32+8 || slash8.N != 1 || slash8.Rules[0] != (PortRule{Lo: 5432, Hi: 5432, Proto: 6}) {
+		t.Errorf("/8 entry = %+v", slash8)
+	}
+	want16 := []PortRule{{Lo: 80, Hi: 90, Proto: 6}, {Lo: 5432, Hi: 5432, Proto: 6}}
+	if slash16.Key.PrefixLen != 32+16 || slash16.Key.TenantID != 7 || int(slash16.N) != len(want16) {
+		t.Fatalf("/16 entry = %+v", slash16)
+	}
+	for i, r := range want16 {
+		if slash16.Rules[i] != r {
+			t.Errorf("/16 rule %d = %+v, want %+v", i, slash16.Rules[i], r)
+		}
+	}
+	if !v6.Key.IPv6 || v6.N != 1 || v6.Rules[0] != (PortRule{Lo: 0, Hi: 65535, Proto: 17}) {
+		t.Errorf("v6 entry = %+v, want every udp port", v6)
+	}
+}
+
+func TestCompileEgressPorts_TooManyRanges(t *testing.T) {
+	var rules []*pb.NetworkPolicyEgressRule
+	for i := 0; i <= MaxPortRules; i++ {
+		rules = append(rules, &pb.NetworkPolicyEgressRule{Cidr: "10.0.0.1", Proto: "tcp", Port: uint32(1000 + 10*i)})
+	}
+	if _, err := CompileEgressPorts(1, mustCompile(t, &pb.NetworkPolicy{Tenant: "a", EgressRules: rules})); err == nil {
+		t.Fatal("want an error past MaxPortRules ranges")
+	}
+}
+
+func TestEgressPortsValueBytes(t *testing.T) {
+	e := EgressPortsEntry{N: 1, Rules: [MaxPortRules]PortRule{{Lo: 443, Hi: 443, Proto: 6}}}
+	b := egressPortsValueBytes(e)
+	if len(b) != 68 || b[4+4] != 6 {
+		t.Fatalf("value = %v", b)
+	}
+}
+
+// The first 8 bytes keep the pre-box-policy struct policy_cfg layout, so the
+// loader can write a truncated value into an older object's map.
+func TestVethPolicyValueLayout(t *testing.T) {
+	b := vethPolicyValue(PolicyConfig{TenantID: 7, Mode: ModeEnforce, AllowIntra: 1, PolicyID: 9})
+	if len(b) != vethPolicyValueSize || b[4] != ModeEnforce || b[5] != 1 || b[6] != 0 {
+		t.Fatalf("value = %v", b)
+	}
+	if b[0]+b[1]+b[2]+b[3] != 7 || b[8]+b[9]+b[10]+b[11] != 9 {
+		t.Errorf("tenant_id/policy_id bytes = %v", b)
+	}
+}
@@ -0,0 +1,138 @@
+package netpolicy
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// boxPolicyName is the shape of a box policy name: a DNS label, so it reads
+// well in `box/<name>` and the CLI.
+var boxPolicyName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// CompiledBoxPolicy is the normalized, validated form of a BoxNetworkPolicy:
+// a CompiledPolicy applied to the tenant's boxes whose labels match Selector.
+type CompiledBoxPolicy struct {
+	Name     string
+	Selector map[string]string // at least one label, keys trimmed
+	Priority int32
+	Policy   CompiledPolicy // Tenant is the box policy's tenant
+}
+
+// CompileBox validates and normalizes a BoxNetworkPolicy. The embedded
+// policy's tenant is ignored and set from the box policy's; a missing
+// embedded policy is an empty one, which denies every external destination.
+func CompileBox(b *pb.BoxNetworkPolicy) (CompiledBoxPolicy, error) {
+	if b == nil {
+		return CompiledBoxPolicy{}, fmt.Errorf("box network policy is nil")
+	}
+	tenant := strings.TrimSpace(b.GetTenant())
+	if tenant == "" {
+		return CompiledBoxPolicy{}, fmt.Errorf("box network policy: tenant is required")
+	}
+	name := strings.TrimSpace(b.GetName())
+	if !boxPolicyName.MatchString(name) {
+		return CompiledBoxPolicy{}, fmt.Errorf("box network policy: invalid name %q (lowercase letters, digits and '-')", b.GetName())
+	}
+	if len(b.GetSelector()) == 0 {
+		return CompiledBoxPolicy{}, fmt.Errorf("box network policy %q: selector needs at least one label", name)
+	}
+	selector := make(map[string]string, len(b.GetSelector()))
+	for k, v := range b.GetSelector() {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			return CompiledBoxPolicy{}, fmt.Errorf("box network policy %q: empty selector label key", name)
+		}
+		selector[k] = v
+	}
+
+	inner := &pb.NetworkPolicy{}
+	if b.GetPolicy() != nil {
+		inner = proto.Clone(b.GetPolicy()).(*pb.NetworkPolicy)
+	}
+	inner.Tenant = tenant
+	policy, err := Compile(inner)
+	if err != nil {
+		return CompiledBoxPolicy{}, fmt.Errorf("box network policy %q: %w", name, err)
+	}
+	return CompiledBoxPolicy{Name: name, Selector: selector, Priority: b.GetPriority(), Policy: policy}, nil
+}
+
+// ToProto renders a CompiledBoxPolicy back into a BoxNetworkPolicy message,
+// the normalized form to persist and echo to callers.
+func (b CompiledBoxPolicy) ToProto() *pb.BoxNetworkPolicy {
+	return &pb.BoxNetworkPolicy{
+		Tenant:   b.Policy.Tenant,
+		Name:     b.Name,
+		Selector: maps.Clone(b.Selector),
+		Priority: b.Priority,
+		Policy:   b.Policy.ToProto(),
+	}
+}
+
+// Selects reports whether a box with these container labels is selected:
+// it carries every selector label with the same value.
+func (b CompiledBoxPolicy) Selects(labels map[string]string) bool {
+	for k, v := range b.Selector {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectBoxPolicy returns the box policy that polices a box with these
+// labels, or nil when none selects it. The highest Priority wins, then the
+// more specific selector (more labels), then the lower name, so the choice
+// never depends on store order.
+func SelectBoxPolicy(policies []CompiledBoxPolicy, labels map[string]string) *CompiledBoxPolicy {
+	var best *CompiledBoxPolicy
+	for i := range policies {
+		p := &policies[i]
+		if !p.Selects(labels) {
+			continue
+		}
+		if best == nil || boxPolicyBefore(p, best) {
+			best = p
+		}
+	}
+	return best
+}
+
+func boxPolicyBefore(a, b *CompiledBoxPolicy) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if len(a.Selector) != len(b.Selector) {
+		return len(a.Selector) > len(b.Selector)
+	}
+	return a.Name < b.Name
+}
+
+// Effective returns the policy that polices a box selected by b, given the
+// tenant-wide policy (nil when the tenant has none). The box policy replaces
+// the tenant-wide allow-list, flags and mode; the tenant-wide deny rules are
+// added to its own, and win where both block the same CIDR, since a virtual
+// patch guards every box of the tenant.
+func (b CompiledBoxPolicy) Effective(tenantWide *CompiledPolicy) CompiledPolicy {
+	out := b.Policy
+	if tenantWide == nil || len(tenantWide.DenyRules) == 0 {
+		return out
+	}
+	deny := append([]DenyRule(nil), tenantWide.DenyRules...)
+	for _, d := range b.Policy.DenyRules {
+		if !slices.ContainsFunc(tenantWide.DenyRules, func(t DenyRule) bool { return t.CIDR == d.CIDR }) {
+			deny = append(deny, d)
+		}
+	}
+	sort.Slice(deny, func(i, j int) bool { return deny[i].CIDR.String() < deny[j].CIDR.String() })
+	out.DenyRules = deny
+	return out
+}
@@ -0,0 +1,114 @@
+package netpolicy
+
+import (
+	"net/netip"
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func mustCompileBox(t *testing.T, b *pb.BoxNetworkPolicy) CompiledBoxPolicy {
+	t.Helper()
+	c, err := CompileBox(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCompileBox(t *testing.T) {
+	c := mustCompileBox(t, &pb.BoxNetworkPolicy{
+		Tenant:   " alice ",
+		Name:     "db",
+		Selector: map[string]string{" role ": "db"},
+		Policy:   &pb.NetworkPolicy{Tenant: "mallory", EgressCidrs: []string{"10.0.0.1/8"}},
+	})
+	if c.Policy.Tenant != "alice" || c.Selector["role"] != "db" || c.Policy.EgressCIDRs[0].String() != "10.0.0.0/8" {
+		t.Errorf("compiled = %+v", c)
+	}
+	if !c.Policy.LogOnly {
+		t.Error("unspecified mode is not log-only")
+	}
+	if p := c.ToProto(); p.GetTenant() != "alice" || p.GetPolicy().GetTenant() != "alice" {
+		t.Errorf("ToProto = %+v", p)
+	}
+	// A box policy without an inner policy allows nothing external.
+	if c := mustCompileBox(t, &pb.BoxNetworkPolicy{Tenant: "alice", Name: "locked", Selector: map[string]string{"role": "db"}}); len(c.Policy.EgressCIDRs)+len(c.Policy.EgressDomains) != 0 {
+		t.Errorf("empty box policy = %+v", c.Policy)
+	}
+
+	for name, b := range map[string]*pb.BoxNetworkPolicy{
+		"no tenant":    {Name: "db", Selector: map[string]string{"role": "db"}},
+		"bad name":     {Tenant: "alice", Name: "DB_1", Selector: map[string]string{"role": "db"}},
+		"no selector":  {Tenant: "alice", Name: "db"},
+		"empty key":    {Tenant: "alice", Name: "db", Selector: map[string]string{" ": "db"}},
+		"inner policy": {Tenant: "alice", Name: "db", Selector: map[string]string{"role": "db"}, Policy: &pb.NetworkPolicy{EgressCidrs: []string{"nope"}}},
+	} {
+		if _, err := CompileBox(b); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestSelectBoxPolicy_Precedence(t *testing.T) {
+	box := func(name string, prio int32, sel map[string]string) CompiledBoxPolicy {
+		return mustCompileBox(t, &pb.BoxNetworkPolicy{Tenant: "alice", Name: name, Priority: prio, Selector: sel})
+	}
+	policies := []CompiledBoxPolicy{
+		box("runners", 0, map[string]string{"role": "ci"}),
+		box("prod-runners", 0, map[string]string{"role": "ci", "env": "prod"}),
+		box("b-db", 0, map[string]string{"role": "db"}),
+		box("a-db", 0, map[string]string{"role": "db"}),
+		box("quarantine", 10, map[string]string{"quarantine": "true"}),
+	}
+	cases := []struct {
+		labels map[string]string
+		want   string
+	}{
+		{map[string]string{"role": "web"}, ""},
+		{map[string]string{"role": "ci"}, "runners"},
+		{map[string]string{"role": "ci", "env": "prod"}, "prod-runners"},
+		{map[string]string{"role": "db"}, "a-db"},
+		{map[string]string{"role": "db", "quarantine": "true"}, "quarantine"},
+		{nil, ""},
+	}
+	for _, tc := range cases {
+		got := SelectBoxPolicy(policies, tc.labels)
+		if (got == nil && tc.want != "") || (got != nil && got.Name != tc.want) {
+			t.Errorf("labels %v: selected %+v, want %q", tc.labels, got, tc.want)
+		}
+	}
+}
+
+// The box policy replaces the tenant-wide allow-list and mode, but every
+// tenant-wide virtual patch still applies.
+func TestBoxPolicyEffective(t *testing.T) {
+	tenant := mustCompile(t, &pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"0.0.0.0/0"},
+		Mode:        pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE,
+		DenyRules:   []*pb.NetworkPolicyDenyRule{{Cidr: "203.0.113.9", Note: "CVE-1"}},
+	})
+	b := mustCompileBox(t, &pb.BoxNetworkPolicy{
+		Tenant:   "alice",
+		Name:     "db",
+		Selector: map[string]string{"role": "db"},
+		Policy: &pb.NetworkPolicy{DenyRules: []*pb.NetworkPolicyDenyRule{
+			{Cidr: "203.0.113.9", Note: "box"},
+			{Cidr: "198.51.100.0/24"},
+		}},
+	})
+	eff := b.Effective(tenant)
+	if len(eff.EgressCIDRs) != 0 || !eff.LogOnly {
+		t.Errorf("effective allow-list/mode = %v log_only=%v, want the box policy's", eff.EgressCIDRs, eff.LogOnly)
+	}
+	if len(eff.DenyRules) != 2 || eff.DenyRules[1].Note != "CVE-1" {
+		t.Errorf("effective deny rules = %+v", eff.DenyRules)
+	}
+	if d := eff.Decide(Observation{Dest: netip.MustParseAddr("8.8.8.8"), Proto: 17, Port: 53}, ""); d.Allowed {
+		t.Errorf("box policy allowed the tenant-wide allow-list: %+v", d)
+	}
+	if got := b.Effective(nil); len(got.DenyRules) != 2 {
+		t.Errorf("without a tenant policy deny rules = %+v", got.DenyRules)
+	}
+}
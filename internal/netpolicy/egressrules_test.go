@@ -0,0 +1,91 @@
+package netpolicy
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestCompile_EgressRules_OK(t *testing.T) {
+	got, err := Compile(&pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"8.8.8.8/32"},
+		EgressRules: []*pb.NetworkPolicyEgressRule{
+			{Domain: "API.GitHub.com.", Proto: "tcp", Port: 443},
+			{Cidr: "10.1.2.3/8", Proto: "TCP", Port: 5432},
+			{Cidr: "10.0.0.0/8", Proto: "tcp", Port: 5432, PortEnd: 5432}, // same rule once normalized
+			{Cidr: "2001:db8::1", Proto: "udp", Port: 8000, PortEnd: 8100},
+			{Cidr: "192.0.2.0/24", Proto: "udp"}, // every udp port
+			{Cidr: "1.1.1.1"},                    // unscoped → bare CIDR
+			{Domain: "example.com"},              // unscoped → bare domain
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	want := []EgressRule{
+		{CIDR: netip.MustParsePrefix("10.0.0.0/8"), Proto: 6, PortLo: 5432, PortHi: 5432},
+		{CIDR: netip.MustParsePrefix("192.0.2.0/24"), Proto: 17},
+		{CIDR: netip.MustParsePrefix("2001:db8::1/128"), Proto: 17, PortLo: 8000, PortHi: 8100},
+		{Domain: "api.github.com", Proto: 6, PortLo: 443, PortHi: 443},
+	}
+	if len(got.EgressRules) != len(want) {
+		t.Fatalf("rules = %+v, want %+v", got.EgressRules, want)
+	}
+	for i := range want {
+		if got.EgressRules[i] != want[i] {
+			t.Errorf("rule %d = %+v, want %+v", i, got.EgressRules[i], want[i])
+		}
+	}
+	if !got.EgressRules[1].AllPorts() || got.EgressRules[0].AllPorts() {
+		t.Error("AllPorts wrong")
+	}
+	if len(got.EgressCIDRs) != 2 || got.EgressCIDRs[0].String() != "1.1.1.1/32" {
+		t.Errorf("bare CIDRs = %v, want the unscoped rule folded in", got.EgressCIDRs)
+	}
+	if len(got.EgressDomains) != 1 || got.EgressDomains[0] != "example.com" {
+		t.Errorf("bare domains = %v, want the unscoped rule folded in", got.EgressDomains)
+	}
+
+	// ToProto round-trips through Compile unchanged.
+	again, err := Compile(got.ToProto())
+	if err != nil {
+		t.Fatalf("recompile: %v", err)
+	}
+	for i := range want {
+		if again.EgressRules[i] != want[i] {
+			t.Errorf("round-trip rule %d = %+v, want %+v", i, again.EgressRules[i], want[i])
+		}
+	}
+	if pr := got.ToProto().GetEgressRules()[2]; pr.GetCidr() != "2001:db8::1/128" || pr.GetPort() != 8000 || pr.GetPortEnd() != 8100 || pr.GetProto() != "udp" {
+		t.Errorf("ToProto range rule = %+v", pr)
+	}
+}
+
+func TestCompile_EgressRules_Invalid(t *testing.T) {
+	cases := []struct {
+		name string
+		rule *pb.NetworkPolicyEgressRule
+		want string
+	}{
+		{"no dest", &pb.NetworkPolicyEgressRule{Proto: "tcp", Port: 1}, "exactly one of cidr and domain"},
+		{"both dests", &pb.NetworkPolicyEgressRule{Cidr: "1.1.1.1", Domain: "x.example", Port: 1}, "exactly one of cidr and domain"},
+		{"bad proto", &pb.NetworkPolicyEgressRule{Cidr: "1.1.1.1", Proto: "sctp", Port: 1}, "unknown egress rule proto"},
+		{"port too big", &pb.NetworkPolicyEgressRule{Cidr: "1.1.1.1", Port: 70000}, "out of range"},
+		{"end below start", &pb.NetworkPolicyEgressRule{Cidr: "1.1.1.1", Port: 443, PortEnd: 80}, "below port"},
+		{"end without start", &pb.NetworkPolicyEgressRule{Cidr: "1.1.1.1", Proto: "tcp", PortEnd: 80}, "needs a port"},
+		{"bad cidr", &pb.NetworkPolicyEgressRule{Cidr: "10.0.0.0/33", Port: 1}, "invalid egress CIDR"},
+		{"bad address", &pb.NetworkPolicyEgressRule{Cidr: "not-an-ip", Port: 1}, "invalid egress rule address"},
+		{"domain with port", &pb.NetworkPolicyEgressRule{Domain: "x.example:443", Proto: "tcp", Port: 443}, "bare hostname"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Compile(&pb.NetworkPolicy{Tenant: "t", EgressRules: []*pb.NetworkPolicyEgressRule{c.rule}})
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("err = %v, want containing %q", err, c.want)
+			}
+		})
+	}
+}
@@ -4,9 +4,9 @@
 //
 // It is deliberately pure (no BPF, no DB, no network): given a *pb.NetworkPolicy
 // it returns either a validation error or a CompiledPolicy with parsed/deduped
-// CIDRs, normalized domains, and a resolved mode. The BPF map-update plumbing
-// (a later Phase A increment) turns a CompiledPolicy into map entries. See
-// docs/security/NETWORK-ISOLATION-DESIGN.md (#315).
+// CIDRs (IPv4 and IPv6), normalized domains, and a resolved mode. The BPF
+// map-update plumbing (a later Phase A increment) turns a CompiledPolicy into
+// map entries. See docs/security/NETWORK-ISOLATION-DESIGN.md (#315).
 package netpolicy
 
 import (
@@ -27,7 +27,7 @@ import (
 type CompiledPolicy struct {
 	Tenant           string
 	AllowIntraTenant bool
-	EgressCIDRs      []netip.Prefix // parsed, masked-to-network, deduped, sorted
+	EgressCIDRs      []netip.Prefix // parsed, masked-to-network, deduped, sorted; v4 and v6
 	EgressDomains    []string       // lowercased, trimmed, deduped, sorted
 	AllowMetadata    bool           // may reach the cloud metadata service (default deny)
 	Mode             pb.NetworkPolicyMode
@@ -40,11 +40,50 @@ type CompiledPolicy struct {
 	// here (not filtered) so the package stays time-pure; the daemon drops expired
 	// rules with DenyRule.Expired(now) before pushing them to the kernel.
 	DenyRules []DenyRule
+	// EgressRules are the port/protocol-scoped allow entries, deduped and
+	// sorted. A rule scoped to neither is folded into EgressCIDRs /
+	// EgressDomains instead, so every entry here is narrower than a bare one.
+	EgressRules []EgressRule
 }
 
+// EgressRule is one normalized port/protocol-scoped egress allow entry.
+// Exactly one of CIDR and Domain is set. Proto 0 means TCP or UDP; a rule
+// with Proto set and PortLo == 0 allows every port of that protocol.
+type EgressRule struct {
+	CIDR   netip.Prefix
+	Domain string
+	Proto  uint8  // 0 = tcp or udp; 6 = tcp, 17 = udp
+	PortLo uint16 // first allowed port (0 with PortHi 0 = every port)
+	PortHi uint16 // last allowed port, inclusive
+}
+
+// Dest renders the rule's destination: the CIDR, or the domain.
+func (r EgressRule) Dest() string {
+	if r.Domain != "" {
+		return r.Domain
+	}
+	return r.CIDR.String()
+}
+
+// toProto renders the rule as a NetworkPolicyEgressRule, PortEnd only when
+// the range spans more than one port.
+func (r EgressRule) toProto() *pb.NetworkPolicyEgressRule {
+	pr := &pb.NetworkPolicyEgressRule{Domain: r.Domain, Proto: protoName(r.Proto), Port: uint32(r.PortLo)}
+	if r.Domain == "" {
+		pr.Cidr = r.CIDR.String()
+	}
+	if r.PortHi != r.PortLo {
+		pr.PortEnd = uint32(r.PortHi)
+	}
+	return pr
+}
+
+// AllPorts reports whether the rule allows every port of its protocol.
+func (r EgressRule) AllPorts() bool { return r.PortLo == 0 && r.PortHi == 0 }
+
 // DenyRule is one normalized virtual-patch block rule (#660). The destination
 // CIDR is matched first; Port/Proto (0 = any) further scope the block to a
-// single service. A host IP is carried as a /32.
+// single service. A host IP is carried as a /32 (v4) or /128 (v6).
 type DenyRule struct {
 	CIDR      netip.Prefix
 	Port      uint16    // 0 = any port
@@ -77,11 +116,15 @@ func Compile(p *pb.NetworkPolicy) (CompiledPolicy, error) {
 		return CompiledPolicy{}, fmt.Errorf("network policy: tenant is required")
 	}
 
-	cidrs, err := compileCIDRs(p.GetEgressCidrs())
+	rules, bareCIDRs, bareDomains, err := compileEgressRules(p.GetEgressRules())
 	if err != nil {
 		return CompiledPolicy{}, err
 	}
-	domains, err := compileDomains(p.GetEgressDomains())
+	cidrs, err := compileCIDRs(append(append([]string(nil), p.GetEgressCidrs()...), bareCIDRs...))
+	if err != nil {
+		return CompiledPolicy{}, err
+	}
+	domains, err := compileDomains(append(append([]string(nil), p.GetEgressDomains()...), bareDomains...))
 	if err != nil {
 		return CompiledPolicy{}, err
 	}
@@ -111,6 +154,7 @@ func Compile(p *pb.NetworkPolicy) (CompiledPolicy, error) {
 		Mode:             mode,
 		LogOnly:          mode != pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE,
 		DenyRules:        deny,
+		EgressRules:      rules,
 	}, nil
 }
 
@@ -139,6 +183,13 @@ func (c CompiledPolicy) ToProto() *pb.NetworkPolicy {
 			}
 		}
 	}
+	var rules []*pb.NetworkPolicyEgressRule
+	if len(c.EgressRules) > 0 {
+		rules = make([]*pb.NetworkPolicyEgressRule, len(c.EgressRules))
+		for i, r := range c.EgressRules {
+			rules[i] = r.toProto()
+		}
+	}
 	return &pb.NetworkPolicy{
 		Tenant:           c.Tenant,
 		AllowIntraTenant: c.AllowIntraTenant,
@@ -147,6 +198,7 @@ func (c CompiledPolicy) ToProto() *pb.NetworkPolicy {
 		AllowMetadata:    c.AllowMetadata,
 		Mode:             c.Mode,
 		DenyRules:        deny,
+		EgressRules:      rules,
 	}
 }
 
@@ -163,6 +215,9 @@ func compileCIDRs(raw []string) ([]netip.Prefix, error) {
 		if err != nil {
 			return nil, fmt.Errorf("network policy: invalid egress CIDR %q: %w", c, err)
 		}
+		if p, err = unmapPrefix(p); err != nil {
+			return nil, fmt.Errorf("network policy: invalid egress CIDR %q: %w", c, err)
+		}
 		p = p.Masked()
 		seen[p.String()] = p
 	}
@@ -204,12 +259,19 @@ func compileDenyRules(raw []*pb.NetworkPolicyDenyRule) ([]DenyRule, error) {
 			if err != nil {
 				return nil, fmt.Errorf("network policy: invalid deny cidr %q: %w", c, err)
 			}
+			if p, err = unmapPrefix(p); err != nil {
+				return nil, fmt.Errorf("network policy: invalid deny cidr %q: %w", c, err)
+			}
 			prefix = p.Masked()
 		} else {
 			a, err := netip.ParseAddr(c)
 			if err != nil {
 				return nil, fmt.Errorf("network policy: invalid deny address %q: %w", c, err)
 			}
+			if a.Zone() != "" {
+				return nil, fmt.Errorf("network policy: invalid deny address %q: zoned addresses are not routable destinations", c)
+			}
+			a = a.Unmap()
 			prefix = netip.PrefixFrom(a, a.BitLen())
 		}
 		if r.GetPort() > 65535 {
@@ -243,6 +305,105 @@ func compileDenyRules(raw []*pb.NetworkPolicyDenyRule) ([]DenyRule, error) {
 	return out, nil
 }
 
+// compileEgressRules parses the port/protocol-scoped egress rules. A rule
+// scoped to neither a protocol nor a port allows everything a bare entry
+// does, so its destination is handed back in bareCIDRs / bareDomains for the
+// ordinary lists rather than kept as a rule. Destinations are validated the
+// way the bare lists validate them; port_end, if set, must not be below port.
+// Rules are deduped and sorted by destination, protocol, then port range.
+func compileEgressRules(raw []*pb.NetworkPolicyEgressRule) (rules []EgressRule, bareCIDRs, bareDomains []string, err error) {
+	seen := make(map[EgressRule]bool, len(raw))
+	for _, r := range raw {
+		if r == nil {
+			continue
+		}
+		cidr, domain := strings.TrimSpace(r.GetCidr()), strings.TrimSpace(r.GetDomain())
+		if (cidr == "") == (domain == "") {
+			return nil, nil, nil, fmt.Errorf("network policy: egress rule needs exactly one of cidr and domain")
+		}
+		proto, err := parseProto(r.GetProto())
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("network policy: unknown egress rule proto %q (want tcp, udp, or empty)", r.GetProto())
+		}
+		lo, hi := r.GetPort(), r.GetPortEnd()
+		if hi == 0 {
+			hi = lo
+		}
+		if lo > 65535 || hi > 65535 {
+			return nil, nil, nil, fmt.Errorf("network policy: egress rule port range %d-%d out of range (0-65535)", lo, hi)
+		}
+		if lo == 0 && hi != 0 {
+			return nil, nil, nil, fmt.Errorf("network policy: egress rule port_end %d needs a port", hi)
+		}
+		if hi < lo {
+			return nil, nil, nil, fmt.Errorf("network policy: egress rule port_end %d is below port %d", hi, lo)
+		}
+		if cidr != "" && !strings.Contains(cidr, "/") {
+			a, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("network policy: invalid egress rule address %q: %w", cidr, err)
+			}
+			a = a.Unmap().WithZone("")
+			cidr = netip.PrefixFrom(a, a.BitLen()).String()
+		}
+		if proto == 0 && lo == 0 {
+			if cidr != "" {
+				bareCIDRs = append(bareCIDRs, cidr)
+			} else {
+				bareDomains = append(bareDomains, domain)
+			}
+			continue
+		}
+		rule := EgressRule{Proto: proto, PortLo: safecast.U16FromUint(lo), PortHi: safecast.U16FromUint(hi)}
+		if cidr != "" {
+			ps, err := compileCIDRs([]string{cidr})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			rule.CIDR = ps[0]
+		} else {
+			ds, err := compileDomains([]string{domain})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			rule.Domain = ds[0]
+		}
+		if !seen[rule] {
+			seen[rule] = true
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Dest() != b.Dest() {
+			return a.Dest() < b.Dest()
+		}
+		if a.Proto != b.Proto {
+			return a.Proto < b.Proto
+		}
+		if a.PortLo != b.PortLo {
+			return a.PortLo < b.PortLo
+		}
+		return a.PortHi < b.PortHi
+	})
+	return rules, bareCIDRs, bareDomains, nil
+}
+
+// unmapPrefix rewrites an IPv4-mapped IPv6 prefix (::ffff:a.b.c.d/n) as the
+// IPv4 prefix it denotes, so the rule lands in the map the kernel actually
+// consults for that traffic — a mapped address never appears on the wire as a
+// v6 destination. A mapped prefix shorter than /96 spans more than the mapped
+// range and is rejected rather than guessed at.
+func unmapPrefix(p netip.Prefix) (netip.Prefix, error) {
+	if !p.Addr().Is4In6() {
+		return p, nil
+	}
+	if p.Bits() < 96 {
+		return netip.Prefix{}, fmt.Errorf("IPv4-mapped prefix shorter than /96")
+	}
+	return netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-96), nil
+}
+
 // parseProto maps a friendly protocol name to its IP protocol number. ""/"any"
 // is 0 (match any protocol).
 func parseProto(s string) (uint8, error) {
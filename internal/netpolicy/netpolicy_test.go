@@ -61,6 +61,7 @@ func TestCompile_Errors(t *testing.T) {
 		{"empty tenant", &pb.NetworkPolicy{Tenant: "  "}, "tenant is required"},
 		{"bad cidr", &pb.NetworkPolicy{Tenant: "t", EgressCidrs: []string{"not-a-cidr"}}, "invalid egress CIDR"},
 		{"bad cidr bits", &pb.NetworkPolicy{Tenant: "t", EgressCidrs: []string{"10.0.0.0/40"}}, "invalid egress CIDR"},
+		{"short mapped cidr", &pb.NetworkPolicy{Tenant: "t", EgressCidrs: []string{"::ffff:0:0/80"}}, "IPv4-mapped"},
 		{"domain with scheme", &pb.NetworkPolicy{Tenant: "t", EgressDomains: []string{"https://x.com"}}, "bare hostname"},
 		{"domain with port", &pb.NetworkPolicy{Tenant: "t", EgressDomains: []string{"x.com:443"}}, "bare hostname"},
 		{"unknown mode", &pb.NetworkPolicy{Tenant: "t", Mode: pb.NetworkPolicyMode(99)}, "unknown mode"},
@@ -82,6 +83,34 @@ func TestCompile_Errors(t *testing.T) {
 	}
 }
 
+// IPv6 CIDRs compile alongside IPv4 ones, masked and deduped the same way; an
+// IPv4-mapped prefix becomes the IPv4 prefix the kernel will see.
+func TestCompile_IPv6(t *testing.T) {
+	got, err := Compile(&pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"2606:4700::1/32", "2606:4700::/32", "::ffff:1.2.3.4/120", "1.2.3.0/24"},
+		DenyRules: []*pb.NetworkPolicyDenyRule{
+			{Cidr: "2001:db8::7"},
+			{Cidr: "::ffff:9.9.9.9", Port: 53, Proto: "udp"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	var cidrs []string
+	for _, p := range got.EgressCIDRs {
+		cidrs = append(cidrs, p.String())
+	}
+	if len(cidrs) != 2 || cidrs[0] != "1.2.3.0/24" || cidrs[1] != "2606:4700::/32" {
+		t.Errorf("egress = %v, want [1.2.3.0/24 2606:4700::/32]", cidrs)
+	}
+	if len(got.DenyRules) != 2 ||
+		got.DenyRules[0].CIDR.String() != "2001:db8::7/128" ||
+		got.DenyRules[1].CIDR.String() != "9.9.9.9/32" {
+		t.Errorf("deny = %+v", got.DenyRules)
+	}
+}
+
 func TestCompile_EmptyListsAreFine(t *testing.T) {
 	got, err := Compile(&pb.NetworkPolicy{Tenant: "t"})
 	if err != nil {
@@ -0,0 +1,73 @@
+package netpolicy
+
+import "net/netip"
+
+// SimulatedDestination is one observed destination judged under the current
+// policy and a proposed replacement.
+type SimulatedDestination struct {
+	Observation
+	PeerTenant string   // owning tenant when the destination is a managed container
+	Current    Decision // ReasonNoPolicy when the tenant has none
+	Proposed   Decision
+}
+
+// Simulation is recorded egress replayed through a proposed policy.
+type Simulation struct {
+	Destinations []SimulatedDestination // busiest first
+}
+
+// NewlyDenied returns the destinations the current policy allows and the
+// proposed one would deny.
+func (s Simulation) NewlyDenied() []SimulatedDestination {
+	return s.filter(func(d SimulatedDestination) bool { return d.Current.Allowed && !d.Proposed.Allowed })
+}
+
+// NewlyAllowed returns the destinations the current policy denies and the
+// proposed one would allow.
+func (s Simulation) NewlyAllowed() []SimulatedDestination {
+	return s.filter(func(d SimulatedDestination) bool { return !d.Current.Allowed && d.Proposed.Allowed })
+}
+
+// StillDenied returns the destinations both policies deny.
+func (s Simulation) StillDenied() []SimulatedDestination {
+	return s.filter(func(d SimulatedDestination) bool { return !d.Current.Allowed && !d.Proposed.Allowed })
+}
+
+func (s Simulation) filter(keep func(SimulatedDestination) bool) []SimulatedDestination {
+	var out []SimulatedDestination
+	for _, d := range s.Destinations {
+		if keep(d) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Simulate replays observed egress through the current policy and a proposed
+// one with DecideNames, the same evaluation the TC program applies. current
+// is nil when the tenant has no policy, which polices nothing: every flow is
+// allowed today. peers maps a managed container's address to its tenant;
+// names lists the domains an address is known by. Either may be nil.
+// Observations are merged per address, protocol and port first.
+func Simulate(observed []Observation, peers func(netip.Addr) string, names func(netip.Addr) []string, current *CompiledPolicy, proposed CompiledPolicy) Simulation {
+	merged := mergeObservations(observed)
+	out := make([]SimulatedDestination, 0, len(merged))
+	for _, m := range merged {
+		d := SimulatedDestination{Observation: m.Observation}
+		if peers != nil {
+			d.PeerTenant = peers(d.Dest)
+		}
+		var ns []string
+		if names != nil {
+			ns = names(d.Dest)
+		}
+		if current != nil {
+			d.Current = current.DecideNames(d.Observation, d.PeerTenant, ns)
+		} else {
+			d.Current = Decision{Allowed: true, Reason: ReasonNoPolicy}
+		}
+		d.Proposed = proposed.DecideNames(d.Observation, d.PeerTenant, ns)
+		out = append(out, d)
+	}
+	return Simulation{Destinations: out}
+}
@@ -0,0 +1,74 @@
+package netpolicy
+
+import (
+	"net/netip"
+	"testing"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestSimulate_Transitions(t *testing.T) {
+	cur := mustCompile(t, &pb.NetworkPolicy{
+		Tenant:        "alice",
+		EgressCidrs:   []string{"10.0.0.0/8"},
+		EgressDomains: []string{"api.github.com"},
+	})
+	prop := *mustCompile(t, &pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"10.100.0.1/32"},
+		EgressRules: []*pb.NetworkPolicyEgressRule{{Domain: "pypi.org", Proto: "tcp", Port: 443}},
+	})
+	names := func(a netip.Addr) []string {
+		// A CDN address both names resolve to.
+		if a.String() == "151.101.1.69" {
+			return []string{"api.github.com", "pypi.org"}
+		}
+		return nil
+	}
+	sim := Simulate([]Observation{
+		obs("10.100.0.1", 17, 53, 40),  // allowed by both
+		obs("10.2.3.4", 6, 5432, 7),    // 10/8 dropped
+		obs("10.2.3.4", 6, 5432, 3),    // merged with the above
+		obs("151.101.1.69", 6, 443, 5), // github today, pypi's rule proposed
+		obs("151.101.1.69", 6, 80, 2),  // github today, nothing proposed
+		obs("203.0.113.9", 6, 443, 1),  // denied by both
+	}, nil, names, cur, prop)
+
+	if len(sim.Destinations) != 5 || sim.Destinations[1].Flows != 10 {
+		t.Fatalf("destinations = %+v", sim.Destinations)
+	}
+	nd := sim.NewlyDenied()
+	if len(nd) != 2 || nd[0].Dest.String() != "10.2.3.4" || nd[1].Port != 80 {
+		t.Errorf("newly denied = %+v", nd)
+	}
+	if nd[0].Current.Entry != "10.0.0.0/8" || nd[0].Proposed.Reason != ReasonNotInAllowList {
+		t.Errorf("decisions = %+v / %+v", nd[0].Current, nd[0].Proposed)
+	}
+	if len(sim.NewlyAllowed()) != 0 || len(sim.StillDenied()) != 1 {
+		t.Errorf("newly allowed %d, still denied %d", len(sim.NewlyAllowed()), len(sim.StillDenied()))
+	}
+	for _, d := range sim.Destinations {
+		if d.Port == 443 && d.Dest.String() == "151.101.1.69" && d.Proposed.Entry != "pypi.org tcp/443" {
+			t.Errorf("shared address matched %q, want the pypi rule", d.Proposed.Entry)
+		}
+	}
+}
+
+// Without a current policy nothing is policed, so every flow the proposal
+// denies is newly denied.
+func TestSimulate_NoCurrentPolicy(t *testing.T) {
+	prop := *mustCompile(t, &pb.NetworkPolicy{Tenant: "alice", EgressCidrs: []string{"192.0.2.0/24"}})
+	sim := Simulate([]Observation{
+		obs("192.0.2.1", 6, 443, 1),
+		obs("10.100.0.9", 6, 5432, 1),
+	}, func(a netip.Addr) string {
+		if a.String() == "10.100.0.9" {
+			return "bob"
+		}
+		return ""
+	}, nil, nil, prop)
+	nd := sim.NewlyDenied()
+	if len(nd) != 1 || nd[0].Current.Reason != ReasonNoPolicy || nd[0].Proposed.Reason != ReasonCrossTenant {
+		t.Errorf("newly denied = %+v", nd)
+	}
+}
@@ -0,0 +1,495 @@
+package netpolicy
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// The cloud metadata endpoints the TC program blocks unless AllowMetadata.
+var (
+	metadataIPv4 = netip.MustParseAddr("169.254.169.254")
+	metadataIPv6 = netip.MustParseAddr("fd00:ec2::254")
+)
+
+// suggestPortCollapse is how many distinct ports to one destination and
+// protocol Suggest proposes as separate rules before it proposes the
+// protocol's whole port range instead. It matches the per-prefix range limit
+// of the kernel's egress_ports map, so a suggestion always loads.
+const suggestPortCollapse = 8
+
+// Decision reasons, as reported by Decide.
+const (
+	ReasonAllowList      = "allow-list"            // an egress CIDR, domain or rule matched
+	ReasonIntraTenant    = "intra-tenant"          // a same-tenant peer, allow_intra_tenant set
+	ReasonLinkScope      = "link-scope"            // v6 link-local/multicast, never policed
+	ReasonVirtualPatch   = "virtual-patch"         // a deny rule matched (deny beats allow)
+	ReasonMetadata       = "metadata"              // the metadata endpoint, allow_metadata unset
+	ReasonCrossTenant    = "cross-tenant"          // another tenant's container
+	ReasonIntraDisabled  = "intra-tenant-disabled" // a same-tenant peer, allow_intra_tenant unset
+	ReasonNotInAllowList = "not-in-allow-list"     // an external destination nothing allows
+	ReasonNoPolicy       = "no-policy"             // the tenant has no policy; nothing is policed
+)
+
+// Observation is one egress destination a tenant's containers reached,
+// aggregated over the flows seen to it: the address, protocol and destination
+// port, with Domain the name the address is known by ("" if none).
+type Observation struct {
+	Dest       netip.Addr
+	Proto      uint8  // IP protocol number: 6 tcp, 17 udp, 1 icmp, …
+	Port       uint16 // destination port; 0 for port-less protocols
+	Domain     string
+	Flows      int64
+	Bytes      int64
+	LastSeen   time.Time
+	Containers []string // sorted
+}
+
+// Decision is the verdict for one observation under a policy. Entry is the
+// allow-list entry that matched, in the CLI's --egress-rule syntax (a bare
+// CIDR or domain renders alone).
+type Decision struct {
+	Allowed bool
+	Reason  string
+	Entry   string
+
+	match allowEntry
+}
+
+// allowEntry is one allow-list entry in typed form: exactly one field is set.
+type allowEntry struct {
+	cidr   netip.Prefix
+	domain string
+	rule   *EgressRule
+}
+
+func (a allowEntry) String() string {
+	switch {
+	case a.rule != nil:
+		return a.rule.String()
+	case a.domain != "":
+		return a.domain
+	case a.cidr.IsValid():
+		return a.cidr.String()
+	}
+	return ""
+}
+
+// String renders the rule in the CLI's --egress-rule syntax,
+// "<dest> <tcp|udp|any>[/<port>[-<end>]]".
+func (r EgressRule) String() string {
+	proto := protoName(r.Proto)
+	if proto == "" {
+		proto = "any"
+	}
+	s := r.Dest() + " " + proto
+	if !r.AllPorts() {
+		s += "/" + strconv.Itoa(int(r.PortLo))
+		if r.PortHi != r.PortLo {
+			s += "-" + strconv.Itoa(int(r.PortHi))
+		}
+	}
+	return s
+}
+
+// matches reports whether the rule allows the observation. A domain rule
+// matches by the name the address was observed under, standing in for the
+// daemon's resolution of the domain to addresses.
+func (r EgressRule) matches(o Observation) bool {
+	if r.Domain != "" {
+		if r.Domain != o.Domain {
+			return false
+		}
+	} else if !r.CIDR.Contains(o.Dest) {
+		return false
+	}
+	if o.Proto != 6 && o.Proto != 17 {
+		return false // only TCP and UDP carry ports
+	}
+	if r.Proto != 0 && r.Proto != o.Proto {
+		return false
+	}
+	return r.AllPorts() || (o.Port >= r.PortLo && o.Port <= r.PortHi)
+}
+
+// Decide evaluates an observation against the policy in the TC program's
+// order: a virtual-patch deny rule, then the metadata endpoint, then another
+// managed container (peerTenant is its tenant, "" for an external
+// destination), then the allow-list. Expired deny rules are the caller's to
+// drop first, as for the kernel.
+func (c CompiledPolicy) Decide(o Observation, peerTenant string) Decision {
+	if o.Dest.Is6() && (o.Dest.IsLinkLocalUnicast() || o.Dest.IsMulticast()) {
+		return Decision{Allowed: true, Reason: ReasonLinkScope}
+	}
+	for _, d := range c.DenyRules {
+		if d.CIDR.Contains(o.Dest) && (d.Port == 0 || d.Port == o.Port) && (d.Proto == 0 || d.Proto == o.Proto) {
+			return Decision{Reason: ReasonVirtualPatch}
+		}
+	}
+	if o.Dest == metadataIPv4 || o.Dest == metadataIPv6 {
+		return Decision{Allowed: c.AllowMetadata, Reason: ReasonMetadata}
+	}
+	if peerTenant != "" {
+		switch {
+		case peerTenant != c.Tenant:
+			return Decision{Reason: ReasonCrossTenant}
+		case !c.AllowIntraTenant:
+			return Decision{Reason: ReasonIntraDisabled}
+		}
+		return Decision{Allowed: true, Reason: ReasonIntraTenant}
+	}
+	if e, ok := c.allowing(o); ok {
+		return Decision{Allowed: true, Reason: ReasonAllowList, Entry: e.String(), match: e}
+	}
+	return Decision{Reason: ReasonNotInAllowList}
+}
+
+// DecideNames is Decide for an address known by several names, as the
+// kernel sees it: every domain resolving to the address installs it, so the
+// observation is allowed when the bare address or any one name is.
+func (c CompiledPolicy) DecideNames(o Observation, peerTenant string, names []string) Decision {
+	o.Domain = ""
+	d := c.Decide(o, peerTenant)
+	if d.Reason != ReasonNotInAllowList {
+		return d
+	}
+	for _, n := range names {
+		o.Domain = n
+		if nd := c.Decide(o, peerTenant); nd.Allowed {
+			return nd
+		}
+	}
+	return d
+}
+
+// allowing finds the allow-list entry that admits an external observation,
+// preferring the broadest kind (bare CIDR, bare domain, then rules) so a
+// suggestion keeps the entry the operator is most likely to have written.
+func (c CompiledPolicy) allowing(o Observation) (allowEntry, bool) {
+	for _, p := range c.EgressCIDRs {
+		if p.Contains(o.Dest) {
+			return allowEntry{cidr: p}, true
+		}
+	}
+	if o.Domain != "" {
+		for _, d := range c.EgressDomains {
+			if d == o.Domain {
+				return allowEntry{domain: d}, true
+			}
+		}
+	}
+	for i := range c.EgressRules {
+		if c.EgressRules[i].matches(o) {
+			r := c.EgressRules[i]
+			return allowEntry{rule: &r}, true
+		}
+	}
+	return allowEntry{}, false
+}
+
+// Entries lists the policy's allow-list in the --egress-rule syntax, plus
+// "allow-intra-tenant" and "allow-metadata" when set, sorted — the unit a
+// suggestion is diffed in.
+func (c CompiledPolicy) Entries() []string {
+	out := make([]string, 0, len(c.EgressCIDRs)+len(c.EgressDomains)+len(c.EgressRules)+2)
+	for _, p := range c.EgressCIDRs {
+		out = append(out, p.String())
+	}
+	out = append(out, c.EgressDomains...)
+	for _, r := range c.EgressRules {
+		out = append(out, r.String())
+	}
+	if c.AllowIntraTenant {
+		out = append(out, "allow-intra-tenant")
+	}
+	if c.AllowMetadata {
+		out = append(out, "allow-metadata")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SuggestedDestination is one merged observation with how the current policy
+// treats it and how the candidate covers it.
+type SuggestedDestination struct {
+	Observation
+	PeerTenant string   // owning tenant when the destination is a managed container
+	Current    Decision // under the current policy; ReasonNoPolicy when the tenant has none
+	Rule       string   // candidate entry that allows it; "" when Excluded or link-scope
+	Excluded   string   // why the candidate does not allow it; "" when it does
+}
+
+// Suggestion is a candidate policy learned from observed egress.
+type Suggestion struct {
+	Policy       *pb.NetworkPolicy // normalized; ENFORCE mode
+	Destinations []SuggestedDestination
+	Added        []string // Entries in the candidate but not the current policy
+	Removed      []string // Entries in the current policy no observation used
+}
+
+// WouldDeny returns the destinations the current policy, enforced, would
+// have dropped: none when the tenant has no policy, which polices nothing.
+func (s Suggestion) WouldDeny() []SuggestedDestination {
+	var out []SuggestedDestination
+	for _, d := range s.Destinations {
+		if !d.Current.Allowed {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Suggest learns an enforce-mode policy for tenant from its observed egress.
+// An observation the current policy already allows keeps the entry that
+// allows it, so an operator-written range is not replaced by host entries;
+// anything else gets the narrowest entry that admits it — a port-scoped
+// rule on the observed domain, or on the host address when no name is known.
+// More than suggestPortCollapse ports to one destination and protocol become
+// one protocol-wide rule. A same-tenant peer sets allow_intra_tenant.
+// Nothing is proposed for the metadata endpoint, a virtual-patched
+// destination, another tenant's container, or a protocol other than TCP and
+// UDP; those carry an Excluded reason instead. Deny rules and allow_metadata
+// carry over from current, which is nil when the tenant has no policy: every
+// destination is then learned as if against an empty one, but reported
+// allowed today (ReasonNoPolicy), as Simulate reports it. peers maps a
+// managed container's address to its tenant.
+func Suggest(tenant string, observed []Observation, peers func(netip.Addr) string, current *CompiledPolicy) (Suggestion, error) {
+	cur := CompiledPolicy{Tenant: tenant}
+	if current != nil {
+		cur = *current
+	}
+	dests := mergeObservations(observed)
+
+	cand := &pb.NetworkPolicy{
+		Tenant:        tenant,
+		Mode:          pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE,
+		AllowMetadata: cur.AllowMetadata,
+		DenyRules:     cur.ToProto().GetDenyRules(),
+	}
+	type scopeKey struct {
+		dest  string
+		proto uint8
+	}
+	ports := make(map[scopeKey][]uint16)
+	var (
+		order  []scopeKey
+		scoped []int // dests awaiting a scoped rule
+	)
+
+	for i := range dests {
+		d := &dests[i]
+		if peers != nil {
+			d.PeerTenant = peers(d.Dest)
+		}
+		dec := cur.Decide(d.Observation, d.PeerTenant)
+		d.Current = dec
+		if current == nil {
+			d.Current = Decision{Allowed: true, Reason: ReasonNoPolicy}
+		}
+		switch {
+		case dec.Reason == ReasonLinkScope:
+			continue
+		case dec.Reason == ReasonVirtualPatch:
+			d.Excluded = "blocked by a virtual-patch deny rule"
+			continue
+		case d.Dest == metadataIPv4 || d.Dest == metadataIPv6:
+			if !cur.AllowMetadata {
+				d.Excluded = "metadata endpoint; set allow_metadata to permit it"
+			} else {
+				d.Rule = "allow-metadata"
+			}
+			continue
+		case d.PeerTenant != "" && d.PeerTenant != tenant:
+			d.Excluded = "another tenant's container"
+			continue
+		case d.PeerTenant != "":
+			cand.AllowIntraTenant = true
+			d.Rule = "allow-intra-tenant"
+			continue
+		case dec.Allowed:
+			addEntry(cand, dec.match)
+			d.Rule = dec.Entry
+			continue
+		case (d.Proto != 6 && d.Proto != 17) || d.Port == 0:
+			d.Excluded = "only TCP and UDP ports can be scoped; add the address as an egress CIDR to allow it"
+			continue
+		}
+		k := scopeKey{dest: d.Domain, proto: d.Proto}
+		if k.dest == "" {
+			k.dest = netip.PrefixFrom(d.Dest, d.Dest.BitLen()).String()
+		}
+		if _, ok := ports[k]; !ok {
+			order = append(order, k)
+		}
+		ports[k] = append(ports[k], d.Port)
+		scoped = append(scoped, i)
+	}
+
+	// The rule each scoped destination landed in, for the report.
+	ruleFor := make(map[scopeKey]map[uint16]string, len(order))
+	for _, k := range order {
+		ps := uniquePorts(ports[k])
+		ruleFor[k] = make(map[uint16]string, len(ps))
+		if len(ps) > suggestPortCollapse {
+			r := scopedRule(k.dest, k.proto, 0)
+			cand.EgressRules = append(cand.EgressRules, r.toProto())
+			for _, p := range ps {
+				ruleFor[k][p] = r.String()
+			}
+			continue
+		}
+		for _, p := range ps {
+			r := scopedRule(k.dest, k.proto, p)
+			cand.EgressRules = append(cand.EgressRules, r.toProto())
+			ruleFor[k][p] = r.String()
+		}
+	}
+	for _, i := range scoped {
+		d := &dests[i]
+		k := scopeKey{dest: d.Domain, proto: d.Proto}
+		if k.dest == "" {
+			k.dest = netip.PrefixFrom(d.Dest, d.Dest.BitLen()).String()
+		}
+		d.Rule = ruleFor[k][d.Port]
+	}
+
+	compiled, err := Compile(cand)
+	if err != nil {
+		return Suggestion{}, fmt.Errorf("suggested policy does not compile: %w", err)
+	}
+	added, removed := diffEntries(cur.Entries(), compiled.Entries())
+	return Suggestion{
+		Policy:       compiled.ToProto(),
+		Destinations: dests,
+		Added:        added,
+		Removed:      removed,
+	}, nil
+}
+
+// mergeObservations folds observations of the same address, protocol and
+// port (from different containers or sources) into one, and orders the
+// result busiest first.
+func mergeObservations(observed []Observation) []SuggestedDestination {
+	type key struct {
+		dest  netip.Addr
+		proto uint8
+		port  uint16
+	}
+	byKey := make(map[key]*Observation, len(observed))
+	for _, o := range observed {
+		o.Dest = o.Dest.Unmap().WithZone("")
+		if !o.Dest.IsValid() {
+			continue
+		}
+		k := key{o.Dest, o.Proto, o.Port}
+		m := byKey[k]
+		if m == nil {
+			c := o
+			c.Containers = append([]string(nil), o.Containers...)
+			byKey[k] = &c
+			continue
+		}
+		m.Flows += o.Flows
+		m.Bytes += o.Bytes
+		if o.LastSeen.After(m.LastSeen) {
+			m.LastSeen = o.LastSeen
+		}
+		if m.Domain == "" {
+			m.Domain = o.Domain
+		}
+		m.Containers = append(m.Containers, o.Containers...)
+	}
+	out := make([]SuggestedDestination, 0, len(byKey))
+	for _, m := range byKey {
+		sort.Strings(m.Containers)
+		m.Containers = dedupSorted(m.Containers)
+		out = append(out, SuggestedDestination{Observation: *m})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Flows != b.Flows {
+			return a.Flows > b.Flows
+		}
+		if a.Dest != b.Dest {
+			return a.Dest.Less(b.Dest)
+		}
+		if a.Proto != b.Proto {
+			return a.Proto < b.Proto
+		}
+		return a.Port < b.Port
+	})
+	return out
+}
+
+// addEntry adds a kept allow-list entry to the candidate.
+func addEntry(p *pb.NetworkPolicy, e allowEntry) {
+	switch {
+	case e.rule != nil:
+		p.EgressRules = append(p.EgressRules, e.rule.toProto())
+	case e.domain != "":
+		p.EgressDomains = append(p.EgressDomains, e.domain)
+	case e.cidr.IsValid():
+		p.EgressCidrs = append(p.EgressCidrs, e.cidr.String())
+	}
+}
+
+// scopedRule builds a rule for dest (a host prefix or a domain); port 0 is
+// the protocol's whole range.
+func scopedRule(dest string, proto uint8, port uint16) EgressRule {
+	r := EgressRule{Proto: proto, PortLo: port, PortHi: port}
+	if p, err := netip.ParsePrefix(dest); err == nil {
+		r.CIDR = p
+	} else {
+		r.Domain = dest
+	}
+	return r
+}
+
+func uniquePorts(ps []uint16) []uint16 {
+	sort.Slice(ps, func(i, j int) bool { return ps[i] < ps[j] })
+	out := ps[:0]
+	for i, p := range ps {
+		if i == 0 || p != ps[i-1] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func dedupSorted(ss []string) []string {
+	out := ss[:0]
+	for i, s := range ss {
+		if i == 0 || s != ss[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// diffEntries returns the sorted entries only in next (added) and only in
+// prev (removed).
+func diffEntries(prev, next []string) (added, removed []string) {
+	in := func(ss []string) map[string]bool {
+		m := make(map[string]bool, len(ss))
+		for _, s := range ss {
+			m[s] = true
+		}
+		return m
+	}
+	p, n := in(prev), in(next)
+	for _, s := range next {
+		if !p[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range prev {
+		if !n[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
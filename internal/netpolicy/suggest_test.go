@@ -0,0 +1,208 @@
+package netpolicy
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func obs(dest string, proto uint8, port uint16, flows int64) Observation {
+	return Observation{Dest: netip.MustParseAddr(dest), Proto: proto, Port: port, Flows: flows, Containers: []string{"alice-container"}}
+}
+
+func mustCompile(t *testing.T, p *pb.NetworkPolicy) *CompiledPolicy {
+	t.Helper()
+	c, err := Compile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
+
+// Decide follows the TC program's order: deny beats allow, metadata beats a
+// covering CIDR, peers are judged by tenant, and only then the allow-list.
+func TestDecide_Order(t *testing.T) {
+	c := mustCompile(t, &pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressCidrs: []string{"0.0.0.0/0"},
+		EgressRules: []*pb.NetworkPolicyEgressRule{{Domain: "api.github.com", Proto: "tcp", Port: 443}},
+		DenyRules:   []*pb.NetworkPolicyDenyRule{{Cidr: "203.0.113.9", Port: 22, Proto: "tcp"}},
+	})
+	gh := obs("2606:50c0::1", 6, 443, 1)
+	gh.Domain = "api.github.com"
+	cases := []struct {
+		name    string
+		o       Observation
+		peer    string
+		allowed bool
+		reason  string
+	}{
+		{"virtual patch", obs("203.0.113.9", 6, 22, 1), "", false, ReasonVirtualPatch},
+		{"other port of patched host", obs("203.0.113.9", 6, 443, 1), "", true, ReasonAllowList},
+		{"metadata under 0/0", obs("169.254.169.254", 6, 80, 1), "", false, ReasonMetadata},
+		{"cross tenant", obs("10.100.0.7", 6, 5432, 1), "bob", false, ReasonCrossTenant},
+		{"intra disabled", obs("10.100.0.8", 6, 5432, 1), "alice", false, ReasonIntraDisabled},
+		{"domain rule", gh, "", true, ReasonAllowList},
+		{"v6 not covered", obs("2001:db8::1", 6, 443, 1), "", false, ReasonNotInAllowList},
+		{"v6 link-local", obs("fe80::1", 17, 547, 1), "", true, ReasonLinkScope},
+	}
+	for _, tc := range cases {
+		d := c.Decide(tc.o, tc.peer)
+		if d.Allowed != tc.allowed || d.Reason != tc.reason {
+			t.Errorf("%s: got %+v, want allowed=%v reason=%s", tc.name, d, tc.allowed, tc.reason)
+		}
+	}
+	if d := c.Decide(gh, ""); d.Entry != "api.github.com tcp/443" {
+		t.Errorf("matched entry = %q", d.Entry)
+	}
+}
+
+func TestSuggest_LearnsScopedRules(t *testing.T) {
+	gh := obs("140.82.112.3", 6, 443, 40)
+	gh.Domain = "api.github.com"
+	gh2 := obs("140.82.112.4", 6, 443, 2) // another address of the same name
+	gh2.Domain = "api.github.com"
+	gh2.Containers = []string{"alice-web-container"}
+	observed := []Observation{
+		gh, gh2,
+		obs("10.100.0.1", 17, 53, 90),    // the bridge resolver
+		obs("10.100.0.8", 6, 5432, 5),    // alice's db container
+		obs("10.100.0.9", 6, 6379, 1),    // bob's container
+		obs("169.254.169.254", 6, 80, 1), // metadata
+		obs("8.8.8.8", 1, 0, 3),          // ping
+	}
+	peers := func(a netip.Addr) string {
+		switch a.String() {
+		case "10.100.0.8":
+			return "alice"
+		case "10.100.0.9":
+			return "bob"
+		}
+		return ""
+	}
+	s, err := Suggest("alice", observed, peers, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := s.Policy
+	if p.GetMode() != pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE || !p.GetAllowIntraTenant() || p.GetAllowMetadata() {
+		t.Errorf("candidate flags = %+v", p)
+	}
+	wantAdded := []string{"10.100.0.1/32 udp/53", "allow-intra-tenant", "api.github.com tcp/443"}
+	if !slices.Equal(s.Added, wantAdded) || len(s.Removed) != 0 {
+		t.Errorf("diff +%v -%v, want +%v", s.Added, s.Removed, wantAdded)
+	}
+	if len(s.Destinations) != 7 {
+		t.Errorf("%d destinations, want 7", len(s.Destinations))
+	}
+	byDest := map[string]SuggestedDestination{}
+	for _, d := range s.Destinations {
+		byDest[d.Dest.String()] = d
+	}
+	for dest, excluded := range map[string]bool{"10.100.0.9": true, "169.254.169.254": true, "8.8.8.8": true, "140.82.112.4": false} {
+		if d := byDest[dest]; (d.Excluded != "") != excluded || (d.Rule == "") != excluded {
+			t.Errorf("%s: rule %q excluded %q", dest, d.Rule, d.Excluded)
+		}
+	}
+	if s.Destinations[0].Dest.String() != "10.100.0.1" {
+		t.Errorf("destinations not busiest first: %v", s.Destinations[0].Dest)
+	}
+}
+
+// An entry of the current policy that allows an observation is kept as
+// written; unused entries show up as removals.
+func TestSuggest_KeepsCoveringEntriesAndDiffs(t *testing.T) {
+	cur := mustCompile(t, &pb.NetworkPolicy{
+		Tenant:        "alice",
+		EgressCidrs:   []string{"10.0.0.0/8", "192.0.2.0/24"},
+		EgressDomains: []string{"unused.example"},
+		AllowMetadata: true,
+		DenyRules:     []*pb.NetworkPolicyDenyRule{{Cidr: "10.9.9.9", Note: "CVE-1"}},
+	})
+	s, err := Suggest("alice", []Observation{
+		obs("10.1.2.3", 6, 5432, 3),
+		obs("10.9.9.9", 6, 80, 1), // virtual-patched
+		obs("198.51.100.7", 6, 8443, 1),
+	}, nil, cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(s.Policy.GetEgressCidrs(), []string{"10.0.0.0/8"}) {
+		t.Errorf("kept CIDRs = %v", s.Policy.GetEgressCidrs())
+	}
+	if len(s.Policy.GetDenyRules()) != 1 || !s.Policy.GetAllowMetadata() {
+		t.Errorf("deny rules / allow_metadata not carried over: %+v", s.Policy)
+	}
+	if !slices.Equal(s.Added, []string{"198.51.100.7/32 tcp/8443"}) || !slices.Equal(s.Removed, []string{"192.0.2.0/24", "unused.example"}) {
+		t.Errorf("diff +%v -%v", s.Added, s.Removed)
+	}
+	wd := s.WouldDeny()
+	if len(wd) != 2 || !slices.ContainsFunc(wd, func(d SuggestedDestination) bool { return d.Current.Reason == ReasonVirtualPatch }) {
+		t.Errorf("would-deny = %+v, want the patched and the uncovered destination", wd)
+	}
+}
+
+// A tenant without a policy is not policed, so nothing would be denied today:
+// Suggest reports every destination the way Simulate does, while still
+// learning the candidate against an empty policy.
+func TestSuggest_NoPolicyDeniesNothing(t *testing.T) {
+	observed := []Observation{
+		obs("198.51.100.7", 6, 8443, 4),
+		obs("169.254.169.254", 6, 80, 1),
+		obs("10.100.0.9", 6, 6379, 1),
+	}
+	peers := func(a netip.Addr) string {
+		if a.String() == "10.100.0.9" {
+			return "bob"
+		}
+		return ""
+	}
+	s, err := Suggest("alice", observed, peers, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wd := s.WouldDeny(); len(wd) != 0 {
+		t.Errorf("would-deny = %+v, want none without a policy", wd)
+	}
+	sim := Simulate(observed, peers, nil, nil, *mustCompile(t, s.Policy))
+	for i, d := range s.Destinations {
+		if d.Current != sim.Destinations[i].Current {
+			t.Errorf("%s: current %+v, Simulate says %+v", d.Dest, d.Current, sim.Destinations[i].Current)
+		}
+	}
+	if !slices.Equal(s.Added, []string{"198.51.100.7/32 tcp/8443"}) {
+		t.Errorf("added = %v", s.Added)
+	}
+	for _, d := range s.Destinations {
+		if d.Dest.String() != "198.51.100.7" && d.Excluded == "" {
+			t.Errorf("%s: not excluded from the candidate (rule %q)", d.Dest, d.Rule)
+		}
+	}
+}
+
+func TestSuggest_CollapsesPortSpread(t *testing.T) {
+	var observed []Observation
+	for p := uint16(9000); p < 9000+suggestPortCollapse+1; p++ {
+		observed = append(observed, obs("203.0.113.5", 17, p, 1))
+	}
+	observed = append(observed, obs("203.0.113.5", 6, 443, 1), obs("203.0.113.5", 6, 443, 1))
+	observed[len(observed)-1].LastSeen = time.Unix(100, 0)
+	s, err := Suggest("alice", observed, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(s.Added, []string{"203.0.113.5/32 tcp/443", "203.0.113.5/32 udp"}) {
+		t.Errorf("added = %v", s.Added)
+	}
+	if len(s.Destinations) != suggestPortCollapse+2 {
+		t.Errorf("merged into %d destinations", len(s.Destinations))
+	}
+	for _, d := range s.Destinations {
+		if d.Proto == 6 && (d.Flows != 2 || !d.LastSeen.Equal(time.Unix(100, 0))) {
+			t.Errorf("tcp/443 not merged: %+v", d.Observation)
+		}
+	}
+}
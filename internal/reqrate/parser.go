@@ -2,18 +2,21 @@
 // edge's structured (JSON) access log — the source for #231's request-rate
 // plane. See docs/REQUEST-RATE-PLANE-DESIGN.md.
 //
-// The package is the offline, unit-tested building block (slice 1): it parses
-// access-log lines, accumulates per-host counts over an interval, and joins
-// hosts to containers using the route table + container list the collector
-// already holds. The live tailer + collector wiring (which turn this into
-// emitted metrics) are later slices; nothing here reads a file or talks to
-// Caddy, so all of it is testable without a running edge.
+// It parses access-log lines, accumulates per-host counts over an interval,
+// and joins hosts to containers using the route table + container list the
+// collector already holds. The RED follow-on (red.go) keeps status, duration
+// and bytes per host as well, so each container gets a rate, an error ratio
+// and latency quantiles; Tail (tail.go) follows the live log file. Parsing
+// and aggregation never talk to Caddy, so all of it is testable without a
+// running edge.
 package reqrate
 
 import (
 	"encoding/json"
 	"net"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // accessEntry is the subset of a Caddy JSON access-log record we need. Caddy
@@ -24,6 +27,56 @@ type accessEntry struct {
 	Request struct {
 		Host string `json:"host"`
 	} `json:"request"`
+	Status   int             `json:"status"`
+	Duration json.RawMessage `json:"duration"`
+	Size     int64           `json:"size"`
+}
+
+// Record is one access-log record reduced to what the RED plane needs.
+type Record struct {
+	Host     string        // lower-cased, port-stripped request host
+	Status   int           // response status; 0 when the record carries none
+	Duration time.Duration // time Caddy spent handling the request
+	Bytes    int64         // response body bytes written
+}
+
+// ParseRecord extracts the host, status, duration and response size from one
+// JSON access-log line, with the same skip rules as ParseHost. Caddy writes
+// duration as float seconds by default and as a Go duration string under
+// duration_format "string"; both are accepted, and an unreadable duration is
+// zero rather than a skipped record.
+func ParseRecord(line []byte) (Record, bool) {
+	var e accessEntry
+	if err := json.Unmarshal(line, &e); err != nil {
+		return Record{}, false
+	}
+	host, ok := normalizeHost(e.Request.Host)
+	if !ok {
+		return Record{}, false
+	}
+	return Record{Host: host, Status: e.Status, Duration: parseDuration(e.Duration), Bytes: e.Size}, true
+}
+
+// parseDuration reads Caddy's duration field: float seconds, or a duration
+// string ("1.5ms").
+func parseDuration(raw json.RawMessage) time.Duration {
+	if len(raw) == 0 {
+		return 0
+	}
+	if raw[0] == '"' {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			if d, err := time.ParseDuration(s); err == nil && d > 0 {
+				return d
+			}
+		}
+		return 0
+	}
+	secs, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs * float64(time.Second))
 }
 
 // ParseHost extracts the (lower-cased, port-stripped) request host from one
@@ -32,11 +85,8 @@ type accessEntry struct {
 // share the file), or that carry no host — callers skip those rather than
 // counting them.
 func ParseHost(line []byte) (string, bool) {
-	var e accessEntry
-	if err := json.Unmarshal(line, &e); err != nil {
-		return "", false
-	}
-	return normalizeHost(e.Request.Host)
+	rec, ok := ParseRecord(line)
+	return rec.Host, ok
 }
 
 // normalizeHost lower-cases the host and strips an optional :port suffix
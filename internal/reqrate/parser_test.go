@@ -1,6 +1,9 @@
 package reqrate
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestParseHost(t *testing.T) {
 	cases := []struct {
@@ -55,3 +58,18 @@ func TestParseHost(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRecord(t *testing.T) {
+	rec, ok := ParseRecord([]byte(`{"msg":"handled request","request":{"host":"Alice.example.com:443"},"status":502,"duration":0.0125,"size":512}`))
+	if !ok || rec.Host != "alice.example.com" || rec.Status != 502 || rec.Duration != 12500*time.Microsecond || rec.Bytes != 512 {
+		t.Errorf("record = %+v, ok=%v", rec, ok)
+	}
+	// duration_format "string"
+	if rec, _ := ParseRecord([]byte(`{"request":{"host":"a"},"status":200,"duration":"1.5ms"}`)); rec.Duration != 1500*time.Microsecond {
+		t.Errorf("string duration = %v", rec.Duration)
+	}
+	// An unreadable duration is zero; the record still counts.
+	if rec, ok := ParseRecord([]byte(`{"request":{"host":"a"},"duration":{}}`)); !ok || rec.Duration != 0 {
+		t.Errorf("bad duration: %+v, ok=%v", rec, ok)
+	}
+}
@@ -0,0 +1,218 @@
+package reqrate
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBounds are the upper bounds of the latency histogram buckets, in the
+// spirit of the Prometheus/OTel HTTP defaults: fine-grained where web
+// requests cluster, coarse out to the 10s tail. A request slower than the
+// last bound lands in an overflow bucket.
+var LatencyBounds = [...]time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Histogram counts request latencies into LatencyBounds buckets (plus the
+// overflow bucket). The zero value is empty and ready to use.
+type Histogram struct {
+	Counts [len(LatencyBounds) + 1]int64
+}
+
+// Observe adds one latency.
+func (h *Histogram) Observe(d time.Duration) {
+	i := sort.Search(len(LatencyBounds), func(i int) bool { return d <= LatencyBounds[i] })
+	h.Counts[i]++
+}
+
+// Merge adds o's counts into h.
+func (h *Histogram) Merge(o Histogram) {
+	for i, n := range o.Counts {
+		h.Counts[i] += n
+	}
+}
+
+// Total is the number of observed latencies.
+func (h *Histogram) Total() int64 {
+	var n int64
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// Quantile estimates the q-th quantile (0 < q ≤ 1), interpolating linearly
+// inside the bucket it falls in. A quantile in the overflow bucket reports
+// the last bound: the histogram only knows the request took at least that
+// long. An empty histogram reports 0.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+	rank := q * float64(total)
+	var seen int64
+	for i, n := range h.Counts {
+		if n == 0 || float64(seen+n) < rank {
+			seen += n
+			continue
+		}
+		if i == len(LatencyBounds) {
+			return LatencyBounds[len(LatencyBounds)-1]
+		}
+		var lower time.Duration
+		if i > 0 {
+			lower = LatencyBounds[i-1]
+		}
+		frac := (rank - float64(seen)) / float64(n)
+		return lower + time.Duration(frac*float64(LatencyBounds[i]-lower))
+	}
+	return LatencyBounds[len(LatencyBounds)-1]
+}
+
+// HostStats is one host's raw RED counts for a window.
+type HostStats struct {
+	Requests     int64
+	ServerErrors int64 // 5xx responses — the RED error signal
+	ClientErrors int64 // 4xx responses, reported alongside but not as errors
+	Bytes        int64 // response body bytes
+	Latency      Histogram
+}
+
+func (s *HostStats) merge(o HostStats) {
+	s.Requests += o.Requests
+	s.ServerErrors += o.ServerErrors
+	s.ClientErrors += o.ClientErrors
+	s.Bytes += o.Bytes
+	s.Latency.Merge(o.Latency)
+}
+
+// Window accumulates per-host RED counts for the current interval — the
+// RED counterpart of Counter, with the same Add-from-the-tailer,
+// Snapshot-from-the-collector hand-off.
+type Window struct {
+	mu    sync.Mutex
+	hosts map[string]*HostStats
+}
+
+// NewWindow returns an empty window.
+func NewWindow() *Window {
+	return &Window{hosts: make(map[string]*HostStats)}
+}
+
+// Observe records one request.
+func (w *Window) Observe(r Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := w.hosts[r.Host]
+	if s == nil {
+		s = &HostStats{}
+		w.hosts[r.Host] = s
+	}
+	s.Requests++
+	switch {
+	case r.Status >= 500:
+		s.ServerErrors++
+	case r.Status >= 400:
+		s.ClientErrors++
+	}
+	s.Bytes += r.Bytes
+	s.Latency.Observe(r.Duration)
+}
+
+// Snapshot returns the window's per-host counts and resets it.
+func (w *Window) Snapshot() map[string]HostStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.hosts
+	w.hosts = make(map[string]*HostStats)
+	out := make(map[string]HostStats, len(prev))
+	for h, s := range prev {
+		out[h] = *s
+	}
+	return out
+}
+
+// REDSample is one container's request rate, errors and duration for a
+// window, ready to record and to serve.
+type REDSample struct {
+	ContainerName string
+	ContainerID   string
+
+	Requests       int64
+	ServerErrors   int64
+	ClientErrors   int64
+	RequestsPerSec float64
+	ErrorRatio     float64 // ServerErrors / Requests
+	BytesPerSec    float64 // response body bytes per second
+
+	P50, P95, P99 time.Duration
+}
+
+// BuildRED joins per-host window counts with a resolver into per-container
+// samples, the RED counterpart of Build: hosts fronting the same container
+// are merged before the ratios and quantiles are derived (quantiles don't
+// sum), unresolved hosts are tallied in dropped, and output is sorted by
+// container name. A non-positive interval yields nothing.
+func BuildRED(stats map[string]HostStats, interval time.Duration, res *Resolver) (samples []REDSample, dropped int) {
+	if interval <= 0 {
+		return nil, 0
+	}
+	type acc struct {
+		c Container
+		s HostStats
+	}
+	byKey := make(map[string]*acc)
+	for host, s := range stats {
+		c, ok := res.Resolve(host)
+		if !ok {
+			dropped++
+			continue
+		}
+		key := c.ContainerID
+		if key == "" {
+			key = "name:" + c.Name
+		}
+		a := byKey[key]
+		if a == nil {
+			a = &acc{c: c}
+			byKey[key] = a
+		}
+		a.s.merge(s)
+	}
+	secs := interval.Seconds()
+	samples = make([]REDSample, 0, len(byKey))
+	for _, a := range byKey {
+		out := REDSample{
+			ContainerName:  a.c.Name,
+			ContainerID:    a.c.ContainerID,
+			Requests:       a.s.Requests,
+			ServerErrors:   a.s.ServerErrors,
+			ClientErrors:   a.s.ClientErrors,
+			RequestsPerSec: float64(a.s.Requests) / secs,
+			BytesPerSec:    float64(a.s.Bytes) / secs,
+			P50:            a.s.Latency.Quantile(0.5),
+			P95:            a.s.Latency.Quantile(0.95),
+			P99:            a.s.Latency.Quantile(0.99),
+		}
+		if a.s.Requests > 0 {
+			out.ErrorRatio = float64(a.s.ServerErrors) / float64(a.s.Requests)
+		}
+		samples = append(samples, out)
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].ContainerName < samples[j].ContainerName
+	})
+	return samples, dropped
+}
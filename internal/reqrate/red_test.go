@@ -0,0 +1,90 @@
+package reqrate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogram_Quantile(t *testing.T) {
+	var h Histogram
+	if got := h.Quantile(0.5); got != 0 {
+		t.Errorf("empty histogram p50 = %v", got)
+	}
+	// 90 fast requests in the (5ms, 10ms] bucket, 10 slow ones past the last bound.
+	for i := 0; i < 90; i++ {
+		h.Observe(8 * time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		h.Observe(time.Minute)
+	}
+	if got := h.Quantile(0.5); got <= 5*time.Millisecond || got > 10*time.Millisecond {
+		t.Errorf("p50 = %v, want inside (5ms, 10ms]", got)
+	}
+	if got := h.Quantile(0.99); got != 10*time.Second {
+		t.Errorf("p99 = %v, want the last bound for an overflow quantile", got)
+	}
+	// Bucket edges are inclusive upper bounds.
+	var edge Histogram
+	edge.Observe(5 * time.Millisecond)
+	if edge.Counts[0] != 1 {
+		t.Errorf("5ms landed in bucket %v", edge.Counts)
+	}
+}
+
+func TestWindow_ObserveAndSnapshot(t *testing.T) {
+	w := NewWindow()
+	for _, r := range []Record{
+		{Host: "a", Status: 200, Bytes: 100, Duration: time.Millisecond},
+		{Host: "a", Status: 503, Bytes: 10, Duration: time.Second},
+		{Host: "a", Status: 404},
+		{Host: "b", Status: 200},
+	} {
+		w.Observe(r)
+	}
+	got := w.Snapshot()
+	if a := got["a"]; a.Requests != 3 || a.ServerErrors != 1 || a.ClientErrors != 1 || a.Bytes != 110 || a.Latency.Total() != 3 {
+		t.Errorf("host a = %+v", a)
+	}
+	if len(w.Snapshot()) != 0 {
+		t.Error("snapshot did not reset the window")
+	}
+}
+
+func TestBuildRED_MergesHostsPerContainer(t *testing.T) {
+	res := NewResolver(
+		[]Route{
+			{Host: "alice.example.com", UpstreamIP: "10.0.0.2"},
+			{Host: "shop.alice.io", UpstreamIP: "10.0.0.2"},
+			{Host: "bob.example.com", UpstreamIP: "10.0.0.3"},
+		},
+		[]Container{{Name: "alice", IP: "10.0.0.2", ContainerID: "uuid-a"}, {Name: "bob", IP: "10.0.0.3"}},
+	)
+	w := NewWindow()
+	for i := 0; i < 8; i++ {
+		w.Observe(Record{Host: "alice.example.com", Status: 200, Bytes: 1000, Duration: 20 * time.Millisecond})
+	}
+	w.Observe(Record{Host: "shop.alice.io", Status: 500, Duration: 3 * time.Second})
+	w.Observe(Record{Host: "shop.alice.io", Status: 502, Duration: 3 * time.Second})
+	w.Observe(Record{Host: "bob.example.com", Status: 200, Duration: time.Millisecond})
+	w.Observe(Record{Host: "stale.example.com", Status: 200})
+
+	samples, dropped := BuildRED(w.Snapshot(), 10*time.Second, res)
+	if dropped != 1 || len(samples) != 2 {
+		t.Fatalf("samples %+v, dropped %d", samples, dropped)
+	}
+	a := samples[0]
+	if a.ContainerName != "alice" || a.ContainerID != "uuid-a" || a.Requests != 10 || a.RequestsPerSec != 1 ||
+		a.ErrorRatio != 0.2 || a.BytesPerSec != 800 {
+		t.Errorf("alice = %+v", a)
+	}
+	// Quantiles come from the merged histogram: the two slow errors are the tail.
+	if a.P50 > 25*time.Millisecond || a.P95 < 2500*time.Millisecond || a.P99 > 5*time.Second {
+		t.Errorf("alice quantiles p50=%v p95=%v p99=%v", a.P50, a.P95, a.P99)
+	}
+	if b := samples[1]; b.ContainerName != "bob" || b.ErrorRatio != 0 || b.P99 > 5*time.Millisecond {
+		t.Errorf("bob = %+v", b)
+	}
+	if s, _ := BuildRED(map[string]HostStats{"alice.example.com": {Requests: 1}}, 0, res); s != nil {
+		t.Errorf("zero interval = %+v", s)
+	}
+}
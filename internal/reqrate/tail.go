@@ -0,0 +1,101 @@
+package reqrate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// maxLineBytes bounds one buffered access-log line, as Scan's scanner does. A
+// longer line is dropped rather than growing the buffer without limit.
+const maxLineBytes = 4 * 1024 * 1024
+
+// Tail follows the access log at path until ctx is cancelled, calling fn with
+// each complete line appended to it. It starts at the current end of the file
+// (history is not replayed into the first window), polls every poll for new
+// data, and reopens the path from the start when the file is rotated (a new
+// inode) or truncated, so a roll doesn't stall the plane. A missing file is
+// waited for. fn runs on Tail's goroutine.
+func Tail(ctx context.Context, path string, poll time.Duration, fn func(line []byte)) error {
+	var (
+		f       *os.File
+		br      *bufio.Reader
+		offset  int64
+		pending []byte
+		first   = true
+	)
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		if f == nil {
+			nf, err := os.Open(path)
+			if err == nil {
+				f, br, pending = nf, bufio.NewReader(nf), nil
+				offset = 0
+				if first {
+					if offset, err = f.Seek(0, io.SeekEnd); err != nil {
+						return err
+					}
+				}
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			first = false // a file that appears later is read from its start
+		}
+		for f != nil {
+			chunk, err := br.ReadSlice('\n')
+			offset += int64(len(chunk))
+			if len(pending)+len(chunk) <= maxLineBytes {
+				pending = append(pending, chunk...)
+			} else {
+				pending = pending[:0] // what's left of it won't parse; ParseRecord skips it
+			}
+			if err == nil {
+				if line := bytes.TrimSpace(pending); len(line) > 0 {
+					fn(line)
+				}
+				pending = pending[:0]
+				continue
+			}
+			if errors.Is(err, bufio.ErrBufferFull) {
+				continue
+			}
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			break // caught up; a partial line stays pending
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if f != nil && rotated(f, path, offset) {
+			_ = f.Close()
+			f = nil
+		}
+	}
+}
+
+// rotated reports whether path no longer names the open file, or the file was
+// truncated behind the read offset.
+func rotated(f *os.File, path string, offset int64) bool {
+	cur, err := os.Stat(path)
+	if err != nil {
+		return errors.Is(err, os.ErrNotExist)
+	}
+	open, err := f.Stat()
+	if err != nil {
+		return true
+	}
+	return !os.SameFile(cur, open) || cur.Size() < offset
+}
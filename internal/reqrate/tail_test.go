@@ -0,0 +1,125 @@
+package reqrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lineSink collects the lines Tail hands back.
+type lineSink struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (s *lineSink) add(line []byte) {
+	s.mu.Lock()
+	s.lines = append(s.lines, string(line))
+	s.mu.Unlock()
+}
+
+func (s *lineSink) waitFor(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		if len(s.lines) >= n {
+			out := append([]string(nil), s.lines...)
+			s.mu.Unlock()
+			return out
+		}
+		s.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.Fatalf("got %d lines %q, want %d", len(s.lines), s.lines, n)
+	return nil
+}
+
+func appendTo(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTail_FollowsAppendsAndRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	appendTo(t, path, "history\n") // before Tail starts: not replayed
+
+	var sink lineSink
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Tail(ctx, path, 5*time.Millisecond, sink.add) }()
+	time.Sleep(30 * time.Millisecond)
+
+	appendTo(t, path, "one\ntw")
+	time.Sleep(30 * time.Millisecond)
+	appendTo(t, path, "o\n") // a line split across writes arrives whole
+	sink.waitFor(t, 2)
+
+	// Rotate: the old file moves aside and a new one takes the path.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendTo(t, path, "three\n")
+	sink.waitFor(t, 3)
+
+	// Truncate in place (copytruncate-style rotation).
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	appendTo(t, path, "four\n")
+	got := sink.waitFor(t, 4)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+	want := []string{"one", "two", "three", "four"}
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("lines = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestTracker_ClosesWindows(t *testing.T) {
+	res := NewResolver([]Route{{Host: "alice.example.com", UpstreamIP: "10.0.0.2"}}, []Container{{Name: "alice", IP: "10.0.0.2"}})
+	tr := NewTracker(func() *Resolver { return res })
+	now := time.Unix(1000, 0)
+	tr.now = func() time.Time { return now }
+	tr.opened = now
+
+	if s, _, _ := tr.Latest(); s != nil {
+		t.Fatalf("samples before the first window closed: %+v", s)
+	}
+	tr.ObserveLine([]byte(`{"request":{"host":"alice.example.com"},"status":500,"duration":0.01}`))
+	tr.ObserveLine([]byte(`{"request":{"host":"alice.example.com"},"status":200,"duration":0.01}`))
+	tr.ObserveLine([]byte(`not json`))
+	now = now.Add(10 * time.Second)
+	tr.Close()
+
+	s, from, to := tr.Latest()
+	if len(s) != 1 || s[0].Requests != 2 || s[0].RequestsPerSec != 0.2 || s[0].ErrorRatio != 0.5 {
+		t.Fatalf("samples = %+v", s)
+	}
+	if to.Sub(from) != 10*time.Second {
+		t.Errorf("window %v → %v", from, to)
+	}
+	now = now.Add(10 * time.Second)
+	tr.Close()
+	if s, _, _ := tr.Latest(); len(s) != 0 {
+		t.Errorf("a quiet window should report no samples, got %+v", s)
+	}
+}
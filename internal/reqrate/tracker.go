@@ -0,0 +1,76 @@
+package reqrate
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Tracker is the live RED plane: Tail feeds it access-log lines, and Run
+// closes the window on every interval into the latest per-container samples,
+// which the metrics collector records and TrafficService serves.
+type Tracker struct {
+	window  *Window
+	resolve func() *Resolver
+	now     func() time.Time
+
+	mu     sync.RWMutex
+	opened time.Time // start of the current window
+	latest []REDSample
+	from   time.Time // the latest samples' window
+	to     time.Time
+}
+
+// NewTracker returns a tracker that attributes hosts with the resolver resolve
+// returns, called once per window (nil resolver → every host dropped).
+func NewTracker(resolve func() *Resolver) *Tracker {
+	t := &Tracker{window: NewWindow(), resolve: resolve, now: time.Now}
+	t.opened = t.now()
+	return t
+}
+
+// ObserveLine parses one access-log line into the current window, skipping
+// lines that aren't access records.
+func (t *Tracker) ObserveLine(line []byte) {
+	if rec, ok := ParseRecord(line); ok {
+		t.window.Observe(rec)
+	}
+}
+
+// Close ends the current window: its counts are attributed to containers and
+// become the latest samples, and a new window opens.
+func (t *Tracker) Close() {
+	now := t.now()
+	stats := t.window.Snapshot()
+	res := t.resolve()
+	if res == nil {
+		res = NewResolver(nil, nil)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.latest, _ = BuildRED(stats, now.Sub(t.opened), res)
+	t.from, t.to, t.opened = t.opened, now, now
+}
+
+// Run closes a window every interval until ctx is cancelled.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Close()
+		}
+	}
+}
+
+// Latest returns the last closed window's per-container samples and its
+// bounds. Before the first window closes, samples is nil and the bounds are
+// zero.
+func (t *Tracker) Latest() (samples []REDSample, from, to time.Time) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.latest, t.from, t.to
+}
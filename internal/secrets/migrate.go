@@ -34,6 +34,10 @@ import (
 //     error in the result — never quietly accepted.
 //   - Bounded memory: pages through rows in batches so
 //     large deployments don't OOM.
+//   - Covers history: retained prior versions in
+//     secret_versions are migrated like live rows, so a
+//     rollback after master-key retirement still has a
+//     readable version to go back to.
 
 // MigrateOptions controls the migration's pacing.
 type MigrateOptions struct {
@@ -69,7 +73,10 @@ type MigrateResult struct {
 type MigrationError struct {
 	Username string
 	Name     string
-	Err      string
+	// Version is set for a retained prior version in
+	// secret_versions; 0 means the live secrets row.
+	Version int32
+	Err     string
 }
 
 // ErrMigrateNoKMS is returned when MigrateLegacyToEnvelope
@@ -118,9 +125,9 @@ func (s *Store) MigrateLegacyToEnvelope(ctx context.Context, opts MigrateOptions
 			default:
 				res.Failed++
 				res.Errors = append(res.Errors, MigrationError{
-					Username: r.username, Name: r.name, Err: migrateErr.Error(),
+					Username: r.username, Name: r.name, Version: r.version, Err: migrateErr.Error(),
 				})
-				log.Printf("[secrets-migrate] %s/%s failed: %v", r.username, r.name, migrateErr)
+				log.Printf("[secrets-migrate] %s/%s%s failed: %v", r.username, r.name, r.versionSuffix(), migrateErr)
 			}
 		}
 	}
@@ -128,16 +135,18 @@ func (s *Store) MigrateLegacyToEnvelope(ctx context.Context, opts MigrateOptions
 }
 
 // VerifyEnvelopeCoverage reports how many legacy vs
-// envelope rows are in the table. Used by operators to
-// confirm 100% coverage before retiring the master key
-// (Phase E).
+// envelope rows are in the secrets and secret_versions
+// tables. Used by operators to confirm 100% coverage
+// before retiring the master key (Phase E).
 type CoverageReport struct {
 	Total    int
 	Legacy   int
 	Envelope int
 }
 
-// VerifyEnvelopeCoverage counts rows by encryption mode.
+// VerifyEnvelopeCoverage counts rows by encryption mode,
+// retained prior versions included — a legacy one would
+// be unreadable once the master key is retired.
 // A deployment that's never enabled KMS reports
 // Envelope=0; a fully-migrated one reports Legacy=0.
 func (s *Store) VerifyEnvelopeCoverage(ctx context.Context) (CoverageReport, error) {
@@ -146,7 +155,11 @@ func (s *Store) VerifyEnvelopeCoverage(ctx context.Context) (CoverageReport, err
 			COUNT(*)                                    AS total,
 			COUNT(*) FILTER (WHERE wrapped_dek IS NULL) AS legacy,
 			COUNT(*) FILTER (WHERE wrapped_dek IS NOT NULL) AS envelope
-		FROM secrets
+		FROM (
+			SELECT wrapped_dek FROM secrets
+			UNION ALL
+			SELECT wrapped_dek FROM secret_versions
+		) AS all_rows
 	`
 	var c CoverageReport
 	if err := s.pool.QueryRow(ctx, q).Scan(&c.Total, &c.Legacy, &c.Envelope); err != nil {
@@ -160,16 +173,29 @@ func (s *Store) VerifyEnvelopeCoverage(ctx context.Context) (CoverageReport, err
 type legacyRow struct {
 	username string
 	name     string
+	version  int32 // 0 for the live secrets row; else a secret_versions row
 	nonce    []byte
 	ct       []byte
 }
 
+// versionSuffix renders a history row's version for log lines.
+func (r legacyRow) versionSuffix() string {
+	if r.version == 0 {
+		return ""
+	}
+	return fmt.Sprintf("@v%d", r.version)
+}
+
 func (s *Store) fetchLegacyBatch(ctx context.Context, limit int) ([]legacyRow, error) {
 	const q = `
-		SELECT username, name, nonce, ciphertext
+		SELECT username, name, 0 AS version, nonce, ciphertext
 		FROM secrets
 		WHERE wrapped_dek IS NULL
-		ORDER BY username, name
+		UNION ALL
+		SELECT username, name, version, nonce, ciphertext
+		FROM secret_versions
+		WHERE wrapped_dek IS NULL
+		ORDER BY username, name, version
 		LIMIT $1
 	`
 	rs, err := s.pool.Query(ctx, q, limit)
@@ -180,7 +206,7 @@ func (s *Store) fetchLegacyBatch(ctx context.Context, limit int) ([]legacyRow, e
 	var out []legacyRow
 	for rs.Next() {
 		var r legacyRow
-		if err := rs.Scan(&r.username, &r.name, &r.nonce, &r.ct); err != nil {
+		if err := rs.Scan(&r.username, &r.name, &r.version, &r.nonce, &r.ct); err != nil {
 			return nil, err
 		}
 		out = append(out, r)
@@ -231,13 +257,24 @@ func (s *Store) migrateOne(ctx context.Context, r legacyRow, dryRun bool) error
 	// Atomic per-row UPDATE. The WHERE wrapped_dek IS
 	// NULL guard means a concurrent migrator that
 	// already promoted this row gets an "already
-	// envelope" no-op (rows affected = 0).
-	const q = `
+	// envelope" no-op (rows affected = 0). A history
+	// row keeps its created_at: that is when the
+	// version was written, not when it was re-encrypted.
+	q := `
 		UPDATE secrets
 		SET nonce = $1, ciphertext = $2, wrapped_dek = $3, kek_id = $4, updated_at = NOW()
 		WHERE username = $5 AND name = $6 AND wrapped_dek IS NULL
 	`
-	tag, err := s.pool.Exec(ctx, q, newNonce, newCT, wrappedDEK, kekID, r.username, r.name)
+	args := []any{newNonce, newCT, wrappedDEK, kekID, r.username, r.name}
+	if r.version != 0 {
+		q = `
+			UPDATE secret_versions
+			SET nonce = $1, ciphertext = $2, wrapped_dek = $3, kek_id = $4
+			WHERE username = $5 AND name = $6 AND version = $7 AND wrapped_dek IS NULL
+		`
+		args = append(args, r.version)
+	}
+	tag, err := s.pool.Exec(ctx, q, args...)
 	if err != nil {
 		return fmt.Errorf("update row: %w", err)
 	}
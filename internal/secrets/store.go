@@ -105,6 +105,10 @@ type Store struct {
 	// on, a legacy row hitting Get is a strong "you
 	// missed a migration" signal that should page.
 	requireEnvelope bool
+
+	// retention is how many versions of each secret secret_versions
+	// keeps, the current one included. See versions.go.
+	retention int
 }
 
 // ErrNotFound is returned by Get / Delete when the (username, name)
@@ -162,7 +166,7 @@ func NewStore(ctx context.Context, pool *pgxpool.Pool, cipher *corecrypto.Cipher
 	if cipher == nil {
 		return nil, errors.New("secrets: cipher is nil")
 	}
-	s := &Store{pool: pool, cipher: cipher}
+	s := &Store{pool: pool, cipher: cipher, retention: DefaultVersionRetention}
 	for _, opt := range opts {
 		opt(s)
 	}
@@ -203,6 +207,31 @@ func (s *Store) initSchema(ctx context.Context) error {
 
 		CREATE INDEX IF NOT EXISTS idx_secrets_username
 			ON secrets(username);
+
+		-- Version history. Holds every retained version of a secret,
+		-- the current one included, in the same encrypted form as
+		-- the secrets row it was written as. See versions.go.
+		CREATE TABLE IF NOT EXISTS secret_versions (
+			username     TEXT NOT NULL,
+			name         TEXT NOT NULL,
+			version      INT  NOT NULL,
+			nonce        BYTEA NOT NULL,
+			ciphertext   BYTEA NOT NULL,
+			wrapped_dek  BYTEA,
+			kek_id       TEXT,
+			delivery     TEXT NOT NULL DEFAULT 'env',
+			created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (username, name, version)
+		);
+
+		-- Rows written before secret_versions existed have no history
+		-- entry for their current version; give them one so every
+		-- secret's current version can be listed and rolled back to.
+		-- A no-op once they have.
+		INSERT INTO secret_versions (username, name, version, nonce, ciphertext, wrapped_dek, kek_id, delivery, created_at)
+		SELECT username, name, version, nonce, ciphertext, wrapped_dek, kek_id, delivery, updated_at
+		FROM secrets
+		ON CONFLICT (username, name, version) DO NOTHING;
 	`
 	_, err := s.pool.Exec(ctx, schema)
 	return err
@@ -222,6 +251,9 @@ func (s *Store) initSchema(ctx context.Context) error {
 // `delivery` (Phase 4.3) is one of "" (defaults to env on storage),
 // "env", "file". Validated at the API boundary; invalid values
 // reject before any DB work.
+//
+// The previous value is not lost: each version is also kept in
+// secret_versions, up to the Store's retention (see Rollback).
 func (s *Store) Set(ctx context.Context, username, name, value, delivery string) (*SecretMetadata, error) {
 	if username == "" {
 		return nil, fmt.Errorf("username is required")
@@ -252,10 +284,34 @@ func (s *Store) Set(ctx context.Context, username, name, value, delivery string)
 		return nil, err
 	}
 
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after a successful Commit
+
+	meta, err := s.writeVersion(ctx, tx, username, name, delivery, nonce, ct, wrappedDEK, kekID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit secret: %w", err)
+	}
+	return meta, nil
+}
+
+// writeVersion stores an already-encrypted value as the secret's next
+// version: it replaces the secrets row, records the version in
+// secret_versions, and drops versions past the retention. Shared by Set
+// and Rollback so both leave the same trail.
+func (s *Store) writeVersion(ctx context.Context, tx pgx.Tx, username, name, delivery string, nonce, ct, wrappedDEK []byte, kekID string) (*SecretMetadata, error) {
 	// INSERT ... ON CONFLICT DO UPDATE handles both create and
 	// rotate in a single round-trip. The version bumps on every
 	// rotation; the row's created_at stays as the original
 	// (set-once-ever timestamp), updated_at moves to NOW().
+	//
+	// The upsert takes the row lock, so concurrent writers get
+	// distinct versions and each records its own below.
 	const q = `
 		INSERT INTO secrets (username, name, nonce, ciphertext, wrapped_dek, kek_id, delivery, version)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, 1)
@@ -272,9 +328,35 @@ func (s *Store) Set(ctx context.Context, username, name, value, delivery string)
 	`
 	var version int32
 	var createdAt, updatedAt time.Time
-	if err := s.pool.QueryRow(ctx, q, username, name, nonce, ct, wrappedDEK, kekID, delivery).Scan(&version, &createdAt, &updatedAt); err != nil {
+	if err := tx.QueryRow(ctx, q, username, name, nonce, ct, wrappedDEK, kekID, delivery).Scan(&version, &createdAt, &updatedAt); err != nil {
 		return nil, fmt.Errorf("upsert secret: %w", err)
 	}
+
+	const hq = `
+		INSERT INTO secret_versions (username, name, version, nonce, ciphertext, wrapped_dek, kek_id, delivery, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (username, name, version) DO UPDATE SET
+			nonce       = EXCLUDED.nonce,
+			ciphertext  = EXCLUDED.ciphertext,
+			wrapped_dek = EXCLUDED.wrapped_dek,
+			kek_id      = EXCLUDED.kek_id,
+			delivery    = EXCLUDED.delivery,
+			created_at  = EXCLUDED.created_at
+	`
+	// The ON CONFLICT covers a secret deleted and set again: its
+	// version restarts at 1, and Delete already removed the old
+	// history, so a conflict here can only be a leftover.
+	if _, err := tx.Exec(ctx, hq, username, name, version, nonce, ct, wrappedDEK, kekID, delivery, updatedAt); err != nil {
+		return nil, fmt.Errorf("record secret version: %w", err)
+	}
+
+	// Every version number is recorded as it is written, so keeping
+	// the newest `retention` numbers keeps exactly that many versions.
+	const pq = `DELETE FROM secret_versions WHERE username = $1 AND name = $2 AND version <= $3`
+	if _, err := tx.Exec(ctx, pq, username, name, version-s.versionRetention()); err != nil {
+		return nil, fmt.Errorf("prune secret versions: %w", err)
+	}
+
 	return &SecretMetadata{
 		Username:  username,
 		Name:      name,
@@ -448,9 +530,9 @@ func (s *Store) List(ctx context.Context, username string) ([]SecretMetadata, er
 	return out, nil
 }
 
-// Delete removes a single secret. Returns ErrNotFound if no such
-// row existed (so callers can return a clean 404 instead of a
-// generic 200).
+// Delete removes a single secret and its version history. Returns
+// ErrNotFound if no such row existed (so callers can return a clean
+// 404 instead of a generic 200).
 func (s *Store) Delete(ctx context.Context, username, name string) error {
 	if username == "" {
 		return fmt.Errorf("username is required")
@@ -458,14 +540,29 @@ func (s *Store) Delete(ctx context.Context, username, name string) error {
 	if err := corecrypto.ValidateName(name); err != nil {
 		return err
 	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after a successful Commit
+
 	const q = `DELETE FROM secrets WHERE username = $1 AND name = $2`
-	tag, err := s.pool.Exec(ctx, q, username, name)
+	tag, err := tx.Exec(ctx, q, username, name)
 	if err != nil {
 		return fmt.Errorf("delete secret: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
 		return ErrNotFound
 	}
+	// Deleting a secret is how a leaked value is got rid of; keeping
+	// its old versions around would defeat that.
+	const hq = `DELETE FROM secret_versions WHERE username = $1 AND name = $2`
+	if _, err := tx.Exec(ctx, hq, username, name); err != nil {
+		return fmt.Errorf("delete secret versions: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit delete: %w", err)
+	}
 	return nil
 }
 
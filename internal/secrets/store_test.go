@@ -2,6 +2,7 @@ package secrets
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -57,6 +58,7 @@ func TestSecretsStore_Roundtrip(t *testing.T) {
 
 	// Clean slate for this test user.
 	_, _ = pool.Exec(ctx, "DELETE FROM secrets WHERE username = $1", "store-test-user")
+	_, _ = pool.Exec(ctx, "DELETE FROM secret_versions WHERE username = $1", "store-test-user")
 
 	// Set, then Get, then List, then rotation (Set again), then
 	// Delete.
@@ -120,6 +122,111 @@ func TestSecretsStore_Roundtrip(t *testing.T) {
 	}
 }
 
+// TestSecretsStore_VersionHistory covers the history table the same way:
+// rotations are kept, a rollback is a new version carrying the old value
+// and delivery mode, retention prunes the oldest, and Delete takes the
+// history with it.
+func TestSecretsStore_VersionHistory(t *testing.T) {
+	dsn := os.Getenv("CONTAINARIUM_TEST_DSN")
+	if dsn == "" {
+		t.Skip("set CONTAINARIUM_TEST_DSN to run this against Postgres (the store-integration lane does)")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect Postgres: %v", err)
+	}
+	defer pool.Close()
+
+	key := make([]byte, corecrypto.MasterKeySize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	cipher, err := corecrypto.NewCipher(key)
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	store, err := NewStore(ctx, pool, cipher, WithVersionRetention(3))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	const user, name = "store-versions-user", "API_KEY"
+	_, _ = pool.Exec(ctx, "DELETE FROM secrets WHERE username = $1", user)
+	_, _ = pool.Exec(ctx, "DELETE FROM secret_versions WHERE username = $1", user)
+
+	for _, set := range []struct{ value, delivery string }{
+		{"good", DeliveryFile},
+		{"bad", DeliveryEnv},
+	} {
+		if _, err := store.Set(ctx, user, name, set.value, set.delivery); err != nil {
+			t.Fatalf("Set %q: %v", set.value, err)
+		}
+	}
+
+	versions, err := store.ListVersions(ctx, user, name)
+	if err != nil {
+		t.Fatalf("ListVersions: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 || !versions[0].Current || versions[1].Current {
+		t.Fatalf("versions = %+v, want 2 (current) then 1", versions)
+	}
+
+	// Undo the bad rotation.
+	meta, err := store.Rollback(ctx, user, name, 1)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if meta.Version != 3 || meta.Delivery != DeliveryFile {
+		t.Errorf("rollback meta = version %d delivery %q, want 3 / file", meta.Version, meta.Delivery)
+	}
+	// What RefreshSecrets delivers is the rolled-back value.
+	all, err := store.LoadAllForUserWithDelivery(ctx, user)
+	if err != nil {
+		t.Fatalf("LoadAllForUserWithDelivery: %v", err)
+	}
+	if got := all[name]; got.Value != "good" || got.Delivery != DeliveryFile {
+		t.Errorf("delivered = %+v, want good / file", got)
+	}
+
+	if _, err := store.Rollback(ctx, user, name, 3); !errors.Is(err, ErrVersionIsCurrent) {
+		t.Errorf("rollback to current: err = %v, want ErrVersionIsCurrent", err)
+	}
+	if _, err := store.Rollback(ctx, user, name, 99); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("rollback to unknown version: err = %v, want ErrVersionNotFound", err)
+	}
+
+	// A fourth version pushes version 1 out of a retention of 3.
+	if _, err := store.Set(ctx, user, name, "newer", ""); err != nil {
+		t.Fatalf("Set newer: %v", err)
+	}
+	versions, err = store.ListVersions(ctx, user, name)
+	if err != nil {
+		t.Fatalf("ListVersions after prune: %v", err)
+	}
+	if len(versions) != 3 || versions[2].Version != 2 {
+		t.Errorf("versions after prune = %+v, want 4, 3, 2", versions)
+	}
+	if _, err := store.Rollback(ctx, user, name, 1); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("rollback to pruned version: err = %v, want ErrVersionNotFound", err)
+	}
+
+	if err := store.Delete(ctx, user, name); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	var left int
+	if err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM secret_versions WHERE username = $1", user).Scan(&left); err != nil {
+		t.Fatalf("count history: %v", err)
+	}
+	if left != 0 {
+		t.Errorf("%d history rows survived Delete", left)
+	}
+	if _, err := store.ListVersions(ctx, user, name); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListVersions after delete: err = %v, want ErrNotFound", err)
+	}
+}
+
 func TestSecretsStore_NilArgsRejected(t *testing.T) {
 	ctx := context.Background()
 	key := make([]byte, corecrypto.MasterKeySize)
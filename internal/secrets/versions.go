@@ -0,0 +1,212 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corecrypto "github.com/footprintai/containarium/pkg/core/secrets"
+	"github.com/jackc/pgx/v5"
+)
+
+// Version history and rollback.
+//
+// Every write — Set and Rollback alike — also lands its encrypted tuple
+// in secret_versions, so that table holds a secret's current version and
+// the ones before it, newest `retention` of them. History is encrypted
+// exactly like the secrets row it was written as: same AAD (username,
+// name), same legacy-or-envelope choice, and the envelope migrator
+// rewrites it alongside the live rows.
+//
+// Rollback does not rewind the counter. It writes the old version's
+// value as a NEW version, so the version number still only goes up, the
+// value being replaced stays in history (the rollback can itself be
+// undone), and a box that was stamped with version N is never told N
+// again for a different value. What RefreshSecrets and container start
+// deliver is simply the current row, so a rolled-back value reaches the
+// box through the same env / file / compose paths as any Set.
+
+// DefaultVersionRetention is how many versions of each secret a Store
+// keeps, the current one included, unless WithVersionRetention says
+// otherwise.
+const DefaultVersionRetention = 10
+
+// ErrVersionNotFound is returned by Rollback when the secret exists but
+// the requested version was never written or has aged out of retention.
+var ErrVersionNotFound = errors.New("secrets: version not found")
+
+// ErrVersionIsCurrent is returned by Rollback when the requested version
+// is the one already in effect.
+var ErrVersionIsCurrent = errors.New("secrets: version is already current")
+
+// WithVersionRetention sets how many versions of each secret are kept,
+// the current one included. Values below 1 keep the default. 1 keeps
+// only the current version, which leaves nothing to roll back to.
+func WithVersionRetention(n int) Option {
+	return func(s *Store) {
+		if n >= 1 {
+			s.retention = n
+		}
+	}
+}
+
+// versionRetention is the effective retention. A Store built without
+// NewStore (the unit tests) gets the default.
+func (s *Store) versionRetention() int32 {
+	if s.retention < 1 {
+		return DefaultVersionRetention
+	}
+	return int32(s.retention) // #nosec G115 -- retention is a small operator-set count
+}
+
+// SecretVersion is the metadata of one retained version. Like
+// SecretMetadata it never carries the value.
+type SecretVersion struct {
+	Version int32
+
+	// CreatedAt is when this version was written.
+	CreatedAt time.Time
+
+	// Delivery is the delivery mode the version was written with;
+	// Rollback restores it along with the value.
+	Delivery string
+
+	// Current marks the version the secrets row holds now — the one
+	// delivered to the box.
+	Current bool
+}
+
+// ListVersions returns the retained versions of a secret, newest first.
+// Returns ErrNotFound if the secret does not exist.
+func (s *Store) ListVersions(ctx context.Context, username, name string) ([]SecretVersion, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if err := corecrypto.ValidateName(name); err != nil {
+		return nil, err
+	}
+	// Every live secret has at least its current version recorded
+	// (Set writes it; initSchema backfills older rows), so no rows
+	// means no secret.
+	const q = `
+		SELECT v.version, v.created_at, v.delivery, v.version = s.version
+		FROM secret_versions v
+		JOIN secrets s ON s.username = v.username AND s.name = v.name
+		WHERE v.username = $1 AND v.name = $2
+		ORDER BY v.version DESC
+	`
+	rows, err := s.pool.Query(ctx, q, username, name)
+	if err != nil {
+		return nil, fmt.Errorf("list secret versions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []SecretVersion
+	for rows.Next() {
+		var v SecretVersion
+		if err := rows.Scan(&v.Version, &v.CreatedAt, &v.Delivery, &v.Current); err != nil {
+			return nil, fmt.Errorf("scan secret version: %w", err)
+		}
+		if v.Delivery == "" {
+			v.Delivery = DeliveryEnv
+		}
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate secret versions: %w", err)
+	}
+	if len(out) == 0 {
+		return nil, ErrNotFound
+	}
+	return out, nil
+}
+
+// Rollback makes a retained version's value and delivery mode current
+// again, written as the secret's next version. Returns the new current
+// metadata; ErrNotFound if the secret does not exist, ErrVersionNotFound
+// if the version is not retained, ErrVersionIsCurrent if it is already
+// in effect.
+//
+// The old value is decrypted and re-encrypted the way Set would encrypt
+// it today, so a version written before KMS was enabled comes back as an
+// envelope row. Under require_envelope a legacy version that the
+// migrator has not reached yet cannot be read, and the rollback fails
+// with the same error a Get would.
+//
+// Nothing is delivered to the box here; RefreshSecrets (or the next
+// container start) does that, exactly as after a Set.
+func (s *Store) Rollback(ctx context.Context, username, name string, version int32) (*SecretMetadata, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if err := corecrypto.ValidateName(name); err != nil {
+		return nil, err
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("secrets: version must be 1 or greater; got %d", version)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after a successful Commit
+
+	// Lock the live row first so a concurrent Set cannot slip a
+	// version in between the check below and the write.
+	var current int32
+	if err := tx.QueryRow(ctx,
+		`SELECT version FROM secrets WHERE username = $1 AND name = $2 FOR UPDATE`,
+		username, name).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock secret: %w", err)
+	}
+	if version == current {
+		return nil, fmt.Errorf("%w: %s/%s is at version %d", ErrVersionIsCurrent, username, name, version)
+	}
+
+	const q = `
+		SELECT nonce, ciphertext, wrapped_dek, kek_id, delivery
+		FROM secret_versions
+		WHERE username = $1 AND name = $2 AND version = $3
+	`
+	var nonce, ct, wrappedDEK []byte
+	var kekID *string
+	var delivery string
+	if err := tx.QueryRow(ctx, q, username, name, version).Scan(&nonce, &ct, &wrappedDEK, &kekID, &delivery); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s/%s version %d (the newest %d versions are kept)",
+				ErrVersionNotFound, username, name, version, s.versionRetention())
+		}
+		return nil, fmt.Errorf("select secret version: %w", err)
+	}
+	kID := ""
+	if kekID != nil {
+		kID = *kekID
+	}
+	if delivery == "" {
+		delivery = DeliveryEnv
+	}
+
+	plaintext, err := s.decryptFromStorage(ctx, username, name, nonce, ct, wrappedDEK, kID)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt secret version %d: %w", version, err)
+	}
+	defer corecrypto.ZeroBytes(plaintext)
+
+	newNonce, newCT, newWrappedDEK, newKekID, err := s.encryptForStorage(ctx, username, name, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := s.writeVersion(ctx, tx, username, name, delivery, newNonce, newCT, newWrappedDEK, newKekID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit rollback: %w", err)
+	}
+	return meta, nil
+}
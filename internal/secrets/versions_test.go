@@ -0,0 +1,36 @@
+package secrets
+
+import "testing"
+
+// Pure-Go coverage for the retention setting. The history SQL itself is
+// exercised by TestSecretsStore_VersionHistory against Postgres.
+
+func TestVersionRetention_DefaultsWhenUnset(t *testing.T) {
+	s := &Store{cipher: newCipher(t)}
+	if got := s.versionRetention(); got != DefaultVersionRetention {
+		t.Fatalf("zero-value retention = %d, want %d", got, DefaultVersionRetention)
+	}
+}
+
+func TestWithVersionRetention(t *testing.T) {
+	s := &Store{cipher: newCipher(t), retention: DefaultVersionRetention}
+	WithVersionRetention(3)(s)
+	if got := s.versionRetention(); got != 3 {
+		t.Fatalf("retention = %d, want 3", got)
+	}
+	// Below 1 would prune the current version's own history row;
+	// it is ignored rather than honoured.
+	WithVersionRetention(0)(s)
+	if got := s.versionRetention(); got != 3 {
+		t.Fatalf("retention after WithVersionRetention(0) = %d, want 3 kept", got)
+	}
+}
+
+func TestLegacyRow_VersionSuffix(t *testing.T) {
+	if got := (legacyRow{}).versionSuffix(); got != "" {
+		t.Errorf("live row suffix = %q, want empty", got)
+	}
+	if got := (legacyRow{version: 4}).versionSuffix(); got != "@v4" {
+		t.Errorf("history row suffix = %q, want @v4", got)
+	}
+}
@@ -49,10 +49,12 @@ var gatewayProviderEnvs = map[string]gatewayProviderEnv{
 
 // mintGatewayToken mints a per-skill gateway token bound to this box's tenant +
 // skill + the configured provider, expiring with the in-box token (agentTokenTTL).
-func (g *gatewayProvisioning) mintGatewayToken(tenant, skillID string) (string, error) {
+// runID (may be empty) attributes the box's calls to a run in the usage ledger.
+func (g *gatewayProvisioning) mintGatewayToken(tenant, skillID, runID string) (string, error) {
 	return modelgateway.MintToken(g.secret, modelgateway.GatewayClaims{
 		Tenant:        tenant,
 		SkillID:       skillID,
+		RunID:         runID,
 		Provider:      g.provider,
 		AllowedModels: g.allowedModels,
 	}, agentTokenTTL)
@@ -25,6 +25,7 @@ type gatewayOTLPSink struct {
 	input  otelmetric.Int64Counter
 	output otelmetric.Int64Counter
 	cached otelmetric.Int64Counter
+	hits   otelmetric.Int64Counter
 }
 
 // newGatewayOTLPSink builds the counters from the global OTel meter. Returns an
@@ -52,7 +53,12 @@ func newGatewayOTLPSink() (*gatewayOTLPSink, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &gatewayOTLPSink{calls: calls, input: input, output: output, cached: cached}, nil
+	hits, err := meter.Int64Counter("model_gateway.cache_hits",
+		otelmetric.WithDescription("Model-gateway calls answered from the response cache (zero tokens billed)"))
+	if err != nil {
+		return nil, err
+	}
+	return &gatewayOTLPSink{calls: calls, input: input, output: output, cached: cached, hits: hits}, nil
 }
 
 // RecordUsage emits one call + its token counts, attributed for per-tenant
@@ -81,6 +87,9 @@ func (s *gatewayOTLPSink) RecordUsage(tenant, skill, provider string, u modelgat
 	if u.CachedTokens > 0 {
 		s.cached.Add(ctx, u.CachedTokens, set)
 	}
+	if u.CacheHit {
+		s.hits.Add(ctx, 1, set)
+	}
 }
 
 // compile-time assertion: gatewayOTLPSink satisfies modelgateway.UsageSink.
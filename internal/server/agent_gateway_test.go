@@ -91,7 +91,7 @@ func TestGatewayProviderKeysFromEnv(t *testing.T) {
 func TestMintGatewayToken_RoundTrips(t *testing.T) {
 	secret := []byte("test-shared-secret")
 	g := &gatewayProvisioning{provider: "anthropic", httpPort: 8080, secret: secret}
-	tok, err := g.mintGatewayToken("agent-hello", "hello-agent")
+	tok, err := g.mintGatewayToken("agent-hello", "hello-agent", "")
 	if err != nil {
 		t.Fatalf("mint: %v", err)
 	}
@@ -112,7 +112,8 @@ func (s *AgentSkillServer) StartAgentWorker(ctx context.Context, req *pb.StartAg
 	// Provision/reuse the box (seeds the skill's persona + its own scoped token,
 	// applies egress policy) — same path the push run uses, with no task input
 	// since the worker pulls its inputs from the queue.
-	containerName, container, err := s.provisionSkillBox(ctx, skill, req.BackendId, req.Pool, "")
+	// No run id: a worker runs many queued tasks under one gateway token.
+	containerName, container, err := s.provisionSkillBox(ctx, skill, req.BackendId, req.Pool, "", "")
 	if err != nil {
 		return nil, err
 	}
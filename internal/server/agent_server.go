@@ -55,6 +55,7 @@ type AgentSkillServer struct {
 	audit     *audit.Store         // records A2A hops under a trace id (Phase 2); set once the pool is ready
 	gateway   *gatewayProvisioning // model-gateway provisioning (#674); nil ⇒ boxes run in direct mode
 	queue     AgentTaskQueue       // pull-based run queue (#674) — Enqueue/Lease/Complete
+	usage     ModelUsageStore      // model-gateway usage ledger, read by GetAgentModelUsage
 }
 
 // SetAuditStore wires the audit store once the Postgres pool exists (it isn't
@@ -88,6 +89,7 @@ func NewAgentSkillServer(recipes *RecipeServer, tokens *auth.TokenManager, netpo
 		tokens:    tokens,
 		netpolicy: netpolicy,
 		queue:     NewMemAgentTaskQueue(),
+		usage:     NewMemModelUsageStore(),
 	}
 }
 
@@ -139,7 +141,13 @@ func (s *AgentSkillServer) RunAgentSkill(ctx context.Context, req *pb.RunAgentSk
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	containerName, container, err := s.provisionSkillBox(ctx, skill, req.BackendId, req.Pool, req.InputJson)
+	// The run id the box's gateway token carries, so the usage ledger can
+	// attribute this run's model calls. Only meaningful with a gateway.
+	runID := ""
+	if s.gateway != nil {
+		runID = "agentrun-" + genTraceID()
+	}
+	containerName, container, err := s.provisionSkillBox(ctx, skill, req.BackendId, req.Pool, req.InputJson, runID)
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +157,7 @@ func (s *AgentSkillServer) RunAgentSkill(ctx context.Context, req *pb.RunAgentSk
 	// degrades to an empty artifact (prior behavior), so a base-image box never
 	// fails the run.
 	artifact := s.runInBoxAgent(containerName)
-	return &pb.RunAgentSkillResponse{Container: container, ArtifactJson: artifact}, nil
+	return &pb.RunAgentSkillResponse{Container: container, ArtifactJson: artifact, RunId: runID}, nil
 }
 
 // agentRuntimeReleaseTag returns the GitHub release tag the agent-runtime box
@@ -174,9 +182,10 @@ func agentRuntimeReleaseTag() string {
 // the box recipe, deploy it, mint a JWT scoped to exactly the skill's
 // allowed_scopes, seed the prompt/token/input/card, and compile allowed_peers
 // into the per-box egress policy. It does NOT run the loop — RunAgentSkill runs
-// it one-shot, RunCrew starts it in serve mode. Returns the container name +
-// the provisioned Container.
-func (s *AgentSkillServer) provisionSkillBox(ctx context.Context, skill *pb.AgentSkill, backendID, pool, inputJSON string) (string, *pb.Container, error) {
+// it one-shot, RunCrew starts it in serve mode. runID, when set, is stamped
+// into the box's gateway token so its model calls are attributed to that run
+// in the usage ledger. Returns the container name + the provisioned Container.
+func (s *AgentSkillServer) provisionSkillBox(ctx context.Context, skill *pb.AgentSkill, backendID, pool, inputJSON, runID string) (string, *pb.Container, error) {
 	// Phase 0 supports only the recipe_id box form (catalog skills). Inline
 	// recipes are an API-only construct deferred to a later phase.
 	recipeID := skill.GetRecipeId()
@@ -253,7 +262,7 @@ func (s *AgentSkillServer) provisionSkillBox(ctx context.Context, skill *pb.Agen
 	// the box). Best-effort: a mint/script error logs and falls back to direct
 	// mode rather than failing provisioning.
 	if s.gateway != nil {
-		if gwTok, gerr := s.gateway.mintGatewayToken(name, skill.Id); gerr != nil {
+		if gwTok, gerr := s.gateway.mintGatewayToken(name, skill.Id, runID); gerr != nil {
 			log.Printf("[agent-skill] gateway token mint failed for %s (box runs direct mode): %v", name, gerr)
 		} else if envScript, eerr := gatewayEnvScript(s.gateway.provider, s.gateway.httpPort, gwTok, agentSeedDir); eerr != nil {
 			log.Printf("[agent-skill] gateway env script failed for %s (box runs direct mode): %v", name, eerr)
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/auth"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// agent_usage_server.go — read side of the model-gateway usage ledger. The
+// gateway writes one row per call (ModelUsageStore); this rolls them up by
+// tenant, skill or run for billing and per-run cost attribution. See
+// docs/AGENT-MODEL-GATEWAY-DESIGN.md (usage ledger section).
+
+// SetUsageStore swaps the usage ledger, used at daemon start to upgrade from
+// the in-memory default to Postgres. Called before the gateway is built, so
+// every call lands in the store the daemon serves reads from.
+func (s *AgentSkillServer) SetUsageStore(store ModelUsageStore) {
+	if store != nil {
+		s.usage = store
+	}
+}
+
+// UsageLedger is the store the model gateway writes calls into
+// (modelgateway.Config.Ledger).
+func (s *AgentSkillServer) UsageLedger() ModelUsageStore { return s.usage }
+
+// GetAgentModelUsage returns usage rollups over [start_time, end_time), grouped
+// by tenant (default), skill or run. Read-only, so agents:read.
+func (s *AgentSkillServer) GetAgentModelUsage(ctx context.Context, req *pb.GetAgentModelUsageRequest) (*pb.GetAgentModelUsageResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeAgentsRead); err != nil {
+		return nil, err
+	}
+	q := ModelUsageQuery{
+		Tenant:  req.Tenant,
+		SkillID: req.SkillId,
+		RunID:   req.RunId,
+		Start:   usageBound(req.StartTime),
+		End:     usageBound(req.EndTime),
+		GroupBy: req.GroupBy,
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && !q.End.After(q.Start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must be after start_time")
+	}
+	rows, err := s.usage.Rollup(ctx, q)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "model usage: %v", err)
+	}
+	return &pb.GetAgentModelUsageResponse{Rollups: rows}, nil
+}
+
+// usageBound converts an optional request timestamp; unset is open-ended.
+func usageBound(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
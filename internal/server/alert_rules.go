@@ -152,6 +152,29 @@ const DefaultAlertRules = `groups:
           summary: "Sustained high CPU during pentest"
           description: "System CPU load has been above 90% for 10 minutes, possibly due to an active pentest scan or attack."
 
+  - name: backup_alerts
+    interval: 60s
+    rules:
+      - alert: BackupScheduleFailed
+        expr: backup_schedule_last_run_failed > 0
+        for: 1m
+        labels:
+          severity: critical
+          source: default
+        annotations:
+          summary: "Scheduled backup failed"
+          description: "The last scheduled backup run for tenant {{ $labels.username }} had dump, prune or restore-test failures. See containarium backup schedule get {{ $labels.username }}."
+
+      - alert: BackupScheduleStale
+        expr: time() - backup_schedule_last_success_timestamp > 172800
+        for: 5m
+        labels:
+          severity: warning
+          source: default
+        annotations:
+          summary: "No successful scheduled backup in 48 hours"
+          description: "Tenant {{ $labels.username }} has had no fully successful scheduled backup run in the last 48 hours."
+
   - name: abuse_alerts
     interval: 30s
     rules:
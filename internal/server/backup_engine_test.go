@@ -17,7 +17,9 @@ func TestEngineToProto(t *testing.T) {
 		stored string
 		want   pb.BackupEngine
 	}{
-		{"the engine we support", backup.EnginePostgres, pb.BackupEngine_BACKUP_ENGINE_POSTGRES},
+		{"postgres", backup.EnginePostgres, pb.BackupEngine_BACKUP_ENGINE_POSTGRES},
+		{"mysql", backup.EngineMySQL, pb.BackupEngine_BACKUP_ENGINE_MYSQL},
+		{"redis", backup.EngineRedis, pb.BackupEngine_BACKUP_ENGINE_REDIS},
 		{"a record predating the enum", "", pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED},
 		{"an engine this build does not know", "mongodb", pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED},
 		{"a near miss", "postgress", pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED},
@@ -35,7 +37,7 @@ func TestEngineToProto(t *testing.T) {
 // must never present itself as Postgres. A caller reading POSTGRES will hand
 // the dump to pg_restore.
 func TestEngineToProto_NeverGuessesPostgres(t *testing.T) {
-	for _, stored := range []string{"", "mongodb", "mysql", "unknown", "POSTGRES", " postgres"} {
+	for _, stored := range []string{"", "mongodb", "mysql", "redis", "unknown", "POSTGRES", " postgres"} {
 		if got := engineToProto(stored); got == pb.BackupEngine_BACKUP_ENGINE_POSTGRES {
 			t.Errorf("engineToProto(%q) reported POSTGRES — a caller would hand this dump to "+
 				"pg_restore on the strength of a guess", stored)
@@ -52,3 +54,28 @@ func TestEngineToProto_MatchesWhatTheManifestWrites(t *testing.T) {
 			"written by this daemon would read as UNSPECIFIED", backup.EnginePostgres)
 	}
 }
+
+// A caller choosing an engine is not describing a dump, so leaving it unset
+// picks the default; every engine the enum names must round-trip to the
+// name the manifest writes, or a record would read back as UNSPECIFIED.
+func TestEngineFromProto(t *testing.T) {
+	if got, err := engineFromProto(pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED); err != nil || got != backup.EnginePostgres {
+		t.Errorf("engineFromProto(UNSPECIFIED) = %q, %v; want %q", got, err, backup.EnginePostgres)
+	}
+	for _, e := range []pb.BackupEngine{
+		pb.BackupEngine_BACKUP_ENGINE_POSTGRES,
+		pb.BackupEngine_BACKUP_ENGINE_MYSQL,
+		pb.BackupEngine_BACKUP_ENGINE_REDIS,
+	} {
+		name, err := engineFromProto(e)
+		if err != nil {
+			t.Fatalf("engineFromProto(%v): %v", e, err)
+		}
+		if back := engineToProto(name); back != e {
+			t.Errorf("%v -> %q -> %v, want a round trip", e, name, back)
+		}
+	}
+	if _, err := engineFromProto(pb.BackupEngine(99)); err == nil {
+		t.Error("an engine value this build does not know should be rejected")
+	}
+}
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/pkg/core/backup"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// SetBackupSchedule creates or replaces a tenant's recurring backup.
+func (s *BackupServer) SetBackupSchedule(ctx context.Context, req *pb.SetBackupScheduleRequest) (*pb.SetBackupScheduleResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeBackupsWrite); err != nil {
+		return nil, err
+	}
+	in := req.GetSchedule()
+	if in == nil || in.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "schedule.username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, in.Username); err != nil {
+		return nil, err
+	}
+	// Every run restore-tests into the target's container, so the caller
+	// must own it just as VerifyBackup requires.
+	if in.VerifyTargetUsername != "" {
+		if err := auth.AuthorizeTenant(ctx, in.VerifyTargetUsername); err != nil {
+			return nil, err
+		}
+	}
+	if in.GetConnection().GetPassword() != "" {
+		return nil, status.Error(codes.InvalidArgument,
+			"connection.password is not accepted on a schedule: schedules are persisted on the host; use trust/peer auth or a credentials file (PGPASSFILE, MySQL option file) inside the container")
+	}
+	dest, err := destFromProto(in.Destination)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := engineFromProto(in.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.mgr.SetSchedule(scheduleFromProto(in, engine, dest))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Printf("[backup] schedule set user=%s cron=%q dest=%s retention=%+v verify_target=%q",
+		stored.Username, stored.Cron, stored.Destination, stored.Retention, stored.VerifyTarget)
+	return &pb.SetBackupScheduleResponse{Schedule: scheduleToProto(stored)}, nil
+}
+
+// GetBackupSchedule returns a tenant's schedule and its last run.
+func (s *BackupServer) GetBackupSchedule(ctx context.Context, req *pb.GetBackupScheduleRequest) (*pb.GetBackupScheduleResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeBackupsRead); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	sched, err := s.mgr.GetSchedule(req.Username)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &pb.GetBackupScheduleResponse{Schedule: scheduleToProto(sched)}, nil
+}
+
+// ListBackupSchedules returns every schedule for admins, and only the
+// caller's own for anyone else.
+func (s *BackupServer) ListBackupSchedules(ctx context.Context, _ *pb.ListBackupSchedulesRequest) (*pb.ListBackupSchedulesResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeBackupsRead); err != nil {
+		return nil, err
+	}
+	only := ""
+	if subject, roles, ok := auth.SubjectFromGRPCContext(ctx); ok && !auth.HasRole(roles, auth.RoleAdmin) {
+		only = subject
+	}
+
+	all, err := s.mgr.ListSchedules()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backup schedules: %v", err)
+	}
+	resp := &pb.ListBackupSchedulesResponse{}
+	for _, sched := range all {
+		if only != "" && sched.Username != only {
+			continue
+		}
+		resp.Schedules = append(resp.Schedules, scheduleToProto(sched))
+	}
+	return resp, nil
+}
+
+// DeleteBackupSchedule stops a tenant's recurring backup. Backups already
+// taken are kept.
+func (s *BackupServer) DeleteBackupSchedule(ctx context.Context, req *pb.DeleteBackupScheduleRequest) (*pb.DeleteBackupScheduleResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeBackupsWrite); err != nil {
+		return nil, err
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	if err := s.mgr.DeleteSchedule(req.Username); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	log.Printf("[backup] schedule deleted user=%s", req.Username)
+	return &pb.DeleteBackupScheduleResponse{Message: "backup schedule deleted: " + req.Username}, nil
+}
+
+// StartScheduler starts the backup scheduler loop. Called from the
+// daemon's Start alongside the other ticker managers.
+func (s *BackupServer) StartScheduler(ctx context.Context) {
+	if s.scheduler != nil {
+		s.scheduler.Start(ctx)
+	}
+}
+
+// StopScheduler stops the scheduler, waiting for an in-flight run.
+func (s *BackupServer) StopScheduler() {
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+	}
+}
+
+// backupContainerResolver adapts the container manager to the
+// scheduler's tenant → container lookup.
+type backupContainerResolver struct{ cs *ContainerServer }
+
+func (r backupContainerResolver) ContainerName(username string) (string, error) {
+	info, err := r.cs.manager.Get(username)
+	if err != nil {
+		return "", err
+	}
+	return info.Name, nil
+}
+
+// backupScheduleNotifier publishes each scheduled run on the events bus
+// and as OTel gauges. The gauges are what the default BackupScheduleFailed
+// and BackupScheduleStale vmalert rules read, so a failing schedule pages
+// the same way a full disk does.
+//
+// Uses the GLOBAL meter provider, like the model-gateway sink: a no-op
+// when monitoring is disabled, so the notifier is always safe to wire.
+type backupScheduleNotifier struct {
+	emitter     *events.Emitter
+	lastFailed  otelmetric.Int64Gauge
+	lastSuccess otelmetric.Int64Gauge
+}
+
+func newBackupScheduleNotifier(emitter *events.Emitter) *backupScheduleNotifier {
+	n := &backupScheduleNotifier{emitter: emitter}
+	meter := otel.GetMeterProvider().Meter("containarium.backup")
+	var err error
+	if n.lastFailed, err = meter.Int64Gauge("backup.schedule.last_run_failed",
+		otelmetric.WithDescription("1 when a tenant's most recent scheduled backup run had failures, else 0")); err != nil {
+		log.Printf("[backup] schedule metrics unavailable: %v", err)
+	}
+	if n.lastSuccess, err = meter.Int64Gauge("backup.schedule.last_success_timestamp",
+		otelmetric.WithDescription("Unix epoch of a tenant's most recent fully successful scheduled backup run"),
+		otelmetric.WithUnit("s")); err != nil {
+		log.Printf("[backup] schedule metrics unavailable: %v", err)
+	}
+	return n
+}
+
+func (n *backupScheduleNotifier) ScheduleRan(sched *backup.Schedule, run *backup.ScheduleRun) {
+	ctx := context.Background()
+	attrs := otelmetric.WithAttributes(attribute.String("username", sched.Username))
+	failed := int64(0)
+	if !run.OK() {
+		failed = 1
+	}
+	if n.lastFailed != nil {
+		n.lastFailed.Record(ctx, failed, attrs)
+	}
+	if run.OK() && n.lastSuccess != nil {
+		n.lastSuccess.Record(ctx, run.FinishedAt.Unix(), attrs)
+	}
+	if n.emitter != nil {
+		n.emitter.EmitBackupScheduleRun(sched.Username, scheduleRunToProto(run))
+	}
+}
+
+// --- proto <-> core mapping ---
+
+func scheduleFromProto(in *pb.BackupSchedule, engine string, dest backup.Destination) *backup.Schedule {
+	conn := connFromProto(in.Connection)
+	out := &backup.Schedule{
+		Username:     in.Username,
+		Cron:         in.Cron,
+		Engine:       engine,
+		Databases:    in.Databases,
+		DBUser:       conn.User,
+		DBHost:       conn.Host,
+		DBPort:       conn.Port,
+		Destination:  dest,
+		GCSBucket:    in.GcsBucket,
+		S3Bucket:     in.S3Bucket,
+		VerifyTarget: in.VerifyTargetUsername,
+	}
+	if r := in.Retention; r != nil {
+		out.Retention = backup.Retention{Daily: int(r.Daily), Weekly: int(r.Weekly), Monthly: int(r.Monthly)}
+	}
+	return out
+}
+
+func scheduleToProto(s *backup.Schedule) *pb.BackupSchedule {
+	// A schedule set before there was an engine choice dumps Postgres.
+	engine := s.Engine
+	if engine == "" {
+		engine = backup.EnginePostgres
+	}
+	out := &pb.BackupSchedule{
+		Username:  s.Username,
+		Cron:      s.Cron,
+		Engine:    engineToProto(engine),
+		Databases: s.Databases,
+		Connection: &pb.PgConnection{
+			User: s.DBUser,
+			Host: s.DBHost,
+			Port: int32(s.DBPort), // #nosec G115 -- TCP port, always in [0,65535]
+		},
+		Destination: destToProto(s.Destination),
+		GcsBucket:   s.GCSBucket,
+		S3Bucket:    s.S3Bucket,
+		Retention: &pb.BackupRetention{
+			Daily:   int32(s.Retention.Daily),   // #nosec G115 -- set from an int32 field
+			Weekly:  int32(s.Retention.Weekly),  // #nosec G115 -- set from an int32 field
+			Monthly: int32(s.Retention.Monthly), // #nosec G115 -- set from an int32 field
+		},
+		VerifyTargetUsername: s.VerifyTarget,
+		UpdatedAt:            s.UpdatedAt.UTC().Format(time.RFC3339),
+		LastRun:              scheduleRunToProto(s.LastRun),
+	}
+	if next := s.NextRun(); !next.IsZero() {
+		out.NextRunAt = next.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+func scheduleRunToProto(r *backup.ScheduleRun) *pb.BackupScheduleRun {
+	if r == nil {
+		return nil
+	}
+	return &pb.BackupScheduleRun{
+		StartedAt:            r.StartedAt.UTC().Format(time.RFC3339),
+		FinishedAt:           r.FinishedAt.UTC().Format(time.RFC3339),
+		BackupIds:            r.BackupIDs,
+		PrunedIds:            r.PrunedIDs,
+		Failures:             r.Failures,
+		VerificationFailures: r.VerificationFailures,
+		Ok:                   r.OK(),
+	}
+}
@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/config"
+	"github.com/footprintai/containarium/internal/events"
+	secretsstore "github.com/footprintai/containarium/internal/secrets"
 	"github.com/footprintai/containarium/pkg/core/backup"
+	corecryptosecrets "github.com/footprintai/containarium/pkg/core/secrets"
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
 
@@ -23,18 +28,22 @@ const defaultBackupDir = "/var/lib/containarium/backups"
 
 // BackupServer implements the gRPC BackupService. It is orchestration over
 // the existing ContainerServer: CreateBackup runs pg_dump inside the
-// tenant's container (via the container manager's Exec/ReadFile), then
+// tenant's container (via the container manager's ExecStream), then
 // stores the dump off-host. Lives in package server to reuse the wired
-// container manager.
+// container manager. Long-running calls publish byte-level progress on
+// the events bus as EVENT_TYPE_BACKUP_PROGRESS.
 type BackupServer struct {
 	pb.UnimplementedBackupServiceServer
 	containers *ContainerServer
 	mgr        *backup.Manager
+	emitter    *events.Emitter
+	scheduler  *backup.Scheduler
 }
 
-// NewBackupServer wires the backup service to the container manager. A GCS
-// uploader is constructed best-effort: if `gcloud` is absent the daemon
-// still serves LOCAL backups and rejects GCS requests with a clear error,
+// NewBackupServer wires the backup service to the container manager. The
+// GCS and S3 uploaders are constructed best-effort: if `gcloud` is absent
+// or no S3 credentials are configured, the daemon still serves the other
+// destinations and rejects requests for that one with a clear error,
 // rather than failing to start.
 func NewBackupServer(containers *ContainerServer) *BackupServer {
 	dir := os.Getenv("CONTAINARIUM_BACKUP_DIR")
@@ -49,9 +58,63 @@ func NewBackupServer(containers *ContainerServer) *BackupServer {
 		uploader = u
 	}
 
+	mgr := backup.NewManager(containers.manager, uploader, dir)
+	if u, err := backup.NewS3Uploader(backup.S3ConfigFromEnv()); err != nil {
+		log.Printf("[backup] S3 uploader unavailable (%v); S3 backups disabled", err)
+	} else {
+		mgr.SetUploader(backup.DestS3, u)
+	}
+	if kms, desc, err := loadBackupKMS(); err != nil {
+		log.Printf("[backup] WARNING: KMS backend config error (%v); new backups will NOT be encrypted", err)
+	} else if kms == nil {
+		log.Printf("[backup] no KMS backend configured; new backups are stored unencrypted")
+	} else {
+		mgr.SetKMS(kms)
+		log.Printf("[backup] archives encrypted client-side, keys wrapped by %s", desc)
+	}
+
+	emitter := events.NewEmitter(events.GetBus())
 	return &BackupServer{
 		containers: containers,
-		mgr:        backup.NewManager(containers.manager, uploader, dir),
+		mgr:        mgr,
+		emitter:    emitter,
+		scheduler: backup.NewScheduler(mgr, backupContainerResolver{cs: containers},
+			newBackupScheduleNotifier(emitter), backup.SchedulerOptions{}),
+	}
+}
+
+// loadBackupKMS builds the KMS backup archive keys are wrapped under: the
+// backend CONTAINARIUM_KMS_BACKEND selects for the secrets store, so one
+// key-management setup covers both. The master key is read only for the
+// inproc backend, the one that wraps with it.
+func loadBackupKMS() (corecryptosecrets.KMSClient, string, error) {
+	var masterKey []byte
+	if strings.EqualFold(strings.TrimSpace(os.Getenv(config.EnvKMSBackend)), secretsstore.KMSBackendInProc) {
+		key, _, err := corecryptosecrets.LoadOrCreateMasterKey(secretsMasterKeyPath)
+		if err != nil {
+			return nil, "", err
+		}
+		masterKey = key
+	}
+	return secretsstore.LoadKMSClient(masterKey)
+}
+
+// progress returns a ProgressFunc that republishes a core progress report
+// for operation ("create", "restore", "verify") on the events bus.
+func (s *BackupServer) progress(operation, username string) backup.ProgressFunc {
+	if s.emitter == nil {
+		return nil
+	}
+	return func(p backup.Progress) {
+		s.emitter.EmitBackupProgress(&pb.BackupProgressEvent{
+			BackupId:   p.BackupID,
+			Username:   username,
+			Operation:  operation,
+			Phase:      string(p.Phase),
+			BytesDone:  p.Bytes,
+			BytesTotal: p.Total,
+			PhaseDone:  p.Done,
+		})
 	}
 }
 
@@ -71,6 +134,10 @@ func (s *BackupServer) CreateBackup(ctx context.Context, req *pb.CreateBackupReq
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	engine, err := engineFromProto(req.Engine)
+	if err != nil {
+		return nil, err
+	}
 
 	info, err := s.containers.manager.Get(req.Username)
 	if err != nil {
@@ -80,9 +147,12 @@ func (s *BackupServer) CreateBackup(ctx context.Context, req *pb.CreateBackupReq
 	opts := backup.CreateOptions{
 		Username:      req.Username,
 		ContainerName: info.Name,
+		Engine:        engine,
 		Conn:          connFromProto(req.Connection),
 		Destination:   dest,
 		GCSBucket:     req.GcsBucket,
+		S3Bucket:      req.S3Bucket,
+		Progress:      s.progress("create", req.Username),
 	}
 
 	// Empty database → back up every non-template database found (#954),
@@ -199,6 +269,7 @@ func (s *BackupServer) RestoreBackup(ctx context.Context, req *pb.RestoreBackupR
 		ContainerName: info.Name,
 		Conn:          connFromProto(req.Connection),
 		Clean:         req.Clean,
+		Progress:      s.progress("restore", rec.Username),
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "restore failed: %v", err)
 	}
@@ -259,6 +330,7 @@ func (s *BackupServer) VerifyBackup(ctx context.Context, req *pb.VerifyBackupReq
 		SourceContainer: sourceName,
 		Conn:            connFromProto(req.Connection),
 		VerifiedBy:      subject,
+		Progress:        s.progress("verify", rec.Username),
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "verification could not run: %v", err)
@@ -312,8 +384,10 @@ func destFromProto(d pb.BackupDestination) (backup.Destination, error) {
 		return backup.DestLocal, nil
 	case pb.BackupDestination_BACKUP_DESTINATION_GCS:
 		return backup.DestGCS, nil
+	case pb.BackupDestination_BACKUP_DESTINATION_S3:
+		return backup.DestS3, nil
 	default:
-		return "", status.Error(codes.InvalidArgument, "destination is required (local or gcs)")
+		return "", status.Error(codes.InvalidArgument, "destination is required (local, gcs or s3)")
 	}
 }
 
@@ -323,11 +397,29 @@ func destToProto(d backup.Destination) pb.BackupDestination {
 		return pb.BackupDestination_BACKUP_DESTINATION_LOCAL
 	case backup.DestGCS:
 		return pb.BackupDestination_BACKUP_DESTINATION_GCS
+	case backup.DestS3:
+		return pb.BackupDestination_BACKUP_DESTINATION_S3
 	default:
 		return pb.BackupDestination_BACKUP_DESTINATION_UNSPECIFIED
 	}
 }
 
+// engineFromProto maps the engine a caller chose to the core name.
+// UNSPECIFIED is Postgres here, unlike on a record: a caller that sets no
+// engine is choosing the default, not describing an existing dump.
+func engineFromProto(e pb.BackupEngine) (string, error) {
+	switch e {
+	case pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED, pb.BackupEngine_BACKUP_ENGINE_POSTGRES:
+		return backup.EnginePostgres, nil
+	case pb.BackupEngine_BACKUP_ENGINE_MYSQL:
+		return backup.EngineMySQL, nil
+	case pb.BackupEngine_BACKUP_ENGINE_REDIS:
+		return backup.EngineRedis, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unsupported engine %v", e)
+	}
+}
+
 // engineToProto maps a stored record's engine to the wire enum.
 //
 // The manifest keeps engine as a string, so records written before the enum
@@ -339,6 +431,10 @@ func engineToProto(engine string) pb.BackupEngine {
 	switch engine {
 	case backup.EnginePostgres:
 		return pb.BackupEngine_BACKUP_ENGINE_POSTGRES
+	case backup.EngineMySQL:
+		return pb.BackupEngine_BACKUP_ENGINE_MYSQL
+	case backup.EngineRedis:
+		return pb.BackupEngine_BACKUP_ENGINE_REDIS
 	default:
 		return pb.BackupEngine_BACKUP_ENGINE_UNSPECIFIED
 	}
@@ -405,5 +501,16 @@ func recordToProto(r *backup.Record) *pb.BackupRecord {
 
 		LastVerification: verificationToProto(r.LastVerification),
 		RelationCount:    r.RelationCount,
+		Scheduled:        r.Scheduled,
+		Encryption:       encryptionToProto(r.Encryption),
+	}
+}
+
+// encryptionToProto exposes how an archive is encrypted, but not its
+// wrapped data key: that stays in the daemon's index.
+func encryptionToProto(e *backup.Encryption) *pb.BackupEncryption {
+	if e == nil {
+		return nil
 	}
+	return &pb.BackupEncryption{Algorithm: e.Algorithm, KekId: e.KEKID}
 }
@@ -0,0 +1,333 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/zfscrypt"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Forking a container from a snapshot (#1160c).
+//
+// Rollback returns a box to a snapshot in place; a fork branches it instead,
+// so N siblings can try alternatives from the same starting point. The
+// mechanism is the one the design deferred "if the CoW cost turns out to
+// matter": a ZFS clone of the snapshot, swapped into the root volume of an
+// Incus instance created empty for it. `incus copy` is not an option — it
+// only knows snapshots Incus took, and these are raw ZFS ones — and a clone
+// makes every sibling instant and free until it diverges.
+//
+// What the lane established about clones (clone_constraints_integration_test)
+// shapes the refusals:
+//
+//  1. ZFS will place a clone under ANOTHER tenant's encryptionroot. The fork
+//     therefore always goes on the source's own pool, and
+//     zfscrypt.ReplaceWithClone refuses anything else.
+//  2. Cloning needs the origin's key loaded. Refused up front, with the same
+//     key-custody message as rollback, before any instance exists.
+//  3. A clone pins its snapshot. Not a refusal, but DeleteContainerSnapshot
+//     names the forks that hold one.
+//
+// A fork is a new box, not a copy of the old one's identity: its own user
+// (the source's, renamed), SSH host keys, machine-id, IP, secrets and labels.
+// It stays in its source's tenant for network policy.
+
+// SetSnapshotForking wires the fork half of the snapshot surface. Call after
+// SetSnapshotStorage; a daemon without it answers CloneContainer with
+// FailedPrecondition.
+func (s *ContainerServer) SetSnapshotForking(forkShell func(incus.ForkSpec) error) {
+	if s.snapshots == nil || forkShell == nil || s.manager == nil {
+		return
+	}
+	s.snapshots.forkShell = forkShell
+	s.snapshots.forkIdentity = s.manager.RefreshForkIdentity
+	s.snapshots.hostAccess = grantForkHostAccess
+}
+
+// grantForkHostAccess gives the fork's user a host-side jump account carrying
+// the same keys as the box, so the sentinel authorizes exactly the keys the
+// box does (#470).
+func grantForkHostAccess(username string, keys []string) error {
+	if err := container.EnsureJumpServerAccount(username); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := container.AddAuthorizedKey(username, key); err != nil {
+			log.Printf("[fork] failed to sync ssh key to jump account for %s: %v", username, err)
+		}
+	}
+	return nil
+}
+
+// CloneContainer creates a new box from a snapshot of an existing one.
+func (s *ContainerServer) CloneContainer(ctx context.Context, req *pb.CloneContainerRequest) (*pb.CloneContainerResponse, error) {
+	ops, dataset, err := s.snapshotDatasetFor(ctx, req.GetUsername(), auth.ScopeContainersWrite)
+	if err != nil {
+		return nil, err
+	}
+	if ops.forkShell == nil || ops.forkIdentity == nil {
+		return nil, status.Error(codes.FailedPrecondition,
+			"forking containers needs the Incus backend, which this daemon is not configured with")
+	}
+
+	srcUser, dstUser := req.GetUsername(), req.GetNewUsername()
+	srcName, dstName := srcUser+"-container", dstUser+"-container"
+	if err := container.ValidateUserContainerName(dstUser); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid new_username: %v", err)
+	}
+	if dstUser == srcUser {
+		return nil, status.Error(codes.InvalidArgument,
+			"new_username must differ from the source; to return a box to a snapshot in place, roll it back")
+	}
+	// The fork is a box of its own, acted on under its own name from now on;
+	// a caller that could not act on it afterwards must not create it.
+	if err := auth.AuthorizeTenant(ctx, dstUser); err != nil {
+		return nil, err
+	}
+	if req.GetSnapshot() == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot name is required")
+	}
+	if err := refuseIncusManaged("fork from", req.GetSnapshot()); err != nil {
+		return nil, err
+	}
+	for _, key := range req.GetSshKeys() {
+		if err := container.ValidateSSHPublicKey(key); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ssh key: %v", err)
+		}
+	}
+	snapshot := dataset + "@" + req.GetSnapshot()
+
+	if names, err := ops.zfs.ListSnapshots(ctx, dataset); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	} else if !slices.Contains(names, snapshot) {
+		return nil, status.Errorf(codes.NotFound, "container %s has no snapshot %q", srcUser, req.GetSnapshot())
+	}
+
+	// Lane fact 2, checked before anything is created: a clone of an unkeyed
+	// snapshot fails in ZFS, but only after the instance exists.
+	if err := ops.zfs.EnsureInspectable(ctx, snapshot); err != nil {
+		if errors.Is(err, zfscrypt.ErrKeyUnavailableForInspection) {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"refusing to fork %s from %s: %v. A fork would be a copy nobody can read — "+
+					"restore key custody and retry; the snapshot is unharmed in the meantime",
+				srcUser, req.GetSnapshot(), err)
+		}
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"cannot determine whether %s can be read, so refusing to fork it: %v", snapshot, err)
+	}
+
+	src, err := s.boxes().Get(ctx, box.BoxRef{Tenant: srcUser})
+	if err != nil || src == nil {
+		return nil, status.Errorf(codes.NotFound, "container for user %s not found: %v", srcUser, err)
+	}
+	if st, err := s.boxes().Get(ctx, box.BoxRef{Tenant: dstUser}); err == nil && st != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "container %s already exists", dstName)
+	}
+
+	// Lane fact 1: the fork goes on the source's pool, never the default.
+	pool := ""
+	if ops.poolFor != nil {
+		if pool, err = ops.poolFor(srcName); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"cannot determine which storage pool %s is on, so the fork cannot be placed "+
+					"safely: %v", srcName, err)
+		}
+	}
+
+	dropEnv, err := s.sourceSecretNames(ctx, srcUser)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"cannot determine which of %s's environment are secrets, so refusing to copy it "+
+				"into a box of another user: %v", srcUser, err)
+	}
+
+	spec := incus.ForkSpec{
+		Source:      srcName,
+		Name:        dstName,
+		StoragePool: pool,
+		Labels:      forkLabels(src.Labels, req.GetLabels()),
+		Tenant:      resolveTenant("", src.Labels[cloudOrgIDLabel], srcName),
+		DropEnv:     dropEnv,
+	}
+	if err := ops.forkShell(spec); err != nil {
+		return nil, status.Errorf(codes.Internal, "create fork %s: %v", dstName, err)
+	}
+
+	// From here on a failure leaves an instance behind; abandon removes it,
+	// so a failed fork is no fork rather than a half-identity box.
+	abandon := func(code codes.Code, format string, args ...any) error {
+		if err := s.boxes().Delete(ctx, box.BoxRef{Tenant: dstUser}, true); err != nil {
+			log.Printf("[fork] failed to remove abandoned fork %s: %v", dstName, err)
+		}
+		return status.Errorf(code, format, args...)
+	}
+
+	target, err := ops.datasetFor(dstName, pool)
+	if err != nil {
+		return nil, abandon(codes.Internal, "resolve dataset for %s: %v", dstName, err)
+	}
+	if err := ops.zfs.ReplaceWithClone(ctx, snapshot, target); err != nil {
+		if errors.Is(err, zfscrypt.ErrCrossTenantClone) {
+			return nil, abandon(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, abandon(codes.Internal, "clone %s into %s: %v", snapshot, dstName, err)
+	}
+	if err := s.recordForkPlacement(srcName, dstName, pool); err != nil {
+		return nil, abandon(codes.Internal, "%v", err)
+	}
+	s.restampForkOTel(src, spec.Labels, dstUser, dstName)
+
+	if err := s.boxes().Start(ctx, box.BoxRef{Tenant: dstUser}); err != nil {
+		return nil, abandon(codes.Internal, "start fork %s: %v", dstName, err)
+	}
+	if err := ops.forkIdentity(dstName, srcUser, dstUser); err != nil {
+		return nil, abandon(codes.Internal, "%v", err)
+	}
+	if len(req.GetSshKeys()) > 0 {
+		if err := s.boxes().SetAuthorizedKeys(ctx, box.BoxRef{Tenant: dstUser}, req.GetSshKeys()); err != nil {
+			return nil, abandon(codes.Internal, "set ssh keys on %s: %v", dstName, err)
+		}
+	}
+
+	protoContainer, sshCommand, err := s.announceBox(ctx, ops, dstUser, dstName, req.GetSshKeys(), "clone_container")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[snapshot] forked %s -> %s (pool=%q)", snapshot, dstName, pool)
+	return &pb.CloneContainerResponse{
+		Container: protoContainer,
+		Message: fmt.Sprintf("forked %s from %s@%s. The fork pins that snapshot: it cannot be "+
+			"deleted until the fork is", dstName, srcUser, req.GetSnapshot()),
+		SshCommand: sshCommand,
+	}, nil
+}
+
+// announceBox finishes a box the snapshot surface built — a fork or a
+// restore — once it has started: its user's secrets, the created event, and
+// the host-side jump account. keys are the SSH keys the request set; empty
+// means the box kept the ones it was built with, which are read back so the
+// jump account carries the same set.
+func (s *ContainerServer) announceBox(ctx context.Context, ops *snapshotOps, username, containerName string, keys []string, reason string) (*pb.Container, string, error) {
+	if s.secretsStore != nil {
+		if n, err := s.stampSecrets(ctx, username); err != nil {
+			log.Printf("[secrets] failed to stamp on %s: %v (continuing)", containerName, err)
+		} else if n > 0 {
+			log.Printf("[secrets] stamped %d secret(s) on %s", n, containerName)
+		}
+	}
+
+	info, err := s.boxes().Get(ctx, box.BoxRef{Tenant: username})
+	if err != nil || info == nil {
+		return nil, "", status.Errorf(codes.Internal, "%s was created but cannot be read back: %v", containerName, err)
+	}
+	s.refreshContainerIPMap()
+	protoContainer := toProtoContainer(info)
+	protoContainer.Pool = s.resolvePool(protoContainer.BackendId)
+	protoContainer.SshHost = s.sshHost
+	s.emitter.EmitContainerCreated(protoContainer)
+
+	if ops.hostAccess != nil {
+		if len(keys) == 0 {
+			// The jump account has to carry the box's in-box keys or the
+			// sentinel rejects them.
+			if keys, err = s.manager.ExtractSSHKeys(containerName, username, false); err != nil {
+				log.Printf("[snapshot] cannot read %s's ssh keys for the jump account: %v", containerName, err)
+			}
+		}
+		if err := ops.hostAccess(username, keys); err != nil {
+			log.Printf("Warning: failed to create jump server account for %s: %v", username, err)
+		}
+		s.notifySentinelKeyChange(ctx, reason+" "+username)
+	}
+	return protoContainer, sshCommandFor(username, protoContainer.SshHost, info.IPAddress), nil
+}
+
+// forkLabels is the fork's label set: the source's, overlaid with the
+// request's, minus the labels that identify the SOURCE to the cloud control
+// plane. A fork carrying its source's cloud container id would have its
+// telemetry and billing attributed to the source.
+func forkLabels(source, overlay map[string]string) map[string]string {
+	out := make(map[string]string, len(source)+len(overlay))
+	for k, v := range source {
+		out[k] = v
+	}
+	for k, v := range overlay {
+		out[k] = v
+	}
+	delete(out, cloudContainerIDLabel)
+	delete(out, guacamoleConnectionIDLabel)
+	return out
+}
+
+// sourceSecretNames lists the source user's secrets, which the fork must not
+// inherit from its source's instance config: secrets are scoped to a user,
+// and the fork is another one.
+func (s *ContainerServer) sourceSecretNames(ctx context.Context, username string) ([]string, error) {
+	if s.secretsStore == nil {
+		return nil, nil
+	}
+	all, err := s.secretsStore.LoadAllForUserWithDelivery(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// recordForkPlacement records the fork's key reference — its source's, since
+// a clone stays under its origin's encryptionroot — so its starts load the
+// right key. A no-op for an unencrypted source.
+func (s *ContainerServer) recordForkPlacement(srcName, dstName, pool string) error {
+	h := s.encryption
+	if !h.enabled() {
+		return nil
+	}
+	ref, ok, err := h.refs.GetKeyRef(srcName)
+	if err != nil {
+		return fmt.Errorf("read key reference of %s: %w", srcName, err)
+	}
+	if !ok {
+		return nil
+	}
+	if err := h.RecordPlacement(dstName, ref, pool); err != nil {
+		return fmt.Errorf("record encryption placement for %s: %w", dstName, err)
+	}
+	return nil
+}
+
+// restampForkOTel points a monitored fork's telemetry at its own identity.
+// The env it inherited names the source's user and container id, so without
+// this its spans land on the source. Best-effort, as in AdoptMigratedContainer.
+func (s *ContainerServer) restampForkOTel(src *box.BoxStatus, labels map[string]string, dstUser, dstName string) {
+	if !src.MonitoringEnabled || s.otelCollectorEndpoint == "" || s.manager == nil {
+		return
+	}
+	bearer, _ := LoadOrCreateOTelBearer()
+	envVars := container.OTelEnvVarsForMigrationWithBearer(
+		dstUser, container.OTelContainerID(labels, dstName), s.localBackendID(), s.otelCollectorEndpoint, bearer,
+	)
+	for k, v := range envVars {
+		if err := s.manager.SetEnv(dstName, k, v); err != nil {
+			log.Printf("[fork] failed to re-stamp %s on %s: %v (continuing — partial OTel beats none)", k, dstName, err)
+		}
+	}
+	// The dotenv file came with the clone too (#370).
+	if err := s.manager.WriteOTelEnvFile(dstName, envVars); err != nil {
+		log.Printf("[fork] failed to write env file on %s: %v (continuing)", dstName, err)
+	}
+}
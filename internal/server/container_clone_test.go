@@ -0,0 +1,278 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/zfscrypt"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Forking a box from a snapshot (#1160c). The refusals are the substance, as
+// with rollback, and each is asserted to have created nothing: a refused fork
+// that leaves an instance behind is a box nobody asked for.
+
+// cloneZFS answers the property read ReplaceWithClone makes separately from
+// the keystatus read EnsureInspectable makes — zfsFake keys responses by
+// subcommand, and both are `zfs get`.
+type cloneZFS struct {
+	*zfsFake
+	props string
+}
+
+func (c *cloneZFS) Run(ctx context.Context, stdin []byte, args ...string) (string, string, error) {
+	if len(args) > 4 && args[0] == "get" && strings.HasPrefix(args[4], "encryptionroot,") {
+		c.calls = append(c.calls, strings.Join(args, " "))
+		return c.props, "", nil
+	}
+	return c.zfsFake.Run(ctx, stdin, args...)
+}
+
+// forkBoxes is a box backend holding a set of existing boxes.
+type forkBoxes struct {
+	box.BoxBackend
+	exists  map[string]*box.BoxStatus
+	started []string
+	deleted []string
+	keys    map[string][]string
+}
+
+func (f *forkBoxes) Get(_ context.Context, ref box.BoxRef) (*box.BoxStatus, error) {
+	if st, ok := f.exists[ref.Tenant]; ok {
+		return st, nil
+	}
+	return nil, errors.New("container not found")
+}
+
+func (f *forkBoxes) Start(_ context.Context, ref box.BoxRef) error {
+	f.started = append(f.started, ref.Tenant)
+	f.exists[ref.Tenant] = &box.BoxStatus{Ref: ref, State: pb.ContainerState_CONTAINER_STATE_RUNNING, IPAddress: "10.0.0.9"}
+	return nil
+}
+
+func (f *forkBoxes) Delete(_ context.Context, ref box.BoxRef, _ bool) error {
+	f.deleted = append(f.deleted, ref.Tenant)
+	delete(f.exists, ref.Tenant)
+	return nil
+}
+
+func (f *forkBoxes) SetAuthorizedKeys(_ context.Context, ref box.BoxRef, keys []string) error {
+	f.keys[ref.Tenant] = keys
+	return nil
+}
+
+type forkRecorder struct {
+	specs      []incus.ForkSpec
+	identities [][3]string
+	shellErr   error
+}
+
+// cloneFixture: alice's box exists with a snapshot "base" on the given pool,
+// and the fake ZFS reports the given encryptionroots for snapshot and target.
+func cloneFixture(t *testing.T, pool, srcRoot, dstRoot string) (*ContainerServer, *cloneZFS, *forkBoxes, *forkRecorder) {
+	t.Helper()
+	p := pool
+	if p == "" {
+		p = "default"
+	}
+	snap := "tank/" + p + "/containers/alice-container@base"
+	target := "tank/" + p + "/containers/bob-container"
+
+	z := &cloneZFS{zfsFake: keyed(newZFSFake())}
+	delete(z.errs, "list")
+	z.stdout["list"] = snap + "\n"
+	z.props = snap + "\tencryptionroot\t" + srcRoot + "\n" +
+		target + "\tencryptionroot\t" + dstRoot + "\n" +
+		target + "\tmountpoint\t/var/lib/incus/storage-pools/" + p + "/containers/bob-container\n"
+
+	boxes := &forkBoxes{
+		exists: map[string]*box.BoxStatus{"alice": {
+			Ref:    box.BoxRef{Tenant: "alice"},
+			State:  pb.ContainerState_CONTAINER_STATE_RUNNING,
+			Labels: map[string]string{"role": "agent", cloudContainerIDLabel: "cld-123"},
+		}},
+		keys: map[string][]string{},
+	}
+	rec := &forkRecorder{}
+	s := &ContainerServer{
+		boxBackend: boxes,
+		emitter:    events.NewEmitter(events.GetBus()),
+		snapshots: &snapshotOps{
+			zfs: zfscrypt.NewManager(z),
+			datasetFor: func(containerName, pool string) (string, error) {
+				if pool == "" {
+					pool = "default"
+				}
+				return "tank/" + pool + "/containers/" + containerName, nil
+			},
+			poolFor: func(string) (string, error) { return pool, nil },
+			forkShell: func(spec incus.ForkSpec) error {
+				rec.specs = append(rec.specs, spec)
+				return rec.shellErr
+			},
+			forkIdentity: func(containerName, oldUser, newUser string) error {
+				rec.identities = append(rec.identities, [3]string{containerName, oldUser, newUser})
+				return nil
+			},
+		},
+	}
+	return s, z, boxes, rec
+}
+
+func adminWriteCtx() context.Context {
+	return auth.ContextWithTestSubjectScopes(context.Background(),
+		"ops", []string{auth.RoleAdmin}, []string{auth.ScopeContainersRead, auth.ScopeContainersWrite})
+}
+
+func cloneReq() *pb.CloneContainerRequest {
+	return &pb.CloneContainerRequest{
+		Username: "alice", Snapshot: "base", NewUsername: "bob",
+		Labels: map[string]string{"attempt": "2"},
+	}
+}
+
+// The core of the slice: the fork is cloned from the source's snapshot on the
+// source's own pool, and gets its own identity.
+func TestCloneContainer_ForksOnTheSourcesPool(t *testing.T) {
+	s, z, boxes, rec := cloneFixture(t, "containarium-tenant-acme", "tank/tenants/acme", "tank/tenants/acme")
+
+	resp, err := s.CloneContainer(adminWriteCtx(), cloneReq())
+	if err != nil {
+		t.Fatalf("CloneContainer: %v", err)
+	}
+
+	if len(rec.specs) != 1 {
+		t.Fatalf("fork shells = %d", len(rec.specs))
+	}
+	spec := rec.specs[0]
+	if spec.Source != "alice-container" || spec.Name != "bob-container" || spec.StoragePool != "containarium-tenant-acme" {
+		t.Errorf("spec = %+v", spec)
+	}
+	if spec.Tenant != "alice" {
+		t.Errorf("fork tenant = %q, want its source's", spec.Tenant)
+	}
+	if spec.Labels["role"] != "agent" || spec.Labels["attempt"] != "2" {
+		t.Errorf("labels = %v", spec.Labels)
+	}
+	if _, ok := spec.Labels[cloudContainerIDLabel]; ok {
+		t.Error("fork inherited its source's cloud container id")
+	}
+
+	if !z.ran("clone -o mountpoint=/var/lib/incus/storage-pools/containarium-tenant-acme/containers/bob-container " +
+		"tank/containarium-tenant-acme/containers/alice-container@base") {
+		t.Errorf("zfs calls = %v", z.calls)
+	}
+	if !z.ran("rename tank/containarium-tenant-acme/containers/bob-container_fork tank/containarium-tenant-acme/containers/bob-container") {
+		t.Errorf("clone was not moved into place: %v", z.calls)
+	}
+	if len(boxes.started) != 1 || boxes.started[0] != "bob" {
+		t.Errorf("started = %v", boxes.started)
+	}
+	if len(rec.identities) != 1 || rec.identities[0] != [3]string{"bob-container", "alice", "bob"} {
+		t.Errorf("identity refresh = %v", rec.identities)
+	}
+	if len(boxes.deleted) != 0 {
+		t.Errorf("deleted %v after a successful fork", boxes.deleted)
+	}
+	if !strings.Contains(resp.GetSshCommand(), "bob") || resp.GetContainer() == nil {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+// Lane fact 2: an unkeyed snapshot cannot be cloned. Refused before an
+// instance exists, with the key-custody remedy.
+func TestCloneContainer_RefusesWhenTheKeyIsUnavailable(t *testing.T) {
+	s, z, _, rec := cloneFixture(t, "containarium-tenant-acme", "tank/tenants/acme", "tank/tenants/acme")
+	z.stdout["get"] = string(zfscrypt.KeyUnavailable)
+
+	_, err := s.CloneContainer(adminWriteCtx(), cloneReq())
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "key custody") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(rec.specs) != 0 || z.ran("clone ") {
+		t.Error("created a fork despite the key being unavailable")
+	}
+}
+
+// Lane fact 1, the daemon's half: ZFS would place the clone under another
+// encryptionroot; the fork must be refused and its shell removed.
+func TestCloneContainer_RefusesAcrossEncryptionRootsAndCleansUp(t *testing.T) {
+	s, z, boxes, _ := cloneFixture(t, "containarium-tenant-acme", "tank/tenants/acme", "tank/tenants/globex")
+
+	_, err := s.CloneContainer(adminWriteCtx(), cloneReq())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v", err)
+	}
+	if z.ran("clone ") || z.ran("destroy") {
+		t.Errorf("ran zfs after refusing: %v", z.calls)
+	}
+	if len(boxes.deleted) != 1 || boxes.deleted[0] != "bob" {
+		t.Errorf("abandoned fork shell not removed: deleted = %v", boxes.deleted)
+	}
+}
+
+func TestCloneContainer_Refusals(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mutate func(*pb.CloneContainerRequest, *forkBoxes)
+		code   codes.Code
+	}{
+		"same name":         {func(r *pb.CloneContainerRequest, _ *forkBoxes) { r.NewUsername = "alice" }, codes.InvalidArgument},
+		"bad name":          {func(r *pb.CloneContainerRequest, _ *forkBoxes) { r.NewUsername = "Bob!" }, codes.InvalidArgument},
+		"incus snapshot":    {func(r *pb.CloneContainerRequest, _ *forkBoxes) { r.Snapshot = "snapshot-sync0" }, codes.InvalidArgument},
+		"missing snapshot":  {func(r *pb.CloneContainerRequest, _ *forkBoxes) { r.Snapshot = "nope" }, codes.NotFound},
+		"bad ssh key":       {func(r *pb.CloneContainerRequest, _ *forkBoxes) { r.SshKeys = []string{"YOUR_KEY"} }, codes.InvalidArgument},
+		"destination taken": {func(_ *pb.CloneContainerRequest, b *forkBoxes) { b.exists["bob"] = &box.BoxStatus{} }, codes.AlreadyExists},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, _, boxes, rec := cloneFixture(t, "", "-", "-")
+			req := cloneReq()
+			tc.mutate(req, boxes)
+			_, err := s.CloneContainer(adminWriteCtx(), req)
+			if status.Code(err) != tc.code {
+				t.Fatalf("err = %v, want %v", err, tc.code)
+			}
+			if len(rec.specs) != 0 {
+				t.Error("created a fork shell despite refusing")
+			}
+		})
+	}
+}
+
+// A tenant token may read its own snapshot but not mint a box under another
+// user's name, which it could not act on afterwards anyway.
+func TestCloneContainer_TenantCannotForkIntoAnotherUser(t *testing.T) {
+	s, _, _, rec := cloneFixture(t, "", "-", "-")
+
+	_, err := s.CloneContainer(writeCtx("alice"), cloneReq())
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("err = %v, want PermissionDenied", err)
+	}
+	if len(rec.specs) != 0 {
+		t.Error("created a fork for an unauthorized caller")
+	}
+}
+
+// A fork pins its snapshot; deleting the snapshot must name the fork rather
+// than pass on ZFS's bare "dependent clones".
+func TestDeleteContainerSnapshot_NamesDependentForks(t *testing.T) {
+	z := newZFSFake()
+	z.errs["destroy"] = errors.New("exit status 1")
+	z.stderr["destroy"] = "cannot destroy: snapshot has dependent clones"
+	z.stdout["get"] = "tank/default/containers/bob-container"
+	s := snapshotFixture(t, z, nil)
+
+	_, err := s.DeleteContainerSnapshot(writeCtx("alice"),
+		&pb.DeleteContainerSnapshotRequest{Username: "alice", Name: "base"})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "bob-container") {
+		t.Fatalf("err = %v, want the dependent fork named", err)
+	}
+}
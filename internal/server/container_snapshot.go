@@ -9,6 +9,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/snapsched"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/snapexport"
 	"github.com/footprintai/containarium/pkg/core/zfscrypt"
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
@@ -96,6 +99,23 @@ type snapshotOps struct {
 	// it is on the default one. nil when encryption is not configured, in
 	// which case every container is on the default pool.
 	poolFor func(containerName string) (string, error)
+
+	// forkShell, forkIdentity and hostAccess are the fork half (#1160c), set
+	// by SetSnapshotForking: creating the instance a clone is swapped into,
+	// renaming the source's user inside the started fork, and the fork's
+	// host-side jump account. forkShell is nil on a daemon that cannot fork.
+	forkShell    func(incus.ForkSpec) error
+	forkIdentity func(containerName, oldUser, newUser string) error
+	hostAccess   func(username string, keys []string) error
+
+	// exporter, shapeOf and shellFromShape are the export half, set by
+	// SetSnapshotExport: shipping snapshot chains off the host, capturing
+	// the instance definition that travels with them, and creating a
+	// restore's instance from it. exporter is nil on a daemon that cannot
+	// export.
+	exporter       *snapexport.Exporter
+	shapeOf        func(containerName string, dropEnv []string) (*incus.InstanceShape, error)
+	shellFromShape func(*incus.InstanceShape, incus.ForkSpec) error
 }
 
 // SetSnapshotStorage wires the snapshot surface.
@@ -181,6 +201,15 @@ func (s *ContainerServer) CreateContainerSnapshot(ctx context.Context, req *pb.C
 				"choose another name", incusSnapshotPrefix)
 	}
 
+	// The scheduler's namespace is reserved the same way: retention prunes
+	// every "auto-<timestamp>" snapshot, so one taken by hand under such a
+	// name would be deleted by the next tick of a policy that never took it.
+	if _, scheduled := snapsched.ParseName(req.GetName()); scheduled {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"%q is the form of a scheduled snapshot's name, which retention prunes; choose another name",
+			req.GetName())
+	}
+
 	// The rest of name validation lives in zfscrypt, which rejects anything
 	// containing '@', '/' or whitespace. Routing through it rather than
 	// concatenating here is the point: a name like "../other" would otherwise
@@ -269,6 +298,18 @@ func (s *ContainerServer) DeleteContainerSnapshot(ctx context.Context, req *pb.D
 	}
 
 	if err := ops.zfs.DestroySnapshot(ctx, full); err != nil {
+		// A fork pins the snapshot it was cloned from (#1160c), and ZFS says
+		// only "snapshot has dependent clones" — not which, or that the
+		// remedy is deleting a box.
+		if clones, cerr := ops.zfs.Clones(ctx, full); cerr == nil && len(clones) > 0 {
+			forks := make([]string, len(clones))
+			for i, c := range clones {
+				forks[i] = c[strings.LastIndex(c, "/")+1:]
+			}
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"snapshot %s is the origin of fork(s) %s; delete them first: %v",
+				req.GetName(), strings.Join(forks, ", "), err)
+		}
 		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
 	}
 
@@ -0,0 +1,389 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/zfscrypt"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Browsing a snapshot without rolling back to it.
+//
+// Recovering one deleted file used to mean rolling the whole box back. These
+// verbs read a snapshot in place instead: list a directory, read a file, and
+// diff a snapshot against a later one or the live box. They are the
+// inspection verbs container_snapshot.go defers to, so they run
+// zfscrypt.EnsureInspectable first and refuse an unavailable key with the
+// same remedy rollback gives.
+//
+// Files are read from the snapshot's .zfs/snapshot directory, which exists
+// only while the box's volume is mounted — while the box runs. A stopped box
+// is refused rather than having its volume mounted behind Incus's back.
+//
+// Every path is resolved through an os.Root at the box's rootfs. A box's
+// filesystem is the tenant's to write, symlinks included, and the daemon
+// reads it as host root: a symlink to /etc/shadow must resolve inside the
+// box, not on the host.
+
+const (
+	// incusRootfsDir is where an Incus container volume keeps the box's
+	// filesystem; beside it are Incus's own backup.yaml and metadata.
+	incusRootfsDir = "rootfs"
+
+	// maxSnapshotReadBytes bounds one ReadContainerSnapshotFile response.
+	maxSnapshotReadBytes = 4 << 20
+
+	// maxSnapshotDiffChanges bounds one DiffContainerSnapshots response. A
+	// diff against a box that has since reinstalled its packages can run to
+	// hundreds of thousands of paths.
+	maxSnapshotDiffChanges = 50000
+)
+
+// snapshotInspectionFor is the inspection verbs' preamble: the snapshot
+// verbs' authorization, at read scope, and the reserved-name refusal.
+func (s *ContainerServer) snapshotInspectionFor(ctx context.Context, username, snapshot, verb string) (*snapshotOps, string, error) {
+	ops, dataset, err := s.snapshotDatasetFor(ctx, username, auth.ScopeContainersRead)
+	if err != nil {
+		return nil, "", err
+	}
+	if snapshot == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "snapshot name is required")
+	}
+	// Incus's snapshots are hidden from the listing (#1390); reading one
+	// would surface a migration's sync point the tenant never took.
+	if err := refuseIncusManaged(verb, snapshot); err != nil {
+		return nil, "", err
+	}
+	return ops, dataset, nil
+}
+
+// inspectionError maps a refusal to read a snapshot to its remedy.
+func inspectionError(username, snapshot string, err error) error {
+	switch {
+	case errors.Is(err, zfscrypt.ErrKeyUnavailableForInspection):
+		return status.Errorf(codes.FailedPrecondition,
+			"cannot read %s's snapshot %s: %v. Restore key custody and retry; the snapshot is unharmed",
+			username, snapshot, err)
+	case errors.Is(err, zfscrypt.ErrNotMounted):
+		return status.Errorf(codes.FailedPrecondition,
+			"cannot read %s's snapshot %s while the container is stopped: %v. Start it and retry",
+			username, snapshot, err)
+	}
+	return status.Errorf(codes.FailedPrecondition, "%v", err)
+}
+
+// openSnapshotRoot opens a snapshot's view of the box's filesystem.
+func (s *ContainerServer) openSnapshotRoot(ctx context.Context, username, snapshot, verb string) (*os.Root, error) {
+	ops, dataset, err := s.snapshotInspectionFor(ctx, username, snapshot, verb)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := ops.zfs.SnapshotDir(ctx, dataset+"@"+snapshot)
+	if err != nil {
+		return nil, inspectionError(username, snapshot, err)
+	}
+	root, err := os.OpenRoot(filepath.Join(dir, incusRootfsDir))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, status.Errorf(codes.NotFound, "%s has no snapshot %q", username, snapshot)
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "open snapshot %s of %s: %v", snapshot, username, err)
+	}
+	return root, nil
+}
+
+// boxPath turns a path inside the box into one relative to its root, "."
+// for the root itself. Cleaning against "/" first means ".." stops there.
+func boxPath(p string) string {
+	clean := path.Clean("/" + p)
+	if clean == "/" {
+		return "."
+	}
+	return clean[1:]
+}
+
+// snapshotPathError maps a failure to resolve a path inside a snapshot.
+func snapshotPathError(snapshot, p string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return status.Errorf(codes.NotFound, "%s does not exist in snapshot %s", p, snapshot)
+	}
+	// Includes a symlink that leads out of the box: os.Root refuses it, and
+	// the tenant is told no more than that.
+	return status.Errorf(codes.InvalidArgument, "cannot read %s in snapshot %s: %v", p, snapshot, err)
+}
+
+// ListContainerSnapshotFiles lists a directory as it was in a snapshot.
+func (s *ContainerServer) ListContainerSnapshotFiles(ctx context.Context, req *pb.ListContainerSnapshotFilesRequest) (*pb.ListContainerSnapshotFilesResponse, error) {
+	root, err := s.openSnapshotRoot(ctx, req.GetUsername(), req.GetSnapshot(), "browse")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = root.Close() }()
+
+	rel := boxPath(req.GetPath())
+	shown := path.Clean("/" + req.GetPath())
+	dir, err := root.Open(rel)
+	if err != nil {
+		return nil, snapshotPathError(req.GetSnapshot(), shown, err)
+	}
+	defer func() { _ = dir.Close() }()
+	if info, err := dir.Stat(); err != nil || !info.IsDir() {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a directory in snapshot %s", shown, req.GetSnapshot())
+	}
+	dirents, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, snapshotPathError(req.GetSnapshot(), shown, err)
+	}
+
+	entries := make([]*pb.SnapshotFileEntry, 0, len(dirents))
+	for _, d := range dirents {
+		// Info is an lstat: a symlink is reported as itself.
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+		e := snapshotFileEntry(info)
+		if e.Type == pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_SYMLINK {
+			e.LinkTarget, _ = root.Readlink(path.Join(rel, d.Name()))
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return &pb.ListContainerSnapshotFilesResponse{Path: shown, Entries: entries}, nil
+}
+
+func snapshotFileEntry(info fs.FileInfo) *pb.SnapshotFileEntry {
+	// st_mode's permission bits: Go keeps setuid, setgid and sticky apart
+	// from Perm.
+	m := info.Mode()
+	mode := uint32(m.Perm())
+	if m&fs.ModeSetuid != 0 {
+		mode |= 0o4000
+	}
+	if m&fs.ModeSetgid != 0 {
+		mode |= 0o2000
+	}
+	if m&fs.ModeSticky != 0 {
+		mode |= 0o1000
+	}
+	return &pb.SnapshotFileEntry{
+		Name:       info.Name(),
+		Type:       snapshotFileType(m),
+		SizeBytes:  info.Size(),
+		Mode:       mode,
+		ModifiedAt: info.ModTime().UTC().Format(time.RFC3339),
+	}
+}
+
+func snapshotFileType(m fs.FileMode) pb.SnapshotFileType {
+	switch {
+	case m.IsRegular():
+		return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_FILE
+	case m.IsDir():
+		return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_DIRECTORY
+	case m&fs.ModeSymlink != 0:
+		return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_SYMLINK
+	}
+	return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_OTHER
+}
+
+// ReadContainerSnapshotFile reads a range of a regular file as it was in a
+// snapshot.
+func (s *ContainerServer) ReadContainerSnapshotFile(ctx context.Context, req *pb.ReadContainerSnapshotFileRequest) (*pb.ReadContainerSnapshotFileResponse, error) {
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+	root, err := s.openSnapshotRoot(ctx, req.GetUsername(), req.GetSnapshot(), "read")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = root.Close() }()
+
+	rel := boxPath(req.GetPath())
+	shown := path.Clean("/" + req.GetPath())
+	info, err := root.Lstat(rel)
+	if err != nil {
+		return nil, snapshotPathError(req.GetSnapshot(), shown, err)
+	}
+	// Not followed: the target may be another path in the box, which the
+	// caller can read by name, or a host path, which they must not.
+	if info.Mode()&fs.ModeSymlink != 0 {
+		target, _ := root.Readlink(rel)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"%s is a symlink to %q in snapshot %s; read its target instead", shown, target, req.GetSnapshot())
+	}
+	if !info.Mode().IsRegular() {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a regular file in snapshot %s", shown, req.GetSnapshot())
+	}
+
+	f, err := root.Open(rel)
+	if err != nil {
+		return nil, snapshotPathError(req.GetSnapshot(), shown, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	size := info.Size()
+	offset := min(req.GetOffset(), size)
+	length := req.GetLength()
+	if length <= 0 || length > maxSnapshotReadBytes {
+		length = maxSnapshotReadBytes
+	}
+	buf := make([]byte, min(length, size-offset))
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, status.Errorf(codes.Internal, "read %s in snapshot %s: %v", shown, req.GetSnapshot(), err)
+	}
+	return &pb.ReadContainerSnapshotFileResponse{
+		Content:   buf[:n],
+		Offset:    offset,
+		SizeBytes: size,
+		Eof:       offset+int64(n) >= size,
+	}, nil
+}
+
+// DiffContainerSnapshots lists the files that differ between a snapshot and
+// a later one, or the live box.
+//
+// `zfs diff` finds the paths — from block pointers, without walking either
+// tree — and each is then stat'ed on both sides for its sizes.
+func (s *ContainerServer) DiffContainerSnapshots(ctx context.Context, req *pb.DiffContainerSnapshotsRequest) (*pb.DiffContainerSnapshotsResponse, error) {
+	from, to := req.GetFromSnapshot(), req.GetToSnapshot()
+	ops, dataset, err := s.snapshotInspectionFor(ctx, req.GetUsername(), from, "diff")
+	if err != nil {
+		return nil, err
+	}
+	toRef := ""
+	if to != "" {
+		if err := refuseIncusManaged("diff", to); err != nil {
+			return nil, err
+		}
+		toRef = dataset + "@" + to
+	}
+
+	changes, err := ops.zfs.Diff(ctx, dataset+"@"+from, toRef)
+	if err != nil {
+		return nil, inspectionError(req.GetUsername(), from, err)
+	}
+
+	// Sizes are decoration, as usage is on the listing: a side that cannot be
+	// opened reports zeroes rather than failing a diff that succeeded.
+	before := openDiffSide(ctx, ops, dataset, dataset+"@"+from)
+	after := openDiffSide(ctx, ops, dataset, toRef)
+	defer closeDiffSide(before)
+	defer closeDiffSide(after)
+
+	resp := &pb.DiffContainerSnapshotsResponse{}
+	add := func(p string, kind pb.SnapshotChangeKind, typ string) {
+		rel, ok := strings.CutPrefix(p, "/"+incusRootfsDir+"/")
+		if !ok {
+			// The volume root and Incus's metadata beside the rootfs are not
+			// the box's files.
+			return
+		}
+		c := &pb.SnapshotFileChange{Path: "/" + rel, Kind: kind, Type: diffFileType(typ)}
+		if kind != pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_ADDED {
+			c.OldSizeBytes = diffSize(before, rel)
+		}
+		if kind != pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_DELETED {
+			c.NewSizeBytes = diffSize(after, rel)
+		}
+		resp.Changes = append(resp.Changes, c)
+	}
+	for _, c := range changes {
+		switch c.Kind {
+		case zfscrypt.ChangeAdded:
+			add(c.Path, pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_ADDED, c.Type)
+		case zfscrypt.ChangeRemoved:
+			add(c.Path, pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_DELETED, c.Type)
+		case zfscrypt.ChangeModified:
+			// A directory is modified whenever an entry in it is added or
+			// removed, and those entries are already listed.
+			if c.Type != "/" {
+				add(c.Path, pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_MODIFIED, c.Type)
+			}
+		case zfscrypt.ChangeRenamed:
+			add(c.Path, pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_DELETED, c.Type)
+			add(c.NewPath, pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_ADDED, c.Type)
+		}
+	}
+
+	sort.SliceStable(resp.Changes, func(i, j int) bool { return resp.Changes[i].Path < resp.Changes[j].Path })
+	if len(resp.Changes) > maxSnapshotDiffChanges {
+		resp.Changes, resp.Truncated = resp.Changes[:maxSnapshotDiffChanges], true
+	}
+	for _, c := range resp.Changes {
+		switch c.Kind {
+		case pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_ADDED:
+			resp.Added++
+		case pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_MODIFIED:
+			resp.Modified++
+		case pb.SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_DELETED:
+			resp.Deleted++
+		}
+	}
+	return resp, nil
+}
+
+// openDiffSide opens one side of a diff at the box's rootfs: a snapshot, or
+// the live dataset when ref is "". nil when it cannot be opened.
+func openDiffSide(ctx context.Context, ops *snapshotOps, dataset, ref string) *os.Root {
+	var dir string
+	var err error
+	if ref == "" {
+		dir, err = ops.zfs.Mountpoint(ctx, dataset)
+	} else {
+		dir, err = ops.zfs.SnapshotDir(ctx, ref)
+	}
+	if err != nil {
+		return nil
+	}
+	root, err := os.OpenRoot(filepath.Join(dir, incusRootfsDir))
+	if err != nil {
+		return nil
+	}
+	return root
+}
+
+func closeDiffSide(root *os.Root) {
+	if root != nil {
+		_ = root.Close()
+	}
+}
+
+// diffSize is rel's size on one side of a diff, 0 when it cannot be read.
+func diffSize(root *os.Root, rel string) int64 {
+	if root == nil {
+		return 0
+	}
+	info, err := root.Lstat(rel)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// diffFileType maps `zfs diff -F`'s file type.
+func diffFileType(t string) pb.SnapshotFileType {
+	switch t {
+	case "F":
+		return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_FILE
+	case "/":
+		return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_DIRECTORY
+	case "@":
+		return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_SYMLINK
+	}
+	return pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_OTHER
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/zfscrypt"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Browsing and diffing snapshots. The fake ZFS reports a temporary directory
+// as the dataset's mountpoint, laid out as a mounted Incus volume is, so the
+// handlers read real files through the same paths they would on a host.
+
+const browseDataset = "tank/default/containers/alice-container"
+
+// browseZFS answers the mounted/mountpoint read separately from the
+// keystatus read that shares the "get" subcommand.
+type browseZFS struct {
+	*zfsFake
+	mountpoint string
+	mounted    string
+}
+
+func (b *browseZFS) Run(ctx context.Context, stdin []byte, args ...string) (string, string, error) {
+	if len(args) > 5 && args[0] == "get" && args[4] == "mounted,mountpoint" {
+		b.calls = append(b.calls, strings.Join(args, " "))
+		ds := args[5]
+		return ds + "\tmounted\t" + b.mounted + "\n" + ds + "\tmountpoint\t" + b.mountpoint + "\n", "", nil
+	}
+	return b.zfsFake.Run(ctx, stdin, args...)
+}
+
+// browseFixture: alice's running box has a snapshot "nightly" holding
+// /home/alice/notes.txt, which has since been deleted from the live box.
+func browseFixture(t *testing.T) (*ContainerServer, *browseZFS) {
+	t.Helper()
+	mp := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(mp, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snap := filepath.Join(".zfs", "snapshot", "nightly", incusRootfsDir)
+	write(filepath.Join(snap, "home/alice/notes.txt"), "remember the milk")
+	write(filepath.Join(snap, "home/alice/todo.txt"), "one")
+	write(filepath.Join(snap, "etc/hostname"), "alice")
+	write(filepath.Join(incusRootfsDir, "home/alice/todo.txt"), "one two three")
+	write(filepath.Join(incusRootfsDir, "home/alice/new.txt"), "fresh")
+	write("backup.yaml", "incus")
+
+	// A tenant controls the symlinks in their box; these point at the host.
+	for name, target := range map[string]string{"passwd": "/etc/passwd", "hostetc": "/etc"} {
+		if err := os.Symlink(target, filepath.Join(mp, snap, "home/alice", name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	z := &browseZFS{zfsFake: newZFSFake(), mountpoint: mp, mounted: "yes"}
+	z.stdout["get"] = string(zfscrypt.KeyAvailable)
+	s := snapshotFixture(t, z.zfsFake, nil)
+	s.snapshots.zfs = zfscrypt.NewManager(z)
+	return s, z
+}
+
+func readCtx(tenant string) context.Context {
+	return tenantWithScopes(tenant, auth.ScopeContainersRead)
+}
+
+// The point of the feature: a file deleted from the box is still there in
+// the snapshot, and listing it needs no rollback.
+func TestListContainerSnapshotFiles_ListsTheSnapshot(t *testing.T) {
+	s, _ := browseFixture(t)
+
+	resp, err := s.ListContainerSnapshotFiles(readCtx("alice"), &pb.ListContainerSnapshotFilesRequest{
+		Username: "alice", Snapshot: "nightly", Path: "home/alice/",
+	})
+	if err != nil {
+		t.Fatalf("ListContainerSnapshotFiles: %v", err)
+	}
+	if resp.GetPath() != "/home/alice" {
+		t.Errorf("path = %q", resp.GetPath())
+	}
+	var names []string
+	byName := map[string]*pb.SnapshotFileEntry{}
+	for _, e := range resp.GetEntries() {
+		names = append(names, e.GetName())
+		byName[e.GetName()] = e
+	}
+	if got := strings.Join(names, ","); got != "hostetc,notes.txt,passwd,todo.txt" {
+		t.Fatalf("entries = %s", got)
+	}
+	notes := byName["notes.txt"]
+	if notes.GetType() != pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_FILE || notes.GetSizeBytes() != 17 || notes.GetMode() != 0o640 {
+		t.Errorf("notes.txt = %+v", notes)
+	}
+	if l := byName["passwd"]; l.GetType() != pb.SnapshotFileType_SNAPSHOT_FILE_TYPE_SYMLINK || l.GetLinkTarget() != "/etc/passwd" {
+		t.Errorf("passwd = %+v, want a symlink reported as itself", l)
+	}
+}
+
+func TestReadContainerSnapshotFile_ReadsARange(t *testing.T) {
+	s, _ := browseFixture(t)
+
+	resp, err := s.ReadContainerSnapshotFile(readCtx("alice"), &pb.ReadContainerSnapshotFileRequest{
+		Username: "alice", Snapshot: "nightly", Path: "/home/alice/notes.txt", Offset: 9, Length: 3,
+	})
+	if err != nil {
+		t.Fatalf("ReadContainerSnapshotFile: %v", err)
+	}
+	if string(resp.GetContent()) != "the" || resp.GetOffset() != 9 || resp.GetSizeBytes() != 17 || resp.GetEof() {
+		t.Errorf("resp = %+v", resp)
+	}
+
+	resp, err = s.ReadContainerSnapshotFile(readCtx("alice"), &pb.ReadContainerSnapshotFileRequest{
+		Username: "alice", Snapshot: "nightly", Path: "/home/alice/notes.txt", Offset: 9,
+	})
+	if err != nil {
+		t.Fatalf("ReadContainerSnapshotFile: %v", err)
+	}
+	if string(resp.GetContent()) != "the milk" || !resp.GetEof() {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+// The daemon reads the snapshot as host root, so no path a tenant can write
+// into their box may resolve to a host file.
+func TestReadContainerSnapshotFile_StaysInsideTheBox(t *testing.T) {
+	s, _ := browseFixture(t)
+
+	for _, tc := range []struct {
+		path string
+		code codes.Code
+	}{
+		{"/home/alice/passwd", codes.InvalidArgument},         // a symlink out
+		{"/home/alice/hostetc/passwd", codes.InvalidArgument}, // through one
+		{"/../../../../../../etc/passwd", codes.NotFound},     // ".." stops at the box's root
+		{"/home/alice", codes.InvalidArgument},                // not a file
+	} {
+		resp, err := s.ReadContainerSnapshotFile(readCtx("alice"), &pb.ReadContainerSnapshotFileRequest{
+			Username: "alice", Snapshot: "nightly", Path: tc.path,
+		})
+		if status.Code(err) != tc.code {
+			t.Errorf("%s: err = %v, want %v", tc.path, err, tc.code)
+		}
+		if len(resp.GetContent()) != 0 {
+			t.Errorf("%s: returned %q", tc.path, resp.GetContent())
+		}
+	}
+}
+
+func TestSnapshotBrowse_RefusesAnUnavailableKey(t *testing.T) {
+	s, z := browseFixture(t)
+	z.stdout["get"] = string(zfscrypt.KeyUnavailable)
+
+	_, err := s.ListContainerSnapshotFiles(readCtx("alice"), &pb.ListContainerSnapshotFilesRequest{
+		Username: "alice", Snapshot: "nightly",
+	})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "key custody") {
+		t.Fatalf("err = %v, want FailedPrecondition naming key custody", err)
+	}
+}
+
+func TestSnapshotBrowse_RefusesAStoppedBox(t *testing.T) {
+	s, z := browseFixture(t)
+	z.mounted = "no"
+
+	_, err := s.DiffContainerSnapshots(readCtx("alice"), &pb.DiffContainerSnapshotsRequest{
+		Username: "alice", FromSnapshot: "nightly",
+	})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "Start it") {
+		t.Fatalf("err = %v, want FailedPrecondition saying to start the box", err)
+	}
+	if z.ran("diff ") {
+		t.Error("ran zfs diff on an unmounted dataset")
+	}
+}
+
+// Authorization is the snapshot verbs' own: another tenant's box is refused
+// before ZFS is asked anything.
+func TestSnapshotBrowse_Refusals(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		call func(*ContainerServer) error
+		code codes.Code
+	}{
+		{"another tenant's box", func(s *ContainerServer) error {
+			_, err := s.ListContainerSnapshotFiles(readCtx("bob"), &pb.ListContainerSnapshotFilesRequest{
+				Username: "alice", Snapshot: "nightly",
+			})
+			return err
+		}, codes.PermissionDenied},
+		{"another tenant's diff", func(s *ContainerServer) error {
+			_, err := s.DiffContainerSnapshots(readCtx("bob"), &pb.DiffContainerSnapshotsRequest{
+				Username: "alice", FromSnapshot: "nightly",
+			})
+			return err
+		}, codes.PermissionDenied},
+		{"no snapshot", func(s *ContainerServer) error {
+			_, err := s.ReadContainerSnapshotFile(readCtx("alice"), &pb.ReadContainerSnapshotFileRequest{
+				Username: "alice", Path: "/etc/hostname",
+			})
+			return err
+		}, codes.InvalidArgument},
+		{"an Incus-managed snapshot", func(s *ContainerServer) error {
+			_, err := s.DiffContainerSnapshots(readCtx("alice"), &pb.DiffContainerSnapshotsRequest{
+				Username: "alice", FromSnapshot: "nightly", ToSnapshot: "snapshot-sync0",
+			})
+			return err
+		}, codes.InvalidArgument},
+		{"a negative offset", func(s *ContainerServer) error {
+			_, err := s.ReadContainerSnapshotFile(readCtx("alice"), &pb.ReadContainerSnapshotFileRequest{
+				Username: "alice", Snapshot: "nightly", Path: "/etc/hostname", Offset: -1,
+			})
+			return err
+		}, codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, z := browseFixture(t)
+			if err := tc.call(s); status.Code(err) != tc.code {
+				t.Fatalf("err = %v, want %v", err, tc.code)
+			}
+			if len(z.calls) != 0 {
+				t.Errorf("zfs was asked %v", z.calls)
+			}
+		})
+	}
+}
+
+func TestReadContainerSnapshotFile_MissingSnapshotIsNotFound(t *testing.T) {
+	s, _ := browseFixture(t)
+	_, err := s.ReadContainerSnapshotFile(readCtx("alice"), &pb.ReadContainerSnapshotFileRequest{
+		Username: "alice", Snapshot: "weekly", Path: "/etc/hostname",
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+}
+
+// zfs diff finds the paths; the handler keeps the box's files, splits a
+// rename, drops directories that only changed contents, and sizes each side.
+func TestDiffContainerSnapshots_AgainstTheLiveBox(t *testing.T) {
+	s, z := browseFixture(t)
+	mp := z.mountpoint
+	z.stdout["diff"] = strings.Join([]string{
+		"M\t/\t" + mp + "/rootfs/home/alice",
+		"-\tF\t" + mp + "/rootfs/home/alice/notes.txt",
+		"M\tF\t" + mp + "/rootfs/home/alice/todo.txt",
+		"R\tF\t" + mp + "/rootfs/etc/hostname\t" + mp + "/rootfs/home/alice/new.txt",
+		"M\tF\t" + mp + "/backup.yaml",
+	}, "\n") + "\n"
+
+	resp, err := s.DiffContainerSnapshots(readCtx("alice"), &pb.DiffContainerSnapshotsRequest{
+		Username: "alice", FromSnapshot: "nightly",
+	})
+	if err != nil {
+		t.Fatalf("DiffContainerSnapshots: %v", err)
+	}
+	if !z.ran("diff -H -F " + browseDataset + "@nightly " + browseDataset) {
+		t.Errorf("zfs calls = %v", z.calls)
+	}
+
+	var got []string
+	for _, c := range resp.GetChanges() {
+		got = append(got, fmt.Sprintf("%s %s %d %d", c.GetPath(),
+			strings.TrimPrefix(c.GetKind().String(), "SNAPSHOT_CHANGE_KIND_"), c.GetOldSizeBytes(), c.GetNewSizeBytes()))
+	}
+	want := []string{
+		"/etc/hostname DELETED 5 0",
+		"/home/alice/new.txt ADDED 0 5",
+		"/home/alice/notes.txt DELETED 17 0",
+		"/home/alice/todo.txt MODIFIED 3 13",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if resp.GetAdded() != 1 || resp.GetModified() != 1 || resp.GetDeleted() != 2 || resp.GetTruncated() {
+		t.Errorf("counts = +%d ~%d -%d truncated=%v", resp.GetAdded(), resp.GetModified(), resp.GetDeleted(), resp.GetTruncated())
+	}
+}
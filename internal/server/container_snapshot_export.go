@@ -0,0 +1,497 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/pkg/core/backup"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/snapexport"
+	"github.com/footprintai/containarium/pkg/core/zfscrypt"
+	"github.com/footprintai/containarium/pkg/core/zfskey"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Off-host snapshot export (#1160d).
+//
+// A snapshot shares blocks with the live dataset and dies with the pool, so
+// until now nothing here survived losing the host — CreateContainerSnapshot
+// says as much in its response. Export ships a snapshot as a raw `zfs send`
+// stream to an object store or a mounted directory (pkg/core/snapexport);
+// import receives the chain into a new box on any backend in the pool.
+//
+// Encrypted containers stay encrypted end to end. The stream is the on-disk
+// ciphertext and the target never holds a key; a restore needs the tenant's
+// key from key custody, exactly as starting the original box would, and the
+// received dataset is re-parented under the restoring host's tenant
+// encryptionroot (zfscrypt.ReplaceWithReceived) so it starts like any other.
+//
+// The restore builds its box the way a fork does — an empty instance shaped
+// like the exported one, its root volume swapped for the received dataset —
+// and shares the fork's tail: identity, SSH keys, secrets, jump account.
+
+// snapshotExportSubdir is where, under the backup directory, streams are
+// staged and each chain's local copy is kept.
+const snapshotExportSubdir = "snapshot-exports"
+
+// snapshotExportFileRootEnv names the directory file:// targets must lie
+// under — typically an NFS mount. Unset, file:// targets are disabled.
+const snapshotExportFileRootEnv = "CONTAINARIUM_SNAPSHOT_EXPORT_FILE_ROOT"
+
+// SetSnapshotExport wires the export half of the snapshot surface. Call
+// after SetSnapshotForking, whose host-access hook restores share; a daemon
+// without it answers both RPCs with FailedPrecondition.
+func (s *ContainerServer) SetSnapshotExport(
+	shapeOf func(containerName string, dropEnv []string) (*incus.InstanceShape, error),
+	shellFromShape func(*incus.InstanceShape, incus.ForkSpec) error,
+) {
+	if s.snapshots == nil || shapeOf == nil || shellFromShape == nil {
+		return
+	}
+	s.snapshots.exporter = newSnapshotExporter(s.snapshots.zfs)
+	s.snapshots.shapeOf = shapeOf
+	s.snapshots.shellFromShape = shellFromShape
+}
+
+// newSnapshotExporter stages under the backup directory and ships with the
+// same uploaders database backups use, constructed the same best-effort way:
+// a target whose uploader is unavailable is refused, not the daemon.
+func newSnapshotExporter(zfs *zfscrypt.Manager) *snapexport.Exporter {
+	dir := os.Getenv("CONTAINARIUM_BACKUP_DIR")
+	if dir == "" {
+		dir = defaultBackupDir
+	}
+	e := snapexport.NewExporter(zfs, filepath.Join(dir, snapshotExportSubdir))
+	if root := os.Getenv(snapshotExportFileRootEnv); root != "" {
+		e.SetUploader("file", snapexport.DirUploader{Root: root})
+	} else {
+		log.Printf("[snapshot] %s is not set; file:// export targets disabled", snapshotExportFileRootEnv)
+	}
+	if u, err := backup.NewGcloudUploader(); err != nil {
+		log.Printf("[snapshot] GCS uploader unavailable (%v); gs:// export targets disabled", err)
+	} else {
+		e.SetUploader("gs", u)
+	}
+	if u, err := backup.NewS3Uploader(backup.S3ConfigFromEnv()); err != nil {
+		log.Printf("[snapshot] S3 uploader unavailable (%v); s3:// export targets disabled", err)
+	} else {
+		e.SetUploader("s3", u)
+	}
+	return e
+}
+
+// ExportContainerSnapshot ships a snapshot to an off-host target.
+func (s *ContainerServer) ExportContainerSnapshot(ctx context.Context, req *pb.ExportContainerSnapshotRequest) (*pb.ExportContainerSnapshotResponse, error) {
+	ops, dataset, err := s.snapshotDatasetFor(ctx, req.GetUsername(), auth.ScopeContainersWrite)
+	if err != nil {
+		return nil, err
+	}
+	if ops.exporter == nil {
+		return nil, status.Error(codes.FailedPrecondition,
+			"exporting snapshots needs the Incus backend, which this daemon is not configured with")
+	}
+	if req.GetSnapshot() == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot name is required")
+	}
+	if err := refuseIncusManaged("export", req.GetSnapshot()); err != nil {
+		return nil, err
+	}
+	if req.GetTarget() == "" {
+		return nil, status.Error(codes.InvalidArgument, "target is required (s3://, gs:// or file:// URI)")
+	}
+	if err := authorizeExportTarget(ctx, req.GetTarget()); err != nil {
+		return nil, err
+	}
+
+	names, err := ops.zfs.ListSnapshots(ctx, dataset)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if !slices.Contains(names, dataset+"@"+req.GetSnapshot()) {
+		return nil, status.Errorf(codes.NotFound, "container %s has no snapshot %q", req.GetUsername(), req.GetSnapshot())
+	}
+	short := make([]string, len(names))
+	for i, n := range names {
+		short[i] = snapshotShortName(n)
+	}
+
+	containerName := req.GetUsername() + "-container"
+	encrypted, tenant, err := s.exportEncryption(ctx, dataset, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The shape travels with the chain; its secrets must not. They are
+	// stamped afresh for whoever the chain is restored as.
+	dropEnv, err := s.sourceSecretNames(ctx, req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"cannot determine which of %s's environment are secrets, so refusing to export its "+
+				"configuration: %v", req.GetUsername(), err)
+	}
+	shape, err := ops.shapeOf(containerName, dropEnv)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "read the definition of %s: %v", containerName, err)
+	}
+	rawShape, err := json.Marshal(shape)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encode the definition of %s: %v", containerName, err)
+	}
+
+	chain, link, err := ops.exporter.Export(ctx, snapexport.ExportOptions{
+		Target:    req.GetTarget(),
+		Container: containerName,
+		Username:  req.GetUsername(),
+		Tenant:    tenant,
+		Encrypted: encrypted,
+		Dataset:   dataset,
+		Snapshot:  req.GetSnapshot(),
+		Snapshots: short,
+		Full:      req.GetFull(),
+		Shape:     rawShape,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "export %s@%s: %v", req.GetUsername(), req.GetSnapshot(), err)
+	}
+
+	kind := "full"
+	if link.Base != "" {
+		kind = "incremental from " + link.Base
+	}
+	log.Printf("[snapshot] exported %s@%s to %s (%s, %d bytes)", dataset, req.GetSnapshot(), link.Object, kind, link.SizeBytes)
+	resp := &pb.ExportContainerSnapshotResponse{
+		Export:  snapshotExportToProto(*link),
+		Message: fmt.Sprintf("exported %s@%s to %s (%s)", req.GetUsername(), req.GetSnapshot(), link.Object, kind),
+	}
+	for _, l := range chain.Links {
+		resp.Chain = append(resp.Chain, snapshotExportToProto(l))
+	}
+	return resp, nil
+}
+
+// authorizeExportTarget allows file:// targets to admins only. They are
+// read and written as the daemon on the host's own filesystem; the
+// exporter confines them to the operator's directory, but which of the
+// chains there a caller may write or restore from is not something a
+// tenant's username can answer.
+func authorizeExportTarget(ctx context.Context, target string) error {
+	if !strings.HasPrefix(target, "file:") {
+		return nil
+	}
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return status.Error(codes.PermissionDenied, "file:// targets are for admins; use an s3:// or gs:// target")
+	}
+	return nil
+}
+
+// exportEncryption reports whether the dataset is encrypted and, if so, the
+// tenant whose key it is under — which a restore has to ask key custody for.
+func (s *ContainerServer) exportEncryption(ctx context.Context, dataset, containerName string) (bool, string, error) {
+	if _, err := s.snapshots.zfs.EncryptionRoot(ctx, dataset); errors.Is(err, zfscrypt.ErrNotEncrypted) {
+		return false, "", nil
+	} else if err != nil {
+		return false, "", status.Errorf(codes.FailedPrecondition,
+			"cannot determine whether %s is encrypted, so refusing to export it: %v", containerName, err)
+	}
+	if h := s.encryption; h.enabled() {
+		if ref, ok, err := h.refs.GetKeyRef(containerName); err != nil {
+			return false, "", status.Errorf(codes.FailedPrecondition, "read key reference of %s: %v", containerName, err)
+		} else if ok {
+			return true, tenantFromRef(ref, containerName), nil
+		}
+	}
+	// Exported anyway it would be ciphertext nothing could name the key
+	// for — a backup that cannot be restored.
+	return false, "", status.Errorf(codes.FailedPrecondition,
+		"%s is encrypted but this daemon has no record of its key, so an export could never be restored", containerName)
+}
+
+// importTenants is whom a restored box belongs to.
+type importTenants struct {
+	// policy is the tenant the box is stamped with and policed as.
+	policy string
+	// key is the tenant whose key an encrypted chain is under.
+	key string
+	// cloudOrgID is the source box's org label, if it is still here.
+	cloudOrgID string
+}
+
+// restoreTenants decides a restore's tenants from what the daemon already
+// trusts — the caller was authorized for srcUser, and srcUser's box, if it
+// still exists here, says the rest exactly as it would to CloneContainer.
+//
+// Once the box (or its host) is gone, an encrypted chain's tenant is all
+// that says which key it is under, and a box created with a tenant_id is not
+// under its username's. Anyone who can write the target wrote the manifest,
+// so its tenant is used only if the caller could act as that tenant anyway.
+func (s *ContainerServer) restoreTenants(ctx context.Context, srcUser, srcName string, chain *snapexport.Chain) (importTenants, error) {
+	t := importTenants{policy: resolveTenant("", "", srcName), key: srcUser}
+	src, err := s.boxes().Get(ctx, box.BoxRef{Tenant: srcUser})
+	if err != nil || src == nil {
+		if !chain.Encrypted || chain.Tenant == "" || chain.Tenant == srcUser {
+			return t, nil
+		}
+		if err := auth.AuthorizeTenant(ctx, chain.Tenant); err != nil {
+			return t, err
+		}
+		t.key, t.cloudOrgID = chain.Tenant, chain.Tenant
+		t.policy = resolveTenant("", chain.Tenant, srcName)
+		return t, nil
+	}
+	t.cloudOrgID = src.Labels[cloudOrgIDLabel]
+	t.policy = resolveTenant("", t.cloudOrgID, srcName)
+	if h := s.encryption; h.enabled() {
+		ref, ok, err := h.refs.GetKeyRef(srcName)
+		if err != nil {
+			return t, status.Errorf(codes.FailedPrecondition, "read key reference of %s: %v", srcName, err)
+		}
+		if ok {
+			t.key = tenantFromRef(ref, srcName)
+		}
+	}
+	return t, nil
+}
+
+func snapshotExportToProto(l snapexport.Link) *pb.SnapshotExport {
+	return &pb.SnapshotExport{
+		Snapshot:     l.Snapshot,
+		BaseSnapshot: l.Base,
+		Object:       l.Object,
+		SizeBytes:    l.SizeBytes,
+		Sha256:       l.SHA256,
+		ExportedAt:   l.ExportedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ImportContainerSnapshot creates a box from an exported snapshot chain.
+func (s *ContainerServer) ImportContainerSnapshot(ctx context.Context, req *pb.ImportContainerSnapshotRequest) (*pb.ImportContainerSnapshotResponse, error) {
+	dstUser := req.GetUsername()
+	srcUser := req.GetSourceUsername()
+	if srcUser == "" {
+		srcUser = dstUser
+	}
+	if dstUser == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.RequireScope(ctx, auth.ScopeContainersWrite); err != nil {
+		return nil, err
+	}
+	// Restoring a chain reads the exported box's data, and creates a box
+	// acted on under its own name from then on; the caller has to be
+	// allowed both.
+	if err := auth.AuthorizeTenant(ctx, dstUser); err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeTenant(ctx, srcUser); err != nil {
+		return nil, err
+	}
+	if err := container.ValidateUserContainerName(dstUser); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid username: %v", err)
+	}
+	if req.GetSource() == "" {
+		return nil, status.Error(codes.InvalidArgument, "source is required (the target the chain was exported to)")
+	}
+	if err := authorizeExportTarget(ctx, req.GetSource()); err != nil {
+		return nil, err
+	}
+	for _, key := range req.GetSshKeys() {
+		if err := container.ValidateSSHPublicKey(key); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ssh key: %v", err)
+		}
+	}
+
+	if backendID := req.GetBackendId(); backendID != "" && s.peerPool != nil && backendID != s.peerPool.LocalBackendID() {
+		peer := s.peerPool.Get(backendID)
+		if peer == nil {
+			return nil, status.Errorf(codes.NotFound, "backend %q not found", backendID)
+		}
+		if !peer.Healthy {
+			return nil, status.Errorf(codes.Unavailable, "backend %q is not healthy", backendID)
+		}
+		resp, err := peer.ForwardImportContainerSnapshot(extractAuthToken(ctx), req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "restore on backend %q: %v", backendID, err)
+		}
+		return resp, nil
+	}
+
+	ops := s.snapshots
+	if ops == nil || ops.exporter == nil {
+		return nil, status.Error(codes.FailedPrecondition,
+			"restoring exported snapshots needs a ZFS storage backend on Incus, which this daemon is not configured with")
+	}
+	if srcUser != dstUser && ops.forkIdentity == nil {
+		return nil, status.Error(codes.FailedPrecondition,
+			"restoring under another username needs the Incus backend, which this daemon is not configured with")
+	}
+
+	srcName, dstName := srcUser+"-container", dstUser+"-container"
+	chain, err := ops.exporter.FetchChain(req.GetSource(), srcName)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+	if chain.Username != srcUser {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"the chain at %s/%s was exported for user %q, not %q", req.GetSource(), srcName, chain.Username, srcUser)
+	}
+	plan, err := chain.Plan(req.GetSnapshot())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+	restored := plan[len(plan)-1].Snapshot
+	var shape incus.InstanceShape
+	if len(chain.Shape) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "the chain for %s carries no container definition to restore", srcName)
+	}
+	if err := json.Unmarshal(chain.Shape, &shape); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the chain for %s has a corrupt container definition: %v", srcName, err)
+	}
+
+	if st, err := s.boxes().Get(ctx, box.BoxRef{Tenant: dstUser}); err == nil && st != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "container %s already exists", dstName)
+	}
+
+	tenants, err := s.restoreTenants(ctx, srcUser, srcName, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// An encrypted chain goes into its tenant's encrypted pool, and the key
+	// is resolved before anything is created: without it the received
+	// dataset could never be adopted, only discarded.
+	pool := ""
+	var ref zfskey.KeyRef
+	var key zfskey.Key
+	if chain.Encrypted {
+		h := s.encryption
+		if !h.enabled() {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"%s was exported encrypted and this daemon has no encryption configured to restore it into", srcName)
+		}
+		// Against a box still here, the manifest's tenant is only checked,
+		// never used: naming another tenant there would otherwise load that
+		// tenant's key.
+		if chain.Tenant != tenants.key {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"the chain for %s names tenant %q as its key holder, but %s belongs to tenant %q",
+				srcName, chain.Tenant, srcUser, tenants.key)
+		}
+		if pool, ref, err = h.EnsureTenantStorage(ctx, tenants.key); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "%v", err)
+		}
+		if key, err = h.keyFor(ctx, tenants.key, ref); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "cannot load the encryption key for tenant %s: %v", tenants.key, err)
+		}
+	}
+
+	// The shape is read from the target, not from this daemon: whoever can
+	// write there wrote it. Only what a tenant could have asked for at
+	// create is applied, and anything else refuses the restore before an
+	// instance exists.
+	restoreShape, err := incus.RestoreShape(&shape, pool)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "refusing to restore %s: %v", srcName, err)
+	}
+
+	labels := map[string]string{}
+	for k, v := range restoreShape.Config {
+		if name, ok := strings.CutPrefix(k, incus.LabelPrefix); ok {
+			labels[name] = v
+		}
+	}
+	labels = forkLabels(labels, nil)
+	// The org is tenancy, not a label: it comes from the daemon's own box
+	// if it has one, or the chain's tenant the caller was authorized for,
+	// and is dropped otherwise.
+	delete(labels, cloudOrgIDLabel)
+	if tenants.cloudOrgID != "" {
+		labels[cloudOrgIDLabel] = tenants.cloudOrgID
+	}
+	spec := incus.ForkSpec{
+		Name:        dstName,
+		StoragePool: pool,
+		Labels:      labels,
+		Tenant:      tenants.policy,
+	}
+	if err := ops.shellFromShape(restoreShape, spec); err != nil {
+		return nil, status.Errorf(codes.Internal, "create %s: %v", dstName, err)
+	}
+
+	// From here on a failure leaves an instance behind; abandon removes it.
+	abandon := func(code codes.Code, format string, args ...any) error {
+		if err := s.boxes().Delete(ctx, box.BoxRef{Tenant: dstUser}, true); err != nil {
+			log.Printf("[snapshot] failed to remove abandoned restore %s: %v", dstName, err)
+		}
+		return status.Errorf(code, format, args...)
+	}
+
+	target, err := ops.datasetFor(dstName, pool)
+	if err != nil {
+		return nil, abandon(codes.Internal, "resolve dataset for %s: %v", dstName, err)
+	}
+	received := target + "_import"
+	discard := func() {
+		if err := ops.zfs.DiscardReceived(ctx, path.Dir(target), received); err != nil {
+			log.Printf("[snapshot] failed to discard %s: %v", received, err)
+		}
+	}
+	if err := ops.exporter.Receive(ctx, req.GetSource(), chain, plan, received); err != nil {
+		discard()
+		return nil, abandon(codes.Internal, "%v", err)
+	}
+	if err := ops.zfs.ReplaceWithReceived(ctx, received, target, key); err != nil {
+		discard()
+		if errors.Is(err, zfscrypt.ErrEncryptionMismatch) {
+			return nil, abandon(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, abandon(codes.Internal, "swap the restored volume into %s: %v", dstName, err)
+	}
+	if chain.Encrypted {
+		if err := s.encryption.RecordPlacement(dstName, ref, pool); err != nil {
+			return nil, abandon(codes.Internal, "%v", err)
+		}
+	}
+
+	if err := s.boxes().Start(ctx, box.BoxRef{Tenant: dstUser}); err != nil {
+		return nil, abandon(codes.Internal, "start %s: %v", dstName, err)
+	}
+	if srcUser != dstUser {
+		if err := ops.forkIdentity(dstName, srcUser, dstUser); err != nil {
+			return nil, abandon(codes.Internal, "%v", err)
+		}
+	}
+	if len(req.GetSshKeys()) > 0 {
+		if err := s.boxes().SetAuthorizedKeys(ctx, box.BoxRef{Tenant: dstUser}, req.GetSshKeys()); err != nil {
+			return nil, abandon(codes.Internal, "set ssh keys on %s: %v", dstName, err)
+		}
+	}
+
+	protoContainer, sshCommand, err := s.announceBox(ctx, ops, dstUser, dstName, req.GetSshKeys(), "import_container_snapshot")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[snapshot] restored %s@%s from %s into %s (%d stream(s), pool=%q)",
+		srcName, restored, req.GetSource(), dstName, len(plan), pool)
+	return &pb.ImportContainerSnapshotResponse{
+		Container:  protoContainer,
+		Message:    fmt.Sprintf("restored %s from %s@%s (%d stream(s))", dstName, srcUser, restored, len(plan)),
+		SshCommand: sshCommand,
+		Snapshot:   restored,
+	}, nil
+}
@@ -0,0 +1,467 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/pkg/core/box"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/snapexport"
+	"github.com/footprintai/containarium/pkg/core/zfscrypt"
+	"github.com/footprintai/containarium/pkg/core/zfskey"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Off-host export and restore (#1160d). The streams themselves are
+// snapexport's and zfscrypt's to test; these pin what the handlers decide —
+// which dataset, which key, which refusals — and that a refused restore
+// leaves nothing behind.
+
+// exportZFS is a cloneZFS that can also stream: sends write a marker naming
+// their args, receives are recorded as calls like any other.
+type exportZFS struct {
+	*cloneZFS
+}
+
+func (e *exportZFS) RunStream(_ context.Context, stdin io.Reader, stdout io.Writer, args ...string) (string, error) {
+	e.calls = append(e.calls, strings.Join(args, " "))
+	if stdin != nil {
+		_, _ = io.Copy(io.Discard, stdin)
+	}
+	_, err := io.WriteString(stdout, strings.Join(args, " "))
+	return "", err
+}
+
+func newExportZFS() *exportZFS {
+	z := &exportZFS{cloneZFS: &cloneZFS{zfsFake: newZFSFake()}}
+	delete(z.errs, "list")
+	// An unencrypted dataset: EncryptionRoot reads "-".
+	z.stdout["get"] = "-"
+	return z
+}
+
+const exportDataset = "tank/default/containers/alice-container"
+
+// exportFixture is alice's box with snapshots a and b, wired to export to a
+// file:// target under the test's temp dir. file:// targets are an admin's,
+// so the tests export as one.
+func exportFixture(t *testing.T) (*ContainerServer, *exportZFS, string) {
+	t.Helper()
+	z := newExportZFS()
+	z.stdout["list"] = exportDataset + "@a\n" + exportDataset + "@b\n"
+	s := snapshotFixture(t, z.zfsFake, nil)
+	s.snapshots.zfs = zfscrypt.NewManager(z)
+	root := t.TempDir()
+	s.snapshots.exporter = snapexport.NewExporter(s.snapshots.zfs, t.TempDir())
+	s.snapshots.exporter.SetUploader("file", snapexport.DirUploader{Root: root})
+	s.snapshots.shapeOf = func(string, []string) (*incus.InstanceShape, error) {
+		return &incus.InstanceShape{
+			Config: map[string]string{
+				"limits.cpu":                              "2",
+				incus.LabelPrefix + "role":                "agent",
+				incus.LabelPrefix + cloudContainerIDLabel: "cld-123",
+			},
+			Profiles: []string{"default"},
+		}, nil
+	}
+	return s, z, "file://" + root
+}
+
+func TestExportContainerSnapshot_IsIncrementalFromThePreviousExport(t *testing.T) {
+	s, z, target := exportFixture(t)
+
+	if _, err := s.ExportContainerSnapshot(adminWriteCtx(), &pb.ExportContainerSnapshotRequest{
+		Username: "alice", Snapshot: "a", Target: target,
+	}); err != nil {
+		t.Fatalf("export a: %v", err)
+	}
+	resp, err := s.ExportContainerSnapshot(adminWriteCtx(), &pb.ExportContainerSnapshotRequest{
+		Username: "alice", Snapshot: "b", Target: target,
+	})
+	if err != nil {
+		t.Fatalf("export b: %v", err)
+	}
+
+	if !z.ran("send -w " + exportDataset + "@a") {
+		t.Errorf("first export was not a full raw send:\n%v", z.calls)
+	}
+	if !z.ran("send -w -i " + exportDataset + "@a " + exportDataset + "@b") {
+		t.Errorf("second export was not incremental from the first:\n%v", z.calls)
+	}
+	if resp.GetExport().GetBaseSnapshot() != "a" || len(resp.GetChain()) != 2 {
+		t.Errorf("response = %v", resp)
+	}
+}
+
+func TestExportContainerSnapshot_Refusals(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ctx  context.Context
+		req  *pb.ExportContainerSnapshotRequest
+		code codes.Code
+	}{
+		"another tenant's box": {writeCtx("bob"), &pb.ExportContainerSnapshotRequest{Username: "alice", Snapshot: "a", Target: "file:///x"}, codes.PermissionDenied},
+		"no target":            {writeCtx("alice"), &pb.ExportContainerSnapshotRequest{Username: "alice", Snapshot: "a"}, codes.InvalidArgument},
+		"unknown snapshot":     {adminWriteCtx(), &pb.ExportContainerSnapshotRequest{Username: "alice", Snapshot: "zz", Target: "file:///x"}, codes.NotFound},
+		"incus's snapshot":     {adminWriteCtx(), &pb.ExportContainerSnapshotRequest{Username: "alice", Snapshot: "snapshot-sync0", Target: "file:///x"}, codes.InvalidArgument},
+		"a tenant's file://":   {writeCtx("alice"), &pb.ExportContainerSnapshotRequest{Username: "alice", Snapshot: "a", Target: "file:///x"}, codes.PermissionDenied},
+		"outside the root":     {adminWriteCtx(), &pb.ExportContainerSnapshotRequest{Username: "alice", Snapshot: "a", Target: "file:///etc"}, codes.FailedPrecondition},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, z, _ := exportFixture(t)
+			_, err := s.ExportContainerSnapshot(tc.ctx, tc.req)
+			if status.Code(err) != tc.code {
+				t.Fatalf("err = %v, want %v", err, tc.code)
+			}
+			if z.ran("send") {
+				t.Errorf("sent anyway:\n%v", z.calls)
+			}
+		})
+	}
+}
+
+// Encrypted, with no key record, the export would be ciphertext nothing can
+// name the key for.
+func TestExportContainerSnapshot_RefusesAnEncryptedBoxWithNoKeyRecord(t *testing.T) {
+	s, z, target := exportFixture(t)
+	z.stdout["get"] = "tank/tenants/alice"
+
+	_, err := s.ExportContainerSnapshot(adminWriteCtx(), &pb.ExportContainerSnapshotRequest{
+		Username: "alice", Snapshot: "a", Target: target,
+	})
+	if status.Code(err) != codes.FailedPrecondition || z.ran("send") {
+		t.Fatalf("err = %v, calls = %v", err, z.calls)
+	}
+}
+
+// importFixture exports alice@a and @b to a target, then returns a fresh
+// daemon — another host — to restore on.
+func importFixture(t *testing.T) (*ContainerServer, *exportZFS, *forkBoxes, *[]incus.ForkSpec, *forkRecorder, string) {
+	t.Helper()
+	src, _, target := exportFixture(t)
+	for _, snap := range []string{"a", "b"} {
+		if _, err := src.ExportContainerSnapshot(adminWriteCtx(), &pb.ExportContainerSnapshotRequest{
+			Username: "alice", Snapshot: snap, Target: target,
+		}); err != nil {
+			t.Fatalf("export %s: %v", snap, err)
+		}
+	}
+
+	received := "tank/default/containers/bob-container_import"
+	dst := "tank/default/containers/bob-container"
+	z := newExportZFS()
+	z.props = received + "\tencryptionroot\t-\n" +
+		dst + "\tencryptionroot\t-\n" +
+		dst + "\tmountpoint\t/var/lib/incus/storage-pools/default/containers/bob-container\n"
+
+	boxes := &forkBoxes{exists: map[string]*box.BoxStatus{}, keys: map[string][]string{}}
+	rec := &forkRecorder{}
+	var shells []incus.ForkSpec
+	mgr := zfscrypt.NewManager(z)
+	exporter := snapexport.NewExporter(mgr, t.TempDir())
+	exporter.SetUploader("file", snapexport.DirUploader{Root: strings.TrimPrefix(target, "file://")})
+	s := &ContainerServer{
+		boxBackend: boxes,
+		emitter:    events.NewEmitter(events.GetBus()),
+		snapshots: &snapshotOps{
+			zfs: mgr,
+			datasetFor: func(containerName, pool string) (string, error) {
+				if pool == "" {
+					pool = "default"
+				}
+				return "tank/" + pool + "/containers/" + containerName, nil
+			},
+			exporter: exporter,
+			shellFromShape: func(_ *incus.InstanceShape, spec incus.ForkSpec) error {
+				shells = append(shells, spec)
+				return nil
+			},
+			forkIdentity: func(containerName, oldUser, newUser string) error {
+				rec.identities = append(rec.identities, [3]string{containerName, oldUser, newUser})
+				return nil
+			},
+		},
+	}
+	return s, z, boxes, &shells, rec, target
+}
+
+func TestImportContainerSnapshot_RestoresTheChainAsANewBox(t *testing.T) {
+	s, z, boxes, shells, rec, target := importFixture(t)
+
+	resp, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "bob", SourceUsername: "alice", Source: target,
+	})
+	if err != nil {
+		t.Fatalf("ImportContainerSnapshot: %v", err)
+	}
+	if resp.GetSnapshot() != "b" {
+		t.Errorf("restored %q, want the newest export", resp.GetSnapshot())
+	}
+
+	if len(*shells) != 1 || (*shells)[0].Name != "bob-container" {
+		t.Fatalf("shells = %+v", *shells)
+	}
+	if labels := (*shells)[0].Labels; labels["role"] != "agent" || labels[cloudContainerIDLabel] != "" {
+		t.Errorf("labels = %v — the source's cloud identity must not come along", labels)
+	}
+	const received = "tank/default/containers/bob-container_import"
+	var recvs int
+	for _, c := range z.calls {
+		if c == "receive -u "+received {
+			recvs++
+		}
+	}
+	if recvs != 2 {
+		t.Errorf("received %d streams, want the full one and the increment:\n%v", recvs, z.calls)
+	}
+	if !z.ran("rename " + received + " tank/default/containers/bob-container") {
+		t.Errorf("restored volume not swapped in:\n%v", z.calls)
+	}
+	if len(boxes.started) != 1 || len(rec.identities) != 1 || rec.identities[0] != [3]string{"bob-container", "alice", "bob"} {
+		t.Errorf("started = %v, identities = %v", boxes.started, rec.identities)
+	}
+}
+
+func TestImportContainerSnapshot_Refusals(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ctx  context.Context
+		req  func(target string) *pb.ImportContainerSnapshotRequest
+		code codes.Code
+	}{
+		"another tenant's chain": {writeCtx("bob"), func(target string) *pb.ImportContainerSnapshotRequest {
+			return &pb.ImportContainerSnapshotRequest{Username: "bob", SourceUsername: "alice", Source: target}
+		}, codes.PermissionDenied},
+		"a tenant's file://": {writeCtx("alice"), func(target string) *pb.ImportContainerSnapshotRequest {
+			return &pb.ImportContainerSnapshotRequest{Username: "alice", Source: target}
+		}, codes.PermissionDenied},
+		"no exported chain": {adminWriteCtx(), func(target string) *pb.ImportContainerSnapshotRequest {
+			return &pb.ImportContainerSnapshotRequest{Username: "bob", SourceUsername: "carol", Source: target}
+		}, codes.NotFound},
+		"unexported snapshot": {adminWriteCtx(), func(target string) *pb.ImportContainerSnapshotRequest {
+			return &pb.ImportContainerSnapshotRequest{Username: "bob", SourceUsername: "alice", Source: target, Snapshot: "zz"}
+		}, codes.NotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, z, _, shells, _, target := importFixture(t)
+			_, err := s.ImportContainerSnapshot(tc.ctx, tc.req(target))
+			if status.Code(err) != tc.code {
+				t.Fatalf("err = %v, want %v", err, tc.code)
+			}
+			if len(*shells) != 0 || z.ran("receive") {
+				t.Errorf("created anyway: shells = %v, calls = %v", *shells, z.calls)
+			}
+		})
+	}
+}
+
+func TestImportContainerSnapshot_RefusesAnExistingBox(t *testing.T) {
+	s, _, boxes, shells, _, target := importFixture(t)
+	boxes.exists["bob"] = &box.BoxStatus{Ref: box.BoxRef{Tenant: "bob"}}
+
+	_, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "bob", SourceUsername: "alice", Source: target,
+	})
+	if status.Code(err) != codes.AlreadyExists || len(*shells) != 0 {
+		t.Fatalf("err = %v, shells = %v", err, *shells)
+	}
+}
+
+// A receive that fails after the shell exists must take the shell and the
+// half-received dataset with it.
+func TestImportContainerSnapshot_AbandonsOnAFailedSwap(t *testing.T) {
+	s, z, boxes, _, _, target := importFixture(t)
+	z.props = strings.Replace(z.props, "_import\tencryptionroot\t-", "_import\tencryptionroot\ttank/x", 1)
+
+	_, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "bob", SourceUsername: "alice", Source: target,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v, want FailedPrecondition for an encryption mismatch", err)
+	}
+	if len(boxes.deleted) != 1 || !z.ran("destroy -r tank/default/containers/bob-container_import") {
+		t.Errorf("deleted = %v, calls = %v", boxes.deleted, z.calls)
+	}
+}
+
+// An encrypted chain can only go into an encrypted pool.
+func TestImportContainerSnapshot_EncryptedChainNeedsEncryption(t *testing.T) {
+	s, _, _, shells, _, target := importFixture(t)
+	markChainEncrypted(t, target, "alice-container")
+
+	_, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "alice", Source: target,
+	})
+	if status.Code(err) != codes.FailedPrecondition || len(*shells) != 0 {
+		t.Fatalf("err = %v, shells = %v", err, *shells)
+	}
+}
+
+// Against a box still on this daemon, the key holder the manifest names is
+// checked, never used: a manifest naming mallory must not get alice's restore
+// mallory's key or pool, even for an admin.
+func TestImportContainerSnapshot_RefusesAChainNamingAnotherTenant(t *testing.T) {
+	s, _, boxes, shells, _, target := importFixture(t)
+	boxes.exists["alice"] = &box.BoxStatus{Ref: box.BoxRef{Tenant: "alice"}}
+	p := &fakeKeyProvider{key: aKey(t)}
+	pools := newFakePools()
+	s.encryption = testHooksWith(t, newZFSFake(), p, &fakeRefStore{refs: map[string]zfskey.KeyRef{
+		"alice-container": {Scheme: zfskey.SchemeFile, URI: "/keys/alice.key", Metadata: map[string]string{"tenant": "alice"}},
+	}}, pools)
+	rewriteChain(t, target, "alice-container", func(c *snapexport.Chain) {
+		c.Encrypted, c.Tenant = true, "mallory"
+	})
+
+	_, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "bob", SourceUsername: "alice", Source: target,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v, want FailedPrecondition", err)
+	}
+	if len(*shells) != 0 || p.loadCall != 0 || p.wrapCall != 0 || len(pools.created) != 0 {
+		t.Errorf("acted on the manifest's tenant: shells = %v, loads = %d, wraps = %d, pools = %v",
+			*shells, p.loadCall, p.wrapCall, pools.created)
+	}
+}
+
+// With the box gone the manifest's tenant is all there is, and anyone who can
+// write the target wrote it: the caller has to be allowed to act as it.
+func TestRestoreTenants_NeedsTheCallerToActAsTheChainsTenant(t *testing.T) {
+	s := &ContainerServer{boxBackend: &forkBoxes{exists: map[string]*box.BoxStatus{}}}
+	chain := &snapexport.Chain{Username: "alice", Encrypted: true, Tenant: "mallory"}
+
+	if _, err := s.restoreTenants(writeCtx("alice"), "alice", "alice-container", chain); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("err = %v, want PermissionDenied", err)
+	}
+	got, err := s.restoreTenants(writeCtx("mallory"), "alice", "alice-container", chain)
+	if err != nil {
+		t.Fatalf("restoreTenants: %v", err)
+	}
+	if got.key != "mallory" || got.policy != "mallory" {
+		t.Errorf("tenants = %+v, want the chain's", got)
+	}
+}
+
+// A box created under a tenant_id is encrypted with that tenant's key, not its
+// username's. Restored after its host is gone, by a caller allowed to act as
+// that tenant, the chain's tenant is the only record of which key that is.
+func TestImportContainerSnapshot_RestoresUnderTheChainsTenantOnceTheBoxIsGone(t *testing.T) {
+	s, _, _, shells, _, target := importFixture(t)
+	p := &fakeKeyProvider{key: aKey(t)}
+	pools := newFakePools()
+	s.encryption = testHooksWith(t, newZFSFake(), p, &fakeRefStore{refs: map[string]zfskey.KeyRef{}}, pools)
+	rewriteChain(t, target, "alice-container", func(c *snapexport.Chain) {
+		c.Encrypted, c.Tenant = true, "acme"
+	})
+
+	_, _ = s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "alice", Source: target,
+	})
+	if len(p.wrapped) != 1 || p.wrapped[0] != "acme" {
+		t.Fatalf("wrapped = %v, want the chain's tenant acme", p.wrapped)
+	}
+	if len(*shells) != 1 {
+		t.Fatalf("shells = %v", *shells)
+	}
+	if spec := (*shells)[0]; spec.Tenant != "acme" || spec.Labels[cloudOrgIDLabel] != "acme" {
+		t.Errorf("tenant = %q, labels = %v, want acme", spec.Tenant, spec.Labels)
+	}
+}
+
+// A manifest's cloud org is tenancy and is not taken from the target.
+func TestImportContainerSnapshot_TakesTheTenantFromTheCallerNotTheManifest(t *testing.T) {
+	s, _, _, shells, _, target := importFixture(t)
+	rewriteChain(t, target, "alice-container", func(c *snapexport.Chain) {
+		var shape incus.InstanceShape
+		if err := json.Unmarshal(c.Shape, &shape); err != nil {
+			t.Fatal(err)
+		}
+		shape.Config[incus.LabelPrefix+cloudOrgIDLabel] = "mallory-org"
+		raw, err := json.Marshal(shape)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Shape = raw
+	})
+
+	if _, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+		Username: "bob", SourceUsername: "alice", Source: target,
+	}); err != nil {
+		t.Fatalf("ImportContainerSnapshot: %v", err)
+	}
+	if len(*shells) != 1 {
+		t.Fatalf("shells = %v", *shells)
+	}
+	if spec := (*shells)[0]; spec.Tenant != "alice" || spec.Labels[cloudOrgIDLabel] != "" {
+		t.Errorf("tenant = %q, labels = %v — taken from the manifest", spec.Tenant, spec.Labels)
+	}
+}
+
+// The shape is read from the target, so anyone who can write there can ask
+// for the host. A restore must refuse before there is an instance to clean up.
+func TestImportContainerSnapshot_RefusesAHostileManifest(t *testing.T) {
+	for name, hostile := range map[string]incus.InstanceShape{
+		"privileged": {Config: map[string]string{"security.privileged": "true"}},
+		"raw.lxc":    {Config: map[string]string{"raw.lxc": "lxc.apparmor.profile=unconfined"}},
+		"host root":  {Devices: map[string]map[string]string{"host": {"type": "disk", "source": "/", "path": "/mnt/host"}}},
+		"profile":    {Profiles: []string{"privileged"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, z, boxes, shells, _, target := importFixture(t)
+			rewriteChain(t, target, "alice-container", func(c *snapexport.Chain) {
+				raw, err := json.Marshal(hostile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				c.Shape = raw
+			})
+
+			_, err := s.ImportContainerSnapshot(adminWriteCtx(), &pb.ImportContainerSnapshotRequest{
+				Username: "bob", SourceUsername: "alice", Source: target,
+			})
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("err = %v, want FailedPrecondition", err)
+			}
+			if len(*shells) != 0 || len(boxes.started) != 0 || z.ran("receive") {
+				t.Errorf("created anyway: shells = %v, started = %v, calls = %v", *shells, boxes.started, z.calls)
+			}
+		})
+	}
+}
+
+// markChainEncrypted rewrites a file:// target's manifest as an encrypted
+// tenant's chain, as a daemon with key custody would have exported it.
+func markChainEncrypted(t *testing.T, target, container string) {
+	t.Helper()
+	rewriteChain(t, target, container, func(c *snapexport.Chain) {
+		c.Encrypted, c.Tenant = true, "alice"
+	})
+}
+
+// rewriteChain edits a file:// target's manifest in place.
+func rewriteChain(t *testing.T, target, container string, edit func(*snapexport.Chain)) {
+	t.Helper()
+	p := filepath.Join(strings.TrimPrefix(target, "file://"), container, "manifest.json")
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c snapexport.Chain
+	if err := json.Unmarshal(b, &c); err != nil {
+		t.Fatal(err)
+	}
+	edit(&c)
+	if b, err = json.Marshal(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
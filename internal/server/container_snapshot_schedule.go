@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/snapsched"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Scheduled container snapshots. The decision logic and the tick loop live
+// in internal/snapsched; this file is the RPC surface that stores a policy
+// on the container, and the adapters that bind the scheduler to the same
+// snapshotOps the manual snapshot RPCs use — so a scheduled snapshot lands on
+// exactly the dataset, and the tenant pool, a manual one would.
+
+// SetContainerSnapshotPolicy creates or replaces a container's snapshot
+// schedule.
+//
+// The hooks run as root inside the box. That is no escalation: the box's own
+// user has passwordless sudo there already, and the caller must be
+// authorized for that user's box to set them.
+func (s *ContainerServer) SetContainerSnapshotPolicy(ctx context.Context, req *pb.SetContainerSnapshotPolicyRequest) (*pb.SetContainerSnapshotPolicyResponse, error) {
+	ops, dataset, err := s.snapshotDatasetFor(ctx, req.GetUsername(), auth.ScopeContainersWrite)
+	if err != nil {
+		return nil, err
+	}
+	if s.manager == nil {
+		return nil, status.Error(codes.FailedPrecondition, "snapshot schedules need the Incus backend")
+	}
+	if req.GetPolicy() == nil {
+		return nil, status.Error(codes.InvalidArgument, "policy is required")
+	}
+	p := snapshotPolicyFromProto(req.GetPolicy())
+	p.UpdatedAt = time.Now().UTC()
+	if err := p.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	raw, err := p.Encode()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	containerName := req.GetUsername() + "-container"
+	if info, err := s.manager.Get(req.GetUsername()); err != nil || info == nil {
+		return nil, status.Errorf(codes.NotFound, "container for user %s not found: %v", req.GetUsername(), err)
+	}
+	if err := s.manager.SetConfig(containerName, incus.SnapshotPolicyKey, raw); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set %s: %v", incus.SnapshotPolicyKey, err)
+	}
+	log.Printf("[snapsched] policy set container=%s %s", containerName, raw)
+	return &pb.SetContainerSnapshotPolicyResponse{Policy: snapshotPolicyToProto(ctx, ops, dataset, p)}, nil
+}
+
+// GetContainerSnapshotPolicy returns a container's snapshot schedule.
+func (s *ContainerServer) GetContainerSnapshotPolicy(ctx context.Context, req *pb.GetContainerSnapshotPolicyRequest) (*pb.GetContainerSnapshotPolicyResponse, error) {
+	ops, dataset, err := s.snapshotDatasetFor(ctx, req.GetUsername(), auth.ScopeContainersRead)
+	if err != nil {
+		return nil, err
+	}
+	if s.manager == nil {
+		return nil, status.Error(codes.FailedPrecondition, "snapshot schedules need the Incus backend")
+	}
+	info, err := s.manager.Get(req.GetUsername())
+	if err != nil || info == nil {
+		return nil, status.Errorf(codes.NotFound, "container for user %s not found: %v", req.GetUsername(), err)
+	}
+	if info.SnapshotPolicy == "" {
+		return nil, status.Errorf(codes.NotFound, "container %s has no snapshot policy", info.Name)
+	}
+	p, err := snapsched.ParsePolicy(info.SnapshotPolicy)
+	if err != nil {
+		// Reported rather than hidden: the scheduler skips a policy it cannot
+		// read, and an operator asking why nothing is being taken needs to
+		// see that this is the reason.
+		return nil, status.Errorf(codes.FailedPrecondition, "container %s: %v — set it again", info.Name, err)
+	}
+	return &pb.GetContainerSnapshotPolicyResponse{Policy: snapshotPolicyToProto(ctx, ops, dataset, p)}, nil
+}
+
+// DeleteContainerSnapshotPolicy stops a container's scheduled snapshots.
+// The snapshots already taken are kept: removing the schedule is not a
+// request to lose its restore points.
+func (s *ContainerServer) DeleteContainerSnapshotPolicy(ctx context.Context, req *pb.DeleteContainerSnapshotPolicyRequest) (*pb.DeleteContainerSnapshotPolicyResponse, error) {
+	if _, _, err := s.snapshotDatasetFor(ctx, req.GetUsername(), auth.ScopeContainersWrite); err != nil {
+		return nil, err
+	}
+	if s.manager == nil {
+		return nil, status.Error(codes.FailedPrecondition, "snapshot schedules need the Incus backend")
+	}
+	containerName := req.GetUsername() + "-container"
+	if err := s.manager.UnsetConfig(containerName, incus.SnapshotPolicyKey); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to clear %s: %v", incus.SnapshotPolicyKey, err)
+	}
+	log.Printf("[snapsched] policy deleted container=%s", containerName)
+	return &pb.DeleteContainerSnapshotPolicyResponse{
+		Message: fmt.Sprintf("snapshot schedule removed from %s; the snapshots it took are kept", containerName),
+	}, nil
+}
+
+// NewSnapshotScheduler builds the scheduler over this server's snapshot
+// surface, or returns nil when the daemon has none (no ZFS, no Incus).
+// Called from the daemon's Start alongside the other ticker managers.
+func (s *ContainerServer) NewSnapshotScheduler(ic *incus.Client) *snapsched.Manager {
+	if s.snapshots == nil || s.manager == nil || ic == nil {
+		return nil
+	}
+	return snapsched.NewManager(
+		&snapschedSource{list: ic.ListContainers, ops: s.snapshots},
+		&snapschedSnapshotter{ops: s.snapshots},
+		&snapschedHooks{cs: s, ic: ic},
+		&snapschedNotifier{emitter: s.emitter},
+		snapsched.Options{},
+	)
+}
+
+// snapschedSource produces the scheduler's views: every user container with
+// a policy, with its snapshot names. Only containers with a policy cost a
+// `zfs list`, so a daemon where nobody schedules snapshots pays nothing.
+type snapschedSource struct {
+	list func() ([]incus.ContainerInfo, error)
+	ops  *snapshotOps
+}
+
+func (a *snapschedSource) ListContainers() ([]snapsched.ContainerView, error) {
+	raw, err := a.list()
+	if err != nil {
+		return nil, err
+	}
+	var out []snapsched.ContainerView
+	for _, c := range raw {
+		if c.SnapshotPolicy == "" || c.Role.IsCoreRole() {
+			continue
+		}
+		p, err := snapsched.ParsePolicy(c.SnapshotPolicy)
+		if err != nil {
+			log.Printf("[snapsched] skipping %s: %v", c.Name, err)
+			continue
+		}
+		dataset, err := a.ops.datasetOf(c.Name)
+		if err != nil {
+			log.Printf("[snapsched] skipping %s: %v", c.Name, err)
+			continue
+		}
+		full, err := a.ops.zfs.ListSnapshots(context.Background(), dataset)
+		if err != nil {
+			log.Printf("[snapsched] skipping %s: list snapshots: %v", c.Name, err)
+			continue
+		}
+		v := snapsched.ContainerView{
+			Name:    c.Name,
+			Policy:  p,
+			Running: strings.EqualFold(c.State, "Running"),
+		}
+		for _, f := range full {
+			if short := snapshotShortName(f); !incusManaged(short) {
+				v.Snapshots = append(v.Snapshots, short)
+			}
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// snapschedSnapshotter takes and destroys snapshots through the same dataset
+// resolution as the manual RPCs — the tenant pool included.
+type snapschedSnapshotter struct{ ops *snapshotOps }
+
+func (a *snapschedSnapshotter) Snapshot(ctx context.Context, container, name string) error {
+	dataset, err := a.ops.datasetOf(container)
+	if err != nil {
+		return err
+	}
+	_, err = a.ops.zfs.Snapshot(ctx, dataset, name)
+	return err
+}
+
+func (a *snapschedSnapshotter) Destroy(ctx context.Context, container, name string) error {
+	dataset, err := a.ops.datasetOf(container)
+	if err != nil {
+		return err
+	}
+	return a.ops.zfs.DestroySnapshot(ctx, dataset+"@"+name)
+}
+
+// snapschedHooks runs a policy's hooks in the box.
+type snapschedHooks struct {
+	cs *ContainerServer
+	ic *incus.Client
+}
+
+func (h *snapschedHooks) RunCommand(ctx context.Context, container, command string) error {
+	_, stderr, err := h.cs.manager.ExecWithOutputContext(ctx, container, []string{"/bin/sh", "-c", command})
+	if err != nil {
+		if msg := strings.TrimSpace(stderr); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *snapschedHooks) Freeze(container string) error   { return h.ic.FreezeContainer(container) }
+func (h *snapschedHooks) Unfreeze(container string) error { return h.ic.UnfreezeContainer(container) }
+
+// snapschedNotifier publishes the scheduler's outcomes on the events bus.
+type snapschedNotifier struct{ emitter *events.Emitter }
+
+func (n *snapschedNotifier) event(container, snapshot string) *pb.ContainerSnapshotEvent {
+	return &pb.ContainerSnapshotEvent{
+		Username:      strings.TrimSuffix(container, "-container"),
+		ContainerName: container,
+		Snapshot:      snapshot,
+	}
+}
+
+func (n *snapschedNotifier) SnapshotCreated(container, snapshot string) {
+	if n.emitter != nil {
+		n.emitter.EmitContainerSnapshotCreated(n.event(container, snapshot))
+	}
+}
+
+func (n *snapschedNotifier) SnapshotPruned(container, snapshot string) {
+	if n.emitter != nil {
+		n.emitter.EmitContainerSnapshotPruned(n.event(container, snapshot))
+	}
+}
+
+func (n *snapschedNotifier) SnapshotFailed(container, snapshot string, err error) {
+	if n.emitter != nil {
+		ev := n.event(container, snapshot)
+		ev.Error = err.Error()
+		n.emitter.EmitContainerSnapshotFailed(ev)
+	}
+}
+
+// --- proto <-> core mapping ---
+
+func snapshotPolicyFromProto(in *pb.ContainerSnapshotPolicy) *snapsched.Policy {
+	return &snapsched.Policy{
+		IntervalSeconds: in.IntervalSeconds,
+		Cron:            in.Cron,
+		KeepLast:        int(in.KeepLast),
+		KeepDaily:       int(in.KeepDaily),
+		KeepWeekly:      int(in.KeepWeekly),
+		PreCommand:      in.PreCommand,
+		PostCommand:     in.PostCommand,
+		Freeze:          in.Freeze,
+	}
+}
+
+// snapshotPolicyToProto renders p, with its next run computed from the
+// container's newest scheduled snapshot. A listing failure leaves next_run_at
+// empty rather than failing the call: the policy itself was read fine.
+func snapshotPolicyToProto(ctx context.Context, ops *snapshotOps, dataset string, p *snapsched.Policy) *pb.ContainerSnapshotPolicy {
+	out := &pb.ContainerSnapshotPolicy{
+		IntervalSeconds: p.IntervalSeconds,
+		Cron:            p.Cron,
+		KeepLast:        int32(p.KeepLast),   // #nosec G115 -- set from an int32 field
+		KeepDaily:       int32(p.KeepDaily),  // #nosec G115 -- set from an int32 field
+		KeepWeekly:      int32(p.KeepWeekly), // #nosec G115 -- set from an int32 field
+		PreCommand:      p.PreCommand,
+		PostCommand:     p.PostCommand,
+		Freeze:          p.Freeze,
+		UpdatedAt:       p.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	names, err := ops.zfs.ListSnapshots(ctx, dataset)
+	if err != nil {
+		return out
+	}
+	var latest time.Time
+	for _, full := range names {
+		if t, ok := snapsched.ParseName(snapshotShortName(full)); ok && t.After(latest) {
+			latest = t
+		}
+	}
+	if next := p.NextRun(latest); !next.IsZero() {
+		out.NextRunAt = next.UTC().Format(time.RFC3339)
+	}
+	return out
+}
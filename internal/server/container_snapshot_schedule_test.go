@@ -0,0 +1,190 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/snapsched"
+	"github.com/footprintai/containarium/pkg/core/container"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	"github.com/footprintai/containarium/pkg/core/incus/incustest"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Scheduled container snapshots. The scheduling decisions are tested in
+// internal/snapsched; these pin the daemon half — that a policy is validated
+// before it is stored, that it is stored where the scheduler reads it, and
+// that the adapters hand the scheduler the same datasets the manual RPCs use.
+
+// scheduleFixture is snapshotFixture plus an Incus mock whose config writes
+// for the policy key land on the container, the way the real client's do.
+func scheduleFixture(t *testing.T, z *zfsFake) (*ContainerServer, *incustest.MockBackend) {
+	t.Helper()
+	s := snapshotFixture(t, z, map[string]string{"alice-container": "containarium-tenant-alice"})
+	mock := incustest.NewMockBackend()
+	mock.Containers["alice-container"] = &incus.ContainerInfo{Name: "alice-container", State: "Running"}
+	mock.SetConfigFunc = func(name, key, value string) error {
+		if key == incus.SnapshotPolicyKey {
+			mock.Containers[name].SnapshotPolicy = value
+		}
+		return nil
+	}
+	mock.UnsetConfigFunc = func(name, key string) error {
+		if key == incus.SnapshotPolicyKey {
+			mock.Containers[name].SnapshotPolicy = ""
+		}
+		return nil
+	}
+	s.manager = container.NewWithBackend(mock)
+	return s, mock
+}
+
+func TestSetContainerSnapshotPolicy_StoresItWhereTheSchedulerReadsIt(t *testing.T) {
+	z := newZFSFake()
+	delete(z.errs, "list") // a dataset with no snapshots lists nothing
+	s, mock := scheduleFixture(t, z)
+
+	resp, err := s.SetContainerSnapshotPolicy(writeCtx("alice"), &pb.SetContainerSnapshotPolicyRequest{
+		Username: "alice",
+		Policy:   &pb.ContainerSnapshotPolicy{IntervalSeconds: 3600, KeepLast: 24, PreCommand: "sync"},
+	})
+	if err != nil {
+		t.Fatalf("SetContainerSnapshotPolicy: %v", err)
+	}
+	p, err := snapsched.ParsePolicy(mock.Containers["alice-container"].SnapshotPolicy)
+	if err != nil {
+		t.Fatalf("stored policy does not parse: %v", err)
+	}
+	if p.IntervalSeconds != 3600 || p.KeepLast != 24 || p.PreCommand != "sync" || p.UpdatedAt.IsZero() {
+		t.Errorf("stored policy = %+v", p)
+	}
+	// No scheduled snapshot yet, so the first run is one interval after the
+	// policy was set.
+	next, err := time.Parse(time.RFC3339, resp.GetPolicy().GetNextRunAt())
+	if err != nil {
+		t.Fatalf("next_run_at = %q: %v", resp.GetPolicy().GetNextRunAt(), err)
+	}
+	if want := p.UpdatedAt.Add(time.Hour).Truncate(time.Second); !next.Equal(want) {
+		t.Errorf("next_run_at = %s, want %s", next, want)
+	}
+
+	got, err := s.GetContainerSnapshotPolicy(writeCtx("alice"), &pb.GetContainerSnapshotPolicyRequest{Username: "alice"})
+	if err != nil {
+		t.Fatalf("GetContainerSnapshotPolicy: %v", err)
+	}
+	if got.GetPolicy().GetKeepLast() != 24 {
+		t.Errorf("Get returned %+v", got.GetPolicy())
+	}
+}
+
+// An invalid policy is refused at the RPC rather than stored and skipped by
+// every tick, where nobody would see why nothing is being taken.
+func TestSetContainerSnapshotPolicy_RefusesAnInvalidPolicy(t *testing.T) {
+	s, mock := scheduleFixture(t, newZFSFake())
+
+	_, err := s.SetContainerSnapshotPolicy(writeCtx("alice"), &pb.SetContainerSnapshotPolicyRequest{
+		Username: "alice",
+		Policy:   &pb.ContainerSnapshotPolicy{IntervalSeconds: 3600},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument for a policy with no retention", err)
+	}
+	if mock.Containers["alice-container"].SnapshotPolicy != "" {
+		t.Error("an invalid policy was stored")
+	}
+}
+
+func TestContainerSnapshotPolicy_DeleteThenGetIsNotFound(t *testing.T) {
+	s, mock := scheduleFixture(t, newZFSFake())
+	mock.Containers["alice-container"].SnapshotPolicy = `{"interval_seconds":3600,"keep_last":3}`
+
+	if _, err := s.DeleteContainerSnapshotPolicy(writeCtx("alice"),
+		&pb.DeleteContainerSnapshotPolicyRequest{Username: "alice"}); err != nil {
+		t.Fatalf("DeleteContainerSnapshotPolicy: %v", err)
+	}
+	_, err := s.GetContainerSnapshotPolicy(writeCtx("alice"), &pb.GetContainerSnapshotPolicyRequest{Username: "alice"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+}
+
+func TestContainerSnapshotPolicy_RefusesAnotherTenantsContainer(t *testing.T) {
+	s, mock := scheduleFixture(t, newZFSFake())
+
+	_, err := s.SetContainerSnapshotPolicy(writeCtx("bob"), &pb.SetContainerSnapshotPolicyRequest{
+		Username: "alice",
+		Policy:   &pb.ContainerSnapshotPolicy{IntervalSeconds: 3600, KeepLast: 3, PreCommand: "id"},
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("err = %v, want PermissionDenied — a policy's hooks run as root in the box", err)
+	}
+	if mock.Containers["alice-container"].SnapshotPolicy != "" {
+		t.Error("another tenant's policy was stored")
+	}
+	mock.Containers["alice-container"].SnapshotPolicy = `{"interval_seconds":3600,"keep_last":3}`
+	if _, err := s.GetContainerSnapshotPolicy(writeCtx("bob"),
+		&pb.GetContainerSnapshotPolicyRequest{Username: "alice"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("bob read alice's policy: %v", err)
+	}
+}
+
+// Retention prunes every snapshot named like a scheduled one. A hand-taken
+// snapshot under such a name would be deleted by a schedule that never took
+// it, so the name is refused up front.
+func TestCreateContainerSnapshot_RefusesAScheduledName(t *testing.T) {
+	z := newZFSFake()
+	s := snapshotFixture(t, z, map[string]string{})
+
+	_, err := s.CreateContainerSnapshot(writeCtx("alice"), &pb.CreateContainerSnapshotRequest{
+		Username: "alice",
+		Name:     snapsched.SnapshotName(time.Now()),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+	if z.ran("snapshot") {
+		t.Fatalf("zfs snapshot ran anyway; calls=%v", z.calls)
+	}
+
+	// Only the exact scheduled form is reserved.
+	if _, err := s.CreateContainerSnapshot(writeCtx("alice"),
+		&pb.CreateContainerSnapshotRequest{Username: "alice", Name: "auto-before-upgrade"}); err != nil {
+		t.Errorf("a hand name that merely starts with auto- was refused: %v", err)
+	}
+}
+
+// The source resolves each box's dataset through its recorded pool — the
+// tenant pool for an encrypted box — and hides Incus's own snapshots, which
+// retention must never count or touch.
+func TestSnapschedSource_ListsThePolicyBoxesSnapshots(t *testing.T) {
+	z := newZFSFake()
+	delete(z.errs, "list")
+	ds := "tank/containarium-tenant-alice/containers/alice-container"
+	z.stdout["list"] = ds + "@auto-20261016T110000Z\n" + ds + "@snapshot-incus0\n" + ds + "@base"
+	s := snapshotFixture(t, z, map[string]string{"alice-container": "containarium-tenant-alice"})
+
+	src := &snapschedSource{ops: s.snapshots, list: func() ([]incus.ContainerInfo, error) {
+		return []incus.ContainerInfo{
+			{Name: "alice-container", State: "Running", SnapshotPolicy: `{"interval_seconds":3600,"keep_last":3}`},
+			{Name: "bob-container", State: "Running"},
+			{Name: "carol-container", SnapshotPolicy: `not json`},
+		}, nil
+	}}
+	views, err := src.ListContainers()
+	if err != nil {
+		t.Fatalf("ListContainers: %v", err)
+	}
+	if len(views) != 1 || views[0].Name != "alice-container" || !views[0].Running {
+		t.Fatalf("views = %+v, want only alice's", views)
+	}
+	if got := strings.Join(views[0].Snapshots, ","); got != "auto-20261016T110000Z,base" {
+		t.Errorf("snapshots = %s", got)
+	}
+	if !z.ran("-r " + ds) {
+		t.Errorf("listed the wrong dataset; calls=%v", z.calls)
+	}
+}
@@ -127,7 +127,7 @@ func (s *CrewServer) RunCrew(ctx context.Context, req *pb.RunCrewRequest) (*pb.R
 	// are seeded with no task input — the crew delivers per-hop input over A2A.
 	for _, sid := range crew.SkillIds {
 		skill, _ := s.skillByID(sid) // existence already checked by validateCrewTopology
-		containerName, _, err := s.agents.provisionSkillBox(ctx, skill, req.BackendId, req.Pool, "")
+		containerName, _, err := s.agents.provisionSkillBox(ctx, skill, req.BackendId, req.Pool, "", run.Id)
 		if err != nil {
 			run.State = pb.CrewRunState_CREW_RUN_STATE_FAILED
 			run.Error = fmt.Sprintf("provision skill %q: %v", sid, err)
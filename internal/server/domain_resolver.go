@@ -14,11 +14,11 @@ type ipResolver interface {
 	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
 }
 
-// DomainResolver maintains a cache of egress_domains → current IPv4 addresses
-// (#315 Phase C). The enforcer refreshes it on a loop and folds the cached IPs
-// into each tenant's egress allow-list as /32 entries, so an allow-rule like
-// "api.github.com" tracks the domain's moving IPs. IPv4-only — the BPF program
-// is IPv4-only.
+// DomainResolver maintains a cache of egress_domains → current A and AAAA
+// addresses (#315 Phase C). The enforcer refreshes it on a loop and folds the
+// cached IPs into each tenant's egress allow-list as host entries (/32, /128),
+// so an allow-rule like "api.github.com" tracks the domain's moving IPs over
+// both families.
 type DomainResolver struct {
 	resolver ipResolver
 	mu       sync.RWMutex
@@ -44,19 +44,25 @@ func (d *DomainResolver) Refresh(ctx context.Context, domains []string) {
 			continue
 		}
 		requested[dom] = true
-		addrs, err := d.resolver.LookupNetIP(ctx, "ip4", dom)
+		addrs, err := d.resolver.LookupNetIP(ctx, "ip", dom)
 		if err != nil {
 			continue // keep prior cache for this domain
 		}
-		v4 := make([]netip.Addr, 0, len(addrs))
+		// Unmap so a v4 answer in ::ffff: form lands in the v4 map, and drop
+		// zones (never meaningful for a public name) and duplicates.
+		ips := make([]netip.Addr, 0, len(addrs))
+		seen := make(map[netip.Addr]bool, len(addrs))
 		for _, a := range addrs {
-			if a.Is4() {
-				v4 = append(v4, a.Unmap())
+			a = a.Unmap().WithZone("")
+			if !a.IsValid() || seen[a] {
+				continue
 			}
+			seen[a] = true
+			ips = append(ips, a)
 		}
-		sort.Slice(v4, func(i, j int) bool { return v4[i].Less(v4[j]) })
+		sort.Slice(ips, func(i, j int) bool { return ips[i].Less(ips[j]) })
 		d.mu.Lock()
-		d.cache[dom] = v4
+		d.cache[dom] = ips
 		d.mu.Unlock()
 	}
 	// Prune domains no longer in any policy. A failed-lookup domain is still in
@@ -70,7 +76,7 @@ func (d *DomainResolver) Refresh(ctx context.Context, domains []string) {
 	d.mu.Unlock()
 }
 
-// IPs returns the cached IPv4 addresses for a domain (a copy; empty if unknown).
+// IPs returns the cached addresses for a domain (a copy; empty if unknown).
 func (d *DomainResolver) IPs(domain string) []netip.Addr {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -35,18 +35,22 @@ func addrs(ss ...string) []netip.Addr {
 
 func TestDomainResolver_RefreshAndIPs(t *testing.T) {
 	f := &fakeIPResolver{results: map[string][]netip.Addr{
-		// unsorted + an IPv6 that must be filtered out
-		"api.github.com": addrs("140.82.114.6", "140.82.112.3", "2606:50c0::1"),
+		// unsorted, both families, and a v4-mapped duplicate of an A answer
+		"api.github.com": addrs("140.82.114.6", "2606:50c0::1", "140.82.112.3", "::ffff:140.82.114.6"),
 	}}
 	r := NewDomainResolver(f)
 	r.Refresh(context.Background(), []string{"api.github.com", "api.github.com"}) // dup ignored
 
 	got := r.IPs("api.github.com")
-	if len(got) != 2 {
-		t.Fatalf("expected 2 IPv4 (IPv6 filtered), got %v", got)
+	want := []string{"140.82.112.3", "140.82.114.6", "2606:50c0::1"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v (AAAA kept, mapped duplicate folded)", got, want)
 	}
-	if got[0].String() != "140.82.112.3" || got[1].String() != "140.82.114.6" {
-		t.Errorf("not sorted: %v", got)
+	for i, w := range want {
+		if got[i].String() != w {
+			t.Errorf("got %v, want sorted %v", got, want)
+			break
+		}
 	}
 	if f.calls["api.github.com"] != 1 {
 		t.Errorf("dup domain should resolve once, got %d calls", f.calls["api.github.com"])
@@ -35,6 +35,7 @@ import (
 	"github.com/footprintai/containarium/internal/pentest"
 	secretsstore "github.com/footprintai/containarium/internal/secrets"
 	"github.com/footprintai/containarium/internal/security"
+	"github.com/footprintai/containarium/internal/snapsched"
 	"github.com/footprintai/containarium/internal/traffic"
 	"github.com/footprintai/containarium/internal/ttlsweeper"
 	"github.com/footprintai/containarium/internal/waf"
@@ -216,8 +217,12 @@ type DualServer struct {
 	peerPool              *PeerPool
 	autoSleepManager      *autosleep.Manager
 	ttlSweeperManager     *ttlsweeper.Manager    // ephemeral CI box auto-delete (#299)
+	snapshotScheduler     *snapsched.Manager     // scheduled container snapshots + retention
+	backupServer          *BackupServer          // owns the backup schedule loop
 	secretsReconciler     *secretsReconciler     // Phase 4.3 Phase B-3
 	networkPolicyEnforcer *NetworkPolicyEnforcer // #315 Phase A — eBPF per-tenant net policy (off unless configured)
+	wafRules              *WAFRuleReloader       // #662 Tier 3 — operator WAF rules, used when WAF inspection is on
+	httpRED               *HTTPREDPlane          // #231 follow-on — nil unless CONTAINARIUM_ACCESS_LOG is set
 
 	// k8sNetPolicyReconciler converges tenant NetworkPolicy objects on the K8s
 	// backend from the same store the eBPF enforcer reads (#1188). Nil on
@@ -248,6 +253,10 @@ func bridgeDNSRaw(baseDomain, caddyIP, sshHost string) string {
 	return raw
 }
 
+// secretsMasterKeyPath is the daemon's master key: the secrets store's
+// cipher key, and the key the inproc KMS backend wraps data keys with.
+const secretsMasterKeyPath = "/etc/containarium/secrets.key"
+
 // NewDualServer creates a new dual server instance
 func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// Audit C-MED-1: when --proxy-protocol is on, the daemon
@@ -335,6 +344,8 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 		// placed on. Wired first, every encrypted container would resolve to
 		// the default pool's dataset — which is a different tenant's storage.
 		containerServer.SetSnapshotStorage(zfscrypt.NewManager(nil), encClient.ContainerDataset)
+		containerServer.SetSnapshotForking(encClient.CreateForkShell)
+		containerServer.SetSnapshotExport(encClient.InstanceShape, encClient.CreateShellFromShape)
 	}
 	// NOTE: metrics-export resume (StartMetricsExportIfEnabled) is
 	// deliberately NOT called here. The resumed collector snapshots the
@@ -461,7 +472,12 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// per-box network policy at launch (Phase 2 / #573).
 	npServer := NewNetworkPolicyServer(NewMemNetworkPolicyStore())
 	npServer.SetSignatureStore(NewMemNetworkPolicySignatureStore()) // #661 PR-B; swapped to Postgres below when available
+	npServer.SetWAFRuleStore(NewMemWAFRuleStore())                  // #662 Tier 3; likewise
+	npServer.SetBoxPolicyStore(NewMemBoxNetworkPolicyStore())       // likewise
 	pb.RegisterNetworkPolicyServiceServer(grpcServer, npServer)
+	if networkServer != nil {
+		networkServer.SetNetworkPolicies(npServer) // topology shows each box's effective policy
+	}
 	log.Printf("NetworkPolicy service enabled (in-memory store; Phase A)")
 
 	// Register AgentSkillService — agent-as-a-box (Phase 0) + A2A transport
@@ -513,9 +529,11 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// Register BackupService — logical (pg_dump) database backups for the
 	// databases running inside containers, stored off-host (local dir or
 	// GCS). Orchestration over the container manager; the GCS uploader is
-	// best-effort (LOCAL-only if `gcloud` is absent). See
+	// best-effort (LOCAL-only if `gcloud` is absent). Its schedule loop
+	// is started with the other tickers in Start. See
 	// docs/DB-BACKUP-OPERATIONS.md.
-	pb.RegisterBackupServiceServer(grpcServer, NewBackupServer(containerServer))
+	backupServer := NewBackupServer(containerServer)
+	pb.RegisterBackupServiceServer(grpcServer, backupServer)
 	log.Printf("Backup service enabled")
 
 	// Register VolumeService — shared, multi-writer CephFS volumes (#384).
@@ -554,6 +572,18 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 	// host edge. Declared here so it's in scope for the DualServer assembly.
 	var cloudClient *cloud.Client
 
+	// HTTP RED plane (#231 follow-on): per-container request rate, 5xx ratio
+	// and latency quantiles from the edge's JSON access log, when
+	// CONTAINARIUM_ACCESS_LOG names it. Off by default; started in Start().
+	var httpRED *HTTPREDPlane
+	if path := strings.TrimSpace(os.Getenv(appconfig.EnvAccessLog)); path != "" {
+		var containers httpREDContainers
+		if networkIncusClient != nil {
+			containers = networkIncusClient
+		}
+		httpRED = NewHTTPREDPlane(path, containers)
+	}
+
 	// Create TrafficServer (always available, but conntrack only works on Linux)
 	var trafficServer *TrafficServer
 	var trafficCollector *traffic.Collector
@@ -570,6 +600,9 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 			log.Printf("Warning: Failed to create traffic collector: %v", err)
 		} else {
 			trafficServer = NewTrafficServer(trafficCollector)
+			if httpRED != nil {
+				trafficServer.SetHTTPStats(httpRED.Tracker())
+			}
 			pb.RegisterTrafficServiceServer(grpcServer, trafficServer)
 			if trafficCollector.IsAvailable() {
 				log.Printf("Traffic monitoring service enabled (conntrack available)")
@@ -750,7 +783,7 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 			if secretsPool, secretsErr := connectToPostgres(postgresConnString, 5, 3*time.Second); secretsErr != nil {
 				log.Printf("Warning: Failed to connect to Postgres for secrets store: %v", secretsErr)
 			} else {
-				key, created, kerr := corecryptosecrets.LoadOrCreateMasterKey("/etc/containarium/secrets.key")
+				key, created, kerr := corecryptosecrets.LoadOrCreateMasterKey(secretsMasterKeyPath)
 				if kerr != nil {
 					log.Printf("Warning: Failed to load secrets master key: %v. Secrets disabled.", kerr)
 					secretsPool.Close()
@@ -912,6 +945,9 @@ func NewDualServer(config *DualServerConfig) (*DualServer, error) {
 						} else {
 							trafficCollector = newCollector
 							trafficServer = NewTrafficServer(trafficCollector)
+							if httpRED != nil {
+								trafficServer.SetHTTPStats(httpRED.Tracker())
+							}
 							log.Printf("Traffic monitoring updated with persistence")
 						}
 					}
@@ -1179,6 +1215,18 @@ skipAppHosting:
 					npServer.SetSignatureStore(sigStore)
 					log.Printf("NetworkPolicy signature persistence enabled (Postgres store)")
 				}
+				if wafStore, wErr := NewPostgresWAFRuleStore(context.Background(), pool); wErr != nil {
+					log.Printf("Warning: Failed to create Postgres WAF rule store: %v", wErr)
+				} else {
+					npServer.SetWAFRuleStore(wafStore)
+					log.Printf("WAF rule persistence enabled (Postgres store)")
+				}
+				if boxStore, bErr := NewPostgresBoxNetworkPolicyStore(context.Background(), pool); bErr != nil {
+					log.Printf("Warning: Failed to create Postgres box network policy store: %v", bErr)
+				} else {
+					npServer.SetBoxPolicyStore(boxStore)
+					log.Printf("Box network policy persistence enabled (Postgres store)")
+				}
 
 				// Agent run state (#1182) shares the same pool. Same best-effort
 				// posture: on failure the in-memory store stays, so the daemon comes
@@ -1208,6 +1256,12 @@ skipAppHosting:
 					agentSkillServer.SetTaskQueue(taskQueue)
 					log.Printf("Agent task-queue persistence enabled (Postgres store)")
 				}
+				if usageStore, uErr := NewPostgresModelUsageStore(context.Background(), pool); uErr != nil {
+					log.Printf("Warning: Failed to create Postgres model usage store: %v", uErr)
+				} else {
+					agentSkillServer.SetUsageStore(usageStore)
+					log.Printf("Model-gateway usage ledger enabled (Postgres store)")
+				}
 			}
 		}
 	}
@@ -1539,6 +1593,7 @@ skipAppHosting:
 		sigArmed := netCfg.PolicySignatures
 		networkPolicyEnforcer.SetSignaturesEnabled(sigArmed)
 		networkPolicyEnforcer.SetSignatureStore(npServer.SignatureStore()) // #661 PR-B: merge operator signatures
+		networkPolicyEnforcer.SetBoxPolicyStore(npServer.BoxPolicyStore())
 		if enforceArmed {
 			log.Printf("NetworkPolicy enforcer configured (obj=%s); ENFORCE ARMED — enforce-mode policies will drop packets", bpfObj)
 		} else {
@@ -1549,6 +1604,27 @@ skipAppHosting:
 		}
 	}
 
+	// Tier 3 (#662): the userspace WAF's rule engine — the built-in signatures
+	// plus the operator rule set, reloaded on every rule edit. Cheap to build;
+	// it only inspects traffic once Start attaches it to the steering proxy.
+	var wafContainers wafContainerLister
+	if networkIncusClient != nil {
+		wafContainers = networkIncusClient
+	}
+	wafRules := NewWAFRuleReloader(waf.NewRuleEngine(waf.NewBuiltinInspector()), npServer.WAFRuleStore, wafContainers)
+	npServer.SetWAFRulesChanged(wafRules.Trigger)
+
+	// Learn mode (network-policy suggest) reads the flows the traffic
+	// collector keeps — persisted history when Postgres is up, live
+	// connections always. Without the collector the RPC reports Unavailable.
+	if trafficCollector != nil && networkIncusClient != nil {
+		var history EgressHistory
+		if st := trafficCollector.GetStore(); st != nil {
+			history = st
+		}
+		npServer.SetSuggestSources(history, trafficCollector.GetConnections, networkIncusClient)
+	}
+
 	// Setup alert store and manager
 	var alertStore *alert.Store
 	var alertManager *alert.Manager
@@ -1629,9 +1705,44 @@ skipAppHosting:
 			} else {
 				gwSink = sink
 			}
+			// Per-tenant/skill budgets + rate limits from the daemon-side
+			// policy file. A file that fails to load is not silently dropped:
+			// the warning says budgets are unenforced (token budgets still apply).
+			var gwBudgets *modelgateway.BudgetPolicy
+			if path := strings.TrimSpace(os.Getenv(appconfig.EnvGatewayBudgets)); path != "" {
+				if p, berr := modelgateway.LoadBudgetPolicy(path); berr != nil {
+					log.Printf("WARNING: model-gateway budget policy not loaded (%v) — daemon-side budgets and rate limits are NOT enforced", berr)
+				} else {
+					gwBudgets = p
+					log.Printf("Model-gateway budget policy loaded from %s (%d tenants, %d priced models)", path, len(p.Tenants), len(p.Prices))
+				}
+			}
+			// Model aliases + provider failover. A policy that fails to load
+			// leaves routing off: calls still reach the token's provider, they
+			// just do not fail over.
+			gwProviders := modelgateway.DefaultProviders()
+			var gwRoutes *modelgateway.RoutingPolicy
+			if path := strings.TrimSpace(os.Getenv(appconfig.EnvGatewayRoutes)); path != "" {
+				if p, rerr := modelgateway.LoadRoutingPolicy(path, gwProviders); rerr != nil {
+					log.Printf("WARNING: model-gateway routing policy not loaded (%v) — model aliases and provider failover are OFF", rerr)
+				} else {
+					gwRoutes = p
+					log.Printf("Model-gateway routing policy loaded from %s (%d routes)", path, len(p.Routes))
+				}
+			}
+			// Opt-in response cache for replayed non-streaming calls.
+			var gwCache *modelgateway.CacheConfig
+			if v := strings.TrimSpace(os.Getenv(appconfig.EnvGatewayCacheTTL)); v != "" && v != "0" {
+				if ttl, terr := time.ParseDuration(v); terr != nil || ttl < 0 {
+					log.Printf("WARNING: %s=%q is not a duration — model-gateway response cache is OFF", appconfig.EnvGatewayCacheTTL, v)
+				} else {
+					gwCache = &modelgateway.CacheConfig{TTL: ttl}
+					log.Printf("Model-gateway response cache enabled (ttl=%s)", ttl)
+				}
+			}
 			gw := modelgateway.New(modelgateway.Config{
 				Secret:       []byte(config.JWTSecret),
-				Providers:    modelgateway.DefaultProviders(),
+				Providers:    gwProviders,
 				ProviderKeys: keys,
 				Sink:         gwSink,
 				// Redact system-prompt (skill persona) leakage on the streaming
@@ -1639,6 +1750,12 @@ skipAppHosting:
 				// CONTAINARIUM_GATEWAY_OUTPUT_FILTER=0 to disable. Streaming token
 				// metering is independent and always on.
 				OutputFilter: os.Getenv(appconfig.EnvGatewayOutputFilter) != "0",
+				Budgets:      gwBudgets,
+				// Durable per-call ledger behind GetAgentModelUsage; Postgres
+				// when the pool came up, in-memory otherwise.
+				Ledger: agentSkillServer.UsageLedger(),
+				Routes: gwRoutes,
+				Cache:  gwCache,
 			})
 			gatewayServer.SetModelGatewayHandler(gw.Handler())
 			primary := gatewayPrimaryProvider(keys)
@@ -1867,10 +1984,15 @@ skipAppHosting:
 		}
 	}
 
+	if httpRED != nil && routeStore != nil {
+		httpRED.routes = routeStore // host → upstream IP for attribution
+	}
+
 	ds := &DualServer{
 		config:                 config,
 		grpcServer:             grpcServer,
 		containerServer:        containerServer,
+		backupServer:           backupServer,
 		appServer:              appServer,
 		networkServer:          networkServer,
 		trafficServer:          trafficServer,
@@ -1901,6 +2023,8 @@ skipAppHosting:
 		zapStore:               zapStore,
 		peerPool:               NewPeerPool(config.LocalBackendID, config.SentinelURL, config.Peers, config.Pool),
 		networkPolicyEnforcer:  networkPolicyEnforcer,
+		wafRules:               wafRules,
+		httpRED:                httpRED,
 		k8sNetPolicyReconciler: k8sNetPolicyReconciler,
 		cloudClient:            cloudClient,
 		startTime:              time.Now(),
@@ -2306,6 +2430,25 @@ func (ds *DualServer) Start(ctx context.Context) error {
 		log.Printf("[ttlsweeper] incus client unavailable: %v (sweeper disabled)", err)
 	}
 
+	// Start the snapshot scheduler. Policies live on each container's Incus
+	// config (user.containarium.snapshot_policy), so there is nothing to
+	// load: a box with no policy costs the tick nothing beyond the listing.
+	if ds.containerServer != nil {
+		if incusClient, err := incus.New(); err == nil {
+			if m := ds.containerServer.NewSnapshotScheduler(incusClient); m != nil {
+				ds.snapshotScheduler = m
+				ds.snapshotScheduler.Start(ctx)
+			}
+		}
+	}
+
+	// Start the backup scheduler. Schedules are persisted in the backup
+	// directory, so a daemon restart picks them back up; a slot missed
+	// while the daemon was down runs once on the first tick.
+	if ds.backupServer != nil {
+		ds.backupServer.StartScheduler(ctx)
+	}
+
 	// Orphan reaper (#835): periodically userdel host accounts whose
 	// container no longer exists. userdel -r on container delete can fail
 	// under lock contention (google-guest-agent race on GCP), leaving stale
@@ -2377,8 +2520,16 @@ func (ds *DualServer) Start(ctx context.Context) error {
 				Collector: ds.trafficCollector,
 			})
 		}
+		// Record the HTTP RED plane's latest window on each tick.
+		if ds.httpRED != nil {
+			ds.metricsCollector.SetHTTPStatsFetcher(ds.httpRED.Tracker())
+		}
 		ds.metricsCollector.Start()
 	}
+	if ds.httpRED != nil {
+		go ds.httpRED.Run(ctx)
+		log.Printf("HTTP RED plane enabled: tailing %s", ds.httpRED.path)
+	}
 
 	// Start security scanner if available
 	// Tenant egress policy on the K8s backend (#1188). Nil on every other
@@ -2489,29 +2640,28 @@ func (ds *DualServer) Start(ctx context.Context) error {
 			log.Printf("Warning: CONTAINARIUM_WAF_TPROXY_ADDR=%q is not a valid host:port; WAF steering disabled", wafAddr)
 		} else {
 			cfg := waf.Config{Addr: wafAddr}
-			// PR-2: attach the reference inspector when CONTAINARIUM_WAF_INSPECT=1.
-			// Observe-only unless ENFORCE is also armed (same gate as the kernel
-			// drop path). Blocks audit as network_policy.waf_block.
+			// PR-2: attach the inspector when CONTAINARIUM_WAF_INSPECT=1 — the
+			// built-in signatures plus the operator rule set (hot-reloaded, so a
+			// rule edit never restarts the listener). Observe-only unless ENFORCE
+			// is also armed (same gate as the kernel drop path). Every match is
+			// audited (network_policy.waf_block / waf_rate_limit / waf_match)
+			// and published on the event bus.
 			switch strings.ToLower(strings.TrimSpace(os.Getenv(appconfig.EnvWAFInspect))) {
 			case "1", "true", "yes", "on":
-				cfg.Inspector = waf.NewBuiltinInspector()
+				cfg.Inspector = ds.wafRules.engine
 				switch strings.ToLower(strings.TrimSpace(os.Getenv(appconfig.EnvNetworkPolicyEnforce))) {
 				case "1", "true", "yes", "on":
 					cfg.EnforceBlock = true
 				}
-				if ds.auditStore != nil {
-					auditStore := ds.auditStore
-					cfg.OnBlock = func(orig string, v waf.Verdict, dropped bool) {
-						detail := `{"orig":"` + orig + `","rule_id":` + itoa(int(v.RuleID)) +
-							`,"rule":"` + v.RuleName + `","dropped":` + boolStr(dropped) + `}`
-						if err := auditStore.Log(ctx, &audit.AuditEntry{
-							Username: "_system", Action: "network_policy.waf_block", ResourceType: "network_policy",
-							ResourceID: orig, Detail: detail,
-						}); err != nil {
-							log.Printf("[waf] audit block: %v", err)
-						}
+				cfg.OnMatch = wafMatchHook(ctx, ds.auditStore, events.GetBus())
+				if v := strings.TrimSpace(os.Getenv(appconfig.EnvWAFMaxBodyBytes)); v != "" {
+					if n, perr := strconv.Atoi(v); perr != nil {
+						log.Printf("Warning: %s=%q is not an integer; inspecting the default body prefix", appconfig.EnvWAFMaxBodyBytes, v)
+					} else {
+						cfg.MaxBodyBytes = n
 					}
 				}
+				go ds.wafRules.Run(ctx, defaultWAFReloadInterval)
 			}
 			if err := waf.Start(ctx, cfg); err != nil {
 				log.Printf("Warning: WAF steering proxy failed to start: %v (continuing without it)", err)
@@ -2640,6 +2790,12 @@ func (ds *DualServer) Start(ctx context.Context) error {
 		if ds.ttlSweeperManager != nil {
 			ds.ttlSweeperManager.Stop()
 		}
+		if ds.snapshotScheduler != nil {
+			ds.snapshotScheduler.Stop()
+		}
+		if ds.backupServer != nil {
+			ds.backupServer.StopScheduler()
+		}
 		if ds.secretsReconciler != nil {
 			ds.secretsReconciler.Stop()
 		}
@@ -329,16 +329,11 @@ func (h *encryptionHooks) PreStart(ctx context.Context, containerName string) er
 		return nil // not an encrypted container
 	}
 
-	tenant := tenantFromRef(ref, containerName)
-	key, cached := h.cacheGet(tenant)
-	if !cached {
-		key, err = h.provider.Load(ctx, ref)
-		if err != nil {
-			// The container stays stopped, by design: it is
-			// unreadable until key custody recovers.
-			return fmt.Errorf("cannot load the encryption key for %s: %w", containerName, err)
-		}
-		h.cachePut(tenant, key)
+	key, err := h.keyFor(ctx, tenantFromRef(ref, containerName), ref)
+	if err != nil {
+		// The container stays stopped, by design: it is
+		// unreadable until key custody recovers.
+		return fmt.Errorf("cannot load the encryption key for %s: %w", containerName, err)
 	}
 
 	if err := h.zfs.LoadKey(ctx, dataset, key); err != nil {
@@ -412,6 +407,20 @@ func (h *encryptionHooks) RecordPlacement(containerName string, ref zfskey.KeyRe
 	return nil
 }
 
+// keyFor returns the key ref unwraps to, from the cache when the tenant's
+// key is already there.
+func (h *encryptionHooks) keyFor(ctx context.Context, tenant string, ref zfskey.KeyRef) (zfskey.Key, error) {
+	if key, ok := h.cacheGet(tenant); ok {
+		return key, nil
+	}
+	key, err := h.provider.Load(ctx, ref)
+	if err != nil {
+		return zfskey.Key{}, err
+	}
+	h.cachePut(tenant, key)
+	return key, nil
+}
+
 func (h *encryptionHooks) cacheGet(tenant string) (zfskey.Key, bool) {
 	if h.cache == nil {
 		return zfskey.Key{}, false
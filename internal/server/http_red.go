@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/internal/reqrate"
+	"github.com/footprintai/containarium/pkg/core/incus"
+)
+
+// httpREDWindow is how often the HTTP RED plane closes a window — the OTel
+// collector's default tick, so each tick records a fresh window.
+const httpREDWindow = 30 * time.Second
+
+// httpREDPoll is how often the access-log tailer checks for new lines.
+const httpREDPoll = time.Second
+
+// httpREDContainers is the slice of the Incus client the RED plane needs to
+// attribute upstream IPs to containers.
+type httpREDContainers interface {
+	ListContainers() ([]incus.ContainerInfo, error)
+}
+
+// httpREDRoutes is the slice of the route store the RED plane needs to map a
+// request host to its upstream IP.
+type httpREDRoutes interface {
+	List(ctx context.Context, activeOnly bool) ([]*app.RouteRecord, error)
+}
+
+// HTTPREDPlane tails the Caddy edge's JSON access log into a reqrate.Tracker
+// (#231 follow-on): per-container request rate, 5xx ratio and latency
+// quantiles, recorded by the metrics collector and served by
+// TrafficService.GetHTTPMetrics. Hosts are attributed through the route store
+// (host → upstream IP) and the container list (IP → container).
+type HTTPREDPlane struct {
+	tracker    *reqrate.Tracker
+	path       string
+	containers httpREDContainers
+
+	// routes is set once the route store is known (it's built after the
+	// traffic service); nil resolves no host.
+	routes httpREDRoutes
+}
+
+// NewHTTPREDPlane builds the plane over the access log at path.
+func NewHTTPREDPlane(path string, containers httpREDContainers) *HTTPREDPlane {
+	p := &HTTPREDPlane{path: path, containers: containers}
+	p.tracker = reqrate.NewTracker(p.resolver)
+	return p
+}
+
+// Tracker returns the plane's tracker (the collector's and RPC's source).
+func (p *HTTPREDPlane) Tracker() *reqrate.Tracker { return p.tracker }
+
+// Run tails the log and closes a window every httpREDWindow until ctx is
+// cancelled.
+func (p *HTTPREDPlane) Run(ctx context.Context) {
+	go func() {
+		if err := reqrate.Tail(ctx, p.path, httpREDPoll, p.tracker.ObserveLine); err != nil {
+			log.Printf("Warning: HTTP RED plane stopped tailing %s: %v", p.path, err)
+		}
+	}()
+	p.tracker.Run(ctx, httpREDWindow)
+}
+
+// resolver joins the active routes with the live containers. A lookup that
+// fails leaves that side empty: the window's hosts are dropped rather than
+// misattributed.
+func (p *HTTPREDPlane) resolver() *reqrate.Resolver {
+	var (
+		routes     []reqrate.Route
+		containers []reqrate.Container
+	)
+	if p.routes != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		recs, err := p.routes.List(ctx, true)
+		if err != nil {
+			log.Printf("Warning: HTTP RED plane: list routes: %v", err)
+		}
+		for _, r := range recs {
+			routes = append(routes, reqrate.Route{Host: r.FullDomain, UpstreamIP: r.TargetIP})
+		}
+	}
+	if p.containers != nil {
+		infos, err := p.containers.ListContainers()
+		if err != nil {
+			log.Printf("Warning: HTTP RED plane: list containers: %v", err)
+		}
+		for _, c := range infos {
+			containers = append(containers, reqrate.Container{
+				Name: c.Name, IP: c.IPAddress, ContainerID: c.Labels["cloud_container_id"],
+			})
+		}
+	}
+	return reqrate.NewResolver(routes, containers)
+}
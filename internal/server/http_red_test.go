@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/app"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+type fakeREDContainers []incus.ContainerInfo
+
+func (f fakeREDContainers) ListContainers() ([]incus.ContainerInfo, error) { return f, nil }
+
+type fakeREDRoutes []*app.RouteRecord
+
+func (f fakeREDRoutes) List(context.Context, bool) ([]*app.RouteRecord, error) { return f, nil }
+
+// redServer is a TrafficServer over one closed window: alice's box served a
+// 5xx and a 2xx, bob's box a 2xx.
+func redServer(t *testing.T) *TrafficServer {
+	t.Helper()
+	plane := NewHTTPREDPlane("unused", fakeREDContainers{
+		{Name: "alice-container", IPAddress: "10.0.0.2", Labels: map[string]string{"cloud_container_id": "uuid-a"}},
+		{Name: "bob-container", IPAddress: "10.0.0.3"},
+	})
+	plane.routes = fakeREDRoutes{
+		{FullDomain: "alice.example.com", TargetIP: "10.0.0.2"},
+		{FullDomain: "bob.example.com", TargetIP: "10.0.0.3"},
+	}
+	for _, line := range []string{
+		`{"request":{"host":"alice.example.com"},"status":500,"duration":0.02}`,
+		`{"request":{"host":"alice.example.com"},"status":200,"duration":0.02}`,
+		`{"request":{"host":"bob.example.com:443"},"status":200,"duration":0.001}`,
+	} {
+		plane.Tracker().ObserveLine([]byte(line))
+	}
+	plane.Tracker().Close()
+	srv := &TrafficServer{}
+	srv.SetHTTPStats(plane.Tracker())
+	return srv
+}
+
+func TestTrafficGetHTTPMetrics(t *testing.T) {
+	off, err := (&TrafficServer{}).GetHTTPMetrics(adminCtx(), &pb.GetHTTPMetricsRequest{})
+	if err != nil || off.GetEnabled() || len(off.GetContainers()) != 0 {
+		t.Fatalf("plane off: %+v, %v", off, err)
+	}
+
+	resp, err := redServer(t).GetHTTPMetrics(adminCtx(), &pb.GetHTTPMetricsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetEnabled() || resp.GetWindowEnd() == nil || len(resp.GetContainers()) != 2 {
+		t.Fatalf("admin view: %+v", resp)
+	}
+	a := resp.GetContainers()[0]
+	if a.GetContainerName() != "alice-container" || a.GetRequests() != 2 || a.GetServerErrors() != 1 ||
+		a.GetErrorRatio() != 0.5 || a.GetP50Ms() <= 10 || a.GetP50Ms() > 25 {
+		t.Errorf("alice: %+v", a)
+	}
+}
+
+// A tenant reads only their own containers, by name or in the list.
+func TestTrafficGetHTTPMetrics_TenantFiltering(t *testing.T) {
+	srv := redServer(t)
+	if _, err := srv.GetHTTPMetrics(tenantCtx("alice"), &pb.GetHTTPMetricsRequest{ContainerName: "bob-container"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("other tenant's container: %v, want PermissionDenied", err)
+	}
+	for _, req := range []*pb.GetHTTPMetricsRequest{{}, {ContainerName: "alice-container"}} {
+		resp, err := srv.GetHTTPMetrics(tenantCtx("alice"), req)
+		if err != nil || len(resp.GetContainers()) != 1 || resp.GetContainers()[0].GetContainerName() != "alice-container" {
+			t.Errorf("%+v: %+v, %v", req, resp, err)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/modelgateway"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ModelUsageStore is the model-gateway usage ledger: one row per model call a
+// box made through the gateway, attributed to tenant, skill and run. The
+// gateway writes it (modelgateway.CallLedger); GetAgentModelUsage reads it
+// back as rollups. Two impls, selected the same way CrewRunStore is:
+// PostgresModelUsageStore when the daemon has a DB pool, MemModelUsageStore
+// for --standalone daemons and tests.
+//
+// The gateway's Meter already answers "how much right now"; this is what
+// billing and per-run cost attribution read, so it has to survive a restart.
+type ModelUsageStore interface {
+	modelgateway.CallLedger
+	// Rollup aggregates the calls matching q by q.GroupBy, provider and
+	// model, ordered by that key.
+	Rollup(ctx context.Context, q ModelUsageQuery) ([]*pb.ModelUsageRollup, error)
+}
+
+// ModelUsageQuery filters and groups a Rollup. Empty strings and zero times
+// match everything.
+type ModelUsageQuery struct {
+	Tenant, SkillID, RunID string
+	Start, End             time.Time // [Start, End)
+	GroupBy                pb.ModelUsageGroupBy
+}
+
+func (q ModelUsageQuery) matches(c modelgateway.CallRecord) bool {
+	return (q.Tenant == "" || c.Tenant == q.Tenant) &&
+		(q.SkillID == "" || c.Skill == q.SkillID) &&
+		(q.RunID == "" || c.RunID == q.RunID) &&
+		(q.Start.IsZero() || !c.At.Before(q.Start)) &&
+		(q.End.IsZero() || c.At.Before(q.End))
+}
+
+// usageKey is a rollup row's key; the fields GroupBy does not select stay
+// empty.
+type usageKey struct {
+	tenant, skill, run, provider, model string
+}
+
+func usageKeyFor(g pb.ModelUsageGroupBy, c modelgateway.CallRecord) usageKey {
+	k := usageKey{provider: c.Provider, model: c.Model}
+	switch g {
+	case pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_SKILL:
+		k.tenant, k.skill = c.Tenant, c.Skill
+	case pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_RUN:
+		k.run = c.RunID
+	default:
+		k.tenant = c.Tenant
+	}
+	return k
+}
+
+// --- in-memory ------------------------------------------------------
+
+// memUsageMaxCalls bounds the in-memory ledger; past it the oldest tenth is
+// dropped. A standalone daemon has no billing plane to lose them from.
+const memUsageMaxCalls = 100_000
+
+// MemModelUsageStore is a goroutine-safe in-memory ledger. Calls do not
+// survive a daemon restart — used on --standalone daemons (no Postgres) and
+// in tests.
+type MemModelUsageStore struct {
+	mu    sync.RWMutex
+	calls []modelgateway.CallRecord
+}
+
+func NewMemModelUsageStore() *MemModelUsageStore {
+	return &MemModelUsageStore{}
+}
+
+func (s *MemModelUsageStore) RecordCall(_ context.Context, c modelgateway.CallRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.calls) >= memUsageMaxCalls {
+		s.calls = append(s.calls[:0], s.calls[memUsageMaxCalls/10:]...)
+	}
+	s.calls = append(s.calls, c)
+	return nil
+}
+
+func (s *MemModelUsageStore) Rollup(_ context.Context, q ModelUsageQuery) ([]*pb.ModelUsageRollup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rows := map[usageKey]*pb.ModelUsageRollup{}
+	for _, c := range s.calls {
+		if !q.matches(c) {
+			continue
+		}
+		k := usageKeyFor(q.GroupBy, c)
+		r := rows[k]
+		if r == nil {
+			r = &pb.ModelUsageRollup{
+				Tenant: k.tenant, SkillId: k.skill, RunId: k.run, Provider: k.provider, Model: k.model,
+				FirstCallAt: timestamppb.New(c.At), LastCallAt: timestamppb.New(c.At),
+			}
+			rows[k] = r
+		}
+		r.Calls++
+		if c.Status >= 400 {
+			r.FailedCalls++
+		}
+		r.InputTokens += c.InputTokens
+		r.OutputTokens += c.OutputTokens
+		r.CachedTokens += c.CachedTokens
+		r.TotalLatencyMs += c.Latency.Milliseconds()
+		if c.At.Before(r.FirstCallAt.AsTime()) {
+			r.FirstCallAt = timestamppb.New(c.At)
+		}
+		if c.At.After(r.LastCallAt.AsTime()) {
+			r.LastCallAt = timestamppb.New(c.At)
+		}
+	}
+	out := make([]*pb.ModelUsageRollup, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		for _, p := range [][2]string{
+			{a.Tenant, b.Tenant}, {a.SkillId, b.SkillId}, {a.RunId, b.RunId}, {a.Provider, b.Provider},
+		} {
+			if p[0] != p[1] {
+				return p[0] < p[1]
+			}
+		}
+		return a.Model < b.Model
+	})
+	return out, nil
+}
+
+// --- postgres -------------------------------------------------------
+
+// PostgresModelUsageStore persists the ledger so usage survives a restart.
+type PostgresModelUsageStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresModelUsageStore creates the table if it does not exist.
+//
+// One narrow row per call, columns rather than a JSON body: every read is an
+// aggregate over them, and the shape is the gateway's, not a proto that
+// evolves. The run index is partial — worker and direct calls carry no run id
+// and would only bloat it.
+func NewPostgresModelUsageStore(ctx context.Context, pool *pgxpool.Pool) (*PostgresModelUsageStore, error) {
+	const schema = `
+		CREATE TABLE IF NOT EXISTS model_usage_calls (
+			id BIGSERIAL PRIMARY KEY,
+			at TIMESTAMPTZ NOT NULL,
+			tenant TEXT NOT NULL,
+			skill TEXT NOT NULL DEFAULT '',
+			run_id TEXT NOT NULL DEFAULT '',
+			provider TEXT NOT NULL,
+			model TEXT NOT NULL DEFAULT '',
+			input_tokens BIGINT NOT NULL DEFAULT 0,
+			output_tokens BIGINT NOT NULL DEFAULT 0,
+			cached_tokens BIGINT NOT NULL DEFAULT 0,
+			latency_ms BIGINT NOT NULL DEFAULT 0,
+			status INTEGER NOT NULL DEFAULT 0,
+			streamed BOOLEAN NOT NULL DEFAULT FALSE,
+			cache_hit BOOLEAN NOT NULL DEFAULT FALSE
+		);
+		ALTER TABLE model_usage_calls ADD COLUMN IF NOT EXISTS cache_hit BOOLEAN NOT NULL DEFAULT FALSE;
+		CREATE INDEX IF NOT EXISTS model_usage_calls_tenant_at_idx ON model_usage_calls (tenant, at);
+		CREATE INDEX IF NOT EXISTS model_usage_calls_run_idx ON model_usage_calls (run_id) WHERE run_id <> '';
+	`
+	if _, err := pool.Exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("init model_usage_calls schema: %w", err)
+	}
+	return &PostgresModelUsageStore{pool: pool}, nil
+}
+
+func (s *PostgresModelUsageStore) RecordCall(ctx context.Context, c modelgateway.CallRecord) error {
+	const q = `
+		INSERT INTO model_usage_calls
+			(at, tenant, skill, run_id, provider, model, input_tokens, output_tokens, cached_tokens, latency_ms, status, streamed, cache_hit)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	if _, err := s.pool.Exec(ctx, q, c.At, c.Tenant, c.Skill, c.RunID, c.Provider, c.Model,
+		c.InputTokens, c.OutputTokens, c.CachedTokens, c.Latency.Milliseconds(), c.Status, c.Streamed, c.CacheHit); err != nil {
+		return fmt.Errorf("record model call for %s: %w", c.Tenant, err)
+	}
+	return nil
+}
+
+// usageGroupColumns returns the SQL for a rollup's tenant/skill/run key
+// columns: the column itself when GroupBy selects it, an empty string
+// otherwise. Only constants reach the query text; every filter value is a
+// parameter.
+func usageGroupColumns(g pb.ModelUsageGroupBy) string {
+	switch g {
+	case pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_SKILL:
+		return "tenant, skill, ''"
+	case pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_RUN:
+		return "'', '', run_id"
+	default:
+		return "tenant, '', ''"
+	}
+}
+
+// Rollup aggregates in SQL. Like FailStranded, the statement is reviewed
+// rather than exercised here (#1300); the grouping contract is tested against
+// MemModelUsageStore.
+func (s *PostgresModelUsageStore) Rollup(ctx context.Context, q ModelUsageQuery) ([]*pb.ModelUsageRollup, error) {
+	query := `
+		SELECT ` + usageGroupColumns(q.GroupBy) + `, provider, model,
+			COUNT(*), COUNT(*) FILTER (WHERE status >= 400),
+			SUM(input_tokens), SUM(output_tokens), SUM(cached_tokens), SUM(latency_ms),
+			MIN(at), MAX(at)
+		FROM model_usage_calls
+		WHERE ($1 = '' OR tenant = $1)
+		  AND ($2 = '' OR skill = $2)
+		  AND ($3 = '' OR run_id = $3)
+		  AND ($4::timestamptz IS NULL OR at >= $4)
+		  AND ($5::timestamptz IS NULL OR at < $5)
+		GROUP BY 1, 2, 3, 4, 5
+		ORDER BY 1, 2, 3, 4, 5
+	`
+	rows, err := s.pool.Query(ctx, query, q.Tenant, q.SkillID, q.RunID, optionalTime(q.Start), optionalTime(q.End))
+	if err != nil {
+		return nil, fmt.Errorf("query model usage: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.ModelUsageRollup
+	for rows.Next() {
+		r := &pb.ModelUsageRollup{}
+		var first, last time.Time
+		if err := rows.Scan(&r.Tenant, &r.SkillId, &r.RunId, &r.Provider, &r.Model,
+			&r.Calls, &r.FailedCalls, &r.InputTokens, &r.OutputTokens, &r.CachedTokens, &r.TotalLatencyMs,
+			&first, &last); err != nil {
+			return nil, fmt.Errorf("scan model usage: %w", err)
+		}
+		r.FirstCallAt, r.LastCallAt = timestamppb.New(first), timestamppb.New(last)
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// optionalTime is nil for the zero time, so the query treats that bound as
+// open.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/footprintai/containarium/internal/modelgateway"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// These run against MemModelUsageStore; the Postgres impl's SQL mirrors the
+// same grouping (see PostgresModelUsageStore.Rollup).
+
+var usageT0 = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func seedUsage(t *testing.T) *MemModelUsageStore {
+	t.Helper()
+	s := NewMemModelUsageStore()
+	for _, c := range []modelgateway.CallRecord{
+		{At: usageT0, Tenant: "acme", Skill: "research", RunID: "run-1", Provider: "anthropic", Model: "claude", InputTokens: 100, OutputTokens: 10, Latency: 200 * time.Millisecond, Status: 200},
+		{At: usageT0.Add(time.Minute), Tenant: "acme", Skill: "research", RunID: "run-1", Provider: "anthropic", Model: "claude", InputTokens: 50, OutputTokens: 5, CachedTokens: 40, Latency: 100 * time.Millisecond, Status: 200},
+		{At: usageT0.Add(2 * time.Minute), Tenant: "acme", Skill: "writer", RunID: "run-2", Provider: "anthropic", Model: "claude", Latency: 10 * time.Millisecond, Status: 529},
+		{At: usageT0.Add(time.Hour), Tenant: "globex", Skill: "research", Provider: "gemini", Model: "flash", InputTokens: 7, Status: 200},
+	} {
+		if err := s.RecordCall(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestMemModelUsageStore_RollupByTenant(t *testing.T) {
+	rows, err := seedUsage(t).Rollup(context.Background(), ModelUsageQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want one per tenant/provider/model: %v", len(rows), rows)
+	}
+	acme := rows[0]
+	if acme.Tenant != "acme" || acme.SkillId != "" || acme.RunId != "" {
+		t.Errorf("tenant grouping kept other keys: %+v", acme)
+	}
+	if acme.Calls != 3 || acme.FailedCalls != 1 || acme.InputTokens != 150 || acme.OutputTokens != 15 ||
+		acme.CachedTokens != 40 || acme.TotalLatencyMs != 310 {
+		t.Errorf("acme totals wrong: %+v", acme)
+	}
+	if !acme.FirstCallAt.AsTime().Equal(usageT0) || !acme.LastCallAt.AsTime().Equal(usageT0.Add(2*time.Minute)) {
+		t.Errorf("acme call window wrong: %v .. %v", acme.FirstCallAt.AsTime(), acme.LastCallAt.AsTime())
+	}
+	if rows[1].Tenant != "globex" || rows[1].Provider != "gemini" {
+		t.Errorf("second row = %+v, want globex/gemini", rows[1])
+	}
+}
+
+func TestMemModelUsageStore_RollupBySkillAndRun(t *testing.T) {
+	s := seedUsage(t)
+	ctx := context.Background()
+
+	bySkill, err := s.Rollup(ctx, ModelUsageQuery{Tenant: "acme", GroupBy: pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_SKILL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bySkill) != 2 || bySkill[0].SkillId != "research" || bySkill[0].Calls != 2 || bySkill[1].SkillId != "writer" {
+		t.Errorf("by skill = %v", bySkill)
+	}
+
+	byRun, err := s.Rollup(ctx, ModelUsageQuery{RunID: "run-1", GroupBy: pb.ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_RUN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byRun) != 1 || byRun[0].RunId != "run-1" || byRun[0].Tenant != "" || byRun[0].Calls != 2 {
+		t.Errorf("by run = %v", byRun)
+	}
+}
+
+func TestMemModelUsageStore_TimeRangeIsHalfOpen(t *testing.T) {
+	rows, err := seedUsage(t).Rollup(context.Background(), ModelUsageQuery{
+		Start: usageT0.Add(time.Minute), End: usageT0.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Tenant != "acme" || rows[0].Calls != 2 {
+		t.Errorf("rows = %v, want acme's calls at +1m and +2m only", rows)
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Policy names GetNetworkTopology reports for a box (NetworkNode.network_policy).
+const (
+	topologyPolicyTenant    = "tenant"
+	topologyBoxPolicyPrefix = "box/"
+)
+
+func (s *NetworkPolicyServer) SetBoxNetworkPolicy(ctx context.Context, req *pb.SetBoxNetworkPolicyRequest) (*pb.SetBoxNetworkPolicyResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.boxStore == nil {
+		return nil, status.Error(codes.Unavailable, "box network policies are not configured on this daemon")
+	}
+	compiled, err := netpolicy.CompileBox(req.GetPolicy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkPortRanges(compiled.Policy); err != nil {
+		return nil, err
+	}
+	stored := compiled.ToProto()
+	if err := s.boxStore.Set(ctx, stored); err != nil {
+		return nil, status.Errorf(codes.Internal, "store box network policy: %v", err)
+	}
+	return &pb.SetBoxNetworkPolicyResponse{Policy: stored}, nil
+}
+
+func (s *NetworkPolicyServer) ListBoxNetworkPolicies(ctx context.Context, req *pb.ListBoxNetworkPoliciesRequest) (*pb.ListBoxNetworkPoliciesResponse, error) {
+	if err := auth.RequireRoleOrScope(ctx, auth.RoleAdmin, auth.ScopeNetworkPolicyRead); err != nil {
+		return nil, err
+	}
+	if s.boxStore == nil {
+		return &pb.ListBoxNetworkPoliciesResponse{}, nil
+	}
+	policies, err := s.boxStore.List(ctx, strings.TrimSpace(req.GetTenant()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list box network policies: %v", err)
+	}
+	return &pb.ListBoxNetworkPoliciesResponse{Policies: policies}, nil
+}
+
+func (s *NetworkPolicyServer) DeleteBoxNetworkPolicy(ctx context.Context, req *pb.DeleteBoxNetworkPolicyRequest) (*pb.DeleteBoxNetworkPolicyResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.boxStore == nil {
+		return nil, status.Error(codes.Unavailable, "box network policies are not configured on this daemon")
+	}
+	if req.GetTenant() == "" || req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant and name are required")
+	}
+	if err := s.boxStore.Delete(ctx, req.GetTenant(), req.GetName()); err != nil && !errors.Is(err, ErrBoxNetworkPolicyNotFound) {
+		return nil, status.Errorf(codes.Internal, "delete box network policy: %v", err)
+	}
+	return &pb.DeleteBoxNetworkPolicyResponse{}, nil
+}
+
+// PolicyAssignment is the network policy that polices one container, as
+// GetNetworkTopology reports it.
+type PolicyAssignment struct {
+	Tenant string
+	Policy string // "tenant", "box/<name>", or "" when the tenant has no policy
+	Mode   string // "log_only" | "enforce"; "" with no policy
+}
+
+// tenantBoxPolicies returns tenant's box policies, compiled; none when box
+// policies are not configured. One that no longer compiles is skipped, as
+// the enforcer skips it.
+func (s *NetworkPolicyServer) tenantBoxPolicies(ctx context.Context, tenant string) ([]netpolicy.CompiledBoxPolicy, error) {
+	if s.boxStore == nil {
+		return nil, nil
+	}
+	stored, err := s.boxStore.List(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	var out []netpolicy.CompiledBoxPolicy
+	for _, p := range stored {
+		if b, err := netpolicy.CompileBox(p); err == nil {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+// PolicyAssignments resolves, for each tenant container, the policy the
+// enforcer applies to it: a selecting box policy, else the tenant-wide one.
+// Keyed by container name; unmanaged containers and the control plane are
+// left out.
+func (s *NetworkPolicyServer) PolicyAssignments(ctx context.Context, containers []incus.ContainerInfo) (map[string]PolicyAssignment, error) {
+	stored, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	modes := make(map[string]pb.NetworkPolicyMode, len(stored))
+	for _, p := range stored {
+		if c, err := netpolicy.Compile(p); err == nil {
+			modes[c.Tenant] = c.Mode
+		}
+	}
+	boxes := make(map[string][]netpolicy.CompiledBoxPolicy)
+	if s.boxStore != nil {
+		stored, err := s.boxStore.List(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range stored {
+			if b, err := netpolicy.CompileBox(p); err == nil {
+				boxes[b.Policy.Tenant] = append(boxes[b.Policy.Tenant], b)
+			}
+		}
+	}
+
+	out := make(map[string]PolicyAssignment)
+	for _, c := range containers {
+		if c.Role == incus.RoleControlPlane {
+			continue
+		}
+		tenant := resolveTenant(c.Tenant, c.Labels[cloudOrgIDLabel], c.Name)
+		if tenant == "" {
+			continue
+		}
+		a := PolicyAssignment{Tenant: tenant}
+		if b := netpolicy.SelectBoxPolicy(boxes[tenant], c.Labels); b != nil {
+			a.Policy, a.Mode = topologyBoxPolicyPrefix+b.Name, shortPolicyMode(b.Policy.Mode)
+		} else if mode, ok := modes[tenant]; ok {
+			a.Policy, a.Mode = topologyPolicyTenant, shortPolicyMode(mode)
+		}
+		out[c.Name] = a
+	}
+	return out, nil
+}
+
+// shortPolicyMode renders a policy mode the way the CLI shows it.
+func shortPolicyMode(m pb.NetworkPolicyMode) string {
+	if m == pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE {
+		return "enforce"
+	}
+	return "log_only"
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ErrBoxNetworkPolicyNotFound is returned by a box policy store's delete for
+// an unknown (tenant, name) (the server maps it to idempotent success).
+var ErrBoxNetworkPolicyNotFound = errors.New("box network policy not found")
+
+// BoxNetworkPolicyStore persists label-selected box policies, keyed by
+// (tenant, name). Policies are stored already validated + normalized.
+type BoxNetworkPolicyStore interface {
+	Set(ctx context.Context, p *pb.BoxNetworkPolicy) error
+	// List returns the box policies of tenant, or of every tenant when
+	// tenant is empty, sorted by tenant then name.
+	List(ctx context.Context, tenant string) ([]*pb.BoxNetworkPolicy, error)
+	Delete(ctx context.Context, tenant, name string) error
+}
+
+type boxPolicyKey struct{ tenant, name string }
+
+// --- in-memory ------------------------------------------------------
+
+// MemBoxNetworkPolicyStore is a goroutine-safe in-memory store used on
+// --standalone daemons and in tests.
+type MemBoxNetworkPolicyStore struct {
+	mu sync.RWMutex
+	m  map[boxPolicyKey]*pb.BoxNetworkPolicy
+}
+
+func NewMemBoxNetworkPolicyStore() *MemBoxNetworkPolicyStore {
+	return &MemBoxNetworkPolicyStore{m: make(map[boxPolicyKey]*pb.BoxNetworkPolicy)}
+}
+
+func (s *MemBoxNetworkPolicyStore) Set(_ context.Context, p *pb.BoxNetworkPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[boxPolicyKey{p.GetTenant(), p.GetName()}] = proto.Clone(p).(*pb.BoxNetworkPolicy)
+	return nil
+}
+
+func (s *MemBoxNetworkPolicyStore) List(_ context.Context, tenant string) ([]*pb.BoxNetworkPolicy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []*pb.BoxNetworkPolicy
+	for k, p := range s.m {
+		if tenant == "" || k.tenant == tenant {
+			out = append(out, proto.Clone(p).(*pb.BoxNetworkPolicy))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].GetTenant() != out[j].GetTenant() {
+			return out[i].GetTenant() < out[j].GetTenant()
+		}
+		return out[i].GetName() < out[j].GetName()
+	})
+	return out, nil
+}
+
+func (s *MemBoxNetworkPolicyStore) Delete(_ context.Context, tenant, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := boxPolicyKey{tenant, name}
+	if _, ok := s.m[k]; !ok {
+		return ErrBoxNetworkPolicyNotFound
+	}
+	delete(s.m, k)
+	return nil
+}
+
+// --- postgres -------------------------------------------------------
+
+type PostgresBoxNetworkPolicyStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresBoxNetworkPolicyStore creates the table if it does not exist.
+// The policy is stored as a protojson body keyed by (tenant, name), like the
+// crew runs, so a new NetworkPolicy field needs no migration here.
+func NewPostgresBoxNetworkPolicyStore(ctx context.Context, pool *pgxpool.Pool) (*PostgresBoxNetworkPolicyStore, error) {
+	const schema = `
+		CREATE TABLE IF NOT EXISTS box_network_policies (
+			tenant TEXT NOT NULL,
+			name TEXT NOT NULL,
+			policy JSONB NOT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (tenant, name)
+		);`
+	if _, err := pool.Exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("init box_network_policies schema: %w", err)
+	}
+	return &PostgresBoxNetworkPolicyStore{pool: pool}, nil
+}
+
+func (s *PostgresBoxNetworkPolicyStore) Set(ctx context.Context, p *pb.BoxNetworkPolicy) error {
+	body, err := protojson.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal box network policy %s/%s: %w", p.GetTenant(), p.GetName(), err)
+	}
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO box_network_policies (tenant, name, policy, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant, name) DO UPDATE SET policy = EXCLUDED.policy, updated_at = NOW()`,
+		p.GetTenant(), p.GetName(), body); err != nil {
+		return fmt.Errorf("upsert box network policy: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresBoxNetworkPolicyStore) List(ctx context.Context, tenant string) ([]*pb.BoxNetworkPolicy, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT policy FROM box_network_policies
+		WHERE $1 = '' OR tenant = $1
+		ORDER BY tenant, name`, tenant)
+	if err != nil {
+		return nil, fmt.Errorf("list box network policies: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.BoxNetworkPolicy
+	for rows.Next() {
+		var body []byte
+		if err := rows.Scan(&body); err != nil {
+			return nil, fmt.Errorf("scan box network policy: %w", err)
+		}
+		var p pb.BoxNetworkPolicy
+		// DiscardUnknown so a daemon rolled back to an older build still reads
+		// policies written by a newer one.
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("unmarshal box network policy: %w", err)
+		}
+		out = append(out, &p)
+	}
+	return out, rows.Err()
+}
+
+func (s *PostgresBoxNetworkPolicyStore) Delete(ctx context.Context, tenant, name string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM box_network_policies WHERE tenant = $1 AND name = $2`, tenant, name)
+	if err != nil {
+		return fmt.Errorf("delete box network policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBoxNetworkPolicyNotFound
+	}
+	return nil
+}
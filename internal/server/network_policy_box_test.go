@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netbpf"
+	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func newBoxNPServer() *NetworkPolicyServer {
+	s := newNPServer()
+	s.SetBoxPolicyStore(NewMemBoxNetworkPolicyStore())
+	return s
+}
+
+func TestBoxNetworkPolicy_SetListDelete(t *testing.T) {
+	s := newBoxNPServer()
+	ctx := npAdminCtx()
+	resp, err := s.SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{Policy: &pb.BoxNetworkPolicy{
+		Tenant:   "alice",
+		Name:     "ci",
+		Selector: map[string]string{"role": "ci"},
+		Policy:   &pb.NetworkPolicy{EgressCidrs: []string{"0.0.0.0/0"}, Mode: pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetPolicy().GetPolicy().GetTenant() != "alice" {
+		t.Errorf("stored inner tenant = %q, want alice", resp.GetPolicy().GetPolicy().GetTenant())
+	}
+	if _, err := s.SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{Policy: &pb.BoxNetworkPolicy{
+		Tenant: "bob", Name: "db", Selector: map[string]string{"role": "db"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := s.ListBoxNetworkPolicies(ctx, &pb.ListBoxNetworkPoliciesRequest{})
+	if err != nil || len(all.GetPolicies()) != 2 {
+		t.Fatalf("list all = %v, %v", all.GetPolicies(), err)
+	}
+	alice, _ := s.ListBoxNetworkPolicies(ctx, &pb.ListBoxNetworkPoliciesRequest{Tenant: "alice"})
+	if len(alice.GetPolicies()) != 1 || alice.GetPolicies()[0].GetName() != "ci" {
+		t.Errorf("list alice = %v", alice.GetPolicies())
+	}
+
+	for i := 0; i < 2; i++ { // idempotent
+		if _, err := s.DeleteBoxNetworkPolicy(ctx, &pb.DeleteBoxNetworkPolicyRequest{Tenant: "alice", Name: "ci"}); err != nil {
+			t.Fatalf("delete #%d: %v", i, err)
+		}
+	}
+	if all, _ := s.ListBoxNetworkPolicies(ctx, &pb.ListBoxNetworkPoliciesRequest{}); len(all.GetPolicies()) != 1 {
+		t.Errorf("after delete = %v", all.GetPolicies())
+	}
+}
+
+func TestBoxNetworkPolicy_Validation(t *testing.T) {
+	s := newBoxNPServer()
+	ctx := npAdminCtx()
+	_, err := s.SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{Policy: &pb.BoxNetworkPolicy{Tenant: "alice", Name: "ci"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("no selector: %v, want InvalidArgument", err)
+	}
+	if _, err := s.DeleteBoxNetworkPolicy(ctx, &pb.DeleteBoxNetworkPolicyRequest{Tenant: "alice"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("delete without name: %v, want InvalidArgument", err)
+	}
+	if _, err := newNPServer().SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("no store: %v, want Unavailable", err)
+	}
+	userCtx := auth.ContextWithTestSubject(context.Background(), "alice", "user")
+	if _, err := s.ListBoxNetworkPolicies(userCtx, &pb.ListBoxNetworkPoliciesRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("non-admin list: %v, want PermissionDenied", err)
+	}
+}
+
+func TestMemBoxNetworkPolicyStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemBoxNetworkPolicyStore()
+	for _, p := range []*pb.BoxNetworkPolicy{
+		{Tenant: "bob", Name: "db"},
+		{Tenant: "alice", Name: "web"},
+		{Tenant: "alice", Name: "ci", Priority: 1},
+		{Tenant: "alice", Name: "ci", Priority: 2}, // upsert
+	} {
+		if err := s.Set(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	list, _ := s.List(ctx, "")
+	if len(list) != 3 || list[0].GetName() != "ci" || list[0].GetPriority() != 2 || list[2].GetTenant() != "bob" {
+		t.Fatalf("list = %v, want sorted by tenant then name with the upsert applied", list)
+	}
+	list[0].Priority = 9 // callers get copies
+	if again, _ := s.List(ctx, "alice"); len(again) != 2 || again[0].GetPriority() != 2 {
+		t.Errorf("list alice = %v", again)
+	}
+	if err := s.Delete(ctx, "alice", "ci"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, "alice", "ci"); !errors.Is(err, ErrBoxNetworkPolicyNotFound) {
+		t.Errorf("second delete = %v, want ErrBoxNetworkPolicyNotFound", err)
+	}
+}
+
+func compiledBox(t *testing.T, b *pb.BoxNetworkPolicy) netpolicy.CompiledBoxPolicy {
+	t.Helper()
+	c, err := netpolicy.CompileBox(b)
+	if err != nil {
+		t.Fatalf("CompileBox: %v", err)
+	}
+	return c
+}
+
+// A box policy gets its own id for egress/deny/ports entries; the box keeps
+// its tenant's id for peer checks and events; siblings stay tenant-wide.
+func TestPlanReconcile_BoxPolicy(t *testing.T) {
+	policies := map[string]netpolicy.CompiledPolicy{
+		"alice": compiled(t, &pb.NetworkPolicy{
+			Tenant:           "alice",
+			AllowIntraTenant: true,
+			EgressCidrs:      []string{"8.8.8.8/32"},
+			DenyRules:        []*pb.NetworkPolicyDenyRule{{Cidr: "203.0.113.9"}},
+		}),
+	}
+	boxes := map[string][]netpolicy.CompiledBoxPolicy{
+		"alice": {compiledBox(t, &pb.BoxNetworkPolicy{
+			Tenant:   "alice",
+			Name:     "db",
+			Selector: map[string]string{"role": "db"},
+			Policy:   &pb.NetworkPolicy{Mode: pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE},
+		})},
+	}
+	views := []containerView{
+		{Name: "alice-container", Tenant: "alice", TenantID: 1, Ifindex: 11, HasVeth: true, Running: true, Labels: map[string]string{"role": "web"}},
+		{Name: "alice-db-container", Tenant: "alice", TenantID: 1, IP: [4]byte{10, 100, 0, 12}, HasIP: true, Ifindex: 12, HasVeth: true, Running: true, Labels: map[string]string{"role": "db"}},
+	}
+	var asked []string
+	ids := selectBoxPolicies(views, boxes, func(name string) (uint32, error) {
+		asked = append(asked, name)
+		return 50, nil
+	})
+	if len(asked) != 1 || asked[0] != boxPolicyRegistryName("alice", "db") || ids[50] != "alice" {
+		t.Fatalf("registry asked %v, ids %v", asked, ids)
+	}
+
+	plan := planReconcile(views, policies, true)
+	if cfg := plan.vethPolicy[12]; cfg.TenantID != 1 || cfg.PolicyID != 50 || cfg.Mode != netbpf.ModeEnforce || cfg.AllowIntra != 0 {
+		t.Errorf("db veth cfg = %+v, want tenant 1, policy 50, enforce, no intra", cfg)
+	}
+	if cfg := plan.vethPolicy[11]; cfg.PolicyID != 0 || cfg.AllowIntra != 1 {
+		t.Errorf("web veth cfg = %+v, want the tenant-wide policy", cfg)
+	}
+	if plan.ipTenant[[4]byte{10, 100, 0, 12}] != 1 {
+		t.Error("the box's IP must stay tagged with its tenant")
+	}
+	// The tenant's 8.8.8.8 under id 1 only; both ids carry the virtual patch.
+	if len(plan.egress) != 1 || plan.egress[0].TenantID != 1 {
+		t.Errorf("egress = %+v", plan.egress)
+	}
+	denyIDs := map[uint32]bool{}
+	for _, d := range plan.deny {
+		denyIDs[d.TenantID] = true
+	}
+	if len(plan.deny) != 2 || !denyIDs[1] || !denyIDs[50] {
+		t.Errorf("deny = %+v, want the patch under ids 1 and 50", plan.deny)
+	}
+
+	// An object without policy_cfg.policy_id polices the box as its own tenant.
+	if n := plan.foldBoxPolicies(); n != 1 {
+		t.Fatalf("folded %d veths, want 1", n)
+	}
+	if cfg := plan.vethPolicy[12]; cfg.TenantID != 50 || cfg.PolicyID != 0 {
+		t.Errorf("folded cfg = %+v", cfg)
+	}
+}
+
+func TestPolicyAssignments(t *testing.T) {
+	s := newBoxNPServer()
+	ctx := npAdminCtx()
+	if _, err := s.SetNetworkPolicy(ctx, &pb.SetNetworkPolicyRequest{Policy: &pb.NetworkPolicy{Tenant: "alice"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{Policy: &pb.BoxNetworkPolicy{
+		Tenant: "alice", Name: "ci", Selector: map[string]string{"role": "ci"},
+		Policy: &pb.NetworkPolicy{Mode: pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.PolicyAssignments(ctx, []incus.ContainerInfo{
+		{Name: "alice-container"},
+		{Name: "ci-runner", Tenant: "alice", Labels: map[string]string{"role": "ci"}},
+		{Name: "bob-container", Labels: map[string]string{"role": "ci"}},
+		{Name: "core-controlplane", Role: incus.RoleControlPlane},
+		{Name: "scratch"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]PolicyAssignment{
+		"alice-container": {Tenant: "alice", Policy: "tenant", Mode: "log_only"},
+		"ci-runner":       {Tenant: "alice", Policy: "box/ci", Mode: "enforce"},
+		"bob-container":   {Tenant: "bob"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("assignments = %v, want %v", got, want)
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("%s = %+v, want %+v", name, got[name], w)
+		}
+	}
+}
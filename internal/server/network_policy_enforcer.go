@@ -82,6 +82,7 @@ type containerInspector interface {
 type NetworkPolicyEnforcer struct {
 	objPath        string // BPF object source: a path, or a keyword ("embedded"/"1") → netbpf.Resolve
 	store          NetworkPolicyStore
+	boxStore       BoxNetworkPolicyStore // label-selected box policies (nil = tenant-wide only)
 	registry       TenantRegistry
 	insp           containerInspector
 	audit          *audit.Store
@@ -110,13 +111,15 @@ type NetworkPolicyEnforcer struct {
 	sigNames   map[uint16]string           // signature id -> name, for audit labelling (built at populate)
 	sigLoaded  string                      // last applied signature set fingerprint (skip redundant map writes)
 
-	mu                sync.Mutex
-	attached          map[int]string                      // ifindex -> container name currently attached
-	idName            map[uint32]string                   // tenant id -> name (for audit/log)
-	enforced          map[uint32]bool                     // tenant ids whose effective mode is ENFORCE (deny == dropped)
-	egressInstalled   map[netbpf.EgressEntry]bool         // egress LPM entries currently in the map
-	denyInstalled     map[netbpf.DenyKey]netbpf.DenyEntry // virtual-patch deny entries currently in the map (#660)
-	ipTenantInstalled map[[4]byte]uint32                  // ip_tenant entries currently in the map (#923: converge deletes)
+	mu                 sync.Mutex
+	attached           map[int]string                                 // ifindex -> container name currently attached
+	idName             map[uint32]string                              // tenant (or box policy) id -> tenant name (for audit/log)
+	enforced           map[int]bool                                   // veth ifindexes whose effective mode is ENFORCE (deny == dropped)
+	egressInstalled    map[netbpf.EgressEntry]bool                    // egress LPM entries currently in the map
+	denyInstalled      map[netbpf.DenyKey]netbpf.DenyEntry            // virtual-patch deny entries currently in the map (#660)
+	ipTenantInstalled  map[[4]byte]uint32                             // ip_tenant entries currently in the map (#923: converge deletes)
+	ipTenant6Installed map[[16]byte]uint32                            // ip_tenant6 entries currently in the map
+	portsInstalled     map[netbpf.EgressEntry]netbpf.EgressPortsEntry // port-scoped allow entries currently in the map
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -125,25 +128,27 @@ type NetworkPolicyEnforcer struct {
 
 func NewNetworkPolicyEnforcer(objPath string, store NetworkPolicyStore, registry TenantRegistry, insp containerInspector, auditStore *audit.Store, bus *events.Bus, enforceEnabled bool) *NetworkPolicyEnforcer {
 	return &NetworkPolicyEnforcer{
-		objPath:           objPath,
-		store:             store,
-		registry:          registry,
-		insp:              insp,
-		audit:             auditStore,
-		bus:               bus,
-		interval:          defaultNetPolicyReconcileInterval,
-		enforceEnabled:    enforceEnabled,
-		resolver:          NewDomainResolver(nil),
-		domainRefresh:     defaultDomainRefreshInterval,
-		flowPoll:          defaultFlowPollInterval,
-		flowIdleTimeout:   defaultFlowIdleTimeout,
-		vethCache:         make(map[string]string),
-		attached:          make(map[int]string),
-		idName:            make(map[uint32]string),
-		enforced:          make(map[uint32]bool),
-		egressInstalled:   make(map[netbpf.EgressEntry]bool),
-		denyInstalled:     make(map[netbpf.DenyKey]netbpf.DenyEntry),
-		ipTenantInstalled: make(map[[4]byte]uint32),
+		objPath:            objPath,
+		store:              store,
+		registry:           registry,
+		insp:               insp,
+		audit:              auditStore,
+		bus:                bus,
+		interval:           defaultNetPolicyReconcileInterval,
+		enforceEnabled:     enforceEnabled,
+		resolver:           NewDomainResolver(nil),
+		domainRefresh:      defaultDomainRefreshInterval,
+		flowPoll:           defaultFlowPollInterval,
+		flowIdleTimeout:    defaultFlowIdleTimeout,
+		vethCache:          make(map[string]string),
+		attached:           make(map[int]string),
+		idName:             make(map[uint32]string),
+		enforced:           make(map[int]bool),
+		egressInstalled:    make(map[netbpf.EgressEntry]bool),
+		denyInstalled:      make(map[netbpf.DenyKey]netbpf.DenyEntry),
+		ipTenantInstalled:  make(map[[4]byte]uint32),
+		ipTenant6Installed: make(map[[16]byte]uint32),
+		portsInstalled:     make(map[netbpf.EgressEntry]netbpf.EgressPortsEntry),
 	}
 }
 
@@ -159,6 +164,11 @@ func (e *NetworkPolicyEnforcer) SetFlowSink(s FlowSink) { e.flowSink = s }
 // so it only runs when the operator opts in; drops still require enforce mode.
 func (e *NetworkPolicyEnforcer) SetSignaturesEnabled(on bool) { e.sigEnabled = on }
 
+// SetBoxPolicyStore wires the label-selected box policy store, so a box a
+// policy selects is policed by it instead of its tenant-wide policy. Must be
+// called before Start. Nil leaves every box on its tenant-wide policy.
+func (e *NetworkPolicyEnforcer) SetBoxPolicyStore(s BoxNetworkPolicyStore) { e.boxStore = s }
+
 // SetSignatureStore wires the operator-signature store (#661 PR-B) so the
 // enforcer merges operator signatures with the built-ins. Must be called before
 // Start. Nil leaves built-ins only.
@@ -414,10 +424,10 @@ func (e *NetworkPolicyEnforcer) Stop() {
 func (e *NetworkPolicyEnforcer) OnDenyEvent(ctx context.Context, ev netbpf.DenyEvent) {
 	e.mu.Lock()
 	tenant := e.idName[ev.TenantID]
-	dropped := e.enforced[ev.TenantID]
+	dropped := e.enforced[int(ev.Ifindex)]
 	e.mu.Unlock()
 	// Always log the denied flow. The program emits the event whether or not it
-	// drops, so the action depends on the tenant's effective mode: a DROP under
+	// drops, so the action depends on the veth's effective mode: a DROP under
 	// enforce, an observation under log_only. This line is the operator's signal.
 	action := "log_only (not dropped)"
 	if dropped {
@@ -425,12 +435,15 @@ func (e *NetworkPolicyEnforcer) OnDenyEvent(ctx context.Context, ev netbpf.DenyE
 	}
 	vpatch := ev.Reason == netbpf.DenyReasonVirtualPatch
 	signature := ev.Reason == netbpf.DenyReasonSignature
+	unparsed := ev.Reason == netbpf.DenyReasonUnparsed
 	kind := "deny"
 	switch {
 	case vpatch:
 		kind = "virtual-patch"
 	case signature:
 		kind = "signature"
+	case unparsed:
+		kind = "unparsed-ipv6"
 	}
 	var sigName string
 	if signature {
@@ -459,14 +472,17 @@ func (e *NetworkPolicyEnforcer) OnDenyEvent(ctx context.Context, ev netbpf.DenyE
 			`,"sig_name":"` + sigName + `","dropped":` + boolStr(dropped) + `}`
 	}
 	// Each deny reason gets its own audit action so an operator can tell a blocked
-	// vulnerable destination (#660) and a blocked inbound exploit (#661) apart from
-	// a routine allow-list miss.
+	// vulnerable destination (#660), a blocked inbound exploit (#661) and an IPv6
+	// header chain the program would not police blind apart from a routine
+	// allow-list miss.
 	var action2 string
 	switch {
 	case signature:
 		action2 = "network_policy.signature_match"
 	case vpatch:
 		action2 = "network_policy.virtual_patch"
+	case unparsed:
+		action2 = "network_policy.unparsed_ipv6"
 	case dropped:
 		action2 = "network_policy.deny_dropped"
 	default:
@@ -628,7 +644,22 @@ func (e *NetworkPolicyEnforcer) reconcile(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	boxes, err := e.compiledBoxPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	for id, tenant := range selectBoxPolicies(views, boxes, func(name string) (uint32, error) { return e.registry.ID(ctx, name) }) {
+		idName[id] = tenant
+	}
 	plan := planReconcile(views, policies, e.enforceEnabled)
+	// An object predating policy_cfg.policy_id can't point a veth at a box
+	// policy's entries; tag those veths with the box policy's id instead,
+	// which keeps the box policy but isolates the box from its peers.
+	if !e.loader.HasBoxPolicies() {
+		if n := plan.foldBoxPolicies(); n > 0 {
+			log.Printf("[netpolicy] %d box(es) under a box policy but loaded BPF object predates policy_cfg.policy_id: policing each as its own tenant (same-tenant peers denied); rebuild netpolicy.bpf.o", n)
+		}
+	}
 
 	// Tier 2 (#661 PR-B): pick up operator signature add/remove/toggle. A no-op
 	// when the set is unchanged, so this is cheap every pass.
@@ -641,6 +672,14 @@ func (e *NetworkPolicyEnforcer) reconcile(ctx context.Context) error {
 	// since-freed or re-purposed IP as a same-tenant peer until a daemon restart
 	// rebuilt the maps (#923).
 	applyIPTenant(e.ipTenantInstalled, plan.ipTenant, e.loader)
+	// IPv6 rides in its own maps. An object built before them passes v6
+	// unpoliced, so its v6 entries are dropped (and said so) rather than failing
+	// one by one below.
+	if e.loader.HasIPv6() {
+		applyIPTenant(e.ipTenant6Installed, plan.ipTenant6, ipTenant6Applier{e.loader})
+	} else if ne, nd := plan.dropIPv6(); ne+nd > 0 {
+		log.Printf("[netpolicy] %d IPv6 egress and %d IPv6 deny entries configured but loaded BPF object lacks the 'egress_cidr6'/'ip_tenant6' maps (rebuild netpolicy.bpf.o to police IPv6)", ne, nd)
+	}
 	// egress allow-list: converge the map (add new, delete stale). Deleting
 	// removed CIDRs is what makes a tightened policy actually take effect in
 	// enforce mode.
@@ -668,14 +707,22 @@ func (e *NetworkPolicyEnforcer) reconcile(ctx context.Context) error {
 	} else if len(plan.deny) > 0 {
 		log.Printf("[netpolicy] %d virtual-patch deny rule(s) configured but loaded BPF object lacks the 'deny_cidr' map (rebuild netpolicy.bpf.o to enable #660)", len(plan.deny))
 	}
-	// Per-veth config + attach. Track which tenants end up in effective-enforce
+	// Port/protocol-scoped allow rules: the same convergence, when the object
+	// carries egress_ports. Without it the rules stay uninstalled, so their
+	// traffic is denied in enforce mode — narrower than the policy, never wider.
+	if e.loader.HasEgressPorts() {
+		applyEgressPorts(e.portsInstalled, plan.ports, e.loader)
+	} else if len(plan.ports) > 0 {
+		log.Printf("[netpolicy] %d port-scoped egress rule(s) configured but loaded BPF object lacks the 'egress_ports' map (rebuild netpolicy.bpf.o to install them)", len(plan.ports))
+	}
+	// Per-veth config + attach. Track which veths end up in effective-enforce
 	// mode, so OnDenyEvent can label a denied flow as dropped vs observed.
-	enforced := make(map[uint32]bool)
+	enforced := make(map[int]bool)
 	e.mu.Lock()
 	present := make(map[int]bool, len(plan.vethPolicy))
 	for ifindex, cfg := range plan.vethPolicy {
 		if cfg.Mode == netbpf.ModeEnforce {
-			enforced[cfg.TenantID] = true
+			enforced[ifindex] = true
 		}
 		if err := e.loader.SetVethPolicy(ifindex, cfg); err != nil {
 			log.Printf("[netpolicy] set veth_policy ifindex %d: %v", ifindex, err)
@@ -749,11 +796,15 @@ func (e *NetworkPolicyEnforcer) gather(_ context.Context) ([]containerView, map[
 			continue
 		}
 		idName[tid] = tenant
-		v := containerView{Name: c.Name, Tenant: tenant, TenantID: tid}
+		v := containerView{Name: c.Name, Tenant: tenant, TenantID: tid, Labels: c.Labels}
 		if ip, err := netip.ParseAddr(c.IPAddress); err == nil && ip.Is4() {
 			v.IP = ip.As4()
 			v.HasIP = true
 		}
+		if ip, err := netip.ParseAddr(c.IPv6Address); err == nil && ip.Is6() && !ip.Is4In6() {
+			v.IP6 = ip.As16()
+			v.HasIP6 = true
+		}
 		if strings.EqualFold(c.State, "running") {
 			v.Running = true
 			running[c.Name] = true
@@ -817,24 +868,56 @@ func (e *NetworkPolicyEnforcer) compiledPolicies(ctx context.Context) (map[strin
 			log.Printf("[netpolicy] compile policy for %q: %v", p.GetTenant(), err)
 			continue
 		}
-		// Phase C: fold each egress_domain's currently-resolved IPs into the
-		// allow-list as /32 CIDRs. planReconcile/CompileEgress then treat them as
-		// ordinary egress entries; diffEgress prunes IPs a domain stopped
-		// resolving to on the next pass.
-		for _, dom := range c.EgressDomains {
-			for _, ip := range e.resolver.IPs(dom) {
-				c.EgressCIDRs = append(c.EgressCIDRs, netip.PrefixFrom(ip, 32))
-			}
-		}
-		// Virtual-patch deny rules (#660): drop any whose expiry has passed so an
-		// expired patch self-removes from the kernel on the next reconcile. Done
-		// here (not in the pure netpolicy/plan layer) so those stay time-free.
-		c.DenyRules = activeDenyRules(c.DenyRules, time.Now())
+		e.resolvePolicy(&c)
 		out[c.Tenant] = c
 	}
 	return out, nil
 }
 
+// compiledBoxPolicies loads + compiles every stored box policy, grouped by
+// tenant, resolved the same way as the tenant-wide ones.
+func (e *NetworkPolicyEnforcer) compiledBoxPolicies(ctx context.Context) (map[string][]netpolicy.CompiledBoxPolicy, error) {
+	if e.boxStore == nil {
+		return nil, nil
+	}
+	stored, err := e.boxStore.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]netpolicy.CompiledBoxPolicy)
+	for _, p := range stored {
+		b, err := netpolicy.CompileBox(p)
+		if err != nil {
+			log.Printf("[netpolicy] compile box policy %s/%s: %v", p.GetTenant(), p.GetName(), err)
+			continue
+		}
+		e.resolvePolicy(&b.Policy)
+		out[b.Policy.Tenant] = append(out[b.Policy.Tenant], b)
+	}
+	return out, nil
+}
+
+// resolvePolicy readies a compiled policy for planning: domains become
+// addresses and expired deny rules are dropped.
+func (e *NetworkPolicyEnforcer) resolvePolicy(c *netpolicy.CompiledPolicy) {
+	// Phase C: fold each egress_domain's currently-resolved IPs into the
+	// allow-list as host CIDRs (/32, or /128 for an AAAA answer).
+	// planReconcile/CompileEgress then treat them as
+	// ordinary egress entries; diffEgress prunes IPs a domain stopped
+	// resolving to on the next pass.
+	for _, dom := range c.EgressDomains {
+		for _, ip := range e.resolver.IPs(dom) {
+			c.EgressCIDRs = append(c.EgressCIDRs, netip.PrefixFrom(ip, ip.BitLen()))
+		}
+	}
+	// Port-scoped domain rules fold the same way, keeping their scope.
+	c.EgressRules = resolveEgressRules(c.EgressRules, e.resolver.IPs)
+	// Virtual-patch deny rules (#660): drop any whose expiry has passed so an
+	// expired patch self-removes from the kernel on the next reconcile. Done
+	// here (not in the pure netpolicy/plan layer) so those stay time-free.
+	c.DenyRules = activeDenyRules(c.DenyRules, time.Now())
+}
+
 // refreshDomains re-resolves every egress_domain across all stored policies into
 // the resolver cache. Best-effort: store/lookup errors are logged, not fatal.
 func (e *NetworkPolicyEnforcer) refreshDomains() {
@@ -843,9 +926,23 @@ func (e *NetworkPolicyEnforcer) refreshDomains() {
 		log.Printf("[netpolicy] domain refresh: list policies: %v", err)
 		return
 	}
+	if e.boxStore != nil {
+		boxes, err := e.boxStore.List(e.ctx, "")
+		if err != nil {
+			log.Printf("[netpolicy] domain refresh: list box policies: %v", err)
+		}
+		for _, b := range boxes {
+			stored = append(stored, b.GetPolicy())
+		}
+	}
 	var domains []string
 	for _, p := range stored {
 		domains = append(domains, p.GetEgressDomains()...)
+		for _, r := range p.GetEgressRules() {
+			if d := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(r.GetDomain()), ".")); d != "" {
+				domains = append(domains, d)
+			}
+		}
 	}
 	if len(domains) == 0 {
 		return
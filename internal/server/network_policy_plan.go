@@ -2,6 +2,7 @@ package server
 
 import (
 	"log"
+	"net/netip"
 	"strconv"
 	"time"
 
@@ -12,8 +13,8 @@ import (
 )
 
 // containerView is a reconcile-time snapshot of one managed container: its
-// tenant, assigned tenant ID, IPv4 (if resolved), and host veth ifindex (if
-// running + resolved). gather() builds these (Linux veth resolution);
+// tenant, assigned tenant ID, IPv4 and IPv6 (if resolved), and host veth
+// ifindex (if running + resolved). gather() builds these (Linux veth resolution);
 // planReconcile() turns them + the compiled policies into BPF map entries.
 type containerView struct {
 	Name     string
@@ -21,25 +22,39 @@ type containerView struct {
 	TenantID uint32
 	IP       [4]byte
 	HasIP    bool
+	IP6      [16]byte
+	HasIP6   bool
 	Ifindex  int
 	HasVeth  bool
 	Running  bool
+	Labels   map[string]string // container labels, for box policy selectors
+
+	// Box is the label-selected box policy that polices the container instead
+	// of its tenant-wide policy (nil = tenant-wide), and PolicyID the registry
+	// id its BPF entries are keyed by. Set by selectBoxPolicies.
+	Box      *netpolicy.CompiledBoxPolicy
+	PolicyID uint32
 }
 
 // reconcilePlan is the desired BPF map state for one reconcile pass — pure data
 // so planReconcile is unit-testable without a kernel.
 type reconcilePlan struct {
 	ipTenant   map[[4]byte]uint32          // container IP -> tenant id
+	ipTenant6  map[[16]byte]uint32         // container IPv6 -> tenant id
 	vethPolicy map[int]netbpf.PolicyConfig // running container veth ifindex -> policy config
 	ifName     map[int]string              // ifindex -> container name (for bookkeeping)
 	egress     []netbpf.EgressEntry        // per-tenant egress allow-list entries
 	deny       []netbpf.DenyEntry          // per-tenant virtual-patch deny entries (#660)
+	ports      []netbpf.EgressPortsEntry   // per-tenant port/protocol-scoped allow entries
 }
 
 // planReconcile computes the desired BPF map state from the current container
 // views and the compiled per-tenant policies. A container with no stored policy
 // gets the Phase A default (log-only, no intra-tenant, empty egress) so its
-// outbound is observed rather than silently unmanaged.
+// outbound is observed rather than silently unmanaged. A container a box
+// policy selects (view.Box) is policed by that policy, plus its tenant's
+// virtual patches, under the box policy's own id; its ip_tenant tag and its
+// peers' view of it stay the tenant's.
 //
 // enforceEnabled is the daemon-wide safety guard (Phase B): when false, a
 // policy's ENFORCE mode is downgraded to LOG_ONLY before it reaches the kernel,
@@ -51,18 +66,31 @@ type reconcilePlan struct {
 func planReconcile(views []containerView, policies map[string]netpolicy.CompiledPolicy, enforceEnabled bool) reconcilePlan {
 	plan := reconcilePlan{
 		ipTenant:   make(map[[4]byte]uint32),
+		ipTenant6:  make(map[[16]byte]uint32),
 		vethPolicy: make(map[int]netbpf.PolicyConfig),
 		ifName:     make(map[int]string),
 	}
-	// egress entries are per tenant, not per container — emit each tenant's set
-	// once, keyed by the tenant IDs we actually saw.
+	// egress entries are per policy, not per container — emit each tenant's
+	// (or box policy's) set once, keyed by the ids we actually saw.
 	egressDone := make(map[uint32]bool)
 
 	for _, v := range views {
 		if v.HasIP {
 			plan.ipTenant[v.IP] = v.TenantID
 		}
+		if v.HasIP6 {
+			plan.ipTenant6[v.IP6] = v.TenantID
+		}
 		policy, hasPolicy := policies[v.Tenant]
+		policyID := v.TenantID
+		if v.Box != nil {
+			var tenantWide *netpolicy.CompiledPolicy
+			if hasPolicy {
+				tenantWide = &policy
+			}
+			policy, hasPolicy = v.Box.Effective(tenantWide), true
+			policyID = v.PolicyID
+		}
 
 		if v.Running && v.HasVeth {
 			var cfg netbpf.PolicyConfig
@@ -71,6 +99,9 @@ func planReconcile(views []containerView, policies map[string]netpolicy.Compiled
 			} else {
 				cfg = netbpf.PolicyConfig{TenantID: v.TenantID, Mode: netbpf.ModeLogOnly}
 			}
+			if v.Box != nil {
+				cfg.PolicyID = policyID
+			}
 			// Safety guard: enforcement only drops when armed daemon-wide.
 			if cfg.Mode == netbpf.ModeEnforce && !enforceEnabled {
 				cfg.Mode = netbpf.ModeLogOnly
@@ -79,22 +110,148 @@ func planReconcile(views []containerView, policies map[string]netpolicy.Compiled
 			plan.ifName[v.Ifindex] = v.Name
 		}
 
-		if hasPolicy && !egressDone[v.TenantID] {
-			if entries, err := netbpf.CompileEgress(v.TenantID, policy); err == nil {
+		if hasPolicy && !egressDone[policyID] {
+			if entries, err := netbpf.CompileEgress(policyID, policy); err == nil {
 				plan.egress = append(plan.egress, entries...)
 			}
-			// Virtual-patch deny entries (#660), once per tenant. Expired rules are
+			// Virtual-patch deny entries (#660), once per policy. Expired rules are
 			// already filtered out of policy.DenyRules by the daemon (compiledPolicies)
 			// before planning, so anything here is active.
-			if entries, err := netbpf.CompileDeny(v.TenantID, policy); err == nil {
+			if entries, err := netbpf.CompileDeny(policyID, policy); err == nil {
 				plan.deny = append(plan.deny, entries...)
 			}
-			egressDone[v.TenantID] = true
+			// Port-scoped allow rules. An error (a prefix needing more port
+			// ranges than the map value holds) leaves the policy's scoped rules
+			// out, which fails closed; say why, since nothing else will. Set
+			// refuses such a policy (checkPortRanges), so only resolved domain
+			// rules can still get here.
+			if entries, err := netbpf.CompileEgressPorts(policyID, policy); err == nil {
+				plan.ports = append(plan.ports, entries...)
+			} else {
+				log.Printf("[netpolicy] egress rules for %q not installed: %v", policyLabel(v), err)
+			}
+			egressDone[policyID] = true
 		}
 	}
 	return plan
 }
 
+// policyLabel names the policy that polices a view, for logs: the tenant, or
+// tenant/box-name for a box policy.
+func policyLabel(v containerView) string {
+	if v.Box != nil {
+		return v.Tenant + "/" + v.Box.Name
+	}
+	return v.Tenant
+}
+
+// boxPolicyRegistryName is the tenant-registry name a box policy's id is
+// assigned under. The prefix keeps it clear of the tenant names the registry
+// otherwise holds: container name prefixes, org ids and tenant labels.
+func boxPolicyRegistryName(tenant, name string) string {
+	return "box-policy:" + tenant + "/" + name
+}
+
+// selectBoxPolicies points each view at the box policy of its tenant that
+// selects it, if any, with the id that policy's BPF entries are keyed by. id
+// assigns ids by registry name (the tenant registry). A policy whose id
+// cannot be assigned leaves its boxes on the tenant-wide policy, logged. It
+// returns the id -> tenant entries to add to the audit sink's map, so a deny
+// event under a box policy's id (see foldBoxPolicies) still names the tenant.
+func selectBoxPolicies(views []containerView, boxes map[string][]netpolicy.CompiledBoxPolicy, id func(name string) (uint32, error)) map[uint32]string {
+	ids := make(map[uint32]string)
+	for i := range views {
+		v := &views[i]
+		b := netpolicy.SelectBoxPolicy(boxes[v.Tenant], v.Labels)
+		if b == nil {
+			continue
+		}
+		pid, err := id(boxPolicyRegistryName(v.Tenant, b.Name))
+		if err != nil {
+			log.Printf("[netpolicy] box policy %s/%s: assign id: %v (its boxes keep the tenant-wide policy)", v.Tenant, b.Name, err)
+			continue
+		}
+		v.Box, v.PolicyID = b, pid
+		ids[pid] = v.Tenant
+	}
+	return ids
+}
+
+// foldBoxPolicies rewrites the plan for a loaded BPF object that predates
+// policy_cfg.policy_id: a box-policy veth is tagged with the box policy's id
+// as its tenant, so the kernel still finds that policy's entries. It fails
+// closed — the box is then a tenant of its own to the program, so its
+// same-tenant peers are denied — and reports how many veths it rewrote.
+func (p *reconcilePlan) foldBoxPolicies() int {
+	n := 0
+	for ifindex, cfg := range p.vethPolicy {
+		if cfg.PolicyID == 0 {
+			continue
+		}
+		cfg.TenantID, cfg.PolicyID = cfg.PolicyID, 0
+		p.vethPolicy[ifindex] = cfg
+		n++
+	}
+	return n
+}
+
+// dropIPv6 strips the plan's IPv6 egress, deny and port-scoped entries and
+// ip_tenant6 tags, for a loaded BPF object that predates the v6 maps, and
+// reports how many policy entries it dropped (port-scoped ones count as
+// egress). Such an object passes v6 traffic unpoliced, so there is nothing to
+// install them into.
+func (p *reconcilePlan) dropIPv6() (egress, deny int) {
+	keptE := p.egress[:0]
+	for _, e := range p.egress {
+		if e.IPv6 {
+			egress++
+			continue
+		}
+		keptE = append(keptE, e)
+	}
+	p.egress = keptE
+	keptD := p.deny[:0]
+	for _, d := range p.deny {
+		if d.IPv6 {
+			deny++
+			continue
+		}
+		keptD = append(keptD, d)
+	}
+	p.deny = keptD
+	keptP := p.ports[:0]
+	for _, e := range p.ports {
+		if e.Key.IPv6 {
+			egress++
+			continue
+		}
+		keptP = append(keptP, e)
+	}
+	p.ports = keptP
+	clear(p.ipTenant6)
+	return egress, deny
+}
+
+// resolveEgressRules replaces each port-scoped domain rule with one host-prefix
+// CIDR rule per address ips currently resolves it to, scope unchanged; CIDR
+// rules pass through. A domain that resolves to nothing contributes nothing.
+func resolveEgressRules(rules []netpolicy.EgressRule, ips func(domain string) []netip.Addr) []netpolicy.EgressRule {
+	out := make([]netpolicy.EgressRule, 0, len(rules))
+	for _, r := range rules {
+		if r.Domain == "" {
+			out = append(out, r)
+			continue
+		}
+		for _, ip := range ips(r.Domain) {
+			h := r
+			h.Domain = ""
+			h.CIDR = netip.PrefixFrom(ip, ip.BitLen())
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
 // subEvents returns the subscriber's event channel, or a nil channel (which
 // blocks forever in a select) when there is no subscriber.
 func subEvents(sub *events.Subscriber) <-chan *pb.Event {
@@ -170,6 +327,53 @@ func diffDeny(installed map[netbpf.DenyKey]netbpf.DenyEntry, desired []netbpf.De
 	return toUpsert, toDel
 }
 
+// diffEgressPorts is diffDeny for the port-scoped allow entries: keyed by the
+// LPM prefix key, upserted when new or when the port list changed, deleted
+// when no desired entry uses the key.
+func diffEgressPorts(installed map[netbpf.EgressEntry]netbpf.EgressPortsEntry, desired []netbpf.EgressPortsEntry) (toUpsert []netbpf.EgressPortsEntry, toDel []netbpf.EgressEntry) {
+	desiredKeys := make(map[netbpf.EgressEntry]bool, len(desired))
+	for _, e := range desired {
+		desiredKeys[e.Key] = true
+		if cur, ok := installed[e.Key]; !ok || cur != e {
+			toUpsert = append(toUpsert, e)
+		}
+	}
+	for k := range installed {
+		if !desiredKeys[k] {
+			toDel = append(toDel, k)
+		}
+	}
+	return toUpsert, toDel
+}
+
+// egressPortsApplier is the slice of the BPF loader the port-scoped allow
+// reconcile needs; *netbpf.Loader satisfies it.
+type egressPortsApplier interface {
+	AddEgressPorts(netbpf.EgressPortsEntry) error
+	DeleteEgressPorts(netbpf.EgressEntry) error
+}
+
+// applyEgressPorts converges egress_ports(6) from installed to desired with
+// applyDeny's semantics: upserts before deletes, and a failed op leaves
+// installed untouched so the next reconcile retries it.
+func applyEgressPorts(installed map[netbpf.EgressEntry]netbpf.EgressPortsEntry, desired []netbpf.EgressPortsEntry, a egressPortsApplier) {
+	upsert, del := diffEgressPorts(installed, desired)
+	for _, e := range upsert {
+		if err := a.AddEgressPorts(e); err != nil {
+			log.Printf("[netpolicy] add egress ports: %v", err)
+			continue
+		}
+		installed[e.Key] = e
+	}
+	for _, k := range del {
+		if err := a.DeleteEgressPorts(k); err != nil {
+			log.Printf("[netpolicy] delete egress ports: %v", err)
+			continue
+		}
+		delete(installed, k)
+	}
+}
+
 // denyApplier is the slice of the BPF loader the deny-rule reconcile needs.
 // *netbpf.Loader satisfies it; an interface keeps applyDeny testable without a
 // kernel.
@@ -209,9 +413,10 @@ func applyDeny(installed map[netbpf.DenyKey]netbpf.DenyEntry, desired []netbpf.D
 // is deleted when no desired entry uses that IP. Deleting stale keys is what
 // makes a freed or excluded IP actually stop being treated as a same-tenant
 // peer — without it the map is add-only and a stale tag survives until a daemon
-// restart rebuilds the maps.
-func diffIPTenant(installed, desired map[[4]byte]uint32) (toSet map[[4]byte]uint32, toDel [][4]byte) {
-	toSet = make(map[[4]byte]uint32)
+// restart rebuilds the maps. K is the address: [4]byte for ip_tenant, [16]byte
+// for ip_tenant6.
+func diffIPTenant[K comparable](installed, desired map[K]uint32) (toSet map[K]uint32, toDel []K) {
+	toSet = make(map[K]uint32)
 	for ip, tid := range desired {
 		if cur, ok := installed[ip]; !ok || cur != tid {
 			toSet[ip] = tid
@@ -226,20 +431,30 @@ func diffIPTenant(installed, desired map[[4]byte]uint32) (toSet map[[4]byte]uint
 }
 
 // ipTenantApplier is the slice of the BPF loader the ip_tenant reconcile needs.
-// *netbpf.Loader satisfies it; an interface keeps applyIPTenant testable without
-// a kernel.
-type ipTenantApplier interface {
-	SetIPTenant(ip [4]byte, tenantID uint32) error
-	DeleteIPTenant(ip [4]byte) error
+// *netbpf.Loader satisfies it for [4]byte (and ipTenant6Applier adapts it for
+// [16]byte); an interface keeps applyIPTenant testable without a kernel.
+type ipTenantApplier[K comparable] interface {
+	SetIPTenant(ip K, tenantID uint32) error
+	DeleteIPTenant(ip K) error
 }
 
+// ipTenant6Applier drives the loader's ip_tenant6 map through the
+// ipTenantApplier[[16]byte] shape.
+type ipTenant6Applier struct{ l *netbpf.Loader }
+
+func (a ipTenant6Applier) SetIPTenant(ip [16]byte, tenantID uint32) error {
+	return a.l.SetIPTenant6(ip, tenantID)
+}
+
+func (a ipTenant6Applier) DeleteIPTenant(ip [16]byte) error { return a.l.DeleteIPTenant6(ip) }
+
 // applyIPTenant converges the kernel ip_tenant map from installed to desired via
 // the applier, updating installed in place (#923). Sets apply BEFORE deletes;
 // diffIPTenant guarantees the two sets never share a key, so a just-set entry is
 // never immediately deleted. A failed op is logged and skipped WITHOUT touching
 // installed, so the next reconcile retries it rather than recording a state the
 // kernel doesn't hold.
-func applyIPTenant(installed, desired map[[4]byte]uint32, a ipTenantApplier) {
+func applyIPTenant[K comparable](installed, desired map[K]uint32, a ipTenantApplier[K]) {
 	set, del := diffIPTenant(installed, desired)
 	for ip, tid := range set {
 		if err := a.SetIPTenant(ip, tid); err != nil {
@@ -111,6 +111,37 @@ func TestPlanReconcile(t *testing.T) {
 	}
 }
 
+// A dual-stack tenant gets both ip_tenant tags and both families of egress
+// and deny entries; dropIPv6 leaves a v4-only plan for an older object.
+func TestPlanReconcile_IPv6(t *testing.T) {
+	policies := map[string]netpolicy.CompiledPolicy{
+		"alice": compiled(t, &pb.NetworkPolicy{
+			Tenant:      "alice",
+			EgressCidrs: []string{"8.8.8.8/32", "2001:4860::/32"},
+			DenyRules:   []*pb.NetworkPolicyDenyRule{{Cidr: "2001:db8::1"}},
+		}),
+	}
+	ip6 := [16]byte{0xfd, 0x42, 15: 0x0a}
+	views := []containerView{
+		{Name: "alice-container", Tenant: "alice", TenantID: 1, IP: [4]byte{10, 100, 0, 10}, HasIP: true, IP6: ip6, HasIP6: true, Ifindex: 11, HasVeth: true, Running: true},
+	}
+	plan := planReconcile(views, policies, true)
+	if plan.ipTenant6[ip6] != 1 || plan.ipTenant[[4]byte{10, 100, 0, 10}] != 1 {
+		t.Fatalf("ip tags = %v / %v, want both families tagged tenant 1", plan.ipTenant, plan.ipTenant6)
+	}
+	if len(plan.egress) != 2 || len(plan.deny) != 1 {
+		t.Fatalf("egress %v deny %v, want 2 egress + 1 deny", plan.egress, plan.deny)
+	}
+
+	ne, nd := plan.dropIPv6()
+	if ne != 1 || nd != 1 {
+		t.Errorf("dropIPv6 = %d, %d; want 1, 1", ne, nd)
+	}
+	if len(plan.egress) != 1 || plan.egress[0].IPv6 || len(plan.deny) != 0 || len(plan.ipTenant6) != 0 {
+		t.Errorf("after dropIPv6: egress %v deny %v ipTenant6 %v", plan.egress, plan.deny, plan.ipTenant6)
+	}
+}
+
 func TestPlanReconcile_EnforceGuard(t *testing.T) {
 	policies := map[string]netpolicy.CompiledPolicy{
 		"alice": compiled(t, &pb.NetworkPolicy{
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/netbpf"
+	"github.com/footprintai/containarium/internal/netpolicy"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// fakePortsApplier records AddEgressPorts/DeleteEgressPorts calls and can be
+// told to fail an add for a given key.
+type fakePortsApplier struct {
+	added   []netbpf.EgressPortsEntry
+	deleted []netbpf.EgressEntry
+	failAdd map[netbpf.EgressEntry]bool
+}
+
+func (f *fakePortsApplier) AddEgressPorts(e netbpf.EgressPortsEntry) error {
+	if f.failAdd[e.Key] {
+		return fmt.Errorf("inject add failure")
+	}
+	f.added = append(f.added, e)
+	return nil
+}
+
+func (f *fakePortsApplier) DeleteEgressPorts(k netbpf.EgressEntry) error {
+	f.deleted = append(f.deleted, k)
+	return nil
+}
+
+func TestPlanReconcile_EgressRules(t *testing.T) {
+	policies := map[string]netpolicy.CompiledPolicy{
+		"alice": compiled(t, &pb.NetworkPolicy{
+			Tenant:      "alice",
+			EgressCidrs: []string{"8.8.8.8/32"},
+			EgressRules: []*pb.NetworkPolicyEgressRule{{Cidr: "10.0.0.0/8", Proto: "tcp", Port: 5432}},
+		}),
+	}
+	views := []containerView{
+		{Name: "alice-container", Tenant: "alice", TenantID: 1, Ifindex: 11, HasVeth: true, Running: true},
+		{Name: "alice-web-container", Tenant: "alice", TenantID: 1, Ifindex: 12, HasVeth: true, Running: true},
+	}
+	plan := planReconcile(views, policies, true)
+	if len(plan.egress) != 1 || len(plan.ports) != 1 {
+		t.Fatalf("egress %+v ports %+v, want one of each (once per tenant)", plan.egress, plan.ports)
+	}
+	p := plan.ports[0]
+	if p.Key.PrefixLen != 32+8 || p.Key.Addr != [4]byte{10} || p.N != 1 || p.Rules[0] != (netbpf.PortRule{Lo: 5432, Hi: 5432, Proto: 6}) {
+		t.Errorf("ports entry = %+v", p)
+	}
+}
+
+// A domain rule becomes one host rule per resolved address, scope intact; an
+// unresolved domain contributes nothing (fail closed).
+func TestResolveEgressRules(t *testing.T) {
+	rules := []netpolicy.EgressRule{
+		{CIDR: netip.MustParsePrefix("10.0.0.0/8"), Proto: 6, PortLo: 5432, PortHi: 5432},
+		{Domain: "api.github.com", Proto: 6, PortLo: 443, PortHi: 443},
+		{Domain: "gone.example", Proto: 17, PortLo: 53, PortHi: 53},
+	}
+	ips := func(d string) []netip.Addr {
+		if d == "api.github.com" {
+			return addrs("140.82.112.3", "2606:50c0::1")
+		}
+		return nil
+	}
+	got := resolveEgressRules(rules, ips)
+	want := []netpolicy.EgressRule{
+		rules[0],
+		{CIDR: netip.MustParsePrefix("140.82.112.3/32"), Proto: 6, PortLo: 443, PortHi: 443},
+		{CIDR: netip.MustParsePrefix("2606:50c0::1/128"), Proto: 6, PortLo: 443, PortHi: 443},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rule %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestApplyEgressPorts(t *testing.T) {
+	k1 := netbpf.EgressEntry{PrefixLen: 40, TenantID: 1, Addr: [4]byte{10}}
+	k2 := netbpf.EgressEntry{PrefixLen: 64, TenantID: 1, Addr: [4]byte{1, 1, 1, 1}}
+	e1 := netbpf.EgressPortsEntry{Key: k1, N: 1, Rules: [netbpf.MaxPortRules]netbpf.PortRule{{Lo: 5432, Hi: 5432, Proto: 6}}}
+	e1b := e1
+	e1b.Rules[0].Hi = 5433
+	e2 := netbpf.EgressPortsEntry{Key: k2, N: 1, Rules: [netbpf.MaxPortRules]netbpf.PortRule{{Lo: 53, Hi: 53, Proto: 17}}}
+
+	installed := map[netbpf.EgressEntry]netbpf.EgressPortsEntry{}
+	f := &fakePortsApplier{}
+	applyEgressPorts(installed, []netbpf.EgressPortsEntry{e1, e2}, f)
+	if len(f.added) != 2 || len(installed) != 2 {
+		t.Fatalf("first apply: added %d installed %d", len(f.added), len(installed))
+	}
+
+	// Steady state is a no-op; a changed range upserts the same key; a
+	// dropped rule deletes its key.
+	f = &fakePortsApplier{}
+	applyEgressPorts(installed, []netbpf.EgressPortsEntry{e1, e2}, f)
+	if len(f.added)+len(f.deleted) != 0 {
+		t.Fatalf("steady state touched the map: %+v", f)
+	}
+	f = &fakePortsApplier{}
+	applyEgressPorts(installed, []netbpf.EgressPortsEntry{e1b}, f)
+	if len(f.added) != 1 || f.added[0] != e1b || len(f.deleted) != 1 || f.deleted[0] != k2 {
+		t.Fatalf("update+delete: %+v", f)
+	}
+
+	// A failed add is not recorded, so the next pass retries it.
+	f = &fakePortsApplier{failAdd: map[netbpf.EgressEntry]bool{k2: true}}
+	applyEgressPorts(installed, []netbpf.EgressPortsEntry{e1b, e2}, f)
+	if _, ok := installed[k2]; ok {
+		t.Fatal("failed add recorded as installed")
+	}
+}
+
+func TestMemStore_EgressRulesRoundTrip(t *testing.T) {
+	s := NewMemNetworkPolicyStore()
+	ctx := context.Background()
+	in := &pb.NetworkPolicy{Tenant: "alice", EgressRules: []*pb.NetworkPolicyEgressRule{{Domain: "api.github.com", Proto: "tcp", Port: 443}}}
+	if err := s.Set(ctx, in); err != nil {
+		t.Fatal(err)
+	}
+	in.EgressRules[0].Port = 1 // the store holds its own copy
+	got, err := s.Get(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.GetEgressRules()) != 1 || got.GetEgressRules()[0].GetPort() != 443 {
+		t.Fatalf("stored rules = %+v", got.GetEgressRules())
+	}
+
+	b, err := encodeEgressRules([]*pb.NetworkPolicyEgressRule{{Cidr: "10.0.0.0/8", Proto: "udp", Port: 8000, PortEnd: 8100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := decodeEgressRules(b)
+	if err != nil || len(out) != 1 || out[0].GetPortEnd() != 8100 || out[0].GetCidr() != "10.0.0.0/8" {
+		t.Fatalf("encode/decode = %+v, %v", out, err)
+	}
+	if out, err := decodeEgressRules([]byte("[]")); err != nil || out != nil {
+		t.Errorf("decode [] = %v, %v; want nil, nil", out, err)
+	}
+}
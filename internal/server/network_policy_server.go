@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/netip"
+	"sort"
 	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netbpf"
 	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/internal/waf"
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
 
@@ -29,10 +33,20 @@ type NetworkPolicyServer struct {
 	pb.UnimplementedNetworkPolicyServiceServer
 	store    NetworkPolicyStore
 	sigStore NetworkPolicySignatureStore // #661 PR-B: operator exploit signatures (global)
+	boxStore BoxNetworkPolicyStore       // label-selected box policies
+
+	wafStore   WAFRuleStore // #662 Tier 3: operator WAF rules
+	wafChanged func()       // reloads the running WAF after an edit (nil → the poll picks it up)
+
+	// Learn mode (SuggestNetworkPolicy): where observed egress comes from.
+	flowHistory EgressHistory                           // persisted flows (nil = none)
+	liveFlows   func(container string) []*pb.Connection // not-yet-persisted flows (nil = none)
+	inventory   suggestContainers                       // tenant containers + peer attribution
+	resolver    reverseResolver                         // names observed addresses
 }
 
 func NewNetworkPolicyServer(store NetworkPolicyStore) *NetworkPolicyServer {
-	return &NetworkPolicyServer{store: store}
+	return &NetworkPolicyServer{store: store, resolver: net.DefaultResolver}
 }
 
 // SetSignatureStore wires the operator-signature store (#661 PR-B). Startup-only,
@@ -47,6 +61,36 @@ func (s *NetworkPolicyServer) SignatureStore() NetworkPolicySignatureStore {
 	return s.sigStore
 }
 
+// SetBoxPolicyStore wires the box policy store. Startup-only, same contract
+// as SetStore. Nil leaves the box policy RPCs returning Unavailable.
+func (s *NetworkPolicyServer) SetBoxPolicyStore(store BoxNetworkPolicyStore) {
+	s.boxStore = store
+}
+
+// BoxPolicyStore returns the box policy store (for the enforcer to read
+// during reconcile). Nil until SetBoxPolicyStore is called.
+func (s *NetworkPolicyServer) BoxPolicyStore() BoxNetworkPolicyStore {
+	return s.boxStore
+}
+
+// SetWAFRuleStore wires the operator WAF rule store (#662 Tier 3). Startup-only,
+// same contract as SetStore. Nil leaves the WAF rule RPCs returning Unavailable.
+func (s *NetworkPolicyServer) SetWAFRuleStore(store WAFRuleStore) {
+	s.wafStore = store
+}
+
+// WAFRuleStore returns the operator WAF rule store (for the WAF reloader).
+func (s *NetworkPolicyServer) WAFRuleStore() WAFRuleStore {
+	return s.wafStore
+}
+
+// SetWAFRulesChanged registers the hook called after a WAF rule is set or
+// deleted, so the running WAF reloads now instead of on its next poll.
+// Startup-only.
+func (s *NetworkPolicyServer) SetWAFRulesChanged(fn func()) {
+	s.wafChanged = fn
+}
+
 // SetStore swaps the backing store. Intended for startup only — called during
 // NewDualServer (before the gRPC server starts serving) to upgrade the initial
 // in-memory store to the Postgres-backed one once the DB connection string is
@@ -61,6 +105,17 @@ func (s *NetworkPolicyServer) Store() NetworkPolicyStore {
 	return s.store
 }
 
+// checkPortRanges refuses a policy whose port-scoped egress rules need more
+// ranges at one prefix than the kernel map holds (netbpf.MaxPortRules).
+// Reconcile would otherwise leave all of them out, failing closed, and say so
+// only in the daemon's log. Domain rules are only checked once resolved.
+func checkPortRanges(c netpolicy.CompiledPolicy) error {
+	if _, err := netbpf.CompileEgressPorts(0, c); err != nil {
+		return status.Error(codes.InvalidArgument, strings.TrimPrefix(err.Error(), "netbpf: "))
+	}
+	return nil
+}
+
 func (s *NetworkPolicyServer) SetNetworkPolicy(ctx context.Context, req *pb.SetNetworkPolicyRequest) (*pb.SetNetworkPolicyResponse, error) {
 	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
 		return nil, err
@@ -70,6 +125,9 @@ func (s *NetworkPolicyServer) SetNetworkPolicy(ctx context.Context, req *pb.SetN
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if err := checkPortRanges(compiled); err != nil {
+		return nil, err
+	}
 	stored := compiled.ToProto()
 	if err := s.store.Set(ctx, stored); err != nil {
 		return nil, status.Errorf(codes.Internal, "store network policy: %v", err)
@@ -255,3 +313,106 @@ func (s *NetworkPolicyServer) DeleteNetworkPolicySignature(ctx context.Context,
 	}
 	return &pb.DeleteNetworkPolicySignatureResponse{}, nil
 }
+
+// --- Tier 3 operator WAF rules (#662) ---
+
+func (s *NetworkPolicyServer) SetWAFRule(ctx context.Context, req *pb.SetWAFRuleRequest) (*pb.SetWAFRuleResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.wafStore == nil {
+		return nil, status.Error(codes.Unavailable, "waf rules are not configured on this daemon")
+	}
+	rule, err := normalizeWAFRule(req.GetRule())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	stored, err := s.wafStore.Set(ctx, rule)
+	if errors.Is(err, errWAFRuleIDRange) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "store waf rule: %v", err)
+	}
+	if s.wafChanged != nil {
+		s.wafChanged()
+	}
+	return &pb.SetWAFRuleResponse{Rule: stored}, nil
+}
+
+func (s *NetworkPolicyServer) ListWAFRules(ctx context.Context, _ *pb.ListWAFRulesRequest) (*pb.ListWAFRulesResponse, error) {
+	if err := auth.RequireRoleOrScope(ctx, auth.RoleAdmin, auth.ScopeNetworkPolicyRead); err != nil {
+		return nil, err
+	}
+	if s.wafStore == nil {
+		return &pb.ListWAFRulesResponse{}, nil
+	}
+	rules, err := s.wafStore.List(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list waf rules: %v", err)
+	}
+	return &pb.ListWAFRulesResponse{Rules: rules}, nil
+}
+
+func (s *NetworkPolicyServer) DeleteWAFRule(ctx context.Context, req *pb.DeleteWAFRuleRequest) (*pb.DeleteWAFRuleResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if s.wafStore == nil {
+		return nil, status.Error(codes.Unavailable, "waf rules are not configured on this daemon")
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := s.wafStore.Delete(ctx, req.GetName()); err != nil && !errors.Is(err, ErrWAFRuleNotFound) {
+		return nil, status.Errorf(codes.Internal, "delete waf rule: %v", err)
+	}
+	if s.wafChanged != nil {
+		s.wafChanged()
+	}
+	return &pb.DeleteWAFRuleResponse{}, nil
+}
+
+// normalizeWAFRule trims and lower-cases a rule's enum-like fields, dedupes its
+// tenant list, and validates it by compiling it, so the store only ever holds
+// rules the WAF can load. The id is output-only and ignored.
+func normalizeWAFRule(in *pb.WAFRule) (*pb.WAFRule, error) {
+	if in == nil {
+		return nil, errors.New("rule is required")
+	}
+	out := &pb.WAFRule{
+		Name:    strings.TrimSpace(in.GetName()),
+		Target:  strings.ToLower(strings.TrimSpace(in.GetTarget())),
+		Header:  strings.TrimSpace(in.GetHeader()),
+		Match:   strings.ToLower(strings.TrimSpace(in.GetMatch())),
+		Pattern: in.GetPattern(),
+		Action:  strings.ToLower(strings.TrimSpace(in.GetAction())),
+		Enabled: in.GetEnabled(),
+		Note:    in.GetNote(),
+
+		RequestsPerMinute: in.GetRequestsPerMinute(),
+		Burst:             in.GetBurst(),
+	}
+	if out.Match == "" {
+		out.Match = string(waf.MatchLiteral)
+	}
+	if out.Action == "" {
+		out.Action = string(waf.ActionBlock)
+	}
+	seen := map[string]bool{}
+	for _, t := range in.GetTenants() {
+		if t = strings.TrimSpace(t); t != "" && !seen[t] {
+			seen[t] = true
+			out.Tenants = append(out.Tenants, t)
+		}
+	}
+	sort.Strings(out.Tenants)
+	rule, err := wafRuleFromProto(out)
+	if err != nil {
+		return nil, err
+	}
+	if err := waf.ValidateRule(rule); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netbpf"
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
 
@@ -63,6 +64,38 @@ func TestNetworkPolicy_SetRejectsInvalid(t *testing.T) {
 	}
 }
 
+// A prefix needing more port ranges than one egress_ports entry holds is
+// refused up front: reconcile would drop all of its port allows.
+func TestNetworkPolicy_RefusesMorePortRangesThanTheMapHolds(t *testing.T) {
+	policy := func() *pb.NetworkPolicy {
+		p := &pb.NetworkPolicy{Tenant: "alice"}
+		for port := uint32(10); port < 10*(netbpf.MaxPortRules+2); port += 10 {
+			p.EgressRules = append(p.EgressRules, &pb.NetworkPolicyEgressRule{Cidr: "10.0.0.0/8", Proto: "tcp", Port: port})
+		}
+		return p
+	}
+	s := newBoxNPServer()
+	ctx := npAdminCtx()
+
+	if _, err := s.SetNetworkPolicy(ctx, &pb.SetNetworkPolicyRequest{Policy: policy()}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("set: %v, want InvalidArgument", err)
+	}
+	if _, err := s.SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{Policy: &pb.BoxNetworkPolicy{
+		Tenant: "alice", Name: "ci", Selector: map[string]string{"role": "ci"}, Policy: policy(),
+	}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("set box: %v, want InvalidArgument", err)
+	}
+	if _, err := s.SimulateNetworkPolicy(ctx, &pb.SimulateNetworkPolicyRequest{Policy: policy()}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("simulate: %v, want InvalidArgument", err)
+	}
+
+	fits := policy()
+	fits.EgressRules = fits.EgressRules[:netbpf.MaxPortRules]
+	if _, err := s.SetNetworkPolicy(ctx, &pb.SetNetworkPolicyRequest{Policy: fits}); err != nil {
+		t.Errorf("set %d ranges: %v", netbpf.MaxPortRules, err)
+	}
+}
+
 func TestNetworkPolicy_GetNotFound(t *testing.T) {
 	s := newNPServer()
 	_, err := s.GetNetworkPolicy(npAdminCtx(), &pb.GetNetworkPolicyRequest{Tenant: "ghost"})
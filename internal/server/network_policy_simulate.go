@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netpolicy"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// Samples SimulateNetworkPolicy returns per transition: the default, and the
+// most a request may ask for.
+const (
+	defaultSimulateSamples = 20
+	maxSimulateSamples     = 200
+)
+
+// SimulateNetworkPolicy is the dry run of SetNetworkPolicy. It compiles the
+// proposed policy exactly as a set would (the stored deny rules carry over)
+// and replays the tenant's recorded egress — the same history learn mode
+// reads — through it and the current policy, counting and sampling the flows
+// whose verdict changes. Verdicts are the TC program's allow/deny; whether a
+// deny drops or is only logged is the policy's mode, reported with it. Boxes a
+// box policy selects are not replayed, since a tenant-wide set leaves them be.
+func (s *NetworkPolicyServer) SimulateNetworkPolicy(ctx context.Context, req *pb.SimulateNetworkPolicyRequest) (*pb.SimulateNetworkPolicyResponse, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if req.GetPolicy() == nil {
+		return nil, status.Error(codes.InvalidArgument, "policy is required")
+	}
+	proposed, err := netpolicy.Compile(req.GetPolicy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkPortRanges(proposed); err != nil {
+		return nil, err
+	}
+	window, err := parseLookback(req.GetSince())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	limit := int(req.GetSampleLimit())
+	switch {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "sample_limit must not be negative")
+	case limit == 0:
+		limit = defaultSimulateSamples
+	case limit > maxSimulateSamples:
+		limit = maxSimulateSamples
+	}
+	if err := s.flowSourcesReady(); err != nil {
+		return nil, err
+	}
+	current, currentPB, err := s.currentPolicy(ctx, proposed.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	// A set keeps the stored deny rules, whatever the request carried.
+	stored := proposed.ToProto()
+	stored.DenyRules = currentPB.GetDenyRules()
+	proposed.DenyRules = nil
+	if current != nil {
+		proposed.DenyRules = current.DenyRules
+	}
+
+	boxes, err := s.tenantBoxPolicies(ctx, proposed.Tenant)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list box network policies: %v", err)
+	}
+	containers, peers, err := suggestInventory(s.inventory, proposed.Tenant, boxes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list containers: %v", err)
+	}
+	since := time.Now().Add(-window)
+	observed, err := s.observedEgress(ctx, containers, since)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "read flow history: %v", err)
+	}
+	var names map[netip.Addr][]string
+	if s.resolver != nil {
+		domains := append(policyDomains(current), policyDomains(&proposed)...)
+		slices.Sort(domains)
+		names = domainAddresses(ctx, s.resolver, slices.Compact(domains))
+	}
+
+	sim := netpolicy.Simulate(observed,
+		func(a netip.Addr) string { return peers[a] },
+		func(a netip.Addr) []string { return names[a] },
+		current, proposed)
+	resp := &pb.SimulateNetworkPolicyResponse{
+		Policy:  stored,
+		Current: currentPB,
+		Since:   since.UTC().Format(time.RFC3339),
+	}
+	resp.Destinations, resp.Flows = simulatedTotals(sim.Destinations)
+	newlyDenied, newlyAllowed := sim.NewlyDenied(), sim.NewlyAllowed()
+	resp.NewlyDeniedDestinations, resp.NewlyDeniedFlows = simulatedTotals(newlyDenied)
+	resp.NewlyAllowedDestinations, resp.NewlyAllowedFlows = simulatedTotals(newlyAllowed)
+	resp.StillDeniedDestinations, resp.StillDeniedFlows = simulatedTotals(sim.StillDenied())
+	for _, d := range newlyDenied[:min(limit, len(newlyDenied))] {
+		resp.NewlyDenied = append(resp.NewlyDenied, simulatedFlowProto(d, names[d.Dest]))
+	}
+	for _, d := range newlyAllowed[:min(limit, len(newlyAllowed))] {
+		resp.NewlyAllowed = append(resp.NewlyAllowed, simulatedFlowProto(d, names[d.Dest]))
+	}
+	return resp, nil
+}
+
+// simulatedTotals counts destinations and the flows to them.
+func simulatedTotals(ds []netpolicy.SimulatedDestination) (dests, flows int64) {
+	for _, d := range ds {
+		flows += d.Flows
+	}
+	return int64(len(ds)), flows
+}
+
+func simulatedFlowProto(d netpolicy.SimulatedDestination, names []string) *pb.NetworkPolicySimulatedFlow {
+	proto := protoName(d.Proto)
+	if proto == "" {
+		proto = strconv.Itoa(int(d.Proto))
+	}
+	var last, domain string
+	if !d.LastSeen.IsZero() {
+		last = d.LastSeen.UTC().Format(time.RFC3339)
+	}
+	if len(names) > 0 {
+		domain = names[0]
+	}
+	return &pb.NetworkPolicySimulatedFlow{
+		DestIp:         d.Dest.String(),
+		Domain:         domain,
+		Proto:          proto,
+		Port:           uint32(d.Port),
+		Flows:          d.Flows,
+		Bytes:          d.Bytes,
+		LastSeen:       last,
+		Containers:     d.Containers,
+		PeerTenant:     d.PeerTenant,
+		CurrentReason:  d.Current.Reason,
+		CurrentEntry:   d.Current.Entry,
+		ProposedReason: d.Proposed.Reason,
+		ProposedEntry:  d.Proposed.Entry,
+	}
+}
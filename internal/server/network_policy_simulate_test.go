@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/internal/traffic"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestSimulateNetworkPolicy(t *testing.T) {
+	s := newNPServer()
+	ctx := npAdminCtx()
+	if _, err := s.SetNetworkPolicy(ctx, &pb.SetNetworkPolicyRequest{Policy: &pb.NetworkPolicy{
+		Tenant:        "alice",
+		EgressCidrs:   []string{"10.0.0.0/8"},
+		EgressDomains: []string{"api.github.com"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PatchNetworkPolicyDenyRules(ctx, &pb.PatchNetworkPolicyDenyRulesRequest{
+		Tenant: "alice", Add: []*pb.NetworkPolicyDenyRule{{Cidr: "198.51.100.1", Note: "CVE-1"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	row := func(dest string, port uint32, n int64) traffic.EgressDestination {
+		return traffic.EgressDestination{ContainerName: "alice-container", DestIP: dest, Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: port, Connections: n, LastSeen: now}
+	}
+	s.SetSuggestSources(&fakeEgressHistory{rows: []traffic.EgressDestination{
+		row("10.1.2.3", 5432, 30),
+		row("10.1.2.4", 5432, 5),
+		row("140.82.112.3", 443, 12),
+		row("203.0.113.7", 443, 4),
+		row("198.51.100.1", 443, 2),
+	}}, nil, fakeInventory{{Name: "alice-container", IPAddress: "10.100.0.5"}})
+	s.resolver = &fakeReverseResolver{fakeIPResolver: fakeIPResolver{results: map[string][]netip.Addr{
+		"api.github.com": addrs("140.82.112.3"),
+	}}}
+
+	// The proposal narrows 10/8 to one host and allows a new /24; its deny
+	// rules are ignored in favour of the stored one.
+	req := &pb.SimulateNetworkPolicyRequest{
+		Policy: &pb.NetworkPolicy{
+			Tenant:      "alice",
+			Mode:        pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE,
+			EgressCidrs: []string{"10.1.2.3/32", "203.0.113.0/24", "198.51.100.0/24"},
+			EgressRules: []*pb.NetworkPolicyEgressRule{{Domain: "api.github.com", Proto: "tcp", Port: 443}},
+			DenyRules:   []*pb.NetworkPolicyDenyRule{{Cidr: "203.0.113.7"}},
+		},
+		Since:       "1d",
+		SampleLimit: 1,
+	}
+	resp, err := s.SimulateNetworkPolicy(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDestinations() != 5 || resp.GetFlows() != 53 {
+		t.Errorf("replayed %d destinations / %d flows", resp.GetDestinations(), resp.GetFlows())
+	}
+	if resp.GetNewlyDeniedDestinations() != 1 || resp.GetNewlyDeniedFlows() != 5 {
+		t.Errorf("newly denied %d / %d", resp.GetNewlyDeniedDestinations(), resp.GetNewlyDeniedFlows())
+	}
+	if resp.GetNewlyAllowedDestinations() != 1 || resp.GetNewlyAllowedFlows() != 4 {
+		t.Errorf("newly allowed %d / %d", resp.GetNewlyAllowedDestinations(), resp.GetNewlyAllowedFlows())
+	}
+	// The virtual-patched host stays denied under both.
+	if resp.GetStillDeniedDestinations() != 1 || resp.GetStillDeniedFlows() != 2 {
+		t.Errorf("still denied %d / %d", resp.GetStillDeniedDestinations(), resp.GetStillDeniedFlows())
+	}
+	nd := resp.GetNewlyDenied()
+	if len(nd) != 1 || nd[0].GetDestIp() != "10.1.2.4" || nd[0].GetCurrentEntry() != "10.0.0.0/8" || nd[0].GetProposedReason() != netpolicy.ReasonNotInAllowList {
+		t.Errorf("newly denied samples = %v", nd)
+	}
+	if len(resp.GetPolicy().GetDenyRules()) != 1 || resp.GetPolicy().GetDenyRules()[0].GetCidr() != "198.51.100.1/32" {
+		t.Errorf("stored-form deny rules = %v", resp.GetPolicy().GetDenyRules())
+	}
+
+	// Nothing was stored.
+	got, err := s.GetNetworkPolicy(ctx, &pb.GetNetworkPolicyRequest{Tenant: "alice"})
+	if err != nil || len(got.GetPolicy().GetEgressCidrs()) != 1 {
+		t.Fatalf("stored policy changed: %v, %v", got.GetPolicy(), err)
+	}
+}
+
+func TestSimulateNetworkPolicy_Validation(t *testing.T) {
+	s := newNPServer()
+	ctx := npAdminCtx()
+	if _, err := s.SimulateNetworkPolicy(ctx, &pb.SimulateNetworkPolicyRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("no policy: %v", err)
+	}
+	bad := &pb.SimulateNetworkPolicyRequest{Policy: &pb.NetworkPolicy{Tenant: "alice", EgressCidrs: []string{"nope"}}}
+	if _, err := s.SimulateNetworkPolicy(ctx, bad); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad cidr: %v", err)
+	}
+	ok := &pb.SimulateNetworkPolicyRequest{Policy: &pb.NetworkPolicy{Tenant: "alice"}}
+	if _, err := s.SimulateNetworkPolicy(ctx, ok); status.Code(err) != codes.Unavailable {
+		t.Errorf("no flow sources: %v", err)
+	}
+}
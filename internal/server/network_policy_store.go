@@ -125,9 +125,28 @@ func clonePolicy(p *pb.NetworkPolicy) *pb.NetworkPolicy {
 		Mode:             p.GetMode(),
 		Source:           p.GetSource(),
 		DenyRules:        cloneDenyRules(p.GetDenyRules()),
+		EgressRules:      cloneEgressRules(p.GetEgressRules()),
 	}
 }
 
+// cloneEgressRules deep-copies an egress-rule slice, like cloneDenyRules.
+func cloneEgressRules(in []*pb.NetworkPolicyEgressRule) []*pb.NetworkPolicyEgressRule {
+	if in == nil {
+		return nil
+	}
+	out := make([]*pb.NetworkPolicyEgressRule, len(in))
+	for i, r := range in {
+		out[i] = &pb.NetworkPolicyEgressRule{
+			Cidr:    r.GetCidr(),
+			Domain:  r.GetDomain(),
+			Proto:   r.GetProto(),
+			Port:    r.GetPort(),
+			PortEnd: r.GetPortEnd(),
+		}
+	}
+	return out
+}
+
 // cloneDenyRules deep-copies a deny-rule slice so stored state can't be mutated
 // through a returned pointer (and vice versa).
 func cloneDenyRules(in []*pb.NetworkPolicyDenyRule) []*pb.NetworkPolicyDenyRule {
@@ -166,6 +185,42 @@ func encodeDenyRules(rules []*pb.NetworkPolicyDenyRule) ([]byte, error) {
 	return json.Marshal(rows)
 }
 
+// egressRuleRow is the JSON shape stored in the network_policies.egress_rules
+// JSONB column, like denyRuleRow.
+type egressRuleRow struct {
+	Cidr    string `json:"cidr,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	Proto   string `json:"proto,omitempty"`
+	Port    uint32 `json:"port,omitempty"`
+	PortEnd uint32 `json:"port_end,omitempty"`
+}
+
+func encodeEgressRules(rules []*pb.NetworkPolicyEgressRule) ([]byte, error) {
+	rows := make([]egressRuleRow, 0, len(rules))
+	for _, r := range rules {
+		rows = append(rows, egressRuleRow{r.GetCidr(), r.GetDomain(), r.GetProto(), r.GetPort(), r.GetPortEnd()})
+	}
+	return json.Marshal(rows)
+}
+
+func decodeEgressRules(b []byte) ([]*pb.NetworkPolicyEgressRule, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	var rows []egressRuleRow
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return nil, fmt.Errorf("decode egress_rules: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	out := make([]*pb.NetworkPolicyEgressRule, len(rows))
+	for i, r := range rows {
+		out[i] = &pb.NetworkPolicyEgressRule{Cidr: r.Cidr, Domain: r.Domain, Proto: r.Proto, Port: r.Port, PortEnd: r.PortEnd}
+	}
+	return out, nil
+}
+
 func decodeDenyRules(b []byte) ([]*pb.NetworkPolicyDenyRule, error) {
 	if len(b) == 0 {
 		return nil, nil
@@ -204,14 +259,16 @@ func NewPostgresNetworkPolicyStore(ctx context.Context, pool *pgxpool.Pool) (*Po
 			allow_metadata BOOLEAN NOT NULL DEFAULT false,
 			source TEXT NOT NULL DEFAULT '',
 			deny_rules JSONB NOT NULL DEFAULT '[]',
+			egress_rules JSONB NOT NULL DEFAULT '[]',
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 		);
 		-- Non-destructive upgrades for tables created before these columns
 		-- (allow_metadata: #315 Phase D; source: #354 convergence;
-		-- deny_rules: #660 virtual patching).
+		-- deny_rules: #660 virtual patching; egress_rules: port-scoped allows).
 		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS allow_metadata BOOLEAN NOT NULL DEFAULT false;
 		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '';
 		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS deny_rules JSONB NOT NULL DEFAULT '[]';
+		ALTER TABLE network_policies ADD COLUMN IF NOT EXISTS egress_rules JSONB NOT NULL DEFAULT '[]';
 	`
 	if _, err := pool.Exec(ctx, schema); err != nil {
 		return nil, fmt.Errorf("init network_policies schema: %w", err)
@@ -226,8 +283,8 @@ func (s *PostgresNetworkPolicyStore) Set(ctx context.Context, p *pb.NetworkPolic
 	// existing tenant's deny rules untouched — so `set` never clobbers them and
 	// needs no client round-trip.
 	const q = `
-		INSERT INTO network_policies (tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, '[]'::jsonb, NOW())
+		INSERT INTO network_policies (tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules, egress_rules, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, '[]'::jsonb, $8::jsonb, NOW())
 		ON CONFLICT (tenant) DO UPDATE SET
 			allow_intra_tenant = EXCLUDED.allow_intra_tenant,
 			egress_cidrs = EXCLUDED.egress_cidrs,
@@ -235,6 +292,7 @@ func (s *PostgresNetworkPolicyStore) Set(ctx context.Context, p *pb.NetworkPolic
 			mode = EXCLUDED.mode,
 			allow_metadata = EXCLUDED.allow_metadata,
 			source = EXCLUDED.source,
+			egress_rules = EXCLUDED.egress_rules,
 			updated_at = NOW()
 	`
 	// egress_cidrs / egress_domains are `TEXT[] NOT NULL DEFAULT '{}'`, but the
@@ -243,10 +301,14 @@ func (s *PostgresNetworkPolicyStore) Set(ctx context.Context, p *pb.NetworkPolic
 	// NOT NULL constraint (SQLSTATE 23502). A policy that allows no domains (or no
 	// CIDRs) arrives with a nil slice, so coerce nil -> empty so the array lands
 	// as '{}' rather than NULL.
-	_, err := s.pool.Exec(ctx, q,
+	rules, err := encodeEgressRules(p.GetEgressRules())
+	if err != nil {
+		return err
+	}
+	_, err = s.pool.Exec(ctx, q,
 		p.GetTenant(), p.GetAllowIntraTenant(),
 		nonNilStrings(p.GetEgressCidrs()), nonNilStrings(p.GetEgressDomains()), int32(p.GetMode()),
-		p.GetAllowMetadata(), p.GetSource())
+		p.GetAllowMetadata(), p.GetSource(), string(rules))
 	if err != nil {
 		return fmt.Errorf("save network policy: %w", err)
 	}
@@ -263,12 +325,12 @@ func nonNilStrings(s []string) []string {
 }
 
 func (s *PostgresNetworkPolicyStore) Get(ctx context.Context, tenant string) (*pb.NetworkPolicy, error) {
-	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules
+	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules, egress_rules
 		FROM network_policies WHERE tenant = $1`
 	p := &pb.NetworkPolicy{}
 	var mode int32
-	var denyJSON []byte
-	err := s.pool.QueryRow(ctx, q, tenant).Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON)
+	var denyJSON, rulesJSON []byte
+	err := s.pool.QueryRow(ctx, q, tenant).Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON, &rulesJSON)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNetworkPolicyNotFound
@@ -279,11 +341,14 @@ func (s *PostgresNetworkPolicyStore) Get(ctx context.Context, tenant string) (*p
 	if p.DenyRules, err = decodeDenyRules(denyJSON); err != nil {
 		return nil, err
 	}
+	if p.EgressRules, err = decodeEgressRules(rulesJSON); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
 func (s *PostgresNetworkPolicyStore) List(ctx context.Context) ([]*pb.NetworkPolicy, error) {
-	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules
+	const q = `SELECT tenant, allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules, egress_rules
 		FROM network_policies ORDER BY tenant`
 	rows, err := s.pool.Query(ctx, q)
 	if err != nil {
@@ -294,14 +359,17 @@ func (s *PostgresNetworkPolicyStore) List(ctx context.Context) ([]*pb.NetworkPol
 	for rows.Next() {
 		p := &pb.NetworkPolicy{}
 		var mode int32
-		var denyJSON []byte
-		if err := rows.Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON); err != nil {
+		var denyJSON, rulesJSON []byte
+		if err := rows.Scan(&p.Tenant, &p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON, &rulesJSON); err != nil {
 			return nil, fmt.Errorf("scan network policy: %w", err)
 		}
 		p.Mode = pb.NetworkPolicyMode(mode)
 		if p.DenyRules, err = decodeDenyRules(denyJSON); err != nil {
 			return nil, err
 		}
+		if p.EgressRules, err = decodeEgressRules(rulesJSON); err != nil {
+			return nil, err
+		}
 		out = append(out, p)
 	}
 	return out, rows.Err()
@@ -326,14 +394,17 @@ func (s *PostgresNetworkPolicyStore) MutateDenyRules(ctx context.Context, tenant
 
 	p := &pb.NetworkPolicy{Tenant: tenant}
 	var mode int32
-	var denyJSON []byte
-	err = tx.QueryRow(ctx, `SELECT allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules
+	var denyJSON, rulesJSON []byte
+	err = tx.QueryRow(ctx, `SELECT allow_intra_tenant, egress_cidrs, egress_domains, mode, allow_metadata, source, deny_rules, egress_rules
 		FROM network_policies WHERE tenant = $1 FOR UPDATE`, tenant).
-		Scan(&p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON)
+		Scan(&p.AllowIntraTenant, &p.EgressCidrs, &p.EgressDomains, &mode, &p.AllowMetadata, &p.Source, &denyJSON, &rulesJSON)
 	if err != nil {
 		return nil, fmt.Errorf("lock policy: %w", err)
 	}
 	p.Mode = pb.NetworkPolicyMode(mode)
+	if p.EgressRules, err = decodeEgressRules(rulesJSON); err != nil {
+		return nil, err
+	}
 	existing, err := decodeDenyRules(denyJSON)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,416 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/auth"
+	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// defaultSuggestWindow is the look-back SuggestNetworkPolicy uses when the
+// request names none: a week covers weekly jobs, which a day would miss.
+const defaultSuggestWindow = 7 * 24 * time.Hour
+
+// maxReverseLookups bounds the PTR lookups one suggestion makes, busiest
+// addresses first, so a tenant that talked to thousands of hosts doesn't
+// stall the RPC on DNS.
+const maxReverseLookups = 64
+
+// reverseLookupTimeout bounds each PTR lookup and its forward confirmation.
+const reverseLookupTimeout = 2 * time.Second
+
+// EgressHistory is the flow history learn mode aggregates. *traffic.Store
+// implements it; eBPF flow records land there through the collector.
+type EgressHistory interface {
+	EgressDestinations(ctx context.Context, containers []string, since time.Time) ([]traffic.EgressDestination, error)
+}
+
+// suggestContainers is the slice of the Incus client learn mode needs to find
+// a tenant's containers and attribute peer addresses to tenants.
+type suggestContainers interface {
+	ListContainers() ([]incus.ContainerInfo, error)
+}
+
+// reverseResolver is the slice of *net.Resolver learn mode names addresses
+// with. *net.Resolver satisfies it.
+type reverseResolver interface {
+	ipResolver
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+// SetSuggestSources wires learn mode: the persisted flow history, the live
+// (not yet persisted) connections of a container, and the container
+// inventory. Startup-only, same contract as SetStore. Without an inventory
+// and at least one flow source SuggestNetworkPolicy returns Unavailable.
+func (s *NetworkPolicyServer) SetSuggestSources(history EgressHistory, live func(container string) []*pb.Connection, inventory suggestContainers) {
+	s.flowHistory = history
+	s.liveFlows = live
+	s.inventory = inventory
+}
+
+// SuggestNetworkPolicy learns a candidate policy for a tenant from the egress
+// its containers made over the window, and reports it against the current
+// policy: the diff, and every destination the current policy, enforced,
+// would have dropped. Containers a box policy selects are left out: the
+// tenant-wide policy does not govern them. Nothing is stored — the operator applies the candidate
+// with SetNetworkPolicy once it reads right.
+func (s *NetworkPolicyServer) SuggestNetworkPolicy(ctx context.Context, req *pb.SuggestNetworkPolicyRequest) (*pb.SuggestNetworkPolicyResponse, error) {
+	if err := auth.RequireRoleOrScope(ctx, auth.RoleAdmin, auth.ScopeNetworkPolicyRead); err != nil {
+		return nil, err
+	}
+	tenant := strings.TrimSpace(req.GetTenant())
+	if tenant == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant is required")
+	}
+	window, err := parseLookback(req.GetSince())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.flowSourcesReady(); err != nil {
+		return nil, err
+	}
+	current, currentPB, err := s.currentPolicy(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	boxes, err := s.tenantBoxPolicies(ctx, tenant)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list box network policies: %v", err)
+	}
+	containers, peers, err := suggestInventory(s.inventory, tenant, boxes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list containers: %v", err)
+	}
+	since := time.Now().Add(-window)
+	observed, err := s.observedEgress(ctx, containers, since)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "read flow history: %v", err)
+	}
+	names := nameAddresses(ctx, s.resolver, observed, policyDomains(current))
+	for i := range observed {
+		observed[i].Domain = names[observed[i].Dest]
+	}
+
+	sug, err := netpolicy.Suggest(tenant, observed, func(a netip.Addr) string { return peers[a] }, current)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.SuggestNetworkPolicyResponse{
+		Candidate: sug.Policy,
+		Current:   currentPB,
+		Added:     sug.Added,
+		Removed:   sug.Removed,
+		WouldDeny: safecast.I32(len(sug.WouldDeny())),
+		Since:     since.UTC().Format(time.RFC3339),
+	}
+	for _, d := range sug.Destinations {
+		resp.Destinations = append(resp.Destinations, observedDestinationProto(d))
+	}
+	return resp, nil
+}
+
+// flowSourcesReady reports Unavailable unless SetSuggestSources wired an
+// inventory and at least one flow source.
+func (s *NetworkPolicyServer) flowSourcesReady() error {
+	if s.inventory == nil || (s.flowHistory == nil && s.liveFlows == nil) {
+		return status.Error(codes.Unavailable, "flow replay needs the traffic collector, which is not running on this daemon")
+	}
+	return nil
+}
+
+// currentPolicy returns the tenant's stored policy, raw and compiled with
+// expired deny rules dropped as the enforcer drops them. Both are nil when the
+// tenant has none.
+func (s *NetworkPolicyServer) currentPolicy(ctx context.Context, tenant string) (*netpolicy.CompiledPolicy, *pb.NetworkPolicy, error) {
+	p, err := s.store.Get(ctx, tenant)
+	if errors.Is(err, ErrNetworkPolicyNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "get network policy: %v", err)
+	}
+	c, err := netpolicy.Compile(p)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "compile current policy: %v", err)
+	}
+	c.DenyRules = activeDenyRules(c.DenyRules, time.Now())
+	return &c, p, nil
+}
+
+// parseLookback parses a learn-mode window: a day count ("7d") or a Go
+// duration ("36h"). Empty is defaultSuggestWindow.
+func parseLookback(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return defaultSuggestWindow, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid since %q (want e.g. 7d or 36h)", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid since %q (want e.g. 7d or 36h)", s)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("since %q must be positive", s)
+	}
+	return d, nil
+}
+
+// suggestInventory returns the names of the tenant's containers its
+// tenant-wide policy governs and every managed container's addresses mapped
+// to its tenant, attributed the way the enforcer's gather does (the control
+// plane is not a tenant). A container one of boxes selects is policed by that
+// box policy instead, so its egress says nothing about the tenant-wide one.
+func suggestInventory(inv suggestContainers, tenant string, boxes []netpolicy.CompiledBoxPolicy) ([]string, map[netip.Addr]string, error) {
+	list, err := inv.ListContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+	var names []string
+	peers := make(map[netip.Addr]string)
+	for _, c := range list {
+		if c.Role == incus.RoleControlPlane {
+			continue
+		}
+		t := resolveTenant(c.Tenant, c.Labels[cloudOrgIDLabel], c.Name)
+		if t == "" {
+			continue
+		}
+		if t == tenant && netpolicy.SelectBoxPolicy(boxes, c.Labels) == nil {
+			names = append(names, c.Name)
+		}
+		for _, s := range []string{c.IPAddress, c.IPv6Address} {
+			if ip, err := netip.ParseAddr(s); err == nil {
+				peers[ip.Unmap()] = t
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, peers, nil
+}
+
+// observedEgress collects the containers' egress since the window start: the
+// persisted history, plus live connections that have not closed yet (a
+// long-lived connection only reaches history when it ends). A flow still
+// live and already persisted is counted twice, so Flows is indicative.
+func (s *NetworkPolicyServer) observedEgress(ctx context.Context, containers []string, since time.Time) ([]netpolicy.Observation, error) {
+	var out []netpolicy.Observation
+	if s.flowHistory != nil && len(containers) > 0 {
+		rows, err := s.flowHistory.EgressDestinations(ctx, containers, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			ip, err := netip.ParseAddr(r.DestIP)
+			if err != nil {
+				continue
+			}
+			out = append(out, netpolicy.Observation{
+				Dest:       ip.Unmap(),
+				Proto:      ipProtoOf(r.Protocol),
+				Port:       safecast.U16FromUint(r.DestPort),
+				Flows:      r.Connections,
+				Bytes:      r.Bytes,
+				LastSeen:   r.LastSeen,
+				Containers: []string{r.ContainerName},
+			})
+		}
+	}
+	if s.liveFlows != nil {
+		for _, name := range containers {
+			for _, c := range s.liveFlows(name) {
+				if c.GetDirection() != pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS {
+					continue
+				}
+				ip, err := netip.ParseAddr(c.GetDestIp())
+				if err != nil {
+					continue
+				}
+				out = append(out, netpolicy.Observation{
+					Dest:       ip.Unmap(),
+					Proto:      ipProtoOf(c.GetProtocol()),
+					Port:       safecast.U16FromUint(c.GetDestPort()),
+					Flows:      1,
+					Bytes:      c.GetBytesSent() + c.GetBytesReceived(),
+					LastSeen:   c.GetLastSeen().AsTime(),
+					Containers: []string{name},
+				})
+			}
+		}
+	}
+	return out, nil
+}
+
+// ipProtoOf maps the traffic view's protocol enum to an IP protocol number.
+func ipProtoOf(p pb.Protocol) uint8 {
+	switch p {
+	case pb.Protocol_PROTOCOL_TCP:
+		return 6
+	case pb.Protocol_PROTOCOL_UDP:
+		return 17
+	case pb.Protocol_PROTOCOL_ICMP:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// policyDomains lists every domain a policy names, bare or in a rule.
+func policyDomains(c *netpolicy.CompiledPolicy) []string {
+	if c == nil {
+		return nil
+	}
+	out := append([]string(nil), c.EgressDomains...)
+	for _, r := range c.EgressRules {
+		if r.Domain != "" {
+			out = append(out, r.Domain)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// nameAddresses maps observed addresses to the names they are known by. A
+// domain the current policy names wins (resolved now, the way the enforcer
+// would); otherwise a PTR name is used only when it resolves back to the
+// address, since anyone controlling a reverse zone can claim any name. Only
+// public addresses are looked up, busiest first, at most maxReverseLookups.
+func nameAddresses(ctx context.Context, r reverseResolver, observed []netpolicy.Observation, domains []string) map[netip.Addr]string {
+	out := make(map[netip.Addr]string)
+	if r == nil {
+		return out
+	}
+	for a, doms := range domainAddresses(ctx, r, domains) {
+		out[a] = doms[0]
+	}
+
+	flows := make(map[netip.Addr]int64)
+	for _, o := range observed {
+		if out[o.Dest] == "" && publicAddr(o.Dest) {
+			flows[o.Dest] += o.Flows
+		}
+	}
+	todo := make([]netip.Addr, 0, len(flows))
+	for a := range flows {
+		todo = append(todo, a)
+	}
+	sort.Slice(todo, func(i, j int) bool {
+		if flows[todo[i]] != flows[todo[j]] {
+			return flows[todo[i]] > flows[todo[j]]
+		}
+		return todo[i].Less(todo[j])
+	})
+	if len(todo) > maxReverseLookups {
+		todo = todo[:maxReverseLookups]
+	}
+	for _, a := range todo {
+		if name := confirmedPTR(ctx, r, a); name != "" {
+			out[a] = name
+		}
+	}
+	return out
+}
+
+// domainAddresses resolves domains now, the way the enforcer would, and maps
+// each address to the domains that resolve to it, in the order given. A name
+// that fails to resolve maps nothing.
+func domainAddresses(ctx context.Context, r ipResolver, domains []string) map[netip.Addr][]string {
+	out := make(map[netip.Addr][]string)
+	for _, dom := range domains {
+		lctx, cancel := context.WithTimeout(ctx, reverseLookupTimeout)
+		addrs, err := r.LookupNetIP(lctx, "ip", dom)
+		cancel()
+		if err != nil {
+			continue
+		}
+		for _, a := range addrs {
+			a = a.Unmap()
+			if !slices.Contains(out[a], dom) {
+				out[a] = append(out[a], dom)
+			}
+		}
+	}
+	return out
+}
+
+// confirmedPTR returns the first PTR name for a that resolves back to a, or "".
+func confirmedPTR(ctx context.Context, r reverseResolver, a netip.Addr) string {
+	lctx, cancel := context.WithTimeout(ctx, reverseLookupTimeout)
+	defer cancel()
+	ptrs, err := r.LookupAddr(lctx, a.String())
+	if err != nil {
+		return ""
+	}
+	for _, p := range ptrs {
+		name := strings.ToLower(strings.TrimSuffix(p, "."))
+		if name == "" {
+			continue
+		}
+		fwd, err := r.LookupNetIP(lctx, "ip", name)
+		if err != nil {
+			continue
+		}
+		for _, f := range fwd {
+			if f.Unmap() == a {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// publicAddr reports whether an address is worth a reverse lookup: not
+// private, loopback, link-local, multicast or unspecified.
+func publicAddr(a netip.Addr) bool {
+	return a.IsGlobalUnicast() && !a.IsPrivate()
+}
+
+func observedDestinationProto(d netpolicy.SuggestedDestination) *pb.NetworkPolicyObservedDestination {
+	proto := protoName(d.Proto)
+	if proto == "" {
+		proto = strconv.Itoa(int(d.Proto))
+	}
+	var last string
+	if !d.LastSeen.IsZero() {
+		last = d.LastSeen.UTC().Format(time.RFC3339)
+	}
+	return &pb.NetworkPolicyObservedDestination{
+		DestIp:     d.Dest.String(),
+		Domain:     d.Domain,
+		Proto:      proto,
+		Port:       uint32(d.Port),
+		Flows:      d.Flows,
+		Bytes:      d.Bytes,
+		LastSeen:   last,
+		Containers: d.Containers,
+		PeerTenant: d.PeerTenant,
+		Allowed:    d.Current.Allowed,
+		Reason:     d.Current.Reason,
+		Entry:      d.Current.Entry,
+		Rule:       d.Rule,
+		Excluded:   d.Excluded,
+	}
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/footprintai/containarium/internal/netpolicy"
+	"github.com/footprintai/containarium/internal/traffic"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// fakeEgressHistory returns rows for the containers asked about and records
+// the window it was asked for.
+type fakeEgressHistory struct {
+	rows  []traffic.EgressDestination
+	since time.Time
+}
+
+func (f *fakeEgressHistory) EgressDestinations(_ context.Context, containers []string, since time.Time) ([]traffic.EgressDestination, error) {
+	f.since = since
+	var out []traffic.EgressDestination
+	for _, r := range f.rows {
+		if slices.Contains(containers, r.ContainerName) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+type fakeInventory []incus.ContainerInfo
+
+func (f fakeInventory) ListContainers() ([]incus.ContainerInfo, error) { return f, nil }
+
+// fakeReverseResolver answers forward lookups from its fakeIPResolver and
+// PTR lookups from ptrs.
+type fakeReverseResolver struct {
+	fakeIPResolver
+	ptrs map[string][]string
+}
+
+func (f *fakeReverseResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	if names, ok := f.ptrs[addr]; ok {
+		return names, nil
+	}
+	return nil, fmt.Errorf("no PTR for %s", addr)
+}
+
+func TestParseLookback(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":     defaultSuggestWindow,
+		"7d":   7 * 24 * time.Hour,
+		"36h":  36 * time.Hour,
+		" 1d ": 24 * time.Hour,
+	}
+	for in, want := range cases {
+		if got, err := parseLookback(in); err != nil || got != want {
+			t.Errorf("parseLookback(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"0d", "-1h", "week", "7x"} {
+		if _, err := parseLookback(in); err == nil {
+			t.Errorf("parseLookback(%q) accepted", in)
+		}
+	}
+}
+
+func TestSuggestNetworkPolicy_NeedsSources(t *testing.T) {
+	s := newNPServer()
+	_, err := s.SuggestNetworkPolicy(npAdminCtx(), &pb.SuggestNetworkPolicyRequest{Tenant: "alice"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("err = %v, want Unavailable", err)
+	}
+	s.SetSuggestSources(&fakeEgressHistory{}, nil, fakeInventory{})
+	_, err = s.SuggestNetworkPolicy(npAdminCtx(), &pb.SuggestNetworkPolicyRequest{Tenant: "alice", Since: "soon"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestSuggestNetworkPolicy_EndToEnd(t *testing.T) {
+	s := newNPServer()
+	ctx := npAdminCtx()
+	if _, err := s.SetNetworkPolicy(ctx, &pb.SetNetworkPolicyRequest{Policy: &pb.NetworkPolicy{
+		Tenant:        "alice",
+		EgressCidrs:   []string{"10.100.0.1/32", "192.0.2.0/24"},
+		EgressDomains: []string{"api.github.com"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	history := &fakeEgressHistory{rows: []traffic.EgressDestination{
+		{ContainerName: "alice-container", DestIP: "10.100.0.1", Protocol: pb.Protocol_PROTOCOL_UDP, DestPort: 53, Connections: 50, LastSeen: now},
+		{ContainerName: "alice-container", DestIP: "140.82.112.3", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 443, Connections: 9, LastSeen: now},
+		{ContainerName: "alice-container", DestIP: "10.100.0.9", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 5432, Connections: 2, LastSeen: now},
+		{ContainerName: "bob-container", DestIP: "198.51.100.1", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 22, Connections: 1, LastSeen: now},
+	}}
+	live := func(name string) []*pb.Connection {
+		if name != "alice-container" {
+			return nil
+		}
+		return []*pb.Connection{
+			{DestIp: "151.101.1.69", DestPort: 443, Protocol: pb.Protocol_PROTOCOL_TCP, Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS, LastSeen: timestamppb.New(now)},
+			{DestIp: "10.100.0.5", DestPort: 22, Protocol: pb.Protocol_PROTOCOL_TCP, Direction: pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS},
+		}
+	}
+	inv := fakeInventory{
+		{Name: "alice-container", IPAddress: "10.100.0.5"},
+		{Name: "bob-container", IPAddress: "10.100.0.9"},
+		{Name: "core-controlplane", IPAddress: "10.100.0.2", Role: incus.RoleControlPlane},
+	}
+	s.SetSuggestSources(history, live, inv)
+	s.resolver = &fakeReverseResolver{
+		fakeIPResolver: fakeIPResolver{results: map[string][]netip.Addr{
+			"api.github.com":    addrs("140.82.112.3"),
+			"pypi.org":          addrs("151.101.1.69"),
+			"spoofed.pypi.test": addrs("203.0.113.66"),
+		}},
+		// The first PTR claims a name that does not resolve back; the second
+		// is forward-confirmed.
+		ptrs: map[string][]string{"151.101.1.69": {"spoofed.pypi.test.", "pypi.org."}},
+	}
+
+	resp, err := s.SuggestNetworkPolicy(ctx, &pb.SuggestNetworkPolicyRequest{Tenant: "alice", Since: "2d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := now.Sub(history.since); d < 47*time.Hour || d > 49*time.Hour {
+		t.Errorf("history asked since %v ago, want ~2d", d)
+	}
+	if resp.GetCurrent().GetTenant() != "alice" || resp.GetCandidate().GetMode() != pb.NetworkPolicyMode_NETWORK_POLICY_MODE_ENFORCE {
+		t.Errorf("current %+v candidate %+v", resp.GetCurrent(), resp.GetCandidate())
+	}
+	if !slices.Equal(resp.GetAdded(), []string{"pypi.org tcp/443"}) || !slices.Equal(resp.GetRemoved(), []string{"192.0.2.0/24"}) {
+		t.Errorf("diff +%v -%v", resp.GetAdded(), resp.GetRemoved())
+	}
+	// bob's database and the new pypi flow would be dropped today.
+	if resp.GetWouldDeny() != 2 {
+		t.Errorf("would_deny = %d, want 2", resp.GetWouldDeny())
+	}
+	byDest := map[string]*pb.NetworkPolicyObservedDestination{}
+	for _, d := range resp.GetDestinations() {
+		byDest[d.GetDestIp()] = d
+	}
+	if len(byDest) != 4 || byDest["198.51.100.1"] != nil {
+		t.Fatalf("destinations = %v, want alice's four", resp.GetDestinations())
+	}
+	if d := byDest["140.82.112.3"]; d.GetDomain() != "api.github.com" || !d.GetAllowed() || d.GetEntry() != "api.github.com" {
+		t.Errorf("github destination = %+v", d)
+	}
+	if d := byDest["10.100.0.9"]; d.GetPeerTenant() != "bob" || d.GetReason() != netpolicy.ReasonCrossTenant || d.GetExcluded() == "" {
+		t.Errorf("cross-tenant destination = %+v", d)
+	}
+	if d := byDest["151.101.1.69"]; d.GetDomain() != "pypi.org" || d.GetRule() != "pypi.org tcp/443" || d.GetAllowed() {
+		t.Errorf("pypi destination = %+v", d)
+	}
+}
+
+// Names come from the current policy's domains first and are never taken
+// from an unconfirmed PTR record; private addresses are not looked up.
+func TestNameAddresses(t *testing.T) {
+	r := &fakeReverseResolver{
+		fakeIPResolver: fakeIPResolver{results: map[string][]netip.Addr{
+			"api.github.com":   addrs("140.82.112.3"),
+			"bank.example":     addrs("192.0.2.77"),
+			"lb.github.com":    addrs("140.82.112.3"),
+			"mirror.gitlab.io": addrs("203.0.113.10"),
+		}},
+		ptrs: map[string][]string{
+			"140.82.112.3": {"lb.github.com."},
+			"203.0.113.10": {"bank.example."},
+			"10.0.0.7":     {"db.internal."},
+		},
+	}
+	observed := []netpolicy.Observation{
+		{Dest: netip.MustParseAddr("140.82.112.3"), Flows: 1},
+		{Dest: netip.MustParseAddr("203.0.113.10"), Flows: 5},
+		{Dest: netip.MustParseAddr("10.0.0.7"), Flows: 9},
+	}
+	got := nameAddresses(context.Background(), r, observed, []string{"api.github.com"})
+	if len(got) != 1 || got[netip.MustParseAddr("140.82.112.3")] != "api.github.com" {
+		t.Errorf("names = %v, want only the policy domain", got)
+	}
+	if r.calls["db.internal"] != 0 || r.calls["lb.github.com"] != 0 {
+		t.Errorf("looked up names it should not have: %v", r.calls)
+	}
+}
+
+// A box a box policy selects is not governed by the tenant-wide policy, so
+// neither learning nor replaying that policy reads its egress.
+func TestSuggestAndSimulate_SkipBoxesABoxPolicySelects(t *testing.T) {
+	s := newBoxNPServer()
+	ctx := npAdminCtx()
+	if _, err := s.SetBoxNetworkPolicy(ctx, &pb.SetBoxNetworkPolicyRequest{Policy: &pb.BoxNetworkPolicy{
+		Tenant: "alice", Name: "ci", Selector: map[string]string{"role": "ci"},
+		Policy: &pb.NetworkPolicy{EgressCidrs: []string{"0.0.0.0/0"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	s.SetSuggestSources(&fakeEgressHistory{rows: []traffic.EgressDestination{
+		{ContainerName: "alice-container", DestIP: "192.0.2.1", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 443, Connections: 3, LastSeen: now},
+		{ContainerName: "alice-ci", DestIP: "198.51.100.1", Protocol: pb.Protocol_PROTOCOL_TCP, DestPort: 443, Connections: 7, LastSeen: now},
+	}}, nil, fakeInventory{
+		{Name: "alice-container", IPAddress: "10.100.0.5"},
+		{Name: "alice-ci", Tenant: "alice", IPAddress: "10.100.0.6", Labels: map[string]string{"role": "ci"}},
+	})
+
+	sug, err := s.SuggestNetworkPolicy(ctx, &pb.SuggestNetworkPolicyRequest{Tenant: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ds := sug.GetDestinations(); len(ds) != 1 || ds[0].GetDestIp() != "192.0.2.1" {
+		t.Errorf("suggest destinations = %v, want only alice-container's", ds)
+	}
+
+	sim, err := s.SimulateNetworkPolicy(ctx, &pb.SimulateNetworkPolicyRequest{Policy: &pb.NetworkPolicy{Tenant: "alice"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.GetDestinations() != 1 || sim.GetFlows() != 3 {
+		t.Errorf("simulated %d destinations, %d flows; want alice-container's 1 and 3", sim.GetDestinations(), sim.GetFlows())
+	}
+}
@@ -33,7 +33,20 @@ type NetworkServer struct {
 	proxyIP            string                   // e.g., "10.100.0.1"
 	baseDomain         string                   // e.g., "example.com"
 	emitter            *events.Emitter
-	egressMgr          *egressproxy.Manager // egress-via-client relays, keyed by box (#808)
+	egressMgr          *egressproxy.Manager  // egress-via-client relays, keyed by box (#808)
+	policies           networkPolicyAssigner // effective network policy per box (nil = not shown)
+}
+
+// networkPolicyAssigner is the slice of the network-policy service
+// GetNetworkTopology needs; *NetworkPolicyServer implements it.
+type networkPolicyAssigner interface {
+	PolicyAssignments(ctx context.Context, containers []incus.ContainerInfo) (map[string]PolicyAssignment, error)
+}
+
+// SetNetworkPolicies wires the network-policy service so GetNetworkTopology
+// reports each box's tenant and effective policy. Startup-only.
+func (s *NetworkServer) SetNetworkPolicies(p networkPolicyAssigner) {
+	s.policies = p
 }
 
 // resolveFullDomain determines the full domain from a user-provided domain string.
@@ -969,6 +982,15 @@ func (s *NetworkServer) GetNetworkTopology(ctx context.Context, req *pb.GetNetwo
 		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
 
+	// Best-effort: a policy store error leaves the policy fields empty rather
+	// than failing the topology.
+	var assigned map[string]PolicyAssignment
+	if s.policies != nil {
+		if assigned, err = s.policies.PolicyAssignments(ctx, containers); err != nil {
+			log.Printf("[topology] network policy assignments: %v", err)
+		}
+	}
+
 	for _, c := range containers {
 		if !req.IncludeStopped && c.State != "Running" {
 			continue
@@ -982,13 +1004,17 @@ func (s *NetworkServer) GetNetworkTopology(ctx context.Context, req *pb.GetNetwo
 		// Get ACL name
 		aclName, _ := s.incusClient.GetContainerACL(c.Name, "eth0")
 
+		pa := assigned[c.Name]
 		topology.Nodes = append(topology.Nodes, &pb.NetworkNode{
-			Id:        c.Name,
-			Type:      "container",
-			Name:      c.Name,
-			IpAddress: c.IPAddress,
-			State:     state,
-			AclName:   aclName,
+			Id:                c.Name,
+			Type:              "container",
+			Name:              c.Name,
+			IpAddress:         c.IPAddress,
+			State:             state,
+			AclName:           aclName,
+			Tenant:            pa.Tenant,
+			NetworkPolicy:     pa.Policy,
+			NetworkPolicyMode: pa.Mode,
 		})
 
 		// Add edge from proxy to container (only if proxy is configured)
@@ -858,6 +858,60 @@ func (pc *PeerClient) ForwardCreateContainer(authToken string, pbReq *pb.CreateC
 	return &out, nil
 }
 
+// ForwardImportContainerSnapshot restores an exported snapshot chain on the
+// peer. Sent as the request message itself, like ForwardCreateContainer, and
+// with the same long timeout: the peer downloads and receives every stream of
+// the chain before it answers, which ForwardRequest's 30s would cut off.
+func (pc *PeerClient) ForwardImportContainerSnapshot(authToken string, pbReq *pb.ImportContainerSnapshotRequest) (*pb.ImportContainerSnapshotResponse, error) {
+	fwd, ok := proto.Clone(pbReq).(*pb.ImportContainerSnapshotRequest)
+	if !ok {
+		return nil, fmt.Errorf("clone import request: unexpected type")
+	}
+	// The peer IS the target; left set, it would route the request again.
+	fwd.BackendId = ""
+
+	bodyBytes, err := protojson.MarshalOptions{UseProtoNames: false}.Marshal(fwd)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	url := fmt.Sprintf("%s://%s/v1/containers/%s/snapshots/import", pc.urlScheme(), pc.Addr, neturl.PathEscape(fwd.Username))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := pc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var out pb.ImportContainerSnapshotResponse
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respBody, &out); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if out.Container != nil {
+		out.Container.BackendId = pc.ID
+	}
+	return &out, nil
+}
+
 // ForwardRequest forwards an arbitrary HTTP request to the peer and returns the response body.
 // GET requests use a 5s timeout to avoid blocking the UI; POST/PUT use 30s for mutations.
 func (pc *PeerClient) ForwardRequest(method, path, authToken string, body []byte) ([]byte, int, error) {
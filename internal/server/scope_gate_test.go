@@ -62,6 +62,10 @@ func TestSecrets_RejectsMissingWriteScope(t *testing.T) {
 	if status.Code(err) != codes.PermissionDenied {
 		t.Fatalf("RefreshSecrets without secrets:write: got %v", err)
 	}
+	_, err = srv.RollbackSecret(ctx, &pb.RollbackSecretRequest{Username: "alice", Name: "X", Version: 1})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("RollbackSecret without secrets:write: got %v", err)
+	}
 }
 
 func TestSecrets_RejectsMissingReadScope(t *testing.T) {
@@ -75,6 +79,10 @@ func TestSecrets_RejectsMissingReadScope(t *testing.T) {
 	if status.Code(err) != codes.PermissionDenied {
 		t.Fatalf("ListSecrets without secrets:read: got %v", err)
 	}
+	_, err = srv.ListSecretVersions(ctx, &pb.ListSecretVersionsRequest{Username: "alice", Name: "X"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("ListSecretVersions without secrets:read: got %v", err)
+	}
 }
 
 func TestSecrets_PassesWithCorrectScope(t *testing.T) {
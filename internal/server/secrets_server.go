@@ -179,6 +179,76 @@ func (s *ContainerServer) RefreshSecrets(ctx context.Context, req *pb.RefreshSec
 	}, nil
 }
 
+// ListSecretVersions returns the retained versions of one secret,
+// newest first. Metadata only — an old value is reachable only by
+// rolling back to it, never read out directly.
+func (s *ContainerServer) ListSecretVersions(ctx context.Context, req *pb.ListSecretVersionsRequest) (*pb.ListSecretVersionsResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeSecretsRead); err != nil {
+		return nil, err
+	}
+	if s.secretsStore == nil {
+		return nil, status.Error(codes.Unavailable, "secrets store not configured on this daemon")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.secretsStore.ListVersions(ctx, req.Username, req.Name)
+	if err != nil {
+		return nil, mapSecretError(err)
+	}
+
+	out := make([]*pb.SecretVersion, 0, len(versions))
+	for _, v := range versions {
+		out = append(out, &pb.SecretVersion{
+			Version:      v.Version,
+			CreatedAt:    v.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			DeliveryMode: deliveryToProto(v.Delivery),
+			Current:      v.Current,
+		})
+	}
+	return &pb.ListSecretVersionsResponse{Versions: out}, nil
+}
+
+// RollbackSecret makes a retained version current again, written as
+// the secret's next version. Like SetSecret it changes only the store:
+// the rolled-back value reaches the box through RefreshSecrets or the
+// next start, by whichever delivery mode the restored version has.
+func (s *ContainerServer) RollbackSecret(ctx context.Context, req *pb.RollbackSecretRequest) (*pb.RollbackSecretResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeSecretsWrite); err != nil {
+		return nil, err
+	}
+	if s.secretsStore == nil {
+		return nil, status.Error(codes.Unavailable, "secrets store not configured on this daemon")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := auth.AuthorizeTenant(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	if req.Version < 1 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+
+	meta, err := s.secretsStore.Rollback(ctx, req.Username, req.Name, req.Version)
+	if err != nil {
+		return nil, mapSecretError(err)
+	}
+
+	// Audit. Never log the value.
+	log.Printf("[secrets] rollback %s/%s to version=%d as version=%d delivery=%s",
+		req.Username, req.Name, req.Version, meta.Version, meta.Delivery)
+
+	return &pb.RollbackSecretResponse{
+		Message: fmt.Sprintf("secret %s rolled back to version %d as version %d", req.Name, req.Version, meta.Version),
+		Secret:  toProtoSecretMetadata(meta),
+	}, nil
+}
+
 // stampSecretsOnLXC reads every secret owned by `username`,
 // decrypts, and `incus config set environment.<NAME>=<value>`s
 // each one onto `<username>-container`. Used by RefreshSecrets
@@ -323,11 +393,17 @@ func (s *ContainerServer) stampSecretsOnLXC(ctx context.Context, username string
 }
 
 // mapSecretError maps store errors to gRPC status codes. Centralized
-// so the five RPC methods stay short.
+// so the RPC methods stay short.
 func mapSecretError(err error) error {
 	if errors.Is(err, secrets.ErrNotFound) {
 		return status.Error(codes.NotFound, "secret not found")
 	}
+	if errors.Is(err, secrets.ErrVersionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, secrets.ErrVersionIsCurrent) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
 	// pkg/core/secrets validation errors carry the right message for
 	// the caller — surface as InvalidArgument.
 	msg := err.Error()
@@ -345,6 +421,7 @@ func isValidationError(msg string) bool {
 		"name must match",
 		"value exceeds",
 		"username is required",
+		"version must be",
 	}
 	for _, kw := range keywords {
 		if containsCI(msg, kw) {
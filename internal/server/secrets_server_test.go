@@ -0,0 +1,36 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/footprintai/containarium/internal/secrets"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The store wraps its rollback errors with the secret and version; the
+// mapping has to see through that, and a missing version must not read as
+// a missing secret.
+func TestMapSecretError_Versions(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{secrets.ErrNotFound, codes.NotFound},
+		{fmt.Errorf("%w: alice/X version 2", secrets.ErrVersionNotFound), codes.NotFound},
+		{fmt.Errorf("%w: alice/X is at version 3", secrets.ErrVersionIsCurrent), codes.FailedPrecondition},
+		{errors.New("secrets: version must be 1 or greater; got 0"), codes.InvalidArgument},
+		{errors.New("decrypt secret version 2: KMS unwrap: boom"), codes.Internal},
+	}
+	for _, tc := range tests {
+		err := mapSecretError(tc.err)
+		if got := status.Code(err); got != tc.want {
+			t.Errorf("mapSecretError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+	if msg := status.Convert(mapSecretError(fmt.Errorf("%w: alice/X version 2", secrets.ErrVersionNotFound))).Message(); msg == "secret not found" {
+		t.Errorf("a missing version reported as %q", msg)
+	}
+}
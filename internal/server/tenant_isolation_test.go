@@ -56,6 +56,14 @@ func TestSecretsAPI_CrossTenantDenied(t *testing.T) {
 			_, err := srv.RefreshSecrets(ctx, &pb.RefreshSecretsRequest{Username: "bob"})
 			return err
 		}},
+		{"ListSecretVersions", func() error {
+			_, err := srv.ListSecretVersions(ctx, &pb.ListSecretVersionsRequest{Username: "bob", Name: "X"})
+			return err
+		}},
+		{"RollbackSecret", func() error {
+			_, err := srv.RollbackSecret(ctx, &pb.RollbackSecretRequest{Username: "bob", Name: "X", Version: 1})
+			return err
+		}},
 	}
 
 	for _, tc := range cases {
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/footprintai/containarium/internal/auth"
 	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/reqrate"
 	"github.com/footprintai/containarium/internal/safecast"
 	"github.com/footprintai/containarium/internal/traffic"
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
@@ -18,6 +22,7 @@ type TrafficServer struct {
 	collector *traffic.Collector
 	eventBus  *events.Bus
 	peerPool  *PeerPool
+	httpStats *reqrate.Tracker // nil unless access-log aggregation is on
 }
 
 // NewTrafficServer creates a new traffic server
@@ -28,6 +33,11 @@ func NewTrafficServer(collector *traffic.Collector) *TrafficServer {
 	}
 }
 
+// SetHTTPStats sets the HTTP RED source GetHTTPMetrics serves.
+func (s *TrafficServer) SetHTTPStats(t *reqrate.Tracker) {
+	s.httpStats = t
+}
+
 // SetPeerPool sets the peer pool for forwarding traffic queries to peers.
 func (s *TrafficServer) SetPeerPool(pool *PeerPool) {
 	s.peerPool = pool
@@ -255,3 +265,54 @@ func (s *TrafficServer) GetTrafficAggregates(ctx context.Context, req *pb.GetTra
 		Aggregates: aggregates,
 	}, nil
 }
+
+// GetHTTPMetrics returns the latest access-log window's per-container HTTP
+// request rate, 5xx ratio and latency quantiles. With container_name the
+// caller must be able to read that container; without, the list is filtered
+// to the containers the caller may read (all of them for an admin).
+func (s *TrafficServer) GetHTTPMetrics(ctx context.Context, req *pb.GetHTTPMetricsRequest) (*pb.GetHTTPMetricsResponse, error) {
+	if err := auth.RequireScope(ctx, auth.ScopeTrafficRead); err != nil {
+		return nil, err
+	}
+	if req.ContainerName != "" {
+		if err := auth.AuthorizeContainerAccess(ctx, req.ContainerName); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pb.GetHTTPMetricsResponse{Enabled: s.httpStats != nil}
+	if s.httpStats == nil {
+		return resp, nil
+	}
+	samples, from, to := s.httpStats.Latest()
+	if !to.IsZero() {
+		resp.WindowStart = timestamppb.New(from)
+		resp.WindowEnd = timestamppb.New(to)
+	}
+	for _, smp := range samples {
+		if req.ContainerName != "" {
+			if smp.ContainerName != req.ContainerName {
+				continue
+			}
+		} else if auth.AuthorizeContainerAccess(ctx, smp.ContainerName) != nil {
+			continue
+		}
+		resp.Containers = append(resp.Containers, &pb.ContainerHTTPMetrics{
+			ContainerName:          smp.ContainerName,
+			Requests:               smp.Requests,
+			ServerErrors:           smp.ServerErrors,
+			ClientErrors:           smp.ClientErrors,
+			RequestsPerSecond:      smp.RequestsPerSec,
+			ErrorRatio:             smp.ErrorRatio,
+			ResponseBytesPerSecond: smp.BytesPerSec,
+			P50Ms:                  durationMillis(smp.P50),
+			P95Ms:                  durationMillis(smp.P95),
+			P99Ms:                  durationMillis(smp.P99),
+		})
+	}
+	return resp, nil
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
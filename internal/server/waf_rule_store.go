@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/footprintai/containarium/internal/safecast"
+	"github.com/footprintai/containarium/internal/waf"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// ErrWAFRuleNotFound is returned by a WAF rule store's delete for an unknown
+// name (the server maps it to idempotent success).
+var ErrWAFRuleNotFound = errors.New("waf rule not found")
+
+// WAFRuleStore persists operator-managed rules for the userspace WAF (#662
+// Tier 3). Like the Tier 2 signatures they are keyed by name, and Set assigns
+// a stable id (>= waf.OperatorRuleIDBase) the first time a name is seen, so
+// the id in a match's audit row and event never changes under an operator.
+type WAFRuleStore interface {
+	Set(ctx context.Context, rule *pb.WAFRule) (*pb.WAFRule, error)
+	List(ctx context.Context) ([]*pb.WAFRule, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// --- in-memory ------------------------------------------------------
+
+// MemWAFRuleStore is a goroutine-safe in-memory store used on --standalone
+// daemons and in tests.
+type MemWAFRuleStore struct {
+	mu     sync.RWMutex
+	m      map[string]*pb.WAFRule
+	nextID uint32
+}
+
+func NewMemWAFRuleStore() *MemWAFRuleStore {
+	return &MemWAFRuleStore{m: make(map[string]*pb.WAFRule), nextID: waf.OperatorRuleIDBase}
+}
+
+func (s *MemWAFRuleStore) Set(_ context.Context, rule *pb.WAFRule) (*pb.WAFRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := proto.Clone(rule).(*pb.WAFRule)
+	if cur, ok := s.m[rule.GetName()]; ok {
+		rec.Id = cur.GetId()
+	} else {
+		if s.nextID > waf.MaxRuleID {
+			return nil, fmt.Errorf("%w: no id left for rule %q (ids are not reused)", errWAFRuleIDRange, rule.GetName())
+		}
+		rec.Id = s.nextID
+		s.nextID++
+	}
+	s.m[rule.GetName()] = rec
+	return proto.Clone(rec).(*pb.WAFRule), nil
+}
+
+func (s *MemWAFRuleStore) List(_ context.Context) ([]*pb.WAFRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*pb.WAFRule, 0, len(s.m))
+	for _, rec := range s.m {
+		out = append(out, proto.Clone(rec).(*pb.WAFRule))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].GetName() < out[j].GetName() })
+	return out, nil
+}
+
+func (s *MemWAFRuleStore) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.m[name]; !ok {
+		return ErrWAFRuleNotFound
+	}
+	delete(s.m, name)
+	return nil
+}
+
+// --- postgres -------------------------------------------------------
+
+type PostgresWAFRuleStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresWAFRuleStore(ctx context.Context, pool *pgxpool.Pool) (*PostgresWAFRuleStore, error) {
+	s := &PostgresWAFRuleStore{pool: pool}
+	const schema = `
+		CREATE TABLE IF NOT EXISTS waf_rules (
+			name TEXT PRIMARY KEY,
+			id INTEGER NOT NULL UNIQUE,
+			target TEXT NOT NULL,
+			header TEXT NOT NULL DEFAULT '',
+			match TEXT NOT NULL,
+			pattern TEXT NOT NULL,
+			action TEXT NOT NULL,
+			tenants TEXT[] NOT NULL DEFAULT '{}',
+			enabled BOOLEAN NOT NULL DEFAULT true,
+			note TEXT NOT NULL DEFAULT '',
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+		ALTER TABLE waf_rules ADD COLUMN IF NOT EXISTS requests_per_minute INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE waf_rules ADD COLUMN IF NOT EXISTS burst INTEGER NOT NULL DEFAULT 0;`
+	if _, err := pool.Exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("init waf_rules schema: %w", err)
+	}
+	return s, nil
+}
+
+func (s *PostgresWAFRuleStore) Set(ctx context.Context, rule *pb.WAFRule) (*pb.WAFRule, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after a successful Commit
+
+	// Keep an existing name's id; assign the next free one for a new name, in
+	// the same transaction so concurrent inserts can't collide on an id.
+	var id int32
+	err = tx.QueryRow(ctx, `SELECT id FROM waf_rules WHERE name = $1`, rule.GetName()).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := tx.QueryRow(ctx,
+			`SELECT COALESCE(MAX(id), $1 - 1) + 1 FROM waf_rules`,
+			int32(waf.OperatorRuleIDBase)).Scan(&id); err != nil {
+			return nil, fmt.Errorf("assign waf rule id: %w", err)
+		}
+		if id > waf.MaxRuleID {
+			return nil, fmt.Errorf("%w: no id left for rule %q (ids are not reused)", errWAFRuleIDRange, rule.GetName())
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("lookup waf rule id: %w", err)
+	}
+
+	tenants := rule.GetTenants()
+	if tenants == nil {
+		tenants = []string{}
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO waf_rules (name, id, target, header, match, pattern, action, tenants, enabled, note,
+			requests_per_minute, burst, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			target = EXCLUDED.target, header = EXCLUDED.header, match = EXCLUDED.match,
+			pattern = EXCLUDED.pattern, action = EXCLUDED.action, tenants = EXCLUDED.tenants,
+			enabled = EXCLUDED.enabled, note = EXCLUDED.note,
+			requests_per_minute = EXCLUDED.requests_per_minute, burst = EXCLUDED.burst, updated_at = NOW()`,
+		rule.GetName(), id, rule.GetTarget(), rule.GetHeader(), rule.GetMatch(), rule.GetPattern(),
+		rule.GetAction(), tenants, rule.GetEnabled(), rule.GetNote(),
+		rule.GetRequestsPerMinute(), rule.GetBurst()); err != nil {
+		return nil, fmt.Errorf("upsert waf rule: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	out := proto.Clone(rule).(*pb.WAFRule)
+	out.Id = safecast.U32(id)
+	return out, nil
+}
+
+func (s *PostgresWAFRuleStore) List(ctx context.Context) ([]*pb.WAFRule, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT name, id, target, header, match, pattern, action, tenants, enabled, note,
+			requests_per_minute, burst
+		FROM waf_rules ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("list waf rules: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.WAFRule
+	for rows.Next() {
+		var (
+			r  pb.WAFRule
+			id int32
+		)
+		if err := rows.Scan(&r.Name, &id, &r.Target, &r.Header, &r.Match, &r.Pattern, &r.Action,
+			&r.Tenants, &r.Enabled, &r.Note, &r.RequestsPerMinute, &r.Burst); err != nil {
+			return nil, fmt.Errorf("scan waf rule: %w", err)
+		}
+		r.Id = safecast.U32(id)
+		out = append(out, &r)
+	}
+	return out, rows.Err()
+}
+
+func (s *PostgresWAFRuleStore) Delete(ctx context.Context, name string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM waf_rules WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("delete waf rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWAFRuleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/footprintai/containarium/internal/audit"
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/waf"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+// defaultWAFReloadInterval is how often the reloader re-reads the rule store
+// and the container IP → tenant map even without a local edit — it picks up a
+// rule changed through another daemon sharing the Postgres store, and boxes
+// created or moved since the last pass.
+const defaultWAFReloadInterval = 30 * time.Second
+
+// errWAFRuleIDRange marks a rule id the engine cannot carry. Clamped or
+// truncated it would collide with another rule's, and every match of one
+// would be audited as the other.
+var errWAFRuleIDRange = fmt.Errorf("waf rule ids above %d do not fit the engine", waf.MaxRuleID)
+
+// wafRuleFromProto converts a stored rule to the engine's form.
+func wafRuleFromProto(r *pb.WAFRule) (waf.Rule, error) {
+	if r.GetId() > waf.MaxRuleID {
+		return waf.Rule{}, fmt.Errorf("%w: rule %q has id %d", errWAFRuleIDRange, r.GetName(), r.GetId())
+	}
+	return waf.Rule{
+		ID:      uint16(r.GetId()), // #nosec G115 -- bounded by waf.MaxRuleID above
+		Name:    r.GetName(),
+		Target:  waf.Target(strings.ToLower(r.GetTarget())),
+		Header:  r.GetHeader(),
+		Match:   waf.MatchKind(strings.ToLower(r.GetMatch())),
+		Pattern: r.GetPattern(),
+		Action:  waf.Action(strings.ToLower(r.GetAction())),
+		Tenants: r.GetTenants(),
+
+		RequestsPerMinute: int(r.GetRequestsPerMinute()),
+		Burst:             int(r.GetBurst()),
+	}, nil
+}
+
+// wafContainerLister is the slice of the Incus client the reloader needs to
+// attribute a destination IP to a tenant.
+type wafContainerLister interface {
+	ListContainers() ([]incus.ContainerInfo, error)
+}
+
+// WAFRuleReloader keeps a waf.RuleEngine in step with the operator rule store
+// (#662 Tier 3). It recompiles on a local edit (Trigger, called by the
+// NetworkPolicyServer) and on a slow poll, and swaps the compiled set into the
+// engine atomically — the proxy keeps its listener and its live connections
+// across a reload. It also owns the container IP → tenant map behind the
+// engine's TenantOf, refreshed on the same poll.
+type WAFRuleReloader struct {
+	engine     *waf.RuleEngine
+	store      func() WAFRuleStore // read at each reload: the store is swapped to Postgres at startup
+	containers wafContainerLister  // nil → no tenant attribution (global rules only)
+	kick       chan struct{}
+
+	mu       sync.RWMutex
+	ipTenant map[string]string
+	loaded   string // fingerprint of the installed set, to log only real changes
+}
+
+// NewWAFRuleReloader wires engine to the store. containers may be nil.
+func NewWAFRuleReloader(engine *waf.RuleEngine, store func() WAFRuleStore, containers wafContainerLister) *WAFRuleReloader {
+	r := &WAFRuleReloader{
+		engine:     engine,
+		store:      store,
+		containers: containers,
+		kick:       make(chan struct{}, 1),
+		ipTenant:   map[string]string{},
+	}
+	engine.TenantOf = r.TenantOf
+	return r
+}
+
+// Trigger asks the run loop for a reload now. Never blocks; a reload already
+// pending absorbs it.
+func (r *WAFRuleReloader) Trigger() {
+	select {
+	case r.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run reloads at startup, on every Trigger, and every interval until ctx is
+// cancelled. Blocks; run it in a goroutine.
+func (r *WAFRuleReloader) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultWAFReloadInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		r.refreshTenants()
+		if err := r.Reload(ctx); err != nil {
+			log.Printf("[waf] reload rules: %v (keeping the previous set)", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-r.kick:
+		}
+	}
+}
+
+// Reload reads the store, compiles the enabled rules, and installs them. A
+// stored rule that no longer compiles is skipped with a log line rather than
+// failing the set, so one bad row can't switch the rest of the WAF off.
+func (r *WAFRuleReloader) Reload(ctx context.Context) error {
+	store := r.store()
+	if store == nil {
+		return nil
+	}
+	stored, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	var rules []waf.Rule
+	for _, s := range stored {
+		if !s.GetEnabled() {
+			continue
+		}
+		rule, err := wafRuleFromProto(s)
+		if err == nil {
+			err = waf.ValidateRule(rule)
+		}
+		if err != nil {
+			log.Printf("[waf] skipping stored rule: %v", err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	set, err := waf.CompileRules(rules)
+	if err != nil {
+		return err
+	}
+	r.engine.Swap(set)
+	fp := fmt.Sprint(rules)
+	r.mu.Lock()
+	changed := fp != r.loaded
+	r.loaded = fp
+	r.mu.Unlock()
+	if changed {
+		log.Printf("[waf] loaded %d operator rule(s)", set.Len())
+	}
+	return nil
+}
+
+// refreshTenants rebuilds the IP → tenant map from the live containers.
+func (r *WAFRuleReloader) refreshTenants() {
+	if r.containers == nil {
+		return
+	}
+	containers, err := r.containers.ListContainers()
+	if err != nil {
+		log.Printf("[waf] list containers for tenant attribution: %v", err)
+		return
+	}
+	m := make(map[string]string, len(containers))
+	for _, c := range containers {
+		if c.IPAddress == "" {
+			continue
+		}
+		if tenant := resolveTenant(c.Tenant, c.Labels[cloudOrgIDLabel], c.Name); tenant != "" {
+			m[c.IPAddress] = tenant
+		}
+	}
+	r.mu.Lock()
+	r.ipTenant = m
+	r.mu.Unlock()
+}
+
+// TenantOf attributes an original destination (host:port) to the tenant
+// owning the container at that IP; "" when unknown.
+func (r *WAFRuleReloader) TenantOf(orig string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ipTenant[waf.HostOf(orig)]
+}
+
+// wafMatchHook returns the proxy's OnMatch: each matched rule is written to
+// the audit log (network_policy.waf_block for a block rule, waf_rate_limit
+// for a rate rule whose bucket was empty, waf_match for a log rule) and
+// published on the event bus. Either sink may be nil.
+func wafMatchHook(ctx context.Context, auditStore *audit.Store, bus *events.Bus) func(orig string, v waf.Verdict, dropped bool) {
+	var emitter *events.Emitter
+	if bus != nil {
+		emitter = events.NewEmitter(bus)
+	}
+	return func(orig string, v waf.Verdict, dropped bool) {
+		for _, m := range v.Matches {
+			// Only the rule that decided the verdict dropped the connection.
+			ruleDropped := dropped && m.RuleID == v.RuleID && m.Action != waf.ActionLog
+			log.Printf("[waf] match: tenant=%q dst=%s rule=%d(%s) action=%s dropped=%v",
+				v.Tenant, orig, m.RuleID, m.RuleName, m.Action, ruleDropped)
+			if emitter != nil {
+				emitter.EmitWAFMatch(&pb.WAFMatchEvent{
+					RuleId:      uint32(m.RuleID),
+					RuleName:    m.RuleName,
+					Action:      string(m.Action),
+					Target:      string(m.Target),
+					Tenant:      v.Tenant,
+					Destination: orig,
+					Dropped:     ruleDropped,
+				})
+			}
+			if auditStore == nil {
+				continue
+			}
+			action := "network_policy.waf_match"
+			switch m.Action {
+			case waf.ActionBlock:
+				action = "network_policy.waf_block"
+			case waf.ActionRateLimit:
+				action = "network_policy.waf_rate_limit"
+			}
+			detail, _ := json.Marshal(map[string]any{
+				"orig": orig, "rule_id": m.RuleID, "rule": m.RuleName, "action": m.Action,
+				"target": m.Target, "tenant": v.Tenant, "dropped": ruleDropped,
+			})
+			if err := auditStore.Log(ctx, &audit.AuditEntry{
+				Username: "_system", Action: action, ResourceType: "network_policy",
+				ResourceID: orig, Detail: string(detail),
+			}); err != nil {
+				log.Printf("[waf] audit match: %v", err)
+			}
+		}
+	}
+}
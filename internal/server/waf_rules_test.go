@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/footprintai/containarium/internal/events"
+	"github.com/footprintai/containarium/internal/waf"
+	"github.com/footprintai/containarium/pkg/core/incus"
+	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
+)
+
+func TestMemWAFRuleStore_StableIDs(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemWAFRuleStore()
+	a, _ := s.Set(ctx, &pb.WAFRule{Name: "a", Target: "path", Match: "literal", Pattern: "x", Action: "block"})
+	b, _ := s.Set(ctx, &pb.WAFRule{Name: "b", Target: "path", Match: "literal", Pattern: "y", Action: "log"})
+	if a.GetId() < waf.OperatorRuleIDBase || b.GetId() == a.GetId() {
+		t.Fatalf("ids %d, %d: want distinct ids from %d", a.GetId(), b.GetId(), waf.OperatorRuleIDBase)
+	}
+	a2, _ := s.Set(ctx, &pb.WAFRule{Name: "a", Target: "query", Match: "regex", Pattern: "z", Action: "log", Id: 7})
+	if a2.GetId() != a.GetId() || a2.GetTarget() != "query" {
+		t.Errorf("upsert: %+v (id should stay %d)", a2, a.GetId())
+	}
+	if err := s.Delete(ctx, "nope"); err != ErrWAFRuleNotFound {
+		t.Errorf("Delete unknown = %v", err)
+	}
+}
+
+func TestWAFRule_SetValidatesAndNormalizes(t *testing.T) {
+	s := NewNetworkPolicyServer(NewMemNetworkPolicyStore())
+	s.SetWAFRuleStore(NewMemWAFRuleStore())
+	var reloads int
+	s.SetWAFRulesChanged(func() { reloads++ })
+	ctx := npAdminCtx()
+
+	out, err := s.SetWAFRule(ctx, &pb.SetWAFRuleRequest{Rule: &pb.WAFRule{
+		Name: " jndi ", Target: "HEADER", Header: "X-Api-Version", Pattern: "${jndi:",
+		Tenants: []string{"globex", "acme", "acme", " "}, Enabled: true,
+	}})
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	r := out.GetRule()
+	if r.GetName() != "jndi" || r.GetTarget() != "header" || r.GetMatch() != "literal" || r.GetAction() != "block" ||
+		len(r.GetTenants()) != 2 || r.GetTenants()[0] != "acme" {
+		t.Errorf("stored rule not normalized: %+v", r)
+	}
+	for name, bad := range map[string]*pb.WAFRule{
+		"bad regex":    {Name: "r", Target: "path", Match: "regex", Pattern: "("},
+		"bad target":   {Name: "r", Target: "cookie", Pattern: "x"},
+		"no header":    {Name: "r", Target: "header", Pattern: "x"},
+		"rate, no rpm": {Name: "r", Action: "rate_limit"},
+	} {
+		if _, err := s.SetWAFRule(ctx, &pb.SetWAFRuleRequest{Rule: bad}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: %v, want InvalidArgument", name, err)
+		}
+	}
+	if _, err := s.DeleteWAFRule(ctx, &pb.DeleteWAFRuleRequest{Name: "jndi"}); err != nil {
+		t.Fatal(err)
+	}
+	if reloads != 2 {
+		t.Errorf("reload hook ran %d times, want once per successful edit", reloads)
+	}
+}
+
+// Rule ids are uint16 in the engine; an id past that is refused, not clamped
+// onto another rule's.
+func TestWAFRule_IDsBeyondTheEngineAreRefused(t *testing.T) {
+	if _, err := wafRuleFromProto(&pb.WAFRule{Name: "r", Id: waf.MaxRuleID + 1}); !errors.Is(err, errWAFRuleIDRange) {
+		t.Errorf("wafRuleFromProto = %v, want errWAFRuleIDRange", err)
+	}
+
+	store := NewMemWAFRuleStore()
+	store.nextID = waf.MaxRuleID
+	s := NewNetworkPolicyServer(NewMemNetworkPolicyStore())
+	s.SetWAFRuleStore(store)
+	ctx := npAdminCtx()
+	rule := func(name string) *pb.SetWAFRuleRequest {
+		return &pb.SetWAFRuleRequest{Rule: &pb.WAFRule{Name: name, Target: "path", Pattern: "x"}}
+	}
+
+	last, err := s.SetWAFRule(ctx, rule("last"))
+	if err != nil || last.GetRule().GetId() != waf.MaxRuleID {
+		t.Fatalf("Set last = %v, %v", last, err)
+	}
+	if _, err := s.SetWAFRule(ctx, rule("one-too-many")); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Set past the last id: %v, want InvalidArgument", err)
+	}
+	if _, err := s.SetWAFRule(ctx, rule("last")); err != nil {
+		t.Errorf("updating an existing rule: %v", err)
+	}
+}
+
+type fakeWAFContainers []incus.ContainerInfo
+
+func (f fakeWAFContainers) ListContainers() ([]incus.ContainerInfo, error) { return f, nil }
+
+// The reloader compiles only enabled rules, skips a stored rule that no
+// longer compiles, and attributes destinations to tenants for enable lists.
+func TestWAFRuleReloader(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemWAFRuleStore()
+	for _, r := range []*pb.WAFRule{
+		{Name: "acme-only", Target: "path", Match: "literal", Pattern: "/admin", Action: "block", Tenants: []string{"acme"}, Enabled: true},
+		{Name: "off", Target: "path", Match: "literal", Pattern: "/", Action: "block"},
+		{Name: "broken", Target: "path", Match: "regex", Pattern: "(", Action: "block", Enabled: true},
+	} {
+		_, _ = store.Set(ctx, r)
+	}
+	engine := waf.NewRuleEngine(nil)
+	rl := NewWAFRuleReloader(engine, func() WAFRuleStore { return store },
+		fakeWAFContainers{{Name: "web", Tenant: "acme", IPAddress: "10.0.0.5"}, {Name: "db", Tenant: "globex", IPAddress: "10.0.0.6"}})
+	rl.refreshTenants()
+	if err := rl.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := engine.Rules().Len(); n != 1 {
+		t.Fatalf("loaded %d rules, want only the enabled, valid one", n)
+	}
+	head := []byte("GET /admin HTTP/1.1\r\n\r\n")
+	if v := engine.InspectConn("", "10.0.0.5:8080", head); !v.Block || v.Tenant != "acme" {
+		t.Errorf("acme box: %+v", v)
+	}
+	if v := engine.InspectConn("", "10.0.0.6:8080", head); v.Block {
+		t.Errorf("globex box blocked by an acme-only rule: %+v", v)
+	}
+}
+
+func TestWAFMatchHook_PublishesEveryMatch(t *testing.T) {
+	bus := events.NewBus()
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_WAF}})
+	defer bus.Unsubscribe(sub.ID)
+
+	hook := wafMatchHook(context.Background(), nil, bus)
+	hook("10.0.0.5:80", waf.Verdict{Block: true, RuleID: 1001, RuleName: "b", Tenant: "acme", Matches: []waf.Match{
+		{RuleID: 1000, RuleName: "l", Action: waf.ActionLog, Target: waf.TargetPath},
+		{RuleID: 1001, RuleName: "b", Action: waf.ActionBlock, Target: waf.TargetQuery},
+	}}, true)
+
+	var got []*pb.WAFMatchEvent
+	for len(got) < 2 {
+		select {
+		case ev := <-sub.Events:
+			if ev.GetType() != pb.EventType_EVENT_TYPE_WAF_MATCH || ev.GetResourceId() != "10.0.0.5:80" {
+				t.Fatalf("event %v", ev)
+			}
+			got = append(got, ev.GetWafMatchEvent())
+		case <-time.After(2 * time.Second):
+			t.Fatalf("got %d events, want 2", len(got))
+		}
+	}
+	if got[0].GetDropped() || got[0].GetAction() != "log" || !got[1].GetDropped() || got[1].GetTenant() != "acme" {
+		t.Errorf("events: %v", got)
+	}
+}
+
+// A rate rule that refused a request dropped it, just as a block rule does.
+func TestWAFMatchHook_RateLimitDrops(t *testing.T) {
+	bus := events.NewBus()
+	sub := bus.Subscribe(&pb.SubscribeEventsRequest{ResourceTypes: []pb.ResourceType{pb.ResourceType_RESOURCE_TYPE_WAF}})
+	defer bus.Unsubscribe(sub.ID)
+
+	hook := wafMatchHook(context.Background(), nil, bus)
+	hook("10.0.0.5:80", waf.Verdict{Block: true, RateLimited: true, RuleID: 1002, RuleName: "r", Tenant: "acme", Matches: []waf.Match{
+		{RuleID: 1002, RuleName: "r", Action: waf.ActionRateLimit, Target: waf.TargetPath},
+	}}, true)
+
+	select {
+	case ev := <-sub.Events:
+		if m := ev.GetWafMatchEvent(); !m.GetDropped() || m.GetAction() != "rate_limit" {
+			t.Errorf("event: %v", m)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event")
+	}
+}
@@ -0,0 +1,36 @@
+// Package snapsched takes container snapshots on a schedule and prunes them
+// to a retention policy. Snapshots themselves exist since #1160
+// (CreateContainerSnapshot and friends); what was missing is anyone taking
+// them — a manual-only snapshot is never there before the risky agent
+// session that needed it.
+//
+// A policy is per container, set by SetContainerSnapshotPolicy and persisted
+// as JSON on the container's Incus config (incus.SnapshotPolicyKey), so it
+// survives a daemon restart and goes away with the box. It names a cadence —
+// a fixed interval or a cron expression — and how many to keep: the last N,
+// one per day for D days, one per ISO week for W weeks.
+//
+// # Design split
+//
+// Shaped like internal/ttlsweeper: a pure Decide that turns container views
+// and a clock into plans, and a Manager that ticks, carries the plans out
+// through interface seams (Source, Snapshotter, Hooks, Notifier) and owns
+// the failure backoff. The daemon-side adapters live in internal/server.
+//
+// # Scheduled snapshots are named, not tracked
+//
+// A scheduled snapshot is called "auto-<UTC timestamp>" (see SnapshotName).
+// The name is the scheduler's only state: the newest one anchors the next
+// run, and retention counts and prunes only names of that form, so a
+// snapshot a tenant took by hand is never pruned. CreateContainerSnapshot
+// refuses names of that form for the same reason.
+//
+// # Hooks
+//
+// A ZFS snapshot is atomic and crash-consistent on its own. What it cannot
+// be is application-consistent, which is what the hooks are for: PreCommand
+// runs inside the box before the snapshot (flush a database, `fsfreeze -f`
+// a mounted block volume), PostCommand after it (the thaw), and Freeze
+// pauses every process in the box across the snapshot itself. Each command
+// has HookTimeout to finish; one that does not has failed.
+package snapsched
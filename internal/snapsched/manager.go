@@ -0,0 +1,351 @@
+package snapsched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is the tick cadence. Cron's resolution is a minute, so a
+// once-a-minute check takes every snapshot in the minute it is due.
+const DefaultInterval = 60 * time.Second
+
+// HookTimeout bounds each run of a policy's PreCommand and PostCommand. A
+// hook still running after it has failed: the tick is serial, so one hung
+// hook would otherwise stop every other box's schedule.
+const HookTimeout = 5 * time.Minute
+
+const (
+	// failBackoffBase is the wait after a container's first failed scheduled
+	// snapshot (or a snapshot's first failed prune); it doubles each
+	// consecutive failure up to failBackoffMax. A failure tends to persist —
+	// a hook that always exits non-zero, a snapshot pinned by a fork — and
+	// Decide keeps planning the same action every tick, so without backoff
+	// one broken policy is a journal line a minute forever. Same shape as
+	// the ttlsweeper's (#831).
+	failBackoffBase = 1 * time.Minute
+	failBackoffMax  = 1 * time.Hour
+	// maxBackoffShift caps the exponent so the shift cannot overflow.
+	maxBackoffShift = 16
+)
+
+// errNoHooks is returned for a policy with hooks on a daemon that cannot
+// run them. Skipping the hooks instead would take exactly the snapshot the
+// policy says must not be taken unquiesced.
+var errNoHooks = errors.New("the policy has snapshot hooks, but this daemon cannot run commands in containers")
+
+// failState tracks consecutive failures of one action.
+type failState struct {
+	count       int
+	nextAttempt time.Time
+}
+
+// Source produces the container views Decide reads. The daemon adapts the
+// Incus client plus a per-container snapshot listing; tests use a literal
+// fake.
+type Source interface {
+	ListContainers() ([]ContainerView, error)
+}
+
+// Snapshotter takes and destroys a container's snapshots by short name.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, container, name string) error
+	Destroy(ctx context.Context, container, name string) error
+}
+
+// Hooks runs a policy's hooks in a container. May be nil, in which case a
+// policy that has hooks fails every run rather than skipping them.
+type Hooks interface {
+	// RunCommand runs command inside the container via /bin/sh -c and
+	// returns an error on a non-zero exit.
+	RunCommand(ctx context.Context, container, command string) error
+	Freeze(container string) error
+	Unfreeze(container string) error
+}
+
+// Notifier is told about every scheduled snapshot taken, pruned or failed.
+// The daemon publishes these on the events bus. May be nil.
+type Notifier interface {
+	SnapshotCreated(container, snapshot string)
+	SnapshotPruned(container, snapshot string)
+	SnapshotFailed(container, snapshot string, err error)
+}
+
+// Manager owns the tick loop. One per daemon, with the same lifecycle as
+// ttlsweeper.Manager: Start spawns, Stop signals and waits, both idempotent.
+//
+// Containers are handled one at a time on the tick goroutine, so a slow
+// hook delays the rest of the tick rather than overlapping the next one.
+type Manager struct {
+	source      Source
+	snaps       Snapshotter
+	hooks       Hooks
+	notifier    Notifier
+	interval    time.Duration
+	hookTimeout time.Duration
+	clock       func() time.Time
+
+	stopCh   chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+
+	// failures is keyed by container for a failed snapshot and by
+	// container@snapshot for a failed prune. Only touched from the run
+	// goroutine.
+	failures map[string]*failState
+}
+
+// Options bundles the optional knobs. Zero values give DefaultInterval,
+// HookTimeout and time.Now.
+type Options struct {
+	Interval    time.Duration
+	HookTimeout time.Duration
+	Clock       func() time.Time
+}
+
+// NewManager constructs a manager. source and snaps must not be nil; hooks
+// and notifier may be.
+func NewManager(source Source, snaps Snapshotter, hooks Hooks, notifier Notifier, opts Options) *Manager {
+	if source == nil {
+		panic("snapsched: nil Source")
+	}
+	if snaps == nil {
+		panic("snapsched: nil Snapshotter")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.HookTimeout <= 0 {
+		opts.HookTimeout = HookTimeout
+	}
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	return &Manager{
+		source:      source,
+		snaps:       snaps,
+		hooks:       hooks,
+		notifier:    notifier,
+		interval:    opts.Interval,
+		hookTimeout: opts.HookTimeout,
+		clock:       opts.Clock,
+		stopCh:      make(chan struct{}),
+		done:        make(chan struct{}),
+		failures:    make(map[string]*failState),
+	}
+}
+
+// Start spawns the tick loop. The loop exits on ctx cancellation or Stop.
+func (m *Manager) Start(ctx context.Context) {
+	go m.run(ctx)
+	log.Printf("[snapsched] ticker started (interval=%s)", m.interval)
+}
+
+// Stop signals the loop to exit and waits for it to finish. Idempotent.
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+	<-m.done
+}
+
+func (m *Manager) run(ctx context.Context) {
+	defer close(m.done)
+
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-t.C:
+			m.tick(ctx)
+		}
+	}
+}
+
+// tick evaluates one round. A failure on one container is logged and
+// notified, and the tick moves on to the next.
+func (m *Manager) tick(ctx context.Context) {
+	containers, err := m.source.ListContainers()
+	if err != nil {
+		log.Printf("[snapsched] list containers: %v", err)
+		return
+	}
+	now := m.clock()
+	plans := Decide(containers, now)
+	m.pruneFailures(plans)
+
+	for _, p := range plans {
+		if p.Snapshot != "" {
+			if m.backingOff(p.Container, now) {
+				continue
+			}
+			taken, err := m.take(ctx, p)
+			if taken {
+				delete(m.failures, p.Container)
+				log.Printf("[snapsched] snapshot %s@%s", p.Container, p.Snapshot)
+				m.notifyCreated(p.Container, p.Snapshot)
+			}
+			if err != nil {
+				m.notifyFailed(p.Container, p.Snapshot, err)
+				if !taken {
+					m.recordFailure(p.Container, err, now)
+					// Prune was computed as though this snapshot exists. A
+					// failing schedule must not eat the restore points it
+					// is failing to replace.
+					continue
+				}
+				log.Printf("[snapsched] snapshot %s@%s taken, but: %v", p.Container, p.Snapshot, err)
+			}
+		}
+		for _, name := range p.Prune {
+			key := p.Container + "@" + name
+			if m.backingOff(key, now) {
+				continue
+			}
+			if err := m.snaps.Destroy(ctx, p.Container, name); err != nil {
+				m.recordFailure(key, err, now)
+				m.notifyFailed(p.Container, name, fmt.Errorf("prune: %w", err))
+				continue
+			}
+			delete(m.failures, key)
+			log.Printf("[snapsched] pruned %s", key)
+			m.notifyPruned(p.Container, name)
+		}
+	}
+}
+
+// take runs the hooks and takes the plan's snapshot. taken reports whether
+// the snapshot exists afterwards; err may be set either way — a snapshot
+// can be taken and its thaw still fail.
+//
+// Order: PreCommand (it needs the box's processes running), Freeze, the
+// snapshot, Unfreeze, PostCommand. Hooks only run in a running box; a
+// stopped box is already as quiet as it gets.
+func (m *Manager) take(ctx context.Context, p Plan) (taken bool, err error) {
+	pol := p.Policy
+	hooked := p.Running && pol.hasHooks()
+	if hooked && m.hooks == nil {
+		return false, errNoHooks
+	}
+
+	if hooked && pol.PostCommand != "" {
+		// Registered before PreCommand runs, so a PreCommand that fails
+		// half-way still gets its thaw.
+		defer func() {
+			if perr := m.runHook(ctx, p.Container, pol.PostCommand); perr != nil {
+				err = errors.Join(err, fmt.Errorf("post-snapshot command: %w", perr))
+			}
+		}()
+	}
+	if hooked && pol.PreCommand != "" {
+		if err := m.runHook(ctx, p.Container, pol.PreCommand); err != nil {
+			return false, fmt.Errorf("pre-snapshot command: %w", err)
+		}
+	}
+
+	if hooked && pol.Freeze {
+		if err := m.hooks.Freeze(p.Container); err != nil {
+			return false, fmt.Errorf("freeze: %w", err)
+		}
+		defer func() {
+			if uerr := m.hooks.Unfreeze(p.Container); uerr != nil {
+				log.Printf("[snapsched] UNFREEZE FAILED for %s — the box stays paused until resumed with `incus start %s`: %v", p.Container, p.Container, uerr)
+				err = errors.Join(err, fmt.Errorf("unfreeze: %w", uerr))
+			}
+		}()
+	}
+
+	if err := m.snaps.Snapshot(ctx, p.Container, p.Snapshot); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// runHook runs one hook command under the hook timeout. A hook that
+// overruns it has failed, as one that exits non-zero has; the command may
+// still be running in the box.
+func (m *Manager) runHook(ctx context.Context, container, command string) error {
+	hctx, cancel := context.WithTimeout(ctx, m.hookTimeout)
+	defer cancel()
+	err := m.hooks.RunCommand(hctx, container, command)
+	if err != nil && errors.Is(hctx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("did not finish within %s: %w", m.hookTimeout, err)
+	}
+	return err
+}
+
+// backingOff reports whether key failed recently enough to skip this tick.
+func (m *Manager) backingOff(key string, now time.Time) bool {
+	fs := m.failures[key]
+	return fs != nil && now.Before(fs.nextAttempt)
+}
+
+// recordFailure bumps key's consecutive-failure count and schedules its
+// next attempt with exponential backoff.
+func (m *Manager) recordFailure(key string, err error, now time.Time) {
+	fs := m.failures[key]
+	if fs == nil {
+		fs = &failState{}
+		m.failures[key] = fs
+	}
+	fs.count++
+	shift := fs.count - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	backoff := failBackoffBase << uint(shift)
+	if backoff > failBackoffMax {
+		backoff = failBackoffMax
+	}
+	fs.nextAttempt = now.Add(backoff)
+	log.Printf("[snapsched] %s: %v (attempt %d, retry in %s)", key, err, fs.count, backoff)
+}
+
+// pruneFailures drops failure records for actions no longer planned — the
+// policy was removed, the snapshot deleted by hand — so the map stays
+// bounded and a recreated box starts clean.
+func (m *Manager) pruneFailures(plans []Plan) {
+	if len(m.failures) == 0 {
+		return
+	}
+	planned := map[string]bool{}
+	for _, p := range plans {
+		if p.Snapshot != "" {
+			planned[p.Container] = true
+		}
+		for _, name := range p.Prune {
+			planned[p.Container+"@"+name] = true
+		}
+	}
+	for key := range m.failures {
+		if !planned[key] {
+			delete(m.failures, key)
+		}
+	}
+}
+
+func (m *Manager) notifyCreated(container, snapshot string) {
+	if m.notifier != nil {
+		m.notifier.SnapshotCreated(container, snapshot)
+	}
+}
+
+func (m *Manager) notifyPruned(container, snapshot string) {
+	if m.notifier != nil {
+		m.notifier.SnapshotPruned(container, snapshot)
+	}
+}
+
+func (m *Manager) notifyFailed(container, snapshot string, err error) {
+	if m.notifier != nil {
+		m.notifier.SnapshotFailed(container, snapshot, err)
+	}
+}
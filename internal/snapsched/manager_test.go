@@ -0,0 +1,279 @@
+package snapsched
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// --- fakes ---
+
+type fakeSource struct {
+	views []ContainerView
+	err   error
+}
+
+func (f *fakeSource) ListContainers() ([]ContainerView, error) { return f.views, f.err }
+
+// journal records every side effect in order, across the snapshotter and
+// the hooks, so ordering can be asserted across both.
+type journal struct {
+	calls []string
+	errs  map[string]error // keyed by call prefix, e.g. "snapshot" or "run pre"
+	// hang is a command that runs until its context is done.
+	hang string
+}
+
+func (j *journal) do(call string) error {
+	j.calls = append(j.calls, call)
+	for prefix, err := range j.errs {
+		if strings.HasPrefix(call, prefix) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *journal) Snapshot(_ context.Context, container, name string) error {
+	return j.do("snapshot " + container + "@" + name)
+}
+
+func (j *journal) Destroy(_ context.Context, container, name string) error {
+	return j.do("destroy " + container + "@" + name)
+}
+
+func (j *journal) RunCommand(ctx context.Context, container, command string) error {
+	if command == j.hang {
+		j.calls = append(j.calls, "run "+command)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return j.do("run " + command)
+}
+
+func (j *journal) Freeze(container string) error   { return j.do("freeze " + container) }
+func (j *journal) Unfreeze(container string) error { return j.do("unfreeze " + container) }
+
+type fakeNotifier struct{ created, pruned, failed []string }
+
+func (n *fakeNotifier) SnapshotCreated(c, s string)         { n.created = append(n.created, c+"@"+s) }
+func (n *fakeNotifier) SnapshotPruned(c, s string)          { n.pruned = append(n.pruned, c+"@"+s) }
+func (n *fakeNotifier) SnapshotFailed(c, s string, _ error) { n.failed = append(n.failed, c+"@"+s) }
+
+func newTestManager(views []ContainerView, now *time.Time) (*Manager, *journal, *fakeNotifier) {
+	j := &journal{errs: map[string]error{}}
+	n := &fakeNotifier{}
+	m := NewManager(&fakeSource{views: views}, j, j, n, Options{
+		Interval: time.Hour, // never fires; tick called directly.
+		Clock:    func() time.Time { return *now },
+	})
+	return m, j, n
+}
+
+// dueView is a running box whose hourly policy is due at t0, with one
+// scheduled snapshot retention will prune once the new one exists.
+func dueView(p *Policy) []ContainerView {
+	return []ContainerView{{
+		Name:      "alice-container",
+		Policy:    p,
+		Running:   true,
+		Snapshots: []string{SnapshotName(t0.Add(-2 * time.Hour)), SnapshotName(t0.Add(-time.Hour))},
+	}}
+}
+
+// --- tests ---
+
+func TestManager_TickTakesThenPrunes(t *testing.T) {
+	now := t0
+	m, j, n := newTestManager(dueView(hourly(1)), &now)
+
+	m.tick(context.Background())
+
+	want := []string{
+		"snapshot alice-container@" + SnapshotName(t0),
+		"destroy alice-container@" + SnapshotName(t0.Add(-2*time.Hour)),
+		"destroy alice-container@" + SnapshotName(t0.Add(-time.Hour)),
+	}
+	if strings.Join(j.calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("calls =\n%s\nwant\n%s", strings.Join(j.calls, "\n"), strings.Join(want, "\n"))
+	}
+	if len(n.created) != 1 || len(n.pruned) != 2 || len(n.failed) != 0 {
+		t.Errorf("notified created=%v pruned=%v failed=%v", n.created, n.pruned, n.failed)
+	}
+}
+
+// Retention is computed as though the new snapshot exists. If it does not,
+// pruning would leave fewer restore points than the policy promises.
+func TestManager_FailedSnapshotPrunesNothingAndBacksOff(t *testing.T) {
+	now := t0
+	m, j, n := newTestManager(dueView(hourly(1)), &now)
+	j.errs["snapshot"] = errors.New("out of space")
+
+	m.tick(context.Background())
+	if len(j.calls) != 1 || !strings.HasPrefix(j.calls[0], "snapshot") {
+		t.Fatalf("calls = %v, want only the failed snapshot", j.calls)
+	}
+	if len(n.failed) != 1 {
+		t.Errorf("failed notifications = %v", n.failed)
+	}
+
+	// Still due a tick later, but inside the backoff window.
+	now = t0.Add(30 * time.Second)
+	m.tick(context.Background())
+	if len(j.calls) != 1 {
+		t.Errorf("retried inside the backoff window: %v", j.calls)
+	}
+
+	now = t0.Add(2 * time.Minute)
+	delete(j.errs, "snapshot")
+	m.tick(context.Background())
+	if len(j.calls) != 4 {
+		t.Errorf("did not retry and prune after the backoff: %v", j.calls)
+	}
+	if len(m.failures) != 0 {
+		t.Errorf("failure state left behind: %v", m.failures)
+	}
+}
+
+func TestManager_HookOrder(t *testing.T) {
+	now := t0
+	p := hourly(5)
+	p.PreCommand, p.PostCommand, p.Freeze = "pre", "post", true
+	m, j, _ := newTestManager(dueView(p), &now)
+
+	m.tick(context.Background())
+
+	want := "run pre,freeze alice-container,snapshot alice-container@" + SnapshotName(t0) + ",unfreeze alice-container,run post"
+	if got := strings.Join(j.calls, ","); got != want {
+		t.Fatalf("calls = %s\nwant    %s", got, want)
+	}
+}
+
+// A failing pre-command skips the snapshot, but its thaw still runs: a
+// pre-command that froze a filesystem and then failed must not leave it
+// frozen.
+func TestManager_FailedPreCommandSkipsTheSnapshotButThaws(t *testing.T) {
+	now := t0
+	p := hourly(5)
+	p.PreCommand, p.PostCommand = "pre", "post"
+	m, j, n := newTestManager(dueView(p), &now)
+	j.errs["run pre"] = errors.New("exit 1")
+
+	m.tick(context.Background())
+
+	if got := strings.Join(j.calls, ","); got != "run pre,run post" {
+		t.Fatalf("calls = %s", got)
+	}
+	if len(n.created) != 0 || len(n.failed) != 1 {
+		t.Errorf("created=%v failed=%v", n.created, n.failed)
+	}
+}
+
+// A pre-command that never finishes fails like one that exits non-zero,
+// rather than holding the tick — and every other box's schedule — forever.
+func TestManager_HungPreCommandTimesOut(t *testing.T) {
+	now := t0
+	p := hourly(5)
+	p.PreCommand, p.PostCommand = "pre", "post"
+	j := &journal{errs: map[string]error{}, hang: "pre"}
+	n := &fakeNotifier{}
+	m := NewManager(&fakeSource{views: dueView(p)}, j, j, n, Options{
+		HookTimeout: 10 * time.Millisecond,
+		Clock:       func() time.Time { return now },
+	})
+
+	m.tick(context.Background())
+
+	if got := strings.Join(j.calls, ","); got != "run pre,run post" {
+		t.Fatalf("calls = %s", got)
+	}
+	if len(n.created) != 0 || len(n.failed) != 1 {
+		t.Errorf("created=%v failed=%v", n.created, n.failed)
+	}
+}
+
+// A taken snapshot whose thaw fails is still a snapshot: it is reported
+// both ways, and retention runs.
+func TestManager_FailedPostCommandKeepsTheSnapshot(t *testing.T) {
+	now := t0
+	p := hourly(1)
+	p.PostCommand = "post"
+	m, j, n := newTestManager(dueView(p), &now)
+	j.errs["run post"] = errors.New("exit 1")
+
+	m.tick(context.Background())
+
+	if len(n.created) != 1 || len(n.failed) != 1 || len(n.pruned) != 2 {
+		t.Errorf("created=%v failed=%v pruned=%v", n.created, n.failed, n.pruned)
+	}
+}
+
+func TestManager_HooksSkippedOnAStoppedBox(t *testing.T) {
+	now := t0
+	p := hourly(5)
+	p.PreCommand, p.Freeze = "pre", true
+	views := dueView(p)
+	views[0].Running = false
+	m, j, _ := newTestManager(views, &now)
+
+	m.tick(context.Background())
+
+	if got := strings.Join(j.calls, ","); got != "snapshot alice-container@"+SnapshotName(t0) {
+		t.Fatalf("calls = %s", got)
+	}
+}
+
+// Without a way to run hooks, a hooked policy fails rather than taking the
+// unquiesced snapshot it exists to prevent.
+func TestManager_HooksRequiredWhenThePolicyHasThem(t *testing.T) {
+	now := t0
+	p := hourly(5)
+	p.PreCommand = "pre"
+	j := &journal{errs: map[string]error{}}
+	n := &fakeNotifier{}
+	m := NewManager(&fakeSource{views: dueView(p)}, j, nil, n, Options{Clock: func() time.Time { return now }})
+
+	m.tick(context.Background())
+
+	if len(j.calls) != 0 || len(n.failed) != 1 {
+		t.Fatalf("calls=%v failed=%v", j.calls, n.failed)
+	}
+}
+
+// A snapshot pinned by a fork cannot be pruned; it is retried on the
+// backoff schedule rather than every tick, and its failure state goes once
+// it is no longer planned.
+func TestManager_PinnedSnapshotBacksOff(t *testing.T) {
+	now := t0.Add(10 * time.Minute) // not due; prune only
+	views := []ContainerView{{
+		Name:      "alice-container",
+		Policy:    hourly(1),
+		Snapshots: []string{SnapshotName(t0.Add(-time.Hour)), SnapshotName(t0)},
+	}}
+	m, j, _ := newTestManager(views, &now)
+	j.errs["destroy"] = errors.New("snapshot has dependent clones")
+
+	m.tick(context.Background())
+	now = now.Add(time.Minute / 2)
+	m.tick(context.Background())
+	if len(j.calls) != 1 {
+		t.Fatalf("calls = %v, want one destroy attempt", j.calls)
+	}
+
+	// The snapshot is deleted by hand; its failure record goes with it.
+	views[0].Snapshots = []string{SnapshotName(t0)}
+	m.tick(context.Background())
+	if len(m.failures) != 0 {
+		t.Errorf("failures = %v", m.failures)
+	}
+}
+
+func TestManager_StartStopIdempotent(t *testing.T) {
+	now := t0
+	m, _, _ := newTestManager(nil, &now)
+	m.Start(context.Background())
+	m.Stop()
+	m.Stop()
+}
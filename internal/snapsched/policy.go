@@ -0,0 +1,271 @@
+package snapsched
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/backup"
+)
+
+// NamePrefix marks a snapshot the scheduler took. Retention only ever
+// counts and prunes snapshots whose name is NamePrefix plus a timestamp in
+// nameLayout — see ParseName.
+const NamePrefix = "auto-"
+
+// nameLayout is the timestamp half of a scheduled snapshot's name. Second
+// resolution, UTC, and no characters ZFS refuses in a snapshot name.
+const nameLayout = "20060102T150405Z"
+
+// MinInterval is the shortest interval a policy may ask for. The tick is a
+// minute; below a few of them the snapshots are noise that retention spends
+// its slots on, and the hooks would run more often than they can finish.
+const MinInterval = 5 * time.Minute
+
+// Policy is one container's snapshot schedule and retention. Exactly one of
+// IntervalSeconds and Cron is set.
+type Policy struct {
+	// IntervalSeconds takes a snapshot every this many seconds, measured
+	// from the previous scheduled snapshot.
+	IntervalSeconds int64 `json:"interval_seconds,omitempty"`
+	// Cron is a five-field cron expression evaluated in UTC, in the dialect
+	// backup schedules use (backup.ParseCron).
+	Cron string `json:"cron,omitempty"`
+
+	// KeepLast keeps the newest N scheduled snapshots.
+	KeepLast int `json:"keep_last,omitempty"`
+	// KeepDaily keeps the newest scheduled snapshot of each of the last N
+	// UTC days that have one.
+	KeepDaily int `json:"keep_daily,omitempty"`
+	// KeepWeekly keeps the newest scheduled snapshot of each of the last N
+	// ISO weeks that have one.
+	KeepWeekly int `json:"keep_weekly,omitempty"`
+
+	// PreCommand runs inside the box (as root, via /bin/sh -c) before each
+	// snapshot. A non-zero exit, or still running after HookTimeout, skips
+	// that snapshot.
+	PreCommand string `json:"pre_command,omitempty"`
+	// PostCommand runs inside the box after each snapshot attempt, whether
+	// or not PreCommand or the snapshot succeeded: it is the thaw for
+	// whatever PreCommand froze. It too fails after HookTimeout.
+	PostCommand string `json:"post_command,omitempty"`
+	// Freeze pauses every process in the box for the instant of the
+	// snapshot. Only applies to a running box.
+	Freeze bool `json:"freeze,omitempty"`
+
+	// UpdatedAt is when the policy was last set. The first scheduled
+	// snapshot is the first run after it, so setting a policy never
+	// triggers a catch-up for slots before it existed.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate refuses a policy that could never run, or that would grow
+// without bound.
+func (p *Policy) Validate() error {
+	switch {
+	case p.IntervalSeconds != 0 && p.Cron != "":
+		return fmt.Errorf("set either an interval or a cron expression, not both")
+	case p.IntervalSeconds == 0 && p.Cron == "":
+		return fmt.Errorf("an interval or a cron expression is required")
+	case p.IntervalSeconds < 0:
+		return fmt.Errorf("interval must be positive, got %ds", p.IntervalSeconds)
+	case p.IntervalSeconds > 0 && time.Duration(p.IntervalSeconds)*time.Second < MinInterval:
+		return fmt.Errorf("interval %ds is shorter than the minimum of %s", p.IntervalSeconds, MinInterval)
+	}
+	if p.Cron != "" {
+		if _, err := backup.ParseCron(p.Cron); err != nil {
+			return err
+		}
+	}
+	if p.KeepLast < 0 || p.KeepDaily < 0 || p.KeepWeekly < 0 {
+		return fmt.Errorf("retention counts must not be negative")
+	}
+	// Unlike a backup schedule, "keep everything" is not on offer: a
+	// snapshot every hour that is never pruned is a pool that fills, and
+	// the first sign is every box on it failing writes.
+	if p.KeepLast == 0 && p.KeepDaily == 0 && p.KeepWeekly == 0 {
+		return fmt.Errorf("a retention is required: set keep_last, keep_daily or keep_weekly")
+	}
+	return nil
+}
+
+// ParsePolicy decodes and validates a policy as stored on a container.
+func ParsePolicy(raw string) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		return nil, fmt.Errorf("corrupt snapshot policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid snapshot policy: %w", err)
+	}
+	return &p, nil
+}
+
+// Encode renders the policy as stored on a container.
+func (p *Policy) Encode() (string, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot policy: %w", err)
+	}
+	return string(b), nil
+}
+
+// NextRun returns when the policy next takes a snapshot, given the time of
+// the newest scheduled snapshot (zero when there is none). The zero time
+// means the policy can never run.
+func (p *Policy) NextRun(latest time.Time) time.Time {
+	anchor := p.UpdatedAt
+	if latest.After(anchor) {
+		anchor = latest
+	}
+	if p.IntervalSeconds > 0 {
+		return anchor.Add(time.Duration(p.IntervalSeconds) * time.Second)
+	}
+	c, err := backup.ParseCron(p.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return c.Next(anchor)
+}
+
+func (p *Policy) hasHooks() bool {
+	return p.PreCommand != "" || p.PostCommand != "" || p.Freeze
+}
+
+// SnapshotName is the name of the scheduled snapshot taken at t.
+func SnapshotName(t time.Time) string {
+	return NamePrefix + t.UTC().Format(nameLayout)
+}
+
+// ParseName reports whether name is a scheduled snapshot's, and when it
+// was taken. A name that merely starts with NamePrefix ("auto-upgrade") is
+// not one: it has to round-trip through SnapshotName.
+func ParseName(name string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(name, NamePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(nameLayout, rest)
+	if err != nil || SnapshotName(t) != name {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ContainerView is the slice of container state Decide reads.
+type ContainerView struct {
+	// Name is the Incus container name (e.g. "alice-container").
+	Name string
+	// Policy is the container's snapshot policy; nil = none.
+	Policy *Policy
+	// Running is true when the box is RUNNING. Hooks run inside the box,
+	// so a stopped box is snapshotted without them.
+	Running bool
+	// Snapshots are the container's snapshot names (the part after '@'),
+	// scheduled or not; Decide picks out the scheduled ones.
+	Snapshots []string
+}
+
+// Plan is what one tick does to one container.
+type Plan struct {
+	Container string
+	Policy    Policy
+	Running   bool
+	// Snapshot is the scheduled snapshot to take now; "" when none is due.
+	Snapshot string
+	// Prune are the scheduled snapshots retention no longer keeps, oldest
+	// first. Computed as though Snapshot has been taken, so a tick whose
+	// snapshot fails must not prune.
+	Prune []string
+}
+
+// Decide returns the plans for this tick: for each container with a
+// policy, whether a snapshot is due and which scheduled snapshots to
+// prune. Containers with nothing to do are left out. Pure — no IO, no
+// clock, no locks.
+func Decide(containers []ContainerView, now time.Time) []Plan {
+	var plans []Plan
+	for _, c := range containers {
+		if c.Policy == nil {
+			continue
+		}
+		var latest time.Time
+		names := make([]string, 0, len(c.Snapshots)+1)
+		for _, name := range c.Snapshots {
+			t, ok := ParseName(name)
+			if !ok {
+				continue
+			}
+			names = append(names, name)
+			if t.After(latest) {
+				latest = t
+			}
+		}
+
+		plan := Plan{Container: c.Name, Policy: *c.Policy, Running: c.Running}
+		if next := c.Policy.NextRun(latest); !next.IsZero() && !next.After(now) {
+			plan.Snapshot = SnapshotName(now)
+			names = append(names, plan.Snapshot)
+		}
+		plan.Prune = Expired(names, *c.Policy)
+		if plan.Snapshot == "" && len(plan.Prune) == 0 {
+			continue
+		}
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+// Expired returns the scheduled snapshots among names that retention p
+// does not keep, oldest first. Names that are not scheduled snapshots are
+// never returned.
+//
+// The daily and weekly tiers fill from the newest snapshot backwards by
+// calendar bucket, so a day with no snapshot (the box was deleted and
+// recreated, the daemon was down) does not use up a slot — the same rule
+// as backup.Expired. A snapshot kept by any tier is kept.
+func Expired(names []string, p Policy) []string {
+	type snap struct {
+		name string
+		at   time.Time
+	}
+	var snaps []snap
+	for _, name := range names {
+		if t, ok := ParseName(name); ok {
+			snaps = append(snaps, snap{name, t})
+		}
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].at.After(snaps[j].at) })
+
+	keep := make(map[string]bool, len(snaps))
+	for i := 0; i < p.KeepLast && i < len(snaps); i++ {
+		keep[snaps[i].name] = true
+	}
+	tier := func(n int, bucket func(time.Time) string) {
+		seen := map[string]bool{}
+		for _, s := range snaps {
+			if len(seen) >= n {
+				return
+			}
+			if b := bucket(s.at); !seen[b] {
+				seen[b] = true
+				keep[s.name] = true
+			}
+		}
+	}
+	tier(p.KeepDaily, func(t time.Time) string { return t.Format("2006-01-02") })
+	tier(p.KeepWeekly, func(t time.Time) string {
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	})
+
+	var out []string
+	for i := len(snaps) - 1; i >= 0; i-- {
+		if !keep[snaps[i].name] {
+			out = append(out, snaps[i].name)
+		}
+	}
+	return out
+}
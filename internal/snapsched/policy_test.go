@@ -0,0 +1,152 @@
+package snapsched
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func hourly(keepLast int) *Policy {
+	return &Policy{IntervalSeconds: 3600, KeepLast: keepLast, UpdatedAt: t0.Add(-48 * time.Hour)}
+}
+
+func TestParseNameRoundTrips(t *testing.T) {
+	name := SnapshotName(t0.In(time.FixedZone("x", 3600)))
+	if name != "auto-20261016T120000Z" {
+		t.Fatalf("SnapshotName = %q", name)
+	}
+	if got, ok := ParseName(name); !ok || !got.Equal(t0) {
+		t.Errorf("ParseName(%q) = %v, %v", name, got, ok)
+	}
+	for _, name := range []string{"auto-upgrade", "pre-auto-20261016T120000Z", "auto-20261016T1200Z", "base"} {
+		if _, ok := ParseName(name); ok {
+			t.Errorf("ParseName(%q) claimed a hand-named snapshot", name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		p    Policy
+		want string
+	}{
+		"interval":        {Policy{IntervalSeconds: 3600, KeepLast: 3}, ""},
+		"cron":            {Policy{Cron: "0 */6 * * *", KeepDaily: 7}, ""},
+		"both":            {Policy{IntervalSeconds: 3600, Cron: "@daily", KeepLast: 3}, "not both"},
+		"neither":         {Policy{KeepLast: 3}, "required"},
+		"too often":       {Policy{IntervalSeconds: 60, KeepLast: 3}, "minimum"},
+		"bad cron":        {Policy{Cron: "every tuesday", KeepLast: 3}, "cron"},
+		"no retention":    {Policy{IntervalSeconds: 3600}, "retention is required"},
+		"negative keep":   {Policy{IntervalSeconds: 3600, KeepLast: -1}, "negative"},
+		"negative period": {Policy{IntervalSeconds: -3600, KeepLast: 1}, "positive"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.p.Validate()
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Validate = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecideTakesADueSnapshotAndPrunesAsThoughItExists(t *testing.T) {
+	views := []ContainerView{{
+		Name:   "alice-container",
+		Policy: hourly(2),
+		Snapshots: []string{
+			"base", // hand-made: never counted, never pruned
+			SnapshotName(t0.Add(-3 * time.Hour)),
+			SnapshotName(t0.Add(-2 * time.Hour)),
+			SnapshotName(t0.Add(-1 * time.Hour)),
+		},
+	}}
+	plans := Decide(views, t0)
+	if len(plans) != 1 {
+		t.Fatalf("plans = %+v", plans)
+	}
+	p := plans[0]
+	if p.Snapshot != SnapshotName(t0) {
+		t.Errorf("snapshot = %q", p.Snapshot)
+	}
+	want := []string{SnapshotName(t0.Add(-3 * time.Hour)), SnapshotName(t0.Add(-2 * time.Hour))}
+	if !reflect.DeepEqual(p.Prune, want) {
+		t.Errorf("prune = %v, want %v", p.Prune, want)
+	}
+}
+
+func TestDecideWaitsForTheInterval(t *testing.T) {
+	views := []ContainerView{
+		{Name: "recent", Policy: hourly(5), Snapshots: []string{SnapshotName(t0.Add(-30 * time.Minute))}},
+		{Name: "nopolicy", Snapshots: []string{SnapshotName(t0.Add(-30 * time.Hour))}},
+	}
+	if plans := Decide(views, t0); len(plans) != 0 {
+		t.Fatalf("plans = %+v, want none", plans)
+	}
+}
+
+// Setting a policy never triggers a catch-up: the first run is one
+// interval (or cron slot) after it was set.
+func TestDecideAnchorsAFreshPolicyOnUpdatedAt(t *testing.T) {
+	p := &Policy{Cron: "0 * * * *", KeepLast: 3, UpdatedAt: t0.Add(-10 * time.Minute)}
+	if plans := Decide([]ContainerView{{Name: "c", Policy: p}}, t0.Add(-time.Minute)); len(plans) != 0 {
+		t.Fatalf("ran before the first slot: %+v", plans)
+	}
+	if plans := Decide([]ContainerView{{Name: "c", Policy: p}}, t0); len(plans) != 1 || plans[0].Snapshot == "" {
+		t.Fatalf("did not run at the first slot: %+v", plans)
+	}
+}
+
+func TestExpiredTiers(t *testing.T) {
+	var names []string
+	// One snapshot every 6 hours for 20 days, newest at t0.
+	for i := 0; i < 80; i++ {
+		names = append(names, SnapshotName(t0.Add(-time.Duration(i)*6*time.Hour)))
+	}
+	names = append(names, "before-upgrade")
+
+	expired := Expired(names, Policy{KeepLast: 4, KeepDaily: 7, KeepWeekly: 3})
+	kept := map[string]bool{}
+	for _, n := range names {
+		kept[n] = true
+	}
+	for _, n := range expired {
+		delete(kept, n)
+	}
+	if !kept["before-upgrade"] {
+		t.Error("pruned a hand-made snapshot")
+	}
+	for i := 0; i < 4; i++ {
+		if !kept[SnapshotName(t0.Add(-time.Duration(i)*6*time.Hour))] {
+			t.Errorf("keep_last dropped snapshot %d", i)
+		}
+	}
+	// Newest per day: 12:00 today (also in keep_last), then 18:00 on each of
+	// the six days before.
+	for d := 1; d < 7; d++ {
+		day := t0.Add(-time.Duration(d) * 24 * time.Hour).Add(6 * time.Hour)
+		if !kept[SnapshotName(day)] {
+			t.Errorf("keep_daily dropped %s", day)
+		}
+	}
+	// 4 last (which span today and yesterday) + 5 more daily + the newest of
+	// the ISO week before the daily window = 10, and the hand-made one.
+	if len(kept) != 11 {
+		t.Errorf("kept %d snapshots, want 11", len(kept))
+	}
+	for i := 1; i < len(expired); i++ {
+		a, _ := ParseName(expired[i-1])
+		b, _ := ParseName(expired[i])
+		if !a.Before(b) {
+			t.Fatalf("expired not oldest first: %v", expired)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net"
+	"net/netip"
 	"sync"
 	"time"
 
@@ -105,12 +106,17 @@ func (c *ContainerCache) Refresh() error {
 			c.ipToName[container.IPAddress] = container.Name
 			c.nameToIP[container.Name] = container.IPAddress
 		}
+		// The IPv6 address attributes v6 flows too. Keyed in canonical form,
+		// which is how both conntrack and the BPF flow reader render it.
+		if ip, err := netip.ParseAddr(container.IPv6Address); err == nil {
+			c.ipToName[ip.String()] = container.Name
+		}
 		if id := container.Labels["cloud_container_id"]; id != "" {
 			c.nameToID[container.Name] = id
 		}
 	}
 
-	log.Printf("Container cache refreshed: %d containers", len(c.ipToName))
+	log.Printf("Container cache refreshed: %d containers", len(c.nameToIP))
 	return nil
 }
 
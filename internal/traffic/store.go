@@ -307,6 +307,67 @@ func (s *Store) QueryConnections(ctx context.Context, params QueryParams) ([]*pb
 	return connections, totalCount, nil
 }
 
+// EgressDestination is one destination a container reached over a window:
+// every egress connection to the same address, protocol and port folded
+// together.
+type EgressDestination struct {
+	ContainerName string
+	DestIP        string
+	Protocol      pb.Protocol
+	DestPort      uint32
+	Connections   int64
+	Bytes         int64 // sent + received
+	LastSeen      time.Time
+}
+
+// maxEgressDestinations bounds one EgressDestinations answer. A container
+// talking to more distinct destinations than this in a window is not one a
+// learned allow-list can describe anyway; the busiest are kept.
+const maxEgressDestinations = 10000
+
+// EgressDestinations aggregates the egress connections the named containers
+// started at or after since, by container, destination address, protocol and
+// port, busiest first. It backs network-policy learn mode.
+func (s *Store) EgressDestinations(ctx context.Context, containers []string, since time.Time) ([]EgressDestination, error) {
+	if len(containers) == 0 {
+		return nil, nil
+	}
+	// host(dest_ip) for the reason QueryConnections gives (#1397).
+	rows, err := s.pool.Query(ctx, `
+		SELECT container_name, host(dest_ip), protocol, COALESCE(dest_port, 0),
+		       COUNT(*), COALESCE(SUM(bytes_sent + bytes_received), 0),
+		       MAX(COALESCE(ended_at, started_at))
+		FROM traffic_connections
+		WHERE container_name = ANY($1) AND started_at >= $2 AND direction = $3
+		GROUP BY container_name, dest_ip, protocol, COALESCE(dest_port, 0)
+		ORDER BY COUNT(*) DESC
+		LIMIT $4
+	`, containers, since, safecast.I16(pb.TrafficDirection_TRAFFIC_DIRECTION_EGRESS), maxEgressDestinations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query egress destinations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []EgressDestination
+	for rows.Next() {
+		var (
+			d        EgressDestination
+			protocol int16
+			port     int32
+		)
+		if err := rows.Scan(&d.ContainerName, &d.DestIP, &protocol, &port, &d.Connections, &d.Bytes, &d.LastSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		d.Protocol = pb.Protocol(protocol)
+		d.DestPort = safecast.U32(port)
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return out, nil
+}
+
 // AggregateParams holds parameters for querying traffic aggregates
 type AggregateParams struct {
 	ContainerName   string
@@ -152,6 +152,45 @@ func TestTrafficStore_AggregatesGroupedByDestIPAreReadable(t *testing.T) {
 	}
 }
 
+// Learn mode proposes an allow-list from this aggregate, so a destination
+// must come back once, with its connections folded together — and an inbound
+// connection, whose destination is the container itself, must not come back.
+func TestTrafficStore_EgressDestinationsFoldsEgressOnly(t *testing.T) {
+	ctx := context.Background()
+	store, container := trafficTestStore(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, id := range []string{"egress-a", "egress-b"} {
+		if err := store.SaveConnection(ctx, aConnection(container, id, now.Add(time.Duration(i)*time.Minute))); err != nil {
+			t.Fatalf("SaveConnection(%s): %v", id, err)
+		}
+	}
+	in := aConnection(container, "ingress-a", now)
+	in.Direction = pb.TrafficDirection_TRAFFIC_DIRECTION_INGRESS
+	in.DestIp, in.DestPort = "10.0.0.10", 8080
+	if err := store.SaveConnection(ctx, in); err != nil {
+		t.Fatalf("SaveConnection(ingress): %v", err)
+	}
+
+	got, err := store.EgressDestinations(ctx, []string{container}, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("EgressDestinations: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d destinations, want 1 (two egress connections to one place; ingress excluded): %+v", len(got), got)
+	}
+	d := got[0]
+	if d.DestIP != "93.184.216.34" || d.DestPort != 443 || d.Protocol != pb.Protocol_PROTOCOL_TCP {
+		t.Errorf("destination = %s %v/%d", d.DestIP, d.Protocol, d.DestPort)
+	}
+	if d.Connections != 2 || d.Bytes != 6000 {
+		t.Errorf("connections/bytes = %d/%d, want 2/6000", d.Connections, d.Bytes)
+	}
+	if !d.LastSeen.Equal(now.Add(time.Minute + 30*time.Second)) {
+		t.Errorf("last seen = %v, want the later connection's end", d.LastSeen)
+	}
+}
+
 // The container filter is the tenancy boundary of this table: every query goes
 // through it, and a leak here shows one tenant another's connection history.
 func TestTrafficStore_QueryIsScopedToOneContainer(t *testing.T) {
@@ -0,0 +1,250 @@
+package transfer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreFileNames are read from every directory the walk enters when
+// SyncOptions.GitIgnore is set, in this order — so a .containariumignore
+// rule overrides a .gitignore rule in the same directory (e.g. to ship a
+// build output git ignores, with "!dist/").
+var ignoreFileNames = []string{".gitignore", ".containariumignore"}
+
+// pathFilter decides which paths a manifest walk skips. rel is
+// forward-slash and relative to the sync root.
+type pathFilter interface {
+	skip(rel string, isDir bool) bool
+}
+
+// dirLoader is implemented by filters whose rules come from files in
+// the tree; the walk calls loadDir on every directory it enters.
+type dirLoader interface {
+	loadDir(root *os.Root, dir string) error
+}
+
+// newFilter returns the filter SyncOptions asks for: substring matching
+// of Excludes (the v1 behaviour), or gitignore semantics over Excludes
+// plus any ignore files found in the tree.
+func (o SyncOptions) newFilter() pathFilter {
+	if !o.GitIgnore {
+		return substringFilter(o.Excludes)
+	}
+	f := &ignoreFilter{loaded: map[string]bool{}}
+	for _, pat := range o.Excludes {
+		f.add("", pat)
+	}
+	return f
+}
+
+// substringFilter is the v1 exclude semantics: skip any path containing
+// one of the patterns.
+type substringFilter []string
+
+func (f substringFilter) skip(rel string, isDir bool) bool {
+	if matchesAny(rel, f) {
+		return true
+	}
+	// "node_modules/" is written with the slash to mean a directory; test
+	// the directory's own path with one appended so walkers prune it
+	// instead of descending and dropping every file inside.
+	return isDir && matchesAny(rel+"/", f)
+}
+
+// ignoreFilter applies gitignore rules: Excludes first (lowest
+// precedence, like core.excludesFile), then each directory's ignore
+// files as the walk reaches them. As in git, the last matching rule
+// wins, a "!" rule re-includes, and nothing inside an ignored directory
+// can be re-included.
+type ignoreFilter struct {
+	rules  []ignoreRule
+	loaded map[string]bool
+}
+
+type ignoreRule struct {
+	base    string // directory holding the rule's file; "" for the root and Excludes
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+func (f *ignoreFilter) skip(rel string, isDir bool) bool {
+	// .git/ is synced by design and git never applies ignore rules to it;
+	// a "logs/" rule must not strip .git/logs.
+	if rel == ".git" || strings.HasPrefix(rel, ".git/") {
+		return false
+	}
+	for i := 0; i < len(rel); i++ {
+		if rel[i] == '/' && f.match(rel[:i], true) {
+			return true
+		}
+	}
+	return f.match(rel, isDir)
+}
+
+func (f *ignoreFilter) match(rel string, isDir bool) bool {
+	ignored := false
+	for _, r := range f.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		sub := rel
+		if r.base != "" {
+			if !strings.HasPrefix(rel, r.base+"/") {
+				continue
+			}
+			sub = rel[len(r.base)+1:]
+		}
+		if r.re.MatchString(sub) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// loadDir reads dir's ignore files. Each directory is loaded once, so
+// Watch re-walking a renamed-in directory doesn't stack duplicate rules.
+func (f *ignoreFilter) loadDir(root *os.Root, dir string) error {
+	if dir == "." {
+		dir = ""
+	}
+	if f.loaded[dir] {
+		return nil
+	}
+	f.loaded[dir] = true
+	for _, name := range ignoreFileNames {
+		file, err := root.Open(filepath.FromSlash(path.Join(dir, name)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("open %s: %w", path.Join(dir, name), err)
+		}
+		sc := bufio.NewScanner(file)
+		for sc.Scan() {
+			f.add(dir, sc.Text())
+		}
+		_ = file.Close()
+		if err := sc.Err(); err != nil {
+			return fmt.Errorf("read %s: %w", path.Join(dir, name), err)
+		}
+	}
+	return nil
+}
+
+// add parses one gitignore line into a rule scoped to base. Blank lines,
+// comments and patterns that don't compile are dropped, as git does.
+func (f *ignoreFilter) add(base, line string) {
+	line = trimTrailingSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	r := ignoreRule{base: base}
+	switch {
+	case strings.HasPrefix(line, "!"):
+		r.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return
+	}
+	// A slash anywhere but the end anchors the pattern to base; without
+	// one it matches a name at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return
+	}
+	r.re = re
+	f.rules = append(f.rules, r)
+}
+
+// trimTrailingSpace drops trailing spaces unless backslash-escaped.
+func trimTrailingSpace(s string) string {
+	for strings.HasSuffix(s, " ") && !strings.HasSuffix(s, `\ `) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// globToRegexp translates a gitignore glob into a regexp body: "*" and
+// "?" stop at slashes, "[...]" is a character class ("!" negates), and
+// "**" as a whole path segment spans directories ("**/x", "a/**/b",
+// "a/**").
+func globToRegexp(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				j := i + 2
+				atStart := i == 0 || p[i-1] == '/'
+				atEnd := j == len(p) || p[j] == '/'
+				if atStart && atEnd {
+					if j == len(p) {
+						b.WriteString(".*")
+						i = j - 1
+					} else {
+						b.WriteString("(?:.*/)?")
+						i = j // also consume the following slash
+					}
+					continue
+				}
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			j := i + 1
+			if j < len(p) && (p[j] == '!' || p[j] == '^') {
+				j++
+			}
+			if j < len(p) && p[j] == ']' {
+				j++
+			}
+			for j < len(p) && p[j] != ']' {
+				j++
+			}
+			if j >= len(p) {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := p[i+1 : j]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = j
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(p[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package transfer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreFilter_GitignoreSemantics(t *testing.T) {
+	f := &ignoreFilter{loaded: map[string]bool{}}
+	for _, line := range []string{
+		"# comment",
+		"",
+		"*.log",
+		"!keep.log",
+		"/build",
+		"dist/",
+		"docs/**/*.pdf",
+		"**/tmp",
+		`\#literal`,
+		"cache/**",
+	} {
+		f.add("", line)
+	}
+
+	cases := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"deep/nested/app.log", false, true},
+		{"deep/app.logx", false, false},
+		{"keep.log", false, false},           // negated
+		{"build", true, true},                // anchored
+		{"build", false, true},               // "/build" matches files too
+		{"src/build", true, false},           // anchored: not at depth
+		{"build/keep.log", false, true},      // can't re-include under an ignored dir
+		{"dist", false, false},               // dir-only rule, regular file
+		{"dist", true, true},                 //
+		{"pkg/dist/out.js", false, true},     // inside an unanchored ignored dir
+		{"docs/a.pdf", false, true},          // "/**/" matches zero dirs
+		{"docs/x/y/a.pdf", false, true},      // ...or many
+		{"other/a.pdf", false, false},        //
+		{"a/b/tmp", true, true},              // leading **/
+		{"notes/tmpfile.txt", false, false},  //
+		{"#literal", false, true},            // escaped comment char
+		{"cache/x/y", false, true},           // trailing /**
+		{"cache", true, false},               // ...but not the dir itself
+		{"x/cache/y", false, false},          // "cache/**" is anchored
+		{"config.env.example", false, false}, // no substring surprises
+		{".git/logs/HEAD", false, false},     // .git is never subject to rules
+		{"src/main.go", false, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, f.skip(c.rel, c.isDir), "skip(%q, dir=%v)", c.rel, c.isDir)
+	}
+}
+
+func TestWalkLocalFiltered_NestedIgnoreFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, body string) {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(body), 0o644))
+	}
+	write(".gitignore", "*.o\ndist/\n")
+	write(".containariumignore", "!dist/\n") // ship the build output git ignores
+	write("main.c", "int main;")
+	write("main.o", "obj")
+	write("dist/app", "bin")
+	write("sub/.gitignore", "secret.txt\n!*.o\n")
+	write("sub/secret.txt", "s")
+	write("sub/keep.o", "obj")
+	write("other/secret.txt", "s")
+	write(".git/HEAD", "ref: refs/heads/main\n")
+
+	opt := SyncOptions{GitIgnore: true}
+	opt.applyDefaultExcludes()
+	m, err := walkLocalFiltered(dir, opt.newFilter())
+	require.NoError(t, err)
+
+	var got []string
+	for p := range m.entries {
+		got = append(got, p)
+	}
+	sort.Strings(got)
+	assert.Equal(t, []string{
+		".containariumignore",
+		".git/HEAD",
+		".gitignore",
+		"dist/app",
+		"main.c",
+		"other/secret.txt", // sub/.gitignore doesn't reach it
+		"sub/.gitignore",
+		"sub/keep.o", // re-included by the deeper file
+	}, got)
+}
+
+func TestManifestFilter_ProtectsIgnoredRemoteFilesFromDelete(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("build/\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+
+	f := SyncOptions{GitIgnore: true, Excludes: []string{"node_modules/"}}.newFilter()
+	local, err := walkLocalFiltered(dir, f)
+	require.NoError(t, err)
+
+	remote := manifestOf(map[string]string{
+		"build/out.bin":           "x",
+		"node_modules/left-pad/i": "y",
+		"stale.txt":               "z",
+	})
+	d := local.diff(SyncOptions{GitIgnore: true}.comparableRemote(remote, f))
+	assert.Equal(t, []string{"stale.txt"}, d.ToDelete, "ignored remote paths are never deletion candidates")
+}
+
+// The default substring mode diffs the whole remote tree, so --delete still
+// removes remote paths the excludes match, as it did before gitignore mode.
+func TestComparableRemote_SubstringModeKeepsTheWholeTree(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+
+	opt := SyncOptions{Excludes: []string{"node_modules/"}}
+	f := opt.newFilter()
+	local, err := walkLocalFiltered(dir, f)
+	require.NoError(t, err)
+
+	remote := manifestOf(map[string]string{
+		"node_modules/left-pad/i": "y",
+		"stale.txt":               "z",
+	})
+	d := local.diff(opt.comparableRemote(remote, f))
+	assert.Equal(t, []string{"node_modules/left-pad/i", "stale.txt"}, d.ToDelete)
+}
+
+func TestSubstringFilter_PrunesSlashSuffixedDirectory(t *testing.T) {
+	f := substringFilter{"node_modules/"}
+	assert.True(t, f.skip("node_modules", true))
+	assert.False(t, f.skip("node_modules", false))
+	assert.True(t, f.skip("web/node_modules/x.js", false))
+}
@@ -0,0 +1,96 @@
+package transfer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// The last-sync manifest is the common ancestor for Pull's three-way
+// compare: what both sides held the last time Sync, Watch or Pull left
+// them in agreement. It lives under ~/.containarium/sync/ rather than in
+// LocalPath so it can never be shipped to the box by a later Sync.
+
+// lastSyncPath returns the state file for one (user, local, remote)
+// triple. The local and remote paths are hashed into the file name so
+// the same laptop directory synced to two boxes (or two remote paths)
+// keeps two independent baselines.
+func lastSyncPath(opt Options) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home dir: %w", err)
+	}
+	sum := sha256.Sum256([]byte(opt.LocalPath + "\x00" + opt.RemotePath))
+	name := hex.EncodeToString(sum[:8]) + ".manifest"
+	return filepath.Join(home, ".containarium", "sync", opt.Username, name), nil
+}
+
+// loadLastSync reads the stored baseline. A missing file is not an
+// error — it just means no sync has completed yet — and yields an empty
+// manifest.
+func loadLastSync(opt Options) (*manifest, error) {
+	p, err := lastSyncPath(opt)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p) // #nosec G304 -- path is derived from the user's home dir + a hash, not caller input.
+	if errors.Is(err, fs.ErrNotExist) {
+		return newManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open last-sync manifest: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return parseRemoteManifest(f)
+}
+
+// saveLastSync replaces the stored baseline with m. Written via a temp
+// file + rename so an interrupted write can't leave a truncated baseline
+// (which Pull would read as "nothing in common" and flag every file as a
+// conflict).
+func saveLastSync(opt Options, m *manifest) error {
+	p, err := lastSyncPath(opt)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return fmt.Errorf("create sync state dir: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := m.writeTo(&buf); err != nil {
+		return err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write last-sync manifest: %w", err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write last-sync manifest: %w", err)
+	}
+	return nil
+}
+
+// writeTo serializes m in the same line format parseRemoteManifest
+// reads, sorted by path so the file diffs cleanly.
+func (m *manifest) writeTo(w io.Writer) error {
+	paths := make([]string, 0, len(m.entries))
+	for p := range m.entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		e := m.entries[p]
+		if _, err := fmt.Fprintf(w, "%s %o %s\n", e.Hash, e.Mode, e.Path); err != nil {
+			return fmt.Errorf("write manifest: %w", err)
+		}
+	}
+	return nil
+}
@@ -35,13 +35,19 @@ func newManifest() *manifest {
 // whose forward-slash form matches one of the excludes (substring match).
 // Symlinks are NOT followed — they're skipped entirely in v1, since
 // mirroring a symlink across the SSH boundary surprises in subtle ways.
+func walkLocal(rootDir string, excludes []string) (*manifest, error) {
+	return walkLocalFiltered(rootDir, substringFilter(excludes))
+}
+
+// walkLocalFiltered is walkLocal with the skip decision delegated to f,
+// so SyncOptions.GitIgnore can swap in gitignore semantics.
 //
 // Uses os.Root (Go 1.24+) so every file open is kernel-enforced to stay
 // inside the caller-specified root. This eliminates the symlink-TOCTOU
 // traversal risk that gosec flags on plain filepath.Walk callbacks: even
 // if a hostile directory swaps a regular file for a symlink between the
 // walk's stat and our open, the open fails rather than escaping the root.
-func walkLocal(rootDir string, excludes []string) (*manifest, error) {
+func walkLocalFiltered(rootDir string, f pathFilter) (*manifest, error) {
 	m := newManifest()
 
 	root, err := os.OpenRoot(rootDir)
@@ -50,17 +56,23 @@ func walkLocal(rootDir string, excludes []string) (*manifest, error) {
 	}
 	defer func() { _ = root.Close() }()
 
-	err = fs.WalkDir(root.FS(), ".", func(relPath string, d fs.DirEntry, walkErr error) error {
+	return m, walkInto(m, root, ".", f)
+}
+
+// walkInto adds every non-skipped regular file under start (a
+// forward-slash path relative to root, "." for the whole tree) to m.
+// Split out of walkLocal so Watch can re-walk just a newly created
+// directory without re-hashing the rest of the tree.
+func walkInto(m *manifest, root *os.Root, start string, f pathFilter) error {
+	loader, _ := f.(dirLoader)
+	return fs.WalkDir(root.FS(), start, func(relPath string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
-		if relPath == "." {
-			return nil
-		}
 		// Forward-slash form for cross-platform consistency.
 		relSlash := filepath.ToSlash(relPath)
 
-		if matchesAny(relSlash, excludes) {
+		if relSlash != "." && f.skip(relSlash, d.IsDir()) {
 			if d.IsDir() {
 				return fs.SkipDir
 			}
@@ -68,6 +80,11 @@ func walkLocal(rootDir string, excludes []string) (*manifest, error) {
 		}
 
 		if d.IsDir() {
+			// Ignore files apply to everything beneath their directory,
+			// and WalkDir visits a directory before its contents.
+			if loader != nil {
+				return loader.loadDir(root, relSlash)
+			}
 			return nil
 		}
 
@@ -81,26 +98,32 @@ func walkLocal(rootDir string, excludes []string) (*manifest, error) {
 			return nil
 		}
 
-		f, err := root.Open(relPath)
+		e, err := hashEntry(root, relSlash, info)
 		if err != nil {
-			return fmt.Errorf("open %s: %w", relPath, err)
-		}
-		h := sha256.New()
-		if _, err := io.Copy(h, f); err != nil {
-			_ = f.Close()
-			return fmt.Errorf("hash %s: %w", relPath, err)
-		}
-		_ = f.Close()
-
-		m.entries[relSlash] = fileEntry{
-			Path: relSlash,
-			Hash: hex.EncodeToString(h.Sum(nil)),
-			Mode: uint32(info.Mode().Perm()),
+			return err
 		}
+		m.entries[relSlash] = e
 		return nil
 	})
+}
 
-	return m, err
+// hashEntry hashes one regular file (opened through root, so the open
+// can't escape it) into a manifest row.
+func hashEntry(root *os.Root, relSlash string, info fs.FileInfo) (fileEntry, error) {
+	f, err := root.Open(filepath.FromSlash(relSlash))
+	if err != nil {
+		return fileEntry{}, fmt.Errorf("open %s: %w", relSlash, err)
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fileEntry{}, fmt.Errorf("hash %s: %w", relSlash, err)
+	}
+	return fileEntry{
+		Path: relSlash,
+		Hash: hex.EncodeToString(h.Sum(nil)),
+		Mode: uint32(info.Mode().Perm()),
+	}, nil
 }
 
 // matchesAny reports whether p matches any of the given substring patterns.
@@ -118,6 +141,21 @@ func matchesAny(p string, patterns []string) bool {
 	return false
 }
 
+// filter returns a copy of m without the entries f skips. The remote
+// manifest script lists everything, so callers run the box side through
+// the same filter as the local walk — otherwise a --delete sync would
+// remove the box's own node_modules/, or anything the project ignores,
+// just because the laptop (correctly) never listed it.
+func (m *manifest) filter(f pathFilter) *manifest {
+	out := newManifest()
+	for p, e := range m.entries {
+		if !f.skip(p, false) {
+			out.entries[p] = e
+		}
+	}
+	return out
+}
+
 // parseRemoteManifest reads the line-oriented output of the remote
 // manifest script:
 //
@@ -0,0 +1,341 @@
+package transfer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// PullOptions extends SyncOptions with pull-specific knobs. Delete and
+// Excludes keep their Sync meaning, mirrored: Delete removes local files
+// the box has deleted since the last sync; Excludes filter both sides.
+type PullOptions struct {
+	SyncOptions
+
+	// Force resolves conflicts in the box's favor instead of leaving the
+	// local copy alone. Off by default — a conflict means someone edited
+	// the laptop copy too, and silently discarding that is the one thing
+	// a pull must not do.
+	Force bool
+}
+
+// Pull is Sync in reverse: it makes LocalPath look like RemotePath,
+// fetching only files whose sha256+mode differs.
+//
+// Unlike Sync, Pull is three-way. It compares both sides against the
+// last-sync manifest (written by every successful Sync, Watch batch and
+// Pull) so it can tell "the box changed this" from "I changed this
+// locally": the former is pulled, the latter kept, and a file changed on
+// both sides is reported in SyncResult.Conflicts and left untouched.
+// With no baseline (first pull into an existing directory), any file
+// that differs is a conflict.
+func Pull(opt PullOptions) (*SyncResult, error) {
+	if err := opt.resolve(); err != nil {
+		return nil, err
+	}
+	opt.applyDefaultExcludes()
+
+	if opt.Verbose {
+		fmt.Fprintf(os.Stderr, "[pull] hashing %s ...\n", opt.LocalPath)
+	}
+	filter := opt.newFilter()
+	local, err := walkLocalFiltered(opt.LocalPath, filter)
+	if err != nil {
+		return nil, fmt.Errorf("walk local: %w", err)
+	}
+	if opt.Verbose {
+		fmt.Fprintf(os.Stderr, "[pull] reading remote manifest at %s ...\n", opt.RemotePath)
+	}
+	remote, err := readRemoteManifest(opt.SyncOptions)
+	if err != nil {
+		return nil, fmt.Errorf("read remote manifest: %w", err)
+	}
+	remote = remote.filter(filter)
+	base, err := loadLastSync(opt.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planPull(local, remote, base, opt.Delete, opt.Force)
+	res := &SyncResult{
+		Added:     plan.added,
+		Modified:  plan.modified,
+		Deleted:   len(plan.toDelete),
+		Conflicts: plan.conflicts,
+	}
+
+	root, err := os.OpenRoot(opt.LocalPath)
+	if err != nil {
+		return nil, fmt.Errorf("open root %s: %w", opt.LocalPath, err)
+	}
+	defer func() { _ = root.Close() }()
+
+	if len(plan.toFetch) > 0 {
+		if opt.Verbose {
+			fmt.Fprintf(os.Stderr, "[pull] fetching %d changed file(s) ...\n", len(plan.toFetch))
+		}
+		n, err := fetchRemoteFiles(opt.SyncOptions, root, plan.toFetch)
+		if err != nil {
+			return nil, err
+		}
+		res.Bytes = n
+	}
+	for _, p := range plan.toDelete {
+		if err := root.Remove(filepath.FromSlash(p)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("remove %s: %w", p, err)
+		}
+	}
+
+	if err := saveLastSync(opt.Options, plan.baseline(local, remote, base)); err != nil && opt.Verbose {
+		fmt.Fprintf(os.Stderr, "[pull] warning: %v\n", err)
+	}
+	return res, nil
+}
+
+// pullPlan is the outcome of the three-way compare.
+type pullPlan struct {
+	toFetch   []string // sorted; remote version replaces (or creates) the local file
+	toDelete  []string // sorted; box deleted it, local copy is untouched since last sync
+	conflicts []string // sorted; changed on both sides, left alone
+	added     int
+	modified  int
+}
+
+// planPull decides, per path, which side's version wins. L, R and B are
+// the local, remote and last-sync entries; "changed" means differs from B.
+//
+//	R only, no B           → fetch (new on the box)
+//	R only, R == B         → skip  (deleted locally since last sync)
+//	L != R, L == B         → fetch (only the box changed)
+//	L != R, R == B         → skip  (only local changed)
+//	L != R otherwise       → conflict
+//	L only, L == B         → delete when del (box deleted it)
+//	L only, L != B, B set  → conflict when del
+//	L only, no B           → skip  (never synced; local-only file)
+//
+// force turns every conflict into the box's version.
+func planPull(local, remote, base *manifest, del, force bool) pullPlan {
+	var p pullPlan
+	same := func(a, b fileEntry) bool { return a.Hash == b.Hash && a.Mode == b.Mode }
+
+	for pth, r := range remote.entries {
+		l, haveL := local.entries[pth]
+		b, haveB := base.entries[pth]
+		switch {
+		case haveL && same(l, r):
+			// in agreement
+		case !haveL && !haveB:
+			p.toFetch = append(p.toFetch, pth)
+			p.added++
+		case !haveL && same(r, b):
+			// deleted locally, untouched on the box — keep it deleted
+		case !haveL:
+			if force {
+				p.toFetch = append(p.toFetch, pth)
+				p.added++
+			} else {
+				p.conflicts = append(p.conflicts, pth)
+			}
+		case haveB && same(l, b):
+			p.toFetch = append(p.toFetch, pth)
+			p.modified++
+		case haveB && same(r, b):
+			// only local changed
+		default:
+			if force {
+				p.toFetch = append(p.toFetch, pth)
+				p.modified++
+			} else {
+				p.conflicts = append(p.conflicts, pth)
+			}
+		}
+	}
+
+	if del {
+		for pth, l := range local.entries {
+			if _, onRemote := remote.entries[pth]; onRemote {
+				continue
+			}
+			b, haveB := base.entries[pth]
+			switch {
+			case !haveB:
+				// local-only, never synced
+			case same(l, b) || force:
+				p.toDelete = append(p.toDelete, pth)
+			default:
+				p.conflicts = append(p.conflicts, pth)
+			}
+		}
+	}
+
+	sort.Strings(p.toFetch)
+	sort.Strings(p.toDelete)
+	sort.Strings(p.conflicts)
+	return p
+}
+
+// baseline computes the last-sync manifest to store after the plan has
+// been applied: the paths just fetched, and the paths where the two sides
+// already agreed. Every other path keeps its previous baseline entry —
+// a local edit the box has not seen stays a local edit, a conflict stays
+// a conflict, and a local file that was never synced stays unsynced, so
+// the next pull neither overwrites nor (with Delete) removes it.
+func (p pullPlan) baseline(local, remote, base *manifest) *manifest {
+	out := newManifest()
+	for pth, b := range base.entries {
+		_, haveL := local.entries[pth]
+		_, haveR := remote.entries[pth]
+		if haveL || haveR {
+			out.entries[pth] = b
+		}
+	}
+	for pth, l := range local.entries {
+		if r, ok := remote.entries[pth]; ok && l.Hash == r.Hash && l.Mode == r.Mode {
+			out.entries[pth] = l
+		}
+	}
+	for _, pth := range p.toFetch {
+		out.entries[pth] = remote.entries[pth]
+	}
+	for _, pth := range p.toDelete {
+		delete(out.entries, pth)
+	}
+	return out
+}
+
+// fetchRemoteFiles tars the named files on the box and extracts them
+// under root. The file list goes to the remote tar NUL-separated on
+// stdin, so there's no argv length limit and no option injection from a
+// path that starts with "-".
+func fetchRemoteFiles(opt SyncOptions, root *os.Root, paths []string) (int64, error) {
+	script := fmt.Sprintf(`
+		set -e
+		cd %s
+		tar czf - --null --verbatim-files-from -T -
+	`, shQuote(opt.RemotePath))
+
+	var list bytes.Buffer
+	for _, p := range paths {
+		list.WriteString(p)
+		list.WriteByte(0)
+	}
+
+	args := append(opt.sshBaseArgs(), opt.sshTarget(), script)
+	// #nosec G204 -- argv to ssh, not shell-evaluated locally; remote
+	// script's variables are shQuote'd.
+	cmd := exec.Command("ssh", args...)
+	cmd.Stdin = &list
+	cmd.Stderr = io.Discard
+	if opt.Verbose {
+		cmd.Stderr = os.Stderr
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, fmt.Errorf("ssh stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("ssh fetch: %w", err)
+	}
+
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[p] = true
+	}
+	cr := &countingReader{r: out}
+	extractErr := extractTar(root, cr, want)
+	// Drain so ssh isn't blocked writing when extraction stopped early.
+	_, _ = io.Copy(io.Discard, out)
+	if err := cmd.Wait(); err != nil {
+		return 0, fmt.Errorf("ssh fetch: %w", err)
+	}
+	if extractErr != nil {
+		return 0, extractErr
+	}
+	return cr.n, nil
+}
+
+// extractTar unpacks a gzipped tar into root, accepting only regular-file
+// entries whose names are in want — the box is not trusted to decide
+// what gets written on the laptop. Each file is written to a temp name
+// and renamed into place, so an interrupted pull never leaves a
+// half-written source file behind.
+func extractTar(root *os.Root, r io.Reader, want map[string]bool) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("read tar: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar: %w", err)
+		}
+		name := path.Clean(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || !want[name] {
+			continue
+		}
+		if err := writeRootFile(root, name, fs.FileMode(hdr.Mode).Perm(), tr); err != nil {
+			return err
+		}
+	}
+}
+
+// writeRootFile writes content to rel (forward-slash) inside root with
+// the given permission bits, creating parent directories as needed.
+func writeRootFile(root *os.Root, rel string, mode fs.FileMode, content io.Reader) error {
+	name := filepath.FromSlash(rel)
+	if dir := filepath.Dir(name); dir != "." {
+		if err := root.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("mkdir %s: %w", dir, err)
+		}
+	}
+	tmp := name + ".containarium-pull"
+	f, err := root.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", rel, err)
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		_ = f.Close()
+		_ = root.Remove(tmp)
+		return fmt.Errorf("write %s: %w", rel, err)
+	}
+	if err := f.Close(); err != nil {
+		_ = root.Remove(tmp)
+		return fmt.Errorf("write %s: %w", rel, err)
+	}
+	// OpenFile's mode is filtered by the umask; set it explicitly so an
+	// executable on the box stays executable here.
+	if err := root.Chmod(tmp, mode); err != nil {
+		_ = root.Remove(tmp)
+		return fmt.Errorf("chmod %s: %w", rel, err)
+	}
+	if err := root.Rename(tmp, name); err != nil {
+		_ = root.Remove(tmp)
+		return fmt.Errorf("rename %s: %w", rel, err)
+	}
+	return nil
+}
+
+// countingReader is countingWriter's mirror for the bytes-fetched metric.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,176 @@
+package transfer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func manifestOf(rows map[string]string) *manifest {
+	m := newManifest()
+	for p, h := range rows {
+		m.entries[p] = fileEntry{Path: p, Hash: h, Mode: 0o644}
+	}
+	return m
+}
+
+func TestPlanPull_ThreeWay(t *testing.T) {
+	base := manifestOf(map[string]string{
+		"box-edit.txt":   "b0",
+		"local-edit.txt": "l0",
+		"both-edit.txt":  "x0",
+		"box-del.txt":    "d0",
+		"local-del.txt":  "k0",
+		"same.txt":       "s0",
+	})
+	local := manifestOf(map[string]string{
+		"box-edit.txt":   "b0",
+		"local-edit.txt": "l1",
+		"both-edit.txt":  "x1",
+		"box-del.txt":    "d0",
+		"same.txt":       "s0",
+		"local-new.txt":  "n0",
+	})
+	remote := manifestOf(map[string]string{
+		"box-edit.txt":   "b1",
+		"local-edit.txt": "l0",
+		"both-edit.txt":  "x2",
+		"local-del.txt":  "k0",
+		"same.txt":       "s0",
+		"box-new.txt":    "r0",
+	})
+
+	p := planPull(local, remote, base, true, false)
+	assert.Equal(t, []string{"box-edit.txt", "box-new.txt"}, p.toFetch)
+	assert.Equal(t, []string{"box-del.txt"}, p.toDelete, "local-new.txt was never synced, so it is not deleted")
+	assert.Equal(t, []string{"both-edit.txt"}, p.conflicts)
+	assert.Equal(t, 1, p.added)
+	assert.Equal(t, 1, p.modified)
+
+	// The stored baseline keeps the conflicted path at its old value so
+	// the next pull still flags it.
+	nb := p.baseline(local, remote, base)
+	assert.Equal(t, "x0", nb.entries["both-edit.txt"].Hash)
+	assert.Equal(t, "b1", nb.entries["box-edit.txt"].Hash)
+	_, kept := nb.entries["box-del.txt"]
+	assert.False(t, kept)
+}
+
+// applyPull is what Pull does to the local tree with a plan: fetched
+// paths take the box's version, deleted paths go.
+func applyPull(local, remote *manifest, p pullPlan) *manifest {
+	out := newManifest()
+	for pth, e := range local.entries {
+		out.entries[pth] = e
+	}
+	for _, pth := range p.toFetch {
+		out.entries[pth] = remote.entries[pth]
+	}
+	for _, pth := range p.toDelete {
+		delete(out.entries, pth)
+	}
+	return out
+}
+
+// A second `sync --from-box --delete` must see the same local edits and
+// unsynced files as the first: the baseline the first one stored may not
+// claim they were synced.
+func TestPlanPull_TwoPullsKeepLocalWork(t *testing.T) {
+	base := manifestOf(map[string]string{"edit.txt": "e0", "same.txt": "s0"})
+	local := manifestOf(map[string]string{"edit.txt": "e1", "same.txt": "s0", "scratch.txt": "n0"})
+	remote := manifestOf(map[string]string{"edit.txt": "e0", "same.txt": "s0"})
+
+	for i := 1; i <= 2; i++ {
+		p := planPull(local, remote, base, true, false)
+		assert.Empty(t, p.toFetch, "pull %d would overwrite the local edit", i)
+		assert.Empty(t, p.toDelete, "pull %d would delete a file that was never synced", i)
+		assert.Empty(t, p.conflicts, "pull %d", i)
+		local, base = applyPull(local, remote, p), p.baseline(local, remote, base)
+	}
+	assert.Equal(t, "e1", local.entries["edit.txt"].Hash)
+	assert.Contains(t, local.entries, "scratch.txt")
+	assert.Equal(t, "e0", base.entries["edit.txt"].Hash, "the baseline is still the last synced version")
+	assert.NotContains(t, base.entries, "scratch.txt")
+}
+
+func TestPlanPull_NoBaselineDifferingFileConflicts(t *testing.T) {
+	local := manifestOf(map[string]string{"a.txt": "l"})
+	remote := manifestOf(map[string]string{"a.txt": "r", "b.txt": "r"})
+
+	p := planPull(local, remote, newManifest(), false, false)
+	assert.Equal(t, []string{"b.txt"}, p.toFetch)
+	assert.Equal(t, []string{"a.txt"}, p.conflicts)
+
+	forced := planPull(local, remote, newManifest(), false, true)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, forced.toFetch)
+	assert.Empty(t, forced.conflicts)
+}
+
+func TestPlanPull_DeleteOffLeavesLocalFiles(t *testing.T) {
+	base := manifestOf(map[string]string{"gone.txt": "g"})
+	local := manifestOf(map[string]string{"gone.txt": "g"})
+
+	p := planPull(local, newManifest(), base, false, false)
+	assert.Empty(t, p.toDelete)
+}
+
+func TestExtractTar_OnlyWantedRegularFiles(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	add := func(name string, mode int64, body string) {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: mode, Size: int64(len(body)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	add("bin/run.sh", 0o755, "#!/bin/sh\n")
+	add("unasked.txt", 0o644, "nope")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "link", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer func() { _ = root.Close() }()
+
+	require.NoError(t, extractTar(root, &buf, map[string]bool{"bin/run.sh": true, "link": true}))
+
+	info, err := os.Stat(filepath.Join(dir, "bin", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm(), "executable bit preserved")
+	_, err = os.Lstat(filepath.Join(dir, "unasked.txt"))
+	assert.True(t, os.IsNotExist(err), "files the plan didn't ask for are dropped")
+	_, err = os.Lstat(filepath.Join(dir, "link"))
+	assert.True(t, os.IsNotExist(err), "non-regular entries are dropped")
+}
+
+func TestLastSync_RoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	opt := Options{Username: "alice", LocalPath: "/src/app", RemotePath: "/home/alice/work"}
+
+	empty, err := loadLastSync(opt)
+	require.NoError(t, err)
+	assert.Empty(t, empty.entries, "no baseline yet is not an error")
+
+	m := newManifest()
+	m.entries["bin/run.sh"] = fileEntry{Path: "bin/run.sh", Hash: "abc", Mode: 0o755}
+	m.entries["with space.txt"] = fileEntry{Path: "with space.txt", Hash: "def", Mode: 0o644}
+	require.NoError(t, saveLastSync(opt, m))
+
+	got, err := loadLastSync(opt)
+	require.NoError(t, err)
+	assert.Equal(t, m.entries, got.entries)
+
+	other := opt
+	other.RemotePath = "/srv/app"
+	none, err := loadLastSync(other)
+	require.NoError(t, err)
+	assert.Empty(t, none.entries, "a different remote path has its own baseline")
+}
@@ -17,9 +17,17 @@ type SyncOptions struct {
 	// exist locally. Off by default — additive sync is safer for a v1.
 	Delete bool
 
-	// Excludes: substring patterns to skip during the local walk. Sensible
+	// Excludes: patterns to skip during the local walk. Substring
+	// matches, or gitignore patterns when GitIgnore is set. Sensible
 	// defaults for common build/cache directories are applied if empty.
 	Excludes []string
+
+	// GitIgnore switches exclusion to gitignore semantics: Excludes are
+	// read as gitignore patterns, and every .gitignore and
+	// .containariumignore in the tree is honored (negation, anchored
+	// and directory-only rules included). The same rules guard the
+	// remote side, so a --delete never removes an ignored file there.
+	GitIgnore bool
 }
 
 // DefaultSyncExcludes is the noise filter applied when SyncOptions.Excludes
@@ -45,12 +53,48 @@ var DefaultSyncExcludes = []string{
 	".envrc",
 }
 
+// DefaultSyncIgnores is DefaultSyncExcludes restated as gitignore
+// patterns, applied when SyncOptions.GitIgnore is set and Excludes is
+// empty. Unlike the substring list it matches names exactly, so ".env"
+// no longer catches "config.env.example".
+var DefaultSyncIgnores = []string{
+	"node_modules/",
+	".terraform/",
+	"__pycache__/",
+	".pytest_cache/",
+	".venv/",
+	"venv/",
+	".DS_Store",
+	".idea/",
+	".vscode/",
+	".env",
+	".env.*",
+	".envrc",
+}
+
+// applyDefaultExcludes fills in the default noise filter for the
+// selected exclude semantics when the caller passed none.
+func (o *SyncOptions) applyDefaultExcludes() {
+	if len(o.Excludes) > 0 {
+		return
+	}
+	if o.GitIgnore {
+		o.Excludes = DefaultSyncIgnores
+	} else {
+		o.Excludes = DefaultSyncExcludes
+	}
+}
+
 // SyncResult summarizes what changed.
 type SyncResult struct {
 	Added    int
 	Modified int
 	Deleted  int
 	Bytes    int64
+
+	// Conflicts lists paths changed on both sides since the last sync,
+	// which Pull left untouched. Always empty for Sync and Watch.
+	Conflicts []string
 }
 
 // Sync mirrors LocalPath to RemotePath, shipping only files whose
@@ -63,17 +107,50 @@ func Sync(opt SyncOptions) (*SyncResult, error) {
 	if err := opt.resolve(); err != nil {
 		return nil, err
 	}
-	if len(opt.Excludes) == 0 {
-		opt.Excludes = DefaultSyncExcludes
+	opt.applyDefaultExcludes()
+	res, local, err := syncOnce(opt, opt.newFilter())
+	if err != nil {
+		return nil, err
 	}
+	recordLastSync(opt.Options, local)
+	return res, nil
+}
 
+// recordLastSync stores m as the baseline for a later Pull. A failure
+// only costs Pull its ability to tell one-sided edits from conflicts
+// (it errs toward reporting conflicts), so it's a warning, not an error
+// for a sync that otherwise succeeded.
+func recordLastSync(opt Options, m *manifest) {
+	if err := saveLastSync(opt, m); err != nil {
+		fmt.Fprintf(os.Stderr, "[sync] warning: %v\n", err)
+	}
+}
+
+// comparableRemote is the part of the remote manifest a sync diffs against.
+// With gitignore semantics, what the patterns ignore is not ours on the
+// remote either — a build run there, say — so it is neither compared nor
+// --deleted. The default substring mode compares the whole remote tree, as
+// it always has.
+func (o SyncOptions) comparableRemote(remote *manifest, f pathFilter) *manifest {
+	if !o.GitIgnore {
+		return remote
+	}
+	return remote.filter(f)
+}
+
+// syncOnce is Sync minus option resolution. It also hands back the local
+// manifest it diffed against, which after a successful call is what the
+// remote holds — Watch seeds its in-memory state from it. f is filled in
+// by the walk (ignore files are loaded as it goes), so Watch can keep
+// using it for events afterwards.
+func syncOnce(opt SyncOptions, f pathFilter) (*SyncResult, *manifest, error) {
 	// 1. Build local manifest.
 	if opt.Verbose {
 		fmt.Fprintf(os.Stderr, "[sync] hashing %s ...\n", opt.LocalPath)
 	}
-	local, err := walkLocal(opt.LocalPath, opt.Excludes)
+	local, err := walkLocalFiltered(opt.LocalPath, f)
 	if err != nil {
-		return nil, fmt.Errorf("walk local: %w", err)
+		return nil, nil, fmt.Errorf("walk local: %w", err)
 	}
 
 	// 2. Read remote manifest. The remote script tolerates a missing
@@ -83,8 +160,9 @@ func Sync(opt SyncOptions) (*SyncResult, error) {
 	}
 	remote, err := readRemoteManifest(opt)
 	if err != nil {
-		return nil, fmt.Errorf("read remote manifest: %w", err)
+		return nil, nil, fmt.Errorf("read remote manifest: %w", err)
 	}
+	remote = opt.comparableRemote(remote, f)
 
 	// 3. Diff.
 	d := local.diff(remote)
@@ -94,7 +172,7 @@ func Sync(opt SyncOptions) (*SyncResult, error) {
 		if opt.Verbose {
 			fmt.Fprintln(os.Stderr, "[sync] no changes")
 		}
-		return &SyncResult{}, nil
+		return &SyncResult{}, local, nil
 	}
 
 	// 4. Build a tar of just the changed files (add + modify).
@@ -107,7 +185,7 @@ func Sync(opt SyncOptions) (*SyncResult, error) {
 		var err error
 		tarOut, err = buildChangedTar(opt.LocalPath, d.ToAddOrModify, &tarbuf)
 		if err != nil {
-			return nil, fmt.Errorf("build tar: %w", err)
+			return nil, nil, fmt.Errorf("build tar: %w", err)
 		}
 	}
 
@@ -154,7 +232,7 @@ func Sync(opt SyncOptions) (*SyncResult, error) {
 		cmd.Stderr = os.Stderr
 	}
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("ssh apply: %w", err)
+		return nil, nil, fmt.Errorf("ssh apply: %w", err)
 	}
 
 	// 6. Compute add vs modify split from local-vs-remote.
@@ -169,7 +247,7 @@ func Sync(opt SyncOptions) (*SyncResult, error) {
 	if opt.Delete {
 		res.Deleted = len(d.ToDelete)
 	}
-	return res, nil
+	return res, local, nil
 }
 
 // conditional returns s when cond is true; empty otherwise. Tiny helper to
@@ -1,8 +1,8 @@
-// Package transfer ships files from the local workstation into a remote
+// Package transfer ships files between the local workstation and a remote
 // Containarium container, via the same SSH path the demo flow uses
 // (laptop → sentinel → sshpiper → backend → containarium-shell → incus exec).
 //
-// Two entry-points serving two mental models:
+// Three entry-points serving three mental models:
 //
 //   - Push: ships committed git history via `git bundle`. Atomic per
 //     commit. Refuses dirty working trees unless IncludeWIP is set.
@@ -10,11 +10,16 @@
 //   - Sync: mirrors the working directory (including .git/) via a manual
 //     content-hash diff + tar of changed files. Pushes uncommitted +
 //     untracked + stash refs alongside committed history. Delta-only on
-//     subsequent calls.
+//     subsequent calls. Watch keeps doing it as files change.
 //
-// Both use one-shot ssh-with-command invocations rather than bidirectional
+//   - Pull: Sync in reverse, for bringing a box's edits back. Three-way
+//     against the manifest the last Sync/Pull left behind, so files
+//     changed on both sides are reported as conflicts, not clobbered.
+//
+// All use plain ssh-with-command invocations rather than bidirectional
 // protocols (git-receive-pack, rsync --server) — those are fragile through
-// our shell stack.
+// our shell stack. Watch's persistent session is the same idea: a shell
+// loop reading length-framed batches from stdin.
 package transfer
 
 import (
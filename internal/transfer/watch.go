@@ -0,0 +1,461 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is the quiet period Watch waits after the last
+// filesystem event before shipping a batch. Editors turn one save into
+// several events (truncate, write, chmod, rename-over); 300ms coalesces
+// them without making the remote feel laggy.
+const DefaultWatchDebounce = 300 * time.Millisecond
+
+// WatchOptions extends SyncOptions with watch-mode knobs.
+type WatchOptions struct {
+	SyncOptions
+
+	// Debounce: quiet period after the last event before a batch ships.
+	// DefaultWatchDebounce when zero.
+	Debounce time.Duration
+}
+
+// Watch runs one full Sync, then keeps LocalPath under an fsnotify watch
+// and ships each debounced batch of changes over a single persistent ssh
+// session — no local walk and no remote manifest read per batch. The
+// in-memory manifest from the initial Sync stands in for the remote
+// state; only the paths named by events are re-hashed.
+//
+// onBatch (optional) receives the initial Sync's result and then one
+// SyncResult per non-empty batch. Watch returns nil when ctx is
+// cancelled, or the first error from the watcher or the ssh session.
+func Watch(ctx context.Context, opt WatchOptions, onBatch func(*SyncResult)) error {
+	if err := opt.resolve(); err != nil {
+		return err
+	}
+	opt.applyDefaultExcludes()
+	if opt.Debounce <= 0 {
+		opt.Debounce = DefaultWatchDebounce
+	}
+	if onBatch == nil {
+		onBatch = func(*SyncResult) {}
+	}
+
+	root, err := os.OpenRoot(opt.LocalPath)
+	if err != nil {
+		return fmt.Errorf("open root %s: %w", opt.LocalPath, err)
+	}
+	defer func() { _ = root.Close() }()
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("start watcher: %w", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	// Register the watches BEFORE the initial sync so an edit made while
+	// it runs still produces an event (and at worst gets shipped twice).
+	filter := opt.newFilter()
+	if err := addWatchTree(w, root, opt.LocalPath, ".", filter); err != nil {
+		return err
+	}
+
+	res, local, err := syncOnce(opt.SyncOptions, filter)
+	if err != nil {
+		return err
+	}
+	recordLastSync(opt.Options, local)
+	onBatch(res)
+
+	// #nosec G204 -- argv to ssh, not shell-evaluated locally; remote
+	// script's variables are shQuote'd.
+	cmd := exec.Command("ssh", append(opt.sshBaseArgs(), opt.sshTarget(), watchRemoteScript(opt.RemotePath))...)
+	cmd.Stderr = io.Discard
+	if opt.Verbose {
+		cmd.Stderr = os.Stderr
+	}
+	sess, err := startWatchSession(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sess.close() }()
+
+	st := &watchState{root: root, known: local, filter: filter, delete: opt.Delete}
+	pending := map[string]struct{}{}
+	rulesChanged := false
+
+	// resync runs a full sync and reseeds the watch state from it — the
+	// fallback whenever the in-memory view can't be trusted or the ignore
+	// rules it was built under changed.
+	resync := func() error {
+		st.filter = opt.newFilter()
+		if err := addWatchTree(w, root, opt.LocalPath, ".", st.filter); err != nil {
+			return err
+		}
+		res, local, err := syncOnce(opt.SyncOptions, st.filter)
+		if err != nil {
+			return err
+		}
+		st.known = local
+		pending = map[string]struct{}{}
+		rulesChanged = false
+		recordLastSync(opt.Options, local)
+		onBatch(res)
+		return nil
+	}
+	timer := time.NewTimer(opt.Debounce)
+	timer.Stop()
+
+	if opt.Verbose {
+		fmt.Fprintf(os.Stderr, "[sync] watching %s ...\n", opt.LocalPath)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			rel, ok := relSlash(opt.LocalPath, ev.Name)
+			if !ok {
+				continue
+			}
+			info, statErr := os.Lstat(ev.Name)
+			isDir := statErr == nil && info.IsDir()
+			if st.filter.skip(rel, isDir) {
+				continue
+			}
+			if opt.GitIgnore && slices.Contains(ignoreFileNames, path.Base(rel)) {
+				// An ignore file changed: what counts as "in the tree"
+				// may have changed anywhere beneath it.
+				rulesChanged = true
+			}
+			// New directories need their own watch; fsnotify isn't
+			// recursive. Files created inside before the watch lands are
+			// caught by plan walking the directory.
+			if ev.Has(fsnotify.Create) && isDir {
+				if err := addWatchTree(w, root, opt.LocalPath, rel, st.filter); err != nil {
+					return err
+				}
+			}
+			pending[rel] = struct{}{}
+			timer.Reset(opt.Debounce)
+
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			if !errors.Is(err, fsnotify.ErrEventOverflow) {
+				return fmt.Errorf("watch: %w", err)
+			}
+			// The kernel dropped events, so the in-memory manifest can't
+			// be trusted. Fall back to one full sync and reseed from it.
+			if opt.Verbose {
+				fmt.Fprintln(os.Stderr, "[sync] watch queue overflowed, resyncing ...")
+			}
+			if err := resync(); err != nil {
+				return err
+			}
+
+		case <-timer.C:
+			if rulesChanged {
+				if opt.Verbose {
+					fmt.Fprintln(os.Stderr, "[sync] ignore rules changed, resyncing ...")
+				}
+				if err := resync(); err != nil {
+					return err
+				}
+				continue
+			}
+			b, err := st.plan(pending)
+			if err != nil {
+				return err
+			}
+			if len(b.addMod) == 0 && len(b.del) == 0 {
+				pending = map[string]struct{}{}
+				continue
+			}
+
+			var tarbuf bytes.Buffer
+			var tarOut int64
+			if len(b.addMod) > 0 {
+				tarOut, err = buildChangedTar(opt.LocalPath, b.addMod, &tarbuf)
+				if errors.Is(err, fs.ErrNotExist) {
+					// A file vanished between hashing and packing — the
+					// editor is still mid-save. Keep the paths pending
+					// and try again after another quiet period.
+					timer.Reset(opt.Debounce)
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("build tar: %w", err)
+				}
+			}
+			pending = map[string]struct{}{}
+
+			if opt.Verbose {
+				fmt.Fprintf(os.Stderr, "[sync] shipping %d changed, %d deleted ...\n", len(b.addMod), len(b.del))
+			}
+			if err := sess.apply(b.del, tarbuf.Bytes()); err != nil {
+				return err
+			}
+			st.commit(b)
+			recordLastSync(opt.Options, st.known)
+			b.result.Bytes = tarOut
+			onBatch(&b.result)
+		}
+	}
+}
+
+// addWatchTree adds a watch on start (relative to root) and every
+// directory beneath it that f doesn't skip, so the watcher stays out of
+// node_modules/ and ignored build outputs entirely instead of watching
+// them and dropping their events. Ignore files are loaded on the way
+// down, exactly as the manifest walk does.
+func addWatchTree(w *fsnotify.Watcher, root *os.Root, rootDir, start string, f pathFilter) error {
+	loader, _ := f.(dirLoader)
+	return fs.WalkDir(root.FS(), start, func(rel string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, fs.ErrNotExist) {
+				return nil // removed while we walked; the remove event covers it
+			}
+			return walkErr
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if rel != "." && f.skip(rel, true) {
+			return fs.SkipDir
+		}
+		if loader != nil {
+			if err := loader.loadDir(root, rel); err != nil {
+				return err
+			}
+		}
+		p := filepath.Join(rootDir, filepath.FromSlash(rel))
+		if err := w.Add(p); err != nil {
+			return fmt.Errorf("watch %s: %w", p, err)
+		}
+		return nil
+	})
+}
+
+// relSlash returns p relative to rootDir in forward-slash form, or false
+// when p lies outside rootDir.
+func relSlash(rootDir, p string) (string, bool) {
+	rel, err := filepath.Rel(rootDir, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// watchState is Watch's view of what the remote holds: the manifest from
+// the initial sync, advanced by every batch the remote acknowledged.
+type watchState struct {
+	root   *os.Root
+	known  *manifest
+	filter pathFilter
+	delete bool
+}
+
+// watchBatch is one planned round-trip to the remote.
+type watchBatch struct {
+	addMod  []string             // sorted, forward-slash paths to ship
+	del     []string             // sorted, forward-slash paths to remove remotely
+	entries map[string]fileEntry // fresh manifest rows for addMod
+	result  SyncResult
+}
+
+// plan re-hashes just the touched paths and diffs them against known. A
+// touched path that is now a directory is walked (it was created or
+// renamed in); one that no longer exists drops itself and everything
+// known beneath it.
+func (s *watchState) plan(touched map[string]struct{}) (watchBatch, error) {
+	b := watchBatch{entries: map[string]fileEntry{}}
+	gone := map[string]struct{}{}
+
+	consider := func(e fileEntry) {
+		if s.filter.skip(e.Path, false) {
+			return
+		}
+		if old, ok := s.known.entries[e.Path]; ok && old.Hash == e.Hash && old.Mode == e.Mode {
+			return
+		}
+		b.entries[e.Path] = e
+	}
+	markGone := func(p string) {
+		for known := range s.known.entries {
+			if known == p || strings.HasPrefix(known, p+"/") {
+				gone[known] = struct{}{}
+			}
+		}
+	}
+
+	for p := range touched {
+		info, err := s.root.Lstat(filepath.FromSlash(p))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			markGone(p)
+		case err != nil:
+			return b, fmt.Errorf("stat %s: %w", p, err)
+		case info.IsDir():
+			sub := newManifest()
+			if err := walkInto(sub, s.root, p, s.filter); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return b, fmt.Errorf("walk %s: %w", p, err)
+			}
+			for _, e := range sub.entries {
+				consider(e)
+			}
+		case info.Mode().IsRegular():
+			e, err := hashEntry(s.root, p, info)
+			if errors.Is(err, fs.ErrNotExist) {
+				markGone(p)
+				continue
+			}
+			if err != nil {
+				return b, err
+			}
+			consider(e)
+		default:
+			// Became a symlink/socket/etc. Sync never ships those, so as
+			// far as the remote is concerned the regular file is gone.
+			markGone(p)
+		}
+	}
+
+	for p := range b.entries {
+		b.addMod = append(b.addMod, p)
+		if _, ok := s.known.entries[p]; ok {
+			b.result.Modified++
+		} else {
+			b.result.Added++
+		}
+	}
+	if s.delete {
+		for p := range gone {
+			if _, replaced := b.entries[p]; !replaced {
+				b.del = append(b.del, p)
+			}
+		}
+		b.result.Deleted = len(b.del)
+	}
+	sort.Strings(b.addMod)
+	sort.Strings(b.del)
+	return b, nil
+}
+
+// commit folds an acknowledged batch into known. Without Delete the
+// remote keeps locally-deleted files, so known keeps them too — a later
+// re-create with identical content then correctly ships nothing.
+func (s *watchState) commit(b watchBatch) {
+	for p, e := range b.entries {
+		s.known.entries[p] = e
+	}
+	for _, p := range b.del {
+		delete(s.known.entries, p)
+	}
+}
+
+// watchRemoteScript is the long-lived loop on the container side of a
+// Watch session. Each batch arrives on stdin as
+//
+//	<n-deletes> <tar-bytes>\n
+//	<n-deletes paths, one per line>
+//	<tar-bytes of gzipped tar>
+//
+// and is acknowledged with "ok" on stdout. `head -c` bounds the tar so
+// tar/gzip can't read ahead into the next batch's header.
+func watchRemoteScript(remotePath string) string {
+	return fmt.Sprintf(`
+		set -e
+		mkdir -p %s
+		cd %s
+		while read -r ndel tarlen; do
+			i=0
+			while [ "$i" -lt "$ndel" ]; do
+				IFS= read -r p
+				rm -f -- "$p"
+				i=$((i + 1))
+			done
+			if [ "$tarlen" -gt 0 ]; then
+				head -c "$tarlen" | tar xzf - 2>/dev/null
+			fi
+			echo ok
+		done
+	`,
+		shQuote(remotePath),
+		shQuote(remotePath),
+	)
+}
+
+// watchSession is the persistent ssh process running watchRemoteScript.
+type watchSession struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *bufio.Reader
+}
+
+// startWatchSession starts cmd (normally ssh running watchRemoteScript)
+// with its stdin/stdout wired for the batch protocol.
+func startWatchSession(cmd *exec.Cmd) (*watchSession, error) {
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("ssh stdin: %w", err)
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("ssh stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("ssh start: %w", err)
+	}
+	return &watchSession{cmd: cmd, in: in, out: bufio.NewReader(out)}, nil
+}
+
+// apply ships one batch and waits for the remote's acknowledgement.
+func (s *watchSession) apply(del []string, tarball []byte) error {
+	var hdr bytes.Buffer
+	fmt.Fprintf(&hdr, "%d %d\n", len(del), len(tarball))
+	for _, p := range del {
+		hdr.WriteString(p)
+		hdr.WriteString("\n")
+	}
+	if _, err := s.in.Write(hdr.Bytes()); err != nil {
+		return fmt.Errorf("ssh apply: %w", err)
+	}
+	if _, err := s.in.Write(tarball); err != nil {
+		return fmt.Errorf("ssh apply: %w", err)
+	}
+	line, err := s.out.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("ssh apply: remote session ended: %w", err)
+	}
+	if strings.TrimSpace(line) != "ok" {
+		return fmt.Errorf("ssh apply: unexpected remote reply %q", strings.TrimSpace(line))
+	}
+	return nil
+}
+
+// close ends the remote loop (EOF on its stdin) and reaps ssh.
+func (s *watchSession) close() error {
+	_ = s.in.Close()
+	return s.cmd.Wait()
+}
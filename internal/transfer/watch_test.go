@@ -0,0 +1,139 @@
+package transfer
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWatchState(t *testing.T, dir string, del bool) *watchState {
+	t.Helper()
+	known, err := walkLocal(dir, []string{"node_modules/"})
+	require.NoError(t, err)
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = root.Close() })
+	return &watchState{root: root, known: known, filter: substringFilter{"node_modules/"}, delete: del}
+}
+
+func touched(paths ...string) map[string]struct{} {
+	m := map[string]struct{}{}
+	for _, p := range paths {
+		m[p] = struct{}{}
+	}
+	return m
+}
+
+func TestWatchPlan_AddModifyDelete(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "same.txt"), []byte("same"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "edit.txt"), []byte("v1"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gone.txt"), []byte("bye"), 0o644))
+	st := newTestWatchState(t, dir, true)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "edit.txt"), []byte("v2"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("hi"), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "gone.txt")))
+
+	b, err := st.plan(touched("same.txt", "edit.txt", "new.txt", "gone.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edit.txt", "new.txt"}, b.addMod, "unchanged same.txt is not re-shipped")
+	assert.Equal(t, []string{"gone.txt"}, b.del)
+	assert.Equal(t, SyncResult{Added: 1, Modified: 1, Deleted: 1}, b.result)
+
+	st.commit(b)
+	_, stillKnown := st.known.entries["gone.txt"]
+	assert.False(t, stillKnown)
+	assert.Equal(t, b.entries["edit.txt"].Hash, st.known.entries["edit.txt"].Hash)
+}
+
+func TestWatchPlan_DirectoryCreatedAndRemoved(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "old", "deep"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old", "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old", "deep", "b.txt"), []byte("b"), 0o644))
+	st := newTestWatchState(t, dir, true)
+
+	// A renamed-in directory arrives as a single event for the directory.
+	require.NoError(t, os.Rename(filepath.Join(dir, "old"), filepath.Join(dir, "new")))
+
+	b, err := st.plan(touched("old", "new"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new/a.txt", "new/deep/b.txt"}, b.addMod)
+	assert.Equal(t, []string{"old/a.txt", "old/deep/b.txt"}, b.del)
+}
+
+func TestWatchPlan_NoDeleteKeepsKnown(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gone.txt"), []byte("bye"), 0o644))
+	st := newTestWatchState(t, dir, false)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "gone.txt")))
+	b, err := st.plan(touched("gone.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, b.del, "additive sync never deletes remotely")
+	st.commit(b)
+
+	// Re-creating with the same content: remote still has it, nothing to ship.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gone.txt"), []byte("bye"), 0o644))
+	b, err = st.plan(touched("gone.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, b.addMod)
+}
+
+func TestWatchPlan_RespectsExcludes(t *testing.T) {
+	dir := t.TempDir()
+	st := newTestWatchState(t, dir, true)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules", "x"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "x", "i.js"), []byte("x"), 0o644))
+
+	b, err := st.plan(touched("node_modules"))
+	require.NoError(t, err)
+	assert.Empty(t, b.addMod)
+}
+
+// TestWatchSession_Protocol runs the remote loop under a local sh, so the
+// framing (head -c bounding each tar) is exercised end to end without ssh.
+func TestWatchSession_Protocol(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil {
+		t.Skip("tar not available")
+	}
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "remote")
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("one"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("two"), 0o644))
+
+	sess, err := startWatchSession(exec.Command("sh", "-c", watchRemoteScript(dst)))
+	require.NoError(t, err)
+
+	var tb bytes.Buffer
+	_, err = buildChangedTar(src, []string{"a.txt", "sub/b.txt"}, &tb)
+	require.NoError(t, err)
+	require.NoError(t, sess.apply(nil, tb.Bytes()))
+
+	// Second batch on the same session: one delete plus a modify.
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("one, edited"), 0o644))
+	tb.Reset()
+	_, err = buildChangedTar(src, []string{"a.txt"}, &tb)
+	require.NoError(t, err)
+	require.NoError(t, sess.apply([]string{"sub/b.txt"}, tb.Bytes()))
+	got, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "one, edited", string(got))
+
+	// Delete-only batch.
+	require.NoError(t, sess.apply([]string{"a.txt"}, nil))
+	require.NoError(t, sess.close())
+
+	_, err = os.Stat(filepath.Join(dst, "a.txt"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dst, "sub", "b.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
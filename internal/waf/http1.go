@@ -0,0 +1,284 @@
+package waf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HTTP/1.1 request framing for the steering proxy. The proxy forwards the wire
+// bytes untouched; this only finds where each request's body ends (so every
+// request on a keep-alive connection is inspected, not just the first) and
+// decodes a bounded prefix of the body for the Inspector.
+
+// defaultMaxBodyBytes bounds how much of a request body is buffered for
+// inspection when the proxy isn't configured otherwise. Bytes past the bound
+// are forwarded uninspected, as head bytes past maxHeadBytes always were.
+const defaultMaxBodyBytes = 64 * 1024
+
+// maxChunkLine bounds a chunk-size line (size + extensions), which a client
+// could otherwise stretch to exhaust the proxy's memory.
+const maxChunkLine = 4096
+
+// errAmbiguousFraming marks a request whose body length can't be determined
+// unambiguously (Content-Length alongside chunked, conflicting lengths, an
+// unknown transfer coding, a header name or length a lenient upstream might
+// read differently). Forwarding it would let the upstream and the proxy
+// disagree on where the next request starts — request smuggling past the
+// inspector — so the proxy refuses it.
+var errAmbiguousFraming = errors.New("ambiguous request framing")
+
+// framing is how a request's body is delimited.
+type framing struct {
+	http          bool  // a parseable HTTP/1.x request head
+	chunked       bool  // Transfer-Encoding: chunked
+	contentLength int64 // body length when !chunked
+	expect100     bool  // Expect: 100-continue
+	upgrade       bool  // CONNECT or Connection: upgrade — the connection stops being HTTP/1.1 after it
+}
+
+// readRequestHead reads one request head (through the blank line) from br.
+// It stops early at max bytes or on a read error, returning what it has:
+// complete is false then, and the caller treats the rest of the connection
+// as an opaque stream. An empty result with io.EOF is a client that closed
+// between requests.
+func readRequestHead(br *bufio.Reader, max int) (head []byte, complete bool, err error) {
+	for len(head) < max {
+		line, err := br.ReadSlice('\n')
+		head = append(head, line...)
+		if err == bufio.ErrBufferFull {
+			continue // a line longer than the buffer: keep accumulating
+		}
+		if err != nil {
+			return head, false, err
+		}
+		if len(head) == len(line) && (string(line) == "\r\n" || string(line) == "\n") {
+			head = head[:0] // tolerate a stray CRLF between requests (RFC 9112 §2.2)
+			continue
+		}
+		if string(line) == "\r\n" || string(line) == "\n" {
+			return head, true, nil
+		}
+	}
+	return head, false, nil
+}
+
+// parseFraming reads the body delimitation out of a request head.
+func parseFraming(head []byte) (framing, error) {
+	var f framing
+	lines := bytes.Split(head, []byte("\n"))
+	parts := strings.Fields(string(lines[0]))
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "HTTP/1.") || !isToken(parts[0]) {
+		return f, nil
+	}
+	f.http = true
+	f.upgrade = parts[0] == http.MethodConnect
+	var (
+		te      []string
+		lengths []string
+	)
+	for _, l := range lines[1:] {
+		line := strings.TrimRight(string(l), "\r")
+		if line != "" && (line[0] == ' ' || line[0] == '\t') {
+			// An obs-fold continuation (RFC 9112 §5.2): an upstream that
+			// unfolds it reads a different value for the header above.
+			return f, errAmbiguousFraming
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if strings.ContainsAny(name, " \t") {
+			// "Transfer-Encoding : chunked" is no header to a strict parser
+			// and Transfer-Encoding to a lenient one (RFC 9112 §5.1).
+			return f, errAmbiguousFraming
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(name) {
+		case "transfer-encoding":
+			for _, c := range strings.Split(value, ",") {
+				if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+					te = append(te, c)
+				}
+			}
+		case "content-length":
+			lengths = append(lengths, value)
+		case "expect":
+			f.expect100 = strings.EqualFold(value, "100-continue")
+		case "connection":
+			for _, c := range strings.Split(value, ",") {
+				if strings.EqualFold(strings.TrimSpace(c), "upgrade") {
+					f.upgrade = true
+				}
+			}
+		}
+	}
+	if len(te) > 0 {
+		// Chunked must be the final coding and the only framing; anything
+		// else leaves the body length to the upstream's interpretation.
+		if len(lengths) > 0 || te[len(te)-1] != "chunked" {
+			return f, errAmbiguousFraming
+		}
+		f.chunked = true
+		return f, nil
+	}
+	for i, v := range lengths {
+		if !isDigits(v) {
+			return f, errAmbiguousFraming // "+3", "0x3", "3 3": 1*DIGIT only
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || (i > 0 && n != f.contentLength) {
+			return f, errAmbiguousFraming
+		}
+		f.contentLength = n
+	}
+	return f, nil
+}
+
+// isDigits reports whether s is one or more ASCII decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isHexDigits reports whether s is one or more ASCII hex digits.
+func isHexDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// hasBody reports whether the request carries a body to read.
+func (f framing) hasBody() bool { return f.chunked || f.contentLength > 0 }
+
+// bodyReader returns a reader over the request's decoded body that also
+// writes every wire byte it consumes (chunk framing included) to raw, so the
+// proxy can forward exactly what the client sent.
+func (f framing) bodyReader(br *bufio.Reader, raw io.Writer) io.Reader {
+	if f.chunked {
+		return &chunkedReader{br: br, raw: raw}
+	}
+	return io.TeeReader(io.LimitReader(br, f.contentLength), raw)
+}
+
+// chunkedReader decodes a chunked body (RFC 9112 §7.1), teeing the wire bytes.
+type chunkedReader struct {
+	br      *bufio.Reader
+	raw     io.Writer
+	left    int64 // data bytes left in the current chunk
+	started bool  // a chunk has been read, so its trailing CRLF is due
+	done    bool
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	for c.left == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		if c.started {
+			if err := c.expectCRLF(); err != nil {
+				return 0, err
+			}
+		}
+		line, err := c.line()
+		if err != nil {
+			return 0, err
+		}
+		// chunk-size is 1*HEXDIG, up against the line end or the first
+		// extension: no sign, no "0x", no padding for a lenient upstream to
+		// read as a different size than this one.
+		size, _, _ := strings.Cut(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), ";")
+		if !isHexDigits(size) {
+			return 0, fmt.Errorf("%w: bad chunk size %q", errAmbiguousFraming, size)
+		}
+		n, err := strconv.ParseInt(size, 16, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: bad chunk size %q", errAmbiguousFraming, size)
+		}
+		c.started = true
+		if n == 0 {
+			// Last chunk: consume the trailer section through its blank line.
+			for {
+				t, err := c.line()
+				if err != nil {
+					return 0, err
+				}
+				if strings.TrimSpace(t) == "" {
+					break
+				}
+			}
+			c.done = true
+			return 0, io.EOF
+		}
+		c.left = n
+	}
+	if int64(len(p)) > c.left {
+		p = p[:c.left]
+	}
+	n, err := c.br.Read(p)
+	c.left -= int64(n)
+	if n > 0 {
+		if _, werr := c.raw.Write(p[:n]); werr != nil {
+			return n, werr
+		}
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// line reads one CRLF-terminated line, bounded by maxChunkLine.
+func (c *chunkedReader) line() (string, error) {
+	var b []byte
+	for {
+		frag, err := c.br.ReadSlice('\n')
+		b = append(b, frag...)
+		if len(b) > maxChunkLine {
+			return "", errors.New("chunk line too long")
+		}
+		if _, werr := c.raw.Write(frag); werr != nil {
+			return "", werr
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+func (c *chunkedReader) expectCRLF() error {
+	l, err := c.line()
+	if err != nil {
+		return err
+	}
+	if strings.TrimRight(l, "\r\n") != "" {
+		return errors.New("missing CRLF after chunk data")
+	}
+	return nil
+}
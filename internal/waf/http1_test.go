@@ -0,0 +1,247 @@
+package waf
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFraming(t *testing.T) {
+	for _, tc := range []struct {
+		name, head string
+		want       framing
+		ambiguous  bool
+	}{
+		{"no body", "GET / HTTP/1.1\r\nHost: t\r\n\r\n", framing{http: true}, false},
+		{"content-length", "POST / HTTP/1.1\r\nContent-Length: 12\r\n\r\n", framing{http: true, contentLength: 12}, false},
+		{"repeated equal lengths", "POST / HTTP/1.1\r\nContent-Length: 3\r\ncontent-length: 3\r\n\r\n", framing{http: true, contentLength: 3}, false},
+		{"chunked", "POST / HTTP/1.1\r\nTransfer-Encoding: gzip, Chunked\r\nExpect: 100-continue\r\n\r\n", framing{http: true, chunked: true, expect100: true}, false},
+		{"not http", "SSH-2.0-OpenSSH\r\n\r\n", framing{}, false},
+		{"TE and CL", "POST / HTTP/1.1\r\nTransfer-Encoding: chunked\r\nContent-Length: 3\r\n\r\n", framing{}, true},
+		{"chunked not final", "POST / HTTP/1.1\r\nTransfer-Encoding: chunked, gzip\r\n\r\n", framing{}, true},
+		{"conflicting lengths", "POST / HTTP/1.1\r\nContent-Length: 3\r\nContent-Length: 4\r\n\r\n", framing{}, true},
+		{"bad length", "POST / HTTP/1.1\r\nContent-Length: -1\r\n\r\n", framing{}, true},
+		{"signed length", "POST / HTTP/1.1\r\nContent-Length: +3\r\n\r\n", framing{}, true},
+		{"length list", "POST / HTTP/1.1\r\nContent-Length: 3 3\r\n\r\n", framing{}, true},
+		{"hex length", "POST / HTTP/1.1\r\nContent-Length: 0x3\r\n\r\n", framing{}, true},
+		{"space before colon", "POST / HTTP/1.1\r\nTransfer-Encoding : chunked\r\n\r\n", framing{}, true},
+		{"tab in name", "POST / HTTP/1.1\r\nContent-Length\t: 3\r\n\r\n", framing{}, true},
+		{"folded value", "POST / HTTP/1.1\r\nTransfer-Encoding:\r\n chunked\r\n\r\n", framing{}, true},
+		{"websocket", "GET /ws HTTP/1.1\r\nConnection: keep-alive, Upgrade\r\nUpgrade: websocket\r\n\r\n", framing{http: true, upgrade: true}, false},
+		{"connect", "CONNECT db:5432 HTTP/1.1\r\nHost: db:5432\r\n\r\n", framing{http: true, upgrade: true}, false},
+		{"padded length", "POST / HTTP/1.1\r\nContent-Length:  7 \r\n\r\n", framing{http: true, contentLength: 7}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := parseFraming([]byte(tc.head))
+			if (err == errAmbiguousFraming) != tc.ambiguous {
+				t.Fatalf("err = %v, ambiguous want %v", err, tc.ambiguous)
+			}
+			if !tc.ambiguous && f != tc.want {
+				t.Errorf("framing = %+v, want %+v", f, tc.want)
+			}
+		})
+	}
+}
+
+// The chunked reader decodes the body and tees exactly the wire bytes —
+// extensions, trailers and all — leaving the next request unread.
+func TestChunkedReader_DecodesAndTeesWireBytes(t *testing.T) {
+	body := "4;ext=1\r\nWiki\r\n6\r\npedia \r\n0\r\nTrailer: x\r\n\r\n"
+	br := bufio.NewReader(strings.NewReader(body + "GET /next HTTP/1.1\r\n\r\n"))
+	var raw bytes.Buffer
+	got, err := io.ReadAll(framing{chunked: true}.bodyReader(br, &raw))
+	if err != nil || string(got) != "Wikipedia " {
+		t.Fatalf("decoded %q, %v", got, err)
+	}
+	if raw.String() != body {
+		t.Errorf("teed %q, want the wire bytes %q", raw.String(), body)
+	}
+	if next, _, _ := readRequestHead(br, maxHeadBytes); !strings.HasPrefix(string(next), "GET /next") {
+		t.Errorf("next request = %q", next)
+	}
+	if _, err := io.ReadAll(framing{chunked: true}.bodyReader(bufio.NewReader(strings.NewReader("zz\r\n")), io.Discard)); err == nil {
+		t.Error("bad chunk size accepted")
+	}
+}
+
+// A chunk size is bare hex digits: anything a lenient upstream could size
+// differently is ambiguous framing, not a chunk.
+func TestChunkedReader_RefusesLooseChunkSizes(t *testing.T) {
+	for _, size := range []string{"+5", "-0", " 5", "5 ", "5\t;ext", "0x5", ""} {
+		body := size + "\r\nhello\r\n0\r\n\r\n"
+		_, err := io.ReadAll(framing{chunked: true}.bodyReader(bufio.NewReader(strings.NewReader(body)), io.Discard))
+		if !errors.Is(err, errAmbiguousFraming) {
+			t.Errorf("chunk size %q: err = %v, want ambiguous framing", size, err)
+		}
+	}
+	got, err := io.ReadAll(framing{chunked: true}.bodyReader(bufio.NewReader(strings.NewReader("05;x\r\nhello\r\n0\r\n\r\n")), io.Discard))
+	if err != nil || string(got) != "hello" {
+		t.Errorf("leading zero with an extension: %q, %v", got, err)
+	}
+}
+
+// startRuleProxy runs an enforcing proxy over rules in front of an echo
+// upstream and returns its address.
+func startRuleProxy(t *testing.T, maxBody int, rules ...Rule) string {
+	t.Helper()
+	echo := echoServer(t)
+	t.Cleanup(func() { _ = echo.Close() })
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	rs, err := CompileRules(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewRuleEngine(NewBuiltinInspector())
+	e.Swap(rs)
+	p := &TransparentProxy{
+		OrigDst:      func(net.Conn) string { return echo.Addr().String() },
+		Inspector:    e,
+		EnforceBlock: true,
+		MaxBodyBytes: maxBody,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = p.Serve(ctx, ln) }()
+	return ln.Addr().String()
+}
+
+// readEcho reads n echoed bytes, or whatever the proxy answers instead.
+func readEcho(t *testing.T, br *bufio.Reader, n int) string {
+	t.Helper()
+	buf := make([]byte, n)
+	got, _ := io.ReadFull(br, buf)
+	return string(buf[:got])
+}
+
+// Every request on a keep-alive connection is inspected: a benign chunked
+// request is forwarded byte for byte, and the exploit hidden in the next
+// request's body is refused.
+func TestProxy_KeepAliveInspectsEveryRequestBody(t *testing.T) {
+	addr := startRuleProxy(t, 0,
+		Rule{ID: 1000, Name: "sqli-body", Target: TargetBody, Match: MatchRegex, Pattern: `(?i)'\s*or\s+1=1`, Action: ActionBlock})
+	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(3 * time.Second))
+	br := bufio.NewReader(conn)
+
+	first := "POST /a HTTP/1.1\r\nHost: t\r\nTransfer-Encoding: chunked\r\n\r\n5\r\nhello\r\n0\r\n\r\n"
+	_, _ = conn.Write([]byte(first))
+	if got := readEcho(t, br, len(first)); got != first {
+		t.Fatalf("benign request not forwarded verbatim: %q", got)
+	}
+	// Form-encoded, split across writes, and only detectable once decoded.
+	_, _ = conn.Write([]byte("POST /login HTTP/1.1\r\nHost: t\r\nContent-Type: application/x-www-form-urlencoded\r\nContent-Length: 21\r\n\r\n"))
+	time.Sleep(20 * time.Millisecond)
+	_, _ = conn.Write([]byte("user=a%27+OR+1%3D1+--"))
+	if line, _ := br.ReadString('\n'); !strings.Contains(line, "403") {
+		t.Fatalf("body exploit on the second request not refused, got %q", line)
+	}
+}
+
+// Bytes past MaxBodyBytes are forwarded uninspected, as documented.
+func TestProxy_BodyPastBoundIsForwarded(t *testing.T) {
+	addr := startRuleProxy(t, 8,
+		Rule{ID: 1000, Name: "marker", Target: TargetBody, Match: MatchLiteral, Pattern: "evil", Action: ActionBlock})
+	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(3 * time.Second))
+	req := "POST / HTTP/1.1\r\nContent-Length: 16\r\n\r\n0123456789::evil"
+	_, _ = conn.Write([]byte(req))
+	if got := readEcho(t, bufio.NewReader(conn), len(req)); got != req {
+		t.Fatalf("got %q, want the request forwarded", got)
+	}
+}
+
+// Each request is framed so that a lenient upstream would find a second
+// request, GET /smuggled, where the proxy sees body bytes (or the reverse).
+// The proxy must answer 400 and forward none of it.
+func TestProxy_AmbiguousFramingRefused(t *testing.T) {
+	for _, tc := range []struct{ name, req string }{
+		{"TE and CL", "POST / HTTP/1.1\r\nContent-Length: 4\r\nTransfer-Encoding: chunked\r\n\r\n0\r\n\r\nGET /smuggled HTTP/1.1\r\n\r\n"},
+		{"space before colon", "POST / HTTP/1.1\r\nTransfer-Encoding : chunked\r\n\r\n0\r\n\r\nGET /smuggled HTTP/1.1\r\n\r\n"},
+		{"signed length", "POST / HTTP/1.1\r\nContent-Length: +0\r\n\r\nGET /smuggled HTTP/1.1\r\n\r\n"},
+		{"signed chunk size", "POST / HTTP/1.1\r\nTransfer-Encoding: chunked\r\n\r\n+0\r\n\r\nGET /smuggled HTTP/1.1\r\n\r\n"},
+		{"padded chunk size", "POST / HTTP/1.1\r\nTransfer-Encoding: chunked\r\n\r\n0 \r\n\r\nGET /smuggled HTTP/1.1\r\n\r\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			addr := startRuleProxy(t, 0)
+			conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = conn.Close() }()
+			_ = conn.SetDeadline(time.Now().Add(3 * time.Second))
+			_, _ = conn.Write([]byte(tc.req))
+			got, _ := io.ReadAll(conn)
+			if !bytes.HasPrefix(got, []byte("HTTP/1.1 400")) || bytes.Contains(got, []byte("smuggled")) {
+				t.Fatalf("got %q, want only a 400", got)
+			}
+		})
+	}
+}
+
+// After a WebSocket handshake the client's frames are piped as they come, not
+// held while the proxy waits for a request head they will never complete.
+func TestProxy_UpgradePipesFramesOpaquely(t *testing.T) {
+	addr := startRuleProxy(t, 0,
+		Rule{ID: 1000, Name: "marker", Target: TargetBody, Match: MatchLiteral, Pattern: "evil", Action: ActionBlock})
+	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(3 * time.Second))
+	br := bufio.NewReader(conn)
+
+	hs := "GET /ws HTTP/1.1\r\nHost: t\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n"
+	_, _ = conn.Write([]byte(hs))
+	if got := readEcho(t, br, len(hs)); got != hs {
+		t.Fatalf("handshake not forwarded: %q", got)
+	}
+	frame := "\x81\x05hello" // one unmasked text frame, no CRLF anywhere
+	_, _ = conn.Write([]byte(frame))
+	if got := readEcho(t, br, len(frame)); got != frame {
+		t.Fatalf("frame held or altered: got %q", got)
+	}
+}
+
+// A rate rule answers 429 once the client's bucket is empty, mid-connection.
+func TestProxy_RateRuleReturns429(t *testing.T) {
+	addr := startRuleProxy(t, 0,
+		Rule{ID: 1000, Name: "login-rate", Target: TargetPath, Match: MatchLiteral, Pattern: "/login", Action: ActionRateLimit,
+			RequestsPerMinute: 1, Burst: 2})
+	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(3 * time.Second))
+	br := bufio.NewReader(conn)
+	req := "GET /login HTTP/1.1\r\nHost: t\r\n\r\n"
+	for i := 0; i < 2; i++ {
+		_, _ = conn.Write([]byte(req))
+		if got := readEcho(t, br, len(req)); got != req {
+			t.Fatalf("request %d within the burst not forwarded: %q", i+1, got)
+		}
+	}
+	_, _ = conn.Write([]byte(req))
+	if line, _ := br.ReadString('\n'); !strings.Contains(line, "429") {
+		t.Fatalf("got %q, want a 429 once the burst is spent", line)
+	}
+}
@@ -1,19 +1,24 @@
 package waf
 
-import (
-	"bytes"
-	"net"
-)
-
 // Verdict is an Inspector's decision about a connection's request head.
 type Verdict struct {
 	Block    bool
 	RuleID   uint16 // the matched rule/signature id (0 if none)
 	RuleName string // human label for the audit log
+
+	// RateLimited marks a block decided by a rate rule: the proxy answers 429
+	// instead of 403.
+	RateLimited bool
+
+	// Set by a RuleEngine: every rule that matched (block, log and exhausted
+	// rate rules), and the destination's tenant when it could be attributed.
+	Matches []Match
+	Tenant  string
 }
 
-// Inspector examines the start of a steered connection's payload — the request
-// head, REASSEMBLED across TCP segments — and decides whether to block. The
+// Inspector examines a steered request — its head, REASSEMBLED across TCP
+// segments, followed by up to the proxy's body bound of its (de-chunked) body —
+// and decides whether to block. The
 // reassembly is the value over Tier 2's in-kernel scan, which only ever sees a
 // single packet (a signature split across segments evades it). The interface is
 // the seam a real WAF engine (Coraza + the OWASP CRS) plugs into later, behind a
@@ -23,11 +28,21 @@ type Inspector interface {
 	Inspect(head []byte) Verdict
 }
 
-// maxHeadBytes bounds how much of a connection's start is buffered for
-// inspection — enough for an HTTP request line + headers (where header-borne
-// exploits like Log4Shell live), capped so a slow/huge request can't exhaust
-// memory. Bytes past the head still flow through, uninspected (documented best-
-// effort limit; full body inspection is a WAF-engine refinement).
+// ConnInspector is an Inspector that also wants the connection's endpoints:
+// the client's source IP (for per-client rate rules) and the original
+// destination (for per-tenant rules). The proxy calls InspectConn instead of
+// Inspect when the Inspector implements it.
+type ConnInspector interface {
+	Inspector
+	InspectConn(src, orig string, req []byte) Verdict
+}
+
+// maxHeadBytes bounds how much of a request head is buffered for inspection —
+// enough for an HTTP request line + headers (where header-borne exploits like
+// Log4Shell live), capped so a slow/huge request can't exhaust memory. A head
+// past the bound is inspected as far as it goes and the connection is then
+// piped uninspected (documented best-effort limit); bodies have their own
+// bound, TransparentProxy.MaxBodyBytes.
 const maxHeadBytes = 16 * 1024
 
 // block403 is the response written to a client whose request an Inspector blocked
@@ -35,28 +50,13 @@ const maxHeadBytes = 16 * 1024
 // connection down cleanly without forwarding upstream.
 var block403 = []byte("HTTP/1.1 403 Forbidden\r\nConnection: close\r\nContent-Length: 0\r\n\r\n")
 
-// readHead reads from c until the end-of-headers marker ("\r\n\r\n"), max bytes,
-// or EOF — whichever first — and returns everything read. Reassembles across
-// however many TCP segments/reads the head spans. The returned bytes are
-// forwarded verbatim to the upstream after inspection (byte-preserving proxy),
-// so this consumes only the head; the rest of the stream is piped afterward.
-func readHead(c net.Conn, max int) []byte {
-	buf := make([]byte, 0, 2048)
-	tmp := make([]byte, 2048)
-	for len(buf) < max {
-		n, err := c.Read(tmp)
-		if n > 0 {
-			buf = append(buf, tmp[:n]...)
-			if bytes.Contains(buf, []byte("\r\n\r\n")) {
-				break
-			}
-		}
-		if err != nil {
-			break // EOF / error before a full head — inspect what we have
-		}
-	}
-	if len(buf) > max {
-		buf = buf[:max]
-	}
-	return buf
-}
+// block429 answers a request refused by a rate rule (enforce mode).
+var block429 = []byte("HTTP/1.1 429 Too Many Requests\r\nConnection: close\r\nContent-Length: 0\r\n\r\n")
+
+// block400 answers a request whose framing the proxy won't guess at (see
+// errAmbiguousFraming). Sent in every mode: forwarding it would be unsafe.
+var block400 = []byte("HTTP/1.1 400 Bad Request\r\nConnection: close\r\nContent-Length: 0\r\n\r\n")
+
+// continue100 is the interim response the proxy sends a client waiting on
+// Expect: 100-continue, so the body arrives to be inspected.
+var continue100 = []byte("HTTP/1.1 100 Continue\r\n\r\n")
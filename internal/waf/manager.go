@@ -14,6 +14,8 @@ type Config struct {
 	Inspector    Inspector                                  // nil → forward-only
 	EnforceBlock bool                                       // false → observe+audit, don't 403
 	OnBlock      func(orig string, v Verdict, dropped bool) // audit hook
+	OnMatch      func(orig string, v Verdict, dropped bool) // rule-match hook (RuleEngine)
+	MaxBodyBytes int                                        // body bytes inspected per request (0 → 64KiB, <0 → none)
 }
 
 // Start binds a transparent listener and serves the steering proxy in a
@@ -29,6 +31,8 @@ func Start(ctx context.Context, cfg Config) error {
 		Inspector:    cfg.Inspector,
 		EnforceBlock: cfg.EnforceBlock,
 		OnBlock:      cfg.OnBlock,
+		OnMatch:      cfg.OnMatch,
+		MaxBodyBytes: cfg.MaxBodyBytes,
 		OnForward:    func(orig string) { log.Printf("[waf] steered connection → original dst %s", orig) },
 	}
 	mode := "forward-only (no inspection)"
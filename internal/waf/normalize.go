@@ -0,0 +1,156 @@
+package waf
+
+import (
+	"bytes"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Request is the parsed, normalized view of a request head that rules match
+// against. Every string has been through normalize, so an evasion that only
+// changes the encoding (double percent-encoding, %u escapes, fullwidth
+// characters) lands on the same bytes as the plain form.
+type Request struct {
+	Method  string
+	Path    string
+	Query   string
+	Headers map[string][]string // keyed by canonical header name
+	Body    string              // the inspected body prefix (de-chunked)
+}
+
+// maxDecodePasses bounds the repeated percent-decoding: enough to unwrap a
+// double- or triple-encoded payload, bounded so a crafted value can't make the
+// proxy loop.
+const maxDecodePasses = 3
+
+// ParseRequest parses an HTTP/1.x request — head (request line + headers),
+// then whatever body follows the blank line — and normalizes its parts. ok is
+// false when it isn't HTTP — a non-HTTP steered flow has nothing for the rule
+// engine to match. A head truncated at maxHeadBytes still parses; the header
+// it cut off is simply incomplete. A form-encoded body is normalized like a
+// query.
+//
+// Lines end at "\n" with an optional "\r", as the proxy's framing reads them
+// (and as upstreams that accept bare-LF heads do): splitting on CRLF alone
+// would read a bare-LF head as one request line and match no header at all.
+func ParseRequest(raw []byte) (Request, bool) {
+	lines, body := splitHead(raw)
+	if len(lines) == 0 {
+		return Request{}, false
+	}
+	parts := strings.Fields(string(lines[0]))
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "HTTP/") || !isToken(parts[0]) {
+		return Request{}, false
+	}
+	req := Request{Method: strings.ToUpper(parts[0]), Headers: map[string][]string{}}
+	target := parts[1]
+	if i := strings.IndexByte(target, '?'); i >= 0 {
+		req.Path, req.Query = target[:i], target[i+1:]
+	} else {
+		req.Path = target
+	}
+	req.Path = normalize(req.Path, false)
+	req.Query = normalize(req.Query, true)
+	for _, l := range lines[1:] {
+		name, value, found := strings.Cut(string(l), ":")
+		if !found {
+			continue
+		}
+		key := textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(name))
+		req.Headers[key] = append(req.Headers[key], normalize(strings.TrimSpace(value), false))
+	}
+	if len(body) > 0 {
+		form := false
+		if ct := req.Headers["Content-Type"]; len(ct) > 0 {
+			form = strings.HasPrefix(strings.ToLower(ct[0]), "application/x-www-form-urlencoded")
+		}
+		req.Body = normalize(string(body), form)
+	}
+	return req, true
+}
+
+// splitHead splits raw into the head's lines, line endings removed, and the
+// body after the first empty line. A head with no empty line (truncated at
+// maxHeadBytes) is all lines and no body.
+func splitHead(raw []byte) (lines [][]byte, body []byte) {
+	for rest := raw; len(rest) > 0; {
+		line, after, found := bytes.Cut(rest, []byte("\n"))
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if found && len(line) == 0 {
+			return lines, after
+		}
+		lines = append(lines, line)
+		rest = after
+	}
+	return lines, nil
+}
+
+// isToken reports whether s is a plausible HTTP method (an RFC 9110 token of
+// letters), which is enough to tell an HTTP head from other protocols.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize undoes the encodings an exploit hides behind: repeated percent-
+// decoding (with '+' as a space in a query), IIS-style %uXXXX escapes, NUL
+// bytes, then Unicode NFKC so compatibility forms (fullwidth "／", ligatures)
+// fold to their ASCII equivalents.
+func normalize(s string, query bool) string {
+	if query {
+		s = strings.ReplaceAll(s, "+", " ")
+	}
+	for i := 0; i < maxDecodePasses && strings.IndexByte(s, '%') >= 0; i++ {
+		d := percentDecode(s)
+		if d == s {
+			break
+		}
+		s = d
+	}
+	s = strings.ReplaceAll(s, "\x00", "")
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "�")
+	}
+	return norm.NFKC.String(s)
+}
+
+// percentDecode decodes %XX and %uXXXX escapes, leaving a malformed escape as
+// it is (unlike url.PathUnescape, which rejects the whole string — an attacker
+// would use one bad escape to switch decoding off).
+func percentDecode(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+5 < len(s) && (s[i+1] == 'u' || s[i+1] == 'U') {
+			if r, err := strconv.ParseUint(s[i+2:i+6], 16, 16); err == nil {
+				b.WriteRune(rune(r))
+				i += 5
+				continue
+			}
+		}
+		if i+2 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte('%')
+	}
+	return b.String()
+}
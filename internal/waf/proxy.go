@@ -12,6 +12,8 @@
 package waf
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -49,18 +51,29 @@ type TransparentProxy struct {
 	// original destination — used by the validator/tests to observe steering.
 	OnForward func(orig string)
 
-	// Inspector, if set (#662 PR-2), examines the reassembled request head before
-	// forwarding. Nil → forward-only (PR-1). When a verdict blocks:
-	//   - EnforceBlock true  → write a 403 and DON'T forward.
+	// Inspector, if set (#662 PR-2), examines each reassembled request — its head
+	// and up to MaxBodyBytes of its body — before forwarding. Nil → forward-only
+	// (PR-1). When a verdict blocks:
+	//   - EnforceBlock true  → write a 403 (429 for a rate rule) and DON'T forward.
 	//   - EnforceBlock false → forward anyway (observe-only), still calling OnBlock.
 	// Mirrors the rest of the stack: audit always, drop only when armed.
 	Inspector    Inspector
 	EnforceBlock bool
 
+	// MaxBodyBytes bounds how much of each request body is buffered and handed
+	// to the Inspector (0 → defaultMaxBodyBytes, negative → heads only). The
+	// rest of a longer body is forwarded uninspected.
+	MaxBodyBytes int
+
 	// OnBlock, if set, is called when the Inspector returns a blocking verdict
 	// (whether or not EnforceBlock dropped it), with the original dst and verdict —
 	// the daemon wires this to the audit log.
 	OnBlock func(orig string, v Verdict, dropped bool)
+
+	// OnMatch, if set, is called when a verdict carries rule matches (a
+	// RuleEngine's block and log rules alike), with whether the connection was
+	// refused. The daemon wires this to the audit log and the event bus.
+	OnMatch func(orig string, v Verdict, dropped bool)
 }
 
 func (p *TransparentProxy) origDst(c net.Conn) string {
@@ -110,50 +123,25 @@ func (p *TransparentProxy) Serve(ctx context.Context, ln net.Listener) error {
 }
 
 // handle recovers a connection's original destination, dials it, and pipes bytes
-// both ways until either side closes.
+// both ways until either side closes. With an Inspector, the client side is read
+// as HTTP/1.1 instead (serveInspected).
 func (p *TransparentProxy) handle(ctx context.Context, client net.Conn) {
 	defer func() { _ = client.Close() }()
 	orig := p.origDst(client)
 	if p.OnForward != nil {
 		p.OnForward(orig)
 	}
-
-	// PR-2 inspection: read + inspect the reassembled request head before
-	// forwarding. The head bytes are replayed verbatim to the upstream after a
-	// pass (byte-preserving), so a steered HTTP request is unmodified end to end.
-	var head []byte
 	if p.Inspector != nil {
-		head = readHead(client, maxHeadBytes)
-		v := p.Inspector.Inspect(head)
-		if v.Block {
-			if p.OnBlock != nil {
-				p.OnBlock(orig, v, p.EnforceBlock)
-			}
-			if p.EnforceBlock {
-				_, _ = client.Write(block403) // refuse; don't forward the exploit
-				return
-			}
-			// observe-only: fall through and forward, having audited the match.
-		}
+		p.serveInspected(ctx, client, orig)
+		return
 	}
 
-	dctx, cancel := context.WithTimeout(ctx, p.dialTimeout())
-	defer cancel()
-	upstream, err := p.dial(dctx, orig)
-	if err != nil {
-		log.Printf("[waf] steer: dial original dst %s failed: %v", orig, err)
+	upstream := p.dialUpstream(ctx, orig)
+	if upstream == nil {
 		return
 	}
 	defer func() { _ = upstream.Close() }()
 
-	// Replay the inspected head to the upstream, then pipe the remainder both ways.
-	if len(head) > 0 {
-		if _, err := upstream.Write(head); err != nil {
-			log.Printf("[waf] steer: replay head to %s failed: %v", orig, err)
-			return
-		}
-	}
-
 	// Bidirectional copy with TCP half-close so an EOF in one direction is
 	// propagated (a half-closed client shouldn't tear down the still-active
 	// response direction).
@@ -164,11 +152,218 @@ func (p *TransparentProxy) handle(ctx context.Context, client net.Conn) {
 	wg.Wait()
 }
 
+// serveInspected runs the inspecting proxy over one client connection. Each
+// request's head — and, up to MaxBodyBytes, its body — is reassembled and
+// inspected before any of it is forwarded, and the loop follows the HTTP/1.1
+// framing (Content-Length or chunked) to the next request, so every request on
+// a keep-alive connection is inspected, not just the first. The wire bytes are
+// replayed verbatim (byte-preserving). A stream that isn't HTTP/1.x, or whose
+// head overruns maxHeadBytes, gets the PR-2 treatment: what arrived is
+// inspected once and the rest is piped opaquely. So does everything after an
+// inspected CONNECT or Connection: Upgrade request (a WebSocket handshake).
+//
+// Responses are piped back as they come, unparsed. A refusal mid-connection
+// writes the 403/429 and closes both sides, which is sound for HTTP/1.1
+// clients that wait for a response before sending the next request.
+func (p *TransparentProxy) serveInspected(ctx context.Context, client net.Conn, orig string) {
+	src := HostOf(client.RemoteAddr().String())
+	br := bufio.NewReaderSize(client, 4096)
+	cw := &lockedWriter{w: client} // shared with the response pipe
+	var (
+		upstream net.Conn
+		respDone chan struct{}
+	)
+	defer func() {
+		if upstream != nil {
+			_ = upstream.Close()
+			<-respDone
+		}
+	}()
+	// connect dials the upstream when the first request passes and starts
+	// piping its responses back.
+	connect := func() bool {
+		if upstream != nil {
+			return true
+		}
+		if upstream = p.dialUpstream(ctx, orig); upstream == nil {
+			return false
+		}
+		respDone = make(chan struct{})
+		go func() {
+			defer close(respDone)
+			_, _ = io.Copy(cw, upstream)
+			closeWrite(client)
+		}()
+		return true
+	}
+
+	for {
+		head, complete, _ := readRequestHead(br, maxHeadBytes)
+		if len(head) == 0 {
+			break // the client is done sending
+		}
+		f, ferr := parseFraming(head)
+		if !complete || !f.http {
+			if p.judge(cw, src, orig, head) || !connect() {
+				return
+			}
+			if _, err := upstream.Write(head); err != nil {
+				log.Printf("[waf] steer: replay head to %s failed: %v", orig, err)
+				return
+			}
+			_, _ = io.Copy(upstream, br)
+			break
+		}
+		if ferr != nil {
+			log.Printf("[waf] steer: refusing request to %s: %v", orig, ferr)
+			_, _ = cw.Write(block400)
+			return
+		}
+
+		// Read the body's inspectable prefix, keeping its wire bytes to replay.
+		// The tee is switched to the upstream once they're flushed, so the
+		// rest of the body streams through while the reader finds its end.
+		tee := &switchWriter{w: &bytes.Buffer{}}
+		var (
+			body []byte
+			rest io.Reader
+		)
+		if f.hasBody() {
+			if f.expect100 {
+				// The client holds the body until it sees a 100; answer it here
+				// rather than forwarding the head before the body is inspected.
+				_, _ = cw.Write(continue100)
+			}
+			rest = f.bodyReader(br, tee)
+			if limit := p.maxBodyBytes(); limit > 0 {
+				var err error
+				if body, err = io.ReadAll(io.LimitReader(rest, int64(limit))); err != nil {
+					log.Printf("[waf] steer: read request body for %s: %v", orig, err)
+					if errors.Is(err, errAmbiguousFraming) {
+						// Nothing of this request has reached the upstream yet.
+						_, _ = cw.Write(block400)
+					}
+					return
+				}
+			}
+		}
+		req := head
+		if len(body) > 0 {
+			req = append(append(make([]byte, 0, len(head)+len(body)), head...), body...)
+		}
+		if p.judge(cw, src, orig, req) || !connect() {
+			return
+		}
+		if _, err := upstream.Write(head); err != nil {
+			log.Printf("[waf] steer: replay head to %s failed: %v", orig, err)
+			return
+		}
+		if rest != nil {
+			if _, err := upstream.Write(tee.w.(*bytes.Buffer).Bytes()); err != nil {
+				log.Printf("[waf] steer: replay body to %s failed: %v", orig, err)
+				return
+			}
+			tee.w = upstream
+			if _, err := io.Copy(io.Discard, rest); err != nil {
+				log.Printf("[waf] steer: forward request body to %s: %v", orig, err)
+				return
+			}
+		}
+		if f.upgrade {
+			// A WebSocket handshake or a CONNECT tunnel: what follows is not
+			// HTTP/1.1, and reading it for a request head would hold the
+			// client's frames until a blank line that never comes. Pipe the
+			// rest opaquely, as for a stream that was never HTTP.
+			_, _ = io.Copy(upstream, br)
+			break
+		}
+	}
+	// The client has finished sending: half-close the upstream so it sees the
+	// EOF, and let the responses drain.
+	if upstream != nil {
+		closeWrite(upstream)
+		<-respDone
+	}
+}
+
+// judge runs the Inspector over one request and reports whether the
+// connection was refused. A blocking verdict refuses only under
+// EnforceBlock; OnMatch/OnBlock see it either way.
+func (p *TransparentProxy) judge(w io.Writer, src, orig string, req []byte) bool {
+	var v Verdict
+	if ci, ok := p.Inspector.(ConnInspector); ok {
+		v = ci.InspectConn(src, orig, req)
+	} else {
+		v = p.Inspector.Inspect(req)
+	}
+	if len(v.Matches) > 0 && p.OnMatch != nil {
+		p.OnMatch(orig, v, v.Block && p.EnforceBlock)
+	}
+	if !v.Block {
+		return false
+	}
+	if p.OnBlock != nil {
+		p.OnBlock(orig, v, p.EnforceBlock)
+	}
+	if !p.EnforceBlock {
+		return false // observe-only: forward anyway, having audited the match
+	}
+	resp := block403 // refuse; don't forward the exploit
+	if v.RateLimited {
+		resp = block429
+	}
+	_, _ = w.Write(resp)
+	return true
+}
+
+func (p *TransparentProxy) maxBodyBytes() int {
+	if p.MaxBodyBytes == 0 {
+		return defaultMaxBodyBytes
+	}
+	return p.MaxBodyBytes
+}
+
+// dialUpstream dials the original destination, logging (and returning nil
+// on) a failure.
+func (p *TransparentProxy) dialUpstream(ctx context.Context, orig string) net.Conn {
+	dctx, cancel := context.WithTimeout(ctx, p.dialTimeout())
+	defer cancel()
+	upstream, err := p.dial(dctx, orig)
+	if err != nil {
+		log.Printf("[waf] steer: dial original dst %s failed: %v", orig, err)
+		return nil
+	}
+	return upstream
+}
+
 // pipe copies src→dst then half-closes dst's write side (so the peer sees EOF)
 // without closing the whole conn, which the other direction may still be using.
 func pipe(dst, src net.Conn) {
 	_, _ = io.Copy(dst, src)
-	if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+	closeWrite(dst)
+}
+
+func closeWrite(c net.Conn) {
+	if cw, ok := c.(interface{ CloseWrite() error }); ok {
 		_ = cw.CloseWrite()
 	}
 }
+
+// lockedWriter serializes the response pipe's writes with the proxy's own
+// (100 Continue, a refusal) on the client connection.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(b)
+}
+
+// switchWriter is a body tee whose destination moves from the replay buffer
+// to the upstream once the inspected prefix has been forwarded.
+type switchWriter struct{ w io.Writer }
+
+func (s *switchWriter) Write(b []byte) (int, error) { return s.w.Write(b) }
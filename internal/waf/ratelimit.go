@@ -0,0 +1,86 @@
+package waf
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateSweepInterval is how often the limiter drops buckets that have refilled
+// to capacity — an idle client's bucket is indistinguishable from a new one,
+// so forgetting it bounds memory by the set of recently active clients.
+const rateSweepInterval = time.Minute
+
+// rateKey scopes a token bucket to one rate rule and one client.
+type rateKey struct {
+	rule uint16
+	src  string
+}
+
+// rateBucket is a token bucket refilled at rpm tokens a minute up to burst.
+type rateBucket struct {
+	tokens   float64
+	refilled time.Time
+	rpm      float64
+	burst    float64
+}
+
+// refill tops the bucket up for the time since it was last drawn from. The
+// rule's current limits apply, so an edited rule takes effect on live buckets.
+func (b *rateBucket) refill(rpm, burst int, now time.Time) {
+	b.rpm, b.burst = float64(rpm), float64(burst)
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.refilled).Minutes()*b.rpm)
+	b.refilled = now
+}
+
+// full reports whether the bucket would be at capacity by now.
+func (b *rateBucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.refilled).Minutes()*b.rpm >= b.burst
+}
+
+// rateLimiter holds the per-(rule, source IP) buckets behind rate_limit rules.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[rateKey]*rateBucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: map[rateKey]*rateBucket{}, now: time.Now}
+}
+
+// allow draws one token from the rule's bucket for src, reporting false when
+// the bucket is empty. A new bucket starts full.
+func (l *rateLimiter) allow(rule uint16, src string, rpm, burst int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.swept) >= rateSweepInterval {
+		for k, b := range l.buckets {
+			if b.full(now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.swept = now
+	}
+	k := rateKey{rule: rule, src: src}
+	b, ok := l.buckets[k]
+	if !ok {
+		b = &rateBucket{tokens: float64(burst), refilled: now}
+		l.buckets[k] = b
+	}
+	b.refill(rpm, burst, now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// size is the number of live buckets.
+func (l *rateLimiter) size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
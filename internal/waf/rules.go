@@ -0,0 +1,345 @@
+package waf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// OperatorRuleIDBase is the first id handed to an operator rule. The built-in
+// signatures use small ids, so an id in the audit says which set matched.
+const OperatorRuleIDBase = 1000
+
+// MaxRuleID is the largest rule id. Ids are uint16 in a Verdict and in the
+// audit row, so a larger one cannot be represented without aliasing another
+// rule's.
+const MaxRuleID = 1<<16 - 1
+
+// Target is the part of the request a rule matches.
+type Target string
+
+const (
+	TargetMethod Target = "method"
+	TargetPath   Target = "path"
+	TargetQuery  Target = "query"
+	TargetHeader Target = "header" // one named header, every value of it
+	TargetBody   Target = "body"   // the inspected prefix of the request body
+)
+
+// MatchKind is how a rule's pattern is applied.
+type MatchKind string
+
+const (
+	// MatchLiteral is a case-insensitive substring match.
+	MatchLiteral MatchKind = "literal"
+	// MatchRegex is an RE2 regular expression, applied as written (use (?i)
+	// for case-insensitivity).
+	MatchRegex MatchKind = "regex"
+)
+
+// Action is what a match does to the connection.
+type Action string
+
+const (
+	ActionBlock Action = "block" // 403 when enforcement is armed
+	ActionLog   Action = "log"   // audit + event only, always forwarded
+	// ActionRateLimit counts matching requests per client source IP against
+	// a token bucket and refuses (429 when enforcement is armed) only those
+	// that find it empty.
+	ActionRateLimit Action = "rate_limit"
+)
+
+// Rule is one operator-managed WAF rule. Tenants is the enable list: empty
+// applies the rule to every tenant's containers, otherwise only to those of
+// the named tenants.
+//
+// A rate_limit rule may leave Target and Pattern empty to count every
+// request. Its bucket refills at RequestsPerMinute and holds Burst tokens
+// (0 → RequestsPerMinute).
+type Rule struct {
+	ID      uint16
+	Name    string
+	Target  Target
+	Header  string // the header name, for TargetHeader
+	Match   MatchKind
+	Pattern string
+	Action  Action
+	Tenants []string
+
+	RequestsPerMinute int
+	Burst             int
+}
+
+// Match is one rule that matched a request.
+type Match struct {
+	RuleID   uint16
+	RuleName string
+	Action   Action
+	Target   Target
+}
+
+// ValidateRule checks a rule the way CompileRules will, so the control plane
+// can reject a bad rule before storing it.
+func ValidateRule(r Rule) error {
+	_, err := compileRule(r)
+	return err
+}
+
+type compiledRule struct {
+	Rule
+	header  string // canonical
+	literal string // lowercased, for MatchLiteral
+	re      *regexp.Regexp
+	tenants map[string]bool
+	all     bool // a rate rule with no matcher: counts every request
+}
+
+func compileRule(r Rule) (*compiledRule, error) {
+	if strings.TrimSpace(r.Name) == "" {
+		return nil, errors.New("rule name is required")
+	}
+	c := &compiledRule{Rule: r}
+	if err := c.compileLimits(); err != nil {
+		return nil, err
+	}
+	if r.Action == ActionRateLimit && r.Target == "" && r.Pattern == "" {
+		c.all = true
+		c.compileTenants()
+		return c, nil
+	}
+	if r.Pattern == "" {
+		return nil, fmt.Errorf("rule %q: pattern is required", r.Name)
+	}
+	switch r.Target {
+	case TargetMethod, TargetPath, TargetQuery, TargetBody:
+		if r.Header != "" {
+			return nil, fmt.Errorf("rule %q: header is only valid with target %q", r.Name, TargetHeader)
+		}
+	case TargetHeader:
+		if strings.TrimSpace(r.Header) == "" {
+			return nil, fmt.Errorf("rule %q: target %q needs a header name", r.Name, TargetHeader)
+		}
+		c.header = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(r.Header))
+	default:
+		return nil, fmt.Errorf("rule %q: unknown target %q (want method, path, query, header or body)", r.Name, r.Target)
+	}
+	switch r.Match {
+	case MatchLiteral:
+		c.literal = strings.ToLower(r.Pattern)
+	case MatchRegex:
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		c.re = re
+	default:
+		return nil, fmt.Errorf("rule %q: unknown match %q (want literal or regex)", r.Name, r.Match)
+	}
+	c.compileTenants()
+	return c, nil
+}
+
+// compileLimits checks the action and, for a rate rule, its bucket.
+func (c *compiledRule) compileLimits() error {
+	switch c.Action {
+	case ActionBlock, ActionLog:
+		if c.RequestsPerMinute != 0 || c.Burst != 0 {
+			return fmt.Errorf("rule %q: requests per minute and burst are only valid with action %q", c.Name, ActionRateLimit)
+		}
+	case ActionRateLimit:
+		if c.RequestsPerMinute <= 0 {
+			return fmt.Errorf("rule %q: action %q needs requests per minute > 0", c.Name, ActionRateLimit)
+		}
+		if c.Burst < 0 {
+			return fmt.Errorf("rule %q: burst must be >= 0", c.Name)
+		}
+		if c.Burst == 0 {
+			c.Burst = c.RequestsPerMinute
+		}
+	default:
+		return fmt.Errorf("rule %q: unknown action %q (want block, log or rate_limit)", c.Name, c.Action)
+	}
+	return nil
+}
+
+func (c *compiledRule) compileTenants() {
+	if len(c.Tenants) > 0 {
+		c.tenants = make(map[string]bool, len(c.Tenants))
+		for _, t := range c.Tenants {
+			c.tenants[t] = true
+		}
+	}
+}
+
+func (c *compiledRule) matches(req *Request) bool {
+	if c.all {
+		return true
+	}
+	var values []string
+	switch c.Target {
+	case TargetMethod:
+		values = []string{req.Method}
+	case TargetPath:
+		values = []string{req.Path}
+	case TargetQuery:
+		values = []string{req.Query}
+	case TargetHeader:
+		values = req.Headers[c.header]
+	case TargetBody:
+		values = []string{req.Body}
+	}
+	for _, v := range values {
+		if c.re != nil {
+			if c.re.MatchString(v) {
+				return true
+			}
+		} else if strings.Contains(strings.ToLower(v), c.literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleSet is a compiled, immutable set of rules. Build one with CompileRules
+// and hand it to a RuleEngine.
+type RuleSet struct {
+	rules []*compiledRule
+}
+
+// CompileRules compiles rules in order (evaluation order is list order). A
+// single bad rule fails the whole set, so a reload never half-applies.
+func CompileRules(rules []Rule) (*RuleSet, error) {
+	rs := &RuleSet{}
+	for _, r := range rules {
+		c, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		rs.rules = append(rs.rules, c)
+	}
+	return rs, nil
+}
+
+// Len is the number of rules in the set.
+func (rs *RuleSet) Len() int { return len(rs.rules) }
+
+// Evaluate returns every rule enabled for tenant that matches req. An empty
+// tenant (a destination the daemon can't attribute) gets only the rules that
+// apply to every tenant.
+// A rate rule is reported whenever its matcher matches; whether the client is
+// over the limit is the RuleEngine's call.
+func (rs *RuleSet) Evaluate(tenant string, req *Request) []Match {
+	var out []Match
+	for _, c := range rs.evaluate(tenant, req) {
+		out = append(out, c.match())
+	}
+	return out
+}
+
+func (rs *RuleSet) evaluate(tenant string, req *Request) []*compiledRule {
+	var out []*compiledRule
+	for _, c := range rs.rules {
+		if c.tenants != nil && !c.tenants[tenant] {
+			continue
+		}
+		if c.matches(req) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (c *compiledRule) match() Match {
+	return Match{RuleID: c.ID, RuleName: c.Name, Action: c.Action, Target: c.Target}
+}
+
+// RuleEngine is the Inspector over an operator rule set. The set is swapped
+// atomically on reload: a connection evaluates whichever set was current
+// when its head arrived, and no connection is dropped or re-inspected by a
+// reload. Base, if set, runs first (the built-in signatures), so operator
+// rules add to the curated set rather than replace it.
+type RuleEngine struct {
+	Base Inspector
+
+	// TenantOf maps a connection's original destination (host:port) to the
+	// tenant owning it, for the rules' enable lists. Nil or "" → unknown.
+	TenantOf func(orig string) string
+
+	set     atomic.Pointer[RuleSet]
+	limiter *rateLimiter
+}
+
+// NewRuleEngine builds an engine with an empty rule set on top of base (nil
+// for operator rules only).
+func NewRuleEngine(base Inspector) *RuleEngine {
+	e := &RuleEngine{Base: base, limiter: newRateLimiter()}
+	e.set.Store(&RuleSet{})
+	return e
+}
+
+// Swap installs a new rule set. Safe to call while connections are being
+// inspected.
+func (e *RuleEngine) Swap(rs *RuleSet) {
+	if rs == nil {
+		rs = &RuleSet{}
+	}
+	e.set.Store(rs)
+}
+
+// Rules returns the current rule set.
+func (e *RuleEngine) Rules() *RuleSet { return e.set.Load() }
+
+// Inspect evaluates a request with no source or destination, so only rules
+// that apply to every tenant are considered and rate rules never fire.
+func (e *RuleEngine) Inspect(req []byte) Verdict {
+	return e.InspectConn("", "", req)
+}
+
+// InspectConn evaluates the built-in set and then the operator rules enabled
+// for the destination's tenant. A matching rate rule draws from src's bucket
+// and counts as a match only when the bucket is empty; with no src it is
+// skipped. The verdict blocks when any match's action is block or
+// rate_limit, naming the first such rule; Matches lists every match for the
+// audit.
+func (e *RuleEngine) InspectConn(src, orig string, req []byte) Verdict {
+	var v Verdict
+	if e.Base != nil {
+		if bv := e.Base.Inspect(req); bv.Block {
+			v = bv
+			v.Matches = append(v.Matches, Match{RuleID: bv.RuleID, RuleName: bv.RuleName, Action: ActionBlock})
+		}
+	}
+	if e.TenantOf != nil && orig != "" {
+		v.Tenant = e.TenantOf(orig)
+	}
+	parsed, ok := ParseRequest(req)
+	if !ok {
+		return v
+	}
+	for _, c := range e.set.Load().evaluate(v.Tenant, &parsed) {
+		if c.Action == ActionRateLimit {
+			if src == "" || e.limiter.allow(c.ID, src, c.RequestsPerMinute, c.Burst) {
+				continue
+			}
+		}
+		v.Matches = append(v.Matches, c.match())
+		if c.Action != ActionLog && !v.Block {
+			v.Block, v.RuleID, v.RuleName = true, c.ID, c.Name
+			v.RateLimited = c.Action == ActionRateLimit
+		}
+	}
+	return v
+}
+
+// HostOf strips the port from an original destination, for TenantOf
+// implementations keyed by container IP.
+func HostOf(orig string) string {
+	if host, _, err := net.SplitHostPort(orig); err == nil {
+		return host
+	}
+	return orig
+}
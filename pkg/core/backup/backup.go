@@ -1,16 +1,27 @@
-// Package backup implements logical (pg_dump) database backups for the
-// databases running inside Containarium containers, stored off the
-// database host.
+// Package backup implements logical database backups for the databases
+// running inside Containarium containers — Postgres (pg_dump), MySQL and
+// MariaDB (mysqldump / mariadb-dump) and Redis (RDB via BGSAVE) — stored
+// off the database host.
 //
 // Design (see docs/DB-BACKUP-OPERATIONS.md for the operator runbook and
 // the ISO 27001 A.8.13 control mapping):
 //
-//   - The dump is produced by running pg_dump *inside* the tenant's
-//     container (reaching the container's own Postgres over loopback),
-//     writing a compressed custom-format archive to the container's /tmp.
-//   - The archive is pulled to the daemon host (ReadFile), checksummed,
-//     and either kept in the host backup directory (LOCAL) or shipped to
-//     an object store and removed from local staging (GCS).
+//   - The dump is produced by running the engine's own dump tool *inside*
+//     the tenant's container (reaching the container's own server over
+//     loopback), writing a compressed archive to its stdout.
+//   - That stdout is streamed to the daemon host (ExecStream), through
+//     the checksum and into a staging file, and either kept in the host
+//     backup directory (LOCAL) or shipped to an object store and removed
+//     from local staging (GCS, S3). Restore and verify stream the staged
+//     file back into the engine's restore step the same way, so daemon
+//     memory stays bounded whatever the dump size.
+//   - What is engine-specific — the dump, restore and scratch-restore
+//     commands — sits behind the engine interface (engine.go); staging,
+//     checksums, upload and the index are shared by every engine.
+//   - With a KMS configured, the archive is encrypted on the host as it
+//     streams in, under a per-backup data key the KMS wraps (encrypt.go);
+//     the checksum, the upload and the object store only ever see
+//     ciphertext.
 //   - Metadata is persisted as a small JSON sidecar per backup in the
 //     host backup directory, so ListBackups works even when the database
 //     being backed up is down — the index never shares a failure domain
@@ -21,20 +32,21 @@
 package backup
 
 import (
+	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
-)
 
-// EnginePostgres is the only database engine supported in v1.
-const EnginePostgres = "postgres"
+	"github.com/footprintai/containarium/pkg/core/secrets"
+)
 
 // Destination is where a dump is stored off-host. Kept as a string in the
 // core so the package stays free of the pb dependency; the server maps it
@@ -44,6 +56,7 @@ type Destination string
 const (
 	DestLocal Destination = "local"
 	DestGCS   Destination = "gcs"
+	DestS3    Destination = "s3"
 )
 
 // ContainerOps is the slice of *container.Manager the backup manager
@@ -53,17 +66,18 @@ type ContainerOps interface {
 	// Exec runs a command inside the container, discarding output.
 	Exec(containerName string, command []string) error
 	// ExecWithOutput runs a command inside the container and returns
-	// stdout/stderr (pg_dump/pg_restore report errors on stderr).
+	// stdout/stderr (the dump and restore tools report errors on stderr).
 	ExecWithOutput(containerName string, command []string) (string, string, error)
-	// ReadFile pulls a file from inside the container into host memory.
-	ReadFile(containerName, path string) ([]byte, error)
-	// WriteFile pushes content to a path inside the container.
-	WriteFile(containerName, path string, content []byte, mode string) error
+	// ExecStream runs a command inside the container with stdin fed from
+	// stdin (nil for none) and stdout copied to stdout as it is produced,
+	// returning stderr. Dump and restore bytes flow through it, so they
+	// never accumulate in daemon memory.
+	ExecStream(containerName string, command []string, stdin io.Reader, stdout io.Writer) (string, error)
 }
 
 // Uploader ships a staged local file to and from an off-host object
-// store. The GCS implementation shells out to the host's `gcloud`; tests
-// supply a fake.
+// store. The GCS implementation shells out to the host's `gcloud`, the S3
+// one speaks the S3 API directly; tests supply a fake.
 type Uploader interface {
 	Upload(localPath, destURI string) error
 	Download(destURI, localPath string) error
@@ -81,24 +95,38 @@ type Record struct {
 	SHA256      string      `json:"sha256"`
 	Destination Destination `json:"destination"`
 	Location    string      `json:"location"`
-	Engine      string      `json:"engine"`
+	// Engine is the database engine the dump was taken with (EnginePostgres,
+	// EngineMySQL, EngineRedis). Restore and verify dispatch on it.
+	Engine string `json:"engine"`
 
 	// LastVerification is the outcome of the most recent restore test,
 	// nil until the backup has been verified. SHA256 proves the bytes
 	// are intact; only this proves the dump is restorable (#1159).
 	LastVerification *Verification `json:"last_verification,omitempty"`
 
-	// RelationCount is the number of user relations in the source
-	// database at dump time — the manifest a restore test compares the
-	// restored schema against. Nil when no manifest was captured (a
+	// RelationCount is the number of user objects in the source
+	// database at dump time — user relations for Postgres, base tables
+	// for MySQL, keys for Redis — the manifest a restore test compares
+	// the restored data against. Nil when no manifest was captured (a
 	// backup taken before verification existed, or a source that could
 	// not be queried).
 	RelationCount *int64 `json:"relation_count,omitempty"`
+
+	// Scheduled marks a backup taken by a Schedule rather than on
+	// demand. Only scheduled backups are subject to retention pruning.
+	Scheduled bool `json:"scheduled,omitempty"`
+
+	// Encryption is how the archive was encrypted, with its wrapped data
+	// key; nil for a plaintext archive (no KMS configured when it was
+	// taken). SizeBytes and SHA256 describe the stored, encrypted bytes.
+	Encryption *Encryption `json:"encryption,omitempty"`
 }
 
-// PgConn carries the connection parameters pg_dump / pg_restore use
-// *inside the container*. Password is passed to the child via the
-// PGPASSWORD environment variable, never on argv.
+// PgConn carries the connection parameters the dump and restore tools use
+// *inside the container*. Named for the first engine and shared by all of
+// them; unset fields take the engine's own defaults. Password is passed
+// to the child via the engine's environment variable (PGPASSWORD,
+// MYSQL_PWD, REDISCLI_AUTH), never on argv.
 type PgConn struct {
 	Database string
 	User     string
@@ -107,42 +135,60 @@ type PgConn struct {
 	Port     int
 }
 
-func (c PgConn) withDefaults() PgConn {
-	if c.User == "" {
-		c.User = "postgres"
-	}
-	if c.Host == "" {
-		c.Host = "127.0.0.1"
-	}
-	if c.Port == 0 {
-		c.Port = 5432
-	}
-	return c
-}
-
 // Manager orchestrates backups. One per daemon.
 type Manager struct {
-	ops      ContainerOps
-	uploader Uploader // may be nil → GCS destinations are rejected
-	dir      string   // host backup directory (dumps for LOCAL + sidecar index for all)
-	clock    func() time.Time
+	ops       ContainerOps
+	uploaders map[Destination]Uploader // missing entry → that destination is rejected
+	dir       string                   // host backup directory (dumps for LOCAL + sidecar index for all)
+	kms       secrets.KMSClient        // nil → new archives are stored unencrypted
+	clock     func() time.Time
 }
 
 // NewManager constructs a backup manager. dir is the host directory where
 // dumps (for LOCAL) and the JSON index (for all destinations) are kept.
-// uploader may be nil when no object store is configured; GCS backups
-// then return a clear error.
+// uploader is the GCS uploader and may be nil when gcloud is not
+// configured; GCS backups then return a clear error. Other object stores
+// are added with SetUploader.
 func NewManager(ops ContainerOps, uploader Uploader, dir string) *Manager {
-	return &Manager{ops: ops, uploader: uploader, dir: dir, clock: time.Now}
+	m := &Manager{ops: ops, uploaders: map[Destination]Uploader{}, dir: dir, clock: time.Now}
+	if uploader != nil {
+		m.uploaders[DestGCS] = uploader
+	}
+	return m
+}
+
+// SetUploader registers the uploader for an off-host destination (DestGCS
+// or DestS3). Call before serving requests; the map is not locked.
+func (m *Manager) SetUploader(d Destination, u Uploader) {
+	m.uploaders[d] = u
+}
+
+// bucketScheme is the URI scheme each off-host destination's bucket must
+// use.
+var bucketScheme = map[Destination]string{
+	DestGCS: "gs://",
+	DestS3:  "s3://",
 }
 
 // CreateOptions parameterizes a backup.
 type CreateOptions struct {
 	Username      string
 	ContainerName string
+	Engine        string // EnginePostgres, EngineMySQL or EngineRedis; empty means EnginePostgres
 	Conn          PgConn
 	Destination   Destination
 	GCSBucket     string // e.g. "gs://my-backups/pg" — required for DestGCS
+	S3Bucket      string // e.g. "s3://my-backups/pg" — required for DestS3
+	Progress      ProgressFunc
+	Scheduled     bool // taken by a Schedule; see Record.Scheduled
+}
+
+// bucket returns the bucket/prefix URI for the chosen destination.
+func (o CreateOptions) bucket() string {
+	if o.Destination == DestS3 {
+		return o.S3Bucket
+	}
+	return o.GCSBucket
 }
 
 // RestoreOptions parameterizes a restore.
@@ -150,7 +196,8 @@ type RestoreOptions struct {
 	ID            string
 	ContainerName string
 	Conn          PgConn // Database empty → restore into the record's database
-	Clean         bool   // pass --clean --if-exists to pg_restore
+	Clean         bool   // drop existing objects first; required for Redis, which always replaces the dataset
+	Progress      ProgressFunc
 }
 
 func (m *Manager) now() time.Time {
@@ -163,22 +210,29 @@ func (m *Manager) now() time.Time {
 // Create dumps the container's database and stores it at the chosen
 // destination, returning the committed record.
 func (m *Manager) Create(opts CreateOptions) (*Record, error) {
-	conn := opts.Conn.withDefaults()
+	eng, engineName, err := engineFor(opts.Engine)
+	if err != nil {
+		return nil, err
+	}
+	conn := eng.defaults(opts.Conn)
 	if conn.Database == "" {
 		return nil, fmt.Errorf("database is required")
 	}
+	if err := eng.checkDatabase(conn.Database); err != nil {
+		return nil, err
+	}
 	if opts.ContainerName == "" {
 		return nil, fmt.Errorf("container name is required")
 	}
 	switch opts.Destination {
 	case DestLocal:
 		// ok
-	case DestGCS:
-		if m.uploader == nil {
-			return nil, fmt.Errorf("gcs destination requested but no object-store uploader is configured on this daemon")
+	case DestGCS, DestS3:
+		if m.uploaders[opts.Destination] == nil {
+			return nil, fmt.Errorf("%s destination requested but no object-store uploader is configured on this daemon", opts.Destination)
 		}
-		if !strings.HasPrefix(opts.GCSBucket, "gs://") {
-			return nil, fmt.Errorf("gcs_bucket must be a gs:// URI, got %q", opts.GCSBucket)
+		if scheme := bucketScheme[opts.Destination]; !strings.HasPrefix(opts.bucket(), scheme) {
+			return nil, fmt.Errorf("%s_bucket must be a %s URI, got %q", opts.Destination, scheme, opts.bucket())
 		}
 	default:
 		return nil, fmt.Errorf("unknown or unspecified destination %q", opts.Destination)
@@ -191,73 +245,109 @@ func (m *Manager) Create(opts CreateOptions) (*Record, error) {
 	if err := validateBackupID(id); err != nil {
 		return nil, fmt.Errorf("cannot derive a safe backup id: %w", err)
 	}
-	inContainerPath := "/tmp/containarium-backup-" + id + ".dump"
-
-	// 1. Dump inside the container (custom format = compressed + selective
-	//    restore). Password travels via PGPASSWORD, not argv.
-	dumpScript := fmt.Sprintf(
-		"pg_dump -h %s -p %d -U %s -d %s -Fc -f %s",
-		shellQuote(conn.Host), conn.Port, shellQuote(conn.User),
-		shellQuote(conn.Database), shellQuote(inContainerPath),
-	)
-	if _, stderr, err := m.ops.ExecWithOutput(opts.ContainerName, wrapPg(conn.Password, dumpScript)); err != nil {
-		return nil, fmt.Errorf("pg_dump failed: %w: %s", err, strings.TrimSpace(stderr))
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	// Wrap the archive key before dumping, so an unreachable KMS fails
+	// the backup up front instead of after a long dump.
+	var aead cipher.AEAD
+	var encryption *Encryption
+	if m.kms != nil {
+		if aead, encryption, err = m.newDataKey(); err != nil {
+			return nil, err
+		}
 	}
 
-	// 2. Pull the archive to the host, then clean up the in-container copy.
-	data, err := m.ops.ReadFile(opts.ContainerName, inContainerPath)
+	// 1. Stream the dump tool's stdout through the checksum into a
+	//    staging file — through the archive cipher first, when there is
+	//    one, so only ciphertext is staged. The password travels via the
+	//    environment, not argv. The staging name is hidden and only
+	//    renamed into place once the dump completes, so a dump cut off
+	//    mid-stream never looks like a backup.
+	dumpTool, _ := eng.tools()
+	localDump := filepath.Join(m.dir, id+".dump")
+	staging := filepath.Join(m.dir, "."+id+".dump.partial")
+	f, err := os.OpenFile(staging, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) // #nosec G304 -- staging is under m.dir with a validated id
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage dump: %w", err)
+	}
+	h := sha256.New()
+	pw := newProgressWriter(opts.Progress, id, PhaseDump, 0)
+	stored := newProgressWriter(nil, id, PhaseDump, 0)
+	var sink io.Writer = io.MultiWriter(f, h, stored)
+	var ew *encryptWriter
+	if aead != nil {
+		if ew, err = newEncryptWriter(sink, aead, id); err != nil {
+			_ = f.Close()
+			_ = os.Remove(staging)
+			return nil, fmt.Errorf("failed to stage dump: %w", err)
+		}
+		sink = ew
+	}
+	stderr, err := m.ops.ExecStream(opts.ContainerName, eng.dumpCmd(conn), nil, io.MultiWriter(sink, pw))
+	if ew != nil && err == nil {
+		if cerr := ew.Close(); cerr != nil {
+			err = fmt.Errorf("failed to stage dump: %w", cerr)
+			stderr = ""
+		}
+	}
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to stage dump: %w", cerr)
+		stderr = ""
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to read dump from container: %w", err)
+		_ = os.Remove(staging)
+		return nil, fmt.Errorf("%s failed: %w: %s", dumpTool, err, strings.TrimSpace(stderr))
+	}
+	pw.done()
+	if pw.n == 0 {
+		_ = os.Remove(staging)
+		return nil, fmt.Errorf("%s produced an empty archive (check database name and credentials)", dumpTool)
 	}
-	_ = m.ops.Exec(opts.ContainerName, []string{"rm", "-f", inContainerPath})
-	if len(data) == 0 {
-		return nil, fmt.Errorf("pg_dump produced an empty archive (check database name and credentials)")
+	if err := os.Rename(staging, localDump); err != nil {
+		_ = os.Remove(staging)
+		return nil, fmt.Errorf("failed to stage dump: %w", err)
 	}
 
-	// Record the source's user-relation count as a manifest for later
+	// Record the source's user-object count as a manifest for later
 	// restore tests to compare against. Best-effort: a source that
 	// cannot be queried still produced a valid dump, so a failure here
 	// leaves the manifest unset rather than failing the backup.
 	var relationCount *int64
-	if n, err := m.countUserRelations(opts.ContainerName, conn, conn.Database); err != nil {
-		log.Printf("[backup] could not record relation manifest for %s/%s: %v (verification will have nothing to compare against)",
-			opts.Username, conn.Database, err)
+	if n, err := eng.countObjects(m.ops, opts.ContainerName, conn, conn.Database); err != nil {
+		log.Printf("[backup] could not record %s manifest for %s/%s: %v (verification will have nothing to compare against)",
+			eng.manifest().noun, opts.Username, conn.Database, err)
 	} else {
 		relationCount = &n
 	}
 
-	sum := sha256.Sum256(data)
 	record := &Record{
 		ID:            id,
 		Username:      opts.Username,
 		Database:      conn.Database,
 		CreatedAt:     m.now().UTC(),
-		SizeBytes:     int64(len(data)),
-		SHA256:        hex.EncodeToString(sum[:]),
+		SizeBytes:     stored.n,
+		SHA256:        hex.EncodeToString(h.Sum(nil)),
 		Destination:   opts.Destination,
-		Engine:        EnginePostgres,
+		Engine:        engineName,
 		RelationCount: relationCount,
+		Scheduled:     opts.Scheduled,
+		Encryption:    encryption,
 	}
 
-	if err := os.MkdirAll(m.dir, 0o700); err != nil {
-		return nil, fmt.Errorf("failed to create backup directory: %w", err)
-	}
-	localDump := filepath.Join(m.dir, id+".dump")
-	if err := os.WriteFile(localDump, data, 0o600); err != nil {
-		return nil, fmt.Errorf("failed to stage dump: %w", err)
-	}
-
-	// 3. For off-host destinations, ship the staged dump and drop the
+	// 2. For off-host destinations, ship the staged dump and drop the
 	//    local copy (the sidecar index stays local).
 	switch opts.Destination {
 	case DestLocal:
 		record.Location = localDump
-	case DestGCS:
-		destURI := strings.TrimRight(opts.GCSBucket, "/") + "/" + id + ".dump"
-		if err := m.uploader.Upload(localDump, destURI); err != nil {
+	case DestGCS, DestS3:
+		destURI := strings.TrimRight(opts.bucket(), "/") + "/" + id + ".dump"
+		report(opts.Progress, Progress{BackupID: id, Phase: PhaseUpload, Total: stored.n})
+		if err := m.uploaders[opts.Destination].Upload(localDump, destURI); err != nil {
 			_ = os.Remove(localDump)
 			return nil, fmt.Errorf("failed to upload dump to %s: %w", destURI, err)
 		}
+		report(opts.Progress, Progress{BackupID: id, Phase: PhaseUpload, Bytes: stored.n, Total: stored.n, Done: true})
 		_ = os.Remove(localDump)
 		record.Location = destURI
 	}
@@ -268,36 +358,28 @@ func (m *Manager) Create(opts CreateOptions) (*Record, error) {
 	return record, nil
 }
 
-// ListDatabases enumerates the non-template databases visible inside the
-// container's Postgres — the same connection info used for pg_dump/
-// pg_restore, just pointed at the always-present "postgres" maintenance
-// database to run a catalog query instead of a dump. Used by CreateAll so
-// a backup never requires the caller to already know a database name (#954).
-func (m *Manager) ListDatabases(containerName string, conn PgConn) ([]string, error) {
-	conn = conn.withDefaults()
+// ListDatabases enumerates the databases a backup of the container
+// should cover, using the same connection info as the dump: the
+// non-template databases for Postgres, the non-system schemas for MySQL,
+// and the single whole-instance dataset for Redis. engineName empty means
+// EnginePostgres. Used by CreateAll so a backup never requires the caller
+// to already know a database name (#954).
+func (m *Manager) ListDatabases(engineName, containerName string, conn PgConn) ([]string, error) {
+	eng, _, err := engineFor(engineName)
+	if err != nil {
+		return nil, err
+	}
 	if containerName == "" {
 		return nil, fmt.Errorf("container name is required")
 	}
-	const query = "SELECT datname FROM pg_database WHERE NOT datistemplate ORDER BY datname;"
-	script := fmt.Sprintf(
-		"psql -h %s -p %d -U %s -d postgres -Atc %s",
-		shellQuote(conn.Host), conn.Port, shellQuote(conn.User), shellQuote(query),
-	)
-	stdout, stderr, err := m.ops.ExecWithOutput(containerName, wrapPg(conn.Password, script))
+	dbs, err := eng.listDatabases(m.ops, containerName, eng.defaults(conn))
 	if err != nil {
-		return nil, fmt.Errorf("list databases: %w: %s", err, strings.TrimSpace(stderr))
-	}
-	var dbs []string
-	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			dbs = append(dbs, line)
-		}
+		return nil, fmt.Errorf("list databases: %w", err)
 	}
 	return dbs, nil
 }
 
-// CreateAll backs up every non-template database in the container — the
+// CreateAll backs up every database ListDatabases finds — the
 // default, no-guessing path (#954): the caller doesn't need to already
 // know a database name, and a scheduled backup can't fail because of a
 // typo'd one, since no name is ever supplied. opts.Conn.Database is
@@ -312,7 +394,7 @@ func (m *Manager) ListDatabases(containerName string, conn PgConn) ([]string, er
 // can surface "7 of 8 databases backed up, orders_db failed: <reason>"
 // instead of an all-or-nothing result.
 func (m *Manager) CreateAll(opts CreateOptions) ([]*Record, []error) {
-	dbs, err := m.ListDatabases(opts.ContainerName, opts.Conn)
+	dbs, err := m.ListDatabases(opts.Engine, opts.ContainerName, opts.Conn)
 	if err != nil {
 		return nil, []error{fmt.Errorf("list databases: %w", err)}
 	}
@@ -395,11 +477,12 @@ func (m *Manager) Delete(id string) error {
 		return err
 	}
 	switch r.Destination {
-	case DestGCS:
-		if m.uploader == nil {
-			return fmt.Errorf("cannot delete GCS object: no object-store uploader configured")
+	case DestGCS, DestS3:
+		u := m.uploaders[r.Destination]
+		if u == nil {
+			return fmt.Errorf("cannot delete %s object: no object-store uploader configured", r.Destination)
 		}
-		if err := m.uploader.Delete(r.Location); err != nil {
+		if err := u.Delete(r.Location); err != nil {
 			return fmt.Errorf("failed to delete %s: %w", r.Location, err)
 		}
 	default:
@@ -422,73 +505,123 @@ func (m *Manager) Restore(opts RestoreOptions) error {
 	if err != nil {
 		return err
 	}
-
-	// Fetch the dump bytes to the host and integrity-check them before
-	// we overwrite a live database.
-	data, err := m.fetchDump(r)
+	eng, _, err := engineFor(r.Engine)
 	if err != nil {
 		return err
 	}
-
-	conn := opts.Conn.withDefaults()
-	if conn.Database == "" {
-		conn.Database = r.Database
+	if err := eng.checkRestore(opts.Clean); err != nil {
+		return err
+	}
+	aead, err := m.recordKey(r)
+	if err != nil {
+		return err
 	}
 
-	inContainerPath := "/tmp/containarium-restore-" + r.ID + ".dump"
-	if err := m.ops.WriteFile(opts.ContainerName, inContainerPath, data, "0600"); err != nil {
-		return fmt.Errorf("failed to push dump into container: %w", err)
+	// Stage the dump on the host and integrity-check it before we
+	// overwrite a live database.
+	dump, cleanup, err := m.openDump(r, aead, opts.Progress)
+	if err != nil {
+		return err
 	}
-	defer func() { _ = m.ops.Exec(opts.ContainerName, []string{"rm", "-f", inContainerPath}) }()
+	defer cleanup()
 
-	cleanFlag := ""
-	if opts.Clean {
-		cleanFlag = "--clean --if-exists "
+	if opts.Conn.Database == "" {
+		opts.Conn.Database = r.Database
 	}
-	restoreScript := fmt.Sprintf(
-		"pg_restore -h %s -p %d -U %s -d %s %s%s",
-		shellQuote(conn.Host), conn.Port, shellQuote(conn.User),
-		shellQuote(conn.Database), cleanFlag, shellQuote(inContainerPath),
-	)
-	if _, stderr, err := m.ops.ExecWithOutput(opts.ContainerName, wrapPg(conn.Password, restoreScript)); err != nil {
-		return fmt.Errorf("pg_restore failed: %w: %s", err, strings.TrimSpace(stderr))
+	conn := eng.defaults(opts.Conn)
+
+	_, restoreTool := eng.tools()
+	pr := newProgressReader(dump, opts.Progress, r.ID, PhaseRestore, r.SizeBytes)
+	if stderr, err := eng.restore(m.ops, opts.ContainerName, conn, plaintext(pr, aead, r.ID), opts.Clean); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", restoreTool, err, strings.TrimSpace(stderr))
 	}
+	pr.done()
 	return nil
 }
 
-// fetchDump pulls a record's dump bytes to the host (downloading from the
-// object store for off-host destinations) and verifies them against the
-// recorded SHA-256. Shared by Restore and Verify so the integrity gate
-// cannot drift between the two paths.
-func (m *Manager) fetchDump(r *Record) ([]byte, error) {
-	var data []byte
-	var err error
+// openDump returns a record's dump as an open host file positioned at
+// the start, after checking it against the recorded SHA-256 — so the
+// integrity gate runs before a single byte reaches the engine's restore. Off-host
+// dumps are downloaded to a staging file first; cleanup closes the file
+// and removes any such staging copy. Shared by Restore and Verify so the
+// gate cannot drift between the two paths.
+//
+// For an encrypted archive (aead non-nil, from recordKey) the same pass
+// also authenticates every chunk, so a tampered or truncated archive is
+// refused before the restore starts rather than failing halfway through
+// it. The caller decrypts the returned file again with plaintext.
+//
+// Hashing reads the file once more, from disk rather than memory: the
+// price of verifying up front while keeping memory bounded.
+func (m *Manager) openDump(r *Record, aead cipher.AEAD, progress ProgressFunc) (*os.File, func(), error) {
+	path := r.Location
+	removeAfter := false
 	switch r.Destination {
-	case DestGCS:
-		if m.uploader == nil {
-			return nil, fmt.Errorf("cannot read GCS backup: no object-store uploader configured")
+	case DestGCS, DestS3:
+		u := m.uploaders[r.Destination]
+		if u == nil {
+			return nil, nil, fmt.Errorf("cannot read %s backup: no object-store uploader configured", r.Destination)
 		}
-		tmp := filepath.Join(m.dir, "."+r.ID+".restore.tmp")
-		if err := m.uploader.Download(r.Location, tmp); err != nil {
-			return nil, fmt.Errorf("failed to download %s: %w", r.Location, err)
+		path = filepath.Join(m.dir, "."+r.ID+".restore.tmp")
+		report(progress, Progress{BackupID: r.ID, Phase: PhaseDownload, Total: r.SizeBytes})
+		if err := u.Download(r.Location, path); err != nil {
+			_ = os.Remove(path)
+			return nil, nil, fmt.Errorf("failed to download %s: %w", r.Location, err)
 		}
-		data, err = os.ReadFile(tmp) // #nosec G304 -- tmp is filepath.Join(m.dir, ...) with a validated record id
-		_ = os.Remove(tmp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read downloaded dump: %w", err)
+		report(progress, Progress{BackupID: r.ID, Phase: PhaseDownload, Bytes: r.SizeBytes, Total: r.SizeBytes, Done: true})
+		removeAfter = true
+	}
+
+	f, err := os.Open(path) // #nosec G304 -- a record location written by Create, or a staging path under m.dir with a validated id
+	if err != nil {
+		if removeAfter {
+			_ = os.Remove(path)
 		}
-	default:
-		data, err = os.ReadFile(r.Location)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read dump %s: %w", r.Location, err)
+		return nil, nil, fmt.Errorf("failed to read dump %s: %w", path, err)
+	}
+	cleanup := func() {
+		_ = f.Close()
+		if removeAfter {
+			_ = os.Remove(path)
 		}
 	}
 
-	sum := sha256.Sum256(data)
-	if got := hex.EncodeToString(sum[:]); got != r.SHA256 {
-		return nil, fmt.Errorf("dump integrity check failed: sha256 %s != recorded %s (corruption or tampering)", got, r.SHA256)
+	h := sha256.New()
+	var authErr error
+	if aead != nil {
+		if _, authErr = io.Copy(io.Discard, newDecryptReader(io.TeeReader(f, h), aead, r.ID)); authErr != nil && authErr != errArchiveAuth {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to read dump %s: %w", path, authErr)
+		}
 	}
-	return data, nil
+	// Hash whatever decryption left unread, so a checksum mismatch is
+	// reported as such even when it also broke decryption.
+	if _, err := io.Copy(h, f); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to read dump %s: %w", path, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != r.SHA256 {
+		cleanup()
+		return nil, nil, fmt.Errorf("dump integrity check failed: sha256 %s != recorded %s (corruption or tampering)", got, r.SHA256)
+	}
+	if authErr != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("dump integrity check failed: %w", authErr)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to rewind dump %s: %w", path, err)
+	}
+	return f, cleanup, nil
+}
+
+// plaintext returns the dump bytes read from src: src itself for a
+// plaintext archive, decrypted under aead for an encrypted one.
+func plaintext(src io.Reader, aead cipher.AEAD, id string) io.Reader {
+	if aead == nil {
+		return src
+	}
+	return newDecryptReader(src, aead, id)
 }
 
 func (m *Manager) sidecarPath(id string) string { return filepath.Join(m.dir, id+".meta.json") }
@@ -519,19 +652,6 @@ func (m *Manager) readSidecar(path string) (*Record, error) {
 	return &r, nil
 }
 
-// wrapPg builds the bash invocation that exports PGPASSWORD (when set)
-// and runs the given pg command under strict mode. Returns the argv for
-// ExecWithOutput.
-func wrapPg(password, pgCmd string) []string {
-	var b strings.Builder
-	b.WriteString("set -euo pipefail; ")
-	if password != "" {
-		fmt.Fprintf(&b, "export PGPASSWORD=%s; ", shellQuote(password))
-	}
-	b.WriteString(pgCmd)
-	return []string{"bash", "-c", b.String()}
-}
-
 // shellQuote single-quotes s for safe interpolation into a bash command,
 // escaping embedded single quotes the POSIX way ('\” closes, escapes,
 // reopens).
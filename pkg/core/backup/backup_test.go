@@ -1,19 +1,21 @@
 package backup
 
 import (
+	"io"
 	"os"
 	"strings"
 	"testing"
 	"time"
 )
 
-// fakeOps is an in-memory ContainerOps. pg_dump is not actually run; any
-// ReadFile of a /tmp dump path returns the canned dumpPayload, simulating
-// the archive pg_dump would have written. WriteFile/Exec are recorded so
-// tests can assert on the restore path.
+// fakeOps is an in-memory ContainerOps. pg_dump is not actually run; a
+// streamed pg_dump writes the canned dumpPayload to stdout, simulating
+// the archive it would have produced. Whatever a streamed pg_restore
+// reads from stdin is captured in restored, and every command is logged,
+// so tests can assert on the restore path.
 type fakeOps struct {
 	dumpPayload []byte
-	written     map[string][]byte // in-container path -> content (restore)
+	restored    []byte // stdin consumed by the last pg_restore
 	execLog     []string
 	failExec    bool
 
@@ -34,7 +36,7 @@ type fakeOps struct {
 }
 
 func newFakeOps(payload []byte) *fakeOps {
-	return &fakeOps{dumpPayload: payload, written: map[string][]byte{}, relationCount: "7"}
+	return &fakeOps{dumpPayload: payload, relationCount: "7"}
 }
 
 func (f *fakeOps) Exec(container string, command []string) error {
@@ -67,16 +69,30 @@ func (f *fakeOps) ExecWithOutput(container string, command []string) (string, st
 	return "", "", nil
 }
 
-func (f *fakeOps) ReadFile(container, path string) ([]byte, error) {
-	if strings.HasSuffix(path, ".dump") {
-		return f.dumpPayload, nil
+func (f *fakeOps) ExecStream(container string, command []string, stdin io.Reader, stdout io.Writer) (string, error) {
+	f.execLog = append(f.execLog, strings.Join(command, " "))
+	full := strings.Join(command, " ")
+	for db := range f.failDatabases {
+		if strings.Contains(full, "-d '"+db+"'") {
+			return "FATAL: database \"" + db + "\" does not exist", errExec
+		}
 	}
-	return nil, errNotFound
-}
-
-func (f *fakeOps) WriteFile(container, path string, content []byte, mode string) error {
-	f.written[path] = content
-	return nil
+	if f.failExec {
+		return "FATAL: database \"missing\" does not exist", errExec
+	}
+	switch {
+	case strings.Contains(full, "pg_dump"):
+		if _, err := stdout.Write(f.dumpPayload); err != nil {
+			return "", err
+		}
+	case strings.Contains(full, "pg_restore"):
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", err
+		}
+		f.restored = b
+	}
+	return "", nil
 }
 
 var (
@@ -198,15 +214,9 @@ func TestRestoreRoundTrip(t *testing.T) {
 		t.Fatalf("Restore: %v", err)
 	}
 
-	// The dump bytes must have been pushed back into the container intact.
-	var pushed []byte
-	for p, c := range ops.written {
-		if strings.HasSuffix(p, ".dump") {
-			pushed = c
-		}
-	}
-	if string(pushed) != string(payload) {
-		t.Errorf("restored bytes = %q, want %q", pushed, payload)
+	// The dump bytes must have been streamed back into pg_restore intact.
+	if string(ops.restored) != string(payload) {
+		t.Errorf("restored bytes = %q, want %q", ops.restored, payload)
 	}
 	joined := strings.Join(ops.execLog, "\n")
 	if !strings.Contains(joined, "pg_restore") || !strings.Contains(joined, "--clean --if-exists") {
@@ -386,7 +396,7 @@ func TestListDatabases(t *testing.T) {
 	ops.listDatabasesOut = "postgres\napp_production\nanalytics\n"
 	m := newTestManager(t, ops)
 
-	dbs, err := m.ListDatabases("alice-container", PgConn{})
+	dbs, err := m.ListDatabases("", "alice-container", PgConn{})
 	if err != nil {
 		t.Fatalf("ListDatabases: %v", err)
 	}
@@ -406,7 +416,7 @@ func TestListDatabases_EmptyOutput(t *testing.T) {
 	ops.listDatabasesOut = ""
 	m := newTestManager(t, ops)
 
-	dbs, err := m.ListDatabases("alice-container", PgConn{})
+	dbs, err := m.ListDatabases("", "alice-container", PgConn{})
 	if err != nil {
 		t.Fatalf("ListDatabases: %v", err)
 	}
@@ -420,7 +430,7 @@ func TestListDatabases_ExecFails(t *testing.T) {
 	ops.listDatabasesErr = errExec
 	m := newTestManager(t, ops)
 
-	if _, err := m.ListDatabases("alice-container", PgConn{}); err == nil {
+	if _, err := m.ListDatabases("", "alice-container", PgConn{}); err == nil {
 		t.Fatal("expected an error")
 	}
 }
@@ -524,3 +534,70 @@ func TestCreateAll_ListDatabasesFails(t *testing.T) {
 		t.Fatalf("got %d errors, want 1", len(errs))
 	}
 }
+
+func TestCreateRestoreReportProgress(t *testing.T) {
+	payload := []byte(strings.Repeat("x", progressInterval+10))
+	ops := newFakeOps(payload)
+	m := newTestManager(t, ops)
+
+	var got []Progress
+	rec, err := m.Create(CreateOptions{
+		Username:      "alice",
+		ContainerName: "alice-container",
+		Conn:          PgConn{Database: "app"},
+		Destination:   DestLocal,
+		Progress:      func(p Progress) { got = append(got, p) },
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	// One interval report mid-stream, then the final one.
+	if len(got) != 2 || got[0].Bytes < progressInterval || got[0].Done {
+		t.Fatalf("dump progress = %+v", got)
+	}
+	if last := got[1]; !last.Done || last.Bytes != int64(len(payload)) || last.Phase != PhaseDump || last.BackupID != rec.ID {
+		t.Errorf("final dump report = %+v", last)
+	}
+
+	got = nil
+	if err := m.Restore(RestoreOptions{
+		ID:            rec.ID,
+		ContainerName: "alice-container",
+		Progress:      func(p Progress) { got = append(got, p) },
+	}); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	last := got[len(got)-1]
+	if last.Phase != PhaseRestore || !last.Done || last.Bytes != int64(len(payload)) || last.Total != int64(len(payload)) {
+		t.Errorf("final restore report = %+v", last)
+	}
+}
+
+func TestCreateFailureLeavesNoStagedDump(t *testing.T) {
+	ops := newFakeOps([]byte("dump"))
+	ops.failExec = true
+	m := newTestManager(t, ops)
+	if _, err := m.Create(CreateOptions{
+		Username:      "alice",
+		ContainerName: "alice-container",
+		Conn:          PgConn{Database: "app"},
+		Destination:   DestLocal,
+	}); err == nil {
+		t.Fatal("Create should fail when pg_dump fails")
+	}
+	entries, _ := os.ReadDir(m.dir)
+	if len(entries) != 0 {
+		t.Errorf("failed dump left files behind: %v", entries)
+	}
+
+	ops.failExec = false
+	ops.dumpPayload = nil
+	if _, err := m.Create(CreateOptions{
+		Username:      "alice",
+		ContainerName: "alice-container",
+		Conn:          PgConn{Database: "app"},
+		Destination:   DestLocal,
+	}); err == nil || !strings.Contains(err.Error(), "empty archive") {
+		t.Errorf("empty dump: err = %v", err)
+	}
+}
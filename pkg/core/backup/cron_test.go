@@ -0,0 +1,63 @@
+package backup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	cases := []struct {
+		expr, from, want string
+	}{
+		{"30 2 * * *", "2026-06-05 01:00", "2026-06-05 02:30"},
+		{"30 2 * * *", "2026-06-05 02:30", "2026-06-06 02:30"}, // strictly after
+		{"@daily", "2026-12-31 23:59", "2027-01-01 00:00"},
+		{"@hourly", "2026-06-05 10:15", "2026-06-05 11:00"},
+		{"*/15 * * * *", "2026-06-05 10:16", "2026-06-05 10:30"},
+		{"0 9-17/4 * * *", "2026-06-05 10:00", "2026-06-05 13:00"},
+		{"0 3 * * sun", "2026-06-05 00:00", "2026-06-07 03:00"}, // 2026-06-05 is a Friday
+		{"0 3 * * 7", "2026-06-05 00:00", "2026-06-07 03:00"},
+		{"0 0 1 jan,jul *", "2026-06-05 00:00", "2026-07-01 00:00"},
+		{"0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+		// dom and dow both restricted: either matches (Vixie semantics).
+		{"0 0 13 * fri", "2026-06-05 00:01", "2026-06-12 00:00"},
+	}
+	for _, tc := range cases {
+		c, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", tc.expr, err)
+			continue
+		}
+		if got := c.Next(at(tc.from)); !got.Equal(at(tc.want)) {
+			t.Errorf("%q after %s = %s, want %s", tc.expr, tc.from, got.Format("2006-01-02 15:04"), tc.want)
+		}
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"0 0 * * fun",
+		"0 0 31 2 *", // never fires
+		"@fortnightly",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+}
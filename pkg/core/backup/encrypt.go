@@ -0,0 +1,271 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/footprintai/containarium/pkg/core/secrets"
+)
+
+// Client-side archive encryption. With a KMS configured (SetKMS), every
+// new archive is encrypted on the daemon host as it streams out of the
+// container — before it is staged, checksummed or uploaded — under a
+// fresh per-backup data key (DEK). The DEK is wrapped by the KMS and the
+// wrapped key is stored in the record's sidecar, never the plaintext
+// key: an object-store bucket, or the backup directory without the KMS,
+// holds nothing readable.
+//
+// Archive format (archiveAlgorithm), the STREAM construction over
+// AES-256-GCM:
+//
+//	header:  magic (8 bytes) || nonce prefix (7 random bytes)
+//	chunks:  AES-GCM(plaintext chunk) for each archiveChunkSize chunk
+//
+// Chunk i is sealed with nonce = prefix || uint32(i) || lastFlag, where
+// lastFlag is 1 only on the final chunk, and with the backup ID as
+// additional data. Reordering, dropping or truncating chunks, or moving
+// an archive under another backup's record, fails authentication rather
+// than restoring something else. Each chunk is authenticated on its own,
+// so neither direction ever holds more than one chunk in memory.
+
+// archiveAlgorithm names the format above in Encryption.Algorithm. A
+// record naming any other algorithm is refused rather than guessed at.
+const archiveAlgorithm = "aes-256-gcm-stream-v1"
+
+const (
+	archiveChunkSize   = 64 << 10
+	archiveNoncePrefix = 7
+)
+
+// archiveMagic opens every encrypted archive, so one is recognisable on
+// disk and never mistaken for a plaintext dump.
+var archiveMagic = []byte("CTMBAK\x00\x01")
+
+// errArchiveAuth is the decryption failure: the wrong key, a tampered or
+// truncated archive, or an archive that belongs to a different backup.
+var errArchiveAuth = errors.New("archive failed to decrypt: wrong key, or the archive was tampered with or truncated")
+
+// Encryption records how an archive was encrypted. Persisted in the
+// sidecar; WrappedDEK is the data key wrapped by the KMS key KEKID and
+// is useless without that KMS.
+type Encryption struct {
+	Algorithm  string `json:"algorithm"`
+	KEKID      string `json:"kek_id"`
+	WrappedDEK []byte `json:"wrapped_dek"`
+}
+
+// SetKMS turns on client-side encryption of new archives under kms. Nil
+// turns it off for new backups; encrypted records still need a KMS to be
+// restored or verified. Call before serving requests.
+func (m *Manager) SetKMS(kms secrets.KMSClient) {
+	m.kms = kms
+}
+
+// newDataKey generates and wraps a fresh DEK for one archive. The
+// plaintext key lives only inside the returned AEAD.
+func (m *Manager) newDataKey() (cipher.AEAD, *Encryption, error) {
+	dek, err := secrets.NewDEK()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer secrets.ZeroBytes(dek)
+	wrapped, kekID, err := m.kms.Wrap(context.Background(), dek)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wrap archive key: %w", err)
+	}
+	aead, err := newArchiveAEAD(dek)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, &Encryption{Algorithm: archiveAlgorithm, KEKID: kekID, WrappedDEK: wrapped}, nil
+}
+
+// recordKey unwraps a record's DEK. It returns nil for a plaintext
+// archive, and an error — before anything is downloaded — for an
+// encrypted one this daemon cannot open.
+func (m *Manager) recordKey(r *Record) (cipher.AEAD, error) {
+	e := r.Encryption
+	if e == nil {
+		return nil, nil
+	}
+	if e.Algorithm != archiveAlgorithm {
+		return nil, fmt.Errorf("backup %s is encrypted with unsupported algorithm %q", r.ID, e.Algorithm)
+	}
+	if m.kms == nil {
+		return nil, fmt.Errorf("backup %s is encrypted (key %s) but no KMS is configured on this daemon", r.ID, e.KEKID)
+	}
+	dek, err := m.kms.Unwrap(context.Background(), e.WrappedDEK, e.KEKID)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap archive key for %s: %w", r.ID, err)
+	}
+	defer secrets.ZeroBytes(dek)
+	return newArchiveAEAD(dek)
+}
+
+func newArchiveAEAD(dek []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, fmt.Errorf("archive cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// archiveNonce builds chunk i's nonce.
+func archiveNonce(prefix []byte, i uint32, last bool) []byte {
+	nonce := make([]byte, 0, archiveNoncePrefix+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, i)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// encryptWriter encrypts what is written to it onto w. Close seals the
+// final chunk and must be called for the archive to be complete.
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	ad     []byte
+	prefix []byte
+	buf    []byte
+	i      uint32
+	closed bool
+	err    error
+}
+
+func newEncryptWriter(w io.Writer, aead cipher.AEAD, id string) (*encryptWriter, error) {
+	prefix := make([]byte, archiveNoncePrefix)
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return nil, fmt.Errorf("generate archive nonce: %w", err)
+	}
+	if _, err := w.Write(append(append([]byte{}, archiveMagic...), prefix...)); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, ad: []byte(id), prefix: prefix,
+		buf: make([]byte, 0, archiveChunkSize+aead.Overhead())}, nil
+}
+
+// Write buffers p, sealing each full chunk once the next byte shows it is
+// not the last one.
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	if e.closed {
+		return 0, errors.New("write to a closed archive")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(e.buf) == archiveChunkSize {
+			if e.err = e.seal(false); e.err != nil {
+				return 0, e.err
+			}
+		}
+		k := min(archiveChunkSize-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:k]...)
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// Close seals the buffered remainder as the final chunk.
+func (e *encryptWriter) Close() error {
+	if e.err != nil || e.closed {
+		return e.err
+	}
+	e.closed = true
+	e.err = e.seal(true)
+	return e.err
+}
+
+func (e *encryptWriter) seal(last bool) error {
+	if e.i == ^uint32(0) {
+		return errors.New("archive too large to encrypt")
+	}
+	out := e.aead.Seal(e.buf[:0], archiveNonce(e.prefix, e.i, last), e.buf, e.ad)
+	e.i++
+	if _, err := e.w.Write(out); err != nil {
+		return err
+	}
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// decryptReader authenticates and decrypts an archive read from r. A
+// chunk's plaintext is returned only after its tag verifies, and EOF only
+// after the chunk flagged last, so a truncated archive is an error, not
+// a short restore.
+type decryptReader struct {
+	r      *bufio.Reader
+	aead   cipher.AEAD
+	ad     []byte
+	prefix []byte
+	chunk  []byte
+	out    []byte
+	i      uint32
+	done   bool
+	err    error
+}
+
+func newDecryptReader(r io.Reader, aead cipher.AEAD, id string) io.Reader {
+	return &decryptReader{r: bufio.NewReader(r), aead: aead, ad: []byte(id),
+		chunk: make([]byte, archiveChunkSize+aead.Overhead())}
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		d.err = d.next()
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// next reads, authenticates and decrypts one chunk into d.out.
+func (d *decryptReader) next() error {
+	if d.prefix == nil {
+		header := make([]byte, len(archiveMagic)+archiveNoncePrefix)
+		if _, err := io.ReadFull(d.r, header); err != nil {
+			return fmt.Errorf("archive header: %w", errArchiveAuth)
+		}
+		if string(header[:len(archiveMagic)]) != string(archiveMagic) {
+			return errors.New("not an encrypted backup archive")
+		}
+		d.prefix = header[len(archiveMagic):]
+	}
+	n, err := io.ReadFull(d.r, d.chunk)
+	switch {
+	case err == io.ErrUnexpectedEOF || err == io.EOF:
+		d.done = true
+	case err != nil:
+		return err
+	default:
+		// A full chunk is the last one only if nothing follows it.
+		if _, perr := d.r.Peek(1); perr == io.EOF {
+			d.done = true
+		} else if perr != nil {
+			return perr
+		}
+	}
+	pt, err := d.aead.Open(d.chunk[:0], archiveNonce(d.prefix, d.i, d.done), d.chunk[:n], d.ad)
+	if err != nil {
+		return errArchiveAuth
+	}
+	d.i++
+	d.out = pt
+	return nil
+}
@@ -0,0 +1,248 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/footprintai/containarium/pkg/core/secrets"
+)
+
+func testAEAD(t *testing.T) cipher.AEAD {
+	t.Helper()
+	aead, err := newArchiveAEAD(bytes.Repeat([]byte{7}, secrets.DEKSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aead
+}
+
+func encryptForTest(t *testing.T, aead cipher.AEAD, id string, plain []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := newEncryptWriter(&buf, aead, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Odd-sized writes, so chunk boundaries never line up with them.
+	for p := plain; len(p) > 0; {
+		k := min(len(p), 1000)
+		if _, err := w.Write(p[:k]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[k:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	aead := testAEAD(t)
+	for _, size := range []int{0, 1, archiveChunkSize - 1, archiveChunkSize, archiveChunkSize + 1, 3*archiveChunkSize + 17} {
+		plain := bytes.Repeat([]byte("pgdump"), size/6+1)[:size]
+		ct := encryptForTest(t, aead, "alice-app-1", plain)
+		if size > 0 && bytes.Contains(ct, plain[:min(size, 64)]) {
+			t.Errorf("size %d: plaintext visible in the archive", size)
+		}
+		got, err := io.ReadAll(newDecryptReader(bytes.NewReader(ct), aead, "alice-app-1"))
+		if err != nil {
+			t.Fatalf("size %d: decrypt: %v", size, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("size %d: round trip returned %d bytes", size, len(got))
+		}
+	}
+}
+
+// Every way of presenting an archive other than the one written must fail
+// authentication — a short read handed to pg_restore is a silent partial
+// restore.
+func TestArchiveRejectsTamperingAndTruncation(t *testing.T) {
+	aead := testAEAD(t)
+	plain := bytes.Repeat([]byte{'x'}, 2*archiveChunkSize+100)
+	ct := encryptForTest(t, aead, "alice-app-1", plain)
+	chunk := archiveChunkSize + aead.Overhead()
+	header := len(archiveMagic) + archiveNoncePrefix
+
+	flipped := append([]byte{}, ct...)
+	flipped[header+10] ^= 1
+
+	for _, tc := range []struct {
+		name string
+		ct   []byte
+		id   string
+	}{
+		{"a flipped bit", flipped, "alice-app-1"},
+		{"the final chunk dropped", ct[:header+2*chunk], "alice-app-1"},
+		{"cut mid-chunk", ct[:header+chunk+10], "alice-app-1"},
+		{"header only", ct[:header], "alice-app-1"},
+		{"another backup's record", ct, "alice-app-2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := io.ReadAll(newDecryptReader(bytes.NewReader(tc.ct), aead, tc.id))
+			if err == nil {
+				t.Fatal("decryption succeeded")
+			}
+		})
+	}
+	if _, err := io.ReadAll(newDecryptReader(strings.NewReader("PGDMP-plaintext"), aead, "x")); err == nil {
+		t.Error("a plaintext dump was accepted as an encrypted archive")
+	}
+}
+
+func newEncryptingManager(t *testing.T, ops ContainerOps) *Manager {
+	t.Helper()
+	kms, err := secrets.NewInProcKMS(bytes.Repeat([]byte{1}, secrets.MasterKeySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTestManager(t, ops)
+	m.SetKMS(kms)
+	return m
+}
+
+func TestCreateEncryptsAndRestoreDecrypts(t *testing.T) {
+	payload := []byte("PGDMP-fake-archive-bytes")
+	ops := newFakeOps(payload)
+	m := newEncryptingManager(t, ops)
+
+	rec, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Conn: PgConn{Database: "app"}, Destination: DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if rec.Encryption == nil || rec.Encryption.Algorithm != archiveAlgorithm ||
+		rec.Encryption.KEKID == "" || len(rec.Encryption.WrappedDEK) == 0 {
+		t.Fatalf("record carries no usable encryption metadata: %+v", rec.Encryption)
+	}
+	stored, err := os.ReadFile(rec.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stored, payload) {
+		t.Error("the stored archive contains the plaintext dump")
+	}
+	if rec.SizeBytes != int64(len(stored)) {
+		t.Errorf("SizeBytes = %d, want the stored size %d", rec.SizeBytes, len(stored))
+	}
+	sidecar, err := os.ReadFile(m.sidecarPath(rec.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(sidecar), `"wrapped_dek"`) {
+		t.Errorf("wrapped key not persisted in the sidecar: %s", sidecar)
+	}
+
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container"}); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if !bytes.Equal(ops.restored, payload) {
+		t.Errorf("restore got %q, want the plaintext %q", ops.restored, payload)
+	}
+
+	v, err := m.Verify(VerifyOptions{ID: rec.ID, TargetContainer: "scratch", SourceContainer: "alice-container"})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if v.Result != VerificationPassed {
+		t.Errorf("verification of an encrypted backup failed: %+v", v.Checks)
+	}
+}
+
+func TestEncryptedRestoreNeedsTheKMS(t *testing.T) {
+	ops := newFakeOps([]byte("PGDMP-fake-archive-bytes"))
+	m := newEncryptingManager(t, ops)
+	rec, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Conn: PgConn{Database: "app"}, Destination: DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	m.SetKMS(nil)
+	ops.execLog = nil
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container"}); err == nil {
+		t.Fatal("restoring an encrypted backup without a KMS should fail")
+	}
+	if len(ops.execLog) != 0 {
+		t.Errorf("a restore that cannot decrypt ran commands: %v", ops.execLog)
+	}
+	v, err := m.Verify(VerifyOptions{ID: rec.ID, TargetContainer: "scratch", SourceContainer: "alice-container"})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if v.Result != VerificationFailed {
+		t.Error("verifying an archive this daemon cannot decrypt should record a failure")
+	}
+}
+
+// A record whose checksum was rewritten to match a tampered archive still
+// must not restore: the chunk tags are the second gate.
+func TestEncryptedRestoreRejectsTamperingBeforeRestoring(t *testing.T) {
+	ops := newFakeOps(bytes.Repeat([]byte("row;"), archiveChunkSize))
+	m := newEncryptingManager(t, ops)
+	rec, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Conn: PgConn{Database: "app"}, Destination: DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	stored, err := os.ReadFile(rec.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored = stored[:len(stored)-100]
+	if err := os.WriteFile(rec.Location, stored, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(stored)
+	rec.SHA256 = hex.EncodeToString(sum[:])
+	rec.SizeBytes = int64(len(stored))
+	if err := m.writeSidecar(rec); err != nil {
+		t.Fatal(err)
+	}
+
+	ops.execLog = nil
+	err = m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container"})
+	if err == nil || !strings.Contains(err.Error(), "integrity") {
+		t.Fatalf("Restore = %v, want an integrity failure", err)
+	}
+	if len(ops.execLog) != 0 {
+		t.Errorf("a truncated archive reached the restore: %v", ops.execLog)
+	}
+}
+
+func TestUnencryptedBackupsStillRestoreWithKMS(t *testing.T) {
+	payload := []byte("PGDMP-fake-archive-bytes")
+	ops := newFakeOps(payload)
+	m := newTestManager(t, ops)
+	rec, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Conn: PgConn{Database: "app"}, Destination: DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if rec.Encryption != nil {
+		t.Fatal("a manager without a KMS encrypted the archive")
+	}
+
+	kms, err := secrets.NewInProcKMS(bytes.Repeat([]byte{1}, secrets.MasterKeySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetKMS(kms)
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container"}); err != nil {
+		t.Fatalf("Restore of a pre-encryption backup: %v", err)
+	}
+	if !bytes.Equal(ops.restored, payload) {
+		t.Errorf("restore got %q, want %q", ops.restored, payload)
+	}
+}
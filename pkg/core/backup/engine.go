@@ -0,0 +1,127 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Engine names, as written to Record.Engine. They are persisted in every
+// sidecar, so an existing value never changes meaning.
+const (
+	EnginePostgres = "postgres"
+	// EngineMySQL covers MySQL and MariaDB: the dump is a gzipped logical
+	// SQL script, which both servers load, produced by whichever of
+	// mariadb-dump and mysqldump the container has.
+	EngineMySQL = "mysql"
+	// EngineRedis is an RDB snapshot taken with BGSAVE. An RDB holds the
+	// whole instance, so a Redis backup has exactly one dataset.
+	EngineRedis = "redis"
+)
+
+// engine is one database engine's half of a backup: how to enumerate,
+// dump, restore and restore-test. The Manager owns everything else —
+// staging, checksums, upload, the index — so an engine never touches the
+// host filesystem and cannot get the integrity gate wrong.
+//
+// Every method runs inside the container through ContainerOps, and every
+// credential reaches the child through the environment, never argv.
+type engine interface {
+	// tools names the dump and restore steps in error messages.
+	tools() (dump, restore string)
+	// defaults fills the connection fields the caller left unset with the
+	// engine's conventional loopback values.
+	defaults(c PgConn) PgConn
+	// checkDatabase rejects a database name the engine cannot dump.
+	checkDatabase(db string) error
+	// checkRestore rejects restore options the engine cannot honour,
+	// before anything is downloaded.
+	checkRestore(clean bool) error
+
+	// listDatabases enumerates what CreateAll should back up.
+	listDatabases(ops ContainerOps, container string, c PgConn) ([]string, error)
+	// dumpCmd is the argv that writes a dump of c.Database to stdout.
+	dumpCmd(c PgConn) []string
+	// restore loads dump into c.Database, returning the engine's stderr.
+	restore(ops ContainerOps, container string, c PgConn, dump io.Reader, clean bool) (string, error)
+
+	// manifest describes what countObjects counts.
+	manifest() manifestSpec
+	// countObjects counts db's user objects: the manifest Create records
+	// at dump time and Verify compares a restore against.
+	countObjects(ops ContainerOps, container string, c PgConn, db string) (int64, error)
+
+	// The scratch lifecycle Verify drives: create a throwaway target
+	// named name, load the dump into it, count what landed, drop it.
+	createScratch(ops ContainerOps, container string, c PgConn, name string) (string, error)
+	loadScratch(ops ContainerOps, container string, c PgConn, name string, dump io.Reader) (string, error)
+	countScratch(ops ContainerOps, container string, c PgConn, name string) (int64, error)
+	dropScratch(ops ContainerOps, container string, c PgConn, name string)
+}
+
+// manifestSpec describes an engine's object manifest.
+type manifestSpec struct {
+	check string // Check.Name the comparison is recorded under
+	noun  string // what is counted, for check details
+	// exact means a restored count must equal the recorded one. False for
+	// an engine whose count drifts between snapshot and count (Redis
+	// keys), where only "nothing came back" is a reliable failure.
+	exact bool
+}
+
+var engines = map[string]engine{
+	EnginePostgres: postgresEngine{},
+	EngineMySQL:    mysqlEngine{},
+	EngineRedis:    redisEngine{},
+}
+
+// engineFor returns the engine named name, and the name as it is
+// recorded. Empty means Postgres: the engine every caller meant before
+// there was a choice. Any other unknown name is an error rather than a
+// guess — handing a dump to the wrong engine's restore is how a restore
+// silently targets the wrong database.
+func engineFor(name string) (engine, string, error) {
+	if name == "" {
+		name = EnginePostgres
+	}
+	e, ok := engines[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported database engine %q (expected %s, %s or %s)",
+			name, EnginePostgres, EngineMySQL, EngineRedis)
+	}
+	return e, name, nil
+}
+
+// anyDatabase is embedded by engines that accept any database name and
+// any restore options.
+type anyDatabase struct{}
+
+func (anyDatabase) checkDatabase(string) error { return nil }
+func (anyDatabase) checkRestore(bool) error    { return nil }
+
+// scratchDir is where an engine without a CREATE DATABASE (Redis) keeps a
+// scratch instance inside the target container.
+func scratchDir(name string) string { return "/tmp/" + name }
+
+// wrapEnv builds the bash invocation that exports a credential variable
+// (when set) and runs script under strict mode.
+func wrapEnv(envVar, secret, script string) []string {
+	var b strings.Builder
+	b.WriteString("set -euo pipefail; ")
+	if secret != "" {
+		fmt.Fprintf(&b, "export %s=%s; ", envVar, shellQuote(secret))
+	}
+	b.WriteString(script)
+	return []string{"bash", "-c", b.String()}
+}
+
+// splitLines returns the non-blank, trimmed lines of s.
+func splitLines(s string) []string {
+	var out []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
@@ -0,0 +1,301 @@
+package backup
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// scriptOps is a ContainerOps for the non-Postgres engines. Commands are
+// matched by substring: the longest key of outputs found in a command
+// supplies its stdout. A streamed command whose script contains
+// dumpMarker writes dumpPayload; any command reading stdin has it
+// captured in streamed, keyed by container.
+type scriptOps struct {
+	dumpMarker  string
+	dumpPayload []byte
+	outputs     map[string]string
+	failOn      string // a command containing this fails with failStderr
+	failStderr  string
+
+	execLog  []string
+	streamed map[string][]byte
+}
+
+func newScriptOps(marker string, payload []byte, outputs map[string]string) *scriptOps {
+	return &scriptOps{dumpMarker: marker, dumpPayload: payload, outputs: outputs, streamed: map[string][]byte{}}
+}
+
+func (s *scriptOps) run(command []string) (string, string, error) {
+	full := strings.Join(command, " ")
+	s.execLog = append(s.execLog, full)
+	if s.failOn != "" && strings.Contains(full, s.failOn) {
+		return "", s.failStderr, errExec
+	}
+	match, ok := "", false
+	for k := range s.outputs {
+		if strings.Contains(full, k) && len(k) >= len(match) {
+			match, ok = k, true
+		}
+	}
+	if ok {
+		return s.outputs[match], "", nil
+	}
+	return "", "", nil
+}
+
+func (s *scriptOps) Exec(container string, command []string) error {
+	_, _, err := s.run(command)
+	return err
+}
+
+func (s *scriptOps) ExecWithOutput(container string, command []string) (string, string, error) {
+	return s.run(command)
+}
+
+func (s *scriptOps) ExecStream(container string, command []string, stdin io.Reader, stdout io.Writer) (string, error) {
+	_, stderr, err := s.run(command)
+	if err != nil {
+		return stderr, err
+	}
+	if stdin != nil {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", err
+		}
+		s.streamed[container] = b
+	}
+	if strings.Contains(strings.Join(command, " "), s.dumpMarker) {
+		if _, err := stdout.Write(s.dumpPayload); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+func (s *scriptOps) log() string { return strings.Join(s.execLog, "\n") }
+
+func TestMySQLCreateRestoreVerify(t *testing.T) {
+	payload := []byte("\x1f\x8b-gzipped-sql")
+	ops := newScriptOps(`"$dump"`, payload, map[string]string{
+		"information_schema.tables": "4",
+	})
+	m := newTestManager(t, ops)
+
+	rec, err := m.Create(CreateOptions{
+		Username:      "alice",
+		ContainerName: "alice-container",
+		Engine:        EngineMySQL,
+		Conn:          PgConn{Database: "shop", Password: "s3cr3t"},
+		Destination:   DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if rec.Engine != EngineMySQL || rec.ID != "alice-shop-20260605T130405Z" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if rec.RelationCount == nil || *rec.RelationCount != 4 {
+		t.Errorf("table manifest = %v, want 4", rec.RelationCount)
+	}
+	log := ops.log()
+	for _, want := range []string{"mariadb-dump", "mysqldump", "--single-transaction", "--skip-add-drop-table", "-P 3306", "-u 'root'", "export MYSQL_PWD="} {
+		if !strings.Contains(log, want) {
+			t.Errorf("dump command missing %q; execLog=%v", want, ops.execLog)
+		}
+	}
+	if strings.Contains(log, "-ps3cr3t") || strings.Contains(log, "--password") {
+		t.Errorf("password on the client's argv: %v", ops.execLog)
+	}
+
+	// A clean restore recreates the database before loading into it.
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container", Clean: true}); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if string(ops.streamed["alice-container"]) != string(payload) {
+		t.Errorf("restored bytes = %q, want %q", ops.streamed["alice-container"], payload)
+	}
+	if !strings.Contains(ops.log(), "DROP DATABASE IF EXISTS `shop`; CREATE DATABASE `shop`") {
+		t.Errorf("clean restore did not recreate the database; execLog=%v", ops.execLog)
+	}
+
+	v, err := m.Verify(VerifyOptions{ID: rec.ID, TargetContainer: "scratch-container", SourceContainer: "alice-container"})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if v.Result != VerificationPassed {
+		t.Fatalf("verification failed: %+v", v)
+	}
+	if last := v.Checks[len(v.Checks)-1]; last.Name != "table_count" || !last.Passed {
+		t.Errorf("last check = %+v, want a passing table_count", last)
+	}
+	if string(ops.streamed["scratch-container"]) != string(payload) {
+		t.Error("dump was not loaded into the scratch container")
+	}
+	if !strings.Contains(ops.log(), "DROP DATABASE IF EXISTS `"+scratchName(rec.ID)+"`") {
+		t.Errorf("scratch database not dropped; execLog=%v", ops.execLog)
+	}
+}
+
+func TestMySQLListDatabasesSkipsSystemSchemas(t *testing.T) {
+	ops := newScriptOps("", nil, map[string]string{
+		"SHOW DATABASES": "information_schema\nmysql\nperformance_schema\nshop\nsys\nwiki\n",
+	})
+	m := newTestManager(t, ops)
+
+	dbs, err := m.ListDatabases(EngineMySQL, "alice-container", PgConn{})
+	if err != nil {
+		t.Fatalf("ListDatabases: %v", err)
+	}
+	if strings.Join(dbs, ",") != "shop,wiki" {
+		t.Errorf("dbs = %v, want [shop wiki]", dbs)
+	}
+}
+
+func TestRedisCreateCoversWholeInstance(t *testing.T) {
+	payload := []byte("REDIS0011-rdb")
+	ops := newScriptOps("BGSAVE", payload, map[string]string{
+		"PING":          "PONG\n",
+		"INFO keyspace": "# Keyspace\r\ndb0:keys=10,expires=0,avg_ttl=0\r\ndb3:keys=2,expires=1,avg_ttl=5\r\n",
+	})
+	m := newTestManager(t, ops)
+
+	recs, errs := m.CreateAll(CreateOptions{
+		Username:      "alice",
+		ContainerName: "alice-container",
+		Engine:        EngineRedis,
+		Conn:          PgConn{Password: "s3cr3t"},
+		Destination:   DestLocal,
+	})
+	if len(errs) != 0 || len(recs) != 1 {
+		t.Fatalf("CreateAll: recs=%d errs=%v", len(recs), errs)
+	}
+	rec := recs[0]
+	if rec.Database != redisDataset || rec.Engine != EngineRedis || rec.SizeBytes != int64(len(payload)) {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if rec.RelationCount == nil || *rec.RelationCount != 12 {
+		t.Errorf("key manifest = %v, want 12", rec.RelationCount)
+	}
+	if !strings.Contains(ops.log(), "export REDISCLI_AUTH=") || !strings.Contains(ops.log(), "-p 6379") {
+		t.Errorf("redis-cli not invoked as expected; execLog=%v", ops.execLog)
+	}
+
+	if _, err := m.Create(CreateOptions{
+		Username:      "alice",
+		ContainerName: "alice-container",
+		Engine:        EngineRedis,
+		Conn:          PgConn{Database: "3"},
+		Destination:   DestLocal,
+	}); err == nil {
+		t.Error("a Redis backup of one numbered database should be refused: an RDB is the whole instance")
+	}
+}
+
+func TestRedisRestoreRequiresClean(t *testing.T) {
+	payload := []byte("REDIS0011-rdb")
+	ops := newScriptOps("BGSAVE", payload, nil)
+	m := newTestManager(t, ops)
+	rec, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Engine: EngineRedis, Destination: DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	ops.execLog = nil
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container"}); err == nil {
+		t.Fatal("a Redis restore without clean should be refused")
+	}
+	if len(ops.execLog) != 0 {
+		t.Errorf("a refused restore ran commands: %v", ops.execLog)
+	}
+
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container", Clean: true}); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if string(ops.streamed["alice-container"]) != string(payload) {
+		t.Errorf("restored bytes = %q, want %q", ops.streamed["alice-container"], payload)
+	}
+	if !strings.Contains(ops.log(), "DEBUG RELOAD NOSAVE") {
+		t.Errorf("restore did not reload the dataset; execLog=%v", ops.execLog)
+	}
+}
+
+// Keys drift between BGSAVE and the count taken after it, so a Redis
+// restore test only fails when nothing came back.
+func TestRedisVerifyKeyCount(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		restored string
+		want     VerificationResult
+	}{
+		{"a few keys fewer is drift, not loss", "db0:keys=95,expires=0,avg_ttl=0", VerificationPassed},
+		{"an empty restore is a failure", "", VerificationFailed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ops := newScriptOps("BGSAVE", []byte("REDIS0011-rdb"), map[string]string{
+				"redis.sock' INFO keyspace": tc.restored,
+				"INFO keyspace":             "db0:keys=100,expires=0,avg_ttl=0",
+			})
+			m := newTestManager(t, ops)
+			rec, err := m.Create(CreateOptions{
+				Username: "alice", ContainerName: "alice-container", Engine: EngineRedis, Destination: DestLocal,
+			})
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			v, err := m.Verify(VerifyOptions{ID: rec.ID, TargetContainer: "scratch-container", SourceContainer: "alice-container"})
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if v.Result != tc.want {
+				t.Errorf("result = %s, want %s: %+v", v.Result, tc.want, v.Checks)
+			}
+			if !strings.Contains(ops.log(), "redis-server --port 0") || !strings.Contains(ops.log(), "SHUTDOWN NOSAVE") {
+				t.Errorf("scratch server not started and stopped; execLog=%v", ops.execLog)
+			}
+		})
+	}
+}
+
+func TestUnknownEngineIsRefused(t *testing.T) {
+	ops := newFakeOps([]byte("dump"))
+	m := newTestManager(t, ops)
+	if _, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Engine: "mongodb",
+		Conn: PgConn{Database: "app"}, Destination: DestLocal,
+	}); err == nil {
+		t.Error("Create with an unknown engine should fail")
+	}
+
+	rec, err := m.Create(CreateOptions{
+		Username: "alice", ContainerName: "alice-container", Conn: PgConn{Database: "app"}, Destination: DestLocal,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	rec.Engine = "mongodb"
+	if err := m.writeSidecar(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Restore(RestoreOptions{ID: rec.ID, ContainerName: "alice-container", Clean: true}); err == nil {
+		t.Error("restoring a record of an unknown engine must fail, not guess pg_restore")
+	}
+}
+
+func TestParseKeyspace(t *testing.T) {
+	for in, want := range map[string]int64{
+		"":           0,
+		"# Keyspace": 0,
+		"# Keyspace\r\ndb0:keys=3,expires=0,avg_ttl=0\r\ndb1:keys=4,expires=2,avg_ttl=10\r\n": 7,
+	} {
+		got, err := parseKeyspace(in)
+		if err != nil || got != want {
+			t.Errorf("parseKeyspace(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	if _, err := parseKeyspace("db0:keys=lots"); err == nil {
+		t.Error("an unreadable keys= value should be an error")
+	}
+}
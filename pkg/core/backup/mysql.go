@@ -0,0 +1,150 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// mysqlEngine dumps MySQL and MariaDB with a logical SQL dump, gzipped in
+// the container so the stream to the host is compressed like a pg_dump
+// custom archive.
+//
+// The client tools are resolved at run time — mariadb-dump / mariadb on
+// a MariaDB box, mysqldump / mysql elsewhere — since MariaDB 11 ships the
+// mysql* names only as optional symlinks. The password travels as
+// MYSQL_PWD, which both clients read, never on argv.
+//
+// The dump is written without DROP TABLE statements, so a plain restore
+// into a database that already has the tables fails rather than
+// overwriting them, matching pg_restore without --clean. A clean restore
+// drops and recreates the database first.
+type mysqlEngine struct{ anyDatabase }
+
+// mysqlSystemSchemas are never listed for backup: they are the server's
+// own catalog, not tenant data, and loading them into another server
+// would corrupt its grants.
+var mysqlSystemSchemas = map[string]bool{
+	"information_schema": true,
+	"performance_schema": true,
+	"mysql":              true,
+	"sys":                true,
+}
+
+const (
+	mysqlResolveClient = `client=$(command -v mariadb || command -v mysql) || ` +
+		`{ echo "neither mariadb nor mysql client found in the container" >&2; exit 127; }; `
+	mysqlResolveDump = `dump=$(command -v mariadb-dump || command -v mysqldump) || ` +
+		`{ echo "neither mariadb-dump nor mysqldump found in the container" >&2; exit 127; }; `
+)
+
+func (mysqlEngine) tools() (string, string) { return "mysqldump", "mysql restore" }
+
+func (mysqlEngine) defaults(c PgConn) PgConn {
+	if c.User == "" {
+		c.User = "root"
+	}
+	if c.Host == "" {
+		c.Host = "127.0.0.1"
+	}
+	if c.Port == 0 {
+		c.Port = 3306
+	}
+	return c
+}
+
+func (mysqlEngine) listDatabases(ops ContainerOps, container string, c PgConn) ([]string, error) {
+	stdout, stderr, err := ops.ExecWithOutput(container, wrapMy(c.Password, mysqlQuery(c, "SHOW DATABASES")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+	var dbs []string
+	for _, db := range splitLines(stdout) {
+		if !mysqlSystemSchemas[db] {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs, nil
+}
+
+func (mysqlEngine) dumpCmd(c PgConn) []string {
+	return wrapMy(c.Password, mysqlResolveDump+fmt.Sprintf(
+		`"$dump" %s --single-transaction --quick --routines --triggers --events --skip-add-drop-table %s | gzip -c`,
+		mysqlConnFlags(c), shellQuote(c.Database),
+	))
+}
+
+func (mysqlEngine) restore(ops ContainerOps, container string, c PgConn, dump io.Reader, clean bool) (string, error) {
+	script := mysqlResolveClient
+	if clean {
+		script += fmt.Sprintf(`"$client" %s -e %s; `, mysqlConnFlags(c), shellQuote(fmt.Sprintf(
+			"DROP DATABASE IF EXISTS %s; CREATE DATABASE %s", quoteMyIdent(c.Database), quoteMyIdent(c.Database))))
+	}
+	script += fmt.Sprintf(`gzip -dc | "$client" %s %s`, mysqlConnFlags(c), shellQuote(c.Database))
+	return ops.ExecStream(container, wrapMy(c.Password, script), dump, io.Discard)
+}
+
+func (mysqlEngine) manifest() manifestSpec {
+	return manifestSpec{check: "table_count", noun: "tables", exact: true}
+}
+
+// countObjects counts db's base tables. Views are left out for the same
+// reason Postgres counts only relkind r/p: the number should mean "the
+// tenant's data".
+func (mysqlEngine) countObjects(ops ContainerOps, container string, c PgConn, db string) (int64, error) {
+	query := "SELECT COUNT(*) FROM information_schema.tables WHERE table_type = 'BASE TABLE' AND table_schema = " + quoteMyString(db)
+	stdout, stderr, err := ops.ExecWithOutput(container, wrapMy(c.Password, mysqlQuery(c, query)))
+	if err != nil {
+		return 0, fmt.Errorf("%s", engineErr(stderr, err))
+	}
+	return parseCount(stdout)
+}
+
+func (mysqlEngine) createScratch(ops ContainerOps, container string, c PgConn, name string) (string, error) {
+	_, stderr, err := ops.ExecWithOutput(container,
+		wrapMy(c.Password, mysqlQuery(c, "CREATE DATABASE "+quoteMyIdent(name))))
+	return stderr, err
+}
+
+func (e mysqlEngine) loadScratch(ops ContainerOps, container string, c PgConn, name string, dump io.Reader) (string, error) {
+	c.Database = name
+	return e.restore(ops, container, c, dump, false)
+}
+
+func (e mysqlEngine) countScratch(ops ContainerOps, container string, c PgConn, name string) (int64, error) {
+	return e.countObjects(ops, container, c, name)
+}
+
+func (mysqlEngine) dropScratch(ops ContainerOps, container string, c PgConn, name string) {
+	_, _, _ = ops.ExecWithOutput(container,
+		wrapMy(c.Password, mysqlQuery(c, "DROP DATABASE IF EXISTS "+quoteMyIdent(name))))
+}
+
+// mysqlConnFlags renders the connection flags both clients accept.
+func mysqlConnFlags(c PgConn) string {
+	return fmt.Sprintf("-h %s -P %d -u %s", shellQuote(c.Host), c.Port, shellQuote(c.User))
+}
+
+// mysqlQuery builds a single-statement client invocation in the
+// skip-column-names/batch form the callers parse.
+func mysqlQuery(c PgConn, sql string) string {
+	return mysqlResolveClient + fmt.Sprintf(`"$client" %s -N -B -e %s`, mysqlConnFlags(c), shellQuote(sql))
+}
+
+// wrapMy is wrapPg for the MySQL clients: the password travels as
+// MYSQL_PWD.
+func wrapMy(password, script string) []string {
+	return wrapEnv("MYSQL_PWD", password, script)
+}
+
+// quoteMyIdent backtick-quotes a MySQL identifier for use in DDL.
+func quoteMyIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+// quoteMyString single-quotes a MySQL string literal, escaping the
+// backslash as well as the quote since MySQL treats it as an escape.
+func quoteMyString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
@@ -0,0 +1,137 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// postgresEngine dumps with pg_dump's custom format (compressed,
+// selectively restorable) and restores with pg_restore.
+type postgresEngine struct{ anyDatabase }
+
+func (postgresEngine) tools() (string, string) { return "pg_dump", "pg_restore" }
+
+func (postgresEngine) defaults(c PgConn) PgConn {
+	if c.User == "" {
+		c.User = "postgres"
+	}
+	if c.Host == "" {
+		c.Host = "127.0.0.1"
+	}
+	if c.Port == 0 {
+		c.Port = 5432
+	}
+	return c
+}
+
+// listDatabases enumerates the non-template databases visible inside the
+// container's Postgres — the same connection info used for pg_dump/
+// pg_restore, just pointed at the always-present "postgres" maintenance
+// database to run a catalog query instead of a dump.
+func (postgresEngine) listDatabases(ops ContainerOps, container string, c PgConn) ([]string, error) {
+	const query = "SELECT datname FROM pg_database WHERE NOT datistemplate ORDER BY datname;"
+	stdout, stderr, err := ops.ExecWithOutput(container, wrapPg(c.Password, psqlCmd(c, "postgres", query)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+	return splitLines(stdout), nil
+}
+
+func (postgresEngine) dumpCmd(c PgConn) []string {
+	return wrapPg(c.Password, fmt.Sprintf(
+		"pg_dump -h %s -p %d -U %s -d %s -Fc",
+		shellQuote(c.Host), c.Port, shellQuote(c.User), shellQuote(c.Database),
+	))
+}
+
+func (postgresEngine) restore(ops ContainerOps, container string, c PgConn, dump io.Reader, clean bool) (string, error) {
+	cleanFlag := ""
+	if clean {
+		cleanFlag = " --clean --if-exists"
+	}
+	script := fmt.Sprintf(
+		"pg_restore -h %s -p %d -U %s -d %s%s",
+		shellQuote(c.Host), c.Port, shellQuote(c.User), shellQuote(c.Database), cleanFlag,
+	)
+	return ops.ExecStream(container, wrapPg(c.Password, script), dump, io.Discard)
+}
+
+func (postgresEngine) manifest() manifestSpec {
+	return manifestSpec{check: "relation_count", noun: "user relations", exact: true}
+}
+
+// userRelationQuery counts tables and partitioned tables belonging to the
+// *user's* schemas.
+//
+// Counting pg_class unfiltered is useless here: every database, however
+// empty, carries ~60 system catalog relations, so an unfiltered count can
+// never reach zero and a check against it would pass for a dump that
+// restored nothing at all. Excluding pg_catalog / information_schema /
+// pg_toast is what makes the number mean "the tenant's data".
+const userRelationQuery = `SELECT count(*) FROM pg_class c ` +
+	`JOIN pg_namespace n ON n.oid = c.relnamespace ` +
+	`WHERE c.relkind IN ('r','p') ` +
+	`AND n.nspname NOT IN ('pg_catalog','information_schema') ` +
+	`AND n.nspname !~ '^pg_toast';`
+
+// countObjects runs userRelationQuery against db inside container.
+func (postgresEngine) countObjects(ops ContainerOps, container string, c PgConn, db string) (int64, error) {
+	stdout, stderr, err := ops.ExecWithOutput(container, wrapPg(c.Password, psqlCmd(c, db, userRelationQuery)))
+	if err != nil {
+		return 0, fmt.Errorf("%s", engineErr(stderr, err))
+	}
+	return parseCount(stdout)
+}
+
+func (postgresEngine) createScratch(ops ContainerOps, container string, c PgConn, name string) (string, error) {
+	_, stderr, err := ops.ExecWithOutput(container,
+		wrapPg(c.Password, psqlCmd(c, "postgres", fmt.Sprintf("CREATE DATABASE %s", quoteIdent(name)))))
+	return stderr, err
+}
+
+func (e postgresEngine) loadScratch(ops ContainerOps, container string, c PgConn, name string, dump io.Reader) (string, error) {
+	c.Database = name
+	return e.restore(ops, container, c, dump, false)
+}
+
+func (e postgresEngine) countScratch(ops ContainerOps, container string, c PgConn, name string) (int64, error) {
+	return e.countObjects(ops, container, c, name)
+}
+
+func (postgresEngine) dropScratch(ops ContainerOps, container string, c PgConn, name string) {
+	_, _, _ = ops.ExecWithOutput(container,
+		wrapPg(c.Password, psqlCmd(c, "postgres", fmt.Sprintf("DROP DATABASE IF EXISTS %s", quoteIdent(name)))))
+}
+
+// quoteIdent double-quotes a Postgres identifier for use in DDL.
+// scratchName already restricts the character set; this is belt-and-braces
+// against the name ever becoming caller-controlled.
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// psqlCmd builds a single-statement psql invocation against db, in the
+// tuples-only/unaligned form the callers parse.
+func psqlCmd(conn PgConn, db, sql string) string {
+	return fmt.Sprintf("psql -h %s -p %d -U %s -d %s -Atc %s",
+		shellQuote(conn.Host), conn.Port, shellQuote(conn.User),
+		shellQuote(db), shellQuote(sql))
+}
+
+// wrapPg builds the bash invocation that exports PGPASSWORD (when set)
+// and runs the given pg command under strict mode. Returns the argv for
+// ExecWithOutput.
+func wrapPg(password, pgCmd string) []string {
+	return wrapEnv("PGPASSWORD", password, pgCmd)
+}
+
+// parseCount reads the single integer a count query prints.
+func parseCount(stdout string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unreadable count %q", strings.TrimSpace(stdout))
+	}
+	return n, nil
+}
@@ -0,0 +1,90 @@
+package backup
+
+import "io"
+
+// Phase names the stage of a backup operation a Progress report is for.
+type Phase string
+
+const (
+	PhaseDump     Phase = "dump"     // pg_dump streaming out of the container
+	PhaseUpload   Phase = "upload"   // staged dump shipping to an object store
+	PhaseDownload Phase = "download" // off-host dump fetching for restore/verify
+	PhaseRestore  Phase = "restore"  // dump streaming into pg_restore
+)
+
+// Progress is one report on a running backup operation. Total is zero
+// when the size is not known up front (a dump in flight); Done marks the
+// last report for a phase.
+type Progress struct {
+	BackupID string
+	Phase    Phase
+	Bytes    int64
+	Total    int64
+	Done     bool
+}
+
+// ProgressFunc receives Progress reports. It is called synchronously on
+// the streaming path, so it must not block.
+type ProgressFunc func(Progress)
+
+// progressInterval is how many bytes pass between reports. Coarse on
+// purpose: a report per write would flood the events bus on a large dump.
+const progressInterval = 4 << 20
+
+func report(fn ProgressFunc, p Progress) {
+	if fn != nil {
+		fn(p)
+	}
+}
+
+// progressCounter counts bytes through a stream and reports every
+// progressInterval, plus once more when done is called.
+type progressCounter struct {
+	fn     ProgressFunc
+	id     string
+	phase  Phase
+	total  int64
+	n      int64
+	lastAt int64
+}
+
+func (c *progressCounter) add(n int) {
+	c.n += int64(n)
+	if c.fn != nil && c.n-c.lastAt >= progressInterval {
+		c.lastAt = c.n
+		c.fn(Progress{BackupID: c.id, Phase: c.phase, Bytes: c.n, Total: c.total})
+	}
+}
+
+func (c *progressCounter) done() {
+	report(c.fn, Progress{BackupID: c.id, Phase: c.phase, Bytes: c.n, Total: c.total, Done: true})
+}
+
+// progressWriter is an io.Writer sink that only counts, for use as one
+// leg of an io.MultiWriter.
+type progressWriter struct{ progressCounter }
+
+func newProgressWriter(fn ProgressFunc, id string, phase Phase, total int64) *progressWriter {
+	return &progressWriter{progressCounter{fn: fn, id: id, phase: phase, total: total}}
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.add(len(p))
+	return len(p), nil
+}
+
+// progressReader counts bytes read through r.
+type progressReader struct {
+	progressCounter
+	r io.Reader
+}
+
+func newProgressReader(r io.Reader, fn ProgressFunc, id string, phase Phase, total int64) *progressReader {
+	return &progressReader{progressCounter: progressCounter{fn: fn, id: id, phase: phase, total: total}, r: r}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.add(n)
+	return n, err
+}
@@ -0,0 +1,202 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// redisDataset is the database name a Redis backup is recorded under. An
+// RDB snapshot holds the whole instance — every numbered database — so
+// there is nothing finer to choose.
+const redisDataset = "redis"
+
+// redisEngine backs up Redis as an RDB snapshot: BGSAVE, wait for it to
+// land, stream the file. The password travels as REDISCLI_AUTH; a
+// non-default ACL user is passed with --user.
+//
+// An RDB can only be loaded whole, so restore always replaces the
+// instance's entire dataset and requires clean as the caller's
+// acknowledgement. The file is put in place and loaded with DEBUG RELOAD
+// NOSAVE, which needs Redis 6.2 or newer and, from Redis 7, the debug
+// command enabled for local connections (enable-debug-command local).
+//
+// A restore test starts a throwaway redis-server in the target container
+// on a unix socket only (no TCP port) with the dump as its RDB: a dump
+// that is not loadable stops that server from starting at all.
+type redisEngine struct{}
+
+// redisBgsaveTimeout bounds the wait for BGSAVE, in seconds. A snapshot
+// that takes longer is almost certainly stuck behind a failing fork.
+const redisBgsaveTimeout = 3600
+
+// redisScratchStartTimeout bounds the wait for a scratch server to load
+// its RDB, in seconds.
+const redisScratchStartTimeout = 600
+
+func (redisEngine) tools() (string, string) { return "redis BGSAVE", "redis restore" }
+
+func (redisEngine) defaults(c PgConn) PgConn {
+	if c.Database == "" {
+		c.Database = redisDataset
+	}
+	if c.Host == "" {
+		c.Host = "127.0.0.1"
+	}
+	if c.Port == 0 {
+		c.Port = 6379
+	}
+	return c
+}
+
+func (redisEngine) checkDatabase(db string) error {
+	if db != redisDataset {
+		return fmt.Errorf("a Redis backup covers the whole instance: leave database empty (or %q), got %q", redisDataset, db)
+	}
+	return nil
+}
+
+func (redisEngine) checkRestore(clean bool) error {
+	if !clean {
+		return fmt.Errorf("a Redis restore replaces the instance's entire dataset: set clean to confirm")
+	}
+	return nil
+}
+
+// listDatabases confirms the server answers and returns the single
+// dataset, so CreateAll fails with the connection error rather than
+// dumping nothing.
+func (redisEngine) listDatabases(ops ContainerOps, container string, c PgConn) ([]string, error) {
+	stdout, stderr, err := ops.ExecWithOutput(container, wrapRedis(c, "cli PING"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+	if got := strings.TrimSpace(stdout); got != "PONG" {
+		return nil, fmt.Errorf("redis did not answer PING: %s", got)
+	}
+	return []string{redisDataset}, nil
+}
+
+// dumpCmd triggers a BGSAVE and, once LASTSAVE moves past the value read
+// before it and the save reports ok, streams the RDB file. Redis writes
+// the snapshot to a temp file and renames it into place, so the file read
+// is always a complete snapshot. BGSAVE SCHEDULE queues behind an AOF
+// rewrite instead of failing. LASTSAVE has one-second resolution, so the
+// one-second pause before BGSAVE keeps a save finishing in the same
+// second as the previous one from looking like no save at all.
+func (redisEngine) dumpCmd(c PgConn) []string {
+	script := fmt.Sprintf(`before=$(cli LASTSAVE); sleep 1; cli BGSAVE SCHEDULE >/dev/null; i=0; `+
+		`while [ "$(cli LASTSAVE)" = "$before" ]; do `+
+		`i=$((i+1)); [ $i -le %d ] || { echo "BGSAVE did not complete within %ds" >&2; exit 1; }; sleep 1; done; `+
+		`cli INFO persistence | grep '^rdb_last_bgsave_status:ok' >/dev/null || { echo "BGSAVE failed: $(cli INFO persistence | grep rdb_last_bgsave_status)" >&2; exit 1; }; `+
+		`dir=$(cli CONFIG GET dir | sed -n 2p); file=$(cli CONFIG GET dbfilename | sed -n 2p); `+
+		`cat "$dir/$file"`,
+		redisBgsaveTimeout, redisBgsaveTimeout)
+	return wrapRedis(c, script)
+}
+
+// restore writes the RDB next to the server's own, renames it over it,
+// and reloads without saving first (which would overwrite it with the
+// current data). With AOF enabled the server would otherwise replay the
+// old AOF on its next restart, so the AOF is rewritten from the restored
+// dataset.
+func (redisEngine) restore(ops ContainerOps, container string, c PgConn, dump io.Reader, _ bool) (string, error) {
+	script := `dir=$(cli CONFIG GET dir | sed -n 2p); file=$(cli CONFIG GET dbfilename | sed -n 2p); ` +
+		`tmp="$dir/.containarium-restore.rdb"; cat > "$tmp"; ` +
+		`chown --reference="$dir" "$tmp" 2>/dev/null || true; mv -f "$tmp" "$dir/$file"; ` +
+		`out=$(cli DEBUG RELOAD NOSAVE 2>&1); [ "$out" = "OK" ] || ` +
+		`{ echo "DEBUG RELOAD NOSAVE failed: $out (Redis 7+ needs enable-debug-command local)" >&2; exit 1; }; ` +
+		`if [ "$(cli CONFIG GET appendonly | sed -n 2p)" = "yes" ]; then cli BGREWRITEAOF >/dev/null; fi`
+	return ops.ExecStream(container, wrapRedis(c, script), dump, io.Discard)
+}
+
+func (redisEngine) manifest() manifestSpec {
+	// Keys change between the snapshot and the count taken after it, so
+	// the count is evidence, not a threshold.
+	return manifestSpec{check: "key_count", noun: "keys", exact: false}
+}
+
+func (redisEngine) countObjects(ops ContainerOps, container string, c PgConn, _ string) (int64, error) {
+	stdout, stderr, err := ops.ExecWithOutput(container, wrapRedis(c, "cli INFO keyspace"))
+	if err != nil {
+		return 0, fmt.Errorf("%s", engineErr(stderr, err))
+	}
+	return parseKeyspace(stdout)
+}
+
+func (redisEngine) createScratch(ops ContainerOps, container string, _ PgConn, name string) (string, error) {
+	dir := shellQuote(scratchDir(name))
+	_, stderr, err := ops.ExecWithOutput(container, []string{"bash", "-c", fmt.Sprintf(
+		`set -euo pipefail; command -v redis-server >/dev/null || { echo "redis-server not found in the target container" >&2; exit 127; }; `+
+			`rm -rf %s; mkdir -m 700 %s`, dir, dir)})
+	return stderr, err
+}
+
+// loadScratch writes the RDB into the scratch directory and starts a
+// server on it, waiting until it answers PING (it answers LOADING while
+// the RDB loads). A server that exits instead leaves its log as the
+// engine error.
+func (redisEngine) loadScratch(ops ContainerOps, container string, _ PgConn, name string, dump io.Reader) (string, error) {
+	dir := scratchDir(name)
+	sock := shellQuote(dir + "/redis.sock")
+	script := fmt.Sprintf(`set -euo pipefail; cat > %[1]s/dump.rdb; `+
+		`redis-server --port 0 --unixsocket %[2]s --unixsocketperm 700 --dir %[1]s --dbfilename dump.rdb `+
+		`--appendonly no --save '' --daemonize yes --pidfile %[1]s/redis.pid --logfile %[1]s/redis.log; `+
+		`for i in $(seq 1 %[3]d); do `+
+		`[ "$(redis-cli -s %[2]s PING 2>/dev/null)" = "PONG" ] && exit 0; sleep 1; done; `+
+		`tail -n 20 %[1]s/redis.log >&2; exit 1`,
+		shellQuote(dir), sock, redisScratchStartTimeout)
+	return ops.ExecStream(container, []string{"bash", "-c", script}, dump, io.Discard)
+}
+
+func (redisEngine) countScratch(ops ContainerOps, container string, _ PgConn, name string) (int64, error) {
+	sock := shellQuote(scratchDir(name) + "/redis.sock")
+	stdout, stderr, err := ops.ExecWithOutput(container, []string{"bash", "-c", "redis-cli -s " + sock + " INFO keyspace"})
+	if err != nil {
+		return 0, fmt.Errorf("%s", engineErr(stderr, err))
+	}
+	return parseKeyspace(stdout)
+}
+
+func (redisEngine) dropScratch(ops ContainerOps, container string, _ PgConn, name string) {
+	dir := shellQuote(scratchDir(name))
+	_, _, _ = ops.ExecWithOutput(container, []string{"bash", "-c", fmt.Sprintf(
+		`redis-cli -s %s/redis.sock SHUTDOWN NOSAVE >/dev/null 2>&1 || true; rm -rf %s`, dir, dir)})
+}
+
+// wrapRedis runs script with a cli function bound to the connection, in
+// raw output mode so replies parse line by line.
+func wrapRedis(c PgConn, script string) []string {
+	user := ""
+	if c.User != "" {
+		user = " --user " + shellQuote(c.User)
+	}
+	fn := fmt.Sprintf(`cli() { redis-cli -h %s -p %d%s --raw "$@"; }; `, shellQuote(c.Host), c.Port, user)
+	return wrapEnv("REDISCLI_AUTH", c.Password, fn+script)
+}
+
+// parseKeyspace sums the keys= counts of INFO keyspace ("db0:keys=12,
+// expires=0,avg_ttl=0"). An empty keyspace section is zero keys.
+func parseKeyspace(info string) (int64, error) {
+	var total int64
+	for _, line := range splitLines(info) {
+		if !strings.HasPrefix(line, "db") {
+			continue
+		}
+		_, fields, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		for _, kv := range strings.Split(fields, ",") {
+			if v, ok := strings.CutPrefix(kv, "keys="); ok {
+				n, err := strconv.ParseInt(v, 10, 64)
+				if err != nil {
+					return 0, fmt.Errorf("unreadable keyspace line %q", line)
+				}
+				total += n
+			}
+		}
+	}
+	return total, nil
+}
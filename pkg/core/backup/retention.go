@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Retention is a grandfather-father-son policy: keep the newest backup
+// of each of the last Daily days, the last Weekly ISO weeks and the last
+// Monthly months that have a backup at all. A backup kept by any tier is
+// kept. Counted per database, over scheduled backups only — an on-demand
+// backup is something an operator asked for by hand, and a schedule
+// never deletes it.
+//
+// The zero value keeps everything.
+type Retention struct {
+	Daily   int `json:"daily,omitempty"`
+	Weekly  int `json:"weekly,omitempty"`
+	Monthly int `json:"monthly,omitempty"`
+}
+
+// IsZero reports whether the policy keeps everything.
+func (r Retention) IsZero() bool { return r.Daily == 0 && r.Weekly == 0 && r.Monthly == 0 }
+
+func (r Retention) validate() error {
+	if r.Daily < 0 || r.Weekly < 0 || r.Monthly < 0 {
+		return fmt.Errorf("retention counts must not be negative")
+	}
+	return nil
+}
+
+// Expired returns the records retention r would delete from records,
+// oldest first. Records that are not Scheduled are never returned, and
+// neither is anything when r is the zero value.
+//
+// Tiers are filled from the newest backup backwards by calendar bucket
+// (UTC day, ISO week, month), so days without a backup — a failed run, a
+// daemon that was down — do not use up a slot: "keep 7 daily" keeps the
+// last seven days that actually have a backup.
+func Expired(records []*Record, r Retention) []*Record {
+	if r.IsZero() {
+		return nil
+	}
+	groups := map[string][]*Record{}
+	for _, rec := range records {
+		if !rec.Scheduled {
+			continue
+		}
+		key := rec.Username + "\x00" + rec.Database
+		groups[key] = append(groups[key], rec)
+	}
+
+	var out []*Record
+	for _, g := range groups {
+		sort.Slice(g, func(i, j int) bool { return g[i].CreatedAt.After(g[j].CreatedAt) })
+		keep := make(map[*Record]bool, len(g))
+		tier := func(n int, bucket func(*Record) string) {
+			seen := map[string]bool{}
+			for _, rec := range g {
+				if len(seen) >= n {
+					return
+				}
+				b := bucket(rec)
+				if !seen[b] {
+					seen[b] = true
+					keep[rec] = true
+				}
+			}
+		}
+		tier(r.Daily, func(rec *Record) string { return rec.CreatedAt.UTC().Format("2006-01-02") })
+		tier(r.Weekly, func(rec *Record) string {
+			y, w := rec.CreatedAt.UTC().ISOWeek()
+			return fmt.Sprintf("%d-W%02d", y, w)
+		})
+		tier(r.Monthly, func(rec *Record) string { return rec.CreatedAt.UTC().Format("2006-01") })
+
+		for _, rec := range g {
+			if !keep[rec] {
+				out = append(out, rec)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dailyRecords returns one scheduled backup of alice/app per day for n
+// days ending on end, newest first.
+func dailyRecords(end time.Time, n int) []*Record {
+	var out []*Record
+	for i := 0; i < n; i++ {
+		ts := end.AddDate(0, 0, -i)
+		out = append(out, &Record{
+			ID:        fmt.Sprintf("alice-app-%s", ts.Format("20060102")),
+			Username:  "alice",
+			Database:  "app",
+			CreatedAt: ts,
+			Scheduled: true,
+		})
+	}
+	return out
+}
+
+func ids(rs []*Record) []string {
+	var out []string
+	for _, r := range rs {
+		out = append(out, r.ID)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestExpiredKeepsDailyAndWeekly(t *testing.T) {
+	// 2026-06-07 is a Sunday; sixty days of nightly backups before it.
+	end := time.Date(2026, 6, 7, 2, 0, 0, 0, time.UTC)
+	records := dailyRecords(end, 60)
+
+	expired := Expired(records, Retention{Daily: 7, Weekly: 4})
+	kept := map[string]bool{}
+	for _, r := range records {
+		kept[r.ID] = true
+	}
+	for _, r := range expired {
+		delete(kept, r.ID)
+	}
+
+	// 7 dailies (06-01..06-07, the whole current ISO week) plus the
+	// newest of each of the three earlier weeks: Sundays 05-31, 05-24
+	// and 05-17.
+	want := []string{
+		"alice-app-20260517", "alice-app-20260524", "alice-app-20260531", "alice-app-20260601",
+		"alice-app-20260602", "alice-app-20260603", "alice-app-20260604",
+		"alice-app-20260605", "alice-app-20260606", "alice-app-20260607",
+	}
+	var got []string
+	for id := range kept {
+		got = append(got, id)
+	}
+	sort.Strings(got)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("kept %v\nwant %v", got, want)
+	}
+	// Oldest first, so a partial prune removes the least valuable backups.
+	for i := 1; i < len(expired); i++ {
+		if expired[i].CreatedAt.Before(expired[i-1].CreatedAt) {
+			t.Fatalf("expired not ordered oldest first")
+		}
+	}
+}
+
+func TestExpiredMonthly(t *testing.T) {
+	end := time.Date(2026, 6, 7, 2, 0, 0, 0, time.UTC)
+	records := dailyRecords(end, 100)
+	expired := Expired(records, Retention{Monthly: 3})
+	if got := len(records) - len(expired); got != 3 {
+		t.Fatalf("kept %d, want 3", got)
+	}
+	kept := map[string]bool{}
+	for _, r := range records {
+		kept[r.ID] = true
+	}
+	for _, r := range expired {
+		delete(kept, r.ID)
+	}
+	for _, id := range []string{"alice-app-20260607", "alice-app-20260531", "alice-app-20260430"} {
+		if !kept[id] {
+			t.Errorf("%s should be kept as its month's newest; kept=%v", id, kept)
+		}
+	}
+}
+
+func TestExpiredIgnoresManualAndZeroPolicy(t *testing.T) {
+	end := time.Date(2026, 6, 7, 2, 0, 0, 0, time.UTC)
+	records := dailyRecords(end, 10)
+	records[9].Scheduled = false // an old on-demand backup
+
+	if got := Expired(records, Retention{}); got != nil {
+		t.Errorf("zero policy expired %v", ids(got))
+	}
+	for _, r := range Expired(records, Retention{Daily: 1}) {
+		if !r.Scheduled {
+			t.Errorf("manual backup %s must never be pruned", r.ID)
+		}
+	}
+}
+
+func TestExpiredCountsPerDatabase(t *testing.T) {
+	end := time.Date(2026, 6, 7, 2, 0, 0, 0, time.UTC)
+	records := dailyRecords(end, 3)
+	for _, r := range dailyRecords(end, 3) {
+		r.Database = "billing"
+		r.ID = strings.Replace(r.ID, "-app-", "-billing-", 1)
+		records = append(records, r)
+	}
+	expired := Expired(records, Retention{Daily: 2})
+	want := []string{"alice-app-20260605", "alice-billing-20260605"}
+	if got := ids(expired); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expired %v, want %v", got, want)
+	}
+}
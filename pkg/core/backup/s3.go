@@ -0,0 +1,514 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultS3PartSize is the multipart chunk size. Dumps at or below it
+	// go up in a single PUT. S3 requires every part but the last to be at
+	// least 5 MiB.
+	DefaultS3PartSize int64 = 16 << 20
+	minS3PartSize     int64 = 5 << 20
+
+	// s3RequestTimeout bounds one request whose body is held in memory — a
+	// single PUT, one part, a create/complete/abort or a delete — from dial
+	// to the last byte of the response. A hung endpoint otherwise holds the
+	// backup job (and its schedule slot) forever.
+	s3RequestTimeout = 10 * time.Minute
+	// s3StallTimeout bounds how long a Download may go without receiving a
+	// byte. The transfer as a whole is not bounded: a dump can be any size.
+	s3StallTimeout = 2 * time.Minute
+)
+
+// S3Config points an S3Uploader at an S3-compatible endpoint. The same
+// client speaks to AWS, MinIO, Cloudflare R2 and Ceph RGW; they differ
+// only in Endpoint, Region and addressing style.
+type S3Config struct {
+	// Endpoint is the service base URL, e.g. "http://minio.internal:9000"
+	// or "https://<account>.r2.cloudflarestorage.com". Empty means AWS
+	// ("https://s3.<region>.amazonaws.com").
+	Endpoint string
+	// Region is the SigV4 signing region. R2 uses "auto"; MinIO and RGW
+	// accept anything, conventionally "us-east-1".
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional, for STS temporary credentials
+	// PathStyle addresses objects as <endpoint>/<bucket>/<key> instead of
+	// <bucket>.<endpoint>/<key>. Self-hosted stores generally need it.
+	PathStyle bool
+	// PartSize overrides DefaultS3PartSize.
+	PartSize int64
+}
+
+// S3ConfigFromEnv reads the daemon's S3 settings:
+//
+//	CONTAINARIUM_BACKUP_S3_ENDPOINT    service URL (empty → AWS)
+//	CONTAINARIUM_BACKUP_S3_REGION      signing region (default us-east-1)
+//	CONTAINARIUM_BACKUP_S3_PATH_STYLE  "true"/"false" (default: true when an endpoint is set)
+//	AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN
+//
+// Credentials use the standard AWS variable names so an operator's
+// existing environment (or systemd EnvironmentFile) works unchanged.
+func S3ConfigFromEnv() S3Config {
+	cfg := S3Config{
+		Endpoint:        os.Getenv("CONTAINARIUM_BACKUP_S3_ENDPOINT"),
+		Region:          os.Getenv("CONTAINARIUM_BACKUP_S3_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	cfg.PathStyle = cfg.Endpoint != ""
+	if v, err := strconv.ParseBool(os.Getenv("CONTAINARIUM_BACKUP_S3_PATH_STYLE")); err == nil {
+		cfg.PathStyle = v
+	}
+	return cfg
+}
+
+// S3Uploader implements Uploader against the S3 REST API with SigV4
+// request signing over net/http — like GcloudUploader, it avoids pulling
+// a cloud SDK into the daemon. URIs are "s3://<bucket>/<key>".
+//
+// Integrity is checked by the server, not just recorded: every PUT and
+// UploadPart carries an x-amz-checksum-sha256 of its body (and the signed
+// x-amz-content-sha256), so a corrupted transfer is rejected with
+// BadDigest instead of being stored.
+type S3Uploader struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+	timeout  time.Duration // per request; s3RequestTimeout
+	stall    time.Duration // per Download read; s3StallTimeout
+}
+
+// NewS3Uploader validates cfg. It does not contact the endpoint, so a
+// daemon starts even while the object store is briefly unreachable.
+func NewS3Uploader(cfg S3Config) (*S3Uploader, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 credentials are not configured (AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY)")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	ep, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || (ep.Scheme != "http" && ep.Scheme != "https") || ep.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.PartSize == 0 {
+		cfg.PartSize = DefaultS3PartSize
+	}
+	if cfg.PartSize < minS3PartSize {
+		return nil, fmt.Errorf("s3 part size %d is below the 5 MiB minimum", cfg.PartSize)
+	}
+	return &S3Uploader{
+		cfg:      cfg,
+		endpoint: ep,
+		client:   &http.Client{},
+		now:      time.Now,
+		timeout:  s3RequestTimeout,
+		stall:    s3StallTimeout,
+	}, nil
+}
+
+func (s *S3Uploader) Upload(localPath, destURI string) error {
+	bucket, key, err := parseS3URI(destURI)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(localPath) // #nosec G304 -- localPath is the manager's staged dump under its backup dir
+	if err != nil {
+		return fmt.Errorf("open %s: %w", localPath, err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", localPath, err)
+	}
+
+	if info.Size() <= s.cfg.PartSize {
+		body, err := io.ReadAll(f)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", localPath, err)
+		}
+		_, err = s.do(http.MethodPut, bucket, key, nil, body, checksumHeader(body))
+		return err
+	}
+	return s.uploadMultipart(bucket, key, f)
+}
+
+// uploadMultipart streams r in PartSize chunks, aborting the upload on
+// any failure so a half-written object never lingers as billable,
+// invisible parts.
+func (s *S3Uploader) uploadMultipart(bucket, key string, r io.Reader) error {
+	resp, err := s.do(http.MethodPost, bucket, key, url.Values{"uploads": {""}}, nil,
+		http.Header{"X-Amz-Checksum-Algorithm": {"SHA256"}})
+	if err != nil {
+		return fmt.Errorf("create multipart upload: %w", err)
+	}
+	var created struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(resp, &created); err != nil || created.UploadID == "" {
+		return fmt.Errorf("create multipart upload: unexpected response %q", truncate(resp))
+	}
+	uploadID := created.UploadID
+
+	abort := func(cause error) error {
+		if _, err := s.do(http.MethodDelete, bucket, key, url.Values{"uploadId": {uploadID}}, nil, nil); err != nil {
+			return fmt.Errorf("%w (abort also failed: %v)", cause, err)
+		}
+		return cause
+	}
+
+	var parts []completedPart
+	buf := make([]byte, s.cfg.PartSize)
+	for n := 1; ; n++ {
+		read, rerr := io.ReadFull(r, buf)
+		if read == 0 && rerr != nil {
+			if rerr == io.EOF {
+				break
+			}
+			return abort(fmt.Errorf("read dump: %w", rerr))
+		}
+		body := buf[:read]
+		sumHdr := checksumHeader(body)
+		q := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadID}}
+		etag, err := s.doPart(bucket, key, q, body, sumHdr)
+		if err != nil {
+			return abort(fmt.Errorf("upload part %d: %w", n, err))
+		}
+		parts = append(parts, completedPart{Number: n, ETag: etag, ChecksumSHA256: sumHdr.Get("X-Amz-Checksum-Sha256")})
+		if rerr != nil { // short final part
+			break
+		}
+	}
+
+	doc, err := xml.Marshal(completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return abort(fmt.Errorf("encode completion: %w", err))
+	}
+	resp, err = s.do(http.MethodPost, bucket, key, url.Values{"uploadId": {uploadID}}, doc,
+		http.Header{"Content-Type": {"application/xml"}})
+	if err != nil {
+		return abort(fmt.Errorf("complete multipart upload: %w", err))
+	}
+	// CompleteMultipartUpload can fail after a 200 — the error arrives in
+	// the body once the server has finished assembling the parts.
+	if e := parseS3Error(resp); e != nil {
+		return abort(fmt.Errorf("complete multipart upload: %w", e))
+	}
+	return nil
+}
+
+func (s *S3Uploader) Download(destURI, localPath string) error {
+	bucket, key, err := parseS3URI(destURI)
+	if err != nil {
+		return err
+	}
+	req, err := s.newRequest(http.MethodGet, bucket, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	// The whole GET is cancelled once it goes s.stall without progress:
+	// the watchdog is armed for the response head and re-armed by every
+	// read of the body.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchdog := time.AfterFunc(s.stall, cancel)
+	defer watchdog.Stop()
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("s3 GET %s: %w", destURI, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	f, err := os.OpenFile(localPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) // #nosec G304 -- manager-chosen temp path under its backup dir
+	if err != nil {
+		return fmt.Errorf("create %s: %w", localPath, err)
+	}
+	body := &stallReader{r: resp.Body, watchdog: watchdog, stall: s.stall}
+	if _, err := io.Copy(f, body); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("s3 GET %s: %w", destURI, err)
+	}
+	return f.Close()
+}
+
+func (s *S3Uploader) Delete(destURI string) error {
+	bucket, key, err := parseS3URI(destURI)
+	if err != nil {
+		return err
+	}
+	_, err = s.do(http.MethodDelete, bucket, key, nil, nil, nil)
+	return err
+}
+
+// do sends one signed request and returns the response body, turning a
+// non-2xx status into an error carrying S3's Code and Message.
+func (s *S3Uploader) do(method, bucket, key string, query url.Values, body []byte, hdr http.Header) ([]byte, error) {
+	req, err := s.newRequest(method, bucket, key, query, body, hdr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return nil, responseError(resp)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s: %w", method, err)
+	}
+	return b, nil
+}
+
+// doPart is do for UploadPart, which reports the part's ETag in a header.
+func (s *S3Uploader) doPart(bucket, key string, query url.Values, body []byte, hdr http.Header) (string, error) {
+	req, err := s.newRequest(http.MethodPut, bucket, key, query, body, hdr)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("s3 PUT: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(resp)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+func (s *S3Uploader) newRequest(method, bucket, key string, query url.Values, body []byte, hdr http.Header) (*http.Request, error) {
+	host, path := s.endpoint.Host, s.endpoint.Path+"/"+bucket+"/"+key
+	if !s.cfg.PathStyle {
+		host, path = bucket+"."+s.endpoint.Host, s.endpoint.Path+"/"+key
+	}
+	// Encode the path ourselves: net/url keeps it as RawPath, so the path
+	// on the wire is byte-for-byte the one that was signed.
+	raw := s.endpoint.Scheme + "://" + host + s3EscapePath(path)
+	if q := canonicalQuery(query); q != "" {
+		raw += "?" + q
+	}
+
+	req, err := http.NewRequest(method, raw, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build s3 request: %w", err)
+	}
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+	s.sign(req, body)
+	return req, nil
+}
+
+// sign adds SigV4 headers (Authorization, X-Amz-Date,
+// X-Amz-Content-Sha256 and, with STS credentials, X-Amz-Security-Token).
+// Every header already on the request is signed.
+func (s *S3Uploader) sign(req *http.Request, body []byte) {
+	t := s.now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	day := t.Format("20060102")
+	payloadHash := hexSHA256(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonHeaders strings.Builder
+	for _, k := range names {
+		canonHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery,
+		canonHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.cfg.Region + "/s3/aws4_request"
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256([]byte(canonical))
+	k := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), day)
+	k = hmacSHA256(k, s.cfg.Region)
+	k = hmacSHA256(k, "s3")
+	k = hmacSHA256(k, "aws4_request")
+	sig := hex.EncodeToString(hmacSHA256(k, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, sig))
+}
+
+// stallReader re-arms a watchdog timer whenever a read returns data.
+type stallReader struct {
+	r        io.Reader
+	watchdog *time.Timer
+	stall    time.Duration
+}
+
+func (r *stallReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.watchdog.Reset(r.stall)
+	}
+	return n, err
+}
+
+type completedPart struct {
+	Number         int    `xml:"PartNumber"`
+	ETag           string `xml:"ETag"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string { return e.Code + ": " + e.Message }
+
+// parseS3Error returns the <Error> document in b, or nil if b is not one.
+func parseS3Error(b []byte) error {
+	var doc struct {
+		XMLName xml.Name
+		s3Error
+	}
+	if xml.Unmarshal(b, &doc) != nil || doc.XMLName.Local != "Error" {
+		return nil
+	}
+	return &doc.s3Error
+}
+
+func responseError(resp *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if e := parseS3Error(b); e != nil {
+		return fmt.Errorf("s3 %s %s: %s: %w", resp.Request.Method, resp.Request.URL.Path, resp.Status, e)
+	}
+	return fmt.Errorf("s3 %s %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status)
+}
+
+// checksumHeader is the x-amz-checksum-sha256 header for body: the
+// base64 (not hex) SHA-256 the server recomputes and rejects on mismatch.
+func checksumHeader(body []byte) http.Header {
+	sum := sha256.Sum256(body)
+	return http.Header{"X-Amz-Checksum-Sha256": {base64.StdEncoding.EncodeToString(sum[:])}}
+}
+
+// parseS3URI splits "s3://bucket/key". Both parts are required.
+func parseS3URI(uri string) (bucket, key string, err error) {
+	rest, ok := strings.CutPrefix(uri, "s3://")
+	if !ok {
+		return "", "", fmt.Errorf("expected an s3:// URI, got %q", uri)
+	}
+	bucket, key, _ = strings.Cut(rest, "/")
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("s3 URI %q must name a bucket and an object key", uri)
+	}
+	return bucket, key, nil
+}
+
+// s3EscapePath percent-encodes everything but RFC 3986 unreserved
+// characters and "/", which is the encoding SigV4 signs for S3.
+func s3EscapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || isUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// canonicalQuery renders q sorted by key with SigV4 encoding ("%20", not
+// "+"). A key with an empty value is written "key=", as S3 signs it.
+func canonicalQuery(q url.Values) string {
+	if len(q) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			parts = append(parts, s3EscapeQuery(k)+"="+s3EscapeQuery(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func s3EscapeQuery(s string) string {
+	return strings.ReplaceAll(s3EscapePath(s), "/", "%2F")
+}
+
+func isUnreserved(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '~'
+}
+
+func hexSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func truncate(b []byte) string {
+	if len(b) > 200 {
+		return string(b[:200]) + "..."
+	}
+	return string(b)
+}
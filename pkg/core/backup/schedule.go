@@ -0,0 +1,253 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schedule is a tenant's recurring backup: which databases to dump, when,
+// where to, how many to keep, and whether to restore-test each run. One
+// per tenant container, persisted as JSON under the backup directory's
+// schedules/ subdirectory so it survives daemon restarts without a
+// database dependency — the same reasoning as the record sidecars.
+type Schedule struct {
+	Username string `json:"username"`
+	// Cron is a five-field cron expression evaluated in UTC (see Cron).
+	Cron string `json:"cron"`
+	// Engine is the database engine to dump; empty (a schedule set before
+	// there was a choice) means EnginePostgres.
+	Engine string `json:"engine,omitempty"`
+	// Databases to dump each run. Empty backs up every non-template
+	// database found, as CreateAll does.
+	Databases []string `json:"databases,omitempty"`
+
+	// Connection parameters inside the container. There is deliberately
+	// no password: a schedule is persisted on the host, and a database
+	// password at rest in the backup directory is a credential leak
+	// waiting for a misconfigured backup tier. Scheduled dumps rely on
+	// trust/peer auth or a credentials file inside the container
+	// (PGPASSFILE, a MySQL option file); a scheduled Redis backup needs a
+	// server that accepts the default user without a password.
+	DBUser string `json:"db_user,omitempty"`
+	DBHost string `json:"db_host,omitempty"`
+	DBPort int    `json:"db_port,omitempty"`
+
+	Destination Destination `json:"destination"`
+	GCSBucket   string      `json:"gcs_bucket,omitempty"`
+	S3Bucket    string      `json:"s3_bucket,omitempty"`
+	Retention   Retention   `json:"retention"`
+
+	// VerifyTarget, when set, is the tenant whose container each new
+	// backup is restore-tested in after the run. Must differ from
+	// Username, for the same reason Verify refuses the source container.
+	VerifyTarget string `json:"verify_target,omitempty"`
+
+	// UpdatedAt is when the schedule was last set. The first run is the
+	// first cron time after it, so setting a schedule never triggers a
+	// catch-up run for slots before it existed.
+	UpdatedAt time.Time `json:"updated_at"`
+	// LastRun is the outcome of the most recent run, nil until one has
+	// happened.
+	LastRun *ScheduleRun `json:"last_run,omitempty"`
+}
+
+// ScheduleRun is the outcome of one scheduled run.
+type ScheduleRun struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// BackupIDs are the backups the run created.
+	BackupIDs []string `json:"backup_ids,omitempty"`
+	// PrunedIDs are the backups retention deleted after the run.
+	PrunedIDs []string `json:"pruned_ids,omitempty"`
+	// Failures are dump, upload and prune errors, one per failed item.
+	Failures []string `json:"failures,omitempty"`
+	// VerificationFailures are backups from this run that failed their
+	// restore test, or whose test could not be run.
+	VerificationFailures []string `json:"verification_failures,omitempty"`
+}
+
+// OK reports whether the run completed without any failure, including a
+// failed restore test.
+func (r *ScheduleRun) OK() bool {
+	return len(r.Failures) == 0 && len(r.VerificationFailures) == 0
+}
+
+// conn returns the schedule's connection parameters for database db.
+func (s *Schedule) conn(db string) PgConn {
+	return PgConn{Database: db, User: s.DBUser, Host: s.DBHost, Port: s.DBPort}
+}
+
+// NextRun returns when the schedule next fires, or the zero time if its
+// cron expression is invalid.
+func (s *Schedule) NextRun() time.Time {
+	c, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	anchor := s.UpdatedAt
+	if s.LastRun != nil && s.LastRun.StartedAt.After(anchor) {
+		anchor = s.LastRun.StartedAt
+	}
+	return c.Next(anchor)
+}
+
+// Validate checks a schedule the way Create checks its options, so a
+// schedule that could never produce a backup is refused when it is set
+// rather than failing every night.
+func (s *Schedule) Validate() error {
+	if err := validateBackupID(s.Username); err != nil {
+		return fmt.Errorf("username: %w", err)
+	}
+	if _, err := ParseCron(s.Cron); err != nil {
+		return err
+	}
+	switch s.Destination {
+	case DestLocal:
+	case DestGCS, DestS3:
+		bucket := s.GCSBucket
+		if s.Destination == DestS3 {
+			bucket = s.S3Bucket
+		}
+		if scheme := bucketScheme[s.Destination]; !strings.HasPrefix(bucket, scheme) {
+			return fmt.Errorf("%s_bucket must be a %s URI, got %q", s.Destination, scheme, bucket)
+		}
+	default:
+		return fmt.Errorf("unknown or unspecified destination %q", s.Destination)
+	}
+	if err := s.Retention.validate(); err != nil {
+		return err
+	}
+	eng, _, err := engineFor(s.Engine)
+	if err != nil {
+		return err
+	}
+	for _, db := range s.Databases {
+		if db == "" {
+			return fmt.Errorf("database names must not be empty")
+		}
+		if err := eng.checkDatabase(db); err != nil {
+			return err
+		}
+	}
+	if s.VerifyTarget != "" && s.VerifyTarget == s.Username {
+		return fmt.Errorf("verify target %q must differ from the schedule's own tenant: a restore test must never load a dump over the database it came from", s.VerifyTarget)
+	}
+	return nil
+}
+
+func (m *Manager) scheduleDir() string { return filepath.Join(m.dir, "schedules") }
+
+func (m *Manager) schedulePath(username string) string {
+	return filepath.Join(m.scheduleDir(), username+".json")
+}
+
+// SetSchedule validates and persists s, replacing any schedule the tenant
+// already has. The previous LastRun is carried over so the run history
+// survives an edit.
+func (m *Manager) SetSchedule(s *Schedule) (*Schedule, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	out := *s
+	out.UpdatedAt = m.now().UTC()
+	out.LastRun = nil
+	if prev, err := m.GetSchedule(s.Username); err == nil {
+		out.LastRun = prev.LastRun
+	}
+	if err := m.writeSchedule(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSchedule returns a tenant's schedule.
+func (m *Manager) GetSchedule(username string) (*Schedule, error) {
+	if err := validateBackupID(username); err != nil {
+		return nil, fmt.Errorf("username: %w", err)
+	}
+	return m.readSchedule(m.schedulePath(username))
+}
+
+// ListSchedules returns every persisted schedule, ordered by tenant.
+func (m *Manager) ListSchedules() ([]*Schedule, error) {
+	entries, err := os.ReadDir(m.scheduleDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read schedule directory: %w", err)
+	}
+	var out []*Schedule
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		s, err := m.readSchedule(filepath.Join(m.scheduleDir(), e.Name()))
+		if err != nil {
+			continue // a corrupt file shouldn't hide the rest
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Username < out[j].Username })
+	return out, nil
+}
+
+// errScheduleNotFound is a tenant with no schedule.
+var errScheduleNotFound = errors.New("backup schedule not found")
+
+// DeleteSchedule removes a tenant's schedule. Backups it already took
+// are left alone.
+func (m *Manager) DeleteSchedule(username string) error {
+	if err := validateBackupID(username); err != nil {
+		return fmt.Errorf("username: %w", err)
+	}
+	if err := os.Remove(m.schedulePath(username)); err != nil {
+		if os.IsNotExist(err) {
+			return errScheduleNotFound
+		}
+		return fmt.Errorf("failed to delete backup schedule: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) writeSchedule(s *Schedule) error {
+	if err := os.MkdirAll(m.scheduleDir(), 0o700); err != nil {
+		return fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode backup schedule: %w", err)
+	}
+	// Write-then-rename so a crash mid-write never leaves a truncated
+	// schedule that silently stops the tenant's backups.
+	tmp := m.schedulePath(s.Username) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write backup schedule: %w", err)
+	}
+	if err := os.Rename(tmp, m.schedulePath(s.Username)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write backup schedule: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) readSchedule(path string) (*Schedule, error) {
+	b, err := os.ReadFile(path) // #nosec G304 -- path is m.schedulePath(username); username validated via validateBackupID
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to read backup schedule: %w", err)
+	}
+	var s Schedule
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("corrupt backup schedule at %s: %w", path, err)
+	}
+	return &s, nil
+}
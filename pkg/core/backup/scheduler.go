@@ -0,0 +1,280 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultSchedulerInterval is how often the scheduler checks for due
+// schedules. Cron's resolution is a minute, so a once-a-minute check
+// fires every schedule in the minute it is due.
+const DefaultSchedulerInterval = 60 * time.Second
+
+// ContainerResolver maps a tenant to the name of its container. The
+// daemon adapts the container manager; tests use a literal fake.
+type ContainerResolver interface {
+	ContainerName(username string) (string, error)
+}
+
+// ScheduleNotifier is told the outcome of every scheduled run, success or
+// not. The daemon publishes it on the events bus and as metrics, which is
+// what makes a failed run alertable.
+type ScheduleNotifier interface {
+	ScheduleRan(s *Schedule, run *ScheduleRun)
+}
+
+// SchedulerOptions bundles the optional knobs. Zero values give
+// DefaultSchedulerInterval and time.Now.
+type SchedulerOptions struct {
+	Interval time.Duration
+	Clock    func() time.Time
+}
+
+// Scheduler runs persisted Schedules. One per daemon; it shares the
+// Manager the BackupService uses, so scheduled and on-demand backups land
+// in the same index. Start spawns the tick loop, Stop signals it and
+// waits; both are safe to call more than once.
+//
+// Runs execute one at a time on the tick goroutine. A long dump delays
+// the next check rather than overlapping with it, so two runs never
+// compete for one tenant's database or the same staging directory.
+type Scheduler struct {
+	mgr      *Manager
+	resolver ContainerResolver
+	notifier ScheduleNotifier
+	interval time.Duration
+	clock    func() time.Time
+
+	stopCh    chan struct{}
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewScheduler constructs a scheduler. notifier may be nil.
+func NewScheduler(mgr *Manager, resolver ContainerResolver, notifier ScheduleNotifier, opts SchedulerOptions) *Scheduler {
+	if mgr == nil {
+		panic("backup: nil Manager")
+	}
+	if resolver == nil {
+		panic("backup: nil ContainerResolver")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultSchedulerInterval
+	}
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	return &Scheduler{
+		mgr:      mgr,
+		resolver: resolver,
+		notifier: notifier,
+		interval: opts.Interval,
+		clock:    opts.Clock,
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start spawns the tick loop and returns immediately.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		go s.run(ctx)
+		log.Printf("[backup] scheduler started (interval=%s)", s.interval)
+	})
+}
+
+// Stop signals the loop to exit and waits for an in-flight run to finish.
+// A scheduler that was never started returns immediately.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	started := true
+	s.startOnce.Do(func() { started = false })
+	if started {
+		<-s.done
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-t.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+// tick runs every due schedule. A schedule that fails is recorded and
+// reported, and the loop moves on — one tenant's broken database must
+// not hold up everyone else's backups.
+func (s *Scheduler) tick(ctx context.Context) {
+	schedules, err := s.mgr.ListSchedules()
+	if err != nil {
+		log.Printf("[backup] list schedules: %v", err)
+		return
+	}
+	for _, sched := range DueSchedules(schedules, s.clock()) {
+		if ctx.Err() != nil {
+			return
+		}
+		s.RunSchedule(sched)
+	}
+}
+
+// DueSchedules returns the schedules whose next fire time is at or
+// before now. A daemon that was down across several fire times runs a
+// schedule once when it comes back, not once per missed slot.
+func DueSchedules(schedules []*Schedule, now time.Time) []*Schedule {
+	var due []*Schedule
+	for _, sched := range schedules {
+		next := sched.NextRun()
+		if !next.IsZero() && !next.After(now) {
+			due = append(due, sched)
+		}
+	}
+	return due
+}
+
+// RunSchedule performs one run of sched: dump, prune, verify. The outcome
+// is persisted on the schedule and handed to the notifier before it is
+// returned.
+func (s *Scheduler) RunSchedule(sched *Schedule) *ScheduleRun {
+	run := &ScheduleRun{StartedAt: s.clock().UTC()}
+	defer func() {
+		run.FinishedAt = s.clock().UTC()
+		// Re-read before writing so an edit made while the run was in
+		// flight is not clobbered by the stale copy, and a delete is not
+		// undone by writing it back.
+		latest, err := s.mgr.GetSchedule(sched.Username)
+		if err != nil {
+			latest = sched
+		}
+		latest.LastRun = run
+		if !errors.Is(err, errScheduleNotFound) {
+			if err := s.mgr.writeSchedule(latest); err != nil {
+				log.Printf("[backup] schedule %s: could not record run: %v", sched.Username, err)
+			}
+		}
+		if s.notifier != nil {
+			s.notifier.ScheduleRan(latest, run)
+		}
+	}()
+
+	container, err := s.resolver.ContainerName(sched.Username)
+	if err != nil {
+		run.Failures = append(run.Failures, fmt.Sprintf("resolve container: %v", err))
+		return run
+	}
+
+	opts := CreateOptions{
+		Username:      sched.Username,
+		ContainerName: container,
+		Engine:        sched.Engine,
+		Conn:          sched.conn(""),
+		Destination:   sched.Destination,
+		GCSBucket:     sched.GCSBucket,
+		S3Bucket:      sched.S3Bucket,
+		Scheduled:     true,
+	}
+	var records []*Record
+	if len(sched.Databases) == 0 {
+		var errs []error
+		records, errs = s.mgr.CreateAll(opts)
+		for _, e := range errs {
+			run.Failures = append(run.Failures, e.Error())
+		}
+	} else {
+		for _, db := range sched.Databases {
+			o := opts
+			o.Conn = sched.conn(db)
+			r, err := s.mgr.Create(o)
+			if err != nil {
+				run.Failures = append(run.Failures, fmt.Sprintf("database %q: %v", db, err))
+				continue
+			}
+			records = append(records, r)
+		}
+	}
+	for _, r := range records {
+		run.BackupIDs = append(run.BackupIDs, r.ID)
+	}
+
+	// Prune only after a run that produced something: a schedule whose
+	// dumps are all failing keeps every backup it still has.
+	if len(records) > 0 {
+		s.prune(sched, run)
+	}
+	if sched.VerifyTarget != "" {
+		s.verify(sched, container, records, run)
+	}
+
+	if run.OK() {
+		log.Printf("[backup] schedule %s: %d backup(s), %d pruned", sched.Username, len(run.BackupIDs), len(run.PrunedIDs))
+	} else {
+		log.Printf("[backup] schedule %s: %d backup(s), %d failure(s), %d failed verification(s)",
+			sched.Username, len(run.BackupIDs), len(run.Failures), len(run.VerificationFailures))
+	}
+	return run
+}
+
+func (s *Scheduler) prune(sched *Schedule, run *ScheduleRun) {
+	if sched.Retention.IsZero() {
+		return
+	}
+	all, err := s.mgr.List(sched.Username)
+	if err != nil {
+		run.Failures = append(run.Failures, fmt.Sprintf("retention: list backups: %v", err))
+		return
+	}
+	for _, r := range Expired(all, sched.Retention) {
+		if err := s.mgr.Delete(r.ID); err != nil {
+			run.Failures = append(run.Failures, fmt.Sprintf("retention: delete %s: %v", r.ID, err))
+			continue
+		}
+		run.PrunedIDs = append(run.PrunedIDs, r.ID)
+	}
+}
+
+func (s *Scheduler) verify(sched *Schedule, source string, records []*Record, run *ScheduleRun) {
+	if len(records) == 0 {
+		return
+	}
+	target, err := s.resolver.ContainerName(sched.VerifyTarget)
+	if err != nil {
+		for _, r := range records {
+			run.VerificationFailures = append(run.VerificationFailures,
+				fmt.Sprintf("%s: resolve verify target %q: %v", r.ID, sched.VerifyTarget, err))
+		}
+		return
+	}
+	for _, r := range records {
+		v, err := s.mgr.Verify(VerifyOptions{
+			ID:              r.ID,
+			TargetContainer: target,
+			SourceContainer: source,
+			Conn:            sched.conn(""),
+			VerifiedBy:      "scheduler",
+		})
+		switch {
+		case err != nil:
+			run.VerificationFailures = append(run.VerificationFailures, fmt.Sprintf("%s: %v", r.ID, err))
+		case v.Result != VerificationPassed:
+			run.VerificationFailures = append(run.VerificationFailures, fmt.Sprintf("%s: %s", r.ID, v.Error))
+		}
+	}
+}
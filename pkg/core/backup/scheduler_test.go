@@ -0,0 +1,289 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeResolver maps tenant → "<tenant>-container", failing for missing.
+type fakeResolver struct{ missing map[string]bool }
+
+func (f fakeResolver) ContainerName(username string) (string, error) {
+	if f.missing[username] {
+		return "", fmt.Errorf("container for %s not found", username)
+	}
+	return username + "-container", nil
+}
+
+type recordingNotifier struct{ runs []*ScheduleRun }
+
+func (n *recordingNotifier) ScheduleRan(_ *Schedule, run *ScheduleRun) { n.runs = append(n.runs, run) }
+
+// movingClock is a settable clock shared by a Manager and its Scheduler.
+type movingClock struct{ t time.Time }
+
+func (c *movingClock) now() time.Time { return c.t }
+
+func newTestScheduler(t *testing.T, ops ContainerOps, resolver ContainerResolver) (*Manager, *Scheduler, *recordingNotifier, *movingClock) {
+	t.Helper()
+	clock := &movingClock{t: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+	m := NewManager(ops, nil, t.TempDir())
+	m.clock = clock.now
+	n := &recordingNotifier{}
+	return m, NewScheduler(m, resolver, n, SchedulerOptions{Clock: clock.now}), n, clock
+}
+
+func TestScheduleStoreRoundTrip(t *testing.T) {
+	m, _, _, clock := newTestScheduler(t, newFakeOps([]byte("dump")), fakeResolver{})
+
+	s, err := m.SetSchedule(&Schedule{
+		Username:    "alice",
+		Cron:        "30 2 * * *",
+		Databases:   []string{"app"},
+		Destination: DestLocal,
+		Retention:   Retention{Daily: 7, Weekly: 4},
+	})
+	if err != nil {
+		t.Fatalf("SetSchedule: %v", err)
+	}
+	if !s.UpdatedAt.Equal(clock.t) {
+		t.Errorf("UpdatedAt = %v, want %v", s.UpdatedAt, clock.t)
+	}
+	got, err := m.GetSchedule("alice")
+	if err != nil || got.Cron != "30 2 * * *" || got.Retention.Weekly != 4 {
+		t.Fatalf("GetSchedule = %+v, %v", got, err)
+	}
+	if want := time.Date(2026, 6, 1, 2, 30, 0, 0, time.UTC); !got.NextRun().Equal(want) {
+		t.Errorf("NextRun = %v, want %v", got.NextRun(), want)
+	}
+	if _, err := m.SetSchedule(&Schedule{Username: "bob", Cron: "@daily", Destination: DestLocal}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := m.ListSchedules()
+	if err != nil || len(list) != 2 || list[0].Username != "alice" {
+		t.Fatalf("ListSchedules = %v, %v", list, err)
+	}
+	// Schedules live in a subdirectory and must not show up as backups.
+	if recs, _ := m.List(""); len(recs) != 0 {
+		t.Errorf("schedules leaked into List: %v", recs)
+	}
+	if err := m.DeleteSchedule("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.GetSchedule("alice"); err == nil {
+		t.Error("schedule should be gone")
+	}
+}
+
+func TestScheduleValidation(t *testing.T) {
+	m := newTestManager(t, newFakeOps(nil))
+	for name, s := range map[string]*Schedule{
+		"bad cron":       {Username: "alice", Cron: "every night", Destination: DestLocal},
+		"no destination": {Username: "alice", Cron: "@daily"},
+		"gcs no bucket":  {Username: "alice", Cron: "@daily", Destination: DestGCS},
+		"traversal":      {Username: "../etc", Cron: "@daily", Destination: DestLocal},
+		"negative":       {Username: "alice", Cron: "@daily", Destination: DestLocal, Retention: Retention{Daily: -1}},
+		"verify own box": {Username: "alice", Cron: "@daily", Destination: DestLocal, VerifyTarget: "alice"},
+		"empty database": {Username: "alice", Cron: "@daily", Destination: DestLocal, Databases: []string{""}},
+	} {
+		if _, err := m.SetSchedule(s); err == nil {
+			t.Errorf("%s: SetSchedule should fail", name)
+		}
+	}
+}
+
+func TestDueSchedules(t *testing.T) {
+	set := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	s := &Schedule{Username: "alice", Cron: "30 2 * * *", UpdatedAt: set}
+
+	if due := DueSchedules([]*Schedule{s}, set.Add(2*time.Hour)); len(due) != 0 {
+		t.Errorf("due before its first slot")
+	}
+	if due := DueSchedules([]*Schedule{s}, set.Add(150*time.Minute)); len(due) != 1 {
+		t.Errorf("not due at its slot")
+	}
+	// Three missed nights still come back as a single due schedule, and
+	// once it has run it is not due again until the next slot.
+	later := set.AddDate(0, 0, 3)
+	if due := DueSchedules([]*Schedule{s}, later); len(due) != 1 {
+		t.Errorf("missed run not due")
+	}
+	s.LastRun = &ScheduleRun{StartedAt: later}
+	if due := DueSchedules([]*Schedule{s}, later.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("due again right after running")
+	}
+}
+
+func TestRunScheduleBacksUpPrunesAndVerifies(t *testing.T) {
+	ops := newVerifyOps([]byte("PGDMP-fake"))
+	m, sched, notes, clock := newTestScheduler(t, ops, fakeResolver{})
+
+	// An on-demand backup from before the schedule existed; retention
+	// must leave it alone.
+	manual, err := m.Create(CreateOptions{Username: "alice", ContainerName: "alice-container", Conn: PgConn{Database: "app"}, Destination: DestLocal})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := m.SetSchedule(&Schedule{
+		Username:     "alice",
+		Cron:         "0 2 * * *",
+		Databases:    []string{"app"},
+		Destination:  DestLocal,
+		Retention:    Retention{Daily: 2},
+		VerifyTarget: "scratch",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var runs []*ScheduleRun
+	for day := 0; day < 4; day++ {
+		clock.t = time.Date(2026, 6, 1+day, 2, 0, 0, 0, time.UTC)
+		run := sched.RunSchedule(s)
+		if !run.OK() {
+			t.Fatalf("day %d run failed: %+v", day, run)
+		}
+		runs = append(runs, run)
+	}
+
+	recs, _ := m.List("alice")
+	var scheduled []string
+	for _, r := range recs {
+		if r.Scheduled {
+			scheduled = append(scheduled, r.ID)
+		}
+	}
+	want := []string{"alice-app-20260604T020000Z", "alice-app-20260603T020000Z"}
+	if strings.Join(scheduled, ",") != strings.Join(want, ",") {
+		t.Errorf("scheduled backups kept = %v, want %v", scheduled, want)
+	}
+	if _, err := m.Get(manual.ID); err != nil {
+		t.Errorf("manual backup was pruned: %v", err)
+	}
+	if got := runs[3].PrunedIDs; len(got) != 1 || got[0] != "alice-app-20260602T020000Z" {
+		t.Errorf("day 4 pruned %v", got)
+	}
+
+	// Each new backup was restore-tested in the verify target only.
+	if len(ops.execByContainer["scratch-container"]) == 0 {
+		t.Error("verification never ran against the target")
+	}
+	rec, _ := m.Get(want[0])
+	if rec.LastVerification == nil || rec.LastVerification.VerifiedBy != "scheduler" {
+		t.Errorf("scheduled verification not recorded: %+v", rec.LastVerification)
+	}
+
+	if len(notes.runs) != 4 {
+		t.Errorf("notifier saw %d runs, want 4", len(notes.runs))
+	}
+	persisted, _ := m.GetSchedule("alice")
+	if persisted.LastRun == nil || !persisted.LastRun.StartedAt.Equal(clock.t) {
+		t.Errorf("last run not persisted: %+v", persisted.LastRun)
+	}
+}
+
+func TestRunScheduleReportsFailures(t *testing.T) {
+	ops := newVerifyOps([]byte("PGDMP-fake"))
+	ops.failDatabases = map[string]bool{"billing": true}
+	ops.failRestore = true
+	m, sched, notes, _ := newTestScheduler(t, ops, fakeResolver{})
+
+	s, err := m.SetSchedule(&Schedule{
+		Username:     "alice",
+		Cron:         "@daily",
+		Databases:    []string{"app", "billing"},
+		Destination:  DestLocal,
+		Retention:    Retention{Daily: 1},
+		VerifyTarget: "scratch",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := sched.RunSchedule(s)
+	if run.OK() {
+		t.Fatal("run should report failures")
+	}
+	if len(run.BackupIDs) != 1 || len(run.Failures) != 1 || !strings.Contains(run.Failures[0], "billing") {
+		t.Errorf("run = %+v", run)
+	}
+	if len(run.VerificationFailures) != 1 {
+		t.Errorf("failed restore test not reported: %+v", run.VerificationFailures)
+	}
+	if len(notes.runs) != 1 || notes.runs[0].OK() {
+		t.Errorf("notifier not told of the failure: %+v", notes.runs)
+	}
+}
+
+func TestRunScheduleMissingContainer(t *testing.T) {
+	m, sched, notes, _ := newTestScheduler(t, newFakeOps([]byte("dump")), fakeResolver{missing: map[string]bool{"alice": true}})
+	s, err := m.SetSchedule(&Schedule{Username: "alice", Cron: "@daily", Destination: DestLocal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := sched.RunSchedule(s)
+	if len(run.Failures) != 1 || len(notes.runs) != 1 {
+		t.Errorf("run = %+v", run)
+	}
+}
+
+// deletingResolver deletes the tenant's schedule as the run resolves its
+// container, as a DeleteBackupSchedule racing the run would.
+type deletingResolver struct{ m *Manager }
+
+func (r deletingResolver) ContainerName(username string) (string, error) {
+	if err := r.m.DeleteSchedule(username); err != nil {
+		return "", err
+	}
+	return username + "-container", nil
+}
+
+// A schedule deleted while it runs stays deleted: recording the run must not
+// write it back.
+func TestRunScheduleDeletedMidRun(t *testing.T) {
+	m, _, notes, clock := newTestScheduler(t, newFakeOps([]byte("dump")), fakeResolver{})
+	sched := NewScheduler(m, deletingResolver{m}, notes, SchedulerOptions{Clock: clock.now})
+	s, err := m.SetSchedule(&Schedule{Username: "alice", Cron: "@daily", Destination: DestLocal, Retention: Retention{Daily: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched.RunSchedule(s)
+	if got, err := m.GetSchedule("alice"); err == nil {
+		t.Fatalf("deleted schedule written back: %+v", got)
+	}
+	if len(notes.runs) != 1 {
+		t.Errorf("notifier not told of the run: %+v", notes.runs)
+	}
+}
+
+func TestSchedulerStartStop(t *testing.T) {
+	m, sched, notes, clock := newTestScheduler(t, newFakeOps([]byte("dump")), fakeResolver{})
+	sched.interval = time.Millisecond
+	if _, err := m.SetSchedule(&Schedule{Username: "alice", Cron: "@hourly", Destination: DestLocal}); err != nil {
+		t.Fatal(err)
+	}
+	clock.t = clock.t.Add(time.Hour)
+	sched.Start(context.Background())
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if s, _ := m.GetSchedule("alice"); s.LastRun != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("scheduler never ran the due schedule")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	sched.Stop()
+	sched.Stop() // idempotent
+	if len(notes.runs) != 1 {
+		t.Errorf("ran %d times at one fixed instant, want 1", len(notes.runs))
+	}
+
+	// A scheduler that was never started stops without blocking.
+	_, idle, _, _ := newTestScheduler(t, newFakeOps(nil), fakeResolver{})
+	idle.Stop()
+}
@@ -2,7 +2,6 @@ package backup
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -52,21 +51,27 @@ type VerifyOptions struct {
 	// matches TargetContainer — a restore test must never be able to
 	// load a dump over the database it came from.
 	SourceContainer string
-	// Conn is the target container's Postgres connection. Conn.Database
-	// is ignored: the scratch database name is generated here.
+	// Conn is the target container's database connection, for the
+	// backup's engine. Conn.Database is ignored: the scratch database
+	// name is generated here. Unused for Redis, whose scratch server
+	// listens on a socket of its own.
 	Conn PgConn
 	// VerifiedBy is the authenticated subject requesting the test, for
 	// the "who" half of the audit record.
 	VerifiedBy string
+	// Progress, when set, is called as the dump streams into the scratch
+	// database.
+	Progress ProgressFunc
 }
 
-// scratchPrefix marks the throwaway databases verification creates, so a
-// leaked one is identifiable on sight during an incident.
+// scratchPrefix marks the throwaway databases (and, for Redis, scratch
+// server directories) verification creates, so a leaked one is
+// identifiable on sight during an incident.
 const scratchPrefix = "containarium_verify_"
 
-// maxIdentLen is Postgres's identifier limit; a longer name is silently
-// truncated by the server, which would break the DROP that pairs with the
-// CREATE. Truncate deliberately instead.
+// maxIdentLen is Postgres's identifier limit (MySQL's is one longer); a
+// longer name is silently truncated by the server, which would break the
+// DROP that pairs with the CREATE. Truncate deliberately instead.
 const maxIdentLen = 63
 
 // Verify restore-tests a stored dump against a throwaway database inside
@@ -83,6 +88,10 @@ func (m *Manager) Verify(opts VerifyOptions) (*Verification, error) {
 	if err != nil {
 		return nil, err
 	}
+	eng, _, err := engineFor(r.Engine)
+	if err != nil {
+		return nil, err
+	}
 	if opts.TargetContainer == "" {
 		return nil, fmt.Errorf("target container is required: a restore test needs a throwaway container to load into")
 	}
@@ -96,8 +105,9 @@ func (m *Manager) Verify(opts VerifyOptions) (*Verification, error) {
 	}
 
 	started := m.now()
-	conn := opts.Conn.withDefaults()
+	conn := eng.defaults(opts.Conn)
 	scratch := scratchName(r.ID)
+	spec := eng.manifest()
 
 	v := &Verification{
 		Result:          VerificationPassed,
@@ -116,17 +126,28 @@ func (m *Manager) Verify(opts VerifyOptions) (*Verification, error) {
 		v.Checks = append(v.Checks, Check{Name: check, Passed: true, Detail: detail})
 	}
 
-	// 1. Integrity — a corrupt dump never reaches the engine.
-	data, err := m.fetchDump(r)
+	// 1. Integrity — a corrupt dump never reaches the engine. An
+	//    encrypted archive whose key cannot be unwrapped is not
+	//    restorable from here, which is a result like any other.
+	aead, err := m.recordKey(r)
+	if err != nil {
+		fail("integrity", err.Error())
+		return m.commitVerification(r, v, started)
+	}
+	dump, cleanup, err := m.openDump(r, aead, opts.Progress)
 	if err != nil {
 		fail("integrity", err.Error())
 		return m.commitVerification(r, v, started)
 	}
-	pass("integrity", fmt.Sprintf("sha256 matches recorded checksum (%d bytes)", len(data)))
+	defer cleanup()
+	detail := fmt.Sprintf("sha256 matches recorded checksum (%d bytes)", r.SizeBytes)
+	if aead != nil {
+		detail += "; archive decrypts and authenticates under key " + r.Encryption.KEKID
+	}
+	pass("integrity", detail)
 
 	// 2. Create the throwaway database in the target container.
-	if _, stderr, err := m.ops.ExecWithOutput(opts.TargetContainer,
-		wrapPg(conn.Password, psqlCmd(conn, "postgres", fmt.Sprintf("CREATE DATABASE %s", quoteIdent(scratch))))); err != nil {
+	if stderr, err := eng.createScratch(m.ops, opts.TargetContainer, conn, scratch); err != nil {
 		fail("scratch_database", fmt.Sprintf("could not create scratch database: %s", engineErr(stderr, err)))
 		return m.commitVerification(r, v, started)
 	}
@@ -134,37 +155,24 @@ func (m *Manager) Verify(opts VerifyOptions) (*Verification, error) {
 
 	// The scratch database is dropped on every path out from here —
 	// including a failed restore — so the target is left as found.
-	defer func() {
-		_, _, _ = m.ops.ExecWithOutput(opts.TargetContainer,
-			wrapPg(conn.Password, psqlCmd(conn, "postgres", fmt.Sprintf("DROP DATABASE IF EXISTS %s", quoteIdent(scratch)))))
-	}()
+	defer eng.dropScratch(m.ops, opts.TargetContainer, conn, scratch)
 
 	// 3. Load the dump into the scratch database.
-	inContainerPath := "/tmp/containarium-verify-" + r.ID + ".dump"
-	if err := m.ops.WriteFile(opts.TargetContainer, inContainerPath, data, "0600"); err != nil {
-		fail("restore", fmt.Sprintf("could not push dump into target container: %v", err))
-		return m.commitVerification(r, v, started)
-	}
-	defer func() { _ = m.ops.Exec(opts.TargetContainer, []string{"rm", "-f", inContainerPath}) }()
-
-	restoreScript := fmt.Sprintf(
-		"pg_restore -h %s -p %d -U %s -d %s %s",
-		shellQuote(conn.Host), conn.Port, shellQuote(conn.User),
-		shellQuote(scratch), shellQuote(inContainerPath),
-	)
-	if _, stderr, err := m.ops.ExecWithOutput(opts.TargetContainer, wrapPg(conn.Password, restoreScript)); err != nil {
+	pr := newProgressReader(dump, opts.Progress, r.ID, PhaseRestore, r.SizeBytes)
+	if stderr, err := eng.loadScratch(m.ops, opts.TargetContainer, conn, scratch, plaintext(pr, aead, r.ID)); err != nil {
 		fail("restore", engineErr(stderr, err))
 		return m.commitVerification(r, v, started)
 	}
+	pr.done()
 	pass("restore", "dump loaded into "+scratch)
 
 	// 4. Compare what landed against the manifest recorded at dump time.
 	//    This is the check the integrity hash cannot make: a truncated
 	//    export hashes perfectly and restores without complaint, and only
-	//    shows up as a shortfall against the source's own relation count.
-	count, err := m.countUserRelations(opts.TargetContainer, conn, scratch)
+	//    shows up as a shortfall against the source's own object count.
+	count, err := eng.countScratch(m.ops, opts.TargetContainer, conn, scratch)
 	if err != nil {
-		fail("relation_count", fmt.Sprintf("could not query restored schema: %v", err))
+		fail(spec.check, fmt.Sprintf("could not query restored data: %v", err))
 		return m.commitVerification(r, v, started)
 	}
 	switch {
@@ -172,15 +180,23 @@ func (m *Manager) Verify(opts VerifyOptions) (*Verification, error) {
 		// Backups taken before verification existed carry no manifest.
 		// Record what we found — that is still evidence — but do not
 		// invent a threshold to judge it against.
-		pass("relation_count", fmt.Sprintf(
-			"%d user relations restored (no manifest recorded at backup time — nothing to compare against)", count))
-	case count != *r.RelationCount:
-		fail("relation_count", fmt.Sprintf(
-			"restored schema has %d user relations, but the source had %d at dump time — the dump is incomplete",
-			count, *r.RelationCount))
+		pass(spec.check, fmt.Sprintf(
+			"%d %s restored (no manifest recorded at backup time — nothing to compare against)", count, spec.noun))
+	case spec.exact && count != *r.RelationCount:
+		fail(spec.check, fmt.Sprintf(
+			"restored data has %d %s, but the source had %d at dump time — the dump is incomplete",
+			count, spec.noun, *r.RelationCount))
+		return m.commitVerification(r, v, started)
+	case !spec.exact && count == 0 && *r.RelationCount > 0:
+		fail(spec.check, fmt.Sprintf(
+			"nothing restored, but the source had %d %s at dump time — the dump is empty",
+			*r.RelationCount, spec.noun))
 		return m.commitVerification(r, v, started)
+	case spec.exact:
+		pass(spec.check, fmt.Sprintf("%d %s, matching the source at dump time", count, spec.noun))
 	default:
-		pass("relation_count", fmt.Sprintf("%d user relations, matching the source at dump time", count))
+		pass(spec.check, fmt.Sprintf("%d %s restored (the source had %d when counted after the snapshot)",
+			count, spec.noun, *r.RelationCount))
 	}
 
 	return m.commitVerification(r, v, started)
@@ -201,36 +217,8 @@ func (m *Manager) commitVerification(r *Record, v *Verification, started time.Ti
 	return v, nil
 }
 
-// userRelationQuery counts tables and partitioned tables belonging to the
-// *user's* schemas.
-//
-// Counting pg_class unfiltered is useless here: every database, however
-// empty, carries ~60 system catalog relations, so an unfiltered count can
-// never reach zero and a check against it would pass for a dump that
-// restored nothing at all. Excluding pg_catalog / information_schema /
-// pg_toast is what makes the number mean "the tenant's data".
-const userRelationQuery = `SELECT count(*) FROM pg_class c ` +
-	`JOIN pg_namespace n ON n.oid = c.relnamespace ` +
-	`WHERE c.relkind IN ('r','p') ` +
-	`AND n.nspname NOT IN ('pg_catalog','information_schema') ` +
-	`AND n.nspname !~ '^pg_toast';`
-
-// countUserRelations runs userRelationQuery against db inside container.
-func (m *Manager) countUserRelations(container string, conn PgConn, db string) (int64, error) {
-	stdout, stderr, err := m.ops.ExecWithOutput(container, wrapPg(conn.Password, psqlCmd(conn, db, userRelationQuery)))
-	if err != nil {
-		return 0, fmt.Errorf("%s", engineErr(stderr, err))
-	}
-	n, err := strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("unreadable relation count %q", strings.TrimSpace(stdout))
-	}
-	return n, nil
-}
-
-// scratchName derives a safe, unquoted-identifier-shaped Postgres
-// database name from a backup id (which carries hyphens and is
-// mixed-case).
+// scratchName derives a safe, unquoted-identifier-shaped database name
+// from a backup id (which carries hyphens and is mixed-case).
 func scratchName(id string) string {
 	var b strings.Builder
 	b.WriteString(scratchPrefix)
@@ -248,21 +236,6 @@ func scratchName(id string) string {
 	return name
 }
 
-// quoteIdent double-quotes a Postgres identifier for use in DDL.
-// scratchName already restricts the character set; this is belt-and-braces
-// against the name ever becoming caller-controlled.
-func quoteIdent(s string) string {
-	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
-}
-
-// psqlCmd builds a single-statement psql invocation against db, in the
-// tuples-only/unaligned form the callers parse.
-func psqlCmd(conn PgConn, db, sql string) string {
-	return fmt.Sprintf("psql -h %s -p %d -U %s -d %s -Atc %s",
-		shellQuote(conn.Host), conn.Port, shellQuote(conn.User),
-		shellQuote(db), shellQuote(sql))
-}
-
 // engineErr prefers the engine's own stderr over the exec wrapper's
 // error, which is what an operator needs to see; it falls back to the Go
 // error when the engine said nothing.
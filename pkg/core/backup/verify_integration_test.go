@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -66,23 +67,21 @@ func (p *podmanOps) ExecWithOutput(container string, command []string) (string,
 	return p.run(container, nil, command)
 }
 
-func (p *podmanOps) ReadFile(container, path string) ([]byte, error) {
-	cmd := exec.Command(p.runtime, "exec", container, "cat", path)
-	var out, errb bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &errb
-	if err := cmd.Run(); err != nil {
-		return nil, err
+func (p *podmanOps) ExecStream(container string, command []string, stdin io.Reader, stdout io.Writer) (string, error) {
+	args := []string{"exec"}
+	if stdin != nil {
+		args = append(args, "-i")
 	}
-	return out.Bytes(), nil
-}
+	args = append(args, container)
+	args = append(args, command...)
 
-func (p *podmanOps) WriteFile(container, path string, content []byte, _ string) error {
-	_, stderr, err := p.run(container, content, []string{"sh", "-c", "cat > " + path})
-	if err != nil {
-		p.t.Logf("WriteFile stderr: %s", stderr)
-	}
-	return err
+	cmd := exec.Command(p.runtime, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stderr.String(), err
 }
 
 func runtimeBin(t *testing.T) string {
@@ -251,15 +250,13 @@ CREATE TABLE a (id int);`
 	if _, stderr, err := ops.run(src, []byte(partial), []string{"psql", "-U", "postgres", "-v", "ON_ERROR_STOP=1"}); err != nil {
 		t.Fatalf("seed partial: %v: %s", err, stderr)
 	}
-	if _, stderr, err := ops.ExecWithOutput(src, []string{
-		"bash", "-c", "pg_dump -U postgres -d partial -Fc -f /tmp/partial.dump",
-	}); err != nil {
+	var partialDump bytes.Buffer
+	if stderr, err := ops.ExecStream(src, []string{
+		"pg_dump", "-U", "postgres", "-d", "partial", "-Fc",
+	}, nil, &partialDump); err != nil {
 		t.Fatalf("dump partial: %v: %s", err, stderr)
 	}
-	partialBytes, err := ops.ReadFile(src, "/tmp/partial.dump")
-	if err != nil {
-		t.Fatalf("read partial dump: %v", err)
-	}
+	partialBytes := partialDump.Bytes()
 	if err := os.WriteFile(rec.Location, partialBytes, 0o600); err != nil {
 		t.Fatalf("swap dump: %v", err)
 	}
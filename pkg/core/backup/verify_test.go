@@ -1,6 +1,7 @@
 package backup
 
 import (
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -71,11 +72,6 @@ func (v *verifyOps) ExecWithOutput(container string, command []string) (string,
 		return "", "", nil
 	case strings.Contains(full, "DROP DATABASE"):
 		return "", "", nil
-	case strings.Contains(full, "pg_restore"):
-		if v.failRestore {
-			return "", "pg_restore: error: could not read from input file: end of file", errExec
-		}
-		return "", "", nil
 	case strings.Contains(full, "pg_class"):
 		// The same query runs twice: once against the source at dump
 		// time (the manifest) and once against the scratch database
@@ -88,6 +84,14 @@ func (v *verifyOps) ExecWithOutput(container string, command []string) (string,
 	return v.fakeOps.ExecWithOutput(container, command)
 }
 
+func (v *verifyOps) ExecStream(container string, command []string, stdin io.Reader, stdout io.Writer) (string, error) {
+	full := v.record(container, command)
+	if strings.Contains(full, "pg_restore") && v.failRestore {
+		return "pg_restore: error: could not read from input file: end of file", errExec
+	}
+	return v.fakeOps.ExecStream(container, command, stdin, stdout)
+}
+
 // seedBackup creates one LOCAL backup from alice's container and returns
 // the manager plus the committed record.
 func seedBackup(t *testing.T, ops ContainerOps) (*Manager, *Record) {
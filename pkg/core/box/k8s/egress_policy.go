@@ -8,9 +8,11 @@ import (
 	"sort"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	pb "github.com/footprintai/containarium/pkg/pb/containarium/v1"
 )
@@ -130,6 +132,30 @@ func compileTenantPolicy(p *pb.NetworkPolicy) ([]networkingv1.NetworkPolicyEgres
 		rules = append(rules, networkingv1.NetworkPolicyEgressRule{To: peers})
 	}
 
+	// Port-scoped rules carry across directly: a NetworkPolicy egress rule
+	// takes ports (with endPort for a range) alongside its peers. A domain
+	// rule is refused for the same reason egress_domains is.
+	for _, r := range p.GetEgressRules() {
+		if r.GetDomain() != "" {
+			unsupported = append(unsupported, UnsupportedPolicyFeature{
+				Feature: "egress_rule domain=" + r.GetDomain(),
+				Reason:  "NetworkPolicy matches IPs, not names, and a resolved snapshot would go stale",
+			})
+			continue
+		}
+		peers, err := egressPeersFor(r.GetCidr())
+		if err != nil {
+			unsupported = append(unsupported, UnsupportedPolicyFeature{
+				Feature: "egress_rule cidr=" + r.GetCidr(), Reason: err.Error(),
+			})
+			continue
+		}
+		if !p.GetAllowMetadata() && coversMetadataIP(r.GetCidr()) {
+			peers = withMetadataExcepted(peers)
+		}
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{To: peers, Ports: egressRulePorts(r)})
+	}
+
 	// Deterministic order so a reconcile does not rewrite the object every
 	// pass and leave an unreadable diff.
 	sort.SliceStable(rules, func(i, j int) bool {
@@ -138,6 +164,35 @@ func compileTenantPolicy(p *pb.NetworkPolicy) ([]networkingv1.NetworkPolicyEgres
 	return rules, unsupported
 }
 
+// egressRulePorts renders a port-scoped rule's protocol and range as
+// NetworkPolicy ports. An empty proto is TCP and UDP, the two protocols that
+// carry ports; port 0 allows every port of the protocol.
+func egressRulePorts(r *pb.NetworkPolicyEgressRule) []networkingv1.NetworkPolicyPort {
+	var protos []corev1.Protocol
+	switch strings.ToLower(strings.TrimSpace(r.GetProto())) {
+	case "tcp":
+		protos = []corev1.Protocol{corev1.ProtocolTCP}
+	case "udp":
+		protos = []corev1.Protocol{corev1.ProtocolUDP}
+	default:
+		protos = []corev1.Protocol{corev1.ProtocolTCP, corev1.ProtocolUDP}
+	}
+	out := make([]networkingv1.NetworkPolicyPort, 0, len(protos))
+	for _, proto := range protos {
+		np := networkingv1.NetworkPolicyPort{Protocol: &proto}
+		if lo := r.GetPort(); lo != 0 {
+			port := intstr.FromInt32(int32(lo)) //nolint:gosec // validated to 1-65535 by netpolicy.Compile
+			np.Port = &port
+			if hi := r.GetPortEnd(); hi > lo {
+				end := int32(hi) //nolint:gosec // validated to ≤ 65535 by netpolicy.Compile
+				np.EndPort = &end
+			}
+		}
+		out = append(out, np)
+	}
+	return out
+}
+
 // withMetadataExcepted subtracts the cloud metadata address from every IPBlock
 // peer, so a broad allow rule stops short of the one address that hands out
 // cloud credentials.
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -170,6 +171,56 @@ func TestCompileTenantPolicy_CompilesCIDRs(t *testing.T) {
 	}
 }
 
+// A port-scoped rule narrows its peer to the protocol and range the tenant
+// named; an unspecified protocol means both TCP and UDP, never "any".
+func TestCompileTenantPolicy_CompilesPortScopedRules(t *testing.T) {
+	compiled, unsupported := compileTenantPolicy(enforcing(&pb.NetworkPolicy{
+		Tenant: "alice",
+		EgressRules: []*pb.NetworkPolicyEgressRule{
+			{Cidr: "10.0.0.0/8", Proto: "tcp", Port: 5432},
+			{Cidr: "192.0.2.0/24", Port: 8000, PortEnd: 8100},
+		},
+	}))
+	if len(unsupported) != 0 {
+		t.Fatalf("unexpected unsupported: %v", unsupported)
+	}
+	if len(compiled) != 2 {
+		t.Fatalf("compiled %d rules, want 2", len(compiled))
+	}
+	pg := compiled[0].Ports
+	if len(pg) != 1 || *pg[0].Protocol != corev1.ProtocolTCP || pg[0].Port.IntValue() != 5432 || pg[0].EndPort != nil {
+		t.Errorf("tcp/5432 compiled to %+v", pg)
+	}
+	rng := compiled[1].Ports
+	if len(rng) != 2 || *rng[0].Protocol != corev1.ProtocolTCP || *rng[1].Protocol != corev1.ProtocolUDP {
+		t.Fatalf("any-proto range compiled to %+v, want tcp and udp", rng)
+	}
+	if rng[0].Port.IntValue() != 8000 || rng[0].EndPort == nil || *rng[0].EndPort != 8100 {
+		t.Errorf("range compiled to %+v, want 8000-8100", rng[0])
+	}
+}
+
+// A port-scoped rule is still subject to the metadata carve-out.
+func TestCompileTenantPolicy_PortScopedRuleExceptsMetadata(t *testing.T) {
+	compiled, _ := compileTenantPolicy(enforcing(&pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressRules: []*pb.NetworkPolicyEgressRule{{Cidr: "169.254.0.0/16", Proto: "tcp", Port: 80}},
+	}))
+	if len(compiled) != 1 || !slices.Contains(compiled[0].To[0].IPBlock.Except, "169.254.169.254/32") {
+		t.Fatalf("metadata IP not excepted from a port-scoped rule: %+v", compiled)
+	}
+}
+
+func TestCompileTenantPolicy_RefusesDomainEgressRules(t *testing.T) {
+	_, unsupported := compileTenantPolicy(enforcing(&pb.NetworkPolicy{
+		Tenant:      "alice",
+		EgressRules: []*pb.NetworkPolicyEgressRule{{Domain: "api.github.com", Proto: "tcp", Port: 443}},
+	}))
+	if len(unsupported) == 0 {
+		t.Fatal("a domain egress rule was accepted; NetworkPolicy cannot match names")
+	}
+}
+
 // Order must not depend on input order, or every reconcile rewrites the object.
 func TestCompileTenantPolicy_DeterministicOrder(t *testing.T) {
 	a := enforcing(&pb.NetworkPolicy{Tenant: "x", EgressCidrs: []string{"10.0.0.0/8", "192.168.0.0/16"}})
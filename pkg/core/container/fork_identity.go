@@ -0,0 +1,51 @@
+package container
+
+import "fmt"
+
+// refreshForkIdentity is run inside a freshly started fork (#1160c): a ZFS
+// clone of another box's snapshot, which therefore still believes it IS that
+// box. $1 = the source's username, $2 = the fork's.
+//
+// The user is renamed rather than recreated so the home directory — the
+// work the fork was taken to branch — comes along with its ownership intact
+// (the uid does not change). Host keys and machine-id are regenerated because
+// they are identity too: two boxes sharing host keys are indistinguishable to
+// an SSH client, and a shared machine-id confuses journald and DHCP.
+//
+// The lingering user manager (podman-restart, #387) runs as the old name and
+// would make usermod refuse with "user is currently used by process"; it is
+// stopped first, and the linger flag moved to the new name so it comes back
+// as the fork's user on the next boot.
+const refreshForkIdentity = `set -e
+uid=$(id -u "$1")
+if [ -e "/var/lib/systemd/linger/$1" ]; then mv "/var/lib/systemd/linger/$1" "/var/lib/systemd/linger/$2"; fi
+systemctl stop "user@$uid.service" 2>/dev/null || true
+pkill -KILL -u "$uid" 2>/dev/null || true
+usermod -l "$2" -d "/home/$2" -m "$1"
+groupmod -n "$2" "$1" 2>/dev/null || true
+rm -f "/etc/sudoers.d/$1"
+printf '%s ALL=(ALL) NOPASSWD:ALL\n' "$2" > "/etc/sudoers.d/$2"
+chmod 0440 "/etc/sudoers.d/$2"
+rm -f /etc/ssh/ssh_host_*
+ssh-keygen -A >/dev/null
+systemctl restart ssh 2>/dev/null || systemctl restart sshd 2>/dev/null || true
+rm -f /etc/machine-id
+systemd-machine-id-setup >/dev/null 2>&1 || dbus-uuidgen --ensure=/etc/machine-id 2>/dev/null || true`
+
+// RefreshForkIdentity gives a running fork its own identity: the source's
+// user renamed to newUser, fresh SSH host keys and a fresh machine-id.
+//
+// One exec, with both usernames as positional parameters and never spliced
+// into the script text — the same idiom as addSSHKeys.
+func (m *Manager) RefreshForkIdentity(containerName, oldUser, newUser string) error {
+	if !isValidUsername(oldUser) || !isValidUsername(newUser) {
+		return fmt.Errorf("invalid username for fork identity: %q -> %q", oldUser, newUser)
+	}
+	if oldUser == newUser {
+		return fmt.Errorf("fork user %q must differ from its source's", newUser)
+	}
+	if err := m.incus.Exec(containerName, []string{"/bin/sh", "-c", refreshForkIdentity, "sh", oldUser, newUser}); err != nil {
+		return fmt.Errorf("failed to refresh fork identity in %s: %w", containerName, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package container
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRefreshForkIdentity_OneExecWithPositionalUsers(t *testing.T) {
+	mock, rec := newSeedBackend()
+	m := NewWithBackend(mock)
+
+	if err := m.RefreshForkIdentity("bob-container", "alice", "bob"); err != nil {
+		t.Fatalf("RefreshForkIdentity: %v", err)
+	}
+	if len(rec.execs) != 1 {
+		t.Fatalf("issued %d execs, want exactly 1: %v", len(rec.execs), rec.execs)
+	}
+	argv := rec.execs[0]
+	if len(argv) != 6 || argv[0] != "/bin/sh" || argv[4] != "alice" || argv[5] != "bob" {
+		t.Fatalf("exec argv = %v", argv)
+	}
+	script := argv[2]
+	for _, frag := range []string{`usermod -l "$2" -d "/home/$2" -m "$1"`, "ssh-keygen -A", "/etc/machine-id", `"/etc/sudoers.d/$2"`} {
+		if !strings.Contains(script, frag) {
+			t.Errorf("script missing %s", frag)
+		}
+	}
+	if strings.Contains(script, "alice") || strings.Contains(script, "bob") {
+		t.Errorf("script text embeds a username instead of using positional params:\n%s", script)
+	}
+}
+
+func TestRefreshForkIdentity_RejectsBadUsersBeforeAnyExec(t *testing.T) {
+	for _, tc := range [][2]string{{"alice", "alice"}, {"alice", "b'ob"}, {"", "bob"}} {
+		mock, rec := newSeedBackend()
+		if err := NewWithBackend(mock).RefreshForkIdentity("c", tc[0], tc[1]); err == nil {
+			t.Errorf("RefreshForkIdentity(%q, %q) accepted", tc[0], tc[1])
+		}
+		if len(rec.execs) != 0 {
+			t.Errorf("RefreshForkIdentity(%q, %q) ran %v", tc[0], tc[1], rec.execs)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package container
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
@@ -1013,10 +1015,18 @@ func (m *Manager) ExecWithOutput(containerName string, command []string) (string
 	return "", "", fmt.Errorf("ExecWithOutput not supported on this incus backend (mock?)")
 }
 
+// ExecWithOutputContext is ExecWithOutput bounded by ctx (see
+// incus.Client.ExecWithOutputContext).
+func (m *Manager) ExecWithOutputContext(ctx context.Context, containerName string, command []string) (string, string, error) {
+	if real, ok := m.incus.(*incus.Client); ok {
+		return real.ExecWithOutputContext(ctx, containerName, command)
+	}
+	return "", "", fmt.Errorf("ExecWithOutputContext not supported on this incus backend (mock?)")
+}
+
 // ReadFile pulls a file from inside the container into host memory.
-// Used by the backup server to retrieve a pg_dump artifact written to
-// the container's /tmp before shipping it off-host. Suitable for
-// logical dumps (typically MBs); not for arbitrarily large files.
+// Suitable for small artifacts (the agent server reads its seed
+// manifest this way); large payloads should go through ExecStream.
 // Type-asserts to the concrete client.
 func (m *Manager) ReadFile(containerName, path string) ([]byte, error) {
 	if real, ok := m.incus.(*incus.Client); ok {
@@ -1025,6 +1035,18 @@ func (m *Manager) ReadFile(containerName, path string) ([]byte, error) {
 	return nil, fmt.Errorf("ReadFile not supported on this incus backend (mock?)")
 }
 
+// ExecStream runs a command inside the container with stdin fed from
+// stdin and stdout streamed to stdout, returning stderr. Used by the
+// backup server to stream pg_dump output out of, and pg_restore input
+// into, a container without buffering the archive in memory.
+// Type-asserts to the concrete client.
+func (m *Manager) ExecStream(containerName string, command []string, stdin io.Reader, stdout io.Writer) (string, error) {
+	if real, ok := m.incus.(*incus.Client); ok {
+		return real.ExecStream(containerName, command, stdin, stdout)
+	}
+	return "", fmt.Errorf("ExecStream not supported on this incus backend (mock?)")
+}
+
 // WriteOTelEnvFile drops the monitoring env vars as a dotenv file at
 // OTelEnvFilePath inside the container, so docker-compose / nested
 // docker apps can consume them via `env_file:` — they don't inherit
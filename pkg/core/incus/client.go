@@ -2,6 +2,7 @@ package incus
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
@@ -446,6 +447,7 @@ type ContainerInfo struct {
 	Username     string
 	State        string
 	IPAddress    string
+	IPv6Address  string // eth0's global IPv6 address; empty when the network has none
 	CPU          string
 	Memory       string
 	Disk         string
@@ -505,6 +507,12 @@ type ContainerInfo struct {
 	// single-box delete can remove it. Empty = unprotected (today's default).
 	DeletePolicy string
 
+	// SnapshotPolicy mirrors user.containarium.snapshot_policy — the box's
+	// snapshot schedule and retention as JSON, parsed by internal/snapsched.
+	// Kept raw here so this package does not depend on the scheduler. Empty
+	// = no scheduled snapshots.
+	SnapshotPolicy string
+
 	// Image is a human-readable description of the image the container was
 	// launched from (Incus's auto-populated image.description config key,
 	// falling back to the volatile.base_image fingerprint). Empty if Incus
@@ -565,6 +573,13 @@ const DeletePolicyKey = "user.containarium.delete_policy"
 // user.containarium.delete_policy protected`) and every deletion path honors it.
 const DeletePolicyProtected = "protected"
 
+// SnapshotPolicyKey is the Incus config key carrying a box's snapshot
+// schedule and retention as JSON. Written by SetContainerSnapshotPolicy and
+// read by the snapshot scheduler on every tick. On the container rather than
+// in a separate store for the same reason as the TTL: it survives a daemon
+// restart, and it goes away with the box.
+const SnapshotPolicyKey = "user.containarium.snapshot_policy"
+
 // DefaultIdleThresholdMinutes is the fallback used when the threshold
 // config key is missing or unparseable.
 const DefaultIdleThresholdMinutes = 15
@@ -899,6 +914,29 @@ func (c *Client) StopContainer(name string, force bool) error {
 	return nil
 }
 
+// FreezeContainer pauses every process in a running container (the cgroup
+// freezer), without stopping it. Used to hold a box still across a
+// scheduled snapshot; UnfreezeContainer resumes it.
+func (c *Client) FreezeContainer(name string) error {
+	return c.updateState(name, "freeze")
+}
+
+// UnfreezeContainer resumes a container paused by FreezeContainer.
+func (c *Client) UnfreezeContainer(name string) error {
+	return c.updateState(name, "unfreeze")
+}
+
+func (c *Client) updateState(name, action string) error {
+	op, err := c.server.UpdateInstanceState(name, api.InstanceStatePut{Action: action, Timeout: 30}, "")
+	if err != nil {
+		return fmt.Errorf("failed to %s container: %w", action, err)
+	}
+	if err := op.Wait(); err != nil {
+		return fmt.Errorf("failed to %s container (operation failed): %w", action, err)
+	}
+	return nil
+}
+
 // DeleteContainer deletes a container
 func (c *Client) DeleteContainer(name string) error {
 	op, err := c.server.DeleteInstance(name)
@@ -945,6 +983,7 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 			StoppedAt:                 parseStoppedAt(inst.Config),
 			DeleteAfterStoppedSeconds: parseDeleteAfterStoppedSeconds(inst.Config),
 			DeletePolicy:              inst.Config[DeletePolicyKey],
+			SnapshotPolicy:            inst.Config[SnapshotPolicyKey],
 			Image:                     imageDescriptionFromConfig(inst.Config),
 		}
 
@@ -1043,6 +1082,7 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 					}
 				}
 			}
+			info.IPv6Address = eth0IPv6(state.Network)
 		}
 
 		containers = append(containers, info)
@@ -1071,6 +1111,7 @@ func (c *Client) GetContainer(name string) (*ContainerInfo, error) {
 		LastStartedAt:        parseLastStartedAt(inst.Config),
 		TTLExpiresAt:         parseTTLExpiresAt(inst.Config),
 		DeletePolicy:         inst.Config[DeletePolicyKey],
+		SnapshotPolicy:       inst.Config[SnapshotPolicyKey],
 		Image:                imageDescriptionFromConfig(inst.Config),
 	}
 
@@ -1170,11 +1211,24 @@ func (c *Client) GetContainer(name string) (*ContainerInfo, error) {
 				}
 			}
 		}
+		info.IPv6Address = eth0IPv6(state.Network)
 	}
 
 	return info, nil
 }
 
+// eth0IPv6 returns eth0's global-scope IPv6 address, or "" if it has none.
+// Only eth0 is consulted: unlike the IPv4 fallbacks above, a v6 address on a
+// nested bridge is never one the host routes to.
+func eth0IPv6(networks map[string]api.InstanceStateNetwork) string {
+	for _, addr := range networks["eth0"].Addresses {
+		if addr.Family == "inet6" && addr.Scope == "global" {
+			return addr.Address
+		}
+	}
+	return ""
+}
+
 // execMaxAttempts bounds retries of an incus exec on the transient
 // PID-tracking failure (see isTransientExecErr). 4 attempts with the
 // linear backoff below is ~1s total — enough to ride out the race
@@ -2352,6 +2406,13 @@ func (c *Client) ReadFile(containerName, filePath string) ([]byte, error) {
 
 // ExecWithOutput executes a command inside a container and returns stdout/stderr
 func (c *Client) ExecWithOutput(containerName string, command []string) (string, string, error) {
+	return c.ExecWithOutputContext(context.Background(), containerName, command)
+}
+
+// ExecWithOutputContext is ExecWithOutput, waiting for the command only until
+// ctx is done. Then it returns ctx's error and asks Incus to cancel the
+// operation, which a running command may outlive.
+func (c *Client) ExecWithOutputContext(ctx context.Context, containerName string, command []string) (string, string, error) {
 	var stdout, stderr bytes.Buffer
 
 	req := api.InstanceExecPost{
@@ -2373,7 +2434,11 @@ func (c *Client) ExecWithOutput(containerName string, command []string) (string,
 		if err != nil {
 			return fmt.Errorf("failed to execute command: %w", err)
 		}
-		if err := op.Wait(); err != nil {
+		if err := op.WaitContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				_ = op.Cancel()
+				return fmt.Errorf("command execution abandoned: %w", ctx.Err())
+			}
 			return fmt.Errorf("command execution failed: %w", err)
 		}
 		// A non-zero exit is a genuine command failure, not the transient
@@ -2389,6 +2454,43 @@ func (c *Client) ExecWithOutput(containerName string, command []string) (string,
 	return stdout.String(), stderr.String(), err
 }
 
+// ExecStream executes a command inside a container, feeding stdin (nil for
+// none) to it and copying its stdout to stdout as it is produced; stderr is
+// captured and returned. Used to stream backup dumps in and out without
+// holding them in memory. Unlike ExecWithOutput it is not retried: a
+// consumed stdin cannot be replayed, and stdout may already be partly
+// written.
+func (c *Client) ExecStream(containerName string, command []string, stdin io.Reader, stdout io.Writer) (string, error) {
+	var stderr bytes.Buffer
+
+	req := api.InstanceExecPost{
+		Command:     command,
+		WaitForWS:   true,
+		Interactive: false,
+	}
+	dataDone := make(chan bool)
+	args := &incus.InstanceExecArgs{Stdin: stdin, Stdout: stdout, Stderr: &stderr, DataDone: dataDone}
+
+	op, err := c.server.ExecInstance(containerName, req, args)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute command: %w", err)
+	}
+	if err := op.Wait(); err != nil {
+		return stderr.String(), fmt.Errorf("command execution failed: %w", err)
+	}
+	// The operation can complete before the websockets have drained;
+	// wait for the last stdout bytes before reporting success.
+	<-dataDone
+
+	opMeta := op.Get()
+	if opMeta.Metadata != nil {
+		if returnVal, ok := opMeta.Metadata["return"].(float64); ok && returnVal != 0 {
+			return stderr.String(), fmt.Errorf("command exited with code %d", int(returnVal))
+		}
+	}
+	return stderr.String(), nil
+}
+
 // CleanupDisk frees disk space inside a container by removing temp files,
 // package manager caches, and trimming journal logs.
 // Returns a human-readable summary and the number of bytes freed.
@@ -0,0 +1,185 @@
+package incus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Forking a container from a snapshot (#1160c).
+//
+// A fork is registered with Incus as an ordinary instance, but its root
+// volume is not Incus's to fill: the daemon swaps a ZFS clone of the source's
+// snapshot into it (zfscrypt.ReplaceWithClone). Incus cannot produce that
+// volume itself — `incus copy` only knows snapshots Incus took, and tenant
+// snapshots are raw ZFS ones — so this file creates the instance with an
+// EMPTY root volume, shaped like the source, for the clone to replace.
+
+// ForkSpec describes a fork's shell: the instance a snapshot clone is swapped
+// into.
+type ForkSpec struct {
+	// Source is the container being forked.
+	Source string
+	// Name is the fork's container name.
+	Name string
+	// StoragePool is the pool the fork's root volume goes on. It must be the
+	// source's: a clone stays under its origin's encryptionroot, and the
+	// swap is refused anywhere else. Empty keeps the source's root device.
+	StoragePool string
+	// Labels replace the source's labels entirely. The caller decides what
+	// carries over — some, like a cloud container id, are identity and must
+	// not.
+	Labels map[string]string
+	// Tenant is stamped as the fork's tenant when the source carries no
+	// explicit one, so a fork named outside the <tenant>-container
+	// convention is still policed as part of its source's tenant.
+	Tenant string
+	// DropEnv names environment variables of the source NOT to carry over —
+	// its secrets, which the caller stamps afresh for the fork's own user.
+	DropEnv []string
+}
+
+// forkDroppedKeys are the source's config keys a fork must not inherit: its
+// lifecycle stamps and snapshot schedule, which describe the source's life
+// rather than the fork's, and its key reference, which the daemon records for
+// the fork itself once the clone is in place.
+var forkDroppedKeys = []string{
+	TTLExpiresAtKey,
+	StoppedAtKey,
+	LastStartedAtKey,
+	DeletePolicyKey,
+	DeleteAfterStoppedSecondsKey,
+	SnapshotPolicyKey,
+	"user.containarium.zfs_key_ref",
+	"user.containarium.zfs_pool",
+}
+
+// CreateForkShell creates a stopped instance shaped like spec.Source — same
+// config, devices and profiles — with an empty root volume.
+func (c *Client) CreateForkShell(spec ForkSpec) error {
+	src, _, err := c.server.GetInstance(spec.Source)
+	if err != nil {
+		return fmt.Errorf("get source container %s: %w", spec.Source, err)
+	}
+	req, err := forkInstancePost(src, spec)
+	if err != nil {
+		return err
+	}
+	op, err := c.server.CreateInstance(req)
+	if err != nil {
+		return fmt.Errorf("create fork %s of %s: %w", spec.Name, spec.Source, err)
+	}
+	if err := op.Wait(); err != nil {
+		return fmt.Errorf("create fork %s of %s (operation failed): %w", spec.Name, spec.Source, err)
+	}
+	return nil
+}
+
+// forkInstancePost builds the create request for a fork's shell.
+//
+// What a fork must NOT share with its source is as much the point as what it
+// does: volatile.* holds the source's MAC addresses and last-seen state, and
+// a NIC's pinned address would put two boxes on one IP.
+func forkInstancePost(src *api.Instance, spec ForkSpec) (api.InstancesPost, error) {
+	if spec.Name == "" || spec.Name == spec.Source {
+		return api.InstancesPost{}, fmt.Errorf("fork name must be set and differ from its source")
+	}
+	if src.Type != "" && src.Type != string(api.InstanceTypeContainer) {
+		return api.InstancesPost{}, fmt.Errorf("cannot fork %s: only containers can be forked, not %s", spec.Source, src.Type)
+	}
+
+	drop := forkDropSet(spec.DropEnv)
+	cfg := make(map[string]string, len(src.Config))
+	for k, v := range src.Config {
+		if forkDrops(drop, k) {
+			continue
+		}
+		if spec.Labels != nil && strings.HasPrefix(k, LabelPrefix) {
+			continue
+		}
+		cfg[k] = v
+	}
+	for k, v := range spec.Labels {
+		cfg[LabelPrefix+k] = v
+	}
+	if cfg[TenantLabelKey] == "" && spec.Tenant != "" {
+		cfg[TenantLabelKey] = spec.Tenant
+	}
+	// Re-render the image templates (hostname, hosts) on first start, as
+	// `incus copy` does — the clone still says it is the source.
+	cfg["volatile.apply_template"] = "copy"
+
+	devices := make(map[string]map[string]string, len(src.Devices))
+	for name, dev := range src.Devices {
+		d := make(map[string]string, len(dev))
+		for k, v := range dev {
+			d[k] = v
+		}
+		switch d["type"] {
+		case "nic":
+			// A pinned address or MAC belongs to the source; the fork gets
+			// its own from the network.
+			delete(d, "ipv4.address")
+			delete(d, "ipv6.address")
+			delete(d, "hwaddr")
+		case "disk":
+			if d["path"] == "/" && spec.StoragePool != "" {
+				d["pool"] = spec.StoragePool
+			}
+		}
+		devices[name] = d
+	}
+	if spec.StoragePool != "" && !hasRootDisk(devices) {
+		// The root disk came from a profile, which names the daemon's
+		// default pool — not the one the clone has to land in.
+		devices["root"] = map[string]string{"type": "disk", "path": "/", "pool": spec.StoragePool}
+	}
+
+	return api.InstancesPost{
+		Name:   spec.Name,
+		Type:   api.InstanceTypeContainer,
+		Source: api.InstanceSource{Type: "none"},
+		InstancePut: api.InstancePut{
+			Architecture: src.Architecture,
+			Config:       cfg,
+			Devices:      devices,
+			Profiles:     src.Profiles,
+		},
+	}, nil
+}
+
+// forkDropSet is forkDroppedKeys plus the environment variables dropEnv
+// names.
+func forkDropSet(dropEnv []string) map[string]bool {
+	drop := map[string]bool{}
+	for _, k := range forkDroppedKeys {
+		drop[k] = true
+	}
+	for _, name := range dropEnv {
+		drop["environment."+name] = true
+	}
+	return drop
+}
+
+// forkDrops reports whether config key k stays behind with the source.
+func forkDrops(drop map[string]bool, k string) bool {
+	return drop[k] || (strings.HasPrefix(k, "volatile.") && !forkKeepsVolatile(k))
+}
+
+// forkKeepsVolatile reports whether a volatile key describes the snapshot's
+// CONTENTS rather than the source instance. The idmap keys record how the
+// rootfs is shifted; a fork without them would have Incus shift the cloned
+// rootfs a second time on first start.
+func forkKeepsVolatile(key string) bool {
+	return strings.HasPrefix(key, "volatile.idmap.") || key == "volatile.last_state.idmap"
+}
+
+func hasRootDisk(devices map[string]map[string]string) bool {
+	for _, d := range devices {
+		if d["type"] == "disk" && d["path"] == "/" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package incus
+
+import (
+	"testing"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func forkSource() *api.Instance {
+	return &api.Instance{
+		Name: "alice-container",
+		Type: string(api.InstanceTypeContainer),
+		InstancePut: api.InstancePut{
+			Config: map[string]string{
+				"limits.cpu":                    "2",
+				"environment.API_TOKEN":         "s3cret",
+				"environment.EDITOR":            "vim",
+				LabelPrefix + "role":            "agent",
+				TTLExpiresAtKey:                 "2026-01-01T00:00:00Z",
+				DeletePolicyKey:                 "protected",
+				"user.containarium.zfs_key_ref": "kms://alice",
+				"volatile.eth0.hwaddr":          "00:16:3e:00:00:01",
+				"volatile.last_state.idmap":     "[]",
+				"volatile.idmap.base":           "0",
+				"security.nesting":              "true",
+			},
+			Devices: map[string]map[string]string{
+				"root": {"type": "disk", "path": "/", "pool": "default", "size": "10GB"},
+				"eth0": {"type": "nic", "network": "incusbr0", "ipv4.address": "10.0.0.5", "hwaddr": "00:16:3e:00:00:01"},
+			},
+			Profiles: []string{"default"},
+		},
+	}
+}
+
+func TestForkInstancePostGivesTheForkItsOwnIdentity(t *testing.T) {
+	req, err := forkInstancePost(forkSource(), ForkSpec{
+		Source:      "alice-container",
+		Name:        "bob-container",
+		StoragePool: "tenant-acme",
+		Labels:      map[string]string{"attempt": "2"},
+		Tenant:      "alice",
+		DropEnv:     []string{"API_TOKEN"},
+	})
+	if err != nil {
+		t.Fatalf("forkInstancePost: %v", err)
+	}
+	if req.Source.Type != "none" || req.Name != "bob-container" {
+		t.Errorf("source/name = %q/%q", req.Source.Type, req.Name)
+	}
+
+	cfg := req.Config
+	for _, gone := range []string{
+		"environment.API_TOKEN", LabelPrefix + "role", TTLExpiresAtKey, DeletePolicyKey,
+		"user.containarium.zfs_key_ref", "volatile.eth0.hwaddr",
+	} {
+		if _, ok := cfg[gone]; ok {
+			t.Errorf("fork inherited %s", gone)
+		}
+	}
+	for k, want := range map[string]string{
+		"limits.cpu":                "2",
+		"environment.EDITOR":        "vim",
+		"security.nesting":          "true",
+		LabelPrefix + "attempt":     "2",
+		TenantLabelKey:              "alice",
+		"volatile.last_state.idmap": "[]",
+		"volatile.apply_template":   "copy",
+	} {
+		if cfg[k] != want {
+			t.Errorf("config[%s] = %q, want %q", k, cfg[k], want)
+		}
+	}
+
+	if got := req.Devices["root"]["pool"]; got != "tenant-acme" {
+		t.Errorf("root pool = %q", got)
+	}
+	eth := req.Devices["eth0"]
+	if eth["ipv4.address"] != "" || eth["hwaddr"] != "" || eth["network"] != "incusbr0" {
+		t.Errorf("eth0 = %v", eth)
+	}
+	if src := forkSource(); src.Devices["eth0"]["ipv4.address"] != "10.0.0.5" {
+		t.Error("fixture mutated")
+	}
+}
+
+// An explicit tenant on the source wins over the caller's fallback, and nil
+// labels keep the source's.
+func TestForkInstancePostKeepsTheSourcesTenantAndLabels(t *testing.T) {
+	src := forkSource()
+	src.Config[TenantLabelKey] = "acme"
+	req, err := forkInstancePost(src, ForkSpec{Source: "alice-container", Name: "bob-container", Tenant: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Config[TenantLabelKey] != "acme" || req.Config[LabelPrefix+"role"] != "agent" {
+		t.Errorf("config = %v", req.Config)
+	}
+	if req.Devices["root"]["pool"] != "default" {
+		t.Errorf("root pool changed without a StoragePool: %v", req.Devices["root"])
+	}
+}
+
+func TestForkInstancePostAddsARootDiskForTheTenantPool(t *testing.T) {
+	src := forkSource()
+	delete(src.Devices, "root")
+	req, err := forkInstancePost(src, ForkSpec{Source: "alice-container", Name: "bob-container", StoragePool: "tenant-acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := req.Devices["root"]; root["path"] != "/" || root["pool"] != "tenant-acme" {
+		t.Errorf("root = %v", root)
+	}
+}
+
+func TestForkInstancePostRefuses(t *testing.T) {
+	vm := forkSource()
+	vm.Type = string(api.InstanceTypeVM)
+	for name, tc := range map[string]struct {
+		src  *api.Instance
+		spec ForkSpec
+	}{
+		"a VM":         {vm, ForkSpec{Source: "alice-container", Name: "bob-container"}},
+		"no name":      {forkSource(), ForkSpec{Source: "alice-container"}},
+		"its own name": {forkSource(), ForkSpec{Source: "alice-container", Name: "alice-container"}},
+	} {
+		if _, err := forkInstancePost(tc.src, tc.spec); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+// A shape leaves the host inside an exported chain, so it must carry no more
+// than a fork would inherit — and restoring from it must build the same
+// shell a fork would.
+func TestShapeOfDropsWhatAForkDrops(t *testing.T) {
+	shape := shapeOf(forkSource(), []string{"API_TOKEN"})
+	for _, gone := range []string{
+		"environment.API_TOKEN", TTLExpiresAtKey, DeletePolicyKey,
+		"user.containarium.zfs_key_ref", "volatile.eth0.hwaddr",
+	} {
+		if _, ok := shape.Config[gone]; ok {
+			t.Errorf("shape carries %s", gone)
+		}
+	}
+	if shape.Config["volatile.idmap.base"] != "0" || shape.Config["environment.EDITOR"] != "vim" {
+		t.Errorf("shape lost what describes the contents: %v", shape.Config)
+	}
+
+	req, err := forkInstancePost(&api.Instance{InstancePut: api.InstancePut{
+		Config: shape.Config, Devices: shape.Devices, Profiles: shape.Profiles,
+	}}, ForkSpec{Name: "alice-container", StoragePool: "tenant-acme"})
+	if err != nil {
+		t.Fatalf("forkInstancePost from a shape: %v", err)
+	}
+	if req.Devices["root"]["pool"] != "tenant-acme" || req.Devices["eth0"]["ipv4.address"] != "" {
+		t.Errorf("devices = %v", req.Devices)
+	}
+}
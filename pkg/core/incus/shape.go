@@ -0,0 +1,219 @@
+package incus
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Recreating a container from an exported snapshot chain on a host that
+// never ran it. The root volume comes from the chain (zfscrypt.Receive); what
+// Incus knows about the instance — limits, devices, profiles — travels with
+// the chain as an InstanceShape, and the restore builds a fork-style shell
+// from it.
+
+// InstanceShape is the part of an instance's definition a restore recreates.
+// It is captured with the same exclusions as a fork — lifecycle stamps, key
+// references, MACs and the secrets named at capture — so what leaves the
+// host says nothing a fork of the box would not inherit.
+type InstanceShape struct {
+	Architecture string                       `json:"architecture,omitempty"`
+	Config       map[string]string            `json:"config,omitempty"`
+	Devices      map[string]map[string]string `json:"devices,omitempty"`
+	Profiles     []string                     `json:"profiles,omitempty"`
+}
+
+// InstanceShape returns name's shape, without the environment variables
+// dropEnv names.
+func (c *Client) InstanceShape(name string, dropEnv []string) (*InstanceShape, error) {
+	inst, _, err := c.server.GetInstance(name)
+	if err != nil {
+		return nil, fmt.Errorf("get container %s: %w", name, err)
+	}
+	return shapeOf(inst, dropEnv), nil
+}
+
+func shapeOf(inst *api.Instance, dropEnv []string) *InstanceShape {
+	drop := forkDropSet(dropEnv)
+	cfg := make(map[string]string, len(inst.Config))
+	for k, v := range inst.Config {
+		if !forkDrops(drop, k) {
+			cfg[k] = v
+		}
+	}
+	return &InstanceShape{
+		Architecture: inst.Architecture,
+		Config:       cfg,
+		Devices:      inst.Devices,
+		Profiles:     inst.Profiles,
+	}
+}
+
+// CreateShellFromShape creates a stopped instance from shape with an empty
+// root volume, as CreateForkShell does from a live source. spec.Source is
+// unused; the rest of spec applies as it does to a fork. shape is applied as
+// given, so one read from an export target must go through RestoreShape
+// first.
+func (c *Client) CreateShellFromShape(shape *InstanceShape, spec ForkSpec) error {
+	spec.Source = ""
+	req, err := forkInstancePost(&api.Instance{
+		Type: string(api.InstanceTypeContainer),
+		InstancePut: api.InstancePut{
+			Architecture: shape.Architecture,
+			Config:       shape.Config,
+			Devices:      shape.Devices,
+			Profiles:     shape.Profiles,
+		},
+	}, spec)
+	if err != nil {
+		return err
+	}
+	op, err := c.server.CreateInstance(req)
+	if err != nil {
+		return fmt.Errorf("create %s from an exported snapshot: %w", spec.Name, err)
+	}
+	if err := op.Wait(); err != nil {
+		return fmt.Errorf("create %s from an exported snapshot (operation failed): %w", spec.Name, err)
+	}
+	return nil
+}
+
+// restoreProfiles are the profiles a restored instance may name. Anything
+// else is a profile of the exporting host's — or of whoever wrote the
+// manifest — and could carry any config or device at all.
+var restoreProfiles = map[string]bool{"default": true}
+
+// restoreNICKeys are the NIC device keys a restore keeps. The pinned
+// address and MAC are dropped by forkInstancePost as for any fork; a NIC
+// naming a host interface (nictype, parent) is refused.
+var restoreNICKeys = map[string]bool{
+	"type":         true,
+	"name":         true,
+	"network":      true,
+	"ipv4.address": true,
+	"ipv6.address": true,
+	"hwaddr":       true,
+}
+
+// RestoreShape returns the part of an exported shape a restore may apply on
+// pool ("" for the daemon's default).
+//
+// The shape comes from a manifest at the export target, so it is a request,
+// not a definition: whoever can write the target can write it. Only what a
+// tenant could have asked CreateContainer for survives — limits, labels,
+// environment, nesting, the idmap the rootfs was shifted with, managed NICs
+// and a root disk on pool. Anything that grants the host (privilege, raw.*,
+// host-path disks, passthrough devices, other profiles) or identity the
+// daemon assigns (role, tenant) is refused with an error naming it; keys
+// describing the exporting host (other volatile.*, NIC ACLs) are dropped.
+func RestoreShape(shape *InstanceShape, pool string) (*InstanceShape, error) {
+	var refused []string
+
+	cfg := make(map[string]string, len(shape.Config))
+	for k, v := range shape.Config {
+		switch {
+		case strings.HasPrefix(k, "limits."),
+			strings.HasPrefix(k, LabelPrefix),
+			strings.HasPrefix(k, "environment."),
+			strings.HasPrefix(k, "image."),
+			forkKeepsVolatile(k),
+			k == "boot.autostart",
+			k == AutoSleepEnabledKey,
+			k == IdleThresholdMinutesKey:
+			cfg[k] = v
+		case k == "security.nesting",
+			k == "security.syscalls.intercept.mknod",
+			k == "security.syscalls.intercept.setxattr":
+			// What enable_podman gives any tenant, and no more.
+			if v != "true" {
+				refused = append(refused, fmt.Sprintf("config %s=%s", k, v))
+				continue
+			}
+			cfg[k] = v
+		case k == "raw.lxc":
+			// Only the PCI mask CreateContainer applies, which the
+			// restore applies afresh below.
+			if !onlyPCIMask(v) {
+				refused = append(refused, "config raw.lxc")
+			}
+		case k == TenantLabelKey,
+			strings.HasPrefix(k, "volatile."),
+			slices.Contains(forkDroppedKeys, k):
+			// The restoring daemon decides the tenant; the rest describes
+			// the exporting host.
+		default:
+			refused = append(refused, "config "+k)
+		}
+	}
+	// A restored box has no GPU (a gpu device is refused below), so it is
+	// masked like any box created without one.
+	cfg["raw.lxc"] = pciMaskRawLXC
+
+	devices := make(map[string]map[string]string, len(shape.Devices))
+	for name, dev := range shape.Devices {
+		switch {
+		case dev["type"] == "nic":
+			d := map[string]string{}
+			for k, v := range dev {
+				switch {
+				case restoreNICKeys[k]:
+					d[k] = v
+				case strings.HasPrefix(k, "security.acls"):
+					// The restoring host's network policy stamps its own.
+				default:
+					refused = append(refused, fmt.Sprintf("device %s: nic %s", name, k))
+				}
+			}
+			devices[name] = d
+		case dev["type"] == "disk" && dev["path"] == "/":
+			for k := range dev {
+				if k != "type" && k != "path" && k != "pool" && k != "size" {
+					refused = append(refused, fmt.Sprintf("device %s: root disk %s", name, k))
+				}
+			}
+			if pool == "" {
+				// The default profile's root disk names the default pool.
+				continue
+			}
+			d := map[string]string{"type": "disk", "path": "/", "pool": pool}
+			if size := dev["size"]; size != "" {
+				d["size"] = size
+			}
+			devices[name] = d
+		default:
+			refused = append(refused, fmt.Sprintf("device %s (type %q)", name, dev["type"]))
+		}
+	}
+
+	for _, p := range shape.Profiles {
+		if !restoreProfiles[p] {
+			refused = append(refused, "profile "+p)
+		}
+	}
+
+	if len(refused) > 0 {
+		slices.Sort(refused)
+		return nil, fmt.Errorf("the exported definition asks for what a restore does not grant: %s",
+			strings.Join(refused, ", "))
+	}
+	return &InstanceShape{
+		Architecture: shape.Architecture,
+		Config:       cfg,
+		Devices:      devices,
+		Profiles:     shape.Profiles,
+	}, nil
+}
+
+// onlyPCIMask reports whether a raw.lxc value holds nothing but
+// pciMaskRawLXC's lines.
+func onlyPCIMask(v string) bool {
+	mask := strings.Split(pciMaskRawLXC, "\n")
+	for _, line := range strings.Split(v, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !slices.Contains(mask, line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package incus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRestoreShapeKeepsWhatATenantCouldHaveAskedFor(t *testing.T) {
+	shape := &InstanceShape{
+		Config: map[string]string{
+			"limits.cpu":                "2",
+			"limits.memory":             "4GB",
+			"environment.EDITOR":        "vim",
+			LabelPrefix + "role":        "agent",
+			"security.nesting":          "true",
+			"raw.lxc":                   pciMaskRawLXC,
+			"volatile.idmap.base":       "0",
+			"volatile.eth0.hwaddr":      "00:16:3e:00:00:01",
+			TenantLabelKey:              "mallory",
+			"volatile.last_state.power": "RUNNING",
+		},
+		Devices: map[string]map[string]string{
+			"root": {"type": "disk", "path": "/", "pool": "elsewhere", "size": "10GB"},
+			"eth0": {"type": "nic", "network": "incusbr0", "security.acls": "old-host-acl"},
+		},
+		Profiles: []string{"default"},
+	}
+
+	got, err := RestoreShape(shape, "tenant-acme")
+	if err != nil {
+		t.Fatalf("RestoreShape: %v", err)
+	}
+	for _, k := range []string{"limits.cpu", "limits.memory", "environment.EDITOR", LabelPrefix + "role", "security.nesting", "volatile.idmap.base"} {
+		if got.Config[k] != shape.Config[k] {
+			t.Errorf("config %s = %q, want %q", k, got.Config[k], shape.Config[k])
+		}
+	}
+	for _, k := range []string{TenantLabelKey, "volatile.eth0.hwaddr", "volatile.last_state.power"} {
+		if _, ok := got.Config[k]; ok {
+			t.Errorf("kept %s", k)
+		}
+	}
+	if got.Config["raw.lxc"] != pciMaskRawLXC {
+		t.Errorf("raw.lxc = %q", got.Config["raw.lxc"])
+	}
+	if root := got.Devices["root"]; root["pool"] != "tenant-acme" || root["size"] != "10GB" {
+		t.Errorf("root = %v, want it on the chosen pool", root)
+	}
+	if _, ok := got.Devices["eth0"]["security.acls"]; ok {
+		t.Errorf("eth0 kept the exporting host's ACL: %v", got.Devices["eth0"])
+	}
+
+	// Without a pool the default profile's root disk applies.
+	if got, err = RestoreShape(shape, ""); err != nil || got.Devices["root"] != nil {
+		t.Errorf("no pool: root = %v, err = %v", got.Devices["root"], err)
+	}
+}
+
+func TestRestoreShapeRefusesWhatGrantsTheHost(t *testing.T) {
+	for name, shape := range map[string]*InstanceShape{
+		"privileged":       {Config: map[string]string{"security.privileged": "true"}},
+		"nesting off":      {Config: map[string]string{"security.nesting": "false"}},
+		"other security":   {Config: map[string]string{"security.idmap.isolated": "false"}},
+		"raw.lxc":          {Config: map[string]string{"raw.lxc": "lxc.apparmor.profile=unconfined\n" + pciMaskRawLXC}},
+		"raw.idmap":        {Config: map[string]string{"raw.idmap": "both 0 0"}},
+		"linux.kernel":     {Config: map[string]string{"linux.kernel_modules": "ip_tables"}},
+		"role":             {Config: map[string]string{RoleKey: "core-postgres"}},
+		"host root":        {Devices: map[string]map[string]string{"host": {"type": "disk", "path": "/mnt", "source": "/"}}},
+		"root with source": {Devices: map[string]map[string]string{"root": {"type": "disk", "path": "/", "source": "/"}}},
+		"unix-char":        {Devices: map[string]map[string]string{"kmsg": {"type": "unix-char", "path": "/dev/kmsg"}}},
+		"gpu":              {Devices: map[string]map[string]string{"gpu": {"type": "gpu"}}},
+		"proxy":            {Devices: map[string]map[string]string{"p": {"type": "proxy", "listen": "tcp:0.0.0.0:22"}}},
+		"host nic":         {Devices: map[string]map[string]string{"eth0": {"type": "nic", "nictype": "physical", "parent": "eth0"}}},
+		"unknown profile":  {Profiles: []string{"default", "privileged"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got, err := RestoreShape(shape, "default"); err == nil {
+				t.Fatalf("RestoreShape = %+v, want a refusal", got)
+			} else if !strings.Contains(err.Error(), "does not grant") {
+				t.Errorf("err = %v", err)
+			}
+		})
+	}
+}
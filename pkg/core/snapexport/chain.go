@@ -0,0 +1,93 @@
+package snapexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Chain is what a target holds for one container: the links in export
+// order, each a full stream or an increment on the link before it. It is
+// persisted as manifest.json next to the streams, so a host that has never
+// seen the container can restore it from the target alone.
+type Chain struct {
+	// Container is the exported container's name, and the directory its
+	// streams live under at the target.
+	Container string `json:"container"`
+	// Username owns the container. A restore is authorized against it as
+	// well as against the box being created, so a chain cannot be restored
+	// by someone who could not have read the box it came from.
+	Username string `json:"username"`
+	// Tenant is the tenant whose key an encrypted chain is wrapped with;
+	// empty for an unencrypted one.
+	Tenant    string `json:"tenant,omitempty"`
+	Encrypted bool   `json:"encrypted"`
+	// Shape is the daemon's record of the instance — config, devices,
+	// profiles, minus secrets — so a restore can create a box like it.
+	// Opaque here; the server layer owns its format.
+	Shape json.RawMessage `json:"shape,omitempty"`
+	Links []Link          `json:"links"`
+}
+
+// Link is one exported stream.
+type Link struct {
+	Snapshot string `json:"snapshot"`
+	// Base is the snapshot this stream is incremental from — always the
+	// previous link's — or empty for a full stream.
+	Base       string    `json:"base,omitempty"`
+	Object     string    `json:"object"`
+	SizeBytes  int64     `json:"size_bytes"`
+	SHA256     string    `json:"sha256"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// Latest returns the newest link, or nil for an empty chain.
+func (c *Chain) Latest() *Link {
+	if len(c.Links) == 0 {
+		return nil
+	}
+	return &c.Links[len(c.Links)-1]
+}
+
+// find returns the index of snapshot's link, or -1.
+func (c *Chain) find(snapshot string) int {
+	for i := range c.Links {
+		if c.Links[i].Snapshot == snapshot {
+			return i
+		}
+	}
+	return -1
+}
+
+// Plan returns the links to receive, in order, to materialize snapshot: the
+// nearest full stream at or before it and every increment after that up to
+// it. snapshot "" means the newest.
+//
+// A chain whose increments do not each follow the link before is refused
+// rather than applied: ZFS would reject the stream mid-restore anyway, after
+// the earlier links had been received for nothing.
+func (c *Chain) Plan(snapshot string) ([]Link, error) {
+	if len(c.Links) == 0 {
+		return nil, fmt.Errorf("no snapshots of %s have been exported", c.Container)
+	}
+	end := len(c.Links) - 1
+	if snapshot != "" {
+		if end = c.find(snapshot); end < 0 {
+			return nil, fmt.Errorf("snapshot %q of %s has not been exported", snapshot, c.Container)
+		}
+	}
+	start := end
+	for start >= 0 && c.Links[start].Base != "" {
+		start--
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("the chain for %s has no full stream before %q", c.Container, c.Links[end].Snapshot)
+	}
+	for i := start + 1; i <= end; i++ {
+		if c.Links[i].Base != c.Links[i-1].Snapshot {
+			return nil, fmt.Errorf("the chain for %s is broken at %q: it is incremental from %q, not %q",
+				c.Container, c.Links[i].Snapshot, c.Links[i].Base, c.Links[i-1].Snapshot)
+		}
+	}
+	return append([]Link(nil), c.Links[start:end+1]...), nil
+}
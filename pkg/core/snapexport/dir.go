@@ -0,0 +1,104 @@
+package snapexport
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirUploader implements backup.Uploader over a directory, for file://
+// targets — an NFS mount or a removable disk. Off-host only as far as the
+// directory is: a path on the container's own pool protects against
+// nothing, and the daemon does not try to tell.
+//
+// Every path is confined to Root. A file:// target is read and written as
+// the daemon, so without a root an export could write streams, and a
+// restore read a manifest, anywhere on the host.
+type DirUploader struct {
+	// Root is the directory every file:// target must lie under. Empty
+	// refuses all of them.
+	Root string
+}
+
+// filePath extracts the absolute path of a file:// URI and checks it lies
+// under d.Root.
+func (d DirUploader) filePath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || u.Host != "" || !filepath.IsAbs(u.Path) {
+		return "", fmt.Errorf("invalid file target %q: expected file:///absolute/path", uri)
+	}
+	p := filepath.Clean(u.Path)
+	root := filepath.Clean(d.Root)
+	if d.Root == "" || (p != root && !strings.HasPrefix(p, root+string(filepath.Separator))) {
+		return "", fmt.Errorf("file target %q is outside the export directory %q", uri, d.Root)
+	}
+	return p, nil
+}
+
+// CheckTarget refuses a target outside d.Root before an export stages a
+// stream for it.
+func (d DirUploader) CheckTarget(uri string) error {
+	_, err := d.filePath(uri)
+	return err
+}
+
+func (d DirUploader) Upload(localPath, destURI string) error {
+	dst, err := d.filePath(destURI)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+		return err
+	}
+	// Written beside the destination and renamed over it, so a reader — a
+	// restore on another host sharing the mount — never sees half a stream.
+	return copyFile(localPath, dst+".partial", dst)
+}
+
+func (d DirUploader) Download(destURI, localPath string) error {
+	src, err := d.filePath(destURI)
+	if err != nil {
+		return err
+	}
+	return copyFile(src, localPath, localPath)
+}
+
+func (d DirUploader) Delete(destURI string) error {
+	p, err := d.filePath(destURI)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// copyFile copies src to tmp and renames tmp to dst.
+func copyFile(src, tmp, dst string) error {
+	in, err := os.Open(src) // #nosec G304 -- paths are the exporter's own staging files and targets
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) // #nosec G304 -- as above
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if tmp != dst {
+		return os.Rename(tmp, dst)
+	}
+	return nil
+}
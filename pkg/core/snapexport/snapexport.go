@@ -0,0 +1,461 @@
+// Package snapexport ships container snapshots off the host they were taken
+// on, as raw ZFS replication streams, and brings them back.
+//
+// Design (see docs/architecture/container-snapshots.md, "Export"):
+//
+//   - A snapshot is exported as a `zfs send -w` stream (zfscrypt.Send), so
+//     an encrypted container's stream is ciphertext under the tenant key
+//     and the target never sees plaintext.
+//   - Each export after the first is incremental from the previous one
+//     when that snapshot is still on the host; the exports of a container
+//     at a target form a Chain, persisted as manifest.json beside the
+//     streams. A restore receives the nearest full stream and the
+//     increments after it (Chain.Plan).
+//   - Streams are staged on the host through a checksum, shipped with the
+//     same backup.Uploader implementations database backups use (s3://,
+//     gs://) or DirUploader (file://), and the staging copy removed.
+//     Restores download, verify the checksum, then receive, so a corrupt
+//     object fails before ZFS sees it.
+//   - The exporter keeps its own copy of each chain in the host directory
+//     to choose incremental bases without reading the target back. A host
+//     with no copy extends the chain already at the target, from a full
+//     stream, rather than overwriting it.
+//
+// The package deliberately does not import the protobuf types or Incus;
+// the server layer supplies the dataset, the snapshot list and the
+// instance shape.
+package snapexport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/footprintai/containarium/pkg/core/backup"
+)
+
+// Sender moves replication streams in and out of ZFS. Satisfied by
+// *zfscrypt.Manager; declared here so tests can supply a fake.
+type Sender interface {
+	Send(ctx context.Context, snapshot, base string, w io.Writer) error
+	Receive(ctx context.Context, dataset string, r io.Reader) error
+}
+
+// manifestName is the chain's object under <target>/<container>/.
+const manifestName = "manifest.json"
+
+// Exporter exports and restores snapshot chains. One per daemon; exports
+// are serialized, since each one reads and extends its chain.
+type Exporter struct {
+	zfs       Sender
+	dir       string                     // staging and the local chain index
+	uploaders map[string]backup.Uploader // by URI scheme; missing → that target is rejected
+	clock     func() time.Time
+	mu        sync.Mutex
+}
+
+// NewExporter constructs an exporter staging under dir. It has no targets
+// until SetUploader adds them.
+func NewExporter(zfs Sender, dir string) *Exporter {
+	return &Exporter{
+		zfs:       zfs,
+		dir:       dir,
+		uploaders: map[string]backup.Uploader{},
+		clock:     time.Now,
+	}
+}
+
+// SetUploader registers the uploader for targets with the URI scheme
+// ("s3", "gs", or "file" with a DirUploader). Call before serving
+// requests; the map is not locked.
+func (e *Exporter) SetUploader(scheme string, u backup.Uploader) {
+	e.uploaders[scheme] = u
+}
+
+// uploader returns the uploader for target and target without a trailing
+// slash.
+func (e *Exporter) uploader(target string) (backup.Uploader, string, error) {
+	scheme, rest, ok := strings.Cut(target, "://")
+	if !ok || strings.Trim(rest, "/") == "" {
+		return nil, "", fmt.Errorf("invalid target %q: expected s3://bucket/prefix, gs://bucket/prefix or file:///path", target)
+	}
+	u := e.uploaders[scheme]
+	if u == nil {
+		return nil, "", fmt.Errorf("%s:// targets are not configured on this daemon", scheme)
+	}
+	target = strings.TrimRight(target, "/")
+	if c, ok := u.(targetChecker); ok {
+		if err := c.CheckTarget(target); err != nil {
+			return nil, "", err
+		}
+	}
+	return u, target, nil
+}
+
+// targetChecker is an uploader that can refuse a target before anything
+// is staged for it.
+type targetChecker interface {
+	CheckTarget(uri string) error
+}
+
+// ExportOptions parameterizes an export.
+type ExportOptions struct {
+	// Target is the URI the chain lives under: s3://bucket/prefix,
+	// gs://bucket/prefix or file:///path.
+	Target    string
+	Container string
+	Username  string
+	Tenant    string
+	Encrypted bool
+	// Dataset is the container's root dataset; Snapshot the name (no "@")
+	// of the snapshot to export.
+	Dataset  string
+	Snapshot string
+	// Snapshots are the names of Dataset's snapshots, oldest first. The
+	// previous export is only used as the incremental base while it is
+	// still among them and older than Snapshot.
+	Snapshots []string
+	// Full sends a full stream even when an incremental one is possible,
+	// starting a fresh run of the chain a restore need not go back past.
+	Full  bool
+	Shape json.RawMessage
+}
+
+// Export sends opts.Snapshot to opts.Target and returns the chain with its
+// link appended. A snapshot that is already in the chain is not sent
+// again; its link is returned as it is.
+func (e *Exporter) Export(ctx context.Context, opts ExportOptions) (*Chain, *Link, error) {
+	if err := validatePathComponent("container", opts.Container); err != nil {
+		return nil, nil, err
+	}
+	if err := validatePathComponent("snapshot", opts.Snapshot); err != nil {
+		return nil, nil, err
+	}
+	up, target, err := e.uploader(opts.Target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	chain, adopted, err := e.chainFor(up, target, opts.Container)
+	if err != nil {
+		return nil, nil, err
+	}
+	if i := chain.find(opts.Snapshot); i >= 0 {
+		return chain, &chain.Links[i], nil
+	}
+	if adopted && chain.Username != opts.Username {
+		return nil, nil, fmt.Errorf("%s/%s holds a chain exported for user %q, not %q", target, opts.Container, chain.Username, opts.Username)
+	}
+	chain.Username, chain.Tenant, chain.Encrypted = opts.Username, opts.Tenant, opts.Encrypted
+	if len(opts.Shape) > 0 {
+		chain.Shape = opts.Shape
+	}
+
+	// A chain adopted from the target was extended from another host, whose
+	// snapshots of the same name need not be these: the next link is full.
+	base := ""
+	if last := chain.Latest(); last != nil && !opts.Full && !adopted && precedes(opts.Snapshots, last.Snapshot, opts.Snapshot) {
+		base = last.Snapshot
+	}
+	baseRef := ""
+	if base != "" {
+		baseRef = opts.Dataset + "@" + base
+	}
+
+	now := e.clock().UTC()
+	object := fmt.Sprintf("%s/%s/%s-%s.zfs", target, opts.Container, now.Format("20060102T150405Z"), opts.Snapshot)
+	size, sum, err := e.stage(ctx, opts.Dataset+"@"+opts.Snapshot, baseRef, func(staged string) error {
+		return up.Upload(staged, object)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chain.Links = append(chain.Links, Link{
+		Snapshot:   opts.Snapshot,
+		Base:       base,
+		Object:     object,
+		SizeBytes:  size,
+		SHA256:     sum,
+		ExportedAt: now,
+	})
+	// The manifest is uploaded before the index is written: an index
+	// ahead of the target would base the next export on a link a restore
+	// cannot find.
+	if err := e.uploadManifest(up, target, chain); err != nil {
+		return nil, nil, err
+	}
+	if err := e.writeIndex(target, chain); err != nil {
+		return nil, nil, err
+	}
+	return chain, chain.Latest(), nil
+}
+
+// stage streams the send into a hidden file under the exporter's
+// directory through the checksum, hands it to ship and removes it.
+func (e *Exporter) stage(ctx context.Context, snapshot, base string, ship func(staged string) error) (int64, string, error) {
+	if err := os.MkdirAll(e.dir, 0o700); err != nil {
+		return 0, "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	f, err := os.CreateTemp(e.dir, ".send-*.partial")
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to stage stream: %w", err)
+	}
+	staged := f.Name()
+	defer func() { _ = os.Remove(staged) }()
+
+	h := sha256.New()
+	cw := &countWriter{}
+	err = e.zfs.Send(ctx, snapshot, base, io.MultiWriter(f, h, cw))
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to stage stream: %w", cerr)
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if err := ship(staged); err != nil {
+		return 0, "", fmt.Errorf("failed to upload %s: %w", snapshot, err)
+	}
+	return cw.n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FetchChain downloads the chain for container from target.
+func (e *Exporter) FetchChain(target, container string) (*Chain, error) {
+	if err := validatePathComponent("container", container); err != nil {
+		return nil, err
+	}
+	up, target, err := e.uploader(target)
+	if err != nil {
+		return nil, err
+	}
+	return e.fetchChain(up, target, container)
+}
+
+func (e *Exporter) fetchChain(up backup.Uploader, target, container string) (*Chain, error) {
+	if err := os.MkdirAll(e.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	f, err := os.CreateTemp(e.dir, ".manifest-*.partial")
+	if err != nil {
+		return nil, err
+	}
+	local := f.Name()
+	_ = f.Close()
+	defer func() { _ = os.Remove(local) }()
+
+	uri := target + "/" + container + "/" + manifestName
+	if err := up.Download(uri, local); err != nil {
+		return nil, fmt.Errorf("no exported chain for %s at %s: %w: %w", container, target, errNoChain, err)
+	}
+	chain, err := readChain(local)
+	if err != nil {
+		return nil, err
+	}
+	if chain.Container != container {
+		return nil, fmt.Errorf("the chain at %s/%s is for container %q", target, container, chain.Container)
+	}
+	return chain, nil
+}
+
+// Receive materializes links — a Plan of the chain fetched from target —
+// into dataset, which must not exist yet: the full stream creates it and
+// each increment extends it. Every stream is downloaded and its checksum
+// verified before ZFS reads a byte of it.
+//
+// The links come from the target's manifest, so their objects are checked
+// to lie directly under <target>/<container>/ before any is read: a
+// manifest must not point a restore at another container's streams, or
+// anywhere else the daemon can reach.
+func (e *Exporter) Receive(ctx context.Context, target string, chain *Chain, links []Link, dataset string) error {
+	if len(links) == 0 || links[0].Base != "" {
+		return fmt.Errorf("a restore must start from a full stream")
+	}
+	up, target, err := e.uploader(target)
+	if err != nil {
+		return err
+	}
+	if err := validatePathComponent("container", chain.Container); err != nil {
+		return err
+	}
+	dir := target + "/" + chain.Container + "/"
+	for _, l := range links {
+		name, ok := strings.CutPrefix(l.Object, dir)
+		if !ok || validatePathComponent("stream", name) != nil || name == manifestName {
+			return fmt.Errorf("the chain for %s names stream %q, which is not under %s", chain.Container, l.Object, dir)
+		}
+	}
+	if err := os.MkdirAll(e.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	for _, l := range links {
+		if err := e.receiveLink(ctx, up, l, dataset); err != nil {
+			return fmt.Errorf("restore %s of %s: %w", l.Snapshot, chain.Container, err)
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) receiveLink(ctx context.Context, up backup.Uploader, l Link, dataset string) error {
+	f, err := os.CreateTemp(e.dir, ".recv-*.partial")
+	if err != nil {
+		return err
+	}
+	local := f.Name()
+	_ = f.Close()
+	defer func() { _ = os.Remove(local) }()
+
+	if err := up.Download(l.Object, local); err != nil {
+		return fmt.Errorf("download %s: %w", l.Object, err)
+	}
+	in, err := os.Open(local) // #nosec G304 -- a temp file this function created
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, in); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != l.SHA256 {
+		return fmt.Errorf("%s is corrupt: sha256 %s, exported as %s", l.Object, got, l.SHA256)
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return e.zfs.Receive(ctx, dataset, in)
+}
+
+// precedes reports whether a comes before b in snapshots.
+func precedes(snapshots []string, a, b string) bool {
+	ia, ib := -1, -1
+	for i, s := range snapshots {
+		switch s {
+		case a:
+			ia = i
+		case b:
+			ib = i
+		}
+	}
+	return ia >= 0 && ib >= 0 && ia < ib
+}
+
+// indexPath is the local copy of the chain for container at target. The
+// target is hashed rather than escaped into the name: it is a URI, and
+// only its identity matters here.
+func (e *Exporter) indexPath(target, container string) string {
+	sum := sha256.Sum256([]byte(target))
+	return filepath.Join(e.dir, "chains", hex.EncodeToString(sum[:8])+"-"+container+".json")
+}
+
+// readIndex returns the local copy of the chain, or a new empty chain when
+// this host has not exported container to target.
+func (e *Exporter) readIndex(target, container string) (*Chain, error) {
+	c, err := readChain(e.indexPath(target, container))
+	if os.IsNotExist(err) {
+		return &Chain{Container: container}, nil
+	}
+	return c, err
+}
+
+// chainFor returns the chain an export extends: the local index or, when
+// this host has not exported container to target, the one already there —
+// a box restored from it, or one whose host was rebuilt, must not overwrite
+// its history with a one-link manifest. adopted reports the latter.
+//
+// A manifest that cannot be downloaded is taken to be absent: the uploaders
+// do not tell a missing object from an unreadable one.
+func (e *Exporter) chainFor(up backup.Uploader, target, container string) (*Chain, bool, error) {
+	chain, err := e.readIndex(target, container)
+	if err != nil || len(chain.Links) > 0 {
+		return chain, false, err
+	}
+	remote, err := e.fetchChain(up, target, container)
+	if errors.Is(err, errNoChain) {
+		return chain, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return remote, len(remote.Links) > 0, nil
+}
+
+// errNoChain is a manifest that could not be downloaded.
+var errNoChain = errors.New("no manifest")
+
+func (e *Exporter) writeIndex(target string, c *Chain) error {
+	p := e.indexPath(target, c.Container)
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	return writeChain(p, c)
+}
+
+func (e *Exporter) uploadManifest(up backup.Uploader, target string, c *Chain) error {
+	f, err := os.CreateTemp(e.dir, ".manifest-*.partial")
+	if err != nil {
+		return err
+	}
+	local := f.Name()
+	_ = f.Close()
+	defer func() { _ = os.Remove(local) }()
+	if err := writeChain(local, c); err != nil {
+		return err
+	}
+	uri := target + "/" + c.Container + "/" + manifestName
+	if err := up.Upload(local, uri); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", uri, err)
+	}
+	return nil
+}
+
+func readChain(path string) (*Chain, error) {
+	b, err := os.ReadFile(path) // #nosec G304 -- the exporter's own index or a temp download
+	if err != nil {
+		return nil, err
+	}
+	var c Chain
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("corrupt snapshot chain %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+func writeChain(path string, c *Chain) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// validatePathComponent rejects a name that would escape the directory it
+// is joined under, locally or at the target.
+func validatePathComponent(what, s string) error {
+	if s == "" || s == "." || s == ".." || strings.ContainsAny(s, "/\\") {
+		return fmt.Errorf("invalid %s name %q", what, s)
+	}
+	return nil
+}
+
+type countWriter struct{ n int64 }
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,318 @@
+package snapexport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSender stands in for zfscrypt.Manager: Send writes "<snapshot> from
+// <base>" so a test can see what was streamed, and Receive records each
+// stream in order.
+type fakeSender struct {
+	sends    [][2]string
+	received []string
+	into     []string
+}
+
+func (f *fakeSender) Send(_ context.Context, snapshot, base string, w io.Writer) error {
+	f.sends = append(f.sends, [2]string{snapshot, base})
+	_, err := io.WriteString(w, snapshot+" from "+base)
+	return err
+}
+
+func (f *fakeSender) Receive(_ context.Context, dataset string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	f.received = append(f.received, string(b))
+	f.into = append(f.into, dataset)
+	return err
+}
+
+const testDataset = "tank/containers/alice-container"
+
+func newTestExporter(t *testing.T) (*Exporter, *fakeSender, string) {
+	t.Helper()
+	zfs := &fakeSender{}
+	e := NewExporter(zfs, filepath.Join(t.TempDir(), "staging"))
+	root := t.TempDir()
+	e.SetUploader("file", DirUploader{Root: root})
+	tick := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	e.clock = func() time.Time { tick = tick.Add(time.Minute); return tick }
+	return e, zfs, "file://" + root + "/exports"
+}
+
+func exportOpts(target, snapshot string, snapshots ...string) ExportOptions {
+	return ExportOptions{
+		Target:    target,
+		Container: "alice-container",
+		Username:  "alice",
+		Dataset:   testDataset,
+		Snapshot:  snapshot,
+		Snapshots: snapshots,
+	}
+}
+
+func TestExportIsIncrementalFromThePreviousExport(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+
+	if _, _, err := e.Export(ctx, exportOpts(target, "a", "a")); err != nil {
+		t.Fatalf("first export: %v", err)
+	}
+	chain, link, err := e.Export(ctx, exportOpts(target, "b", "a", "b"))
+	if err != nil {
+		t.Fatalf("second export: %v", err)
+	}
+	if zfs.sends[0][1] != "" || zfs.sends[1][1] != testDataset+"@a" {
+		t.Fatalf("sends = %v, want full then incremental from @a", zfs.sends)
+	}
+	if link.Base != "a" || len(chain.Links) != 2 {
+		t.Fatalf("link = %+v, chain = %+v", link, chain.Links)
+	}
+	if link.SizeBytes != int64(len(testDataset+"@b from "+testDataset+"@a")) || link.SHA256 == "" {
+		t.Errorf("link size/sum = %d/%q", link.SizeBytes, link.SHA256)
+	}
+
+	// The manifest at the target is what a host that never saw the box
+	// restores from.
+	remote, err := e.FetchChain(target, "alice-container")
+	if err != nil {
+		t.Fatalf("FetchChain: %v", err)
+	}
+	if len(remote.Links) != 2 || remote.Username != "alice" {
+		t.Errorf("remote chain = %+v", remote)
+	}
+
+	staged, _ := filepath.Glob(filepath.Join(e.dir, ".*.partial"))
+	if len(staged) != 0 {
+		t.Errorf("staging files left behind: %v", staged)
+	}
+}
+
+// The base has to still exist on the host, and be older, or `zfs send -i`
+// has nothing to diff against.
+func TestExportFallsBackToFullWhenTheBaseIsGone(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+	if _, _, err := e.Export(ctx, exportOpts(target, "a", "a")); err != nil {
+		t.Fatal(err)
+	}
+	_, link, err := e.Export(ctx, exportOpts(target, "c", "b", "c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Base != "" || zfs.sends[1][1] != "" {
+		t.Errorf("link = %+v, send = %v — want a full stream", link, zfs.sends[1])
+	}
+
+	opts := exportOpts(target, "d", "c", "d")
+	opts.Full = true
+	if _, link, err = e.Export(ctx, opts); err != nil || link.Base != "" {
+		t.Errorf("--full export: link = %+v, err = %v", link, err)
+	}
+}
+
+func TestExportOfAnExportedSnapshotSendsNothing(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+	_, first, err := e.Export(ctx, exportOpts(target, "a", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, again, err := e.Export(ctx, exportOpts(target, "a", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zfs.sends) != 1 || again.Object != first.Object {
+		t.Errorf("sends = %v, link = %+v", zfs.sends, again)
+	}
+}
+
+// Another host — a rebuilt one, or one a box was restored to — has no index
+// for the chain already at the target, and must extend it rather than
+// overwrite it.
+func TestExportExtendsTheTargetsChainWithoutALocalIndex(t *testing.T) {
+	e, _, target := newTestExporter(t)
+	ctx := context.Background()
+	for _, snap := range []string{"a", "b"} {
+		if _, _, err := e.Export(ctx, exportOpts(target, snap, "a", "b", "c")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	other := NewExporter(&fakeSender{}, filepath.Join(t.TempDir(), "staging"))
+	other.uploaders = e.uploaders
+	other.clock = e.clock
+	chain, link, err := other.Export(ctx, exportOpts(target, "c", "a", "b", "c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain.Links) != 3 || link.Base != "" {
+		t.Fatalf("links = %+v — want a, b kept and c full", chain.Links)
+	}
+	if got, err := other.FetchChain(target, "alice-container"); err != nil || len(got.Links) != 3 {
+		t.Errorf("manifest = %+v, err = %v", got, err)
+	}
+
+	bob := exportOpts(target, "d", "d")
+	bob.Username = "bob"
+	third := NewExporter(&fakeSender{}, filepath.Join(t.TempDir(), "staging"))
+	third.uploaders = e.uploaders
+	if _, _, err := third.Export(ctx, bob); err == nil {
+		t.Error("overwrote alice's chain with bob's")
+	}
+}
+
+func TestExportRejectsUnknownTargetsAndUnsafeNames(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+	for name, opts := range map[string]ExportOptions{
+		"unconfigured scheme": exportOpts("s3://bucket/x", "a", "a"),
+		"no scheme":           exportOpts("/mnt/backups", "a", "a"),
+		"escaping snapshot":   exportOpts(target, "../a", "../a"),
+		"outside the root":    exportOpts("file://"+t.TempDir(), "a", "a"),
+		"escaping the root":   exportOpts(target+"/../../etc", "a", "a"),
+	} {
+		if _, _, err := e.Export(ctx, opts); err == nil {
+			t.Errorf("%s: exported", name)
+		}
+	}
+	if len(zfs.sends) != 0 {
+		t.Errorf("sent anyway: %v", zfs.sends)
+	}
+}
+
+func TestReceiveAppliesThePlanInOrder(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+	for i, s := range []string{"a", "b", "c"} {
+		if _, _, err := e.Export(ctx, exportOpts(target, s, []string{"a", "b", "c"}[:i+1]...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain, err := e.FetchChain(target, "alice-container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := chain.Plan("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Receive(ctx, target, chain, plan, "tank/containers/bob-container_import"); err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	want := []string{testDataset + "@a from ", testDataset + "@b from " + testDataset + "@a"}
+	if strings.Join(zfs.received, "|") != strings.Join(want, "|") {
+		t.Errorf("received = %q, want %q", zfs.received, want)
+	}
+}
+
+func TestReceiveRefusesACorruptStream(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+	_, link, err := e.Export(ctx, exportOpts(target, "a", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := strings.TrimPrefix(link.Object, "file://")
+	if err := os.WriteFile(p, []byte("flipped"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	chain, _ := e.FetchChain(target, "alice-container")
+	if err := e.Receive(ctx, target, chain, chain.Links, "tank/x"); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(zfs.received) != 0 {
+		t.Errorf("ZFS was handed a corrupt stream")
+	}
+}
+
+// The links come from the target's manifest; one pointing outside the
+// container's directory is refused before anything is read.
+func TestReceiveRefusesStreamsOutsideTheContainer(t *testing.T) {
+	e, zfs, target := newTestExporter(t)
+	ctx := context.Background()
+	if _, _, err := e.Export(ctx, exportOpts(target, "a", "a")); err != nil {
+		t.Fatal(err)
+	}
+	chain, err := e.FetchChain(target, "alice-container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, object := range map[string]string{
+		"another container": target + "/bob-container/x.zfs",
+		"traversal":         target + "/alice-container/../bob-container/x.zfs",
+		"another target":    "file:///etc/shadow",
+		"the manifest":      target + "/alice-container/" + manifestName,
+	} {
+		links := []Link{chain.Links[0]}
+		links[0].Object = object
+		if err := e.Receive(ctx, target, chain, links, "tank/x"); err == nil {
+			t.Errorf("%s: received %s", name, object)
+		}
+	}
+	if len(zfs.received) != 0 {
+		t.Errorf("received = %v", zfs.received)
+	}
+}
+
+func TestFetchChainRefusesAnotherContainersManifest(t *testing.T) {
+	e, _, target := newTestExporter(t)
+	if _, _, err := e.Export(context.Background(), exportOpts(target, "a", "a")); err != nil {
+		t.Fatal(err)
+	}
+	root := strings.TrimPrefix(target, "file://")
+	if err := os.MkdirAll(filepath.Join(root, "bob-container"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(root, "alice-container", manifestName), filepath.Join(root, "bob-container", manifestName)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.FetchChain(target, "bob-container"); err == nil {
+		t.Fatal("fetched alice's chain as bob's")
+	}
+}
+
+func TestPlanStartsFromTheNearestFullStream(t *testing.T) {
+	c := &Chain{Container: "x", Links: []Link{
+		{Snapshot: "a"},
+		{Snapshot: "b", Base: "a"},
+		{Snapshot: "c"},
+		{Snapshot: "d", Base: "c"},
+	}}
+	names := func(ls []Link) string {
+		var s []string
+		for _, l := range ls {
+			s = append(s, l.Snapshot)
+		}
+		return strings.Join(s, ",")
+	}
+	for snapshot, want := range map[string]string{"": "c,d", "b": "a,b", "c": "c", "a": "a"} {
+		got, err := c.Plan(snapshot)
+		if err != nil || names(got) != want {
+			t.Errorf("Plan(%q) = %s, %v; want %s", snapshot, names(got), err, want)
+		}
+	}
+	if _, err := c.Plan("zz"); err == nil {
+		t.Error("planned a snapshot that was never exported")
+	}
+
+	broken := &Chain{Container: "x", Links: []Link{{Snapshot: "a"}, {Snapshot: "c", Base: "b"}}}
+	if _, err := broken.Plan(""); err == nil {
+		t.Error("planned a chain with a missing increment")
+	}
+}
+
+func TestChainRoundTripsItsShape(t *testing.T) {
+	c := Chain{Container: "x", Shape: json.RawMessage(`{"architecture":"x86_64"}`)}
+	b, _ := json.Marshal(c)
+	var back Chain
+	if err := json.Unmarshal(b, &back); err != nil || string(back.Shape) != `{"architecture":"x86_64"}` {
+		t.Errorf("shape = %s, %v", back.Shape, err)
+	}
+}
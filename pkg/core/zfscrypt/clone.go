@@ -0,0 +1,144 @@
+package zfscrypt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Cloning a snapshot into another container's place (#1160c).
+//
+// clone_constraints_integration_test.go established what ZFS does and does
+// not guarantee here: a clone can be created anywhere in the pool, it keeps
+// its ORIGIN's encryptionroot wherever it lands, it needs the origin's key
+// loaded, and it pins the origin snapshot until the clone is destroyed. The
+// first of those is the hazard — ZFS will happily put tenant A's data inside
+// tenant B's subtree — so the refusal lives here, in the one primitive that
+// creates clones, rather than in each caller.
+
+// ErrCrossTenantClone reports a clone whose origin and destination are under
+// different encryptionroots. ZFS would permit it; the daemon must not, or one
+// tenant's data comes to rest in another tenant's tree where that tenant's
+// offboarding (#1343) acts on it.
+var ErrCrossTenantClone = fmt.Errorf("clone would place a snapshot under a different encryptionroot")
+
+// cloneCarriedProps are the properties of the replaced dataset the clone
+// takes over. They are what the storage layer (Incus) set on the volume it
+// created — where it mounts it, that it mounts it itself, and its size limit
+// — and a clone would otherwise inherit them from its new parent instead.
+var cloneCarriedProps = []string{"mountpoint", "canmount", "quota", "refquota"}
+
+// ReplaceWithClone swaps the dataset target for a clone of snapshot, keeping
+// target's mount and quota properties.
+//
+// Used to fork a container: the storage layer creates an empty volume for
+// the new instance, and this puts the snapshot's contents in its place
+// without copying a block.
+//
+// The clone is created under a temporary name and only then does target get
+// destroyed and the clone renamed over it, so a clone ZFS refuses — the key
+// unloaded, the pool full — leaves target exactly as it was. A rename that
+// fails after that leaves neither.
+//
+// Refused with ErrCrossTenantClone unless snapshot and target share an
+// encryptionroot (or are both unencrypted).
+func (m *Manager) ReplaceWithClone(ctx context.Context, snapshot, target string) error {
+	origin, _, ok := strings.Cut(snapshot, "@")
+	if !ok {
+		return fmt.Errorf("invalid snapshot reference %q: expected <dataset>@<name>", snapshot)
+	}
+	if err := validateDataset(origin); err != nil {
+		return err
+	}
+	if err := validateDataset(target); err != nil {
+		return err
+	}
+	if target == origin || strings.HasPrefix(target, origin+"/") {
+		return fmt.Errorf("cannot replace %s with a clone of its own snapshot %s", target, snapshot)
+	}
+
+	props := append([]string{"encryptionroot"}, cloneCarriedProps...)
+	stdout, stderr, err := m.run.Run(ctx, nil,
+		"get", "-Hp", "-o", "name,property,value", strings.Join(props, ","), snapshot, target)
+	if err != nil {
+		return fmt.Errorf("read properties of %s and %s: %w: %s", snapshot, target, err, strings.TrimSpace(stderr))
+	}
+	values := parseProps(stdout)
+
+	// "-" is what ZFS reports for an unencrypted dataset. Compared as-is, so
+	// an unencrypted origin into an encrypted target (or the reverse) is as
+	// much a mismatch as two different tenants.
+	srcRoot, dstRoot := values[snapshot]["encryptionroot"], values[target]["encryptionroot"]
+	if srcRoot == "" || dstRoot == "" {
+		return fmt.Errorf("read encryptionroot of %s and %s: missing from %q", snapshot, target, strings.TrimSpace(stdout))
+	}
+	if srcRoot != dstRoot {
+		return fmt.Errorf("%s (encryptionroot %s) into %s (encryptionroot %s): %w",
+			snapshot, srcRoot, target, dstRoot, ErrCrossTenantClone)
+	}
+
+	tmp := target + "_fork"
+	args := []string{"clone"}
+	for _, p := range cloneCarriedProps {
+		if v := values[target][p]; v != "" && v != "-" && v != "none" && v != "0" {
+			args = append(args, "-o", p+"="+v)
+		}
+	}
+	args = append(args, snapshot, tmp)
+	if _, stderr, err := m.run.Run(ctx, nil, args...); err != nil {
+		return fmt.Errorf("clone %s: %w: %s", snapshot, err, strings.TrimSpace(stderr))
+	}
+
+	if _, stderr, err := m.run.Run(ctx, nil, "destroy", target); err != nil {
+		// Leave target in place and take the clone back out, so the caller
+		// is left with what it had.
+		_, _, _ = m.run.Run(ctx, nil, "destroy", tmp)
+		return fmt.Errorf("destroy %s to replace it: %w: %s", target, err, strings.TrimSpace(stderr))
+	}
+	if _, stderr, err := m.run.Run(ctx, nil, "rename", tmp, target); err != nil {
+		// Target is already gone, so there is nothing to hand back; an
+		// orphaned clone would only pin the source snapshot against
+		// destroy.
+		_, _, _ = m.run.Run(ctx, nil, "destroy", tmp)
+		return fmt.Errorf("rename clone %s to %s: %w: %s", tmp, target, err, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// Clones returns the datasets cloned from a snapshot — what keeps it from
+// being destroyed. Empty when nothing depends on it.
+func (m *Manager) Clones(ctx context.Context, snapshot string) ([]string, error) {
+	dataset, _, ok := strings.Cut(snapshot, "@")
+	if !ok {
+		return nil, fmt.Errorf("invalid snapshot reference %q: expected <dataset>@<name>", snapshot)
+	}
+	if err := validateDataset(dataset); err != nil {
+		return nil, err
+	}
+	stdout, stderr, err := m.run.Run(ctx, nil, "get", "-H", "-o", "value", "clones", snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("read clones of %s: %w: %s", snapshot, err, strings.TrimSpace(stderr))
+	}
+	v := strings.TrimSpace(stdout)
+	if v == "" || v == "-" {
+		return nil, nil
+	}
+	return strings.Split(v, ","), nil
+}
+
+// parseProps reads `zfs get -H -o name,property,value` output into
+// name → property → value.
+func parseProps(stdout string) map[string]map[string]string {
+	out := map[string]map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		if out[fields[0]] == nil {
+			out[fields[0]] = map[string]string{}
+		}
+		out[fields[0]][fields[1]] = fields[2]
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package zfscrypt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Unit coverage for ReplaceWithClone (#1160c). What ZFS itself permits is
+// recorded in clone_constraints_integration_test.go; these pin the
+// orchestration around it.
+
+const (
+	cloneSnap   = "tank/containers/alice-container@base"
+	cloneTarget = "tank/containers/bob-container"
+)
+
+func cloneProps(srcRoot, dstRoot string) string {
+	return strings.Join([]string{
+		cloneSnap + "\tencryptionroot\t" + srcRoot,
+		cloneSnap + "\tmountpoint\t-",
+		cloneSnap + "\tcanmount\t-",
+		cloneSnap + "\tquota\t-",
+		cloneSnap + "\trefquota\t-",
+		cloneTarget + "\tencryptionroot\t" + dstRoot,
+		cloneTarget + "\tmountpoint\t/var/lib/incus/storage-pools/default/containers/bob-container",
+		cloneTarget + "\tcanmount\tnoauto",
+		cloneTarget + "\tquota\t0",
+		cloneTarget + "\trefquota\t10737418240",
+	}, "\n") + "\n"
+}
+
+// The clone is made before the target is destroyed, so a clone ZFS refuses
+// leaves the target intact; and it takes over the properties the storage
+// layer set on the volume it replaces.
+func TestReplaceWithCloneClonesBeforeDestroying(t *testing.T) {
+	f := newFakeRunner()
+	f.stdout["get"] = cloneProps("tank/tenants/acme", "tank/tenants/acme")
+
+	if err := NewManager(f).ReplaceWithClone(context.Background(), cloneSnap, cloneTarget); err != nil {
+		t.Fatalf("ReplaceWithClone: %v", err)
+	}
+
+	var order []string
+	for _, c := range f.calls {
+		order = append(order, c[0])
+	}
+	if got := strings.Join(order, ","); got != "get,clone,destroy,rename" {
+		t.Fatalf("subcommand order = %s", got)
+	}
+	clone := strings.Join(f.calls[1], " ")
+	for _, want := range []string{
+		"-o mountpoint=/var/lib/incus/storage-pools/default/containers/bob-container",
+		"-o canmount=noauto",
+		"-o refquota=10737418240",
+		cloneSnap + " " + cloneTarget + "_fork",
+	} {
+		if !strings.Contains(clone, want) {
+			t.Errorf("clone %q missing %q", clone, want)
+		}
+	}
+	if strings.Contains(clone, "quota=0") {
+		t.Errorf("clone carried an unset quota: %q", clone)
+	}
+	if got := strings.Join(f.lastCall(), " "); got != "rename "+cloneTarget+"_fork "+cloneTarget {
+		t.Errorf("last call = %q", got)
+	}
+}
+
+// ZFS places a clone wherever it is asked to; the daemon must refuse one
+// that would land under another tenant's encryptionroot.
+func TestReplaceWithCloneRefusesAcrossEncryptionRoots(t *testing.T) {
+	for name, roots := range map[string][2]string{
+		"two tenants":          {"tank/tenants/acme", "tank/tenants/globex"},
+		"encrypted into plain": {"tank/tenants/acme", "-"},
+		"plain into encrypted": {"-", "tank/tenants/acme"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newFakeRunner()
+			f.stdout["get"] = cloneProps(roots[0], roots[1])
+			err := NewManager(f).ReplaceWithClone(context.Background(), cloneSnap, cloneTarget)
+			if !errors.Is(err, ErrCrossTenantClone) {
+				t.Fatalf("err = %v, want ErrCrossTenantClone", err)
+			}
+			if f.ran("clone") || f.ran("destroy") {
+				t.Errorf("ran zfs after refusing:\n%s", f.allArgs())
+			}
+		})
+	}
+}
+
+func TestReplaceWithCloneLeavesTargetWhenCloneFails(t *testing.T) {
+	f := newFakeRunner()
+	f.stdout["get"] = cloneProps("-", "-")
+	f.errs["clone"] = errors.New("exit status 1")
+	f.stderr["clone"] = "cannot clone: out of space"
+
+	if err := NewManager(f).ReplaceWithClone(context.Background(), cloneSnap, cloneTarget); err == nil {
+		t.Fatal("expected the clone failure")
+	}
+	if f.ran("destroy") {
+		t.Error("destroyed the target although the clone failed")
+	}
+}
+
+// A rename that fails after target was destroyed takes the clone out too, or
+// it would be left pinning the source snapshot.
+func TestReplaceWithCloneDestroysTheCloneWhenRenameFails(t *testing.T) {
+	f := newFakeRunner()
+	f.stdout["get"] = cloneProps("-", "-")
+	f.errs["rename"] = errors.New("exit status 1")
+
+	if err := NewManager(f).ReplaceWithClone(context.Background(), cloneSnap, cloneTarget); err == nil {
+		t.Fatal("expected the rename failure")
+	}
+	if got := strings.Join(f.lastCall(), " "); got != "destroy "+cloneTarget+"_fork" {
+		t.Errorf("last call = %q, want the clone destroyed", got)
+	}
+}
+
+func TestReplaceWithCloneRefusesItsOwnOrigin(t *testing.T) {
+	f := newFakeRunner()
+	err := NewManager(f).ReplaceWithClone(context.Background(), cloneSnap, "tank/containers/alice-container")
+	if err == nil {
+		t.Fatal("replaced a dataset with a clone of its own snapshot")
+	}
+	if len(f.calls) != 0 {
+		t.Errorf("ran zfs: %s", f.allArgs())
+	}
+}
+
+func TestClonesParsesTheList(t *testing.T) {
+	f := newFakeRunner()
+	f.stdout["get"] = "tank/containers/bob-container,tank/containers/carol-container\n"
+	got, err := NewManager(f).Clones(context.Background(), cloneSnap)
+	if err != nil || len(got) != 2 || got[1] != "tank/containers/carol-container" {
+		t.Fatalf("Clones = %v, %v", got, err)
+	}
+
+	f.stdout["get"] = "\n"
+	if got, err := NewManager(f).Clones(context.Background(), cloneSnap); err != nil || got != nil {
+		t.Errorf("Clones(none) = %v, %v", got, err)
+	}
+}
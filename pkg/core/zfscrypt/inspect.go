@@ -0,0 +1,201 @@
+package zfscrypt
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Reading a snapshot's contents without rolling back to it.
+//
+// Nothing here mounts anything. A mounted dataset's snapshots are reachable,
+// read-only, under <mountpoint>/.zfs/snapshot/<name> — ZFS mounts each one on
+// first access — and `zfs diff` reports paths under the same mountpoint and
+// refuses an unmounted dataset. So inspection works on a dataset the storage
+// layer (Incus) has mounted, which for a container means a running one, and
+// is refused with ErrNotMounted otherwise rather than mounting a volume
+// behind Incus's back.
+//
+// Not yet verified against a real pool: the `zfs diff -H -F` line format and
+// its octal escaping, which parseDiff takes from the OpenZFS man page and
+// libzfs_diff.c.
+
+// ErrNotMounted reports that a dataset is not mounted, so its snapshots'
+// contents cannot be reached through it.
+var ErrNotMounted = fmt.Errorf("dataset is not mounted")
+
+// Mountpoint returns where a mounted dataset is mounted, or ErrNotMounted.
+func (m *Manager) Mountpoint(ctx context.Context, dataset string) (string, error) {
+	if err := validateDataset(dataset); err != nil {
+		return "", err
+	}
+	stdout, stderr, err := m.run.Run(ctx, nil,
+		"get", "-Hp", "-o", "name,property,value", "mounted,mountpoint", dataset)
+	if err != nil {
+		return "", fmt.Errorf("read mountpoint of %s: %w: %s", dataset, err, strings.TrimSpace(stderr))
+	}
+	props := parseProps(stdout)[dataset]
+	if props["mounted"] != "yes" {
+		return "", fmt.Errorf("%s: %w", dataset, ErrNotMounted)
+	}
+	mp := props["mountpoint"]
+	if !path.IsAbs(mp) {
+		return "", fmt.Errorf("dataset %s is mounted but reports mountpoint %q", dataset, mp)
+	}
+	return mp, nil
+}
+
+// SnapshotDir returns the read-only directory a snapshot's contents appear
+// under. It checks first that they can be read: ErrKeyUnavailableForInspection
+// when the key is not loaded, ErrNotMounted when the dataset is not mounted.
+func (m *Manager) SnapshotDir(ctx context.Context, snapshot string) (string, error) {
+	dataset, name, ok := strings.Cut(snapshot, "@")
+	if !ok {
+		return "", fmt.Errorf("invalid snapshot reference %q: expected <dataset>@<name>", snapshot)
+	}
+	if err := validateSnapshotName(name); err != nil {
+		return "", err
+	}
+	if err := m.EnsureInspectable(ctx, snapshot); err != nil {
+		return "", err
+	}
+	mp, err := m.Mountpoint(ctx, dataset)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(mp, ".zfs", "snapshot", name), nil
+}
+
+// ChangeKind is the first column of `zfs diff`.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "+"
+	ChangeRemoved  ChangeKind = "-"
+	ChangeModified ChangeKind = "M"
+	ChangeRenamed  ChangeKind = "R"
+)
+
+// Change is one path `zfs diff` reports.
+type Change struct {
+	Kind ChangeKind
+
+	// Type is `zfs diff -F`'s file type: "F" a regular file, "/" a
+	// directory, "@" a symlink; "B", "C", "|", "=", ">" and "P" the rest.
+	Type string
+
+	// Path is relative to the dataset's mountpoint and starts with "/". For
+	// a rename it is the old path.
+	Path string
+
+	// NewPath is a rename's new path, relative like Path.
+	NewPath string
+}
+
+// Diff reports what changed between snapshot from and a later snapshot to of
+// the same dataset, or the dataset as it is now when to is "".
+//
+// Both sides are the one dataset's, under one key, so a single check covers
+// them; like SnapshotDir it returns ErrKeyUnavailableForInspection or
+// ErrNotMounted.
+func (m *Manager) Diff(ctx context.Context, from, to string) ([]Change, error) {
+	dataset, name, ok := strings.Cut(from, "@")
+	if !ok {
+		return nil, fmt.Errorf("invalid snapshot reference %q: expected <dataset>@<name>", from)
+	}
+	if err := validateSnapshotName(name); err != nil {
+		return nil, err
+	}
+	later := dataset
+	if to != "" {
+		toDataset, toName, ok := strings.Cut(to, "@")
+		if !ok || toDataset != dataset {
+			return nil, fmt.Errorf("cannot diff %s against %s: expected a snapshot of %s", from, to, dataset)
+		}
+		if err := validateSnapshotName(toName); err != nil {
+			return nil, err
+		}
+		later = to
+	}
+
+	if err := m.EnsureInspectable(ctx, from); err != nil {
+		return nil, err
+	}
+	mp, err := m.Mountpoint(ctx, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr, err := m.run.Run(ctx, nil, "diff", "-H", "-F", from, later)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s %s: %w: %s", from, later, err, strings.TrimSpace(stderr))
+	}
+	return parseDiff(stdout, mp)
+}
+
+// parseDiff reads `zfs diff -H -F` output, making paths relative to mp.
+func parseDiff(stdout, mp string) ([]Change, error) {
+	var out []Change
+	for _, line := range strings.Split(stdout, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("unexpected zfs diff line %q", line)
+		}
+		c := Change{Kind: ChangeKind(fields[0]), Type: fields[1]}
+		var err error
+		if c.Path, err = diffPath(fields[2], mp); err != nil {
+			return nil, err
+		}
+		switch c.Kind {
+		case ChangeAdded, ChangeRemoved, ChangeModified:
+		case ChangeRenamed:
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("unexpected zfs diff rename line %q", line)
+			}
+			if c.NewPath, err = diffPath(fields[3], mp); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected zfs diff change %q in %q", fields[0], line)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// diffPath unescapes one `zfs diff` path and makes it relative to mp.
+//
+// zfs diff writes a space, a backslash and any byte outside printable ASCII
+// as a backslash and four octal digits ("\0040" for a space), so a path
+// never contains the tab that separates the columns.
+func diffPath(escaped, mp string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(escaped); i++ {
+		if escaped[i] != '\\' {
+			b.WriteByte(escaped[i])
+			continue
+		}
+		if i+5 > len(escaped) {
+			return "", fmt.Errorf("truncated escape in zfs diff path %q", escaped)
+		}
+		v, err := strconv.ParseUint(escaped[i+1:i+5], 8, 8)
+		if err != nil {
+			return "", fmt.Errorf("bad escape in zfs diff path %q: %w", escaped, err)
+		}
+		b.WriteByte(byte(v))
+		i += 4
+	}
+	p := b.String()
+	switch {
+	case p == mp:
+		return "/", nil
+	case strings.HasPrefix(p, mp+"/"):
+		return p[len(mp):], nil
+	}
+	return "", fmt.Errorf("zfs diff path %q is not under mountpoint %s", p, mp)
+}
@@ -0,0 +1,145 @@
+package zfscrypt
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Unit coverage for snapshot inspection. The diff format is named in
+// inspect.go as not yet checked against a real pool; these pin the parsing
+// and the refusals around it.
+
+const inspectMount = "/var/lib/incus/storage-pools/default/containers/alice-container"
+
+// inspectFake answers keystatus reads separately from the mounted/mountpoint
+// read that shares the "get" subcommand.
+type inspectFake struct {
+	*fakeRunner
+	keystatus string
+	mounted   string
+}
+
+func newInspectFake() *inspectFake {
+	return &inspectFake{fakeRunner: newFakeRunner(), keystatus: "available", mounted: "yes"}
+}
+
+func (f *inspectFake) Run(ctx context.Context, stdin []byte, args ...string) (string, string, error) {
+	stdout, stderr, err := f.fakeRunner.Run(ctx, stdin, args...)
+	if len(args) > 4 && args[0] == "get" {
+		switch args[4] {
+		case "keystatus":
+			return f.keystatus, "", nil
+		case "mounted,mountpoint":
+			ds := args[5]
+			return ds + "\tmounted\t" + f.mounted + "\n" + ds + "\tmountpoint\t" + inspectMount + "\n", "", nil
+		}
+	}
+	return stdout, stderr, err
+}
+
+func TestSnapshotDirIsUnderTheMountpoint(t *testing.T) {
+	f := newInspectFake()
+	dir, err := NewManager(f).SnapshotDir(context.Background(), "tank/c/alice@nightly")
+	if err != nil {
+		t.Fatalf("SnapshotDir: %v", err)
+	}
+	if want := inspectMount + "/.zfs/snapshot/nightly"; dir != want {
+		t.Errorf("dir = %q, want %q", dir, want)
+	}
+}
+
+// The key is checked before anything else, so the caller gets the error that
+// names key custody rather than whatever reading ciphertext looks like.
+func TestSnapshotDirRefusesAnUnavailableKey(t *testing.T) {
+	f := newInspectFake()
+	f.keystatus = "unavailable"
+	_, err := NewManager(f).SnapshotDir(context.Background(), "tank/c/alice@nightly")
+	if !errors.Is(err, ErrKeyUnavailableForInspection) {
+		t.Fatalf("err = %v, want ErrKeyUnavailableForInspection", err)
+	}
+	if f.ran("mounted,mountpoint") {
+		t.Error("read the mountpoint after the key check failed")
+	}
+}
+
+func TestSnapshotDirRefusesAnUnmountedDataset(t *testing.T) {
+	f := newInspectFake()
+	f.mounted = "no"
+	_, err := NewManager(f).SnapshotDir(context.Background(), "tank/c/alice@nightly")
+	if !errors.Is(err, ErrNotMounted) {
+		t.Fatalf("err = %v, want ErrNotMounted", err)
+	}
+}
+
+func TestSnapshotDirRejectsATraversingName(t *testing.T) {
+	f := newInspectFake()
+	if _, err := NewManager(f).SnapshotDir(context.Background(), "tank/c/alice@../../etc"); err == nil {
+		t.Fatal("accepted a snapshot name containing '/'")
+	}
+	if len(f.calls) != 0 {
+		t.Errorf("a rejected name reached zfs: %v", f.calls)
+	}
+}
+
+func TestDiffParsesChangesRelativeToTheMountpoint(t *testing.T) {
+	f := newInspectFake()
+	f.stdout["diff"] = strings.Join([]string{
+		"M\t/\t" + inspectMount + "/rootfs/home/alice",
+		"+\tF\t" + inspectMount + "/rootfs/home/alice/new\\0040file.txt",
+		"-\tF\t" + inspectMount + "/rootfs/home/alice/gone",
+		"R\tF\t" + inspectMount + "/rootfs/home/alice/a\t" + inspectMount + "/rootfs/home/alice/b",
+		"M\t@\t" + inspectMount,
+	}, "\n") + "\n"
+
+	got, err := NewManager(f).Diff(context.Background(), "tank/c/alice@a", "tank/c/alice@b")
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if call := strings.Join(f.lastCall(), " "); call != "diff -H -F tank/c/alice@a tank/c/alice@b" {
+		t.Errorf("diff args = %q", call)
+	}
+	want := []Change{
+		{Kind: ChangeModified, Type: "/", Path: "/rootfs/home/alice"},
+		{Kind: ChangeAdded, Type: "F", Path: "/rootfs/home/alice/new file.txt"},
+		{Kind: ChangeRemoved, Type: "F", Path: "/rootfs/home/alice/gone"},
+		{Kind: ChangeRenamed, Type: "F", Path: "/rootfs/home/alice/a", NewPath: "/rootfs/home/alice/b"},
+		{Kind: ChangeModified, Type: "@", Path: "/"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDiffAgainstTheLiveDatasetNamesIt(t *testing.T) {
+	f := newInspectFake()
+	if _, err := NewManager(f).Diff(context.Background(), "tank/c/alice@a", ""); err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if call := strings.Join(f.lastCall(), " "); call != "diff -H -F tank/c/alice@a tank/c/alice" {
+		t.Errorf("diff args = %q", call)
+	}
+}
+
+func TestDiffRefusesAnotherDatasetsSnapshot(t *testing.T) {
+	f := newInspectFake()
+	if _, err := NewManager(f).Diff(context.Background(), "tank/c/alice@a", "tank/c/bob@b"); err == nil {
+		t.Fatal("diffed two datasets' snapshots")
+	}
+	if len(f.calls) != 0 {
+		t.Errorf("a refused diff reached zfs: %v", f.calls)
+	}
+}
+
+// A path outside the mountpoint means the output is not what parseDiff
+// understands; guessing at it would put a wrong path in front of a user
+// recovering files.
+func TestDiffRejectsAPathOutsideTheMountpoint(t *testing.T) {
+	f := newInspectFake()
+	f.stdout["diff"] = "+\tF\t/etc/passwd\n"
+	if _, err := NewManager(f).Diff(context.Background(), "tank/c/alice@a", ""); err == nil {
+		t.Fatal("accepted a path outside the mountpoint")
+	}
+}
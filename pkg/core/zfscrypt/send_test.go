@@ -0,0 +1,198 @@
+package zfscrypt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/footprintai/containarium/pkg/core/zfskey"
+)
+
+// Unit coverage for the replication streams behind snapshot export. As with
+// the rest of the package, these pin what runs and in what order; what ZFS
+// does with a raw stream is named in send.go for the lane to check.
+
+// streamFake is a fakeRunner that can also stream: RunStream records its
+// args like Run, writes sent to stdout and keeps what it read from stdin.
+type streamFake struct {
+	*fakeRunner
+	sent      string
+	received  []byte
+	keystatus string
+}
+
+func newStreamFake() *streamFake {
+	return &streamFake{fakeRunner: newFakeRunner(), keystatus: "unavailable"}
+}
+
+// Run answers keystatus reads separately from the property read that shares
+// the "get" subcommand, so LoadKey sees a key to load.
+func (s *streamFake) Run(ctx context.Context, stdin []byte, args ...string) (string, string, error) {
+	stdout, stderr, err := s.fakeRunner.Run(ctx, stdin, args...)
+	if len(args) > 4 && args[0] == "get" && args[4] == "keystatus" {
+		return s.keystatus, "", nil
+	}
+	return stdout, stderr, err
+}
+
+func (s *streamFake) RunStream(_ context.Context, stdin io.Reader, stdout io.Writer, args ...string) (string, error) {
+	s.calls = append(s.calls, args)
+	if stdin != nil {
+		s.received, _ = io.ReadAll(stdin)
+	}
+	if _, err := io.WriteString(stdout, s.sent); err != nil {
+		return "", err
+	}
+	return s.stderr[args[0]], s.errs[args[0]]
+}
+
+func TestSendIsRawAndIncrementalFromTheBase(t *testing.T) {
+	f := newStreamFake()
+	f.sent = "stream-bytes"
+	m := NewManager(f)
+
+	var out bytes.Buffer
+	if err := m.Send(context.Background(), "tank/c/alice@b", "tank/c/alice@a", &out); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got := strings.Join(f.lastCall(), " "); got != "send -w -i tank/c/alice@a tank/c/alice@b" {
+		t.Errorf("send args = %q — a stream that is not raw carries an encrypted box's plaintext", got)
+	}
+	if out.String() != "stream-bytes" {
+		t.Errorf("stream = %q", out.String())
+	}
+
+	if err := m.Send(context.Background(), "tank/c/alice@b", "", io.Discard); err != nil {
+		t.Fatalf("full Send: %v", err)
+	}
+	if got := strings.Join(f.lastCall(), " "); got != "send -w tank/c/alice@b" {
+		t.Errorf("full send args = %q", got)
+	}
+}
+
+func TestSendRefusesABaseOfAnotherDataset(t *testing.T) {
+	f := newStreamFake()
+	err := NewManager(f).Send(context.Background(), "tank/c/alice@b", "tank/c/bob@a", io.Discard)
+	if err == nil || len(f.calls) != 0 {
+		t.Fatalf("err = %v, calls = %v", err, f.calls)
+	}
+}
+
+// A runner that can only buffer must not be handed a disk-sized stream.
+func TestSendAndReceiveNeedAStreamingRunner(t *testing.T) {
+	m := NewManager(newFakeRunner())
+	if err := m.Send(context.Background(), "tank/c/alice@b", "", io.Discard); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("Send: %v", err)
+	}
+	if err := m.Receive(context.Background(), "tank/c/alice", strings.NewReader("x")); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("Receive: %v", err)
+	}
+}
+
+func TestReceiveLeavesItUnmountedAndNeverForces(t *testing.T) {
+	f := newStreamFake()
+	if err := NewManager(f).Receive(context.Background(), "tank/c/alice_import", strings.NewReader("stream")); err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if got := strings.Join(f.lastCall(), " "); got != "receive -u tank/c/alice_import" {
+		t.Errorf("receive args = %q — -F would roll back a diverged dataset to fit the stream", got)
+	}
+	if string(f.received) != "stream" {
+		t.Errorf("stdin = %q", f.received)
+	}
+}
+
+const (
+	recvDataset = "tank/tenants/acme/containers/alice-container_import"
+	recvTarget  = "tank/tenants/acme/containers/alice-container"
+)
+
+func recvProps(srcRoot, dstRoot string) string {
+	return strings.Join([]string{
+		recvDataset + "\tencryptionroot\t" + srcRoot,
+		recvDataset + "\tmountpoint\t-",
+		recvTarget + "\tencryptionroot\t" + dstRoot,
+		recvTarget + "\tmountpoint\t/var/lib/incus/storage-pools/t/containers/alice-container",
+		recvTarget + "\tcanmount\tnoauto",
+		recvTarget + "\trefquota\t10737418240",
+	}, "\n") + "\n"
+}
+
+// A raw-received encrypted dataset is its own encryptionroot. It has to be
+// re-parented under the tenant's root, or the pre-start hook — which only
+// loads the tenant root's key — could never unlock the restored box.
+func TestReplaceWithReceivedAdoptsIntoTheTenantRoot(t *testing.T) {
+	f := newStreamFake()
+	f.stdout["get"] = recvProps(recvDataset, "tank/tenants/acme")
+
+	if err := NewManager(f).ReplaceWithReceived(context.Background(), recvDataset, recvTarget, testKey(t, 7)); err != nil {
+		t.Fatalf("ReplaceWithReceived: %v", err)
+	}
+
+	var order []string
+	for _, c := range f.calls {
+		order = append(order, c[0])
+	}
+	want := "get,get,load-key,get,load-key,change-key,set,set,set,destroy,rename"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("order = %s\nwant    %s", got, want)
+	}
+	if !f.ran("change-key -i " + recvDataset) {
+		t.Errorf("not re-parented:\n%s", f.allArgs())
+	}
+	if !f.ran("set refquota=10737418240 " + recvDataset) {
+		t.Errorf("the volume's quota was not carried over:\n%s", f.allArgs())
+	}
+	if !f.ran("rename " + recvDataset + " " + recvTarget) {
+		t.Errorf("not swapped in:\n%s", f.allArgs())
+	}
+}
+
+func TestReplaceWithReceivedRefusesAnEncryptionMismatch(t *testing.T) {
+	for name, roots := range map[string][2]string{
+		"encrypted into plaintext": {recvDataset, "-"},
+		"plaintext into encrypted": {"-", "tank/tenants/acme"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newStreamFake()
+			f.stdout["get"] = recvProps(roots[0], roots[1])
+
+			err := NewManager(f).ReplaceWithReceived(context.Background(), recvDataset, recvTarget, testKey(t, 7))
+			if !errors.Is(err, ErrEncryptionMismatch) {
+				t.Fatalf("err = %v, want ErrEncryptionMismatch", err)
+			}
+			if f.ran("destroy") {
+				t.Fatalf("target destroyed anyway:\n%s", f.allArgs())
+			}
+		})
+	}
+}
+
+func TestReplaceWithReceivedUnencryptedNeedsNoKey(t *testing.T) {
+	f := newStreamFake()
+	f.stdout["get"] = recvProps("-", "-")
+
+	if err := NewManager(f).ReplaceWithReceived(context.Background(), recvDataset, recvTarget, zfskey.Key{}); err != nil {
+		t.Fatalf("ReplaceWithReceived: %v", err)
+	}
+	if f.ran("load-key") || f.ran("change-key") {
+		t.Errorf("touched keys for a plaintext dataset:\n%s", f.allArgs())
+	}
+}
+
+func TestDiscardReceivedOnlyUnderItsParent(t *testing.T) {
+	f := newStreamFake()
+	m := NewManager(f)
+	if err := m.DiscardReceived(context.Background(), "tank/c", "tank/other/x_import"); err == nil {
+		t.Fatal("discarded a dataset outside its parent")
+	}
+	if err := m.DiscardReceived(context.Background(), "tank/c", "tank/c/x_import"); err != nil {
+		t.Fatalf("DiscardReceived: %v", err)
+	}
+	if got := strings.Join(f.lastCall(), " "); got != "destroy -r tank/c/x_import" {
+		t.Errorf("args = %q", got)
+	}
+}
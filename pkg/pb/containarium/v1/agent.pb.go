@@ -11,6 +11,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -79,6 +80,63 @@ func (AgentTaskState) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{0}
 }
 
+// ModelUsageGroupBy picks the rollup key of GetAgentModelUsage. Rows are
+// always further split by provider and model, since cost depends on both.
+type ModelUsageGroupBy int32
+
+const (
+	// Same as TENANT.
+	ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_UNSPECIFIED ModelUsageGroupBy = 0
+	ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_TENANT      ModelUsageGroupBy = 1
+	// One row per tenant + skill.
+	ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_SKILL ModelUsageGroupBy = 2
+	// One row per run id — a crew run spans every member skill's box.
+	ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_RUN ModelUsageGroupBy = 3
+)
+
+// Enum value maps for ModelUsageGroupBy.
+var (
+	ModelUsageGroupBy_name = map[int32]string{
+		0: "MODEL_USAGE_GROUP_BY_UNSPECIFIED",
+		1: "MODEL_USAGE_GROUP_BY_TENANT",
+		2: "MODEL_USAGE_GROUP_BY_SKILL",
+		3: "MODEL_USAGE_GROUP_BY_RUN",
+	}
+	ModelUsageGroupBy_value = map[string]int32{
+		"MODEL_USAGE_GROUP_BY_UNSPECIFIED": 0,
+		"MODEL_USAGE_GROUP_BY_TENANT":      1,
+		"MODEL_USAGE_GROUP_BY_SKILL":       2,
+		"MODEL_USAGE_GROUP_BY_RUN":         3,
+	}
+)
+
+func (x ModelUsageGroupBy) Enum() *ModelUsageGroupBy {
+	p := new(ModelUsageGroupBy)
+	*p = x
+	return p
+}
+
+func (x ModelUsageGroupBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelUsageGroupBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_agent_proto_enumTypes[1].Descriptor()
+}
+
+func (ModelUsageGroupBy) Type() protoreflect.EnumType {
+	return &file_containarium_v1_agent_proto_enumTypes[1]
+}
+
+func (x ModelUsageGroupBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelUsageGroupBy.Descriptor instead.
+func (ModelUsageGroupBy) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{1}
+}
+
 // CrewTopology describes how a crew's skills are wired together.
 type CrewTopology int32
 
@@ -120,11 +178,11 @@ func (x CrewTopology) String() string {
 }
 
 func (CrewTopology) Descriptor() protoreflect.EnumDescriptor {
-	return file_containarium_v1_agent_proto_enumTypes[1].Descriptor()
+	return file_containarium_v1_agent_proto_enumTypes[2].Descriptor()
 }
 
 func (CrewTopology) Type() protoreflect.EnumType {
-	return &file_containarium_v1_agent_proto_enumTypes[1]
+	return &file_containarium_v1_agent_proto_enumTypes[2]
 }
 
 func (x CrewTopology) Number() protoreflect.EnumNumber {
@@ -133,7 +191,7 @@ func (x CrewTopology) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrewTopology.Descriptor instead.
 func (CrewTopology) EnumDescriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{1}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{2}
 }
 
 // CrewRunState tracks the lifecycle of a single crew execution.
@@ -179,11 +237,11 @@ func (x CrewRunState) String() string {
 }
 
 func (CrewRunState) Descriptor() protoreflect.EnumDescriptor {
-	return file_containarium_v1_agent_proto_enumTypes[2].Descriptor()
+	return file_containarium_v1_agent_proto_enumTypes[3].Descriptor()
 }
 
 func (CrewRunState) Type() protoreflect.EnumType {
-	return &file_containarium_v1_agent_proto_enumTypes[2]
+	return &file_containarium_v1_agent_proto_enumTypes[3]
 }
 
 func (x CrewRunState) Number() protoreflect.EnumNumber {
@@ -192,7 +250,7 @@ func (x CrewRunState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrewRunState.Descriptor instead.
 func (CrewRunState) EnumDescriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{2}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{3}
 }
 
 // AgentCard is the discovery document a skill serves so peer agents can find
@@ -710,7 +768,11 @@ type RunAgentSkillResponse struct {
 	// The box the agent ran in.
 	Container *Container `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
 	// JSON artifact matching the skill's output schema.
-	ArtifactJson  string `protobuf:"bytes,2,opt,name=artifact_json,json=artifactJson,proto3" json:"artifact_json,omitempty"`
+	ArtifactJson string `protobuf:"bytes,2,opt,name=artifact_json,json=artifactJson,proto3" json:"artifact_json,omitempty"`
+	// Id this run's model calls are attributed to in the model-gateway usage
+	// ledger (GetAgentModelUsage run_id). Empty when the daemon serves no
+	// model gateway.
+	RunId         string `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -759,6 +821,13 @@ func (x *RunAgentSkillResponse) GetArtifactJson() string {
 	return ""
 }
 
+func (x *RunAgentSkillResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
 // AgentTask is one unit of work delegated to a peer agent.
 type AgentTask struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1020,6 +1089,280 @@ func (x *SendAgentTaskResponse) GetTraceId() string {
 	return ""
 }
 
+type GetAgentModelUsageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filters; empty matches everything.
+	Tenant  string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	SkillId string `protobuf:"bytes,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	RunId   string `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Time range of the calls; unset start is unbounded, unset end is now.
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	GroupBy       ModelUsageGroupBy      `protobuf:"varint,6,opt,name=group_by,json=groupBy,proto3,enum=containarium.v1.ModelUsageGroupBy" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentModelUsageRequest) Reset() {
+	*x = GetAgentModelUsageRequest{}
+	mi := &file_containarium_v1_agent_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentModelUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentModelUsageRequest) ProtoMessage() {}
+
+func (x *GetAgentModelUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_agent_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentModelUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentModelUsageRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetAgentModelUsageRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetAgentModelUsageRequest) GetSkillId() string {
+	if x != nil {
+		return x.SkillId
+	}
+	return ""
+}
+
+func (x *GetAgentModelUsageRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *GetAgentModelUsageRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetAgentModelUsageRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetAgentModelUsageRequest) GetGroupBy() ModelUsageGroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ModelUsageGroupBy_MODEL_USAGE_GROUP_BY_UNSPECIFIED
+}
+
+// ModelUsageRollup aggregates the calls sharing one rollup key. Fields not
+// part of the key are empty.
+type ModelUsageRollup struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Tenant   string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	SkillId  string                 `protobuf:"bytes,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	RunId    string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Provider string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	Model    string                 `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`
+	Calls    int64                  `protobuf:"varint,6,opt,name=calls,proto3" json:"calls,omitempty"`
+	// Calls the gateway answered with an HTTP status >= 400.
+	FailedCalls  int64 `protobuf:"varint,7,opt,name=failed_calls,json=failedCalls,proto3" json:"failed_calls,omitempty"`
+	InputTokens  int64 `protobuf:"varint,8,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens int64 `protobuf:"varint,9,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CachedTokens int64 `protobuf:"varint,10,opt,name=cached_tokens,json=cachedTokens,proto3" json:"cached_tokens,omitempty"`
+	// Sum of call latencies, for averaging and for wall-clock attribution.
+	TotalLatencyMs int64                  `protobuf:"varint,11,opt,name=total_latency_ms,json=totalLatencyMs,proto3" json:"total_latency_ms,omitempty"`
+	FirstCallAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=first_call_at,json=firstCallAt,proto3" json:"first_call_at,omitempty"`
+	LastCallAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_call_at,json=lastCallAt,proto3" json:"last_call_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ModelUsageRollup) Reset() {
+	*x = ModelUsageRollup{}
+	mi := &file_containarium_v1_agent_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelUsageRollup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelUsageRollup) ProtoMessage() {}
+
+func (x *ModelUsageRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_agent_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelUsageRollup.ProtoReflect.Descriptor instead.
+func (*ModelUsageRollup) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ModelUsageRollup) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *ModelUsageRollup) GetSkillId() string {
+	if x != nil {
+		return x.SkillId
+	}
+	return ""
+}
+
+func (x *ModelUsageRollup) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ModelUsageRollup) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ModelUsageRollup) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ModelUsageRollup) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *ModelUsageRollup) GetFailedCalls() int64 {
+	if x != nil {
+		return x.FailedCalls
+	}
+	return 0
+}
+
+func (x *ModelUsageRollup) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *ModelUsageRollup) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *ModelUsageRollup) GetCachedTokens() int64 {
+	if x != nil {
+		return x.CachedTokens
+	}
+	return 0
+}
+
+func (x *ModelUsageRollup) GetTotalLatencyMs() int64 {
+	if x != nil {
+		return x.TotalLatencyMs
+	}
+	return 0
+}
+
+func (x *ModelUsageRollup) GetFirstCallAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstCallAt
+	}
+	return nil
+}
+
+func (x *ModelUsageRollup) GetLastCallAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCallAt
+	}
+	return nil
+}
+
+type GetAgentModelUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rollups       []*ModelUsageRollup    `protobuf:"bytes,1,rep,name=rollups,proto3" json:"rollups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentModelUsageResponse) Reset() {
+	*x = GetAgentModelUsageResponse{}
+	mi := &file_containarium_v1_agent_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentModelUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentModelUsageResponse) ProtoMessage() {}
+
+func (x *GetAgentModelUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_agent_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentModelUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentModelUsageResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetAgentModelUsageResponse) GetRollups() []*ModelUsageRollup {
+	if x != nil {
+		return x.Rollups
+	}
+	return nil
+}
+
 // EnqueueAgentTaskRequest places one task on the pull queue.
 type EnqueueAgentTaskRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1033,7 +1376,7 @@ type EnqueueAgentTaskRequest struct {
 
 func (x *EnqueueAgentTaskRequest) Reset() {
 	*x = EnqueueAgentTaskRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[12]
+	mi := &file_containarium_v1_agent_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1045,7 +1388,7 @@ func (x *EnqueueAgentTaskRequest) String() string {
 func (*EnqueueAgentTaskRequest) ProtoMessage() {}
 
 func (x *EnqueueAgentTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[12]
+	mi := &file_containarium_v1_agent_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1058,7 +1401,7 @@ func (x *EnqueueAgentTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnqueueAgentTaskRequest.ProtoReflect.Descriptor instead.
 func (*EnqueueAgentTaskRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{12}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *EnqueueAgentTaskRequest) GetSkillId() string {
@@ -1085,7 +1428,7 @@ type EnqueueAgentTaskResponse struct {
 
 func (x *EnqueueAgentTaskResponse) Reset() {
 	*x = EnqueueAgentTaskResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[13]
+	mi := &file_containarium_v1_agent_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1097,7 +1440,7 @@ func (x *EnqueueAgentTaskResponse) String() string {
 func (*EnqueueAgentTaskResponse) ProtoMessage() {}
 
 func (x *EnqueueAgentTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[13]
+	mi := &file_containarium_v1_agent_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +1453,7 @@ func (x *EnqueueAgentTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnqueueAgentTaskResponse.ProtoReflect.Descriptor instead.
 func (*EnqueueAgentTaskResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{13}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *EnqueueAgentTaskResponse) GetTaskId() string {
@@ -1136,7 +1479,7 @@ type LeaseAgentTaskRequest struct {
 
 func (x *LeaseAgentTaskRequest) Reset() {
 	*x = LeaseAgentTaskRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[14]
+	mi := &file_containarium_v1_agent_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1148,7 +1491,7 @@ func (x *LeaseAgentTaskRequest) String() string {
 func (*LeaseAgentTaskRequest) ProtoMessage() {}
 
 func (x *LeaseAgentTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[14]
+	mi := &file_containarium_v1_agent_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1161,7 +1504,7 @@ func (x *LeaseAgentTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseAgentTaskRequest.ProtoReflect.Descriptor instead.
 func (*LeaseAgentTaskRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{14}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *LeaseAgentTaskRequest) GetWorkerId() string {
@@ -1202,7 +1545,7 @@ type LeaseAgentTaskResponse struct {
 
 func (x *LeaseAgentTaskResponse) Reset() {
 	*x = LeaseAgentTaskResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[15]
+	mi := &file_containarium_v1_agent_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1214,7 +1557,7 @@ func (x *LeaseAgentTaskResponse) String() string {
 func (*LeaseAgentTaskResponse) ProtoMessage() {}
 
 func (x *LeaseAgentTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[15]
+	mi := &file_containarium_v1_agent_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1570,7 @@ func (x *LeaseAgentTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseAgentTaskResponse.ProtoReflect.Descriptor instead.
 func (*LeaseAgentTaskResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{15}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *LeaseAgentTaskResponse) GetHasTask() bool {
@@ -1281,7 +1624,7 @@ type CompleteAgentTaskRequest struct {
 
 func (x *CompleteAgentTaskRequest) Reset() {
 	*x = CompleteAgentTaskRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[16]
+	mi := &file_containarium_v1_agent_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1293,7 +1636,7 @@ func (x *CompleteAgentTaskRequest) String() string {
 func (*CompleteAgentTaskRequest) ProtoMessage() {}
 
 func (x *CompleteAgentTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[16]
+	mi := &file_containarium_v1_agent_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1306,7 +1649,7 @@ func (x *CompleteAgentTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteAgentTaskRequest.ProtoReflect.Descriptor instead.
 func (*CompleteAgentTaskRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{16}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CompleteAgentTaskRequest) GetTaskId() string {
@@ -1348,7 +1691,7 @@ type CompleteAgentTaskResponse struct {
 
 func (x *CompleteAgentTaskResponse) Reset() {
 	*x = CompleteAgentTaskResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[17]
+	mi := &file_containarium_v1_agent_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1360,7 +1703,7 @@ func (x *CompleteAgentTaskResponse) String() string {
 func (*CompleteAgentTaskResponse) ProtoMessage() {}
 
 func (x *CompleteAgentTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[17]
+	mi := &file_containarium_v1_agent_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1373,7 +1716,7 @@ func (x *CompleteAgentTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteAgentTaskResponse.ProtoReflect.Descriptor instead.
 func (*CompleteAgentTaskResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{17}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CompleteAgentTaskResponse) GetAccepted() bool {
@@ -1401,7 +1744,7 @@ type StartAgentWorkerRequest struct {
 
 func (x *StartAgentWorkerRequest) Reset() {
 	*x = StartAgentWorkerRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[18]
+	mi := &file_containarium_v1_agent_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1413,7 +1756,7 @@ func (x *StartAgentWorkerRequest) String() string {
 func (*StartAgentWorkerRequest) ProtoMessage() {}
 
 func (x *StartAgentWorkerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[18]
+	mi := &file_containarium_v1_agent_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1426,7 +1769,7 @@ func (x *StartAgentWorkerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAgentWorkerRequest.ProtoReflect.Descriptor instead.
 func (*StartAgentWorkerRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{18}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *StartAgentWorkerRequest) GetSkillId() string {
@@ -1469,7 +1812,7 @@ type StartAgentWorkerResponse struct {
 
 func (x *StartAgentWorkerResponse) Reset() {
 	*x = StartAgentWorkerResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[19]
+	mi := &file_containarium_v1_agent_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1481,7 +1824,7 @@ func (x *StartAgentWorkerResponse) String() string {
 func (*StartAgentWorkerResponse) ProtoMessage() {}
 
 func (x *StartAgentWorkerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[19]
+	mi := &file_containarium_v1_agent_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1494,7 +1837,7 @@ func (x *StartAgentWorkerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAgentWorkerResponse.ProtoReflect.Descriptor instead.
 func (*StartAgentWorkerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{19}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *StartAgentWorkerResponse) GetContainer() *Container {
@@ -1531,7 +1874,7 @@ type Crew struct {
 
 func (x *Crew) Reset() {
 	*x = Crew{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[20]
+	mi := &file_containarium_v1_agent_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1543,7 +1886,7 @@ func (x *Crew) String() string {
 func (*Crew) ProtoMessage() {}
 
 func (x *Crew) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[20]
+	mi := &file_containarium_v1_agent_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1556,7 +1899,7 @@ func (x *Crew) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Crew.ProtoReflect.Descriptor instead.
 func (*Crew) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{20}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Crew) GetId() string {
@@ -1627,7 +1970,7 @@ type CrewRun struct {
 
 func (x *CrewRun) Reset() {
 	*x = CrewRun{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[21]
+	mi := &file_containarium_v1_agent_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1639,7 +1982,7 @@ func (x *CrewRun) String() string {
 func (*CrewRun) ProtoMessage() {}
 
 func (x *CrewRun) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[21]
+	mi := &file_containarium_v1_agent_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1652,7 +1995,7 @@ func (x *CrewRun) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CrewRun.ProtoReflect.Descriptor instead.
 func (*CrewRun) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{21}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CrewRun) GetId() string {
@@ -1719,7 +2062,7 @@ type ListCrewsRequest struct {
 
 func (x *ListCrewsRequest) Reset() {
 	*x = ListCrewsRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[22]
+	mi := &file_containarium_v1_agent_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1731,7 +2074,7 @@ func (x *ListCrewsRequest) String() string {
 func (*ListCrewsRequest) ProtoMessage() {}
 
 func (x *ListCrewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[22]
+	mi := &file_containarium_v1_agent_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1744,7 +2087,7 @@ func (x *ListCrewsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCrewsRequest.ProtoReflect.Descriptor instead.
 func (*ListCrewsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{22}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{25}
 }
 
 type ListCrewsResponse struct {
@@ -1756,7 +2099,7 @@ type ListCrewsResponse struct {
 
 func (x *ListCrewsResponse) Reset() {
 	*x = ListCrewsResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[23]
+	mi := &file_containarium_v1_agent_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1768,7 +2111,7 @@ func (x *ListCrewsResponse) String() string {
 func (*ListCrewsResponse) ProtoMessage() {}
 
 func (x *ListCrewsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[23]
+	mi := &file_containarium_v1_agent_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1781,7 +2124,7 @@ func (x *ListCrewsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCrewsResponse.ProtoReflect.Descriptor instead.
 func (*ListCrewsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{23}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ListCrewsResponse) GetCrews() []*Crew {
@@ -1800,7 +2143,7 @@ type GetCrewRequest struct {
 
 func (x *GetCrewRequest) Reset() {
 	*x = GetCrewRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[24]
+	mi := &file_containarium_v1_agent_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1812,7 +2155,7 @@ func (x *GetCrewRequest) String() string {
 func (*GetCrewRequest) ProtoMessage() {}
 
 func (x *GetCrewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[24]
+	mi := &file_containarium_v1_agent_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1825,7 +2168,7 @@ func (x *GetCrewRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCrewRequest.ProtoReflect.Descriptor instead.
 func (*GetCrewRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{24}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetCrewRequest) GetId() string {
@@ -1844,7 +2187,7 @@ type GetCrewResponse struct {
 
 func (x *GetCrewResponse) Reset() {
 	*x = GetCrewResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[25]
+	mi := &file_containarium_v1_agent_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1856,7 +2199,7 @@ func (x *GetCrewResponse) String() string {
 func (*GetCrewResponse) ProtoMessage() {}
 
 func (x *GetCrewResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[25]
+	mi := &file_containarium_v1_agent_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1869,7 +2212,7 @@ func (x *GetCrewResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCrewResponse.ProtoReflect.Descriptor instead.
 func (*GetCrewResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{25}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetCrewResponse) GetCrew() *Crew {
@@ -1892,7 +2235,7 @@ type RunCrewRequest struct {
 
 func (x *RunCrewRequest) Reset() {
 	*x = RunCrewRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[26]
+	mi := &file_containarium_v1_agent_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1904,7 +2247,7 @@ func (x *RunCrewRequest) String() string {
 func (*RunCrewRequest) ProtoMessage() {}
 
 func (x *RunCrewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[26]
+	mi := &file_containarium_v1_agent_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1917,7 +2260,7 @@ func (x *RunCrewRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunCrewRequest.ProtoReflect.Descriptor instead.
 func (*RunCrewRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{26}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *RunCrewRequest) GetCrewId() string {
@@ -1957,7 +2300,7 @@ type RunCrewResponse struct {
 
 func (x *RunCrewResponse) Reset() {
 	*x = RunCrewResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[27]
+	mi := &file_containarium_v1_agent_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1969,7 +2312,7 @@ func (x *RunCrewResponse) String() string {
 func (*RunCrewResponse) ProtoMessage() {}
 
 func (x *RunCrewResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[27]
+	mi := &file_containarium_v1_agent_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1982,7 +2325,7 @@ func (x *RunCrewResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunCrewResponse.ProtoReflect.Descriptor instead.
 func (*RunCrewResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{27}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *RunCrewResponse) GetRun() *CrewRun {
@@ -2001,7 +2344,7 @@ type GetCrewRunRequest struct {
 
 func (x *GetCrewRunRequest) Reset() {
 	*x = GetCrewRunRequest{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[28]
+	mi := &file_containarium_v1_agent_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2013,7 +2356,7 @@ func (x *GetCrewRunRequest) String() string {
 func (*GetCrewRunRequest) ProtoMessage() {}
 
 func (x *GetCrewRunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[28]
+	mi := &file_containarium_v1_agent_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2026,7 +2369,7 @@ func (x *GetCrewRunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCrewRunRequest.ProtoReflect.Descriptor instead.
 func (*GetCrewRunRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{28}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetCrewRunRequest) GetId() string {
@@ -2045,7 +2388,7 @@ type GetCrewRunResponse struct {
 
 func (x *GetCrewRunResponse) Reset() {
 	*x = GetCrewRunResponse{}
-	mi := &file_containarium_v1_agent_proto_msgTypes[29]
+	mi := &file_containarium_v1_agent_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2057,7 +2400,7 @@ func (x *GetCrewRunResponse) String() string {
 func (*GetCrewRunResponse) ProtoMessage() {}
 
 func (x *GetCrewRunResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_agent_proto_msgTypes[29]
+	mi := &file_containarium_v1_agent_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2070,7 +2413,7 @@ func (x *GetCrewRunResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCrewRunResponse.ProtoReflect.Descriptor instead.
 func (*GetCrewRunResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{29}
+	return file_containarium_v1_agent_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetCrewRunResponse) GetRun() *CrewRun {
@@ -2084,7 +2427,7 @@ var File_containarium_v1_agent_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_agent_proto_rawDesc = "" +
 	"\n" +
-	"\x1bcontainarium/v1/agent.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x1fcontainarium/v1/container.proto\x1a\x1ccontainarium/v1/recipe.proto\"\xcf\x01\n" +
+	"\x1bcontainarium/v1/agent.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x1fcontainarium/v1/container.proto\x1a\x1ccontainarium/v1/recipe.proto\"\xcf\x01\n" +
 	"\tAgentCard\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -2120,10 +2463,11 @@ const file_containarium_v1_agent_proto_rawDesc = "" +
 	"backend_id\x18\x02 \x01(\tR\tbackendId\x12\x12\n" +
 	"\x04pool\x18\x03 \x01(\tR\x04pool\x12\x1d\n" +
 	"\n" +
-	"input_json\x18\x04 \x01(\tR\tinputJson\"v\n" +
+	"input_json\x18\x04 \x01(\tR\tinputJson\"\x8d\x01\n" +
 	"\x15RunAgentSkillResponse\x128\n" +
 	"\tcontainer\x18\x01 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\x12#\n" +
-	"\rartifact_json\x18\x02 \x01(\tR\fartifactJson\":\n" +
+	"\rartifact_json\x18\x02 \x01(\tR\fartifactJson\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\":\n" +
 	"\tAgentTask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -2143,7 +2487,33 @@ const file_containarium_v1_agent_proto_rawDesc = "" +
 	"\btrace_id\x18\x04 \x01(\tR\atraceId\"n\n" +
 	"\x15SendAgentTaskResponse\x12:\n" +
 	"\bartifact\x18\x01 \x01(\v2\x1e.containarium.v1.AgentArtifactR\bartifact\x12\x19\n" +
-	"\btrace_id\x18\x02 \x01(\tR\atraceId\"S\n" +
+	"\btrace_id\x18\x02 \x01(\tR\atraceId\"\x96\x02\n" +
+	"\x19GetAgentModelUsageRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x19\n" +
+	"\bskill_id\x18\x02 \x01(\tR\askillId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12=\n" +
+	"\bgroup_by\x18\x06 \x01(\x0e2\".containarium.v1.ModelUsageGroupByR\agroupBy\"\xdc\x03\n" +
+	"\x10ModelUsageRollup\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x19\n" +
+	"\bskill_id\x18\x02 \x01(\tR\askillId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05model\x18\x05 \x01(\tR\x05model\x12\x14\n" +
+	"\x05calls\x18\x06 \x01(\x03R\x05calls\x12!\n" +
+	"\ffailed_calls\x18\a \x01(\x03R\vfailedCalls\x12!\n" +
+	"\finput_tokens\x18\b \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\t \x01(\x03R\foutputTokens\x12#\n" +
+	"\rcached_tokens\x18\n" +
+	" \x01(\x03R\fcachedTokens\x12(\n" +
+	"\x10total_latency_ms\x18\v \x01(\x03R\x0etotalLatencyMs\x12>\n" +
+	"\rfirst_call_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vfirstCallAt\x12<\n" +
+	"\flast_call_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastCallAt\"Y\n" +
+	"\x1aGetAgentModelUsageResponse\x12;\n" +
+	"\arollups\x18\x01 \x03(\v2!.containarium.v1.ModelUsageRollupR\arollups\"S\n" +
 	"\x17EnqueueAgentTaskRequest\x12\x19\n" +
 	"\bskill_id\x18\x01 \x01(\tR\askillId\x12\x1d\n" +
 	"\n" +
@@ -2220,7 +2590,12 @@ const file_containarium_v1_agent_proto_rawDesc = "" +
 	"\x1aAGENT_TASK_STATE_SUBMITTED\x10\x01\x12\x1c\n" +
 	"\x18AGENT_TASK_STATE_WORKING\x10\x02\x12\x1e\n" +
 	"\x1aAGENT_TASK_STATE_COMPLETED\x10\x03\x12\x1b\n" +
-	"\x17AGENT_TASK_STATE_FAILED\x10\x04*\x85\x01\n" +
+	"\x17AGENT_TASK_STATE_FAILED\x10\x04*\x98\x01\n" +
+	"\x11ModelUsageGroupBy\x12$\n" +
+	" MODEL_USAGE_GROUP_BY_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bMODEL_USAGE_GROUP_BY_TENANT\x10\x01\x12\x1e\n" +
+	"\x1aMODEL_USAGE_GROUP_BY_SKILL\x10\x02\x12\x1c\n" +
+	"\x18MODEL_USAGE_GROUP_BY_RUN\x10\x03*\x85\x01\n" +
 	"\fCrewTopology\x12\x1d\n" +
 	"\x19CREW_TOPOLOGY_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16CREW_TOPOLOGY_PIPELINE\x10\x01\x12\x1e\n" +
@@ -2232,7 +2607,7 @@ const file_containarium_v1_agent_proto_rawDesc = "" +
 	"\x16CREW_RUN_STATE_RUNNING\x10\x02\x12\x1c\n" +
 	"\x18CREW_RUN_STATE_COMPLETED\x10\x03\x12\x19\n" +
 	"\x15CREW_RUN_STATE_FAILED\x10\x04\x12\x1c\n" +
-	"\x18CREW_RUN_STATE_CANCELLED\x10\x052\xfd\x11\n" +
+	"\x18CREW_RUN_STATE_CANCELLED\x10\x052\xea\x14\n" +
 	"\x11AgentSkillService\x12\xf6\x01\n" +
 	"\x0fListAgentSkills\x12'.containarium.v1.ListAgentSkillsRequest\x1a(.containarium.v1.ListAgentSkillsResponse\"\x8f\x01\x92At\n" +
 	"\x06Agents\x12\x11List agent skills\x1aWReturns all packaged agent skills that can be run individually or composed into a crew.\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/agent-skills\x12\xf8\x01\n" +
@@ -2249,7 +2624,9 @@ const file_containarium_v1_agent_proto_rawDesc = "" +
 	"\x11CompleteAgentTask\x12).containarium.v1.CompleteAgentTaskRequest\x1a*.containarium.v1.CompleteAgentTaskResponse\"\xb9\x01\x92A\x88\x01\n" +
 	"\x06Agents\x12)Complete a leased agent task (pull queue)\x1aSWorker boxes report a leased task's artifact/error here; a stale lease is rejected.\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/agent-tasks/{task_id}/complete\x12\xda\x02\n" +
 	"\x10StartAgentWorker\x12(.containarium.v1.StartAgentWorkerRequest\x1a).containarium.v1.StartAgentWorkerResponse\"\xf0\x01\x92A\xbf\x01\n" +
-	"\x06Agents\x12%Start a pull-queue worker for a skill\x1a\x8d\x01Provisions the skill's box, mints an agents:run queue credential, and launches the in-box runtime in poll mode to lease and run queued tasks.\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/agent-skills/{skill_id}/worker2\x96\a\n" +
+	"\x06Agents\x12%Start a pull-queue worker for a skill\x1a\x8d\x01Provisions the skill's box, mints an agents:run queue credential, and launches the in-box runtime in poll mode to lease and run queued tasks.\x82\xd3\xe4\x93\x02':\x01*\"\"/v1/agent-skills/{skill_id}/worker\x12\xea\x02\n" +
+	"\x12GetAgentModelUsage\x12*.containarium.v1.GetAgentModelUsageRequest\x1a+.containarium.v1.GetAgentModelUsageResponse\"\xfa\x01\x92A\xdf\x01\n" +
+	"\x06Agents\x12\x15Get agent model usage\x1a\xbd\x01Per-tenant, per-skill or per-run rollups of the model calls agent boxes made through the model gateway: calls, failures, input/output/cached tokens and latency, split by provider and model.\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/agent-usage2\x96\a\n" +
 	"\vCrewService\x12\xbc\x01\n" +
 	"\tListCrews\x12!.containarium.v1.ListCrewsRequest\x1a\".containarium.v1.ListCrewsResponse\"h\x92AT\n" +
 	"\x06Agents\x12\n" +
@@ -2275,89 +2652,102 @@ func file_containarium_v1_agent_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_agent_proto_rawDescData
 }
 
-var file_containarium_v1_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_containarium_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_containarium_v1_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_containarium_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_containarium_v1_agent_proto_goTypes = []any{
-	(AgentTaskState)(0),               // 0: containarium.v1.AgentTaskState
-	(CrewTopology)(0),                 // 1: containarium.v1.CrewTopology
-	(CrewRunState)(0),                 // 2: containarium.v1.CrewRunState
-	(*AgentCard)(nil),                 // 3: containarium.v1.AgentCard
-	(*AgentSkill)(nil),                // 4: containarium.v1.AgentSkill
-	(*ListAgentSkillsRequest)(nil),    // 5: containarium.v1.ListAgentSkillsRequest
-	(*ListAgentSkillsResponse)(nil),   // 6: containarium.v1.ListAgentSkillsResponse
-	(*GetAgentSkillRequest)(nil),      // 7: containarium.v1.GetAgentSkillRequest
-	(*GetAgentSkillResponse)(nil),     // 8: containarium.v1.GetAgentSkillResponse
-	(*RunAgentSkillRequest)(nil),      // 9: containarium.v1.RunAgentSkillRequest
-	(*RunAgentSkillResponse)(nil),     // 10: containarium.v1.RunAgentSkillResponse
-	(*AgentTask)(nil),                 // 11: containarium.v1.AgentTask
-	(*AgentArtifact)(nil),             // 12: containarium.v1.AgentArtifact
-	(*SendAgentTaskRequest)(nil),      // 13: containarium.v1.SendAgentTaskRequest
-	(*SendAgentTaskResponse)(nil),     // 14: containarium.v1.SendAgentTaskResponse
-	(*EnqueueAgentTaskRequest)(nil),   // 15: containarium.v1.EnqueueAgentTaskRequest
-	(*EnqueueAgentTaskResponse)(nil),  // 16: containarium.v1.EnqueueAgentTaskResponse
-	(*LeaseAgentTaskRequest)(nil),     // 17: containarium.v1.LeaseAgentTaskRequest
-	(*LeaseAgentTaskResponse)(nil),    // 18: containarium.v1.LeaseAgentTaskResponse
-	(*CompleteAgentTaskRequest)(nil),  // 19: containarium.v1.CompleteAgentTaskRequest
-	(*CompleteAgentTaskResponse)(nil), // 20: containarium.v1.CompleteAgentTaskResponse
-	(*StartAgentWorkerRequest)(nil),   // 21: containarium.v1.StartAgentWorkerRequest
-	(*StartAgentWorkerResponse)(nil),  // 22: containarium.v1.StartAgentWorkerResponse
-	(*Crew)(nil),                      // 23: containarium.v1.Crew
-	(*CrewRun)(nil),                   // 24: containarium.v1.CrewRun
-	(*ListCrewsRequest)(nil),          // 25: containarium.v1.ListCrewsRequest
-	(*ListCrewsResponse)(nil),         // 26: containarium.v1.ListCrewsResponse
-	(*GetCrewRequest)(nil),            // 27: containarium.v1.GetCrewRequest
-	(*GetCrewResponse)(nil),           // 28: containarium.v1.GetCrewResponse
-	(*RunCrewRequest)(nil),            // 29: containarium.v1.RunCrewRequest
-	(*RunCrewResponse)(nil),           // 30: containarium.v1.RunCrewResponse
-	(*GetCrewRunRequest)(nil),         // 31: containarium.v1.GetCrewRunRequest
-	(*GetCrewRunResponse)(nil),        // 32: containarium.v1.GetCrewRunResponse
-	(*Recipe)(nil),                    // 33: containarium.v1.Recipe
-	(*Container)(nil),                 // 34: containarium.v1.Container
+	(AgentTaskState)(0),                // 0: containarium.v1.AgentTaskState
+	(ModelUsageGroupBy)(0),             // 1: containarium.v1.ModelUsageGroupBy
+	(CrewTopology)(0),                  // 2: containarium.v1.CrewTopology
+	(CrewRunState)(0),                  // 3: containarium.v1.CrewRunState
+	(*AgentCard)(nil),                  // 4: containarium.v1.AgentCard
+	(*AgentSkill)(nil),                 // 5: containarium.v1.AgentSkill
+	(*ListAgentSkillsRequest)(nil),     // 6: containarium.v1.ListAgentSkillsRequest
+	(*ListAgentSkillsResponse)(nil),    // 7: containarium.v1.ListAgentSkillsResponse
+	(*GetAgentSkillRequest)(nil),       // 8: containarium.v1.GetAgentSkillRequest
+	(*GetAgentSkillResponse)(nil),      // 9: containarium.v1.GetAgentSkillResponse
+	(*RunAgentSkillRequest)(nil),       // 10: containarium.v1.RunAgentSkillRequest
+	(*RunAgentSkillResponse)(nil),      // 11: containarium.v1.RunAgentSkillResponse
+	(*AgentTask)(nil),                  // 12: containarium.v1.AgentTask
+	(*AgentArtifact)(nil),              // 13: containarium.v1.AgentArtifact
+	(*SendAgentTaskRequest)(nil),       // 14: containarium.v1.SendAgentTaskRequest
+	(*SendAgentTaskResponse)(nil),      // 15: containarium.v1.SendAgentTaskResponse
+	(*GetAgentModelUsageRequest)(nil),  // 16: containarium.v1.GetAgentModelUsageRequest
+	(*ModelUsageRollup)(nil),           // 17: containarium.v1.ModelUsageRollup
+	(*GetAgentModelUsageResponse)(nil), // 18: containarium.v1.GetAgentModelUsageResponse
+	(*EnqueueAgentTaskRequest)(nil),    // 19: containarium.v1.EnqueueAgentTaskRequest
+	(*EnqueueAgentTaskResponse)(nil),   // 20: containarium.v1.EnqueueAgentTaskResponse
+	(*LeaseAgentTaskRequest)(nil),      // 21: containarium.v1.LeaseAgentTaskRequest
+	(*LeaseAgentTaskResponse)(nil),     // 22: containarium.v1.LeaseAgentTaskResponse
+	(*CompleteAgentTaskRequest)(nil),   // 23: containarium.v1.CompleteAgentTaskRequest
+	(*CompleteAgentTaskResponse)(nil),  // 24: containarium.v1.CompleteAgentTaskResponse
+	(*StartAgentWorkerRequest)(nil),    // 25: containarium.v1.StartAgentWorkerRequest
+	(*StartAgentWorkerResponse)(nil),   // 26: containarium.v1.StartAgentWorkerResponse
+	(*Crew)(nil),                       // 27: containarium.v1.Crew
+	(*CrewRun)(nil),                    // 28: containarium.v1.CrewRun
+	(*ListCrewsRequest)(nil),           // 29: containarium.v1.ListCrewsRequest
+	(*ListCrewsResponse)(nil),          // 30: containarium.v1.ListCrewsResponse
+	(*GetCrewRequest)(nil),             // 31: containarium.v1.GetCrewRequest
+	(*GetCrewResponse)(nil),            // 32: containarium.v1.GetCrewResponse
+	(*RunCrewRequest)(nil),             // 33: containarium.v1.RunCrewRequest
+	(*RunCrewResponse)(nil),            // 34: containarium.v1.RunCrewResponse
+	(*GetCrewRunRequest)(nil),          // 35: containarium.v1.GetCrewRunRequest
+	(*GetCrewRunResponse)(nil),         // 36: containarium.v1.GetCrewRunResponse
+	(*Recipe)(nil),                     // 37: containarium.v1.Recipe
+	(*Container)(nil),                  // 38: containarium.v1.Container
+	(*timestamppb.Timestamp)(nil),      // 39: google.protobuf.Timestamp
 }
 var file_containarium_v1_agent_proto_depIdxs = []int32{
-	33, // 0: containarium.v1.AgentSkill.recipe:type_name -> containarium.v1.Recipe
-	3,  // 1: containarium.v1.AgentSkill.agent_card:type_name -> containarium.v1.AgentCard
-	4,  // 2: containarium.v1.ListAgentSkillsResponse.skills:type_name -> containarium.v1.AgentSkill
-	4,  // 3: containarium.v1.GetAgentSkillResponse.skill:type_name -> containarium.v1.AgentSkill
-	34, // 4: containarium.v1.RunAgentSkillResponse.container:type_name -> containarium.v1.Container
+	37, // 0: containarium.v1.AgentSkill.recipe:type_name -> containarium.v1.Recipe
+	4,  // 1: containarium.v1.AgentSkill.agent_card:type_name -> containarium.v1.AgentCard
+	5,  // 2: containarium.v1.ListAgentSkillsResponse.skills:type_name -> containarium.v1.AgentSkill
+	5,  // 3: containarium.v1.GetAgentSkillResponse.skill:type_name -> containarium.v1.AgentSkill
+	38, // 4: containarium.v1.RunAgentSkillResponse.container:type_name -> containarium.v1.Container
 	0,  // 5: containarium.v1.AgentArtifact.state:type_name -> containarium.v1.AgentTaskState
-	12, // 6: containarium.v1.SendAgentTaskResponse.artifact:type_name -> containarium.v1.AgentArtifact
-	34, // 7: containarium.v1.StartAgentWorkerResponse.container:type_name -> containarium.v1.Container
-	1,  // 8: containarium.v1.Crew.topology:type_name -> containarium.v1.CrewTopology
-	2,  // 9: containarium.v1.CrewRun.state:type_name -> containarium.v1.CrewRunState
-	23, // 10: containarium.v1.ListCrewsResponse.crews:type_name -> containarium.v1.Crew
-	23, // 11: containarium.v1.GetCrewResponse.crew:type_name -> containarium.v1.Crew
-	24, // 12: containarium.v1.RunCrewResponse.run:type_name -> containarium.v1.CrewRun
-	24, // 13: containarium.v1.GetCrewRunResponse.run:type_name -> containarium.v1.CrewRun
-	5,  // 14: containarium.v1.AgentSkillService.ListAgentSkills:input_type -> containarium.v1.ListAgentSkillsRequest
-	7,  // 15: containarium.v1.AgentSkillService.GetAgentSkill:input_type -> containarium.v1.GetAgentSkillRequest
-	9,  // 16: containarium.v1.AgentSkillService.RunAgentSkill:input_type -> containarium.v1.RunAgentSkillRequest
-	13, // 17: containarium.v1.AgentSkillService.SendAgentTask:input_type -> containarium.v1.SendAgentTaskRequest
-	15, // 18: containarium.v1.AgentSkillService.EnqueueAgentTask:input_type -> containarium.v1.EnqueueAgentTaskRequest
-	17, // 19: containarium.v1.AgentSkillService.LeaseAgentTask:input_type -> containarium.v1.LeaseAgentTaskRequest
-	19, // 20: containarium.v1.AgentSkillService.CompleteAgentTask:input_type -> containarium.v1.CompleteAgentTaskRequest
-	21, // 21: containarium.v1.AgentSkillService.StartAgentWorker:input_type -> containarium.v1.StartAgentWorkerRequest
-	25, // 22: containarium.v1.CrewService.ListCrews:input_type -> containarium.v1.ListCrewsRequest
-	27, // 23: containarium.v1.CrewService.GetCrew:input_type -> containarium.v1.GetCrewRequest
-	29, // 24: containarium.v1.CrewService.RunCrew:input_type -> containarium.v1.RunCrewRequest
-	31, // 25: containarium.v1.CrewService.GetCrewRun:input_type -> containarium.v1.GetCrewRunRequest
-	6,  // 26: containarium.v1.AgentSkillService.ListAgentSkills:output_type -> containarium.v1.ListAgentSkillsResponse
-	8,  // 27: containarium.v1.AgentSkillService.GetAgentSkill:output_type -> containarium.v1.GetAgentSkillResponse
-	10, // 28: containarium.v1.AgentSkillService.RunAgentSkill:output_type -> containarium.v1.RunAgentSkillResponse
-	14, // 29: containarium.v1.AgentSkillService.SendAgentTask:output_type -> containarium.v1.SendAgentTaskResponse
-	16, // 30: containarium.v1.AgentSkillService.EnqueueAgentTask:output_type -> containarium.v1.EnqueueAgentTaskResponse
-	18, // 31: containarium.v1.AgentSkillService.LeaseAgentTask:output_type -> containarium.v1.LeaseAgentTaskResponse
-	20, // 32: containarium.v1.AgentSkillService.CompleteAgentTask:output_type -> containarium.v1.CompleteAgentTaskResponse
-	22, // 33: containarium.v1.AgentSkillService.StartAgentWorker:output_type -> containarium.v1.StartAgentWorkerResponse
-	26, // 34: containarium.v1.CrewService.ListCrews:output_type -> containarium.v1.ListCrewsResponse
-	28, // 35: containarium.v1.CrewService.GetCrew:output_type -> containarium.v1.GetCrewResponse
-	30, // 36: containarium.v1.CrewService.RunCrew:output_type -> containarium.v1.RunCrewResponse
-	32, // 37: containarium.v1.CrewService.GetCrewRun:output_type -> containarium.v1.GetCrewRunResponse
-	26, // [26:38] is the sub-list for method output_type
-	14, // [14:26] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	13, // 6: containarium.v1.SendAgentTaskResponse.artifact:type_name -> containarium.v1.AgentArtifact
+	39, // 7: containarium.v1.GetAgentModelUsageRequest.start_time:type_name -> google.protobuf.Timestamp
+	39, // 8: containarium.v1.GetAgentModelUsageRequest.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 9: containarium.v1.GetAgentModelUsageRequest.group_by:type_name -> containarium.v1.ModelUsageGroupBy
+	39, // 10: containarium.v1.ModelUsageRollup.first_call_at:type_name -> google.protobuf.Timestamp
+	39, // 11: containarium.v1.ModelUsageRollup.last_call_at:type_name -> google.protobuf.Timestamp
+	17, // 12: containarium.v1.GetAgentModelUsageResponse.rollups:type_name -> containarium.v1.ModelUsageRollup
+	38, // 13: containarium.v1.StartAgentWorkerResponse.container:type_name -> containarium.v1.Container
+	2,  // 14: containarium.v1.Crew.topology:type_name -> containarium.v1.CrewTopology
+	3,  // 15: containarium.v1.CrewRun.state:type_name -> containarium.v1.CrewRunState
+	27, // 16: containarium.v1.ListCrewsResponse.crews:type_name -> containarium.v1.Crew
+	27, // 17: containarium.v1.GetCrewResponse.crew:type_name -> containarium.v1.Crew
+	28, // 18: containarium.v1.RunCrewResponse.run:type_name -> containarium.v1.CrewRun
+	28, // 19: containarium.v1.GetCrewRunResponse.run:type_name -> containarium.v1.CrewRun
+	6,  // 20: containarium.v1.AgentSkillService.ListAgentSkills:input_type -> containarium.v1.ListAgentSkillsRequest
+	8,  // 21: containarium.v1.AgentSkillService.GetAgentSkill:input_type -> containarium.v1.GetAgentSkillRequest
+	10, // 22: containarium.v1.AgentSkillService.RunAgentSkill:input_type -> containarium.v1.RunAgentSkillRequest
+	14, // 23: containarium.v1.AgentSkillService.SendAgentTask:input_type -> containarium.v1.SendAgentTaskRequest
+	19, // 24: containarium.v1.AgentSkillService.EnqueueAgentTask:input_type -> containarium.v1.EnqueueAgentTaskRequest
+	21, // 25: containarium.v1.AgentSkillService.LeaseAgentTask:input_type -> containarium.v1.LeaseAgentTaskRequest
+	23, // 26: containarium.v1.AgentSkillService.CompleteAgentTask:input_type -> containarium.v1.CompleteAgentTaskRequest
+	25, // 27: containarium.v1.AgentSkillService.StartAgentWorker:input_type -> containarium.v1.StartAgentWorkerRequest
+	16, // 28: containarium.v1.AgentSkillService.GetAgentModelUsage:input_type -> containarium.v1.GetAgentModelUsageRequest
+	29, // 29: containarium.v1.CrewService.ListCrews:input_type -> containarium.v1.ListCrewsRequest
+	31, // 30: containarium.v1.CrewService.GetCrew:input_type -> containarium.v1.GetCrewRequest
+	33, // 31: containarium.v1.CrewService.RunCrew:input_type -> containarium.v1.RunCrewRequest
+	35, // 32: containarium.v1.CrewService.GetCrewRun:input_type -> containarium.v1.GetCrewRunRequest
+	7,  // 33: containarium.v1.AgentSkillService.ListAgentSkills:output_type -> containarium.v1.ListAgentSkillsResponse
+	9,  // 34: containarium.v1.AgentSkillService.GetAgentSkill:output_type -> containarium.v1.GetAgentSkillResponse
+	11, // 35: containarium.v1.AgentSkillService.RunAgentSkill:output_type -> containarium.v1.RunAgentSkillResponse
+	15, // 36: containarium.v1.AgentSkillService.SendAgentTask:output_type -> containarium.v1.SendAgentTaskResponse
+	20, // 37: containarium.v1.AgentSkillService.EnqueueAgentTask:output_type -> containarium.v1.EnqueueAgentTaskResponse
+	22, // 38: containarium.v1.AgentSkillService.LeaseAgentTask:output_type -> containarium.v1.LeaseAgentTaskResponse
+	24, // 39: containarium.v1.AgentSkillService.CompleteAgentTask:output_type -> containarium.v1.CompleteAgentTaskResponse
+	26, // 40: containarium.v1.AgentSkillService.StartAgentWorker:output_type -> containarium.v1.StartAgentWorkerResponse
+	18, // 41: containarium.v1.AgentSkillService.GetAgentModelUsage:output_type -> containarium.v1.GetAgentModelUsageResponse
+	30, // 42: containarium.v1.CrewService.ListCrews:output_type -> containarium.v1.ListCrewsResponse
+	32, // 43: containarium.v1.CrewService.GetCrew:output_type -> containarium.v1.GetCrewResponse
+	34, // 44: containarium.v1.CrewService.RunCrew:output_type -> containarium.v1.RunCrewResponse
+	36, // 45: containarium.v1.CrewService.GetCrewRun:output_type -> containarium.v1.GetCrewRunResponse
+	33, // [33:46] is the sub-list for method output_type
+	20, // [20:33] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_agent_proto_init() }
@@ -2376,8 +2766,8 @@ func file_containarium_v1_agent_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_agent_proto_rawDesc), len(file_containarium_v1_agent_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   30,
+			NumEnums:      4,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
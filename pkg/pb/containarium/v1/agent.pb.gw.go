@@ -329,6 +329,41 @@ func local_request_AgentSkillService_StartAgentWorker_0(ctx context.Context, mar
 	return msg, metadata, err
 }
 
+var filter_AgentSkillService_GetAgentModelUsage_0 = &utilities.DoubleArray{Encoding: map[string]int{}, Base: []int(nil), Check: []int(nil)}
+
+func request_AgentSkillService_GetAgentModelUsage_0(ctx context.Context, marshaler runtime.Marshaler, client AgentSkillServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq GetAgentModelUsageRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_AgentSkillService_GetAgentModelUsage_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+	}
+	msg, err := client.GetAgentModelUsage(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+	return msg, metadata, err
+}
+
+func local_request_AgentSkillService_GetAgentModelUsage_0(ctx context.Context, marshaler runtime.Marshaler, server AgentSkillServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq GetAgentModelUsageRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_AgentSkillService_GetAgentModelUsage_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	msg, err := server.GetAgentModelUsage(ctx, &protoReq)
+	return msg, metadata, err
+}
+
 func request_CrewService_ListCrews_0(ctx context.Context, marshaler runtime.Marshaler, client CrewServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var (
 		protoReq ListCrewsRequest
@@ -639,6 +674,26 @@ func RegisterAgentSkillServiceHandlerServer(ctx context.Context, mux *runtime.Se
 		}
 		forward_AgentSkillService_StartAgentWorker_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodGet, pattern_AgentSkillService_GetAgentModelUsage_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/containarium.v1.AgentSkillService/GetAgentModelUsage", runtime.WithHTTPPathPattern("/v1/agent-usage"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_AgentSkillService_GetAgentModelUsage_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_AgentSkillService_GetAgentModelUsage_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 
 	return nil
 }
@@ -905,29 +960,48 @@ func RegisterAgentSkillServiceHandlerClient(ctx context.Context, mux *runtime.Se
 		}
 		forward_AgentSkillService_StartAgentWorker_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodGet, pattern_AgentSkillService_GetAgentModelUsage_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/containarium.v1.AgentSkillService/GetAgentModelUsage", runtime.WithHTTPPathPattern("/v1/agent-usage"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_AgentSkillService_GetAgentModelUsage_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_AgentSkillService_GetAgentModelUsage_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 	return nil
 }
 
 var (
-	pattern_AgentSkillService_ListAgentSkills_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "agent-skills"}, ""))
-	pattern_AgentSkillService_GetAgentSkill_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v1", "agent-skills", "id"}, ""))
-	pattern_AgentSkillService_RunAgentSkill_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-skills", "skill_id", "run"}, ""))
-	pattern_AgentSkillService_SendAgentTask_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-skills", "to_peer_id", "call"}, ""))
-	pattern_AgentSkillService_EnqueueAgentTask_0  = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "agent-tasks"}, ""))
-	pattern_AgentSkillService_LeaseAgentTask_0    = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2}, []string{"v1", "agent-tasks", "lease"}, ""))
-	pattern_AgentSkillService_CompleteAgentTask_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-tasks", "task_id", "complete"}, ""))
-	pattern_AgentSkillService_StartAgentWorker_0  = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-skills", "skill_id", "worker"}, ""))
+	pattern_AgentSkillService_ListAgentSkills_0    = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "agent-skills"}, ""))
+	pattern_AgentSkillService_GetAgentSkill_0      = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v1", "agent-skills", "id"}, ""))
+	pattern_AgentSkillService_RunAgentSkill_0      = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-skills", "skill_id", "run"}, ""))
+	pattern_AgentSkillService_SendAgentTask_0      = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-skills", "to_peer_id", "call"}, ""))
+	pattern_AgentSkillService_EnqueueAgentTask_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "agent-tasks"}, ""))
+	pattern_AgentSkillService_LeaseAgentTask_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2}, []string{"v1", "agent-tasks", "lease"}, ""))
+	pattern_AgentSkillService_CompleteAgentTask_0  = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-tasks", "task_id", "complete"}, ""))
+	pattern_AgentSkillService_StartAgentWorker_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2, 2, 3}, []string{"v1", "agent-skills", "skill_id", "worker"}, ""))
+	pattern_AgentSkillService_GetAgentModelUsage_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "agent-usage"}, ""))
 )
 
 var (
-	forward_AgentSkillService_ListAgentSkills_0   = runtime.ForwardResponseMessage
-	forward_AgentSkillService_GetAgentSkill_0     = runtime.ForwardResponseMessage
-	forward_AgentSkillService_RunAgentSkill_0     = runtime.ForwardResponseMessage
-	forward_AgentSkillService_SendAgentTask_0     = runtime.ForwardResponseMessage
-	forward_AgentSkillService_EnqueueAgentTask_0  = runtime.ForwardResponseMessage
-	forward_AgentSkillService_LeaseAgentTask_0    = runtime.ForwardResponseMessage
-	forward_AgentSkillService_CompleteAgentTask_0 = runtime.ForwardResponseMessage
-	forward_AgentSkillService_StartAgentWorker_0  = runtime.ForwardResponseMessage
+	forward_AgentSkillService_ListAgentSkills_0    = runtime.ForwardResponseMessage
+	forward_AgentSkillService_GetAgentSkill_0      = runtime.ForwardResponseMessage
+	forward_AgentSkillService_RunAgentSkill_0      = runtime.ForwardResponseMessage
+	forward_AgentSkillService_SendAgentTask_0      = runtime.ForwardResponseMessage
+	forward_AgentSkillService_EnqueueAgentTask_0   = runtime.ForwardResponseMessage
+	forward_AgentSkillService_LeaseAgentTask_0     = runtime.ForwardResponseMessage
+	forward_AgentSkillService_CompleteAgentTask_0  = runtime.ForwardResponseMessage
+	forward_AgentSkillService_StartAgentWorker_0   = runtime.ForwardResponseMessage
+	forward_AgentSkillService_GetAgentModelUsage_0 = runtime.ForwardResponseMessage
 )
 
 // RegisterCrewServiceHandlerFromEndpoint is same as RegisterCrewServiceHandler but
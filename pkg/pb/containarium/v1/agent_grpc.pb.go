@@ -19,14 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AgentSkillService_ListAgentSkills_FullMethodName   = "/containarium.v1.AgentSkillService/ListAgentSkills"
-	AgentSkillService_GetAgentSkill_FullMethodName     = "/containarium.v1.AgentSkillService/GetAgentSkill"
-	AgentSkillService_RunAgentSkill_FullMethodName     = "/containarium.v1.AgentSkillService/RunAgentSkill"
-	AgentSkillService_SendAgentTask_FullMethodName     = "/containarium.v1.AgentSkillService/SendAgentTask"
-	AgentSkillService_EnqueueAgentTask_FullMethodName  = "/containarium.v1.AgentSkillService/EnqueueAgentTask"
-	AgentSkillService_LeaseAgentTask_FullMethodName    = "/containarium.v1.AgentSkillService/LeaseAgentTask"
-	AgentSkillService_CompleteAgentTask_FullMethodName = "/containarium.v1.AgentSkillService/CompleteAgentTask"
-	AgentSkillService_StartAgentWorker_FullMethodName  = "/containarium.v1.AgentSkillService/StartAgentWorker"
+	AgentSkillService_ListAgentSkills_FullMethodName    = "/containarium.v1.AgentSkillService/ListAgentSkills"
+	AgentSkillService_GetAgentSkill_FullMethodName      = "/containarium.v1.AgentSkillService/GetAgentSkill"
+	AgentSkillService_RunAgentSkill_FullMethodName      = "/containarium.v1.AgentSkillService/RunAgentSkill"
+	AgentSkillService_SendAgentTask_FullMethodName      = "/containarium.v1.AgentSkillService/SendAgentTask"
+	AgentSkillService_EnqueueAgentTask_FullMethodName   = "/containarium.v1.AgentSkillService/EnqueueAgentTask"
+	AgentSkillService_LeaseAgentTask_FullMethodName     = "/containarium.v1.AgentSkillService/LeaseAgentTask"
+	AgentSkillService_CompleteAgentTask_FullMethodName  = "/containarium.v1.AgentSkillService/CompleteAgentTask"
+	AgentSkillService_StartAgentWorker_FullMethodName   = "/containarium.v1.AgentSkillService/StartAgentWorker"
+	AgentSkillService_GetAgentModelUsage_FullMethodName = "/containarium.v1.AgentSkillService/GetAgentModelUsage"
 )
 
 // AgentSkillServiceClient is the client API for AgentSkillService service.
@@ -65,6 +66,11 @@ type AgentSkillServiceClient interface {
 	// the queue endpoints; the worker resolves the daemon URL from its default
 	// route (the backend host) at launch.
 	StartAgentWorker(ctx context.Context, in *StartAgentWorkerRequest, opts ...grpc.CallOption) (*StartAgentWorkerResponse, error)
+	// GetAgentModelUsage rolls up the model-gateway usage ledger: every model
+	// call a box made through the gateway, attributed to its tenant, skill and
+	// run (RunAgentSkill / RunCrew run id). Durable when the daemon has
+	// Postgres; in-memory (since the last restart) otherwise.
+	GetAgentModelUsage(ctx context.Context, in *GetAgentModelUsageRequest, opts ...grpc.CallOption) (*GetAgentModelUsageResponse, error)
 }
 
 type agentSkillServiceClient struct {
@@ -155,6 +161,16 @@ func (c *agentSkillServiceClient) StartAgentWorker(ctx context.Context, in *Star
 	return out, nil
 }
 
+func (c *agentSkillServiceClient) GetAgentModelUsage(ctx context.Context, in *GetAgentModelUsageRequest, opts ...grpc.CallOption) (*GetAgentModelUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentModelUsageResponse)
+	err := c.cc.Invoke(ctx, AgentSkillService_GetAgentModelUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentSkillServiceServer is the server API for AgentSkillService service.
 // All implementations must embed UnimplementedAgentSkillServiceServer
 // for forward compatibility.
@@ -191,6 +207,11 @@ type AgentSkillServiceServer interface {
 	// the queue endpoints; the worker resolves the daemon URL from its default
 	// route (the backend host) at launch.
 	StartAgentWorker(context.Context, *StartAgentWorkerRequest) (*StartAgentWorkerResponse, error)
+	// GetAgentModelUsage rolls up the model-gateway usage ledger: every model
+	// call a box made through the gateway, attributed to its tenant, skill and
+	// run (RunAgentSkill / RunCrew run id). Durable when the daemon has
+	// Postgres; in-memory (since the last restart) otherwise.
+	GetAgentModelUsage(context.Context, *GetAgentModelUsageRequest) (*GetAgentModelUsageResponse, error)
 	mustEmbedUnimplementedAgentSkillServiceServer()
 }
 
@@ -225,6 +246,9 @@ func (UnimplementedAgentSkillServiceServer) CompleteAgentTask(context.Context, *
 func (UnimplementedAgentSkillServiceServer) StartAgentWorker(context.Context, *StartAgentWorkerRequest) (*StartAgentWorkerResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method StartAgentWorker not implemented")
 }
+func (UnimplementedAgentSkillServiceServer) GetAgentModelUsage(context.Context, *GetAgentModelUsageRequest) (*GetAgentModelUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAgentModelUsage not implemented")
+}
 func (UnimplementedAgentSkillServiceServer) mustEmbedUnimplementedAgentSkillServiceServer() {}
 func (UnimplementedAgentSkillServiceServer) testEmbeddedByValue()                           {}
 
@@ -390,6 +414,24 @@ func _AgentSkillService_StartAgentWorker_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentSkillService_GetAgentModelUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentModelUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentSkillServiceServer).GetAgentModelUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentSkillService_GetAgentModelUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentSkillServiceServer).GetAgentModelUsage(ctx, req.(*GetAgentModelUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentSkillService_ServiceDesc is the grpc.ServiceDesc for AgentSkillService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -429,6 +471,10 @@ var AgentSkillService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartAgentWorker",
 			Handler:    _AgentSkillService_StartAgentWorker_Handler,
 		},
+		{
+			MethodName: "GetAgentModelUsage",
+			Handler:    _AgentSkillService_GetAgentModelUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "containarium/v1/agent.proto",
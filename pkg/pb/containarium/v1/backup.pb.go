@@ -41,6 +41,11 @@ const (
 	// (`gcloud storage cp`). True off-host durability; the recommended
 	// production destination.
 	BackupDestination_BACKUP_DESTINATION_GCS BackupDestination = 2
+	// An S3-compatible bucket (AWS S3, MinIO, Cloudflare R2, Ceph RGW),
+	// written over the S3 API with multipart upload and server-validated
+	// SHA-256 checksums. Endpoint and credentials are daemon configuration
+	// (CONTAINARIUM_BACKUP_S3_* and AWS_* env vars), never request fields.
+	BackupDestination_BACKUP_DESTINATION_S3 BackupDestination = 3
 )
 
 // Enum value maps for BackupDestination.
@@ -49,11 +54,13 @@ var (
 		0: "BACKUP_DESTINATION_UNSPECIFIED",
 		1: "BACKUP_DESTINATION_LOCAL",
 		2: "BACKUP_DESTINATION_GCS",
+		3: "BACKUP_DESTINATION_S3",
 	}
 	BackupDestination_value = map[string]int32{
 		"BACKUP_DESTINATION_UNSPECIFIED": 0,
 		"BACKUP_DESTINATION_LOCAL":       1,
 		"BACKUP_DESTINATION_GCS":         2,
+		"BACKUP_DESTINATION_S3":          3,
 	}
 )
 
@@ -84,20 +91,30 @@ func (BackupDestination) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{0}
 }
 
-// BackupEngine identifies the database engine a backup was taken from.
-//
-// Postgres is the only engine implemented today; the enum exists so that a
-// second one has somewhere to be named, and so that the engine a record was
-// taken with is a typed value rather than a string the reader has to trust.
+// BackupEngine identifies the database engine a backup was taken from, so
+// that the engine a record was taken with is a typed value rather than a
+// string the reader has to trust.
 type BackupEngine int32
 
 const (
-	// Unset, or a record written by a daemon that predates this enum.
-	// Rejected wherever an engine has to be chosen — never treated as
-	// Postgres by default, because guessing the engine of a dump is how a
+	// On a BackupRecord: a record written by a daemon that predates this
+	// enum, or by an engine this daemon does not know. Never treated as
+	// Postgres there, because guessing the engine of a dump is how a
 	// restore silently targets the wrong one.
+	//
+	// On a CreateBackupRequest or BackupSchedule, where the caller is
+	// choosing rather than describing: Postgres, the only engine there was
+	// before there was a choice.
 	BackupEngine_BACKUP_ENGINE_UNSPECIFIED BackupEngine = 0
-	BackupEngine_BACKUP_ENGINE_POSTGRES    BackupEngine = 1
+	// pg_dump custom-format archive, restored with pg_restore.
+	BackupEngine_BACKUP_ENGINE_POSTGRES BackupEngine = 1
+	// MySQL or MariaDB: a gzipped logical SQL dump (mariadb-dump or
+	// mysqldump, whichever the container has), restored with the client.
+	BackupEngine_BACKUP_ENGINE_MYSQL BackupEngine = 2
+	// Redis: an RDB snapshot taken with BGSAVE. An RDB holds the whole
+	// instance, so a Redis backup's database is always "redis" and a
+	// restore replaces the entire dataset (clean must be set).
+	BackupEngine_BACKUP_ENGINE_REDIS BackupEngine = 3
 )
 
 // Enum value maps for BackupEngine.
@@ -105,10 +122,14 @@ var (
 	BackupEngine_name = map[int32]string{
 		0: "BACKUP_ENGINE_UNSPECIFIED",
 		1: "BACKUP_ENGINE_POSTGRES",
+		2: "BACKUP_ENGINE_MYSQL",
+		3: "BACKUP_ENGINE_REDIS",
 	}
 	BackupEngine_value = map[string]int32{
 		"BACKUP_ENGINE_UNSPECIFIED": 0,
 		"BACKUP_ENGINE_POSTGRES":    1,
+		"BACKUP_ENGINE_MYSQL":       2,
+		"BACKUP_ENGINE_REDIS":       3,
 	}
 )
 
@@ -218,7 +239,7 @@ type BackupRecord struct {
 	// Where the dump is stored.
 	Destination BackupDestination `protobuf:"varint,7,opt,name=destination,proto3,enum=containarium.v1.BackupDestination" json:"destination,omitempty"`
 	// Concrete location of the dump: a host path for LOCAL, a gs:// URI
-	// for GCS.
+	// for GCS, an s3:// URI for S3.
 	Location string `protobuf:"bytes,8,opt,name=location,proto3" json:"location,omitempty"`
 	// Database engine the dump was taken with.
 	//
@@ -232,13 +253,22 @@ type BackupRecord struct {
 	LastVerification *BackupVerification `protobuf:"bytes,10,opt,name=last_verification,json=lastVerification,proto3" json:"last_verification,omitempty"`
 	// Number of user relations (tables and partitioned tables, excluding
 	// system catalogs) in the source database at dump time — the manifest
-	// a restore test compares the restored schema against.
+	// a restore test compares the restored schema against. For MySQL it is
+	// the base-table count; for Redis the key count, which drifts between
+	// snapshot and count and so is only checked for "nothing restored".
 	//
 	// Optional because it is absent on backups taken before verification
 	// existed, and because a source that cannot be queried still produces
 	// a valid dump. Unset means "no manifest": verification records what
 	// it found but has nothing to compare it to.
 	RelationCount *int64 `protobuf:"varint,11,opt,name=relation_count,json=relationCount,proto3,oneof" json:"relation_count,omitempty"`
+	// True when the backup was taken by a BackupSchedule rather than on
+	// demand. Only scheduled backups are pruned by a schedule's retention.
+	Scheduled bool `protobuf:"varint,12,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// How the archive is encrypted; unset for a plaintext archive (taken
+	// while no KMS was configured). When set, size_bytes and sha256
+	// describe the encrypted bytes as stored.
+	Encryption    *BackupEncryption `protobuf:"bytes,13,opt,name=encryption,proto3" json:"encryption,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -350,6 +380,80 @@ func (x *BackupRecord) GetRelationCount() int64 {
 	return 0
 }
 
+func (x *BackupRecord) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *BackupRecord) GetEncryption() *BackupEncryption {
+	if x != nil {
+		return x.Encryption
+	}
+	return nil
+}
+
+// BackupEncryption describes a client-side encrypted archive. The daemon
+// encrypts each archive on the host, before it is staged or uploaded,
+// under a per-backup data key wrapped by the configured KMS. The wrapped
+// key itself stays in the daemon's index and is not exposed here.
+type BackupEncryption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Archive format, e.g. "aes-256-gcm-stream-v1".
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// The KMS key the data key is wrapped under (provider-specific: a
+	// Cloud KMS resource name, an AWS key ARN, a Vault Transit key). The
+	// backup can only be restored by a daemon with access to this key.
+	KekId         string `protobuf:"bytes,2,opt,name=kek_id,json=kekId,proto3" json:"kek_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupEncryption) Reset() {
+	*x = BackupEncryption{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupEncryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupEncryption) ProtoMessage() {}
+
+func (x *BackupEncryption) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupEncryption.ProtoReflect.Descriptor instead.
+func (*BackupEncryption) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BackupEncryption) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *BackupEncryption) GetKekId() string {
+	if x != nil {
+		return x.KekId
+	}
+	return ""
+}
+
 // VerificationCheck is one engine-appropriate assertion made during a
 // restore test, recorded individually so the evidence shows *what* was
 // checked rather than just a pass/fail bit.
@@ -368,7 +472,7 @@ type VerificationCheck struct {
 
 func (x *VerificationCheck) Reset() {
 	*x = VerificationCheck{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[1]
+	mi := &file_containarium_v1_backup_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -380,7 +484,7 @@ func (x *VerificationCheck) String() string {
 func (*VerificationCheck) ProtoMessage() {}
 
 func (x *VerificationCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[1]
+	mi := &file_containarium_v1_backup_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,7 +497,7 @@ func (x *VerificationCheck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerificationCheck.ProtoReflect.Descriptor instead.
 func (*VerificationCheck) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{1}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *VerificationCheck) GetName() string {
@@ -452,7 +556,7 @@ type BackupVerification struct {
 
 func (x *BackupVerification) Reset() {
 	*x = BackupVerification{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[2]
+	mi := &file_containarium_v1_backup_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -464,7 +568,7 @@ func (x *BackupVerification) String() string {
 func (*BackupVerification) ProtoMessage() {}
 
 func (x *BackupVerification) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[2]
+	mi := &file_containarium_v1_backup_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +581,7 @@ func (x *BackupVerification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackupVerification.ProtoReflect.Descriptor instead.
 func (*BackupVerification) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{2}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BackupVerification) GetVerifiedAt() string {
@@ -536,10 +640,13 @@ func (x *BackupVerification) GetChecks() []*VerificationCheck {
 	return nil
 }
 
-// PgConnection carries the connection parameters pg_dump / pg_restore use
-// *inside the container*. Defaults target a per-container local Postgres
-// reached over loopback. db_password is never logged and is passed to the
-// child process via the PGPASSWORD environment variable, not argv.
+// PgConnection carries the connection parameters the dump and restore
+// tools use *inside the container*. Named for Postgres, the first engine;
+// the same fields serve every BackupEngine. Defaults target a
+// per-container local server reached over loopback on the engine's
+// standard port. The password is never logged and is passed to the child
+// process through the environment (PGPASSWORD, MYSQL_PWD, REDISCLI_AUTH),
+// not argv.
 type PgConnection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Logical database to dump or restore into. For CreateBackup, leave
@@ -551,14 +658,16 @@ type PgConnection struct {
 	// (restore in place) — always required in practice there since a
 	// record always names exactly one database.
 	Database string `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
-	// Postgres role. Defaults to "postgres" when empty.
+	// Role or user. Defaults to "postgres" for Postgres and "root" for
+	// MySQL; for Redis, an ACL user (empty means the default user).
 	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
 	// Password for the role. Optional (empty for trust/peer auth or when a
 	// PGPASSFILE is configured in the container). Never logged.
 	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 	// Host as seen from *inside* the container. Defaults to "127.0.0.1".
 	Host string `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
-	// Port. Defaults to 5432 when zero.
+	// Port. Defaults to the engine's standard port (5432, 3306, 6379) when
+	// zero.
 	Port          int32 `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -566,7 +675,7 @@ type PgConnection struct {
 
 func (x *PgConnection) Reset() {
 	*x = PgConnection{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[3]
+	mi := &file_containarium_v1_backup_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +687,7 @@ func (x *PgConnection) String() string {
 func (*PgConnection) ProtoMessage() {}
 
 func (x *PgConnection) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[3]
+	mi := &file_containarium_v1_backup_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +700,7 @@ func (x *PgConnection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PgConnection.ProtoReflect.Descriptor instead.
 func (*PgConnection) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{3}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PgConnection) GetDatabase() string {
@@ -635,22 +744,29 @@ type CreateBackupRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Tenant (username) whose container holds the database.
 	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// Connection parameters for pg_dump inside the container. Leave
+	// Connection parameters for the dump tool inside the container. Leave
 	// connection.database empty to back up every non-template database
-	// found (#954, the default); set it to back up just that one.
+	// found (#954, the default); set it to back up just that one. Redis
+	// has exactly one dataset, so leave it empty there.
 	Connection *PgConnection `protobuf:"bytes,2,opt,name=connection,proto3" json:"connection,omitempty"`
 	// Where to store the dump.
 	Destination BackupDestination `protobuf:"varint,3,opt,name=destination,proto3,enum=containarium.v1.BackupDestination" json:"destination,omitempty"`
 	// For GCS: the destination bucket/prefix, e.g. "gs://my-backups/pg".
 	// Ignored for LOCAL. The object key is appended as "<id>.dump".
-	GcsBucket     string `protobuf:"bytes,4,opt,name=gcs_bucket,json=gcsBucket,proto3" json:"gcs_bucket,omitempty"`
+	GcsBucket string `protobuf:"bytes,4,opt,name=gcs_bucket,json=gcsBucket,proto3" json:"gcs_bucket,omitempty"`
+	// For S3: the destination bucket/prefix, e.g. "s3://my-backups/pg".
+	// Ignored for other destinations. The object key is appended as
+	// "<id>.dump".
+	S3Bucket string `protobuf:"bytes,5,opt,name=s3_bucket,json=s3Bucket,proto3" json:"s3_bucket,omitempty"`
+	// Database engine to dump. UNSPECIFIED means Postgres.
+	Engine        BackupEngine `protobuf:"varint,6,opt,name=engine,proto3,enum=containarium.v1.BackupEngine" json:"engine,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateBackupRequest) Reset() {
 	*x = CreateBackupRequest{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[4]
+	mi := &file_containarium_v1_backup_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -662,7 +778,7 @@ func (x *CreateBackupRequest) String() string {
 func (*CreateBackupRequest) ProtoMessage() {}
 
 func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[4]
+	mi := &file_containarium_v1_backup_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,7 +791,7 @@ func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBackupRequest.ProtoReflect.Descriptor instead.
 func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{4}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateBackupRequest) GetUsername() string {
@@ -706,6 +822,20 @@ func (x *CreateBackupRequest) GetGcsBucket() string {
 	return ""
 }
 
+func (x *CreateBackupRequest) GetS3Bucket() string {
+	if x != nil {
+		return x.S3Bucket
+	}
+	return ""
+}
+
+func (x *CreateBackupRequest) GetEngine() BackupEngine {
+	if x != nil {
+		return x.Engine
+	}
+	return BackupEngine_BACKUP_ENGINE_UNSPECIFIED
+}
+
 type CreateBackupResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Operator-facing summary.
@@ -731,7 +861,7 @@ type CreateBackupResponse struct {
 
 func (x *CreateBackupResponse) Reset() {
 	*x = CreateBackupResponse{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[5]
+	mi := &file_containarium_v1_backup_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -743,7 +873,7 @@ func (x *CreateBackupResponse) String() string {
 func (*CreateBackupResponse) ProtoMessage() {}
 
 func (x *CreateBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[5]
+	mi := &file_containarium_v1_backup_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,7 +886,7 @@ func (x *CreateBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBackupResponse.ProtoReflect.Descriptor instead.
 func (*CreateBackupResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{5}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateBackupResponse) GetMessage() string {
@@ -799,7 +929,7 @@ type ListBackupsRequest struct {
 
 func (x *ListBackupsRequest) Reset() {
 	*x = ListBackupsRequest{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[6]
+	mi := &file_containarium_v1_backup_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -811,7 +941,7 @@ func (x *ListBackupsRequest) String() string {
 func (*ListBackupsRequest) ProtoMessage() {}
 
 func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[6]
+	mi := &file_containarium_v1_backup_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +954,7 @@ func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
 func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{6}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListBackupsRequest) GetUsername() string {
@@ -843,7 +973,7 @@ type ListBackupsResponse struct {
 
 func (x *ListBackupsResponse) Reset() {
 	*x = ListBackupsResponse{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[7]
+	mi := &file_containarium_v1_backup_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -855,7 +985,7 @@ func (x *ListBackupsResponse) String() string {
 func (*ListBackupsResponse) ProtoMessage() {}
 
 func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[7]
+	mi := &file_containarium_v1_backup_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,7 +998,7 @@ func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
 func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{7}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListBackupsResponse) GetRecords() []*BackupRecord {
@@ -888,7 +1018,7 @@ type GetBackupRequest struct {
 
 func (x *GetBackupRequest) Reset() {
 	*x = GetBackupRequest{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[8]
+	mi := &file_containarium_v1_backup_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -900,7 +1030,7 @@ func (x *GetBackupRequest) String() string {
 func (*GetBackupRequest) ProtoMessage() {}
 
 func (x *GetBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[8]
+	mi := &file_containarium_v1_backup_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,7 +1043,7 @@ func (x *GetBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBackupRequest.ProtoReflect.Descriptor instead.
 func (*GetBackupRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{8}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetBackupRequest) GetId() string {
@@ -932,7 +1062,7 @@ type GetBackupResponse struct {
 
 func (x *GetBackupResponse) Reset() {
 	*x = GetBackupResponse{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[9]
+	mi := &file_containarium_v1_backup_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -944,7 +1074,7 @@ func (x *GetBackupResponse) String() string {
 func (*GetBackupResponse) ProtoMessage() {}
 
 func (x *GetBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[9]
+	mi := &file_containarium_v1_backup_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,7 +1087,7 @@ func (x *GetBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBackupResponse.ProtoReflect.Descriptor instead.
 func (*GetBackupResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{9}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetBackupResponse) GetRecord() *BackupRecord {
@@ -988,7 +1118,7 @@ type RestoreBackupRequest struct {
 
 func (x *RestoreBackupRequest) Reset() {
 	*x = RestoreBackupRequest{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[10]
+	mi := &file_containarium_v1_backup_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1000,7 +1130,7 @@ func (x *RestoreBackupRequest) String() string {
 func (*RestoreBackupRequest) ProtoMessage() {}
 
 func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[10]
+	mi := &file_containarium_v1_backup_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1013,7 +1143,7 @@ func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestoreBackupRequest.ProtoReflect.Descriptor instead.
 func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{10}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RestoreBackupRequest) GetId() string {
@@ -1046,7 +1176,7 @@ type RestoreBackupResponse struct {
 
 func (x *RestoreBackupResponse) Reset() {
 	*x = RestoreBackupResponse{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[11]
+	mi := &file_containarium_v1_backup_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1058,7 +1188,7 @@ func (x *RestoreBackupResponse) String() string {
 func (*RestoreBackupResponse) ProtoMessage() {}
 
 func (x *RestoreBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[11]
+	mi := &file_containarium_v1_backup_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +1201,7 @@ func (x *RestoreBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestoreBackupResponse.ProtoReflect.Descriptor instead.
 func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{11}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RestoreBackupResponse) GetMessage() string {
@@ -1106,7 +1236,7 @@ type VerifyBackupRequest struct {
 
 func (x *VerifyBackupRequest) Reset() {
 	*x = VerifyBackupRequest{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[12]
+	mi := &file_containarium_v1_backup_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1118,7 +1248,7 @@ func (x *VerifyBackupRequest) String() string {
 func (*VerifyBackupRequest) ProtoMessage() {}
 
 func (x *VerifyBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[12]
+	mi := &file_containarium_v1_backup_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1131,7 +1261,7 @@ func (x *VerifyBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyBackupRequest.ProtoReflect.Descriptor instead.
 func (*VerifyBackupRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{12}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *VerifyBackupRequest) GetId() string {
@@ -1170,7 +1300,7 @@ type VerifyBackupResponse struct {
 
 func (x *VerifyBackupResponse) Reset() {
 	*x = VerifyBackupResponse{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[13]
+	mi := &file_containarium_v1_backup_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1182,7 +1312,7 @@ func (x *VerifyBackupResponse) String() string {
 func (*VerifyBackupResponse) ProtoMessage() {}
 
 func (x *VerifyBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[13]
+	mi := &file_containarium_v1_backup_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1195,7 +1325,7 @@ func (x *VerifyBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyBackupResponse.ProtoReflect.Descriptor instead.
 func (*VerifyBackupResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{13}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *VerifyBackupResponse) GetMessage() string {
@@ -1229,7 +1359,7 @@ type DeleteBackupRequest struct {
 
 func (x *DeleteBackupRequest) Reset() {
 	*x = DeleteBackupRequest{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[14]
+	mi := &file_containarium_v1_backup_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1241,7 +1371,7 @@ func (x *DeleteBackupRequest) String() string {
 func (*DeleteBackupRequest) ProtoMessage() {}
 
 func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[14]
+	mi := &file_containarium_v1_backup_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1254,7 +1384,7 @@ func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{14}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteBackupRequest) GetId() string {
@@ -1273,7 +1403,7 @@ type DeleteBackupResponse struct {
 
 func (x *DeleteBackupResponse) Reset() {
 	*x = DeleteBackupResponse{}
-	mi := &file_containarium_v1_backup_proto_msgTypes[15]
+	mi := &file_containarium_v1_backup_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1285,7 +1415,7 @@ func (x *DeleteBackupResponse) String() string {
 func (*DeleteBackupResponse) ProtoMessage() {}
 
 func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_backup_proto_msgTypes[15]
+	mi := &file_containarium_v1_backup_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +1428,7 @@ func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBackupResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{15}
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteBackupResponse) GetMessage() string {
@@ -1308,103 +1438,836 @@ func (x *DeleteBackupResponse) GetMessage() string {
 	return ""
 }
 
-var File_containarium_v1_backup_proto protoreflect.FileDescriptor
+// BackupRetention is a grandfather-father-son policy: keep the newest
+// backup of each of the last `daily` days, `weekly` ISO weeks and
+// `monthly` months that have one. Counted per database over scheduled
+// backups only; on-demand backups are never pruned. All zero keeps
+// everything.
+type BackupRetention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Daily         int32                  `protobuf:"varint,1,opt,name=daily,proto3" json:"daily,omitempty"`
+	Weekly        int32                  `protobuf:"varint,2,opt,name=weekly,proto3" json:"weekly,omitempty"`
+	Monthly       int32                  `protobuf:"varint,3,opt,name=monthly,proto3" json:"monthly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_containarium_v1_backup_proto_rawDesc = "" +
-	"\n" +
-	"\x1ccontainarium/v1/backup.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xd6\x03\n" +
-	"\fBackupRecord\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
-	"\bdatabase\x18\x03 \x01(\tR\bdatabase\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x16\n" +
-	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12D\n" +
-	"\vdestination\x18\a \x01(\x0e2\".containarium.v1.BackupDestinationR\vdestination\x12\x1a\n" +
-	"\blocation\x18\b \x01(\tR\blocation\x125\n" +
-	"\x06engine\x18\t \x01(\x0e2\x1d.containarium.v1.BackupEngineR\x06engine\x12P\n" +
-	"\x11last_verification\x18\n" +
-	" \x01(\v2#.containarium.v1.BackupVerificationR\x10lastVerification\x12*\n" +
-	"\x0erelation_count\x18\v \x01(\x03H\x00R\rrelationCount\x88\x01\x01B\x11\n" +
-	"\x0f_relation_count\"W\n" +
-	"\x11VerificationCheck\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
-	"\x06passed\x18\x02 \x01(\bR\x06passed\x12\x16\n" +
-	"\x06detail\x18\x03 \x01(\tR\x06detail\"\xdc\x02\n" +
-	"\x12BackupVerification\x12\x1f\n" +
-	"\vverified_at\x18\x01 \x01(\tR\n" +
-	"verifiedAt\x12;\n" +
-	"\x06result\x18\x02 \x01(\x0e2#.containarium.v1.VerificationResultR\x06result\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\x12)\n" +
-	"\x10target_container\x18\x04 \x01(\tR\x0ftargetContainer\x12)\n" +
-	"\x10scratch_database\x18\x05 \x01(\tR\x0fscratchDatabase\x12\x1f\n" +
-	"\vduration_ms\x18\x06 \x01(\x03R\n" +
-	"durationMs\x12\x1f\n" +
-	"\vverified_by\x18\a \x01(\tR\n" +
-	"verifiedBy\x12:\n" +
-	"\x06checks\x18\b \x03(\v2\".containarium.v1.VerificationCheckR\x06checks\"\x82\x01\n" +
-	"\fPgConnection\x12\x1a\n" +
-	"\bdatabase\x18\x01 \x01(\tR\bdatabase\x12\x12\n" +
-	"\x04user\x18\x02 \x01(\tR\x04user\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x12\n" +
-	"\x04host\x18\x04 \x01(\tR\x04host\x12\x12\n" +
-	"\x04port\x18\x05 \x01(\x05R\x04port\"\xd5\x01\n" +
-	"\x13CreateBackupRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\x12=\n" +
-	"\n" +
-	"connection\x18\x02 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
-	"connection\x12D\n" +
-	"\vdestination\x18\x03 \x01(\x0e2\".containarium.v1.BackupDestinationR\vdestination\x12\x1d\n" +
-	"\n" +
-	"gcs_bucket\x18\x04 \x01(\tR\tgcsBucket\"\xbc\x01\n" +
-	"\x14CreateBackupResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x125\n" +
-	"\x06record\x18\x02 \x01(\v2\x1d.containarium.v1.BackupRecordR\x06record\x127\n" +
-	"\arecords\x18\x03 \x03(\v2\x1d.containarium.v1.BackupRecordR\arecords\x12\x1a\n" +
-	"\bfailures\x18\x04 \x03(\tR\bfailures\"0\n" +
-	"\x12ListBackupsRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"N\n" +
-	"\x13ListBackupsResponse\x127\n" +
-	"\arecords\x18\x01 \x03(\v2\x1d.containarium.v1.BackupRecordR\arecords\"\"\n" +
-	"\x10GetBackupRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
-	"\x11GetBackupResponse\x125\n" +
-	"\x06record\x18\x01 \x01(\v2\x1d.containarium.v1.BackupRecordR\x06record\"{\n" +
-	"\x14RestoreBackupRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
-	"\n" +
-	"connection\x18\x02 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
-	"connection\x12\x14\n" +
-	"\x05clean\x18\x03 \x01(\bR\x05clean\"1\n" +
-	"\x15RestoreBackupResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\x8d\x01\n" +
-	"\x13VerifyBackupRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
-	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12=\n" +
-	"\n" +
-	"connection\x18\x03 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
-	"connection\"\xb0\x01\n" +
-	"\x14VerifyBackupResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12G\n" +
-	"\fverification\x18\x02 \x01(\v2#.containarium.v1.BackupVerificationR\fverification\x125\n" +
-	"\x06record\x18\x03 \x01(\v2\x1d.containarium.v1.BackupRecordR\x06record\"%\n" +
-	"\x13DeleteBackupRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
-	"\x14DeleteBackupResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage*q\n" +
+func (x *BackupRetention) Reset() {
+	*x = BackupRetention{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupRetention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRetention) ProtoMessage() {}
+
+func (x *BackupRetention) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRetention.ProtoReflect.Descriptor instead.
+func (*BackupRetention) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BackupRetention) GetDaily() int32 {
+	if x != nil {
+		return x.Daily
+	}
+	return 0
+}
+
+func (x *BackupRetention) GetWeekly() int32 {
+	if x != nil {
+		return x.Weekly
+	}
+	return 0
+}
+
+func (x *BackupRetention) GetMonthly() int32 {
+	if x != nil {
+		return x.Monthly
+	}
+	return 0
+}
+
+// BackupScheduleRun is the outcome of one scheduled run.
+type BackupScheduleRun struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC3339 UTC timestamps bracketing the run.
+	StartedAt  string `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt string `protobuf:"bytes,2,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// Backups the run created.
+	BackupIds []string `protobuf:"bytes,3,rep,name=backup_ids,json=backupIds,proto3" json:"backup_ids,omitempty"`
+	// Backups retention deleted after the run.
+	PrunedIds []string `protobuf:"bytes,4,rep,name=pruned_ids,json=prunedIds,proto3" json:"pruned_ids,omitempty"`
+	// Dump, upload and prune errors, one per failed item.
+	Failures []string `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`
+	// Backups from this run whose restore test failed or could not run.
+	VerificationFailures []string `protobuf:"bytes,6,rep,name=verification_failures,json=verificationFailures,proto3" json:"verification_failures,omitempty"`
+	// True when failures and verification_failures are both empty.
+	Ok            bool `protobuf:"varint,7,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupScheduleRun) Reset() {
+	*x = BackupScheduleRun{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupScheduleRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupScheduleRun) ProtoMessage() {}
+
+func (x *BackupScheduleRun) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupScheduleRun.ProtoReflect.Descriptor instead.
+func (*BackupScheduleRun) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BackupScheduleRun) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *BackupScheduleRun) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *BackupScheduleRun) GetBackupIds() []string {
+	if x != nil {
+		return x.BackupIds
+	}
+	return nil
+}
+
+func (x *BackupScheduleRun) GetPrunedIds() []string {
+	if x != nil {
+		return x.PrunedIds
+	}
+	return nil
+}
+
+func (x *BackupScheduleRun) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *BackupScheduleRun) GetVerificationFailures() []string {
+	if x != nil {
+		return x.VerificationFailures
+	}
+	return nil
+}
+
+func (x *BackupScheduleRun) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+// BackupSchedule is a tenant's recurring backup. One per tenant
+// container, persisted by the daemon.
+type BackupSchedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant whose container's databases are backed up.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Five-field cron expression, evaluated in UTC, e.g. "30 2 * * *".
+	// The @hourly/@daily/@weekly/@monthly macros are accepted.
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Databases to dump each run. Empty backs up every non-template
+	// database found at run time.
+	Databases []string `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	// Connection parameters inside the container. password must be empty:
+	// schedules are persisted on the host, so scheduled dumps rely on
+	// trust/peer auth or a credentials file (PGPASSFILE, MySQL option
+	// file) inside the container.
+	Connection *PgConnection `protobuf:"bytes,4,opt,name=connection,proto3" json:"connection,omitempty"`
+	// Where each run stores its dumps.
+	Destination BackupDestination `protobuf:"varint,5,opt,name=destination,proto3,enum=containarium.v1.BackupDestination" json:"destination,omitempty"`
+	GcsBucket   string            `protobuf:"bytes,6,opt,name=gcs_bucket,json=gcsBucket,proto3" json:"gcs_bucket,omitempty"`
+	S3Bucket    string            `protobuf:"bytes,7,opt,name=s3_bucket,json=s3Bucket,proto3" json:"s3_bucket,omitempty"`
+	// Retention applied after each run that produced a backup.
+	Retention *BackupRetention `protobuf:"bytes,8,opt,name=retention,proto3" json:"retention,omitempty"`
+	// When set, every new backup is restore-tested in this tenant's
+	// container after the run. Must differ from username.
+	VerifyTargetUsername string `protobuf:"bytes,9,opt,name=verify_target_username,json=verifyTargetUsername,proto3" json:"verify_target_username,omitempty"`
+	// Output only: RFC3339 UTC time the schedule was last set, and when it
+	// next fires.
+	UpdatedAt string `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	NextRunAt string `protobuf:"bytes,11,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	// Output only: the most recent run, unset until one has happened.
+	LastRun *BackupScheduleRun `protobuf:"bytes,12,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	// Database engine to dump. UNSPECIFIED means Postgres.
+	Engine        BackupEngine `protobuf:"varint,13,opt,name=engine,proto3,enum=containarium.v1.BackupEngine" json:"engine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupSchedule) Reset() {
+	*x = BackupSchedule{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSchedule) ProtoMessage() {}
+
+func (x *BackupSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSchedule.ProtoReflect.Descriptor instead.
+func (*BackupSchedule) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BackupSchedule) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetDatabases() []string {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetConnection() *PgConnection {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetDestination() BackupDestination {
+	if x != nil {
+		return x.Destination
+	}
+	return BackupDestination_BACKUP_DESTINATION_UNSPECIFIED
+}
+
+func (x *BackupSchedule) GetGcsBucket() string {
+	if x != nil {
+		return x.GcsBucket
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetS3Bucket() string {
+	if x != nil {
+		return x.S3Bucket
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetRetention() *BackupRetention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetVerifyTargetUsername() string {
+	if x != nil {
+		return x.VerifyTargetUsername
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *BackupSchedule) GetLastRun() *BackupScheduleRun {
+	if x != nil {
+		return x.LastRun
+	}
+	return nil
+}
+
+func (x *BackupSchedule) GetEngine() BackupEngine {
+	if x != nil {
+		return x.Engine
+	}
+	return BackupEngine_BACKUP_ENGINE_UNSPECIFIED
+}
+
+// SetBackupScheduleRequest creates or replaces a tenant's schedule.
+type SetBackupScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *BackupSchedule        `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBackupScheduleRequest) Reset() {
+	*x = SetBackupScheduleRequest{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBackupScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBackupScheduleRequest) ProtoMessage() {}
+
+func (x *SetBackupScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBackupScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetBackupScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetBackupScheduleRequest) GetSchedule() *BackupSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type SetBackupScheduleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stored schedule, with next_run_at filled in.
+	Schedule      *BackupSchedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBackupScheduleResponse) Reset() {
+	*x = SetBackupScheduleResponse{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBackupScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBackupScheduleResponse) ProtoMessage() {}
+
+func (x *SetBackupScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBackupScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SetBackupScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetBackupScheduleResponse) GetSchedule() *BackupSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type GetBackupScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupScheduleRequest) Reset() {
+	*x = GetBackupScheduleRequest{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupScheduleRequest) ProtoMessage() {}
+
+func (x *GetBackupScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetBackupScheduleRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetBackupScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *BackupSchedule        `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupScheduleResponse) Reset() {
+	*x = GetBackupScheduleResponse{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupScheduleResponse) ProtoMessage() {}
+
+func (x *GetBackupScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetBackupScheduleResponse) GetSchedule() *BackupSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type ListBackupSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupSchedulesRequest) Reset() {
+	*x = ListBackupSchedulesRequest{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupSchedulesRequest) ProtoMessage() {}
+
+func (x *ListBackupSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListBackupSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{24}
+}
+
+type ListBackupSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*BackupSchedule      `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupSchedulesResponse) Reset() {
+	*x = ListBackupSchedulesResponse{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupSchedulesResponse) ProtoMessage() {}
+
+func (x *ListBackupSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListBackupSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListBackupSchedulesResponse) GetSchedules() []*BackupSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+// DeleteBackupScheduleRequest removes a tenant's schedule. Backups it
+// already took are kept.
+type DeleteBackupScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupScheduleRequest) Reset() {
+	*x = DeleteBackupScheduleRequest{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteBackupScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBackupScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteBackupScheduleRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type DeleteBackupScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupScheduleResponse) Reset() {
+	*x = DeleteBackupScheduleResponse{}
+	mi := &file_containarium_v1_backup_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteBackupScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_backup_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBackupScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_backup_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteBackupScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_containarium_v1_backup_proto protoreflect.FileDescriptor
+
+const file_containarium_v1_backup_proto_rawDesc = "" +
+	"\n" +
+	"\x1ccontainarium/v1/backup.proto\x12\x0fcontainarium.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xb7\x04\n" +
+	"\fBackupRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bdatabase\x18\x03 \x01(\tR\bdatabase\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12D\n" +
+	"\vdestination\x18\a \x01(\x0e2\".containarium.v1.BackupDestinationR\vdestination\x12\x1a\n" +
+	"\blocation\x18\b \x01(\tR\blocation\x125\n" +
+	"\x06engine\x18\t \x01(\x0e2\x1d.containarium.v1.BackupEngineR\x06engine\x12P\n" +
+	"\x11last_verification\x18\n" +
+	" \x01(\v2#.containarium.v1.BackupVerificationR\x10lastVerification\x12*\n" +
+	"\x0erelation_count\x18\v \x01(\x03H\x00R\rrelationCount\x88\x01\x01\x12\x1c\n" +
+	"\tscheduled\x18\f \x01(\bR\tscheduled\x12A\n" +
+	"\n" +
+	"encryption\x18\r \x01(\v2!.containarium.v1.BackupEncryptionR\n" +
+	"encryptionB\x11\n" +
+	"\x0f_relation_count\"G\n" +
+	"\x10BackupEncryption\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x15\n" +
+	"\x06kek_id\x18\x02 \x01(\tR\x05kekId\"W\n" +
+	"\x11VerificationCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\bR\x06passed\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\xdc\x02\n" +
+	"\x12BackupVerification\x12\x1f\n" +
+	"\vverified_at\x18\x01 \x01(\tR\n" +
+	"verifiedAt\x12;\n" +
+	"\x06result\x18\x02 \x01(\x0e2#.containarium.v1.VerificationResultR\x06result\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12)\n" +
+	"\x10target_container\x18\x04 \x01(\tR\x0ftargetContainer\x12)\n" +
+	"\x10scratch_database\x18\x05 \x01(\tR\x0fscratchDatabase\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\x12\x1f\n" +
+	"\vverified_by\x18\a \x01(\tR\n" +
+	"verifiedBy\x12:\n" +
+	"\x06checks\x18\b \x03(\v2\".containarium.v1.VerificationCheckR\x06checks\"\x82\x01\n" +
+	"\fPgConnection\x12\x1a\n" +
+	"\bdatabase\x18\x01 \x01(\tR\bdatabase\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04host\x18\x04 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x05 \x01(\x05R\x04port\"\xa9\x02\n" +
+	"\x13CreateBackupRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12=\n" +
+	"\n" +
+	"connection\x18\x02 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
+	"connection\x12D\n" +
+	"\vdestination\x18\x03 \x01(\x0e2\".containarium.v1.BackupDestinationR\vdestination\x12\x1d\n" +
+	"\n" +
+	"gcs_bucket\x18\x04 \x01(\tR\tgcsBucket\x12\x1b\n" +
+	"\ts3_bucket\x18\x05 \x01(\tR\bs3Bucket\x125\n" +
+	"\x06engine\x18\x06 \x01(\x0e2\x1d.containarium.v1.BackupEngineR\x06engine\"\xbc\x01\n" +
+	"\x14CreateBackupResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x125\n" +
+	"\x06record\x18\x02 \x01(\v2\x1d.containarium.v1.BackupRecordR\x06record\x127\n" +
+	"\arecords\x18\x03 \x03(\v2\x1d.containarium.v1.BackupRecordR\arecords\x12\x1a\n" +
+	"\bfailures\x18\x04 \x03(\tR\bfailures\"0\n" +
+	"\x12ListBackupsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"N\n" +
+	"\x13ListBackupsResponse\x127\n" +
+	"\arecords\x18\x01 \x03(\v2\x1d.containarium.v1.BackupRecordR\arecords\"\"\n" +
+	"\x10GetBackupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
+	"\x11GetBackupResponse\x125\n" +
+	"\x06record\x18\x01 \x01(\v2\x1d.containarium.v1.BackupRecordR\x06record\"{\n" +
+	"\x14RestoreBackupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\n" +
+	"connection\x18\x02 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
+	"connection\x12\x14\n" +
+	"\x05clean\x18\x03 \x01(\bR\x05clean\"1\n" +
+	"\x15RestoreBackupResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x8d\x01\n" +
+	"\x13VerifyBackupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12=\n" +
+	"\n" +
+	"connection\x18\x03 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
+	"connection\"\xb0\x01\n" +
+	"\x14VerifyBackupResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12G\n" +
+	"\fverification\x18\x02 \x01(\v2#.containarium.v1.BackupVerificationR\fverification\x125\n" +
+	"\x06record\x18\x03 \x01(\v2\x1d.containarium.v1.BackupRecordR\x06record\"%\n" +
+	"\x13DeleteBackupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x14DeleteBackupResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"Y\n" +
+	"\x0fBackupRetention\x12\x14\n" +
+	"\x05daily\x18\x01 \x01(\x05R\x05daily\x12\x16\n" +
+	"\x06weekly\x18\x02 \x01(\x05R\x06weekly\x12\x18\n" +
+	"\amonthly\x18\x03 \x01(\x05R\amonthly\"\xf2\x01\n" +
+	"\x11BackupScheduleRun\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x02 \x01(\tR\n" +
+	"finishedAt\x12\x1d\n" +
+	"\n" +
+	"backup_ids\x18\x03 \x03(\tR\tbackupIds\x12\x1d\n" +
+	"\n" +
+	"pruned_ids\x18\x04 \x03(\tR\tprunedIds\x12\x1a\n" +
+	"\bfailures\x18\x05 \x03(\tR\bfailures\x123\n" +
+	"\x15verification_failures\x18\x06 \x03(\tR\x14verificationFailures\x12\x0e\n" +
+	"\x02ok\x18\a \x01(\bR\x02ok\"\xca\x04\n" +
+	"\x0eBackupSchedule\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x12\x1c\n" +
+	"\tdatabases\x18\x03 \x03(\tR\tdatabases\x12=\n" +
+	"\n" +
+	"connection\x18\x04 \x01(\v2\x1d.containarium.v1.PgConnectionR\n" +
+	"connection\x12D\n" +
+	"\vdestination\x18\x05 \x01(\x0e2\".containarium.v1.BackupDestinationR\vdestination\x12\x1d\n" +
+	"\n" +
+	"gcs_bucket\x18\x06 \x01(\tR\tgcsBucket\x12\x1b\n" +
+	"\ts3_bucket\x18\a \x01(\tR\bs3Bucket\x12>\n" +
+	"\tretention\x18\b \x01(\v2 .containarium.v1.BackupRetentionR\tretention\x124\n" +
+	"\x16verify_target_username\x18\t \x01(\tR\x14verifyTargetUsername\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\x12\x1e\n" +
+	"\vnext_run_at\x18\v \x01(\tR\tnextRunAt\x12=\n" +
+	"\blast_run\x18\f \x01(\v2\".containarium.v1.BackupScheduleRunR\alastRun\x125\n" +
+	"\x06engine\x18\r \x01(\x0e2\x1d.containarium.v1.BackupEngineR\x06engine\"W\n" +
+	"\x18SetBackupScheduleRequest\x12;\n" +
+	"\bschedule\x18\x01 \x01(\v2\x1f.containarium.v1.BackupScheduleR\bschedule\"X\n" +
+	"\x19SetBackupScheduleResponse\x12;\n" +
+	"\bschedule\x18\x01 \x01(\v2\x1f.containarium.v1.BackupScheduleR\bschedule\"6\n" +
+	"\x18GetBackupScheduleRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"X\n" +
+	"\x19GetBackupScheduleResponse\x12;\n" +
+	"\bschedule\x18\x01 \x01(\v2\x1f.containarium.v1.BackupScheduleR\bschedule\"\x1c\n" +
+	"\x1aListBackupSchedulesRequest\"\\\n" +
+	"\x1bListBackupSchedulesResponse\x12=\n" +
+	"\tschedules\x18\x01 \x03(\v2\x1f.containarium.v1.BackupScheduleR\tschedules\"9\n" +
+	"\x1bDeleteBackupScheduleRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"8\n" +
+	"\x1cDeleteBackupScheduleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage*\x8c\x01\n" +
 	"\x11BackupDestination\x12\"\n" +
 	"\x1eBACKUP_DESTINATION_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18BACKUP_DESTINATION_LOCAL\x10\x01\x12\x1a\n" +
-	"\x16BACKUP_DESTINATION_GCS\x10\x02*I\n" +
+	"\x16BACKUP_DESTINATION_GCS\x10\x02\x12\x19\n" +
+	"\x15BACKUP_DESTINATION_S3\x10\x03*{\n" +
 	"\fBackupEngine\x12\x1d\n" +
 	"\x19BACKUP_ENGINE_UNSPECIFIED\x10\x00\x12\x1a\n" +
-	"\x16BACKUP_ENGINE_POSTGRES\x10\x01*y\n" +
+	"\x16BACKUP_ENGINE_POSTGRES\x10\x01\x12\x17\n" +
+	"\x13BACKUP_ENGINE_MYSQL\x10\x02\x12\x17\n" +
+	"\x13BACKUP_ENGINE_REDIS\x10\x03*y\n" +
 	"\x12VerificationResult\x12#\n" +
 	"\x1fVERIFICATION_RESULT_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aVERIFICATION_RESULT_PASSED\x10\x01\x12\x1e\n" +
-	"\x1aVERIFICATION_RESULT_FAILED\x10\x022\xc0\r\n" +
+	"\x1aVERIFICATION_RESULT_FAILED\x10\x022\xf2\x16\n" +
 	"\rBackupService\x12\xfb\x02\n" +
 	"\fCreateBackup\x12$.containarium.v1.CreateBackupRequest\x1a%.containarium.v1.CreateBackupResponse\"\x9d\x02\x92A\x83\x02\n" +
 	"\aBackups\x12\x0fCreate a backup\x1a\xe6\x01Runs pg_dump inside the tenant's container and stores the dump at the chosen off-host destination (local backup dir or GCS). Leave connection.database empty to back up every non-template database found; set it to back up just one.\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/backups\x12\xd3\x01\n" +
@@ -1415,9 +2278,17 @@ const file_containarium_v1_backup_proto_rawDesc = "" +
 	"\rRestoreBackup\x12%.containarium.v1.RestoreBackupRequest\x1a&.containarium.v1.RestoreBackupResponse\"\xa7\x01\x92A\x80\x01\n" +
 	"\aBackups\x12\x10Restore a backup\x1acStreams a stored dump back into a container's database via pg_restore. Destructive when clean=true.\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/backups/{id}/restore\x12\x9f\x03\n" +
 	"\fVerifyBackup\x12$.containarium.v1.VerifyBackupRequest\x1a%.containarium.v1.VerifyBackupResponse\"\xc1\x02\x92A\x9b\x02\n" +
-	"\aBackups\x12\x0fVerify a backup\x1a\xfe\x01Restore-tests a stored dump by loading it into a throwaway database inside a target container and running sanity checks, then dropping the scratch database. Never touches the source container. Records the outcome on the backup as durable A.8.13 evidence.\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/backups/{id}/verify\x12\xf2\x01\n" +
-	"\fDeleteBackup\x12$.containarium.v1.DeleteBackupRequest\x1a%.containarium.v1.DeleteBackupResponse\"\x94\x01\x92Ay\n" +
-	"\aBackups\x12\x0fDelete a backup\x1a]Deletes a stored dump and its metadata. Retention enforcement is the caller's responsibility.\x82\xd3\xe4\x93\x02\x12*\x10/v1/backups/{id}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+	"\aBackups\x12\x0fVerify a backup\x1a\xfe\x01Restore-tests a stored dump by loading it into a throwaway database inside a target container and running sanity checks, then dropping the scratch database. Never touches the source container. Records the outcome on the backup as durable A.8.13 evidence.\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/backups/{id}/verify\x12\xa8\x02\n" +
+	"\fDeleteBackup\x12$.containarium.v1.DeleteBackupRequest\x1a%.containarium.v1.DeleteBackupResponse\"\xca\x01\x92A\xae\x01\n" +
+	"\aBackups\x12\x0fDelete a backup\x1a\x91\x01Deletes a stored dump and its metadata. Scheduled backups are pruned by their schedule's retention; anything else is the caller's responsibility.\x82\xd3\xe4\x93\x02\x12*\x10/v1/backups/{id}\x12\xd8\x02\n" +
+	"\x11SetBackupSchedule\x12).containarium.v1.SetBackupScheduleRequest\x1a*.containarium.v1.SetBackupScheduleResponse\"\xeb\x01\x92A\xc8\x01\n" +
+	"\aBackups\x12\x15Set a backup schedule\x1a\xa5\x01Creates or replaces a tenant's recurring backup: a cron expression, the databases to dump, a destination, GFS retention, and an optional restore test after each run.\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/backup-schedules\x12\x90\x02\n" +
+	"\x11GetBackupSchedule\x12).containarium.v1.GetBackupScheduleRequest\x1a*.containarium.v1.GetBackupScheduleResponse\"\xa3\x01\x92Ay\n" +
+	"\aBackups\x12\x15Get a backup schedule\x1aWReturns a tenant's backup schedule, when it next runs, and the outcome of its last run.\x82\xd3\xe4\x93\x02!\x12\x1f/v1/backup-schedules/{username}\x12\xff\x01\n" +
+	"\x13ListBackupSchedules\x12+.containarium.v1.ListBackupSchedulesRequest\x1a,.containarium.v1.ListBackupSchedulesResponse\"\x8c\x01\x92Am\n" +
+	"\aBackups\x12\x15List backup schedules\x1aKLists backup schedules. Admins see all tenants; a non-admin sees their own.\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/backup-schedules\x12\x89\x02\n" +
+	"\x14DeleteBackupSchedule\x12,.containarium.v1.DeleteBackupScheduleRequest\x1a-.containarium.v1.DeleteBackupScheduleResponse\"\x93\x01\x92Ai\n" +
+	"\aBackups\x12\x18Delete a backup schedule\x1aDStops a tenant's recurring backup. Backups it already took are kept.\x82\xd3\xe4\x93\x02!*\x1f/v1/backup-schedules/{username}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var (
 	file_containarium_v1_backup_proto_rawDescOnce sync.Once
@@ -1432,61 +2303,92 @@ func file_containarium_v1_backup_proto_rawDescGZIP() []byte {
 }
 
 var file_containarium_v1_backup_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_containarium_v1_backup_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_containarium_v1_backup_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_containarium_v1_backup_proto_goTypes = []any{
-	(BackupDestination)(0),        // 0: containarium.v1.BackupDestination
-	(BackupEngine)(0),             // 1: containarium.v1.BackupEngine
-	(VerificationResult)(0),       // 2: containarium.v1.VerificationResult
-	(*BackupRecord)(nil),          // 3: containarium.v1.BackupRecord
-	(*VerificationCheck)(nil),     // 4: containarium.v1.VerificationCheck
-	(*BackupVerification)(nil),    // 5: containarium.v1.BackupVerification
-	(*PgConnection)(nil),          // 6: containarium.v1.PgConnection
-	(*CreateBackupRequest)(nil),   // 7: containarium.v1.CreateBackupRequest
-	(*CreateBackupResponse)(nil),  // 8: containarium.v1.CreateBackupResponse
-	(*ListBackupsRequest)(nil),    // 9: containarium.v1.ListBackupsRequest
-	(*ListBackupsResponse)(nil),   // 10: containarium.v1.ListBackupsResponse
-	(*GetBackupRequest)(nil),      // 11: containarium.v1.GetBackupRequest
-	(*GetBackupResponse)(nil),     // 12: containarium.v1.GetBackupResponse
-	(*RestoreBackupRequest)(nil),  // 13: containarium.v1.RestoreBackupRequest
-	(*RestoreBackupResponse)(nil), // 14: containarium.v1.RestoreBackupResponse
-	(*VerifyBackupRequest)(nil),   // 15: containarium.v1.VerifyBackupRequest
-	(*VerifyBackupResponse)(nil),  // 16: containarium.v1.VerifyBackupResponse
-	(*DeleteBackupRequest)(nil),   // 17: containarium.v1.DeleteBackupRequest
-	(*DeleteBackupResponse)(nil),  // 18: containarium.v1.DeleteBackupResponse
+	(BackupDestination)(0),               // 0: containarium.v1.BackupDestination
+	(BackupEngine)(0),                    // 1: containarium.v1.BackupEngine
+	(VerificationResult)(0),              // 2: containarium.v1.VerificationResult
+	(*BackupRecord)(nil),                 // 3: containarium.v1.BackupRecord
+	(*BackupEncryption)(nil),             // 4: containarium.v1.BackupEncryption
+	(*VerificationCheck)(nil),            // 5: containarium.v1.VerificationCheck
+	(*BackupVerification)(nil),           // 6: containarium.v1.BackupVerification
+	(*PgConnection)(nil),                 // 7: containarium.v1.PgConnection
+	(*CreateBackupRequest)(nil),          // 8: containarium.v1.CreateBackupRequest
+	(*CreateBackupResponse)(nil),         // 9: containarium.v1.CreateBackupResponse
+	(*ListBackupsRequest)(nil),           // 10: containarium.v1.ListBackupsRequest
+	(*ListBackupsResponse)(nil),          // 11: containarium.v1.ListBackupsResponse
+	(*GetBackupRequest)(nil),             // 12: containarium.v1.GetBackupRequest
+	(*GetBackupResponse)(nil),            // 13: containarium.v1.GetBackupResponse
+	(*RestoreBackupRequest)(nil),         // 14: containarium.v1.RestoreBackupRequest
+	(*RestoreBackupResponse)(nil),        // 15: containarium.v1.RestoreBackupResponse
+	(*VerifyBackupRequest)(nil),          // 16: containarium.v1.VerifyBackupRequest
+	(*VerifyBackupResponse)(nil),         // 17: containarium.v1.VerifyBackupResponse
+	(*DeleteBackupRequest)(nil),          // 18: containarium.v1.DeleteBackupRequest
+	(*DeleteBackupResponse)(nil),         // 19: containarium.v1.DeleteBackupResponse
+	(*BackupRetention)(nil),              // 20: containarium.v1.BackupRetention
+	(*BackupScheduleRun)(nil),            // 21: containarium.v1.BackupScheduleRun
+	(*BackupSchedule)(nil),               // 22: containarium.v1.BackupSchedule
+	(*SetBackupScheduleRequest)(nil),     // 23: containarium.v1.SetBackupScheduleRequest
+	(*SetBackupScheduleResponse)(nil),    // 24: containarium.v1.SetBackupScheduleResponse
+	(*GetBackupScheduleRequest)(nil),     // 25: containarium.v1.GetBackupScheduleRequest
+	(*GetBackupScheduleResponse)(nil),    // 26: containarium.v1.GetBackupScheduleResponse
+	(*ListBackupSchedulesRequest)(nil),   // 27: containarium.v1.ListBackupSchedulesRequest
+	(*ListBackupSchedulesResponse)(nil),  // 28: containarium.v1.ListBackupSchedulesResponse
+	(*DeleteBackupScheduleRequest)(nil),  // 29: containarium.v1.DeleteBackupScheduleRequest
+	(*DeleteBackupScheduleResponse)(nil), // 30: containarium.v1.DeleteBackupScheduleResponse
 }
 var file_containarium_v1_backup_proto_depIdxs = []int32{
 	0,  // 0: containarium.v1.BackupRecord.destination:type_name -> containarium.v1.BackupDestination
 	1,  // 1: containarium.v1.BackupRecord.engine:type_name -> containarium.v1.BackupEngine
-	5,  // 2: containarium.v1.BackupRecord.last_verification:type_name -> containarium.v1.BackupVerification
-	2,  // 3: containarium.v1.BackupVerification.result:type_name -> containarium.v1.VerificationResult
-	4,  // 4: containarium.v1.BackupVerification.checks:type_name -> containarium.v1.VerificationCheck
-	6,  // 5: containarium.v1.CreateBackupRequest.connection:type_name -> containarium.v1.PgConnection
-	0,  // 6: containarium.v1.CreateBackupRequest.destination:type_name -> containarium.v1.BackupDestination
-	3,  // 7: containarium.v1.CreateBackupResponse.record:type_name -> containarium.v1.BackupRecord
-	3,  // 8: containarium.v1.CreateBackupResponse.records:type_name -> containarium.v1.BackupRecord
-	3,  // 9: containarium.v1.ListBackupsResponse.records:type_name -> containarium.v1.BackupRecord
-	3,  // 10: containarium.v1.GetBackupResponse.record:type_name -> containarium.v1.BackupRecord
-	6,  // 11: containarium.v1.RestoreBackupRequest.connection:type_name -> containarium.v1.PgConnection
-	6,  // 12: containarium.v1.VerifyBackupRequest.connection:type_name -> containarium.v1.PgConnection
-	5,  // 13: containarium.v1.VerifyBackupResponse.verification:type_name -> containarium.v1.BackupVerification
-	3,  // 14: containarium.v1.VerifyBackupResponse.record:type_name -> containarium.v1.BackupRecord
-	7,  // 15: containarium.v1.BackupService.CreateBackup:input_type -> containarium.v1.CreateBackupRequest
-	9,  // 16: containarium.v1.BackupService.ListBackups:input_type -> containarium.v1.ListBackupsRequest
-	11, // 17: containarium.v1.BackupService.GetBackup:input_type -> containarium.v1.GetBackupRequest
-	13, // 18: containarium.v1.BackupService.RestoreBackup:input_type -> containarium.v1.RestoreBackupRequest
-	15, // 19: containarium.v1.BackupService.VerifyBackup:input_type -> containarium.v1.VerifyBackupRequest
-	17, // 20: containarium.v1.BackupService.DeleteBackup:input_type -> containarium.v1.DeleteBackupRequest
-	8,  // 21: containarium.v1.BackupService.CreateBackup:output_type -> containarium.v1.CreateBackupResponse
-	10, // 22: containarium.v1.BackupService.ListBackups:output_type -> containarium.v1.ListBackupsResponse
-	12, // 23: containarium.v1.BackupService.GetBackup:output_type -> containarium.v1.GetBackupResponse
-	14, // 24: containarium.v1.BackupService.RestoreBackup:output_type -> containarium.v1.RestoreBackupResponse
-	16, // 25: containarium.v1.BackupService.VerifyBackup:output_type -> containarium.v1.VerifyBackupResponse
-	18, // 26: containarium.v1.BackupService.DeleteBackup:output_type -> containarium.v1.DeleteBackupResponse
-	21, // [21:27] is the sub-list for method output_type
-	15, // [15:21] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	6,  // 2: containarium.v1.BackupRecord.last_verification:type_name -> containarium.v1.BackupVerification
+	4,  // 3: containarium.v1.BackupRecord.encryption:type_name -> containarium.v1.BackupEncryption
+	2,  // 4: containarium.v1.BackupVerification.result:type_name -> containarium.v1.VerificationResult
+	5,  // 5: containarium.v1.BackupVerification.checks:type_name -> containarium.v1.VerificationCheck
+	7,  // 6: containarium.v1.CreateBackupRequest.connection:type_name -> containarium.v1.PgConnection
+	0,  // 7: containarium.v1.CreateBackupRequest.destination:type_name -> containarium.v1.BackupDestination
+	1,  // 8: containarium.v1.CreateBackupRequest.engine:type_name -> containarium.v1.BackupEngine
+	3,  // 9: containarium.v1.CreateBackupResponse.record:type_name -> containarium.v1.BackupRecord
+	3,  // 10: containarium.v1.CreateBackupResponse.records:type_name -> containarium.v1.BackupRecord
+	3,  // 11: containarium.v1.ListBackupsResponse.records:type_name -> containarium.v1.BackupRecord
+	3,  // 12: containarium.v1.GetBackupResponse.record:type_name -> containarium.v1.BackupRecord
+	7,  // 13: containarium.v1.RestoreBackupRequest.connection:type_name -> containarium.v1.PgConnection
+	7,  // 14: containarium.v1.VerifyBackupRequest.connection:type_name -> containarium.v1.PgConnection
+	6,  // 15: containarium.v1.VerifyBackupResponse.verification:type_name -> containarium.v1.BackupVerification
+	3,  // 16: containarium.v1.VerifyBackupResponse.record:type_name -> containarium.v1.BackupRecord
+	7,  // 17: containarium.v1.BackupSchedule.connection:type_name -> containarium.v1.PgConnection
+	0,  // 18: containarium.v1.BackupSchedule.destination:type_name -> containarium.v1.BackupDestination
+	20, // 19: containarium.v1.BackupSchedule.retention:type_name -> containarium.v1.BackupRetention
+	21, // 20: containarium.v1.BackupSchedule.last_run:type_name -> containarium.v1.BackupScheduleRun
+	1,  // 21: containarium.v1.BackupSchedule.engine:type_name -> containarium.v1.BackupEngine
+	22, // 22: containarium.v1.SetBackupScheduleRequest.schedule:type_name -> containarium.v1.BackupSchedule
+	22, // 23: containarium.v1.SetBackupScheduleResponse.schedule:type_name -> containarium.v1.BackupSchedule
+	22, // 24: containarium.v1.GetBackupScheduleResponse.schedule:type_name -> containarium.v1.BackupSchedule
+	22, // 25: containarium.v1.ListBackupSchedulesResponse.schedules:type_name -> containarium.v1.BackupSchedule
+	8,  // 26: containarium.v1.BackupService.CreateBackup:input_type -> containarium.v1.CreateBackupRequest
+	10, // 27: containarium.v1.BackupService.ListBackups:input_type -> containarium.v1.ListBackupsRequest
+	12, // 28: containarium.v1.BackupService.GetBackup:input_type -> containarium.v1.GetBackupRequest
+	14, // 29: containarium.v1.BackupService.RestoreBackup:input_type -> containarium.v1.RestoreBackupRequest
+	16, // 30: containarium.v1.BackupService.VerifyBackup:input_type -> containarium.v1.VerifyBackupRequest
+	18, // 31: containarium.v1.BackupService.DeleteBackup:input_type -> containarium.v1.DeleteBackupRequest
+	23, // 32: containarium.v1.BackupService.SetBackupSchedule:input_type -> containarium.v1.SetBackupScheduleRequest
+	25, // 33: containarium.v1.BackupService.GetBackupSchedule:input_type -> containarium.v1.GetBackupScheduleRequest
+	27, // 34: containarium.v1.BackupService.ListBackupSchedules:input_type -> containarium.v1.ListBackupSchedulesRequest
+	29, // 35: containarium.v1.BackupService.DeleteBackupSchedule:input_type -> containarium.v1.DeleteBackupScheduleRequest
+	9,  // 36: containarium.v1.BackupService.CreateBackup:output_type -> containarium.v1.CreateBackupResponse
+	11, // 37: containarium.v1.BackupService.ListBackups:output_type -> containarium.v1.ListBackupsResponse
+	13, // 38: containarium.v1.BackupService.GetBackup:output_type -> containarium.v1.GetBackupResponse
+	15, // 39: containarium.v1.BackupService.RestoreBackup:output_type -> containarium.v1.RestoreBackupResponse
+	17, // 40: containarium.v1.BackupService.VerifyBackup:output_type -> containarium.v1.VerifyBackupResponse
+	19, // 41: containarium.v1.BackupService.DeleteBackup:output_type -> containarium.v1.DeleteBackupResponse
+	24, // 42: containarium.v1.BackupService.SetBackupSchedule:output_type -> containarium.v1.SetBackupScheduleResponse
+	26, // 43: containarium.v1.BackupService.GetBackupSchedule:output_type -> containarium.v1.GetBackupScheduleResponse
+	28, // 44: containarium.v1.BackupService.ListBackupSchedules:output_type -> containarium.v1.ListBackupSchedulesResponse
+	30, // 45: containarium.v1.BackupService.DeleteBackupSchedule:output_type -> containarium.v1.DeleteBackupScheduleResponse
+	36, // [36:46] is the sub-list for method output_type
+	26, // [26:36] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_backup_proto_init() }
@@ -1501,7 +2403,7 @@ func file_containarium_v1_backup_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_backup_proto_rawDesc), len(file_containarium_v1_backup_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   16,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BackupService_CreateBackup_FullMethodName  = "/containarium.v1.BackupService/CreateBackup"
-	BackupService_ListBackups_FullMethodName   = "/containarium.v1.BackupService/ListBackups"
-	BackupService_GetBackup_FullMethodName     = "/containarium.v1.BackupService/GetBackup"
-	BackupService_RestoreBackup_FullMethodName = "/containarium.v1.BackupService/RestoreBackup"
-	BackupService_VerifyBackup_FullMethodName  = "/containarium.v1.BackupService/VerifyBackup"
-	BackupService_DeleteBackup_FullMethodName  = "/containarium.v1.BackupService/DeleteBackup"
+	BackupService_CreateBackup_FullMethodName         = "/containarium.v1.BackupService/CreateBackup"
+	BackupService_ListBackups_FullMethodName          = "/containarium.v1.BackupService/ListBackups"
+	BackupService_GetBackup_FullMethodName            = "/containarium.v1.BackupService/GetBackup"
+	BackupService_RestoreBackup_FullMethodName        = "/containarium.v1.BackupService/RestoreBackup"
+	BackupService_VerifyBackup_FullMethodName         = "/containarium.v1.BackupService/VerifyBackup"
+	BackupService_DeleteBackup_FullMethodName         = "/containarium.v1.BackupService/DeleteBackup"
+	BackupService_SetBackupSchedule_FullMethodName    = "/containarium.v1.BackupService/SetBackupSchedule"
+	BackupService_GetBackupSchedule_FullMethodName    = "/containarium.v1.BackupService/GetBackupSchedule"
+	BackupService_ListBackupSchedules_FullMethodName  = "/containarium.v1.BackupService/ListBackupSchedules"
+	BackupService_DeleteBackupSchedule_FullMethodName = "/containarium.v1.BackupService/DeleteBackupSchedule"
 )
 
 // BackupServiceClient is the client API for BackupService service.
@@ -48,6 +52,14 @@ type BackupServiceClient interface {
 	VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error)
 	// DeleteBackup removes a stored dump and its index entry.
 	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error)
+	// SetBackupSchedule creates or replaces a tenant's recurring backup.
+	SetBackupSchedule(ctx context.Context, in *SetBackupScheduleRequest, opts ...grpc.CallOption) (*SetBackupScheduleResponse, error)
+	// GetBackupSchedule returns a tenant's schedule and its last run.
+	GetBackupSchedule(ctx context.Context, in *GetBackupScheduleRequest, opts ...grpc.CallOption) (*GetBackupScheduleResponse, error)
+	// ListBackupSchedules returns every schedule the caller may see.
+	ListBackupSchedules(ctx context.Context, in *ListBackupSchedulesRequest, opts ...grpc.CallOption) (*ListBackupSchedulesResponse, error)
+	// DeleteBackupSchedule removes a tenant's schedule.
+	DeleteBackupSchedule(ctx context.Context, in *DeleteBackupScheduleRequest, opts ...grpc.CallOption) (*DeleteBackupScheduleResponse, error)
 }
 
 type backupServiceClient struct {
@@ -118,6 +130,46 @@ func (c *backupServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackup
 	return out, nil
 }
 
+func (c *backupServiceClient) SetBackupSchedule(ctx context.Context, in *SetBackupScheduleRequest, opts ...grpc.CallOption) (*SetBackupScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBackupScheduleResponse)
+	err := c.cc.Invoke(ctx, BackupService_SetBackupSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupServiceClient) GetBackupSchedule(ctx context.Context, in *GetBackupScheduleRequest, opts ...grpc.CallOption) (*GetBackupScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackupScheduleResponse)
+	err := c.cc.Invoke(ctx, BackupService_GetBackupSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupServiceClient) ListBackupSchedules(ctx context.Context, in *ListBackupSchedulesRequest, opts ...grpc.CallOption) (*ListBackupSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBackupSchedulesResponse)
+	err := c.cc.Invoke(ctx, BackupService_ListBackupSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupServiceClient) DeleteBackupSchedule(ctx context.Context, in *DeleteBackupScheduleRequest, opts ...grpc.CallOption) (*DeleteBackupScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBackupScheduleResponse)
+	err := c.cc.Invoke(ctx, BackupService_DeleteBackupSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackupServiceServer is the server API for BackupService service.
 // All implementations must embed UnimplementedBackupServiceServer
 // for forward compatibility.
@@ -139,6 +191,14 @@ type BackupServiceServer interface {
 	VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error)
 	// DeleteBackup removes a stored dump and its index entry.
 	DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error)
+	// SetBackupSchedule creates or replaces a tenant's recurring backup.
+	SetBackupSchedule(context.Context, *SetBackupScheduleRequest) (*SetBackupScheduleResponse, error)
+	// GetBackupSchedule returns a tenant's schedule and its last run.
+	GetBackupSchedule(context.Context, *GetBackupScheduleRequest) (*GetBackupScheduleResponse, error)
+	// ListBackupSchedules returns every schedule the caller may see.
+	ListBackupSchedules(context.Context, *ListBackupSchedulesRequest) (*ListBackupSchedulesResponse, error)
+	// DeleteBackupSchedule removes a tenant's schedule.
+	DeleteBackupSchedule(context.Context, *DeleteBackupScheduleRequest) (*DeleteBackupScheduleResponse, error)
 	mustEmbedUnimplementedBackupServiceServer()
 }
 
@@ -167,6 +227,18 @@ func (UnimplementedBackupServiceServer) VerifyBackup(context.Context, *VerifyBac
 func (UnimplementedBackupServiceServer) DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteBackup not implemented")
 }
+func (UnimplementedBackupServiceServer) SetBackupSchedule(context.Context, *SetBackupScheduleRequest) (*SetBackupScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBackupSchedule not implemented")
+}
+func (UnimplementedBackupServiceServer) GetBackupSchedule(context.Context, *GetBackupScheduleRequest) (*GetBackupScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackupSchedule not implemented")
+}
+func (UnimplementedBackupServiceServer) ListBackupSchedules(context.Context, *ListBackupSchedulesRequest) (*ListBackupSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBackupSchedules not implemented")
+}
+func (UnimplementedBackupServiceServer) DeleteBackupSchedule(context.Context, *DeleteBackupScheduleRequest) (*DeleteBackupScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBackupSchedule not implemented")
+}
 func (UnimplementedBackupServiceServer) mustEmbedUnimplementedBackupServiceServer() {}
 func (UnimplementedBackupServiceServer) testEmbeddedByValue()                       {}
 
@@ -296,6 +368,78 @@ func _BackupService_DeleteBackup_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackupService_SetBackupSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBackupScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupServiceServer).SetBackupSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupService_SetBackupSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupServiceServer).SetBackupSchedule(ctx, req.(*SetBackupScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupService_GetBackupSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupServiceServer).GetBackupSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupService_GetBackupSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupServiceServer).GetBackupSchedule(ctx, req.(*GetBackupScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupService_ListBackupSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupServiceServer).ListBackupSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupService_ListBackupSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupServiceServer).ListBackupSchedules(ctx, req.(*ListBackupSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupService_DeleteBackupSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBackupScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupServiceServer).DeleteBackupSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupService_DeleteBackupSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupServiceServer).DeleteBackupSchedule(ctx, req.(*DeleteBackupScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BackupService_ServiceDesc is the grpc.ServiceDesc for BackupService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -327,6 +471,22 @@ var BackupService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBackup",
 			Handler:    _BackupService_DeleteBackup_Handler,
 		},
+		{
+			MethodName: "SetBackupSchedule",
+			Handler:    _BackupService_SetBackupSchedule_Handler,
+		},
+		{
+			MethodName: "GetBackupSchedule",
+			Handler:    _BackupService_GetBackupSchedule_Handler,
+		},
+		{
+			MethodName: "ListBackupSchedules",
+			Handler:    _BackupService_ListBackupSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteBackupSchedule",
+			Handler:    _BackupService_DeleteBackupSchedule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "containarium/v1/backup.proto",
@@ -2161,14 +2161,18 @@ type NetworkPolicy struct {
 	// Allow container↔container traffic within the same tenant. When false, even
 	// same-tenant peers can't reach each other.
 	AllowIntraTenant bool `protobuf:"varint,2,opt,name=allow_intra_tenant,json=allowIntraTenant,proto3" json:"allow_intra_tenant,omitempty"`
-	// Allowed egress destination CIDRs (e.g. "10.0.0.0/8", "1.2.3.4/32").
+	// Allowed egress destination CIDRs, IPv4 or IPv6 (e.g. "10.0.0.0/8",
+	// "1.2.3.4/32", "2606:4700::/32"). An IPv4-mapped IPv6 prefix is stored as
+	// its IPv4 form.
 	EgressCidrs []string `protobuf:"bytes,3,rep,name=egress_cidrs,json=egressCidrs,proto3" json:"egress_cidrs,omitempty"`
 	// Allowed egress domains (e.g. "api.github.com"); the daemon resolves these
-	// to CIDRs on a refresh loop and folds them into the egress allow set.
+	// (A and AAAA) on a refresh loop and folds the addresses into the egress
+	// allow set.
 	EgressDomains []string `protobuf:"bytes,4,rep,name=egress_domains,json=egressDomains,proto3" json:"egress_domains,omitempty"`
 	// Enforcement mode. Unspecified is treated as LOG_ONLY in Phase A.
 	Mode NetworkPolicyMode `protobuf:"varint,5,opt,name=mode,proto3,enum=containarium.v1.NetworkPolicyMode" json:"mode,omitempty"`
-	// Allow reaching the cloud metadata service (169.254.169.254). Default false:
+	// Allow reaching the cloud metadata service (169.254.169.254, and
+	// fd00:ec2::254 over IPv6). Default false:
 	// the metadata IP is denied even if egress_cidrs/egress_domains would
 	// otherwise cover it (deny-beats-allow for this one sensitive IP, since it
 	// hands out cloud credentials). Set true only for a tenant that legitimately
@@ -2188,7 +2192,14 @@ type NetworkPolicy struct {
 	// real upstream fix ships — instant, in-kernel, zero downtime. A rule whose
 	// expires_at is in the past is dropped at compile time, so the patch
 	// self-removes once the fix lands.
-	DenyRules     []*NetworkPolicyDenyRule `protobuf:"bytes,8,rep,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`
+	DenyRules []*NetworkPolicyDenyRule `protobuf:"bytes,8,rep,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`
+	// Port- and protocol-scoped egress allow rules (e.g. "api.github.com
+	// tcp/443", "10.0.0.0/8 tcp/5432"). Unlike egress_cidrs / egress_domains,
+	// which allow every port on a destination, a rule allows only its
+	// proto/port range. A rule with neither proto nor port is the same as a
+	// bare egress_cidrs / egress_domains entry and is normalized into those
+	// lists.
+	EgressRules   []*NetworkPolicyEgressRule `protobuf:"bytes,9,rep,name=egress_rules,json=egressRules,proto3" json:"egress_rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2279,14 +2290,105 @@ func (x *NetworkPolicy) GetDenyRules() []*NetworkPolicyDenyRule {
 	return nil
 }
 
+func (x *NetworkPolicy) GetEgressRules() []*NetworkPolicyEgressRule {
+	if x != nil {
+		return x.EgressRules
+	}
+	return nil
+}
+
+// NetworkPolicyEgressRule is one port/protocol-scoped egress allow entry.
+// Exactly one of cidr and domain is set.
+type NetworkPolicyEgressRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Destination CIDR, IPv4 or IPv6; a bare host IP is a /32 or /128.
+	Cidr string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Destination domain, resolved (A and AAAA) like egress_domains.
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// IP protocol: "tcp" | "udp" | "" (either).
+	Proto string `protobuf:"bytes,3,opt,name=proto,proto3" json:"proto,omitempty"`
+	// First destination port of the allowed range (0 = every port of proto).
+	Port uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	// Last destination port of the range, inclusive (0 = just port).
+	PortEnd       uint32 `protobuf:"varint,5,opt,name=port_end,json=portEnd,proto3" json:"port_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkPolicyEgressRule) Reset() {
+	*x = NetworkPolicyEgressRule{}
+	mi := &file_containarium_v1_config_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkPolicyEgressRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkPolicyEgressRule) ProtoMessage() {}
+
+func (x *NetworkPolicyEgressRule) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkPolicyEgressRule.ProtoReflect.Descriptor instead.
+func (*NetworkPolicyEgressRule) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NetworkPolicyEgressRule) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *NetworkPolicyEgressRule) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *NetworkPolicyEgressRule) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
+func (x *NetworkPolicyEgressRule) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NetworkPolicyEgressRule) GetPortEnd() uint32 {
+	if x != nil {
+		return x.PortEnd
+	}
+	return 0
+}
+
 // NetworkPolicyDenyRule is one virtual-patch block rule (#660). Traffic from a
 // tenant's container to the destination is denied — dropped in ENFORCE mode,
 // audited (action network_policy.virtual_patch) in every mode. Deny beats the
 // egress allow-list.
 type NetworkPolicyDenyRule struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Destination CIDR to block (e.g. "1.2.3.4/32", "10.0.0.0/8"). Required.
-	// IPv4 only (Phase A parses IPv4); a host IP is just a /32.
+	// Destination CIDR to block (e.g. "1.2.3.4/32", "10.0.0.0/8",
+	// "2001:db8::/32"). Required. IPv4 or IPv6; a bare host IP is a /32 (v4)
+	// or /128 (v6).
 	Cidr string `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`
 	// Optional destination port to scope the block (0 = any port). Lets a rule
 	// target only the vulnerable service (e.g. a Redis CVE on 6379) without
@@ -2307,7 +2409,7 @@ type NetworkPolicyDenyRule struct {
 
 func (x *NetworkPolicyDenyRule) Reset() {
 	*x = NetworkPolicyDenyRule{}
-	mi := &file_containarium_v1_config_proto_msgTypes[23]
+	mi := &file_containarium_v1_config_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2319,7 +2421,7 @@ func (x *NetworkPolicyDenyRule) String() string {
 func (*NetworkPolicyDenyRule) ProtoMessage() {}
 
 func (x *NetworkPolicyDenyRule) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[23]
+	mi := &file_containarium_v1_config_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2332,7 +2434,7 @@ func (x *NetworkPolicyDenyRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkPolicyDenyRule.ProtoReflect.Descriptor instead.
 func (*NetworkPolicyDenyRule) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{23}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *NetworkPolicyDenyRule) GetCidr() string {
@@ -2379,7 +2481,7 @@ type SetNetworkPolicyRequest struct {
 
 func (x *SetNetworkPolicyRequest) Reset() {
 	*x = SetNetworkPolicyRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[24]
+	mi := &file_containarium_v1_config_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2391,7 +2493,7 @@ func (x *SetNetworkPolicyRequest) String() string {
 func (*SetNetworkPolicyRequest) ProtoMessage() {}
 
 func (x *SetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[24]
+	mi := &file_containarium_v1_config_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2404,7 +2506,7 @@ func (x *SetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNetworkPolicyRequest.ProtoReflect.Descriptor instead.
 func (*SetNetworkPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{24}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SetNetworkPolicyRequest) GetPolicy() *NetworkPolicy {
@@ -2424,7 +2526,7 @@ type SetNetworkPolicyResponse struct {
 
 func (x *SetNetworkPolicyResponse) Reset() {
 	*x = SetNetworkPolicyResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[25]
+	mi := &file_containarium_v1_config_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2436,7 +2538,7 @@ func (x *SetNetworkPolicyResponse) String() string {
 func (*SetNetworkPolicyResponse) ProtoMessage() {}
 
 func (x *SetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[25]
+	mi := &file_containarium_v1_config_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2449,7 +2551,7 @@ func (x *SetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNetworkPolicyResponse.ProtoReflect.Descriptor instead.
 func (*SetNetworkPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{25}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *SetNetworkPolicyResponse) GetPolicy() *NetworkPolicy {
@@ -2468,7 +2570,7 @@ type GetNetworkPolicyRequest struct {
 
 func (x *GetNetworkPolicyRequest) Reset() {
 	*x = GetNetworkPolicyRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[26]
+	mi := &file_containarium_v1_config_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2480,7 +2582,7 @@ func (x *GetNetworkPolicyRequest) String() string {
 func (*GetNetworkPolicyRequest) ProtoMessage() {}
 
 func (x *GetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[26]
+	mi := &file_containarium_v1_config_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2493,7 +2595,7 @@ func (x *GetNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkPolicyRequest.ProtoReflect.Descriptor instead.
 func (*GetNetworkPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{26}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetNetworkPolicyRequest) GetTenant() string {
@@ -2512,7 +2614,7 @@ type GetNetworkPolicyResponse struct {
 
 func (x *GetNetworkPolicyResponse) Reset() {
 	*x = GetNetworkPolicyResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[27]
+	mi := &file_containarium_v1_config_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2524,7 +2626,7 @@ func (x *GetNetworkPolicyResponse) String() string {
 func (*GetNetworkPolicyResponse) ProtoMessage() {}
 
 func (x *GetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[27]
+	mi := &file_containarium_v1_config_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2537,7 +2639,7 @@ func (x *GetNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkPolicyResponse.ProtoReflect.Descriptor instead.
 func (*GetNetworkPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{27}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetNetworkPolicyResponse) GetPolicy() *NetworkPolicy {
@@ -2555,7 +2657,7 @@ type ListNetworkPoliciesRequest struct {
 
 func (x *ListNetworkPoliciesRequest) Reset() {
 	*x = ListNetworkPoliciesRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[28]
+	mi := &file_containarium_v1_config_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2567,7 +2669,7 @@ func (x *ListNetworkPoliciesRequest) String() string {
 func (*ListNetworkPoliciesRequest) ProtoMessage() {}
 
 func (x *ListNetworkPoliciesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[28]
+	mi := &file_containarium_v1_config_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2580,7 +2682,7 @@ func (x *ListNetworkPoliciesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNetworkPoliciesRequest.ProtoReflect.Descriptor instead.
 func (*ListNetworkPoliciesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{28}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{29}
 }
 
 type ListNetworkPoliciesResponse struct {
@@ -2592,7 +2694,7 @@ type ListNetworkPoliciesResponse struct {
 
 func (x *ListNetworkPoliciesResponse) Reset() {
 	*x = ListNetworkPoliciesResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[29]
+	mi := &file_containarium_v1_config_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2604,7 +2706,7 @@ func (x *ListNetworkPoliciesResponse) String() string {
 func (*ListNetworkPoliciesResponse) ProtoMessage() {}
 
 func (x *ListNetworkPoliciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[29]
+	mi := &file_containarium_v1_config_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2617,7 +2719,7 @@ func (x *ListNetworkPoliciesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNetworkPoliciesResponse.ProtoReflect.Descriptor instead.
 func (*ListNetworkPoliciesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{29}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ListNetworkPoliciesResponse) GetPolicies() []*NetworkPolicy {
@@ -2636,7 +2738,7 @@ type DeleteNetworkPolicyRequest struct {
 
 func (x *DeleteNetworkPolicyRequest) Reset() {
 	*x = DeleteNetworkPolicyRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[30]
+	mi := &file_containarium_v1_config_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2648,7 +2750,7 @@ func (x *DeleteNetworkPolicyRequest) String() string {
 func (*DeleteNetworkPolicyRequest) ProtoMessage() {}
 
 func (x *DeleteNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[30]
+	mi := &file_containarium_v1_config_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2661,7 +2763,7 @@ func (x *DeleteNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNetworkPolicyRequest.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{30}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *DeleteNetworkPolicyRequest) GetTenant() string {
@@ -2679,7 +2781,7 @@ type DeleteNetworkPolicyResponse struct {
 
 func (x *DeleteNetworkPolicyResponse) Reset() {
 	*x = DeleteNetworkPolicyResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[31]
+	mi := &file_containarium_v1_config_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2691,7 +2793,7 @@ func (x *DeleteNetworkPolicyResponse) String() string {
 func (*DeleteNetworkPolicyResponse) ProtoMessage() {}
 
 func (x *DeleteNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[31]
+	mi := &file_containarium_v1_config_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2704,7 +2806,7 @@ func (x *DeleteNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNetworkPolicyResponse.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{31}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{32}
 }
 
 // PatchNetworkPolicyDenyRulesRequest atomically mutates a tenant's virtual-patch
@@ -2725,7 +2827,7 @@ type PatchNetworkPolicyDenyRulesRequest struct {
 
 func (x *PatchNetworkPolicyDenyRulesRequest) Reset() {
 	*x = PatchNetworkPolicyDenyRulesRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[32]
+	mi := &file_containarium_v1_config_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2737,7 +2839,7 @@ func (x *PatchNetworkPolicyDenyRulesRequest) String() string {
 func (*PatchNetworkPolicyDenyRulesRequest) ProtoMessage() {}
 
 func (x *PatchNetworkPolicyDenyRulesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[32]
+	mi := &file_containarium_v1_config_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2750,7 +2852,7 @@ func (x *PatchNetworkPolicyDenyRulesRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PatchNetworkPolicyDenyRulesRequest.ProtoReflect.Descriptor instead.
 func (*PatchNetworkPolicyDenyRulesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{32}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *PatchNetworkPolicyDenyRulesRequest) GetTenant() string {
@@ -2774,46 +2876,1440 @@ func (x *PatchNetworkPolicyDenyRulesRequest) GetRemoveCidrs() []string {
 	return nil
 }
 
-// NetworkPolicySignature is one operator-managed cleartext exploit signature
-// (#661 Tier 2, PR-B). Unlike deny rules these are GLOBAL (fleet-wide), not
-// tenant-scoped — an exploit pattern is matched against every scanned container's
-// inbound payload. They augment the daemon's curated built-in set.
-type NetworkPolicySignature struct {
+// SuggestNetworkPolicyRequest asks for a policy learned from a tenant's
+// observed egress over a look-back window.
+type SuggestNetworkPolicyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tenant string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Look-back window: a Go duration ("36h") or a day count ("7d"). Empty
+	// means 7d.
+	Since         string `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestNetworkPolicyRequest) Reset() {
+	*x = SuggestNetworkPolicyRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestNetworkPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestNetworkPolicyRequest) ProtoMessage() {}
+
+func (x *SuggestNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestNetworkPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SuggestNetworkPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SuggestNetworkPolicyRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *SuggestNetworkPolicyRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+// NetworkPolicyObservedDestination is one egress destination seen during the
+// window — every flow to the same address, protocol and port folded
+// together — with how the current policy treats it and which candidate entry
+// covers it.
+type NetworkPolicyObservedDestination struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DestIp string                 `protobuf:"bytes,1,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
+	// The name the address is known by (a policy domain that resolves to it, or
+	// a forward-confirmed PTR name). Empty when unknown.
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// "tcp", "udp", "icmp", or the IP protocol number.
+	Proto string `protobuf:"bytes,3,opt,name=proto,proto3" json:"proto,omitempty"`
+	Port  uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Flows int64  `protobuf:"varint,5,opt,name=flows,proto3" json:"flows,omitempty"`
+	Bytes int64  `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// RFC3339 time of the last flow.
+	LastSeen   string   `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Containers []string `protobuf:"bytes,8,rep,name=containers,proto3" json:"containers,omitempty"`
+	// Owning tenant when the destination is a managed container.
+	PeerTenant string `protobuf:"bytes,9,opt,name=peer_tenant,json=peerTenant,proto3" json:"peer_tenant,omitempty"`
+	// Whether the current policy, enforced, allows it, and why: one of
+	// allow-list, intra-tenant, link-scope, virtual-patch, metadata,
+	// cross-tenant, intra-tenant-disabled, not-in-allow-list.
+	Allowed bool   `protobuf:"varint,10,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Reason  string `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The current allow-list entry that matched, if any.
+	Entry string `protobuf:"bytes,12,opt,name=entry,proto3" json:"entry,omitempty"`
+	// The candidate entry that allows it; empty when excluded.
+	Rule string `protobuf:"bytes,13,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Why the candidate does not allow it (metadata, cross-tenant, …).
+	Excluded      string `protobuf:"bytes,14,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkPolicyObservedDestination) Reset() {
+	*x = NetworkPolicyObservedDestination{}
+	mi := &file_containarium_v1_config_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkPolicyObservedDestination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkPolicyObservedDestination) ProtoMessage() {}
+
+func (x *NetworkPolicyObservedDestination) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkPolicyObservedDestination.ProtoReflect.Descriptor instead.
+func (*NetworkPolicyObservedDestination) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *NetworkPolicyObservedDestination) GetDestIp() string {
+	if x != nil {
+		return x.DestIp
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NetworkPolicyObservedDestination) GetFlows() int64 {
+	if x != nil {
+		return x.Flows
+	}
+	return 0
+}
+
+func (x *NetworkPolicyObservedDestination) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *NetworkPolicyObservedDestination) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetContainers() []string {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *NetworkPolicyObservedDestination) GetPeerTenant() string {
+	if x != nil {
+		return x.PeerTenant
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *NetworkPolicyObservedDestination) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetEntry() string {
+	if x != nil {
+		return x.Entry
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *NetworkPolicyObservedDestination) GetExcluded() string {
+	if x != nil {
+		return x.Excluded
+	}
+	return ""
+}
+
+type SuggestNetworkPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The learned policy, in ENFORCE mode. Deny rules and allow_metadata carry
+	// over from the current policy.
+	Candidate *NetworkPolicy `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	// The stored policy; unset when the tenant has none.
+	Current *NetworkPolicy `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	// Observed destinations, busiest first.
+	Destinations []*NetworkPolicyObservedDestination `protobuf:"bytes,3,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	// Allow entries ("api.github.com tcp/443", "10.0.0.0/8",
+	// "allow-intra-tenant", …) in the candidate but not the current policy, and
+	// the reverse.
+	Added   []string `protobuf:"bytes,4,rep,name=added,proto3" json:"added,omitempty"`
+	Removed []string `protobuf:"bytes,5,rep,name=removed,proto3" json:"removed,omitempty"`
+	// How many destinations the current policy, enforced, would have denied.
+	WouldDeny int32 `protobuf:"varint,6,opt,name=would_deny,json=wouldDeny,proto3" json:"would_deny,omitempty"`
+	// RFC3339 start of the window.
+	Since         string `protobuf:"bytes,7,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestNetworkPolicyResponse) Reset() {
+	*x = SuggestNetworkPolicyResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestNetworkPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestNetworkPolicyResponse) ProtoMessage() {}
+
+func (x *SuggestNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestNetworkPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SuggestNetworkPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SuggestNetworkPolicyResponse) GetCandidate() *NetworkPolicy {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+func (x *SuggestNetworkPolicyResponse) GetCurrent() *NetworkPolicy {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *SuggestNetworkPolicyResponse) GetDestinations() []*NetworkPolicyObservedDestination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+func (x *SuggestNetworkPolicyResponse) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *SuggestNetworkPolicyResponse) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *SuggestNetworkPolicyResponse) GetWouldDeny() int32 {
+	if x != nil {
+		return x.WouldDeny
+	}
+	return 0
+}
+
+func (x *SuggestNetworkPolicyResponse) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+// SimulateNetworkPolicyRequest is a dry run of SetNetworkPolicy: the proposed
+// policy is replayed against the tenant's recorded egress instead of stored.
+type SimulateNetworkPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The policy SetNetworkPolicy would be sent. Its deny rules are ignored: a
+	// set keeps the stored ones, and so does the simulation.
+	Policy *NetworkPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Look-back window: a Go duration ("36h") or a day count ("7d"). Empty
+	// means 7d.
+	Since string `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// Samples returned per transition, busiest first. 0 means 20; capped at
+	// 200.
+	SampleLimit   int32 `protobuf:"varint,3,opt,name=sample_limit,json=sampleLimit,proto3" json:"sample_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateNetworkPolicyRequest) Reset() {
+	*x = SimulateNetworkPolicyRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateNetworkPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateNetworkPolicyRequest) ProtoMessage() {}
+
+func (x *SimulateNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateNetworkPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SimulateNetworkPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SimulateNetworkPolicyRequest) GetPolicy() *NetworkPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SimulateNetworkPolicyRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *SimulateNetworkPolicyRequest) GetSampleLimit() int32 {
+	if x != nil {
+		return x.SampleLimit
+	}
+	return 0
+}
+
+// NetworkPolicySimulatedFlow is one recorded egress destination — every flow
+// to the same address, protocol and port folded together — judged under the
+// current and the proposed policy.
+type NetworkPolicySimulatedFlow struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DestIp string                 `protobuf:"bytes,1,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
+	// A domain of either policy that resolves to the address. Empty when none.
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// "tcp", "udp", "icmp", or the IP protocol number.
+	Proto string `protobuf:"bytes,3,opt,name=proto,proto3" json:"proto,omitempty"`
+	Port  uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Flows int64  `protobuf:"varint,5,opt,name=flows,proto3" json:"flows,omitempty"`
+	Bytes int64  `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// RFC3339 time of the last flow.
+	LastSeen   string   `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Containers []string `protobuf:"bytes,8,rep,name=containers,proto3" json:"containers,omitempty"`
+	// Owning tenant when the destination is a managed container.
+	PeerTenant string `protobuf:"bytes,9,opt,name=peer_tenant,json=peerTenant,proto3" json:"peer_tenant,omitempty"`
+	// Why each policy allows or denies it (the reasons of
+	// NetworkPolicyObservedDestination, plus no-policy when the tenant has
+	// none), and the allow-list entry that matched, if any.
+	CurrentReason  string `protobuf:"bytes,10,opt,name=current_reason,json=currentReason,proto3" json:"current_reason,omitempty"`
+	CurrentEntry   string `protobuf:"bytes,11,opt,name=current_entry,json=currentEntry,proto3" json:"current_entry,omitempty"`
+	ProposedReason string `protobuf:"bytes,12,opt,name=proposed_reason,json=proposedReason,proto3" json:"proposed_reason,omitempty"`
+	ProposedEntry  string `protobuf:"bytes,13,opt,name=proposed_entry,json=proposedEntry,proto3" json:"proposed_entry,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NetworkPolicySimulatedFlow) Reset() {
+	*x = NetworkPolicySimulatedFlow{}
+	mi := &file_containarium_v1_config_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkPolicySimulatedFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkPolicySimulatedFlow) ProtoMessage() {}
+
+func (x *NetworkPolicySimulatedFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkPolicySimulatedFlow.ProtoReflect.Descriptor instead.
+func (*NetworkPolicySimulatedFlow) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *NetworkPolicySimulatedFlow) GetDestIp() string {
+	if x != nil {
+		return x.DestIp
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetProto() string {
+	if x != nil {
+		return x.Proto
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NetworkPolicySimulatedFlow) GetFlows() int64 {
+	if x != nil {
+		return x.Flows
+	}
+	return 0
+}
+
+func (x *NetworkPolicySimulatedFlow) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *NetworkPolicySimulatedFlow) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetContainers() []string {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *NetworkPolicySimulatedFlow) GetPeerTenant() string {
+	if x != nil {
+		return x.PeerTenant
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetCurrentReason() string {
+	if x != nil {
+		return x.CurrentReason
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetCurrentEntry() string {
+	if x != nil {
+		return x.CurrentEntry
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetProposedReason() string {
+	if x != nil {
+		return x.ProposedReason
+	}
+	return ""
+}
+
+func (x *NetworkPolicySimulatedFlow) GetProposedEntry() string {
+	if x != nil {
+		return x.ProposedEntry
+	}
+	return ""
+}
+
+type SimulateNetworkPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The proposed policy as SetNetworkPolicy would store it.
+	Policy *NetworkPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// The stored policy; unset when the tenant has none (nothing is policed).
+	Current *NetworkPolicy `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	// RFC3339 start of the replayed window.
+	Since string `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// Destinations and flows replayed.
+	Destinations int64 `protobuf:"varint,4,opt,name=destinations,proto3" json:"destinations,omitempty"`
+	Flows        int64 `protobuf:"varint,5,opt,name=flows,proto3" json:"flows,omitempty"`
+	// Destinations and flows allowed today that the proposal would deny.
+	NewlyDeniedDestinations int64 `protobuf:"varint,6,opt,name=newly_denied_destinations,json=newlyDeniedDestinations,proto3" json:"newly_denied_destinations,omitempty"`
+	NewlyDeniedFlows        int64 `protobuf:"varint,7,opt,name=newly_denied_flows,json=newlyDeniedFlows,proto3" json:"newly_denied_flows,omitempty"`
+	// Destinations and flows denied today that the proposal would allow.
+	NewlyAllowedDestinations int64 `protobuf:"varint,8,opt,name=newly_allowed_destinations,json=newlyAllowedDestinations,proto3" json:"newly_allowed_destinations,omitempty"`
+	NewlyAllowedFlows        int64 `protobuf:"varint,9,opt,name=newly_allowed_flows,json=newlyAllowedFlows,proto3" json:"newly_allowed_flows,omitempty"`
+	// Destinations and flows both policies deny.
+	StillDeniedDestinations int64 `protobuf:"varint,10,opt,name=still_denied_destinations,json=stillDeniedDestinations,proto3" json:"still_denied_destinations,omitempty"`
+	StillDeniedFlows        int64 `protobuf:"varint,11,opt,name=still_denied_flows,json=stillDeniedFlows,proto3" json:"still_denied_flows,omitempty"`
+	// Samples of each transition, busiest first, at most sample_limit each.
+	NewlyDenied   []*NetworkPolicySimulatedFlow `protobuf:"bytes,12,rep,name=newly_denied,json=newlyDenied,proto3" json:"newly_denied,omitempty"`
+	NewlyAllowed  []*NetworkPolicySimulatedFlow `protobuf:"bytes,13,rep,name=newly_allowed,json=newlyAllowed,proto3" json:"newly_allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateNetworkPolicyResponse) Reset() {
+	*x = SimulateNetworkPolicyResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateNetworkPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateNetworkPolicyResponse) ProtoMessage() {}
+
+func (x *SimulateNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateNetworkPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SimulateNetworkPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SimulateNetworkPolicyResponse) GetPolicy() *NetworkPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SimulateNetworkPolicyResponse) GetCurrent() *NetworkPolicy {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *SimulateNetworkPolicyResponse) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *SimulateNetworkPolicyResponse) GetDestinations() int64 {
+	if x != nil {
+		return x.Destinations
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetFlows() int64 {
+	if x != nil {
+		return x.Flows
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetNewlyDeniedDestinations() int64 {
+	if x != nil {
+		return x.NewlyDeniedDestinations
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetNewlyDeniedFlows() int64 {
+	if x != nil {
+		return x.NewlyDeniedFlows
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetNewlyAllowedDestinations() int64 {
+	if x != nil {
+		return x.NewlyAllowedDestinations
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetNewlyAllowedFlows() int64 {
+	if x != nil {
+		return x.NewlyAllowedFlows
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetStillDeniedDestinations() int64 {
+	if x != nil {
+		return x.StillDeniedDestinations
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetStillDeniedFlows() int64 {
+	if x != nil {
+		return x.StillDeniedFlows
+	}
+	return 0
+}
+
+func (x *SimulateNetworkPolicyResponse) GetNewlyDenied() []*NetworkPolicySimulatedFlow {
+	if x != nil {
+		return x.NewlyDenied
+	}
+	return nil
+}
+
+func (x *SimulateNetworkPolicyResponse) GetNewlyAllowed() []*NetworkPolicySimulatedFlow {
+	if x != nil {
+		return x.NewlyAllowed
+	}
+	return nil
+}
+
+// BoxNetworkPolicy is a network policy for the subset of a tenant's boxes
+// whose container labels (`containarium label set`) match its selector. A
+// selected box is policed by the box policy INSTEAD of the tenant-wide
+// NetworkPolicy: its allow-list, intra-tenant and metadata flags and mode all
+// come from the box policy. The tenant-wide virtual-patch deny rules still
+// apply on top, since a patch guards every box of the tenant. When several
+// box policies select a box, the highest priority wins, then the one with
+// more selector labels, then the lower name.
+type BoxNetworkPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant whose boxes this policy may select (required).
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Name, unique within the tenant (lowercase letters, digits and '-').
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Labels a box must carry, all of them, to be selected (at least one).
+	Selector map[string]string `protobuf:"bytes,3,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Precedence among the tenant's box policies; higher wins.
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// The policy applied to selected boxes. Its tenant is ignored and set
+	// from the box policy's.
+	Policy        *NetworkPolicy `protobuf:"bytes,5,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoxNetworkPolicy) Reset() {
+	*x = BoxNetworkPolicy{}
+	mi := &file_containarium_v1_config_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoxNetworkPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoxNetworkPolicy) ProtoMessage() {}
+
+func (x *BoxNetworkPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoxNetworkPolicy.ProtoReflect.Descriptor instead.
+func (*BoxNetworkPolicy) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BoxNetworkPolicy) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *BoxNetworkPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BoxNetworkPolicy) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *BoxNetworkPolicy) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *BoxNetworkPolicy) GetPolicy() *NetworkPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetBoxNetworkPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *BoxNetworkPolicy      `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoxNetworkPolicyRequest) Reset() {
+	*x = SetBoxNetworkPolicyRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoxNetworkPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoxNetworkPolicyRequest) ProtoMessage() {}
+
+func (x *SetBoxNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoxNetworkPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetBoxNetworkPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetBoxNetworkPolicyRequest) GetPolicy() *BoxNetworkPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetBoxNetworkPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stored policy (echoed back in its normalized form).
+	Policy        *BoxNetworkPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBoxNetworkPolicyResponse) Reset() {
+	*x = SetBoxNetworkPolicyResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBoxNetworkPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBoxNetworkPolicyResponse) ProtoMessage() {}
+
+func (x *SetBoxNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBoxNetworkPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetBoxNetworkPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetBoxNetworkPolicyResponse) GetPolicy() *BoxNetworkPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type ListBoxNetworkPoliciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant to list; empty lists every tenant's box policies.
+	Tenant        string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBoxNetworkPoliciesRequest) Reset() {
+	*x = ListBoxNetworkPoliciesRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBoxNetworkPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBoxNetworkPoliciesRequest) ProtoMessage() {}
+
+func (x *ListBoxNetworkPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBoxNetworkPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListBoxNetworkPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListBoxNetworkPoliciesRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ListBoxNetworkPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []*BoxNetworkPolicy    `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBoxNetworkPoliciesResponse) Reset() {
+	*x = ListBoxNetworkPoliciesResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBoxNetworkPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBoxNetworkPoliciesResponse) ProtoMessage() {}
+
+func (x *ListBoxNetworkPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBoxNetworkPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListBoxNetworkPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListBoxNetworkPoliciesResponse) GetPolicies() []*BoxNetworkPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+type DeleteBoxNetworkPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBoxNetworkPolicyRequest) Reset() {
+	*x = DeleteBoxNetworkPolicyRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBoxNetworkPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBoxNetworkPolicyRequest) ProtoMessage() {}
+
+func (x *DeleteBoxNetworkPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBoxNetworkPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBoxNetworkPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteBoxNetworkPolicyRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeleteBoxNetworkPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteBoxNetworkPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBoxNetworkPolicyResponse) Reset() {
+	*x = DeleteBoxNetworkPolicyResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBoxNetworkPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBoxNetworkPolicyResponse) ProtoMessage() {}
+
+func (x *DeleteBoxNetworkPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBoxNetworkPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBoxNetworkPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{46}
+}
+
+// NetworkPolicySignature is one operator-managed cleartext exploit signature
+// (#661 Tier 2, PR-B). Unlike deny rules these are GLOBAL (fleet-wide), not
+// tenant-scoped — an exploit pattern is matched against every scanned container's
+// inbound payload. They augment the daemon's curated built-in set.
+type NetworkPolicySignature struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique operator label, typically the CVE id (e.g. "CVE-2024-1234"). The
+	// identity for upsert/delete.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The cleartext bytes to substring-match in the inbound payload, 1..32 bytes
+	// (the BPF SIG_MAX_LEN). Matched as raw bytes; a longer pattern is rejected.
+	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Whether this signature is active. Default true; a disabled signature is
+	// stored but not loaded into the kernel scan.
+	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Assigned signature id (output only), echoed in the audit on a match. Operator
+	// signatures get ids in a reserved high range so they never collide with the
+	// built-in set's low ids.
+	Id uint32 `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`
+	// Optional operator note.
+	Note          string `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkPolicySignature) Reset() {
+	*x = NetworkPolicySignature{}
+	mi := &file_containarium_v1_config_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkPolicySignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkPolicySignature) ProtoMessage() {}
+
+func (x *NetworkPolicySignature) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkPolicySignature.ProtoReflect.Descriptor instead.
+func (*NetworkPolicySignature) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *NetworkPolicySignature) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkPolicySignature) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *NetworkPolicySignature) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *NetworkPolicySignature) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NetworkPolicySignature) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type SetNetworkPolicySignatureRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Signature     *NetworkPolicySignature `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNetworkPolicySignatureRequest) Reset() {
+	*x = SetNetworkPolicySignatureRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNetworkPolicySignatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNetworkPolicySignatureRequest) ProtoMessage() {}
+
+func (x *SetNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNetworkPolicySignatureRequest.ProtoReflect.Descriptor instead.
+func (*SetNetworkPolicySignatureRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetNetworkPolicySignatureRequest) GetSignature() *NetworkPolicySignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SetNetworkPolicySignatureResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Signature     *NetworkPolicySignature `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"` // stored form, with the assigned id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNetworkPolicySignatureResponse) Reset() {
+	*x = SetNetworkPolicySignatureResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNetworkPolicySignatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNetworkPolicySignatureResponse) ProtoMessage() {}
+
+func (x *SetNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNetworkPolicySignatureResponse.ProtoReflect.Descriptor instead.
+func (*SetNetworkPolicySignatureResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetNetworkPolicySignatureResponse) GetSignature() *NetworkPolicySignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ListNetworkPolicySignaturesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworkPolicySignaturesRequest) Reset() {
+	*x = ListNetworkPolicySignaturesRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworkPolicySignaturesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworkPolicySignaturesRequest) ProtoMessage() {}
+
+func (x *ListNetworkPolicySignaturesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworkPolicySignaturesRequest.ProtoReflect.Descriptor instead.
+func (*ListNetworkPolicySignaturesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{50}
+}
+
+type ListNetworkPolicySignaturesResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Signatures    []*NetworkPolicySignature `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"` // operator signatures only (built-ins are implicit)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworkPolicySignaturesResponse) Reset() {
+	*x = ListNetworkPolicySignaturesResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworkPolicySignaturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworkPolicySignaturesResponse) ProtoMessage() {}
+
+func (x *ListNetworkPolicySignaturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworkPolicySignaturesResponse.ProtoReflect.Descriptor instead.
+func (*ListNetworkPolicySignaturesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListNetworkPolicySignaturesResponse) GetSignatures() []*NetworkPolicySignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type DeleteNetworkPolicySignatureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNetworkPolicySignatureRequest) Reset() {
+	*x = DeleteNetworkPolicySignatureRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNetworkPolicySignatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNetworkPolicySignatureRequest) ProtoMessage() {}
+
+func (x *DeleteNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNetworkPolicySignatureRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNetworkPolicySignatureRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteNetworkPolicySignatureRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteNetworkPolicySignatureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNetworkPolicySignatureResponse) Reset() {
+	*x = DeleteNetworkPolicySignatureResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNetworkPolicySignatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNetworkPolicySignatureResponse) ProtoMessage() {}
+
+func (x *DeleteNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNetworkPolicySignatureResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNetworkPolicySignatureResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{53}
+}
+
+// WAFRule is one operator-managed rule for the userspace WAF (#662 Tier 3):
+// a regex or literal matched against one part of a steered HTTP request after
+// URL/percent/unicode normalization. Rules are global unless `tenants` names
+// the tenants whose containers they apply to. Edits are hot-reloaded into the
+// running proxy without dropping connections.
+type WAFRule struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique operator label, typically the CVE id (e.g. "CVE-2024-1234"). The
-	// identity for upsert/delete.
+	// Unique operator label; the identity for upsert/delete.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// The cleartext bytes to substring-match in the inbound payload, 1..32 bytes
-	// (the BPF SIG_MAX_LEN). Matched as raw bytes; a longer pattern is rejected.
-	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
-	// Whether this signature is active. Default true; a disabled signature is
-	// stored but not loaded into the kernel scan.
-	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	// Assigned signature id (output only), echoed in the audit on a match. Operator
-	// signatures get ids in a reserved high range so they never collide with the
-	// built-in set's low ids.
-	Id uint32 `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"`
+	// Part of the request matched: "method", "path", "query", "header" or
+	// "body" (the inspected body prefix). A rate_limit rule may leave target
+	// and pattern empty to count every request.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Header name, required when target is "header" (case-insensitive).
+	Header string `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	// "literal" (case-insensitive substring) or "regex" (RE2, as written).
+	Match string `protobuf:"bytes,4,opt,name=match,proto3" json:"match,omitempty"`
+	// The literal or regular expression.
+	Pattern string `protobuf:"bytes,5,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// "block" (403 when enforcement is armed), "log" (audit only) or
+	// "rate_limit" (429 once a client source IP exceeds requests_per_minute).
+	Action string `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	// Tenants the rule is enabled for; empty means every tenant.
+	Tenants []string `protobuf:"bytes,7,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	// Whether the rule is loaded into the WAF. A disabled rule is stored only.
+	Enabled bool `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Assigned rule id (output only), echoed in the audit and events on a match.
+	// Operator rules get ids from 1000 up, clear of the built-in signatures.
+	Id uint32 `protobuf:"varint,9,opt,name=id,proto3" json:"id,omitempty"`
 	// Optional operator note.
-	Note          string `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	Note string `protobuf:"bytes,10,opt,name=note,proto3" json:"note,omitempty"`
+	// Token-bucket refill rate per client source IP, for action "rate_limit".
+	RequestsPerMinute int32 `protobuf:"varint,11,opt,name=requests_per_minute,json=requestsPerMinute,proto3" json:"requests_per_minute,omitempty"`
+	// Bucket capacity for action "rate_limit" (0 → requests_per_minute).
+	Burst         int32 `protobuf:"varint,12,opt,name=burst,proto3" json:"burst,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NetworkPolicySignature) Reset() {
-	*x = NetworkPolicySignature{}
-	mi := &file_containarium_v1_config_proto_msgTypes[33]
+func (x *WAFRule) Reset() {
+	*x = WAFRule{}
+	mi := &file_containarium_v1_config_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NetworkPolicySignature) String() string {
+func (x *WAFRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkPolicySignature) ProtoMessage() {}
+func (*WAFRule) ProtoMessage() {}
 
-func (x *NetworkPolicySignature) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[33]
+func (x *WAFRule) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2824,68 +4320,117 @@ func (x *NetworkPolicySignature) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkPolicySignature.ProtoReflect.Descriptor instead.
-func (*NetworkPolicySignature) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use WAFRule.ProtoReflect.Descriptor instead.
+func (*WAFRule) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *NetworkPolicySignature) GetName() string {
+func (x *WAFRule) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *NetworkPolicySignature) GetPattern() string {
+func (x *WAFRule) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *WAFRule) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *WAFRule) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *WAFRule) GetPattern() string {
 	if x != nil {
 		return x.Pattern
 	}
 	return ""
 }
 
-func (x *NetworkPolicySignature) GetEnabled() bool {
+func (x *WAFRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WAFRule) GetTenants() []string {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+func (x *WAFRule) GetEnabled() bool {
 	if x != nil {
 		return x.Enabled
 	}
 	return false
 }
 
-func (x *NetworkPolicySignature) GetId() uint32 {
+func (x *WAFRule) GetId() uint32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *NetworkPolicySignature) GetNote() string {
+func (x *WAFRule) GetNote() string {
 	if x != nil {
 		return x.Note
 	}
 	return ""
 }
 
-type SetNetworkPolicySignatureRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Signature     *NetworkPolicySignature `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+func (x *WAFRule) GetRequestsPerMinute() int32 {
+	if x != nil {
+		return x.RequestsPerMinute
+	}
+	return 0
+}
+
+func (x *WAFRule) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+type SetWAFRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *WAFRule               `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetNetworkPolicySignatureRequest) Reset() {
-	*x = SetNetworkPolicySignatureRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[34]
+func (x *SetWAFRuleRequest) Reset() {
+	*x = SetWAFRuleRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetNetworkPolicySignatureRequest) String() string {
+func (x *SetWAFRuleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetNetworkPolicySignatureRequest) ProtoMessage() {}
+func (*SetWAFRuleRequest) ProtoMessage() {}
 
-func (x *SetNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[34]
+func (x *SetWAFRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2896,40 +4441,40 @@ func (x *SetNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetNetworkPolicySignatureRequest.ProtoReflect.Descriptor instead.
-func (*SetNetworkPolicySignatureRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use SetWAFRuleRequest.ProtoReflect.Descriptor instead.
+func (*SetWAFRuleRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *SetNetworkPolicySignatureRequest) GetSignature() *NetworkPolicySignature {
+func (x *SetWAFRuleRequest) GetRule() *WAFRule {
 	if x != nil {
-		return x.Signature
+		return x.Rule
 	}
 	return nil
 }
 
-type SetNetworkPolicySignatureResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Signature     *NetworkPolicySignature `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"` // stored form, with the assigned id
+type SetWAFRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *WAFRule               `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"` // stored form, with the assigned id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetNetworkPolicySignatureResponse) Reset() {
-	*x = SetNetworkPolicySignatureResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[35]
+func (x *SetWAFRuleResponse) Reset() {
+	*x = SetWAFRuleResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetNetworkPolicySignatureResponse) String() string {
+func (x *SetWAFRuleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetNetworkPolicySignatureResponse) ProtoMessage() {}
+func (*SetWAFRuleResponse) ProtoMessage() {}
 
-func (x *SetNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[35]
+func (x *SetWAFRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2940,39 +4485,39 @@ func (x *SetNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetNetworkPolicySignatureResponse.ProtoReflect.Descriptor instead.
-func (*SetNetworkPolicySignatureResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use SetWAFRuleResponse.ProtoReflect.Descriptor instead.
+func (*SetWAFRuleResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *SetNetworkPolicySignatureResponse) GetSignature() *NetworkPolicySignature {
+func (x *SetWAFRuleResponse) GetRule() *WAFRule {
 	if x != nil {
-		return x.Signature
+		return x.Rule
 	}
 	return nil
 }
 
-type ListNetworkPolicySignaturesRequest struct {
+type ListWAFRulesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNetworkPolicySignaturesRequest) Reset() {
-	*x = ListNetworkPolicySignaturesRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[36]
+func (x *ListWAFRulesRequest) Reset() {
+	*x = ListWAFRulesRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNetworkPolicySignaturesRequest) String() string {
+func (x *ListWAFRulesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNetworkPolicySignaturesRequest) ProtoMessage() {}
+func (*ListWAFRulesRequest) ProtoMessage() {}
 
-func (x *ListNetworkPolicySignaturesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[36]
+func (x *ListWAFRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2983,33 +4528,33 @@ func (x *ListNetworkPolicySignaturesRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNetworkPolicySignaturesRequest.ProtoReflect.Descriptor instead.
-func (*ListNetworkPolicySignaturesRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use ListWAFRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListWAFRulesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{57}
 }
 
-type ListNetworkPolicySignaturesResponse struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	Signatures    []*NetworkPolicySignature `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"` // operator signatures only (built-ins are implicit)
+type ListWAFRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*WAFRule             `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"` // operator rules only (built-ins are implicit)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNetworkPolicySignaturesResponse) Reset() {
-	*x = ListNetworkPolicySignaturesResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[37]
+func (x *ListWAFRulesResponse) Reset() {
+	*x = ListWAFRulesResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNetworkPolicySignaturesResponse) String() string {
+func (x *ListWAFRulesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNetworkPolicySignaturesResponse) ProtoMessage() {}
+func (*ListWAFRulesResponse) ProtoMessage() {}
 
-func (x *ListNetworkPolicySignaturesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[37]
+func (x *ListWAFRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3020,40 +4565,40 @@ func (x *ListNetworkPolicySignaturesResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNetworkPolicySignaturesResponse.ProtoReflect.Descriptor instead.
-func (*ListNetworkPolicySignaturesResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use ListWAFRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListWAFRulesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *ListNetworkPolicySignaturesResponse) GetSignatures() []*NetworkPolicySignature {
+func (x *ListWAFRulesResponse) GetRules() []*WAFRule {
 	if x != nil {
-		return x.Signatures
+		return x.Rules
 	}
 	return nil
 }
 
-type DeleteNetworkPolicySignatureRequest struct {
+type DeleteWAFRuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteNetworkPolicySignatureRequest) Reset() {
-	*x = DeleteNetworkPolicySignatureRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[38]
+func (x *DeleteWAFRuleRequest) Reset() {
+	*x = DeleteWAFRuleRequest{}
+	mi := &file_containarium_v1_config_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNetworkPolicySignatureRequest) String() string {
+func (x *DeleteWAFRuleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNetworkPolicySignatureRequest) ProtoMessage() {}
+func (*DeleteWAFRuleRequest) ProtoMessage() {}
 
-func (x *DeleteNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[38]
+func (x *DeleteWAFRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3064,39 +4609,39 @@ func (x *DeleteNetworkPolicySignatureRequest) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNetworkPolicySignatureRequest.ProtoReflect.Descriptor instead.
-func (*DeleteNetworkPolicySignatureRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use DeleteWAFRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWAFRuleRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *DeleteNetworkPolicySignatureRequest) GetName() string {
+func (x *DeleteWAFRuleRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-type DeleteNetworkPolicySignatureResponse struct {
+type DeleteWAFRuleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteNetworkPolicySignatureResponse) Reset() {
-	*x = DeleteNetworkPolicySignatureResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[39]
+func (x *DeleteWAFRuleResponse) Reset() {
+	*x = DeleteWAFRuleResponse{}
+	mi := &file_containarium_v1_config_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNetworkPolicySignatureResponse) String() string {
+func (x *DeleteWAFRuleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNetworkPolicySignatureResponse) ProtoMessage() {}
+func (*DeleteWAFRuleResponse) ProtoMessage() {}
 
-func (x *DeleteNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[39]
+func (x *DeleteWAFRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_config_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3107,9 +4652,9 @@ func (x *DeleteNetworkPolicySignatureResponse) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNetworkPolicySignatureResponse.ProtoReflect.Descriptor instead.
-func (*DeleteNetworkPolicySignatureResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use DeleteWAFRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWAFRuleResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{60}
 }
 
 // BackendInfo describes one backend in the fleet — the local daemon
@@ -3170,7 +4715,7 @@ type BackendInfo struct {
 
 func (x *BackendInfo) Reset() {
 	*x = BackendInfo{}
-	mi := &file_containarium_v1_config_proto_msgTypes[40]
+	mi := &file_containarium_v1_config_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3182,7 +4727,7 @@ func (x *BackendInfo) String() string {
 func (*BackendInfo) ProtoMessage() {}
 
 func (x *BackendInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[40]
+	mi := &file_containarium_v1_config_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3195,7 +4740,7 @@ func (x *BackendInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackendInfo.ProtoReflect.Descriptor instead.
 func (*BackendInfo) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{40}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *BackendInfo) GetId() string {
@@ -3337,7 +4882,7 @@ type HostLoad struct {
 
 func (x *HostLoad) Reset() {
 	*x = HostLoad{}
-	mi := &file_containarium_v1_config_proto_msgTypes[41]
+	mi := &file_containarium_v1_config_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3349,7 +4894,7 @@ func (x *HostLoad) String() string {
 func (*HostLoad) ProtoMessage() {}
 
 func (x *HostLoad) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[41]
+	mi := &file_containarium_v1_config_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3362,7 +4907,7 @@ func (x *HostLoad) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HostLoad.ProtoReflect.Descriptor instead.
 func (*HostLoad) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{41}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *HostLoad) GetCpuLoad_1M() float64 {
@@ -3477,7 +5022,7 @@ type CapabilityProfile struct {
 
 func (x *CapabilityProfile) Reset() {
 	*x = CapabilityProfile{}
-	mi := &file_containarium_v1_config_proto_msgTypes[42]
+	mi := &file_containarium_v1_config_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3489,7 +5034,7 @@ func (x *CapabilityProfile) String() string {
 func (*CapabilityProfile) ProtoMessage() {}
 
 func (x *CapabilityProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[42]
+	mi := &file_containarium_v1_config_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3502,7 +5047,7 @@ func (x *CapabilityProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapabilityProfile.ProtoReflect.Descriptor instead.
 func (*CapabilityProfile) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{42}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *CapabilityProfile) GetCpuCores() int32 {
@@ -3617,7 +5162,7 @@ type CapabilityBenchmark struct {
 
 func (x *CapabilityBenchmark) Reset() {
 	*x = CapabilityBenchmark{}
-	mi := &file_containarium_v1_config_proto_msgTypes[43]
+	mi := &file_containarium_v1_config_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3629,7 +5174,7 @@ func (x *CapabilityBenchmark) String() string {
 func (*CapabilityBenchmark) ProtoMessage() {}
 
 func (x *CapabilityBenchmark) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[43]
+	mi := &file_containarium_v1_config_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3642,7 +5187,7 @@ func (x *CapabilityBenchmark) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapabilityBenchmark.ProtoReflect.Descriptor instead.
 func (*CapabilityBenchmark) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{43}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *CapabilityBenchmark) GetCpuOpsPerSec() int64 {
@@ -3700,7 +5245,7 @@ type CapacityHeadroom struct {
 
 func (x *CapacityHeadroom) Reset() {
 	*x = CapacityHeadroom{}
-	mi := &file_containarium_v1_config_proto_msgTypes[44]
+	mi := &file_containarium_v1_config_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3712,7 +5257,7 @@ func (x *CapacityHeadroom) String() string {
 func (*CapacityHeadroom) ProtoMessage() {}
 
 func (x *CapacityHeadroom) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[44]
+	mi := &file_containarium_v1_config_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3725,7 +5270,7 @@ func (x *CapacityHeadroom) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapacityHeadroom.ProtoReflect.Descriptor instead.
 func (*CapacityHeadroom) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{44}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *CapacityHeadroom) GetAdvertised() bool {
@@ -3804,7 +5349,7 @@ type CapacityPolicy struct {
 
 func (x *CapacityPolicy) Reset() {
 	*x = CapacityPolicy{}
-	mi := &file_containarium_v1_config_proto_msgTypes[45]
+	mi := &file_containarium_v1_config_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3816,7 +5361,7 @@ func (x *CapacityPolicy) String() string {
 func (*CapacityPolicy) ProtoMessage() {}
 
 func (x *CapacityPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[45]
+	mi := &file_containarium_v1_config_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3829,7 +5374,7 @@ func (x *CapacityPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapacityPolicy.ProtoReflect.Descriptor instead.
 func (*CapacityPolicy) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{45}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *CapacityPolicy) GetWindowStartHour() int32 {
@@ -3875,7 +5420,7 @@ type BackendGPU struct {
 
 func (x *BackendGPU) Reset() {
 	*x = BackendGPU{}
-	mi := &file_containarium_v1_config_proto_msgTypes[46]
+	mi := &file_containarium_v1_config_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3887,7 +5432,7 @@ func (x *BackendGPU) String() string {
 func (*BackendGPU) ProtoMessage() {}
 
 func (x *BackendGPU) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[46]
+	mi := &file_containarium_v1_config_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3900,7 +5445,7 @@ func (x *BackendGPU) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackendGPU.ProtoReflect.Descriptor instead.
 func (*BackendGPU) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{46}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *BackendGPU) GetVendor() string {
@@ -3933,7 +5478,7 @@ type ListBackendsRequest struct {
 
 func (x *ListBackendsRequest) Reset() {
 	*x = ListBackendsRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[47]
+	mi := &file_containarium_v1_config_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3945,7 +5490,7 @@ func (x *ListBackendsRequest) String() string {
 func (*ListBackendsRequest) ProtoMessage() {}
 
 func (x *ListBackendsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[47]
+	mi := &file_containarium_v1_config_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3958,7 +5503,7 @@ func (x *ListBackendsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackendsRequest.ProtoReflect.Descriptor instead.
 func (*ListBackendsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{47}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{68}
 }
 
 // ListBackendsResponse is the response from listing backends
@@ -3972,7 +5517,7 @@ type ListBackendsResponse struct {
 
 func (x *ListBackendsResponse) Reset() {
 	*x = ListBackendsResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[48]
+	mi := &file_containarium_v1_config_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3984,7 +5529,7 @@ func (x *ListBackendsResponse) String() string {
 func (*ListBackendsResponse) ProtoMessage() {}
 
 func (x *ListBackendsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[48]
+	mi := &file_containarium_v1_config_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3997,7 +5542,7 @@ func (x *ListBackendsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackendsResponse.ProtoReflect.Descriptor instead.
 func (*ListBackendsResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{48}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *ListBackendsResponse) GetBackends() []*BackendInfo {
@@ -4022,7 +5567,7 @@ type AdvertiseCapacityRequest struct {
 
 func (x *AdvertiseCapacityRequest) Reset() {
 	*x = AdvertiseCapacityRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[49]
+	mi := &file_containarium_v1_config_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4034,7 +5579,7 @@ func (x *AdvertiseCapacityRequest) String() string {
 func (*AdvertiseCapacityRequest) ProtoMessage() {}
 
 func (x *AdvertiseCapacityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[49]
+	mi := &file_containarium_v1_config_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4047,7 +5592,7 @@ func (x *AdvertiseCapacityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdvertiseCapacityRequest.ProtoReflect.Descriptor instead.
 func (*AdvertiseCapacityRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{49}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *AdvertiseCapacityRequest) GetPolicy() *CapacityPolicy {
@@ -4069,7 +5614,7 @@ type AdvertiseCapacityResponse struct {
 
 func (x *AdvertiseCapacityResponse) Reset() {
 	*x = AdvertiseCapacityResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[50]
+	mi := &file_containarium_v1_config_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4081,7 +5626,7 @@ func (x *AdvertiseCapacityResponse) String() string {
 func (*AdvertiseCapacityResponse) ProtoMessage() {}
 
 func (x *AdvertiseCapacityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[50]
+	mi := &file_containarium_v1_config_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4094,7 +5639,7 @@ func (x *AdvertiseCapacityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdvertiseCapacityResponse.ProtoReflect.Descriptor instead.
 func (*AdvertiseCapacityResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{50}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *AdvertiseCapacityResponse) GetHeadroom() *CapacityHeadroom {
@@ -4126,7 +5671,7 @@ type WithdrawCapacityRequest struct {
 
 func (x *WithdrawCapacityRequest) Reset() {
 	*x = WithdrawCapacityRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[51]
+	mi := &file_containarium_v1_config_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4138,7 +5683,7 @@ func (x *WithdrawCapacityRequest) String() string {
 func (*WithdrawCapacityRequest) ProtoMessage() {}
 
 func (x *WithdrawCapacityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[51]
+	mi := &file_containarium_v1_config_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4151,7 +5696,7 @@ func (x *WithdrawCapacityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithdrawCapacityRequest.ProtoReflect.Descriptor instead.
 func (*WithdrawCapacityRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{51}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *WithdrawCapacityRequest) GetDrain() bool {
@@ -4192,7 +5737,7 @@ type WithdrawCapacityResponse struct {
 
 func (x *WithdrawCapacityResponse) Reset() {
 	*x = WithdrawCapacityResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[52]
+	mi := &file_containarium_v1_config_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4204,7 +5749,7 @@ func (x *WithdrawCapacityResponse) String() string {
 func (*WithdrawCapacityResponse) ProtoMessage() {}
 
 func (x *WithdrawCapacityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[52]
+	mi := &file_containarium_v1_config_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4217,7 +5762,7 @@ func (x *WithdrawCapacityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithdrawCapacityResponse.ProtoReflect.Descriptor instead.
 func (*WithdrawCapacityResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{52}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *WithdrawCapacityResponse) GetHeadroom() *CapacityHeadroom {
@@ -4265,7 +5810,7 @@ type GetCapacityHeadroomRequest struct {
 
 func (x *GetCapacityHeadroomRequest) Reset() {
 	*x = GetCapacityHeadroomRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[53]
+	mi := &file_containarium_v1_config_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4277,7 +5822,7 @@ func (x *GetCapacityHeadroomRequest) String() string {
 func (*GetCapacityHeadroomRequest) ProtoMessage() {}
 
 func (x *GetCapacityHeadroomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[53]
+	mi := &file_containarium_v1_config_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4290,7 +5835,7 @@ func (x *GetCapacityHeadroomRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapacityHeadroomRequest.ProtoReflect.Descriptor instead.
 func (*GetCapacityHeadroomRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{53}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{74}
 }
 
 // GetCapacityHeadroomResponse returns the current headroom snapshot.
@@ -4303,7 +5848,7 @@ type GetCapacityHeadroomResponse struct {
 
 func (x *GetCapacityHeadroomResponse) Reset() {
 	*x = GetCapacityHeadroomResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[54]
+	mi := &file_containarium_v1_config_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4315,7 +5860,7 @@ func (x *GetCapacityHeadroomResponse) String() string {
 func (*GetCapacityHeadroomResponse) ProtoMessage() {}
 
 func (x *GetCapacityHeadroomResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[54]
+	mi := &file_containarium_v1_config_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4328,7 +5873,7 @@ func (x *GetCapacityHeadroomResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapacityHeadroomResponse.ProtoReflect.Descriptor instead.
 func (*GetCapacityHeadroomResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{54}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *GetCapacityHeadroomResponse) GetHeadroom() *CapacityHeadroom {
@@ -4357,7 +5902,7 @@ type ProfileBackendRequest struct {
 
 func (x *ProfileBackendRequest) Reset() {
 	*x = ProfileBackendRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[55]
+	mi := &file_containarium_v1_config_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4369,7 +5914,7 @@ func (x *ProfileBackendRequest) String() string {
 func (*ProfileBackendRequest) ProtoMessage() {}
 
 func (x *ProfileBackendRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[55]
+	mi := &file_containarium_v1_config_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4382,7 +5927,7 @@ func (x *ProfileBackendRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileBackendRequest.ProtoReflect.Descriptor instead.
 func (*ProfileBackendRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{55}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *ProfileBackendRequest) GetBackendId() string {
@@ -4411,7 +5956,7 @@ type ProfileBackendResponse struct {
 
 func (x *ProfileBackendResponse) Reset() {
 	*x = ProfileBackendResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[56]
+	mi := &file_containarium_v1_config_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4423,7 +5968,7 @@ func (x *ProfileBackendResponse) String() string {
 func (*ProfileBackendResponse) ProtoMessage() {}
 
 func (x *ProfileBackendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[56]
+	mi := &file_containarium_v1_config_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4436,7 +5981,7 @@ func (x *ProfileBackendResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileBackendResponse.ProtoReflect.Descriptor instead.
 func (*ProfileBackendResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{56}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *ProfileBackendResponse) GetProfile() *CapabilityProfile {
@@ -4465,7 +6010,7 @@ type GetCapabilityProfileRequest struct {
 
 func (x *GetCapabilityProfileRequest) Reset() {
 	*x = GetCapabilityProfileRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[57]
+	mi := &file_containarium_v1_config_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4477,7 +6022,7 @@ func (x *GetCapabilityProfileRequest) String() string {
 func (*GetCapabilityProfileRequest) ProtoMessage() {}
 
 func (x *GetCapabilityProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[57]
+	mi := &file_containarium_v1_config_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4490,7 +6035,7 @@ func (x *GetCapabilityProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapabilityProfileRequest.ProtoReflect.Descriptor instead.
 func (*GetCapabilityProfileRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{57}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *GetCapabilityProfileRequest) GetBackendId() string {
@@ -4513,7 +6058,7 @@ type GetCapabilityProfileResponse struct {
 
 func (x *GetCapabilityProfileResponse) Reset() {
 	*x = GetCapabilityProfileResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[58]
+	mi := &file_containarium_v1_config_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4525,7 +6070,7 @@ func (x *GetCapabilityProfileResponse) String() string {
 func (*GetCapabilityProfileResponse) ProtoMessage() {}
 
 func (x *GetCapabilityProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[58]
+	mi := &file_containarium_v1_config_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4538,7 +6083,7 @@ func (x *GetCapabilityProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCapabilityProfileResponse.ProtoReflect.Descriptor instead.
 func (*GetCapabilityProfileResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{58}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *GetCapabilityProfileResponse) GetProfile() *CapabilityProfile {
@@ -4607,7 +6152,7 @@ type SelfMeasurement struct {
 
 func (x *SelfMeasurement) Reset() {
 	*x = SelfMeasurement{}
-	mi := &file_containarium_v1_config_proto_msgTypes[59]
+	mi := &file_containarium_v1_config_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4619,7 +6164,7 @@ func (x *SelfMeasurement) String() string {
 func (*SelfMeasurement) ProtoMessage() {}
 
 func (x *SelfMeasurement) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[59]
+	mi := &file_containarium_v1_config_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4632,7 +6177,7 @@ func (x *SelfMeasurement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelfMeasurement.ProtoReflect.Descriptor instead.
 func (*SelfMeasurement) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{59}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *SelfMeasurement) GetHashAlgorithm() string {
@@ -4733,7 +6278,7 @@ type ProgramDigest struct {
 
 func (x *ProgramDigest) Reset() {
 	*x = ProgramDigest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[60]
+	mi := &file_containarium_v1_config_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4745,7 +6290,7 @@ func (x *ProgramDigest) String() string {
 func (*ProgramDigest) ProtoMessage() {}
 
 func (x *ProgramDigest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[60]
+	mi := &file_containarium_v1_config_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4758,7 +6303,7 @@ func (x *ProgramDigest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProgramDigest.ProtoReflect.Descriptor instead.
 func (*ProgramDigest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{60}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *ProgramDigest) GetName() string {
@@ -4788,7 +6333,7 @@ type GetSelfMeasurementRequest struct {
 
 func (x *GetSelfMeasurementRequest) Reset() {
 	*x = GetSelfMeasurementRequest{}
-	mi := &file_containarium_v1_config_proto_msgTypes[61]
+	mi := &file_containarium_v1_config_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4800,7 +6345,7 @@ func (x *GetSelfMeasurementRequest) String() string {
 func (*GetSelfMeasurementRequest) ProtoMessage() {}
 
 func (x *GetSelfMeasurementRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[61]
+	mi := &file_containarium_v1_config_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4813,7 +6358,7 @@ func (x *GetSelfMeasurementRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSelfMeasurementRequest.ProtoReflect.Descriptor instead.
 func (*GetSelfMeasurementRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{61}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *GetSelfMeasurementRequest) GetBackendId() string {
@@ -4835,7 +6380,7 @@ type GetSelfMeasurementResponse struct {
 
 func (x *GetSelfMeasurementResponse) Reset() {
 	*x = GetSelfMeasurementResponse{}
-	mi := &file_containarium_v1_config_proto_msgTypes[62]
+	mi := &file_containarium_v1_config_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4847,7 +6392,7 @@ func (x *GetSelfMeasurementResponse) String() string {
 func (*GetSelfMeasurementResponse) ProtoMessage() {}
 
 func (x *GetSelfMeasurementResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_config_proto_msgTypes[62]
+	mi := &file_containarium_v1_config_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4860,7 +6405,7 @@ func (x *GetSelfMeasurementResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSelfMeasurementResponse.ProtoReflect.Descriptor instead.
 func (*GetSelfMeasurementResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_config_proto_rawDescGZIP(), []int{62}
+	return file_containarium_v1_config_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *GetSelfMeasurementResponse) GetMeasurement() *SelfMeasurement {
@@ -5018,7 +6563,7 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12'\n" +
 	"\x0fcurrent_version\x18\x02 \x01(\tR\x0ecurrentVersion\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x12!\n" +
-	"\fcompleted_at\x18\x04 \x01(\tR\vcompletedAt\"\xdd\x02\n" +
+	"\fcompleted_at\x18\x04 \x01(\tR\vcompletedAt\"\xaa\x03\n" +
 	"\rNetworkPolicy\x12\x16\n" +
 	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12,\n" +
 	"\x12allow_intra_tenant\x18\x02 \x01(\bR\x10allowIntraTenant\x12!\n" +
@@ -5028,7 +6573,14 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"\x0eallow_metadata\x18\x06 \x01(\bR\rallowMetadata\x12\x16\n" +
 	"\x06source\x18\a \x01(\tR\x06source\x12E\n" +
 	"\n" +
-	"deny_rules\x18\b \x03(\v2&.containarium.v1.NetworkPolicyDenyRuleR\tdenyRules\"\x88\x01\n" +
+	"deny_rules\x18\b \x03(\v2&.containarium.v1.NetworkPolicyDenyRuleR\tdenyRules\x12K\n" +
+	"\fegress_rules\x18\t \x03(\v2(.containarium.v1.NetworkPolicyEgressRuleR\vegressRules\"\x8a\x01\n" +
+	"\x17NetworkPolicyEgressRule\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x14\n" +
+	"\x05proto\x18\x03 \x01(\tR\x05proto\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\rR\x04port\x12\x19\n" +
+	"\bport_end\x18\x05 \x01(\rR\aportEnd\"\x88\x01\n" +
 	"\x15NetworkPolicyDenyRule\x12\x12\n" +
 	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\rR\x04port\x12\x14\n" +
@@ -5053,7 +6605,96 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"\"PatchNetworkPolicyDenyRulesRequest\x12\x16\n" +
 	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x128\n" +
 	"\x03add\x18\x02 \x03(\v2&.containarium.v1.NetworkPolicyDenyRuleR\x03add\x12!\n" +
-	"\fremove_cidrs\x18\x03 \x03(\tR\vremoveCidrs\"\x84\x01\n" +
+	"\fremove_cidrs\x18\x03 \x03(\tR\vremoveCidrs\"K\n" +
+	"\x1bSuggestNetworkPolicyRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\tR\x05since\"\xff\x02\n" +
+	" NetworkPolicyObservedDestination\x12\x17\n" +
+	"\adest_ip\x18\x01 \x01(\tR\x06destIp\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x14\n" +
+	"\x05proto\x18\x03 \x01(\tR\x05proto\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\rR\x04port\x12\x14\n" +
+	"\x05flows\x18\x05 \x01(\x03R\x05flows\x12\x14\n" +
+	"\x05bytes\x18\x06 \x01(\x03R\x05bytes\x12\x1b\n" +
+	"\tlast_seen\x18\a \x01(\tR\blastSeen\x12\x1e\n" +
+	"\n" +
+	"containers\x18\b \x03(\tR\n" +
+	"containers\x12\x1f\n" +
+	"\vpeer_tenant\x18\t \x01(\tR\n" +
+	"peerTenant\x12\x18\n" +
+	"\aallowed\x18\n" +
+	" \x01(\bR\aallowed\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\x12\x14\n" +
+	"\x05entry\x18\f \x01(\tR\x05entry\x12\x12\n" +
+	"\x04rule\x18\r \x01(\tR\x04rule\x12\x1a\n" +
+	"\bexcluded\x18\x0e \x01(\tR\bexcluded\"\xd2\x02\n" +
+	"\x1cSuggestNetworkPolicyResponse\x12<\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x1e.containarium.v1.NetworkPolicyR\tcandidate\x128\n" +
+	"\acurrent\x18\x02 \x01(\v2\x1e.containarium.v1.NetworkPolicyR\acurrent\x12U\n" +
+	"\fdestinations\x18\x03 \x03(\v21.containarium.v1.NetworkPolicyObservedDestinationR\fdestinations\x12\x14\n" +
+	"\x05added\x18\x04 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x05 \x03(\tR\aremoved\x12\x1d\n" +
+	"\n" +
+	"would_deny\x18\x06 \x01(\x05R\twouldDeny\x12\x14\n" +
+	"\x05since\x18\a \x01(\tR\x05since\"\x8f\x01\n" +
+	"\x1cSimulateNetworkPolicyRequest\x126\n" +
+	"\x06policy\x18\x01 \x01(\v2\x1e.containarium.v1.NetworkPolicyR\x06policy\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\tR\x05since\x12!\n" +
+	"\fsample_limit\x18\x03 \x01(\x05R\vsampleLimit\"\x9d\x03\n" +
+	"\x1aNetworkPolicySimulatedFlow\x12\x17\n" +
+	"\adest_ip\x18\x01 \x01(\tR\x06destIp\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x14\n" +
+	"\x05proto\x18\x03 \x01(\tR\x05proto\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\rR\x04port\x12\x14\n" +
+	"\x05flows\x18\x05 \x01(\x03R\x05flows\x12\x14\n" +
+	"\x05bytes\x18\x06 \x01(\x03R\x05bytes\x12\x1b\n" +
+	"\tlast_seen\x18\a \x01(\tR\blastSeen\x12\x1e\n" +
+	"\n" +
+	"containers\x18\b \x03(\tR\n" +
+	"containers\x12\x1f\n" +
+	"\vpeer_tenant\x18\t \x01(\tR\n" +
+	"peerTenant\x12%\n" +
+	"\x0ecurrent_reason\x18\n" +
+	" \x01(\tR\rcurrentReason\x12#\n" +
+	"\rcurrent_entry\x18\v \x01(\tR\fcurrentEntry\x12'\n" +
+	"\x0fproposed_reason\x18\f \x01(\tR\x0eproposedReason\x12%\n" +
+	"\x0eproposed_entry\x18\r \x01(\tR\rproposedEntry\"\xc5\x05\n" +
+	"\x1dSimulateNetworkPolicyResponse\x126\n" +
+	"\x06policy\x18\x01 \x01(\v2\x1e.containarium.v1.NetworkPolicyR\x06policy\x128\n" +
+	"\acurrent\x18\x02 \x01(\v2\x1e.containarium.v1.NetworkPolicyR\acurrent\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\tR\x05since\x12\"\n" +
+	"\fdestinations\x18\x04 \x01(\x03R\fdestinations\x12\x14\n" +
+	"\x05flows\x18\x05 \x01(\x03R\x05flows\x12:\n" +
+	"\x19newly_denied_destinations\x18\x06 \x01(\x03R\x17newlyDeniedDestinations\x12,\n" +
+	"\x12newly_denied_flows\x18\a \x01(\x03R\x10newlyDeniedFlows\x12<\n" +
+	"\x1anewly_allowed_destinations\x18\b \x01(\x03R\x18newlyAllowedDestinations\x12.\n" +
+	"\x13newly_allowed_flows\x18\t \x01(\x03R\x11newlyAllowedFlows\x12:\n" +
+	"\x19still_denied_destinations\x18\n" +
+	" \x01(\x03R\x17stillDeniedDestinations\x12,\n" +
+	"\x12still_denied_flows\x18\v \x01(\x03R\x10stillDeniedFlows\x12N\n" +
+	"\fnewly_denied\x18\f \x03(\v2+.containarium.v1.NetworkPolicySimulatedFlowR\vnewlyDenied\x12P\n" +
+	"\rnewly_allowed\x18\r \x03(\v2+.containarium.v1.NetworkPolicySimulatedFlowR\fnewlyAllowed\"\x9c\x02\n" +
+	"\x10BoxNetworkPolicy\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12K\n" +
+	"\bselector\x18\x03 \x03(\v2/.containarium.v1.BoxNetworkPolicy.SelectorEntryR\bselector\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x126\n" +
+	"\x06policy\x18\x05 \x01(\v2\x1e.containarium.v1.NetworkPolicyR\x06policy\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\x1aSetBoxNetworkPolicyRequest\x129\n" +
+	"\x06policy\x18\x01 \x01(\v2!.containarium.v1.BoxNetworkPolicyR\x06policy\"X\n" +
+	"\x1bSetBoxNetworkPolicyResponse\x129\n" +
+	"\x06policy\x18\x01 \x01(\v2!.containarium.v1.BoxNetworkPolicyR\x06policy\"7\n" +
+	"\x1dListBoxNetworkPoliciesRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\"_\n" +
+	"\x1eListBoxNetworkPoliciesResponse\x12=\n" +
+	"\bpolicies\x18\x01 \x03(\v2!.containarium.v1.BoxNetworkPolicyR\bpolicies\"K\n" +
+	"\x1dDeleteBoxNetworkPolicyRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\" \n" +
+	"\x1eDeleteBoxNetworkPolicyResponse\"\x84\x01\n" +
 	"\x16NetworkPolicySignature\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\apattern\x18\x02 \x01(\tR\apattern\x12\x18\n" +
@@ -5071,7 +6712,31 @@ const file_containarium_v1_config_proto_rawDesc = "" +
 	"signatures\"9\n" +
 	"#DeleteNetworkPolicySignatureRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"&\n" +
-	"$DeleteNetworkPolicySignatureResponse\"\xb9\x04\n" +
+	"$DeleteNetworkPolicySignatureResponse\"\xb3\x02\n" +
+	"\aWAFRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x16\n" +
+	"\x06header\x18\x03 \x01(\tR\x06header\x12\x14\n" +
+	"\x05match\x18\x04 \x01(\tR\x05match\x12\x18\n" +
+	"\apattern\x18\x05 \x01(\tR\apattern\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x18\n" +
+	"\atenants\x18\a \x03(\tR\atenants\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x12\x0e\n" +
+	"\x02id\x18\t \x01(\rR\x02id\x12\x12\n" +
+	"\x04note\x18\n" +
+	" \x01(\tR\x04note\x12.\n" +
+	"\x13requests_per_minute\x18\v \x01(\x05R\x11requestsPerMinute\x12\x14\n" +
+	"\x05burst\x18\f \x01(\x05R\x05burst\"A\n" +
+	"\x11SetWAFRuleRequest\x12,\n" +
+	"\x04rule\x18\x01 \x01(\v2\x18.containarium.v1.WAFRuleR\x04rule\"B\n" +
+	"\x12SetWAFRuleResponse\x12,\n" +
+	"\x04rule\x18\x01 \x01(\v2\x18.containarium.v1.WAFRuleR\x04rule\"\x15\n" +
+	"\x13ListWAFRulesRequest\"F\n" +
+	"\x14ListWAFRulesResponse\x12.\n" +
+	"\x05rules\x18\x01 \x03(\v2\x18.containarium.v1.WAFRuleR\x05rules\"*\n" +
+	"\x14DeleteWAFRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x17\n" +
+	"\x15DeleteWAFRuleResponse\"\xb9\x04\n" +
 	"\vBackendInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
@@ -5274,7 +6939,7 @@ func file_containarium_v1_config_proto_rawDescGZIP() []byte {
 }
 
 var file_containarium_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_containarium_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
+var file_containarium_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 86)
 var file_containarium_v1_config_proto_goTypes = []any{
 	(StorageDriver)(0),                           // 0: containarium.v1.StorageDriver
 	(StorageIsolation)(0),                        // 1: containarium.v1.StorageIsolation
@@ -5306,57 +6971,79 @@ var file_containarium_v1_config_proto_goTypes = []any{
 	(*GetUpgradeStatusRequest)(nil),              // 27: containarium.v1.GetUpgradeStatusRequest
 	(*GetUpgradeStatusResponse)(nil),             // 28: containarium.v1.GetUpgradeStatusResponse
 	(*NetworkPolicy)(nil),                        // 29: containarium.v1.NetworkPolicy
-	(*NetworkPolicyDenyRule)(nil),                // 30: containarium.v1.NetworkPolicyDenyRule
-	(*SetNetworkPolicyRequest)(nil),              // 31: containarium.v1.SetNetworkPolicyRequest
-	(*SetNetworkPolicyResponse)(nil),             // 32: containarium.v1.SetNetworkPolicyResponse
-	(*GetNetworkPolicyRequest)(nil),              // 33: containarium.v1.GetNetworkPolicyRequest
-	(*GetNetworkPolicyResponse)(nil),             // 34: containarium.v1.GetNetworkPolicyResponse
-	(*ListNetworkPoliciesRequest)(nil),           // 35: containarium.v1.ListNetworkPoliciesRequest
-	(*ListNetworkPoliciesResponse)(nil),          // 36: containarium.v1.ListNetworkPoliciesResponse
-	(*DeleteNetworkPolicyRequest)(nil),           // 37: containarium.v1.DeleteNetworkPolicyRequest
-	(*DeleteNetworkPolicyResponse)(nil),          // 38: containarium.v1.DeleteNetworkPolicyResponse
-	(*PatchNetworkPolicyDenyRulesRequest)(nil),   // 39: containarium.v1.PatchNetworkPolicyDenyRulesRequest
-	(*NetworkPolicySignature)(nil),               // 40: containarium.v1.NetworkPolicySignature
-	(*SetNetworkPolicySignatureRequest)(nil),     // 41: containarium.v1.SetNetworkPolicySignatureRequest
-	(*SetNetworkPolicySignatureResponse)(nil),    // 42: containarium.v1.SetNetworkPolicySignatureResponse
-	(*ListNetworkPolicySignaturesRequest)(nil),   // 43: containarium.v1.ListNetworkPolicySignaturesRequest
-	(*ListNetworkPolicySignaturesResponse)(nil),  // 44: containarium.v1.ListNetworkPolicySignaturesResponse
-	(*DeleteNetworkPolicySignatureRequest)(nil),  // 45: containarium.v1.DeleteNetworkPolicySignatureRequest
-	(*DeleteNetworkPolicySignatureResponse)(nil), // 46: containarium.v1.DeleteNetworkPolicySignatureResponse
-	(*BackendInfo)(nil),                          // 47: containarium.v1.BackendInfo
-	(*HostLoad)(nil),                             // 48: containarium.v1.HostLoad
-	(*CapabilityProfile)(nil),                    // 49: containarium.v1.CapabilityProfile
-	(*CapabilityBenchmark)(nil),                  // 50: containarium.v1.CapabilityBenchmark
-	(*CapacityHeadroom)(nil),                     // 51: containarium.v1.CapacityHeadroom
-	(*CapacityPolicy)(nil),                       // 52: containarium.v1.CapacityPolicy
-	(*BackendGPU)(nil),                           // 53: containarium.v1.BackendGPU
-	(*ListBackendsRequest)(nil),                  // 54: containarium.v1.ListBackendsRequest
-	(*ListBackendsResponse)(nil),                 // 55: containarium.v1.ListBackendsResponse
-	(*AdvertiseCapacityRequest)(nil),             // 56: containarium.v1.AdvertiseCapacityRequest
-	(*AdvertiseCapacityResponse)(nil),            // 57: containarium.v1.AdvertiseCapacityResponse
-	(*WithdrawCapacityRequest)(nil),              // 58: containarium.v1.WithdrawCapacityRequest
-	(*WithdrawCapacityResponse)(nil),             // 59: containarium.v1.WithdrawCapacityResponse
-	(*GetCapacityHeadroomRequest)(nil),           // 60: containarium.v1.GetCapacityHeadroomRequest
-	(*GetCapacityHeadroomResponse)(nil),          // 61: containarium.v1.GetCapacityHeadroomResponse
-	(*ProfileBackendRequest)(nil),                // 62: containarium.v1.ProfileBackendRequest
-	(*ProfileBackendResponse)(nil),               // 63: containarium.v1.ProfileBackendResponse
-	(*GetCapabilityProfileRequest)(nil),          // 64: containarium.v1.GetCapabilityProfileRequest
-	(*GetCapabilityProfileResponse)(nil),         // 65: containarium.v1.GetCapabilityProfileResponse
-	(*SelfMeasurement)(nil),                      // 66: containarium.v1.SelfMeasurement
-	(*ProgramDigest)(nil),                        // 67: containarium.v1.ProgramDigest
-	(*GetSelfMeasurementRequest)(nil),            // 68: containarium.v1.GetSelfMeasurementRequest
-	(*GetSelfMeasurementResponse)(nil),           // 69: containarium.v1.GetSelfMeasurementResponse
-	nil,                                          // 70: containarium.v1.WithdrawCapacityResponse.FailedEntry
-	(*ResourceLimits)(nil),                       // 71: containarium.v1.ResourceLimits
-	(OSType)(0),                                  // 72: containarium.v1.OSType
+	(*NetworkPolicyEgressRule)(nil),              // 30: containarium.v1.NetworkPolicyEgressRule
+	(*NetworkPolicyDenyRule)(nil),                // 31: containarium.v1.NetworkPolicyDenyRule
+	(*SetNetworkPolicyRequest)(nil),              // 32: containarium.v1.SetNetworkPolicyRequest
+	(*SetNetworkPolicyResponse)(nil),             // 33: containarium.v1.SetNetworkPolicyResponse
+	(*GetNetworkPolicyRequest)(nil),              // 34: containarium.v1.GetNetworkPolicyRequest
+	(*GetNetworkPolicyResponse)(nil),             // 35: containarium.v1.GetNetworkPolicyResponse
+	(*ListNetworkPoliciesRequest)(nil),           // 36: containarium.v1.ListNetworkPoliciesRequest
+	(*ListNetworkPoliciesResponse)(nil),          // 37: containarium.v1.ListNetworkPoliciesResponse
+	(*DeleteNetworkPolicyRequest)(nil),           // 38: containarium.v1.DeleteNetworkPolicyRequest
+	(*DeleteNetworkPolicyResponse)(nil),          // 39: containarium.v1.DeleteNetworkPolicyResponse
+	(*PatchNetworkPolicyDenyRulesRequest)(nil),   // 40: containarium.v1.PatchNetworkPolicyDenyRulesRequest
+	(*SuggestNetworkPolicyRequest)(nil),          // 41: containarium.v1.SuggestNetworkPolicyRequest
+	(*NetworkPolicyObservedDestination)(nil),     // 42: containarium.v1.NetworkPolicyObservedDestination
+	(*SuggestNetworkPolicyResponse)(nil),         // 43: containarium.v1.SuggestNetworkPolicyResponse
+	(*SimulateNetworkPolicyRequest)(nil),         // 44: containarium.v1.SimulateNetworkPolicyRequest
+	(*NetworkPolicySimulatedFlow)(nil),           // 45: containarium.v1.NetworkPolicySimulatedFlow
+	(*SimulateNetworkPolicyResponse)(nil),        // 46: containarium.v1.SimulateNetworkPolicyResponse
+	(*BoxNetworkPolicy)(nil),                     // 47: containarium.v1.BoxNetworkPolicy
+	(*SetBoxNetworkPolicyRequest)(nil),           // 48: containarium.v1.SetBoxNetworkPolicyRequest
+	(*SetBoxNetworkPolicyResponse)(nil),          // 49: containarium.v1.SetBoxNetworkPolicyResponse
+	(*ListBoxNetworkPoliciesRequest)(nil),        // 50: containarium.v1.ListBoxNetworkPoliciesRequest
+	(*ListBoxNetworkPoliciesResponse)(nil),       // 51: containarium.v1.ListBoxNetworkPoliciesResponse
+	(*DeleteBoxNetworkPolicyRequest)(nil),        // 52: containarium.v1.DeleteBoxNetworkPolicyRequest
+	(*DeleteBoxNetworkPolicyResponse)(nil),       // 53: containarium.v1.DeleteBoxNetworkPolicyResponse
+	(*NetworkPolicySignature)(nil),               // 54: containarium.v1.NetworkPolicySignature
+	(*SetNetworkPolicySignatureRequest)(nil),     // 55: containarium.v1.SetNetworkPolicySignatureRequest
+	(*SetNetworkPolicySignatureResponse)(nil),    // 56: containarium.v1.SetNetworkPolicySignatureResponse
+	(*ListNetworkPolicySignaturesRequest)(nil),   // 57: containarium.v1.ListNetworkPolicySignaturesRequest
+	(*ListNetworkPolicySignaturesResponse)(nil),  // 58: containarium.v1.ListNetworkPolicySignaturesResponse
+	(*DeleteNetworkPolicySignatureRequest)(nil),  // 59: containarium.v1.DeleteNetworkPolicySignatureRequest
+	(*DeleteNetworkPolicySignatureResponse)(nil), // 60: containarium.v1.DeleteNetworkPolicySignatureResponse
+	(*WAFRule)(nil),                              // 61: containarium.v1.WAFRule
+	(*SetWAFRuleRequest)(nil),                    // 62: containarium.v1.SetWAFRuleRequest
+	(*SetWAFRuleResponse)(nil),                   // 63: containarium.v1.SetWAFRuleResponse
+	(*ListWAFRulesRequest)(nil),                  // 64: containarium.v1.ListWAFRulesRequest
+	(*ListWAFRulesResponse)(nil),                 // 65: containarium.v1.ListWAFRulesResponse
+	(*DeleteWAFRuleRequest)(nil),                 // 66: containarium.v1.DeleteWAFRuleRequest
+	(*DeleteWAFRuleResponse)(nil),                // 67: containarium.v1.DeleteWAFRuleResponse
+	(*BackendInfo)(nil),                          // 68: containarium.v1.BackendInfo
+	(*HostLoad)(nil),                             // 69: containarium.v1.HostLoad
+	(*CapabilityProfile)(nil),                    // 70: containarium.v1.CapabilityProfile
+	(*CapabilityBenchmark)(nil),                  // 71: containarium.v1.CapabilityBenchmark
+	(*CapacityHeadroom)(nil),                     // 72: containarium.v1.CapacityHeadroom
+	(*CapacityPolicy)(nil),                       // 73: containarium.v1.CapacityPolicy
+	(*BackendGPU)(nil),                           // 74: containarium.v1.BackendGPU
+	(*ListBackendsRequest)(nil),                  // 75: containarium.v1.ListBackendsRequest
+	(*ListBackendsResponse)(nil),                 // 76: containarium.v1.ListBackendsResponse
+	(*AdvertiseCapacityRequest)(nil),             // 77: containarium.v1.AdvertiseCapacityRequest
+	(*AdvertiseCapacityResponse)(nil),            // 78: containarium.v1.AdvertiseCapacityResponse
+	(*WithdrawCapacityRequest)(nil),              // 79: containarium.v1.WithdrawCapacityRequest
+	(*WithdrawCapacityResponse)(nil),             // 80: containarium.v1.WithdrawCapacityResponse
+	(*GetCapacityHeadroomRequest)(nil),           // 81: containarium.v1.GetCapacityHeadroomRequest
+	(*GetCapacityHeadroomResponse)(nil),          // 82: containarium.v1.GetCapacityHeadroomResponse
+	(*ProfileBackendRequest)(nil),                // 83: containarium.v1.ProfileBackendRequest
+	(*ProfileBackendResponse)(nil),               // 84: containarium.v1.ProfileBackendResponse
+	(*GetCapabilityProfileRequest)(nil),          // 85: containarium.v1.GetCapabilityProfileRequest
+	(*GetCapabilityProfileResponse)(nil),         // 86: containarium.v1.GetCapabilityProfileResponse
+	(*SelfMeasurement)(nil),                      // 87: containarium.v1.SelfMeasurement
+	(*ProgramDigest)(nil),                        // 88: containarium.v1.ProgramDigest
+	(*GetSelfMeasurementRequest)(nil),            // 89: containarium.v1.GetSelfMeasurementRequest
+	(*GetSelfMeasurementResponse)(nil),           // 90: containarium.v1.GetSelfMeasurementResponse
+	nil,                                          // 91: containarium.v1.BoxNetworkPolicy.SelectorEntry
+	nil,                                          // 92: containarium.v1.WithdrawCapacityResponse.FailedEntry
+	(*ResourceLimits)(nil),                       // 93: containarium.v1.ResourceLimits
+	(OSType)(0),                                  // 94: containarium.v1.OSType
 }
 var file_containarium_v1_config_proto_depIdxs = []int32{
 	8,  // 0: containarium.v1.Config.incus:type_name -> containarium.v1.IncusConfig
-	71, // 1: containarium.v1.Config.default_resources:type_name -> containarium.v1.ResourceLimits
+	93, // 1: containarium.v1.Config.default_resources:type_name -> containarium.v1.ResourceLimits
 	9,  // 2: containarium.v1.Config.network:type_name -> containarium.v1.NetworkConfig
 	10, // 3: containarium.v1.Config.storage:type_name -> containarium.v1.StorageConfig
 	11, // 4: containarium.v1.Config.security:type_name -> containarium.v1.SecurityConfig
-	72, // 5: containarium.v1.Config.default_os_type:type_name -> containarium.v1.OSType
+	94, // 5: containarium.v1.Config.default_os_type:type_name -> containarium.v1.OSType
 	7,  // 6: containarium.v1.GetConfigResponse.config:type_name -> containarium.v1.Config
 	7,  // 7: containarium.v1.UpdateConfigRequest.config:type_name -> containarium.v1.Config
 	7,  // 8: containarium.v1.UpdateConfigResponse.config:type_name -> containarium.v1.Config
@@ -5370,37 +7057,54 @@ var file_containarium_v1_config_proto_depIdxs = []int32{
 	16, // 16: containarium.v1.GetSystemInfoResponse.peers:type_name -> containarium.v1.SystemInfo
 	6,  // 17: containarium.v1.ValidateGPUResponse.status:type_name -> containarium.v1.ValidateGPUResponse.GPUStatus
 	4,  // 18: containarium.v1.NetworkPolicy.mode:type_name -> containarium.v1.NetworkPolicyMode
-	30, // 19: containarium.v1.NetworkPolicy.deny_rules:type_name -> containarium.v1.NetworkPolicyDenyRule
-	29, // 20: containarium.v1.SetNetworkPolicyRequest.policy:type_name -> containarium.v1.NetworkPolicy
-	29, // 21: containarium.v1.SetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
-	29, // 22: containarium.v1.GetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
-	29, // 23: containarium.v1.ListNetworkPoliciesResponse.policies:type_name -> containarium.v1.NetworkPolicy
-	30, // 24: containarium.v1.PatchNetworkPolicyDenyRulesRequest.add:type_name -> containarium.v1.NetworkPolicyDenyRule
-	40, // 25: containarium.v1.SetNetworkPolicySignatureRequest.signature:type_name -> containarium.v1.NetworkPolicySignature
-	40, // 26: containarium.v1.SetNetworkPolicySignatureResponse.signature:type_name -> containarium.v1.NetworkPolicySignature
-	40, // 27: containarium.v1.ListNetworkPolicySignaturesResponse.signatures:type_name -> containarium.v1.NetworkPolicySignature
-	53, // 28: containarium.v1.BackendInfo.gpus:type_name -> containarium.v1.BackendGPU
-	51, // 29: containarium.v1.BackendInfo.headroom:type_name -> containarium.v1.CapacityHeadroom
-	49, // 30: containarium.v1.BackendInfo.capability_profile:type_name -> containarium.v1.CapabilityProfile
-	48, // 31: containarium.v1.BackendInfo.host_load:type_name -> containarium.v1.HostLoad
-	17, // 32: containarium.v1.BackendInfo.storage:type_name -> containarium.v1.BackendStorage
-	50, // 33: containarium.v1.CapabilityProfile.benchmark:type_name -> containarium.v1.CapabilityBenchmark
-	52, // 34: containarium.v1.CapacityHeadroom.policy:type_name -> containarium.v1.CapacityPolicy
-	47, // 35: containarium.v1.ListBackendsResponse.backends:type_name -> containarium.v1.BackendInfo
-	52, // 36: containarium.v1.AdvertiseCapacityRequest.policy:type_name -> containarium.v1.CapacityPolicy
-	51, // 37: containarium.v1.AdvertiseCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
-	51, // 38: containarium.v1.WithdrawCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
-	70, // 39: containarium.v1.WithdrawCapacityResponse.failed:type_name -> containarium.v1.WithdrawCapacityResponse.FailedEntry
-	51, // 40: containarium.v1.GetCapacityHeadroomResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
-	49, // 41: containarium.v1.ProfileBackendResponse.profile:type_name -> containarium.v1.CapabilityProfile
-	49, // 42: containarium.v1.GetCapabilityProfileResponse.profile:type_name -> containarium.v1.CapabilityProfile
-	67, // 43: containarium.v1.SelfMeasurement.program_digests:type_name -> containarium.v1.ProgramDigest
-	66, // 44: containarium.v1.GetSelfMeasurementResponse.measurement:type_name -> containarium.v1.SelfMeasurement
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	45, // [45:45] is the sub-list for extension extendee
-	0,  // [0:45] is the sub-list for field type_name
+	31, // 19: containarium.v1.NetworkPolicy.deny_rules:type_name -> containarium.v1.NetworkPolicyDenyRule
+	30, // 20: containarium.v1.NetworkPolicy.egress_rules:type_name -> containarium.v1.NetworkPolicyEgressRule
+	29, // 21: containarium.v1.SetNetworkPolicyRequest.policy:type_name -> containarium.v1.NetworkPolicy
+	29, // 22: containarium.v1.SetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
+	29, // 23: containarium.v1.GetNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
+	29, // 24: containarium.v1.ListNetworkPoliciesResponse.policies:type_name -> containarium.v1.NetworkPolicy
+	31, // 25: containarium.v1.PatchNetworkPolicyDenyRulesRequest.add:type_name -> containarium.v1.NetworkPolicyDenyRule
+	29, // 26: containarium.v1.SuggestNetworkPolicyResponse.candidate:type_name -> containarium.v1.NetworkPolicy
+	29, // 27: containarium.v1.SuggestNetworkPolicyResponse.current:type_name -> containarium.v1.NetworkPolicy
+	42, // 28: containarium.v1.SuggestNetworkPolicyResponse.destinations:type_name -> containarium.v1.NetworkPolicyObservedDestination
+	29, // 29: containarium.v1.SimulateNetworkPolicyRequest.policy:type_name -> containarium.v1.NetworkPolicy
+	29, // 30: containarium.v1.SimulateNetworkPolicyResponse.policy:type_name -> containarium.v1.NetworkPolicy
+	29, // 31: containarium.v1.SimulateNetworkPolicyResponse.current:type_name -> containarium.v1.NetworkPolicy
+	45, // 32: containarium.v1.SimulateNetworkPolicyResponse.newly_denied:type_name -> containarium.v1.NetworkPolicySimulatedFlow
+	45, // 33: containarium.v1.SimulateNetworkPolicyResponse.newly_allowed:type_name -> containarium.v1.NetworkPolicySimulatedFlow
+	91, // 34: containarium.v1.BoxNetworkPolicy.selector:type_name -> containarium.v1.BoxNetworkPolicy.SelectorEntry
+	29, // 35: containarium.v1.BoxNetworkPolicy.policy:type_name -> containarium.v1.NetworkPolicy
+	47, // 36: containarium.v1.SetBoxNetworkPolicyRequest.policy:type_name -> containarium.v1.BoxNetworkPolicy
+	47, // 37: containarium.v1.SetBoxNetworkPolicyResponse.policy:type_name -> containarium.v1.BoxNetworkPolicy
+	47, // 38: containarium.v1.ListBoxNetworkPoliciesResponse.policies:type_name -> containarium.v1.BoxNetworkPolicy
+	54, // 39: containarium.v1.SetNetworkPolicySignatureRequest.signature:type_name -> containarium.v1.NetworkPolicySignature
+	54, // 40: containarium.v1.SetNetworkPolicySignatureResponse.signature:type_name -> containarium.v1.NetworkPolicySignature
+	54, // 41: containarium.v1.ListNetworkPolicySignaturesResponse.signatures:type_name -> containarium.v1.NetworkPolicySignature
+	61, // 42: containarium.v1.SetWAFRuleRequest.rule:type_name -> containarium.v1.WAFRule
+	61, // 43: containarium.v1.SetWAFRuleResponse.rule:type_name -> containarium.v1.WAFRule
+	61, // 44: containarium.v1.ListWAFRulesResponse.rules:type_name -> containarium.v1.WAFRule
+	74, // 45: containarium.v1.BackendInfo.gpus:type_name -> containarium.v1.BackendGPU
+	72, // 46: containarium.v1.BackendInfo.headroom:type_name -> containarium.v1.CapacityHeadroom
+	70, // 47: containarium.v1.BackendInfo.capability_profile:type_name -> containarium.v1.CapabilityProfile
+	69, // 48: containarium.v1.BackendInfo.host_load:type_name -> containarium.v1.HostLoad
+	17, // 49: containarium.v1.BackendInfo.storage:type_name -> containarium.v1.BackendStorage
+	71, // 50: containarium.v1.CapabilityProfile.benchmark:type_name -> containarium.v1.CapabilityBenchmark
+	73, // 51: containarium.v1.CapacityHeadroom.policy:type_name -> containarium.v1.CapacityPolicy
+	68, // 52: containarium.v1.ListBackendsResponse.backends:type_name -> containarium.v1.BackendInfo
+	73, // 53: containarium.v1.AdvertiseCapacityRequest.policy:type_name -> containarium.v1.CapacityPolicy
+	72, // 54: containarium.v1.AdvertiseCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
+	72, // 55: containarium.v1.WithdrawCapacityResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
+	92, // 56: containarium.v1.WithdrawCapacityResponse.failed:type_name -> containarium.v1.WithdrawCapacityResponse.FailedEntry
+	72, // 57: containarium.v1.GetCapacityHeadroomResponse.headroom:type_name -> containarium.v1.CapacityHeadroom
+	70, // 58: containarium.v1.ProfileBackendResponse.profile:type_name -> containarium.v1.CapabilityProfile
+	70, // 59: containarium.v1.GetCapabilityProfileResponse.profile:type_name -> containarium.v1.CapabilityProfile
+	88, // 60: containarium.v1.SelfMeasurement.program_digests:type_name -> containarium.v1.ProgramDigest
+	87, // 61: containarium.v1.GetSelfMeasurementResponse.measurement:type_name -> containarium.v1.SelfMeasurement
+	62, // [62:62] is the sub-list for method output_type
+	62, // [62:62] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_config_proto_init() }
@@ -5415,7 +7119,7 @@ func file_containarium_v1_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_config_proto_rawDesc), len(file_containarium_v1_config_proto_rawDesc)),
 			NumEnums:      7,
-			NumMessages:   64,
+			NumMessages:   86,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -463,6 +463,117 @@ func (CloudMetricsGroup) EnumDescriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{6}
 }
 
+// SnapshotFileType is what kind of filesystem object a snapshot path is.
+type SnapshotFileType int32
+
+const (
+	SnapshotFileType_SNAPSHOT_FILE_TYPE_UNSPECIFIED SnapshotFileType = 0
+	SnapshotFileType_SNAPSHOT_FILE_TYPE_FILE        SnapshotFileType = 1
+	SnapshotFileType_SNAPSHOT_FILE_TYPE_DIRECTORY   SnapshotFileType = 2
+	SnapshotFileType_SNAPSHOT_FILE_TYPE_SYMLINK     SnapshotFileType = 3
+	// A device, FIFO or socket.
+	SnapshotFileType_SNAPSHOT_FILE_TYPE_OTHER SnapshotFileType = 4
+)
+
+// Enum value maps for SnapshotFileType.
+var (
+	SnapshotFileType_name = map[int32]string{
+		0: "SNAPSHOT_FILE_TYPE_UNSPECIFIED",
+		1: "SNAPSHOT_FILE_TYPE_FILE",
+		2: "SNAPSHOT_FILE_TYPE_DIRECTORY",
+		3: "SNAPSHOT_FILE_TYPE_SYMLINK",
+		4: "SNAPSHOT_FILE_TYPE_OTHER",
+	}
+	SnapshotFileType_value = map[string]int32{
+		"SNAPSHOT_FILE_TYPE_UNSPECIFIED": 0,
+		"SNAPSHOT_FILE_TYPE_FILE":        1,
+		"SNAPSHOT_FILE_TYPE_DIRECTORY":   2,
+		"SNAPSHOT_FILE_TYPE_SYMLINK":     3,
+		"SNAPSHOT_FILE_TYPE_OTHER":       4,
+	}
+)
+
+func (x SnapshotFileType) Enum() *SnapshotFileType {
+	p := new(SnapshotFileType)
+	*p = x
+	return p
+}
+
+func (x SnapshotFileType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnapshotFileType) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_container_proto_enumTypes[7].Descriptor()
+}
+
+func (SnapshotFileType) Type() protoreflect.EnumType {
+	return &file_containarium_v1_container_proto_enumTypes[7]
+}
+
+func (x SnapshotFileType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SnapshotFileType.Descriptor instead.
+func (SnapshotFileType) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{7}
+}
+
+// SnapshotChangeKind is how a path differs between two states of a
+// container.
+type SnapshotChangeKind int32
+
+const (
+	SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_UNSPECIFIED SnapshotChangeKind = 0
+	SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_ADDED       SnapshotChangeKind = 1
+	SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_MODIFIED    SnapshotChangeKind = 2
+	SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_DELETED     SnapshotChangeKind = 3
+)
+
+// Enum value maps for SnapshotChangeKind.
+var (
+	SnapshotChangeKind_name = map[int32]string{
+		0: "SNAPSHOT_CHANGE_KIND_UNSPECIFIED",
+		1: "SNAPSHOT_CHANGE_KIND_ADDED",
+		2: "SNAPSHOT_CHANGE_KIND_MODIFIED",
+		3: "SNAPSHOT_CHANGE_KIND_DELETED",
+	}
+	SnapshotChangeKind_value = map[string]int32{
+		"SNAPSHOT_CHANGE_KIND_UNSPECIFIED": 0,
+		"SNAPSHOT_CHANGE_KIND_ADDED":       1,
+		"SNAPSHOT_CHANGE_KIND_MODIFIED":    2,
+		"SNAPSHOT_CHANGE_KIND_DELETED":     3,
+	}
+)
+
+func (x SnapshotChangeKind) Enum() *SnapshotChangeKind {
+	p := new(SnapshotChangeKind)
+	*p = x
+	return p
+}
+
+func (x SnapshotChangeKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnapshotChangeKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_containarium_v1_container_proto_enumTypes[8].Descriptor()
+}
+
+func (SnapshotChangeKind) Type() protoreflect.EnumType {
+	return &file_containarium_v1_container_proto_enumTypes[8]
+}
+
+func (x SnapshotChangeKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SnapshotChangeKind.Descriptor instead.
+func (SnapshotChangeKind) EnumDescriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{8}
+}
+
 // ResourceLimits defines resource constraints for a container
 type ResourceLimits struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -5450,34 +5561,46 @@ func (x *RollbackContainerSnapshotResponse) GetDestroyedSnapshots() []string {
 	return nil
 }
 
-// DeleteTenantStorageRequest tears down a departing tenant's encrypted
-// storage (#1343): the Incus storage pool, and the encrypted dataset it is
-// sourced at.
+// CloneContainerRequest forks a new container from a snapshot of an existing
+// one.
 //
-// Refused while the tenant still has containers — deleting the pool from
-// under a live box destroys its storage.
-type DeleteTenantStorageRequest struct {
+// The fork gets a fresh identity rather than a byte-for-byte copy of the
+// source's: its own user (new_username, renamed in place from the source's),
+// SSH keys, IP, secrets and labels. Everything else — installed packages,
+// the working tree, files in the home directory — is the snapshot's.
+type CloneContainerRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The tenant being offboarded.
-	Tenant        string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// The source container.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The source snapshot to fork from.
+	Snapshot string `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// The fork's username; its container is <new_username>-container. Must be
+	// in the source's tenant, or the caller must be an admin.
+	NewUsername string `protobuf:"bytes,3,opt,name=new_username,json=newUsername,proto3" json:"new_username,omitempty"`
+	// SSH public keys for the fork. Empty keeps the keys of the source's user
+	// as they were in the snapshot.
+	SshKeys []string `protobuf:"bytes,4,rep,name=ssh_keys,json=sshKeys,proto3" json:"ssh_keys,omitempty"`
+	// Labels to set on the fork, merged over the source's. The source's
+	// identity labels (its cloud container id) are never inherited.
+	Labels        map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTenantStorageRequest) Reset() {
-	*x = DeleteTenantStorageRequest{}
+func (x *CloneContainerRequest) Reset() {
+	*x = CloneContainerRequest{}
 	mi := &file_containarium_v1_container_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTenantStorageRequest) String() string {
+func (x *CloneContainerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTenantStorageRequest) ProtoMessage() {}
+func (*CloneContainerRequest) ProtoMessage() {}
 
-func (x *DeleteTenantStorageRequest) ProtoReflect() protoreflect.Message {
+func (x *CloneContainerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_containarium_v1_container_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -5489,47 +5612,69 @@ func (x *DeleteTenantStorageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTenantStorageRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTenantStorageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloneContainerRequest.ProtoReflect.Descriptor instead.
+func (*CloneContainerRequest) Descriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *DeleteTenantStorageRequest) GetTenant() string {
+func (x *CloneContainerRequest) GetUsername() string {
 	if x != nil {
-		return x.Tenant
+		return x.Username
 	}
 	return ""
 }
 
-// DeleteTenantStorageResponse records what was destroyed, so an operator has
-// a statement of what this removed rather than only that it succeeded.
-type DeleteTenantStorageResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Human-readable summary.
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	// The Incus storage pool that was deleted.
-	StoragePool string `protobuf:"bytes,2,opt,name=storage_pool,json=storagePool,proto3" json:"storage_pool,omitempty"`
-	// The ZFS dataset that was destroyed — the tenant's encryptionroot, and
-	// with it the only thing that could decrypt their data.
-	Dataset       string `protobuf:"bytes,3,opt,name=dataset,proto3" json:"dataset,omitempty"`
+func (x *CloneContainerRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *CloneContainerRequest) GetNewUsername() string {
+	if x != nil {
+		return x.NewUsername
+	}
+	return ""
+}
+
+func (x *CloneContainerRequest) GetSshKeys() []string {
+	if x != nil {
+		return x.SshKeys
+	}
+	return nil
+}
+
+func (x *CloneContainerRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CloneContainerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Container     *Container             `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SshCommand    string                 `protobuf:"bytes,3,opt,name=ssh_command,json=sshCommand,proto3" json:"ssh_command,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTenantStorageResponse) Reset() {
-	*x = DeleteTenantStorageResponse{}
+func (x *CloneContainerResponse) Reset() {
+	*x = CloneContainerResponse{}
 	mi := &file_containarium_v1_container_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTenantStorageResponse) String() string {
+func (x *CloneContainerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTenantStorageResponse) ProtoMessage() {}
+func (*CloneContainerResponse) ProtoMessage() {}
 
-func (x *DeleteTenantStorageResponse) ProtoReflect() protoreflect.Message {
+func (x *CloneContainerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_containarium_v1_container_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -5541,70 +5686,64 @@ func (x *DeleteTenantStorageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTenantStorageResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTenantStorageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloneContainerResponse.ProtoReflect.Descriptor instead.
+func (*CloneContainerResponse) Descriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *DeleteTenantStorageResponse) GetMessage() string {
+func (x *CloneContainerResponse) GetContainer() *Container {
 	if x != nil {
-		return x.Message
+		return x.Container
 	}
-	return ""
+	return nil
 }
 
-func (x *DeleteTenantStorageResponse) GetStoragePool() string {
+func (x *CloneContainerResponse) GetMessage() string {
 	if x != nil {
-		return x.StoragePool
+		return x.Message
 	}
 	return ""
 }
 
-func (x *DeleteTenantStorageResponse) GetDataset() string {
+func (x *CloneContainerResponse) GetSshCommand() string {
 	if x != nil {
-		return x.Dataset
+		return x.SshCommand
 	}
 	return ""
 }
 
-// RewrapContainerRequest rotates the key protecting a container's data
-// (#1204), driven by the control plane during a maintenance window.
-//
-// IMPORTANT: rotation is per-TENANT, not per-container. A tenant's
-// containers share one encryptionroot by design (#1199), so rewrapping the
-// container named here rotates the key for every container that tenant
-// owns. The RPC is named for a container because that is what a caller
-// holds; the response says how many containers were affected so the caller
-// cannot be surprised by it.
-type RewrapContainerRequest struct {
+// SnapshotExport is one exported snapshot stream at a target.
+type SnapshotExport struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The container whose tenant's key is being rotated.
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// The NEW key reference, JSON-encoded, already provisioned by whoever
-	// owns key custody. The daemon resolves it through its own KeyProvider —
-	// key material never crosses this wire, exactly as at create time.
-	//
-	// Rotation is control-plane-driven (design resolved decision #5): the
-	// daemon exposes the primitive and holds no rotation schedule of its own.
-	NewKeyRef     string `protobuf:"bytes,2,opt,name=new_key_ref,json=newKeyRef,proto3" json:"new_key_ref,omitempty"`
+	// The snapshot the stream materializes.
+	Snapshot string `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// The snapshot the stream is incremental from — the previous export in
+	// the chain — or empty for a full stream.
+	BaseSnapshot string `protobuf:"bytes,2,opt,name=base_snapshot,json=baseSnapshot,proto3" json:"base_snapshot,omitempty"`
+	// The stream's object URI at the target.
+	Object    string `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	SizeBytes int64  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sha256    string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// RFC3339.
+	ExportedAt    string `protobuf:"bytes,6,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RewrapContainerRequest) Reset() {
-	*x = RewrapContainerRequest{}
+func (x *SnapshotExport) Reset() {
+	*x = SnapshotExport{}
 	mi := &file_containarium_v1_container_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RewrapContainerRequest) String() string {
+func (x *SnapshotExport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RewrapContainerRequest) ProtoMessage() {}
+func (*SnapshotExport) ProtoMessage() {}
 
-func (x *RewrapContainerRequest) ProtoReflect() protoreflect.Message {
+func (x *SnapshotExport) ProtoReflect() protoreflect.Message {
 	mi := &file_containarium_v1_container_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -5616,54 +5755,84 @@ func (x *RewrapContainerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RewrapContainerRequest.ProtoReflect.Descriptor instead.
-func (*RewrapContainerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotExport.ProtoReflect.Descriptor instead.
+func (*SnapshotExport) Descriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *RewrapContainerRequest) GetUsername() string {
+func (x *SnapshotExport) GetSnapshot() string {
 	if x != nil {
-		return x.Username
+		return x.Snapshot
 	}
 	return ""
 }
 
-func (x *RewrapContainerRequest) GetNewKeyRef() string {
+func (x *SnapshotExport) GetBaseSnapshot() string {
 	if x != nil {
-		return x.NewKeyRef
+		return x.BaseSnapshot
 	}
 	return ""
 }
 
-// RewrapContainerResponse reports the outcome of a rotation.
-type RewrapContainerResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Human-readable summary.
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	// Every container now protected by the new key — the whole tenant, not
-	// just the one named in the request.
-	RewrappedContainers []string `protobuf:"bytes,2,rep,name=rewrapped_containers,json=rewrappedContainers,proto3" json:"rewrapped_containers,omitempty"`
-	// Whether the named container was running before the rotation and has
-	// been started again afterwards.
-	Restarted     bool `protobuf:"varint,3,opt,name=restarted,proto3" json:"restarted,omitempty"`
+func (x *SnapshotExport) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *SnapshotExport) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *SnapshotExport) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *SnapshotExport) GetExportedAt() string {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return ""
+}
+
+// ExportContainerSnapshotRequest ships a snapshot off the host.
+type ExportContainerSnapshotRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The snapshot to export.
+	Snapshot string `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Where the container's chain lives: s3://bucket/prefix,
+	// gs://bucket/prefix or file:///path. Streams are written under
+	// <target>/<username>-container/.
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// Send a full stream even when an incremental one is possible, so a
+	// restore of this snapshot or later needs nothing exported before it.
+	Full          bool `protobuf:"varint,4,opt,name=full,proto3" json:"full,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RewrapContainerResponse) Reset() {
-	*x = RewrapContainerResponse{}
+func (x *ExportContainerSnapshotRequest) Reset() {
+	*x = ExportContainerSnapshotRequest{}
 	mi := &file_containarium_v1_container_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RewrapContainerResponse) String() string {
+func (x *ExportContainerSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RewrapContainerResponse) ProtoMessage() {}
+func (*ExportContainerSnapshotRequest) ProtoMessage() {}
 
-func (x *RewrapContainerResponse) ProtoReflect() protoreflect.Message {
+func (x *ExportContainerSnapshotRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_containarium_v1_container_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -5675,69 +5844,65 @@ func (x *RewrapContainerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RewrapContainerResponse.ProtoReflect.Descriptor instead.
-func (*RewrapContainerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportContainerSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*ExportContainerSnapshotRequest) Descriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *RewrapContainerResponse) GetMessage() string {
+func (x *ExportContainerSnapshotRequest) GetUsername() string {
 	if x != nil {
-		return x.Message
+		return x.Username
 	}
 	return ""
 }
 
-func (x *RewrapContainerResponse) GetRewrappedContainers() []string {
+func (x *ExportContainerSnapshotRequest) GetSnapshot() string {
 	if x != nil {
-		return x.RewrappedContainers
+		return x.Snapshot
 	}
-	return nil
+	return ""
 }
 
-func (x *RewrapContainerResponse) GetRestarted() bool {
+func (x *ExportContainerSnapshotRequest) GetTarget() string {
 	if x != nil {
-		return x.Restarted
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ExportContainerSnapshotRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
 	}
 	return false
 }
 
-// PrepareEncryptedMigrationRequest asks a destination daemon whether it
-// can take over an encrypted container, BEFORE any data is copied.
-//
-// Only the key REFERENCE travels — never key material. The ref names a
-// key in whatever custody the destination is configured with; resolving
-// it is the destination's job with its own KeyProvider, which is the
-// whole point of the check.
-type PrepareEncryptedMigrationRequest struct {
+type ExportContainerSnapshotResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Username of the container about to be migrated.
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	// The tenant whose encryptionroot the container lives under. The
-	// destination provisions storage for this tenant, not for the
-	// container: a tenant's containers share one encryptionroot.
-	Tenant string `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
-	// The source's stored KeyRef, JSON-encoded exactly as it is held on
-	// the container's own metadata. An opaque reference (scheme + URI +
-	// metadata); it carries no key bytes.
-	KeyRef        string `protobuf:"bytes,3,opt,name=key_ref,json=keyRef,proto3" json:"key_ref,omitempty"`
+	// The stream for this snapshot — existing, if it had already been
+	// exported to the target.
+	Export *SnapshotExport `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	// Every export of the container to the target, oldest first.
+	Chain         []*SnapshotExport `protobuf:"bytes,2,rep,name=chain,proto3" json:"chain,omitempty"`
+	Message       string            `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PrepareEncryptedMigrationRequest) Reset() {
-	*x = PrepareEncryptedMigrationRequest{}
+func (x *ExportContainerSnapshotResponse) Reset() {
+	*x = ExportContainerSnapshotResponse{}
 	mi := &file_containarium_v1_container_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PrepareEncryptedMigrationRequest) String() string {
+func (x *ExportContainerSnapshotResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrepareEncryptedMigrationRequest) ProtoMessage() {}
+func (*ExportContainerSnapshotResponse) ProtoMessage() {}
 
-func (x *PrepareEncryptedMigrationRequest) ProtoReflect() protoreflect.Message {
+func (x *ExportContainerSnapshotResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_containarium_v1_container_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -5749,43 +5914,1523 @@ func (x *PrepareEncryptedMigrationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrepareEncryptedMigrationRequest.ProtoReflect.Descriptor instead.
-func (*PrepareEncryptedMigrationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportContainerSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*ExportContainerSnapshotResponse) Descriptor() ([]byte, []int) {
 	return file_containarium_v1_container_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *PrepareEncryptedMigrationRequest) GetUsername() string {
+func (x *ExportContainerSnapshotResponse) GetExport() *SnapshotExport {
 	if x != nil {
-		return x.Username
+		return x.Export
 	}
-	return ""
+	return nil
 }
 
-func (x *PrepareEncryptedMigrationRequest) GetTenant() string {
+func (x *ExportContainerSnapshotResponse) GetChain() []*SnapshotExport {
 	if x != nil {
-		return x.Tenant
+		return x.Chain
 	}
-	return ""
+	return nil
 }
 
-func (x *PrepareEncryptedMigrationRequest) GetKeyRef() string {
+func (x *ExportContainerSnapshotResponse) GetMessage() string {
 	if x != nil {
-		return x.KeyRef
+		return x.Message
 	}
 	return ""
 }
 
-// PrepareEncryptedMigrationResponse tells the source whether to proceed,
-// and where to put the container if so.
-type PrepareEncryptedMigrationResponse struct {
+// ImportContainerSnapshotRequest restores a container from exported
+// snapshots.
+//
+// The new container gets the exported one's files, limits, devices and
+// profiles, and a fresh IP and secrets. Both username and source_username
+// must be in the caller's tenant, or the caller must be an admin.
+type ImportContainerSnapshotRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Whether the destination resolved the KeyRef through its own key
-	// custody. False means the migration must not start: the container
-	// would arrive unopenable after a full data copy.
-	CanResolve bool `protobuf:"varint,1,opt,name=can_resolve,json=canResolve,proto3" json:"can_resolve,omitempty"`
-	// Why not, when can_resolve is false. Operator-facing.
-	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	// The destination storage pool the container must be copied into —
+	// The container to create; its container is <username>-container.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The target the chain was exported to.
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// The exported container's username. Empty means username — restoring a
+	// box under its own name.
+	SourceUsername string `protobuf:"bytes,3,opt,name=source_username,json=sourceUsername,proto3" json:"source_username,omitempty"`
+	// The exported snapshot to restore. Empty restores the newest.
+	Snapshot string `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// The backend to restore onto. Empty means this daemon.
+	BackendId string `protobuf:"bytes,5,opt,name=backend_id,json=backendId,proto3" json:"backend_id,omitempty"`
+	// SSH public keys for the restored container. Empty keeps the keys as
+	// they were in the snapshot.
+	SshKeys       []string `protobuf:"bytes,6,rep,name=ssh_keys,json=sshKeys,proto3" json:"ssh_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportContainerSnapshotRequest) Reset() {
+	*x = ImportContainerSnapshotRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportContainerSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportContainerSnapshotRequest) ProtoMessage() {}
+
+func (x *ImportContainerSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportContainerSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*ImportContainerSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ImportContainerSnapshotRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotRequest) GetSourceUsername() string {
+	if x != nil {
+		return x.SourceUsername
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotRequest) GetBackendId() string {
+	if x != nil {
+		return x.BackendId
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotRequest) GetSshKeys() []string {
+	if x != nil {
+		return x.SshKeys
+	}
+	return nil
+}
+
+type ImportContainerSnapshotResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Container  *Container             `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Message    string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SshCommand string                 `protobuf:"bytes,3,opt,name=ssh_command,json=sshCommand,proto3" json:"ssh_command,omitempty"`
+	// The snapshot the container was restored to.
+	Snapshot      string `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportContainerSnapshotResponse) Reset() {
+	*x = ImportContainerSnapshotResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportContainerSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportContainerSnapshotResponse) ProtoMessage() {}
+
+func (x *ImportContainerSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportContainerSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*ImportContainerSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ImportContainerSnapshotResponse) GetContainer() *Container {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+func (x *ImportContainerSnapshotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotResponse) GetSshCommand() string {
+	if x != nil {
+		return x.SshCommand
+	}
+	return ""
+}
+
+func (x *ImportContainerSnapshotResponse) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+// SnapshotFileEntry is one entry of a directory in a snapshot.
+type SnapshotFileEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      SnapshotFileType       `protobuf:"varint,2,opt,name=type,proto3,enum=containarium.v1.SnapshotFileType" json:"type,omitempty"`
+	SizeBytes int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Permission bits, as in stat(2)'s st_mode & 07777.
+	Mode uint32 `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	// RFC3339.
+	ModifiedAt string `protobuf:"bytes,5,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	// Where a symlink points, as stored. Empty for anything else.
+	LinkTarget    string `protobuf:"bytes,6,opt,name=link_target,json=linkTarget,proto3" json:"link_target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotFileEntry) Reset() {
+	*x = SnapshotFileEntry{}
+	mi := &file_containarium_v1_container_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotFileEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotFileEntry) ProtoMessage() {}
+
+func (x *SnapshotFileEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotFileEntry.ProtoReflect.Descriptor instead.
+func (*SnapshotFileEntry) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SnapshotFileEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SnapshotFileEntry) GetType() SnapshotFileType {
+	if x != nil {
+		return x.Type
+	}
+	return SnapshotFileType_SNAPSHOT_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *SnapshotFileEntry) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *SnapshotFileEntry) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *SnapshotFileEntry) GetModifiedAt() string {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return ""
+}
+
+func (x *SnapshotFileEntry) GetLinkTarget() string {
+	if x != nil {
+		return x.LinkTarget
+	}
+	return ""
+}
+
+// ListContainerSnapshotFilesRequest lists a directory as it was in a
+// snapshot.
+type ListContainerSnapshotFilesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Snapshot string                 `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// A path inside the container, e.g. "/home/alice". Empty means "/".
+	Path          string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainerSnapshotFilesRequest) Reset() {
+	*x = ListContainerSnapshotFilesRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainerSnapshotFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainerSnapshotFilesRequest) ProtoMessage() {}
+
+func (x *ListContainerSnapshotFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainerSnapshotFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListContainerSnapshotFilesRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListContainerSnapshotFilesRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListContainerSnapshotFilesRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *ListContainerSnapshotFilesRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListContainerSnapshotFilesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The listed directory, cleaned.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Sorted by name.
+	Entries       []*SnapshotFileEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainerSnapshotFilesResponse) Reset() {
+	*x = ListContainerSnapshotFilesResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainerSnapshotFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainerSnapshotFilesResponse) ProtoMessage() {}
+
+func (x *ListContainerSnapshotFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainerSnapshotFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListContainerSnapshotFilesResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListContainerSnapshotFilesResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ListContainerSnapshotFilesResponse) GetEntries() []*SnapshotFileEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// ReadContainerSnapshotFileRequest reads a regular file as it was in a
+// snapshot, a range at a time.
+type ReadContainerSnapshotFileRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Snapshot string                 `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// A path inside the container, e.g. "/home/alice/notes.txt".
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Where to start reading.
+	Offset int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// How many bytes to read. 0 or more than 4 MiB reads 4 MiB.
+	Length        int64 `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadContainerSnapshotFileRequest) Reset() {
+	*x = ReadContainerSnapshotFileRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadContainerSnapshotFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadContainerSnapshotFileRequest) ProtoMessage() {}
+
+func (x *ReadContainerSnapshotFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadContainerSnapshotFileRequest.ProtoReflect.Descriptor instead.
+func (*ReadContainerSnapshotFileRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ReadContainerSnapshotFileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ReadContainerSnapshotFileRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *ReadContainerSnapshotFileRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ReadContainerSnapshotFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadContainerSnapshotFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type ReadContainerSnapshotFileResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Content []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// The offset content starts at.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The whole file's size.
+	SizeBytes int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Set when content reaches the end of the file.
+	Eof           bool `protobuf:"varint,4,opt,name=eof,proto3" json:"eof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadContainerSnapshotFileResponse) Reset() {
+	*x = ReadContainerSnapshotFileResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadContainerSnapshotFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadContainerSnapshotFileResponse) ProtoMessage() {}
+
+func (x *ReadContainerSnapshotFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadContainerSnapshotFileResponse.ProtoReflect.Descriptor instead.
+func (*ReadContainerSnapshotFileResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ReadContainerSnapshotFileResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ReadContainerSnapshotFileResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadContainerSnapshotFileResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ReadContainerSnapshotFileResponse) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+// SnapshotFileChange is one path that differs. A rename is reported as the
+// old path deleted and the new one added.
+type SnapshotFileChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Kind  SnapshotChangeKind     `protobuf:"varint,2,opt,name=kind,proto3,enum=containarium.v1.SnapshotChangeKind" json:"kind,omitempty"`
+	Type  SnapshotFileType       `protobuf:"varint,3,opt,name=type,proto3,enum=containarium.v1.SnapshotFileType" json:"type,omitempty"`
+	// The size before the change. 0 for an added path.
+	OldSizeBytes int64 `protobuf:"varint,4,opt,name=old_size_bytes,json=oldSizeBytes,proto3" json:"old_size_bytes,omitempty"`
+	// The size after the change. 0 for a deleted path.
+	NewSizeBytes  int64 `protobuf:"varint,5,opt,name=new_size_bytes,json=newSizeBytes,proto3" json:"new_size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotFileChange) Reset() {
+	*x = SnapshotFileChange{}
+	mi := &file_containarium_v1_container_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotFileChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotFileChange) ProtoMessage() {}
+
+func (x *SnapshotFileChange) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotFileChange.ProtoReflect.Descriptor instead.
+func (*SnapshotFileChange) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *SnapshotFileChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SnapshotFileChange) GetKind() SnapshotChangeKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SnapshotChangeKind_SNAPSHOT_CHANGE_KIND_UNSPECIFIED
+}
+
+func (x *SnapshotFileChange) GetType() SnapshotFileType {
+	if x != nil {
+		return x.Type
+	}
+	return SnapshotFileType_SNAPSHOT_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *SnapshotFileChange) GetOldSizeBytes() int64 {
+	if x != nil {
+		return x.OldSizeBytes
+	}
+	return 0
+}
+
+func (x *SnapshotFileChange) GetNewSizeBytes() int64 {
+	if x != nil {
+		return x.NewSizeBytes
+	}
+	return 0
+}
+
+// DiffContainerSnapshotsRequest compares a snapshot with a later snapshot
+// of the same container, or with the container as it is now.
+type DiffContainerSnapshotsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The earlier state.
+	FromSnapshot string `protobuf:"bytes,2,opt,name=from_snapshot,json=fromSnapshot,proto3" json:"from_snapshot,omitempty"`
+	// The later state. Empty compares with the live container.
+	ToSnapshot    string `protobuf:"bytes,3,opt,name=to_snapshot,json=toSnapshot,proto3" json:"to_snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffContainerSnapshotsRequest) Reset() {
+	*x = DiffContainerSnapshotsRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffContainerSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffContainerSnapshotsRequest) ProtoMessage() {}
+
+func (x *DiffContainerSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffContainerSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*DiffContainerSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *DiffContainerSnapshotsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *DiffContainerSnapshotsRequest) GetFromSnapshot() string {
+	if x != nil {
+		return x.FromSnapshot
+	}
+	return ""
+}
+
+func (x *DiffContainerSnapshotsRequest) GetToSnapshot() string {
+	if x != nil {
+		return x.ToSnapshot
+	}
+	return ""
+}
+
+type DiffContainerSnapshotsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sorted by path. Directories whose only change is their contents are
+	// left out; the contents that changed are listed themselves.
+	Changes  []*SnapshotFileChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Added    int32                 `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	Modified int32                 `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	Deleted  int32                 `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Set when there were more changes than one response carries; the
+	// counts above cover only the changes listed.
+	Truncated     bool `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffContainerSnapshotsResponse) Reset() {
+	*x = DiffContainerSnapshotsResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffContainerSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffContainerSnapshotsResponse) ProtoMessage() {}
+
+func (x *DiffContainerSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffContainerSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*DiffContainerSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *DiffContainerSnapshotsResponse) GetChanges() []*SnapshotFileChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *DiffContainerSnapshotsResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *DiffContainerSnapshotsResponse) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *DiffContainerSnapshotsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *DiffContainerSnapshotsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// ContainerSnapshotPolicy schedules a container's snapshots and bounds how
+// many are kept. Exactly one of interval_seconds and cron is set, and at
+// least one keep_* count: a schedule that never prunes is a pool that fills.
+//
+// The scheduler names its snapshots "auto-<UTC timestamp>" and retention
+// counts and prunes only those, so a snapshot taken by hand is never pruned.
+type ContainerSnapshotPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Take a snapshot every this many seconds (minimum 300).
+	IntervalSeconds int64 `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	// Or: a five-field cron expression, evaluated in UTC.
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Keep the newest N scheduled snapshots.
+	KeepLast int32 `protobuf:"varint,3,opt,name=keep_last,json=keepLast,proto3" json:"keep_last,omitempty"`
+	// Keep the newest scheduled snapshot of each of the last N days.
+	KeepDaily int32 `protobuf:"varint,4,opt,name=keep_daily,json=keepDaily,proto3" json:"keep_daily,omitempty"`
+	// Keep the newest scheduled snapshot of each of the last N ISO weeks.
+	KeepWeekly int32 `protobuf:"varint,5,opt,name=keep_weekly,json=keepWeekly,proto3" json:"keep_weekly,omitempty"`
+	// Run inside the container (as root, via /bin/sh -c) before each
+	// snapshot — flush a database, `fsfreeze -f` a mounted volume. A non-zero
+	// exit, or running past five minutes, skips that snapshot.
+	PreCommand string `protobuf:"bytes,6,opt,name=pre_command,json=preCommand,proto3" json:"pre_command,omitempty"`
+	// Run inside the container after each snapshot attempt, whether or not it
+	// or pre_command succeeded — the thaw. Also limited to five minutes.
+	PostCommand string `protobuf:"bytes,7,opt,name=post_command,json=postCommand,proto3" json:"post_command,omitempty"`
+	// Pause every process in the container for the instant of the snapshot.
+	Freeze bool `protobuf:"varint,8,opt,name=freeze,proto3" json:"freeze,omitempty"`
+	// When the policy was last set (RFC3339). Output only.
+	UpdatedAt string `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// When the next scheduled snapshot is due (RFC3339). Output only.
+	NextRunAt     string `protobuf:"bytes,10,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerSnapshotPolicy) Reset() {
+	*x = ContainerSnapshotPolicy{}
+	mi := &file_containarium_v1_container_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerSnapshotPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerSnapshotPolicy) ProtoMessage() {}
+
+func (x *ContainerSnapshotPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerSnapshotPolicy.ProtoReflect.Descriptor instead.
+func (*ContainerSnapshotPolicy) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ContainerSnapshotPolicy) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *ContainerSnapshotPolicy) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotPolicy) GetKeepLast() int32 {
+	if x != nil {
+		return x.KeepLast
+	}
+	return 0
+}
+
+func (x *ContainerSnapshotPolicy) GetKeepDaily() int32 {
+	if x != nil {
+		return x.KeepDaily
+	}
+	return 0
+}
+
+func (x *ContainerSnapshotPolicy) GetKeepWeekly() int32 {
+	if x != nil {
+		return x.KeepWeekly
+	}
+	return 0
+}
+
+func (x *ContainerSnapshotPolicy) GetPreCommand() string {
+	if x != nil {
+		return x.PreCommand
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotPolicy) GetPostCommand() string {
+	if x != nil {
+		return x.PostCommand
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotPolicy) GetFreeze() bool {
+	if x != nil {
+		return x.Freeze
+	}
+	return false
+}
+
+func (x *ContainerSnapshotPolicy) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotPolicy) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+type SetContainerSnapshotPolicyRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Username      string                   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Policy        *ContainerSnapshotPolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetContainerSnapshotPolicyRequest) Reset() {
+	*x = SetContainerSnapshotPolicyRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetContainerSnapshotPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerSnapshotPolicyRequest) ProtoMessage() {}
+
+func (x *SetContainerSnapshotPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerSnapshotPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetContainerSnapshotPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *SetContainerSnapshotPolicyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SetContainerSnapshotPolicyRequest) GetPolicy() *ContainerSnapshotPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetContainerSnapshotPolicyResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Policy        *ContainerSnapshotPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetContainerSnapshotPolicyResponse) Reset() {
+	*x = SetContainerSnapshotPolicyResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetContainerSnapshotPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerSnapshotPolicyResponse) ProtoMessage() {}
+
+func (x *SetContainerSnapshotPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerSnapshotPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetContainerSnapshotPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *SetContainerSnapshotPolicyResponse) GetPolicy() *ContainerSnapshotPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type GetContainerSnapshotPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerSnapshotPolicyRequest) Reset() {
+	*x = GetContainerSnapshotPolicyRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerSnapshotPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerSnapshotPolicyRequest) ProtoMessage() {}
+
+func (x *GetContainerSnapshotPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerSnapshotPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerSnapshotPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetContainerSnapshotPolicyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetContainerSnapshotPolicyResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Policy        *ContainerSnapshotPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerSnapshotPolicyResponse) Reset() {
+	*x = GetContainerSnapshotPolicyResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerSnapshotPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerSnapshotPolicyResponse) ProtoMessage() {}
+
+func (x *GetContainerSnapshotPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerSnapshotPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerSnapshotPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GetContainerSnapshotPolicyResponse) GetPolicy() *ContainerSnapshotPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type DeleteContainerSnapshotPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContainerSnapshotPolicyRequest) Reset() {
+	*x = DeleteContainerSnapshotPolicyRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContainerSnapshotPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContainerSnapshotPolicyRequest) ProtoMessage() {}
+
+func (x *DeleteContainerSnapshotPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContainerSnapshotPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteContainerSnapshotPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *DeleteContainerSnapshotPolicyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type DeleteContainerSnapshotPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContainerSnapshotPolicyResponse) Reset() {
+	*x = DeleteContainerSnapshotPolicyResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContainerSnapshotPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContainerSnapshotPolicyResponse) ProtoMessage() {}
+
+func (x *DeleteContainerSnapshotPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContainerSnapshotPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteContainerSnapshotPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *DeleteContainerSnapshotPolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// DeleteTenantStorageRequest tears down a departing tenant's encrypted
+// storage (#1343): the Incus storage pool, and the encrypted dataset it is
+// sourced at.
+//
+// Refused while the tenant still has containers — deleting the pool from
+// under a live box destroys its storage.
+type DeleteTenantStorageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The tenant being offboarded.
+	Tenant        string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantStorageRequest) Reset() {
+	*x = DeleteTenantStorageRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantStorageRequest) ProtoMessage() {}
+
+func (x *DeleteTenantStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantStorageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantStorageRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DeleteTenantStorageRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// DeleteTenantStorageResponse records what was destroyed, so an operator has
+// a statement of what this removed rather than only that it succeeded.
+type DeleteTenantStorageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable summary.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The Incus storage pool that was deleted.
+	StoragePool string `protobuf:"bytes,2,opt,name=storage_pool,json=storagePool,proto3" json:"storage_pool,omitempty"`
+	// The ZFS dataset that was destroyed — the tenant's encryptionroot, and
+	// with it the only thing that could decrypt their data.
+	Dataset       string `protobuf:"bytes,3,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantStorageResponse) Reset() {
+	*x = DeleteTenantStorageResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantStorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantStorageResponse) ProtoMessage() {}
+
+func (x *DeleteTenantStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantStorageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTenantStorageResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *DeleteTenantStorageResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteTenantStorageResponse) GetStoragePool() string {
+	if x != nil {
+		return x.StoragePool
+	}
+	return ""
+}
+
+func (x *DeleteTenantStorageResponse) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+// RewrapContainerRequest rotates the key protecting a container's data
+// (#1204), driven by the control plane during a maintenance window.
+//
+// IMPORTANT: rotation is per-TENANT, not per-container. A tenant's
+// containers share one encryptionroot by design (#1199), so rewrapping the
+// container named here rotates the key for every container that tenant
+// owns. The RPC is named for a container because that is what a caller
+// holds; the response says how many containers were affected so the caller
+// cannot be surprised by it.
+type RewrapContainerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The container whose tenant's key is being rotated.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The NEW key reference, JSON-encoded, already provisioned by whoever
+	// owns key custody. The daemon resolves it through its own KeyProvider —
+	// key material never crosses this wire, exactly as at create time.
+	//
+	// Rotation is control-plane-driven (design resolved decision #5): the
+	// daemon exposes the primitive and holds no rotation schedule of its own.
+	NewKeyRef     string `protobuf:"bytes,2,opt,name=new_key_ref,json=newKeyRef,proto3" json:"new_key_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewrapContainerRequest) Reset() {
+	*x = RewrapContainerRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewrapContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewrapContainerRequest) ProtoMessage() {}
+
+func (x *RewrapContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewrapContainerRequest.ProtoReflect.Descriptor instead.
+func (*RewrapContainerRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *RewrapContainerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RewrapContainerRequest) GetNewKeyRef() string {
+	if x != nil {
+		return x.NewKeyRef
+	}
+	return ""
+}
+
+// RewrapContainerResponse reports the outcome of a rotation.
+type RewrapContainerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human-readable summary.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Every container now protected by the new key — the whole tenant, not
+	// just the one named in the request.
+	RewrappedContainers []string `protobuf:"bytes,2,rep,name=rewrapped_containers,json=rewrappedContainers,proto3" json:"rewrapped_containers,omitempty"`
+	// Whether the named container was running before the rotation and has
+	// been started again afterwards.
+	Restarted     bool `protobuf:"varint,3,opt,name=restarted,proto3" json:"restarted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewrapContainerResponse) Reset() {
+	*x = RewrapContainerResponse{}
+	mi := &file_containarium_v1_container_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewrapContainerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewrapContainerResponse) ProtoMessage() {}
+
+func (x *RewrapContainerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewrapContainerResponse.ProtoReflect.Descriptor instead.
+func (*RewrapContainerResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RewrapContainerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RewrapContainerResponse) GetRewrappedContainers() []string {
+	if x != nil {
+		return x.RewrappedContainers
+	}
+	return nil
+}
+
+func (x *RewrapContainerResponse) GetRestarted() bool {
+	if x != nil {
+		return x.Restarted
+	}
+	return false
+}
+
+// PrepareEncryptedMigrationRequest asks a destination daemon whether it
+// can take over an encrypted container, BEFORE any data is copied.
+//
+// Only the key REFERENCE travels — never key material. The ref names a
+// key in whatever custody the destination is configured with; resolving
+// it is the destination's job with its own KeyProvider, which is the
+// whole point of the check.
+type PrepareEncryptedMigrationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Username of the container about to be migrated.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The tenant whose encryptionroot the container lives under. The
+	// destination provisions storage for this tenant, not for the
+	// container: a tenant's containers share one encryptionroot.
+	Tenant string `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// The source's stored KeyRef, JSON-encoded exactly as it is held on
+	// the container's own metadata. An opaque reference (scheme + URI +
+	// metadata); it carries no key bytes.
+	KeyRef        string `protobuf:"bytes,3,opt,name=key_ref,json=keyRef,proto3" json:"key_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrepareEncryptedMigrationRequest) Reset() {
+	*x = PrepareEncryptedMigrationRequest{}
+	mi := &file_containarium_v1_container_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareEncryptedMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareEncryptedMigrationRequest) ProtoMessage() {}
+
+func (x *PrepareEncryptedMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_container_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareEncryptedMigrationRequest.ProtoReflect.Descriptor instead.
+func (*PrepareEncryptedMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *PrepareEncryptedMigrationRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PrepareEncryptedMigrationRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *PrepareEncryptedMigrationRequest) GetKeyRef() string {
+	if x != nil {
+		return x.KeyRef
+	}
+	return ""
+}
+
+// PrepareEncryptedMigrationResponse tells the source whether to proceed,
+// and where to put the container if so.
+type PrepareEncryptedMigrationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the destination resolved the KeyRef through its own key
+	// custody. False means the migration must not start: the container
+	// would arrive unopenable after a full data copy.
+	CanResolve bool `protobuf:"varint,1,opt,name=can_resolve,json=canResolve,proto3" json:"can_resolve,omitempty"`
+	// Why not, when can_resolve is false. Operator-facing.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The destination storage pool the container must be copied into —
 	// the pool sourced at this tenant's encryptionroot. A copy that
 	// ignores it lands on the destination's default pool, outside the
 	// tenant's encryptionroot, and the container arrives unencrypted
@@ -5797,7 +7442,7 @@ type PrepareEncryptedMigrationResponse struct {
 
 func (x *PrepareEncryptedMigrationResponse) Reset() {
 	*x = PrepareEncryptedMigrationResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[74]
+	mi := &file_containarium_v1_container_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5809,7 +7454,7 @@ func (x *PrepareEncryptedMigrationResponse) String() string {
 func (*PrepareEncryptedMigrationResponse) ProtoMessage() {}
 
 func (x *PrepareEncryptedMigrationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[74]
+	mi := &file_containarium_v1_container_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5822,7 +7467,7 @@ func (x *PrepareEncryptedMigrationResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PrepareEncryptedMigrationResponse.ProtoReflect.Descriptor instead.
 func (*PrepareEncryptedMigrationResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{74}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *PrepareEncryptedMigrationResponse) GetCanResolve() bool {
@@ -5861,7 +7506,7 @@ type AdoptMigratedContainerResponse struct {
 
 func (x *AdoptMigratedContainerResponse) Reset() {
 	*x = AdoptMigratedContainerResponse{}
-	mi := &file_containarium_v1_container_proto_msgTypes[75]
+	mi := &file_containarium_v1_container_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5873,7 +7518,7 @@ func (x *AdoptMigratedContainerResponse) String() string {
 func (*AdoptMigratedContainerResponse) ProtoMessage() {}
 
 func (x *AdoptMigratedContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_container_proto_msgTypes[75]
+	mi := &file_containarium_v1_container_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5886,7 +7531,7 @@ func (x *AdoptMigratedContainerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdoptMigratedContainerResponse.ProtoReflect.Descriptor instead.
 func (*AdoptMigratedContainerResponse) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_container_proto_rawDescGZIP(), []int{75}
+	return file_containarium_v1_container_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *AdoptMigratedContainerResponse) GetMessage() string {
@@ -6291,7 +7936,128 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"!RollbackContainerSnapshotResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12+\n" +
 	"\x11container_stopped\x18\x02 \x01(\bR\x10containerStopped\x12/\n" +
-	"\x13destroyed_snapshots\x18\x03 \x03(\tR\x12destroyedSnapshots\"4\n" +
+	"\x13destroyed_snapshots\x18\x03 \x03(\tR\x12destroyedSnapshots\"\x94\x02\n" +
+	"\x15CloneContainerRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bsnapshot\x18\x02 \x01(\tR\bsnapshot\x12!\n" +
+	"\fnew_username\x18\x03 \x01(\tR\vnewUsername\x12\x19\n" +
+	"\bssh_keys\x18\x04 \x03(\tR\asshKeys\x12J\n" +
+	"\x06labels\x18\x05 \x03(\v22.containarium.v1.CloneContainerRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x16CloneContainerResponse\x128\n" +
+	"\tcontainer\x18\x01 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vssh_command\x18\x03 \x01(\tR\n" +
+	"sshCommand\"\xc1\x01\n" +
+	"\x0eSnapshotExport\x12\x1a\n" +
+	"\bsnapshot\x18\x01 \x01(\tR\bsnapshot\x12#\n" +
+	"\rbase_snapshot\x18\x02 \x01(\tR\fbaseSnapshot\x12\x16\n" +
+	"\x06object\x18\x03 \x01(\tR\x06object\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12\x1f\n" +
+	"\vexported_at\x18\x06 \x01(\tR\n" +
+	"exportedAt\"\x84\x01\n" +
+	"\x1eExportContainerSnapshotRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bsnapshot\x18\x02 \x01(\tR\bsnapshot\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x12\n" +
+	"\x04full\x18\x04 \x01(\bR\x04full\"\xab\x01\n" +
+	"\x1fExportContainerSnapshotResponse\x127\n" +
+	"\x06export\x18\x01 \x01(\v2\x1f.containarium.v1.SnapshotExportR\x06export\x125\n" +
+	"\x05chain\x18\x02 \x03(\v2\x1f.containarium.v1.SnapshotExportR\x05chain\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xd3\x01\n" +
+	"\x1eImportContainerSnapshotRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12'\n" +
+	"\x0fsource_username\x18\x03 \x01(\tR\x0esourceUsername\x12\x1a\n" +
+	"\bsnapshot\x18\x04 \x01(\tR\bsnapshot\x12\x1d\n" +
+	"\n" +
+	"backend_id\x18\x05 \x01(\tR\tbackendId\x12\x19\n" +
+	"\bssh_keys\x18\x06 \x03(\tR\asshKeys\"\xb2\x01\n" +
+	"\x1fImportContainerSnapshotResponse\x128\n" +
+	"\tcontainer\x18\x01 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vssh_command\x18\x03 \x01(\tR\n" +
+	"sshCommand\x12\x1a\n" +
+	"\bsnapshot\x18\x04 \x01(\tR\bsnapshot\"\xd3\x01\n" +
+	"\x11SnapshotFileEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.containarium.v1.SnapshotFileTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\rR\x04mode\x12\x1f\n" +
+	"\vmodified_at\x18\x05 \x01(\tR\n" +
+	"modifiedAt\x12\x1f\n" +
+	"\vlink_target\x18\x06 \x01(\tR\n" +
+	"linkTarget\"o\n" +
+	"!ListContainerSnapshotFilesRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bsnapshot\x18\x02 \x01(\tR\bsnapshot\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\"v\n" +
+	"\"ListContainerSnapshotFilesResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12<\n" +
+	"\aentries\x18\x02 \x03(\v2\".containarium.v1.SnapshotFileEntryR\aentries\"\x9e\x01\n" +
+	" ReadContainerSnapshotFileRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bsnapshot\x18\x02 \x01(\tR\bsnapshot\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x05 \x01(\x03R\x06length\"\x86\x01\n" +
+	"!ReadContainerSnapshotFileResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x10\n" +
+	"\x03eof\x18\x04 \x01(\bR\x03eof\"\xe4\x01\n" +
+	"\x12SnapshotFileChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x127\n" +
+	"\x04kind\x18\x02 \x01(\x0e2#.containarium.v1.SnapshotChangeKindR\x04kind\x125\n" +
+	"\x04type\x18\x03 \x01(\x0e2!.containarium.v1.SnapshotFileTypeR\x04type\x12$\n" +
+	"\x0eold_size_bytes\x18\x04 \x01(\x03R\foldSizeBytes\x12$\n" +
+	"\x0enew_size_bytes\x18\x05 \x01(\x03R\fnewSizeBytes\"\x81\x01\n" +
+	"\x1dDiffContainerSnapshotsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12#\n" +
+	"\rfrom_snapshot\x18\x02 \x01(\tR\ffromSnapshot\x12\x1f\n" +
+	"\vto_snapshot\x18\x03 \x01(\tR\n" +
+	"toSnapshot\"\xc9\x01\n" +
+	"\x1eDiffContainerSnapshotsResponse\x12=\n" +
+	"\achanges\x18\x01 \x03(\v2#.containarium.v1.SnapshotFileChangeR\achanges\x12\x14\n" +
+	"\x05added\x18\x02 \x01(\x05R\x05added\x12\x1a\n" +
+	"\bmodified\x18\x03 \x01(\x05R\bmodified\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\x05R\adeleted\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated\"\xd0\x02\n" +
+	"\x17ContainerSnapshotPolicy\x12)\n" +
+	"\x10interval_seconds\x18\x01 \x01(\x03R\x0fintervalSeconds\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x12\x1b\n" +
+	"\tkeep_last\x18\x03 \x01(\x05R\bkeepLast\x12\x1d\n" +
+	"\n" +
+	"keep_daily\x18\x04 \x01(\x05R\tkeepDaily\x12\x1f\n" +
+	"\vkeep_weekly\x18\x05 \x01(\x05R\n" +
+	"keepWeekly\x12\x1f\n" +
+	"\vpre_command\x18\x06 \x01(\tR\n" +
+	"preCommand\x12!\n" +
+	"\fpost_command\x18\a \x01(\tR\vpostCommand\x12\x16\n" +
+	"\x06freeze\x18\b \x01(\bR\x06freeze\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x12\x1e\n" +
+	"\vnext_run_at\x18\n" +
+	" \x01(\tR\tnextRunAt\"\x81\x01\n" +
+	"!SetContainerSnapshotPolicyRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12@\n" +
+	"\x06policy\x18\x02 \x01(\v2(.containarium.v1.ContainerSnapshotPolicyR\x06policy\"f\n" +
+	"\"SetContainerSnapshotPolicyResponse\x12@\n" +
+	"\x06policy\x18\x01 \x01(\v2(.containarium.v1.ContainerSnapshotPolicyR\x06policy\"?\n" +
+	"!GetContainerSnapshotPolicyRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"f\n" +
+	"\"GetContainerSnapshotPolicyResponse\x12@\n" +
+	"\x06policy\x18\x01 \x01(\v2(.containarium.v1.ContainerSnapshotPolicyR\x06policy\"B\n" +
+	"$DeleteContainerSnapshotPolicyRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"A\n" +
+	"%DeleteContainerSnapshotPolicyResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"4\n" +
 	"\x1aDeleteTenantStorageRequest\x12\x16\n" +
 	"\x06tenant\x18\x01 \x01(\tR\x06tenant\"t\n" +
 	"\x1bDeleteTenantStorageResponse\x12\x18\n" +
@@ -6352,7 +8118,18 @@ const file_containarium_v1_container_proto_rawDesc = "" +
 	"\x1fCLOUD_METRICS_GROUP_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18CLOUD_METRICS_GROUP_HOST\x10\x01\x12!\n" +
 	"\x1dCLOUD_METRICS_GROUP_CONTAINER\x10\x02\x12 \n" +
-	"\x1cCLOUD_METRICS_GROUP_PLATFORM\x10\x03:B\n" +
+	"\x1cCLOUD_METRICS_GROUP_PLATFORM\x10\x03*\xb3\x01\n" +
+	"\x10SnapshotFileType\x12\"\n" +
+	"\x1eSNAPSHOT_FILE_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SNAPSHOT_FILE_TYPE_FILE\x10\x01\x12 \n" +
+	"\x1cSNAPSHOT_FILE_TYPE_DIRECTORY\x10\x02\x12\x1e\n" +
+	"\x1aSNAPSHOT_FILE_TYPE_SYMLINK\x10\x03\x12\x1c\n" +
+	"\x18SNAPSHOT_FILE_TYPE_OTHER\x10\x04*\x9f\x01\n" +
+	"\x12SnapshotChangeKind\x12$\n" +
+	" SNAPSHOT_CHANGE_KIND_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSNAPSHOT_CHANGE_KIND_ADDED\x10\x01\x12!\n" +
+	"\x1dSNAPSHOT_CHANGE_KIND_MODIFIED\x10\x02\x12 \n" +
+	"\x1cSNAPSHOT_CHANGE_KIND_DELETED\x10\x03:B\n" +
 	"\n" +
 	"state_name\x12!.google.protobuf.EnumValueOptions\x18ц\x03 \x01(\tR\tstateNameBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
@@ -6368,152 +8145,190 @@ func file_containarium_v1_container_proto_rawDescGZIP() []byte {
 	return file_containarium_v1_container_proto_rawDescData
 }
 
-var file_containarium_v1_container_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_containarium_v1_container_proto_msgTypes = make([]protoimpl.MessageInfo, 82)
+var file_containarium_v1_container_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_containarium_v1_container_proto_msgTypes = make([]protoimpl.MessageInfo, 105)
 var file_containarium_v1_container_proto_goTypes = []any{
-	(OSType)(0),                               // 0: containarium.v1.OSType
-	(AccessType)(0),                           // 1: containarium.v1.AccessType
-	(ContainerState)(0),                       // 2: containarium.v1.ContainerState
-	(DeletePolicy)(0),                         // 3: containarium.v1.DeletePolicy
-	(EncryptionState)(0),                      // 4: containarium.v1.EncryptionState
-	(CloudMetricsProvider)(0),                 // 5: containarium.v1.CloudMetricsProvider
-	(CloudMetricsGroup)(0),                    // 6: containarium.v1.CloudMetricsGroup
-	(*ResourceLimits)(nil),                    // 7: containarium.v1.ResourceLimits
-	(*NetworkInfo)(nil),                       // 8: containarium.v1.NetworkInfo
-	(*Container)(nil),                         // 9: containarium.v1.Container
-	(*ContainerMetrics)(nil),                  // 10: containarium.v1.ContainerMetrics
-	(*CreateContainerRequest)(nil),            // 11: containarium.v1.CreateContainerRequest
-	(*CreateContainerResponse)(nil),           // 12: containarium.v1.CreateContainerResponse
-	(*ListContainersRequest)(nil),             // 13: containarium.v1.ListContainersRequest
-	(*ListContainersResponse)(nil),            // 14: containarium.v1.ListContainersResponse
-	(*GetContainerRequest)(nil),               // 15: containarium.v1.GetContainerRequest
-	(*GetContainerResponse)(nil),              // 16: containarium.v1.GetContainerResponse
-	(*DebugContainerRequest)(nil),             // 17: containarium.v1.DebugContainerRequest
-	(*DebugContainerResponse)(nil),            // 18: containarium.v1.DebugContainerResponse
-	(*DeleteContainerRequest)(nil),            // 19: containarium.v1.DeleteContainerRequest
-	(*DeleteContainerResponse)(nil),           // 20: containarium.v1.DeleteContainerResponse
-	(*StartContainerRequest)(nil),             // 21: containarium.v1.StartContainerRequest
-	(*StartContainerResponse)(nil),            // 22: containarium.v1.StartContainerResponse
-	(*StopContainerRequest)(nil),              // 23: containarium.v1.StopContainerRequest
-	(*StopContainerResponse)(nil),             // 24: containarium.v1.StopContainerResponse
-	(*ToggleMonitoringRequest)(nil),           // 25: containarium.v1.ToggleMonitoringRequest
-	(*ToggleMonitoringResponse)(nil),          // 26: containarium.v1.ToggleMonitoringResponse
-	(*ToggleAutoSleepRequest)(nil),            // 27: containarium.v1.ToggleAutoSleepRequest
-	(*ToggleAutoSleepResponse)(nil),           // 28: containarium.v1.ToggleAutoSleepResponse
-	(*SetContainerTTLRequest)(nil),            // 29: containarium.v1.SetContainerTTLRequest
-	(*SetContainerTTLResponse)(nil),           // 30: containarium.v1.SetContainerTTLResponse
-	(*SetContainerDeletePolicyRequest)(nil),   // 31: containarium.v1.SetContainerDeletePolicyRequest
-	(*SetContainerDeletePolicyResponse)(nil),  // 32: containarium.v1.SetContainerDeletePolicyResponse
-	(*SetContainerAttributionRequest)(nil),    // 33: containarium.v1.SetContainerAttributionRequest
-	(*SetContainerAttributionResponse)(nil),   // 34: containarium.v1.SetContainerAttributionResponse
-	(*AddSSHKeyRequest)(nil),                  // 35: containarium.v1.AddSSHKeyRequest
-	(*AddSSHKeyResponse)(nil),                 // 36: containarium.v1.AddSSHKeyResponse
-	(*RemoveSSHKeyRequest)(nil),               // 37: containarium.v1.RemoveSSHKeyRequest
-	(*RemoveSSHKeyResponse)(nil),              // 38: containarium.v1.RemoveSSHKeyResponse
-	(*GetMetricsRequest)(nil),                 // 39: containarium.v1.GetMetricsRequest
-	(*GetMetricsResponse)(nil),                // 40: containarium.v1.GetMetricsResponse
-	(*ResizeContainerRequest)(nil),            // 41: containarium.v1.ResizeContainerRequest
-	(*ResizeContainerResponse)(nil),           // 42: containarium.v1.ResizeContainerResponse
-	(*Collaborator)(nil),                      // 43: containarium.v1.Collaborator
-	(*AddCollaboratorRequest)(nil),            // 44: containarium.v1.AddCollaboratorRequest
-	(*AddCollaboratorResponse)(nil),           // 45: containarium.v1.AddCollaboratorResponse
-	(*RemoveCollaboratorRequest)(nil),         // 46: containarium.v1.RemoveCollaboratorRequest
-	(*RemoveCollaboratorResponse)(nil),        // 47: containarium.v1.RemoveCollaboratorResponse
-	(*ListCollaboratorsRequest)(nil),          // 48: containarium.v1.ListCollaboratorsRequest
-	(*ListCollaboratorsResponse)(nil),         // 49: containarium.v1.ListCollaboratorsResponse
-	(*CleanupDiskRequest)(nil),                // 50: containarium.v1.CleanupDiskRequest
-	(*CleanupDiskResponse)(nil),               // 51: containarium.v1.CleanupDiskResponse
-	(*InstallStackRequest)(nil),               // 52: containarium.v1.InstallStackRequest
-	(*InstallStackResponse)(nil),              // 53: containarium.v1.InstallStackResponse
-	(*StackParameter)(nil),                    // 54: containarium.v1.StackParameter
-	(*StackInfo)(nil),                         // 55: containarium.v1.StackInfo
-	(*ListStacksRequest)(nil),                 // 56: containarium.v1.ListStacksRequest
-	(*ListStacksResponse)(nil),                // 57: containarium.v1.ListStacksResponse
-	(*GetMonitoringInfoRequest)(nil),          // 58: containarium.v1.GetMonitoringInfoRequest
-	(*GetMonitoringInfoResponse)(nil),         // 59: containarium.v1.GetMonitoringInfoResponse
-	(*SetMetricsExportRequest)(nil),           // 60: containarium.v1.SetMetricsExportRequest
-	(*SetMetricsExportResponse)(nil),          // 61: containarium.v1.SetMetricsExportResponse
-	(*GetMetricsExportRequest)(nil),           // 62: containarium.v1.GetMetricsExportRequest
-	(*GetMetricsExportResponse)(nil),          // 63: containarium.v1.GetMetricsExportResponse
-	(*MoveContainerRequest)(nil),              // 64: containarium.v1.MoveContainerRequest
-	(*MoveContainerResponse)(nil),             // 65: containarium.v1.MoveContainerResponse
-	(*AdoptMigratedContainerRequest)(nil),     // 66: containarium.v1.AdoptMigratedContainerRequest
-	(*ContainerSnapshot)(nil),                 // 67: containarium.v1.ContainerSnapshot
-	(*CreateContainerSnapshotRequest)(nil),    // 68: containarium.v1.CreateContainerSnapshotRequest
-	(*CreateContainerSnapshotResponse)(nil),   // 69: containarium.v1.CreateContainerSnapshotResponse
-	(*ListContainerSnapshotsRequest)(nil),     // 70: containarium.v1.ListContainerSnapshotsRequest
-	(*ListContainerSnapshotsResponse)(nil),    // 71: containarium.v1.ListContainerSnapshotsResponse
-	(*DeleteContainerSnapshotRequest)(nil),    // 72: containarium.v1.DeleteContainerSnapshotRequest
-	(*DeleteContainerSnapshotResponse)(nil),   // 73: containarium.v1.DeleteContainerSnapshotResponse
-	(*RollbackContainerSnapshotRequest)(nil),  // 74: containarium.v1.RollbackContainerSnapshotRequest
-	(*RollbackContainerSnapshotResponse)(nil), // 75: containarium.v1.RollbackContainerSnapshotResponse
-	(*DeleteTenantStorageRequest)(nil),        // 76: containarium.v1.DeleteTenantStorageRequest
-	(*DeleteTenantStorageResponse)(nil),       // 77: containarium.v1.DeleteTenantStorageResponse
-	(*RewrapContainerRequest)(nil),            // 78: containarium.v1.RewrapContainerRequest
-	(*RewrapContainerResponse)(nil),           // 79: containarium.v1.RewrapContainerResponse
-	(*PrepareEncryptedMigrationRequest)(nil),  // 80: containarium.v1.PrepareEncryptedMigrationRequest
-	(*PrepareEncryptedMigrationResponse)(nil), // 81: containarium.v1.PrepareEncryptedMigrationResponse
-	(*AdoptMigratedContainerResponse)(nil),    // 82: containarium.v1.AdoptMigratedContainerResponse
-	nil,                                       // 83: containarium.v1.Container.LabelsEntry
-	nil,                                       // 84: containarium.v1.CreateContainerRequest.LabelsEntry
-	nil,                                       // 85: containarium.v1.CreateContainerRequest.StackParametersEntry
-	nil,                                       // 86: containarium.v1.ListContainersRequest.LabelFilterEntry
-	nil,                                       // 87: containarium.v1.SetContainerAttributionRequest.LabelsEntry
-	nil,                                       // 88: containarium.v1.SetContainerAttributionResponse.LabelsEntry
-	(*timestamppb.Timestamp)(nil),             // 89: google.protobuf.Timestamp
-	(*descriptorpb.EnumValueOptions)(nil),     // 90: google.protobuf.EnumValueOptions
+	(OSType)(0),                                   // 0: containarium.v1.OSType
+	(AccessType)(0),                               // 1: containarium.v1.AccessType
+	(ContainerState)(0),                           // 2: containarium.v1.ContainerState
+	(DeletePolicy)(0),                             // 3: containarium.v1.DeletePolicy
+	(EncryptionState)(0),                          // 4: containarium.v1.EncryptionState
+	(CloudMetricsProvider)(0),                     // 5: containarium.v1.CloudMetricsProvider
+	(CloudMetricsGroup)(0),                        // 6: containarium.v1.CloudMetricsGroup
+	(SnapshotFileType)(0),                         // 7: containarium.v1.SnapshotFileType
+	(SnapshotChangeKind)(0),                       // 8: containarium.v1.SnapshotChangeKind
+	(*ResourceLimits)(nil),                        // 9: containarium.v1.ResourceLimits
+	(*NetworkInfo)(nil),                           // 10: containarium.v1.NetworkInfo
+	(*Container)(nil),                             // 11: containarium.v1.Container
+	(*ContainerMetrics)(nil),                      // 12: containarium.v1.ContainerMetrics
+	(*CreateContainerRequest)(nil),                // 13: containarium.v1.CreateContainerRequest
+	(*CreateContainerResponse)(nil),               // 14: containarium.v1.CreateContainerResponse
+	(*ListContainersRequest)(nil),                 // 15: containarium.v1.ListContainersRequest
+	(*ListContainersResponse)(nil),                // 16: containarium.v1.ListContainersResponse
+	(*GetContainerRequest)(nil),                   // 17: containarium.v1.GetContainerRequest
+	(*GetContainerResponse)(nil),                  // 18: containarium.v1.GetContainerResponse
+	(*DebugContainerRequest)(nil),                 // 19: containarium.v1.DebugContainerRequest
+	(*DebugContainerResponse)(nil),                // 20: containarium.v1.DebugContainerResponse
+	(*DeleteContainerRequest)(nil),                // 21: containarium.v1.DeleteContainerRequest
+	(*DeleteContainerResponse)(nil),               // 22: containarium.v1.DeleteContainerResponse
+	(*StartContainerRequest)(nil),                 // 23: containarium.v1.StartContainerRequest
+	(*StartContainerResponse)(nil),                // 24: containarium.v1.StartContainerResponse
+	(*StopContainerRequest)(nil),                  // 25: containarium.v1.StopContainerRequest
+	(*StopContainerResponse)(nil),                 // 26: containarium.v1.StopContainerResponse
+	(*ToggleMonitoringRequest)(nil),               // 27: containarium.v1.ToggleMonitoringRequest
+	(*ToggleMonitoringResponse)(nil),              // 28: containarium.v1.ToggleMonitoringResponse
+	(*ToggleAutoSleepRequest)(nil),                // 29: containarium.v1.ToggleAutoSleepRequest
+	(*ToggleAutoSleepResponse)(nil),               // 30: containarium.v1.ToggleAutoSleepResponse
+	(*SetContainerTTLRequest)(nil),                // 31: containarium.v1.SetContainerTTLRequest
+	(*SetContainerTTLResponse)(nil),               // 32: containarium.v1.SetContainerTTLResponse
+	(*SetContainerDeletePolicyRequest)(nil),       // 33: containarium.v1.SetContainerDeletePolicyRequest
+	(*SetContainerDeletePolicyResponse)(nil),      // 34: containarium.v1.SetContainerDeletePolicyResponse
+	(*SetContainerAttributionRequest)(nil),        // 35: containarium.v1.SetContainerAttributionRequest
+	(*SetContainerAttributionResponse)(nil),       // 36: containarium.v1.SetContainerAttributionResponse
+	(*AddSSHKeyRequest)(nil),                      // 37: containarium.v1.AddSSHKeyRequest
+	(*AddSSHKeyResponse)(nil),                     // 38: containarium.v1.AddSSHKeyResponse
+	(*RemoveSSHKeyRequest)(nil),                   // 39: containarium.v1.RemoveSSHKeyRequest
+	(*RemoveSSHKeyResponse)(nil),                  // 40: containarium.v1.RemoveSSHKeyResponse
+	(*GetMetricsRequest)(nil),                     // 41: containarium.v1.GetMetricsRequest
+	(*GetMetricsResponse)(nil),                    // 42: containarium.v1.GetMetricsResponse
+	(*ResizeContainerRequest)(nil),                // 43: containarium.v1.ResizeContainerRequest
+	(*ResizeContainerResponse)(nil),               // 44: containarium.v1.ResizeContainerResponse
+	(*Collaborator)(nil),                          // 45: containarium.v1.Collaborator
+	(*AddCollaboratorRequest)(nil),                // 46: containarium.v1.AddCollaboratorRequest
+	(*AddCollaboratorResponse)(nil),               // 47: containarium.v1.AddCollaboratorResponse
+	(*RemoveCollaboratorRequest)(nil),             // 48: containarium.v1.RemoveCollaboratorRequest
+	(*RemoveCollaboratorResponse)(nil),            // 49: containarium.v1.RemoveCollaboratorResponse
+	(*ListCollaboratorsRequest)(nil),              // 50: containarium.v1.ListCollaboratorsRequest
+	(*ListCollaboratorsResponse)(nil),             // 51: containarium.v1.ListCollaboratorsResponse
+	(*CleanupDiskRequest)(nil),                    // 52: containarium.v1.CleanupDiskRequest
+	(*CleanupDiskResponse)(nil),                   // 53: containarium.v1.CleanupDiskResponse
+	(*InstallStackRequest)(nil),                   // 54: containarium.v1.InstallStackRequest
+	(*InstallStackResponse)(nil),                  // 55: containarium.v1.InstallStackResponse
+	(*StackParameter)(nil),                        // 56: containarium.v1.StackParameter
+	(*StackInfo)(nil),                             // 57: containarium.v1.StackInfo
+	(*ListStacksRequest)(nil),                     // 58: containarium.v1.ListStacksRequest
+	(*ListStacksResponse)(nil),                    // 59: containarium.v1.ListStacksResponse
+	(*GetMonitoringInfoRequest)(nil),              // 60: containarium.v1.GetMonitoringInfoRequest
+	(*GetMonitoringInfoResponse)(nil),             // 61: containarium.v1.GetMonitoringInfoResponse
+	(*SetMetricsExportRequest)(nil),               // 62: containarium.v1.SetMetricsExportRequest
+	(*SetMetricsExportResponse)(nil),              // 63: containarium.v1.SetMetricsExportResponse
+	(*GetMetricsExportRequest)(nil),               // 64: containarium.v1.GetMetricsExportRequest
+	(*GetMetricsExportResponse)(nil),              // 65: containarium.v1.GetMetricsExportResponse
+	(*MoveContainerRequest)(nil),                  // 66: containarium.v1.MoveContainerRequest
+	(*MoveContainerResponse)(nil),                 // 67: containarium.v1.MoveContainerResponse
+	(*AdoptMigratedContainerRequest)(nil),         // 68: containarium.v1.AdoptMigratedContainerRequest
+	(*ContainerSnapshot)(nil),                     // 69: containarium.v1.ContainerSnapshot
+	(*CreateContainerSnapshotRequest)(nil),        // 70: containarium.v1.CreateContainerSnapshotRequest
+	(*CreateContainerSnapshotResponse)(nil),       // 71: containarium.v1.CreateContainerSnapshotResponse
+	(*ListContainerSnapshotsRequest)(nil),         // 72: containarium.v1.ListContainerSnapshotsRequest
+	(*ListContainerSnapshotsResponse)(nil),        // 73: containarium.v1.ListContainerSnapshotsResponse
+	(*DeleteContainerSnapshotRequest)(nil),        // 74: containarium.v1.DeleteContainerSnapshotRequest
+	(*DeleteContainerSnapshotResponse)(nil),       // 75: containarium.v1.DeleteContainerSnapshotResponse
+	(*RollbackContainerSnapshotRequest)(nil),      // 76: containarium.v1.RollbackContainerSnapshotRequest
+	(*RollbackContainerSnapshotResponse)(nil),     // 77: containarium.v1.RollbackContainerSnapshotResponse
+	(*CloneContainerRequest)(nil),                 // 78: containarium.v1.CloneContainerRequest
+	(*CloneContainerResponse)(nil),                // 79: containarium.v1.CloneContainerResponse
+	(*SnapshotExport)(nil),                        // 80: containarium.v1.SnapshotExport
+	(*ExportContainerSnapshotRequest)(nil),        // 81: containarium.v1.ExportContainerSnapshotRequest
+	(*ExportContainerSnapshotResponse)(nil),       // 82: containarium.v1.ExportContainerSnapshotResponse
+	(*ImportContainerSnapshotRequest)(nil),        // 83: containarium.v1.ImportContainerSnapshotRequest
+	(*ImportContainerSnapshotResponse)(nil),       // 84: containarium.v1.ImportContainerSnapshotResponse
+	(*SnapshotFileEntry)(nil),                     // 85: containarium.v1.SnapshotFileEntry
+	(*ListContainerSnapshotFilesRequest)(nil),     // 86: containarium.v1.ListContainerSnapshotFilesRequest
+	(*ListContainerSnapshotFilesResponse)(nil),    // 87: containarium.v1.ListContainerSnapshotFilesResponse
+	(*ReadContainerSnapshotFileRequest)(nil),      // 88: containarium.v1.ReadContainerSnapshotFileRequest
+	(*ReadContainerSnapshotFileResponse)(nil),     // 89: containarium.v1.ReadContainerSnapshotFileResponse
+	(*SnapshotFileChange)(nil),                    // 90: containarium.v1.SnapshotFileChange
+	(*DiffContainerSnapshotsRequest)(nil),         // 91: containarium.v1.DiffContainerSnapshotsRequest
+	(*DiffContainerSnapshotsResponse)(nil),        // 92: containarium.v1.DiffContainerSnapshotsResponse
+	(*ContainerSnapshotPolicy)(nil),               // 93: containarium.v1.ContainerSnapshotPolicy
+	(*SetContainerSnapshotPolicyRequest)(nil),     // 94: containarium.v1.SetContainerSnapshotPolicyRequest
+	(*SetContainerSnapshotPolicyResponse)(nil),    // 95: containarium.v1.SetContainerSnapshotPolicyResponse
+	(*GetContainerSnapshotPolicyRequest)(nil),     // 96: containarium.v1.GetContainerSnapshotPolicyRequest
+	(*GetContainerSnapshotPolicyResponse)(nil),    // 97: containarium.v1.GetContainerSnapshotPolicyResponse
+	(*DeleteContainerSnapshotPolicyRequest)(nil),  // 98: containarium.v1.DeleteContainerSnapshotPolicyRequest
+	(*DeleteContainerSnapshotPolicyResponse)(nil), // 99: containarium.v1.DeleteContainerSnapshotPolicyResponse
+	(*DeleteTenantStorageRequest)(nil),            // 100: containarium.v1.DeleteTenantStorageRequest
+	(*DeleteTenantStorageResponse)(nil),           // 101: containarium.v1.DeleteTenantStorageResponse
+	(*RewrapContainerRequest)(nil),                // 102: containarium.v1.RewrapContainerRequest
+	(*RewrapContainerResponse)(nil),               // 103: containarium.v1.RewrapContainerResponse
+	(*PrepareEncryptedMigrationRequest)(nil),      // 104: containarium.v1.PrepareEncryptedMigrationRequest
+	(*PrepareEncryptedMigrationResponse)(nil),     // 105: containarium.v1.PrepareEncryptedMigrationResponse
+	(*AdoptMigratedContainerResponse)(nil),        // 106: containarium.v1.AdoptMigratedContainerResponse
+	nil,                                           // 107: containarium.v1.Container.LabelsEntry
+	nil,                                           // 108: containarium.v1.CreateContainerRequest.LabelsEntry
+	nil,                                           // 109: containarium.v1.CreateContainerRequest.StackParametersEntry
+	nil,                                           // 110: containarium.v1.ListContainersRequest.LabelFilterEntry
+	nil,                                           // 111: containarium.v1.SetContainerAttributionRequest.LabelsEntry
+	nil,                                           // 112: containarium.v1.SetContainerAttributionResponse.LabelsEntry
+	nil,                                           // 113: containarium.v1.CloneContainerRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),                 // 114: google.protobuf.Timestamp
+	(*descriptorpb.EnumValueOptions)(nil),         // 115: google.protobuf.EnumValueOptions
 }
 var file_containarium_v1_container_proto_depIdxs = []int32{
-	2,  // 0: containarium.v1.Container.state:type_name -> containarium.v1.ContainerState
-	7,  // 1: containarium.v1.Container.resources:type_name -> containarium.v1.ResourceLimits
-	8,  // 2: containarium.v1.Container.network:type_name -> containarium.v1.NetworkInfo
-	83, // 3: containarium.v1.Container.labels:type_name -> containarium.v1.Container.LabelsEntry
-	0,  // 4: containarium.v1.Container.os_type:type_name -> containarium.v1.OSType
-	1,  // 5: containarium.v1.Container.access_type:type_name -> containarium.v1.AccessType
-	89, // 6: containarium.v1.Container.ttl_expires_at:type_name -> google.protobuf.Timestamp
-	89, // 7: containarium.v1.Container.stopped_at:type_name -> google.protobuf.Timestamp
-	3,  // 8: containarium.v1.Container.delete_policy:type_name -> containarium.v1.DeletePolicy
-	4,  // 9: containarium.v1.Container.encryption_state:type_name -> containarium.v1.EncryptionState
-	7,  // 10: containarium.v1.CreateContainerRequest.resources:type_name -> containarium.v1.ResourceLimits
-	84, // 11: containarium.v1.CreateContainerRequest.labels:type_name -> containarium.v1.CreateContainerRequest.LabelsEntry
-	0,  // 12: containarium.v1.CreateContainerRequest.os_type:type_name -> containarium.v1.OSType
-	85, // 13: containarium.v1.CreateContainerRequest.stack_parameters:type_name -> containarium.v1.CreateContainerRequest.StackParametersEntry
-	9,  // 14: containarium.v1.CreateContainerResponse.container:type_name -> containarium.v1.Container
-	2,  // 15: containarium.v1.ListContainersRequest.state:type_name -> containarium.v1.ContainerState
-	86, // 16: containarium.v1.ListContainersRequest.label_filter:type_name -> containarium.v1.ListContainersRequest.LabelFilterEntry
-	9,  // 17: containarium.v1.ListContainersResponse.containers:type_name -> containarium.v1.Container
-	9,  // 18: containarium.v1.GetContainerResponse.container:type_name -> containarium.v1.Container
-	10, // 19: containarium.v1.GetContainerResponse.metrics:type_name -> containarium.v1.ContainerMetrics
-	9,  // 20: containarium.v1.StartContainerResponse.container:type_name -> containarium.v1.Container
-	9,  // 21: containarium.v1.StopContainerResponse.container:type_name -> containarium.v1.Container
-	89, // 22: containarium.v1.SetContainerTTLResponse.ttl_expires_at:type_name -> google.protobuf.Timestamp
-	3,  // 23: containarium.v1.SetContainerDeletePolicyRequest.delete_policy:type_name -> containarium.v1.DeletePolicy
-	3,  // 24: containarium.v1.SetContainerDeletePolicyResponse.delete_policy:type_name -> containarium.v1.DeletePolicy
-	87, // 25: containarium.v1.SetContainerAttributionRequest.labels:type_name -> containarium.v1.SetContainerAttributionRequest.LabelsEntry
-	88, // 26: containarium.v1.SetContainerAttributionResponse.labels:type_name -> containarium.v1.SetContainerAttributionResponse.LabelsEntry
-	10, // 27: containarium.v1.GetMetricsResponse.metrics:type_name -> containarium.v1.ContainerMetrics
-	9,  // 28: containarium.v1.ResizeContainerResponse.container:type_name -> containarium.v1.Container
-	43, // 29: containarium.v1.AddCollaboratorResponse.collaborator:type_name -> containarium.v1.Collaborator
-	43, // 30: containarium.v1.ListCollaboratorsResponse.collaborators:type_name -> containarium.v1.Collaborator
-	9,  // 31: containarium.v1.CleanupDiskResponse.container:type_name -> containarium.v1.Container
-	9,  // 32: containarium.v1.InstallStackResponse.container:type_name -> containarium.v1.Container
-	54, // 33: containarium.v1.StackInfo.parameters:type_name -> containarium.v1.StackParameter
-	55, // 34: containarium.v1.ListStacksResponse.stacks:type_name -> containarium.v1.StackInfo
-	5,  // 35: containarium.v1.SetMetricsExportRequest.provider:type_name -> containarium.v1.CloudMetricsProvider
-	6,  // 36: containarium.v1.SetMetricsExportRequest.groups:type_name -> containarium.v1.CloudMetricsGroup
-	5,  // 37: containarium.v1.SetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
-	6,  // 38: containarium.v1.SetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
-	5,  // 39: containarium.v1.GetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
-	89, // 40: containarium.v1.GetMetricsExportResponse.last_success_at:type_name -> google.protobuf.Timestamp
-	6,  // 41: containarium.v1.GetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
-	67, // 42: containarium.v1.CreateContainerSnapshotResponse.snapshot:type_name -> containarium.v1.ContainerSnapshot
-	67, // 43: containarium.v1.ListContainerSnapshotsResponse.snapshots:type_name -> containarium.v1.ContainerSnapshot
-	90, // 44: containarium.v1.state_name:extendee -> google.protobuf.EnumValueOptions
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	44, // [44:45] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	2,   // 0: containarium.v1.Container.state:type_name -> containarium.v1.ContainerState
+	9,   // 1: containarium.v1.Container.resources:type_name -> containarium.v1.ResourceLimits
+	10,  // 2: containarium.v1.Container.network:type_name -> containarium.v1.NetworkInfo
+	107, // 3: containarium.v1.Container.labels:type_name -> containarium.v1.Container.LabelsEntry
+	0,   // 4: containarium.v1.Container.os_type:type_name -> containarium.v1.OSType
+	1,   // 5: containarium.v1.Container.access_type:type_name -> containarium.v1.AccessType
+	114, // 6: containarium.v1.Container.ttl_expires_at:type_name -> google.protobuf.Timestamp
+	114, // 7: containarium.v1.Container.stopped_at:type_name -> google.protobuf.Timestamp
+	3,   // 8: containarium.v1.Container.delete_policy:type_name -> containarium.v1.DeletePolicy
+	4,   // 9: containarium.v1.Container.encryption_state:type_name -> containarium.v1.EncryptionState
+	9,   // 10: containarium.v1.CreateContainerRequest.resources:type_name -> containarium.v1.ResourceLimits
+	108, // 11: containarium.v1.CreateContainerRequest.labels:type_name -> containarium.v1.CreateContainerRequest.LabelsEntry
+	0,   // 12: containarium.v1.CreateContainerRequest.os_type:type_name -> containarium.v1.OSType
+	109, // 13: containarium.v1.CreateContainerRequest.stack_parameters:type_name -> containarium.v1.CreateContainerRequest.StackParametersEntry
+	11,  // 14: containarium.v1.CreateContainerResponse.container:type_name -> containarium.v1.Container
+	2,   // 15: containarium.v1.ListContainersRequest.state:type_name -> containarium.v1.ContainerState
+	110, // 16: containarium.v1.ListContainersRequest.label_filter:type_name -> containarium.v1.ListContainersRequest.LabelFilterEntry
+	11,  // 17: containarium.v1.ListContainersResponse.containers:type_name -> containarium.v1.Container
+	11,  // 18: containarium.v1.GetContainerResponse.container:type_name -> containarium.v1.Container
+	12,  // 19: containarium.v1.GetContainerResponse.metrics:type_name -> containarium.v1.ContainerMetrics
+	11,  // 20: containarium.v1.StartContainerResponse.container:type_name -> containarium.v1.Container
+	11,  // 21: containarium.v1.StopContainerResponse.container:type_name -> containarium.v1.Container
+	114, // 22: containarium.v1.SetContainerTTLResponse.ttl_expires_at:type_name -> google.protobuf.Timestamp
+	3,   // 23: containarium.v1.SetContainerDeletePolicyRequest.delete_policy:type_name -> containarium.v1.DeletePolicy
+	3,   // 24: containarium.v1.SetContainerDeletePolicyResponse.delete_policy:type_name -> containarium.v1.DeletePolicy
+	111, // 25: containarium.v1.SetContainerAttributionRequest.labels:type_name -> containarium.v1.SetContainerAttributionRequest.LabelsEntry
+	112, // 26: containarium.v1.SetContainerAttributionResponse.labels:type_name -> containarium.v1.SetContainerAttributionResponse.LabelsEntry
+	12,  // 27: containarium.v1.GetMetricsResponse.metrics:type_name -> containarium.v1.ContainerMetrics
+	11,  // 28: containarium.v1.ResizeContainerResponse.container:type_name -> containarium.v1.Container
+	45,  // 29: containarium.v1.AddCollaboratorResponse.collaborator:type_name -> containarium.v1.Collaborator
+	45,  // 30: containarium.v1.ListCollaboratorsResponse.collaborators:type_name -> containarium.v1.Collaborator
+	11,  // 31: containarium.v1.CleanupDiskResponse.container:type_name -> containarium.v1.Container
+	11,  // 32: containarium.v1.InstallStackResponse.container:type_name -> containarium.v1.Container
+	56,  // 33: containarium.v1.StackInfo.parameters:type_name -> containarium.v1.StackParameter
+	57,  // 34: containarium.v1.ListStacksResponse.stacks:type_name -> containarium.v1.StackInfo
+	5,   // 35: containarium.v1.SetMetricsExportRequest.provider:type_name -> containarium.v1.CloudMetricsProvider
+	6,   // 36: containarium.v1.SetMetricsExportRequest.groups:type_name -> containarium.v1.CloudMetricsGroup
+	5,   // 37: containarium.v1.SetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
+	6,   // 38: containarium.v1.SetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
+	5,   // 39: containarium.v1.GetMetricsExportResponse.provider:type_name -> containarium.v1.CloudMetricsProvider
+	114, // 40: containarium.v1.GetMetricsExportResponse.last_success_at:type_name -> google.protobuf.Timestamp
+	6,   // 41: containarium.v1.GetMetricsExportResponse.groups:type_name -> containarium.v1.CloudMetricsGroup
+	69,  // 42: containarium.v1.CreateContainerSnapshotResponse.snapshot:type_name -> containarium.v1.ContainerSnapshot
+	69,  // 43: containarium.v1.ListContainerSnapshotsResponse.snapshots:type_name -> containarium.v1.ContainerSnapshot
+	113, // 44: containarium.v1.CloneContainerRequest.labels:type_name -> containarium.v1.CloneContainerRequest.LabelsEntry
+	11,  // 45: containarium.v1.CloneContainerResponse.container:type_name -> containarium.v1.Container
+	80,  // 46: containarium.v1.ExportContainerSnapshotResponse.export:type_name -> containarium.v1.SnapshotExport
+	80,  // 47: containarium.v1.ExportContainerSnapshotResponse.chain:type_name -> containarium.v1.SnapshotExport
+	11,  // 48: containarium.v1.ImportContainerSnapshotResponse.container:type_name -> containarium.v1.Container
+	7,   // 49: containarium.v1.SnapshotFileEntry.type:type_name -> containarium.v1.SnapshotFileType
+	85,  // 50: containarium.v1.ListContainerSnapshotFilesResponse.entries:type_name -> containarium.v1.SnapshotFileEntry
+	8,   // 51: containarium.v1.SnapshotFileChange.kind:type_name -> containarium.v1.SnapshotChangeKind
+	7,   // 52: containarium.v1.SnapshotFileChange.type:type_name -> containarium.v1.SnapshotFileType
+	90,  // 53: containarium.v1.DiffContainerSnapshotsResponse.changes:type_name -> containarium.v1.SnapshotFileChange
+	93,  // 54: containarium.v1.SetContainerSnapshotPolicyRequest.policy:type_name -> containarium.v1.ContainerSnapshotPolicy
+	93,  // 55: containarium.v1.SetContainerSnapshotPolicyResponse.policy:type_name -> containarium.v1.ContainerSnapshotPolicy
+	93,  // 56: containarium.v1.GetContainerSnapshotPolicyResponse.policy:type_name -> containarium.v1.ContainerSnapshotPolicy
+	115, // 57: containarium.v1.state_name:extendee -> google.protobuf.EnumValueOptions
+	58,  // [58:58] is the sub-list for method output_type
+	58,  // [58:58] is the sub-list for method input_type
+	58,  // [58:58] is the sub-list for extension type_name
+	57,  // [57:58] is the sub-list for extension extendee
+	0,   // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_container_proto_init() }
@@ -6526,8 +8341,8 @@ func file_containarium_v1_container_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_container_proto_rawDesc), len(file_containarium_v1_container_proto_rawDesc)),
-			NumEnums:      7,
-			NumMessages:   82,
+			NumEnums:      9,
+			NumMessages:   105,
 			NumExtensions: 1,
 			NumServices:   0,
 		},
@@ -41,6 +41,12 @@ const (
 	EventType_EVENT_TYPE_CONTAINER_STOPPED EventType = 4
 	// Container state changed
 	EventType_EVENT_TYPE_CONTAINER_STATE_CHANGED EventType = 5
+	// A scheduled container snapshot was taken
+	EventType_EVENT_TYPE_CONTAINER_SNAPSHOT_CREATED EventType = 6
+	// A scheduled container snapshot was pruned by its retention
+	EventType_EVENT_TYPE_CONTAINER_SNAPSHOT_PRUNED EventType = 7
+	// A scheduled container snapshot, its hooks or a prune failed
+	EventType_EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED EventType = 8
 	// App events (10-19)
 	// App was deployed
 	EventType_EVENT_TYPE_APP_DEPLOYED EventType = 10
@@ -63,6 +69,16 @@ const (
 	// Traffic events (40-49)
 	// Traffic/connection update
 	EventType_EVENT_TYPE_TRAFFIC_UPDATE EventType = 40
+	// Backup events (50-59)
+	// Progress of a running backup, restore or verification
+	EventType_EVENT_TYPE_BACKUP_PROGRESS EventType = 50
+	// A scheduled backup run completed without failures
+	EventType_EVENT_TYPE_BACKUP_SCHEDULE_SUCCEEDED EventType = 51
+	// A scheduled backup run had dump, prune or verification failures
+	EventType_EVENT_TYPE_BACKUP_SCHEDULE_FAILED EventType = 52
+	// WAF events (60-69)
+	// A userspace WAF rule matched a steered request
+	EventType_EVENT_TYPE_WAF_MATCH EventType = 60
 )
 
 // Enum value maps for EventType.
@@ -74,6 +90,9 @@ var (
 		3:  "EVENT_TYPE_CONTAINER_STARTED",
 		4:  "EVENT_TYPE_CONTAINER_STOPPED",
 		5:  "EVENT_TYPE_CONTAINER_STATE_CHANGED",
+		6:  "EVENT_TYPE_CONTAINER_SNAPSHOT_CREATED",
+		7:  "EVENT_TYPE_CONTAINER_SNAPSHOT_PRUNED",
+		8:  "EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED",
 		10: "EVENT_TYPE_APP_DEPLOYED",
 		11: "EVENT_TYPE_APP_DELETED",
 		12: "EVENT_TYPE_APP_STARTED",
@@ -83,23 +102,34 @@ var (
 		21: "EVENT_TYPE_ROUTE_DELETED",
 		30: "EVENT_TYPE_METRICS_UPDATE",
 		40: "EVENT_TYPE_TRAFFIC_UPDATE",
+		50: "EVENT_TYPE_BACKUP_PROGRESS",
+		51: "EVENT_TYPE_BACKUP_SCHEDULE_SUCCEEDED",
+		52: "EVENT_TYPE_BACKUP_SCHEDULE_FAILED",
+		60: "EVENT_TYPE_WAF_MATCH",
 	}
 	EventType_value = map[string]int32{
-		"EVENT_TYPE_UNSPECIFIED":             0,
-		"EVENT_TYPE_CONTAINER_CREATED":       1,
-		"EVENT_TYPE_CONTAINER_DELETED":       2,
-		"EVENT_TYPE_CONTAINER_STARTED":       3,
-		"EVENT_TYPE_CONTAINER_STOPPED":       4,
-		"EVENT_TYPE_CONTAINER_STATE_CHANGED": 5,
-		"EVENT_TYPE_APP_DEPLOYED":            10,
-		"EVENT_TYPE_APP_DELETED":             11,
-		"EVENT_TYPE_APP_STARTED":             12,
-		"EVENT_TYPE_APP_STOPPED":             13,
-		"EVENT_TYPE_APP_STATE_CHANGED":       14,
-		"EVENT_TYPE_ROUTE_ADDED":             20,
-		"EVENT_TYPE_ROUTE_DELETED":           21,
-		"EVENT_TYPE_METRICS_UPDATE":          30,
-		"EVENT_TYPE_TRAFFIC_UPDATE":          40,
+		"EVENT_TYPE_UNSPECIFIED":                0,
+		"EVENT_TYPE_CONTAINER_CREATED":          1,
+		"EVENT_TYPE_CONTAINER_DELETED":          2,
+		"EVENT_TYPE_CONTAINER_STARTED":          3,
+		"EVENT_TYPE_CONTAINER_STOPPED":          4,
+		"EVENT_TYPE_CONTAINER_STATE_CHANGED":    5,
+		"EVENT_TYPE_CONTAINER_SNAPSHOT_CREATED": 6,
+		"EVENT_TYPE_CONTAINER_SNAPSHOT_PRUNED":  7,
+		"EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED":  8,
+		"EVENT_TYPE_APP_DEPLOYED":               10,
+		"EVENT_TYPE_APP_DELETED":                11,
+		"EVENT_TYPE_APP_STARTED":                12,
+		"EVENT_TYPE_APP_STOPPED":                13,
+		"EVENT_TYPE_APP_STATE_CHANGED":          14,
+		"EVENT_TYPE_ROUTE_ADDED":                20,
+		"EVENT_TYPE_ROUTE_DELETED":              21,
+		"EVENT_TYPE_METRICS_UPDATE":             30,
+		"EVENT_TYPE_TRAFFIC_UPDATE":             40,
+		"EVENT_TYPE_BACKUP_PROGRESS":            50,
+		"EVENT_TYPE_BACKUP_SCHEDULE_SUCCEEDED":  51,
+		"EVENT_TYPE_BACKUP_SCHEDULE_FAILED":     52,
+		"EVENT_TYPE_WAF_MATCH":                  60,
 	}
 )
 
@@ -146,6 +176,10 @@ const (
 	ResourceType_RESOURCE_TYPE_METRICS ResourceType = 4
 	// Traffic resource
 	ResourceType_RESOURCE_TYPE_TRAFFIC ResourceType = 5
+	// Backup resource
+	ResourceType_RESOURCE_TYPE_BACKUP ResourceType = 6
+	// Userspace WAF (network-policy Tier 3)
+	ResourceType_RESOURCE_TYPE_WAF ResourceType = 7
 )
 
 // Enum value maps for ResourceType.
@@ -157,6 +191,8 @@ var (
 		3: "RESOURCE_TYPE_ROUTE",
 		4: "RESOURCE_TYPE_METRICS",
 		5: "RESOURCE_TYPE_TRAFFIC",
+		6: "RESOURCE_TYPE_BACKUP",
+		7: "RESOURCE_TYPE_WAF",
 	}
 	ResourceType_value = map[string]int32{
 		"RESOURCE_TYPE_UNSPECIFIED": 0,
@@ -165,6 +201,8 @@ var (
 		"RESOURCE_TYPE_ROUTE":       3,
 		"RESOURCE_TYPE_METRICS":     4,
 		"RESOURCE_TYPE_TRAFFIC":     5,
+		"RESOURCE_TYPE_BACKUP":      6,
+		"RESOURCE_TYPE_WAF":         7,
 	}
 )
 
@@ -250,6 +288,80 @@ func (x *ContainerEvent) GetPreviousState() ContainerState {
 	return ContainerState_CONTAINER_STATE_UNSPECIFIED
 }
 
+// ContainerSnapshotEvent reports one scheduled snapshot taken, pruned or
+// failed. The event's resource_id is the container name.
+type ContainerSnapshotEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Owner of the container
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The container snapshotted
+	ContainerName string `protobuf:"bytes,2,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Snapshot name (the part after '@')
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// What failed, for EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerSnapshotEvent) Reset() {
+	*x = ContainerSnapshotEvent{}
+	mi := &file_containarium_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerSnapshotEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerSnapshotEvent) ProtoMessage() {}
+
+func (x *ContainerSnapshotEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerSnapshotEvent.ProtoReflect.Descriptor instead.
+func (*ContainerSnapshotEvent) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ContainerSnapshotEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotEvent) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotEvent) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *ContainerSnapshotEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // AppEvent contains app-specific event data
 type AppEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -263,7 +375,7 @@ type AppEvent struct {
 
 func (x *AppEvent) Reset() {
 	*x = AppEvent{}
-	mi := &file_containarium_v1_events_proto_msgTypes[1]
+	mi := &file_containarium_v1_events_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -275,7 +387,7 @@ func (x *AppEvent) String() string {
 func (*AppEvent) ProtoMessage() {}
 
 func (x *AppEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_events_proto_msgTypes[1]
+	mi := &file_containarium_v1_events_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,7 +400,7 @@ func (x *AppEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppEvent.ProtoReflect.Descriptor instead.
 func (*AppEvent) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_events_proto_rawDescGZIP(), []int{1}
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *AppEvent) GetApp() *App {
@@ -316,7 +428,7 @@ type RouteEvent struct {
 
 func (x *RouteEvent) Reset() {
 	*x = RouteEvent{}
-	mi := &file_containarium_v1_events_proto_msgTypes[2]
+	mi := &file_containarium_v1_events_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -328,7 +440,7 @@ func (x *RouteEvent) String() string {
 func (*RouteEvent) ProtoMessage() {}
 
 func (x *RouteEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_events_proto_msgTypes[2]
+	mi := &file_containarium_v1_events_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +453,7 @@ func (x *RouteEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteEvent.ProtoReflect.Descriptor instead.
 func (*RouteEvent) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_events_proto_rawDescGZIP(), []int{2}
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RouteEvent) GetRoute() *ProxyRoute {
@@ -362,7 +474,7 @@ type MetricsEvent struct {
 
 func (x *MetricsEvent) Reset() {
 	*x = MetricsEvent{}
-	mi := &file_containarium_v1_events_proto_msgTypes[3]
+	mi := &file_containarium_v1_events_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -374,7 +486,7 @@ func (x *MetricsEvent) String() string {
 func (*MetricsEvent) ProtoMessage() {}
 
 func (x *MetricsEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_events_proto_msgTypes[3]
+	mi := &file_containarium_v1_events_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,7 +499,7 @@ func (x *MetricsEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsEvent.ProtoReflect.Descriptor instead.
 func (*MetricsEvent) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_events_proto_rawDescGZIP(), []int{3}
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MetricsEvent) GetMetrics() []*ContainerMetrics {
@@ -397,6 +509,264 @@ func (x *MetricsEvent) GetMetrics() []*ContainerMetrics {
 	return nil
 }
 
+// BackupProgressEvent reports bytes moved by a running backup operation.
+// Emitted periodically while a dump streams, and once more when each
+// phase finishes.
+type BackupProgressEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The backup being created, restored or verified
+	BackupId string `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	// Owner of the backup
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// Operation in flight: "create", "restore" or "verify"
+	Operation string `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Stage of the operation: "dump", "upload", "download" or "restore"
+	Phase string `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	// Bytes processed so far in this phase
+	BytesDone int64 `protobuf:"varint,5,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	// Expected bytes for this phase; 0 when not known up front
+	BytesTotal int64 `protobuf:"varint,6,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	// True on the last report for this phase
+	PhaseDone     bool `protobuf:"varint,7,opt,name=phase_done,json=phaseDone,proto3" json:"phase_done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupProgressEvent) Reset() {
+	*x = BackupProgressEvent{}
+	mi := &file_containarium_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupProgressEvent) ProtoMessage() {}
+
+func (x *BackupProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupProgressEvent.ProtoReflect.Descriptor instead.
+func (*BackupProgressEvent) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BackupProgressEvent) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *BackupProgressEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *BackupProgressEvent) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *BackupProgressEvent) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *BackupProgressEvent) GetBytesDone() int64 {
+	if x != nil {
+		return x.BytesDone
+	}
+	return 0
+}
+
+func (x *BackupProgressEvent) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *BackupProgressEvent) GetPhaseDone() bool {
+	if x != nil {
+		return x.PhaseDone
+	}
+	return false
+}
+
+// BackupScheduleRunEvent reports the outcome of one scheduled backup run.
+type BackupScheduleRunEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant whose schedule ran
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// What the run did
+	Run           *BackupScheduleRun `protobuf:"bytes,2,opt,name=run,proto3" json:"run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupScheduleRunEvent) Reset() {
+	*x = BackupScheduleRunEvent{}
+	mi := &file_containarium_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupScheduleRunEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupScheduleRunEvent) ProtoMessage() {}
+
+func (x *BackupScheduleRunEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupScheduleRunEvent.ProtoReflect.Descriptor instead.
+func (*BackupScheduleRunEvent) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BackupScheduleRunEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *BackupScheduleRunEvent) GetRun() *BackupScheduleRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+// WAFMatchEvent reports one WAF rule matching a steered request. The event's
+// resource_id is the connection's original destination (host:port).
+type WAFMatchEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matched rule id (operator rules from 1000, built-in signatures below)
+	RuleId uint32 `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Matched rule name
+	RuleName string `protobuf:"bytes,2,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	// The rule's action: "block" or "log"
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Part of the request that matched; empty for a built-in signature
+	Target string `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	// Tenant owning the destination, when the daemon could attribute it
+	Tenant string `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Original destination of the connection (host:port)
+	Destination string `protobuf:"bytes,6,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Whether the connection was refused (a block rule with enforcement armed)
+	Dropped       bool `protobuf:"varint,7,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WAFMatchEvent) Reset() {
+	*x = WAFMatchEvent{}
+	mi := &file_containarium_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WAFMatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WAFMatchEvent) ProtoMessage() {}
+
+func (x *WAFMatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WAFMatchEvent.ProtoReflect.Descriptor instead.
+func (*WAFMatchEvent) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WAFMatchEvent) GetRuleId() uint32 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *WAFMatchEvent) GetRuleName() string {
+	if x != nil {
+		return x.RuleName
+	}
+	return ""
+}
+
+func (x *WAFMatchEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WAFMatchEvent) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *WAFMatchEvent) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *WAFMatchEvent) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *WAFMatchEvent) GetDropped() bool {
+	if x != nil {
+		return x.Dropped
+	}
+	return false
+}
+
 // Event is the top-level event message sent to clients
 type Event struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -419,6 +789,10 @@ type Event struct {
 	//	*Event_RouteEvent
 	//	*Event_MetricsEvent
 	//	*Event_TrafficEvent
+	//	*Event_BackupProgressEvent
+	//	*Event_BackupScheduleRunEvent
+	//	*Event_WafMatchEvent
+	//	*Event_ContainerSnapshotEvent
 	Payload       isEvent_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -426,7 +800,7 @@ type Event struct {
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_containarium_v1_events_proto_msgTypes[4]
+	mi := &file_containarium_v1_events_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +812,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_events_proto_msgTypes[4]
+	mi := &file_containarium_v1_events_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +825,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_events_proto_rawDescGZIP(), []int{4}
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Event) GetId() string {
@@ -541,6 +915,42 @@ func (x *Event) GetTrafficEvent() *TrafficEvent {
 	return nil
 }
 
+func (x *Event) GetBackupProgressEvent() *BackupProgressEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_BackupProgressEvent); ok {
+			return x.BackupProgressEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetBackupScheduleRunEvent() *BackupScheduleRunEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_BackupScheduleRunEvent); ok {
+			return x.BackupScheduleRunEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetWafMatchEvent() *WAFMatchEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_WafMatchEvent); ok {
+			return x.WafMatchEvent
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetContainerSnapshotEvent() *ContainerSnapshotEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ContainerSnapshotEvent); ok {
+			return x.ContainerSnapshotEvent
+		}
+	}
+	return nil
+}
+
 type isEvent_Payload interface {
 	isEvent_Payload()
 }
@@ -565,6 +975,22 @@ type Event_TrafficEvent struct {
 	TrafficEvent *TrafficEvent `protobuf:"bytes,14,opt,name=traffic_event,json=trafficEvent,proto3,oneof"`
 }
 
+type Event_BackupProgressEvent struct {
+	BackupProgressEvent *BackupProgressEvent `protobuf:"bytes,15,opt,name=backup_progress_event,json=backupProgressEvent,proto3,oneof"`
+}
+
+type Event_BackupScheduleRunEvent struct {
+	BackupScheduleRunEvent *BackupScheduleRunEvent `protobuf:"bytes,16,opt,name=backup_schedule_run_event,json=backupScheduleRunEvent,proto3,oneof"`
+}
+
+type Event_WafMatchEvent struct {
+	WafMatchEvent *WAFMatchEvent `protobuf:"bytes,17,opt,name=waf_match_event,json=wafMatchEvent,proto3,oneof"`
+}
+
+type Event_ContainerSnapshotEvent struct {
+	ContainerSnapshotEvent *ContainerSnapshotEvent `protobuf:"bytes,18,opt,name=container_snapshot_event,json=containerSnapshotEvent,proto3,oneof"`
+}
+
 func (*Event_ContainerEvent) isEvent_Payload() {}
 
 func (*Event_AppEvent) isEvent_Payload() {}
@@ -575,6 +1001,14 @@ func (*Event_MetricsEvent) isEvent_Payload() {}
 
 func (*Event_TrafficEvent) isEvent_Payload() {}
 
+func (*Event_BackupProgressEvent) isEvent_Payload() {}
+
+func (*Event_BackupScheduleRunEvent) isEvent_Payload() {}
+
+func (*Event_WafMatchEvent) isEvent_Payload() {}
+
+func (*Event_ContainerSnapshotEvent) isEvent_Payload() {}
+
 // SubscribeEventsRequest configures the event subscription
 type SubscribeEventsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -590,7 +1024,7 @@ type SubscribeEventsRequest struct {
 
 func (x *SubscribeEventsRequest) Reset() {
 	*x = SubscribeEventsRequest{}
-	mi := &file_containarium_v1_events_proto_msgTypes[5]
+	mi := &file_containarium_v1_events_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -602,7 +1036,7 @@ func (x *SubscribeEventsRequest) String() string {
 func (*SubscribeEventsRequest) ProtoMessage() {}
 
 func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_containarium_v1_events_proto_msgTypes[5]
+	mi := &file_containarium_v1_events_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +1049,7 @@ func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
-	return file_containarium_v1_events_proto_rawDescGZIP(), []int{5}
+	return file_containarium_v1_events_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SubscribeEventsRequest) GetResourceTypes() []ResourceType {
@@ -643,10 +1077,15 @@ var File_containarium_v1_events_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x1ccontainarium/v1/events.proto\x12\x0fcontainarium.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x1fcontainarium/v1/container.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1dcontainarium/v1/traffic.proto\"\x92\x01\n" +
+	"\x1ccontainarium/v1/events.proto\x12\x0fcontainarium.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x1fcontainarium/v1/container.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1dcontainarium/v1/traffic.proto\x1a\x1ccontainarium/v1/backup.proto\"\x92\x01\n" +
 	"\x0eContainerEvent\x128\n" +
 	"\tcontainer\x18\x01 \x01(\v2\x1a.containarium.v1.ContainerR\tcontainer\x12F\n" +
-	"\x0eprevious_state\x18\x02 \x01(\x0e2\x1f.containarium.v1.ContainerStateR\rpreviousState\"t\n" +
+	"\x0eprevious_state\x18\x02 \x01(\x0e2\x1f.containarium.v1.ContainerStateR\rpreviousState\"\x8d\x01\n" +
+	"\x16ContainerSnapshotEvent\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12%\n" +
+	"\x0econtainer_name\x18\x02 \x01(\tR\rcontainerName\x12\x1a\n" +
+	"\bsnapshot\x18\x03 \x01(\tR\bsnapshot\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"t\n" +
 	"\bAppEvent\x12&\n" +
 	"\x03app\x18\x01 \x01(\v2\x14.containarium.v1.AppR\x03app\x12@\n" +
 	"\x0eprevious_state\x18\x02 \x01(\x0e2\x19.containarium.v1.AppStateR\rpreviousState\"?\n" +
@@ -654,7 +1093,29 @@ const file_containarium_v1_events_proto_rawDesc = "" +
 	"RouteEvent\x121\n" +
 	"\x05route\x18\x01 \x01(\v2\x1b.containarium.v1.ProxyRouteR\x05route\"K\n" +
 	"\fMetricsEvent\x12;\n" +
-	"\ametrics\x18\x01 \x03(\v2!.containarium.v1.ContainerMetricsR\ametrics\"\xc3\x04\n" +
+	"\ametrics\x18\x01 \x03(\v2!.containarium.v1.ContainerMetricsR\ametrics\"\xe1\x01\n" +
+	"\x13BackupProgressEvent\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x12\x1d\n" +
+	"\n" +
+	"bytes_done\x18\x05 \x01(\x03R\tbytesDone\x12\x1f\n" +
+	"\vbytes_total\x18\x06 \x01(\x03R\n" +
+	"bytesTotal\x12\x1d\n" +
+	"\n" +
+	"phase_done\x18\a \x01(\bR\tphaseDone\"j\n" +
+	"\x16BackupScheduleRunEvent\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x124\n" +
+	"\x03run\x18\x02 \x01(\v2\".containarium.v1.BackupScheduleRunR\x03run\"\xc9\x01\n" +
+	"\rWAFMatchEvent\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\rR\x06ruleId\x12\x1b\n" +
+	"\trule_name\x18\x02 \x01(\tR\bruleName\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\x12 \n" +
+	"\vdestination\x18\x06 \x01(\tR\vdestination\x12\x18\n" +
+	"\adropped\x18\a \x01(\bR\adropped\"\xb4\a\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x1a.containarium.v1.EventTypeR\x04type\x12B\n" +
@@ -668,19 +1129,26 @@ const file_containarium_v1_events_proto_rawDesc = "" +
 	"\vroute_event\x18\f \x01(\v2\x1b.containarium.v1.RouteEventH\x00R\n" +
 	"routeEvent\x12D\n" +
 	"\rmetrics_event\x18\r \x01(\v2\x1d.containarium.v1.MetricsEventH\x00R\fmetricsEvent\x12D\n" +
-	"\rtraffic_event\x18\x0e \x01(\v2\x1d.containarium.v1.TrafficEventH\x00R\ftrafficEventB\t\n" +
+	"\rtraffic_event\x18\x0e \x01(\v2\x1d.containarium.v1.TrafficEventH\x00R\ftrafficEvent\x12Z\n" +
+	"\x15backup_progress_event\x18\x0f \x01(\v2$.containarium.v1.BackupProgressEventH\x00R\x13backupProgressEvent\x12d\n" +
+	"\x19backup_schedule_run_event\x18\x10 \x01(\v2'.containarium.v1.BackupScheduleRunEventH\x00R\x16backupScheduleRunEvent\x12H\n" +
+	"\x0fwaf_match_event\x18\x11 \x01(\v2\x1e.containarium.v1.WAFMatchEventH\x00R\rwafMatchEvent\x12c\n" +
+	"\x18container_snapshot_event\x18\x12 \x01(\v2'.containarium.v1.ContainerSnapshotEventH\x00R\x16containerSnapshotEventB\t\n" +
 	"\apayload\"\xc1\x01\n" +
 	"\x16SubscribeEventsRequest\x12D\n" +
 	"\x0eresource_types\x18\x01 \x03(\x0e2\x1d.containarium.v1.ResourceTypeR\rresourceTypes\x12'\n" +
 	"\x0finclude_metrics\x18\x02 \x01(\bR\x0eincludeMetrics\x128\n" +
-	"\x18metrics_interval_seconds\x18\x03 \x01(\x05R\x16metricsIntervalSeconds*\xe2\x03\n" +
+	"\x18metrics_interval_seconds\x18\x03 \x01(\x05R\x16metricsIntervalSeconds*\xec\x05\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_CREATED\x10\x01\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_DELETED\x10\x02\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_STARTED\x10\x03\x12 \n" +
 	"\x1cEVENT_TYPE_CONTAINER_STOPPED\x10\x04\x12&\n" +
-	"\"EVENT_TYPE_CONTAINER_STATE_CHANGED\x10\x05\x12\x1b\n" +
+	"\"EVENT_TYPE_CONTAINER_STATE_CHANGED\x10\x05\x12)\n" +
+	"%EVENT_TYPE_CONTAINER_SNAPSHOT_CREATED\x10\x06\x12(\n" +
+	"$EVENT_TYPE_CONTAINER_SNAPSHOT_PRUNED\x10\a\x12(\n" +
+	"$EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED\x10\b\x12\x1b\n" +
 	"\x17EVENT_TYPE_APP_DEPLOYED\x10\n" +
 	"\x12\x1a\n" +
 	"\x16EVENT_TYPE_APP_DELETED\x10\v\x12\x1a\n" +
@@ -690,14 +1158,20 @@ const file_containarium_v1_events_proto_rawDesc = "" +
 	"\x16EVENT_TYPE_ROUTE_ADDED\x10\x14\x12\x1c\n" +
 	"\x18EVENT_TYPE_ROUTE_DELETED\x10\x15\x12\x1d\n" +
 	"\x19EVENT_TYPE_METRICS_UPDATE\x10\x1e\x12\x1d\n" +
-	"\x19EVENT_TYPE_TRAFFIC_UPDATE\x10(*\xb0\x01\n" +
+	"\x19EVENT_TYPE_TRAFFIC_UPDATE\x10(\x12\x1e\n" +
+	"\x1aEVENT_TYPE_BACKUP_PROGRESS\x102\x12(\n" +
+	"$EVENT_TYPE_BACKUP_SCHEDULE_SUCCEEDED\x103\x12%\n" +
+	"!EVENT_TYPE_BACKUP_SCHEDULE_FAILED\x104\x12\x18\n" +
+	"\x14EVENT_TYPE_WAF_MATCH\x10<*\xe1\x01\n" +
 	"\fResourceType\x12\x1d\n" +
 	"\x19RESOURCE_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17RESOURCE_TYPE_CONTAINER\x10\x01\x12\x15\n" +
 	"\x11RESOURCE_TYPE_APP\x10\x02\x12\x17\n" +
 	"\x13RESOURCE_TYPE_ROUTE\x10\x03\x12\x19\n" +
 	"\x15RESOURCE_TYPE_METRICS\x10\x04\x12\x19\n" +
-	"\x15RESOURCE_TYPE_TRAFFIC\x10\x052\xa3\x02\n" +
+	"\x15RESOURCE_TYPE_TRAFFIC\x10\x05\x12\x18\n" +
+	"\x14RESOURCE_TYPE_BACKUP\x10\x06\x12\x15\n" +
+	"\x11RESOURCE_TYPE_WAF\x10\a2\xa3\x02\n" +
 	"\fEventService\x12\x92\x02\n" +
 	"\x0fSubscribeEvents\x12'.containarium.v1.SubscribeEventsRequest\x1a\x16.containarium.v1.Event\"\xbb\x01\x92A\x9b\x01\n" +
 	"\x06Events\x12\x1dSubscribe to real-time events\x1arOpens a Server-Sent Events stream for real-time resource updates. Filter by resource types using query parameters.\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/events/subscribe0\x01BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
@@ -715,48 +1189,58 @@ func file_containarium_v1_events_proto_rawDescGZIP() []byte {
 }
 
 var file_containarium_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_containarium_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_containarium_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_containarium_v1_events_proto_goTypes = []any{
 	(EventType)(0),                 // 0: containarium.v1.EventType
 	(ResourceType)(0),              // 1: containarium.v1.ResourceType
 	(*ContainerEvent)(nil),         // 2: containarium.v1.ContainerEvent
-	(*AppEvent)(nil),               // 3: containarium.v1.AppEvent
-	(*RouteEvent)(nil),             // 4: containarium.v1.RouteEvent
-	(*MetricsEvent)(nil),           // 5: containarium.v1.MetricsEvent
-	(*Event)(nil),                  // 6: containarium.v1.Event
-	(*SubscribeEventsRequest)(nil), // 7: containarium.v1.SubscribeEventsRequest
-	(*Container)(nil),              // 8: containarium.v1.Container
-	(ContainerState)(0),            // 9: containarium.v1.ContainerState
-	(*App)(nil),                    // 10: containarium.v1.App
-	(AppState)(0),                  // 11: containarium.v1.AppState
-	(*ProxyRoute)(nil),             // 12: containarium.v1.ProxyRoute
-	(*ContainerMetrics)(nil),       // 13: containarium.v1.ContainerMetrics
-	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
-	(*TrafficEvent)(nil),           // 15: containarium.v1.TrafficEvent
+	(*ContainerSnapshotEvent)(nil), // 3: containarium.v1.ContainerSnapshotEvent
+	(*AppEvent)(nil),               // 4: containarium.v1.AppEvent
+	(*RouteEvent)(nil),             // 5: containarium.v1.RouteEvent
+	(*MetricsEvent)(nil),           // 6: containarium.v1.MetricsEvent
+	(*BackupProgressEvent)(nil),    // 7: containarium.v1.BackupProgressEvent
+	(*BackupScheduleRunEvent)(nil), // 8: containarium.v1.BackupScheduleRunEvent
+	(*WAFMatchEvent)(nil),          // 9: containarium.v1.WAFMatchEvent
+	(*Event)(nil),                  // 10: containarium.v1.Event
+	(*SubscribeEventsRequest)(nil), // 11: containarium.v1.SubscribeEventsRequest
+	(*Container)(nil),              // 12: containarium.v1.Container
+	(ContainerState)(0),            // 13: containarium.v1.ContainerState
+	(*App)(nil),                    // 14: containarium.v1.App
+	(AppState)(0),                  // 15: containarium.v1.AppState
+	(*ProxyRoute)(nil),             // 16: containarium.v1.ProxyRoute
+	(*ContainerMetrics)(nil),       // 17: containarium.v1.ContainerMetrics
+	(*BackupScheduleRun)(nil),      // 18: containarium.v1.BackupScheduleRun
+	(*timestamppb.Timestamp)(nil),  // 19: google.protobuf.Timestamp
+	(*TrafficEvent)(nil),           // 20: containarium.v1.TrafficEvent
 }
 var file_containarium_v1_events_proto_depIdxs = []int32{
-	8,  // 0: containarium.v1.ContainerEvent.container:type_name -> containarium.v1.Container
-	9,  // 1: containarium.v1.ContainerEvent.previous_state:type_name -> containarium.v1.ContainerState
-	10, // 2: containarium.v1.AppEvent.app:type_name -> containarium.v1.App
-	11, // 3: containarium.v1.AppEvent.previous_state:type_name -> containarium.v1.AppState
-	12, // 4: containarium.v1.RouteEvent.route:type_name -> containarium.v1.ProxyRoute
-	13, // 5: containarium.v1.MetricsEvent.metrics:type_name -> containarium.v1.ContainerMetrics
-	0,  // 6: containarium.v1.Event.type:type_name -> containarium.v1.EventType
-	1,  // 7: containarium.v1.Event.resource_type:type_name -> containarium.v1.ResourceType
-	14, // 8: containarium.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 9: containarium.v1.Event.container_event:type_name -> containarium.v1.ContainerEvent
-	3,  // 10: containarium.v1.Event.app_event:type_name -> containarium.v1.AppEvent
-	4,  // 11: containarium.v1.Event.route_event:type_name -> containarium.v1.RouteEvent
-	5,  // 12: containarium.v1.Event.metrics_event:type_name -> containarium.v1.MetricsEvent
-	15, // 13: containarium.v1.Event.traffic_event:type_name -> containarium.v1.TrafficEvent
-	1,  // 14: containarium.v1.SubscribeEventsRequest.resource_types:type_name -> containarium.v1.ResourceType
-	7,  // 15: containarium.v1.EventService.SubscribeEvents:input_type -> containarium.v1.SubscribeEventsRequest
-	6,  // 16: containarium.v1.EventService.SubscribeEvents:output_type -> containarium.v1.Event
-	16, // [16:17] is the sub-list for method output_type
-	15, // [15:16] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	12, // 0: containarium.v1.ContainerEvent.container:type_name -> containarium.v1.Container
+	13, // 1: containarium.v1.ContainerEvent.previous_state:type_name -> containarium.v1.ContainerState
+	14, // 2: containarium.v1.AppEvent.app:type_name -> containarium.v1.App
+	15, // 3: containarium.v1.AppEvent.previous_state:type_name -> containarium.v1.AppState
+	16, // 4: containarium.v1.RouteEvent.route:type_name -> containarium.v1.ProxyRoute
+	17, // 5: containarium.v1.MetricsEvent.metrics:type_name -> containarium.v1.ContainerMetrics
+	18, // 6: containarium.v1.BackupScheduleRunEvent.run:type_name -> containarium.v1.BackupScheduleRun
+	0,  // 7: containarium.v1.Event.type:type_name -> containarium.v1.EventType
+	1,  // 8: containarium.v1.Event.resource_type:type_name -> containarium.v1.ResourceType
+	19, // 9: containarium.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 10: containarium.v1.Event.container_event:type_name -> containarium.v1.ContainerEvent
+	4,  // 11: containarium.v1.Event.app_event:type_name -> containarium.v1.AppEvent
+	5,  // 12: containarium.v1.Event.route_event:type_name -> containarium.v1.RouteEvent
+	6,  // 13: containarium.v1.Event.metrics_event:type_name -> containarium.v1.MetricsEvent
+	20, // 14: containarium.v1.Event.traffic_event:type_name -> containarium.v1.TrafficEvent
+	7,  // 15: containarium.v1.Event.backup_progress_event:type_name -> containarium.v1.BackupProgressEvent
+	8,  // 16: containarium.v1.Event.backup_schedule_run_event:type_name -> containarium.v1.BackupScheduleRunEvent
+	9,  // 17: containarium.v1.Event.waf_match_event:type_name -> containarium.v1.WAFMatchEvent
+	3,  // 18: containarium.v1.Event.container_snapshot_event:type_name -> containarium.v1.ContainerSnapshotEvent
+	1,  // 19: containarium.v1.SubscribeEventsRequest.resource_types:type_name -> containarium.v1.ResourceType
+	11, // 20: containarium.v1.EventService.SubscribeEvents:input_type -> containarium.v1.SubscribeEventsRequest
+	10, // 21: containarium.v1.EventService.SubscribeEvents:output_type -> containarium.v1.Event
+	21, // [21:22] is the sub-list for method output_type
+	20, // [20:21] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_events_proto_init() }
@@ -768,12 +1252,17 @@ func file_containarium_v1_events_proto_init() {
 	file_containarium_v1_app_proto_init()
 	file_containarium_v1_network_proto_init()
 	file_containarium_v1_traffic_proto_init()
-	file_containarium_v1_events_proto_msgTypes[4].OneofWrappers = []any{
+	file_containarium_v1_backup_proto_init()
+	file_containarium_v1_events_proto_msgTypes[8].OneofWrappers = []any{
 		(*Event_ContainerEvent)(nil),
 		(*Event_AppEvent)(nil),
 		(*Event_RouteEvent)(nil),
 		(*Event_MetricsEvent)(nil),
 		(*Event_TrafficEvent)(nil),
+		(*Event_BackupProgressEvent)(nil),
+		(*Event_BackupScheduleRunEvent)(nil),
+		(*Event_WafMatchEvent)(nil),
+		(*Event_ContainerSnapshotEvent)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -781,7 +1270,7 @@ func file_containarium_v1_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_events_proto_rawDesc), len(file_containarium_v1_events_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   6,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -713,9 +713,16 @@ type NetworkNode struct {
 	// Node state: "running", "stopped", "error"
 	State string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
 	// Associated ACL name
-	AclName       string `protobuf:"bytes,6,opt,name=acl_name,json=aclName,proto3" json:"acl_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	AclName string `protobuf:"bytes,6,opt,name=acl_name,json=aclName,proto3" json:"acl_name,omitempty"`
+	// Tenant owning the container; empty for unmanaged and system nodes.
+	Tenant string `protobuf:"bytes,7,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Network policy policing the container: "tenant" for the tenant-wide
+	// policy, "box/<name>" for a label-selected box policy, empty when none.
+	NetworkPolicy string `protobuf:"bytes,8,opt,name=network_policy,json=networkPolicy,proto3" json:"network_policy,omitempty"`
+	// Mode of that policy: "log_only" or "enforce".
+	NetworkPolicyMode string `protobuf:"bytes,9,opt,name=network_policy_mode,json=networkPolicyMode,proto3" json:"network_policy_mode,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *NetworkNode) Reset() {
@@ -790,6 +797,27 @@ func (x *NetworkNode) GetAclName() string {
 	return ""
 }
 
+func (x *NetworkNode) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetNetworkPolicy() string {
+	if x != nil {
+		return x.NetworkPolicy
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetNetworkPolicyMode() string {
+	if x != nil {
+		return x.NetworkPolicyMode
+	}
+	return ""
+}
+
 // NetworkEdge represents a connection between nodes
 type NetworkEdge struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2839,7 +2867,7 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\bprotocol\x18\x04 \x01(\x0e2\x1e.containarium.v1.RouteProtocolR\bprotocol\x12\x16\n" +
 	"\x06active\x18\x05 \x01(\bR\x06active\x12%\n" +
 	"\x0econtainer_name\x18\x06 \x01(\tR\rcontainerName\x12 \n" +
-	"\vdescription\x18\a \x01(\tR\vdescription\"\x95\x01\n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\x84\x02\n" +
 	"\vNetworkNode\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
@@ -2847,7 +2875,10 @@ const file_containarium_v1_network_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x14\n" +
 	"\x05state\x18\x05 \x01(\tR\x05state\x12\x19\n" +
-	"\bacl_name\x18\x06 \x01(\tR\aaclName\"\x83\x01\n" +
+	"\bacl_name\x18\x06 \x01(\tR\aaclName\x12\x16\n" +
+	"\x06tenant\x18\a \x01(\tR\x06tenant\x12%\n" +
+	"\x0enetwork_policy\x18\b \x01(\tR\rnetworkPolicy\x12.\n" +
+	"\x13network_policy_mode\x18\t \x01(\tR\x11networkPolicyMode\"\x83\x01\n" +
 	"\vNetworkEdge\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x12\x12\n" +
@@ -26,7 +26,7 @@ var File_containarium_v1_network_policy_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_network_policy_proto_rawDesc = "" +
 	"\n" +
-	"$containarium/v1/network_policy.proto\x12\x0fcontainarium.v1\x1a\x1ccontainarium/v1/config.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xd8\x11\n" +
+	"$containarium/v1/network_policy.proto\x12\x0fcontainarium.v1\x1a\x1ccontainarium/v1/config.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xeb\"\n" +
 	"\x14NetworkPolicyService\x12\x8d\x02\n" +
 	"\x10SetNetworkPolicy\x12(.containarium.v1.SetNetworkPolicyRequest\x1a).containarium.v1.SetNetworkPolicyResponse\"\xa3\x01\x92A\x80\x01\n" +
 	"\rNetworkPolicy\x12\x12Set network policy\x1a[Create or replace a tenant's network-isolation policy (validated + normalized). Admin-only.\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/network-policies\x12\xee\x01\n" +
@@ -37,13 +37,30 @@ const file_containarium_v1_network_policy_proto_rawDesc = "" +
 	"\x13DeleteNetworkPolicy\x12+.containarium.v1.DeleteNetworkPolicyRequest\x1a,.containarium.v1.DeleteNetworkPolicyResponse\"\x94\x01\x92Al\n" +
 	"\rNetworkPolicy\x12\x15Delete network policy\x1aDRemove a tenant's network-isolation policy (idempotent). Admin-only.\x82\xd3\xe4\x93\x02\x1f*\x1d/v1/network-policies/{tenant}\x12\xac\x02\n" +
 	"\x1bPatchNetworkPolicyDenyRules\x123.containarium.v1.PatchNetworkPolicyDenyRulesRequest\x1a).containarium.v1.SetNetworkPolicyResponse\"\xac\x01\x92A\x7f\n" +
-	"\rNetworkPolicy\x12\x1fPatch network-policy deny rules\x1aMAtomically add/remove a tenant's virtual-patch deny rules (#660). Admin-only.\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/network-policies/deny-rules\x12\xa9\x02\n" +
+	"\rNetworkPolicy\x12\x1fPatch network-policy deny rules\x1aMAtomically add/remove a tenant's virtual-patch deny rules (#660). Admin-only.\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/network-policies/deny-rules\x12\xc3\x02\n" +
+	"\x14SuggestNetworkPolicy\x12,.containarium.v1.SuggestNetworkPolicyRequest\x1a-.containarium.v1.SuggestNetworkPolicyResponse\"\xcd\x01\x92A\x9c\x01\n" +
+	"\rNetworkPolicy\x12\x16Suggest network policy\x1asLearn a candidate policy from a tenant's observed egress and report what the current policy would deny. Admin-only.\x82\xd3\xe4\x93\x02'\x12%/v1/network-policies/{tenant}/suggest\x12\xb4\x02\n" +
+	"\x15SimulateNetworkPolicy\x12-.containarium.v1.SimulateNetworkPolicyRequest\x1a..containarium.v1.SimulateNetworkPolicyResponse\"\xbb\x01\x92A\x8f\x01\n" +
+	"\rNetworkPolicy\x12\x17Simulate network policy\x1aeReplay a tenant's recorded egress through a proposed policy and report what would change. Admin-only.\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/network-policies/simulate\x12\xa2\x02\n" +
+	"\x13SetBoxNetworkPolicy\x12+.containarium.v1.SetBoxNetworkPolicyRequest\x1a,.containarium.v1.SetBoxNetworkPolicyResponse\"\xaf\x01\x92A\x88\x01\n" +
+	"\rNetworkPolicy\x12\x16Set box network policy\x1a_Create or replace a label-selected network policy for a subset of a tenant's boxes. Admin-only.\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/box-network-policies\x12\xa1\x02\n" +
+	"\x16ListBoxNetworkPolicies\x12..containarium.v1.ListBoxNetworkPoliciesRequest\x1a/.containarium.v1.ListBoxNetworkPoliciesResponse\"\xa5\x01\x92A\x81\x01\n" +
+	"\rNetworkPolicy\x12\x19List box network policies\x1aUList the label-selected network policies of a tenant, or of every tenant. Admin-only.\x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/box-network-policies\x12\x9b\x02\n" +
+	"\x16DeleteBoxNetworkPolicy\x12..containarium.v1.DeleteBoxNetworkPolicyRequest\x1a/.containarium.v1.DeleteBoxNetworkPolicyResponse\"\x9f\x01\x92Al\n" +
+	"\rNetworkPolicy\x12\x19Delete box network policy\x1a@Remove a label-selected network policy (idempotent). Admin-only.\x82\xd3\xe4\x93\x02**(/v1/box-network-policies/{tenant}/{name}\x12\xa9\x02\n" +
 	"\x19SetNetworkPolicySignature\x121.containarium.v1.SetNetworkPolicySignatureRequest\x1a2.containarium.v1.SetNetworkPolicySignatureResponse\"\xa4\x01\x92Ay\n" +
 	"\rNetworkPolicy\x12\x1cSet network-policy signature\x1aJCreate or replace a global cleartext exploit signature (#661). Admin-only.\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/network-policy-signatures\x12\xa5\x02\n" +
 	"\x1bListNetworkPolicySignatures\x123.containarium.v1.ListNetworkPolicySignaturesRequest\x1a4.containarium.v1.ListNetworkPolicySignaturesResponse\"\x9a\x01\x92Ar\n" +
 	"\rNetworkPolicy\x12\x1eList network-policy signatures\x1aAList every operator-managed exploit signature (#661). Admin-only.\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/network-policy-signatures\x12\xbb\x02\n" +
 	"\x1cDeleteNetworkPolicySignature\x124.containarium.v1.DeleteNetworkPolicySignatureRequest\x1a5.containarium.v1.DeleteNetworkPolicySignatureResponse\"\xad\x01\x92A~\n" +
-	"\rNetworkPolicy\x12\x1fDelete network-policy signature\x1aLRemove an operator-managed exploit signature (#661, idempotent). Admin-only.\x82\xd3\xe4\x93\x02&*$/v1/network-policy-signatures/{name}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+	"\rNetworkPolicy\x12\x1fDelete network-policy signature\x1aLRemove an operator-managed exploit signature (#661, idempotent). Admin-only.\x82\xd3\xe4\x93\x02&*$/v1/network-policy-signatures/{name}\x12\xde\x01\n" +
+	"\n" +
+	"SetWAFRule\x12\".containarium.v1.SetWAFRuleRequest\x1a#.containarium.v1.SetWAFRuleResponse\"\x86\x01\x92Ak\n" +
+	"\rNetworkPolicy\x12\fSet WAF rule\x1aLCreate or replace an operator rule for the userspace WAF (#662). Admin-only.\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/waf-rules\x12\xd8\x01\n" +
+	"\fListWAFRules\x12$.containarium.v1.ListWAFRulesRequest\x1a%.containarium.v1.ListWAFRulesResponse\"{\x92Ac\n" +
+	"\rNetworkPolicy\x12\x0eList WAF rules\x1aBList every operator rule for the userspace WAF (#662). Admin-only.\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/waf-rules\x12\xf0\x01\n" +
+	"\rDeleteWAFRule\x12%.containarium.v1.DeleteWAFRuleRequest\x1a&.containarium.v1.DeleteWAFRuleResponse\"\x8f\x01\x92Ap\n" +
+	"\rNetworkPolicy\x12\x0fDelete WAF rule\x1aNRemove an operator rule from the userspace WAF (#662, idempotent). Admin-only.\x82\xd3\xe4\x93\x02\x16*\x14/v1/waf-rules/{name}BKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var file_containarium_v1_network_policy_proto_goTypes = []any{
 	(*SetNetworkPolicyRequest)(nil),              // 0: containarium.v1.SetNetworkPolicyRequest
@@ -51,16 +68,32 @@ var file_containarium_v1_network_policy_proto_goTypes = []any{
 	(*ListNetworkPoliciesRequest)(nil),           // 2: containarium.v1.ListNetworkPoliciesRequest
 	(*DeleteNetworkPolicyRequest)(nil),           // 3: containarium.v1.DeleteNetworkPolicyRequest
 	(*PatchNetworkPolicyDenyRulesRequest)(nil),   // 4: containarium.v1.PatchNetworkPolicyDenyRulesRequest
-	(*SetNetworkPolicySignatureRequest)(nil),     // 5: containarium.v1.SetNetworkPolicySignatureRequest
-	(*ListNetworkPolicySignaturesRequest)(nil),   // 6: containarium.v1.ListNetworkPolicySignaturesRequest
-	(*DeleteNetworkPolicySignatureRequest)(nil),  // 7: containarium.v1.DeleteNetworkPolicySignatureRequest
-	(*SetNetworkPolicyResponse)(nil),             // 8: containarium.v1.SetNetworkPolicyResponse
-	(*GetNetworkPolicyResponse)(nil),             // 9: containarium.v1.GetNetworkPolicyResponse
-	(*ListNetworkPoliciesResponse)(nil),          // 10: containarium.v1.ListNetworkPoliciesResponse
-	(*DeleteNetworkPolicyResponse)(nil),          // 11: containarium.v1.DeleteNetworkPolicyResponse
-	(*SetNetworkPolicySignatureResponse)(nil),    // 12: containarium.v1.SetNetworkPolicySignatureResponse
-	(*ListNetworkPolicySignaturesResponse)(nil),  // 13: containarium.v1.ListNetworkPolicySignaturesResponse
-	(*DeleteNetworkPolicySignatureResponse)(nil), // 14: containarium.v1.DeleteNetworkPolicySignatureResponse
+	(*SuggestNetworkPolicyRequest)(nil),          // 5: containarium.v1.SuggestNetworkPolicyRequest
+	(*SimulateNetworkPolicyRequest)(nil),         // 6: containarium.v1.SimulateNetworkPolicyRequest
+	(*SetBoxNetworkPolicyRequest)(nil),           // 7: containarium.v1.SetBoxNetworkPolicyRequest
+	(*ListBoxNetworkPoliciesRequest)(nil),        // 8: containarium.v1.ListBoxNetworkPoliciesRequest
+	(*DeleteBoxNetworkPolicyRequest)(nil),        // 9: containarium.v1.DeleteBoxNetworkPolicyRequest
+	(*SetNetworkPolicySignatureRequest)(nil),     // 10: containarium.v1.SetNetworkPolicySignatureRequest
+	(*ListNetworkPolicySignaturesRequest)(nil),   // 11: containarium.v1.ListNetworkPolicySignaturesRequest
+	(*DeleteNetworkPolicySignatureRequest)(nil),  // 12: containarium.v1.DeleteNetworkPolicySignatureRequest
+	(*SetWAFRuleRequest)(nil),                    // 13: containarium.v1.SetWAFRuleRequest
+	(*ListWAFRulesRequest)(nil),                  // 14: containarium.v1.ListWAFRulesRequest
+	(*DeleteWAFRuleRequest)(nil),                 // 15: containarium.v1.DeleteWAFRuleRequest
+	(*SetNetworkPolicyResponse)(nil),             // 16: containarium.v1.SetNetworkPolicyResponse
+	(*GetNetworkPolicyResponse)(nil),             // 17: containarium.v1.GetNetworkPolicyResponse
+	(*ListNetworkPoliciesResponse)(nil),          // 18: containarium.v1.ListNetworkPoliciesResponse
+	(*DeleteNetworkPolicyResponse)(nil),          // 19: containarium.v1.DeleteNetworkPolicyResponse
+	(*SuggestNetworkPolicyResponse)(nil),         // 20: containarium.v1.SuggestNetworkPolicyResponse
+	(*SimulateNetworkPolicyResponse)(nil),        // 21: containarium.v1.SimulateNetworkPolicyResponse
+	(*SetBoxNetworkPolicyResponse)(nil),          // 22: containarium.v1.SetBoxNetworkPolicyResponse
+	(*ListBoxNetworkPoliciesResponse)(nil),       // 23: containarium.v1.ListBoxNetworkPoliciesResponse
+	(*DeleteBoxNetworkPolicyResponse)(nil),       // 24: containarium.v1.DeleteBoxNetworkPolicyResponse
+	(*SetNetworkPolicySignatureResponse)(nil),    // 25: containarium.v1.SetNetworkPolicySignatureResponse
+	(*ListNetworkPolicySignaturesResponse)(nil),  // 26: containarium.v1.ListNetworkPolicySignaturesResponse
+	(*DeleteNetworkPolicySignatureResponse)(nil), // 27: containarium.v1.DeleteNetworkPolicySignatureResponse
+	(*SetWAFRuleResponse)(nil),                   // 28: containarium.v1.SetWAFRuleResponse
+	(*ListWAFRulesResponse)(nil),                 // 29: containarium.v1.ListWAFRulesResponse
+	(*DeleteWAFRuleResponse)(nil),                // 30: containarium.v1.DeleteWAFRuleResponse
 }
 var file_containarium_v1_network_policy_proto_depIdxs = []int32{
 	0,  // 0: containarium.v1.NetworkPolicyService.SetNetworkPolicy:input_type -> containarium.v1.SetNetworkPolicyRequest
@@ -68,19 +101,35 @@ var file_containarium_v1_network_policy_proto_depIdxs = []int32{
 	2,  // 2: containarium.v1.NetworkPolicyService.ListNetworkPolicies:input_type -> containarium.v1.ListNetworkPoliciesRequest
 	3,  // 3: containarium.v1.NetworkPolicyService.DeleteNetworkPolicy:input_type -> containarium.v1.DeleteNetworkPolicyRequest
 	4,  // 4: containarium.v1.NetworkPolicyService.PatchNetworkPolicyDenyRules:input_type -> containarium.v1.PatchNetworkPolicyDenyRulesRequest
-	5,  // 5: containarium.v1.NetworkPolicyService.SetNetworkPolicySignature:input_type -> containarium.v1.SetNetworkPolicySignatureRequest
-	6,  // 6: containarium.v1.NetworkPolicyService.ListNetworkPolicySignatures:input_type -> containarium.v1.ListNetworkPolicySignaturesRequest
-	7,  // 7: containarium.v1.NetworkPolicyService.DeleteNetworkPolicySignature:input_type -> containarium.v1.DeleteNetworkPolicySignatureRequest
-	8,  // 8: containarium.v1.NetworkPolicyService.SetNetworkPolicy:output_type -> containarium.v1.SetNetworkPolicyResponse
-	9,  // 9: containarium.v1.NetworkPolicyService.GetNetworkPolicy:output_type -> containarium.v1.GetNetworkPolicyResponse
-	10, // 10: containarium.v1.NetworkPolicyService.ListNetworkPolicies:output_type -> containarium.v1.ListNetworkPoliciesResponse
-	11, // 11: containarium.v1.NetworkPolicyService.DeleteNetworkPolicy:output_type -> containarium.v1.DeleteNetworkPolicyResponse
-	8,  // 12: containarium.v1.NetworkPolicyService.PatchNetworkPolicyDenyRules:output_type -> containarium.v1.SetNetworkPolicyResponse
-	12, // 13: containarium.v1.NetworkPolicyService.SetNetworkPolicySignature:output_type -> containarium.v1.SetNetworkPolicySignatureResponse
-	13, // 14: containarium.v1.NetworkPolicyService.ListNetworkPolicySignatures:output_type -> containarium.v1.ListNetworkPolicySignaturesResponse
-	14, // 15: containarium.v1.NetworkPolicyService.DeleteNetworkPolicySignature:output_type -> containarium.v1.DeleteNetworkPolicySignatureResponse
-	8,  // [8:16] is the sub-list for method output_type
-	0,  // [0:8] is the sub-list for method input_type
+	5,  // 5: containarium.v1.NetworkPolicyService.SuggestNetworkPolicy:input_type -> containarium.v1.SuggestNetworkPolicyRequest
+	6,  // 6: containarium.v1.NetworkPolicyService.SimulateNetworkPolicy:input_type -> containarium.v1.SimulateNetworkPolicyRequest
+	7,  // 7: containarium.v1.NetworkPolicyService.SetBoxNetworkPolicy:input_type -> containarium.v1.SetBoxNetworkPolicyRequest
+	8,  // 8: containarium.v1.NetworkPolicyService.ListBoxNetworkPolicies:input_type -> containarium.v1.ListBoxNetworkPoliciesRequest
+	9,  // 9: containarium.v1.NetworkPolicyService.DeleteBoxNetworkPolicy:input_type -> containarium.v1.DeleteBoxNetworkPolicyRequest
+	10, // 10: containarium.v1.NetworkPolicyService.SetNetworkPolicySignature:input_type -> containarium.v1.SetNetworkPolicySignatureRequest
+	11, // 11: containarium.v1.NetworkPolicyService.ListNetworkPolicySignatures:input_type -> containarium.v1.ListNetworkPolicySignaturesRequest
+	12, // 12: containarium.v1.NetworkPolicyService.DeleteNetworkPolicySignature:input_type -> containarium.v1.DeleteNetworkPolicySignatureRequest
+	13, // 13: containarium.v1.NetworkPolicyService.SetWAFRule:input_type -> containarium.v1.SetWAFRuleRequest
+	14, // 14: containarium.v1.NetworkPolicyService.ListWAFRules:input_type -> containarium.v1.ListWAFRulesRequest
+	15, // 15: containarium.v1.NetworkPolicyService.DeleteWAFRule:input_type -> containarium.v1.DeleteWAFRuleRequest
+	16, // 16: containarium.v1.NetworkPolicyService.SetNetworkPolicy:output_type -> containarium.v1.SetNetworkPolicyResponse
+	17, // 17: containarium.v1.NetworkPolicyService.GetNetworkPolicy:output_type -> containarium.v1.GetNetworkPolicyResponse
+	18, // 18: containarium.v1.NetworkPolicyService.ListNetworkPolicies:output_type -> containarium.v1.ListNetworkPoliciesResponse
+	19, // 19: containarium.v1.NetworkPolicyService.DeleteNetworkPolicy:output_type -> containarium.v1.DeleteNetworkPolicyResponse
+	16, // 20: containarium.v1.NetworkPolicyService.PatchNetworkPolicyDenyRules:output_type -> containarium.v1.SetNetworkPolicyResponse
+	20, // 21: containarium.v1.NetworkPolicyService.SuggestNetworkPolicy:output_type -> containarium.v1.SuggestNetworkPolicyResponse
+	21, // 22: containarium.v1.NetworkPolicyService.SimulateNetworkPolicy:output_type -> containarium.v1.SimulateNetworkPolicyResponse
+	22, // 23: containarium.v1.NetworkPolicyService.SetBoxNetworkPolicy:output_type -> containarium.v1.SetBoxNetworkPolicyResponse
+	23, // 24: containarium.v1.NetworkPolicyService.ListBoxNetworkPolicies:output_type -> containarium.v1.ListBoxNetworkPoliciesResponse
+	24, // 25: containarium.v1.NetworkPolicyService.DeleteBoxNetworkPolicy:output_type -> containarium.v1.DeleteBoxNetworkPolicyResponse
+	25, // 26: containarium.v1.NetworkPolicyService.SetNetworkPolicySignature:output_type -> containarium.v1.SetNetworkPolicySignatureResponse
+	26, // 27: containarium.v1.NetworkPolicyService.ListNetworkPolicySignatures:output_type -> containarium.v1.ListNetworkPolicySignaturesResponse
+	27, // 28: containarium.v1.NetworkPolicyService.DeleteNetworkPolicySignature:output_type -> containarium.v1.DeleteNetworkPolicySignatureResponse
+	28, // 29: containarium.v1.NetworkPolicyService.SetWAFRule:output_type -> containarium.v1.SetWAFRuleResponse
+	29, // 30: containarium.v1.NetworkPolicyService.ListWAFRules:output_type -> containarium.v1.ListWAFRulesResponse
+	30, // 31: containarium.v1.NetworkPolicyService.DeleteWAFRule:output_type -> containarium.v1.DeleteWAFRuleResponse
+	16, // [16:32] is the sub-list for method output_type
+	0,  // [0:16] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
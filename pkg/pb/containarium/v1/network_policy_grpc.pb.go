@@ -24,9 +24,17 @@ const (
 	NetworkPolicyService_ListNetworkPolicies_FullMethodName          = "/containarium.v1.NetworkPolicyService/ListNetworkPolicies"
 	NetworkPolicyService_DeleteNetworkPolicy_FullMethodName          = "/containarium.v1.NetworkPolicyService/DeleteNetworkPolicy"
 	NetworkPolicyService_PatchNetworkPolicyDenyRules_FullMethodName  = "/containarium.v1.NetworkPolicyService/PatchNetworkPolicyDenyRules"
+	NetworkPolicyService_SuggestNetworkPolicy_FullMethodName         = "/containarium.v1.NetworkPolicyService/SuggestNetworkPolicy"
+	NetworkPolicyService_SimulateNetworkPolicy_FullMethodName        = "/containarium.v1.NetworkPolicyService/SimulateNetworkPolicy"
+	NetworkPolicyService_SetBoxNetworkPolicy_FullMethodName          = "/containarium.v1.NetworkPolicyService/SetBoxNetworkPolicy"
+	NetworkPolicyService_ListBoxNetworkPolicies_FullMethodName       = "/containarium.v1.NetworkPolicyService/ListBoxNetworkPolicies"
+	NetworkPolicyService_DeleteBoxNetworkPolicy_FullMethodName       = "/containarium.v1.NetworkPolicyService/DeleteBoxNetworkPolicy"
 	NetworkPolicyService_SetNetworkPolicySignature_FullMethodName    = "/containarium.v1.NetworkPolicyService/SetNetworkPolicySignature"
 	NetworkPolicyService_ListNetworkPolicySignatures_FullMethodName  = "/containarium.v1.NetworkPolicyService/ListNetworkPolicySignatures"
 	NetworkPolicyService_DeleteNetworkPolicySignature_FullMethodName = "/containarium.v1.NetworkPolicyService/DeleteNetworkPolicySignature"
+	NetworkPolicyService_SetWAFRule_FullMethodName                   = "/containarium.v1.NetworkPolicyService/SetWAFRule"
+	NetworkPolicyService_ListWAFRules_FullMethodName                 = "/containarium.v1.NetworkPolicyService/ListWAFRules"
+	NetworkPolicyService_DeleteWAFRule_FullMethodName                = "/containarium.v1.NetworkPolicyService/DeleteWAFRule"
 )
 
 // NetworkPolicyServiceClient is the client API for NetworkPolicyService service.
@@ -56,6 +64,27 @@ type NetworkPolicyServiceClient interface {
 	// updates and `SetNetworkPolicy` (the allow-policy) never has to round-trip
 	// through the client to preserve them. Echoes the normalized stored policy.
 	PatchNetworkPolicyDenyRules(ctx context.Context, in *PatchNetworkPolicyDenyRulesRequest, opts ...grpc.CallOption) (*SetNetworkPolicyResponse, error)
+	// SuggestNetworkPolicy proposes a policy for a tenant from the egress its
+	// containers were observed making (learn mode): a candidate enforce-mode
+	// policy, its diff against the current one, and the destinations the
+	// current policy would have denied. Read-only — nothing is stored.
+	SuggestNetworkPolicy(ctx context.Context, in *SuggestNetworkPolicyRequest, opts ...grpc.CallOption) (*SuggestNetworkPolicyResponse, error)
+	// SimulateNetworkPolicy is a dry run of SetNetworkPolicy: it compiles the
+	// proposed policy and replays the tenant's recorded egress through it and
+	// the current policy, reporting the flows that would newly be denied or
+	// allowed. Read-only — nothing is stored.
+	SimulateNetworkPolicy(ctx context.Context, in *SimulateNetworkPolicyRequest, opts ...grpc.CallOption) (*SimulateNetworkPolicyResponse, error)
+	// SetBoxNetworkPolicy creates or replaces a box policy (upsert by tenant and
+	// name): a policy for the tenant's boxes whose container labels match its
+	// selector. A selected box is policed by it instead of the tenant-wide
+	// policy. The stored form is echoed back.
+	SetBoxNetworkPolicy(ctx context.Context, in *SetBoxNetworkPolicyRequest, opts ...grpc.CallOption) (*SetBoxNetworkPolicyResponse, error)
+	// ListBoxNetworkPolicies returns the box policies of one tenant, or of
+	// every tenant when tenant is empty.
+	ListBoxNetworkPolicies(ctx context.Context, in *ListBoxNetworkPoliciesRequest, opts ...grpc.CallOption) (*ListBoxNetworkPoliciesResponse, error)
+	// DeleteBoxNetworkPolicy removes a box policy. Idempotent; the boxes it
+	// selected fall back to the tenant-wide policy.
+	DeleteBoxNetworkPolicy(ctx context.Context, in *DeleteBoxNetworkPolicyRequest, opts ...grpc.CallOption) (*DeleteBoxNetworkPolicyResponse, error)
 	// SetNetworkPolicySignature creates or replaces a global operator exploit
 	// signature (#661 Tier 2, upsert by name). Validated + normalized; the stored
 	// form (with its assigned id) is echoed back.
@@ -66,6 +95,15 @@ type NetworkPolicyServiceClient interface {
 	// DeleteNetworkPolicySignature removes an operator signature by name.
 	// Idempotent.
 	DeleteNetworkPolicySignature(ctx context.Context, in *DeleteNetworkPolicySignatureRequest, opts ...grpc.CallOption) (*DeleteNetworkPolicySignatureResponse, error)
+	// SetWAFRule creates or replaces an operator WAF rule (#662 Tier 3, upsert by
+	// name). Validated (the pattern compiles) before it is stored; the stored
+	// form with its assigned id is echoed back. The running WAF reloads it.
+	SetWAFRule(ctx context.Context, in *SetWAFRuleRequest, opts ...grpc.CallOption) (*SetWAFRuleResponse, error)
+	// ListWAFRules returns every operator WAF rule (the built-in signatures are
+	// implicit and not listed here).
+	ListWAFRules(ctx context.Context, in *ListWAFRulesRequest, opts ...grpc.CallOption) (*ListWAFRulesResponse, error)
+	// DeleteWAFRule removes an operator WAF rule by name. Idempotent.
+	DeleteWAFRule(ctx context.Context, in *DeleteWAFRuleRequest, opts ...grpc.CallOption) (*DeleteWAFRuleResponse, error)
 }
 
 type networkPolicyServiceClient struct {
@@ -126,6 +164,56 @@ func (c *networkPolicyServiceClient) PatchNetworkPolicyDenyRules(ctx context.Con
 	return out, nil
 }
 
+func (c *networkPolicyServiceClient) SuggestNetworkPolicy(ctx context.Context, in *SuggestNetworkPolicyRequest, opts ...grpc.CallOption) (*SuggestNetworkPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestNetworkPolicyResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_SuggestNetworkPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkPolicyServiceClient) SimulateNetworkPolicy(ctx context.Context, in *SimulateNetworkPolicyRequest, opts ...grpc.CallOption) (*SimulateNetworkPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulateNetworkPolicyResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_SimulateNetworkPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkPolicyServiceClient) SetBoxNetworkPolicy(ctx context.Context, in *SetBoxNetworkPolicyRequest, opts ...grpc.CallOption) (*SetBoxNetworkPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBoxNetworkPolicyResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_SetBoxNetworkPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkPolicyServiceClient) ListBoxNetworkPolicies(ctx context.Context, in *ListBoxNetworkPoliciesRequest, opts ...grpc.CallOption) (*ListBoxNetworkPoliciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBoxNetworkPoliciesResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_ListBoxNetworkPolicies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkPolicyServiceClient) DeleteBoxNetworkPolicy(ctx context.Context, in *DeleteBoxNetworkPolicyRequest, opts ...grpc.CallOption) (*DeleteBoxNetworkPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBoxNetworkPolicyResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_DeleteBoxNetworkPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkPolicyServiceClient) SetNetworkPolicySignature(ctx context.Context, in *SetNetworkPolicySignatureRequest, opts ...grpc.CallOption) (*SetNetworkPolicySignatureResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetNetworkPolicySignatureResponse)
@@ -156,6 +244,36 @@ func (c *networkPolicyServiceClient) DeleteNetworkPolicySignature(ctx context.Co
 	return out, nil
 }
 
+func (c *networkPolicyServiceClient) SetWAFRule(ctx context.Context, in *SetWAFRuleRequest, opts ...grpc.CallOption) (*SetWAFRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWAFRuleResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_SetWAFRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkPolicyServiceClient) ListWAFRules(ctx context.Context, in *ListWAFRulesRequest, opts ...grpc.CallOption) (*ListWAFRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWAFRulesResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_ListWAFRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkPolicyServiceClient) DeleteWAFRule(ctx context.Context, in *DeleteWAFRuleRequest, opts ...grpc.CallOption) (*DeleteWAFRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWAFRuleResponse)
+	err := c.cc.Invoke(ctx, NetworkPolicyService_DeleteWAFRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkPolicyServiceServer is the server API for NetworkPolicyService service.
 // All implementations must embed UnimplementedNetworkPolicyServiceServer
 // for forward compatibility.
@@ -183,6 +301,27 @@ type NetworkPolicyServiceServer interface {
 	// updates and `SetNetworkPolicy` (the allow-policy) never has to round-trip
 	// through the client to preserve them. Echoes the normalized stored policy.
 	PatchNetworkPolicyDenyRules(context.Context, *PatchNetworkPolicyDenyRulesRequest) (*SetNetworkPolicyResponse, error)
+	// SuggestNetworkPolicy proposes a policy for a tenant from the egress its
+	// containers were observed making (learn mode): a candidate enforce-mode
+	// policy, its diff against the current one, and the destinations the
+	// current policy would have denied. Read-only — nothing is stored.
+	SuggestNetworkPolicy(context.Context, *SuggestNetworkPolicyRequest) (*SuggestNetworkPolicyResponse, error)
+	// SimulateNetworkPolicy is a dry run of SetNetworkPolicy: it compiles the
+	// proposed policy and replays the tenant's recorded egress through it and
+	// the current policy, reporting the flows that would newly be denied or
+	// allowed. Read-only — nothing is stored.
+	SimulateNetworkPolicy(context.Context, *SimulateNetworkPolicyRequest) (*SimulateNetworkPolicyResponse, error)
+	// SetBoxNetworkPolicy creates or replaces a box policy (upsert by tenant and
+	// name): a policy for the tenant's boxes whose container labels match its
+	// selector. A selected box is policed by it instead of the tenant-wide
+	// policy. The stored form is echoed back.
+	SetBoxNetworkPolicy(context.Context, *SetBoxNetworkPolicyRequest) (*SetBoxNetworkPolicyResponse, error)
+	// ListBoxNetworkPolicies returns the box policies of one tenant, or of
+	// every tenant when tenant is empty.
+	ListBoxNetworkPolicies(context.Context, *ListBoxNetworkPoliciesRequest) (*ListBoxNetworkPoliciesResponse, error)
+	// DeleteBoxNetworkPolicy removes a box policy. Idempotent; the boxes it
+	// selected fall back to the tenant-wide policy.
+	DeleteBoxNetworkPolicy(context.Context, *DeleteBoxNetworkPolicyRequest) (*DeleteBoxNetworkPolicyResponse, error)
 	// SetNetworkPolicySignature creates or replaces a global operator exploit
 	// signature (#661 Tier 2, upsert by name). Validated + normalized; the stored
 	// form (with its assigned id) is echoed back.
@@ -193,6 +332,15 @@ type NetworkPolicyServiceServer interface {
 	// DeleteNetworkPolicySignature removes an operator signature by name.
 	// Idempotent.
 	DeleteNetworkPolicySignature(context.Context, *DeleteNetworkPolicySignatureRequest) (*DeleteNetworkPolicySignatureResponse, error)
+	// SetWAFRule creates or replaces an operator WAF rule (#662 Tier 3, upsert by
+	// name). Validated (the pattern compiles) before it is stored; the stored
+	// form with its assigned id is echoed back. The running WAF reloads it.
+	SetWAFRule(context.Context, *SetWAFRuleRequest) (*SetWAFRuleResponse, error)
+	// ListWAFRules returns every operator WAF rule (the built-in signatures are
+	// implicit and not listed here).
+	ListWAFRules(context.Context, *ListWAFRulesRequest) (*ListWAFRulesResponse, error)
+	// DeleteWAFRule removes an operator WAF rule by name. Idempotent.
+	DeleteWAFRule(context.Context, *DeleteWAFRuleRequest) (*DeleteWAFRuleResponse, error)
 	mustEmbedUnimplementedNetworkPolicyServiceServer()
 }
 
@@ -218,6 +366,21 @@ func (UnimplementedNetworkPolicyServiceServer) DeleteNetworkPolicy(context.Conte
 func (UnimplementedNetworkPolicyServiceServer) PatchNetworkPolicyDenyRules(context.Context, *PatchNetworkPolicyDenyRulesRequest) (*SetNetworkPolicyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method PatchNetworkPolicyDenyRules not implemented")
 }
+func (UnimplementedNetworkPolicyServiceServer) SuggestNetworkPolicy(context.Context, *SuggestNetworkPolicyRequest) (*SuggestNetworkPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestNetworkPolicy not implemented")
+}
+func (UnimplementedNetworkPolicyServiceServer) SimulateNetworkPolicy(context.Context, *SimulateNetworkPolicyRequest) (*SimulateNetworkPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SimulateNetworkPolicy not implemented")
+}
+func (UnimplementedNetworkPolicyServiceServer) SetBoxNetworkPolicy(context.Context, *SetBoxNetworkPolicyRequest) (*SetBoxNetworkPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBoxNetworkPolicy not implemented")
+}
+func (UnimplementedNetworkPolicyServiceServer) ListBoxNetworkPolicies(context.Context, *ListBoxNetworkPoliciesRequest) (*ListBoxNetworkPoliciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBoxNetworkPolicies not implemented")
+}
+func (UnimplementedNetworkPolicyServiceServer) DeleteBoxNetworkPolicy(context.Context, *DeleteBoxNetworkPolicyRequest) (*DeleteBoxNetworkPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBoxNetworkPolicy not implemented")
+}
 func (UnimplementedNetworkPolicyServiceServer) SetNetworkPolicySignature(context.Context, *SetNetworkPolicySignatureRequest) (*SetNetworkPolicySignatureResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetNetworkPolicySignature not implemented")
 }
@@ -227,6 +390,15 @@ func (UnimplementedNetworkPolicyServiceServer) ListNetworkPolicySignatures(conte
 func (UnimplementedNetworkPolicyServiceServer) DeleteNetworkPolicySignature(context.Context, *DeleteNetworkPolicySignatureRequest) (*DeleteNetworkPolicySignatureResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteNetworkPolicySignature not implemented")
 }
+func (UnimplementedNetworkPolicyServiceServer) SetWAFRule(context.Context, *SetWAFRuleRequest) (*SetWAFRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWAFRule not implemented")
+}
+func (UnimplementedNetworkPolicyServiceServer) ListWAFRules(context.Context, *ListWAFRulesRequest) (*ListWAFRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWAFRules not implemented")
+}
+func (UnimplementedNetworkPolicyServiceServer) DeleteWAFRule(context.Context, *DeleteWAFRuleRequest) (*DeleteWAFRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWAFRule not implemented")
+}
 func (UnimplementedNetworkPolicyServiceServer) mustEmbedUnimplementedNetworkPolicyServiceServer() {}
 func (UnimplementedNetworkPolicyServiceServer) testEmbeddedByValue()                              {}
 
@@ -338,6 +510,96 @@ func _NetworkPolicyService_PatchNetworkPolicyDenyRules_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkPolicyService_SuggestNetworkPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestNetworkPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).SuggestNetworkPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_SuggestNetworkPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).SuggestNetworkPolicy(ctx, req.(*SuggestNetworkPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkPolicyService_SimulateNetworkPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateNetworkPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).SimulateNetworkPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_SimulateNetworkPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).SimulateNetworkPolicy(ctx, req.(*SimulateNetworkPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkPolicyService_SetBoxNetworkPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBoxNetworkPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).SetBoxNetworkPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_SetBoxNetworkPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).SetBoxNetworkPolicy(ctx, req.(*SetBoxNetworkPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkPolicyService_ListBoxNetworkPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBoxNetworkPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).ListBoxNetworkPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_ListBoxNetworkPolicies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).ListBoxNetworkPolicies(ctx, req.(*ListBoxNetworkPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkPolicyService_DeleteBoxNetworkPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBoxNetworkPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).DeleteBoxNetworkPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_DeleteBoxNetworkPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).DeleteBoxNetworkPolicy(ctx, req.(*DeleteBoxNetworkPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkPolicyService_SetNetworkPolicySignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetNetworkPolicySignatureRequest)
 	if err := dec(in); err != nil {
@@ -392,6 +654,60 @@ func _NetworkPolicyService_DeleteNetworkPolicySignature_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkPolicyService_SetWAFRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWAFRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).SetWAFRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_SetWAFRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).SetWAFRule(ctx, req.(*SetWAFRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkPolicyService_ListWAFRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWAFRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).ListWAFRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_ListWAFRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).ListWAFRules(ctx, req.(*ListWAFRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkPolicyService_DeleteWAFRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWAFRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkPolicyServiceServer).DeleteWAFRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkPolicyService_DeleteWAFRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkPolicyServiceServer).DeleteWAFRule(ctx, req.(*DeleteWAFRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NetworkPolicyService_ServiceDesc is the grpc.ServiceDesc for NetworkPolicyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -419,6 +735,26 @@ var NetworkPolicyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PatchNetworkPolicyDenyRules",
 			Handler:    _NetworkPolicyService_PatchNetworkPolicyDenyRules_Handler,
 		},
+		{
+			MethodName: "SuggestNetworkPolicy",
+			Handler:    _NetworkPolicyService_SuggestNetworkPolicy_Handler,
+		},
+		{
+			MethodName: "SimulateNetworkPolicy",
+			Handler:    _NetworkPolicyService_SimulateNetworkPolicy_Handler,
+		},
+		{
+			MethodName: "SetBoxNetworkPolicy",
+			Handler:    _NetworkPolicyService_SetBoxNetworkPolicy_Handler,
+		},
+		{
+			MethodName: "ListBoxNetworkPolicies",
+			Handler:    _NetworkPolicyService_ListBoxNetworkPolicies_Handler,
+		},
+		{
+			MethodName: "DeleteBoxNetworkPolicy",
+			Handler:    _NetworkPolicyService_DeleteBoxNetworkPolicy_Handler,
+		},
 		{
 			MethodName: "SetNetworkPolicySignature",
 			Handler:    _NetworkPolicyService_SetNetworkPolicySignature_Handler,
@@ -431,6 +767,18 @@ var NetworkPolicyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteNetworkPolicySignature",
 			Handler:    _NetworkPolicyService_DeleteNetworkPolicySignature_Handler,
 		},
+		{
+			MethodName: "SetWAFRule",
+			Handler:    _NetworkPolicyService_SetWAFRule_Handler,
+		},
+		{
+			MethodName: "ListWAFRules",
+			Handler:    _NetworkPolicyService_ListWAFRules_Handler,
+		},
+		{
+			MethodName: "DeleteWAFRule",
+			Handler:    _NetworkPolicyService_DeleteWAFRule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "containarium/v1/network_policy.proto",
@@ -768,6 +768,302 @@ func (x *RefreshSecretsResponse) GetStamped() int32 {
 	return 0
 }
 
+// SecretVersion is one retained version of a secret — metadata only,
+// like SecretMetadata. The daemon keeps the newest few versions of each
+// secret (the current one included) so a bad rotation can be undone.
+type SecretVersion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version number, as SecretMetadata.version reported it when written.
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// RFC3339 time the version was written.
+	CreatedAt string `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Delivery mode the version was written with. RollbackSecret restores
+	// it along with the value.
+	DeliveryMode SecretDelivery `protobuf:"varint,3,opt,name=delivery_mode,json=deliveryMode,proto3,enum=containarium.v1.SecretDelivery" json:"delivery_mode,omitempty"`
+	// True for the version currently in effect — the one RefreshSecrets
+	// delivers.
+	Current       bool `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretVersion) Reset() {
+	*x = SecretVersion{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretVersion) ProtoMessage() {}
+
+func (x *SecretVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretVersion.ProtoReflect.Descriptor instead.
+func (*SecretVersion) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SecretVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SecretVersion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SecretVersion) GetDeliveryMode() SecretDelivery {
+	if x != nil {
+		return x.DeliveryMode
+	}
+	return SecretDelivery_SECRET_DELIVERY_UNSPECIFIED
+}
+
+func (x *SecretVersion) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+// ListSecretVersionsRequest lists a secret's retained versions.
+type ListSecretVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretVersionsRequest) Reset() {
+	*x = ListSecretVersionsRequest{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretVersionsRequest) ProtoMessage() {}
+
+func (x *ListSecretVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListSecretVersionsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListSecretVersionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListSecretVersionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Versions      []*SecretVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretVersionsResponse) Reset() {
+	*x = ListSecretVersionsResponse{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretVersionsResponse) ProtoMessage() {}
+
+func (x *ListSecretVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListSecretVersionsResponse) GetVersions() []*SecretVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// RollbackSecretRequest makes a retained version current again. The old
+// value is written as a NEW version (the counter never goes back), so the
+// value it replaces stays in history and the rollback can be undone.
+//
+// Like SetSecret, this does not touch the running container; call
+// RefreshSecrets to deliver the rolled-back value without a restart.
+type RollbackSecretRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Version to restore, from ListSecretVersions.
+	Version       int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackSecretRequest) Reset() {
+	*x = RollbackSecretRequest{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackSecretRequest) ProtoMessage() {}
+
+func (x *RollbackSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackSecretRequest.ProtoReflect.Descriptor instead.
+func (*RollbackSecretRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RollbackSecretRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RollbackSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RollbackSecretRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RollbackSecretResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operator-facing summary ("rolled back to version N as version M").
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Resulting metadata after the write.
+	Secret        *SecretMetadata `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackSecretResponse) Reset() {
+	*x = RollbackSecretResponse{}
+	mi := &file_containarium_v1_secrets_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackSecretResponse) ProtoMessage() {}
+
+func (x *RollbackSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_secrets_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackSecretResponse.ProtoReflect.Descriptor instead.
+func (*RollbackSecretResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_secrets_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RollbackSecretResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RollbackSecretResponse) GetSecret() *SecretMetadata {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
 var File_containarium_v1_secrets_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_secrets_proto_rawDesc = "" +
@@ -811,7 +1107,25 @@ const file_containarium_v1_secrets_proto_rawDesc = "" +
 	"\busername\x18\x01 \x01(\tR\busername\"L\n" +
 	"\x16RefreshSecretsResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\astamped\x18\x02 \x01(\x05R\astamped*\x81\x01\n" +
+	"\astamped\x18\x02 \x01(\x05R\astamped\"\xa8\x01\n" +
+	"\rSecretVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\tR\tcreatedAt\x12D\n" +
+	"\rdelivery_mode\x18\x03 \x01(\x0e2\x1f.containarium.v1.SecretDeliveryR\fdeliveryMode\x12\x18\n" +
+	"\acurrent\x18\x04 \x01(\bR\acurrent\"K\n" +
+	"\x19ListSecretVersionsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"X\n" +
+	"\x1aListSecretVersionsResponse\x12:\n" +
+	"\bversions\x18\x01 \x03(\v2\x1e.containarium.v1.SecretVersionR\bversions\"a\n" +
+	"\x15RollbackSecretRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"k\n" +
+	"\x16RollbackSecretResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x127\n" +
+	"\x06secret\x18\x02 \x01(\v2\x1f.containarium.v1.SecretMetadataR\x06secret*\x81\x01\n" +
 	"\x0eSecretDelivery\x12\x1f\n" +
 	"\x1bSECRET_DELIVERY_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13SECRET_DELIVERY_ENV\x10\x01\x12\x18\n" +
@@ -831,32 +1145,40 @@ func file_containarium_v1_secrets_proto_rawDescGZIP() []byte {
 }
 
 var file_containarium_v1_secrets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_containarium_v1_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_containarium_v1_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_containarium_v1_secrets_proto_goTypes = []any{
-	(SecretDelivery)(0),            // 0: containarium.v1.SecretDelivery
-	(*SecretMetadata)(nil),         // 1: containarium.v1.SecretMetadata
-	(*SetSecretRequest)(nil),       // 2: containarium.v1.SetSecretRequest
-	(*SetSecretResponse)(nil),      // 3: containarium.v1.SetSecretResponse
-	(*GetSecretRequest)(nil),       // 4: containarium.v1.GetSecretRequest
-	(*GetSecretResponse)(nil),      // 5: containarium.v1.GetSecretResponse
-	(*ListSecretsRequest)(nil),     // 6: containarium.v1.ListSecretsRequest
-	(*ListSecretsResponse)(nil),    // 7: containarium.v1.ListSecretsResponse
-	(*DeleteSecretRequest)(nil),    // 8: containarium.v1.DeleteSecretRequest
-	(*DeleteSecretResponse)(nil),   // 9: containarium.v1.DeleteSecretResponse
-	(*RefreshSecretsRequest)(nil),  // 10: containarium.v1.RefreshSecretsRequest
-	(*RefreshSecretsResponse)(nil), // 11: containarium.v1.RefreshSecretsResponse
+	(SecretDelivery)(0),                // 0: containarium.v1.SecretDelivery
+	(*SecretMetadata)(nil),             // 1: containarium.v1.SecretMetadata
+	(*SetSecretRequest)(nil),           // 2: containarium.v1.SetSecretRequest
+	(*SetSecretResponse)(nil),          // 3: containarium.v1.SetSecretResponse
+	(*GetSecretRequest)(nil),           // 4: containarium.v1.GetSecretRequest
+	(*GetSecretResponse)(nil),          // 5: containarium.v1.GetSecretResponse
+	(*ListSecretsRequest)(nil),         // 6: containarium.v1.ListSecretsRequest
+	(*ListSecretsResponse)(nil),        // 7: containarium.v1.ListSecretsResponse
+	(*DeleteSecretRequest)(nil),        // 8: containarium.v1.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),       // 9: containarium.v1.DeleteSecretResponse
+	(*RefreshSecretsRequest)(nil),      // 10: containarium.v1.RefreshSecretsRequest
+	(*RefreshSecretsResponse)(nil),     // 11: containarium.v1.RefreshSecretsResponse
+	(*SecretVersion)(nil),              // 12: containarium.v1.SecretVersion
+	(*ListSecretVersionsRequest)(nil),  // 13: containarium.v1.ListSecretVersionsRequest
+	(*ListSecretVersionsResponse)(nil), // 14: containarium.v1.ListSecretVersionsResponse
+	(*RollbackSecretRequest)(nil),      // 15: containarium.v1.RollbackSecretRequest
+	(*RollbackSecretResponse)(nil),     // 16: containarium.v1.RollbackSecretResponse
 }
 var file_containarium_v1_secrets_proto_depIdxs = []int32{
-	0, // 0: containarium.v1.SecretMetadata.delivery_mode:type_name -> containarium.v1.SecretDelivery
-	0, // 1: containarium.v1.SetSecretRequest.delivery_mode:type_name -> containarium.v1.SecretDelivery
-	1, // 2: containarium.v1.SetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
-	1, // 3: containarium.v1.GetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
-	1, // 4: containarium.v1.ListSecretsResponse.secrets:type_name -> containarium.v1.SecretMetadata
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0,  // 0: containarium.v1.SecretMetadata.delivery_mode:type_name -> containarium.v1.SecretDelivery
+	0,  // 1: containarium.v1.SetSecretRequest.delivery_mode:type_name -> containarium.v1.SecretDelivery
+	1,  // 2: containarium.v1.SetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
+	1,  // 3: containarium.v1.GetSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
+	1,  // 4: containarium.v1.ListSecretsResponse.secrets:type_name -> containarium.v1.SecretMetadata
+	0,  // 5: containarium.v1.SecretVersion.delivery_mode:type_name -> containarium.v1.SecretDelivery
+	12, // 6: containarium.v1.ListSecretVersionsResponse.versions:type_name -> containarium.v1.SecretVersion
+	1,  // 7: containarium.v1.RollbackSecretResponse.secret:type_name -> containarium.v1.SecretMetadata
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_secrets_proto_init() }
@@ -870,7 +1192,7 @@ func file_containarium_v1_secrets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_secrets_proto_rawDesc), len(file_containarium_v1_secrets_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
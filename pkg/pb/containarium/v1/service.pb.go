@@ -26,7 +26,7 @@ var File_containarium_v1_service_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1dcontainarium/v1/service.proto\x12\x0fcontainarium.v1\x1a\x1fcontainarium/v1/container.proto\x1a\x1ccontainarium/v1/config.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1bcontainarium/v1/alert.proto\x1a\x1dcontainarium/v1/secrets.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2ҫ\x01\n" +
+	"\x1dcontainarium/v1/service.proto\x12\x0fcontainarium.v1\x1a\x1fcontainarium/v1/container.proto\x1a\x1ccontainarium/v1/config.proto\x1a\x19containarium/v1/app.proto\x1a\x1dcontainarium/v1/network.proto\x1a\x1bcontainarium/v1/alert.proto\x1a\x1dcontainarium/v1/secrets.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x89\xd1\x01\n" +
 	"\x10ContainerService\x12\xae\x02\n" +
 	"\x0fCreateContainer\x12'.containarium.v1.CreateContainerRequest\x1a(.containarium.v1.CreateContainerResponse\"\xc7\x01\x92A\xaa\x01\n" +
 	"\n" +
@@ -58,7 +58,25 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"\x17DeleteContainerSnapshot\x12/.containarium.v1.DeleteContainerSnapshotRequest\x1a0.containarium.v1.DeleteContainerSnapshotResponse\"\xd8\x01\x92A\xa2\x01\n" +
 	"\x14Container Operations\x12\x1bDelete a container snapshot\x1amRemoves the snapshot and reports the bytes reclaimed. Works while the container's encryption key is unloaded.\x82\xd3\xe4\x93\x02,**/v1/containers/{username}/snapshots/{name}\x12\xe5\x03\n" +
 	"\x19RollbackContainerSnapshot\x121.containarium.v1.RollbackContainerSnapshotRequest\x1a2.containarium.v1.RollbackContainerSnapshotResponse\"\xe0\x02\x92A\x9e\x02\n" +
-	"\x14Container Operations\x12#Roll a container back to a snapshot\x1a\xe0\x01Discards everything written since the snapshot. Refuses a running container unless force is set, refuses to destroy newer snapshots unless destroy_newer is set, and refuses when the container's encryption key is unavailable.\x82\xd3\xe4\x93\x028:\x01*\"3/v1/containers/{username}/snapshots/{name}/rollback\x12\xe7\x03\n" +
+	"\x14Container Operations\x12#Roll a container back to a snapshot\x1a\xe0\x01Discards everything written since the snapshot. Refuses a running container unless force is set, refuses to destroy newer snapshots unless destroy_newer is set, and refuses when the container's encryption key is unavailable.\x82\xd3\xe4\x93\x028:\x01*\"3/v1/containers/{username}/snapshots/{name}/rollback\x12\xab\x04\n" +
+	"\x0eCloneContainer\x12&.containarium.v1.CloneContainerRequest\x1a'.containarium.v1.CloneContainerResponse\"\xc7\x03\x92A\x84\x03\n" +
+	"\x14Container Operations\x12$Fork a new container from a snapshot\x1a\xc5\x02Creates a new container from a snapshot of an existing one as an instant copy-on-write ZFS clone, with a fresh user, SSH keys, IP, secrets and labels. The fork stays in the source's tenant and storage pool. Refused when the source's encryption key is unavailable, and the snapshot cannot be deleted while a fork of it exists.\x82\xd3\xe4\x93\x029:\x01*\"4/v1/containers/{username}/snapshots/{snapshot}/clone\x12\xf4\x03\n" +
+	"\x17ExportContainerSnapshot\x12/.containarium.v1.ExportContainerSnapshotRequest\x1a0.containarium.v1.ExportContainerSnapshotResponse\"\xf5\x02\x92A\xb1\x02\n" +
+	"\x14Container Operations\x12(Export a container snapshot off the host\x1a\xee\x01Streams the snapshot with a raw `zfs send` to an s3://, gs:// or file:// target, incremental from the container's previous export to that target when possible. Encrypted containers are exported as ciphertext; the target never holds a key.\x82\xd3\xe4\x93\x02::\x01*\"5/v1/containers/{username}/snapshots/{snapshot}/export\x12\xe2\x03\n" +
+	"\x17ImportContainerSnapshot\x12/.containarium.v1.ImportContainerSnapshotRequest\x1a0.containarium.v1.ImportContainerSnapshotResponse\"\xe3\x02\x92A\xaa\x02\n" +
+	"\x14Container Operations\x12+Restore a container from exported snapshots\x1a\xe4\x01Receives the exported chain up to the requested snapshot into a new container shaped like the exported one, with its own IP and secrets. An encrypted chain is restored into its tenant's encrypted pool and needs the tenant's key.\x82\xd3\xe4\x93\x02/:\x01*\"*/v1/containers/{username}/snapshots/import\x12\x9f\x03\n" +
+	"\x1aListContainerSnapshotFiles\x122.containarium.v1.ListContainerSnapshotFilesRequest\x1a3.containarium.v1.ListContainerSnapshotFilesResponse\"\x97\x02\x92A\xd7\x01\n" +
+	"\x14Container Operations\x12(List a directory in a container snapshot\x1a\x94\x01Lists a directory of the container's filesystem as it was when the snapshot was taken. Needs the container running and its encryption key available.\x82\xd3\xe4\x93\x026\x124/v1/containers/{username}/snapshots/{snapshot}/files\x12\xa3\x03\n" +
+	"\x19ReadContainerSnapshotFile\x121.containarium.v1.ReadContainerSnapshotFileRequest\x1a2.containarium.v1.ReadContainerSnapshotFileResponse\"\x9e\x02\x92A\xdf\x01\n" +
+	"\x14Container Operations\x12%Read a file from a container snapshot\x1a\x9f\x01Reads up to 4 MiB of a regular file as it was when the snapshot was taken, from the given offset. Needs the container running and its encryption key available.\x82\xd3\xe4\x93\x025\x123/v1/containers/{username}/snapshots/{snapshot}/file\x12\xd7\x03\n" +
+	"\x16DiffContainerSnapshots\x12..containarium.v1.DiffContainerSnapshotsRequest\x1a/.containarium.v1.DiffContainerSnapshotsResponse\"\xdb\x02\x92A\x97\x02\n" +
+	"\x14Container Operations\x12\x19Diff a container snapshot\x1a\xe3\x01Lists the paths added, modified and deleted between the snapshot and to_snapshot, or the live container when to_snapshot is empty, with their sizes before and after. Needs the container running and its encryption key available.\x82\xd3\xe4\x93\x02:\x128/v1/containers/{username}/snapshots/{from_snapshot}/diff\x12\xe2\x03\n" +
+	"\x1aSetContainerSnapshotPolicy\x122.containarium.v1.SetContainerSnapshotPolicyRequest\x1a3.containarium.v1.SetContainerSnapshotPolicyResponse\"\xda\x02\x92A\xa2\x02\n" +
+	"\x14Container Operations\x12 Schedule a container's snapshots\x1a\xe7\x01Sets an interval or cron schedule for the container's snapshots, a retention (keep the last N, one per day, one per week), and optional hooks run inside the container around each snapshot. Retention only prunes scheduled snapshots.\x82\xd3\xe4\x93\x02.:\x01*\x1a)/v1/containers/{username}/snapshot-policy\x12\xf6\x01\n" +
+	"\x1aGetContainerSnapshotPolicy\x122.containarium.v1.GetContainerSnapshotPolicyRequest\x1a3.containarium.v1.GetContainerSnapshotPolicyResponse\"o\x92A;\n" +
+	"\x14Container Operations\x12#Get a container's snapshot schedule\x82\xd3\xe4\x93\x02+\x12)/v1/containers/{username}/snapshot-policy\x12\xdb\x02\n" +
+	"\x1dDeleteContainerSnapshotPolicy\x125.containarium.v1.DeleteContainerSnapshotPolicyRequest\x1a6.containarium.v1.DeleteContainerSnapshotPolicyResponse\"\xca\x01\x92A\x95\x01\n" +
+	"\x14Container Operations\x12&Stop a container's scheduled snapshots\x1aURemoves the schedule. Snapshots it already took are kept; delete them like any other.\x82\xd3\xe4\x93\x02+*)/v1/containers/{username}/snapshot-policy\x12\xe7\x03\n" +
 	"\x13DeleteTenantStorage\x12+.containarium.v1.DeleteTenantStorageRequest\x1a,.containarium.v1.DeleteTenantStorageResponse\"\xf4\x02\x92A\xcc\x02\n" +
 	"\x14Container Operations\x12.Destroy a departing tenant's encrypted storage\x1a\x83\x02Deletes the tenant's Incus storage pool and then the encrypted dataset it was sourced at. Refused while the tenant still has containers. Irreversible — the dataset is the tenant's encryptionroot, so this destroys the only key path to their data. Admin only.\x82\xd3\xe4\x93\x02\x1e*\x1c/v1/tenants/{tenant}/storage\x12\xc5\x03\n" +
 	"\x0fRewrapContainer\x12'.containarium.v1.RewrapContainerRequest\x1a(.containarium.v1.RewrapContainerResponse\"\xde\x02\x92A\xaf\x02\n" +
@@ -163,7 +181,11 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"\fDeleteSecret\x12$.containarium.v1.DeleteSecretRequest\x1a%.containarium.v1.DeleteSecretResponse\"\xdf\x01\x92A\xb6\x01\n" +
 	"\aSecrets\x12\x16Delete a tenant secret\x1a\x92\x01Removes the secret from Postgres. Does NOT cascade to env-var stamps on running containers — call RefreshSecrets to re-stamp without restarting.\x82\xd3\xe4\x93\x02\x1f*\x1d/v1/secrets/{username}/{name}\x12\x90\x03\n" +
 	"\x0eRefreshSecrets\x12&.containarium.v1.RefreshSecretsRequest\x1a'.containarium.v1.RefreshSecretsResponse\"\xac\x02\x92A\xff\x01\n" +
-	"\aSecrets\x12(Re-stamp tenant secrets into the LXC env\x1a\xc9\x01Reads all of the tenant's secrets from the DB, decrypts, and updates the LXC's environment.<NAME> config keys to match. Running processes keep their old env (POSIX); new execs see the refreshed values.\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/secrets/{username}/refreshB\xa4\x04\x92A\xd5\x03\x12\xc4\x02\n" +
+	"\aSecrets\x12(Re-stamp tenant secrets into the LXC env\x1a\xc9\x01Reads all of the tenant's secrets from the DB, decrypts, and updates the LXC's environment.<NAME> config keys to match. Running processes keep their old env (POSIX); new execs see the refreshed values.\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/secrets/{username}/refresh\x12\x99\x03\n" +
+	"\x12ListSecretVersions\x12*.containarium.v1.ListSecretVersionsRequest\x1a+.containarium.v1.ListSecretVersionsResponse\"\xa9\x02\x92A\xf7\x01\n" +
+	"\aSecrets\x12\x1fList a tenant secret's versions\x1a\xca\x01Returns the retained versions of a secret, newest first, with when each was written and its delivery mode. The daemon keeps the newest 10 by default, the current one included. Values are never returned.\x82\xd3\xe4\x93\x02(\x12&/v1/secrets/{username}/{name}/versions\x12\xb0\x03\n" +
+	"\x0eRollbackSecret\x12&.containarium.v1.RollbackSecretRequest\x1a'.containarium.v1.RollbackSecretResponse\"\xcc\x02\x92A\x97\x02\n" +
+	"\aSecrets\x12/Roll a tenant secret back to an earlier version\x1a\xda\x01Restores a retained version's value and delivery mode as the secret's next version; the value it replaces stays in history. Like SetSecret, it does not reach running containers until RefreshSecrets (or the next start).\x82\xd3\xe4\x93\x02+:\x01*\"&/v1/secrets/{username}/{name}/rollbackB\xa4\x04\x92A\xd5\x03\x12\xc4\x02\n" +
 	"\x10Containarium API\x12\xa0\x01Container management API for LXC-based development environments. Provides both gRPC and REST interfaces for managing containers, SSH keys, and system resources.\";\n" +
 	"\fContainarium\x12+https://github.com/footprintai/containarium*K\n" +
 	"\n" +
@@ -175,128 +197,150 @@ const file_containarium_v1_service_proto_rawDesc = "" +
 	"\x06bearer\x12\x00ZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var file_containarium_v1_service_proto_goTypes = []any{
-	(*CreateContainerRequest)(nil),            // 0: containarium.v1.CreateContainerRequest
-	(*ListContainersRequest)(nil),             // 1: containarium.v1.ListContainersRequest
-	(*GetContainerRequest)(nil),               // 2: containarium.v1.GetContainerRequest
-	(*DebugContainerRequest)(nil),             // 3: containarium.v1.DebugContainerRequest
-	(*DeleteContainerRequest)(nil),            // 4: containarium.v1.DeleteContainerRequest
-	(*StartContainerRequest)(nil),             // 5: containarium.v1.StartContainerRequest
-	(*StopContainerRequest)(nil),              // 6: containarium.v1.StopContainerRequest
-	(*ResizeContainerRequest)(nil),            // 7: containarium.v1.ResizeContainerRequest
-	(*MoveContainerRequest)(nil),              // 8: containarium.v1.MoveContainerRequest
-	(*CreateContainerSnapshotRequest)(nil),    // 9: containarium.v1.CreateContainerSnapshotRequest
-	(*ListContainerSnapshotsRequest)(nil),     // 10: containarium.v1.ListContainerSnapshotsRequest
-	(*DeleteContainerSnapshotRequest)(nil),    // 11: containarium.v1.DeleteContainerSnapshotRequest
-	(*RollbackContainerSnapshotRequest)(nil),  // 12: containarium.v1.RollbackContainerSnapshotRequest
-	(*DeleteTenantStorageRequest)(nil),        // 13: containarium.v1.DeleteTenantStorageRequest
-	(*RewrapContainerRequest)(nil),            // 14: containarium.v1.RewrapContainerRequest
-	(*PrepareEncryptedMigrationRequest)(nil),  // 15: containarium.v1.PrepareEncryptedMigrationRequest
-	(*AdoptMigratedContainerRequest)(nil),     // 16: containarium.v1.AdoptMigratedContainerRequest
-	(*ToggleMonitoringRequest)(nil),           // 17: containarium.v1.ToggleMonitoringRequest
-	(*ToggleAutoSleepRequest)(nil),            // 18: containarium.v1.ToggleAutoSleepRequest
-	(*SetContainerTTLRequest)(nil),            // 19: containarium.v1.SetContainerTTLRequest
-	(*SetContainerDeletePolicyRequest)(nil),   // 20: containarium.v1.SetContainerDeletePolicyRequest
-	(*SetContainerAttributionRequest)(nil),    // 21: containarium.v1.SetContainerAttributionRequest
-	(*AddSSHKeyRequest)(nil),                  // 22: containarium.v1.AddSSHKeyRequest
-	(*RemoveSSHKeyRequest)(nil),               // 23: containarium.v1.RemoveSSHKeyRequest
-	(*AddCollaboratorRequest)(nil),            // 24: containarium.v1.AddCollaboratorRequest
-	(*RemoveCollaboratorRequest)(nil),         // 25: containarium.v1.RemoveCollaboratorRequest
-	(*ListCollaboratorsRequest)(nil),          // 26: containarium.v1.ListCollaboratorsRequest
-	(*GetMetricsRequest)(nil),                 // 27: containarium.v1.GetMetricsRequest
-	(*CleanupDiskRequest)(nil),                // 28: containarium.v1.CleanupDiskRequest
-	(*InstallStackRequest)(nil),               // 29: containarium.v1.InstallStackRequest
-	(*ListStacksRequest)(nil),                 // 30: containarium.v1.ListStacksRequest
-	(*GetSystemInfoRequest)(nil),              // 31: containarium.v1.GetSystemInfoRequest
-	(*ListBackendsRequest)(nil),               // 32: containarium.v1.ListBackendsRequest
-	(*AdvertiseCapacityRequest)(nil),          // 33: containarium.v1.AdvertiseCapacityRequest
-	(*WithdrawCapacityRequest)(nil),           // 34: containarium.v1.WithdrawCapacityRequest
-	(*GetCapacityHeadroomRequest)(nil),        // 35: containarium.v1.GetCapacityHeadroomRequest
-	(*ProfileBackendRequest)(nil),             // 36: containarium.v1.ProfileBackendRequest
-	(*GetCapabilityProfileRequest)(nil),       // 37: containarium.v1.GetCapabilityProfileRequest
-	(*GetSelfMeasurementRequest)(nil),         // 38: containarium.v1.GetSelfMeasurementRequest
-	(*GetLatestReleaseRequest)(nil),           // 39: containarium.v1.GetLatestReleaseRequest
-	(*ValidateGPURequest)(nil),                // 40: containarium.v1.ValidateGPURequest
-	(*TriggerUpgradeRequest)(nil),             // 41: containarium.v1.TriggerUpgradeRequest
-	(*GetUpgradeStatusRequest)(nil),           // 42: containarium.v1.GetUpgradeStatusRequest
-	(*GetMonitoringInfoRequest)(nil),          // 43: containarium.v1.GetMonitoringInfoRequest
-	(*SetMetricsExportRequest)(nil),           // 44: containarium.v1.SetMetricsExportRequest
-	(*GetMetricsExportRequest)(nil),           // 45: containarium.v1.GetMetricsExportRequest
-	(*CreateAlertRuleRequest)(nil),            // 46: containarium.v1.CreateAlertRuleRequest
-	(*ListAlertRulesRequest)(nil),             // 47: containarium.v1.ListAlertRulesRequest
-	(*GetAlertRuleRequest)(nil),               // 48: containarium.v1.GetAlertRuleRequest
-	(*UpdateAlertRuleRequest)(nil),            // 49: containarium.v1.UpdateAlertRuleRequest
-	(*DeleteAlertRuleRequest)(nil),            // 50: containarium.v1.DeleteAlertRuleRequest
-	(*GetAlertingInfoRequest)(nil),            // 51: containarium.v1.GetAlertingInfoRequest
-	(*ListDefaultAlertRulesRequest)(nil),      // 52: containarium.v1.ListDefaultAlertRulesRequest
-	(*UpdateAlertingConfigRequest)(nil),       // 53: containarium.v1.UpdateAlertingConfigRequest
-	(*TestWebhookRequest)(nil),                // 54: containarium.v1.TestWebhookRequest
-	(*ListWebhookDeliveriesRequest)(nil),      // 55: containarium.v1.ListWebhookDeliveriesRequest
-	(*SetSecretRequest)(nil),                  // 56: containarium.v1.SetSecretRequest
-	(*GetSecretRequest)(nil),                  // 57: containarium.v1.GetSecretRequest
-	(*ListSecretsRequest)(nil),                // 58: containarium.v1.ListSecretsRequest
-	(*DeleteSecretRequest)(nil),               // 59: containarium.v1.DeleteSecretRequest
-	(*RefreshSecretsRequest)(nil),             // 60: containarium.v1.RefreshSecretsRequest
-	(*CreateContainerResponse)(nil),           // 61: containarium.v1.CreateContainerResponse
-	(*ListContainersResponse)(nil),            // 62: containarium.v1.ListContainersResponse
-	(*GetContainerResponse)(nil),              // 63: containarium.v1.GetContainerResponse
-	(*DebugContainerResponse)(nil),            // 64: containarium.v1.DebugContainerResponse
-	(*DeleteContainerResponse)(nil),           // 65: containarium.v1.DeleteContainerResponse
-	(*StartContainerResponse)(nil),            // 66: containarium.v1.StartContainerResponse
-	(*StopContainerResponse)(nil),             // 67: containarium.v1.StopContainerResponse
-	(*ResizeContainerResponse)(nil),           // 68: containarium.v1.ResizeContainerResponse
-	(*MoveContainerResponse)(nil),             // 69: containarium.v1.MoveContainerResponse
-	(*CreateContainerSnapshotResponse)(nil),   // 70: containarium.v1.CreateContainerSnapshotResponse
-	(*ListContainerSnapshotsResponse)(nil),    // 71: containarium.v1.ListContainerSnapshotsResponse
-	(*DeleteContainerSnapshotResponse)(nil),   // 72: containarium.v1.DeleteContainerSnapshotResponse
-	(*RollbackContainerSnapshotResponse)(nil), // 73: containarium.v1.RollbackContainerSnapshotResponse
-	(*DeleteTenantStorageResponse)(nil),       // 74: containarium.v1.DeleteTenantStorageResponse
-	(*RewrapContainerResponse)(nil),           // 75: containarium.v1.RewrapContainerResponse
-	(*PrepareEncryptedMigrationResponse)(nil), // 76: containarium.v1.PrepareEncryptedMigrationResponse
-	(*AdoptMigratedContainerResponse)(nil),    // 77: containarium.v1.AdoptMigratedContainerResponse
-	(*ToggleMonitoringResponse)(nil),          // 78: containarium.v1.ToggleMonitoringResponse
-	(*ToggleAutoSleepResponse)(nil),           // 79: containarium.v1.ToggleAutoSleepResponse
-	(*SetContainerTTLResponse)(nil),           // 80: containarium.v1.SetContainerTTLResponse
-	(*SetContainerDeletePolicyResponse)(nil),  // 81: containarium.v1.SetContainerDeletePolicyResponse
-	(*SetContainerAttributionResponse)(nil),   // 82: containarium.v1.SetContainerAttributionResponse
-	(*AddSSHKeyResponse)(nil),                 // 83: containarium.v1.AddSSHKeyResponse
-	(*RemoveSSHKeyResponse)(nil),              // 84: containarium.v1.RemoveSSHKeyResponse
-	(*AddCollaboratorResponse)(nil),           // 85: containarium.v1.AddCollaboratorResponse
-	(*RemoveCollaboratorResponse)(nil),        // 86: containarium.v1.RemoveCollaboratorResponse
-	(*ListCollaboratorsResponse)(nil),         // 87: containarium.v1.ListCollaboratorsResponse
-	(*GetMetricsResponse)(nil),                // 88: containarium.v1.GetMetricsResponse
-	(*CleanupDiskResponse)(nil),               // 89: containarium.v1.CleanupDiskResponse
-	(*InstallStackResponse)(nil),              // 90: containarium.v1.InstallStackResponse
-	(*ListStacksResponse)(nil),                // 91: containarium.v1.ListStacksResponse
-	(*GetSystemInfoResponse)(nil),             // 92: containarium.v1.GetSystemInfoResponse
-	(*ListBackendsResponse)(nil),              // 93: containarium.v1.ListBackendsResponse
-	(*AdvertiseCapacityResponse)(nil),         // 94: containarium.v1.AdvertiseCapacityResponse
-	(*WithdrawCapacityResponse)(nil),          // 95: containarium.v1.WithdrawCapacityResponse
-	(*GetCapacityHeadroomResponse)(nil),       // 96: containarium.v1.GetCapacityHeadroomResponse
-	(*ProfileBackendResponse)(nil),            // 97: containarium.v1.ProfileBackendResponse
-	(*GetCapabilityProfileResponse)(nil),      // 98: containarium.v1.GetCapabilityProfileResponse
-	(*GetSelfMeasurementResponse)(nil),        // 99: containarium.v1.GetSelfMeasurementResponse
-	(*GetLatestReleaseResponse)(nil),          // 100: containarium.v1.GetLatestReleaseResponse
-	(*ValidateGPUResponse)(nil),               // 101: containarium.v1.ValidateGPUResponse
-	(*TriggerUpgradeResponse)(nil),            // 102: containarium.v1.TriggerUpgradeResponse
-	(*GetUpgradeStatusResponse)(nil),          // 103: containarium.v1.GetUpgradeStatusResponse
-	(*GetMonitoringInfoResponse)(nil),         // 104: containarium.v1.GetMonitoringInfoResponse
-	(*SetMetricsExportResponse)(nil),          // 105: containarium.v1.SetMetricsExportResponse
-	(*GetMetricsExportResponse)(nil),          // 106: containarium.v1.GetMetricsExportResponse
-	(*CreateAlertRuleResponse)(nil),           // 107: containarium.v1.CreateAlertRuleResponse
-	(*ListAlertRulesResponse)(nil),            // 108: containarium.v1.ListAlertRulesResponse
-	(*GetAlertRuleResponse)(nil),              // 109: containarium.v1.GetAlertRuleResponse
-	(*UpdateAlertRuleResponse)(nil),           // 110: containarium.v1.UpdateAlertRuleResponse
-	(*DeleteAlertRuleResponse)(nil),           // 111: containarium.v1.DeleteAlertRuleResponse
-	(*GetAlertingInfoResponse)(nil),           // 112: containarium.v1.GetAlertingInfoResponse
-	(*ListDefaultAlertRulesResponse)(nil),     // 113: containarium.v1.ListDefaultAlertRulesResponse
-	(*UpdateAlertingConfigResponse)(nil),      // 114: containarium.v1.UpdateAlertingConfigResponse
-	(*TestWebhookResponse)(nil),               // 115: containarium.v1.TestWebhookResponse
-	(*ListWebhookDeliveriesResponse)(nil),     // 116: containarium.v1.ListWebhookDeliveriesResponse
-	(*SetSecretResponse)(nil),                 // 117: containarium.v1.SetSecretResponse
-	(*GetSecretResponse)(nil),                 // 118: containarium.v1.GetSecretResponse
-	(*ListSecretsResponse)(nil),               // 119: containarium.v1.ListSecretsResponse
-	(*DeleteSecretResponse)(nil),              // 120: containarium.v1.DeleteSecretResponse
-	(*RefreshSecretsResponse)(nil),            // 121: containarium.v1.RefreshSecretsResponse
+	(*CreateContainerRequest)(nil),                // 0: containarium.v1.CreateContainerRequest
+	(*ListContainersRequest)(nil),                 // 1: containarium.v1.ListContainersRequest
+	(*GetContainerRequest)(nil),                   // 2: containarium.v1.GetContainerRequest
+	(*DebugContainerRequest)(nil),                 // 3: containarium.v1.DebugContainerRequest
+	(*DeleteContainerRequest)(nil),                // 4: containarium.v1.DeleteContainerRequest
+	(*StartContainerRequest)(nil),                 // 5: containarium.v1.StartContainerRequest
+	(*StopContainerRequest)(nil),                  // 6: containarium.v1.StopContainerRequest
+	(*ResizeContainerRequest)(nil),                // 7: containarium.v1.ResizeContainerRequest
+	(*MoveContainerRequest)(nil),                  // 8: containarium.v1.MoveContainerRequest
+	(*CreateContainerSnapshotRequest)(nil),        // 9: containarium.v1.CreateContainerSnapshotRequest
+	(*ListContainerSnapshotsRequest)(nil),         // 10: containarium.v1.ListContainerSnapshotsRequest
+	(*DeleteContainerSnapshotRequest)(nil),        // 11: containarium.v1.DeleteContainerSnapshotRequest
+	(*RollbackContainerSnapshotRequest)(nil),      // 12: containarium.v1.RollbackContainerSnapshotRequest
+	(*CloneContainerRequest)(nil),                 // 13: containarium.v1.CloneContainerRequest
+	(*ExportContainerSnapshotRequest)(nil),        // 14: containarium.v1.ExportContainerSnapshotRequest
+	(*ImportContainerSnapshotRequest)(nil),        // 15: containarium.v1.ImportContainerSnapshotRequest
+	(*ListContainerSnapshotFilesRequest)(nil),     // 16: containarium.v1.ListContainerSnapshotFilesRequest
+	(*ReadContainerSnapshotFileRequest)(nil),      // 17: containarium.v1.ReadContainerSnapshotFileRequest
+	(*DiffContainerSnapshotsRequest)(nil),         // 18: containarium.v1.DiffContainerSnapshotsRequest
+	(*SetContainerSnapshotPolicyRequest)(nil),     // 19: containarium.v1.SetContainerSnapshotPolicyRequest
+	(*GetContainerSnapshotPolicyRequest)(nil),     // 20: containarium.v1.GetContainerSnapshotPolicyRequest
+	(*DeleteContainerSnapshotPolicyRequest)(nil),  // 21: containarium.v1.DeleteContainerSnapshotPolicyRequest
+	(*DeleteTenantStorageRequest)(nil),            // 22: containarium.v1.DeleteTenantStorageRequest
+	(*RewrapContainerRequest)(nil),                // 23: containarium.v1.RewrapContainerRequest
+	(*PrepareEncryptedMigrationRequest)(nil),      // 24: containarium.v1.PrepareEncryptedMigrationRequest
+	(*AdoptMigratedContainerRequest)(nil),         // 25: containarium.v1.AdoptMigratedContainerRequest
+	(*ToggleMonitoringRequest)(nil),               // 26: containarium.v1.ToggleMonitoringRequest
+	(*ToggleAutoSleepRequest)(nil),                // 27: containarium.v1.ToggleAutoSleepRequest
+	(*SetContainerTTLRequest)(nil),                // 28: containarium.v1.SetContainerTTLRequest
+	(*SetContainerDeletePolicyRequest)(nil),       // 29: containarium.v1.SetContainerDeletePolicyRequest
+	(*SetContainerAttributionRequest)(nil),        // 30: containarium.v1.SetContainerAttributionRequest
+	(*AddSSHKeyRequest)(nil),                      // 31: containarium.v1.AddSSHKeyRequest
+	(*RemoveSSHKeyRequest)(nil),                   // 32: containarium.v1.RemoveSSHKeyRequest
+	(*AddCollaboratorRequest)(nil),                // 33: containarium.v1.AddCollaboratorRequest
+	(*RemoveCollaboratorRequest)(nil),             // 34: containarium.v1.RemoveCollaboratorRequest
+	(*ListCollaboratorsRequest)(nil),              // 35: containarium.v1.ListCollaboratorsRequest
+	(*GetMetricsRequest)(nil),                     // 36: containarium.v1.GetMetricsRequest
+	(*CleanupDiskRequest)(nil),                    // 37: containarium.v1.CleanupDiskRequest
+	(*InstallStackRequest)(nil),                   // 38: containarium.v1.InstallStackRequest
+	(*ListStacksRequest)(nil),                     // 39: containarium.v1.ListStacksRequest
+	(*GetSystemInfoRequest)(nil),                  // 40: containarium.v1.GetSystemInfoRequest
+	(*ListBackendsRequest)(nil),                   // 41: containarium.v1.ListBackendsRequest
+	(*AdvertiseCapacityRequest)(nil),              // 42: containarium.v1.AdvertiseCapacityRequest
+	(*WithdrawCapacityRequest)(nil),               // 43: containarium.v1.WithdrawCapacityRequest
+	(*GetCapacityHeadroomRequest)(nil),            // 44: containarium.v1.GetCapacityHeadroomRequest
+	(*ProfileBackendRequest)(nil),                 // 45: containarium.v1.ProfileBackendRequest
+	(*GetCapabilityProfileRequest)(nil),           // 46: containarium.v1.GetCapabilityProfileRequest
+	(*GetSelfMeasurementRequest)(nil),             // 47: containarium.v1.GetSelfMeasurementRequest
+	(*GetLatestReleaseRequest)(nil),               // 48: containarium.v1.GetLatestReleaseRequest
+	(*ValidateGPURequest)(nil),                    // 49: containarium.v1.ValidateGPURequest
+	(*TriggerUpgradeRequest)(nil),                 // 50: containarium.v1.TriggerUpgradeRequest
+	(*GetUpgradeStatusRequest)(nil),               // 51: containarium.v1.GetUpgradeStatusRequest
+	(*GetMonitoringInfoRequest)(nil),              // 52: containarium.v1.GetMonitoringInfoRequest
+	(*SetMetricsExportRequest)(nil),               // 53: containarium.v1.SetMetricsExportRequest
+	(*GetMetricsExportRequest)(nil),               // 54: containarium.v1.GetMetricsExportRequest
+	(*CreateAlertRuleRequest)(nil),                // 55: containarium.v1.CreateAlertRuleRequest
+	(*ListAlertRulesRequest)(nil),                 // 56: containarium.v1.ListAlertRulesRequest
+	(*GetAlertRuleRequest)(nil),                   // 57: containarium.v1.GetAlertRuleRequest
+	(*UpdateAlertRuleRequest)(nil),                // 58: containarium.v1.UpdateAlertRuleRequest
+	(*DeleteAlertRuleRequest)(nil),                // 59: containarium.v1.DeleteAlertRuleRequest
+	(*GetAlertingInfoRequest)(nil),                // 60: containarium.v1.GetAlertingInfoRequest
+	(*ListDefaultAlertRulesRequest)(nil),          // 61: containarium.v1.ListDefaultAlertRulesRequest
+	(*UpdateAlertingConfigRequest)(nil),           // 62: containarium.v1.UpdateAlertingConfigRequest
+	(*TestWebhookRequest)(nil),                    // 63: containarium.v1.TestWebhookRequest
+	(*ListWebhookDeliveriesRequest)(nil),          // 64: containarium.v1.ListWebhookDeliveriesRequest
+	(*SetSecretRequest)(nil),                      // 65: containarium.v1.SetSecretRequest
+	(*GetSecretRequest)(nil),                      // 66: containarium.v1.GetSecretRequest
+	(*ListSecretsRequest)(nil),                    // 67: containarium.v1.ListSecretsRequest
+	(*DeleteSecretRequest)(nil),                   // 68: containarium.v1.DeleteSecretRequest
+	(*RefreshSecretsRequest)(nil),                 // 69: containarium.v1.RefreshSecretsRequest
+	(*ListSecretVersionsRequest)(nil),             // 70: containarium.v1.ListSecretVersionsRequest
+	(*RollbackSecretRequest)(nil),                 // 71: containarium.v1.RollbackSecretRequest
+	(*CreateContainerResponse)(nil),               // 72: containarium.v1.CreateContainerResponse
+	(*ListContainersResponse)(nil),                // 73: containarium.v1.ListContainersResponse
+	(*GetContainerResponse)(nil),                  // 74: containarium.v1.GetContainerResponse
+	(*DebugContainerResponse)(nil),                // 75: containarium.v1.DebugContainerResponse
+	(*DeleteContainerResponse)(nil),               // 76: containarium.v1.DeleteContainerResponse
+	(*StartContainerResponse)(nil),                // 77: containarium.v1.StartContainerResponse
+	(*StopContainerResponse)(nil),                 // 78: containarium.v1.StopContainerResponse
+	(*ResizeContainerResponse)(nil),               // 79: containarium.v1.ResizeContainerResponse
+	(*MoveContainerResponse)(nil),                 // 80: containarium.v1.MoveContainerResponse
+	(*CreateContainerSnapshotResponse)(nil),       // 81: containarium.v1.CreateContainerSnapshotResponse
+	(*ListContainerSnapshotsResponse)(nil),        // 82: containarium.v1.ListContainerSnapshotsResponse
+	(*DeleteContainerSnapshotResponse)(nil),       // 83: containarium.v1.DeleteContainerSnapshotResponse
+	(*RollbackContainerSnapshotResponse)(nil),     // 84: containarium.v1.RollbackContainerSnapshotResponse
+	(*CloneContainerResponse)(nil),                // 85: containarium.v1.CloneContainerResponse
+	(*ExportContainerSnapshotResponse)(nil),       // 86: containarium.v1.ExportContainerSnapshotResponse
+	(*ImportContainerSnapshotResponse)(nil),       // 87: containarium.v1.ImportContainerSnapshotResponse
+	(*ListContainerSnapshotFilesResponse)(nil),    // 88: containarium.v1.ListContainerSnapshotFilesResponse
+	(*ReadContainerSnapshotFileResponse)(nil),     // 89: containarium.v1.ReadContainerSnapshotFileResponse
+	(*DiffContainerSnapshotsResponse)(nil),        // 90: containarium.v1.DiffContainerSnapshotsResponse
+	(*SetContainerSnapshotPolicyResponse)(nil),    // 91: containarium.v1.SetContainerSnapshotPolicyResponse
+	(*GetContainerSnapshotPolicyResponse)(nil),    // 92: containarium.v1.GetContainerSnapshotPolicyResponse
+	(*DeleteContainerSnapshotPolicyResponse)(nil), // 93: containarium.v1.DeleteContainerSnapshotPolicyResponse
+	(*DeleteTenantStorageResponse)(nil),           // 94: containarium.v1.DeleteTenantStorageResponse
+	(*RewrapContainerResponse)(nil),               // 95: containarium.v1.RewrapContainerResponse
+	(*PrepareEncryptedMigrationResponse)(nil),     // 96: containarium.v1.PrepareEncryptedMigrationResponse
+	(*AdoptMigratedContainerResponse)(nil),        // 97: containarium.v1.AdoptMigratedContainerResponse
+	(*ToggleMonitoringResponse)(nil),              // 98: containarium.v1.ToggleMonitoringResponse
+	(*ToggleAutoSleepResponse)(nil),               // 99: containarium.v1.ToggleAutoSleepResponse
+	(*SetContainerTTLResponse)(nil),               // 100: containarium.v1.SetContainerTTLResponse
+	(*SetContainerDeletePolicyResponse)(nil),      // 101: containarium.v1.SetContainerDeletePolicyResponse
+	(*SetContainerAttributionResponse)(nil),       // 102: containarium.v1.SetContainerAttributionResponse
+	(*AddSSHKeyResponse)(nil),                     // 103: containarium.v1.AddSSHKeyResponse
+	(*RemoveSSHKeyResponse)(nil),                  // 104: containarium.v1.RemoveSSHKeyResponse
+	(*AddCollaboratorResponse)(nil),               // 105: containarium.v1.AddCollaboratorResponse
+	(*RemoveCollaboratorResponse)(nil),            // 106: containarium.v1.RemoveCollaboratorResponse
+	(*ListCollaboratorsResponse)(nil),             // 107: containarium.v1.ListCollaboratorsResponse
+	(*GetMetricsResponse)(nil),                    // 108: containarium.v1.GetMetricsResponse
+	(*CleanupDiskResponse)(nil),                   // 109: containarium.v1.CleanupDiskResponse
+	(*InstallStackResponse)(nil),                  // 110: containarium.v1.InstallStackResponse
+	(*ListStacksResponse)(nil),                    // 111: containarium.v1.ListStacksResponse
+	(*GetSystemInfoResponse)(nil),                 // 112: containarium.v1.GetSystemInfoResponse
+	(*ListBackendsResponse)(nil),                  // 113: containarium.v1.ListBackendsResponse
+	(*AdvertiseCapacityResponse)(nil),             // 114: containarium.v1.AdvertiseCapacityResponse
+	(*WithdrawCapacityResponse)(nil),              // 115: containarium.v1.WithdrawCapacityResponse
+	(*GetCapacityHeadroomResponse)(nil),           // 116: containarium.v1.GetCapacityHeadroomResponse
+	(*ProfileBackendResponse)(nil),                // 117: containarium.v1.ProfileBackendResponse
+	(*GetCapabilityProfileResponse)(nil),          // 118: containarium.v1.GetCapabilityProfileResponse
+	(*GetSelfMeasurementResponse)(nil),            // 119: containarium.v1.GetSelfMeasurementResponse
+	(*GetLatestReleaseResponse)(nil),              // 120: containarium.v1.GetLatestReleaseResponse
+	(*ValidateGPUResponse)(nil),                   // 121: containarium.v1.ValidateGPUResponse
+	(*TriggerUpgradeResponse)(nil),                // 122: containarium.v1.TriggerUpgradeResponse
+	(*GetUpgradeStatusResponse)(nil),              // 123: containarium.v1.GetUpgradeStatusResponse
+	(*GetMonitoringInfoResponse)(nil),             // 124: containarium.v1.GetMonitoringInfoResponse
+	(*SetMetricsExportResponse)(nil),              // 125: containarium.v1.SetMetricsExportResponse
+	(*GetMetricsExportResponse)(nil),              // 126: containarium.v1.GetMetricsExportResponse
+	(*CreateAlertRuleResponse)(nil),               // 127: containarium.v1.CreateAlertRuleResponse
+	(*ListAlertRulesResponse)(nil),                // 128: containarium.v1.ListAlertRulesResponse
+	(*GetAlertRuleResponse)(nil),                  // 129: containarium.v1.GetAlertRuleResponse
+	(*UpdateAlertRuleResponse)(nil),               // 130: containarium.v1.UpdateAlertRuleResponse
+	(*DeleteAlertRuleResponse)(nil),               // 131: containarium.v1.DeleteAlertRuleResponse
+	(*GetAlertingInfoResponse)(nil),               // 132: containarium.v1.GetAlertingInfoResponse
+	(*ListDefaultAlertRulesResponse)(nil),         // 133: containarium.v1.ListDefaultAlertRulesResponse
+	(*UpdateAlertingConfigResponse)(nil),          // 134: containarium.v1.UpdateAlertingConfigResponse
+	(*TestWebhookResponse)(nil),                   // 135: containarium.v1.TestWebhookResponse
+	(*ListWebhookDeliveriesResponse)(nil),         // 136: containarium.v1.ListWebhookDeliveriesResponse
+	(*SetSecretResponse)(nil),                     // 137: containarium.v1.SetSecretResponse
+	(*GetSecretResponse)(nil),                     // 138: containarium.v1.GetSecretResponse
+	(*ListSecretsResponse)(nil),                   // 139: containarium.v1.ListSecretsResponse
+	(*DeleteSecretResponse)(nil),                  // 140: containarium.v1.DeleteSecretResponse
+	(*RefreshSecretsResponse)(nil),                // 141: containarium.v1.RefreshSecretsResponse
+	(*ListSecretVersionsResponse)(nil),            // 142: containarium.v1.ListSecretVersionsResponse
+	(*RollbackSecretResponse)(nil),                // 143: containarium.v1.RollbackSecretResponse
 }
 var file_containarium_v1_service_proto_depIdxs = []int32{
 	0,   // 0: containarium.v1.ContainerService.CreateContainer:input_type -> containarium.v1.CreateContainerRequest
@@ -312,117 +356,139 @@ var file_containarium_v1_service_proto_depIdxs = []int32{
 	10,  // 10: containarium.v1.ContainerService.ListContainerSnapshots:input_type -> containarium.v1.ListContainerSnapshotsRequest
 	11,  // 11: containarium.v1.ContainerService.DeleteContainerSnapshot:input_type -> containarium.v1.DeleteContainerSnapshotRequest
 	12,  // 12: containarium.v1.ContainerService.RollbackContainerSnapshot:input_type -> containarium.v1.RollbackContainerSnapshotRequest
-	13,  // 13: containarium.v1.ContainerService.DeleteTenantStorage:input_type -> containarium.v1.DeleteTenantStorageRequest
-	14,  // 14: containarium.v1.ContainerService.RewrapContainer:input_type -> containarium.v1.RewrapContainerRequest
-	15,  // 15: containarium.v1.ContainerService.PrepareEncryptedMigration:input_type -> containarium.v1.PrepareEncryptedMigrationRequest
-	16,  // 16: containarium.v1.ContainerService.AdoptMigratedContainer:input_type -> containarium.v1.AdoptMigratedContainerRequest
-	17,  // 17: containarium.v1.ContainerService.ToggleMonitoring:input_type -> containarium.v1.ToggleMonitoringRequest
-	18,  // 18: containarium.v1.ContainerService.ToggleAutoSleep:input_type -> containarium.v1.ToggleAutoSleepRequest
-	19,  // 19: containarium.v1.ContainerService.SetContainerTTL:input_type -> containarium.v1.SetContainerTTLRequest
-	20,  // 20: containarium.v1.ContainerService.SetContainerDeletePolicy:input_type -> containarium.v1.SetContainerDeletePolicyRequest
-	21,  // 21: containarium.v1.ContainerService.SetContainerAttribution:input_type -> containarium.v1.SetContainerAttributionRequest
-	22,  // 22: containarium.v1.ContainerService.AddSSHKey:input_type -> containarium.v1.AddSSHKeyRequest
-	23,  // 23: containarium.v1.ContainerService.RemoveSSHKey:input_type -> containarium.v1.RemoveSSHKeyRequest
-	24,  // 24: containarium.v1.ContainerService.AddCollaborator:input_type -> containarium.v1.AddCollaboratorRequest
-	25,  // 25: containarium.v1.ContainerService.RemoveCollaborator:input_type -> containarium.v1.RemoveCollaboratorRequest
-	26,  // 26: containarium.v1.ContainerService.ListCollaborators:input_type -> containarium.v1.ListCollaboratorsRequest
-	27,  // 27: containarium.v1.ContainerService.GetMetrics:input_type -> containarium.v1.GetMetricsRequest
-	28,  // 28: containarium.v1.ContainerService.CleanupDisk:input_type -> containarium.v1.CleanupDiskRequest
-	29,  // 29: containarium.v1.ContainerService.InstallStack:input_type -> containarium.v1.InstallStackRequest
-	30,  // 30: containarium.v1.ContainerService.ListStacks:input_type -> containarium.v1.ListStacksRequest
-	31,  // 31: containarium.v1.ContainerService.GetSystemInfo:input_type -> containarium.v1.GetSystemInfoRequest
-	32,  // 32: containarium.v1.ContainerService.ListBackends:input_type -> containarium.v1.ListBackendsRequest
-	33,  // 33: containarium.v1.ContainerService.AdvertiseCapacity:input_type -> containarium.v1.AdvertiseCapacityRequest
-	34,  // 34: containarium.v1.ContainerService.WithdrawCapacity:input_type -> containarium.v1.WithdrawCapacityRequest
-	35,  // 35: containarium.v1.ContainerService.GetCapacityHeadroom:input_type -> containarium.v1.GetCapacityHeadroomRequest
-	36,  // 36: containarium.v1.ContainerService.ProfileBackend:input_type -> containarium.v1.ProfileBackendRequest
-	37,  // 37: containarium.v1.ContainerService.GetCapabilityProfile:input_type -> containarium.v1.GetCapabilityProfileRequest
-	38,  // 38: containarium.v1.ContainerService.GetSelfMeasurement:input_type -> containarium.v1.GetSelfMeasurementRequest
-	39,  // 39: containarium.v1.ContainerService.GetLatestRelease:input_type -> containarium.v1.GetLatestReleaseRequest
-	40,  // 40: containarium.v1.ContainerService.ValidateGPU:input_type -> containarium.v1.ValidateGPURequest
-	41,  // 41: containarium.v1.ContainerService.TriggerUpgrade:input_type -> containarium.v1.TriggerUpgradeRequest
-	42,  // 42: containarium.v1.ContainerService.GetUpgradeStatus:input_type -> containarium.v1.GetUpgradeStatusRequest
-	43,  // 43: containarium.v1.ContainerService.GetMonitoringInfo:input_type -> containarium.v1.GetMonitoringInfoRequest
-	44,  // 44: containarium.v1.ContainerService.SetMetricsExport:input_type -> containarium.v1.SetMetricsExportRequest
-	45,  // 45: containarium.v1.ContainerService.GetMetricsExport:input_type -> containarium.v1.GetMetricsExportRequest
-	46,  // 46: containarium.v1.ContainerService.CreateAlertRule:input_type -> containarium.v1.CreateAlertRuleRequest
-	47,  // 47: containarium.v1.ContainerService.ListAlertRules:input_type -> containarium.v1.ListAlertRulesRequest
-	48,  // 48: containarium.v1.ContainerService.GetAlertRule:input_type -> containarium.v1.GetAlertRuleRequest
-	49,  // 49: containarium.v1.ContainerService.UpdateAlertRule:input_type -> containarium.v1.UpdateAlertRuleRequest
-	50,  // 50: containarium.v1.ContainerService.DeleteAlertRule:input_type -> containarium.v1.DeleteAlertRuleRequest
-	51,  // 51: containarium.v1.ContainerService.GetAlertingInfo:input_type -> containarium.v1.GetAlertingInfoRequest
-	52,  // 52: containarium.v1.ContainerService.ListDefaultAlertRules:input_type -> containarium.v1.ListDefaultAlertRulesRequest
-	53,  // 53: containarium.v1.ContainerService.UpdateAlertingConfig:input_type -> containarium.v1.UpdateAlertingConfigRequest
-	54,  // 54: containarium.v1.ContainerService.TestWebhook:input_type -> containarium.v1.TestWebhookRequest
-	55,  // 55: containarium.v1.ContainerService.ListWebhookDeliveries:input_type -> containarium.v1.ListWebhookDeliveriesRequest
-	56,  // 56: containarium.v1.ContainerService.SetSecret:input_type -> containarium.v1.SetSecretRequest
-	57,  // 57: containarium.v1.ContainerService.GetSecret:input_type -> containarium.v1.GetSecretRequest
-	58,  // 58: containarium.v1.ContainerService.ListSecrets:input_type -> containarium.v1.ListSecretsRequest
-	59,  // 59: containarium.v1.ContainerService.DeleteSecret:input_type -> containarium.v1.DeleteSecretRequest
-	60,  // 60: containarium.v1.ContainerService.RefreshSecrets:input_type -> containarium.v1.RefreshSecretsRequest
-	61,  // 61: containarium.v1.ContainerService.CreateContainer:output_type -> containarium.v1.CreateContainerResponse
-	62,  // 62: containarium.v1.ContainerService.ListContainers:output_type -> containarium.v1.ListContainersResponse
-	63,  // 63: containarium.v1.ContainerService.GetContainer:output_type -> containarium.v1.GetContainerResponse
-	64,  // 64: containarium.v1.ContainerService.DebugContainer:output_type -> containarium.v1.DebugContainerResponse
-	65,  // 65: containarium.v1.ContainerService.DeleteContainer:output_type -> containarium.v1.DeleteContainerResponse
-	66,  // 66: containarium.v1.ContainerService.StartContainer:output_type -> containarium.v1.StartContainerResponse
-	67,  // 67: containarium.v1.ContainerService.StopContainer:output_type -> containarium.v1.StopContainerResponse
-	68,  // 68: containarium.v1.ContainerService.ResizeContainer:output_type -> containarium.v1.ResizeContainerResponse
-	69,  // 69: containarium.v1.ContainerService.MoveContainer:output_type -> containarium.v1.MoveContainerResponse
-	70,  // 70: containarium.v1.ContainerService.CreateContainerSnapshot:output_type -> containarium.v1.CreateContainerSnapshotResponse
-	71,  // 71: containarium.v1.ContainerService.ListContainerSnapshots:output_type -> containarium.v1.ListContainerSnapshotsResponse
-	72,  // 72: containarium.v1.ContainerService.DeleteContainerSnapshot:output_type -> containarium.v1.DeleteContainerSnapshotResponse
-	73,  // 73: containarium.v1.ContainerService.RollbackContainerSnapshot:output_type -> containarium.v1.RollbackContainerSnapshotResponse
-	74,  // 74: containarium.v1.ContainerService.DeleteTenantStorage:output_type -> containarium.v1.DeleteTenantStorageResponse
-	75,  // 75: containarium.v1.ContainerService.RewrapContainer:output_type -> containarium.v1.RewrapContainerResponse
-	76,  // 76: containarium.v1.ContainerService.PrepareEncryptedMigration:output_type -> containarium.v1.PrepareEncryptedMigrationResponse
-	77,  // 77: containarium.v1.ContainerService.AdoptMigratedContainer:output_type -> containarium.v1.AdoptMigratedContainerResponse
-	78,  // 78: containarium.v1.ContainerService.ToggleMonitoring:output_type -> containarium.v1.ToggleMonitoringResponse
-	79,  // 79: containarium.v1.ContainerService.ToggleAutoSleep:output_type -> containarium.v1.ToggleAutoSleepResponse
-	80,  // 80: containarium.v1.ContainerService.SetContainerTTL:output_type -> containarium.v1.SetContainerTTLResponse
-	81,  // 81: containarium.v1.ContainerService.SetContainerDeletePolicy:output_type -> containarium.v1.SetContainerDeletePolicyResponse
-	82,  // 82: containarium.v1.ContainerService.SetContainerAttribution:output_type -> containarium.v1.SetContainerAttributionResponse
-	83,  // 83: containarium.v1.ContainerService.AddSSHKey:output_type -> containarium.v1.AddSSHKeyResponse
-	84,  // 84: containarium.v1.ContainerService.RemoveSSHKey:output_type -> containarium.v1.RemoveSSHKeyResponse
-	85,  // 85: containarium.v1.ContainerService.AddCollaborator:output_type -> containarium.v1.AddCollaboratorResponse
-	86,  // 86: containarium.v1.ContainerService.RemoveCollaborator:output_type -> containarium.v1.RemoveCollaboratorResponse
-	87,  // 87: containarium.v1.ContainerService.ListCollaborators:output_type -> containarium.v1.ListCollaboratorsResponse
-	88,  // 88: containarium.v1.ContainerService.GetMetrics:output_type -> containarium.v1.GetMetricsResponse
-	89,  // 89: containarium.v1.ContainerService.CleanupDisk:output_type -> containarium.v1.CleanupDiskResponse
-	90,  // 90: containarium.v1.ContainerService.InstallStack:output_type -> containarium.v1.InstallStackResponse
-	91,  // 91: containarium.v1.ContainerService.ListStacks:output_type -> containarium.v1.ListStacksResponse
-	92,  // 92: containarium.v1.ContainerService.GetSystemInfo:output_type -> containarium.v1.GetSystemInfoResponse
-	93,  // 93: containarium.v1.ContainerService.ListBackends:output_type -> containarium.v1.ListBackendsResponse
-	94,  // 94: containarium.v1.ContainerService.AdvertiseCapacity:output_type -> containarium.v1.AdvertiseCapacityResponse
-	95,  // 95: containarium.v1.ContainerService.WithdrawCapacity:output_type -> containarium.v1.WithdrawCapacityResponse
-	96,  // 96: containarium.v1.ContainerService.GetCapacityHeadroom:output_type -> containarium.v1.GetCapacityHeadroomResponse
-	97,  // 97: containarium.v1.ContainerService.ProfileBackend:output_type -> containarium.v1.ProfileBackendResponse
-	98,  // 98: containarium.v1.ContainerService.GetCapabilityProfile:output_type -> containarium.v1.GetCapabilityProfileResponse
-	99,  // 99: containarium.v1.ContainerService.GetSelfMeasurement:output_type -> containarium.v1.GetSelfMeasurementResponse
-	100, // 100: containarium.v1.ContainerService.GetLatestRelease:output_type -> containarium.v1.GetLatestReleaseResponse
-	101, // 101: containarium.v1.ContainerService.ValidateGPU:output_type -> containarium.v1.ValidateGPUResponse
-	102, // 102: containarium.v1.ContainerService.TriggerUpgrade:output_type -> containarium.v1.TriggerUpgradeResponse
-	103, // 103: containarium.v1.ContainerService.GetUpgradeStatus:output_type -> containarium.v1.GetUpgradeStatusResponse
-	104, // 104: containarium.v1.ContainerService.GetMonitoringInfo:output_type -> containarium.v1.GetMonitoringInfoResponse
-	105, // 105: containarium.v1.ContainerService.SetMetricsExport:output_type -> containarium.v1.SetMetricsExportResponse
-	106, // 106: containarium.v1.ContainerService.GetMetricsExport:output_type -> containarium.v1.GetMetricsExportResponse
-	107, // 107: containarium.v1.ContainerService.CreateAlertRule:output_type -> containarium.v1.CreateAlertRuleResponse
-	108, // 108: containarium.v1.ContainerService.ListAlertRules:output_type -> containarium.v1.ListAlertRulesResponse
-	109, // 109: containarium.v1.ContainerService.GetAlertRule:output_type -> containarium.v1.GetAlertRuleResponse
-	110, // 110: containarium.v1.ContainerService.UpdateAlertRule:output_type -> containarium.v1.UpdateAlertRuleResponse
-	111, // 111: containarium.v1.ContainerService.DeleteAlertRule:output_type -> containarium.v1.DeleteAlertRuleResponse
-	112, // 112: containarium.v1.ContainerService.GetAlertingInfo:output_type -> containarium.v1.GetAlertingInfoResponse
-	113, // 113: containarium.v1.ContainerService.ListDefaultAlertRules:output_type -> containarium.v1.ListDefaultAlertRulesResponse
-	114, // 114: containarium.v1.ContainerService.UpdateAlertingConfig:output_type -> containarium.v1.UpdateAlertingConfigResponse
-	115, // 115: containarium.v1.ContainerService.TestWebhook:output_type -> containarium.v1.TestWebhookResponse
-	116, // 116: containarium.v1.ContainerService.ListWebhookDeliveries:output_type -> containarium.v1.ListWebhookDeliveriesResponse
-	117, // 117: containarium.v1.ContainerService.SetSecret:output_type -> containarium.v1.SetSecretResponse
-	118, // 118: containarium.v1.ContainerService.GetSecret:output_type -> containarium.v1.GetSecretResponse
-	119, // 119: containarium.v1.ContainerService.ListSecrets:output_type -> containarium.v1.ListSecretsResponse
-	120, // 120: containarium.v1.ContainerService.DeleteSecret:output_type -> containarium.v1.DeleteSecretResponse
-	121, // 121: containarium.v1.ContainerService.RefreshSecrets:output_type -> containarium.v1.RefreshSecretsResponse
-	61,  // [61:122] is the sub-list for method output_type
-	0,   // [0:61] is the sub-list for method input_type
+	13,  // 13: containarium.v1.ContainerService.CloneContainer:input_type -> containarium.v1.CloneContainerRequest
+	14,  // 14: containarium.v1.ContainerService.ExportContainerSnapshot:input_type -> containarium.v1.ExportContainerSnapshotRequest
+	15,  // 15: containarium.v1.ContainerService.ImportContainerSnapshot:input_type -> containarium.v1.ImportContainerSnapshotRequest
+	16,  // 16: containarium.v1.ContainerService.ListContainerSnapshotFiles:input_type -> containarium.v1.ListContainerSnapshotFilesRequest
+	17,  // 17: containarium.v1.ContainerService.ReadContainerSnapshotFile:input_type -> containarium.v1.ReadContainerSnapshotFileRequest
+	18,  // 18: containarium.v1.ContainerService.DiffContainerSnapshots:input_type -> containarium.v1.DiffContainerSnapshotsRequest
+	19,  // 19: containarium.v1.ContainerService.SetContainerSnapshotPolicy:input_type -> containarium.v1.SetContainerSnapshotPolicyRequest
+	20,  // 20: containarium.v1.ContainerService.GetContainerSnapshotPolicy:input_type -> containarium.v1.GetContainerSnapshotPolicyRequest
+	21,  // 21: containarium.v1.ContainerService.DeleteContainerSnapshotPolicy:input_type -> containarium.v1.DeleteContainerSnapshotPolicyRequest
+	22,  // 22: containarium.v1.ContainerService.DeleteTenantStorage:input_type -> containarium.v1.DeleteTenantStorageRequest
+	23,  // 23: containarium.v1.ContainerService.RewrapContainer:input_type -> containarium.v1.RewrapContainerRequest
+	24,  // 24: containarium.v1.ContainerService.PrepareEncryptedMigration:input_type -> containarium.v1.PrepareEncryptedMigrationRequest
+	25,  // 25: containarium.v1.ContainerService.AdoptMigratedContainer:input_type -> containarium.v1.AdoptMigratedContainerRequest
+	26,  // 26: containarium.v1.ContainerService.ToggleMonitoring:input_type -> containarium.v1.ToggleMonitoringRequest
+	27,  // 27: containarium.v1.ContainerService.ToggleAutoSleep:input_type -> containarium.v1.ToggleAutoSleepRequest
+	28,  // 28: containarium.v1.ContainerService.SetContainerTTL:input_type -> containarium.v1.SetContainerTTLRequest
+	29,  // 29: containarium.v1.ContainerService.SetContainerDeletePolicy:input_type -> containarium.v1.SetContainerDeletePolicyRequest
+	30,  // 30: containarium.v1.ContainerService.SetContainerAttribution:input_type -> containarium.v1.SetContainerAttributionRequest
+	31,  // 31: containarium.v1.ContainerService.AddSSHKey:input_type -> containarium.v1.AddSSHKeyRequest
+	32,  // 32: containarium.v1.ContainerService.RemoveSSHKey:input_type -> containarium.v1.RemoveSSHKeyRequest
+	33,  // 33: containarium.v1.ContainerService.AddCollaborator:input_type -> containarium.v1.AddCollaboratorRequest
+	34,  // 34: containarium.v1.ContainerService.RemoveCollaborator:input_type -> containarium.v1.RemoveCollaboratorRequest
+	35,  // 35: containarium.v1.ContainerService.ListCollaborators:input_type -> containarium.v1.ListCollaboratorsRequest
+	36,  // 36: containarium.v1.ContainerService.GetMetrics:input_type -> containarium.v1.GetMetricsRequest
+	37,  // 37: containarium.v1.ContainerService.CleanupDisk:input_type -> containarium.v1.CleanupDiskRequest
+	38,  // 38: containarium.v1.ContainerService.InstallStack:input_type -> containarium.v1.InstallStackRequest
+	39,  // 39: containarium.v1.ContainerService.ListStacks:input_type -> containarium.v1.ListStacksRequest
+	40,  // 40: containarium.v1.ContainerService.GetSystemInfo:input_type -> containarium.v1.GetSystemInfoRequest
+	41,  // 41: containarium.v1.ContainerService.ListBackends:input_type -> containarium.v1.ListBackendsRequest
+	42,  // 42: containarium.v1.ContainerService.AdvertiseCapacity:input_type -> containarium.v1.AdvertiseCapacityRequest
+	43,  // 43: containarium.v1.ContainerService.WithdrawCapacity:input_type -> containarium.v1.WithdrawCapacityRequest
+	44,  // 44: containarium.v1.ContainerService.GetCapacityHeadroom:input_type -> containarium.v1.GetCapacityHeadroomRequest
+	45,  // 45: containarium.v1.ContainerService.ProfileBackend:input_type -> containarium.v1.ProfileBackendRequest
+	46,  // 46: containarium.v1.ContainerService.GetCapabilityProfile:input_type -> containarium.v1.GetCapabilityProfileRequest
+	47,  // 47: containarium.v1.ContainerService.GetSelfMeasurement:input_type -> containarium.v1.GetSelfMeasurementRequest
+	48,  // 48: containarium.v1.ContainerService.GetLatestRelease:input_type -> containarium.v1.GetLatestReleaseRequest
+	49,  // 49: containarium.v1.ContainerService.ValidateGPU:input_type -> containarium.v1.ValidateGPURequest
+	50,  // 50: containarium.v1.ContainerService.TriggerUpgrade:input_type -> containarium.v1.TriggerUpgradeRequest
+	51,  // 51: containarium.v1.ContainerService.GetUpgradeStatus:input_type -> containarium.v1.GetUpgradeStatusRequest
+	52,  // 52: containarium.v1.ContainerService.GetMonitoringInfo:input_type -> containarium.v1.GetMonitoringInfoRequest
+	53,  // 53: containarium.v1.ContainerService.SetMetricsExport:input_type -> containarium.v1.SetMetricsExportRequest
+	54,  // 54: containarium.v1.ContainerService.GetMetricsExport:input_type -> containarium.v1.GetMetricsExportRequest
+	55,  // 55: containarium.v1.ContainerService.CreateAlertRule:input_type -> containarium.v1.CreateAlertRuleRequest
+	56,  // 56: containarium.v1.ContainerService.ListAlertRules:input_type -> containarium.v1.ListAlertRulesRequest
+	57,  // 57: containarium.v1.ContainerService.GetAlertRule:input_type -> containarium.v1.GetAlertRuleRequest
+	58,  // 58: containarium.v1.ContainerService.UpdateAlertRule:input_type -> containarium.v1.UpdateAlertRuleRequest
+	59,  // 59: containarium.v1.ContainerService.DeleteAlertRule:input_type -> containarium.v1.DeleteAlertRuleRequest
+	60,  // 60: containarium.v1.ContainerService.GetAlertingInfo:input_type -> containarium.v1.GetAlertingInfoRequest
+	61,  // 61: containarium.v1.ContainerService.ListDefaultAlertRules:input_type -> containarium.v1.ListDefaultAlertRulesRequest
+	62,  // 62: containarium.v1.ContainerService.UpdateAlertingConfig:input_type -> containarium.v1.UpdateAlertingConfigRequest
+	63,  // 63: containarium.v1.ContainerService.TestWebhook:input_type -> containarium.v1.TestWebhookRequest
+	64,  // 64: containarium.v1.ContainerService.ListWebhookDeliveries:input_type -> containarium.v1.ListWebhookDeliveriesRequest
+	65,  // 65: containarium.v1.ContainerService.SetSecret:input_type -> containarium.v1.SetSecretRequest
+	66,  // 66: containarium.v1.ContainerService.GetSecret:input_type -> containarium.v1.GetSecretRequest
+	67,  // 67: containarium.v1.ContainerService.ListSecrets:input_type -> containarium.v1.ListSecretsRequest
+	68,  // 68: containarium.v1.ContainerService.DeleteSecret:input_type -> containarium.v1.DeleteSecretRequest
+	69,  // 69: containarium.v1.ContainerService.RefreshSecrets:input_type -> containarium.v1.RefreshSecretsRequest
+	70,  // 70: containarium.v1.ContainerService.ListSecretVersions:input_type -> containarium.v1.ListSecretVersionsRequest
+	71,  // 71: containarium.v1.ContainerService.RollbackSecret:input_type -> containarium.v1.RollbackSecretRequest
+	72,  // 72: containarium.v1.ContainerService.CreateContainer:output_type -> containarium.v1.CreateContainerResponse
+	73,  // 73: containarium.v1.ContainerService.ListContainers:output_type -> containarium.v1.ListContainersResponse
+	74,  // 74: containarium.v1.ContainerService.GetContainer:output_type -> containarium.v1.GetContainerResponse
+	75,  // 75: containarium.v1.ContainerService.DebugContainer:output_type -> containarium.v1.DebugContainerResponse
+	76,  // 76: containarium.v1.ContainerService.DeleteContainer:output_type -> containarium.v1.DeleteContainerResponse
+	77,  // 77: containarium.v1.ContainerService.StartContainer:output_type -> containarium.v1.StartContainerResponse
+	78,  // 78: containarium.v1.ContainerService.StopContainer:output_type -> containarium.v1.StopContainerResponse
+	79,  // 79: containarium.v1.ContainerService.ResizeContainer:output_type -> containarium.v1.ResizeContainerResponse
+	80,  // 80: containarium.v1.ContainerService.MoveContainer:output_type -> containarium.v1.MoveContainerResponse
+	81,  // 81: containarium.v1.ContainerService.CreateContainerSnapshot:output_type -> containarium.v1.CreateContainerSnapshotResponse
+	82,  // 82: containarium.v1.ContainerService.ListContainerSnapshots:output_type -> containarium.v1.ListContainerSnapshotsResponse
+	83,  // 83: containarium.v1.ContainerService.DeleteContainerSnapshot:output_type -> containarium.v1.DeleteContainerSnapshotResponse
+	84,  // 84: containarium.v1.ContainerService.RollbackContainerSnapshot:output_type -> containarium.v1.RollbackContainerSnapshotResponse
+	85,  // 85: containarium.v1.ContainerService.CloneContainer:output_type -> containarium.v1.CloneContainerResponse
+	86,  // 86: containarium.v1.ContainerService.ExportContainerSnapshot:output_type -> containarium.v1.ExportContainerSnapshotResponse
+	87,  // 87: containarium.v1.ContainerService.ImportContainerSnapshot:output_type -> containarium.v1.ImportContainerSnapshotResponse
+	88,  // 88: containarium.v1.ContainerService.ListContainerSnapshotFiles:output_type -> containarium.v1.ListContainerSnapshotFilesResponse
+	89,  // 89: containarium.v1.ContainerService.ReadContainerSnapshotFile:output_type -> containarium.v1.ReadContainerSnapshotFileResponse
+	90,  // 90: containarium.v1.ContainerService.DiffContainerSnapshots:output_type -> containarium.v1.DiffContainerSnapshotsResponse
+	91,  // 91: containarium.v1.ContainerService.SetContainerSnapshotPolicy:output_type -> containarium.v1.SetContainerSnapshotPolicyResponse
+	92,  // 92: containarium.v1.ContainerService.GetContainerSnapshotPolicy:output_type -> containarium.v1.GetContainerSnapshotPolicyResponse
+	93,  // 93: containarium.v1.ContainerService.DeleteContainerSnapshotPolicy:output_type -> containarium.v1.DeleteContainerSnapshotPolicyResponse
+	94,  // 94: containarium.v1.ContainerService.DeleteTenantStorage:output_type -> containarium.v1.DeleteTenantStorageResponse
+	95,  // 95: containarium.v1.ContainerService.RewrapContainer:output_type -> containarium.v1.RewrapContainerResponse
+	96,  // 96: containarium.v1.ContainerService.PrepareEncryptedMigration:output_type -> containarium.v1.PrepareEncryptedMigrationResponse
+	97,  // 97: containarium.v1.ContainerService.AdoptMigratedContainer:output_type -> containarium.v1.AdoptMigratedContainerResponse
+	98,  // 98: containarium.v1.ContainerService.ToggleMonitoring:output_type -> containarium.v1.ToggleMonitoringResponse
+	99,  // 99: containarium.v1.ContainerService.ToggleAutoSleep:output_type -> containarium.v1.ToggleAutoSleepResponse
+	100, // 100: containarium.v1.ContainerService.SetContainerTTL:output_type -> containarium.v1.SetContainerTTLResponse
+	101, // 101: containarium.v1.ContainerService.SetContainerDeletePolicy:output_type -> containarium.v1.SetContainerDeletePolicyResponse
+	102, // 102: containarium.v1.ContainerService.SetContainerAttribution:output_type -> containarium.v1.SetContainerAttributionResponse
+	103, // 103: containarium.v1.ContainerService.AddSSHKey:output_type -> containarium.v1.AddSSHKeyResponse
+	104, // 104: containarium.v1.ContainerService.RemoveSSHKey:output_type -> containarium.v1.RemoveSSHKeyResponse
+	105, // 105: containarium.v1.ContainerService.AddCollaborator:output_type -> containarium.v1.AddCollaboratorResponse
+	106, // 106: containarium.v1.ContainerService.RemoveCollaborator:output_type -> containarium.v1.RemoveCollaboratorResponse
+	107, // 107: containarium.v1.ContainerService.ListCollaborators:output_type -> containarium.v1.ListCollaboratorsResponse
+	108, // 108: containarium.v1.ContainerService.GetMetrics:output_type -> containarium.v1.GetMetricsResponse
+	109, // 109: containarium.v1.ContainerService.CleanupDisk:output_type -> containarium.v1.CleanupDiskResponse
+	110, // 110: containarium.v1.ContainerService.InstallStack:output_type -> containarium.v1.InstallStackResponse
+	111, // 111: containarium.v1.ContainerService.ListStacks:output_type -> containarium.v1.ListStacksResponse
+	112, // 112: containarium.v1.ContainerService.GetSystemInfo:output_type -> containarium.v1.GetSystemInfoResponse
+	113, // 113: containarium.v1.ContainerService.ListBackends:output_type -> containarium.v1.ListBackendsResponse
+	114, // 114: containarium.v1.ContainerService.AdvertiseCapacity:output_type -> containarium.v1.AdvertiseCapacityResponse
+	115, // 115: containarium.v1.ContainerService.WithdrawCapacity:output_type -> containarium.v1.WithdrawCapacityResponse
+	116, // 116: containarium.v1.ContainerService.GetCapacityHeadroom:output_type -> containarium.v1.GetCapacityHeadroomResponse
+	117, // 117: containarium.v1.ContainerService.ProfileBackend:output_type -> containarium.v1.ProfileBackendResponse
+	118, // 118: containarium.v1.ContainerService.GetCapabilityProfile:output_type -> containarium.v1.GetCapabilityProfileResponse
+	119, // 119: containarium.v1.ContainerService.GetSelfMeasurement:output_type -> containarium.v1.GetSelfMeasurementResponse
+	120, // 120: containarium.v1.ContainerService.GetLatestRelease:output_type -> containarium.v1.GetLatestReleaseResponse
+	121, // 121: containarium.v1.ContainerService.ValidateGPU:output_type -> containarium.v1.ValidateGPUResponse
+	122, // 122: containarium.v1.ContainerService.TriggerUpgrade:output_type -> containarium.v1.TriggerUpgradeResponse
+	123, // 123: containarium.v1.ContainerService.GetUpgradeStatus:output_type -> containarium.v1.GetUpgradeStatusResponse
+	124, // 124: containarium.v1.ContainerService.GetMonitoringInfo:output_type -> containarium.v1.GetMonitoringInfoResponse
+	125, // 125: containarium.v1.ContainerService.SetMetricsExport:output_type -> containarium.v1.SetMetricsExportResponse
+	126, // 126: containarium.v1.ContainerService.GetMetricsExport:output_type -> containarium.v1.GetMetricsExportResponse
+	127, // 127: containarium.v1.ContainerService.CreateAlertRule:output_type -> containarium.v1.CreateAlertRuleResponse
+	128, // 128: containarium.v1.ContainerService.ListAlertRules:output_type -> containarium.v1.ListAlertRulesResponse
+	129, // 129: containarium.v1.ContainerService.GetAlertRule:output_type -> containarium.v1.GetAlertRuleResponse
+	130, // 130: containarium.v1.ContainerService.UpdateAlertRule:output_type -> containarium.v1.UpdateAlertRuleResponse
+	131, // 131: containarium.v1.ContainerService.DeleteAlertRule:output_type -> containarium.v1.DeleteAlertRuleResponse
+	132, // 132: containarium.v1.ContainerService.GetAlertingInfo:output_type -> containarium.v1.GetAlertingInfoResponse
+	133, // 133: containarium.v1.ContainerService.ListDefaultAlertRules:output_type -> containarium.v1.ListDefaultAlertRulesResponse
+	134, // 134: containarium.v1.ContainerService.UpdateAlertingConfig:output_type -> containarium.v1.UpdateAlertingConfigResponse
+	135, // 135: containarium.v1.ContainerService.TestWebhook:output_type -> containarium.v1.TestWebhookResponse
+	136, // 136: containarium.v1.ContainerService.ListWebhookDeliveries:output_type -> containarium.v1.ListWebhookDeliveriesResponse
+	137, // 137: containarium.v1.ContainerService.SetSecret:output_type -> containarium.v1.SetSecretResponse
+	138, // 138: containarium.v1.ContainerService.GetSecret:output_type -> containarium.v1.GetSecretResponse
+	139, // 139: containarium.v1.ContainerService.ListSecrets:output_type -> containarium.v1.ListSecretsResponse
+	140, // 140: containarium.v1.ContainerService.DeleteSecret:output_type -> containarium.v1.DeleteSecretResponse
+	141, // 141: containarium.v1.ContainerService.RefreshSecrets:output_type -> containarium.v1.RefreshSecretsResponse
+	142, // 142: containarium.v1.ContainerService.ListSecretVersions:output_type -> containarium.v1.ListSecretVersionsResponse
+	143, // 143: containarium.v1.ContainerService.RollbackSecret:output_type -> containarium.v1.RollbackSecretResponse
+	72,  // [72:144] is the sub-list for method output_type
+	0,   // [0:72] is the sub-list for method input_type
 	0,   // [0:0] is the sub-list for extension type_name
 	0,   // [0:0] is the sub-list for extension extendee
 	0,   // [0:0] is the sub-list for field type_name
@@ -19,67 +19,78 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ContainerService_CreateContainer_FullMethodName           = "/containarium.v1.ContainerService/CreateContainer"
-	ContainerService_ListContainers_FullMethodName            = "/containarium.v1.ContainerService/ListContainers"
-	ContainerService_GetContainer_FullMethodName              = "/containarium.v1.ContainerService/GetContainer"
-	ContainerService_DebugContainer_FullMethodName            = "/containarium.v1.ContainerService/DebugContainer"
-	ContainerService_DeleteContainer_FullMethodName           = "/containarium.v1.ContainerService/DeleteContainer"
-	ContainerService_StartContainer_FullMethodName            = "/containarium.v1.ContainerService/StartContainer"
-	ContainerService_StopContainer_FullMethodName             = "/containarium.v1.ContainerService/StopContainer"
-	ContainerService_ResizeContainer_FullMethodName           = "/containarium.v1.ContainerService/ResizeContainer"
-	ContainerService_MoveContainer_FullMethodName             = "/containarium.v1.ContainerService/MoveContainer"
-	ContainerService_CreateContainerSnapshot_FullMethodName   = "/containarium.v1.ContainerService/CreateContainerSnapshot"
-	ContainerService_ListContainerSnapshots_FullMethodName    = "/containarium.v1.ContainerService/ListContainerSnapshots"
-	ContainerService_DeleteContainerSnapshot_FullMethodName   = "/containarium.v1.ContainerService/DeleteContainerSnapshot"
-	ContainerService_RollbackContainerSnapshot_FullMethodName = "/containarium.v1.ContainerService/RollbackContainerSnapshot"
-	ContainerService_DeleteTenantStorage_FullMethodName       = "/containarium.v1.ContainerService/DeleteTenantStorage"
-	ContainerService_RewrapContainer_FullMethodName           = "/containarium.v1.ContainerService/RewrapContainer"
-	ContainerService_PrepareEncryptedMigration_FullMethodName = "/containarium.v1.ContainerService/PrepareEncryptedMigration"
-	ContainerService_AdoptMigratedContainer_FullMethodName    = "/containarium.v1.ContainerService/AdoptMigratedContainer"
-	ContainerService_ToggleMonitoring_FullMethodName          = "/containarium.v1.ContainerService/ToggleMonitoring"
-	ContainerService_ToggleAutoSleep_FullMethodName           = "/containarium.v1.ContainerService/ToggleAutoSleep"
-	ContainerService_SetContainerTTL_FullMethodName           = "/containarium.v1.ContainerService/SetContainerTTL"
-	ContainerService_SetContainerDeletePolicy_FullMethodName  = "/containarium.v1.ContainerService/SetContainerDeletePolicy"
-	ContainerService_SetContainerAttribution_FullMethodName   = "/containarium.v1.ContainerService/SetContainerAttribution"
-	ContainerService_AddSSHKey_FullMethodName                 = "/containarium.v1.ContainerService/AddSSHKey"
-	ContainerService_RemoveSSHKey_FullMethodName              = "/containarium.v1.ContainerService/RemoveSSHKey"
-	ContainerService_AddCollaborator_FullMethodName           = "/containarium.v1.ContainerService/AddCollaborator"
-	ContainerService_RemoveCollaborator_FullMethodName        = "/containarium.v1.ContainerService/RemoveCollaborator"
-	ContainerService_ListCollaborators_FullMethodName         = "/containarium.v1.ContainerService/ListCollaborators"
-	ContainerService_GetMetrics_FullMethodName                = "/containarium.v1.ContainerService/GetMetrics"
-	ContainerService_CleanupDisk_FullMethodName               = "/containarium.v1.ContainerService/CleanupDisk"
-	ContainerService_InstallStack_FullMethodName              = "/containarium.v1.ContainerService/InstallStack"
-	ContainerService_ListStacks_FullMethodName                = "/containarium.v1.ContainerService/ListStacks"
-	ContainerService_GetSystemInfo_FullMethodName             = "/containarium.v1.ContainerService/GetSystemInfo"
-	ContainerService_ListBackends_FullMethodName              = "/containarium.v1.ContainerService/ListBackends"
-	ContainerService_AdvertiseCapacity_FullMethodName         = "/containarium.v1.ContainerService/AdvertiseCapacity"
-	ContainerService_WithdrawCapacity_FullMethodName          = "/containarium.v1.ContainerService/WithdrawCapacity"
-	ContainerService_GetCapacityHeadroom_FullMethodName       = "/containarium.v1.ContainerService/GetCapacityHeadroom"
-	ContainerService_ProfileBackend_FullMethodName            = "/containarium.v1.ContainerService/ProfileBackend"
-	ContainerService_GetCapabilityProfile_FullMethodName      = "/containarium.v1.ContainerService/GetCapabilityProfile"
-	ContainerService_GetSelfMeasurement_FullMethodName        = "/containarium.v1.ContainerService/GetSelfMeasurement"
-	ContainerService_GetLatestRelease_FullMethodName          = "/containarium.v1.ContainerService/GetLatestRelease"
-	ContainerService_ValidateGPU_FullMethodName               = "/containarium.v1.ContainerService/ValidateGPU"
-	ContainerService_TriggerUpgrade_FullMethodName            = "/containarium.v1.ContainerService/TriggerUpgrade"
-	ContainerService_GetUpgradeStatus_FullMethodName          = "/containarium.v1.ContainerService/GetUpgradeStatus"
-	ContainerService_GetMonitoringInfo_FullMethodName         = "/containarium.v1.ContainerService/GetMonitoringInfo"
-	ContainerService_SetMetricsExport_FullMethodName          = "/containarium.v1.ContainerService/SetMetricsExport"
-	ContainerService_GetMetricsExport_FullMethodName          = "/containarium.v1.ContainerService/GetMetricsExport"
-	ContainerService_CreateAlertRule_FullMethodName           = "/containarium.v1.ContainerService/CreateAlertRule"
-	ContainerService_ListAlertRules_FullMethodName            = "/containarium.v1.ContainerService/ListAlertRules"
-	ContainerService_GetAlertRule_FullMethodName              = "/containarium.v1.ContainerService/GetAlertRule"
-	ContainerService_UpdateAlertRule_FullMethodName           = "/containarium.v1.ContainerService/UpdateAlertRule"
-	ContainerService_DeleteAlertRule_FullMethodName           = "/containarium.v1.ContainerService/DeleteAlertRule"
-	ContainerService_GetAlertingInfo_FullMethodName           = "/containarium.v1.ContainerService/GetAlertingInfo"
-	ContainerService_ListDefaultAlertRules_FullMethodName     = "/containarium.v1.ContainerService/ListDefaultAlertRules"
-	ContainerService_UpdateAlertingConfig_FullMethodName      = "/containarium.v1.ContainerService/UpdateAlertingConfig"
-	ContainerService_TestWebhook_FullMethodName               = "/containarium.v1.ContainerService/TestWebhook"
-	ContainerService_ListWebhookDeliveries_FullMethodName     = "/containarium.v1.ContainerService/ListWebhookDeliveries"
-	ContainerService_SetSecret_FullMethodName                 = "/containarium.v1.ContainerService/SetSecret"
-	ContainerService_GetSecret_FullMethodName                 = "/containarium.v1.ContainerService/GetSecret"
-	ContainerService_ListSecrets_FullMethodName               = "/containarium.v1.ContainerService/ListSecrets"
-	ContainerService_DeleteSecret_FullMethodName              = "/containarium.v1.ContainerService/DeleteSecret"
-	ContainerService_RefreshSecrets_FullMethodName            = "/containarium.v1.ContainerService/RefreshSecrets"
+	ContainerService_CreateContainer_FullMethodName               = "/containarium.v1.ContainerService/CreateContainer"
+	ContainerService_ListContainers_FullMethodName                = "/containarium.v1.ContainerService/ListContainers"
+	ContainerService_GetContainer_FullMethodName                  = "/containarium.v1.ContainerService/GetContainer"
+	ContainerService_DebugContainer_FullMethodName                = "/containarium.v1.ContainerService/DebugContainer"
+	ContainerService_DeleteContainer_FullMethodName               = "/containarium.v1.ContainerService/DeleteContainer"
+	ContainerService_StartContainer_FullMethodName                = "/containarium.v1.ContainerService/StartContainer"
+	ContainerService_StopContainer_FullMethodName                 = "/containarium.v1.ContainerService/StopContainer"
+	ContainerService_ResizeContainer_FullMethodName               = "/containarium.v1.ContainerService/ResizeContainer"
+	ContainerService_MoveContainer_FullMethodName                 = "/containarium.v1.ContainerService/MoveContainer"
+	ContainerService_CreateContainerSnapshot_FullMethodName       = "/containarium.v1.ContainerService/CreateContainerSnapshot"
+	ContainerService_ListContainerSnapshots_FullMethodName        = "/containarium.v1.ContainerService/ListContainerSnapshots"
+	ContainerService_DeleteContainerSnapshot_FullMethodName       = "/containarium.v1.ContainerService/DeleteContainerSnapshot"
+	ContainerService_RollbackContainerSnapshot_FullMethodName     = "/containarium.v1.ContainerService/RollbackContainerSnapshot"
+	ContainerService_CloneContainer_FullMethodName                = "/containarium.v1.ContainerService/CloneContainer"
+	ContainerService_ExportContainerSnapshot_FullMethodName       = "/containarium.v1.ContainerService/ExportContainerSnapshot"
+	ContainerService_ImportContainerSnapshot_FullMethodName       = "/containarium.v1.ContainerService/ImportContainerSnapshot"
+	ContainerService_ListContainerSnapshotFiles_FullMethodName    = "/containarium.v1.ContainerService/ListContainerSnapshotFiles"
+	ContainerService_ReadContainerSnapshotFile_FullMethodName     = "/containarium.v1.ContainerService/ReadContainerSnapshotFile"
+	ContainerService_DiffContainerSnapshots_FullMethodName        = "/containarium.v1.ContainerService/DiffContainerSnapshots"
+	ContainerService_SetContainerSnapshotPolicy_FullMethodName    = "/containarium.v1.ContainerService/SetContainerSnapshotPolicy"
+	ContainerService_GetContainerSnapshotPolicy_FullMethodName    = "/containarium.v1.ContainerService/GetContainerSnapshotPolicy"
+	ContainerService_DeleteContainerSnapshotPolicy_FullMethodName = "/containarium.v1.ContainerService/DeleteContainerSnapshotPolicy"
+	ContainerService_DeleteTenantStorage_FullMethodName           = "/containarium.v1.ContainerService/DeleteTenantStorage"
+	ContainerService_RewrapContainer_FullMethodName               = "/containarium.v1.ContainerService/RewrapContainer"
+	ContainerService_PrepareEncryptedMigration_FullMethodName     = "/containarium.v1.ContainerService/PrepareEncryptedMigration"
+	ContainerService_AdoptMigratedContainer_FullMethodName        = "/containarium.v1.ContainerService/AdoptMigratedContainer"
+	ContainerService_ToggleMonitoring_FullMethodName              = "/containarium.v1.ContainerService/ToggleMonitoring"
+	ContainerService_ToggleAutoSleep_FullMethodName               = "/containarium.v1.ContainerService/ToggleAutoSleep"
+	ContainerService_SetContainerTTL_FullMethodName               = "/containarium.v1.ContainerService/SetContainerTTL"
+	ContainerService_SetContainerDeletePolicy_FullMethodName      = "/containarium.v1.ContainerService/SetContainerDeletePolicy"
+	ContainerService_SetContainerAttribution_FullMethodName       = "/containarium.v1.ContainerService/SetContainerAttribution"
+	ContainerService_AddSSHKey_FullMethodName                     = "/containarium.v1.ContainerService/AddSSHKey"
+	ContainerService_RemoveSSHKey_FullMethodName                  = "/containarium.v1.ContainerService/RemoveSSHKey"
+	ContainerService_AddCollaborator_FullMethodName               = "/containarium.v1.ContainerService/AddCollaborator"
+	ContainerService_RemoveCollaborator_FullMethodName            = "/containarium.v1.ContainerService/RemoveCollaborator"
+	ContainerService_ListCollaborators_FullMethodName             = "/containarium.v1.ContainerService/ListCollaborators"
+	ContainerService_GetMetrics_FullMethodName                    = "/containarium.v1.ContainerService/GetMetrics"
+	ContainerService_CleanupDisk_FullMethodName                   = "/containarium.v1.ContainerService/CleanupDisk"
+	ContainerService_InstallStack_FullMethodName                  = "/containarium.v1.ContainerService/InstallStack"
+	ContainerService_ListStacks_FullMethodName                    = "/containarium.v1.ContainerService/ListStacks"
+	ContainerService_GetSystemInfo_FullMethodName                 = "/containarium.v1.ContainerService/GetSystemInfo"
+	ContainerService_ListBackends_FullMethodName                  = "/containarium.v1.ContainerService/ListBackends"
+	ContainerService_AdvertiseCapacity_FullMethodName             = "/containarium.v1.ContainerService/AdvertiseCapacity"
+	ContainerService_WithdrawCapacity_FullMethodName              = "/containarium.v1.ContainerService/WithdrawCapacity"
+	ContainerService_GetCapacityHeadroom_FullMethodName           = "/containarium.v1.ContainerService/GetCapacityHeadroom"
+	ContainerService_ProfileBackend_FullMethodName                = "/containarium.v1.ContainerService/ProfileBackend"
+	ContainerService_GetCapabilityProfile_FullMethodName          = "/containarium.v1.ContainerService/GetCapabilityProfile"
+	ContainerService_GetSelfMeasurement_FullMethodName            = "/containarium.v1.ContainerService/GetSelfMeasurement"
+	ContainerService_GetLatestRelease_FullMethodName              = "/containarium.v1.ContainerService/GetLatestRelease"
+	ContainerService_ValidateGPU_FullMethodName                   = "/containarium.v1.ContainerService/ValidateGPU"
+	ContainerService_TriggerUpgrade_FullMethodName                = "/containarium.v1.ContainerService/TriggerUpgrade"
+	ContainerService_GetUpgradeStatus_FullMethodName              = "/containarium.v1.ContainerService/GetUpgradeStatus"
+	ContainerService_GetMonitoringInfo_FullMethodName             = "/containarium.v1.ContainerService/GetMonitoringInfo"
+	ContainerService_SetMetricsExport_FullMethodName              = "/containarium.v1.ContainerService/SetMetricsExport"
+	ContainerService_GetMetricsExport_FullMethodName              = "/containarium.v1.ContainerService/GetMetricsExport"
+	ContainerService_CreateAlertRule_FullMethodName               = "/containarium.v1.ContainerService/CreateAlertRule"
+	ContainerService_ListAlertRules_FullMethodName                = "/containarium.v1.ContainerService/ListAlertRules"
+	ContainerService_GetAlertRule_FullMethodName                  = "/containarium.v1.ContainerService/GetAlertRule"
+	ContainerService_UpdateAlertRule_FullMethodName               = "/containarium.v1.ContainerService/UpdateAlertRule"
+	ContainerService_DeleteAlertRule_FullMethodName               = "/containarium.v1.ContainerService/DeleteAlertRule"
+	ContainerService_GetAlertingInfo_FullMethodName               = "/containarium.v1.ContainerService/GetAlertingInfo"
+	ContainerService_ListDefaultAlertRules_FullMethodName         = "/containarium.v1.ContainerService/ListDefaultAlertRules"
+	ContainerService_UpdateAlertingConfig_FullMethodName          = "/containarium.v1.ContainerService/UpdateAlertingConfig"
+	ContainerService_TestWebhook_FullMethodName                   = "/containarium.v1.ContainerService/TestWebhook"
+	ContainerService_ListWebhookDeliveries_FullMethodName         = "/containarium.v1.ContainerService/ListWebhookDeliveries"
+	ContainerService_SetSecret_FullMethodName                     = "/containarium.v1.ContainerService/SetSecret"
+	ContainerService_GetSecret_FullMethodName                     = "/containarium.v1.ContainerService/GetSecret"
+	ContainerService_ListSecrets_FullMethodName                   = "/containarium.v1.ContainerService/ListSecrets"
+	ContainerService_DeleteSecret_FullMethodName                  = "/containarium.v1.ContainerService/DeleteSecret"
+	ContainerService_RefreshSecrets_FullMethodName                = "/containarium.v1.ContainerService/RefreshSecrets"
+	ContainerService_ListSecretVersions_FullMethodName            = "/containarium.v1.ContainerService/ListSecretVersions"
+	ContainerService_RollbackSecret_FullMethodName                = "/containarium.v1.ContainerService/RollbackSecret"
 )
 
 // ContainerServiceClient is the client API for ContainerService service.
@@ -139,6 +150,50 @@ type ContainerServiceClient interface {
 	// and refuses when the encryption key is unavailable — a rollback whose
 	// result cannot be read is not a restore (#1202 AC2).
 	RollbackContainerSnapshot(ctx context.Context, in *RollbackContainerSnapshotRequest, opts ...grpc.CallOption) (*RollbackContainerSnapshotResponse, error)
+	// CloneContainer creates a new box from a snapshot of an existing one, so a
+	// working box can be branched into siblings that try alternatives in
+	// parallel.
+	//
+	// The fork is a ZFS clone of the snapshot — instant, and sharing blocks
+	// with its origin until they diverge — registered as its own Incus
+	// instance with a fresh identity: its own user, SSH keys, IP, secrets and
+	// labels. It stays in the source's tenant and encryptionroot; the clone
+	// pins the snapshot until the fork is deleted.
+	CloneContainer(ctx context.Context, in *CloneContainerRequest, opts ...grpc.CallOption) (*CloneContainerResponse, error)
+	// ExportContainerSnapshot ships a snapshot off the host as a raw ZFS
+	// replication stream, so it survives losing the host and its pool. An
+	// encrypted container's stream stays ciphertext under its tenant's key.
+	// Each export to a target after the first is incremental from the
+	// previous one while that snapshot still exists.
+	ExportContainerSnapshot(ctx context.Context, in *ExportContainerSnapshotRequest, opts ...grpc.CallOption) (*ExportContainerSnapshotResponse, error)
+	// ImportContainerSnapshot creates a container from a chain of exported
+	// snapshots, on this daemon or — with backend_id — any backend in the
+	// pool, whether or not the source host still exists.
+	ImportContainerSnapshot(ctx context.Context, in *ImportContainerSnapshotRequest, opts ...grpc.CallOption) (*ImportContainerSnapshotResponse, error)
+	// ListContainerSnapshotFiles lists a directory as it was in a snapshot,
+	// so a lost file can be found without rolling the container back.
+	//
+	// Reads the snapshot's contents, so like rollback it needs the encryption
+	// key loaded, and the container's volume mounted — in practice, the
+	// container running.
+	ListContainerSnapshotFiles(ctx context.Context, in *ListContainerSnapshotFilesRequest, opts ...grpc.CallOption) (*ListContainerSnapshotFilesResponse, error)
+	// ReadContainerSnapshotFile reads a file as it was in a snapshot, a range
+	// at a time. Symlinks are not followed; read their target instead.
+	ReadContainerSnapshotFile(ctx context.Context, in *ReadContainerSnapshotFileRequest, opts ...grpc.CallOption) (*ReadContainerSnapshotFileResponse, error)
+	// DiffContainerSnapshots lists the files added, modified and deleted
+	// between a snapshot and a later one, or the live container.
+	DiffContainerSnapshots(ctx context.Context, in *DiffContainerSnapshotsRequest, opts ...grpc.CallOption) (*DiffContainerSnapshotsResponse, error)
+	// SetContainerSnapshotPolicy creates or replaces a container's snapshot
+	// schedule and retention. The daemon's scheduler takes "auto-<timestamp>"
+	// snapshots on it and prunes only those; snapshots taken by hand are never
+	// touched.
+	SetContainerSnapshotPolicy(ctx context.Context, in *SetContainerSnapshotPolicyRequest, opts ...grpc.CallOption) (*SetContainerSnapshotPolicyResponse, error)
+	// GetContainerSnapshotPolicy returns a container's snapshot policy and
+	// when it next runs.
+	GetContainerSnapshotPolicy(ctx context.Context, in *GetContainerSnapshotPolicyRequest, opts ...grpc.CallOption) (*GetContainerSnapshotPolicyResponse, error)
+	// DeleteContainerSnapshotPolicy stops a container's scheduled snapshots.
+	// Snapshots already taken are kept.
+	DeleteContainerSnapshotPolicy(ctx context.Context, in *DeleteContainerSnapshotPolicyRequest, opts ...grpc.CallOption) (*DeleteContainerSnapshotPolicyResponse, error)
 	// DeleteTenantStorage tears down a departing tenant's encrypted storage
 	// (#1343) — the Incus storage pool, then the encrypted dataset it is
 	// sourced at, in that order.
@@ -377,6 +432,13 @@ type ContainerServiceClient interface {
 	// useful after rotation when the next exec'd process should see
 	// the new value without a full container restart.
 	RefreshSecrets(ctx context.Context, in *RefreshSecretsRequest, opts ...grpc.CallOption) (*RefreshSecretsResponse, error)
+	// ListSecretVersions lists the retained versions of a secret,
+	// metadata only — never values.
+	ListSecretVersions(ctx context.Context, in *ListSecretVersionsRequest, opts ...grpc.CallOption) (*ListSecretVersionsResponse, error)
+	// RollbackSecret makes a retained version of a secret current again,
+	// written as a new version. Does not touch running containers —
+	// caller invokes RefreshSecrets to deliver it.
+	RollbackSecret(ctx context.Context, in *RollbackSecretRequest, opts ...grpc.CallOption) (*RollbackSecretResponse, error)
 }
 
 type containerServiceClient struct {
@@ -517,6 +579,96 @@ func (c *containerServiceClient) RollbackContainerSnapshot(ctx context.Context,
 	return out, nil
 }
 
+func (c *containerServiceClient) CloneContainer(ctx context.Context, in *CloneContainerRequest, opts ...grpc.CallOption) (*CloneContainerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneContainerResponse)
+	err := c.cc.Invoke(ctx, ContainerService_CloneContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ExportContainerSnapshot(ctx context.Context, in *ExportContainerSnapshotRequest, opts ...grpc.CallOption) (*ExportContainerSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportContainerSnapshotResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ExportContainerSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ImportContainerSnapshot(ctx context.Context, in *ImportContainerSnapshotRequest, opts ...grpc.CallOption) (*ImportContainerSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportContainerSnapshotResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ImportContainerSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ListContainerSnapshotFiles(ctx context.Context, in *ListContainerSnapshotFilesRequest, opts ...grpc.CallOption) (*ListContainerSnapshotFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContainerSnapshotFilesResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ListContainerSnapshotFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ReadContainerSnapshotFile(ctx context.Context, in *ReadContainerSnapshotFileRequest, opts ...grpc.CallOption) (*ReadContainerSnapshotFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadContainerSnapshotFileResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ReadContainerSnapshotFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) DiffContainerSnapshots(ctx context.Context, in *DiffContainerSnapshotsRequest, opts ...grpc.CallOption) (*DiffContainerSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffContainerSnapshotsResponse)
+	err := c.cc.Invoke(ctx, ContainerService_DiffContainerSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) SetContainerSnapshotPolicy(ctx context.Context, in *SetContainerSnapshotPolicyRequest, opts ...grpc.CallOption) (*SetContainerSnapshotPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetContainerSnapshotPolicyResponse)
+	err := c.cc.Invoke(ctx, ContainerService_SetContainerSnapshotPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) GetContainerSnapshotPolicy(ctx context.Context, in *GetContainerSnapshotPolicyRequest, opts ...grpc.CallOption) (*GetContainerSnapshotPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContainerSnapshotPolicyResponse)
+	err := c.cc.Invoke(ctx, ContainerService_GetContainerSnapshotPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) DeleteContainerSnapshotPolicy(ctx context.Context, in *DeleteContainerSnapshotPolicyRequest, opts ...grpc.CallOption) (*DeleteContainerSnapshotPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteContainerSnapshotPolicyResponse)
+	err := c.cc.Invoke(ctx, ContainerService_DeleteContainerSnapshotPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *containerServiceClient) DeleteTenantStorage(ctx context.Context, in *DeleteTenantStorageRequest, opts ...grpc.CallOption) (*DeleteTenantStorageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteTenantStorageResponse)
@@ -997,6 +1149,26 @@ func (c *containerServiceClient) RefreshSecrets(ctx context.Context, in *Refresh
 	return out, nil
 }
 
+func (c *containerServiceClient) ListSecretVersions(ctx context.Context, in *ListSecretVersionsRequest, opts ...grpc.CallOption) (*ListSecretVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecretVersionsResponse)
+	err := c.cc.Invoke(ctx, ContainerService_ListSecretVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) RollbackSecret(ctx context.Context, in *RollbackSecretRequest, opts ...grpc.CallOption) (*RollbackSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackSecretResponse)
+	err := c.cc.Invoke(ctx, ContainerService_RollbackSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContainerServiceServer is the server API for ContainerService service.
 // All implementations must embed UnimplementedContainerServiceServer
 // for forward compatibility.
@@ -1054,6 +1226,50 @@ type ContainerServiceServer interface {
 	// and refuses when the encryption key is unavailable — a rollback whose
 	// result cannot be read is not a restore (#1202 AC2).
 	RollbackContainerSnapshot(context.Context, *RollbackContainerSnapshotRequest) (*RollbackContainerSnapshotResponse, error)
+	// CloneContainer creates a new box from a snapshot of an existing one, so a
+	// working box can be branched into siblings that try alternatives in
+	// parallel.
+	//
+	// The fork is a ZFS clone of the snapshot — instant, and sharing blocks
+	// with its origin until they diverge — registered as its own Incus
+	// instance with a fresh identity: its own user, SSH keys, IP, secrets and
+	// labels. It stays in the source's tenant and encryptionroot; the clone
+	// pins the snapshot until the fork is deleted.
+	CloneContainer(context.Context, *CloneContainerRequest) (*CloneContainerResponse, error)
+	// ExportContainerSnapshot ships a snapshot off the host as a raw ZFS
+	// replication stream, so it survives losing the host and its pool. An
+	// encrypted container's stream stays ciphertext under its tenant's key.
+	// Each export to a target after the first is incremental from the
+	// previous one while that snapshot still exists.
+	ExportContainerSnapshot(context.Context, *ExportContainerSnapshotRequest) (*ExportContainerSnapshotResponse, error)
+	// ImportContainerSnapshot creates a container from a chain of exported
+	// snapshots, on this daemon or — with backend_id — any backend in the
+	// pool, whether or not the source host still exists.
+	ImportContainerSnapshot(context.Context, *ImportContainerSnapshotRequest) (*ImportContainerSnapshotResponse, error)
+	// ListContainerSnapshotFiles lists a directory as it was in a snapshot,
+	// so a lost file can be found without rolling the container back.
+	//
+	// Reads the snapshot's contents, so like rollback it needs the encryption
+	// key loaded, and the container's volume mounted — in practice, the
+	// container running.
+	ListContainerSnapshotFiles(context.Context, *ListContainerSnapshotFilesRequest) (*ListContainerSnapshotFilesResponse, error)
+	// ReadContainerSnapshotFile reads a file as it was in a snapshot, a range
+	// at a time. Symlinks are not followed; read their target instead.
+	ReadContainerSnapshotFile(context.Context, *ReadContainerSnapshotFileRequest) (*ReadContainerSnapshotFileResponse, error)
+	// DiffContainerSnapshots lists the files added, modified and deleted
+	// between a snapshot and a later one, or the live container.
+	DiffContainerSnapshots(context.Context, *DiffContainerSnapshotsRequest) (*DiffContainerSnapshotsResponse, error)
+	// SetContainerSnapshotPolicy creates or replaces a container's snapshot
+	// schedule and retention. The daemon's scheduler takes "auto-<timestamp>"
+	// snapshots on it and prunes only those; snapshots taken by hand are never
+	// touched.
+	SetContainerSnapshotPolicy(context.Context, *SetContainerSnapshotPolicyRequest) (*SetContainerSnapshotPolicyResponse, error)
+	// GetContainerSnapshotPolicy returns a container's snapshot policy and
+	// when it next runs.
+	GetContainerSnapshotPolicy(context.Context, *GetContainerSnapshotPolicyRequest) (*GetContainerSnapshotPolicyResponse, error)
+	// DeleteContainerSnapshotPolicy stops a container's scheduled snapshots.
+	// Snapshots already taken are kept.
+	DeleteContainerSnapshotPolicy(context.Context, *DeleteContainerSnapshotPolicyRequest) (*DeleteContainerSnapshotPolicyResponse, error)
 	// DeleteTenantStorage tears down a departing tenant's encrypted storage
 	// (#1343) — the Incus storage pool, then the encrypted dataset it is
 	// sourced at, in that order.
@@ -1292,6 +1508,13 @@ type ContainerServiceServer interface {
 	// useful after rotation when the next exec'd process should see
 	// the new value without a full container restart.
 	RefreshSecrets(context.Context, *RefreshSecretsRequest) (*RefreshSecretsResponse, error)
+	// ListSecretVersions lists the retained versions of a secret,
+	// metadata only — never values.
+	ListSecretVersions(context.Context, *ListSecretVersionsRequest) (*ListSecretVersionsResponse, error)
+	// RollbackSecret makes a retained version of a secret current again,
+	// written as a new version. Does not touch running containers —
+	// caller invokes RefreshSecrets to deliver it.
+	RollbackSecret(context.Context, *RollbackSecretRequest) (*RollbackSecretResponse, error)
 	mustEmbedUnimplementedContainerServiceServer()
 }
 
@@ -1341,6 +1564,33 @@ func (UnimplementedContainerServiceServer) DeleteContainerSnapshot(context.Conte
 func (UnimplementedContainerServiceServer) RollbackContainerSnapshot(context.Context, *RollbackContainerSnapshotRequest) (*RollbackContainerSnapshotResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RollbackContainerSnapshot not implemented")
 }
+func (UnimplementedContainerServiceServer) CloneContainer(context.Context, *CloneContainerRequest) (*CloneContainerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloneContainer not implemented")
+}
+func (UnimplementedContainerServiceServer) ExportContainerSnapshot(context.Context, *ExportContainerSnapshotRequest) (*ExportContainerSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportContainerSnapshot not implemented")
+}
+func (UnimplementedContainerServiceServer) ImportContainerSnapshot(context.Context, *ImportContainerSnapshotRequest) (*ImportContainerSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportContainerSnapshot not implemented")
+}
+func (UnimplementedContainerServiceServer) ListContainerSnapshotFiles(context.Context, *ListContainerSnapshotFilesRequest) (*ListContainerSnapshotFilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListContainerSnapshotFiles not implemented")
+}
+func (UnimplementedContainerServiceServer) ReadContainerSnapshotFile(context.Context, *ReadContainerSnapshotFileRequest) (*ReadContainerSnapshotFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadContainerSnapshotFile not implemented")
+}
+func (UnimplementedContainerServiceServer) DiffContainerSnapshots(context.Context, *DiffContainerSnapshotsRequest) (*DiffContainerSnapshotsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffContainerSnapshots not implemented")
+}
+func (UnimplementedContainerServiceServer) SetContainerSnapshotPolicy(context.Context, *SetContainerSnapshotPolicyRequest) (*SetContainerSnapshotPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetContainerSnapshotPolicy not implemented")
+}
+func (UnimplementedContainerServiceServer) GetContainerSnapshotPolicy(context.Context, *GetContainerSnapshotPolicyRequest) (*GetContainerSnapshotPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetContainerSnapshotPolicy not implemented")
+}
+func (UnimplementedContainerServiceServer) DeleteContainerSnapshotPolicy(context.Context, *DeleteContainerSnapshotPolicyRequest) (*DeleteContainerSnapshotPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteContainerSnapshotPolicy not implemented")
+}
 func (UnimplementedContainerServiceServer) DeleteTenantStorage(context.Context, *DeleteTenantStorageRequest) (*DeleteTenantStorageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteTenantStorage not implemented")
 }
@@ -1485,6 +1735,12 @@ func (UnimplementedContainerServiceServer) DeleteSecret(context.Context, *Delete
 func (UnimplementedContainerServiceServer) RefreshSecrets(context.Context, *RefreshSecretsRequest) (*RefreshSecretsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RefreshSecrets not implemented")
 }
+func (UnimplementedContainerServiceServer) ListSecretVersions(context.Context, *ListSecretVersionsRequest) (*ListSecretVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSecretVersions not implemented")
+}
+func (UnimplementedContainerServiceServer) RollbackSecret(context.Context, *RollbackSecretRequest) (*RollbackSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackSecret not implemented")
+}
 func (UnimplementedContainerServiceServer) mustEmbedUnimplementedContainerServiceServer() {}
 func (UnimplementedContainerServiceServer) testEmbeddedByValue()                          {}
 
@@ -1740,6 +1996,168 @@ func _ContainerService_RollbackContainerSnapshot_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_CloneContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).CloneContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_CloneContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).CloneContainer(ctx, req.(*CloneContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ExportContainerSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportContainerSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ExportContainerSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ExportContainerSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ExportContainerSnapshot(ctx, req.(*ExportContainerSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ImportContainerSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportContainerSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ImportContainerSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ImportContainerSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ImportContainerSnapshot(ctx, req.(*ImportContainerSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ListContainerSnapshotFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainerSnapshotFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ListContainerSnapshotFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ListContainerSnapshotFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ListContainerSnapshotFiles(ctx, req.(*ListContainerSnapshotFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ReadContainerSnapshotFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadContainerSnapshotFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ReadContainerSnapshotFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ReadContainerSnapshotFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ReadContainerSnapshotFile(ctx, req.(*ReadContainerSnapshotFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_DiffContainerSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffContainerSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).DiffContainerSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_DiffContainerSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).DiffContainerSnapshots(ctx, req.(*DiffContainerSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_SetContainerSnapshotPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContainerSnapshotPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).SetContainerSnapshotPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_SetContainerSnapshotPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).SetContainerSnapshotPolicy(ctx, req.(*SetContainerSnapshotPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_GetContainerSnapshotPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerSnapshotPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).GetContainerSnapshotPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_GetContainerSnapshotPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).GetContainerSnapshotPolicy(ctx, req.(*GetContainerSnapshotPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_DeleteContainerSnapshotPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteContainerSnapshotPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).DeleteContainerSnapshotPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_DeleteContainerSnapshotPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).DeleteContainerSnapshotPolicy(ctx, req.(*DeleteContainerSnapshotPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ContainerService_DeleteTenantStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteTenantStorageRequest)
 	if err := dec(in); err != nil {
@@ -2604,6 +3022,42 @@ func _ContainerService_RefreshSecrets_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_ListSecretVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ListSecretVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ListSecretVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ListSecretVersions(ctx, req.(*ListSecretVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_RollbackSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).RollbackSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_RollbackSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).RollbackSecret(ctx, req.(*RollbackSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ContainerService_ServiceDesc is the grpc.ServiceDesc for ContainerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2663,6 +3117,42 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RollbackContainerSnapshot",
 			Handler:    _ContainerService_RollbackContainerSnapshot_Handler,
 		},
+		{
+			MethodName: "CloneContainer",
+			Handler:    _ContainerService_CloneContainer_Handler,
+		},
+		{
+			MethodName: "ExportContainerSnapshot",
+			Handler:    _ContainerService_ExportContainerSnapshot_Handler,
+		},
+		{
+			MethodName: "ImportContainerSnapshot",
+			Handler:    _ContainerService_ImportContainerSnapshot_Handler,
+		},
+		{
+			MethodName: "ListContainerSnapshotFiles",
+			Handler:    _ContainerService_ListContainerSnapshotFiles_Handler,
+		},
+		{
+			MethodName: "ReadContainerSnapshotFile",
+			Handler:    _ContainerService_ReadContainerSnapshotFile_Handler,
+		},
+		{
+			MethodName: "DiffContainerSnapshots",
+			Handler:    _ContainerService_DiffContainerSnapshots_Handler,
+		},
+		{
+			MethodName: "SetContainerSnapshotPolicy",
+			Handler:    _ContainerService_SetContainerSnapshotPolicy_Handler,
+		},
+		{
+			MethodName: "GetContainerSnapshotPolicy",
+			Handler:    _ContainerService_GetContainerSnapshotPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteContainerSnapshotPolicy",
+			Handler:    _ContainerService_DeleteContainerSnapshotPolicy_Handler,
+		},
 		{
 			MethodName: "DeleteTenantStorage",
 			Handler:    _ContainerService_DeleteTenantStorage_Handler,
@@ -2855,6 +3345,14 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshSecrets",
 			Handler:    _ContainerService_RefreshSecrets_Handler,
 		},
+		{
+			MethodName: "ListSecretVersions",
+			Handler:    _ContainerService_ListSecretVersions_Handler,
+		},
+		{
+			MethodName: "RollbackSecret",
+			Handler:    _ContainerService_RollbackSecret_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "containarium/v1/service.proto",
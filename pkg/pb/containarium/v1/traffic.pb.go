@@ -1516,6 +1516,252 @@ func (x *GetTrafficAggregatesResponse) GetAggregates() []*TrafficAggregate {
 	return nil
 }
 
+// ContainerHTTPMetrics is one container's HTTP RED figures (rate, errors,
+// duration) for an access-log window, measured at the Caddy edge.
+type ContainerHTTPMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	// Requests handled in the window
+	Requests int64 `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Requests answered 5xx
+	ServerErrors int64 `protobuf:"varint,3,opt,name=server_errors,json=serverErrors,proto3" json:"server_errors,omitempty"`
+	// Requests answered 4xx (reported, not counted as errors)
+	ClientErrors int64 `protobuf:"varint,4,opt,name=client_errors,json=clientErrors,proto3" json:"client_errors,omitempty"`
+	// Requests per second over the window
+	RequestsPerSecond float64 `protobuf:"fixed64,5,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// server_errors / requests (0 when there were no requests)
+	ErrorRatio float64 `protobuf:"fixed64,6,opt,name=error_ratio,json=errorRatio,proto3" json:"error_ratio,omitempty"`
+	// Response body bytes per second
+	ResponseBytesPerSecond float64 `protobuf:"fixed64,7,opt,name=response_bytes_per_second,json=responseBytesPerSecond,proto3" json:"response_bytes_per_second,omitempty"`
+	// Request duration quantiles in milliseconds, estimated from a latency
+	// histogram; a quantile past the last bucket (10s) reports 10000.
+	P50Ms         float64 `protobuf:"fixed64,8,opt,name=p50_ms,json=p50Ms,proto3" json:"p50_ms,omitempty"`
+	P95Ms         float64 `protobuf:"fixed64,9,opt,name=p95_ms,json=p95Ms,proto3" json:"p95_ms,omitempty"`
+	P99Ms         float64 `protobuf:"fixed64,10,opt,name=p99_ms,json=p99Ms,proto3" json:"p99_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerHTTPMetrics) Reset() {
+	*x = ContainerHTTPMetrics{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerHTTPMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerHTTPMetrics) ProtoMessage() {}
+
+func (x *ContainerHTTPMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerHTTPMetrics.ProtoReflect.Descriptor instead.
+func (*ContainerHTTPMetrics) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ContainerHTTPMetrics) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *ContainerHTTPMetrics) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetServerErrors() int64 {
+	if x != nil {
+		return x.ServerErrors
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetClientErrors() int64 {
+	if x != nil {
+		return x.ClientErrors
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetRequestsPerSecond() float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetErrorRatio() float64 {
+	if x != nil {
+		return x.ErrorRatio
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetResponseBytesPerSecond() float64 {
+	if x != nil {
+		return x.ResponseBytesPerSecond
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetP50Ms() float64 {
+	if x != nil {
+		return x.P50Ms
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetP95Ms() float64 {
+	if x != nil {
+		return x.P95Ms
+	}
+	return 0
+}
+
+func (x *ContainerHTTPMetrics) GetP99Ms() float64 {
+	if x != nil {
+		return x.P99Ms
+	}
+	return 0
+}
+
+// GetHTTPMetricsRequest reads the latest HTTP RED window
+type GetHTTPMetricsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Container name (optional; empty = every container the caller may read)
+	ContainerName string `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHTTPMetricsRequest) Reset() {
+	*x = GetHTTPMetricsRequest{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHTTPMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHTTPMetricsRequest) ProtoMessage() {}
+
+func (x *GetHTTPMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHTTPMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetHTTPMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetHTTPMetricsRequest) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+type GetHTTPMetricsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Per-container figures, sorted by name. Containers that served no
+	// requests in the window are absent.
+	Containers []*ContainerHTTPMetrics `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	// The window the figures cover (unset before the first window closes)
+	WindowStart *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Whether access-log aggregation is enabled on this daemon
+	// (CONTAINARIUM_ACCESS_LOG). When false the list is always empty.
+	Enabled       bool `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHTTPMetricsResponse) Reset() {
+	*x = GetHTTPMetricsResponse{}
+	mi := &file_containarium_v1_traffic_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHTTPMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHTTPMetricsResponse) ProtoMessage() {}
+
+func (x *GetHTTPMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_containarium_v1_traffic_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHTTPMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetHTTPMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_containarium_v1_traffic_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetHTTPMetricsResponse) GetContainers() []*ContainerHTTPMetrics {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *GetHTTPMetricsResponse) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *GetHTTPMetricsResponse) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *GetHTTPMetricsResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
 var File_containarium_v1_traffic_proto protoreflect.FileDescriptor
 
 const file_containarium_v1_traffic_proto_rawDesc = "" +
@@ -1632,7 +1878,30 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\x1cGetTrafficAggregatesResponse\x12A\n" +
 	"\n" +
 	"aggregates\x18\x01 \x03(\v2!.containarium.v1.TrafficAggregateR\n" +
-	"aggregates*[\n" +
+	"aggregates\"\xf4\x02\n" +
+	"\x14ContainerHTTPMetrics\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x03R\brequests\x12#\n" +
+	"\rserver_errors\x18\x03 \x01(\x03R\fserverErrors\x12#\n" +
+	"\rclient_errors\x18\x04 \x01(\x03R\fclientErrors\x12.\n" +
+	"\x13requests_per_second\x18\x05 \x01(\x01R\x11requestsPerSecond\x12\x1f\n" +
+	"\verror_ratio\x18\x06 \x01(\x01R\n" +
+	"errorRatio\x129\n" +
+	"\x19response_bytes_per_second\x18\a \x01(\x01R\x16responseBytesPerSecond\x12\x15\n" +
+	"\x06p50_ms\x18\b \x01(\x01R\x05p50Ms\x12\x15\n" +
+	"\x06p95_ms\x18\t \x01(\x01R\x05p95Ms\x12\x15\n" +
+	"\x06p99_ms\x18\n" +
+	" \x01(\x01R\x05p99Ms\">\n" +
+	"\x15GetHTTPMetricsRequest\x12%\n" +
+	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\"\xf3\x01\n" +
+	"\x16GetHTTPMetricsResponse\x12E\n" +
+	"\n" +
+	"containers\x18\x01 \x03(\v2%.containarium.v1.ContainerHTTPMetricsR\n" +
+	"containers\x12=\n" +
+	"\fwindow_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vwindowStart\x129\n" +
+	"\n" +
+	"window_end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\twindowEnd\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled*[\n" +
 	"\bProtocol\x12\x18\n" +
 	"\x14PROTOCOL_UNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fPROTOCOL_TCP\x10\x01\x12\x10\n" +
@@ -1657,8 +1926,7 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\x1eTRAFFIC_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16TRAFFIC_EVENT_TYPE_NEW\x10\x01\x12\x1d\n" +
 	"\x19TRAFFIC_EVENT_TYPE_UPDATE\x10\x02\x12\x1e\n" +
-	"\x1aTRAFFIC_EVENT_TYPE_DESTROY\x10\x032\xba\n" +
-	"\n" +
+	"\x1aTRAFFIC_EVENT_TYPE_DESTROY\x10\x032\x9d\r\n" +
 	"\x0eTrafficService\x12\x85\x02\n" +
 	"\x0eGetConnections\x12&.containarium.v1.GetConnectionsRequest\x1a'.containarium.v1.GetConnectionsResponse\"\xa1\x01\x92Ak\n" +
 	"\aTraffic\x12\x16Get active connections\x1aHReturns active network connections for a container tracked by conntrack.\x82\xd3\xe4\x93\x02-\x12+/v1/containers/{container_name}/connections\x12\x8f\x02\n" +
@@ -1669,7 +1937,9 @@ const file_containarium_v1_traffic_proto_rawDesc = "" +
 	"\x13QueryTrafficHistory\x12+.containarium.v1.QueryTrafficHistoryRequest\x1a,.containarium.v1.QueryTrafficHistoryResponse\"\x97\x01\x92A]\n" +
 	"\aTraffic\x12\x15Query traffic history\x1a;Returns historical connection data from persistent storage.\x82\xd3\xe4\x93\x021\x12//v1/containers/{container_name}/traffic/history\x12\x93\x02\n" +
 	"\x14GetTrafficAggregates\x12,.containarium.v1.GetTrafficAggregatesRequest\x1a-.containarium.v1.GetTrafficAggregatesResponse\"\x9d\x01\x92A`\n" +
-	"\aTraffic\x12\x16Get traffic aggregates\x1a=Returns aggregated traffic statistics over time for analysis.\x82\xd3\xe4\x93\x024\x122/v1/containers/{container_name}/traffic/aggregatesBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
+	"\aTraffic\x12\x16Get traffic aggregates\x1a=Returns aggregated traffic statistics over time for analysis.\x82\xd3\xe4\x93\x024\x122/v1/containers/{container_name}/traffic/aggregates\x12\xe0\x02\n" +
+	"\x0eGetHTTPMetrics\x12&.containarium.v1.GetHTTPMetricsRequest\x1a'.containarium.v1.GetHTTPMetricsResponse\"\xfc\x01\x92A\xb0\x01\n" +
+	"\aTraffic\x12\x14Get HTTP RED metrics\x1a\x8e\x01Returns per-container HTTP request rate, 5xx error ratio and p50/p95/p99 latency for the latest access-log window, measured at the Caddy edge.\x82\xd3\xe4\x93\x02BZ.\x12,/v1/containers/{container_name}/traffic/http\x12\x10/v1/traffic/httpBKZIgithub.com/footprintai/containarium/pkg/pb/containarium/v1;containariumv1b\x06proto3"
 
 var (
 	file_containarium_v1_traffic_proto_rawDescOnce sync.Once
@@ -1684,7 +1954,7 @@ func file_containarium_v1_traffic_proto_rawDescGZIP() []byte {
 }
 
 var file_containarium_v1_traffic_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_containarium_v1_traffic_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_containarium_v1_traffic_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_containarium_v1_traffic_proto_goTypes = []any{
 	(Protocol)(0),                        // 0: containarium.v1.Protocol
 	(ConnectionState)(0),                 // 1: containarium.v1.ConnectionState
@@ -1705,48 +1975,56 @@ var file_containarium_v1_traffic_proto_goTypes = []any{
 	(*QueryTrafficHistoryResponse)(nil),  // 16: containarium.v1.QueryTrafficHistoryResponse
 	(*GetTrafficAggregatesRequest)(nil),  // 17: containarium.v1.GetTrafficAggregatesRequest
 	(*GetTrafficAggregatesResponse)(nil), // 18: containarium.v1.GetTrafficAggregatesResponse
-	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
+	(*ContainerHTTPMetrics)(nil),         // 19: containarium.v1.ContainerHTTPMetrics
+	(*GetHTTPMetricsRequest)(nil),        // 20: containarium.v1.GetHTTPMetricsRequest
+	(*GetHTTPMetricsResponse)(nil),       // 21: containarium.v1.GetHTTPMetricsResponse
+	(*timestamppb.Timestamp)(nil),        // 22: google.protobuf.Timestamp
 }
 var file_containarium_v1_traffic_proto_depIdxs = []int32{
 	0,  // 0: containarium.v1.Connection.protocol:type_name -> containarium.v1.Protocol
 	1,  // 1: containarium.v1.Connection.state:type_name -> containarium.v1.ConnectionState
 	2,  // 2: containarium.v1.Connection.direction:type_name -> containarium.v1.TrafficDirection
-	19, // 3: containarium.v1.Connection.first_seen:type_name -> google.protobuf.Timestamp
-	19, // 4: containarium.v1.Connection.last_seen:type_name -> google.protobuf.Timestamp
+	22, // 3: containarium.v1.Connection.first_seen:type_name -> google.protobuf.Timestamp
+	22, // 4: containarium.v1.Connection.last_seen:type_name -> google.protobuf.Timestamp
 	3,  // 5: containarium.v1.TrafficEvent.type:type_name -> containarium.v1.TrafficEventType
 	4,  // 6: containarium.v1.TrafficEvent.connection:type_name -> containarium.v1.Connection
-	19, // 7: containarium.v1.TrafficEvent.timestamp:type_name -> google.protobuf.Timestamp
+	22, // 7: containarium.v1.TrafficEvent.timestamp:type_name -> google.protobuf.Timestamp
 	7,  // 8: containarium.v1.ConnectionSummary.top_destinations:type_name -> containarium.v1.DestinationStats
 	0,  // 9: containarium.v1.HistoricalConnection.protocol:type_name -> containarium.v1.Protocol
 	2,  // 10: containarium.v1.HistoricalConnection.direction:type_name -> containarium.v1.TrafficDirection
-	19, // 11: containarium.v1.HistoricalConnection.started_at:type_name -> google.protobuf.Timestamp
-	19, // 12: containarium.v1.HistoricalConnection.ended_at:type_name -> google.protobuf.Timestamp
-	19, // 13: containarium.v1.TrafficAggregate.timestamp:type_name -> google.protobuf.Timestamp
+	22, // 11: containarium.v1.HistoricalConnection.started_at:type_name -> google.protobuf.Timestamp
+	22, // 12: containarium.v1.HistoricalConnection.ended_at:type_name -> google.protobuf.Timestamp
+	22, // 13: containarium.v1.TrafficAggregate.timestamp:type_name -> google.protobuf.Timestamp
 	0,  // 14: containarium.v1.GetConnectionsRequest.protocol:type_name -> containarium.v1.Protocol
 	4,  // 15: containarium.v1.GetConnectionsResponse.connections:type_name -> containarium.v1.Connection
 	6,  // 16: containarium.v1.GetConnectionSummaryResponse.summary:type_name -> containarium.v1.ConnectionSummary
 	3,  // 17: containarium.v1.SubscribeTrafficRequest.event_types:type_name -> containarium.v1.TrafficEventType
-	19, // 18: containarium.v1.QueryTrafficHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
-	19, // 19: containarium.v1.QueryTrafficHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	22, // 18: containarium.v1.QueryTrafficHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	22, // 19: containarium.v1.QueryTrafficHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
 	8,  // 20: containarium.v1.QueryTrafficHistoryResponse.connections:type_name -> containarium.v1.HistoricalConnection
-	19, // 21: containarium.v1.GetTrafficAggregatesRequest.start_time:type_name -> google.protobuf.Timestamp
-	19, // 22: containarium.v1.GetTrafficAggregatesRequest.end_time:type_name -> google.protobuf.Timestamp
+	22, // 21: containarium.v1.GetTrafficAggregatesRequest.start_time:type_name -> google.protobuf.Timestamp
+	22, // 22: containarium.v1.GetTrafficAggregatesRequest.end_time:type_name -> google.protobuf.Timestamp
 	9,  // 23: containarium.v1.GetTrafficAggregatesResponse.aggregates:type_name -> containarium.v1.TrafficAggregate
-	10, // 24: containarium.v1.TrafficService.GetConnections:input_type -> containarium.v1.GetConnectionsRequest
-	12, // 25: containarium.v1.TrafficService.GetConnectionSummary:input_type -> containarium.v1.GetConnectionSummaryRequest
-	14, // 26: containarium.v1.TrafficService.SubscribeTraffic:input_type -> containarium.v1.SubscribeTrafficRequest
-	15, // 27: containarium.v1.TrafficService.QueryTrafficHistory:input_type -> containarium.v1.QueryTrafficHistoryRequest
-	17, // 28: containarium.v1.TrafficService.GetTrafficAggregates:input_type -> containarium.v1.GetTrafficAggregatesRequest
-	11, // 29: containarium.v1.TrafficService.GetConnections:output_type -> containarium.v1.GetConnectionsResponse
-	13, // 30: containarium.v1.TrafficService.GetConnectionSummary:output_type -> containarium.v1.GetConnectionSummaryResponse
-	5,  // 31: containarium.v1.TrafficService.SubscribeTraffic:output_type -> containarium.v1.TrafficEvent
-	16, // 32: containarium.v1.TrafficService.QueryTrafficHistory:output_type -> containarium.v1.QueryTrafficHistoryResponse
-	18, // 33: containarium.v1.TrafficService.GetTrafficAggregates:output_type -> containarium.v1.GetTrafficAggregatesResponse
-	29, // [29:34] is the sub-list for method output_type
-	24, // [24:29] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	19, // 24: containarium.v1.GetHTTPMetricsResponse.containers:type_name -> containarium.v1.ContainerHTTPMetrics
+	22, // 25: containarium.v1.GetHTTPMetricsResponse.window_start:type_name -> google.protobuf.Timestamp
+	22, // 26: containarium.v1.GetHTTPMetricsResponse.window_end:type_name -> google.protobuf.Timestamp
+	10, // 27: containarium.v1.TrafficService.GetConnections:input_type -> containarium.v1.GetConnectionsRequest
+	12, // 28: containarium.v1.TrafficService.GetConnectionSummary:input_type -> containarium.v1.GetConnectionSummaryRequest
+	14, // 29: containarium.v1.TrafficService.SubscribeTraffic:input_type -> containarium.v1.SubscribeTrafficRequest
+	15, // 30: containarium.v1.TrafficService.QueryTrafficHistory:input_type -> containarium.v1.QueryTrafficHistoryRequest
+	17, // 31: containarium.v1.TrafficService.GetTrafficAggregates:input_type -> containarium.v1.GetTrafficAggregatesRequest
+	20, // 32: containarium.v1.TrafficService.GetHTTPMetrics:input_type -> containarium.v1.GetHTTPMetricsRequest
+	11, // 33: containarium.v1.TrafficService.GetConnections:output_type -> containarium.v1.GetConnectionsResponse
+	13, // 34: containarium.v1.TrafficService.GetConnectionSummary:output_type -> containarium.v1.GetConnectionSummaryResponse
+	5,  // 35: containarium.v1.TrafficService.SubscribeTraffic:output_type -> containarium.v1.TrafficEvent
+	16, // 36: containarium.v1.TrafficService.QueryTrafficHistory:output_type -> containarium.v1.QueryTrafficHistoryResponse
+	18, // 37: containarium.v1.TrafficService.GetTrafficAggregates:output_type -> containarium.v1.GetTrafficAggregatesResponse
+	21, // 38: containarium.v1.TrafficService.GetHTTPMetrics:output_type -> containarium.v1.GetHTTPMetricsResponse
+	33, // [33:39] is the sub-list for method output_type
+	27, // [27:33] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_containarium_v1_traffic_proto_init() }
@@ -1760,7 +2038,7 @@ func file_containarium_v1_traffic_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_containarium_v1_traffic_proto_rawDesc), len(file_containarium_v1_traffic_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   15,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,7 @@ const (
 	TrafficService_SubscribeTraffic_FullMethodName     = "/containarium.v1.TrafficService/SubscribeTraffic"
 	TrafficService_QueryTrafficHistory_FullMethodName  = "/containarium.v1.TrafficService/QueryTrafficHistory"
 	TrafficService_GetTrafficAggregates_FullMethodName = "/containarium.v1.TrafficService/GetTrafficAggregates"
+	TrafficService_GetHTTPMetrics_FullMethodName       = "/containarium.v1.TrafficService/GetHTTPMetrics"
 )
 
 // TrafficServiceClient is the client API for TrafficService service.
@@ -42,6 +43,9 @@ type TrafficServiceClient interface {
 	QueryTrafficHistory(ctx context.Context, in *QueryTrafficHistoryRequest, opts ...grpc.CallOption) (*QueryTrafficHistoryResponse, error)
 	// GetTrafficAggregates returns time-series traffic aggregates
 	GetTrafficAggregates(ctx context.Context, in *GetTrafficAggregatesRequest, opts ...grpc.CallOption) (*GetTrafficAggregatesResponse, error)
+	// GetHTTPMetrics returns per-container HTTP request rate, error ratio and
+	// latency quantiles from the edge access log
+	GetHTTPMetrics(ctx context.Context, in *GetHTTPMetricsRequest, opts ...grpc.CallOption) (*GetHTTPMetricsResponse, error)
 }
 
 type trafficServiceClient struct {
@@ -111,6 +115,16 @@ func (c *trafficServiceClient) GetTrafficAggregates(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *trafficServiceClient) GetHTTPMetrics(ctx context.Context, in *GetHTTPMetricsRequest, opts ...grpc.CallOption) (*GetHTTPMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHTTPMetricsResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetHTTPMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrafficServiceServer is the server API for TrafficService service.
 // All implementations must embed UnimplementedTrafficServiceServer
 // for forward compatibility.
@@ -127,6 +141,9 @@ type TrafficServiceServer interface {
 	QueryTrafficHistory(context.Context, *QueryTrafficHistoryRequest) (*QueryTrafficHistoryResponse, error)
 	// GetTrafficAggregates returns time-series traffic aggregates
 	GetTrafficAggregates(context.Context, *GetTrafficAggregatesRequest) (*GetTrafficAggregatesResponse, error)
+	// GetHTTPMetrics returns per-container HTTP request rate, error ratio and
+	// latency quantiles from the edge access log
+	GetHTTPMetrics(context.Context, *GetHTTPMetricsRequest) (*GetHTTPMetricsResponse, error)
 	mustEmbedUnimplementedTrafficServiceServer()
 }
 
@@ -152,6 +169,9 @@ func (UnimplementedTrafficServiceServer) QueryTrafficHistory(context.Context, *Q
 func (UnimplementedTrafficServiceServer) GetTrafficAggregates(context.Context, *GetTrafficAggregatesRequest) (*GetTrafficAggregatesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTrafficAggregates not implemented")
 }
+func (UnimplementedTrafficServiceServer) GetHTTPMetrics(context.Context, *GetHTTPMetricsRequest) (*GetHTTPMetricsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHTTPMetrics not implemented")
+}
 func (UnimplementedTrafficServiceServer) mustEmbedUnimplementedTrafficServiceServer() {}
 func (UnimplementedTrafficServiceServer) testEmbeddedByValue()                        {}
 
@@ -256,6 +276,24 @@ func _TrafficService_GetTrafficAggregates_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrafficService_GetHTTPMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHTTPMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetHTTPMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetHTTPMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetHTTPMetrics(ctx, req.(*GetHTTPMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrafficService_ServiceDesc is the grpc.ServiceDesc for TrafficService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -279,6 +317,10 @@ var TrafficService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTrafficAggregates",
 			Handler:    _TrafficService_GetTrafficAggregates_Handler,
 		},
+		{
+			MethodName: "GetHTTPMetrics",
+			Handler:    _TrafficService_GetHTTPMetrics_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -183,6 +183,9 @@ export interface NetworkNode {
   ipAddress?: string;
   state: string;
   aclName?: string;
+  tenant?: string;
+  networkPolicy?: string;
+  networkPolicyMode?: string;
 }
 
 /**
@@ -8,6 +8,9 @@ export type EventType =
   | 'EVENT_TYPE_CONTAINER_STARTED'
   | 'EVENT_TYPE_CONTAINER_STOPPED'
   | 'EVENT_TYPE_CONTAINER_STATE_CHANGED'
+  | 'EVENT_TYPE_CONTAINER_SNAPSHOT_CREATED'
+  | 'EVENT_TYPE_CONTAINER_SNAPSHOT_PRUNED'
+  | 'EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED'
   | 'EVENT_TYPE_APP_DEPLOYED'
   | 'EVENT_TYPE_APP_DELETED'
   | 'EVENT_TYPE_APP_STARTED'
@@ -16,7 +19,11 @@ export type EventType =
   | 'EVENT_TYPE_ROUTE_ADDED'
   | 'EVENT_TYPE_ROUTE_DELETED'
   | 'EVENT_TYPE_METRICS_UPDATE'
-  | 'EVENT_TYPE_TRAFFIC_UPDATE';
+  | 'EVENT_TYPE_TRAFFIC_UPDATE'
+  | 'EVENT_TYPE_BACKUP_PROGRESS'
+  | 'EVENT_TYPE_BACKUP_SCHEDULE_SUCCEEDED'
+  | 'EVENT_TYPE_BACKUP_SCHEDULE_FAILED'
+  | 'EVENT_TYPE_WAF_MATCH';
 
 /**
  * Resource types from the backend
@@ -27,7 +34,9 @@ export type ResourceType =
   | 'RESOURCE_TYPE_APP'
   | 'RESOURCE_TYPE_ROUTE'
   | 'RESOURCE_TYPE_METRICS'
-  | 'RESOURCE_TYPE_TRAFFIC';
+  | 'RESOURCE_TYPE_TRAFFIC'
+  | 'RESOURCE_TYPE_BACKUP'
+  | 'RESOURCE_TYPE_WAF';
 
 /**
  * Container event payload
@@ -48,6 +57,16 @@ export interface ContainerEventPayload {
   previousState?: string;
 }
 
+/**
+ * Scheduled container snapshot event payload
+ */
+export interface ContainerSnapshotEventPayload {
+  username: string;
+  containerName: string;
+  snapshot: string;
+  error?: string; // set on EVENT_TYPE_CONTAINER_SNAPSHOT_FAILED
+}
+
 /**
  * App event payload
  */
@@ -121,6 +140,48 @@ export interface TrafficEventPayload {
   };
 }
 
+/**
+ * Backup progress event payload
+ */
+export interface BackupProgressEventPayload {
+  backupId: string;
+  username: string;
+  operation: 'create' | 'restore' | 'verify';
+  phase: 'dump' | 'upload' | 'download' | 'restore';
+  bytesDone: number;
+  bytesTotal: number; // 0 when not known up front
+  phaseDone: boolean;
+}
+
+/**
+ * Scheduled backup run event payload
+ */
+export interface BackupScheduleRunEventPayload {
+  username: string;
+  run: {
+    startedAt: string;
+    finishedAt: string;
+    backupIds?: string[];
+    prunedIds?: string[];
+    failures?: string[];
+    verificationFailures?: string[];
+    ok: boolean;
+  };
+}
+
+/**
+ * WAF rule match event payload
+ */
+export interface WAFMatchEventPayload {
+  ruleId: number;
+  ruleName: string;
+  action: 'block' | 'log';
+  target?: string; // empty for a built-in signature
+  tenant?: string;
+  destination: string;
+  dropped: boolean;
+}
+
 /**
  * Server-sent event from the backend
  */
@@ -131,10 +192,14 @@ export interface ServerEvent {
   resourceId: string;
   timestamp: string;
   containerEvent?: ContainerEventPayload;
+  containerSnapshotEvent?: ContainerSnapshotEventPayload;
   appEvent?: AppEventPayload;
   routeEvent?: RouteEventPayload;
   metricsEvent?: MetricsEventPayload;
   trafficEvent?: TrafficEventPayload;
+  backupProgressEvent?: BackupProgressEventPayload;
+  backupScheduleRunEvent?: BackupScheduleRunEventPayload;
+  wafMatchEvent?: WAFMatchEventPayload;
 }
 
 /**